@@ -33,3 +33,19 @@ type BasicRes interface {
 	ReplaceLogger(logger log.Logger) BasicRes
 	GetDal() dal.Dal
 }
+
+// ReplicaBasicRes is implemented by the BasicRes of the server when DB_REPLICA_URL is set, the analytical reads of the
+// query endpoints go to the replica so that they don't slow the collections down
+type ReplicaBasicRes interface {
+	GetReplicaDal() dal.Dal
+}
+
+// GetReplicaDal returns the Dal of the read replica, or the Dal of the BasicRes when there is no replica
+func GetReplicaDal(basicRes BasicRes) dal.Dal {
+	if res, ok := basicRes.(ReplicaBasicRes); ok {
+		if replicaDb := res.GetReplicaDal(); replicaDb != nil {
+			return replicaDb
+		}
+	}
+	return basicRes.GetDal()
+}
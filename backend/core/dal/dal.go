@@ -187,6 +187,14 @@ type Transaction interface {
 	Commit() errors.Error
 }
 
+// TableSchemaRedirector is implemented by the Dal able to keep some tables in another schema, i.e. the domain tables
+// of a project isolated from the others
+type TableSchemaRedirector interface {
+	// WithTableSchema returns a Dal reading and writing the tables in the schema, the tables referred by the models,
+	// From and the joins are redirected, the ones within raw sql are not
+	WithTableSchema(schema string, tables []string) Dal
+}
+
 type Rows interface {
 	// Next prepares the next result row for reading with the Scan method. It
 	// returns true on success, or false if there is no next result row or an error
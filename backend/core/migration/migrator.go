@@ -18,15 +18,21 @@ limitations under the License.
 package migration
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	core "github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"sort"
 	"sync"
+	"time"
 )
 
+// progressLogInterval throttles the logging of the progress reported by the resumable scripts
+const progressLogInterval = 30 * time.Second
+
 type scriptWithComment struct {
 	script  plugin.MigrationScript
 	comment string
@@ -39,6 +45,7 @@ type migratorImpl struct {
 	executed map[string]bool
 	scripts  []*scriptWithComment
 	pending  []*scriptWithComment
+	online   []*scriptWithComment
 }
 
 func (m *migratorImpl) loadExecuted() errors.Error {
@@ -73,43 +80,159 @@ func (m *migratorImpl) Register(scripts []plugin.MigrationScript, comment string
 			comment: comment,
 		}
 		m.scripts = append(m.scripts, swc)
-		if !m.executed[scriptId] {
-			m.pending = append(m.pending, swc)
-		} else {
+		if m.executed[scriptId] {
 			m.logger.Debug("skipping previously executed migration script: %s", scriptId)
+		} else if isOnline(script) {
+			m.online = append(m.online, swc)
+		} else {
+			m.pending = append(m.pending, swc)
 		}
 	}
 }
 
-// Execute all registered migration script in order and mark them as executed in migration_history table
+func isOnline(script plugin.MigrationScript) bool {
+	online, ok := script.(plugin.OnlineMigrationScript)
+	return ok && online.Online()
+}
+
+// Execute all registered migration script in order and mark them as executed in migration_history table,
+// except the OnlineMigrationScript which are left to ExecuteOnline
 func (m *migratorImpl) Execute() errors.Error {
 	// sort the scripts by version
 	sort.Slice(m.pending, func(i, j int) bool {
 		return m.pending[i].script.Version() < m.pending[j].script.Version()
 	})
 	// execute them one by one
-	db := m.basicRes.GetDal()
-	for _, swc := range m.pending {
-		scriptId := getScriptId(swc.script.Name(), swc.script.Version())
-		m.logger.Info("applying migration script %s", scriptId)
-		err := swc.script.Up(m.basicRes)
+	for len(m.pending) > 0 {
+		err := m.apply(m.pending[0])
 		if err != nil {
 			return err
 		}
-		err = db.Create(&MigrationHistory{
-			ScriptVersion: swc.script.Version(),
-			ScriptName:    swc.script.Name(),
-			Comment:       swc.comment,
-		})
+		m.pending = m.pending[1:]
+	}
+	return nil
+}
+
+// ExecuteOnline applies the pending OnlineMigrationScript in order, it is meant to run in the background once
+// Execute is done, a failed script stops the following ones and is resumed on the next startup. The online scripts
+// are ordered by version among themselves only: they run after all the offline scripts, the ones with a later version
+// included, so an online script must work on the latest schema of the tables it migrates. It fails while offline
+// scripts are pending, an online script never runs ahead of an offline script with an earlier version
+func (m *migratorImpl) ExecuteOnline() errors.Error {
+	m.Lock()
+	if len(m.pending) > 0 {
+		m.Unlock()
+		return errors.Default.New("the offline migration scripts must be applied before the online ones")
+	}
+	scripts := m.online
+	m.online = nil
+	m.Unlock()
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].script.Version() < scripts[j].script.Version()
+	})
+	for _, swc := range scripts {
+		err := m.apply(swc)
 		if err != nil {
-			return errors.Default.Wrap(err, fmt.Sprintf("failed to execute migration script %s", scriptId))
+			return err
 		}
-		m.executed[scriptId] = true
-		m.pending = m.pending[1:]
 	}
 	return nil
 }
 
+func (m *migratorImpl) apply(swc *scriptWithComment) errors.Error {
+	db := m.basicRes.GetDal()
+	scriptId := getScriptId(swc.script.Name(), swc.script.Version())
+	m.logger.Info("applying migration script %s", scriptId)
+	var err errors.Error
+	if resumable, ok := swc.script.(plugin.ResumableMigrationScript); ok {
+		err = m.applyResumable(resumable)
+	} else {
+		err = swc.script.Up(m.basicRes)
+	}
+	if err != nil {
+		return err
+	}
+	history := &MigrationHistory{
+		ScriptVersion: swc.script.Version(),
+		ScriptName:    swc.script.Name(),
+		Comment:       swc.comment,
+	}
+	if revertible, ok := swc.script.(plugin.RevertibleMigrationScript); ok {
+		rollback, e := json.Marshal(revertible.Rollback(db.Dialect()))
+		if e != nil {
+			return errors.Default.Wrap(e, fmt.Sprintf("failed to record the rollback of migration script %s", scriptId))
+		}
+		history.RollbackScript = string(rollback)
+	}
+	err = db.Create(history)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to execute migration script %s", scriptId))
+	}
+	m.Lock()
+	m.executed[scriptId] = true
+	m.Unlock()
+	return nil
+}
+
+// applyResumable runs the script from the checkpoint of its previous run and keeps its MigrationState up to date
+func (m *migratorImpl) applyResumable(script plugin.ResumableMigrationScript) errors.Error {
+	db := m.basicRes.GetDal()
+	scriptId := getScriptId(script.Name(), script.Version())
+	err := db.AutoMigrate(&MigrationState{})
+	if err != nil {
+		return errors.Default.Wrap(err, "error migrating the migration states")
+	}
+	state := &MigrationState{}
+	err = db.First(state, dal.Where("script_version = ? AND script_name = ?", script.Version(), script.Name()))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to load the state of migration script %s", scriptId))
+	}
+	now := time.Now()
+	if state.Checkpoint != "" {
+		m.logger.Info("resuming migration script %s from %s", scriptId, state.Checkpoint)
+	} else {
+		state.StartedAt = &now
+	}
+	state.ScriptVersion = script.Version()
+	state.ScriptName = script.Name()
+	state.Online = isOnline(script)
+	state.Status = MIGRATION_RUNNING
+	state.Message = ""
+	state.UpdatedAt = &now
+	state.FinishedAt = nil
+	err = db.CreateOrUpdate(state)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to save the state of migration script %s", scriptId))
+	}
+	loggedAt := now
+	progress := func(done, total int64, checkpoint string) errors.Error {
+		now := time.Now()
+		state.Done = done
+		state.Total = total
+		state.Checkpoint = checkpoint
+		state.UpdatedAt = &now
+		if now.Sub(loggedAt) >= progressLogInterval {
+			m.logger.Info("migration script %s progress: %d/%d", scriptId, done, total)
+			loggedAt = now
+		}
+		return db.Update(state)
+	}
+	err = script.UpFrom(m.basicRes, state.Checkpoint, progress)
+	now = time.Now()
+	state.UpdatedAt = &now
+	if err != nil {
+		state.Status = MIGRATION_FAILED
+		state.Message = err.Error()
+		if e := db.Update(state); e != nil {
+			m.logger.Warn(e, "failed to save the state of migration script %s", scriptId)
+		}
+		return err
+	}
+	state.Status = MIGRATION_DONE
+	state.FinishedAt = &now
+	return db.Update(state)
+}
+
 // HasPendingScripts returns if there is any pending migration scripts
 func (m *migratorImpl) HasPendingScripts() bool {
 	return len(m.executed) > 0 && len(m.pending) > 0
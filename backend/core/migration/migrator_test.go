@@ -85,3 +85,134 @@ func TestHasPendingScripts(t *testing.T) {
 	// make sure all method got called
 	mockDal.AssertExpectations(t)
 }
+
+// migrationStateDal records the states of the resumable scripts saved by the migrator
+func migrationStateDal(saved *[]MigrationState) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(nil).Once()
+	record := func(args mock.Arguments) {
+		*saved = append(*saved, *args.Get(0).(*MigrationState))
+	}
+	mockDal.On("CreateOrUpdate", mock.AnythingOfType("*migration.MigrationState"), mock.Anything).Run(record).Return(nil)
+	mockDal.On("Update", mock.AnythingOfType("*migration.MigrationState"), mock.Anything).Run(record).Return(nil)
+	return mockDal
+}
+
+func TestResumableScriptResumesFromCheckpoint(t *testing.T) {
+	var saved []MigrationState
+	mockDal := migrationStateDal(&saved)
+	startedAt := time.Now().Add(-time.Hour)
+	mockDal.On("First", mock.AnythingOfType("*migration.MigrationState"), mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*MigrationState) = MigrationState{
+			ScriptVersion: 1,
+			ScriptName:    "A",
+			Status:        MIGRATION_FAILED,
+			Done:          500,
+			Total:         1000,
+			Checkpoint:    "500",
+			Message:       "lost connection",
+			StartedAt:     &startedAt,
+		}
+	}).Return(nil).Once()
+	mockDal.On("Create", mock.AnythingOfType("*migration.MigrationHistory"), mock.Anything).Return(nil).Once()
+
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+	script := new(mockplugin.ResumableMigrationScript)
+	script.On("Version").Return(uint64(1))
+	script.On("Name").Return("A")
+	// the script goes on from the checkpoint of the failed run
+	script.On("UpFrom", mock.Anything, "500", mock.Anything).Run(func(args mock.Arguments) {
+		progress := args.Get(2).(plugin.MigrationProgress)
+		assert.Nil(t, progress(1000, 1000, "1000"))
+	}).Return(nil).Once()
+	migrator.Register([]plugin.MigrationScript{script}, "UnitTest")
+	assert.Nil(t, migrator.Execute())
+
+	assert.Len(t, saved, 3)
+	assert.Equal(t, MIGRATION_RUNNING, saved[0].Status)
+	assert.Equal(t, "500", saved[0].Checkpoint)
+	assert.Equal(t, "", saved[0].Message)
+	assert.Equal(t, &startedAt, saved[0].StartedAt)
+	assert.Equal(t, "1000", saved[1].Checkpoint)
+	assert.Equal(t, MIGRATION_DONE, saved[2].Status)
+	assert.NotNil(t, saved[2].FinishedAt)
+	script.AssertNotCalled(t, "Up", mock.Anything)
+	mockDal.AssertExpectations(t)
+	script.AssertExpectations(t)
+}
+
+func TestResumableScriptFailureRecorded(t *testing.T) {
+	var saved []MigrationState
+	mockDal := migrationStateDal(&saved)
+	notFound := errors.NotFound.New("record not found")
+	mockDal.On("First", mock.AnythingOfType("*migration.MigrationState"), mock.Anything).Return(notFound).Once()
+	mockDal.On("IsErrorNotFound", notFound).Return(true).Once()
+
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+	failure := errors.Default.New("lost connection")
+	script := new(mockplugin.ResumableMigrationScript)
+	script.On("Version").Return(uint64(1))
+	script.On("Name").Return("A")
+	script.On("UpFrom", mock.Anything, "", mock.Anything).Run(func(args mock.Arguments) {
+		progress := args.Get(2).(plugin.MigrationProgress)
+		assert.Nil(t, progress(10, 100, "10"))
+	}).Return(failure).Once()
+	migrator.Register([]plugin.MigrationScript{script}, "UnitTest")
+	assert.Equal(t, failure, migrator.Execute())
+
+	// the checkpoint of the failed run is kept for the next one, and the script isn't recorded as applied
+	last := saved[len(saved)-1]
+	assert.Equal(t, MIGRATION_FAILED, last.Status)
+	assert.Equal(t, failure.Error(), last.Message)
+	assert.Equal(t, "10", last.Checkpoint)
+	assert.Nil(t, last.FinishedAt)
+	assert.NotNil(t, last.StartedAt)
+	mockDal.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockDal.AssertExpectations(t)
+}
+
+func TestOnlineScriptsAfterOfflineScripts(t *testing.T) {
+	var saved []MigrationState
+	mockDal := migrationStateDal(&saved)
+	notFound := errors.NotFound.New("record not found")
+	mockDal.On("First", mock.AnythingOfType("*migration.MigrationState"), mock.Anything).Return(notFound)
+	mockDal.On("IsErrorNotFound", notFound).Return(true)
+	mockDal.On("Create", mock.AnythingOfType("*migration.MigrationHistory"), mock.Anything).Return(nil)
+
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+	var applied []string
+	offline := func(name string, version uint64) *mockplugin.MigrationScript {
+		script := new(mockplugin.MigrationScript)
+		script.On("Version").Return(version)
+		script.On("Name").Return(name)
+		script.On("Up", mock.Anything).Run(func(mock.Arguments) { applied = append(applied, name) }).Return(nil).Once()
+		return script
+	}
+	online := new(mockplugin.OnlineMigrationScript)
+	online.On("Version").Return(uint64(5))
+	online.On("Name").Return("E")
+	online.On("Online").Return(true)
+	online.On("UpFrom", mock.Anything, "", mock.Anything).Run(func(mock.Arguments) { applied = append(applied, "E") }).Return(nil).Once()
+	migrator.Register([]plugin.MigrationScript{offline("F", 6), online, offline("D", 4)}, "UnitTest")
+
+	// the online script waits for the offline one with an earlier version
+	onlineMigrator := migrator.(plugin.OnlineMigrator)
+	assert.NotNil(t, onlineMigrator.ExecuteOnline())
+	assert.Empty(t, applied)
+
+	// then runs after all the offline scripts, the ones with a later version included
+	assert.Nil(t, migrator.Execute())
+	assert.Equal(t, []string{"D", "F"}, applied)
+	assert.Nil(t, onlineMigrator.ExecuteOnline())
+	assert.Equal(t, []string{"D", "F", "E"}, applied)
+	assert.Equal(t, MIGRATION_DONE, saved[len(saved)-1].Status)
+	online.AssertNotCalled(t, "Up", mock.Anything)
+	online.AssertExpectations(t)
+}
@@ -24,8 +24,36 @@ type MigrationHistory struct {
 	ScriptVersion uint64 `gorm:"primarykey"`
 	ScriptName    string `gorm:"primarykey;type:varchar(255)"`
 	Comment       string
+	// RollbackScript is the json array of the statements reverting a RevertibleMigrationScript
+	RollbackScript string `gorm:"type:text"`
 }
 
 func (MigrationHistory) TableName() string {
 	return "_devlake_migration_history"
 }
+
+const (
+	MIGRATION_RUNNING = "MIGRATION_RUNNING"
+	MIGRATION_FAILED  = "MIGRATION_FAILED"
+	MIGRATION_DONE    = "MIGRATION_DONE"
+)
+
+// MigrationState tracks the progress of a ResumableMigrationScript, the checkpoint of a running or failed one is
+// where it resumes on the next run
+type MigrationState struct {
+	ScriptVersion uint64     `gorm:"primarykey" json:"scriptVersion"`
+	ScriptName    string     `gorm:"primarykey;type:varchar(255)" json:"scriptName"`
+	Online        bool       `json:"online"`
+	Status        string     `gorm:"type:varchar(20)" json:"status"`
+	Done          int64      `json:"done"`
+	Total         int64      `json:"total"`
+	Checkpoint    string     `gorm:"type:text" json:"checkpoint"`
+	Message       string     `gorm:"type:text" json:"message"`
+	StartedAt     *time.Time `json:"startedAt"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+	FinishedAt    *time.Time `json:"finishedAt"`
+}
+
+func (MigrationState) TableName() string {
+	return "_devlake_migration_states"
+}
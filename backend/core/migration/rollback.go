@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// Rollback reverts an applied RevertibleMigrationScript by the statements recorded in its history, and forgets it so
+// the next migration applies it again. Only the latest script registered under the same comment can be reverted, since
+// the later ones may depend on it
+func Rollback(basicRes context.BasicRes, scriptName string, scriptVersion uint64) errors.Error {
+	db := basicRes.GetDal()
+	scriptId := getScriptId(scriptName, scriptVersion)
+	history := &MigrationHistory{}
+	err := db.First(history, dal.Where("script_version = ? AND script_name = ?", scriptVersion, scriptName))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.New(fmt.Sprintf("migration script %s was never applied", scriptId))
+		}
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to load the history of migration script %s", scriptId))
+	}
+	if history.RollbackScript == "" {
+		return errors.BadInput.New(fmt.Sprintf("migration script %s recorded no rollback", scriptId))
+	}
+	latest := &MigrationHistory{}
+	err = db.First(latest, dal.Where("comment = ?", history.Comment), dal.Orderby("script_version DESC"))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to load the latest migration script of %s", history.Comment))
+	}
+	if latest.ScriptVersion != history.ScriptVersion {
+		return errors.BadInput.New(fmt.Sprintf(
+			"migration script %s is followed by %s, roll it back first",
+			scriptId, getScriptId(latest.ScriptName, latest.ScriptVersion),
+		))
+	}
+	var statements []string
+	if e := json.Unmarshal([]byte(history.RollbackScript), &statements); e != nil {
+		return errors.Default.Wrap(e, fmt.Sprintf("failed to decode the rollback of migration script %s", scriptId))
+	}
+	basicRes.GetLogger().Info("rolling back migration script %s", scriptId)
+	for _, statement := range statements {
+		err = db.Exec(statement)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to roll back migration script %s", scriptId))
+		}
+	}
+	where := dal.Where("script_version = ? AND script_name = ?", scriptVersion, scriptName)
+	if db.HasTable(&MigrationState{}) {
+		err = db.Delete(&MigrationState{}, where)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to delete the state of migration script %s", scriptId))
+		}
+	}
+	return db.Delete(&MigrationHistory{}, where)
+}
+
+// GetMigrationStates returns the progress of the resumable migration scripts, the running ones first
+func GetMigrationStates(basicRes context.BasicRes) ([]MigrationState, errors.Error) {
+	db := basicRes.GetDal()
+	states := make([]MigrationState, 0)
+	if !db.HasTable(&MigrationState{}) {
+		return states, nil
+	}
+	err := db.All(&states, dal.Orderby("finished_at IS NOT NULL, script_version DESC"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load the migration states")
+	}
+	return states, nil
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/apache/incubator-devlake/impls/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// historyDal keeps the migration histories in memory, the lookups of Rollback are answered from them
+func historyDal(histories *[]MigrationHistory, executed *[]string) *mockdal.Dal {
+	notFound := errors.NotFound.New("record not found")
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Dialect").Return("mysql")
+	mockDal.On("IsErrorNotFound", notFound).Return(true)
+	mockDal.On("HasTable", mock.Anything).Return(false)
+	mockDal.On("Create", mock.AnythingOfType("*migration.MigrationHistory"), mock.Anything).Run(func(args mock.Arguments) {
+		*histories = append(*histories, *args.Get(0).(*MigrationHistory))
+	}).Return(nil)
+	mockDal.On("Exec", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*executed = append(*executed, args.String(0))
+	}).Return(nil)
+	mockDal.On("First", mock.AnythingOfType("*migration.MigrationHistory"), mock.Anything).Return(func(dst interface{}, clauses ...dal.Clause) errors.Error {
+		where := clauses[0].Data.(dal.DalClause)
+		var found *MigrationHistory
+		for i := range *histories {
+			history := &(*histories)[i]
+			switch where.Expr {
+			case "script_version = ? AND script_name = ?":
+				if history.ScriptVersion == where.Params[0] && history.ScriptName == where.Params[1] {
+					found = history
+				}
+			case "comment = ?":
+				if history.Comment == where.Params[0] && (found == nil || history.ScriptVersion > found.ScriptVersion) {
+					found = history
+				}
+			}
+		}
+		if found == nil {
+			return notFound
+		}
+		*dst.(*MigrationHistory) = *found
+		return nil
+	})
+	mockDal.On("Delete", mock.AnythingOfType("*migration.MigrationHistory"), mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(1).([]dal.Clause)[0].Data.(dal.DalClause)
+		for i, history := range *histories {
+			if history.ScriptVersion == where.Params[0] && history.ScriptName == where.Params[1] {
+				*histories = append((*histories)[:i], (*histories)[i+1:]...)
+				return
+			}
+		}
+	}).Return(nil)
+	return mockDal
+}
+
+func revertibleScript(name string, version uint64, rollback ...string) *mockplugin.RevertibleMigrationScript {
+	script := new(mockplugin.RevertibleMigrationScript)
+	script.On("Version").Return(version)
+	script.On("Name").Return(name)
+	script.On("Up", mock.Anything).Return(nil).Once()
+	script.On("Rollback", "mysql").Return(rollback)
+	return script
+}
+
+func TestRollbackInReverseOrder(t *testing.T) {
+	var histories []MigrationHistory
+	var executed []string
+	mockDal := historyDal(&histories, &executed)
+	basicRes := context.NewDefaultBasicRes(viper.New(), unithelper.DummyLogger(), mockDal)
+	migrator, err := NewMigrator(basicRes)
+	assert.Nil(t, err)
+	migrator.Register([]plugin.MigrationScript{
+		revertibleScript("B", 2, "ALTER TABLE issues DROP COLUMN b"),
+		revertibleScript("A", 1, "ALTER TABLE issues DROP COLUMN a1", "ALTER TABLE issues DROP COLUMN a2"),
+	}, "UnitTest")
+	assert.Nil(t, migrator.Execute())
+	assert.Len(t, histories, 2)
+	assert.Equal(t, `["ALTER TABLE issues DROP COLUMN a1","ALTER TABLE issues DROP COLUMN a2"]`, histories[0].RollbackScript)
+
+	// the scripts applied later must be rolled back first
+	err = Rollback(basicRes, "A", 1)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	assert.Contains(t, err.Error(), "followed by B:2")
+	assert.Empty(t, executed)
+
+	assert.Nil(t, Rollback(basicRes, "B", 2))
+	assert.Nil(t, Rollback(basicRes, "A", 1))
+	assert.Equal(t, []string{
+		"ALTER TABLE issues DROP COLUMN b",
+		"ALTER TABLE issues DROP COLUMN a1",
+		"ALTER TABLE issues DROP COLUMN a2",
+	}, executed)
+	assert.Empty(t, histories)
+
+	err = Rollback(basicRes, "A", 1)
+	assert.Equal(t, errors.NotFound, err.GetType())
+}
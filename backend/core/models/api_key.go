@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// the scopes granted to an api key
+const (
+	API_KEY_SCOPE_ADMIN             = "admin"             // everything, including the management of the api keys and the audit logs
+	API_KEY_SCOPE_WRITE             = "write"             // everything but the management of the api keys and the audit logs
+	API_KEY_SCOPE_READ              = "read"              // the GET requests and the graphql queries
+	API_KEY_SCOPE_READONLY          = "readonly"          // the domain data, the dashboards and the pipeline status only, alone
+	API_KEY_SCOPE_METRICS_READ      = "metrics:read"      // the metrics endpoint only
+	API_KEY_SCOPE_PIPELINES_TRIGGER = "pipelines:trigger" // creating, triggering, rerunning and following pipelines
+	API_KEY_SCOPE_WEBHOOK_PUSH      = "webhook:push"      // pushing deployments, issues and coverages to the webhook plugin
+)
+
+// ApiKey grants the access to the REST API to the requests bearing the key in the X-Api-Key header. Only the hash
+// of the key is stored, the key itself is returned once when it is created.
+type ApiKey struct {
+	common.Model
+	Name       string     `json:"name" gorm:"type:varchar(255)"`
+	Prefix     string     `json:"prefix" gorm:"type:varchar(20)"`
+	KeyHash    string     `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	Scopes     []string   `json:"scopes" gorm:"type:text;serializer:json"`
+	ExpiredAt  *time.Time `json:"expiredAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	// UserId is the user the requests bearing the key act on behalf of, the role bindings of the user apply to them
+	UserId *uint64 `json:"userId" gorm:"index"`
+	// Workspace confines the requests bearing the key to a workspace when it is set
+	Workspace string `json:"workspace" gorm:"type:varchar(255);index"`
+}
+
+func (ApiKey) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type ApiInputApiKey struct {
+	Name      string     `json:"name" validate:"required"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=admin write read readonly metrics:read pipelines:trigger webhook:push"`
+	ExpiredAt *time.Time `json:"expiredAt"`
+	UserId    *uint64    `json:"userId"`
+	Workspace string     `json:"workspace"`
+}
+
+// ApiOutputApiKey holds the key along with its settings, it is returned only when the key is created
+type ApiOutputApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	ARCHIVE_RUNNING        = "RUNNING"
+	ARCHIVE_DONE           = "DONE"
+	ARCHIVE_FAILED         = "FAILED"
+	ARCHIVE_RESTORING      = "RESTORING"
+	ARCHIVE_RESTORED       = "RESTORED"
+	ARCHIVE_RESTORE_FAILED = "RESTORE_FAILED"
+)
+
+const (
+	ARCHIVE_TRIGGER_CRON   = "cron"
+	ARCHIVE_TRIGGER_MANUAL = "manual"
+)
+
+// Archive is the export of the rows of a table older than the Cutoff to parquet files in the object store, the rows
+// are deleted from the table once exported and may be restored from the files listed by the manifest
+type Archive struct {
+	common.Model
+	ArchivedTable string     `gorm:"type:varchar(255);index" json:"archivedTable"`
+	Status        string     `gorm:"type:varchar(20)" json:"status"`
+	Trigger       string     `gorm:"type:varchar(20)" json:"trigger"`
+	CutoffColumn  string     `gorm:"type:varchar(100)" json:"cutoffColumn"`
+	Cutoff        time.Time  `json:"cutoff"`
+	ManifestKey   string     `gorm:"type:varchar(500)" json:"manifestKey"`
+	Files         int        `json:"files"`
+	Rows          int64      `json:"rows"`
+	DeletedRows   int64      `json:"deletedRows"`
+	BeganAt       *time.Time `json:"beganAt"`
+	FinishedAt    *time.Time `json:"finishedAt"`
+	RestoredRows  int64      `json:"restoredRows"`
+	RestoredAt    *time.Time `json:"restoredAt"`
+	Message       string     `json:"message"`
+}
+
+func (Archive) TableName() string {
+	return "_devlake_archives"
+}
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AuditLog records a mutating call to the REST API: who made it, on which resource, and the state of the resource
+// before and after the call. The credentials are masked out of the states.
+type AuditLog struct {
+	ID           uint64         `json:"id" gorm:"primaryKey"`
+	CreatedAt    time.Time      `json:"createdAt" gorm:"index"`
+	Actor        string         `json:"actor" gorm:"type:varchar(255);index"`
+	UserId       *uint64        `json:"userId"`
+	ApiKeyId     *uint64        `json:"apiKeyId"`
+	ClientIp     string         `json:"clientIp" gorm:"type:varchar(100)"`
+	Method       string         `json:"method" gorm:"type:varchar(10)"`
+	Path         string         `json:"path" gorm:"type:varchar(500)"`
+	ResourceType string         `json:"resourceType" gorm:"type:varchar(255);index"`
+	ResourceId   string         `json:"resourceId" gorm:"type:varchar(255)"`
+	Status       int            `json:"status"`
+	Before       datatypes.JSON `json:"before"`
+	After        datatypes.JSON `json:"after"`
+	// Diff maps the paths of the changed fields, i.e. `settings.connections`, to their values before and after
+	Diff datatypes.JSON `json:"diff"`
+}
+
+func (AuditLog) TableName() string {
+	return "_devlake_audit_logs"
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	CDC_UPSERT = "upsert"
+	CDC_DELETE = "delete"
+)
+
+// CdcEvent is a row-level change of a captured domain table waiting to be published, the changes are recorded along
+// with the statements changing the rows so they are kept in order and survive a restart
+type CdcEvent struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	SourceTable string    `gorm:"type:varchar(255)" json:"sourceTable"`
+	Operation   string    `gorm:"type:varchar(10)" json:"operation"`
+	Key         string    `json:"key"`
+	Row         string    `json:"row"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (CdcEvent) TableName() string {
+	return "_devlake_cdc_events"
+}
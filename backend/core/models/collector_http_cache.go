@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+// CollectorHttpCache is the validators of the last response to a request of a collector, the next collections send
+// them along so that the unchanged resources are answered by 304 Not Modified, which the GitHub api doesn't count
+// against the rate limit. The requests are identified by the hash of their raw table, raw data params, method and url
+type CollectorHttpCache struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	UrlHash       string    `gorm:"primaryKey;type:varchar(64)" json:"urlHash"`
+	RawDataTable  string    `gorm:"column:raw_data_table;type:varchar(255);index:idx_collector_http_cache_scope" json:"rawDataTable"`
+	RawDataParams string    `gorm:"column:raw_data_params;type:varchar(255);index:idx_collector_http_cache_scope" json:"rawDataParams"`
+	Url           string    `gorm:"type:text" json:"url"`
+	ETag          string    `gorm:"column:etag;type:varchar(255)" json:"etag"`
+	LastModified  string    `gorm:"type:varchar(100)" json:"lastModified"`
+	// Items is the number of records the response was parsed into, the pagination goes on with it on 304
+	Items int `json:"items"`
+}
+
+func (CollectorHttpCache) TableName() string {
+	return "_devlake_collector_http_cache"
+}
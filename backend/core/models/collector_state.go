@@ -21,13 +21,18 @@ import (
 	"time"
 )
 
+// CollectorLatestState is the incremental collection state of a stream (the raw table) of a scope (the raw data params),
+// the Go and the remote plugins share it
 type CollectorLatestState struct {
-	CreatedAt          time.Time `json:"createdAt"`
-	UpdatedAt          time.Time `json:"updatedAt"`
-	RawDataParams      string    `gorm:"primaryKey;column:raw_data_params;type:varchar(255);index" json:"raw_data_params"`
-	RawDataTable       string    `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"raw_data_table"`
-	TimeAfter          *time.Time
-	LatestSuccessStart *time.Time
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+	RawDataParams      string     `gorm:"primaryKey;column:raw_data_params;type:varchar(255);index" json:"raw_data_params"`
+	RawDataTable       string     `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"raw_data_table"`
+	Plugin             string     `gorm:"type:varchar(100);index" json:"plugin"`
+	TimeAfter          *time.Time `json:"timeAfter"`
+	LatestSuccessStart *time.Time `json:"latestSuccessStart"`
+	// Cursor is the JSON encoded position the next collection resumes from, e.g. the last updated date or page token
+	Cursor string `gorm:"type:text" json:"cursor"`
 }
 
 func (CollectorLatestState) TableName() string {
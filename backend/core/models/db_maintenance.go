@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	DB_MAINTENANCE_RUNNING = "RUNNING"
+	DB_MAINTENANCE_DONE    = "DONE"
+	DB_MAINTENANCE_FAILED  = "FAILED"
+)
+
+const (
+	DB_MAINTENANCE_TRIGGER_CRON   = "cron"
+	DB_MAINTENANCE_TRIGGER_MANUAL = "manual"
+)
+
+// DbMaintenance is a run of the job refreshing the statistics of the hot raw and domain tables and reclaiming their
+// free space, its counters are updated as the tables get maintained
+type DbMaintenance struct {
+	common.Model
+	Status           string     `gorm:"type:varchar(20)" json:"status"`
+	Trigger          string     `gorm:"type:varchar(20)" json:"trigger"`
+	BeganAt          *time.Time `json:"beganAt"`
+	FinishedAt       *time.Time `json:"finishedAt"`
+	TotalTables      int        `json:"totalTables"`
+	MaintainedTables int        `json:"maintainedTables"`
+	CurrentTable     string     `gorm:"type:varchar(255)" json:"currentTable"`
+	Message          string     `json:"message"`
+}
+
+func (DbMaintenance) TableName() string {
+	return "_devlake_db_maintenances"
+}
+
+// DbMaintenanceTable is a table maintained by a DbMaintenance, along with its bloat statistics before the maintenance
+type DbMaintenanceTable struct {
+	MaintenanceId uint64 `gorm:"primaryKey" json:"maintenanceId"`
+	Table         string `gorm:"primaryKey;column:table_name;type:varchar(255)" json:"table"`
+	TableBloatStat
+	// Operations are the statements run on the table, e.g. ANALYZE,OPTIMIZE
+	Operations string `gorm:"type:varchar(100)" json:"operations"`
+	DurationMs int64  `json:"durationMs"`
+	Message    string `json:"message"`
+}
+
+func (DbMaintenanceTable) TableName() string {
+	return "_devlake_db_maintenance_tables"
+}
+
+// TableBloatStat is the size of a table and how much of it is reclaimable, FreeBytes are reported by MySQL and
+// DeadRows by PostgreSQL
+type TableBloatStat struct {
+	TableRows  int64   `json:"tableRows"`
+	TotalBytes int64   `json:"totalBytes"`
+	FreeBytes  int64   `json:"freeBytes"`
+	DeadRows   int64   `json:"deadRows"`
+	BloatRatio float64 `json:"bloatRatio"`
+}
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	CODE_OWNER_USER  = "USER"
+	CODE_OWNER_TEAM  = "TEAM"
+	CODE_OWNER_EMAIL = "EMAIL"
+)
+
+// CodeOwner is an owner of the paths of a repo matching Pattern, as declared by a rule of its CODEOWNERS file.
+// The rules are kept in the order of the file, the last one matching a path takes precedence
+type CodeOwner struct {
+	common.NoPKModel
+	RepoId  string `gorm:"primaryKey;type:varchar(255)"`
+	LineNo  int    `gorm:"primaryKey"`
+	Owner   string `gorm:"primaryKey;type:varchar(255)"`
+	Pattern string `gorm:"type:varchar(500)"`
+	// OwnerType is USER, TEAM (a GitHub team or a GitLab group) or EMAIL
+	OwnerType string `gorm:"type:varchar(20)"`
+	// Section is the GitLab section of the rule, empty for GitHub
+	Section   string `gorm:"type:varchar(255)"`
+	FilePath  string `gorm:"type:varchar(255)"`
+	CommitSha string `gorm:"type:varchar(40)"`
+}
+
+func (CodeOwner) TableName() string {
+	return "code_owners"
+}
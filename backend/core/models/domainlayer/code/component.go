@@ -17,8 +17,14 @@ limitations under the License.
 
 package code
 
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// Component maps the paths of a repo matching PathRegex to a logical sub-project, e.g. a service of a monorepo.
+// gitextractor attributes every commit file to one of them in commit_file_components
 type Component struct {
-	RepoId    string `gorm:"type:varchar(255)"`
+	RepoId    string `gorm:"primaryKey;type:varchar(255)"`
 	Name      string `gorm:"primaryKey;type:varchar(255)"`
 	PathRegex string `gorm:"type:varchar(255)"`
 }
@@ -26,3 +32,26 @@ type Component struct {
 func (Component) TableName() string {
 	return "components"
 }
+
+// CommitComponent attributes a commit to the components whose paths it touched
+type CommitComponent struct {
+	common.NoPKModel
+	RepoId        string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha     string `gorm:"primaryKey;type:varchar(40)"`
+	ComponentName string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (CommitComponent) TableName() string {
+	return "commit_components"
+}
+
+// PullRequestComponent attributes a pull request to the components of its commits
+type PullRequestComponent struct {
+	common.NoPKModel
+	PullRequestId string `gorm:"primaryKey;type:varchar(255)"`
+	ComponentName string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (PullRequestComponent) TableName() string {
+	return "pull_request_components"
+}
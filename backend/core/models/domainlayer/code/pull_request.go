@@ -53,6 +53,14 @@ type PullRequest struct {
 	BaseRef        string `gorm:"type:varchar(255)"`
 	BaseCommitSha  string `gorm:"type:varchar(40)"`
 	HeadCommitSha  string `gorm:"type:varchar(40)"`
+	// Additions, Deletions and ChangedFiles measure the size of the diff, ReviewRounds counts the alternations
+	// between pushing commits and reviewing them
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	ReviewRounds int
+	// DeletedAt is set once the pull request no longer exists upstream, convertors never overwrite it
+	DeletedAt *time.Time `gorm:"index;<-:create"`
 }
 
 func (PullRequest) TableName() string {
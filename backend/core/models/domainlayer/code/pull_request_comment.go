@@ -34,6 +34,10 @@ type PullRequestComment struct {
 	Type          string `gorm:"type:varchar(255)"`
 	ReviewId      string `gorm:"type:varchar(255)"`
 	Status        string `gorm:"type:varchar(255)"`
+	// ThreadId is the review thread the comment belongs to, empty for comments outside of threads
+	ThreadId string `gorm:"index;type:varchar(255)"`
+	// ReplyToId is the comment this comment replies to
+	ReplyToId string `gorm:"type:varchar(255)"`
 }
 
 func (PullRequestComment) TableName() string {
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// types of the reviewer events
+const (
+	REVIEW_REQUESTED         = "REVIEW_REQUESTED"
+	REVIEW_REQUEST_REMOVED   = "REVIEW_REQUEST_REMOVED"
+	REVIEW_APPROVED          = "APPROVED"
+	REVIEW_CHANGES_REQUESTED = "CHANGES_REQUESTED"
+	REVIEW_COMMENTED         = "COMMENTED"
+	REVIEW_DISMISSED         = "DISMISSED"
+)
+
+// PullRequestReviewEvent records the lifecycle of a reviewer on a pull request
+type PullRequestReviewEvent struct {
+	domainlayer.DomainEntity
+	PullRequestId string `gorm:"index;type:varchar(255)"`
+	Type          string `gorm:"type:varchar(100)"`
+	OriginalType  string `gorm:"type:varchar(100)"`
+	// ReviewerId is the account requested to or doing the review
+	ReviewerId string `gorm:"type:varchar(255)"`
+	// ActorId is the account triggering the event, e.g. the author requesting a review or a maintainer dismissing it
+	ActorId     string `gorm:"type:varchar(255)"`
+	ReviewId    string `gorm:"type:varchar(255)"`
+	CreatedDate time.Time
+}
+
+func (PullRequestReviewEvent) TableName() string {
+	return "pull_request_review_events"
+}
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// PullRequestReviewThread is a discussion on a pull request, usually attached to a line of the diff
+type PullRequestReviewThread struct {
+	domainlayer.DomainEntity
+	PullRequestId string `gorm:"index;type:varchar(255)"`
+	Path          string `gorm:"type:varchar(255)"`
+	Line          int
+	IsResolved    bool
+	IsOutdated    bool
+	AuthorId      string `gorm:"type:varchar(255)"`
+	ResolverId    string `gorm:"type:varchar(255)"`
+	CommentCount  int
+	CreatedDate   time.Time
+	ResolvedDate  *time.Time
+}
+
+func (PullRequestReviewThread) TableName() string {
+	return "pull_request_review_threads"
+}
@@ -35,7 +35,8 @@ type Repo struct {
 	ForkedFrom  string     `json:"forkedFrom"`
 	CreatedDate *time.Time `json:"createdDate"`
 	UpdatedDate *time.Time `json:"updatedDate"`
-	Deleted     bool       `json:"deleted"`
+	// DeletedAt is set once the repo no longer exists upstream, convertors never overwrite it
+	DeletedAt *time.Time `json:"deletedAt" gorm:"index;<-:create"`
 }
 
 func (Repo) TableName() string {
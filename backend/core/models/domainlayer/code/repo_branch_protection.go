@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// RepoBranchProtection is the normalized set of policies enforced on a branch, or a branch pattern, of a repo
+type RepoBranchProtection struct {
+	domainlayer.DomainEntity
+	RepoId                        string `gorm:"index;type:varchar(255)"`
+	Branch                        string `gorm:"type:varchar(255)"`
+	RequiredApprovingReviewCount  int
+	RequireCodeOwnerReviews       bool
+	DismissStaleReviews           bool
+	RequiredStatusChecks          string // comma separated names of the checks which must pass before merging
+	RequireUpToDateBranch         bool
+	EnforceAdmins                 bool
+	AllowForcePushes              bool
+	AllowDeletions                bool
+	RequireLinearHistory          bool
+	RequireSignedCommits          bool
+	RequireConversationResolution bool
+}
+
+func (RepoBranchProtection) TableName() string {
+	return "repo_branch_protections"
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ecosystems of the dependencies, named after the package-url types
+const (
+	ECOSYSTEM_GOLANG = "golang"
+	ECOSYSTEM_NPM    = "npm"
+	ECOSYSTEM_PYPI   = "pypi"
+	ECOSYSTEM_MAVEN  = "maven"
+)
+
+const (
+	DEPENDENCY_SCOPE_RUNTIME     = "RUNTIME"
+	DEPENDENCY_SCOPE_DEVELOPMENT = "DEVELOPMENT"
+)
+
+// RepoDependency is a package a repo depends on, as declared by one of its manifests.
+// ManifestPath is empty for the dependencies reported by the dependency graph of the hosting platform
+type RepoDependency struct {
+	common.NoPKModel
+	RepoId       string `gorm:"primaryKey;type:varchar(255)"`
+	ManifestPath string `gorm:"primaryKey;type:varchar(200)"`
+	Ecosystem    string `gorm:"primaryKey;type:varchar(50)"`
+	// PackageName is qualified the way the ecosystem does, e.g. `org.apache.logging.log4j:log4j-core` for maven
+	PackageName string `gorm:"primaryKey;index;type:varchar(200)"`
+	// Version is the declared version, which may be a range or a requirement specifier
+	Version   string `gorm:"type:varchar(255)"`
+	Scope     string `gorm:"type:varchar(20)"`
+	CommitSha string `gorm:"type:varchar(40)"`
+}
+
+func (RepoDependency) TableName() string {
+	return "repo_dependencies"
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codequality
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// CqAnalysis is the latest analysis of a branch or a pull request of a cq_project, the metrics only count the new code
+type CqAnalysis struct {
+	domainlayer.DomainEntity
+	CqProjectId               string `gorm:"index;type:varchar(255)"`
+	Type                      string `gorm:"type:varchar(100)"`
+	Name                      string `gorm:"type:varchar(255)"`
+	Title                     string `gorm:"type:varchar(255)"`
+	Branch                    string `gorm:"type:varchar(255)"`
+	BaseBranch                string `gorm:"type:varchar(255)"`
+	IsMain                    bool
+	PullRequestId             string `gorm:"index;type:varchar(255)"`
+	Url                       string `gorm:"type:varchar(255)"`
+	QualityGateStatus         string `gorm:"type:varchar(100)"`
+	CommitSha                 string `gorm:"type:varchar(128)"`
+	AnalysisDate              *time.Time
+	NewLines                  int
+	NewBugs                   int
+	NewVulnerabilities        int
+	NewCodeSmells             int
+	NewSecurityHotspots       int
+	NewCoverage               float64
+	NewDuplicatedLinesDensity float64
+}
+
+func (CqAnalysis) TableName() string {
+	return "cq_analyses"
+}
+
+// the types of the analyses
+const (
+	ANALYSIS_BRANCH       = "BRANCH"
+	ANALYSIS_PULL_REQUEST = "PULL_REQUEST"
+)
+
+// the statuses of quality gates
+const (
+	QUALITY_GATE_OK    = "OK"
+	QUALITY_GATE_ERROR = "ERROR"
+)
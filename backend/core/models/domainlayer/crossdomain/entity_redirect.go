@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// EntityRedirect records a duplicated entity, e.g. the same repo collected through two connections, merged into the
+// entity kept. The references to FromId are redirected to ToId every time the duplicate is collected again
+type EntityRedirect struct {
+	// EntityType is the table of the entities, either repos or boards
+	EntityType string `gorm:"primaryKey;type:varchar(100)" json:"entityType"`
+	FromId     string `gorm:"primaryKey;type:varchar(255)" json:"fromId"`
+	ToId       string `gorm:"index;type:varchar(255)" json:"toId"`
+	common.NoPKModel
+}
+
+func (EntityRedirect) TableName() string {
+	return "entity_redirects"
+}
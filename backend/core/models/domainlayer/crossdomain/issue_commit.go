@@ -25,6 +25,9 @@ type IssueCommit struct {
 	common.NoPKModel
 	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
 	CommitSha string `gorm:"primaryKey;type:varchar(255)"`
+	// Confidence is how likely the link is correct, from 0 to 1, and LinkRule is the rule which produced it
+	Confidence float64
+	LinkRule   string `gorm:"type:varchar(255)"`
 }
 
 func (IssueCommit) TableName() string {
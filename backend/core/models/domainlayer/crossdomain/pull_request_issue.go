@@ -26,6 +26,9 @@ type PullRequestIssue struct {
 	IssueId        string `gorm:"primaryKey;type:varchar(255)"`
 	PullRequestKey int
 	IssueKey       int
+	// Confidence is how likely the link is correct, from 0 to 1, and LinkRule is the rule which produced it
+	Confidence float64
+	LinkRule   string `gorm:"type:varchar(255)"`
 	common.NoPKModel
 }
 
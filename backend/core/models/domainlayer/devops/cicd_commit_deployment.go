@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// CicdCommitDeployment links a commit to the successful deployment which shipped it first, for every
+// cicd scope and environment
+type CicdCommitDeployment struct {
+	CommitSha          string `gorm:"primaryKey;type:varchar(255)"`
+	CicdScopeId        string `gorm:"primaryKey;type:varchar(255)"`
+	Environment        string `gorm:"primaryKey;type:varchar(255)"`
+	RepoId             string `gorm:"type:varchar(255)"`
+	DeploymentCommitId string `gorm:"index;type:varchar(255)"`
+	CicdDeploymentId   string `gorm:"type:varchar(255)"`
+	DeployedDate       *time.Time
+	common.NoPKModel
+}
+
+func (CicdCommitDeployment) TableName() string {
+	return "cicd_commit_deployments"
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// CicdEnvironment is a deployment target defined in a cicd tool, Type is one of PRODUCTION, STAGING and TESTING
+type CicdEnvironment struct {
+	domainlayer.DomainEntity
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	Name        string `gorm:"type:varchar(255)"`
+	Type        string `gorm:"type:varchar(100)"`
+	Url         string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+	UpdatedDate *time.Time
+}
+
+func (CicdEnvironment) TableName() string {
+	return "cicd_environments"
+}
+
+// types of the environment gates
+const (
+	GATE_REQUIRED_REVIEWERS = "REQUIRED_REVIEWERS"
+	GATE_WAIT_TIMER         = "WAIT_TIMER"
+	GATE_BRANCH_POLICY      = "BRANCH_POLICY"
+	GATE_OTHER              = "OTHER"
+)
+
+// CicdEnvironmentGate is a condition which must be met before deploying to an environment
+type CicdEnvironmentGate struct {
+	EnvironmentId string `gorm:"primaryKey;type:varchar(255)"`
+	Type          string `gorm:"primaryKey;type:varchar(100)"`
+	// Value is the configuration of the gate, e.g. the reviewers for REQUIRED_REVIEWERS or minutes for WAIT_TIMER
+	Value string
+	common.NoPKModel
+}
+
+func (CicdEnvironmentGate) TableName() string {
+	return "cicd_environment_gates"
+}
+
+// states of the deployment approvals
+const (
+	APPROVAL_PENDING  = "PENDING"
+	APPROVAL_APPROVED = "APPROVED"
+	APPROVAL_REJECTED = "REJECTED"
+)
+
+// CicdDeploymentApproval records who approved or rejected a deployment waiting at a gate of an environment
+type CicdDeploymentApproval struct {
+	domainlayer.DomainEntity
+	CicdDeploymentId string `gorm:"index;type:varchar(255)"`
+	EnvironmentId    string `gorm:"type:varchar(255)"`
+	State            string `gorm:"type:varchar(100)"`
+	ApproverId       string `gorm:"type:varchar(255)"`
+	ApproverName     string `gorm:"type:varchar(255)"`
+	Comment          string
+	RequestedDate    *time.Time
+	ApprovedDate     *time.Time
+	// WaitingSec is the time the deployment waited for the approval
+	WaitingSec *uint64
+}
+
+func (CicdDeploymentApproval) TableName() string {
+	return "cicd_deployment_approvals"
+}
@@ -47,6 +47,12 @@ type CICDTask struct {
 	StartedDate  time.Time
 	FinishedDate *time.Time
 	CicdScopeId  string `gorm:"index;type:varchar(255)"`
+	// QueuedDate is when the task was queued, QueuedDurationSec is how long it waited for a runner
+	QueuedDate        *time.Time
+	QueuedDurationSec *uint64
+	Runner            string `gorm:"type:varchar(255)"`
+	// FailedStep is the name of the first step of the task that failed
+	FailedStep string `gorm:"type:varchar(255)"`
 }
 
 func (CICDTask) TableName() string {
@@ -21,6 +21,9 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/featureflag"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 )
 
@@ -31,48 +34,98 @@ type Tabler interface {
 func GetDomainTablesInfo() []Tabler {
 	return []Tabler{
 		// code
+		&code.CodeOwner{},
 		&code.Commit{},
 		&code.CommitFile{},
+		&code.CommitComponent{},
 		&code.CommitFileComponent{},
 		&code.CommitParent{},
 		&code.Component{},
 		&code.PullRequest{},
 		&code.PullRequestComment{},
 		&code.PullRequestCommit{},
+		&code.PullRequestComponent{},
 		&code.PullRequestLabel{},
+		&code.PullRequestReviewEvent{},
+		&code.PullRequestReviewThread{},
 		&code.Ref{},
 		&code.CommitsDiff{},
 		&code.RefCommit{},
 		&code.RefsPrCherrypick{},
 		&code.Repo{},
+		&code.RepoBranchProtection{},
 		&code.RepoCommit{},
+		&code.RepoDependency{},
 		&code.RepoLanguage{},
 		// crossdomain
 		&crossdomain.Account{},
 		&crossdomain.BoardRepo{},
+		&crossdomain.CustomField{},
+		&crossdomain.EntityRedirect{},
 		&crossdomain.IssueCommit{},
 		&crossdomain.IssueRepoCommit{},
 		&crossdomain.ProjectMapping{},
 		&crossdomain.PullRequestIssue{},
 		&crossdomain.RefsIssuesDiffs{},
 		&crossdomain.Team{},
+		&crossdomain.TeamClosure{},
+		&crossdomain.TeamScope{},
 		&crossdomain.TeamUser{},
 		&crossdomain.User{},
 		&crossdomain.UserAccount{},
 		// devops
+		&devops.CicdArtifact{},
+		&devops.CicdCommitDeployment{},
+		&devops.CicdDeploymentApproval{},
+		&devops.CicdDeploymentCommitComponent{},
+		&devops.CicdDeploymentArtifact{},
+		&devops.CicdEnvironment{},
+		&devops.CicdEnvironmentGate{},
+		&devops.CicdRelease{},
+		&devops.CicdReleaseArtifact{},
 		&devops.CICDPipeline{},
 		&devops.CICDTask{},
 		// didgen no table
+		// featureflag
+		&featureflag.FeatureFlag{},
+		&featureflag.FeatureFlagEvent{},
+		&featureflag.FeatureFlagScope{},
+		// qa
+		&qa.QaCoverage{},
+		&qa.QaTestCase{},
+		&qa.QaTestRun{},
+		// security
+		&security.SecurityScope{},
+		&security.SecurityVulnerability{},
+		&security.SecurityVulnerabilityPullRequest{},
 		// ticket
 		&ticket.Board{},
 		&ticket.BoardIssue{},
 		&ticket.BoardSprint{},
+		&ticket.EscalationPolicy{},
+		&ticket.EscalationRule{},
 		&ticket.Issue{},
 		&ticket.IssueChangelogs{},
 		&ticket.IssueComment{},
 		&ticket.IssueLabel{},
+		&ticket.IssueResponder{},
+		&ticket.IssueSla{},
 		&ticket.IssueWorklog{},
+		&ticket.OncallSchedule{},
+		&ticket.OncallShift{},
 		&ticket.Sprint{},
 		&ticket.SprintIssue{},
+		&ticket.SprintIssueHistory{},
+		&ticket.SprintScope{},
 	}
 }
+
+// GetDomainTableNames returns the names of the domain tables
+func GetDomainTableNames() []string {
+	tablers := GetDomainTablesInfo()
+	names := make([]string, len(tablers))
+	for i, tabler := range tablers {
+		names[i] = tabler.TableName()
+	}
+	return names
+}
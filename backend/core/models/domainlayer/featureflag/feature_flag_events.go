@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflag
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// types of the feature flag events, the tool specific type is kept in OriginalEventType
+const (
+	EVENT_CREATED             = "CREATED"
+	EVENT_TOGGLED_ON          = "TOGGLED_ON"
+	EVENT_TOGGLED_OFF         = "TOGGLED_OFF"
+	EVENT_ROLLOUT_CHANGED     = "ROLLOUT_CHANGED"
+	EVENT_ENVIRONMENT_CHANGED = "ENVIRONMENT_CHANGED"
+	EVENT_ARCHIVED            = "ARCHIVED"
+)
+
+// FeatureFlagEvent is a change of a feature flag, events of a single environment carry it so they can be
+// correlated with the deployments and incidents of the same environment
+type FeatureFlagEvent struct {
+	domainlayer.DomainEntity
+	FeatureFlagId     string `gorm:"index;type:varchar(255)"`
+	EventType         string `gorm:"type:varchar(100)"`
+	OriginalEventType string `gorm:"type:varchar(100)"`
+	// Environment is one of PRODUCTION, STAGING and TESTING, the name used by the tool is kept in OriginalEnvironment
+	Environment         string `gorm:"type:varchar(100)"`
+	OriginalEnvironment string `gorm:"type:varchar(255)"`
+	// Enabled is the state of the flag in the environment after the change
+	Enabled bool
+	// RolloutPercentage is the share of the traffic served the flag after the change, nil if the tool doesn't tell
+	RolloutPercentage *float64
+	AuthorId          string `gorm:"type:varchar(255)"`
+	AuthorName        string `gorm:"type:varchar(255)"`
+	Comment           string
+	CreatedDate       time.Time `gorm:"index"`
+}
+
+func (FeatureFlagEvent) TableName() string {
+	return "feature_flag_events"
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflag
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.Scope = (*FeatureFlagScope)(nil)
+
+// FeatureFlagScope is the project of a feature flag tool which the flags belong to
+type FeatureFlagScope struct {
+	domainlayer.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Tool        string `gorm:"type:varchar(100)"`
+	Url         string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+	UpdatedDate *time.Time
+}
+
+func (FeatureFlagScope) TableName() string {
+	return "feature_flag_scopes"
+}
+
+func (s *FeatureFlagScope) ScopeId() string {
+	return s.Id
+}
+
+func (s *FeatureFlagScope) ScopeName() string {
+	return s.Name
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflag
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// kinds of the feature flags
+const (
+	KIND_BOOLEAN      = "BOOLEAN"
+	KIND_MULTIVARIATE = "MULTIVARIATE"
+)
+
+// standard statuses of the feature flags, the tool specific status is kept in OriginalStatus
+const (
+	STATUS_ACTIVE   = "ACTIVE"
+	STATUS_ARCHIVED = "ARCHIVED"
+)
+
+type FeatureFlag struct {
+	domainlayer.DomainEntity
+	FeatureFlagScopeId string `gorm:"index;type:varchar(255)"`
+	Tool               string `gorm:"type:varchar(100)"`
+	// Key is the identifier of the flag referenced by the code
+	Key            string `gorm:"index;type:varchar(255)"`
+	Name           string `gorm:"type:varchar(255)"`
+	Description    string
+	Url            string `gorm:"type:varchar(255)"`
+	Kind           string `gorm:"type:varchar(100)"`
+	Status         string `gorm:"type:varchar(100)"`
+	OriginalStatus string `gorm:"type:varchar(100)"`
+	CreatorId      string `gorm:"type:varchar(255)"`
+	CreatorName    string `gorm:"type:varchar(255)"`
+	CreatedDate    *time.Time
+	UpdatedDate    *time.Time
+	ArchivedDate   *time.Time
+}
+
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
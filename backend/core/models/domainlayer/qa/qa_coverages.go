@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qa
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// QaCoverage is the code coverage measured for a commit of a repo
+type QaCoverage struct {
+	domainlayer.DomainEntity
+	Tool            string `gorm:"type:varchar(100)"`
+	RepoId          string `gorm:"index;type:varchar(255)"`
+	CommitSha       string `gorm:"index;type:varchar(255)"`
+	Branch          string `gorm:"type:varchar(255)"`
+	PipelineId      string `gorm:"index;type:varchar(255)"`
+	TestRunId       string `gorm:"type:varchar(255)"`
+	LinesTotal      int
+	LinesCovered    int
+	BranchesTotal   int
+	BranchesCovered int
+	LineCoverage    float64
+	BranchCoverage  float64
+	CreatedDate     *time.Time
+}
+
+func (QaCoverage) TableName() string {
+	return "qa_coverages"
+}
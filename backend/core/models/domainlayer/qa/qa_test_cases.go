@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qa
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// QaTestCase is the result of a single test case within a test run
+type QaTestCase struct {
+	domainlayer.DomainEntity
+	TestRunId string `gorm:"index;type:varchar(255)"`
+	Name      string `gorm:"type:varchar(255)"`
+	ClassName string `gorm:"type:varchar(255)"`
+	FilePath  string `gorm:"type:varchar(255)"`
+	Status    string `gorm:"type:varchar(100)"`
+	// Retries is the number of times the test case was re-executed before reaching its final status
+	Retries      int
+	Message      string
+	DurationSec  float64
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+}
+
+func (QaTestCase) TableName() string {
+	return "qa_test_cases"
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qa
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// statuses of a test run or a single test case execution
+const (
+	STATUS_PASSED  = "PASSED"
+	STATUS_FAILED  = "FAILED"
+	STATUS_SKIPPED = "SKIPPED"
+	STATUS_FLAKY   = "FLAKY"
+)
+
+// QaTestRun is a single execution of a test suite, usually triggered by a cicd pipeline
+type QaTestRun struct {
+	domainlayer.DomainEntity
+	Name         string `gorm:"type:varchar(255)"`
+	Tool         string `gorm:"type:varchar(100)"`
+	CicdScopeId  string `gorm:"index;type:varchar(255)"`
+	PipelineId   string `gorm:"index;type:varchar(255)"`
+	RepoId       string `gorm:"index;type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(255)"`
+	Status       string `gorm:"type:varchar(100)"`
+	TotalCount   int
+	PassedCount  int
+	FailedCount  int
+	SkippedCount int
+	FlakyCount   int
+	DurationSec  float64
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+}
+
+func (QaTestRun) TableName() string {
+	return "qa_test_runs"
+}
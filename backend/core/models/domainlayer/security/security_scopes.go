@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.Scope = (*SecurityScope)(nil)
+
+// SecurityScope is the project/target scanned by a security tool
+type SecurityScope struct {
+	domainlayer.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Tool        string `gorm:"type:varchar(100)"`
+	Url         string `gorm:"type:varchar(255)"`
+	RepoId      string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+	UpdatedDate *time.Time
+}
+
+func (SecurityScope) TableName() string {
+	return "security_scopes"
+}
+
+func (s *SecurityScope) ScopeId() string {
+	return s.Id
+}
+
+func (s *SecurityScope) ScopeName() string {
+	return s.Name
+}
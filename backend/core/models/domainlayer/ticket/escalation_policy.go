@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+const (
+	ESCALATION_TARGET_SCHEDULE = "SCHEDULE"
+	ESCALATION_TARGET_ACCOUNT  = "ACCOUNT"
+)
+
+type EscalationPolicy struct {
+	domainlayer.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Url         string `gorm:"type:varchar(255)"`
+	// NumLoops is the number of times the policy is repeated when nobody acknowledges
+	NumLoops int
+}
+
+func (EscalationPolicy) TableName() string {
+	return "escalation_policies"
+}
+
+// EscalationRule is a level of an escalation policy, targets are notified DelayMinutes after the previous level
+type EscalationRule struct {
+	common.NoPKModel
+	EscalationPolicyId string `gorm:"primaryKey;type:varchar(255)"`
+	Level              int    `gorm:"primaryKey"`
+	TargetType         string `gorm:"primaryKey;type:varchar(100)"`
+	TargetId           string `gorm:"primaryKey;type:varchar(255)"`
+	DelayMinutes       int
+}
+
+func (EscalationRule) TableName() string {
+	return "escalation_rules"
+}
@@ -52,6 +52,8 @@ type Issue struct {
 	Severity                string `gorm:"type:varchar(255)"`
 	Component               string `gorm:"type:varchar(255)"`
 	OriginalProject         string `gorm:"type:varchar(255)"`
+	// DeletedAt is set once the issue no longer exists upstream, convertors never overwrite it
+	DeletedAt *time.Time `gorm:"index;<-:create"`
 }
 
 func (Issue) TableName() string {
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueResponder records who was paged for an incident and when they responded
+type IssueResponder struct {
+	common.NoPKModel
+	IssueId            string `gorm:"primaryKey;type:varchar(255)"`
+	AccountId          string `gorm:"primaryKey;type:varchar(255)"`
+	EscalationPolicyId string `gorm:"type:varchar(255)"`
+	EscalationLevel    int
+	AssignedDate       *time.Time
+	AcknowledgedDate   *time.Time
+	// AckTimeMinutes is the time from AssignedDate to AcknowledgedDate, nil if not acknowledged
+	AckTimeMinutes *int64
+}
+
+func (IssueResponder) TableName() string {
+	return "issue_responders"
+}
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// states of the sla cycles
+const (
+	SLA_ONGOING   = "ONGOING"
+	SLA_PAUSED    = "PAUSED"
+	SLA_COMPLETED = "COMPLETED"
+)
+
+// IssueSla is a cycle of a service level agreement clock of an issue, e.g. the time to first response of an
+// incident, a clock restarts a new cycle when the issue is reopened
+type IssueSla struct {
+	domainlayer.DomainEntity
+	IssueId string `gorm:"index;type:varchar(255)"`
+	Name    string `gorm:"type:varchar(255)"`
+	State   string `gorm:"type:varchar(100)"`
+	// Breached is true once the cycle ran over the goal
+	Breached         bool
+	GoalMinutes      *int64
+	ElapsedMinutes   int64
+	RemainingMinutes *int64
+	StartDate        *time.Time
+	StopDate         *time.Time
+	BreachDate       *time.Time
+}
+
+func (IssueSla) TableName() string {
+	return "issue_slas"
+}
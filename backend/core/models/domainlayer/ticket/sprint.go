@@ -29,6 +29,13 @@ var (
 	AfterSprint  = "AFTER_SPRINT"
 )
 
+// standard statuses of sprints, trackers map their own sprint, iteration or milestone states to them
+const (
+	SPRINT_STATUS_FUTURE = "FUTURE"
+	SPRINT_STATUS_ACTIVE = "ACTIVE"
+	SPRINT_STATUS_CLOSED = "CLOSED"
+)
+
 type Sprint struct {
 	domainlayer.DomainEntity
 	Name            string `gorm:"type:varchar(255)"`
@@ -53,3 +60,35 @@ type SprintIssue struct {
 func (SprintIssue) TableName() string {
 	return "sprint_issues"
 }
+
+// SprintIssueHistory is an interval during which the issue belonged to the sprint, RemovedDate is nil if it still does
+type SprintIssueHistory struct {
+	common.NoPKModel
+	SprintId    string    `gorm:"primaryKey;type:varchar(255)"`
+	IssueId     string    `gorm:"primaryKey;type:varchar(255)"`
+	AddedDate   time.Time `gorm:"primaryKey"`
+	RemovedDate *time.Time
+}
+
+func (SprintIssueHistory) TableName() string {
+	return "sprint_issue_histories"
+}
+
+// SprintScope compares the issues committed when the sprint started with the ones added, removed and completed
+// until it ended
+type SprintScope struct {
+	common.NoPKModel
+	SprintId             string `gorm:"primaryKey;type:varchar(255)"`
+	CommittedIssues      int
+	CommittedStoryPoints float64
+	AddedIssues          int
+	AddedStoryPoints     float64
+	RemovedIssues        int
+	RemovedStoryPoints   float64
+	CompletedIssues      int
+	CompletedStoryPoints float64
+}
+
+func (SprintScope) TableName() string {
+	return "sprint_scopes"
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	ENCRYPTION_KEY_ACTIVE  = "ACTIVE"
+	ENCRYPTION_KEY_RETIRED = "RETIRED"
+)
+
+// EncryptionKey is a data key of the encrypted columns, it is kept wrapped by ENCODE_KEY (the key encryption key).
+// The values are encrypted by the active key and prefixed by its id, the retired keys are kept until no value is
+// encrypted by them, see EncryptionKeyRotation
+type EncryptionKey struct {
+	common.Model
+	WrappedKey string     `gorm:"type:text" json:"-"`
+	Status     string     `gorm:"type:varchar(20)" json:"status"`
+	RetiredAt  *time.Time `json:"retiredAt"`
+}
+
+func (EncryptionKey) TableName() string {
+	return "_devlake_encryption_keys"
+}
+
+const (
+	ENCRYPTION_KEY_ROTATION_RUNNING = "RUNNING"
+	ENCRYPTION_KEY_ROTATION_DONE    = "DONE"
+	ENCRYPTION_KEY_ROTATION_FAILED  = "FAILED"
+)
+
+// EncryptionKeyRotation is a run of the job activating a new data key and re-encrypting the values of the encrypted
+// columns by it, its counters are updated as the tables get re-encrypted
+type EncryptionKeyRotation struct {
+	common.Model
+	Status          string     `gorm:"type:varchar(20)" json:"status"`
+	KeyId           uint64     `json:"keyId"`
+	BeganAt         *time.Time `json:"beganAt"`
+	FinishedAt      *time.Time `json:"finishedAt"`
+	TotalTables     int        `json:"totalTables"`
+	RotatedTables   int        `json:"rotatedTables"`
+	CurrentTable    string     `gorm:"type:varchar(255)" json:"currentTable"`
+	ReEncryptedRows int64      `json:"reEncryptedRows"`
+	Message         string     `json:"message"`
+}
+
+func (EncryptionKeyRotation) TableName() string {
+	return "_devlake_encryption_key_rotations"
+}
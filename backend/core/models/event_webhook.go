@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// the server events the outbound webhooks may subscribe to
+const (
+	EVENT_PIPELINE_COMPLETED   = "pipeline.completed"   // a pipeline finished, possibly with some tasks failed but skipped
+	EVENT_PIPELINE_FAILED      = "pipeline.failed"      // a pipeline failed
+	EVENT_BLUEPRINT_DISABLED   = "blueprint.disabled"   // a blueprint, or the project it belongs to, was disabled
+	EVENT_SCOPE_DELETED        = "scope.deleted"        // a scope was deleted from a connection
+	EVENT_CONNECTION_UNHEALTHY = "connection.unhealthy" // a task was rejected by the data source with the credentials of its connection
+)
+
+// the statuses of an event delivery
+const (
+	EVENT_DELIVERY_PENDING   = "PENDING"
+	EVENT_DELIVERY_SUCCEEDED = "SUCCEEDED"
+	EVENT_DELIVERY_FAILED    = "FAILED"
+)
+
+// EventWebhook posts the server events it subscribes to to an external url, the bodies are signed by HMAC-SHA256
+// with the secret in the X-Lake-Signature-256 header as `sha256=<hex digest>`
+type EventWebhook struct {
+	common.Model
+	Name   string   `json:"name" gorm:"type:varchar(255)"`
+	Url    string   `json:"url" gorm:"type:varchar(500)"`
+	Secret string   `json:"-" gorm:"serializer:encdec"`
+	Events []string `json:"events" gorm:"type:text;serializer:json"`
+	Enable bool     `json:"enable"`
+	// MaxRetries is how many times a failed delivery is retried, with an exponential backoff
+	MaxRetries int `json:"maxRetries"`
+}
+
+func (EventWebhook) TableName() string {
+	return "_devlake_event_webhooks"
+}
+
+// Subscribes tells whether the webhook is posted the event, a webhook without any event is posted all of them
+func (w *EventWebhook) Subscribes(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ApiInputEventWebhook creates or patches an event webhook, the fields left out are kept as they are
+type ApiInputEventWebhook struct {
+	Name       *string  `json:"name"`
+	Url        *string  `json:"url" validate:"omitempty,url"`
+	Secret     *string  `json:"secret"`
+	Events     []string `json:"events" validate:"omitempty,dive,oneof=pipeline.completed pipeline.failed blueprint.disabled scope.deleted connection.unhealthy"`
+	Enable     *bool    `json:"enable"`
+	MaxRetries *int     `json:"maxRetries" validate:"omitempty,min=0,max=10"`
+}
+
+// EventDelivery records the posting of an event to a webhook along with its attempts
+type EventDelivery struct {
+	common.Model
+	WebhookId     uint64     `json:"webhookId" gorm:"index"`
+	Event         string     `json:"event" gorm:"type:varchar(100)"`
+	Payload       string     `json:"payload"`
+	Status        string     `json:"status" gorm:"type:varchar(20);index"`
+	Attempts      int        `json:"attempts"`
+	ResponseCode  int        `json:"responseCode"`
+	Response      string     `json:"response"`
+	Message       string     `json:"message"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
+	DeliveredAt   *time.Time `json:"deliveredAt"`
+}
+
+func (EventDelivery) TableName() string {
+	return "_devlake_event_deliveries"
+}
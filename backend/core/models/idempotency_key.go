@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// IdempotencyKey records the response of a request bearing an Idempotency-Key header, so the retries of the request
+// get the original response back instead of doing it again. The keys of each client and route are apart.
+type IdempotencyKey struct {
+	// the api key, the user or else the IP of the client, i.e. `api-key:1`, `user:alice` or `ip:10.0.0.1`
+	Client string `json:"client" gorm:"primaryKey;type:varchar(100)"`
+	// the method along with the route, i.e. `POST /blueprints/:blueprintId/trigger`
+	Route string `json:"route" gorm:"primaryKey;type:varchar(100)"`
+	Key   string `json:"key" gorm:"primaryKey;column:idempotency_key;type:varchar(255)"`
+	// the hash of the path and the body, a retry must send the same request
+	RequestHash string `json:"requestHash" gorm:"type:varchar(64)"`
+	// the status of the response, 0 while the request is in flight
+	Status    int            `json:"status"`
+	Response  datatypes.JSON `json:"response"`
+	CreatedAt time.Time      `json:"createdAt" gorm:"index"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "_devlake_idempotency_keys"
+}
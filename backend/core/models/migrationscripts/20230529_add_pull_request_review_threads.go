@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addPullRequestReviewThreads)(nil)
+
+type addPullRequestReviewThreads struct{}
+
+type pullRequestComment20230529 struct {
+	ThreadId  string `gorm:"index;type:varchar(255)"`
+	ReplyToId string `gorm:"type:varchar(255)"`
+}
+
+func (pullRequestComment20230529) TableName() string {
+	return "pull_request_comments"
+}
+
+func (*addPullRequestReviewThreads) Up(basicRes context.BasicRes) errors.Error {
+	err := basicRes.GetDal().AutoMigrate(&pullRequestComment20230529{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.PullRequestReviewThread{},
+		&archived.PullRequestReviewEvent{},
+	)
+}
+
+func (*addPullRequestReviewThreads) Version() uint64 {
+	return 20230529143000
+}
+
+func (*addPullRequestReviewThreads) Name() string {
+	return "add pull request review threads and events"
+}
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addLinkConfidence)(nil)
+
+type addLinkConfidence struct{}
+
+type pullRequestIssue20230606 struct {
+	Confidence float64
+	LinkRule   string `gorm:"type:varchar(255)"`
+}
+
+func (pullRequestIssue20230606) TableName() string {
+	return "pull_request_issues"
+}
+
+type issueCommit20230606 struct {
+	Confidence float64
+	LinkRule   string `gorm:"type:varchar(255)"`
+}
+
+func (issueCommit20230606) TableName() string {
+	return "issue_commits"
+}
+
+func (*addLinkConfidence) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.AutoMigrate(&pullRequestIssue20230606{})
+	if err != nil {
+		return err
+	}
+	return db.AutoMigrate(&issueCommit20230606{})
+}
+
+func (*addLinkConfidence) Version() uint64 {
+	return 20230606094500
+}
+
+func (*addLinkConfidence) Name() string {
+	return "add confidence and link_rule to pull_request_issues and issue_commits"
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addTombstones)(nil)
+
+type addTombstones struct{}
+
+type pullRequest20230607 struct {
+	DeletedAt *time.Time `gorm:"index"`
+}
+
+func (pullRequest20230607) TableName() string {
+	return "pull_requests"
+}
+
+type issue20230607 struct {
+	DeletedAt *time.Time `gorm:"index"`
+}
+
+func (issue20230607) TableName() string {
+	return "issues"
+}
+
+type repo20230607 struct {
+	DeletedAt *time.Time `gorm:"index"`
+}
+
+func (repo20230607) TableName() string {
+	return "repos"
+}
+
+func (*addTombstones) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.AutoMigrate(&pullRequest20230607{})
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&issue20230607{})
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&repo20230607{})
+	if err != nil {
+		return err
+	}
+	// deleted_at supersedes the deleted flag of repos, which was never set
+	return db.DropColumns("repos", "deleted")
+}
+
+func (*addTombstones) Version() uint64 {
+	return 20230607103000
+}
+
+func (*addTombstones) Name() string {
+	return "add deleted_at tombstone to pull_requests, issues and repos, drop repos.deleted"
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addComponentAttribution)(nil)
+
+type addComponentAttribution struct{}
+
+type component20230608 struct {
+	RepoId    string `gorm:"primaryKey;type:varchar(255)"`
+	Name      string `gorm:"primaryKey;type:varchar(255)"`
+	PathRegex string `gorm:"type:varchar(255)"`
+}
+
+func (component20230608) TableName() string {
+	return "components"
+}
+
+func (script *addComponentAttribution) Up(basicRes context.BasicRes) errors.Error {
+	// components are configured per repo, so the same name may be used by several repos
+	err := migrationhelper.TransformTable(
+		basicRes,
+		script,
+		"components",
+		func(s *component20220722) (*component20230608, errors.Error) {
+			return &component20230608{
+				RepoId:    s.RepoId,
+				Name:      s.Name,
+				PathRegex: s.PathRegex,
+			}, nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.CommitComponent{},
+		&archived.PullRequestComponent{},
+		&archived.CicdDeploymentCommitComponent{},
+	)
+}
+
+func (*addComponentAttribution) Version() uint64 {
+	return 20230608093000
+}
+
+func (*addComponentAttribution) Name() string {
+	return "key components by repo, add commit_components, pull_request_components and cicd_deployment_commit_components"
+}
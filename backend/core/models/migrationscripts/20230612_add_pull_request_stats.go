@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addPullRequestStats)(nil)
+
+type addPullRequestStats struct{}
+
+type pullRequest20230612 struct {
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	ReviewRounds int
+}
+
+func (pullRequest20230612) TableName() string {
+	return "pull_requests"
+}
+
+func (*addPullRequestStats) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&pullRequest20230612{})
+}
+
+func (*addPullRequestStats) Version() uint64 {
+	return 20230612100000
+}
+
+func (*addPullRequestStats) Name() string {
+	return "add additions, deletions, changed_files and review_rounds to pull_requests"
+}
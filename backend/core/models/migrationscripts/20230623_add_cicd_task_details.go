@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addCicdTaskDetails)(nil)
+
+type addCicdTaskDetails struct{}
+
+type cicdTask20230623 struct {
+	QueuedDate        *time.Time
+	QueuedDurationSec *uint64
+	Runner            string `gorm:"type:varchar(255)"`
+	FailedStep        string `gorm:"type:varchar(255)"`
+}
+
+func (cicdTask20230623) TableName() string {
+	return "cicd_tasks"
+}
+
+func (*addCicdTaskDetails) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&cicdTask20230623{})
+}
+
+func (*addCicdTaskDetails) Version() uint64 {
+	return 20230623100000
+}
+
+func (*addCicdTaskDetails) Name() string {
+	return "add queued_date, queued_duration_sec, runner and failed_step to cicd_tasks"
+}
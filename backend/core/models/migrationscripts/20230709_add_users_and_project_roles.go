@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addUsersAndProjectRoles)(nil)
+
+type addUsersAndProjectRoles struct{}
+
+type apiKey20230709 struct {
+	UserId *uint64 `gorm:"index"`
+}
+
+func (apiKey20230709) TableName() string {
+	return "_devlake_api_keys"
+}
+
+func (*addUsersAndProjectRoles) Up(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.DevlakeUser{},
+		&archived.ProjectRoleBinding{},
+		&archived.ConnectionOwner{},
+	)
+	if err != nil {
+		return err
+	}
+	return basicRes.GetDal().AutoMigrate(&apiKey20230709{})
+}
+
+func (*addUsersAndProjectRoles) Version() uint64 {
+	return 20230709100000
+}
+
+func (*addUsersAndProjectRoles) Name() string {
+	return "add _devlake_users, _devlake_project_role_bindings, _devlake_connection_owners and user_id to _devlake_api_keys"
+}
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type projectRoleBinding20230712 struct {
+	Source string `gorm:"type:varchar(20)"`
+}
+
+func (projectRoleBinding20230712) TableName() string {
+	return "_devlake_project_role_bindings"
+}
+
+type addRoleBindingSources struct{}
+
+func (*addRoleBindingSources) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &projectRoleBinding20230712{})
+}
+
+func (*addRoleBindingSources) Version() uint64 {
+	return 20230712100000
+}
+
+func (*addRoleBindingSources) Name() string {
+	return "add source to _devlake_project_role_bindings"
+}
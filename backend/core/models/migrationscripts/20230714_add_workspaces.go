@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addWorkspaces)(nil)
+
+type project20230714 struct {
+	Workspace string `gorm:"type:varchar(255);index"`
+}
+
+func (project20230714) TableName() string {
+	return "projects"
+}
+
+type apiKey20230714 struct {
+	Workspace string `gorm:"type:varchar(255);index"`
+}
+
+func (apiKey20230714) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type addWorkspaces struct{}
+
+func (*addWorkspaces) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.Workspace{},
+		&archived.WorkspaceConnection{},
+		&project20230714{},
+		&apiKey20230714{},
+	)
+}
+
+func (*addWorkspaces) Version() uint64 {
+	return 20230714100000
+}
+
+func (*addWorkspaces) Name() string {
+	return "add _devlake_workspaces and the workspace of the projects and the api keys"
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.MigrationScript = (*partitionRawTables)(nil)
+
+type partitionRawTables struct{}
+
+func (*partitionRawTables) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	tables, err := db.AllTables()
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if !strings.HasPrefix(table, "_raw_") {
+			continue
+		}
+		// the raw tables created by the remote plugins don't follow helper.RawData
+		columns, err := db.GetColumns(dal.DefaultTabler{Name: table}, func(columnMeta dal.ColumnMeta) bool {
+			return columnMeta.Name() == "id" || columnMeta.Name() == "created_at"
+		})
+		if err != nil {
+			return err
+		}
+		if len(columns) < 2 {
+			basicRes.GetLogger().Warn(nil, "skip partitioning %s which lacks the id/created_at columns", table)
+			continue
+		}
+		basicRes.GetLogger().Info("partitioning %s by collection month", table)
+		err = helper.PartitionRawTable(db, table)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to partition %s", table))
+		}
+	}
+	return nil
+}
+
+func (*partitionRawTables) Version() uint64 {
+	return 20230720100000
+}
+
+func (*partitionRawTables) Name() string {
+	return "partition the raw tables by collection month"
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addRawDataPurges)(nil)
+
+type rawDataRetention20230721 struct {
+	RetentionDays int
+}
+
+func (rawDataRetention20230721) TableName() string {
+	return "_devlake_raw_data_retentions"
+}
+
+type addRawDataPurges struct{}
+
+func (*addRawDataPurges) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &rawDataRetention20230721{}, &archived.RawDataPurge{})
+}
+
+func (*addRawDataPurges) Version() uint64 {
+	return 20230721100000
+}
+
+func (*addRawDataPurges) Name() string {
+	return "add retention_days to _devlake_raw_data_retentions and _devlake_raw_data_purges"
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCursorToCollectorState)(nil)
+
+type collectorLatestState20230723 struct {
+	Plugin string `gorm:"type:varchar(100);index"`
+	Cursor string `gorm:"type:text"`
+}
+
+func (collectorLatestState20230723) TableName() string {
+	return "_devlake_collector_latest_state"
+}
+
+type addCursorToCollectorState struct{}
+
+func (*addCursorToCollectorState) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &collectorLatestState20230723{})
+}
+
+func (*addCursorToCollectorState) Version() uint64 {
+	return 20230723100000
+}
+
+func (*addCursorToCollectorState) Name() string {
+	return "add plugin and cursor to _devlake_collector_latest_state"
+}
@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addEncryptionKeys)(nil)
+
+type encryptionKey20230730 struct {
+	archived.Model
+	WrappedKey string `gorm:"type:text"`
+	Status     string `gorm:"type:varchar(20)"`
+	RetiredAt  *time.Time
+}
+
+func (encryptionKey20230730) TableName() string {
+	return "_devlake_encryption_keys"
+}
+
+type encryptionKeyRotation20230730 struct {
+	archived.Model
+	Status          string `gorm:"type:varchar(20)"`
+	KeyId           uint64
+	BeganAt         *time.Time
+	FinishedAt      *time.Time
+	TotalTables     int
+	RotatedTables   int
+	CurrentTable    string `gorm:"type:varchar(255)"`
+	ReEncryptedRows int64
+	Message         string
+}
+
+func (encryptionKeyRotation20230730) TableName() string {
+	return "_devlake_encryption_key_rotations"
+}
+
+type addEncryptionKeys struct{}
+
+func (*addEncryptionKeys) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &encryptionKey20230730{}, &encryptionKeyRotation20230730{})
+}
+
+func (*addEncryptionKeys) Version() uint64 {
+	return 20230730100000
+}
+
+func (*addEncryptionKeys) Name() string {
+	return "add _devlake_encryption_keys and _devlake_encryption_key_rotations tables"
+}
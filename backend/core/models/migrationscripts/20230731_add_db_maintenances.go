@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addDbMaintenances)(nil)
+
+type dbMaintenance20230731 struct {
+	archived.Model
+	Status           string `gorm:"type:varchar(20)"`
+	Trigger          string `gorm:"type:varchar(20)"`
+	BeganAt          *time.Time
+	FinishedAt       *time.Time
+	TotalTables      int
+	MaintainedTables int
+	CurrentTable     string `gorm:"type:varchar(255)"`
+	Message          string
+}
+
+func (dbMaintenance20230731) TableName() string {
+	return "_devlake_db_maintenances"
+}
+
+type dbMaintenanceTable20230731 struct {
+	MaintenanceId uint64 `gorm:"primaryKey"`
+	Table         string `gorm:"primaryKey;column:table_name;type:varchar(255)"`
+	TableRows     int64
+	TotalBytes    int64
+	FreeBytes     int64
+	DeadRows      int64
+	BloatRatio    float64
+	Operations    string `gorm:"type:varchar(100)"`
+	DurationMs    int64
+	Message       string
+}
+
+func (dbMaintenanceTable20230731) TableName() string {
+	return "_devlake_db_maintenance_tables"
+}
+
+type addDbMaintenances struct{}
+
+func (*addDbMaintenances) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &dbMaintenance20230731{}, &dbMaintenanceTable20230731{})
+}
+
+func (*addDbMaintenances) Version() uint64 {
+	return 20230731100000
+}
+
+func (*addDbMaintenances) Name() string {
+	return "add _devlake_db_maintenances and _devlake_db_maintenance_tables tables"
+}
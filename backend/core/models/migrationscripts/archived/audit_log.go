@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type AuditLog struct {
+	ID           uint64    `gorm:"primaryKey"`
+	CreatedAt    time.Time `gorm:"index"`
+	Actor        string    `gorm:"type:varchar(255);index"`
+	UserId       *uint64
+	ApiKeyId     *uint64
+	ClientIp     string `gorm:"type:varchar(100)"`
+	Method       string `gorm:"type:varchar(10)"`
+	Path         string `gorm:"type:varchar(500)"`
+	ResourceType string `gorm:"type:varchar(255);index"`
+	ResourceId   string `gorm:"type:varchar(255)"`
+	Status       int
+	Before       datatypes.JSON
+	After        datatypes.JSON
+	Diff         datatypes.JSON
+}
+
+func (AuditLog) TableName() string {
+	return "_devlake_audit_logs"
+}
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type CicdArtifact struct {
+	DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Type        string `gorm:"type:varchar(100)"`
+	Version     string `gorm:"type:varchar(255)"`
+	Digest      string `gorm:"type:varchar(255)"`
+	Registry    string `gorm:"type:varchar(255)"`
+	Url         string `gorm:"type:varchar(255)"`
+	SizeBytes   int64
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	PipelineId  string `gorm:"index;type:varchar(255)"`
+	RepoId      string `gorm:"type:varchar(255)"`
+	CommitSha   string `gorm:"index;type:varchar(255)"`
+	CreatedDate time.Time
+}
+
+func (CicdArtifact) TableName() string {
+	return "cicd_artifacts"
+}
+
+type CicdDeploymentArtifact struct {
+	CicdDeploymentId string `gorm:"primaryKey;type:varchar(255)"`
+	ArtifactId       string `gorm:"primaryKey;type:varchar(255)"`
+	NoPKModel
+}
+
+func (CicdDeploymentArtifact) TableName() string {
+	return "cicd_deployment_artifacts"
+}
+
+type CicdRelease struct {
+	DomainEntity
+	Name          string `gorm:"type:varchar(255)"`
+	Version       string `gorm:"type:varchar(255)"`
+	TagName       string `gorm:"type:varchar(255)"`
+	Description   string
+	Url           string `gorm:"type:varchar(255)"`
+	IsPrerelease  bool
+	CicdScopeId   string `gorm:"index;type:varchar(255)"`
+	PipelineId    string `gorm:"type:varchar(255)"`
+	RepoId        string `gorm:"index;type:varchar(255)"`
+	CommitSha     string `gorm:"index;type:varchar(255)"`
+	PrevReleaseId string `gorm:"type:varchar(255)"`
+	AuthorId      string `gorm:"type:varchar(255)"`
+	CreatedDate   time.Time
+	PublishedDate *time.Time
+}
+
+func (CicdRelease) TableName() string {
+	return "cicd_releases"
+}
+
+type CicdReleaseArtifact struct {
+	ReleaseId  string `gorm:"primaryKey;type:varchar(255)"`
+	ArtifactId string `gorm:"primaryKey;type:varchar(255)"`
+	NoPKModel
+}
+
+func (CicdReleaseArtifact) TableName() string {
+	return "cicd_release_artifacts"
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+type CommitComponent struct {
+	NoPKModel
+	RepoId        string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha     string `gorm:"primaryKey;type:varchar(40)"`
+	ComponentName string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (CommitComponent) TableName() string {
+	return "commit_components"
+}
+
+type PullRequestComponent struct {
+	NoPKModel
+	PullRequestId string `gorm:"primaryKey;type:varchar(255)"`
+	ComponentName string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (PullRequestComponent) TableName() string {
+	return "pull_request_components"
+}
+
+type CicdDeploymentCommitComponent struct {
+	NoPKModel
+	DeploymentCommitId string `gorm:"primaryKey;type:varchar(255)"`
+	ComponentName      string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (CicdDeploymentCommitComponent) TableName() string {
+	return "cicd_deployment_commit_components"
+}
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type CustomField struct {
+	// EntityType is the table of the entity, e.g. issues
+	EntityType string `gorm:"primaryKey;type:varchar(100)"`
+	EntityId   string `gorm:"primaryKey;type:varchar(255)"`
+	FieldName  string `gorm:"primaryKey;type:varchar(255)"`
+	FieldType  string `gorm:"type:varchar(20)"`
+	// only the value matching the FieldType is set
+	StringValue string
+	NumberValue *float64
+	DateValue   *time.Time
+	NoPKModel
+}
+
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
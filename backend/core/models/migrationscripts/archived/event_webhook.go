@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import "time"
+
+type EventWebhook struct {
+	Model
+	Name       string `gorm:"type:varchar(255)"`
+	Url        string `gorm:"type:varchar(500)"`
+	Secret     string
+	Events     string `gorm:"type:text"`
+	Enable     bool
+	MaxRetries int
+}
+
+func (EventWebhook) TableName() string {
+	return "_devlake_event_webhooks"
+}
+
+type EventDelivery struct {
+	Model
+	WebhookId     uint64 `gorm:"index"`
+	Event         string `gorm:"type:varchar(100)"`
+	Payload       string
+	Status        string `gorm:"type:varchar(20);index"`
+	Attempts      int
+	ResponseCode  int
+	Response      string
+	Message       string
+	NextAttemptAt *time.Time
+	DeliveredAt   *time.Time
+}
+
+func (EventDelivery) TableName() string {
+	return "_devlake_event_deliveries"
+}
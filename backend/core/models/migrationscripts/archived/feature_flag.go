@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type FeatureFlagScope struct {
+	DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Tool        string `gorm:"type:varchar(100)"`
+	Url         string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+	UpdatedDate *time.Time
+}
+
+func (FeatureFlagScope) TableName() string {
+	return "feature_flag_scopes"
+}
+
+type FeatureFlag struct {
+	DomainEntity
+	FeatureFlagScopeId string `gorm:"index;type:varchar(255)"`
+	Tool               string `gorm:"type:varchar(100)"`
+	Key                string `gorm:"index;type:varchar(255)"`
+	Name               string `gorm:"type:varchar(255)"`
+	Description        string
+	Url                string `gorm:"type:varchar(255)"`
+	Kind               string `gorm:"type:varchar(100)"`
+	Status             string `gorm:"type:varchar(100)"`
+	OriginalStatus     string `gorm:"type:varchar(100)"`
+	CreatorId          string `gorm:"type:varchar(255)"`
+	CreatorName        string `gorm:"type:varchar(255)"`
+	CreatedDate        *time.Time
+	UpdatedDate        *time.Time
+	ArchivedDate       *time.Time
+}
+
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
+
+type FeatureFlagEvent struct {
+	DomainEntity
+	FeatureFlagId       string `gorm:"index;type:varchar(255)"`
+	EventType           string `gorm:"type:varchar(100)"`
+	OriginalEventType   string `gorm:"type:varchar(100)"`
+	Environment         string `gorm:"type:varchar(100)"`
+	OriginalEnvironment string `gorm:"type:varchar(255)"`
+	Enabled             bool
+	RolloutPercentage   *float64
+	AuthorId            string `gorm:"type:varchar(255)"`
+	AuthorName          string `gorm:"type:varchar(255)"`
+	Comment             string
+	CreatedDate         time.Time `gorm:"index"`
+}
+
+func (FeatureFlagEvent) TableName() string {
+	return "feature_flag_events"
+}
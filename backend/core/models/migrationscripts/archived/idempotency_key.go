@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type IdempotencyKey struct {
+	Client      string `gorm:"primaryKey;type:varchar(100)"`
+	Route       string `gorm:"primaryKey;type:varchar(100)"`
+	Key         string `gorm:"primaryKey;column:idempotency_key;type:varchar(255)"`
+	RequestHash string `gorm:"type:varchar(64)"`
+	Status      int
+	Response    datatypes.JSON
+	CreatedAt   time.Time `gorm:"index"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "_devlake_idempotency_keys"
+}
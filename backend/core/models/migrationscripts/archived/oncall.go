@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type OncallSchedule struct {
+	DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Url         string `gorm:"type:varchar(255)"`
+	TimeZone    string `gorm:"type:varchar(100)"`
+}
+
+func (OncallSchedule) TableName() string {
+	return "oncall_schedules"
+}
+
+type OncallShift struct {
+	NoPKModel
+	ScheduleId string    `gorm:"primaryKey;type:varchar(255)"`
+	AccountId  string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate  time.Time `gorm:"primaryKey"`
+	EndDate    *time.Time
+}
+
+func (OncallShift) TableName() string {
+	return "oncall_shifts"
+}
+
+type EscalationPolicy struct {
+	DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Url         string `gorm:"type:varchar(255)"`
+	NumLoops    int
+}
+
+func (EscalationPolicy) TableName() string {
+	return "escalation_policies"
+}
+
+type EscalationRule struct {
+	NoPKModel
+	EscalationPolicyId string `gorm:"primaryKey;type:varchar(255)"`
+	Level              int    `gorm:"primaryKey"`
+	TargetType         string `gorm:"primaryKey;type:varchar(100)"`
+	TargetId           string `gorm:"primaryKey;type:varchar(255)"`
+	DelayMinutes       int
+}
+
+func (EscalationRule) TableName() string {
+	return "escalation_rules"
+}
+
+type IssueResponder struct {
+	NoPKModel
+	IssueId            string `gorm:"primaryKey;type:varchar(255)"`
+	AccountId          string `gorm:"primaryKey;type:varchar(255)"`
+	EscalationPolicyId string `gorm:"type:varchar(255)"`
+	EscalationLevel    int
+	AssignedDate       *time.Time
+	AcknowledgedDate   *time.Time
+	AckTimeMinutes     *int64
+}
+
+func (IssueResponder) TableName() string {
+	return "issue_responders"
+}
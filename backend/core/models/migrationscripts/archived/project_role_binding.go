@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import "time"
+
+type DevlakeUser struct {
+	Model
+	Name    string `gorm:"type:varchar(255);uniqueIndex"`
+	Email   string `gorm:"type:varchar(255)"`
+	IsAdmin bool
+}
+
+func (DevlakeUser) TableName() string {
+	return "_devlake_users"
+}
+
+type ProjectRoleBinding struct {
+	ProjectName string `gorm:"primaryKey;type:varchar(255)"`
+	UserId      uint64 `gorm:"primaryKey"`
+	Role        string `gorm:"type:varchar(20)"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (ProjectRoleBinding) TableName() string {
+	return "_devlake_project_role_bindings"
+}
+
+type ConnectionOwner struct {
+	Plugin       string `gorm:"primaryKey;type:varchar(255)"`
+	ConnectionId uint64 `gorm:"primaryKey"`
+	UserId       uint64 `gorm:"index"`
+	CreatedAt    time.Time
+}
+
+func (ConnectionOwner) TableName() string {
+	return "_devlake_connection_owners"
+}
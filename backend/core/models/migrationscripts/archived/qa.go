@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type QaTestRun struct {
+	DomainEntity
+	Name         string `gorm:"type:varchar(255)"`
+	Tool         string `gorm:"type:varchar(100)"`
+	CicdScopeId  string `gorm:"index;type:varchar(255)"`
+	PipelineId   string `gorm:"index;type:varchar(255)"`
+	RepoId       string `gorm:"index;type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(255)"`
+	Status       string `gorm:"type:varchar(100)"`
+	TotalCount   int
+	PassedCount  int
+	FailedCount  int
+	SkippedCount int
+	FlakyCount   int
+	DurationSec  float64
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+}
+
+func (QaTestRun) TableName() string {
+	return "qa_test_runs"
+}
+
+type QaTestCase struct {
+	DomainEntity
+	TestRunId    string `gorm:"index;type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	ClassName    string `gorm:"type:varchar(255)"`
+	FilePath     string `gorm:"type:varchar(255)"`
+	Status       string `gorm:"type:varchar(100)"`
+	Retries      int
+	Message      string
+	DurationSec  float64
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+}
+
+func (QaTestCase) TableName() string {
+	return "qa_test_cases"
+}
+
+type QaCoverage struct {
+	DomainEntity
+	Tool            string `gorm:"type:varchar(100)"`
+	RepoId          string `gorm:"index;type:varchar(255)"`
+	CommitSha       string `gorm:"index;type:varchar(255)"`
+	Branch          string `gorm:"type:varchar(255)"`
+	PipelineId      string `gorm:"index;type:varchar(255)"`
+	TestRunId       string `gorm:"type:varchar(255)"`
+	LinesTotal      int
+	LinesCovered    int
+	BranchesTotal   int
+	BranchesCovered int
+	LineCoverage    float64
+	BranchCoverage  float64
+	CreatedDate     *time.Time
+}
+
+func (QaCoverage) TableName() string {
+	return "qa_coverages"
+}
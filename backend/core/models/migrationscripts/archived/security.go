@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type SecurityScope struct {
+	DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Tool        string `gorm:"type:varchar(100)"`
+	Url         string `gorm:"type:varchar(255)"`
+	RepoId      string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+	UpdatedDate *time.Time
+}
+
+func (SecurityScope) TableName() string {
+	return "security_scopes"
+}
+
+type SecurityVulnerability struct {
+	DomainEntity
+	SecurityScopeId  string `gorm:"index;type:varchar(255)"`
+	Tool             string `gorm:"type:varchar(100)"`
+	ScanType         string `gorm:"type:varchar(100)"`
+	Title            string `gorm:"type:varchar(255)"`
+	Description      string
+	Url              string `gorm:"type:varchar(255)"`
+	Severity         string `gorm:"type:varchar(100)"`
+	Cwe              string `gorm:"type:varchar(100)"`
+	Cve              string `gorm:"type:varchar(100)"`
+	RepoId           string `gorm:"index;type:varchar(255)"`
+	Component        string `gorm:"type:varchar(255)"`
+	ComponentVersion string `gorm:"type:varchar(100)"`
+	FixedVersion     string `gorm:"type:varchar(100)"`
+	Line             int
+	Status           string `gorm:"type:varchar(100)"`
+	OriginalStatus   string `gorm:"type:varchar(100)"`
+	CreatedDate      *time.Time
+	UpdatedDate      *time.Time
+	ResolvedDate     *time.Time
+}
+
+func (SecurityVulnerability) TableName() string {
+	return "security_vulnerabilities"
+}
+
+type SecurityVulnerabilityPullRequest struct {
+	VulnerabilityId string `gorm:"primaryKey;type:varchar(255)"`
+	PullRequestId   string `gorm:"primaryKey;type:varchar(255)"`
+	NoPKModel
+}
+
+func (SecurityVulnerabilityPullRequest) TableName() string {
+	return "security_vulnerability_pull_requests"
+}
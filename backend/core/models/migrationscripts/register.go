@@ -85,5 +85,52 @@ func All() []plugin.MigrationScript {
 		new(modifyPrLabelsAndComments),
 		new(renameFinishedCommitsDiffs),
 		new(addUpdatedDateToIssueComments),
+		new(addSecurityDomain),
+		new(addQaDomain),
+		new(addOncallTables),
+		new(addTeamHierarchy),
+		new(addPullRequestReviewThreads),
+		new(addCicdReleases),
+		new(addRepoBranchProtections),
+		new(addCicdEnvironments),
+		new(addCicdCommitDeployments),
+		new(addLinkConfidence),
+		new(addTombstones),
+		new(addComponentAttribution),
+		new(addSprintHistory),
+		new(addTeamScopes),
+		new(addCodeOwners),
+		new(addPullRequestStats),
+		new(addFeatureFlagDomain),
+		new(addRepoDependencies),
+		new(addRowLineages),
+		new(addCustomFields),
+		new(addEntityRedirects),
+		new(addRawDataRetentions),
+		new(addCqAnalyses),
+		new(addCicdTaskDetails),
+		new(addIssueSlas),
+		new(addSecurityVulnerabilityPullRequests),
+		new(addApiKeys),
+		new(addUsersAndProjectRoles),
+		new(addAuditLogs),
+		new(addEventWebhooks),
+		new(addRoleBindingSources),
+		new(addSavedQueries),
+		new(addWorkspaces),
+		new(addIdempotencyKeys),
+		new(addSettings),
+		new(partitionRawTables),
+		new(addRawDataPurges),
+		new(addArchives),
+		new(addCursorToCollectorState),
+		new(addRawDataDedupStats),
+		new(addCollectorHttpCache),
+		new(addBackfillToPipelines),
+		new(addDataSchemaToProjects),
+		new(addCdcEvents),
+		new(addEncryptionKeys),
+		new(addDbMaintenances),
+		new(addRawDataCompactions),
 	}
 }
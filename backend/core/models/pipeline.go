@@ -41,15 +41,18 @@ type Pipeline struct {
 	Stage         int             `json:"stage"`
 	Labels        []string        `json:"labels" gorm:"-"`
 	SkipOnFail    bool            `json:"skipOnFail"`
+	// Backfill restricts the run to a historical window, nil for a regular run
+	Backfill *plugin.BackfillWindow `json:"backfill" gorm:"type:text;serializer:json"`
 }
 
 // We use a 2D array because the request body must be an array of a set of tasks
 // to be executed concurrently, while each set is to be executed sequentially.
 type NewPipeline struct {
-	Name        string              `json:"name"`
-	Plan        plugin.PipelinePlan `json:"plan" swaggertype:"array,string" example:"please check api /pipelines/<PLUGIN_NAME>/pipeline-plan"`
-	Labels      []string            `json:"labels"`
-	SkipOnFail  bool                `json:"skipOnFail"`
+	Name        string                 `json:"name"`
+	Plan        plugin.PipelinePlan    `json:"plan" swaggertype:"array,string" example:"please check api /pipelines/<PLUGIN_NAME>/pipeline-plan"`
+	Labels      []string               `json:"labels"`
+	SkipOnFail  bool                   `json:"skipOnFail"`
+	Backfill    *plugin.BackfillWindow `json:"backfill"`
 	BlueprintId uint64
 }
 
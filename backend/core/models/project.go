@@ -24,10 +24,15 @@ import (
 type BaseProject struct {
 	Name        string `json:"name" mapstructure:"name" gorm:"primaryKey;type:varchar(255)" validate:"required"`
 	Description string `json:"description" mapstructure:"description" gorm:"type:text"`
+	TeamId      string `json:"teamId" mapstructure:"teamId" gorm:"type:varchar(255)"`
 }
 
 type Project struct {
 	BaseProject `mapstructure:",squash"`
+	// Workspace is the workspace the project belongs to, empty when it belongs to none
+	Workspace string `json:"workspace" mapstructure:"workspace" gorm:"type:varchar(255);index"`
+	// DataSchema is the schema the domain data of the project is isolated in, empty when it is shared with the others
+	DataSchema string `json:"dataSchema" mapstructure:"dataSchema" gorm:"type:varchar(64)"`
 	common.NoPKModel
 }
 
@@ -59,10 +64,16 @@ type ApiInputProject struct {
 	BaseProject `mapstructure:",squash"`
 	Enable      *bool         `json:"enable" mapstructure:"enable"`
 	Metrics     *[]BaseMetric `json:"metrics" mapstructure:"metrics"`
+	// Workspace is only taken into account when the project is created
+	Workspace string `json:"workspace" mapstructure:"workspace"`
+	// DataSchema is only taken into account when the project is created, see Project.DataSchema
+	DataSchema string `json:"dataSchema" mapstructure:"dataSchema" validate:"omitempty,max=63"`
 }
 
 type ApiOutputProject struct {
 	BaseProject `mapstructure:",squash"`
+	Workspace   string        `json:"workspace" mapstructure:"workspace"`
+	DataSchema  string        `json:"dataSchema" mapstructure:"dataSchema"`
 	Metrics     *[]BaseMetric `json:"metrics" mapstructure:"metrics"`
 	Blueprint   *Blueprint    `json:"blueprint" mapstructure:"blueprint"`
 }
@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+// RawDataDedupStat counts the api payloads the collectors received for a stream (the raw table) of a scope (the raw
+// data params) and those skipped because an identical payload was stored already, see RAW_DATA_DEDUP
+type RawDataDedupStat struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	RawDataTable  string    `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"rawDataTable"`
+	RawDataParams string    `gorm:"primaryKey;column:raw_data_params;type:varchar(255)" json:"rawDataParams"`
+	Plugin        string    `gorm:"type:varchar(100);index" json:"plugin"`
+	Collected     int64     `json:"collected"`
+	Duplicated    int64     `json:"duplicated"`
+	// DedupRatio is the share of the collected payloads that were duplicated
+	DedupRatio float64 `gorm:"-" json:"dedupRatio"`
+}
+
+func (RawDataDedupStat) TableName() string {
+	return "_devlake_raw_data_dedup_stats"
+}
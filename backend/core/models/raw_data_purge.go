@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	RAW_DATA_PURGE_RUNNING = "RUNNING"
+	RAW_DATA_PURGE_DONE    = "DONE"
+	RAW_DATA_PURGE_FAILED  = "FAILED"
+)
+
+const (
+	RAW_DATA_PURGE_TRIGGER_CRON   = "cron"
+	RAW_DATA_PURGE_TRIGGER_MANUAL = "manual"
+)
+
+// RawDataPurge is a run of the job deleting the raw api payloads older than the RetentionDays of their
+// RawDataRetention, its counters are updated as the raw tables get purged
+type RawDataPurge struct {
+	common.Model
+	Status            string     `gorm:"type:varchar(20)" json:"status"`
+	Trigger           string     `gorm:"type:varchar(20)" json:"trigger"`
+	BeganAt           *time.Time `json:"beganAt"`
+	FinishedAt        *time.Time `json:"finishedAt"`
+	TotalTables       int        `json:"totalTables"`
+	PurgedTables      int        `json:"purgedTables"`
+	CurrentTable      string     `gorm:"type:varchar(255)" json:"currentTable"`
+	DeletedRows       int64      `json:"deletedRows"`
+	DroppedPartitions int        `json:"droppedPartitions"`
+	Message           string     `json:"message"`
+}
+
+func (RawDataPurge) TableName() string {
+	return "_devlake_raw_data_purges"
+}
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+// RawDataRetention toggles whether the raw api payloads of a scope are kept once they are extracted, and for how
+// long. The scope is identified by the plugin and the _raw_data_params of its subtasks, a retention with empty
+// _raw_data_params applies to the scopes of the plugin without a retention of their own. The payloads are kept
+// unless a retention says otherwise. They are dropped at the end of the task, once every subtask reading them ran.
+// Dropping them saves storage, but the extraction can no longer be replayed: the tool layer rows of the scope are
+// upserted by the incremental runs instead of being refreshed, the rows deleted upstream are only cleaned up by the
+// runs collecting the scope in full.
+type RawDataRetention struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	Plugin        string    `gorm:"primaryKey;type:varchar(100)" json:"plugin"`
+	RawDataParams string    `gorm:"primaryKey;column:raw_data_params;type:varchar(255)" json:"rawDataParams"`
+	KeepRawData   bool      `json:"keepRawData"`
+	// RetentionDays is the age past which the kept payloads are purged by the RawDataPurge job, 0 keeps them forever
+	RetentionDays int `json:"retentionDays"`
+}
+
+func (RawDataRetention) TableName() string {
+	return "_devlake_raw_data_retentions"
+}
@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+// RowLineage records the run which produced or last updated the rows of a table. The rows are stamped by their raw
+// data origin: all the rows of TargetTable sharing the same _raw_data_table and _raw_data_params are written by the
+// same subtask run, which makes it possible to trace a suspect metric back to the collection that fed it.
+type RowLineage struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	TargetTable   string    `gorm:"primaryKey;type:varchar(100)" json:"targetTable"`
+	RawDataTable  string    `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"rawDataTable"`
+	RawDataParams string    `gorm:"primaryKey;column:raw_data_params;type:varchar(255)" json:"rawDataParams"`
+	PipelineId    uint64    `gorm:"index" json:"pipelineId"`
+	TaskId        uint64    `gorm:"index" json:"taskId"`
+	Plugin        string    `gorm:"type:varchar(100)" json:"plugin"`
+	PluginVersion string    `gorm:"type:varchar(100)" json:"pluginVersion"`
+	Subtask       string    `gorm:"type:varchar(255)" json:"subtask"`
+}
+
+func (RowLineage) TableName() string {
+	return "_devlake_row_lineages"
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// the comparisons of the safe query filters
+const (
+	QUERY_OP_EQ       = "eq"
+	QUERY_OP_NE       = "ne"
+	QUERY_OP_GT       = "gt"
+	QUERY_OP_GTE      = "gte"
+	QUERY_OP_LT       = "lt"
+	QUERY_OP_LTE      = "lte"
+	QUERY_OP_IN       = "in"   // the value is a list, or a comma-separated string for a parameter
+	QUERY_OP_LIKE     = "like" // the value is a pattern with % wildcards
+	QUERY_OP_NULL     = "null"
+	QUERY_OP_NOT_NULL = "notnull"
+)
+
+// SafeQuery reads the rows of a domain table without resorting to sql, the columns are checked against the table
+// and the values are bound as parameters
+type SafeQuery struct {
+	Table string `json:"table" validate:"required"`
+	// Columns are the selected columns, all of them by default, they have to be grouped by along with aggregates
+	Columns    []string         `json:"columns"`
+	Aggregates []QueryAggregate `json:"aggregates" validate:"dive"`
+	GroupBy    []string         `json:"groupBy"`
+	Filters    []QueryFilter    `json:"filters" validate:"dive"`
+	// OrderBy are the selected columns or aggregates, descending when prefixed by -, i.e. `-created_date`
+	OrderBy []string `json:"orderBy"`
+	// Limit is capped by SAFE_QUERY_MAX_ROWS, which is the default
+	Limit int `json:"limit" validate:"min=0"`
+}
+
+type QueryAggregate struct {
+	Func string `json:"func" validate:"required,oneof=count countDistinct sum avg min max"`
+	// Column is left out to count the rows
+	Column string `json:"column"`
+	As     string `json:"as" validate:"required"`
+}
+
+type QueryFilter struct {
+	Column string      `json:"column" validate:"required"`
+	Op     string      `json:"op" validate:"required,oneof=eq ne gt gte lt lte in like null notnull"`
+	Value  interface{} `json:"value"`
+	// Param names the parameter of a saved query the value is taken from, the value being its default
+	Param string `json:"param"`
+}
+
+// SavedQuery is a safe query run by its name, with its parameters given on every run
+type SavedQuery struct {
+	Name        string    `json:"name" gorm:"primaryKey;type:varchar(255)" validate:"required"`
+	Description string    `json:"description"`
+	Query       SafeQuery `json:"query" gorm:"type:text;serializer:json"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (SavedQuery) TableName() string {
+	return "_devlake_saved_queries"
+}
@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Setting is a setting of the server changed at runtime through the settings api, kept by its name as json. The
+// settings which were never put fall back to their defaults, which are read from the environment.
+type Setting struct {
+	Name      string         `json:"name" gorm:"primaryKey;type:varchar(100)"`
+	Value     datatypes.JSON `json:"value"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+func (Setting) TableName() string {
+	return "_devlake_settings"
+}
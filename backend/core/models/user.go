@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// the roles a user may be granted on a project
+const (
+	PROJECT_ROLE_ADMIN      = "admin"      // maintainer, plus managing the role bindings of the project
+	PROJECT_ROLE_MAINTAINER = "maintainer" // viewer, plus changing the project, its blueprints, connections and scopes and running its pipelines
+	PROJECT_ROLE_VIEWER     = "viewer"     // reading the project, its blueprints, connections, scopes and pipelines
+)
+
+// User is the identity the role bindings are granted to, it is either authenticated by the auth provider, its name
+// being the username of the token, or by an api key owned by the user.
+// An admin user is granted every role on every project, as well as the global endpoints.
+type User struct {
+	common.Model
+	Name    string `json:"name" gorm:"type:varchar(255);uniqueIndex"`
+	Email   string `json:"email" gorm:"type:varchar(255)"`
+	IsAdmin bool   `json:"isAdmin"`
+}
+
+func (User) TableName() string {
+	return "_devlake_users"
+}
+
+type ApiInputUser struct {
+	Name    string `json:"name" validate:"required"`
+	Email   string `json:"email"`
+	IsAdmin bool   `json:"isAdmin"`
+}
+
+// the sources of the role bindings
+const (
+	ROLE_BINDING_SOURCE_MANUAL = ""      // granted through the api
+	ROLE_BINDING_SOURCE_GROUP  = "group" // derived from the groups of the token by AUTH_GROUP_ROLES, replaced on every sign-in
+)
+
+// ProjectRoleBinding grants a role on a project to a user
+type ProjectRoleBinding struct {
+	ProjectName string    `json:"projectName" gorm:"primaryKey;type:varchar(255)" validate:"required"`
+	UserId      uint64    `json:"userId" gorm:"primaryKey" validate:"required"`
+	Role        string    `json:"role" gorm:"type:varchar(20)" validate:"required,oneof=admin maintainer viewer"`
+	Source      string    `json:"source" gorm:"type:varchar(20)"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (ProjectRoleBinding) TableName() string {
+	return "_devlake_project_role_bindings"
+}
+
+// ConnectionOwner records who created a plugin connection, the connection is visible to its owner and to the
+// members of the projects whose blueprints use it
+type ConnectionOwner struct {
+	Plugin       string    `json:"plugin" gorm:"primaryKey;type:varchar(255)"`
+	ConnectionId uint64    `json:"connectionId" gorm:"primaryKey"`
+	UserId       uint64    `json:"userId" gorm:"index"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (ConnectionOwner) TableName() string {
+	return "_devlake_connection_owners"
+}
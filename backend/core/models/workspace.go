@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// Workspace isolates the connections and the projects of a tenant, along with the blueprints, the pipelines and
+// the domain data of the projects. The requests of a workspace, i.e. bearing an api key of the workspace, reach
+// nothing else.
+type Workspace struct {
+	Name        string `json:"name" mapstructure:"name" gorm:"primaryKey;type:varchar(255)" validate:"required"`
+	Description string `json:"description" mapstructure:"description" gorm:"type:text"`
+	common.NoPKModel
+}
+
+func (Workspace) TableName() string {
+	return "_devlake_workspaces"
+}
+
+// WorkspaceConnection assigns a plugin connection to a workspace, the connections out of any workspace are visible to
+// the requests out of any workspace only
+type WorkspaceConnection struct {
+	Plugin       string `json:"plugin" gorm:"primaryKey;type:varchar(255)"`
+	ConnectionId uint64 `json:"connectionId" gorm:"primaryKey"`
+	Workspace    string `json:"workspace" gorm:"type:varchar(255);index"`
+	common.NoPKModel
+}
+
+func (WorkspaceConnection) TableName() string {
+	return "_devlake_workspace_connections"
+}
@@ -27,8 +27,8 @@ import (
 type ApiResourceInput struct {
 	Params  map[string]string      // path variables
 	Query   url.Values             // query string
-	Body    map[string]interface{} // json body
-	Request *http.Request
+	Body    map[string]interface{} // json body, it is nil when the body is a list
+	Request *http.Request          // the body of a json request can be read again, i.e. to verify its signature
 }
 
 // OutputFile is the file returned
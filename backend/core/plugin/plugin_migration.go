@@ -29,6 +29,32 @@ type MigrationScript interface {
 	Name() string
 }
 
+// MigrationProgress is called by a ResumableMigrationScript after each batch to report how far it went, the checkpoint
+// is persisted so an interrupted run resumes from there rather than starting over
+type MigrationProgress func(done, total int64, checkpoint string) errors.Error
+
+// ResumableMigrationScript is a long-running data migration reporting its progress and resuming after interruption
+type ResumableMigrationScript interface {
+	MigrationScript
+	// UpFrom is called instead of Up with the checkpoint of the interrupted run, or empty on the first run
+	UpFrom(basicRes context.BasicRes, checkpoint string, progress MigrationProgress) errors.Error
+}
+
+// OnlineMigrationScript is applied in the background once the server is up instead of blocking its startup,
+// so it must only migrate the data which the rest of the code tolerates being half migrated. It is applied after all
+// the offline scripts, whatever their versions, so it must work on the latest schema of the tables it migrates
+type OnlineMigrationScript interface {
+	ResumableMigrationScript
+	Online() bool
+}
+
+// RevertibleMigrationScript records the statements reverting it along with its migration history
+type RevertibleMigrationScript interface {
+	MigrationScript
+	// Rollback returns the sql statements reverting the script on the given dialect
+	Rollback(dialect string) []string
+}
+
 // Migrator is responsible for making sure the registered scripts get applied to database and only once
 type Migrator interface {
 	Register(scripts []MigrationScript, comment string)
@@ -36,6 +62,12 @@ type Migrator interface {
 	HasPendingScripts() bool
 }
 
+// OnlineMigrator applies the OnlineMigrationScript left pending by Execute
+type OnlineMigrator interface {
+	Migrator
+	ExecuteOnline() errors.Error
+}
+
 // PluginMigration is implemented by the plugin to declare all migration script that have to be applied to the database
 type PluginMigration interface {
 	MigrationScripts() []MigrationScript
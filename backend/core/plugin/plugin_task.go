@@ -19,8 +19,10 @@ package plugin
 
 import (
 	"context"
+	"fmt"
 	corecontext "github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
+	"time"
 )
 
 type ProgressType int
@@ -64,6 +66,66 @@ type TaskContext interface {
 	SubTaskContext(subtask string) (SubTaskContext, errors.Error)
 }
 
+// TaskLineage identifies the run of a task, the rows saved by its subtasks are traced back to it
+type TaskLineage struct {
+	PipelineId    uint64
+	TaskId        uint64
+	Plugin        string
+	PluginVersion string
+}
+
+// LineageTaskContext is implemented by the TaskContext of the tasks run by a pipeline
+type LineageTaskContext interface {
+	GetLineage() *TaskLineage
+}
+
+// RawDataTaskContext is implemented by the TaskContext of the tasks run by a pipeline, it tracks the raw data shared
+// by the subtasks so that the payloads a retention doesn't keep are only dropped once the whole task succeeded
+type RawDataTaskContext interface {
+	// SetRawDataRecollected tells the earlier raw rows of params were flushed and all of them were collected again
+	SetRawDataRecollected(table string, params string)
+	// IsRawDataRecollected tells if the raw rows of params were all collected again by the task
+	IsRawDataRecollected(table string, params string) bool
+	// DropRawDataAfterTask drops the raw rows of params up to maxId once every subtask of the task succeeded
+	DropRawDataAfterTask(table string, params string, maxId uint64)
+}
+
+// BackfillWindow restricts a backfill run to the data of [From, To), the collected data is merged into the existing
+// one instead of replacing it, and the collector states of the regular runs are left untouched
+type BackfillWindow struct {
+	From *time.Time `json:"from" validate:"required"`
+	To   *time.Time `json:"to"`
+}
+
+// Validate checks the window starts at From and isn't empty
+func (w *BackfillWindow) Validate() errors.Error {
+	if w.From == nil {
+		return errors.BadInput.New("the backfill window requires from")
+	}
+	if w.To != nil && !w.To.After(*w.From) {
+		return errors.BadInput.New("the backfill window must end after it starts")
+	}
+	return nil
+}
+
+func (w *BackfillWindow) String() string {
+	to := "now"
+	if w.To != nil {
+		to = w.To.Format(time.RFC3339)
+	}
+	from := ""
+	if w.From != nil {
+		from = w.From.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("[%s, %s)", from, to)
+}
+
+// BackfillTaskContext is implemented by the TaskContext of the tasks run by a pipeline
+type BackfillTaskContext interface {
+	// GetBackfill returns the window of the backfill run, or nil for a regular run
+	GetBackfill() *BackfillWindow
+}
+
 type SubTask interface {
 	// Execute FIXME ...
 	Execute() errors.Error
@@ -78,6 +140,9 @@ const DOMAIN_TYPE_CODE_REVIEW = "CODEREVIEW"   //nolint
 const DOMAIN_TYPE_CROSS = "CROSS"              //nolint
 const DOMAIN_TYPE_CICD = "CICD"                //nolint
 const DOMAIN_TYPE_CODE_QUALITY = "CODEQUALITY" //nolint
+const DOMAIN_TYPE_SECURITY = "SECURITY"        //nolint
+const DOMAIN_TYPE_QA = "QA"                    //nolint
+const DOMAIN_TYPE_FEATURE_FLAG = "FEATUREFLAG" //nolint
 
 var DOMAIN_TYPES = []string{
 	DOMAIN_TYPE_CODE,
@@ -86,6 +151,9 @@ var DOMAIN_TYPES = []string{
 	DOMAIN_TYPE_CROSS,
 	DOMAIN_TYPE_CICD,
 	DOMAIN_TYPE_CODE_QUALITY,
+	DOMAIN_TYPE_SECURITY,
+	DOMAIN_TYPE_QA,
+	DOMAIN_TYPE_FEATURE_FLAG,
 } //nolint
 
 // SubTaskMeta Metadata of a subtask
@@ -112,3 +180,13 @@ type CloseablePluginTask interface {
 	PluginTask
 	Close(taskCtx TaskContext) errors.Error
 }
+
+// ConcurrentPluginTask Extends PluginTask, subtasks are partitioned into groups which are independent of each other.
+// Subtasks within a group are executed in order, while distinct groups may be executed concurrently
+type ConcurrentPluginTask interface {
+	PluginTask
+	// SubTaskGroup returns the name of the group the subtask belongs to
+	SubTaskGroup(subtaskMeta *SubTaskMeta) string
+	// SubTaskParallelism returns the maximum number of groups to be executed at the same time, 1 means serial execution
+	SubTaskParallelism(taskCtx TaskContext, options map[string]interface{}) int
+}
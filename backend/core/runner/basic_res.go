@@ -21,9 +21,12 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
 	"github.com/apache/incubator-devlake/core/plugin"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
+	"github.com/apache/incubator-devlake/impls/dalclickhouse"
 	"github.com/apache/incubator-devlake/impls/dalgorm"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"gorm.io/gorm"
@@ -50,7 +53,28 @@ func CreateAppBasicRes() context.BasicRes {
 		panic(err)
 	}
 	dalgorm.Init(cfg.GetString(plugin.EncodeKeyEnvStr))
-	return CreateBasicRes(cfg, logger, db)
+	if err = dalgorm.LoadEncryptionKeys(dalgorm.NewDalgorm(db)); err != nil {
+		panic(err)
+	}
+	if err = RegisterDbStatsMetrics(db, "devlake"); err != nil {
+		logger.Error(err, "failed to register the metrics of the database")
+	}
+	if cfg.GetString("DOMAIN_DB_URL") == "" {
+		return CreateBasicRes(cfg, logger, db)
+	}
+	domainDb, err := NewDomainGormDb(cfg, logger)
+	if err != nil {
+		panic(err)
+	}
+	domainDal := dalgorm.NewDalgorm(domainDb)
+	var domainTables []string
+	for _, table := range domaininfo.GetDomainTablesInfo() {
+		if err = domainDal.AutoMigrate(table); err != nil {
+			panic(errors.Default.Wrap(err, fmt.Sprintf("failed to migrate %s on the domain database", table.TableName())))
+		}
+		domainTables = append(domainTables, table.TableName())
+	}
+	return contextimpl.NewDefaultBasicRes(cfg, logger, dalclickhouse.NewDomainDal(dalgorm.NewDalgorm(db), domainDal, domainTables))
 }
 
 // CreateBasicRes returns a BasicRes based on what was given
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+)
+
+// GetCdcTables returns the domain tables whose changes are captured, CDC_TABLES is a comma-separated list of them or
+// * for all, the other tables are ignored
+func GetCdcTables(configReader config.ConfigReader) []string {
+	setting := strings.TrimSpace(configReader.GetString("CDC_TABLES"))
+	if setting == "" {
+		return nil
+	}
+	domainTables := domaininfo.GetDomainTableNames()
+	if setting == "*" {
+		return domainTables
+	}
+	selected := make(map[string]bool)
+	for _, table := range strings.Split(setting, ",") {
+		selected[strings.TrimSpace(table)] = true
+	}
+	var tables []string
+	for _, table := range domainTables {
+		if selected[table] {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/impls/dalclickhouse"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -42,70 +44,195 @@ func NewGormDb(configReader config.ConfigReader, logger log.Logger) (*gorm.DB, e
 
 // NewGormDbEx acts like NewGormDb but accept extra sessionConfig
 func NewGormDbEx(configReader config.ConfigReader, logger log.Logger, sessionConfig *dal.SessionConfig) (*gorm.DB, errors.Error) {
-	dbLoggingLevel := gormLogger.Error
-	switch strings.ToLower(configReader.GetString("DB_LOGGING_LEVEL")) {
-	case "silent":
-		dbLoggingLevel = gormLogger.Silent
-	case "warn":
-		dbLoggingLevel = gormLogger.Warn
-	case "info":
-		dbLoggingLevel = gormLogger.Info
-	}
+	return openGormDb(configReader, logger, sessionConfig, "DB_URL")
+}
 
-	idleConns := configReader.GetInt("DB_IDLE_CONNS")
-	if idleConns <= 0 {
-		idleConns = 10
-	}
-	dbMaxOpenConns := configReader.GetInt("DB_MAX_CONNS")
-	if dbMaxOpenConns <= 0 {
-		dbMaxOpenConns = 100
-	}
+// NewReplicaGormDb creates the *gorm.DB of the read replica given by DB_REPLICA_URL, the heavy analytical reads are
+// served by it so that they don't slow the collections down. It shares the pool settings of DB_URL
+func NewReplicaGormDb(configReader config.ConfigReader, logger log.Logger) (*gorm.DB, errors.Error) {
+	return openGormDb(configReader, logger, &dal.SessionConfig{
+		PrepareStmt:            true,
+		SkipDefaultTransaction: true,
+	}, "DB_REPLICA_URL")
+}
 
+func openGormDb(configReader config.ConfigReader, logger log.Logger, sessionConfig *dal.SessionConfig, urlKey string) (*gorm.DB, errors.Error) {
 	dbConfig := &gorm.Config{
-		Logger: gormLogger.New(
-			logger,
-			gormLogger.Config{
-				SlowThreshold:             time.Second,    // Slow SQL threshold
-				LogLevel:                  dbLoggingLevel, // Log level
-				IgnoreRecordNotFoundError: true,           // Ignore ErrRecordNotFound error for logger
-				Colorful:                  true,           // Disable color
-			},
-		),
+		Logger:                 newGormLogger(configReader, logger),
 		PrepareStmt:            sessionConfig.PrepareStmt,
 		SkipDefaultTransaction: sessionConfig.SkipDefaultTransaction,
 	}
-	dbUrl := configReader.GetString("DB_URL")
+	dbUrl := configReader.GetString(urlKey)
 	if dbUrl == "" {
-		return nil, errors.BadInput.New("DB_URL is required")
+		return nil, errors.BadInput.New(fmt.Sprintf("%s is required", urlKey))
 	}
 	u, err := url.Parse(dbUrl)
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
 	var db *gorm.DB
+	var pool dbPoolConfig
 	switch strings.ToLower(u.Scheme) {
 	case "mysql":
-		dbUrl = fmt.Sprintf("%s@tcp(%s)%s?%s", getUserString(u), u.Host, u.Path, addLocal(u.Query()))
+		pool = loadDbPoolConfig(configReader, "mysql")
+		query := u.Query()
+		addStatementTimeout(query, "max_execution_time", pool.statementTimeout)
+		dbUrl = fmt.Sprintf("%s@tcp(%s)%s?%s", getUserString(u), u.Host, u.Path, addLocal(query))
 		db, err = gorm.Open(mysql.Open(dbUrl), dbConfig)
 	case "postgresql", "postgres", "pg":
-		db, err = gorm.Open(postgres.Open(dbUrl), dbConfig)
+		pool = loadDbPoolConfig(configReader, "postgres")
+		query := u.Query()
+		addStatementTimeout(query, "statement_timeout", pool.statementTimeout)
+		u.RawQuery = query.Encode()
+		db, err = gorm.Open(postgres.Open(u.String()), dbConfig)
 	default:
-		return nil, errors.BadInput.New(fmt.Sprintf("invalid DB_URL:%s", dbUrl))
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid %s:%s", urlKey, dbUrl))
 	}
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	// the domain tables of the projects with a data schema are redirected there
+	if err = dalgorm.RegisterTableSchemaCallbacks(db); err != nil {
+		return nil, errors.Convert(err)
+	}
+	// the changes of the tables selected by CDC_TABLES are recorded for the server to publish them
+	if cdcTables := GetCdcTables(configReader); len(cdcTables) > 0 {
+		if err = dalgorm.RegisterChangeCaptureCallbacks(db, cdcTables); err != nil {
+			return nil, errors.Convert(err)
+		}
+	}
+	// the durations of the queries on the domain and tool tables are exposed by GET /slow-queries
+	if tracked, maxStats := GetQueryStatsSettings(configReader); tracked {
+		if err = dalgorm.RegisterQueryStatsCallbacks(db, queryStatsTables(), maxStats); err != nil {
+			return nil, errors.Convert(err)
+		}
+	}
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
-	sqlDB.SetMaxIdleConns(idleConns)
-	sqlDB.SetMaxOpenConns(dbMaxOpenConns)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(pool.maxIdleConns)
+	sqlDB.SetMaxOpenConns(pool.maxOpenConns)
+	sqlDB.SetConnMaxLifetime(pool.connMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.connMaxIdleTime)
+	logger.Info(
+		"db pool: %d max open connections, %d idle, %s max lifetime, %s max idle time, %s statement timeout",
+		pool.maxOpenConns,
+		pool.maxIdleConns,
+		pool.connMaxLifetime,
+		pool.connMaxIdleTime,
+		pool.statementTimeout,
+	)
 
 	return db, errors.Convert(err)
 }
 
+// dbPoolConfig is the sizing of the connection pool of the database, see the DB_* settings of env.example
+type dbPoolConfig struct {
+	maxOpenConns     int
+	maxIdleConns     int
+	connMaxLifetime  time.Duration
+	connMaxIdleTime  time.Duration
+	statementTimeout time.Duration
+}
+
+// loadDbPoolConfig reads the pool settings. Postgres forks a process per connection and allows 100 of them by
+// default, shared by the server, the workers and the python plugins, its pool is kept smaller than the MySQL one
+func loadDbPoolConfig(configReader config.ConfigReader, dialect string) dbPoolConfig {
+	pool := dbPoolConfig{
+		maxOpenConns:     configReader.GetInt("DB_MAX_CONNS"),
+		maxIdleConns:     configReader.GetInt("DB_IDLE_CONNS"),
+		connMaxLifetime:  configReader.GetDuration("DB_CONN_MAX_LIFETIME"),
+		connMaxIdleTime:  configReader.GetDuration("DB_CONN_MAX_IDLE_TIME"),
+		statementTimeout: configReader.GetDuration("DB_STATEMENT_TIMEOUT"),
+	}
+	if pool.maxOpenConns <= 0 {
+		pool.maxOpenConns = 100
+		if dialect == "postgres" {
+			pool.maxOpenConns = 30
+		}
+	}
+	if pool.maxIdleConns <= 0 {
+		pool.maxIdleConns = 10
+	}
+	if pool.maxIdleConns > pool.maxOpenConns {
+		pool.maxIdleConns = pool.maxOpenConns
+	}
+	if pool.connMaxLifetime <= 0 {
+		pool.connMaxLifetime = time.Hour
+	}
+	if pool.connMaxIdleTime <= 0 {
+		pool.connMaxIdleTime = 5 * time.Minute
+	}
+	if pool.statementTimeout < 0 {
+		pool.statementTimeout = 0
+	}
+	return pool
+}
+
+// addStatementTimeout sets the session variable limiting the execution time of the statements, in milliseconds,
+// unless the DB_URL sets it already. The variable is max_execution_time on MySQL, which only limits the SELECTs,
+// and statement_timeout on Postgres
+func addStatementTimeout(query url.Values, variable string, timeout time.Duration) {
+	if timeout <= 0 || query.Get(variable) != "" {
+		return
+	}
+	query.Set(variable, fmt.Sprintf("%d", timeout.Milliseconds()))
+}
+
+// NewDomainGormDb creates the *gorm.DB of the database given by DOMAIN_DB_URL, which receives a copy of the domain layer tables
+func NewDomainGormDb(configReader config.ConfigReader, logger log.Logger) (*gorm.DB, errors.Error) {
+	dbUrl := configReader.GetString("DOMAIN_DB_URL")
+	u, err := url.Parse(dbUrl)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	dbConfig := &gorm.Config{
+		Logger:                 newGormLogger(configReader, logger),
+		SkipDefaultTransaction: true,
+	}
+	var db *gorm.DB
+	switch strings.ToLower(u.Scheme) {
+	case "clickhouse":
+		query := u.Query()
+		query.Set("username", u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			query.Set("password", password)
+		}
+		if database := strings.TrimPrefix(u.Path, "/"); database != "" {
+			query.Set("database", database)
+		}
+		db, err = gorm.Open(dalclickhouse.Open(fmt.Sprintf("tcp://%s?%s", u.Host, query.Encode())), dbConfig)
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid DOMAIN_DB_URL:%s", dbUrl))
+	}
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	return db, nil
+}
+
+func newGormLogger(configReader config.ConfigReader, logger log.Logger) gormLogger.Interface {
+	dbLoggingLevel := gormLogger.Error
+	switch strings.ToLower(configReader.GetString("DB_LOGGING_LEVEL")) {
+	case "silent":
+		dbLoggingLevel = gormLogger.Silent
+	case "warn":
+		dbLoggingLevel = gormLogger.Warn
+	case "info":
+		dbLoggingLevel = gormLogger.Info
+	}
+	return gormLogger.New(
+		logger,
+		gormLogger.Config{
+			SlowThreshold:             time.Second,    // Slow SQL threshold
+			LogLevel:                  dbLoggingLevel, // Log level
+			IgnoreRecordNotFoundError: true,           // Ignore ErrRecordNotFound error for logger
+			Colorful:                  true,           // Disable color
+		},
+	)
+}
+
 func getUserString(u *url.URL) string {
 	userString := u.User.Username()
 	password, ok := u.User.Password()
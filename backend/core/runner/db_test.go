@@ -20,6 +20,10 @@ package runner
 import (
 	"net/url"
 	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_addLocal(t *testing.T) {
@@ -66,3 +70,35 @@ func Test_addLocal(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadDbPoolConfig(t *testing.T) {
+	v := viper.New()
+	pool := loadDbPoolConfig(v, "postgres")
+	assert.Equal(t, 30, pool.maxOpenConns)
+	assert.Equal(t, 10, pool.maxIdleConns)
+	assert.Equal(t, time.Hour, pool.connMaxLifetime)
+	assert.Equal(t, 5*time.Minute, pool.connMaxIdleTime)
+	assert.Equal(t, time.Duration(0), pool.statementTimeout)
+	assert.Equal(t, 100, loadDbPoolConfig(v, "mysql").maxOpenConns)
+
+	v.Set("DB_MAX_CONNS", 5)
+	v.Set("DB_CONN_MAX_LIFETIME", "30m")
+	v.Set("DB_STATEMENT_TIMEOUT", "90s")
+	pool = loadDbPoolConfig(v, "postgres")
+	assert.Equal(t, 5, pool.maxOpenConns)
+	// the idle connections never outnumber the open ones
+	assert.Equal(t, 5, pool.maxIdleConns)
+	assert.Equal(t, 30*time.Minute, pool.connMaxLifetime)
+	assert.Equal(t, 90*time.Second, pool.statementTimeout)
+}
+
+func Test_addStatementTimeout(t *testing.T) {
+	query := url.Values{}
+	addStatementTimeout(query, "statement_timeout", 0)
+	assert.Equal(t, "", query.Encode())
+	addStatementTimeout(query, "statement_timeout", 90*time.Second)
+	assert.Equal(t, "statement_timeout=90000", query.Encode())
+	// the DB_URL takes precedence
+	addStatementTimeout(query, "statement_timeout", time.Second)
+	assert.Equal(t, "statement_timeout=90000", query.Encode())
+}
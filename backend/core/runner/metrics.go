@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+var taskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "devlake_task_duration_seconds",
+	Help:    "Duration of the tasks of the pipelines, by the plugin running them and how they finished",
+	Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+}, []string{"plugin", "status"})
+
+func observeTaskDuration(pluginName string, status string, duration time.Duration) {
+	taskDuration.WithLabelValues(pluginName, status).Observe(duration.Seconds())
+}
+
+// RegisterDbStatsMetrics exposes the stats of the connection pool of the database, i.e. the connections in use and
+// the time spent waiting for one, labeled by the name of the database
+func RegisterDbStatsMetrics(db *gorm.DB, name string) errors.Error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	for _, collector := range []prometheus.Collector{
+		collectors.NewDBStatsCollector(sqlDB, name),
+		newDbPoolCollector(sqlDB, name),
+	} {
+		err = prometheus.Register(collector)
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok && err != nil {
+			return errors.Convert(err)
+		}
+	}
+	return nil
+}
+
+// dbPoolCollector publishes the utilization of the connection pool, the share of the max open connections in use,
+// so that an alert can fire before the pipelines queue for connections
+type dbPoolCollector struct {
+	db          *sql.DB
+	utilization *prometheus.Desc
+}
+
+func newDbPoolCollector(db *sql.DB, name string) *dbPoolCollector {
+	return &dbPoolCollector{
+		db: db,
+		utilization: prometheus.NewDesc(
+			"devlake_db_pool_utilization",
+			"Share of the max open connections of the database pool in use, 1 when the queries wait for a connection",
+			nil,
+			prometheus.Labels{"db_name": name},
+		),
+	}
+}
+
+func (c *dbPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.utilization
+}
+
+func (c *dbPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+	utilization := 0.0
+	if stats.MaxOpenConnections > 0 {
+		utilization = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+	}
+	ch <- prometheus.MustNewConstMetric(c.utilization, prometheus.GaugeValue, utilization)
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// lazyGormDb returns a *gorm.DB whose pool never connects unless a query is run
+func lazyGormDb(t *testing.T, host string) *gorm.DB {
+	sqlDB, err := sql.Open("mysql", "merico:merico@tcp("+host+":3306)/lake")
+	assert.Nil(t, err)
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		DisableAutomaticPing: true,
+	})
+	assert.Nil(t, err)
+	return db
+}
+
+func TestRegisterDbStatsMetricsLabelsBothPools(t *testing.T) {
+	assert.Nil(t, RegisterDbStatsMetrics(lazyGormDb(t, "primary"), "devlake"))
+	assert.Nil(t, RegisterDbStatsMetrics(lazyGormDb(t, "replica"), "devlake_replica"))
+	// registering the same pool name again is not an error
+	assert.Nil(t, RegisterDbStatsMetrics(lazyGormDb(t, "replica"), "devlake_replica"))
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.Nil(t, err)
+	dbNames := map[string][]string{}
+	for _, family := range families {
+		if family.GetName() != "devlake_db_pool_utilization" && family.GetName() != "go_sql_open_connections" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "db_name" {
+					dbNames[family.GetName()] = append(dbNames[family.GetName()], label.GetValue())
+				}
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"devlake", "devlake_replica"}, dbNames["devlake_db_pool_utilization"])
+	assert.ElementsMatch(t, []string{"devlake", "devlake_replica"}, dbNames["go_sql_open_connections"])
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	contextimpl "github.com/apache/incubator-devlake/impls/context"
+)
+
+// isolateProjectData redirects the domain tables to the data schema of the project the pipeline belongs to, the
+// resources are returned as is for the projects sharing their domain data
+func isolateProjectData(basicRes context.BasicRes, pipeline *models.Pipeline) (context.BasicRes, errors.Error) {
+	if pipeline.BlueprintId == 0 {
+		return basicRes, nil
+	}
+	db := basicRes.GetDal()
+	blueprint := &models.Blueprint{}
+	err := db.First(blueprint, dal.Where("id = ?", pipeline.BlueprintId))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error loading the blueprint of pipeline %d", pipeline.ID))
+	}
+	if blueprint.ProjectName == "" {
+		return basicRes, nil
+	}
+	project := &models.Project{}
+	err = db.First(project, dal.Where("name = ?", blueprint.ProjectName))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error loading project %s", blueprint.ProjectName))
+	}
+	if project.DataSchema == "" {
+		return basicRes, nil
+	}
+	redirector, ok := db.(dal.TableSchemaRedirector)
+	if !ok {
+		return nil, errors.Default.New(fmt.Sprintf("the domain data of project %s can't be isolated by the dal", project.Name))
+	}
+	basicRes.GetLogger().Info("writing the domain data of project %s to schema %s", project.Name, project.DataSchema)
+	return contextimpl.NewDefaultBasicRes(
+		basicRes.GetConfigReader(),
+		basicRes.GetLogger(),
+		redirector.WithTableSchema(project.DataSchema, domaininfo.GetDomainTableNames()),
+	), nil
+}
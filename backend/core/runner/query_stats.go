@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+)
+
+const defaultSlowQueryMaxStats = 1000
+
+// GetQueryStatsSettings tells if the durations of the queries are tracked, unless SLOW_QUERY_TRACKING is false, and
+// how many distinct queries are kept by SLOW_QUERY_MAX_STATS
+func GetQueryStatsSettings(configReader config.ConfigReader) (bool, int) {
+	if configReader.IsSet("SLOW_QUERY_TRACKING") && !configReader.GetBool("SLOW_QUERY_TRACKING") {
+		return false, 0
+	}
+	maxStats := configReader.GetInt("SLOW_QUERY_MAX_STATS")
+	if maxStats <= 0 {
+		maxStats = defaultSlowQueryMaxStats
+	}
+	return true, maxStats
+}
+
+// queryStatsTables accepts the tables whose queries are tracked, i.e. the domain and the tool tables where the
+// indexes fitting the workload make a difference
+func queryStatsTables() func(table string) bool {
+	domainTables := make(map[string]bool)
+	for _, table := range domaininfo.GetDomainTableNames() {
+		domainTables[table] = true
+	}
+	return func(table string) bool {
+		return strings.HasPrefix(table, "_tool_") || domainTables[table]
+	}
+}
@@ -27,9 +27,11 @@ import (
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/core/version"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"sync"
 	"time"
 )
 
@@ -73,6 +75,7 @@ func RunTask(
 		finishedAt := time.Now()
 		spentSeconds := finishedAt.Unix() - beganAt.Unix()
 		if err != nil {
+			observeTaskDuration(task.Plugin, models.TASK_FAILED, finishedAt.Sub(beganAt))
 			lakeErr := errors.AsLakeErrorType(err)
 			subTaskName := "unknown"
 			if lakeErr = lakeErr.As(errors.SubtaskErr); lakeErr != nil {
@@ -94,6 +97,7 @@ func RunTask(
 				logger.Error(dbe, "failed to finalize task status into db (task failed)")
 			}
 		} else {
+			observeTaskDuration(task.Plugin, models.TASK_COMPLETED, finishedAt.Sub(beganAt))
 			dbe := db.UpdateColumns(task, []dal.DalSet{
 				{ColumnName: "status", Value: models.TASK_COMPLETED},
 				{ColumnName: "message", Value: ""},
@@ -230,7 +234,30 @@ func RunPluginSubTasks(
 		}
 	}
 
+	dbPipeline := &models.Pipeline{}
+	if task.PipelineId != 0 {
+		err = basicRes.GetDal().First(dbPipeline, dal.Where("id = ?", task.PipelineId))
+		if err != nil {
+			return err
+		}
+		basicRes, err = isolateProjectData(basicRes, dbPipeline)
+		if err != nil {
+			return err
+		}
+	}
+
 	taskCtx := contextimpl.NewDefaultTaskContext(ctx, basicRes, task.Plugin, subtasksFlag, progress)
+	// the plugins are released along with the framework, they share its version
+	taskCtx.(*contextimpl.DefaultTaskContext).SetLineage(&plugin.TaskLineage{
+		PipelineId:    task.PipelineId,
+		TaskId:        task.ID,
+		Plugin:        task.Plugin,
+		PluginVersion: version.Version,
+	})
+	if dbPipeline.Backfill != nil {
+		logger.Info("backfilling the window %s", dbPipeline.Backfill)
+	}
+	taskCtx.(*contextimpl.DefaultTaskContext).SetBackfill(dbPipeline.Backfill)
 	if closeablePlugin, ok := pluginTask.(plugin.CloseablePluginTask); ok {
 		defer closeablePlugin.Close(taskCtx)
 	}
@@ -244,10 +271,11 @@ func RunPluginSubTasks(
 	}
 	taskCtx.SetData(taskData)
 
-	// execute subtasks in order
-	taskCtx.SetProgress(0, steps)
-	subtaskNumber := 0
-	for _, subtaskMeta := range subtaskMetas {
+	// collect enabled subtasks in order
+	var enabledMetas []*plugin.SubTaskMeta
+	var subtaskCtxs []plugin.SubTaskContext
+	for i := range subtaskMetas {
+		subtaskMeta := &subtaskMetas[i]
 		subtaskCtx, err := taskCtx.SubTaskContext(subtaskMeta.Name)
 		if err != nil {
 			// sth went wrong
@@ -257,27 +285,128 @@ func RunPluginSubTasks(
 			// subtask was disabled
 			continue
 		}
+		enabledMetas = append(enabledMetas, subtaskMeta)
+		subtaskCtxs = append(subtaskCtxs, subtaskCtx)
+	}
 
-		// run subtask
+	taskCtx.SetProgress(0, steps)
+	var numberMu sync.Mutex
+	subtaskNumber := 0
+	runOne := func(i int) errors.Error {
+		subtaskMeta := enabledMetas[i]
 		logger.Info("executing subtask %s", subtaskMeta.Name)
+		numberMu.Lock()
 		subtaskNumber++
+		number := subtaskNumber
+		numberMu.Unlock()
 		if progress != nil {
 			progress <- plugin.RunningProgress{
 				Type:          plugin.SetCurrentSubTask,
 				SubTaskName:   subtaskMeta.Name,
-				SubTaskNumber: subtaskNumber,
+				SubTaskNumber: number,
 			}
 		}
-		err = runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, subtaskMeta.EntryPoint)
+		err := runSubtask(basicRes, subtaskCtxs[i], task.ID, number, subtaskMeta.EntryPoint)
 		if err != nil {
-			err = errors.SubtaskErr.Wrap(err, fmt.Sprintf("subtask %s ended unexpectedly", subtaskMeta.Name), errors.WithData(&subtaskMeta))
+			err = errors.SubtaskErr.Wrap(err, fmt.Sprintf("subtask %s ended unexpectedly", subtaskMeta.Name), errors.WithData(subtaskMeta))
 			logger.Error(err, "")
 			return err
 		}
 		taskCtx.IncProgress(1)
+		return nil
+	}
+
+	// execute independent groups of subtasks concurrently if the plugin supports it
+	if concurrentPlugin, ok := pluginTask.(plugin.ConcurrentPluginTask); ok {
+		parallelism := concurrentPlugin.SubTaskParallelism(taskCtx, options)
+		if parallelism > 1 {
+			groups := groupSubtasks(enabledMetas, concurrentPlugin.SubTaskGroup)
+			logger.Info("executing %d groups of subtasks with parallelism %d", len(groups), parallelism)
+			err = runSubtaskGroups(groups, parallelism, runOne)
+			if err != nil {
+				return err
+			}
+			return taskCtx.(*contextimpl.DefaultTaskContext).DropRawData()
+		}
+	}
+
+	// execute subtasks in order
+	for i := range enabledMetas {
+		if err := runOne(i); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	// the raw data a retention doesn't keep is dropped once every subtask reading it ran
+	return taskCtx.(*contextimpl.DefaultTaskContext).DropRawData()
+}
+
+// groupSubtasks partitions the subtasks by their group name, the order of the groups and of the subtasks within each
+// group is the order in which they were declared. Returned groups contain indexes into metas.
+func groupSubtasks(metas []*plugin.SubTaskMeta, groupOf func(*plugin.SubTaskMeta) string) [][]int {
+	var groups [][]int
+	groupIndex := make(map[string]int)
+	for i, meta := range metas {
+		name := groupOf(meta)
+		idx, ok := groupIndex[name]
+		if !ok {
+			idx = len(groups)
+			groupIndex[name] = idx
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], i)
+	}
+	return groups
+}
+
+// runSubtaskGroups runs at most parallelism groups at the same time, a group stops at its first failing subtask and
+// no new group is started once any of them failed. The first error encountered is returned.
+func runSubtaskGroups(groups [][]int, parallelism int, runOne func(int) errors.Error) errors.Error {
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	var firstErr errors.Error
+	failed := func() bool {
+		errMu.Lock()
+		defer errMu.Unlock()
+		return firstErr != nil
+	}
+	setErr := func(err errors.Error) {
+		errMu.Lock()
+		defer errMu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	semaphore := make(chan struct{}, parallelism)
+	for _, group := range groups {
+		semaphore <- struct{}{}
+		if failed() {
+			<-semaphore
+			break
+		}
+		wg.Add(1)
+		go func(group []int) {
+			defer func() {
+				// the recovery in RunTask doesn't cover this goroutine
+				if r := recover(); r != nil {
+					setErr(errors.Default.New(fmt.Sprintf("run subtask failed with panic: %v (%s)", r, utils.GatherCallFrames(0))))
+				}
+				<-semaphore
+				wg.Done()
+			}()
+			for _, i := range group {
+				if failed() {
+					return
+				}
+				if err := runOne(i); err != nil {
+					setErr(err)
+					return
+				}
+			}
+		}(group)
+	}
+	wg.Wait()
+	return firstErr
 }
 
 // UpdateProgressDetail FIXME ...
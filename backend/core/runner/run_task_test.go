@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupSubtasks(t *testing.T) {
+	metas := []*plugin.SubTaskMeta{
+		{Name: "collectBuilds"},
+		{Name: "collectJobs"},
+		{Name: "collectPullRequests"},
+		{Name: "extractBuilds"},
+		{Name: "extractJobs"},
+		{Name: "extractPullRequests"},
+	}
+	groupOf := map[string]string{
+		"collectBuilds":       "builds",
+		"collectJobs":         "builds",
+		"collectPullRequests": "pullrequests",
+		"extractBuilds":       "builds",
+		"extractJobs":         "builds",
+		"extractPullRequests": "pullrequests",
+	}
+	groups := groupSubtasks(metas, func(meta *plugin.SubTaskMeta) string {
+		return groupOf[meta.Name]
+	})
+	// groups keep the order of their first subtask, and subtasks keep their order within a group
+	assert.Equal(t, [][]int{{0, 1, 3, 4}, {2, 5}}, groups)
+}
+
+func TestRunSubtaskGroupsKeepsOrderWithinGroup(t *testing.T) {
+	var mu sync.Mutex
+	var ran []int
+	groups := [][]int{{0, 2, 4}, {1, 3}}
+	err := runSubtaskGroups(groups, 2, func(i int) errors.Error {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, i)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, ran)
+	position := make(map[int]int)
+	for p, i := range ran {
+		position[i] = p
+	}
+	assert.Less(t, position[0], position[2])
+	assert.Less(t, position[2], position[4])
+	assert.Less(t, position[1], position[3])
+}
+
+func TestRunSubtaskGroupsRespectsParallelism(t *testing.T) {
+	var running, maxRunning int32
+	groups := [][]int{{0}, {1}, {2}, {3}, {4}, {5}}
+	err := runSubtaskGroups(groups, 2, func(i int) errors.Error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, maxRunning, int32(2))
+}
+
+func TestRunSubtaskGroupsStopsAtFirstError(t *testing.T) {
+	var ran []int
+	groups := [][]int{{0, 1, 2}, {3}}
+	err := runSubtaskGroups(groups, 1, func(i int) errors.Error {
+		ran = append(ran, i)
+		if i == 1 {
+			return errors.Default.New("failed")
+		}
+		return nil
+	})
+	assert.NotNil(t, err)
+	// the rest of the failing group and the groups not started yet are skipped
+	assert.Equal(t, []int{0, 1}, ran)
+}
+
+func TestRunSubtaskGroupsRecoversPanic(t *testing.T) {
+	err := runSubtaskGroups([][]int{{0}}, 1, func(i int) errors.Error {
+		panic("boom")
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/generator/util"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+const remotePluginsDir = `python/plugins`
+
+func init() {
+	rootCmd.AddCommand(createRemotePluginCmd)
+}
+
+func remotePluginNameNotExistValidate() promptui.ValidateFunc {
+	return func(input string) error {
+		if input == `` {
+			return errors.Default.New("plugin name require")
+		}
+		// pydevlake derives the plugin name from the lowercased class name, so only alphanumeric names round-trip
+		nameReg := regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+		if !nameReg.MatchString(input) {
+			return errors.Default.New("plugin name invalid (start with a-z and consist with a-z0-9)")
+		}
+		for _, dir := range []string{remotePluginsDir, `plugins`} {
+			_, err := os.Stat(filepath.Join(dir, input))
+			if err == nil {
+				return errors.Default.New("plugin exists")
+			}
+			if !os.IsNotExist(err) {
+				return errors.Default.Wrap(err, "err getting plugin path")
+			}
+		}
+		return nil
+	}
+}
+
+var createRemotePluginCmd = &cobra.Command{
+	Use:   "create-remote-plugin [plugin_name]",
+	Short: "Create a new remote (python) plugin",
+	Long: `Create a new remote (python) plugin
+Type in what the name of plugin is, then generator will create a new pydevlake plugin in python/plugins/$plugin_name,
+with a sample stream and its unit tests, and an e2e test in test/e2e/remote/$plugin_name for you`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var pluginName string
+
+		// try to get plugin name
+		if len(args) > 0 {
+			pluginName = args[0]
+		}
+		err := remotePluginNameNotExistValidate()(pluginName)
+		if err != nil {
+			prompt := promptui.Prompt{
+				Label:    "plugin_name",
+				Validate: remotePluginNameNotExistValidate(),
+				Default:  pluginName,
+			}
+			pluginName, err = prompt.Run()
+			cobra.CheckErr(err)
+		}
+
+		values := map[string]string{}
+		util.GenerateAllFormatVar(values, `plugin_name`, pluginName)
+		templates := map[string]string{
+			`README.md`:      util.ReadTemplate("generator/template/remote-plugin/README.md-template"),
+			`pyproject.toml`: util.ReadTemplate("generator/template/remote-plugin/pyproject.toml-template"),
+			`run.sh`:         util.ReadTemplate("generator/template/remote-plugin/run.sh-template"),
+			`build.sh`:       util.ReadTemplate("generator/template/remote-plugin/build.sh-template"),
+			fmt.Sprintf(`%s/__init__.py`, pluginName):       util.ReadTemplate("generator/template/remote-plugin/plugin/__init__.py-template"),
+			fmt.Sprintf(`%s/main.py`, pluginName):           util.ReadTemplate("generator/template/remote-plugin/plugin/main.py-template"),
+			fmt.Sprintf(`%s/models.py`, pluginName):         util.ReadTemplate("generator/template/remote-plugin/plugin/models.py-template"),
+			fmt.Sprintf(`%s/api.py`, pluginName):            util.ReadTemplate("generator/template/remote-plugin/plugin/api.py-template"),
+			fmt.Sprintf(`%s/streams/builds.py`, pluginName): util.ReadTemplate("generator/template/remote-plugin/plugin/streams/builds.py-template"),
+			`tests/__init__.py`:                             util.ReadTemplate("generator/template/remote-plugin/tests/__init__.py-template"),
+			`tests/plugin_test.py`:                          util.ReadTemplate("generator/template/remote-plugin/tests/plugin_test.py-template"),
+			`tests/streams_test.py`:                         util.ReadTemplate("generator/template/remote-plugin/tests/streams_test.py-template"),
+		}
+		e2eTemplates := map[string]string{
+			fmt.Sprintf(`%s_test.go`, pluginName): util.ReadTemplate("generator/template/remote-plugin/e2e/plugin_test.go-template"),
+		}
+
+		e2eValues := util.DetectExistVars(e2eTemplates, values)
+		values = util.DetectExistVars(templates, values)
+		println(`vars in template:`, fmt.Sprint(values))
+
+		// write template
+		pluginPath := filepath.Join(remotePluginsDir, pluginName)
+		util.ReplaceVarInTemplates(templates, values)
+		util.WriteTemplates(pluginPath, templates)
+		util.ReplaceVarInTemplates(e2eTemplates, e2eValues)
+		util.WriteTemplates(filepath.Join(`test/e2e/remote`, pluginName), e2eTemplates)
+
+		// the server loads remote plugins by executing their run.sh
+		for _, script := range []string{`run.sh`, `build.sh`} {
+			cobra.CheckErr(os.Chmod(filepath.Join(pluginPath, script), 0755))
+		}
+	},
+}
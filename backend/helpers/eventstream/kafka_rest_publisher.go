@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type kafkaRestPublisher struct {
+	baseUrl  string
+	user     string
+	password string
+	client   *http.Client
+}
+
+type kafkaRestRecord struct {
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaRestResponse struct {
+	Offsets []struct {
+		Partition *int   `json:"partition"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// NewKafkaRestPublisher produces the messages to Kafka by the v2 api of its REST proxy, in the embedded json format
+func NewKafkaRestPublisher(u *url.URL) Publisher {
+	p := &kafkaRestPublisher{
+		baseUrl: strings.TrimSuffix(fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path), "/"),
+		client:  &http.Client{Timeout: time.Minute},
+	}
+	if u.User != nil {
+		p.user = u.User.Username()
+		p.password, _ = u.User.Password()
+	}
+	return p
+}
+
+func (p *kafkaRestPublisher) Publish(ctx context.Context, topic string, messages []Message) errors.Error {
+	records := make([]kafkaRestRecord, len(messages))
+	for i, message := range messages {
+		records[i] = kafkaRestRecord{Key: message.Key, Value: message.Value}
+	}
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return errors.Default.Wrap(err, "error encoding the records")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseUrl+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return errors.Convert(err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if p.user != "" {
+		req.SetBasicAuth(p.user, p.password)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error producing to %s", topic))
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error reading the response of %s", topic))
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("error producing to %s: %s", topic, string(resBody)))
+	}
+	// the records are acknowledged one by one, the failed ones carry an error code
+	response := &kafkaRestResponse{}
+	err = json.Unmarshal(resBody, response)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error decoding the response of %s", topic))
+	}
+	for _, offset := range response.Offsets {
+		if offset.ErrorCode != nil {
+			return errors.Default.New(fmt.Sprintf("error producing to %s: %s (%d)", topic, offset.Error, *offset.ErrorCode))
+		}
+	}
+	return nil
+}
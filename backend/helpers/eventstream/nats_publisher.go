@@ -0,0 +1,166 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const natsDefaultPort = "4222"
+
+type natsPublisher struct {
+	address  string
+	host     string
+	user     string
+	password string
+	token    string
+	tls      bool
+}
+
+type natsServerInfo struct {
+	TlsRequired bool  `json:"tls_required"`
+	MaxPayload  int64 `json:"max_payload"`
+}
+
+type natsConnectOptions struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+// NewNatsPublisher publishes the messages to the subjects of a NATS server by its core protocol, the user of the url
+// without a password is taken as the token
+func NewNatsPublisher(u *url.URL) Publisher {
+	p := &natsPublisher{
+		address: u.Host,
+		host:    u.Hostname(),
+		tls:     u.Scheme == "tls",
+	}
+	if u.Port() == "" {
+		p.address = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			p.user = u.User.Username()
+			p.password = password
+		} else {
+			p.token = u.User.Username()
+		}
+	}
+	return p
+}
+
+// Publish sends the messages over a new connection and waits for the server to answer a PING, the server answers
+// only once the messages before it were processed
+func (p *natsPublisher) Publish(ctx context.Context, subject string, messages []Message) errors.Error {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error connecting to %s", p.address))
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(time.Minute)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return errors.Default.Wrap(err, "error reading the INFO of the server")
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return errors.Default.New(fmt.Sprintf("unexpected greeting from the server: %s", strings.TrimSpace(line)))
+	}
+	info := &natsServerInfo{}
+	err = json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "INFO ")), info)
+	if err != nil {
+		return errors.Default.Wrap(err, "error decoding the INFO of the server")
+	}
+	// the server greets in plain text and expects the handshake right after
+	if p.tls || info.TlsRequired {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: p.host, MinVersion: tls.VersionTLS12})
+		err = tlsConn.Handshake()
+		if err != nil {
+			return errors.Default.Wrap(err, "error negotiating tls with the server")
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	connect, err := json.Marshal(&natsConnectOptions{
+		Name:      "devlake",
+		Lang:      "go",
+		Version:   "1",
+		User:      p.user,
+		Pass:      p.password,
+		AuthToken: p.token,
+	})
+	if err != nil {
+		return errors.Convert(err)
+	}
+	writer := bufio.NewWriter(conn)
+	_, _ = fmt.Fprintf(writer, "CONNECT %s\r\n", connect)
+	for _, message := range messages {
+		if info.MaxPayload > 0 && int64(len(message.Value)) > info.MaxPayload {
+			return errors.BadInput.New(fmt.Sprintf("the message of %d bytes exceeds the max payload of the server", len(message.Value)))
+		}
+		_, _ = fmt.Fprintf(writer, "PUB %s %d\r\n", subject, len(message.Value))
+		_, _ = writer.Write(message.Value)
+		_, _ = writer.WriteString("\r\n")
+	}
+	_, _ = writer.WriteString("PING\r\n")
+	err = writer.Flush()
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error publishing to %s", subject))
+	}
+	for {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error waiting for the server to process the messages of %s", subject))
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			_, err = conn.Write([]byte("PONG\r\n"))
+			if err != nil {
+				return errors.Convert(err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.Default.New(fmt.Sprintf("error publishing to %s: %s", subject, strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))))
+		}
+	}
+}
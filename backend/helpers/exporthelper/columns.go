@@ -0,0 +1,182 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// ColumnType is the type of the values of an exported column
+type ColumnType int
+
+const (
+	STRING ColumnType = iota
+	INT
+	FLOAT
+	BOOL
+	TIME
+)
+
+// Column is a column of the exported rows
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// RowWriter writes the exported rows in a file format, the values of a row are in the order of the columns
+type RowWriter interface {
+	Write(row []interface{}) errors.Error
+	// Close flushes the rows left and completes the file, the underlying writer is left open
+	Close() errors.Error
+}
+
+// NewRowWriter returns the writer of the format, csv or parquet
+func NewRowWriter(format string, w io.Writer, columns []Column) (RowWriter, errors.Error) {
+	switch format {
+	case "csv":
+		return NewCsvWriter(w, columns)
+	case "parquet":
+		return NewParquetWriter(w, columns)
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unsupported export format %s, csv or parquet expected", format))
+}
+
+// ContentType returns the mime type of the format
+func ContentType(format string) string {
+	if format == "parquet" {
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv"
+}
+
+// ColumnsOf maps the columns of a query result to the exported columns by their database types
+func ColumnsOf(columnTypes []*sql.ColumnType) []Column {
+	columns := make([]Column, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = Column{Name: columnType.Name(), Type: typeOf(columnType.DatabaseTypeName())}
+	}
+	return columns
+}
+
+func typeOf(databaseType string) ColumnType {
+	databaseType = strings.ToUpper(databaseType)
+	switch {
+	case strings.Contains(databaseType, "BOOL"):
+		return BOOL
+	case strings.Contains(databaseType, "INT"), databaseType == "SERIAL":
+		return INT
+	case strings.Contains(databaseType, "FLOAT"), strings.Contains(databaseType, "DOUBLE"),
+		strings.Contains(databaseType, "REAL"), strings.Contains(databaseType, "DECIMAL"),
+		strings.Contains(databaseType, "NUMERIC"):
+		return FLOAT
+	case strings.Contains(databaseType, "DATE"), strings.Contains(databaseType, "TIME"):
+		return TIME
+	}
+	return STRING
+}
+
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02 15:04:05", "2006-01-02"}
+
+// Normalize converts a value scanned by the database driver to the go type of the column, nil stays nil
+func (c Column) Normalize(value interface{}) (interface{}, errors.Error) {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	var err error
+	switch c.Type {
+	case STRING:
+		if t, ok := value.(time.Time); ok {
+			return t.Format(time.RFC3339Nano), nil
+		}
+		return fmt.Sprint(value), nil
+	case INT:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			var i int64
+			i, err = strconv.ParseInt(v, 10, 64)
+			if err == nil {
+				return i, nil
+			}
+		default:
+			var i int64
+			i, err = strconv.ParseInt(fmt.Sprint(v), 10, 64)
+			if err == nil {
+				return i, nil
+			}
+		}
+	case FLOAT:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		default:
+			var f float64
+			f, err = strconv.ParseFloat(fmt.Sprint(v), 64)
+			if err == nil {
+				return f, nil
+			}
+		}
+	case BOOL:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		default:
+			var b bool
+			b, err = strconv.ParseBool(fmt.Sprint(v))
+			if err == nil {
+				return b, nil
+			}
+		}
+	case TIME:
+		switch v := value.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			for _, layout := range timeLayouts {
+				var t time.Time
+				t, err = time.Parse(layout, v)
+				if err == nil {
+					return t, nil
+				}
+			}
+		default:
+			err = fmt.Errorf("unexpected time %v", v)
+		}
+	}
+	return nil, errors.Default.Wrap(errors.Convert(err), fmt.Sprintf("invalid value of the column %s", c.Name))
+}
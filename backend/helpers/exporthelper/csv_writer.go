@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []Column
+	record  []string
+}
+
+// NewCsvWriter writes the rows as csv, along with a header of the column names, the nulls are empty fields
+func NewCsvWriter(w io.Writer, columns []Column) (RowWriter, errors.Error) {
+	writer := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	err := writer.Write(header)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	return &csvWriter{writer: writer, columns: columns, record: make([]string, len(columns))}, nil
+}
+
+func (w *csvWriter) Write(row []interface{}) errors.Error {
+	for i, column := range w.columns {
+		value, err := column.Normalize(row[i])
+		if err != nil {
+			return err
+		}
+		switch v := value.(type) {
+		case nil:
+			w.record[i] = ""
+		case string:
+			w.record[i] = v
+		case int64:
+			w.record[i] = strconv.FormatInt(v, 10)
+		case float64:
+			w.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			w.record[i] = strconv.FormatBool(v)
+		case time.Time:
+			w.record[i] = v.Format(time.RFC3339)
+		}
+	}
+	return errors.Convert(w.writer.Write(w.record))
+}
+
+func (w *csvWriter) Close() errors.Error {
+	w.writer.Flush()
+	return errors.Convert(w.writer.Error())
+}
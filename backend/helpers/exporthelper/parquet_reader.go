@@ -0,0 +1,244 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// ReadParquet decodes the columns and the rows of a parquet file written by NewParquetWriter, the values are of the
+// go types returned by Column.Normalize. Only the flat schemas of uncompressed and plain encoded data pages are
+// supported
+func ReadParquet(file []byte) ([]Column, [][]interface{}, errors.Error) {
+	if len(file) < 12 || !bytes.Equal(file[:4], parquetMagic) || !bytes.Equal(file[len(file)-4:], parquetMagic) {
+		return nil, nil, errors.BadInput.New("not a parquet file")
+	}
+	footerLength := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	if footerLength > len(file)-12 {
+		return nil, nil, errors.BadInput.New("invalid parquet footer length")
+	}
+	footer, err := newCompactReader(file[len(file)-8-footerLength : len(file)-8]).readStruct()
+	if err != nil {
+		return nil, nil, errors.BadInput.Wrap(errors.Convert(err), "invalid parquet footer")
+	}
+	columns, e := parquetColumnsOf(fieldList(footer, 2))
+	if e != nil {
+		return nil, nil, e
+	}
+	rows := make([][]interface{}, 0, fieldInt(footer, 3))
+	for _, element := range fieldList(footer, 4) {
+		rowGroup, _ := element.(map[int16]interface{})
+		chunks := fieldList(rowGroup, 1)
+		if len(chunks) != len(columns) {
+			return nil, nil, errors.BadInput.New(fmt.Sprintf("%d column chunks for %d columns", len(chunks), len(columns)))
+		}
+		groupRows := make([][]interface{}, fieldInt(rowGroup, 3))
+		for i := range groupRows {
+			groupRows[i] = make([]interface{}, len(columns))
+		}
+		for j, chunk := range chunks {
+			meta := fieldStruct(chunk.(map[int16]interface{}), 3)
+			values, e := readParquetChunk(file, meta, columns[j], len(groupRows))
+			if e != nil {
+				return nil, nil, errors.BadInput.Wrap(e, fmt.Sprintf("invalid column %s", columns[j].Name))
+			}
+			for i, value := range values {
+				groupRows[i][j] = value
+			}
+		}
+		rows = append(rows, groupRows...)
+	}
+	return columns, rows, nil
+}
+
+func parquetColumnsOf(schema []interface{}) ([]Column, errors.Error) {
+	if len(schema) == 0 {
+		return nil, errors.BadInput.New("empty parquet schema")
+	}
+	// the first element is the root of the others
+	columns := make([]Column, 0, len(schema)-1)
+	for _, element := range schema[1:] {
+		fields, _ := element.(map[int16]interface{})
+		column := Column{Name: string(fieldBinary(fields, 4))}
+		if _, ok := fields[5]; ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("nested column %s is not supported", column.Name))
+		}
+		_, converted := fields[6]
+		switch fieldInt(fields, 1) {
+		case parquetBoolean:
+			column.Type = BOOL
+		case parquetInt64:
+			column.Type = INT
+			if converted && fieldInt(fields, 6) == parquetConvertedTimestampMillis {
+				column.Type = TIME
+			}
+		case parquetDouble:
+			column.Type = FLOAT
+		case parquetByteArray:
+			column.Type = STRING
+		default:
+			return nil, errors.BadInput.New(fmt.Sprintf("unsupported type of the column %s", column.Name))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func readParquetChunk(file []byte, meta map[int16]interface{}, column Column, numRows int) ([]interface{}, errors.Error) {
+	if fieldInt(meta, 4) != parquetUncompressed {
+		return nil, errors.BadInput.New("compressed pages are not supported")
+	}
+	offset := int(fieldInt(meta, 9))
+	values := make([]interface{}, 0, numRows)
+	for len(values) < numRows {
+		if offset < 4 || offset >= len(file) {
+			return nil, errors.BadInput.New("invalid page offset")
+		}
+		reader := newCompactReader(file[offset:])
+		header, err := reader.readStruct()
+		if err != nil {
+			return nil, errors.BadInput.Wrap(errors.Convert(err), "invalid page header")
+		}
+		pageStart := offset + reader.pos
+		pageEnd := pageStart + int(fieldInt(header, 3))
+		if pageEnd > len(file) || pageEnd < pageStart {
+			return nil, errors.BadInput.New("truncated page")
+		}
+		dataPage := fieldStruct(header, 5)
+		if fieldInt(header, 1) != parquetDataPage || fieldInt(dataPage, 2) != parquetEncodingPlain {
+			return nil, errors.BadInput.New("only the plain encoded data pages are supported")
+		}
+		pageValues, err := decodeParquetPage(file[pageStart:pageEnd], column, int(fieldInt(dataPage, 1)))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(errors.Convert(err), "invalid page")
+		}
+		values = append(values, pageValues...)
+		offset = pageEnd
+	}
+	return values, nil
+}
+
+// decodeParquetPage decodes the definition levels of an optional column followed by the values which are not null
+func decodeParquetPage(page []byte, column Column, numValues int) ([]interface{}, error) {
+	if len(page) < 4 || 4+int(binary.LittleEndian.Uint32(page)) > len(page) {
+		return nil, errCompactTruncated
+	}
+	levelsLength := int(binary.LittleEndian.Uint32(page))
+	defined, err := decodeParquetLevels(page[4:4+levelsLength], numValues)
+	if err != nil {
+		return nil, err
+	}
+	page = page[4+levelsLength:]
+	values := make([]interface{}, numValues)
+	pos, bit := 0, 0
+	for i := range values {
+		if !defined[i] {
+			continue
+		}
+		switch column.Type {
+		case BOOL:
+			if bit/8 >= len(page) {
+				return nil, errCompactTruncated
+			}
+			values[i] = page[bit/8]&(1<<(bit%8)) != 0
+			bit++
+		case INT, FLOAT, TIME:
+			if pos+8 > len(page) {
+				return nil, errCompactTruncated
+			}
+			v := binary.LittleEndian.Uint64(page[pos:])
+			pos += 8
+			switch column.Type {
+			case INT:
+				values[i] = int64(v)
+			case FLOAT:
+				values[i] = math.Float64frombits(v)
+			default:
+				values[i] = time.UnixMilli(int64(v)).UTC()
+			}
+		default:
+			if pos+4 > len(page) || pos+4+int(binary.LittleEndian.Uint32(page[pos:])) > len(page) {
+				return nil, errCompactTruncated
+			}
+			n := int(binary.LittleEndian.Uint32(page[pos:]))
+			values[i] = string(page[pos+4 : pos+4+n])
+			pos += 4 + n
+		}
+	}
+	return values, nil
+}
+
+// decodeParquetLevels decodes the definition levels of bit width 1 in the rle/bit packed hybrid encoding
+func decodeParquetLevels(levels []byte, numValues int) ([]bool, error) {
+	defined := make([]bool, 0, numValues)
+	reader := newCompactReader(levels)
+	for len(defined) < numValues {
+		header, err := reader.varint()
+		if err != nil {
+			return nil, err
+		}
+		if header&1 == 0 {
+			// a run of the same level
+			level, err := reader.readByte()
+			if err != nil {
+				return nil, err
+			}
+			for n := header >> 1; n > 0 && len(defined) < numValues; n-- {
+				defined = append(defined, level == 1)
+			}
+			continue
+		}
+		// groups of 8 bit packed levels
+		for n := header >> 1; n > 0; n-- {
+			packed, err := reader.readByte()
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < 8 && len(defined) < numValues; i++ {
+				defined = append(defined, packed&(1<<i) != 0)
+			}
+		}
+	}
+	return defined, nil
+}
+
+func fieldInt(fields map[int16]interface{}, id int16) int64 {
+	v, _ := fields[id].(int64)
+	return v
+}
+
+func fieldBinary(fields map[int16]interface{}, id int16) []byte {
+	v, _ := fields[id].([]byte)
+	return v
+}
+
+func fieldStruct(fields map[int16]interface{}, id int16) map[int16]interface{} {
+	v, _ := fields[id].(map[int16]interface{})
+	return v
+}
+
+func fieldList(fields map[int16]interface{}, id int16) []interface{} {
+	v, _ := fields[id].([]interface{})
+	return v
+}
@@ -0,0 +1,247 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// the rows of a row group are held in memory until it is written
+const parquetRowGroupSize = 10000
+
+var parquetMagic = []byte("PAR1")
+
+// the enums of the parquet format, see https://github.com/apache/parquet-format/blob/master/src/main/thrift/parquet.thrift
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetConvertedUtf8            = 0
+	parquetConvertedTimestampMillis = 9
+
+	parquetOptional      = 1
+	parquetEncodingPlain = 0
+	parquetEncodingRle   = 3
+	parquetDataPage      = 0
+	parquetUncompressed  = 0
+)
+
+type parquetWriter struct {
+	w         io.Writer
+	offset    int64
+	columns   []Column
+	values    [][]interface{}
+	rows      int64
+	rowGroups []*compactWriter
+}
+
+// NewParquetWriter writes the rows as an uncompressed parquet file, every column is optional and plain encoded, the
+// rows are split into row groups so only one of them is held in memory
+func NewParquetWriter(w io.Writer, columns []Column) (RowWriter, errors.Error) {
+	writer := &parquetWriter{w: w, columns: columns, values: make([][]interface{}, len(columns))}
+	return writer, writer.write(parquetMagic)
+}
+
+func (w *parquetWriter) write(b []byte) errors.Error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return errors.Convert(err)
+}
+
+func (w *parquetWriter) Write(row []interface{}) errors.Error {
+	if len(w.columns) == 0 {
+		return nil
+	}
+	for i, column := range w.columns {
+		value, err := column.Normalize(row[i])
+		if err != nil {
+			return err
+		}
+		w.values[i] = append(w.values[i], value)
+	}
+	if len(w.values[0]) >= parquetRowGroupSize {
+		return w.flushRowGroup()
+	}
+	return nil
+}
+
+func (w *parquetWriter) flushRowGroup() errors.Error {
+	if len(w.columns) == 0 || len(w.values[0]) == 0 {
+		return nil
+	}
+	numRows := int64(len(w.values[0]))
+	rowGroup := newCompactWriter()
+	rowGroup.listBegin(1, compactStruct, len(w.columns))
+	var totalSize int64
+	for i, column := range w.columns {
+		chunkOffset := w.offset
+		page := w.encodePage(column, w.values[i])
+		header := newCompactWriter()
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.structBegin(5)
+		header.i32(1, int32(numRows))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRle)
+		header.i32(4, parquetEncodingRle)
+		header.structEnd()
+		header.structEnd()
+		if err := w.write(header.bytes()); err != nil {
+			return err
+		}
+		if err := w.write(page); err != nil {
+			return err
+		}
+		chunkSize := int64(header.buf.Len() + len(page))
+		totalSize += chunkSize
+		// ColumnChunk
+		rowGroup.elementBegin()
+		rowGroup.i64(2, chunkOffset)
+		rowGroup.structBegin(3)
+		rowGroup.i32(1, column.parquetType())
+		rowGroup.listBegin(2, compactI32, 2)
+		rowGroup.varint(zigzag(parquetEncodingPlain))
+		rowGroup.varint(zigzag(parquetEncodingRle))
+		rowGroup.listBegin(3, compactBinary, 1)
+		rowGroup.rawBinary([]byte(column.Name))
+		rowGroup.i32(4, parquetUncompressed)
+		rowGroup.i64(5, numRows)
+		rowGroup.i64(6, chunkSize)
+		rowGroup.i64(7, chunkSize)
+		rowGroup.i64(9, chunkOffset)
+		rowGroup.structEnd()
+		rowGroup.structEnd()
+		w.values[i] = w.values[i][:0]
+	}
+	rowGroup.i64(2, totalSize)
+	rowGroup.i64(3, numRows)
+	rowGroup.structEnd()
+	w.rowGroups = append(w.rowGroups, rowGroup)
+	w.rows += numRows
+	return nil
+}
+
+// encodePage encodes the definition levels of the values, bit packed, followed by the values which are not null
+func (w *parquetWriter) encodePage(column Column, values []interface{}) []byte {
+	var page bytes.Buffer
+	levels := newCompactWriter()
+	groups := (len(values) + 7) / 8
+	levels.varint(uint64(groups<<1 | 1))
+	packed := make([]byte, groups)
+	for i, value := range values {
+		if value != nil {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	levels.buf.Write(packed)
+	_ = binary.Write(&page, binary.LittleEndian, uint32(levels.buf.Len()))
+	page.Write(levels.buf.Bytes())
+
+	var bits []byte
+	count := 0
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			_ = binary.Write(&page, binary.LittleEndian, uint32(len(v)))
+			page.WriteString(v)
+		case int64:
+			_ = binary.Write(&page, binary.LittleEndian, v)
+		case float64:
+			_ = binary.Write(&page, binary.LittleEndian, math.Float64bits(v))
+		case time.Time:
+			_ = binary.Write(&page, binary.LittleEndian, v.UnixMilli())
+		case bool:
+			if count%8 == 0 {
+				bits = append(bits, 0)
+			}
+			if v {
+				bits[count/8] |= 1 << (count % 8)
+			}
+			count++
+		}
+	}
+	page.Write(bits)
+	return page.Bytes()
+}
+
+func (c Column) parquetType() int32 {
+	switch c.Type {
+	case INT, TIME:
+		return parquetInt64
+	case FLOAT:
+		return parquetDouble
+	case BOOL:
+		return parquetBoolean
+	}
+	return parquetByteArray
+}
+
+// Close writes the row group left and the footer, which holds the schema and the locations of the row groups
+func (w *parquetWriter) Close() errors.Error {
+	err := w.flushRowGroup()
+	if err != nil {
+		return err
+	}
+	footer := newCompactWriter()
+	footer.i32(1, 1)
+	footer.listBegin(2, compactStruct, len(w.columns)+1)
+	footer.elementBegin()
+	footer.binary(4, []byte("schema"))
+	footer.i32(5, int32(len(w.columns)))
+	footer.structEnd()
+	for _, column := range w.columns {
+		footer.elementBegin()
+		footer.i32(1, column.parquetType())
+		footer.i32(3, parquetOptional)
+		footer.binary(4, []byte(column.Name))
+		switch column.Type {
+		case STRING:
+			footer.i32(6, parquetConvertedUtf8)
+		case TIME:
+			footer.i32(6, parquetConvertedTimestampMillis)
+		}
+		footer.structEnd()
+	}
+	footer.i64(3, w.rows)
+	footer.listBegin(4, compactStruct, len(w.rowGroups))
+	for _, rowGroup := range w.rowGroups {
+		footer.buf.Write(rowGroup.bytes())
+	}
+	footer.binary(6, []byte("devlake"))
+	footer.structEnd()
+	err = w.write(footer.bytes())
+	if err != nil {
+		return err
+	}
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(footer.buf.Len()))
+	err = w.write(length)
+	if err != nil {
+		return err
+	}
+	return w.write(parquetMagic)
+}
@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// the types of the thrift compact protocol the parquet metadata is encoded in
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// compactWriter encodes a thrift struct with the compact protocol, the fields must be written in the order of their ids
+type compactWriter struct {
+	buf bytes.Buffer
+	// the id of the last field written of each struct being written
+	lastIds []int16
+}
+
+func newCompactWriter() *compactWriter {
+	return &compactWriter{lastIds: []int16{0}}
+}
+
+func zigzag(n int64) uint64 {
+	return uint64((n << 1) ^ (n >> 63))
+}
+
+func (w *compactWriter) varint(n uint64) {
+	b := make([]byte, binary.MaxVarintLen64)
+	w.buf.Write(b[:binary.PutUvarint(b, n)])
+}
+
+func (w *compactWriter) field(id int16, fieldType byte) {
+	last := &w.lastIds[len(w.lastIds)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		w.buf.WriteByte(fieldType)
+		w.varint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (w *compactWriter) i32(id int16, v int32) {
+	w.field(id, compactI32)
+	w.varint(zigzag(int64(v)))
+}
+
+func (w *compactWriter) i64(id int16, v int64) {
+	w.field(id, compactI64)
+	w.varint(zigzag(v))
+}
+
+func (w *compactWriter) binary(id int16, b []byte) {
+	w.field(id, compactBinary)
+	w.rawBinary(b)
+}
+
+func (w *compactWriter) rawBinary(b []byte) {
+	w.varint(uint64(len(b)))
+	w.buf.Write(b)
+}
+
+// listBegin starts a list field, the elements follow it
+func (w *compactWriter) listBegin(id int16, elementType byte, size int) {
+	w.field(id, compactList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elementType)
+	} else {
+		w.buf.WriteByte(0xf0 | elementType)
+		w.varint(uint64(size))
+	}
+}
+
+// structBegin starts a struct field, it is ended by structEnd
+func (w *compactWriter) structBegin(id int16) {
+	w.field(id, compactStruct)
+	w.elementBegin()
+}
+
+// elementBegin starts a struct element of a list, it is ended by structEnd
+func (w *compactWriter) elementBegin() {
+	w.lastIds = append(w.lastIds, 0)
+}
+
+func (w *compactWriter) structEnd() {
+	w.buf.WriteByte(0)
+	w.lastIds = w.lastIds[:len(w.lastIds)-1]
+}
+
+func (w *compactWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// compactReader decodes the thrift compact structs without their definitions, the structs are maps by field id of
+// int64, []byte, []interface{} or the nested structs
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+func newCompactReader(data []byte) *compactReader {
+	return &compactReader{data: data}
+}
+
+var errCompactTruncated = fmt.Errorf("truncated thrift struct")
+
+func (r *compactReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errCompactTruncated
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *compactReader) zigzag() (int64, error) {
+	v, err := r.varint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (r *compactReader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errCompactTruncated
+	}
+	r.pos++
+	return r.data[r.pos-1], nil
+}
+
+func (r *compactReader) readValue(valueType byte) (interface{}, error) {
+	switch valueType {
+	case compactI32, compactI64:
+		return r.zigzag()
+	case compactBinary:
+		n, err := r.varint()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(r.data)-r.pos) {
+			return nil, errCompactTruncated
+		}
+		b := r.data[r.pos : r.pos+int(n)]
+		r.pos += int(n)
+		return b, nil
+	case compactList:
+		header, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			size, err = r.varint()
+			if err != nil {
+				return nil, err
+			}
+		}
+		// every element takes a byte at least
+		if size > uint64(len(r.data)-r.pos) {
+			return nil, errCompactTruncated
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i], err = r.readValue(header & 0x0f)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case compactStruct:
+		return r.readStruct()
+	}
+	return nil, fmt.Errorf("unsupported thrift type %d", valueType)
+}
+
+func (r *compactReader) readStruct() (map[int16]interface{}, error) {
+	fields := make(map[int16]interface{})
+	var id int16
+	for {
+		header, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if header == 0 {
+			return fields, nil
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			var v int64
+			v, err = r.zigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		fields[id], err = r.readValue(header & 0x0f)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporthelper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testColumns = []Column{
+	{Name: "id", Type: STRING},
+	{Name: "duration", Type: INT},
+	{Name: "ratio", Type: FLOAT},
+	{Name: "success", Type: BOOL},
+	{Name: "finished_date", Type: TIME},
+}
+
+func testRows() [][]interface{} {
+	finished := time.Date(2023, 7, 1, 8, 30, 0, 0, time.UTC)
+	return [][]interface{}{
+		{[]byte("github:GithubRun:1:1"), int64(60), 0.5, int64(1), finished},
+		{"github:GithubRun:1:2", nil, nil, false, []byte("2023-07-01 08:30:00")},
+	}
+}
+
+func TestCsvWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewRowWriter("csv", &buf, testColumns)
+	assert.Nil(t, err)
+	for _, row := range testRows() {
+		assert.Nil(t, writer.Write(row))
+	}
+	assert.Nil(t, writer.Close())
+	assert.Equal(t, "id,duration,ratio,success,finished_date\n"+
+		"github:GithubRun:1:1,60,0.5,true,2023-07-01T08:30:00Z\n"+
+		"github:GithubRun:1:2,,,false,2023-07-01T08:30:00Z\n", buf.String())
+
+	_, err = NewRowWriter("xlsx", &buf, testColumns)
+	assert.NotNil(t, err)
+	writer, _ = NewRowWriter("csv", &buf, testColumns)
+	assert.NotNil(t, writer.Write([]interface{}{"x", "not a number", nil, nil, nil}))
+}
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewRowWriter("parquet", &buf, testColumns)
+	assert.Nil(t, err)
+	for _, row := range testRows() {
+		assert.Nil(t, writer.Write(row))
+	}
+	assert.Nil(t, writer.Close())
+
+	file := buf.Bytes()
+	assert.Equal(t, parquetMagic, file[:4])
+	assert.Equal(t, parquetMagic, file[len(file)-4:])
+	footerLength := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer, e := newCompactReader(file[len(file)-8-footerLength : len(file)-8]).readStruct()
+	assert.Nil(t, e)
+
+	assert.Equal(t, int64(1), footer[1])
+	assert.Equal(t, int64(2), footer[3])
+	schema := footer[2].([]interface{})
+	assert.Len(t, schema, 6)
+	assert.Equal(t, "schema", string(schema[0].(map[int16]interface{})[4].([]byte)))
+	assert.Equal(t, int64(5), schema[0].(map[int16]interface{})[5])
+	assert.Equal(t, "finished_date", string(schema[5].(map[int16]interface{})[4].([]byte)))
+	assert.Equal(t, int64(parquetConvertedTimestampMillis), schema[5].(map[int16]interface{})[6])
+
+	rowGroups := footer[4].([]interface{})
+	assert.Len(t, rowGroups, 1)
+	chunks := rowGroups[0].(map[int16]interface{})[1].([]interface{})
+	assert.Len(t, chunks, 5)
+
+	// the page of the durations holds the definition levels 1, 0 followed by the only value 60
+	meta := chunks[1].(map[int16]interface{})[3].(map[int16]interface{})
+	assert.Equal(t, int64(parquetInt64), meta[1])
+	assert.Equal(t, int64(2), meta[5])
+	reader := newCompactReader(file[meta[9].(int64):])
+	header, e := reader.readStruct()
+	assert.Nil(t, e)
+	assert.Equal(t, int64(2), header[5].(map[int16]interface{})[1])
+	page := file[int(meta[9].(int64))+reader.pos : int(meta[9].(int64))+reader.pos+int(header[3].(int64))]
+	assert.Equal(t, []byte{2, 0, 0, 0, 3, 1}, page[:6])
+	assert.Equal(t, int64(60), int64(binary.LittleEndian.Uint64(page[6:])))
+
+	// the ratios are doubles
+	meta = chunks[2].(map[int16]interface{})[3].(map[int16]interface{})
+	reader = newCompactReader(file[meta[9].(int64):])
+	header, e = reader.readStruct()
+	assert.Nil(t, e)
+	page = file[int(meta[9].(int64))+reader.pos : int(meta[9].(int64))+reader.pos+int(header[3].(int64))]
+	assert.Equal(t, 0.5, math.Float64frombits(binary.LittleEndian.Uint64(page[6:])))
+}
+
+func TestReadParquet(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewParquetWriter(&buf, testColumns)
+	assert.Nil(t, err)
+	// more rows than a row group
+	rows := make([][]interface{}, 0)
+	for i := 0; i < parquetRowGroupSize+10; i++ {
+		rows = append(rows, testRows()...)
+	}
+	for _, row := range rows {
+		assert.Nil(t, writer.Write(row))
+	}
+	assert.Nil(t, writer.Close())
+
+	columns, readRows, err := ReadParquet(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, testColumns, columns)
+	assert.Len(t, readRows, len(rows))
+	finished := time.Date(2023, 7, 1, 8, 30, 0, 0, time.UTC)
+	assert.Equal(t, []interface{}{"github:GithubRun:1:1", int64(60), 0.5, true, finished}, readRows[0])
+	assert.Equal(t, []interface{}{"github:GithubRun:1:2", nil, nil, false, finished}, readRows[len(rows)-1])
+
+	_, _, err = ReadParquet([]byte("PAR1 not a parquet file PAR1"))
+	assert.NotNil(t, err)
+}
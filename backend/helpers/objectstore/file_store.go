@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore keeps the objects as the files under the directory
+func NewFileStore(dir string) Store {
+	return &fileStore{dir: dir}
+}
+
+// pathOf returns the path of the file of the key, a key can't lead out of the directory
+func (s *fileStore) pathOf(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+func (s *fileStore) Put(_ context.Context, key string, body []byte) errors.Error {
+	p := s.pathOf(key)
+	err := os.MkdirAll(filepath.Dir(p), 0o755)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error creating the directory of %s", key))
+	}
+	// write to a temporary file first so a failure doesn't leave a partial object
+	tmp := p + ".tmp"
+	err = os.WriteFile(tmp, body, 0o644)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error writing %s", key))
+	}
+	return errors.Convert(os.Rename(tmp, p))
+}
+
+func (s *fileStore) Get(_ context.Context, key string) ([]byte, errors.Error) {
+	body, err := os.ReadFile(s.pathOf(key))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFound.New(fmt.Sprintf("object %s not found", key))
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error reading %s", key))
+	}
+	return body, nil
+}
+
+func (s *fileStore) Delete(_ context.Context, key string) errors.Error {
+	err := os.Remove(s.pathOf(key))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Default.Wrap(err, fmt.Sprintf("error deleting %s", key))
+	}
+	return nil
+}
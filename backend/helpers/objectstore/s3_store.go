@@ -0,0 +1,236 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type s3Store struct {
+	client          *http.Client
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	prefix          string
+	accessKeyId     string
+	secretAccessKey string
+	// pathStyle puts the bucket in the path rather than the host, MinIO is usually reached that way
+	pathStyle bool
+}
+
+// NewS3Store keeps the objects in a bucket of an s3 compatible server, the requests are signed with the signature
+// version 4. The bucket is addressed by the virtual host on AWS and by the path on the other endpoints
+func NewS3Store(endpoint, region, bucket, prefix, accessKeyId, secretAccessKey string) (Store, errors.Error) {
+	pathStyle := endpoint != ""
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid object store endpoint %s", endpoint))
+	}
+	return &s3Store{
+		client:          &http.Client{Timeout: 5 * time.Minute},
+		endpoint:        u,
+		region:          region,
+		bucket:          bucket,
+		prefix:          strings.Trim(prefix, "/"),
+		accessKeyId:     accessKeyId,
+		secretAccessKey: secretAccessKey,
+		pathStyle:       pathStyle && !strings.HasSuffix(u.Host, "storage.googleapis.com"),
+	}, nil
+}
+
+func (s *s3Store) urlOf(key string) *url.URL {
+	key = strings.TrimLeft(key, "/")
+	if s.prefix != "" {
+		key = s.prefix + "/" + key
+	}
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path = "/" + s.bucket + "/" + key
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	return &u
+}
+
+func (s *s3Store) do(ctx context.Context, method, key string, body []byte) (*http.Response, errors.Error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.urlOf(key).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	req.ContentLength = int64(len(body))
+	signV4(req, body, s.region, s.accessKeyId, s.secretAccessKey, time.Now())
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error requesting %s %s", method, key))
+	}
+	return res, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, body []byte) errors.Error {
+	res, err := s.do(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return errorOf(res, key)
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) ([]byte, errors.Error) {
+	res, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if err = errorOf(res, key); err != nil {
+		return nil, err
+	}
+	body, e := io.ReadAll(res.Body)
+	if e != nil {
+		return nil, errors.Default.Wrap(e, fmt.Sprintf("error reading %s", key))
+	}
+	return body, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) errors.Error {
+	res, err := s.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return errorOf(res, key)
+}
+
+func errorOf(res *http.Response, key string) errors.Error {
+	if res.StatusCode < 300 {
+		return nil
+	}
+	// the error document of s3 is short, the code and the message are enough to tell what went wrong
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	msg := fmt.Sprintf("%s %s of %s: %s", res.Request.Method, res.Status, key, body)
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		return errors.NotFound.New(msg)
+	case http.StatusUnauthorized:
+		return errors.Unauthorized.New(msg)
+	case http.StatusForbidden:
+		return errors.Forbidden.New(msg)
+	}
+	return errors.Default.New(msg)
+}
+
+// signV4 signs the request with the AWS signature version 4 in the Authorization header
+func signV4(req *http.Request, body []byte, region, accessKeyId, secretAccessKey string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	key := hmacSha256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSha256(key, region)
+	key = hmacSha256(key, "s3")
+	key = hmacSha256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyId, scope, signedHeaders, signature,
+	))
+}
+
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// uriEncode escapes every byte but the unreserved characters, the slashes are kept unless encodeSlash
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' && !encodeSlash {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
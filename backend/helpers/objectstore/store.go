@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// Store keeps the objects by their keys, the keys are slash separated paths relative to the prefix of the store
+type Store interface {
+	Put(ctx context.Context, key string, body []byte) errors.Error
+	// Get returns an errors.NotFound error when the object doesn't exist
+	Get(ctx context.Context, key string) ([]byte, errors.Error)
+	Delete(ctx context.Context, key string) errors.Error
+}
+
+// Open returns the store of the url:
+//   - file:///var/lib/devlake/archive for a local directory
+//   - s3://bucket/prefix?region=us-east-1 for AWS S3, a MinIO or any other s3 compatible server is set by the
+//     endpoint parameter, e.g. s3://bucket/prefix?endpoint=http://minio:9000
+//   - gs://bucket/prefix for Google Cloud Storage by its s3 interoperability, the keys are HMAC keys
+func Open(rawUrl, accessKeyId, secretAccessKey string) (Store, errors.Error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid object store url")
+	}
+	prefix := strings.Trim(u.Path, "/")
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.BadInput.New("the directory of the file object store is missing")
+		}
+		return NewFileStore(u.Path), nil
+	case "s3", "gs":
+		if u.Host == "" {
+			return nil, errors.BadInput.New("the bucket of the object store is missing")
+		}
+		if accessKeyId == "" || secretAccessKey == "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("the access keys of the %s object store are missing", u.Scheme))
+		}
+		query := u.Query()
+		region := query.Get("region")
+		endpoint := query.Get("endpoint")
+		if u.Scheme == "gs" {
+			// the interoperability api of gcs takes any region
+			region = "auto"
+			endpoint = "https://storage.googleapis.com"
+		}
+		if region == "" {
+			region = "us-east-1"
+		}
+		return NewS3Store(endpoint, region, u.Host, prefix, accessKeyId, secretAccessKey)
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unsupported object store %s, file, s3 or gs expected", u.Scheme))
+}
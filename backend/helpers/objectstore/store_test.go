@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+	assert.Nil(t, store.Put(ctx, "_raw_jira_api_issues/1/manifest.json", []byte(`{"rows":1}`)))
+	body, err := store.Get(ctx, "_raw_jira_api_issues/1/manifest.json")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"rows":1}`, string(body))
+	assert.Nil(t, store.Delete(ctx, "_raw_jira_api_issues/1/manifest.json"))
+	_, err = store.Get(ctx, "_raw_jira_api_issues/1/manifest.json")
+	assert.Equal(t, errors.NotFound, err.GetType())
+	assert.Nil(t, store.Delete(ctx, "_raw_jira_api_issues/1/manifest.json"))
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open("file://"+dir, "", "")
+	assert.Nil(t, err)
+	testStore(t, store)
+	// the keys stay in the directory
+	assert.Equal(t, dir+"/etc/passwd", store.(*fileStore).pathOf("../../etc/passwd"))
+}
+
+func TestS3Store(t *testing.T) {
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(auth, "/minio/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, "/devlake/archive/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			if sha256Hex(body) != r.Header.Get("X-Amz-Content-Sha256") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+				return
+			}
+			_, _ = w.Write(body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store, err := Open("s3://devlake/archive?region=minio&endpoint="+server.URL, "AKID", "SECRET")
+	assert.Nil(t, err)
+	testStore(t, store)
+
+	store, err = Open("s3://devlake/archive?region=minio&endpoint="+server.URL, "AKID", "")
+	assert.NotNil(t, err)
+	assert.Nil(t, store)
+}
+
+func TestOpen(t *testing.T) {
+	store, err := Open("s3://devlake/archive", "AKID", "SECRET")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://devlake.s3.us-east-1.amazonaws.com/archive/a/b.parquet", store.(*s3Store).urlOf("a/b.parquet").String())
+	store, err = Open("gs://devlake", "AKID", "SECRET")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://devlake.storage.googleapis.com/a/b.parquet", store.(*s3Store).urlOf("a/b.parquet").String())
+	_, err = Open("ftp://devlake", "", "")
+	assert.NotNil(t, err)
+}
+
+func TestUriEncode(t *testing.T) {
+	assert.Equal(t, "/a%20b/c~d%3D", uriEncode("/a b/c~d=", false))
+	assert.Equal(t, "a%2Fb", uriEncode("a/b", true))
+}
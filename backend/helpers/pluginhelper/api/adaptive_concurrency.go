@@ -0,0 +1,234 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// the limit is cut by this factor when an endpoint errs or slows down
+	concurrencyDecreaseFactor = 0.75
+	// the responses slower than this many times the best smoothed latency of the endpoint are taken for congestion
+	concurrencyLatencyTolerance = 2.0
+	// the weight of the latest response in the smoothed latency
+	concurrencyLatencySmoothing = 0.2
+)
+
+var endpointIdPattern = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{32,40}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// AdaptiveConcurrency limits the requests in flight to each endpoint of an api, the limit ramps up by one every
+// limit successful responses and is cut by a quarter when the endpoint fails, is throttled or slows down. The
+// throughput then settles right below the point the upstream protections kick in, whatever the number of workers
+type AdaptiveConcurrency struct {
+	mu       sync.Mutex
+	min      int
+	max      int
+	initial  int
+	limiters map[string]*ConcurrencyLimiter
+}
+
+// NewAdaptiveConcurrency creates an AdaptiveConcurrency, the limits of the endpoints start at initial and stay
+// between min and max
+func NewAdaptiveConcurrency(min, initial, max int) *AdaptiveConcurrency {
+	if max < 1 {
+		max = 1
+	}
+	if min < 1 {
+		min = 1
+	}
+	if min > max {
+		min = max
+	}
+	if initial < min {
+		initial = min
+	}
+	if initial > max {
+		initial = max
+	}
+	return &AdaptiveConcurrency{
+		min:      min,
+		max:      max,
+		initial:  initial,
+		limiters: make(map[string]*ConcurrencyLimiter),
+	}
+}
+
+// For returns the limiter of the endpoint of the request, the ids in the path are ignored so that the requests of
+// all the issues, say, share a limiter
+func (a *AdaptiveConcurrency) For(method, path string) *ConcurrencyLimiter {
+	endpoint := EndpointOf(method, path)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	limiter, ok := a.limiters[endpoint]
+	if !ok {
+		limiter = &ConcurrencyLimiter{
+			endpoint: endpoint,
+			min:      a.min,
+			max:      a.max,
+			limit:    float64(a.initial),
+			changed:  make(chan struct{}),
+		}
+		a.limiters[endpoint] = limiter
+	}
+	return limiter
+}
+
+// Limiters returns the limiters of the endpoints requested so far, sorted by endpoint
+func (a *AdaptiveConcurrency) Limiters() []*ConcurrencyLimiter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	limiters := make([]*ConcurrencyLimiter, 0, len(a.limiters))
+	for _, limiter := range a.limiters {
+		limiters = append(limiters, limiter)
+	}
+	sort.Slice(limiters, func(i, j int) bool {
+		return limiters[i].endpoint < limiters[j].endpoint
+	})
+	return limiters
+}
+
+// EndpointOf identifies the endpoint of a request by its method and path, with the query dropped and the numeric,
+// hash and uuid segments replaced by {id}
+func EndpointOf(method, path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if endpointIdPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return method + " " + strings.Join(segments, "/")
+}
+
+// ConcurrencyLimiter limits the requests in flight to an endpoint, see AdaptiveConcurrency
+type ConcurrencyLimiter struct {
+	mu           sync.Mutex
+	endpoint     string
+	min          int
+	max          int
+	limit        float64
+	inflight     int
+	latency      time.Duration
+	bestLatency  time.Duration
+	lastDecrease time.Time
+	requests     int64
+	failures     int64
+	changed      chan struct{}
+}
+
+// Acquire blocks until a request can be sent to the endpoint without exceeding its limit, or the ctx is done
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.inflight < int(l.limit) {
+			l.inflight++
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Release records the outcome of a request acquired before and adjusts the limit accordingly
+func (l *ConcurrencyLimiter) Release(latency time.Duration, failed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	busy := l.inflight >= int(l.limit)
+	l.inflight--
+	l.requests++
+	if failed {
+		l.failures++
+		l.decrease()
+	} else {
+		if l.latency == 0 {
+			l.latency = latency
+		} else {
+			l.latency = time.Duration((1-concurrencyLatencySmoothing)*float64(l.latency) + concurrencyLatencySmoothing*float64(latency))
+		}
+		if l.bestLatency == 0 || l.latency < l.bestLatency {
+			l.bestLatency = l.latency
+		}
+		if float64(l.latency) > concurrencyLatencyTolerance*float64(l.bestLatency) {
+			l.decrease()
+		} else if busy {
+			// only ramp up when the limit is what holds the requests back
+			l.limit += 1 / l.limit
+			if l.limit > float64(l.max) {
+				l.limit = float64(l.max)
+			}
+		}
+	}
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// decrease cuts the limit, at most once per smoothed latency since the requests in flight were sent before the
+// previous cut and would otherwise cut it again
+func (l *ConcurrencyLimiter) decrease() {
+	now := time.Now()
+	if !l.lastDecrease.IsZero() && now.Sub(l.lastDecrease) < l.latency {
+		return
+	}
+	l.lastDecrease = now
+	l.limit *= concurrencyDecreaseFactor
+	if l.limit < float64(l.min) {
+		l.limit = float64(l.min)
+	}
+}
+
+// Endpoint returns the endpoint the limiter is for
+func (l *ConcurrencyLimiter) Endpoint() string {
+	return l.endpoint
+}
+
+// Limit returns the current number of requests allowed in flight
+func (l *ConcurrencyLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Stats returns the requests released so far, those failed and the smoothed latency
+func (l *ConcurrencyLimiter) Stats() (requests int64, failures int64, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.requests, l.failures, l.latency
+}
+
+// isCongestion tells if the response shows the endpoint is overloaded or throttling, rather than the request is bad
+func isCongestion(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointOf(t *testing.T) {
+	assert.Equal(t, "GET repos/{id}/pulls/{id}/commits", EndpointOf("GET", "repos/123/pulls/45/commits?page=2"))
+	assert.Equal(t, "GET api/v4/projects/{id}/repository/commits/{id}", EndpointOf("GET", "api/v4/projects/8/repository/commits/0123456789abcdef0123456789abcdef01234567"))
+	assert.Equal(t, "GET rest/agile/1.0/board/{id}/sprint", EndpointOf("GET", "rest/agile/1.0/board/12/sprint"))
+}
+
+func TestConcurrencyLimiterRampsUp(t *testing.T) {
+	concurrency := NewAdaptiveConcurrency(1, 2, 4)
+	limiter := concurrency.For("GET", "issues/1")
+	ctx := context.Background()
+
+	// the limit only grows while it holds the requests back
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, limiter.Acquire(ctx))
+		limiter.Release(time.Second, false)
+	}
+	assert.Equal(t, 2, limiter.Limit())
+
+	// it grows by one every limit responses
+	for i := 0; i < 10; i++ {
+		n := limiter.Limit()
+		for j := 0; j < n; j++ {
+			assert.Nil(t, limiter.Acquire(ctx))
+		}
+		for j := 0; j < n; j++ {
+			limiter.Release(time.Second, false)
+		}
+	}
+	assert.Equal(t, 4, limiter.Limit())
+
+	// the same endpoint whatever the id
+	assert.Same(t, limiter, concurrency.For("GET", "issues/2"))
+}
+
+func TestConcurrencyLimiterBacksOff(t *testing.T) {
+	limiter := NewAdaptiveConcurrency(1, 8, 8).For("GET", "issues")
+	ctx := context.Background()
+
+	assert.Nil(t, limiter.Acquire(ctx))
+	limiter.Release(time.Millisecond, true)
+	assert.Equal(t, 6, limiter.Limit())
+
+	// the responses sent before the cut don't cut it again
+	assert.Nil(t, limiter.Acquire(ctx))
+	limiter.Release(time.Millisecond, false)
+	limiter.lastDecrease = time.Now()
+	limiter.latency = time.Hour
+	assert.Nil(t, limiter.Acquire(ctx))
+	limiter.Release(time.Millisecond, true)
+	assert.Equal(t, 6, limiter.Limit())
+
+	// slowing down is taken for congestion too
+	limiter.lastDecrease = time.Time{}
+	limiter.latency = 0
+	limiter.bestLatency = 0
+	assert.Nil(t, limiter.Acquire(ctx))
+	limiter.Release(time.Millisecond, false)
+	assert.Nil(t, limiter.Acquire(ctx))
+	limiter.Release(time.Second, false)
+	assert.Equal(t, 4, limiter.Limit())
+
+	// never below the min
+	for i := 0; i < 10; i++ {
+		limiter.lastDecrease = time.Time{}
+		assert.Nil(t, limiter.Acquire(ctx))
+		limiter.Release(time.Millisecond, true)
+	}
+	assert.Equal(t, 1, limiter.Limit())
+	requests, failures, _ := limiter.Stats()
+	assert.Equal(t, int64(15), requests)
+	assert.Equal(t, int64(12), failures)
+}
+
+func TestConcurrencyLimiterBlocks(t *testing.T) {
+	limiter := NewAdaptiveConcurrency(1, 1, 1).For("GET", "issues")
+	assert.Nil(t, limiter.Acquire(context.Background()))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- limiter.Acquire(context.Background())
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("the limit was exceeded")
+	case <-time.After(50 * time.Millisecond):
+	}
+	limiter.Release(time.Millisecond, false)
+	assert.Nil(t, <-acquired)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, limiter.Acquire(ctx))
+}
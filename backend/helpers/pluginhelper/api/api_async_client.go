@@ -45,6 +45,7 @@ type ApiAsyncClient struct {
 	maxRetry     int
 	numOfWorkers int
 	logger       log.Logger
+	concurrency  *AdaptiveConcurrency
 }
 
 const defaultTimeout = 120 * time.Second
@@ -75,6 +76,7 @@ func CreateAsyncApiClient(
 	}
 
 	apiClient.SetLogger(taskCtx.GetLogger())
+	apiClient.SetPlugin(taskCtx.GetName())
 
 	globalRateLimitPerHour, err := utils.StrToIntOr(taskCtx.GetConfig("API_REQUESTS_PER_HOUR"), 18000)
 	if err != nil {
@@ -113,6 +115,15 @@ func CreateAsyncApiClient(
 		duration.String(),
 		tickInterval.String(),
 	)
+	// the workers bound the requests in flight, the adaptive concurrency finds how many of them each endpoint takes
+	var concurrency *AdaptiveConcurrency
+	if taskCtx.GetConfig("API_ADAPTIVE_CONCURRENCY") != "false" {
+		minConcurrency, err := utils.StrToIntOr(taskCtx.GetConfig("API_MIN_CONCURRENCY"), 1)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse API_MIN_CONCURRENCY")
+		}
+		concurrency = NewAdaptiveConcurrency(minConcurrency, numOfWorkers/4, numOfWorkers)
+	}
 	scheduler, err := NewWorkerScheduler(
 		taskCtx.GetContext(),
 		numOfWorkers,
@@ -130,6 +141,7 @@ func CreateAsyncApiClient(
 		retry,
 		numOfWorkers,
 		logger,
+		concurrency,
 	}, nil
 }
 
@@ -162,8 +174,20 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		var respBody []byte
 
 		apiClient.logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
+		var limiter *ConcurrencyLimiter
+		if apiClient.concurrency != nil {
+			limiter = apiClient.concurrency.For(method, path)
+			err = limiter.Acquire(apiClient.WorkerScheduler.ctx)
+			if err != nil {
+				return errors.Convert(err)
+			}
+		}
+		start := time.Now()
 		res, err = apiClient.Do(method, path, query, body, header)
 		if err == ErrIgnoreAndContinue {
+			if limiter != nil {
+				limiter.Release(time.Since(start), false)
+			}
 			// make sure defer func got be executed
 			err = nil //nolint
 			return nil
@@ -179,6 +203,9 @@ func (apiClient *ApiAsyncClient) DoAsync(
 				res.Body = io.NopCloser(bytes.NewBuffer(respBody))
 			}
 		}
+		if limiter != nil {
+			limiter.Release(time.Since(start), isCongestion(res, err))
+		}
 
 		// check
 		needRetry := false
@@ -245,6 +272,25 @@ func (apiClient *ApiAsyncClient) GetNumOfWorkers() int {
 	return apiClient.numOfWorkers
 }
 
+// Release logs the concurrency the endpoints settled at and releases the workers
+func (apiClient *ApiAsyncClient) Release() {
+	apiClient.WorkerScheduler.Release()
+	if apiClient.concurrency == nil {
+		return
+	}
+	for _, limiter := range apiClient.concurrency.Limiters() {
+		requests, failures, latency := limiter.Stats()
+		apiClient.logger.Info(
+			"endpoint %s settled at concurrency %d, %d requests, %d failed, latency %s",
+			limiter.Endpoint(),
+			limiter.Limit(),
+			requests,
+			failures,
+			latency.String(),
+		)
+	}
+}
+
 // RateLimitedApiClient FIXME ...
 type RateLimitedApiClient interface {
 	DoGetAsync(path string, query url.Values, header http.Header, handler common.ApiAsyncCallback)
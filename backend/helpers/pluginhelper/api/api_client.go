@@ -57,6 +57,8 @@ type ApiClient struct {
 	afterResponse common.ApiClientAfterResponse
 	ctx           gocontext.Context
 	logger        log.Logger
+	// the plugin the calls are counted for in the metrics
+	plugin string
 }
 
 // NewApiClientFromConnection creates ApiClient based on given connection.
@@ -262,6 +264,11 @@ func (apiClient *ApiClient) SetLogger(logger log.Logger) {
 	apiClient.logger = logger
 }
 
+// SetPlugin sets the plugin the calls of the client are counted for in the metrics
+func (apiClient *ApiClient) SetPlugin(pluginName string) {
+	apiClient.plugin = pluginName
+}
+
 func (apiClient *ApiClient) logDebug(format string, a ...interface{}) {
 	if apiClient.logger != nil {
 		apiClient.logger.Debug(format, a...)
@@ -327,7 +334,9 @@ func (apiClient *ApiClient) Do(
 		}
 	}
 	apiClient.logDebug("[api-client] %v %v", method, *uri)
+	beganAt := time.Now()
 	res, err = errors.Convert01(apiClient.client.Do(req))
+	observeApiCall(apiClient.plugin, method, res, beganAt)
 	if err != nil {
 		apiClient.logError(err, "[api-client] failed to request %s with error", req.URL.String())
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("error requesting %s", req.URL.String()))
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// the kinds of the failed api calls
+const (
+	apiCallErrorTransport = "transport"
+	apiCallErrorStatus    = "status"
+)
+
+var (
+	apiCallsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "devlake_collector_api_calls_total",
+		Help: "Number of the calls the plugins made to the apis of the data sources, by the class of their status code",
+	}, []string{"plugin", "method", "code"})
+	apiCallErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "devlake_collector_api_errors_total",
+		Help: "Number of the calls to the apis of the data sources which failed, either to be sent or by their status code",
+	}, []string{"plugin", "kind"})
+	apiCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "devlake_collector_api_call_duration_seconds",
+		Help:    "Duration of the calls the plugins made to the apis of the data sources",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"plugin"})
+)
+
+// observeApiCall records a call of a client to the api of a data source, res is nil when the call could not be made.
+// The calls of the clients without a plugin, i.e. testing a connection, are left out.
+func observeApiCall(pluginName string, method string, res *http.Response, beganAt time.Time) {
+	if pluginName == "" {
+		return
+	}
+	apiCallDuration.WithLabelValues(pluginName).Observe(time.Since(beganAt).Seconds())
+	if res == nil {
+		apiCallsTotal.WithLabelValues(pluginName, method, "error").Inc()
+		apiCallErrorsTotal.WithLabelValues(pluginName, apiCallErrorTransport).Inc()
+		return
+	}
+	apiCallsTotal.WithLabelValues(pluginName, method, fmt.Sprintf("%dxx", res.StatusCode/100)).Inc()
+	if res.StatusCode >= http.StatusBadRequest {
+		apiCallErrorsTotal.WithLabelValues(pluginName, apiCallErrorStatus).Inc()
+	}
+}
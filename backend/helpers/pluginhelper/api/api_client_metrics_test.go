@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveApiCall(t *testing.T) {
+	observeApiCall("metricstest", http.MethodGet, &http.Response{StatusCode: http.StatusOK}, time.Now())
+	observeApiCall("metricstest", http.MethodGet, &http.Response{StatusCode: http.StatusTooManyRequests}, time.Now())
+	observeApiCall("metricstest", http.MethodGet, nil, time.Now())
+	// the clients without a plugin are left out
+	observeApiCall("", http.MethodGet, nil, time.Now())
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(apiCallsTotal.WithLabelValues("metricstest", http.MethodGet, "2xx")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(apiCallsTotal.WithLabelValues("metricstest", http.MethodGet, "4xx")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(apiCallsTotal.WithLabelValues("metricstest", http.MethodGet, "error")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(apiCallErrorsTotal.WithLabelValues("metricstest", apiCallErrorStatus)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(apiCallErrorsTotal.WithLabelValues("metricstest", apiCallErrorTransport)))
+	assert.Equal(t, 3, testutil.CollectAndCount(apiCallsTotal))
+}
@@ -29,6 +29,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/common"
 )
@@ -50,6 +51,9 @@ type RequestData struct {
 	CustomData interface{}
 }
 
+// streamedRawRowsBatchSize is the number of items streamed by a ResponseStreamer saved at once
+const streamedRawRowsBatchSize = 100
+
 // AsyncResponseHandler FIXME ...
 type AsyncResponseHandler func(res *http.Response) error
 
@@ -83,9 +87,12 @@ type ApiCollectorArgs struct {
 	// NORMALLY, DO NOT SPECIFY THIS PARAMETER, unless you know what it means
 	Concurrency    int
 	ResponseParser func(res *http.Response) ([]json.RawMessage, errors.Error)
-	AfterResponse  common.ApiClientAfterResponse
-	RequestBody    func(reqData *RequestData) map[string]interface{}
-	Method         string
+	// ResponseStreamer takes the place of ResponseParser for the huge responses, it hands the items of the response
+	// to the collector one at a time and they are saved in batches, see StreamRawMessageArrayFromResponse
+	ResponseStreamer func(res *http.Response, handle func(item json.RawMessage) errors.Error) errors.Error
+	AfterResponse    common.ApiClientAfterResponse
+	RequestBody      func(reqData *RequestData) map[string]interface{}
+	Method           string
 }
 
 // ApiCollector FIXME ...
@@ -93,6 +100,7 @@ type ApiCollector struct {
 	*RawDataSubTask
 	args        *ApiCollectorArgs
 	urlTemplate *template.Template
+	httpCache   *collectorHttpCache
 }
 
 // NewApiCollector allocates a new ApiCollector with the given args.
@@ -115,8 +123,8 @@ func NewApiCollector(args ApiCollectorArgs) (*ApiCollector, errors.Error) {
 	if args.ApiClient == nil {
 		return nil, errors.Default.New("ApiClient is required")
 	}
-	if args.ResponseParser == nil {
-		return nil, errors.Default.New("ResponseParser is required")
+	if args.ResponseParser == nil && args.ResponseStreamer == nil {
+		return nil, errors.Default.New("one of ResponseParser and ResponseStreamer is required")
 	}
 	apiCollector := &ApiCollector{
 		RawDataSubTask: rawDataSubTask,
@@ -143,17 +151,32 @@ func (collector *ApiCollector) Execute() errors.Error {
 
 	// make sure table is created
 	db := collector.args.Ctx.GetDal()
-	err := db.AutoMigrate(&RawData{}, dal.From(collector.table))
+	err := collector.MigrateRawTable()
 	if err != nil {
 		return errors.Default.Wrap(err, "error auto-migrating collector")
 	}
 
-	// flush data if not incremental collection
-	if !collector.args.Incremental {
+	// flush data if not incremental collection, a backfill is merged into the existing data instead
+	backfill := BackfillOf(collector.args.Ctx)
+	if !collector.args.Incremental && backfill == nil {
 		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
 		if err != nil {
 			return errors.Default.Wrap(err, "error deleting data from collector")
 		}
+		if rawDataCtx := collector.rawDataTaskContext(); rawDataCtx != nil {
+			rawDataCtx.SetRawDataRecollected(collector.table, collector.params)
+		}
+	}
+
+	collector.startDedup()
+
+	// the validators of the responses are kept for the next collections to send conditional requests, only the
+	// incremental collections keep the records of the unchanged responses to answer them
+	if collector.args.Incremental && collector.args.Ctx.GetConfig("API_CONDITIONAL_REQUESTS") != "false" && backfill == nil {
+		collector.httpCache, err = loadCollectorHttpCache(db, collector.table, collector.params)
+		if err != nil {
+			return err
+		}
 	}
 
 	// if MinTickInterval was specified
@@ -214,6 +237,10 @@ func (collector *ApiCollector) Execute() errors.Error {
 		err = errors.Default.Wrap(err, "Error waiting for async Collector execution")
 	} else {
 		logger.Info("end api collection without error")
+		if collector.httpCache != nil && collector.httpCache.notModified > 0 {
+			logger.Info("%d responses were not modified since the last collection", collector.httpCache.notModified)
+		}
+		err = collector.saveDedupStat()
 	}
 
 	return err
@@ -414,11 +441,35 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			panic(err)
 		}
 	}
+	// only the incremental collections keep the records of the unchanged responses, and only the pagination by the
+	// number of records can go on without the response
+	var cacheKey string
+	var cached *models.CollectorHttpCache
+	if collector.httpCache != nil && collector.args.Incremental && collector.args.Method != http.MethodPost {
+		cacheUrl := apiUrl
+		if len(apiQuery) > 0 {
+			cacheUrl += "?" + apiQuery.Encode()
+		}
+		cacheKey = collector.httpCache.key(http.MethodGet, cacheUrl)
+		if handler == nil || collector.args.GetTotalPages == nil && collector.args.GetNextPageCustomData == nil {
+			apiHeader, cached = collector.httpCache.conditional(cacheKey, apiHeader)
+		}
+	}
 	logger := collector.args.Ctx.GetLogger()
 	logger.Debug("fetchAsync <<< enqueueing for %s %v", apiUrl, apiQuery)
 	responseHandler := func(res *http.Response) errors.Error {
 		defer logger.Debug("fetchAsync >>> done for %s %v %v", apiUrl, apiQuery, collector.args.RequestBody)
 		logger := collector.args.Ctx.GetLogger()
+		// the records of the unchanged response were stored by a previous collection
+		if cached != nil && res.StatusCode == http.StatusNotModified {
+			res.Body.Close()
+			collector.httpCache.hit()
+			collector.args.Ctx.IncProgress(1)
+			if handler != nil {
+				return handler(cached.Items, nil, res)
+			}
+			return nil
+		}
 		// read body to buffer
 		body, err := io.ReadAll(res.Body)
 		if err != nil {
@@ -426,37 +477,40 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 		}
 		res.Body.Close()
 		res.Body = io.NopCloser(bytes.NewBuffer(body))
-		// convert body to array of RawJSON
-		items, err := collector.args.ResponseParser(res)
+		// convert body to array of RawJSON, the streamed items are saved along the way
+		var count int
+		if collector.args.ResponseStreamer != nil {
+			count, err = collector.streamRawRows(res, reqData.InputJSON)
+		} else {
+			var items []json.RawMessage
+			items, err = collector.args.ResponseParser(res)
+			count = len(items)
+			if err == nil || errors.Is(err, ErrFinishCollect) {
+				if saveErr := collector.saveRawRows(items, res.Request.URL.String(), reqData.InputJSON); saveErr != nil {
+					return saveErr
+				}
+			}
+		}
 		if err != nil {
 			if errors.Is(err, ErrFinishCollect) {
 				logger.Info("a fetch stop by parser, reqInput: #%s", reqData.Params)
 				handler = nil
+				// the pagination would go on should the response be answered from the cache
+				cacheKey = ""
 			} else {
 				return errors.Default.Wrap(err, fmt.Sprintf("error parsing response from %s", apiUrl))
 			}
 		}
-		// save to db
-		count := len(items)
+		if cacheKey != "" {
+			cacheErr := collector.httpCache.save(cacheKey, res.Request.URL.String(), res, count)
+			if cacheErr != nil {
+				return cacheErr
+			}
+		}
 		if count == 0 {
 			collector.args.Ctx.IncProgress(1)
 			return nil
 		}
-		db := collector.args.Ctx.GetDal()
-		urlString := res.Request.URL.String()
-		rows := make([]*RawData, count)
-		for i, msg := range items {
-			rows[i] = &RawData{
-				Params: collector.params,
-				Data:   msg,
-				Url:    urlString,
-				Input:  reqData.InputJSON,
-			}
-		}
-		err = db.Create(rows, dal.From(collector.table))
-		if err != nil {
-			return errors.Default.Wrap(err, fmt.Sprintf("error inserting raw rows into %s", collector.table))
-		}
 		logger.Debug("fetchAsync === total %d rows were saved into database", count)
 		// increase progress only when it was not nested
 		collector.args.Ctx.IncProgress(1)
@@ -475,4 +529,57 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 	logger.Debug("fetchAsync === enqueued for %s %v", apiUrl, apiQuery)
 }
 
+// saveRawRows saves the items of a response into the raw table, the items collected before are skipped
+func (collector *ApiCollector) saveRawRows(items []json.RawMessage, url string, input []byte) errors.Error {
+	if len(items) == 0 {
+		return nil
+	}
+	rows := make([]*RawData, len(items))
+	for i, msg := range items {
+		rows[i] = &RawData{
+			Params: collector.params,
+			Data:   msg,
+			Url:    url,
+			Input:  input,
+		}
+	}
+	rows, err := collector.dedupRawRows(rows)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	err = OffloadRawData(collector.args.Ctx.GetContext(), collector.table, rows)
+	if err != nil {
+		return err
+	}
+	err = collector.args.Ctx.GetDal().Create(rows, dal.From(collector.table))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error inserting raw rows into %s", collector.table))
+	}
+	return nil
+}
+
+// streamRawRows saves the items handed by the ResponseStreamer in batches, only a batch of them is held at once
+func (collector *ApiCollector) streamRawRows(res *http.Response, input []byte) (int, errors.Error) {
+	url := res.Request.URL.String()
+	count := 0
+	batch := make([]json.RawMessage, 0, streamedRawRowsBatchSize)
+	err := collector.args.ResponseStreamer(res, func(item json.RawMessage) errors.Error {
+		count++
+		batch = append(batch, item)
+		if len(batch) < streamedRawRowsBatchSize {
+			return nil
+		}
+		saveErr := collector.saveRawRows(batch, url, input)
+		batch = batch[:0]
+		return saveErr
+	})
+	if err != nil && !errors.Is(err, ErrFinishCollect) {
+		return count, err
+	}
+	if saveErr := collector.saveRawRows(batch, url, input); saveErr != nil {
+		return count, saveErr
+	}
+	return count, err
+}
+
 var _ plugin.SubTask = (*ApiCollector)(nil)
@@ -19,6 +19,7 @@ package api
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/common"
 	"github.com/apache/incubator-devlake/helpers/unithelper"
@@ -27,6 +28,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,9 +45,14 @@ func (t TestOpts) GetParams() any {
 
 func TestFetchPageUndetermined(t *testing.T) {
 	mockDal := new(mockdal.Dal)
+	mockDal.On("HasTable", mock.Anything).Return(true).Once()
+	mockDal.On("Dialect").Return("")
 	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
 	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Pluck", "hash", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("First", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil).Once()
 
 	mockCtx := unithelper.DummySubTaskContext(mockDal)
 
@@ -105,3 +112,113 @@ func TestFetchPageUndetermined(t *testing.T) {
 
 	mockDal.AssertExpectations(t)
 }
+
+func TestNonIncrementalCollectionWritesNoHttpCache(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("HasTable", mock.Anything).Return(true).Once()
+	mockDal.On("Dialect").Return("")
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Pluck", "hash", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("First", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
+
+	mockCtx := unithelper.DummySubTaskContext(mockDal)
+
+	// the responses carry the validators of a conditional request
+	mockApi := new(mockapi.RateLimitedApiClient)
+	mockApi.On("DoGetAsync", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		res := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Etag": []string{`W/"abc"`}},
+			Request: &http.Request{
+				URL: &url.URL{},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("[1,2,3]")),
+		}
+		handler := args.Get(3).(common.ApiAsyncCallback)
+		assert.Nil(t, handler(res))
+	}).Once()
+	mockApi.On("HasError").Return(false)
+	mockApi.On("WaitAsync").Return(nil)
+	mockApi.On("GetAfterFunction", mock.Anything).Return(nil)
+	mockApi.On("SetAfterFunction", mock.Anything).Return()
+	mockApi.On("Release").Return()
+
+	collector, err := NewApiCollector(ApiCollectorArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:     mockCtx,
+			Table:   "whatever rawtable",
+			Options: &TestOpts{},
+		},
+		ApiClient:      mockApi,
+		UrlTemplate:    "whatever url",
+		ResponseParser: GetRawMessageArrayFromResponse,
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, collector.Execute())
+
+	mockDal.AssertExpectations(t)
+	// the cache of the responses is neither loaded nor saved
+	mockDal.AssertNotCalled(t, "All", mock.Anything, mock.Anything)
+	mockDal.AssertNotCalled(t, "CreateOrUpdate", mock.AnythingOfType("*models.CollectorHttpCache"), mock.Anything)
+	assert.Nil(t, collector.httpCache)
+}
+
+func TestStreamedResponseSavedInBatches(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("HasTable", mock.Anything).Return(true).Once()
+	mockDal.On("Dialect").Return("")
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Pluck", "hash", mock.Anything, mock.Anything).Return(nil).Times(3)
+	var batches []int
+	mockDal.On("Create", mock.AnythingOfType("[]*api.RawData"), mock.Anything).Run(func(args mock.Arguments) {
+		batches = append(batches, len(args.Get(0).([]*RawData)))
+	}).Return(nil)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
+
+	mockCtx := unithelper.DummySubTaskContext(mockDal)
+
+	items := make([]string, 250)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"id":%d}`, i)
+	}
+	mockApi := new(mockapi.RateLimitedApiClient)
+	mockApi.On("DoGetAsync", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		res := &http.Response{
+			StatusCode: http.StatusOK,
+			Request: &http.Request{
+				URL: &url.URL{},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(`{"total":250,"issues":[` + strings.Join(items, ",") + `]}`)),
+		}
+		handler := args.Get(3).(common.ApiAsyncCallback)
+		assert.Nil(t, handler(res))
+	}).Once()
+	mockApi.On("HasError").Return(false)
+	mockApi.On("WaitAsync").Return(nil)
+	mockApi.On("GetAfterFunction", mock.Anything).Return(nil)
+	mockApi.On("SetAfterFunction", mock.Anything).Return()
+	mockApi.On("Release").Return()
+
+	collector, err := NewApiCollector(ApiCollectorArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:     mockCtx,
+			Table:   "whatever rawtable",
+			Options: &TestOpts{},
+		},
+		ApiClient:        mockApi,
+		UrlTemplate:      "whatever url",
+		ResponseStreamer: StreamRawMessageArrayFromResponse("issues"),
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, collector.Execute())
+	// the items are saved as they are decoded, never all of them at once
+	assert.Equal(t, []int{100, 100, 50}, batches)
+	mockDal.AssertExpectations(t)
+}
@@ -23,7 +23,6 @@ import (
 	"net/url"
 	"time"
 
-	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -35,9 +34,13 @@ type ApiCollectorStateManager struct {
 	RawDataSubTaskArgs
 	// *ApiCollector
 	// *GraphqlCollector
-	subtasks     []plugin.SubTask
-	LatestState  models.CollectorLatestState
-	TimeAfter    *time.Time
+	subtasks    []plugin.SubTask
+	LatestState models.CollectorLatestState
+	TimeAfter   *time.Time
+	// TimeBefore ends the window of a backfill run for the apis filtering by it, the data past it is merged as well
+	// otherwise. It is nil for a regular run or an open-ended backfill
+	TimeBefore   *time.Time
+	Backfill     *plugin.BackfillWindow
 	ExecuteStart time.Time
 }
 
@@ -49,33 +52,33 @@ func NewStatefulApiCollector(args RawDataSubTaskArgs, timeAfter *time.Time) (*Ap
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "Couldn't resolve raw subtask args")
 	}
-	latestState := models.CollectorLatestState{}
-	err = db.First(&latestState, dal.Where(`raw_data_table = ? AND raw_data_params = ?`, rawDataSubTask.table, rawDataSubTask.params))
+	latestState, err := LoadCollectorState(db, rawDataSubTask.table, rawDataSubTask.params)
 	if err != nil {
-		if db.IsErrorNotFound(err) {
-			latestState = models.CollectorLatestState{
-				RawDataTable:  rawDataSubTask.table,
-				RawDataParams: rawDataSubTask.params,
-			}
-		} else {
-			return nil, errors.Default.Wrap(err, "failed to load JiraLatestCollectorMeta")
-		}
+		return nil, err
 	}
-	return &ApiCollectorStateManager{
+	manager := &ApiCollectorStateManager{
 		RawDataSubTaskArgs: args,
-		LatestState:        latestState,
+		LatestState:        *latestState,
 		TimeAfter:          timeAfter,
 		ExecuteStart:       time.Now(),
-	}, nil
+	}
+	// a backfill collects its window whatever the state is
+	if backfill := BackfillOf(args.Ctx); backfill != nil {
+		manager.Backfill = backfill
+		manager.TimeAfter = backfill.From
+		manager.TimeBefore = backfill.To
+	}
+	return manager, nil
 }
 
-// IsIncremental indicates if the collector should operate in incremental mode
+// IsIncremental indicates if the collector should operate in incremental mode, a backfill never does as it collects
+// its whole window, which is merged into the existing data
 func (m *ApiCollectorStateManager) IsIncremental() bool {
 	prevSyncTime := m.LatestState.LatestSuccessStart
 	prevTimeAfter := m.LatestState.TimeAfter
 	currTimeAfter := m.TimeAfter
 
-	if prevSyncTime == nil {
+	if prevSyncTime == nil || m.Backfill != nil {
 		return false
 	}
 	if currTimeAfter != nil {
@@ -84,6 +87,17 @@ func (m *ApiCollectorStateManager) IsIncremental() bool {
 	return prevTimeAfter == nil
 }
 
+// GetCursor decodes the cursor saved by the last successful collection into dst, false is returned if there
+// is none, e.g. the first collection or the state was reset
+func (m *ApiCollectorStateManager) GetCursor(dst interface{}) (bool, errors.Error) {
+	return DecodeCollectorCursor(&m.LatestState, dst)
+}
+
+// SetCursor sets the position the next collection resumes from, it is saved once all the collectors succeed
+func (m *ApiCollectorStateManager) SetCursor(cursor interface{}) errors.Error {
+	return EncodeCollectorCursor(&m.LatestState, cursor)
+}
+
 // InitCollector init the embedded collector
 func (m *ApiCollectorStateManager) InitCollector(args ApiCollectorArgs) errors.Error {
 	args.RawDataSubTaskArgs = m.RawDataSubTaskArgs
@@ -115,9 +129,17 @@ func (m *ApiCollectorStateManager) Execute() errors.Error {
 		}
 	}
 
+	// the next regular run carries on from the state of the previous one, as the backfill didn't collect what's new
+	if m.Backfill != nil {
+		m.Ctx.GetLogger().Info("backfilled %s, the collector state is left untouched", m.Backfill)
+		return nil
+	}
 	db := m.Ctx.GetDal()
 	m.LatestState.LatestSuccessStart = &m.ExecuteStart
 	m.LatestState.TimeAfter = m.TimeAfter
+	if taskCtx := m.Ctx.TaskContext(); taskCtx != nil {
+		m.LatestState.Plugin = taskCtx.GetName()
+	}
 	return db.CreateOrUpdate(&m.LatestState)
 }
 
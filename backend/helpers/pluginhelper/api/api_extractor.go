@@ -83,9 +83,17 @@ func (extractor *ApiExtractor) Execute() errors.Error {
 	defer cursor.Close()
 	row := &RawData{}
 
-	// batch save divider
-	RAW_DATA_ORIGIN := "RawDataOrigin"
-	divider := NewBatchSaveDivider(extractor.args.Ctx, extractor.args.BatchSize, extractor.table, extractor.params)
+	keepRawData, err := extractor.keepsRawData()
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting raw data retention")
+	}
+	// batch save divider, the outdated records can only be deleted if all of their raw data is extracted again: the
+	// raw data was kept, or the dropped one was collected again in full by the task
+	dividerTable := extractor.table
+	if !keepRawData && !extractor.rawDataTaskContext().IsRawDataRecollected(extractor.table, extractor.params) {
+		dividerTable = ""
+	}
+	divider := NewBatchSaveDivider(extractor.args.Ctx, extractor.args.BatchSize, dividerTable, extractor.params)
 
 	// prgress
 	extractor.args.Ctx.SetProgress(0, -1)
@@ -101,37 +109,57 @@ func (extractor *ApiExtractor) Execute() errors.Error {
 		if err != nil {
 			return errors.Default.Wrap(err, "error fetching row")
 		}
-
-		results, err := extractor.args.Extract(row)
+		err = LoadRawData(ctx, row)
 		if err != nil {
-			return errors.Default.Wrap(err, "error calling plugin Extract implementation")
+			return err
 		}
-		for _, result := range results {
-			// get the batch operator for the specific type
-			batch, err := divider.ForType(reflect.TypeOf(result))
-			if err != nil {
-				return errors.Default.Wrap(err, "error getting batch from result")
-			}
-			// set raw data origin field
-			origin := reflect.ValueOf(result).Elem().FieldByName(RAW_DATA_ORIGIN)
-			if origin.IsValid() && origin.IsZero() {
-				origin.Set(reflect.ValueOf(common.RawDataOrigin{
-					RawDataTable:  extractor.table,
-					RawDataId:     row.ID,
-					RawDataParams: row.Params,
-				}))
-			}
-			// records get saved into db when slots were max outed
-			err = batch.Add(result)
-			if err != nil {
-				return errors.Default.Wrap(err, "error adding result to batch")
-			}
+
+		err = extractor.extract(row, divider)
+		if err != nil {
+			return err
 		}
 		extractor.args.Ctx.IncProgress(1)
 	}
 
 	// save the last batches
-	return divider.Close()
+	err = divider.Close()
+	if err != nil || keepRawData || row.ID == 0 {
+		return err
+	}
+	// the other subtasks of the task may read the same raw data, it is dropped once all of them ran
+	extractor.rawDataTaskContext().DropRawDataAfterTask(extractor.table, extractor.params, row.ID)
+	return nil
+}
+
+// extract saves the records the plugin extracts from the row
+func (extractor *ApiExtractor) extract(row *RawData, divider *BatchSaveDivider) errors.Error {
+	RAW_DATA_ORIGIN := "RawDataOrigin"
+	results, err := extractor.args.Extract(row)
+	if err != nil {
+		return errors.Default.Wrap(err, "error calling plugin Extract implementation")
+	}
+	for _, result := range results {
+		// get the batch operator for the specific type
+		batch, err := divider.ForType(reflect.TypeOf(result))
+		if err != nil {
+			return errors.Default.Wrap(err, "error getting batch from result")
+		}
+		// set raw data origin field
+		origin := reflect.ValueOf(result).Elem().FieldByName(RAW_DATA_ORIGIN)
+		if origin.IsValid() && origin.IsZero() {
+			origin.Set(reflect.ValueOf(common.RawDataOrigin{
+				RawDataTable:  extractor.table,
+				RawDataId:     row.ID,
+				RawDataParams: row.Params,
+			}))
+		}
+		// records get saved into db when slots were max outed
+		err = batch.Add(result)
+		if err != nil {
+			return errors.Default.Wrap(err, "error adding result to batch")
+		}
+	}
+	return nil
 }
 
 var _ plugin.SubTask = (*ApiExtractor)(nil)
@@ -20,7 +20,9 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
 	"reflect"
 	"time"
@@ -30,11 +32,15 @@ import (
 
 // RawData is raw data structure in DB storage
 type RawData struct {
-	ID        uint64 `gorm:"primaryKey"`
-	Params    string `gorm:"type:varchar(255);index"`
-	Data      []byte
-	Url       string
-	Input     datatypes.JSON
+	ID     uint64 `gorm:"primaryKey"`
+	Params string `gorm:"type:varchar(255);index"`
+	Data   []byte
+	Url    string
+	Input  datatypes.JSON
+	// Hash is the content hash of the payload, see RawDataHash
+	Hash string `gorm:"type:varchar(64);index"`
+	// BlobKey locates the payload in the blob store when it was too large to be kept in Data, see OffloadRawData
+	BlobKey   string `gorm:"type:varchar(255)"`
 	CreatedAt time.Time
 }
 
@@ -62,6 +68,7 @@ type RawDataSubTask struct {
 	args   *RawDataSubTaskArgs
 	table  string
 	params string
+	dedup  *rawDataDedup
 }
 
 // NewRawDataSubTask constructor for RawDataSubTask
@@ -105,3 +112,55 @@ func (r *RawDataSubTask) GetTable() string {
 func (r *RawDataSubTask) GetParams() string {
 	return r.params
 }
+
+// MigrateRawTable makes sure the raw table exists with the columns of RawData. The new tables are partitioned by
+// collection month, see PartitionRawTable, and the partitions of the current and next months are created ahead
+func (r *RawDataSubTask) MigrateRawTable() errors.Error {
+	db := r.args.Ctx.GetDal()
+	created := !db.HasTable(r.table)
+	err := db.AutoMigrate(&RawData{}, dal.From(r.table))
+	if err != nil {
+		return err
+	}
+	// the rows land in the catch-all partition when the partitions are missing, the collection can go on
+	if created {
+		err = PartitionRawTable(db, r.table)
+	} else {
+		err = EnsureRawTablePartitions(db, r.table, time.Now().AddDate(0, 1, 0))
+	}
+	if err != nil {
+		r.args.Ctx.GetLogger().Warn(err, "failed to partition %s", r.table)
+	}
+	return nil
+}
+
+// rawDataTaskContext returns the context tracking the raw data along the task, or nil for the standalone subtasks
+func (r *RawDataSubTask) rawDataTaskContext() plugin.RawDataTaskContext {
+	rawDataCtx, _ := r.args.Ctx.TaskContext().(plugin.RawDataTaskContext)
+	return rawDataCtx
+}
+
+// keepsRawData tells if the raw data should be kept once extracted, see models.RawDataRetention. The retentions are
+// only looked up for the subtasks run by a pipeline, the raw data of the standalone ones is always kept
+func (r *RawDataSubTask) keepsRawData() (bool, errors.Error) {
+	taskCtx := r.args.Ctx.TaskContext()
+	lineageCtx, ok := taskCtx.(plugin.LineageTaskContext)
+	if !ok || lineageCtx.GetLineage() == nil || r.params == "" || r.rawDataTaskContext() == nil {
+		return true, nil
+	}
+	db := r.args.Ctx.GetDal()
+	// the retention of the scope takes precedence over the one of the plugin, given by empty params
+	retention := &models.RawDataRetention{}
+	err := db.First(
+		retention,
+		dal.Where("plugin = ? AND raw_data_params IN ?", lineageCtx.GetLineage().Plugin, []string{r.params, ""}),
+		dal.Orderby("raw_data_params DESC"),
+	)
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return retention.KeepRawData, nil
+}
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// pipelineTaskContext stands for the context of a task run by a pipeline
+type pipelineTaskContext struct {
+	*mockplugin.TaskContext
+	*mockplugin.RawDataTaskContext
+	lineage *plugin.TaskLineage
+}
+
+func (c *pipelineTaskContext) GetLineage() *plugin.TaskLineage {
+	return c.lineage
+}
+
+func newRawDataSubTask(db *mockdal.Dal, taskCtx plugin.TaskContext, params string) *RawDataSubTask {
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetDal").Return(db)
+	mockCtx.On("TaskContext").Return(taskCtx)
+	return &RawDataSubTask{
+		args:   &RawDataSubTaskArgs{Ctx: mockCtx, Table: "github_api_issues"},
+		table:  "_raw_github_api_issues",
+		params: params,
+	}
+}
+
+func TestKeepsRawData(t *testing.T) {
+	params := `{"ConnectionId":1,"Name":"apache/incubator-devlake"}`
+	taskCtx := &pipelineTaskContext{
+		new(mockplugin.TaskContext),
+		new(mockplugin.RawDataTaskContext),
+		&plugin.TaskLineage{PipelineId: 1, TaskId: 2, Plugin: "github"},
+	}
+
+	// the raw data of the standalone subtasks is kept without looking up the retentions
+	db := new(mockdal.Dal)
+	keep, err := newRawDataSubTask(db, nil, params).keepsRawData()
+	assert.Nil(t, err)
+	assert.True(t, keep)
+	keep, err = newRawDataSubTask(db, &pipelineTaskContext{taskCtx.TaskContext, taskCtx.RawDataTaskContext, nil}, params).keepsRawData()
+	assert.Nil(t, err)
+	assert.True(t, keep)
+	keep, err = newRawDataSubTask(db, taskCtx, "").keepsRawData()
+	assert.Nil(t, err)
+	assert.True(t, keep)
+	db.AssertNotCalled(t, "First", mock.Anything, mock.Anything)
+
+	// the retention of the scope, or else the one of the plugin, is returned
+	db = new(mockdal.Dal)
+	db.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.RawDataRetention).KeepRawData = false
+	}).Return(nil).Once()
+	keep, err = newRawDataSubTask(db, taskCtx, params).keepsRawData()
+	assert.Nil(t, err)
+	assert.False(t, keep)
+
+	// the raw data is kept without a retention
+	notFound := errors.NotFound.New("record not found")
+	db = new(mockdal.Dal)
+	db.On("First", mock.Anything, mock.Anything).Return(notFound).Once()
+	db.On("IsErrorNotFound", notFound).Return(true).Once()
+	keep, err = newRawDataSubTask(db, taskCtx, params).keepsRawData()
+	assert.Nil(t, err)
+	assert.True(t, keep)
+
+	failure := errors.Default.New("lost connection")
+	db = new(mockdal.Dal)
+	db.On("First", mock.Anything, mock.Anything).Return(failure).Once()
+	db.On("IsErrorNotFound", failure).Return(false).Once()
+	_, err = newRawDataSubTask(db, taskCtx, params).keepsRawData()
+	assert.Equal(t, failure, err)
+}
+
+func TestApiExtractorDropsRawDataAfterTask(t *testing.T) {
+	params := `{"ConnectionId":1,"Name":"apache/incubator-devlake"}`
+	rawDataCtx := new(mockplugin.RawDataTaskContext)
+	rawDataCtx.On("IsRawDataRecollected", "_raw_github_api_issues", params).Return(false)
+	rawDataCtx.On("DropRawDataAfterTask", "_raw_github_api_issues", params, uint64(42)).Once()
+	taskCtx := &pipelineTaskContext{
+		new(mockplugin.TaskContext),
+		rawDataCtx,
+		&plugin.TaskLineage{PipelineId: 1, TaskId: 2, Plugin: "github"},
+	}
+
+	db := new(mockdal.Dal)
+	db.On("Count", mock.Anything).Return(int64(1), nil)
+	mockRows := new(mockdal.Rows)
+	mockRows.On("Next").Return(true).Once()
+	mockRows.On("Next").Return(false).Once()
+	mockRows.On("Close").Return(nil)
+	db.On("Cursor", mock.Anything).Return(mockRows, nil)
+	db.On("Fetch", mockRows, mock.Anything).Run(func(args mock.Arguments) {
+		row := args.Get(1).(*RawData)
+		row.ID = 42
+		row.Params = params
+		row.Data = []byte(`{}`)
+	}).Return(nil)
+	db.On("First", mock.Anything, mock.Anything).Return(nil)
+
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetDal").Return(db)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+	mockCtx.On("GetContext").Return(context.Background())
+	mockCtx.On("SetProgress", mock.Anything, mock.Anything)
+	mockCtx.On("IncProgress", mock.Anything)
+	mockCtx.On("TaskContext").Return(taskCtx)
+	extractor, err := NewApiExtractor(ApiExtractorArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:    mockCtx,
+			Table:  "github_api_issues",
+			Params: map[string]interface{}{"ConnectionId": 1, "Name": "apache/incubator-devlake"},
+		},
+		Extract: func(row *RawData) ([]interface{}, errors.Error) {
+			return nil, nil
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, extractor.Execute())
+	// the raw rows are left for the other subtasks, the task drops them once all of them ran
+	db.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	rawDataCtx.AssertExpectations(t)
+}
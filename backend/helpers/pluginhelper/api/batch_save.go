@@ -21,13 +21,20 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
 )
 
+// both mysql and postgres take up to 65535 placeholders in a statement
+const batchSaveMaxPlaceholders = 65535
+
 // BatchSave performs mulitple records persistence of a specific type in one sql query to improve the performance
 type BatchSave struct {
 	basicRes context.BasicRes
@@ -42,6 +49,19 @@ type BatchSave struct {
 	valueIndex map[string]int
 	primaryKey []reflect.StructField
 	tableName  string
+	// lineage is the run saving the records, it is nil unless the subtask is run by a pipeline
+	lineage *plugin.TaskLineage
+	subtask string
+	// origins of the saved records, true once their lineage is recorded
+	origins map[common.RawDataOrigin]bool
+	// statementSize is the records saved by a statement, the placeholders of a statement are limited
+	statementSize int
+	// insertFirst inserts the records without upserting them, until one of them turns out to exist already
+	insertFirst bool
+	// async flushes the records in the background while the next ones are added, only one flush runs at a time
+	async bool
+	// flushing receives the result of the flush running in the background
+	flushing chan errors.Error
 }
 
 // NewBatchSave creates a new BatchSave instance
@@ -61,7 +81,7 @@ func NewBatchSave(basicRes context.BasicRes, slotType reflect.Type, size int, ta
 	}
 
 	logger := basicRes.GetLogger().Nested(slotType.String())
-	return &BatchSave{
+	batch := &BatchSave{
 		basicRes:   basicRes,
 		log:        logger,
 		db:         db,
@@ -71,7 +91,24 @@ func NewBatchSave(basicRes context.BasicRes, slotType reflect.Type, size int, ta
 		valueIndex: make(map[string]int),
 		primaryKey: primaryKey,
 		tableName:  tn,
-	}, nil
+		origins:    make(map[common.RawDataOrigin]bool),
+	}
+	batch.statementSize = size
+	if columns := countColumns(slotType.Elem()); columns > 0 && batchSaveMaxPlaceholders/columns < size {
+		batch.statementSize = batchSaveMaxPlaceholders / columns
+	}
+	if subtaskCtx, ok := basicRes.(plugin.SubTaskContext); ok {
+		if lineageCtx, ok := subtaskCtx.TaskContext().(plugin.LineageTaskContext); ok && lineageCtx.GetLineage() != nil {
+			batch.lineage = lineageCtx.GetLineage()
+			batch.subtask = subtaskCtx.GetName()
+			if batch.tableName == "" {
+				if tabler, ok := reflect.New(slotType.Elem()).Interface().(dal.Tabler); ok {
+					batch.tableName = tabler.TableName()
+				}
+			}
+		}
+	}
+	return batch, nil
 }
 
 // Add record to cache. BatchSave would flush them into Database when cache is max out
@@ -96,42 +133,138 @@ func (c *BatchSave) Add(slot interface{}) errors.Error {
 	}
 	c.slots.Index(c.current).Set(reflect.ValueOf(slot))
 	c.current++
+	if c.lineage != nil {
+		if getRawDataOrigin, ok := slot.(common.GetRawDataOrigin); ok {
+			origin := common.RawDataOrigin{
+				RawDataTable:  getRawDataOrigin.GetRawDataOrigin().RawDataTable,
+				RawDataParams: getRawDataOrigin.GetRawDataOrigin().RawDataParams,
+			}
+			if _, ok := c.origins[origin]; !ok && origin.RawDataTable != "" {
+				c.origins[origin] = false
+			}
+		}
+	}
 	// flush out into database if max outed
 	if c.current == c.size {
-		return c.Flush()
+		return c.flush()
 	} else if c.current%100 == 0 {
 		c.log.Debug("batch save current: %d", c.current)
 	}
 	return nil
 }
 
-// Flush save cached records into database
+// Flush save cached records into database, it returns once they are saved
 func (c *BatchSave) Flush() errors.Error {
+	err := c.flush()
+	if err != nil {
+		return err
+	}
+	return c.wait()
+}
+
+// flush saves the cached records, in the background if async, the cache is emptied for the next records
+func (c *BatchSave) flush() errors.Error {
 	if c.current == 0 {
 		return nil
 	}
-	clauses := make([]dal.Clause, 0)
-	if c.tableName != "" {
-		clauses = append(clauses, dal.From(c.tableName))
-	}
-	err := c.db.CreateOrUpdate(c.slots.Slice(0, c.current).Interface(), clauses...)
+	// the previous flush must be done before the next, the records of both may share primary keys
+	err := c.wait()
 	if err != nil {
 		return err
 	}
-	c.log.Debug("batch save flush total %d records to database", c.current)
+	slots := c.slots.Slice(0, c.current)
+	count := c.current
+	// the lineage of an origin is recorded along with the first records of it
+	var origins []common.RawDataOrigin
+	for origin, saved := range c.origins {
+		if !saved {
+			origins = append(origins, origin)
+			c.origins[origin] = true
+		}
+	}
 	c.current = 0
 	c.valueIndex = make(map[string]int)
+	if !c.async {
+		return c.save(slots, count, origins)
+	}
+	// the slots being saved are left to the background flush
+	c.slots = reflect.MakeSlice(reflect.SliceOf(c.slotType), c.size, c.size)
+	c.flushing = make(chan errors.Error, 1)
+	go func(flushing chan errors.Error) {
+		flushing <- c.save(slots, count, origins)
+	}(c.flushing)
 	return nil
 }
 
-// Close would flash the cache and release resources
-func (c *BatchSave) Close() errors.Error {
-	if c.current > 0 {
-		return c.Flush()
+// wait returns the result of the flush running in the background, if any
+func (c *BatchSave) wait() errors.Error {
+	if c.flushing == nil {
+		return nil
+	}
+	err := <-c.flushing
+	c.flushing = nil
+	return err
+}
+
+// save writes the records by multi-row statements of statementSize records
+func (c *BatchSave) save(slots reflect.Value, count int, origins []common.RawDataOrigin) errors.Error {
+	clauses := make([]dal.Clause, 0)
+	if c.tableName != "" {
+		clauses = append(clauses, dal.From(c.tableName))
+	}
+	begin := time.Now()
+	for start := 0; start < count; start += c.statementSize {
+		end := start + c.statementSize
+		if end > count {
+			end = count
+		}
+		records := slots.Slice(start, end).Interface()
+		if c.insertFirst {
+			err := c.db.Create(records, clauses...)
+			if err == nil {
+				continue
+			}
+			if !c.db.IsDuplicationError(err) {
+				return err
+			}
+			// some records exist already, the upsert takes over for the rest of the records
+			c.log.Debug("batch save falls back to upserts: %s", err.Error())
+			c.insertFirst = false
+		}
+		err := c.db.CreateOrUpdate(records, clauses...)
+		if err != nil {
+			return err
+		}
+	}
+	c.log.Debug("batch save flush total %d records to database in %s", count, time.Since(begin))
+	return c.saveLineages(origins)
+}
+
+// saveLineages stamps the records saved so far with the run, by their raw data origin
+func (c *BatchSave) saveLineages(origins []common.RawDataOrigin) errors.Error {
+	for _, origin := range origins {
+		err := c.db.CreateOrUpdate(&models.RowLineage{
+			TargetTable:   c.tableName,
+			RawDataTable:  origin.RawDataTable,
+			RawDataParams: origin.RawDataParams,
+			PipelineId:    c.lineage.PipelineId,
+			TaskId:        c.lineage.TaskId,
+			Plugin:        c.lineage.Plugin,
+			PluginVersion: c.lineage.PluginVersion,
+			Subtask:       c.subtask,
+		})
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// Close would flash the cache and release resources
+func (c *BatchSave) Close() errors.Error {
+	return c.Flush()
+}
+
 func getKeyValue(iface interface{}, primaryKey []reflect.StructField) string {
 	var ss []string
 	ifv := reflect.ValueOf(iface)
@@ -147,3 +280,23 @@ func getKeyValue(iface interface{}, primaryKey []reflect.StructField) string {
 	}
 	return strings.Join(ss, ":")
 }
+
+// countColumns returns the columns of the fields of a model, the embedded structs included
+func countColumns(t reflect.Type) int {
+	if t.Kind() != reflect.Struct {
+		return 0
+	}
+	count := 0
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("gorm") == "-" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			count += countColumns(field.Type)
+			continue
+		}
+		count++
+	}
+	return count
+}
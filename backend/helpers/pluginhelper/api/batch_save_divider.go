@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -38,11 +39,21 @@ type BatchSaveDivider struct {
 	batchSize int
 	table     string
 	params    string
+	async     bool
 }
 
-// NewBatchSaveDivider create a new BatchInsertDivider instance
+// NewBatchSaveDivider create a new BatchInsertDivider instance, BATCH_SAVE_SIZE overrides the batchSize of all the
+// dividers and BATCH_SAVE_ASYNC=false flushes the batches in the foreground
 func NewBatchSaveDivider(basicRes context.BasicRes, batchSize int, table string, params string) *BatchSaveDivider {
 	logger := basicRes.GetLogger().Nested("batch divider")
+	v := config.GetConfig()
+	if size := v.GetInt("BATCH_SAVE_SIZE"); size > 0 {
+		batchSize = size
+	}
+	async := true
+	if v.IsSet("BATCH_SAVE_ASYNC") {
+		async = v.GetBool("BATCH_SAVE_ASYNC")
+	}
 	return &BatchSaveDivider{
 		basicRes:  basicRes,
 		log:       logger,
@@ -51,6 +62,7 @@ func NewBatchSaveDivider(basicRes context.BasicRes, batchSize int, table string,
 		batchSize: batchSize,
 		table:     table,
 		params:    params,
+		async:     async,
 	}
 }
 
@@ -65,6 +77,7 @@ func (d *BatchSaveDivider) ForType(rowType reflect.Type) (*BatchSave, errors.Err
 		if err != nil {
 			return nil, err
 		}
+		batch.async = d.async
 		d.batches[rowType] = batch
 		// delete outdated records if rowType was not PartialUpdate
 		rowElemType := rowType.Elem()
@@ -85,6 +98,8 @@ func (d *BatchSaveDivider) ForType(rowType reflect.Type) (*BatchSave, errors.Err
 			if err != nil {
 				return nil, err
 			}
+			// none of the records of the params is left, the records are new unless shared with other params
+			batch.insertFirst = true
 		}
 	}
 	return batch, nil
@@ -92,11 +107,13 @@ func (d *BatchSaveDivider) ForType(rowType reflect.Type) (*BatchSave, errors.Err
 
 // Close all batches so the rest records get saved into db
 func (d *BatchSaveDivider) Close() errors.Error {
+	var firstErr errors.Error
+	// every batch must be closed, their flushes may still be running in the background
 	for _, batch := range d.batches {
 		err := batch.Close()
-		if err != nil {
-			return err
+		if err != nil && firstErr == nil {
+			firstErr = err
 		}
 	}
-	return nil
+	return firstErr
 }
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLineageRecord struct {
+	common.RawDataOrigin
+	Id string `gorm:"primaryKey"`
+}
+
+func (MockLineageRecord) TableName() string {
+	return "mock_lineage_records"
+}
+
+type mockLineageTaskContext struct {
+	*mockplugin.TaskContext
+	lineage *plugin.TaskLineage
+}
+
+func (c *mockLineageTaskContext) GetLineage() *plugin.TaskLineage {
+	return c.lineage
+}
+
+func TestBatchSaveLineage(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetDal").Return(mockDal)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+	mockCtx.On("GetName").Return("convertMocks")
+	mockCtx.On("TaskContext").Return(&mockLineageTaskContext{
+		TaskContext: new(mockplugin.TaskContext),
+		lineage:     &plugin.TaskLineage{PipelineId: 1, TaskId: 2, Plugin: "mock", PluginVersion: "v0.17.0"},
+	})
+	mockDal.On("GetPrimaryKeyFields", mock.Anything).Return(
+		[]reflect.StructField{
+			{Name: "Id", Type: reflect.TypeOf("")},
+		},
+	)
+	var lineages []*models.RowLineage
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		if lineage, ok := args.Get(0).(*models.RowLineage); ok {
+			lineages = append(lineages, lineage)
+		}
+	}).Return(nil)
+
+	batch, err := NewBatchSave(mockCtx, reflect.TypeOf(&MockLineageRecord{}), 2)
+	assert.Nil(t, err)
+	origin := common.RawDataOrigin{RawDataTable: "_raw_mock", RawDataParams: "{}", RawDataId: 1}
+	for _, id := range []string{"a", "b", "c"} {
+		assert.Nil(t, batch.Add(&MockLineageRecord{RawDataOrigin: origin, Id: id}))
+	}
+	assert.Nil(t, batch.Close())
+
+	// the records sharing the same origin are stamped once, whatever the number of flushes
+	assert.Equal(t, []*models.RowLineage{
+		{
+			TargetTable:   "mock_lineage_records",
+			RawDataTable:  "_raw_mock",
+			RawDataParams: "{}",
+			PipelineId:    1,
+			TaskId:        2,
+			Plugin:        "mock",
+			PluginVersion: "v0.17.0",
+			Subtask:       "convertMocks",
+		},
+	}, lineages)
+	mockDal.AssertNumberOfCalls(t, "CreateOrUpdate", 3)
+}
+
+func TestBatchSaveInsertFirst(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	mockDal.On("GetPrimaryKeyFields", mock.Anything).Return(
+		[]reflect.StructField{
+			{Name: "Id", Type: reflect.TypeOf("")},
+		},
+	)
+	duplication := errors.Default.New("duplicate key")
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(duplication).Once()
+	mockDal.On("IsDuplicationError", duplication).Return(true)
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
+
+	batch, err := NewBatchSave(mockRes, reflect.TypeOf(&MockLineageRecord{}), 2)
+	assert.Nil(t, err)
+	batch.insertFirst = true
+	batch.async = true
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		assert.Nil(t, batch.Add(&MockLineageRecord{Id: id}))
+	}
+	assert.Nil(t, batch.Close())
+
+	// the records are inserted until some of them exist, the upserts take over from then on
+	mockDal.AssertNumberOfCalls(t, "Create", 2)
+	mockDal.AssertNumberOfCalls(t, "CreateOrUpdate", 2)
+}
+
+func TestCountColumns(t *testing.T) {
+	assert.Equal(t, 5, countColumns(reflect.TypeOf(MockLineageRecord{})))
+	assert.Equal(t, 0, countColumns(reflect.TypeOf("")))
+}
@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// collectorHttpCache holds the validators of the last responses to the requests of a collector, see
+// models.CollectorHttpCache. It is shared by the concurrent response handlers of the collector
+type collectorHttpCache struct {
+	mu          sync.Mutex
+	db          dal.Dal
+	table       string
+	params      string
+	entries     map[string]*models.CollectorHttpCache
+	notModified int64
+}
+
+// loadCollectorHttpCache loads the validators stored for the raw table and params
+func loadCollectorHttpCache(db dal.Dal, table, params string) (*collectorHttpCache, errors.Error) {
+	var entries []*models.CollectorHttpCache
+	err := db.All(&entries, dal.Where("raw_data_table = ? AND raw_data_params = ?", table, params))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading the collector http cache")
+	}
+	cache := &collectorHttpCache{
+		db:      db,
+		table:   table,
+		params:  params,
+		entries: make(map[string]*models.CollectorHttpCache, len(entries)),
+	}
+	for _, entry := range entries {
+		cache.entries[entry.UrlHash] = entry
+	}
+	return cache, nil
+}
+
+// key identifies the request along with the raw table and params, the same url may be collected for several scopes
+func (c *collectorHttpCache) key(method, url string) string {
+	h := sha256.New()
+	for _, part := range []string{c.table, c.params, method, url} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// conditional returns a copy of the header with the validators stored for the request, along with the stored entry,
+// or the header as is and nil when there are none
+func (c *collectorHttpCache) conditional(key string, header http.Header) (http.Header, *models.CollectorHttpCache) {
+	c.mu.Lock()
+	entry := c.entries[key]
+	c.mu.Unlock()
+	if entry == nil {
+		return header, nil
+	}
+	conditional := header.Clone()
+	if conditional == nil {
+		conditional = http.Header{}
+	}
+	if entry.ETag != "" {
+		conditional.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		conditional.Set("If-Modified-Since", entry.LastModified)
+	}
+	return conditional, entry
+}
+
+// save stores the validators of the response, if any, with the number of records it was parsed into
+func (c *collectorHttpCache) save(key, url string, res *http.Response, items int) errors.Error {
+	etag := res.Header.Get("ETag")
+	lastModified := res.Header.Get("Last-Modified")
+	if res.StatusCode != http.StatusOK || etag == "" && lastModified == "" {
+		return nil
+	}
+	entry := &models.CollectorHttpCache{
+		UrlHash:       key,
+		RawDataTable:  c.table,
+		RawDataParams: c.params,
+		Url:           url,
+		ETag:          etag,
+		LastModified:  lastModified,
+		Items:         items,
+	}
+	c.mu.Lock()
+	if prev := c.entries[key]; prev != nil {
+		entry.CreatedAt = prev.CreatedAt
+	}
+	c.entries[key] = entry
+	c.mu.Unlock()
+	err := c.db.CreateOrUpdate(entry)
+	if err != nil {
+		return errors.Default.Wrap(err, "error saving the collector http cache")
+	}
+	return nil
+}
+
+// hit counts a response not modified since the stored one
+func (c *collectorHttpCache) hit() {
+	atomic.AddInt64(&c.notModified, 1)
+}
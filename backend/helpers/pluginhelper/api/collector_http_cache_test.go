@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorHttpCacheConditional(t *testing.T) {
+	cache := &collectorHttpCache{
+		table:   "_raw_github_api_pull_requests",
+		params:  `{"ConnectionId":1,"Name":"apache/incubator-devlake"}`,
+		entries: map[string]*models.CollectorHttpCache{},
+	}
+	key := cache.key(http.MethodGet, "repos/apache/incubator-devlake/pulls?page=1")
+	assert.NotEqual(t, key, cache.key(http.MethodGet, "repos/apache/incubator-devlake/pulls?page=2"))
+	other := &collectorHttpCache{table: cache.table, params: `{"ConnectionId":2,"Name":"apache/incubator-devlake"}`}
+	assert.NotEqual(t, key, other.key(http.MethodGet, "repos/apache/incubator-devlake/pulls?page=1"))
+
+	header := http.Header{"Accept": []string{"application/json"}}
+	conditional, entry := cache.conditional(key, header)
+	assert.Nil(t, entry)
+	assert.Equal(t, header, conditional)
+
+	cache.entries[key] = &models.CollectorHttpCache{ETag: `W/"abc"`, LastModified: "Mon, 24 Jul 2023 10:00:00 GMT", Items: 100}
+	conditional, entry = cache.conditional(key, header)
+	assert.Equal(t, 100, entry.Items)
+	assert.Equal(t, `W/"abc"`, conditional.Get("If-None-Match"))
+	assert.Equal(t, "Mon, 24 Jul 2023 10:00:00 GMT", conditional.Get("If-Modified-Since"))
+	assert.Equal(t, "application/json", conditional.Get("Accept"))
+	// the header of the collector is left untouched
+	assert.Equal(t, "", header.Get("If-None-Match"))
+
+	conditional, _ = cache.conditional(key, nil)
+	assert.Equal(t, `W/"abc"`, conditional.Get("If-None-Match"))
+
+	// the responses without validators are not stored
+	assert.Nil(t, cache.save(key, "url", &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, 10))
+	assert.Equal(t, 100, cache.entries[key].Items)
+}
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// LoadCollectorState loads the incremental collection state of the stream (the raw table) of the scope (the raw data params),
+// a blank state is returned if the stream was never collected or its state was reset
+func LoadCollectorState(db dal.Dal, table, params string) (*models.CollectorLatestState, errors.Error) {
+	state := &models.CollectorLatestState{}
+	err := db.First(state, dal.Where(`raw_data_table = ? AND raw_data_params = ?`, table, params))
+	if err != nil {
+		if !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, "failed to load the collector state")
+		}
+		state = &models.CollectorLatestState{
+			RawDataTable:  table,
+			RawDataParams: params,
+		}
+	}
+	return state, nil
+}
+
+// DecodeCollectorCursor decodes the cursor of the state into dst, false is returned if no cursor was stored
+func DecodeCollectorCursor(state *models.CollectorLatestState, dst interface{}) (bool, errors.Error) {
+	if state.Cursor == "" {
+		return false, nil
+	}
+	err := json.Unmarshal([]byte(state.Cursor), dst)
+	if err != nil {
+		return false, errors.Default.Wrap(err, "failed to decode the collector cursor")
+	}
+	return true, nil
+}
+
+// EncodeCollectorCursor stores the cursor into the state, a nil cursor clears it
+func EncodeCollectorCursor(state *models.CollectorLatestState, cursor interface{}) errors.Error {
+	if cursor == nil {
+		state.Cursor = ""
+		return nil
+	}
+	blob, err := json.Marshal(cursor)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to encode the collector cursor")
+	}
+	state.Cursor = string(blob)
+	return nil
+}
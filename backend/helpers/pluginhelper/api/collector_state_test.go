@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorCursor(t *testing.T) {
+	type cursor struct {
+		UpdatedAfter string `json:"updatedAfter"`
+		Page         int    `json:"page"`
+	}
+	state := &models.CollectorLatestState{}
+
+	var got cursor
+	found, err := DecodeCollectorCursor(state, &got)
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	assert.Nil(t, EncodeCollectorCursor(state, cursor{UpdatedAfter: "2023-07-01", Page: 3}))
+	found, err = DecodeCollectorCursor(state, &got)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, cursor{UpdatedAfter: "2023-07-01", Page: 3}, got)
+
+	assert.Nil(t, EncodeCollectorCursor(state, nil))
+	assert.Equal(t, "", state.Cursor)
+
+	state.Cursor = "{"
+	_, err = DecodeCollectorCursor(state, &got)
+	assert.NotNil(t, err)
+}
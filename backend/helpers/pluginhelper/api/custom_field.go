@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+)
+
+// customFieldObjectKeys are the keys holding the readable value of the options, users and versions picked by a field
+var customFieldObjectKeys = []string{"value", "name", "displayName", "key"}
+
+// NewCustomField types the JSON value of a custom field kept by the tool layer. The strings in one of the formats of
+// Iso8601Time are dates, the objects are reduced to their value, name or key and the arrays are joined by commas.
+// It returns nil for null or empty values, so that only the fields set on the entity are kept
+func NewCustomField(entityType string, entityId string, fieldName string, value json.RawMessage) (*crossdomain.CustomField, errors.Error) {
+	var decoded interface{}
+	err := json.Unmarshal(value, &decoded)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to decode the value of the custom field "+fieldName)
+	}
+	customField := &crossdomain.CustomField{
+		EntityType: entityType,
+		EntityId:   entityId,
+		FieldName:  fieldName,
+	}
+	switch v := decoded.(type) {
+	case float64:
+		customField.FieldType = crossdomain.CUSTOM_FIELD_TYPE_NUMBER
+		customField.NumberValue = &v
+		return customField, nil
+	case string:
+		if date, err := ConvertStringToTime(v); err == nil {
+			customField.FieldType = crossdomain.CUSTOM_FIELD_TYPE_DATE
+			customField.DateValue = &date
+			return customField, nil
+		}
+	}
+	customField.StringValue = customFieldString(decoded)
+	if customField.StringValue == "" {
+		return nil, nil
+	}
+	customField.FieldType = crossdomain.CUSTOM_FIELD_TYPE_STRING
+	return customField, nil
+}
+
+func customFieldString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			if s := customFieldString(item); s != "" {
+				items = append(items, s)
+			}
+		}
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		for _, key := range customFieldObjectKeys {
+			if s, ok := v[key].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	blob, _ := json.Marshal(value)
+	return string(blob)
+}
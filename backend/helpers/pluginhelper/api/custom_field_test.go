@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCustomField(t *testing.T) {
+	newCustomField := func(value string) *crossdomain.CustomField {
+		customField, err := NewCustomField("issues", "jira:JiraIssue:1:10001", "team", json.RawMessage(value))
+		assert.Nil(t, err)
+		return customField
+	}
+	number := 3.5
+	date := time.Date(2020, 6, 12, 0, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, newCustomField(`null`))
+	assert.Nil(t, newCustomField(`""`))
+	assert.Nil(t, newCustomField(`[]`))
+	assert.Equal(t, &crossdomain.CustomField{
+		EntityType:  "issues",
+		EntityId:    "jira:JiraIssue:1:10001",
+		FieldName:   "team",
+		FieldType:   crossdomain.CUSTOM_FIELD_TYPE_NUMBER,
+		NumberValue: &number,
+	}, newCustomField(`3.5`))
+	assert.Equal(t, &crossdomain.CustomField{
+		EntityType: "issues",
+		EntityId:   "jira:JiraIssue:1:10001",
+		FieldName:  "team",
+		FieldType:  crossdomain.CUSTOM_FIELD_TYPE_DATE,
+		DateValue:  &date,
+	}, newCustomField(`"2020-06-12"`))
+	assert.Equal(t, "Platform", newCustomField(`"Platform"`).StringValue)
+	assert.Equal(t, "Platform", newCustomField(`{"self": "https://jira/option/1", "value": "Platform", "id": "1"}`).StringValue)
+	assert.Equal(t, "Platform,Payments", newCustomField(`[{"value": "Platform"}, {"value": "Payments"}]`).StringValue)
+	assert.Equal(t, "true", newCustomField(`true`).StringValue)
+	assert.Equal(t, `{"id":1}`, newCustomField(`{"id": 1}`).StringValue)
+	assert.Equal(t, crossdomain.CUSTOM_FIELD_TYPE_STRING, newCustomField(`"0|i000db:"`).FieldType)
+
+	_, err := NewCustomField("issues", "jira:JiraIssue:1:10001", "team", json.RawMessage(`{`))
+	assert.NotNil(t, err)
+}
@@ -130,15 +130,15 @@ func (collector *GraphqlCollector) Execute() errors.Error {
 
 	// make sure table is created
 	db := collector.args.Ctx.GetDal()
-	err := db.AutoMigrate(&RawData{}, dal.From(collector.table))
+	err := collector.MigrateRawTable()
 	if err != nil {
 		return errors.Default.Wrap(err, "error running auto-migrate")
 	}
 
 	divider := NewBatchSaveDivider(collector.args.Ctx, collector.args.BatchSize, collector.table, collector.params)
 
-	// flush data if not incremental collection
-	if collector.args.Incremental {
+	// flush data if not incremental collection, a backfill is merged into the existing data instead
+	if collector.args.Incremental || BackfillOf(collector.args.Ctx) != nil {
 		// re extract data for new transformation rules
 		err = collector.ExtractExistRawData(divider)
 		if err != nil {
@@ -149,6 +149,9 @@ func (collector *GraphqlCollector) Execute() errors.Error {
 		if err != nil {
 			return errors.Default.Wrap(err, "error deleting data from collector")
 		}
+		if rawDataCtx := collector.rawDataTaskContext(); rawDataCtx != nil {
+			rawDataCtx.SetRawDataRecollected(collector.table, collector.params)
+		}
 	}
 
 	collector.args.Ctx.SetProgress(0, -1)
@@ -321,6 +324,10 @@ func (collector *GraphqlCollector) ExtractExistRawData(divider *BatchSaveDivider
 		if err != nil {
 			return errors.Default.Wrap(err, "error fetching row")
 		}
+		err = LoadRawData(ctx, row)
+		if err != nil {
+			return err
+		}
 
 		err = errors.Convert(json.Unmarshal(row.Data, &query))
 		if err != nil {
@@ -409,6 +416,11 @@ func (collector *GraphqlCollector) fetchAsync(divider *BatchSaveDivider, reqData
 		Url:    queryStr,
 		Input:  variablesJson,
 	}
+	offloadErr := OffloadRawData(collector.args.Ctx.GetContext(), collector.table, []*RawData{row})
+	if offloadErr != nil {
+		collector.checkError(offloadErr)
+		return
+	}
 	err = db.Create(row, dal.From(collector.table))
 	if err != nil {
 		collector.checkError(errors.Default.Wrap(err, `not created row table in graphql collector`))
@@ -68,6 +68,11 @@ func init() {
 			Matcher: regexp.MustCompile(`[\d]{4}-[\d]{2}-[\d]{2} [\d]{2}:[\d]{2}:[\d]{2}$`),
 			Format:  "2006-01-02 15:04:05",
 		},
+		{
+			// gerrit returns timestamps in UTC with nanoseconds
+			Matcher: regexp.MustCompile(`[\d]{4}-[\d]{2}-[\d]{2} [\d]{2}:[\d]{2}:[\d]{2}\.[\d]{9}$`),
+			Format:  "2006-01-02 15:04:05.000000000",
+		},
 		{
 			Matcher: regexp.MustCompile(`[+-][\d]{2}-[\d]{2}$`),
 			Format:  "2006-01-02",
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// DefaultMaxJsonItemSize is the largest item of a json array decoded by StreamJsonArray by default
+const DefaultMaxJsonItemSize = 64 << 20
+
+// the json.Decoder reads ahead of the item it decodes by its buffer
+const jsonStreamReadAhead = 64 << 10
+
+var errJsonItemTooLarge = fmt.Errorf("json item too large")
+
+// jsonItemReader stops the decoder from reading further than the limit, so the buffer of the decoder is bounded by
+// the size of an item
+type jsonItemReader struct {
+	r     io.Reader
+	read  int64
+	limit int64
+}
+
+func (r *jsonItemReader) Read(p []byte) (int, error) {
+	if r.read >= r.limit {
+		return 0, errJsonItemTooLarge
+	}
+	if int64(len(p)) > r.limit-r.read {
+		p = p[:r.limit-r.read]
+	}
+	n, err := r.r.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+// StreamJsonArray decodes the items of the json array under the keys of the path one at a time, the array is the
+// whole document when the path is empty, i.e. the path is ["issues"] for {"total": 2, "issues": [{...}, {...}]}. Only
+// one item is held in memory, an item larger than maxItemSize, DefaultMaxJsonItemSize if 0, fails the decoding. A
+// missing array or a null is an empty array
+func StreamJsonArray(r io.Reader, path []string, maxItemSize int, handle func(item json.RawMessage) errors.Error) errors.Error {
+	if maxItemSize <= 0 {
+		maxItemSize = DefaultMaxJsonItemSize
+	}
+	reader := &jsonItemReader{r: r, limit: int64(maxItemSize) + jsonStreamReadAhead}
+	decoder := json.NewDecoder(reader)
+	found, err := seekJsonPath(decoder, reader, path, int64(maxItemSize))
+	if err != nil || !found {
+		return err
+	}
+	for decoder.More() {
+		reader.limit = decoder.InputOffset() + int64(maxItemSize) + jsonStreamReadAhead
+		var item json.RawMessage
+		if e := decoder.Decode(&item); e != nil {
+			return jsonStreamError(e, maxItemSize)
+		}
+		if err = handle(item); err != nil {
+			return err
+		}
+	}
+	// the closing bracket
+	_, e := decoder.Token()
+	return jsonStreamError(e, maxItemSize)
+}
+
+// seekJsonPath moves the decoder into the array under the keys of the path, the values of the other keys are skipped
+func seekJsonPath(decoder *json.Decoder, reader *jsonItemReader, path []string, maxItemSize int64) (bool, errors.Error) {
+	for depth := 0; ; depth++ {
+		token, e := decoder.Token()
+		if e != nil {
+			return false, jsonStreamError(e, int(maxItemSize))
+		}
+		if token == nil {
+			return false, nil
+		}
+		delim, ok := token.(json.Delim)
+		if depth == len(path) {
+			if !ok || delim != '[' {
+				return false, errors.Default.New(fmt.Sprintf("expected an array, got %v", token))
+			}
+			return true, nil
+		}
+		if !ok || delim != '{' {
+			return false, errors.Default.New(fmt.Sprintf("expected an object with the key %s, got %v", path[depth], token))
+		}
+		for {
+			if !decoder.More() {
+				return false, nil
+			}
+			reader.limit = decoder.InputOffset() + maxItemSize + jsonStreamReadAhead
+			key, e := decoder.Token()
+			if e != nil {
+				return false, jsonStreamError(e, int(maxItemSize))
+			}
+			if key == path[depth] {
+				break
+			}
+			var skipped json.RawMessage
+			if e = decoder.Decode(&skipped); e != nil {
+				return false, jsonStreamError(e, int(maxItemSize))
+			}
+		}
+	}
+}
+
+func jsonStreamError(err error, maxItemSize int) errors.Error {
+	if err == nil {
+		return nil
+	}
+	if err == errJsonItemTooLarge {
+		return errors.Default.New(fmt.Sprintf("json item larger than %d bytes", maxItemSize))
+	}
+	return errors.Default.Wrap(err, "error decoding json stream")
+}
+
+// StreamRawMessageArrayFromResponse returns a ResponseStreamer decoding the items of the json array under the keys of
+// the path one at a time, the whole page is never unmarshalled at once
+func StreamRawMessageArrayFromResponse(path ...string) func(res *http.Response, handle func(item json.RawMessage) errors.Error) errors.Error {
+	return func(res *http.Response, handle func(item json.RawMessage) errors.Error) errors.Error {
+		if res == nil {
+			return errors.Default.New("res is nil")
+		}
+		defer res.Body.Close()
+		err := StreamJsonArray(res.Body, path, 0, handle)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error decoding response of %s", res.Request.URL.String()))
+		}
+		return nil
+	}
+}
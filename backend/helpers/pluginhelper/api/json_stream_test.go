@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func streamJsonArray(body string, path []string, maxItemSize int) ([]string, errors.Error) {
+	items := make([]string, 0)
+	err := StreamJsonArray(strings.NewReader(body), path, maxItemSize, func(item json.RawMessage) errors.Error {
+		items = append(items, string(item))
+		return nil
+	})
+	return items, err
+}
+
+func TestStreamJsonArray(t *testing.T) {
+	items, err := streamJsonArray(`[{"id":1},{"id":2}]`, nil, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`{"id":1}`, `{"id":2}`}, items)
+
+	items, err = streamJsonArray(`{"total":2,"names":{"issues":[]},"issues":[{"id":1,"fields":{"labels":["a"]}},{"id":2}],"maxResults":50}`, []string{"issues"}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`{"id":1,"fields":{"labels":["a"]}}`, `{"id":2}`}, items)
+
+	items, err = streamJsonArray(`{"data":{"values":[1,2,3]}}`, []string{"data", "values"}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, items)
+
+	// a missing array is empty
+	items, err = streamJsonArray(`{"total":0}`, []string{"issues"}, 0)
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+	items, err = streamJsonArray(`{"issues":null}`, []string{"issues"}, 0)
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+
+	_, err = streamJsonArray(`{"issues":{}}`, []string{"issues"}, 0)
+	assert.NotNil(t, err)
+	_, err = streamJsonArray(`[{"id":1},`, nil, 0)
+	assert.NotNil(t, err)
+}
+
+func TestStreamJsonArrayMaxItemSize(t *testing.T) {
+	large := `{"description":"` + strings.Repeat("x", 1<<20) + `"}`
+	items, err := streamJsonArray(`[`+large+`,{"id":2}]`, nil, 2<<20)
+	assert.Nil(t, err)
+	assert.Len(t, items, 2)
+
+	_, err = streamJsonArray(`[{"id":1},`+large+`]`, nil, 1024)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "json item larger than 1024 bytes")
+}
@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// the texts a link rule can be applied to
+const (
+	LINK_SOURCE_PR_TITLE       = "PR_TITLE"
+	LINK_SOURCE_PR_DESCRIPTION = "PR_DESCRIPTION"
+	LINK_SOURCE_PR_BRANCH      = "PR_BRANCH"
+	LINK_SOURCE_COMMIT_MESSAGE = "COMMIT_MESSAGE"
+)
+
+// LinkRule extracts issue keys from the texts of the given sources. The issue key is the named group `key` of
+// Pattern if present, the first group otherwise. When KeyPattern is set, the issue keys are the first group of
+// every match of KeyPattern within the text matched by Pattern instead, so that a list of issues can be linked.
+type LinkRule struct {
+	Name       string   `json:"name" mapstructure:"name"`
+	Sources    []string `json:"sources" mapstructure:"sources"`
+	Pattern    string   `json:"pattern" mapstructure:"pattern"`
+	KeyPattern string   `json:"keyPattern" mapstructure:"keyPattern"`
+	Confidence float64  `json:"confidence" mapstructure:"confidence"`
+}
+
+// Link is an issue key found in a text
+type Link struct {
+	IssueKey   string  `json:"issueKey"`
+	Confidence float64 `json:"confidence"`
+	Rule       string  `json:"rule"`
+}
+
+type compiledLinkRule struct {
+	LinkRule
+	re       *regexp.Regexp
+	keyRe    *regexp.Regexp
+	keyGroup int
+}
+
+// LinkEngine applies a set of rules to texts
+type LinkEngine struct {
+	rules map[string][]*compiledLinkRule
+}
+
+// NewLinkEngine compiles the rules, it fails if any of them is invalid
+func NewLinkEngine(rules []LinkRule) (*LinkEngine, errors.Error) {
+	engine := &LinkEngine{rules: make(map[string][]*compiledLinkRule)}
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid pattern of rule %s", rule.Name))
+		}
+		compiled := &compiledLinkRule{LinkRule: rule, re: re}
+		if rule.KeyPattern != "" {
+			compiled.keyRe, err = regexp.Compile(rule.KeyPattern)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid key pattern of rule %s", rule.Name))
+			}
+			if compiled.keyRe.NumSubexp() == 0 {
+				return nil, errors.BadInput.New(fmt.Sprintf("key pattern of rule %s must have a group capturing the issue key", rule.Name))
+			}
+		} else {
+			if re.NumSubexp() == 0 {
+				return nil, errors.BadInput.New(fmt.Sprintf("pattern of rule %s must have a group capturing the issue key", rule.Name))
+			}
+			compiled.keyGroup = re.SubexpIndex("key")
+			if compiled.keyGroup < 0 {
+				compiled.keyGroup = 1
+			}
+		}
+		if rule.Confidence <= 0 || rule.Confidence > 1 {
+			return nil, errors.BadInput.New(fmt.Sprintf("confidence of rule %s must be in (0, 1]", rule.Name))
+		}
+		for _, source := range rule.Sources {
+			switch source {
+			case LINK_SOURCE_PR_TITLE, LINK_SOURCE_PR_DESCRIPTION, LINK_SOURCE_PR_BRANCH, LINK_SOURCE_COMMIT_MESSAGE:
+			default:
+				return nil, errors.BadInput.New(fmt.Sprintf("unknown source %s of rule %s", source, rule.Name))
+			}
+			engine.rules[source] = append(engine.rules[source], compiled)
+		}
+	}
+	return engine, nil
+}
+
+// Match returns the issue keys found in the texts, which are keyed by source. When a key is found by several rules
+// the one with the highest confidence is kept. Links are sorted by confidence in descending order
+func (e *LinkEngine) Match(texts map[string]string) []Link {
+	best := make(map[string]Link)
+	for source, text := range texts {
+		if text == "" {
+			continue
+		}
+		for _, rule := range e.rules[source] {
+			for _, key := range rule.findKeys(text) {
+				if link, ok := best[key]; !ok || link.Confidence < rule.Confidence {
+					best[key] = Link{IssueKey: key, Confidence: rule.Confidence, Rule: rule.Name}
+				}
+			}
+		}
+	}
+	links := make([]Link, 0, len(best))
+	for _, link := range best {
+		links = append(links, link)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Confidence != links[j].Confidence {
+			return links[i].Confidence > links[j].Confidence
+		}
+		return links[i].IssueKey < links[j].IssueKey
+	})
+	return links
+}
+
+func (r *compiledLinkRule) findKeys(text string) []string {
+	var keys []string
+	for _, match := range r.re.FindAllStringSubmatch(text, -1) {
+		if r.keyRe == nil {
+			if match[r.keyGroup] != "" {
+				keys = append(keys, match[r.keyGroup])
+			}
+			continue
+		}
+		for _, keyMatch := range r.keyRe.FindAllStringSubmatch(match[0], -1) {
+			if keyMatch[1] != "" {
+				keys = append(keys, keyMatch[1])
+			}
+		}
+	}
+	return keys
+}
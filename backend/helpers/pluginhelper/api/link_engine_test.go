@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testLinkRules = []LinkRule{
+	{
+		Name:       "closing-keyword",
+		Sources:    []string{LINK_SOURCE_PR_DESCRIPTION, LINK_SOURCE_COMMIT_MESSAGE},
+		Pattern:    `(?i)(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s+#(?P<key>\d+)`,
+		Confidence: 0.95,
+	},
+	{
+		Name:       "issue-key-in-title",
+		Sources:    []string{LINK_SOURCE_PR_TITLE, LINK_SOURCE_COMMIT_MESSAGE},
+		Pattern:    `\b(?P<key>[A-Z][A-Z0-9]+-\d+)\b`,
+		Confidence: 0.9,
+	},
+	{
+		Name:       "issue-key-in-branch",
+		Sources:    []string{LINK_SOURCE_PR_BRANCH},
+		Pattern:    `(?P<key>[A-Z][A-Z0-9]+-\d+)`,
+		Confidence: 0.8,
+	},
+	{
+		Name:       "issue-key-in-description",
+		Sources:    []string{LINK_SOURCE_PR_DESCRIPTION},
+		Pattern:    `\b(?P<key>[A-Z][A-Z0-9]+-\d+)\b`,
+		Confidence: 0.6,
+	},
+}
+
+func TestLinkEngineMatch(t *testing.T) {
+	engine, err := NewLinkEngine(testLinkRules)
+	assert.Nil(t, err)
+
+	links := engine.Match(map[string]string{
+		LINK_SOURCE_PR_TITLE:       "DL-12 add linker",
+		LINK_SOURCE_PR_BRANCH:      "feature/DL-34-linker",
+		LINK_SOURCE_PR_DESCRIPTION: "Fixes #56, see also DL-12 and DL-78",
+	})
+	assert.Equal(t, []Link{
+		{IssueKey: "56", Confidence: 0.95, Rule: "closing-keyword"},
+		{IssueKey: "DL-12", Confidence: 0.9, Rule: "issue-key-in-title"},
+		{IssueKey: "DL-34", Confidence: 0.8, Rule: "issue-key-in-branch"},
+		{IssueKey: "DL-78", Confidence: 0.6, Rule: "issue-key-in-description"},
+	}, links)
+}
+
+func TestLinkEngineCustomRule(t *testing.T) {
+	engine, err := NewLinkEngine([]LinkRule{
+		{Name: "story", Sources: []string{LINK_SOURCE_COMMIT_MESSAGE}, Pattern: `--story=(\d+)`, Confidence: 1},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Link{
+		{IssueKey: "1001", Confidence: 1, Rule: "story"},
+	}, engine.Match(map[string]string{
+		LINK_SOURCE_COMMIT_MESSAGE: "fix: crash --story=1001",
+		LINK_SOURCE_PR_TITLE:       "--story=1002",
+	}))
+}
+
+func TestLinkEngineKeyPattern(t *testing.T) {
+	engine, err := NewLinkEngine([]LinkRule{
+		{
+			Name:       "close-list",
+			Sources:    []string{LINK_SOURCE_PR_DESCRIPTION},
+			Pattern:    `(?mi)(fix|close)[\s]*.*((#\d+[ ]*)+)`,
+			KeyPattern: `#(\d+)`,
+			Confidence: 1,
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Link{
+		{IssueKey: "12", Confidence: 1, Rule: "close-list"},
+		{IssueKey: "34", Confidence: 1, Rule: "close-list"},
+	}, engine.Match(map[string]string{
+		LINK_SOURCE_PR_DESCRIPTION: "closes #12 #34\nrelated to #56",
+	}))
+}
+
+func TestNewLinkEngineInvalidRules(t *testing.T) {
+	_, err := NewLinkEngine([]LinkRule{{Name: "no-group", Sources: []string{LINK_SOURCE_PR_TITLE}, Pattern: `DL-\d+`, Confidence: 1}})
+	assert.NotNil(t, err)
+	_, err = NewLinkEngine([]LinkRule{{Name: "no-key-group", Sources: []string{LINK_SOURCE_PR_TITLE}, Pattern: `DL-\d+`, KeyPattern: `\d+`, Confidence: 1}})
+	assert.NotNil(t, err)
+	_, err = NewLinkEngine([]LinkRule{{Name: "bad-source", Sources: []string{"BODY"}, Pattern: `(DL-\d+)`, Confidence: 1}})
+	assert.NotNil(t, err)
+	_, err = NewLinkEngine([]LinkRule{{Name: "bad-confidence", Sources: []string{LINK_SOURCE_PR_TITLE}, Pattern: `(DL-\d+)`, Confidence: 2}})
+	assert.NotNil(t, err)
+}
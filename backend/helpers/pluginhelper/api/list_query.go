@@ -0,0 +1,349 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"gorm.io/gorm/schema"
+)
+
+// the query params of the list endpoints which are not filters, `fields` masks the responses
+var listQueryReserved = map[string]bool{"page": true, "pageSize": true, "sort": true, "fields": true}
+
+// ListField is a field of a model the lists may be sorted by, and filtered by unless it is a time
+type ListField struct {
+	Column string
+	Kind   reflect.Kind
+	IsTime bool
+}
+
+// ListQuery holds the pagination, the sorting and the filters of a list endpoint, i.e.
+// `?page=2&pageSize=20&sort=-createdAt,name&name=lake*&enable=true`.
+// The filters are the query params named after the fields of the model, a filter given several times matches any of
+// its values, and a value containing `*` matches the text it stands for.
+type ListQuery struct {
+	Page     int
+	PageSize int
+	Sorts    []ListSort
+	Filters  []ListFilter
+}
+
+type ListSort struct {
+	Field  string
+	Column string
+	Desc   bool
+}
+
+type ListFilter struct {
+	Field  string
+	Column string
+	Values []interface{}
+}
+
+// ListResponse is the envelope of the lists, the items are under the name of the resource, i.e. `blueprints`
+type ListResponse map[string]interface{}
+
+// NewListResponse wraps a page of the items along with the count of all the items matching the filters
+func NewListResponse(name string, items interface{}, count int64, page int, pageSize int) ListResponse {
+	return ListResponse{
+		name:       items,
+		"count":    count,
+		"page":     page,
+		"pageSize": pageSize,
+	}
+}
+
+// NewPageResponse wraps a page of the items listed by the query params
+func NewPageResponse(name string, items interface{}, count int64, query url.Values) ListResponse {
+	pageSize, page := getPageParam(query, "pageSize", "page")
+	return NewListResponse(name, items, count, page, pageSize)
+}
+
+// ListFields returns the fields of the model the lists may be sorted and filtered by, keyed by their json names,
+// the fields of the embedded structs included. The columns are qualified by the table of the model when it has one
+// so the lists may join other tables.
+func ListFields(model interface{}) map[string]ListField {
+	fields := make(map[string]ListField)
+	table := ""
+	if tabler, ok := model.(schema.Tabler); ok {
+		table = tabler.TableName() + "."
+	}
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		collectListFields(t, table, fields)
+	}
+	return fields
+}
+
+func collectListFields(t reflect.Type, table string, fields map[string]ListField) {
+	naming := schema.NamingStrategy{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			collectListFields(f.Type, table, fields)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		column := naming.ColumnName("", f.Name)
+		for _, setting := range strings.Split(f.Tag.Get("gorm"), ";") {
+			if strings.HasPrefix(setting, "column:") {
+				column = strings.TrimPrefix(setting, "column:")
+			}
+		}
+		column = table + column
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft == reflect.TypeOf(time.Time{}) {
+			fields[name] = ListField{Column: column, Kind: reflect.Struct, IsTime: true}
+			continue
+		}
+		switch ft.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			fields[name] = ListField{Column: column, Kind: ft.Kind()}
+		}
+	}
+}
+
+// ParseListQuery reads the list query out of the query params, the sorting by an unknown field is rejected while
+// the params which are not fields are left to the endpoints
+func ParseListQuery(query url.Values, fields map[string]ListField) (*ListQuery, errors.Error) {
+	listQuery := &ListQuery{}
+	listQuery.PageSize, listQuery.Page = getPageParam(query, "pageSize", "page")
+	for _, sorts := range query["sort"] {
+		for _, s := range strings.Split(sorts, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			desc := strings.HasPrefix(s, "-")
+			s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+			field, ok := fields[s]
+			if !ok {
+				return nil, errors.BadInput.New(fmt.Sprintf("the list cannot be sorted by %s", s))
+			}
+			listQuery.Sorts = append(listQuery.Sorts, ListSort{Field: s, Column: field.Column, Desc: desc})
+		}
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok || listQueryReserved[name] || field.IsTime {
+			continue
+		}
+		filter := ListFilter{Field: name, Column: field.Column}
+		for _, raw := range query[name] {
+			value, err := parseListFilterValue(field, raw)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid value of the filter %s", name))
+			}
+			filter.Values = append(filter.Values, value)
+		}
+		listQuery.Filters = append(listQuery.Filters, filter)
+	}
+	return listQuery, nil
+}
+
+func parseListFilterValue(field ListField, raw string) (interface{}, error) {
+	switch field.Kind {
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(raw, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(raw, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(raw, 64)
+	}
+	return raw, nil
+}
+
+// Clauses returns the where clauses of the filters
+func (q *ListQuery) Clauses() []dal.Clause {
+	if q == nil {
+		return nil
+	}
+	clauses := make([]dal.Clause, 0, len(q.Filters))
+	for _, filter := range q.Filters {
+		conditions := make([]string, 0, len(filter.Values))
+		params := make([]interface{}, 0, len(filter.Values))
+		for _, value := range filter.Values {
+			if text, ok := value.(string); ok && strings.Contains(text, "*") {
+				conditions = append(conditions, filter.Column+" LIKE ?")
+				params = append(params, strings.ReplaceAll(text, "*", "%"))
+			} else {
+				conditions = append(conditions, filter.Column+" = ?")
+				params = append(params, value)
+			}
+		}
+		clauses = append(clauses, dal.Where("("+strings.Join(conditions, " OR ")+")", params...))
+	}
+	return clauses
+}
+
+// Orderby returns the order by expression of the sorting, or the default one when the query sorts nothing
+func (q *ListQuery) Orderby(defaultOrder string) string {
+	if q == nil || len(q.Sorts) == 0 {
+		return defaultOrder
+	}
+	orders := make([]string, 0, len(q.Sorts))
+	for _, s := range q.Sorts {
+		if s.Desc {
+			orders = append(orders, s.Column+" DESC")
+		} else {
+			orders = append(orders, s.Column)
+		}
+	}
+	return strings.Join(orders, ", ")
+}
+
+// GetSkip returns how many records should be skipped for the page
+func (q *ListQuery) GetSkip() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// Apply filters, sorts and paginates a slice in memory, by the json values of its items, it returns the page along
+// with the count of the items matching the filters
+func (q *ListQuery) Apply(list interface{}) (interface{}, int64, errors.Error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice {
+		return list, 0, errors.Default.New("only the slices may be paginated in memory")
+	}
+	type entry struct {
+		item   reflect.Value
+		fields map[string]interface{}
+	}
+	entries := make([]entry, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		raw, err := json.Marshal(v.Index(i).Interface())
+		if err != nil {
+			return nil, 0, errors.Convert(err)
+		}
+		fields := make(map[string]interface{})
+		_ = json.Unmarshal(raw, &fields)
+		if q.matches(fields) {
+			entries = append(entries, entry{item: v.Index(i), fields: fields})
+		}
+	}
+	if len(q.Sorts) > 0 {
+		sort.SliceStable(entries, func(i, j int) bool {
+			for _, s := range q.Sorts {
+				c := compareListValues(entries[i].fields[s.Field], entries[j].fields[s.Field])
+				if c != 0 {
+					return (c < 0) != s.Desc
+				}
+			}
+			return false
+		})
+	}
+	page := reflect.MakeSlice(v.Type(), 0, q.PageSize)
+	for i := q.GetSkip(); i < len(entries) && i < q.GetSkip()+q.PageSize; i++ {
+		page = reflect.Append(page, entries[i].item)
+	}
+	return page.Interface(), int64(len(entries)), nil
+}
+
+func (q *ListQuery) matches(fields map[string]interface{}) bool {
+	for _, filter := range q.Filters {
+		actual := fmt.Sprint(fields[filter.Field])
+		if number, ok := fields[filter.Field].(float64); ok {
+			actual = strconv.FormatFloat(number, 'f', -1, 64)
+		}
+		matched := false
+		for _, value := range filter.Values {
+			expected := fmt.Sprint(value)
+			if strings.Contains(expected, "*") {
+				matched = matchListPattern(expected, actual)
+			} else {
+				matched = expected == actual
+			}
+			if matched {
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchListPattern matches the text against a pattern where `*` stands for any text
+func matchListPattern(pattern, text string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(text, parts[0]) {
+		return false
+	}
+	text = text[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(text, part)
+		}
+		idx := strings.Index(text, part)
+		if idx < 0 {
+			return false
+		}
+		text = text[idx+len(part):]
+	}
+	return true
+}
+
+func compareListValues(a, b interface{}) int {
+	if x, ok := a.(float64); ok {
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type listQueryItem struct {
+	common.Model
+	Name     string `json:"name"`
+	Enable   bool   `json:"enable"`
+	Priority int    `json:"priority" gorm:"column:prio"`
+	Secret   string `json:"-"`
+}
+
+func (listQueryItem) TableName() string {
+	return "_devlake_items"
+}
+
+func TestListFields(t *testing.T) {
+	fields := ListFields(&listQueryItem{})
+	assert.Equal(t, "_devlake_items.id", fields["id"].Column)
+	assert.True(t, fields["createdAt"].IsTime)
+	assert.Equal(t, "_devlake_items.prio", fields["priority"].Column)
+	assert.NotContains(t, fields, "Secret")
+}
+
+func TestParseListQuery(t *testing.T) {
+	fields := ListFields(&listQueryItem{})
+	query, err := ParseListQuery(url.Values{
+		"page":    {"2"},
+		"sort":    {"-createdAt,name"},
+		"name":    {"lake*", "devlake"},
+		"enable":  {"true"},
+		"unknown": {"x"},
+	}, fields)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, query.Page)
+	assert.Equal(t, 50, query.PageSize)
+	assert.Equal(t, 50, query.GetSkip())
+	assert.Equal(t, "_devlake_items.created_at DESC, _devlake_items.name", query.Orderby("id DESC"))
+	clauses := query.Clauses()
+	assert.Len(t, clauses, 2)
+	assert.Equal(t, []interface{}{true}, query.Filters[0].Values)
+
+	_, err = ParseListQuery(url.Values{"sort": {"secret"}}, fields)
+	assert.NotNil(t, err)
+	_, err = ParseListQuery(url.Values{"priority": {"high"}}, fields)
+	assert.NotNil(t, err)
+
+	var nilQuery *ListQuery
+	assert.Equal(t, "id DESC", nilQuery.Orderby("id DESC"))
+	assert.Nil(t, nilQuery.Clauses())
+}
+
+func TestListQueryApply(t *testing.T) {
+	now := time.Now()
+	items := []listQueryItem{
+		{Model: common.Model{ID: 1, CreatedAt: now}, Name: "lake", Priority: 3},
+		{Model: common.Model{ID: 2, CreatedAt: now.Add(time.Hour)}, Name: "devlake", Priority: 1, Enable: true},
+		{Model: common.Model{ID: 3, CreatedAt: now.Add(2 * time.Hour)}, Name: "lakehouse", Priority: 2},
+	}
+	fields := ListFields(&listQueryItem{})
+
+	query, err := ParseListQuery(url.Values{"name": {"lake*"}, "sort": {"-priority"}}, fields)
+	assert.Nil(t, err)
+	page, count, err := query.Apply(items)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []listQueryItem{items[0], items[2]}, page)
+
+	query, err = ParseListQuery(url.Values{"pageSize": {"1"}, "page": {"2"}, "sort": {"id"}}, fields)
+	assert.Nil(t, err)
+	page, count, err = query.Apply(items)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, []listQueryItem{items[1]}, page)
+
+	query, err = ParseListQuery(url.Values{"enable": {"true"}, "id": {"2", "3"}}, fields)
+	assert.Nil(t, err)
+	page, count, err = query.Apply(items)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, []listQueryItem{items[1]}, page)
+}
@@ -0,0 +1,237 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+// PullRequestStatsEnricherArgs is the arguments of NewPullRequestStatsEnricher
+type PullRequestStatsEnricherArgs struct {
+	RawDataSubTaskArgs
+	// RepoId is the domain layer id of the repo whose pull requests are enriched
+	RepoId string
+}
+
+// PullRequestStatsEnricher fills the additions, deletions, changed_files and review_rounds the plugin couldn't get
+// from its platform with the ones derived from the domain layer pull_request_commits, commits, commit_files and
+// pull_request_comments, so the size of pull requests can be compared across all SCM plugins
+type PullRequestStatsEnricher struct {
+	*RawDataSubTask
+	args *PullRequestStatsEnricherArgs
+}
+
+// NewPullRequestStatsEnricher creates a new PullRequestStatsEnricher
+func NewPullRequestStatsEnricher(args PullRequestStatsEnricherArgs) (*PullRequestStatsEnricher, errors.Error) {
+	if args.RepoId == "" {
+		return nil, errors.Default.New("RepoId is required for PullRequestStatsEnricher")
+	}
+	rawDataSubTask, err := NewRawDataSubTask(args.RawDataSubTaskArgs)
+	if err != nil {
+		return nil, err
+	}
+	return &PullRequestStatsEnricher{
+		RawDataSubTask: rawDataSubTask,
+		args:           &args,
+	}, nil
+}
+
+type pullRequestDiffStats struct {
+	PullRequestId string
+	Additions     int
+	Deletions     int
+	ChangedFiles  int
+}
+
+type pullRequestActivity struct {
+	PullRequestId string
+	CreatedDate   time.Time
+}
+
+// Execute loads the pull requests of the repo and saves the ones whose stats were missing
+func (enricher *PullRequestStatsEnricher) Execute() errors.Error {
+	db := enricher.args.Ctx.GetDal()
+	repoId := enricher.args.RepoId
+
+	var pullRequests []code.PullRequest
+	err := db.All(&pullRequests, dal.From(&code.PullRequest{}), dal.Where("base_repo_id = ?", repoId))
+	if err != nil {
+		return err
+	}
+	var lineStats []pullRequestDiffStats
+	err = db.All(
+		&lineStats,
+		dal.Select("prc.pull_request_id, SUM(c.additions) AS additions, SUM(c.deletions) AS deletions"),
+		dal.From("pull_request_commits prc"),
+		dal.Join("INNER JOIN pull_requests pr ON (pr.id = prc.pull_request_id)"),
+		dal.Join("INNER JOIN commits c ON (c.sha = prc.commit_sha)"),
+		dal.Where("pr.base_repo_id = ?", repoId),
+		dal.Groupby("prc.pull_request_id"),
+	)
+	if err != nil {
+		return err
+	}
+	var fileStats []pullRequestDiffStats
+	err = db.All(
+		&fileStats,
+		dal.Select("prc.pull_request_id, COUNT(DISTINCT cf.file_path) AS changed_files"),
+		dal.From("pull_request_commits prc"),
+		dal.Join("INNER JOIN pull_requests pr ON (pr.id = prc.pull_request_id)"),
+		dal.Join("INNER JOIN commit_files cf ON (cf.commit_sha = prc.commit_sha)"),
+		dal.Where("pr.base_repo_id = ?", repoId),
+		dal.Groupby("prc.pull_request_id"),
+	)
+	if err != nil {
+		return err
+	}
+	var commits []pullRequestActivity
+	err = db.All(
+		&commits,
+		dal.Select("prc.pull_request_id, prc.commit_authored_date AS created_date"),
+		dal.From("pull_request_commits prc"),
+		dal.Join("INNER JOIN pull_requests pr ON (pr.id = prc.pull_request_id)"),
+		dal.Where("pr.base_repo_id = ?", repoId),
+	)
+	if err != nil {
+		return err
+	}
+	var comments []pullRequestActivity
+	err = db.All(
+		&comments,
+		dal.Select("prc.pull_request_id, prc.created_date"),
+		dal.From("pull_request_comments prc"),
+		dal.Join("INNER JOIN pull_requests pr ON (pr.id = prc.pull_request_id)"),
+		dal.Where("pr.base_repo_id = ?", repoId),
+	)
+	if err != nil {
+		return err
+	}
+
+	statsMap := make(map[string]*pullRequestDiffStats)
+	for i := range lineStats {
+		statsMap[lineStats[i].PullRequestId] = &lineStats[i]
+	}
+	for _, fileStat := range fileStats {
+		if stats := statsMap[fileStat.PullRequestId]; stats != nil {
+			stats.ChangedFiles = fileStat.ChangedFiles
+		} else {
+			statsMap[fileStat.PullRequestId] = &pullRequestDiffStats{
+				PullRequestId: fileStat.PullRequestId,
+				ChangedFiles:  fileStat.ChangedFiles,
+			}
+		}
+	}
+	commitDates := make(map[string][]time.Time)
+	for _, commit := range commits {
+		commitDates[commit.PullRequestId] = append(commitDates[commit.PullRequestId], commit.CreatedDate)
+	}
+	commentDates := make(map[string][]time.Time)
+	for _, comment := range comments {
+		commentDates[comment.PullRequestId] = append(commentDates[comment.PullRequestId], comment.CreatedDate)
+	}
+
+	// the pull requests keep the raw data origin of their convertor, so they are saved by a plain BatchSave
+	batch, err := NewBatchSave(enricher.args.Ctx, reflect.TypeOf(&code.PullRequest{}), 500)
+	if err != nil {
+		return err
+	}
+	for i := range pullRequests {
+		pr := &pullRequests[i]
+		if !fillPullRequestStats(pr, statsMap[pr.Id], commitDates[pr.Id], commentDates[pr.Id]) {
+			continue
+		}
+		err = batch.Add(pr)
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Close()
+}
+
+// fillPullRequestStats fills the stats of the pull request the platform didn't provide, and reports whether any of
+// them changed. Stats reported by the platform are kept since they are more accurate than the ones summed from commits
+func fillPullRequestStats(pr *code.PullRequest, stats *pullRequestDiffStats, commitDates, commentDates []time.Time) bool {
+	changed := false
+	if stats != nil {
+		if pr.Additions == 0 && pr.Deletions == 0 && (stats.Additions != 0 || stats.Deletions != 0) {
+			pr.Additions = stats.Additions
+			pr.Deletions = stats.Deletions
+			changed = true
+		}
+		if pr.ChangedFiles == 0 && stats.ChangedFiles != 0 {
+			pr.ChangedFiles = stats.ChangedFiles
+			changed = true
+		}
+	}
+	if pr.ReviewRounds == 0 {
+		pr.ReviewRounds = CountReviewRounds(commitDates, commentDates)
+		changed = true
+	}
+	return changed
+}
+
+// CountReviewRounds counts the rounds of review of a pull request, a round ends whenever comments follow commits,
+// and the commits pushed after the last comment start another round waiting for review
+func CountReviewRounds(commitDates, commentDates []time.Time) int {
+	if len(commitDates) == 0 && len(commentDates) == 0 {
+		return 1
+	}
+	commits := sortedTimes(commitDates)
+	comments := sortedTimes(commentDates)
+	i := 0
+	j := 0
+	reviewRounds := 0
+	// state is used to keep track of previous activity
+	// 0: init, 1: commit, 2: comment
+	// whenever state is switched to comment, we increment reviewRounds by 1
+	state := 0
+	for i < len(commits) && j < len(comments) {
+		if commits[i].Before(comments[j]) {
+			i++
+			state = 1
+		} else {
+			j++
+			if state != 2 {
+				reviewRounds++
+			}
+			state = 2
+		}
+	}
+	// There's another implicit round of review in 2 scenarios
+	// One: the last state is commit (state == 1)
+	// Two: the last state is comment but there're still commits left
+	if state == 1 || i < len(commits) {
+		reviewRounds++
+	}
+	return reviewRounds
+}
+
+func sortedTimes(times []time.Time) []time.Time {
+	sorted := make([]time.Time, len(times))
+	copy(sorted, times)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+	return sorted
+}
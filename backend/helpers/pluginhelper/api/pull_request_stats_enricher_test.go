@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountReviewRounds(t *testing.T) {
+	assert.Equal(t, 1, CountReviewRounds(nil, nil))
+	// commits waiting for review
+	assert.Equal(t, 1, CountReviewRounds([]time.Time{day(1), day(2)}, nil))
+	// commit, review, fix, review
+	assert.Equal(t, 2, CountReviewRounds(
+		[]time.Time{day(5), day(1)},
+		[]time.Time{day(6), day(2), day(3)},
+	))
+	// commit, review, fix waiting for another review
+	assert.Equal(t, 2, CountReviewRounds(
+		[]time.Time{day(1), day(4)},
+		[]time.Time{day(2), day(3)},
+	))
+}
+
+func TestFillPullRequestStats(t *testing.T) {
+	pr := &code.PullRequest{}
+	changed := fillPullRequestStats(
+		pr,
+		&pullRequestDiffStats{Additions: 10, Deletions: 3, ChangedFiles: 2},
+		[]time.Time{day(1)},
+		[]time.Time{day(2)},
+	)
+	assert.True(t, changed)
+	assert.Equal(t, 10, pr.Additions)
+	assert.Equal(t, 3, pr.Deletions)
+	assert.Equal(t, 2, pr.ChangedFiles)
+	assert.Equal(t, 1, pr.ReviewRounds)
+
+	// stats reported by the platform are kept
+	pr = &code.PullRequest{Additions: 5, ChangedFiles: 1, ReviewRounds: 3}
+	changed = fillPullRequestStats(pr, &pullRequestDiffStats{Additions: 10, Deletions: 3, ChangedFiles: 2}, nil, nil)
+	assert.False(t, changed)
+	assert.Equal(t, 5, pr.Additions)
+	assert.Equal(t, 0, pr.Deletions)
+	assert.Equal(t, 1, pr.ChangedFiles)
+	assert.Equal(t, 3, pr.ReviewRounds)
+}
@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/objectstore"
+)
+
+const defaultBlobThresholdBytes = 1 << 20
+
+// blobStore is the store of the oversized payloads, it is opened on first use and reopened once BLOB_STORE_URL changes
+var blobStore struct {
+	sync.Mutex
+	url   string
+	store objectstore.Store
+}
+
+// getBlobStore returns the store set by BLOB_STORE_URL, or nil when the payloads are all kept in the raw tables
+func getBlobStore() (objectstore.Store, errors.Error) {
+	v := config.GetConfig()
+	url := v.GetString("BLOB_STORE_URL")
+	if url == "" {
+		return nil, nil
+	}
+	blobStore.Lock()
+	defer blobStore.Unlock()
+	if blobStore.store != nil && blobStore.url == url {
+		return blobStore.store, nil
+	}
+	store, err := objectstore.Open(url, v.GetString("BLOB_ACCESS_KEY_ID"), v.GetString("BLOB_SECRET_ACCESS_KEY"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to open the blob store of BLOB_STORE_URL")
+	}
+	blobStore.url = url
+	blobStore.store = store
+	return store, nil
+}
+
+// OffloadRawData moves the payloads larger than BLOB_THRESHOLD_BYTES (1MiB by default) of the rows to the blob store
+// before they are saved, the rows keep the key of their payload in BlobKey and no Data. The keys are the content hashes
+// of the payloads under the raw table, i.e. a payload collected again is stored once. Nothing is moved unless
+// BLOB_STORE_URL is set
+func OffloadRawData(ctx context.Context, table string, rows []*RawData) errors.Error {
+	store, err := getBlobStore()
+	if err != nil || store == nil {
+		return err
+	}
+	threshold := config.GetConfig().GetInt("BLOB_THRESHOLD_BYTES")
+	if threshold <= 0 {
+		threshold = defaultBlobThresholdBytes
+	}
+	for _, row := range rows {
+		if len(row.Data) <= threshold {
+			continue
+		}
+		sum := sha256.Sum256(row.Data)
+		key := fmt.Sprintf("%s/%s", table, hex.EncodeToString(sum[:]))
+		err = store.Put(ctx, key, row.Data)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to store the payload of %d bytes into the blob store", len(row.Data)))
+		}
+		row.BlobKey = key
+		row.Data = nil
+	}
+	return nil
+}
+
+// LoadRawData fills the Data of a row whose payload was moved to the blob store by OffloadRawData
+func LoadRawData(ctx context.Context, row *RawData) errors.Error {
+	if row.BlobKey == "" {
+		return nil
+	}
+	store, err := getBlobStore()
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return errors.Default.New(fmt.Sprintf("the payload of raw row %d is in the blob store but BLOB_STORE_URL is not set", row.ID))
+	}
+	data, err := store.Get(ctx, row.BlobKey)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to load the payload %s from the blob store", row.BlobKey))
+	}
+	row.Data = data
+	return nil
+}
+
+// DeleteRawDataBlobs deletes the payloads of the keys from the blob store, the caller makes sure no row refers to them
+func DeleteRawDataBlobs(ctx context.Context, keys []string) errors.Error {
+	if len(keys) == 0 {
+		return nil
+	}
+	store, err := getBlobStore()
+	if err != nil || store == nil {
+		return err
+	}
+	for _, key := range keys {
+		err = store.Delete(ctx, key)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to delete the payload %s from the blob store", key))
+		}
+	}
+	return nil
+}
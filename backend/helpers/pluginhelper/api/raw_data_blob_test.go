@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffloadRawData(t *testing.T) {
+	dir := t.TempDir()
+	v := config.GetConfig()
+	v.Set("BLOB_STORE_URL", "file://"+dir)
+	v.Set("BLOB_THRESHOLD_BYTES", 8)
+	defer v.Set("BLOB_STORE_URL", "")
+	defer v.Set("BLOB_THRESHOLD_BYTES", "")
+
+	ctx := context.Background()
+	large := bytes.Repeat([]byte("x"), 9)
+	rows := []*RawData{
+		{ID: 1, Data: []byte(`{"id":1}`)},
+		{ID: 2, Data: large},
+	}
+	assert.Nil(t, OffloadRawData(ctx, "_raw_test", rows))
+	// the payloads up to the threshold stay in the row
+	assert.Equal(t, `{"id":1}`, string(rows[0].Data))
+	assert.Empty(t, rows[0].BlobKey)
+	assert.Nil(t, rows[1].Data)
+	assert.Contains(t, rows[1].BlobKey, "_raw_test/")
+	_, err := os.Stat(filepath.Join(dir, rows[1].BlobKey))
+	assert.Nil(t, err)
+
+	assert.Nil(t, LoadRawData(ctx, rows[0]))
+	assert.Equal(t, `{"id":1}`, string(rows[0].Data))
+	assert.Nil(t, LoadRawData(ctx, rows[1]))
+	assert.Equal(t, large, rows[1].Data)
+
+	assert.Nil(t, DeleteRawDataBlobs(ctx, []string{rows[1].BlobKey}))
+	assert.NotNil(t, LoadRawData(ctx, &RawData{BlobKey: rows[1].BlobKey}))
+}
+
+func TestOffloadRawDataWithoutBlobStore(t *testing.T) {
+	ctx := context.Background()
+	rows := []*RawData{{ID: 1, Data: bytes.Repeat([]byte("x"), defaultBlobThresholdBytes+1)}}
+	assert.Nil(t, OffloadRawData(ctx, "_raw_test", rows))
+	assert.Empty(t, rows[0].BlobKey)
+	// a payload moved away can't be read once the blob store is unset
+	assert.NotNil(t, LoadRawData(ctx, &RawData{ID: 2, BlobKey: "_raw_test/abc"}))
+}
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync/atomic"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// rawDataDedup counts the payloads a collector received and skipped, it is shared by the concurrent response handlers
+type rawDataDedup struct {
+	collected  int64
+	duplicated int64
+}
+
+// RawDataHash is the content hash of a payload, the input it was collected for is part of it since the extractors
+// may read it, i.e. the same comment collected for two issues makes two records
+func RawDataHash(data []byte, input []byte) string {
+	h := sha256.New()
+	h.Write(data)
+	h.Write([]byte{0})
+	h.Write(input)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// startDedup makes the collector skip the payloads stored already for its params, unless RAW_DATA_DEDUP is false.
+// The incremental collections fetch the records updated in a window overlapping the previous one, the unchanged ones
+// are neither stored nor extracted twice
+func (r *RawDataSubTask) startDedup() {
+	v := config.GetConfig()
+	if v.IsSet("RAW_DATA_DEDUP") && !v.GetBool("RAW_DATA_DEDUP") {
+		r.dedup = nil
+		return
+	}
+	r.dedup = &rawDataDedup{}
+}
+
+// dedupRawRows sets the hashes of the rows and drops those whose payload is stored already, or repeated in the rows
+func (r *RawDataSubTask) dedupRawRows(rows []*RawData) ([]*RawData, errors.Error) {
+	for _, row := range rows {
+		row.Hash = RawDataHash(row.Data, row.Input)
+	}
+	if r.dedup == nil || len(rows) == 0 {
+		return rows, nil
+	}
+	hashes := make([]string, len(rows))
+	for i, row := range rows {
+		hashes[i] = row.Hash
+	}
+	var stored []string
+	err := r.args.Ctx.GetDal().Pluck(
+		"hash",
+		&stored,
+		dal.From(r.table),
+		dal.Where("params = ? AND hash IN ?", r.params, hashes),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error looking up the stored payloads")
+	}
+	seen := make(map[string]bool, len(rows))
+	for _, hash := range stored {
+		seen[hash] = true
+	}
+	kept := rows[:0]
+	for _, row := range rows {
+		if seen[row.Hash] {
+			continue
+		}
+		seen[row.Hash] = true
+		kept = append(kept, row)
+	}
+	atomic.AddInt64(&r.dedup.collected, int64(len(rows)))
+	atomic.AddInt64(&r.dedup.duplicated, int64(len(rows)-len(kept)))
+	return kept, nil
+}
+
+// saveDedupStat adds the counts of the collection to the models.RawDataDedupStat of the stream of the scope
+func (r *RawDataSubTask) saveDedupStat() errors.Error {
+	if r.dedup == nil || r.dedup.collected == 0 {
+		return nil
+	}
+	db := r.args.Ctx.GetDal()
+	stat := &models.RawDataDedupStat{}
+	err := db.First(stat, dal.Where("raw_data_table = ? AND raw_data_params = ?", r.table, r.params))
+	if err != nil {
+		if !db.IsErrorNotFound(err) {
+			return errors.Default.Wrap(err, "error loading the dedup stat")
+		}
+		stat = &models.RawDataDedupStat{RawDataTable: r.table, RawDataParams: r.params}
+	}
+	if taskCtx := r.args.Ctx.TaskContext(); taskCtx != nil {
+		stat.Plugin = taskCtx.GetName()
+	}
+	stat.Collected += r.dedup.collected
+	stat.Duplicated += r.dedup.duplicated
+	r.args.Ctx.GetLogger().Info("skipped %d duplicated payloads of %d", r.dedup.duplicated, r.dedup.collected)
+	return db.CreateOrUpdate(stat)
+}
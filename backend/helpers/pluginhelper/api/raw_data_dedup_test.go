@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawDataHash(t *testing.T) {
+	hash := RawDataHash([]byte(`{"id":1}`), []byte(`{"issueId":10}`))
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, RawDataHash([]byte(`{"id":1}`), []byte(`{"issueId":10}`)))
+	// the same payload collected for another input is another record
+	assert.NotEqual(t, hash, RawDataHash([]byte(`{"id":1}`), []byte(`{"issueId":11}`)))
+	// the boundary between the payload and the input matters
+	assert.NotEqual(t, RawDataHash([]byte("ab"), []byte("c")), RawDataHash([]byte("a"), []byte("bc")))
+}
@@ -0,0 +1,333 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// The raw tables are partitioned by the month of their `created_at`, the collection time, so the retention and
+// the incremental scans only touch the partitions they need. MySQL names the partitions `pYYYYMM` and keeps a
+// `pmax` partition for the rows beyond the last month, Postgres names them `<table>_pYYYYMM` and keeps a
+// `<table>_pdefault` partition for the rows out of any range.
+const (
+	rawPartitionMonthLayout = "200601"
+	rawPartitionTimeLayout  = "2006-01-02 15:04:05"
+)
+
+// IsRawTablePartitioned tells if the raw table was partitioned by PartitionRawTable
+func IsRawTablePartitioned(db dal.Dal, table string) (bool, errors.Error) {
+	var query string
+	switch db.Dialect() {
+	case "mysql":
+		query = "SELECT COUNT(*) FROM information_schema.partitions WHERE table_schema = DATABASE() AND table_name = ? AND partition_name IS NOT NULL"
+	case "postgres":
+		query = "SELECT COUNT(*) FROM pg_partitioned_table pt JOIN pg_class c ON c.oid = pt.partrelid WHERE c.relname = ?"
+	default:
+		return false, nil
+	}
+	counts, err := queryRawPartitionStrings(db, query, table)
+	if err != nil {
+		return false, err
+	}
+	return len(counts) > 0 && counts[0] != "0", nil
+}
+
+// PartitionRawTable converts the raw table into a table partitioned by collection month, with a partition
+// for each month from the oldest row to the next month. The rows are copied over on Postgres which can not
+// partition an existing table, expect it to take a while on large tables.
+func PartitionRawTable(db dal.Dal, table string) errors.Error {
+	partitioned, err := IsRawTablePartitioned(db, table)
+	if err != nil || partitioned {
+		return err
+	}
+	if db.Dialect() == "postgres" {
+		// the partitions are tables on their own on Postgres
+		isPartition, err := queryRawPartitionStrings(db, "SELECT relispartition FROM pg_class WHERE relname = ?", table)
+		if err != nil || (len(isPartition) > 0 && isPartition[0] == "true") {
+			return err
+		}
+	}
+	now := time.Now()
+	oldest := now
+	var minCreatedAt []sql.NullTime
+	err = db.Pluck("MIN(created_at)", &minCreatedAt, dal.From(table))
+	if err != nil {
+		return err
+	}
+	if len(minCreatedAt) > 0 && minCreatedAt[0].Valid && minCreatedAt[0].Time.Before(now) {
+		oldest = minCreatedAt[0].Time
+	}
+	months := rawPartitionMonths(oldest, now.AddDate(0, 1, 0))
+	switch db.Dialect() {
+	case "mysql":
+		return partitionMysqlRawTable(db, table, months)
+	case "postgres":
+		return partitionPostgresRawTable(db, table, months)
+	}
+	return errors.BadInput.New(fmt.Sprintf("partitioning is not supported by %s", db.Dialect()))
+}
+
+func partitionMysqlRawTable(db dal.Dal, table string, months []time.Time) errors.Error {
+	// the partitioning column must be part of the primary key
+	err := db.Exec("ALTER TABLE ? DROP PRIMARY KEY, ADD PRIMARY KEY (id, created_at)", dal.ClauseTable{Name: table})
+	if err != nil {
+		return err
+	}
+	definitions := make([]string, 0, len(months)+1)
+	for _, month := range months {
+		definitions = append(definitions, mysqlRawPartitionDefinition(month))
+	}
+	definitions = append(definitions, "PARTITION pmax VALUES LESS THAN (MAXVALUE)")
+	return db.Exec(
+		fmt.Sprintf("ALTER TABLE ? PARTITION BY RANGE COLUMNS(created_at) (%s)", strings.Join(definitions, ", ")),
+		dal.ClauseTable{Name: table},
+	)
+}
+
+func partitionPostgresRawTable(db dal.Dal, table string, months []time.Time) errors.Error {
+	unpartitioned := table + "_unpartitioned"
+	tx := db.Begin()
+	err := func() errors.Error {
+		err := tx.RenameTable(table, unpartitioned)
+		if err != nil {
+			return err
+		}
+		err = tx.Exec(
+			"CREATE TABLE ? (LIKE ? INCLUDING DEFAULTS) PARTITION BY RANGE (created_at)",
+			dal.ClauseTable{Name: table}, dal.ClauseTable{Name: unpartitioned},
+		)
+		if err != nil {
+			return err
+		}
+		// the partitioning column must be part of the primary key
+		err = tx.Exec("ALTER TABLE ? ADD PRIMARY KEY (id, created_at)", dal.ClauseTable{Name: table})
+		if err != nil {
+			return err
+		}
+		err = tx.Exec("CREATE TABLE ? PARTITION OF ? DEFAULT", dal.ClauseTable{Name: table + "_pdefault"}, dal.ClauseTable{Name: table})
+		if err != nil {
+			return err
+		}
+		for _, month := range months {
+			err = createPostgresRawPartition(tx, table, month)
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.Exec("INSERT INTO ? SELECT * FROM ?", dal.ClauseTable{Name: table}, dal.ClauseTable{Name: unpartitioned})
+		if err != nil {
+			return err
+		}
+		// the id sequence is owned by the old table and would be dropped along with it
+		sequences, err := queryRawPartitionStrings(tx, "SELECT pg_get_serial_sequence(?, 'id')", unpartitioned)
+		if err != nil {
+			return err
+		}
+		if len(sequences) > 0 && sequences[0] != "" {
+			err = tx.Exec(fmt.Sprintf("ALTER SEQUENCE %s OWNED BY ?.id", sequences[0]), dal.ClauseTable{Name: table})
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.DropTables(unpartitioned)
+		if err != nil {
+			return err
+		}
+		return tx.Exec("CREATE INDEX ? ON ? (params)", dal.ClauseTable{Name: fmt.Sprintf("idx_%s_params", table)}, dal.ClauseTable{Name: table})
+	}()
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to partition %s", table))
+	}
+	return tx.Commit()
+}
+
+// EnsureRawTablePartitions creates the partitions of the months up to `until` on a partitioned raw table,
+// the rows of the months without partition go to the `pmax`/`pdefault` partition
+func EnsureRawTablePartitions(db dal.Dal, table string, until time.Time) errors.Error {
+	partitioned, err := IsRawTablePartitioned(db, table)
+	if err != nil || !partitioned {
+		return err
+	}
+	partitions, err := ListRawTablePartitions(db, table)
+	if err != nil {
+		return err
+	}
+	from := time.Now()
+	if len(partitions) > 0 {
+		from = partitions[len(partitions)-1].AddDate(0, 1, 0)
+	}
+	for _, month := range rawPartitionMonths(from, until) {
+		switch db.Dialect() {
+		case "mysql":
+			err = db.Exec(
+				fmt.Sprintf("ALTER TABLE ? REORGANIZE PARTITION pmax INTO (%s, PARTITION pmax VALUES LESS THAN (MAXVALUE))", mysqlRawPartitionDefinition(month)),
+				dal.ClauseTable{Name: table},
+			)
+		case "postgres":
+			err = createPostgresRawPartition(db, table, month)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListRawTablePartitions returns the months of the partitions of the raw table in ascending order
+func ListRawTablePartitions(db dal.Dal, table string) ([]time.Time, errors.Error) {
+	var query string
+	switch db.Dialect() {
+	case "mysql":
+		query = "SELECT partition_name FROM information_schema.partitions WHERE table_schema = DATABASE() AND table_name = ? AND partition_name IS NOT NULL ORDER BY partition_ordinal_position"
+	case "postgres":
+		query = "SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid JOIN pg_class p ON p.oid = i.inhparent WHERE p.relname = ? ORDER BY c.relname"
+	default:
+		return nil, nil
+	}
+	names, err := queryRawPartitionStrings(db, query, table)
+	if err != nil {
+		return nil, err
+	}
+	months := make([]time.Time, 0, len(names))
+	for _, name := range names {
+		if month, ok := rawPartitionMonth(name); ok {
+			months = append(months, month)
+		}
+	}
+	return months, nil
+}
+
+// DropRawTablePartitionsBefore drops the partitions of the months ended before the month of `before`, which
+// is how the raw data of a partitioned table expires without scanning it. Returns the number of dropped partitions.
+func DropRawTablePartitionsBefore(db dal.Dal, table string, before time.Time) (int, errors.Error) {
+	months, err := ListRawTablePartitions(db, table)
+	if err != nil {
+		return 0, err
+	}
+	limit := rawPartitionMonthOf(before)
+	var expired []string
+	for _, month := range months {
+		if !month.Before(limit) {
+			break
+		}
+		expired = append(expired, rawPartitionName(db, table, month))
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+	if db.Dialect() == "mysql" {
+		err = db.Exec(fmt.Sprintf("ALTER TABLE ? DROP PARTITION %s", strings.Join(expired, ", ")), dal.ClauseTable{Name: table})
+	} else {
+		tables := make([]interface{}, 0, len(expired))
+		for _, name := range expired {
+			tables = append(tables, name)
+		}
+		err = db.DropTables(tables...)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(expired), nil
+}
+
+// IsRawTablePartitionName tells if the table is a partition of a raw table rather than a raw table, Postgres
+// lists the partitions among the tables
+func IsRawTablePartitionName(table string) bool {
+	if strings.HasSuffix(table, "_pdefault") || strings.HasSuffix(table, "_unpartitioned") {
+		return true
+	}
+	i := strings.LastIndex(table, "_p")
+	if i < 0 {
+		return false
+	}
+	_, ok := rawPartitionMonth(table[i+1:])
+	return ok
+}
+
+func createPostgresRawPartition(db dal.Dal, table string, month time.Time) errors.Error {
+	return db.Exec(
+		fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS ? PARTITION OF ? FOR VALUES FROM ('%s') TO ('%s')",
+			month.Format(rawPartitionTimeLayout), month.AddDate(0, 1, 0).Format(rawPartitionTimeLayout),
+		),
+		dal.ClauseTable{Name: rawPartitionName(db, table, month)}, dal.ClauseTable{Name: table},
+	)
+}
+
+func mysqlRawPartitionDefinition(month time.Time) string {
+	return fmt.Sprintf(
+		"PARTITION p%s VALUES LESS THAN ('%s')",
+		month.Format(rawPartitionMonthLayout), month.AddDate(0, 1, 0).Format(rawPartitionTimeLayout),
+	)
+}
+
+func rawPartitionName(db dal.Dal, table string, month time.Time) string {
+	if db.Dialect() == "mysql" {
+		return "p" + month.Format(rawPartitionMonthLayout)
+	}
+	return fmt.Sprintf("%s_p%s", table, month.Format(rawPartitionMonthLayout))
+}
+
+// rawPartitionMonth parses the month out of a partition name, the `pmax`/`pdefault` partitions have none
+func rawPartitionMonth(name string) (time.Time, bool) {
+	i := strings.LastIndex(name, "p")
+	if i < 0 {
+		return time.Time{}, false
+	}
+	month, err := time.ParseInLocation(rawPartitionMonthLayout, name[i+1:], time.Local)
+	return month, err == nil
+}
+
+func rawPartitionMonthOf(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+}
+
+// rawPartitionMonths returns the first day of every month from the month of `from` to the month of `until`
+func rawPartitionMonths(from, until time.Time) []time.Time {
+	var months []time.Time
+	for month := rawPartitionMonthOf(from); !month.After(until); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	return months
+}
+
+func queryRawPartitionStrings(db dal.Dal, query string, params ...interface{}) ([]string, errors.Error) {
+	rows, err := db.RawCursor(query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var value sql.NullString
+		if err := rows.Scan(&value); err != nil {
+			return nil, errors.Convert(err)
+		}
+		values = append(values, value.String)
+	}
+	return values, errors.Convert(rows.Err())
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawPartitionMonths(t *testing.T) {
+	from := time.Date(2022, 11, 17, 8, 0, 0, 0, time.Local)
+	until := time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local)
+	months := rawPartitionMonths(from, until)
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 11, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 12, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local),
+		time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local),
+	}, months)
+	assert.Equal(t, "PARTITION p202301 VALUES LESS THAN ('2023-02-01 00:00:00')", mysqlRawPartitionDefinition(months[2]))
+}
+
+func TestRawPartitionMonth(t *testing.T) {
+	month, ok := rawPartitionMonth("p202307")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.Local), month)
+
+	month, ok = rawPartitionMonth("_raw_jira_api_issues_p202307")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.Local), month)
+
+	_, ok = rawPartitionMonth("pmax")
+	assert.False(t, ok)
+	_, ok = rawPartitionMonth("_raw_jira_api_issues_pdefault")
+	assert.False(t, ok)
+}
+
+func TestIsRawTablePartitionName(t *testing.T) {
+	assert.True(t, IsRawTablePartitionName("_raw_jira_api_issues_p202307"))
+	assert.True(t, IsRawTablePartitionName("_raw_jira_api_issues_pdefault"))
+	assert.False(t, IsRawTablePartitionName("_raw_jira_api_issues"))
+	assert.False(t, IsRawTablePartitionName("_raw_github_api_pull_requests"))
+}
@@ -0,0 +1,175 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+)
+
+const tagRefPrefix = "refs/tags/"
+
+// ReleaseDeploymentGeneratorArgs is the arguments of NewReleaseDeploymentGenerator
+type ReleaseDeploymentGeneratorArgs struct {
+	RawDataSubTaskArgs
+	// CicdScopeId is the domain layer id of the cicd_scope whose cicd_releases are deployments
+	CicdScopeId string
+	// RepoId is the domain layer id of the repo whose git tags (refs cloned by gitextractor) are deployments
+	RepoId  string
+	RepoUrl string
+	// Pattern is matched against the tag names, only the releases and tags matching it are deployments
+	Pattern string
+	// Environment of the deployments, devops.PRODUCTION if empty
+	Environment string
+}
+
+// ReleaseDeploymentGenerator generates cicd_deployment_commits from the releases and git tags matching a pattern,
+// so projects shipping by tagging get deployment based metrics without a cicd pipeline
+type ReleaseDeploymentGenerator struct {
+	*RawDataSubTask
+	args    *ReleaseDeploymentGeneratorArgs
+	pattern *regexp.Regexp
+}
+
+// ReleasedTag is a release or a git tag, CreatedDate is the publish date of the release or the date of the tagged commit
+type ReleasedTag struct {
+	Id          string
+	Name        string
+	TagName     string
+	CommitSha   string
+	CreatedDate *time.Time
+}
+
+// NewReleaseDeploymentGenerator creates a new ReleaseDeploymentGenerator
+func NewReleaseDeploymentGenerator(args ReleaseDeploymentGeneratorArgs) (*ReleaseDeploymentGenerator, errors.Error) {
+	if args.CicdScopeId == "" && args.RepoId == "" {
+		return nil, errors.Default.New("CicdScopeId or RepoId is required for ReleaseDeploymentGenerator")
+	}
+	pattern, err := errors.Convert01(regexp.Compile(args.Pattern))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid release deployment pattern")
+	}
+	if args.Environment == "" {
+		args.Environment = devops.PRODUCTION
+	}
+	rawDataSubTask, err := NewRawDataSubTask(args.RawDataSubTaskArgs)
+	if err != nil {
+		return nil, err
+	}
+	return &ReleaseDeploymentGenerator{
+		RawDataSubTask: rawDataSubTask,
+		args:           &args,
+		pattern:        pattern,
+	}, nil
+}
+
+// Execute loads the releases and tags of the scope and saves the matching ones as deployments
+func (generator *ReleaseDeploymentGenerator) Execute() errors.Error {
+	db := generator.args.Ctx.GetDal()
+	var releases []ReleasedTag
+	if generator.args.CicdScopeId != "" {
+		// the tag of the release may have been found by gitextractor only after the release was converted
+		err := db.All(
+			&releases,
+			dal.Select("cr.id, cr.name, cr.tag_name, COALESCE(NULLIF(cr.commit_sha, ''), r.commit_sha) AS commit_sha, COALESCE(cr.published_date, cr.created_date) AS created_date"),
+			dal.From("cicd_releases cr"),
+			dal.Join("LEFT JOIN refs r ON (r.repo_id = ? AND r.name = CONCAT('refs/tags/', cr.tag_name))", generator.args.RepoId),
+			dal.Where("cr.cicd_scope_id = ? AND cr.is_prerelease = ?", generator.args.CicdScopeId, false),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	var tags []ReleasedTag
+	if generator.args.RepoId != "" {
+		// tags with a release are deployed by the release, prereleases included
+		err := db.All(
+			&tags,
+			dal.Select("r.id, r.name, r.name AS tag_name, r.commit_sha, COALESCE(r.created_date, c.committed_date) AS created_date"),
+			dal.From("refs r"),
+			dal.Join("LEFT JOIN commits c ON (c.sha = r.commit_sha)"),
+			dal.Where(
+				`r.repo_id = ? AND r.ref_type = ? AND NOT EXISTS (
+					SELECT 1 FROM cicd_releases cr WHERE cr.cicd_scope_id = ? AND CONCAT('refs/tags/', cr.tag_name) = r.name
+				)`,
+				generator.args.RepoId, "TAG", generator.args.CicdScopeId,
+			),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	divider := NewBatchSaveDivider(generator.args.Ctx, 500, generator.table, generator.params)
+	batch, err := divider.ForType(reflect.TypeOf(&devops.CicdDeploymentCommit{}))
+	if err != nil {
+		return err
+	}
+	origin := common.RawDataOrigin{RawDataTable: generator.table, RawDataParams: generator.params}
+	for _, deployment := range BuildReleaseDeployments(append(releases, tags...), generator.pattern, generator.args) {
+		deployment.RawDataOrigin = origin
+		err = batch.Add(deployment)
+		if err != nil {
+			return err
+		}
+	}
+	return divider.Close()
+}
+
+// BuildReleaseDeployments turns the released tags whose names match the pattern into successful deployments,
+// the ones without a commit or a date can't be placed on the history and are skipped
+func BuildReleaseDeployments(released []ReleasedTag, pattern *regexp.Regexp, args *ReleaseDeploymentGeneratorArgs) []*devops.CicdDeploymentCommit {
+	var deployments []*devops.CicdDeploymentCommit
+	for _, tag := range released {
+		tagName := strings.TrimPrefix(tag.TagName, tagRefPrefix)
+		if tag.CommitSha == "" || tag.CreatedDate == nil || !pattern.MatchString(tagName) {
+			continue
+		}
+		name := tag.Name
+		if name == "" || name == tag.TagName {
+			name = tagName
+		}
+		deployments = append(deployments, &devops.CicdDeploymentCommit{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: tag.Id,
+			},
+			CicdScopeId:      args.CicdScopeId,
+			CicdDeploymentId: tag.Id,
+			Name:             name,
+			Result:           devops.SUCCESS,
+			Status:           devops.DONE,
+			Environment:      args.Environment,
+			CreatedDate:      *tag.CreatedDate,
+			StartedDate:      tag.CreatedDate,
+			FinishedDate:     tag.CreatedDate,
+			CommitSha:        tag.CommitSha,
+			RefName:          tagName,
+			RepoId:           args.RepoId,
+			RepoUrl:          args.RepoUrl,
+		})
+	}
+	return deployments
+}
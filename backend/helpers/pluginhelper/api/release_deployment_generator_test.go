@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReleaseDeployments(t *testing.T) {
+	args := &ReleaseDeploymentGeneratorArgs{
+		CicdScopeId: "github:GithubRepo:1:1",
+		RepoId:      "github:GithubRepo:1:1",
+		RepoUrl:     "https://github.com/apache/incubator-devlake",
+		Environment: devops.PRODUCTION,
+	}
+	deployments := BuildReleaseDeployments(
+		[]ReleasedTag{
+			{Id: "github:GithubRelease:1:10", Name: "Release 1.0", TagName: "v1.0.0", CommitSha: "aaa", CreatedDate: dayPtr(1)},
+			{Id: "github:GithubRelease:1:11", Name: "Nightly", TagName: "nightly", CommitSha: "bbb", CreatedDate: dayPtr(2)},
+			{Id: "github:GithubRelease:1:12", Name: "", TagName: "v1.1.0", CommitSha: "", CreatedDate: dayPtr(3)},
+			{Id: "github:GithubRepo:1:1:refs/tags/v1.2.0", Name: "refs/tags/v1.2.0", TagName: "refs/tags/v1.2.0", CommitSha: "ccc", CreatedDate: dayPtr(4)},
+			{Id: "github:GithubRepo:1:1:refs/tags/v1.3.0", Name: "refs/tags/v1.3.0", TagName: "refs/tags/v1.3.0", CommitSha: "ddd"},
+		},
+		regexp.MustCompile(`^v\d+\.\d+\.\d+$`),
+		args,
+	)
+	assert.Equal(t, []*devops.CicdDeploymentCommit{
+		{
+			DomainEntity:     domainlayer.DomainEntity{Id: "github:GithubRelease:1:10"},
+			CicdScopeId:      args.CicdScopeId,
+			CicdDeploymentId: "github:GithubRelease:1:10",
+			Name:             "Release 1.0",
+			Result:           devops.SUCCESS,
+			Status:           devops.DONE,
+			Environment:      devops.PRODUCTION,
+			CreatedDate:      day(1),
+			StartedDate:      dayPtr(1),
+			FinishedDate:     dayPtr(1),
+			CommitSha:        "aaa",
+			RefName:          "v1.0.0",
+			RepoId:           args.RepoId,
+			RepoUrl:          args.RepoUrl,
+		},
+		{
+			DomainEntity:     domainlayer.DomainEntity{Id: "github:GithubRepo:1:1:refs/tags/v1.2.0"},
+			CicdScopeId:      args.CicdScopeId,
+			CicdDeploymentId: "github:GithubRepo:1:1:refs/tags/v1.2.0",
+			Name:             "v1.2.0",
+			Result:           devops.SUCCESS,
+			Status:           devops.DONE,
+			Environment:      devops.PRODUCTION,
+			CreatedDate:      day(4),
+			StartedDate:      dayPtr(4),
+			FinishedDate:     dayPtr(4),
+			CommitSha:        "ccc",
+			RefName:          "v1.2.0",
+			RepoId:           args.RepoId,
+			RepoUrl:          args.RepoUrl,
+		},
+	}, deployments)
+}
+
+func TestNewReleaseDeploymentGeneratorInvalidPattern(t *testing.T) {
+	_, err := NewReleaseDeploymentGenerator(ReleaseDeploymentGeneratorArgs{
+		RepoId:  "github:GithubRepo:1:1",
+		Pattern: "v(",
+	})
+	assert.NotNil(t, err)
+}
@@ -30,7 +30,8 @@ type ScopeDatabaseHelper[Conn any, Scope any, Tr any] interface {
 	SaveScope(scopes []*Scope) errors.Error
 	UpdateScope(connectionId uint64, scopeId string, scope *Scope) errors.Error
 	GetScope(connectionId uint64, scopeId string) (Scope, errors.Error)
-	ListScopes(input *plugin.ApiResourceInput, connectionId uint64) ([]*Scope, errors.Error)
+	// ListScopes returns a page of the scopes of the connection along with the count of all of them matching the filters
+	ListScopes(input *plugin.ApiResourceInput, connectionId uint64) ([]*Scope, int64, errors.Error)
 	DeleteScope(connectionId uint64, scopeId string) errors.Error
 	GetTransformationRule(ruleId uint64) (Tr, errors.Error)
 	ListTransformationRules(ruleIds []uint64) ([]*Tr, errors.Error)
@@ -86,11 +87,22 @@ func (s *ScopeDatabaseHelperImpl[Conn, Scope, Tr]) GetScope(connectionId uint64,
 	return scope, err
 }
 
-func (s *ScopeDatabaseHelperImpl[Conn, Scope, Tr]) ListScopes(input *plugin.ApiResourceInput, connectionId uint64) ([]*Scope, errors.Error) {
-	limit, offset := GetLimitOffset(input.Query, "pageSize", "page")
+func (s *ScopeDatabaseHelperImpl[Conn, Scope, Tr]) ListScopes(input *plugin.ApiResourceInput, connectionId uint64) ([]*Scope, int64, errors.Error) {
+	listQuery, err := ParseListQuery(input.Query, ListFields(new(Scope)))
+	if err != nil {
+		return nil, 0, err
+	}
+	clauses := append([]dal.Clause{dal.From(new(Scope)), dal.Where("connection_id = ?", connectionId)}, listQuery.Clauses()...)
+	count, err := s.db.Count(clauses...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if orderby := listQuery.Orderby(""); orderby != "" {
+		clauses = append(clauses, dal.Orderby(orderby))
+	}
 	var scopes []*Scope
-	err := s.db.All(&scopes, dal.Where("connection_id = ?", connectionId), dal.Limit(limit), dal.Offset(offset))
-	return scopes, err
+	err = s.db.All(&scopes, append(clauses, dal.Limit(listQuery.PageSize), dal.Offset(listQuery.GetSkip()))...)
+	return scopes, count, err
 }
 
 func (s *ScopeDatabaseHelperImpl[Conn, Scope, Tr]) DeleteScope(connectionId uint64, scopeId string) errors.Error {
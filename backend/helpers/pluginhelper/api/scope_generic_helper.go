@@ -189,22 +189,24 @@ func (c *GenericScopeApiHelper[Conn, Scope, Tr]) UpdateScope(input *plugin.ApiRe
 	return scopeRes[0], nil
 }
 
-func (c *GenericScopeApiHelper[Conn, Scope, Tr]) GetScopes(input *plugin.ApiResourceInput) ([]*ScopeRes[Scope], errors.Error) {
+// GetScopes returns a page of the scopes of the connection, sorted and filtered by the query params, along with the
+// count of all of them matching the filters
+func (c *GenericScopeApiHelper[Conn, Scope, Tr]) GetScopes(input *plugin.ApiResourceInput) ([]*ScopeRes[Scope], int64, errors.Error) {
 	params := c.extractFromGetReqParam(input)
 	if params.connectionId == 0 {
-		return nil, errors.BadInput.New("invalid path params: \"connectionId\" not set")
+		return nil, 0, errors.BadInput.New("invalid path params: \"connectionId\" not set")
 	}
 	err := c.dbHelper.VerifyConnection(params.connectionId)
 	if err != nil {
-		return nil, errors.Default.Wrap(err, fmt.Sprintf("error verifying connection for connection ID %d", params.connectionId))
+		return nil, 0, errors.Default.Wrap(err, fmt.Sprintf("error verifying connection for connection ID %d", params.connectionId))
 	}
-	scopes, err := c.dbHelper.ListScopes(input, params.connectionId)
+	scopes, count, err := c.dbHelper.ListScopes(input, params.connectionId)
 	if err != nil {
-		return nil, errors.Default.Wrap(err, fmt.Sprintf("error verifying connection for connection ID %d", params.connectionId))
+		return nil, 0, errors.Default.Wrap(err, fmt.Sprintf("error listing scopes for connection ID %d", params.connectionId))
 	}
 	apiScopes, err := c.addTransformationName(scopes...)
 	if err != nil {
-		return nil, errors.Default.Wrap(err, "error associating transformations with scopes")
+		return nil, 0, errors.Default.Wrap(err, "error associating transformations with scopes")
 	}
 	if params.loadBlueprints {
 		scopesById := c.mapByScopeId(apiScopes)
@@ -214,15 +216,14 @@ func (c *GenericScopeApiHelper[Conn, Scope, Tr]) GetScopes(input *plugin.ApiReso
 		}
 		blueprintMap, err := c.bpManager.GetBlueprintsByScopes(params.connectionId, scopeIds...)
 		if err != nil {
-			return nil, errors.Default.Wrap(err, fmt.Sprintf("error getting blueprints for scopes from connection %d", params.connectionId))
+			return nil, 0, errors.Default.Wrap(err, fmt.Sprintf("error getting blueprints for scopes from connection %d", params.connectionId))
 		}
-		apiScopes = nil
+		// the scopes keep the order of the page
 		for scopeId, scope := range scopesById {
 			if bps, ok := blueprintMap[scopeId]; ok {
 				scope.Blueprints = bps
 				delete(blueprintMap, scopeId)
 			}
-			apiScopes = append(apiScopes, scope)
 		}
 		if len(blueprintMap) > 0 {
 			var danglingIds []string
@@ -232,7 +233,7 @@ func (c *GenericScopeApiHelper[Conn, Scope, Tr]) GetScopes(input *plugin.ApiReso
 			c.log.Warn(nil, "The following dangling scopes were found: %v", danglingIds)
 		}
 	}
-	return apiScopes, nil
+	return apiScopes, count, nil
 }
 
 func (c *GenericScopeApiHelper[Conn, Scope, Tr]) GetScope(input *plugin.ApiResourceInput) (*ScopeRes[Scope], errors.Error) {
@@ -560,7 +561,7 @@ func (sr *ScopeRes[T]) MarshalJSON() ([]byte, error) {
 
 func createDeleteQuery(tableName string, scopeIdKey string, scopeId string) string {
 	column := "_raw_data_params"
-	if tableName == (models.CollectorLatestState{}.TableName()) {
+	if tableName == (models.CollectorLatestState{}.TableName()) || tableName == (models.CollectorHttpCache{}.TableName()) {
 		column = "raw_data_params"
 	} else if strings.HasPrefix(tableName, "_raw_") {
 		column = "params"
@@ -602,7 +603,7 @@ func getAffectedTables(pluginName string) ([]string, errors.Error) {
 			}
 		}
 		// additional tables
-		tables = append(tables, models.CollectorLatestState{}.TableName())
+		tables = append(tables, models.CollectorLatestState{}.TableName(), models.CollectorHttpCache{}.TableName())
 	}
 	return tables, nil
 }
@@ -105,11 +105,11 @@ func (c *ScopeApiHelper[Conn, Scope, Tr]) Update(input *plugin.ApiResourceInput,
 }
 
 func (c *ScopeApiHelper[Conn, Scope, Tr]) GetScopeList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	scopes, err := c.GetScopes(input)
+	scopes, count, err := c.GetScopes(input)
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: scopes, Status: http.StatusOK}, nil
+	return &plugin.ApiResourceOutput{Body: NewPageResponse("scopes", scopes, count, input.Query), Status: http.StatusOK}, nil
 }
 
 // TODO remove fieldName param in the future and adjust plugins to use reflection params on init
@@ -0,0 +1,337 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// SprintEnricherArgs is the arguments of NewSprintEnricher
+type SprintEnricherArgs struct {
+	RawDataSubTaskArgs
+	// BoardId is the domain layer id of the board whose sprints are enriched
+	BoardId string
+	// SprintFieldName is the field_name of the issue_changelogs recording the sprint changes of issues,
+	// whose original_from_value and original_to_value are comma separated domain layer sprint ids.
+	SprintFieldName string
+	// SprintChanges are the sprint changes of trackers recording them elsewhere than issue_changelogs.
+	// Only the current sprint_issues are used when neither SprintFieldName nor SprintChanges is given
+	SprintChanges []SprintChange
+}
+
+// SprintEnricher generates sprint_issue_histories and sprint_scopes from the domain layer sprints, sprint_issues
+// and issue_changelogs of a board, so sprint analytics work the same way for all trackers
+type SprintEnricher struct {
+	*RawDataSubTask
+	args *SprintEnricherArgs
+}
+
+// SprintChange is a change of the sprints of an issue, the sprint ids are comma separated domain layer ids
+type SprintChange struct {
+	IssueId       string
+	FromSprintIds string
+	ToSprintIds   string
+	CreatedDate   time.Time
+}
+
+// NewSprintEnricher creates a new SprintEnricher
+func NewSprintEnricher(args SprintEnricherArgs) (*SprintEnricher, errors.Error) {
+	if args.BoardId == "" {
+		return nil, errors.Default.New("BoardId is required for SprintEnricher")
+	}
+	rawDataSubTask, err := NewRawDataSubTask(args.RawDataSubTaskArgs)
+	if err != nil {
+		return nil, err
+	}
+	return &SprintEnricher{
+		RawDataSubTask: rawDataSubTask,
+		args:           &args,
+	}, nil
+}
+
+// Execute loads the sprints of the board and saves their issue histories and scopes
+func (enricher *SprintEnricher) Execute() errors.Error {
+	db := enricher.args.Ctx.GetDal()
+	boardId := enricher.args.BoardId
+
+	var sprints []ticket.Sprint
+	err := db.All(
+		&sprints,
+		dal.Select("s.*"),
+		dal.From("sprints s"),
+		dal.Join("INNER JOIN board_sprints bs ON (bs.sprint_id = s.id)"),
+		dal.Where("bs.board_id = ?", boardId),
+	)
+	if err != nil {
+		return err
+	}
+	var memberships []ticket.SprintIssue
+	err = db.All(
+		&memberships,
+		dal.Select("si.*"),
+		dal.From("sprint_issues si"),
+		dal.Join("INNER JOIN board_sprints bs ON (bs.sprint_id = si.sprint_id)"),
+		dal.Where("bs.board_id = ?", boardId),
+	)
+	if err != nil {
+		return err
+	}
+	var issues []ticket.Issue
+	err = db.All(
+		&issues,
+		dal.Select("i.*"),
+		dal.From("issues i"),
+		dal.Join("INNER JOIN board_issues bi ON (bi.issue_id = i.id)"),
+		dal.Where("bi.board_id = ?", boardId),
+	)
+	if err != nil {
+		return err
+	}
+	changes := enricher.args.SprintChanges
+	if enricher.args.SprintFieldName != "" {
+		var changelogs []SprintChange
+		err = db.All(
+			&changelogs,
+			dal.Select("ic.issue_id, ic.original_from_value AS from_sprint_ids, ic.original_to_value AS to_sprint_ids, ic.created_date"),
+			dal.From("issue_changelogs ic"),
+			dal.Join("INNER JOIN board_issues bi ON (bi.issue_id = ic.issue_id)"),
+			dal.Where("bi.board_id = ? AND ic.field_name = ?", boardId, enricher.args.SprintFieldName),
+			dal.Orderby("ic.created_date"),
+		)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, changelogs...)
+	}
+
+	sprintMap := make(map[string]*ticket.Sprint, len(sprints))
+	for i := range sprints {
+		sprintMap[sprints[i].Id] = &sprints[i]
+	}
+	issueMap := make(map[string]*ticket.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].Id] = &issues[i]
+	}
+	histories := BuildSprintIssueHistories(memberships, changes, func(sprintId, issueId string) time.Time {
+		if issue := issueMap[issueId]; issue != nil && issue.CreatedDate != nil {
+			return *issue.CreatedDate
+		}
+		if sprint := sprintMap[sprintId]; sprint != nil && sprint.StartedDate != nil {
+			return *sprint.StartedDate
+		}
+		return time.Time{}
+	})
+
+	divider := NewBatchSaveDivider(enricher.args.Ctx, 500, enricher.table, enricher.params)
+	origin := common.RawDataOrigin{RawDataTable: enricher.table, RawDataParams: enricher.params}
+	historiesBySprint := make(map[string][]*ticket.SprintIssueHistory)
+	for _, history := range histories {
+		// sprints of other boards are left to the enrichment of their own boards
+		if sprintMap[history.SprintId] == nil {
+			continue
+		}
+		historiesBySprint[history.SprintId] = append(historiesBySprint[history.SprintId], history)
+		history.RawDataOrigin = origin
+		err = saveWithDivider(divider, history)
+		if err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	for _, sprint := range sprints {
+		scope := CalculateSprintScope(&sprint, historiesBySprint[sprint.Id], issueMap, now)
+		if scope == nil {
+			continue
+		}
+		scope.RawDataOrigin = origin
+		err = saveWithDivider(divider, scope)
+		if err != nil {
+			return err
+		}
+	}
+	return divider.Close()
+}
+
+func saveWithDivider(divider *BatchSaveDivider, record interface{}) errors.Error {
+	batch, err := divider.ForType(reflect.TypeOf(record))
+	if err != nil {
+		return err
+	}
+	return batch.Add(record)
+}
+
+// BuildSprintIssueHistories replays the sprint changes on top of the current memberships, addedSince returns the date
+// an issue joined a sprint when no change records it, i.e. it has been there since the issue was created
+func BuildSprintIssueHistories(
+	memberships []ticket.SprintIssue,
+	changes []SprintChange,
+	addedSince func(sprintId, issueId string) time.Time,
+) []*ticket.SprintIssueHistory {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].CreatedDate.Before(changes[j].CreatedDate)
+	})
+	type key struct{ sprintId, issueId string }
+	var histories []*ticket.SprintIssueHistory
+	open := make(map[key]*ticket.SprintIssueHistory)
+	seen := make(map[key]bool)
+	for _, change := range changes {
+		from := splitSprintIds(change.FromSprintIds)
+		to := splitSprintIds(change.ToSprintIds)
+		for sprintId := range from {
+			if to[sprintId] {
+				continue
+			}
+			k := key{sprintId, change.IssueId}
+			removedDate := change.CreatedDate
+			history := open[k]
+			if history == nil {
+				// the issue was in the sprint before the first recorded change
+				history = &ticket.SprintIssueHistory{SprintId: sprintId, IssueId: change.IssueId, AddedDate: addedSince(sprintId, change.IssueId)}
+				if history.AddedDate.After(removedDate) {
+					history.AddedDate = removedDate
+				}
+				histories = append(histories, history)
+			}
+			history.RemovedDate = &removedDate
+			delete(open, k)
+			seen[k] = true
+		}
+		for sprintId := range to {
+			if from[sprintId] {
+				continue
+			}
+			k := key{sprintId, change.IssueId}
+			if open[k] != nil {
+				continue
+			}
+			history := &ticket.SprintIssueHistory{SprintId: sprintId, IssueId: change.IssueId, AddedDate: change.CreatedDate}
+			histories = append(histories, history)
+			open[k] = history
+			seen[k] = true
+		}
+	}
+	for _, membership := range memberships {
+		k := key{membership.SprintId, membership.IssueId}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		histories = append(histories, &ticket.SprintIssueHistory{
+			SprintId:  membership.SprintId,
+			IssueId:   membership.IssueId,
+			AddedDate: addedSince(membership.SprintId, membership.IssueId),
+		})
+	}
+	return histories
+}
+
+func splitSprintIds(ids string) map[string]bool {
+	result := make(map[string]bool)
+	for _, id := range strings.Split(ids, ",") {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			result[id] = true
+		}
+	}
+	return result
+}
+
+// CalculateSprintScope counts the issues committed at the start of the sprint, added and removed during the sprint, and
+// completed by its end, the end is the completed date, the planned end date, or now for running sprints.
+// It returns nil for sprints which have not started
+func CalculateSprintScope(
+	sprint *ticket.Sprint,
+	histories []*ticket.SprintIssueHistory,
+	issues map[string]*ticket.Issue,
+	now time.Time,
+) *ticket.SprintScope {
+	if sprint.StartedDate == nil {
+		return nil
+	}
+	start := *sprint.StartedDate
+	end := now
+	if sprint.CompletedDate != nil {
+		end = *sprint.CompletedDate
+	} else if sprint.EndedDate != nil && sprint.EndedDate.Before(now) {
+		end = *sprint.EndedDate
+	}
+	committed := make(map[string]bool)
+	added := make(map[string]bool)
+	atEnd := make(map[string]bool)
+	removed := make(map[string]bool)
+	for _, history := range histories {
+		if history.AddedDate.After(end) {
+			continue
+		}
+		if history.RemovedDate != nil && !history.RemovedDate.After(start) {
+			continue
+		}
+		if !history.AddedDate.After(start) {
+			committed[history.IssueId] = true
+		} else {
+			added[history.IssueId] = true
+		}
+		if history.RemovedDate == nil || history.RemovedDate.After(end) {
+			atEnd[history.IssueId] = true
+		} else {
+			removed[history.IssueId] = true
+		}
+	}
+	scope := &ticket.SprintScope{SprintId: sprint.Id}
+	storyPoint := func(issueId string) float64 {
+		if issue := issues[issueId]; issue != nil {
+			return issue.StoryPoint
+		}
+		return 0
+	}
+	for issueId := range committed {
+		scope.CommittedIssues++
+		scope.CommittedStoryPoints += storyPoint(issueId)
+	}
+	for issueId := range added {
+		if committed[issueId] {
+			continue
+		}
+		scope.AddedIssues++
+		scope.AddedStoryPoints += storyPoint(issueId)
+	}
+	for issueId := range removed {
+		if atEnd[issueId] {
+			continue
+		}
+		scope.RemovedIssues++
+		scope.RemovedStoryPoints += storyPoint(issueId)
+	}
+	for issueId := range atEnd {
+		issue := issues[issueId]
+		if issue == nil || issue.ResolutionDate == nil || issue.ResolutionDate.After(end) {
+			continue
+		}
+		scope.CompletedIssues++
+		scope.CompletedStoryPoints += issue.StoryPoint
+	}
+	return scope
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func day(d int) time.Time {
+	return time.Date(2023, 6, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dayPtr(d int) *time.Time {
+	t := day(d)
+	return &t
+}
+
+func TestBuildSprintIssueHistories(t *testing.T) {
+	histories := BuildSprintIssueHistories(
+		[]ticket.SprintIssue{
+			{SprintId: "s2", IssueId: "i1"},
+			{SprintId: "s1", IssueId: "i2"},
+			{SprintId: "s1", IssueId: "i3"},
+		},
+		[]SprintChange{
+			{IssueId: "i1", FromSprintIds: "s1", ToSprintIds: "s1,s2", CreatedDate: day(5)},
+			{IssueId: "i1", FromSprintIds: "", ToSprintIds: "s1", CreatedDate: day(2)},
+			{IssueId: "i1", FromSprintIds: "s1,s2", ToSprintIds: "s2", CreatedDate: day(8)},
+			{IssueId: "i2", FromSprintIds: "s1", ToSprintIds: "", CreatedDate: day(3)},
+			{IssueId: "i2", FromSprintIds: "", ToSprintIds: "s1", CreatedDate: day(4)},
+		},
+		func(sprintId, issueId string) time.Time {
+			return day(1)
+		},
+	)
+	assert.Equal(t, []*ticket.SprintIssueHistory{
+		{SprintId: "s1", IssueId: "i1", AddedDate: day(2), RemovedDate: dayPtr(8)},
+		{SprintId: "s1", IssueId: "i2", AddedDate: day(1), RemovedDate: dayPtr(3)},
+		{SprintId: "s1", IssueId: "i2", AddedDate: day(4)},
+		{SprintId: "s2", IssueId: "i1", AddedDate: day(5)},
+		{SprintId: "s1", IssueId: "i3", AddedDate: day(1)},
+	}, histories)
+}
+
+func TestCalculateSprintScope(t *testing.T) {
+	sprint := &ticket.Sprint{
+		DomainEntity:  domainlayer.DomainEntity{Id: "s1"},
+		StartedDate:   dayPtr(3),
+		EndedDate:     dayPtr(10),
+		CompletedDate: dayPtr(11),
+	}
+	issues := map[string]*ticket.Issue{
+		"committed-done":    {StoryPoint: 3, ResolutionDate: dayPtr(9)},
+		"committed-open":    {StoryPoint: 5},
+		"added-done":        {StoryPoint: 2, ResolutionDate: dayPtr(6)},
+		"removed":           {StoryPoint: 8},
+		"done-after-sprint": {StoryPoint: 1, ResolutionDate: dayPtr(12)},
+	}
+	scope := CalculateSprintScope(sprint, []*ticket.SprintIssueHistory{
+		{IssueId: "committed-done", AddedDate: day(1)},
+		{IssueId: "committed-open", AddedDate: day(3)},
+		{IssueId: "added-done", AddedDate: day(4)},
+		{IssueId: "removed", AddedDate: day(1), RemovedDate: dayPtr(5)},
+		{IssueId: "done-after-sprint", AddedDate: day(2)},
+		{IssueId: "left-before-start", AddedDate: day(1), RemovedDate: dayPtr(2)},
+		{IssueId: "added-after-end", AddedDate: day(12)},
+	}, issues, day(20))
+	assert.Equal(t, &ticket.SprintScope{
+		SprintId:             "s1",
+		CommittedIssues:      4,
+		CommittedStoryPoints: 17,
+		AddedIssues:          1,
+		AddedStoryPoints:     2,
+		RemovedIssues:        1,
+		RemovedStoryPoints:   8,
+		CompletedIssues:      2,
+		CompletedStoryPoints: 5,
+	}, scope)
+
+	assert.Nil(t, CalculateSprintScope(&ticket.Sprint{}, nil, issues, day(20)))
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const tombstoneBatchSize = 500
+
+// TombstoneArgs is the arguments of MarkDeletedRecords
+type TombstoneArgs struct {
+	Ctx plugin.SubTaskContext
+	// Table is the domain layer model to be reconciled, it must have the `id` and `deleted_at` columns
+	Table dal.Tabler
+	// Clauses narrow down the records to those belonging to the scope being reconciled
+	Clauses []dal.Clause
+	// LiveIds are the domain ids of the records still existing upstream
+	LiveIds []string
+}
+
+// MarkDeletedRecords sets `deleted_at` of the records which are no longer present upstream,
+// and clears it for records which reappeared, so consumers can tell stale rows from live ones.
+// Records already marked keep the time they were first found deleted
+func MarkDeletedRecords(args TombstoneArgs) errors.Error {
+	db := args.Ctx.GetDal()
+	var existingIds []string
+	err := db.Pluck("id", &existingIds, append([]dal.Clause{dal.From(args.Table)}, args.Clauses...)...)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load existing ids")
+	}
+	deletedIds, liveIds := splitTombstoneIds(existingIds, args.LiveIds)
+	// `deleted_at` is create-only on the models so that convertors never reset it, update it by table name instead
+	table := args.Table.TableName()
+	now := time.Now()
+	for _, batch := range chunkIds(deletedIds, tombstoneBatchSize) {
+		err = db.UpdateColumn(table, "deleted_at", now, dal.Where("id IN ? AND deleted_at IS NULL", batch))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to mark deleted records")
+		}
+	}
+	for _, batch := range chunkIds(liveIds, tombstoneBatchSize) {
+		err = db.UpdateColumn(table, "deleted_at", nil, dal.Where("id IN ? AND deleted_at IS NOT NULL", batch))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to clear tombstones")
+		}
+	}
+	args.Ctx.GetLogger().Info("%s: %d of %d records marked as deleted", table, len(deletedIds), len(existingIds))
+	return nil
+}
+
+// splitTombstoneIds splits the existing ids into those missing upstream and those still alive
+func splitTombstoneIds(existingIds []string, upstreamIds []string) (deletedIds []string, liveIds []string) {
+	upstream := make(map[string]bool, len(upstreamIds))
+	for _, id := range upstreamIds {
+		upstream[id] = true
+	}
+	for _, id := range existingIds {
+		if upstream[id] {
+			liveIds = append(liveIds, id)
+		} else {
+			deletedIds = append(deletedIds, id)
+		}
+	}
+	return
+}
+
+func chunkIds(ids []string, size int) [][]string {
+	var chunks [][]string
+	for size < len(ids) {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
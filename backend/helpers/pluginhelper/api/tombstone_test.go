@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTombstoneRecord struct {
+	Id string `gorm:"primaryKey"`
+}
+
+func (MockTombstoneRecord) TableName() string {
+	return "mock_tombstone_records"
+}
+
+func TestMarkDeletedRecords(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetDal").Return(mockDal)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+
+	mockDal.On("Pluck", "id", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = []string{"a", "b", "c"}
+	}).Return(nil).Once()
+	var marked, cleared dal.DalClause
+	// the updates go by table name, otherwise gorm would skip the create-only column.
+	// the nil expectation comes first, AnythingOfType can't be matched against nil
+	mockDal.On("UpdateColumn", "mock_tombstone_records", "deleted_at", nil, mock.Anything).Run(func(args mock.Arguments) {
+		cleared = args.Get(3).([]dal.Clause)[0].Data.(dal.DalClause)
+	}).Return(nil).Once()
+	mockDal.On("UpdateColumn", "mock_tombstone_records", "deleted_at", mock.AnythingOfType("time.Time"), mock.Anything).Run(func(args mock.Arguments) {
+		marked = args.Get(3).([]dal.Clause)[0].Data.(dal.DalClause)
+	}).Return(nil).Once()
+
+	err := MarkDeletedRecords(TombstoneArgs{
+		Ctx:     mockCtx,
+		Table:   &MockTombstoneRecord{},
+		LiveIds: []string{"c", "a", "d"},
+	})
+	assert.Nil(t, err)
+	mockDal.AssertExpectations(t)
+	// records already marked keep the time they were first found deleted
+	assert.Equal(t, "id IN ? AND deleted_at IS NULL", marked.Expr)
+	assert.Equal(t, []interface{}{[]string{"b"}}, marked.Params)
+	assert.Equal(t, "id IN ? AND deleted_at IS NOT NULL", cleared.Expr)
+	assert.Equal(t, []interface{}{[]string{"a", "c"}}, cleared.Params)
+}
+
+func TestMarkDeletedRecordsAllAlive(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetDal").Return(mockDal)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+
+	mockDal.On("Pluck", "id", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = []string{"a"}
+	}).Return(nil).Once()
+	mockDal.On("UpdateColumn", "mock_tombstone_records", "deleted_at", nil, mock.Anything).Return(nil).Once()
+
+	err := MarkDeletedRecords(TombstoneArgs{
+		Ctx:     mockCtx,
+		Table:   &MockTombstoneRecord{},
+		LiveIds: []string{"a"},
+	})
+	assert.Nil(t, err)
+	mockDal.AssertExpectations(t)
+	mockDal.AssertNumberOfCalls(t, "UpdateColumn", 1)
+}
+
+func TestSplitTombstoneIds(t *testing.T) {
+	deletedIds, liveIds := splitTombstoneIds([]string{"a", "b", "c"}, []string{"c", "a", "d"})
+	assert.Equal(t, []string{"b"}, deletedIds)
+	assert.Equal(t, []string{"a", "c"}, liveIds)
+}
+
+func TestChunkIds(t *testing.T) {
+	assert.Nil(t, chunkIds(nil, 2))
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, chunkIds([]string{"a", "b", "c"}, 2))
+	assert.Equal(t, [][]string{{"a", "b"}}, chunkIds([]string{"a", "b"}, 2))
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -130,3 +132,213 @@ func (t TransformationRuleHelper[Tr]) List(input *plugin.ApiResourceInput) (*plu
 	}
 	return &plugin.ApiResourceOutput{Body: rules, Status: http.StatusOK}, nil
 }
+
+// the operations of a batch of transformation rules
+const (
+	BATCH_OP_CREATE = "create"
+	BATCH_OP_UPDATE = "update"
+	BATCH_OP_DELETE = "delete"
+)
+
+// TransformationRuleBatchResult is the outcome of one item of a batch, the index is its position among the items
+// of the same operation
+type TransformationRuleBatchResult struct {
+	Op    string      `json:"op"`
+	Index int         `json:"index"`
+	Id    uint64      `json:"id,omitempty"`
+	Rule  interface{} `json:"rule,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// TransformationRuleBatchOutput tells whether the batch was applied, which is all or nothing, along with the
+// outcome of every item
+type TransformationRuleBatchOutput struct {
+	Applied bool                             `json:"applied"`
+	Results []*TransformationRuleBatchResult `json:"results"`
+}
+
+type transformationRuleBatchItem[Tr dal.Tabler] struct {
+	result *TransformationRuleBatchResult
+	rule   *Tr
+}
+
+// Batch creates, updates and deletes the transformation rules of a connection in one transaction. The body holds
+// the rules to create under `create`, the rules to update along with their id under `update` and the ids of the
+// rules to delete under `delete`. Every item is checked before anything is written, nothing is written when any
+// of them fails and the output tells which ones did.
+func (t TransformationRuleHelper[Tr]) Batch(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, e := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if e != nil || connectionId == 0 {
+		return nil, errors.Default.Wrap(e, "the connection ID should be an non-zero integer")
+	}
+	creates, err := batchTransformationRuleItems(input.Body, BATCH_OP_CREATE)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := batchTransformationRuleItems(input.Body, BATCH_OP_UPDATE)
+	if err != nil {
+		return nil, err
+	}
+	deletes, err := batchTransformationRuleItems(input.Body, BATCH_OP_DELETE)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*transformationRuleBatchItem[Tr], 0, len(creates)+len(updates)+len(deletes))
+	failed := false
+	check := func(op string, index int, id uint64, prepare func() (*Tr, errors.Error)) {
+		item := &transformationRuleBatchItem[Tr]{
+			result: &TransformationRuleBatchResult{Op: op, Index: index, Id: id},
+		}
+		item.rule, err = prepare()
+		if err != nil {
+			item.result.Error = err.Messages().Format()
+			failed = true
+		}
+		items = append(items, item)
+	}
+	for i, v := range creates {
+		check(BATCH_OP_CREATE, i, 0, func() (*Tr, errors.Error) {
+			body, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, errors.BadInput.New("the transformation rule to create should be an object")
+			}
+			var rule Tr
+			if err := DecodeMapStruct(body, &rule, false); err != nil {
+				return nil, errors.BadInput.Wrap(err, "error in decoding transformation rule")
+			}
+			return t.checkBatchRule(&rule, connectionId)
+		})
+	}
+	for i, v := range updates {
+		body, _ := v.(map[string]interface{})
+		id := batchTransformationRuleId(body["id"])
+		check(BATCH_OP_UPDATE, i, id, func() (*Tr, errors.Error) {
+			if body == nil || id == 0 {
+				return nil, errors.BadInput.New("the transformation rule to update should be an object with its id")
+			}
+			rule, err := t.findBatchRule(id, connectionId)
+			if err != nil {
+				return nil, err
+			}
+			if err := DecodeMapStruct(body, rule, false); err != nil {
+				return nil, errors.BadInput.Wrap(err, "error decoding map into transformationRule")
+			}
+			return t.checkBatchRule(rule, connectionId)
+		})
+	}
+	for i, v := range deletes {
+		id := batchTransformationRuleId(v)
+		check(BATCH_OP_DELETE, i, id, func() (*Tr, errors.Error) {
+			if id == 0 {
+				return nil, errors.BadInput.New("the transformation rule to delete should be given by its id")
+			}
+			return t.findBatchRule(id, connectionId)
+		})
+	}
+	if failed {
+		return batchTransformationRuleOutput(false, items), nil
+	}
+
+	tx := t.db.Begin()
+	for _, item := range items {
+		switch item.result.Op {
+		case BATCH_OP_CREATE:
+			err = tx.Create(item.rule)
+		case BATCH_OP_UPDATE:
+			err = tx.Update(item.rule)
+		case BATCH_OP_DELETE:
+			err = tx.Delete(item.rule)
+		}
+		if err != nil {
+			if t.db.IsDuplicationError(err) {
+				item.result.Error = "there was a transformation rule with the same name, please choose another name"
+			} else {
+				item.result.Error = err.Messages().Format()
+			}
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				t.log.Error(rollbackErr, "failed to rollback the batch of transformation rules")
+			}
+			return batchTransformationRuleOutput(false, items), nil
+		}
+		if item.result.Op != BATCH_OP_DELETE {
+			if valueId := reflect.ValueOf(item.rule).Elem().FieldByName("ID"); valueId.IsValid() {
+				item.result.Id = valueId.Uint()
+			}
+			item.result.Rule = item.rule
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Default.Wrap(err, "error on saving the batch of transformation rules")
+	}
+	return batchTransformationRuleOutput(true, items), nil
+}
+
+func (t TransformationRuleHelper[Tr]) findBatchRule(id uint64, connectionId uint64) (*Tr, errors.Error) {
+	var rule Tr
+	err := t.db.First(&rule, dal.Where("id = ? AND connection_id = ?", id, connectionId))
+	if err != nil {
+		if t.db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("could not find the transformation rule %d of the connection", id))
+		}
+		return nil, errors.Default.Wrap(err, "error on get TransformationRule")
+	}
+	return &rule, nil
+}
+
+// checkBatchRule validates a rule of the batch and keeps it in the connection of the batch
+func (t TransformationRuleHelper[Tr]) checkBatchRule(rule *Tr, connectionId uint64) (*Tr, errors.Error) {
+	if t.validator != nil {
+		if err := t.validator.Struct(rule); err != nil {
+			return nil, errors.BadInput.Wrap(err, "error validating transformation rule")
+		}
+	}
+	valueConnectionId := reflect.ValueOf(rule).Elem().FieldByName("ConnectionId")
+	if valueConnectionId.IsValid() {
+		valueConnectionId.SetUint(connectionId)
+	}
+	return rule, nil
+}
+
+func batchTransformationRuleItems(body map[string]interface{}, op string) ([]interface{}, errors.Error) {
+	if body[op] == nil {
+		return nil, nil
+	}
+	items, ok := body[op].([]interface{})
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("the %s of the batch should be a list", op))
+	}
+	return items, nil
+}
+
+// batchTransformationRuleId returns the id of a rule given in json, 0 when it is not a valid one
+func batchTransformationRuleId(v interface{}) uint64 {
+	switch id := v.(type) {
+	case float64:
+		if id > 0 && id == float64(uint64(id)) {
+			return uint64(id)
+		}
+	case json.Number:
+		n, _ := strconv.ParseUint(id.String(), 10, 64)
+		return n
+	case string:
+		n, _ := strconv.ParseUint(id, 10, 64)
+		return n
+	}
+	return 0
+}
+
+func batchTransformationRuleOutput[Tr dal.Tabler](applied bool, items []*transformationRuleBatchItem[Tr]) *plugin.ApiResourceOutput {
+	output := &TransformationRuleBatchOutput{
+		Applied: applied,
+		Results: make([]*TransformationRuleBatchResult, 0, len(items)),
+	}
+	for _, item := range items {
+		output.Results = append(output.Results, item.result)
+	}
+	status := http.StatusOK
+	if !applied {
+		status = http.StatusBadRequest
+	}
+	return &plugin.ApiResourceOutput{Body: output, Status: status}
+}
@@ -30,9 +30,14 @@ type BlueprintManager struct {
 }
 
 type GetBlueprintQuery struct {
-	Enable      *bool
-	IsManual    *bool
-	Label       string
+	Enable   *bool
+	IsManual *bool
+	Label    string
+	// ProjectNames restricts the blueprints to the given projects when it is not nil
+	ProjectNames []string
+	// Clauses are the extra filters of the list, and Orderby overrides the default order of it
+	Clauses     []dal.Clause
+	Orderby     string
 	SkipRecords int
 	PageSize    int
 }
@@ -90,13 +95,21 @@ func (b *BlueprintManager) GetDbBlueprints(query *GetBlueprintQuery) ([]*models.
 			dal.Where("bl.name = ?", query.Label),
 		)
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where("project_name IN ?", query.ProjectNames))
+	}
+	clauses = append(clauses, query.Clauses...)
 
 	// count total records
 	count, err := b.db.Count(clauses...)
 	if err != nil {
 		return nil, 0, err
 	}
-	clauses = append(clauses, dal.Orderby("id DESC"))
+	orderby := query.Orderby
+	if orderby == "" {
+		orderby = "id DESC"
+	}
+	clauses = append(clauses, dal.Orderby(orderby))
 	// load paginated blueprints from database
 	if query.SkipRecords != 0 {
 		clauses = append(clauses, dal.Offset(query.SkipRecords))
@@ -193,7 +193,11 @@ func (c *Collector[Stream]) pushResults(results []json.RawMessage) errors.Error
 			Input:  defaultInput, // n/a
 		}
 	}
-	err := c.ctx.GetDal().Create(rows, dal.From(c.rawSubtask.GetTable()))
+	err := helper.OffloadRawData(c.ctx.GetContext(), c.rawSubtask.GetTable(), rows)
+	if err != nil {
+		return err
+	}
+	err = c.ctx.GetDal().Create(rows, dal.From(c.rawSubtask.GetTable()))
 	if err != nil {
 		return errors.Default.Wrap(err, "error pushing records to collector table")
 	}
@@ -201,8 +205,7 @@ func (c *Collector[Stream]) pushResults(results []json.RawMessage) errors.Error
 }
 
 func (c *Collector[Stream]) prepareDB() errors.Error {
-	db := c.ctx.GetDal()
-	err := db.AutoMigrate(&helper.RawData{}, dal.From(c.rawSubtask.GetTable()))
+	err := c.rawSubtask.MigrateRawTable()
 	if err != nil {
 		return errors.Default.Wrap(err, "error auto-migrating collector")
 	}
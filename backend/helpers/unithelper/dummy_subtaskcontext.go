@@ -18,6 +18,8 @@ limitations under the License.
 package unithelper
 
 import (
+	"context"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
 	"github.com/stretchr/testify/mock"
@@ -31,5 +33,8 @@ func DummySubTaskContext(db dal.Dal) *mockplugin.SubTaskContext {
 	mockCtx.On("SetProgress", mock.Anything, mock.Anything)
 	mockCtx.On("IncProgress", mock.Anything, mock.Anything)
 	mockCtx.On("GetName").Return("test")
+	mockCtx.On("GetConfig", mock.Anything).Return("").Maybe()
+	mockCtx.On("TaskContext").Return(nil).Maybe()
+	mockCtx.On("GetContext").Return(context.Background()).Maybe()
 	return mockCtx
 }
@@ -26,9 +26,10 @@ import (
 
 // DefaultBasicRes offers a common implementation for the  BasisRes interface
 type DefaultBasicRes struct {
-	cfg    config.ConfigReader
-	logger log.Logger
-	db     dal.Dal
+	cfg       config.ConfigReader
+	logger    log.Logger
+	db        dal.Dal
+	replicaDb dal.Dal
 }
 
 // GetConfigReader returns the ConfigReader instance
@@ -49,18 +50,20 @@ func (c *DefaultBasicRes) GetLogger() log.Logger {
 // NestedLogger returns a new DefaultBasicRes with a new nested logger
 func (c *DefaultBasicRes) NestedLogger(name string) context.BasicRes {
 	return &DefaultBasicRes{
-		cfg:    c.cfg,
-		logger: c.logger.Nested(name),
-		db:     c.db,
+		cfg:       c.cfg,
+		logger:    c.logger.Nested(name),
+		db:        c.db,
+		replicaDb: c.replicaDb,
 	}
 }
 
 // ReplaceLogger returns a new DefaultBasicRes with the specified logger
 func (c *DefaultBasicRes) ReplaceLogger(logger log.Logger) context.BasicRes {
 	return &DefaultBasicRes{
-		cfg:    c.cfg,
-		logger: logger,
-		db:     c.db,
+		cfg:       c.cfg,
+		logger:    logger,
+		db:        c.db,
+		replicaDb: c.replicaDb,
 	}
 }
 
@@ -69,6 +72,16 @@ func (c *DefaultBasicRes) GetDal() dal.Dal {
 	return c.db
 }
 
+// GetReplicaDal returns the Dal of the read replica, nil unless SetReplicaDal was called
+func (c *DefaultBasicRes) GetReplicaDal() dal.Dal {
+	return c.replicaDb
+}
+
+// SetReplicaDal sets the Dal of the read replica serving the analytical reads of the query endpoints
+func (c *DefaultBasicRes) SetReplicaDal(replicaDb dal.Dal) {
+	c.replicaDb = replicaDb
+}
+
 // NewDefaultBasicRes creates a new DefaultBasicRes instance
 func NewDefaultBasicRes(
 	cfg config.ConfigReader,
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReplicaDal(t *testing.T) {
+	db := new(mockdal.Dal)
+	basicRes := NewDefaultBasicRes(nil, unithelper.DummyLogger(), db)
+	// without a replica the reads go to the database
+	assert.Same(t, db, context.GetReplicaDal(basicRes))
+
+	replicaDb := new(mockdal.Dal)
+	basicRes.SetReplicaDal(replicaDb)
+	assert.Same(t, replicaDb, context.GetReplicaDal(basicRes))
+	// the BasicRes handed to the plugins keep the replica
+	assert.Same(t, replicaDb, context.GetReplicaDal(basicRes.NestedLogger("dora")))
+	assert.Same(t, db, basicRes.NestedLogger("dora").GetDal())
+}
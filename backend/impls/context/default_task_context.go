@@ -21,8 +21,10 @@ import (
 	gocontext "context"
 	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"sort"
 	"time"
 )
 
@@ -31,6 +33,21 @@ type DefaultTaskContext struct {
 	*defaultExecContext
 	subtasks    map[string]bool
 	subtaskCtxs map[string]*DefaultSubTaskContext
+	lineage     *plugin.TaskLineage
+	backfill    *plugin.BackfillWindow
+	rawData     map[rawDataKey]*rawDataState
+}
+
+// rawDataKey identifies the raw rows of a scope in a raw table
+type rawDataKey struct {
+	table  string
+	params string
+}
+
+// rawDataState tracks the raw rows of a scope along the task
+type rawDataState struct {
+	recollected bool
+	dropMaxId   uint64
 }
 
 // SetProgress FIXME ...
@@ -74,6 +91,90 @@ func (c *DefaultTaskContext) SetData(data interface{}) {
 	c.data = data
 }
 
+// SetLineage sets the run the rows saved by the subtasks are traced back to
+func (c *DefaultTaskContext) SetLineage(lineage *plugin.TaskLineage) {
+	c.lineage = lineage
+}
+
+// GetLineage returns the run of the task, or nil if it isn't run by a pipeline
+func (c *DefaultTaskContext) GetLineage() *plugin.TaskLineage {
+	return c.lineage
+}
+
+// SetBackfill restricts the subtasks to the window of a backfill run
+func (c *DefaultTaskContext) SetBackfill(backfill *plugin.BackfillWindow) {
+	c.backfill = backfill
+}
+
+// GetBackfill returns the window of the backfill run, or nil for a regular run
+func (c *DefaultTaskContext) GetBackfill() *plugin.BackfillWindow {
+	return c.backfill
+}
+
+func (c *DefaultTaskContext) rawDataState(table string, params string) *rawDataState {
+	key := rawDataKey{table, params}
+	if c.rawData[key] == nil {
+		c.rawData[key] = &rawDataState{}
+	}
+	return c.rawData[key]
+}
+
+// SetRawDataRecollected tells the earlier raw rows of params were flushed and all of them were collected again
+func (c *DefaultTaskContext) SetRawDataRecollected(table string, params string) {
+	c.defaultExecContext.mu.Lock()
+	defer c.defaultExecContext.mu.Unlock()
+	c.rawDataState(table, params).recollected = true
+}
+
+// IsRawDataRecollected tells if the raw rows of params were all collected again by the task
+func (c *DefaultTaskContext) IsRawDataRecollected(table string, params string) bool {
+	c.defaultExecContext.mu.Lock()
+	defer c.defaultExecContext.mu.Unlock()
+	state := c.rawData[rawDataKey{table, params}]
+	return state != nil && state.recollected
+}
+
+// DropRawDataAfterTask drops the raw rows of params up to maxId once every subtask of the task succeeded, the
+// extractors reading the same raw table register the drop one after the other
+func (c *DefaultTaskContext) DropRawDataAfterTask(table string, params string, maxId uint64) {
+	c.defaultExecContext.mu.Lock()
+	defer c.defaultExecContext.mu.Unlock()
+	state := c.rawDataState(table, params)
+	if maxId > state.dropMaxId {
+		state.dropMaxId = maxId
+	}
+}
+
+// DropRawData drops the raw rows registered by DropRawDataAfterTask, it must be called once all the subtasks ran
+func (c *DefaultTaskContext) DropRawData() errors.Error {
+	c.defaultExecContext.mu.Lock()
+	defer c.defaultExecContext.mu.Unlock()
+	keys := make([]rawDataKey, 0, len(c.rawData))
+	for key, state := range c.rawData {
+		if state.dropMaxId > 0 {
+			keys = append(keys, key)
+		}
+	}
+	// drop in a stable order so the logs of the runs are comparable
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].table != keys[j].table {
+			return keys[i].table < keys[j].table
+		}
+		return keys[i].params < keys[j].params
+	})
+	db := c.GetDal()
+	for _, key := range keys {
+		maxId := c.rawData[key].dropMaxId
+		c.GetLogger().Info("drop data from %s where params=%s and id<=%d", key.table, key.params, maxId)
+		err := db.Exec("DELETE FROM ? WHERE params = ? AND id <= ?", dal.ClauseTable{Name: key.table}, key.params, maxId)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error dropping the raw data of %s", key.table))
+		}
+		delete(c.rawData, key)
+	}
+	return nil
+}
+
 // NewDefaultTaskContext holds everything needed by the task execution.
 func NewDefaultTaskContext(
 	ctx gocontext.Context,
@@ -86,7 +187,13 @@ func NewDefaultTaskContext(
 		newDefaultExecContext(ctx, basicRes, name, nil, progress),
 		subtasks,
 		make(map[string]*DefaultSubTaskContext),
+		nil,
+		nil,
+		make(map[rawDataKey]*rawDataState),
 	}
 }
 
 var _ plugin.TaskContext = (*DefaultTaskContext)(nil)
+var _ plugin.LineageTaskContext = (*DefaultTaskContext)(nil)
+var _ plugin.BackfillTaskContext = (*DefaultTaskContext)(nil)
+var _ plugin.RawDataTaskContext = (*DefaultTaskContext)(nil)
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropRawDataAfterTask(t *testing.T) {
+	db := new(mockdal.Dal)
+	basicRes := NewDefaultBasicRes(nil, unithelper.DummyLogger(), db)
+	taskCtx := NewDefaultTaskContext(gocontext.Background(), basicRes, "github", nil, nil).(*DefaultTaskContext)
+	issues := `{"ConnectionId":1,"Name":"apache/incubator-devlake"}`
+	taskCtx.SetRawDataRecollected("_raw_github_api_issues", issues)
+	assert.True(t, taskCtx.IsRawDataRecollected("_raw_github_api_issues", issues))
+	assert.False(t, taskCtx.IsRawDataRecollected("_raw_github_api_pull_requests", issues))
+
+	// the extractors of the same raw table drop up to the last row read by any of them
+	taskCtx.DropRawDataAfterTask("_raw_github_api_issues", issues, 10)
+	taskCtx.DropRawDataAfterTask("_raw_github_api_issues", issues, 12)
+	taskCtx.DropRawDataAfterTask("_raw_github_api_issues", issues, 11)
+	db.AssertNotCalled(t, "Exec")
+
+	db.On("Exec", "DELETE FROM ? WHERE params = ? AND id <= ?", []interface{}{
+		dal.ClauseTable{Name: "_raw_github_api_issues"}, issues, uint64(12),
+	}).Return(nil).Once()
+	assert.Nil(t, taskCtx.DropRawData())
+	// the raw data is only dropped once
+	assert.Nil(t, taskCtx.DropRawData())
+	db.AssertExpectations(t)
+}
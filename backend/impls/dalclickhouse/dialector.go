@@ -0,0 +1,251 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalclickhouse
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	// registers the `clickhouse` database/sql driver
+	_ "github.com/ClickHouse/clickhouse-go"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// Dialector is a gorm.Dialector for ClickHouse built on top of the database/sql driver of clickhouse-go v1.
+// Tables are created with the ReplacingMergeTree engine ordered by their primary keys, so an INSERT
+// of an existing key acts as an upsert once ClickHouse merges the parts.
+type Dialector struct {
+	DSN  string
+	Conn gorm.ConnPool
+}
+
+// Open creates a Dialector for the given clickhouse-go DSN, i.e. `tcp://host:9000?database=lake`
+func Open(dsn string) gorm.Dialector {
+	return &Dialector{DSN: dsn}
+}
+
+// Name returns the name of the dialect
+func (d Dialector) Name() string {
+	return "clickhouse"
+}
+
+// Initialize registers the callbacks and clause builders, and opens the connection pool
+func (d Dialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{
+		// ON CONFLICT is left out: the ReplacingMergeTree engine takes care of duplicated keys
+		CreateClauses: []string{"INSERT", "VALUES"},
+		QueryClauses:  []string{"SELECT", "FROM", "WHERE", "GROUP BY", "ORDER BY", "LIMIT"},
+		UpdateClauses: []string{"UPDATE", "SET", "WHERE"},
+		DeleteClauses: []string{"DELETE", "FROM", "WHERE"},
+	})
+	// clickhouse-go v1 accepts INSERT statements only in batch mode
+	if err := db.Callback().Create().Replace("gorm:create", create); err != nil {
+		return err
+	}
+	if d.Conn != nil {
+		db.ConnPool = d.Conn
+	} else {
+		conn, err := sql.Open("clickhouse", d.DSN)
+		if err != nil {
+			return err
+		}
+		db.ConnPool = conn
+	}
+	for k, v := range d.ClauseBuilders() {
+		db.ClauseBuilders[k] = v
+	}
+	return nil
+}
+
+// ClauseBuilders rewrites UPDATE statements into ClickHouse mutations, i.e. `ALTER TABLE t UPDATE a=? WHERE ...`
+func (d Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
+	return map[string]clause.ClauseBuilder{
+		"UPDATE": func(c clause.Clause, builder clause.Builder) {
+			builder.WriteString("ALTER TABLE ")
+			c.Expression.Build(builder)
+			builder.WriteString(" UPDATE")
+		},
+		"SET": func(c clause.Clause, builder clause.Builder) {
+			c.Expression.Build(builder)
+		},
+	}
+}
+
+// Migrator returns the migrator of ClickHouse
+func (d Dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return Migrator{migrator.Migrator{Config: migrator.Config{
+		DB:        db,
+		Dialector: d,
+	}}}
+}
+
+// DataTypeOf maps the field to a ClickHouse column type, pointers become Nullable columns
+func (d Dialector) DataTypeOf(field *schema.Field) string {
+	dataType := d.baseDataTypeOf(field)
+	if field.FieldType.Kind() == reflect.Ptr && !field.PrimaryKey {
+		return fmt.Sprintf("Nullable(%s)", dataType)
+	}
+	return dataType
+}
+
+func (d Dialector) baseDataTypeOf(field *schema.Field) string {
+	switch field.DataType {
+	case schema.Bool:
+		return "UInt8"
+	case schema.Int:
+		switch {
+		case field.Size <= 8:
+			return "Int8"
+		case field.Size <= 16:
+			return "Int16"
+		case field.Size <= 32:
+			return "Int32"
+		}
+		return "Int64"
+	case schema.Uint:
+		switch {
+		case field.Size <= 8:
+			return "UInt8"
+		case field.Size <= 16:
+			return "UInt16"
+		case field.Size <= 32:
+			return "UInt32"
+		}
+		return "UInt64"
+	case schema.Float:
+		if field.Size <= 32 {
+			return "Float32"
+		}
+		return "Float64"
+	case schema.Time:
+		return "DateTime64(3)"
+	}
+	// types given by the `gorm:"type:..."` tags were written for MySQL/Postgres
+	dataType := strings.ToLower(string(field.DataType))
+	switch {
+	case strings.HasPrefix(dataType, "datetime"), strings.HasPrefix(dataType, "timestamp"):
+		return "DateTime64(3)"
+	case strings.HasPrefix(dataType, "bigint"):
+		return "Int64"
+	case strings.HasPrefix(dataType, "int"):
+		return "Int32"
+	case strings.HasPrefix(dataType, "bool"), strings.HasPrefix(dataType, "tinyint(1)"):
+		return "UInt8"
+	case strings.HasPrefix(dataType, "float"), strings.HasPrefix(dataType, "double"), strings.HasPrefix(dataType, "decimal"):
+		return "Float64"
+	}
+	return "String"
+}
+
+// DefaultValueOf returns the expression of the default value
+func (d Dialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "DEFAULT"}
+}
+
+// BindVarTo writes the placeholder of the variable
+func (d Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	_ = writer.WriteByte('?')
+}
+
+// QuoteTo quotes the identifier with backticks
+func (d Dialector) QuoteTo(writer clause.Writer, str string) {
+	_ = writer.WriteByte('`')
+	for _, r := range str {
+		switch r {
+		case '`':
+			_, _ = writer.WriteString("``")
+		case '.':
+			_, _ = writer.WriteString("`.`")
+		default:
+			_, _ = writer.WriteString(string(r))
+		}
+	}
+	_ = writer.WriteByte('`')
+}
+
+// Explain returns the sql with the vars inlined, for logging purposes
+func (d Dialector) Explain(sql string, vars ...interface{}) string {
+	return logger.ExplainSQL(sql, nil, `'`, vars...)
+}
+
+// create inserts the rows in batch mode: one transaction with a prepared INSERT executed once per row
+func create(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	stmt := db.Statement
+	values := callbacks.ConvertToCreateValues(stmt)
+	if len(values.Columns) == 0 || len(values.Values) == 0 {
+		return
+	}
+	stmt.WriteString("INSERT INTO ")
+	stmt.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+	stmt.WriteString(" (")
+	for i, column := range values.Columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteQuoted(column)
+	}
+	stmt.WriteString(")")
+	if db.DryRun {
+		return
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	tx, err := sqlDB.BeginTx(stmt.Context, nil)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	err = insertRows(tx, stmt, values.Values)
+	if err != nil {
+		_ = tx.Rollback()
+		_ = db.AddError(err)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	db.RowsAffected = int64(len(values.Values))
+}
+
+func insertRows(tx *sql.Tx, stmt *gorm.Statement, rows [][]interface{}) error {
+	prepared, err := tx.PrepareContext(stmt.Context, stmt.SQL.String())
+	if err != nil {
+		return err
+	}
+	defer prepared.Close()
+	for _, row := range rows {
+		if _, err = prepared.ExecContext(stmt.Context, row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
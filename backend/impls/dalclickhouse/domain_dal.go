@@ -0,0 +1,263 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalclickhouse
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// DomainDal serves everything from the primary database (MySQL/Postgres) and mirrors the writes
+// to the domain layer tables onto ClickHouse, where Grafana runs its analytical queries.
+// The primary database stays the source of truth so the joins between the tool and domain tables,
+// the migrations and the transactions keep working as they do without ClickHouse.
+// ClickHouse replaces whole rows: Update and UpdateAllColumn are mirrored as upserts of the entity.
+type DomainDal struct {
+	dal.Dal
+	domain  dal.Dal
+	tables  map[string]struct{}
+	pending *[]func(dal.Dal) errors.Error
+}
+
+var _ dal.Dal = (*DomainDal)(nil)
+
+// NewDomainDal creates a DomainDal mirroring the writes to the given domain tables onto the `domain` Dal
+func NewDomainDal(primary dal.Dal, domain dal.Dal, domainTables []string) *DomainDal {
+	tables := make(map[string]struct{}, len(domainTables))
+	for _, table := range domainTables {
+		tables[table] = struct{}{}
+	}
+	return &DomainDal{Dal: primary, domain: domain, tables: tables}
+}
+
+// Domain returns the Dal of ClickHouse, for the domain layer reads
+func (d *DomainDal) Domain() dal.Dal {
+	return d.domain
+}
+
+// IsDomainTable checks if the writes to the table are mirrored
+func (d *DomainDal) IsDomainTable(table string) bool {
+	_, ok := d.tables[table]
+	return ok
+}
+
+func (d *DomainDal) mirror(entity interface{}, clauses []dal.Clause, op func(db dal.Dal, entity interface{}) errors.Error) errors.Error {
+	if !d.IsDomainTable(tableOf(entity, clauses)) {
+		return nil
+	}
+	if d.pending != nil {
+		// replayed on Commit, the batch savers reuse their slices once flushed
+		entity := snapshot(entity)
+		*d.pending = append(*d.pending, func(db dal.Dal) errors.Error {
+			return op(db, entity)
+		})
+		return nil
+	}
+	err := op(d.domain, entity)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to mirror the write onto the domain database")
+	}
+	return nil
+}
+
+// Create insert record to database
+func (d *DomainDal) Create(entity interface{}, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.Create(entity, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entity, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.Create(e, clauses...)
+	})
+}
+
+// CreateWithMap insert record to database
+func (d *DomainDal) CreateWithMap(entity interface{}, record map[string]interface{}) errors.Error {
+	if err := d.Dal.CreateWithMap(entity, record); err != nil {
+		return err
+	}
+	return d.mirror(entity, nil, func(db dal.Dal, e interface{}) errors.Error {
+		return db.CreateWithMap(e, record)
+	})
+}
+
+// Update updates record
+func (d *DomainDal) Update(entity interface{}, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.Update(entity, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entity, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.CreateOrUpdate(e, clauses...)
+	})
+}
+
+// UpdateColumn allows you to update multiple records
+func (d *DomainDal) UpdateColumn(entityOrTable interface{}, columnName string, value interface{}, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.UpdateColumn(entityOrTable, columnName, value, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entityOrTable, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.UpdateColumn(e, columnName, value, clauses...)
+	})
+}
+
+// UpdateColumns allows you to update multiple columns of multiple records
+func (d *DomainDal) UpdateColumns(entityOrTable interface{}, set []dal.DalSet, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.UpdateColumns(entityOrTable, set, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entityOrTable, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.UpdateColumns(e, set, clauses...)
+	})
+}
+
+// UpdateAllColumn updated all Columns of entity
+func (d *DomainDal) UpdateAllColumn(entity interface{}, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.UpdateAllColumn(entity, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entity, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.CreateOrUpdate(e, clauses...)
+	})
+}
+
+// CreateOrUpdate tries to create the record, or fallback to update all if failed
+func (d *DomainDal) CreateOrUpdate(entity interface{}, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.CreateOrUpdate(entity, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entity, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.CreateOrUpdate(e, clauses...)
+	})
+}
+
+// CreateIfNotExist tries to create the record if not exist
+func (d *DomainDal) CreateIfNotExist(entity interface{}, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.CreateIfNotExist(entity, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entity, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.CreateIfNotExist(e, clauses...)
+	})
+}
+
+// Delete records from database
+func (d *DomainDal) Delete(entity interface{}, clauses ...dal.Clause) errors.Error {
+	if err := d.Dal.Delete(entity, clauses...); err != nil {
+		return err
+	}
+	return d.mirror(entity, clauses, func(db dal.Dal, e interface{}) errors.Error {
+		return db.Delete(e, clauses...)
+	})
+}
+
+// Session creates a new manual session for special scenarios
+func (d *DomainDal) Session(config dal.SessionConfig) dal.Dal {
+	return &DomainDal{Dal: d.Dal.Session(config), domain: d.domain, tables: d.tables}
+}
+
+// Begin create a new transaction, the mirrored writes are applied once the transaction is committed
+func (d *DomainDal) Begin() dal.Transaction {
+	tx := d.Dal.Begin()
+	pending := make([]func(dal.Dal) errors.Error, 0)
+	return &DomainTransaction{
+		DomainDal: &DomainDal{Dal: tx, domain: d.domain, tables: d.tables, pending: &pending},
+		tx:        tx,
+	}
+}
+
+// DomainTransaction is the transaction of a DomainDal
+type DomainTransaction struct {
+	*DomainDal
+	tx dal.Transaction
+}
+
+var _ dal.Transaction = (*DomainTransaction)(nil)
+
+// Rollback the transaction and drop the pending mirrored writes
+func (t *DomainTransaction) Rollback() errors.Error {
+	*t.pending = nil
+	return t.tx.Rollback()
+}
+
+// Commit the transaction and apply the pending mirrored writes
+func (t *DomainTransaction) Commit() errors.Error {
+	if err := t.tx.Commit(); err != nil {
+		return err
+	}
+	pending := *t.pending
+	*t.pending = nil
+	for _, op := range pending {
+		if err := op(t.domain); err != nil {
+			return errors.Default.Wrap(err, "failed to mirror the write onto the domain database")
+		}
+	}
+	return nil
+}
+
+// tableOf returns the name of the table targeted by the entity, or by the From clause when the entity doesn't tell
+func tableOf(entity interface{}, clauses []dal.Clause) string {
+	if table := tableNameOf(entity); table != "" {
+		return table
+	}
+	for _, c := range clauses {
+		if c.Type != dal.FromClause {
+			continue
+		}
+		switch from := c.Data.(type) {
+		case dal.ClauseTable:
+			return from.Name
+		case dal.DalClause:
+			return from.Expr
+		default:
+			return tableNameOf(from)
+		}
+	}
+	return ""
+}
+
+func tableNameOf(entity interface{}) string {
+	switch e := entity.(type) {
+	case nil:
+		return ""
+	case string:
+		return e
+	case dal.Tabler:
+		return e.TableName()
+	}
+	t := reflect.TypeOf(entity)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if tabler, ok := reflect.New(t).Interface().(dal.Tabler); ok {
+		return tabler.TableName()
+	}
+	return ""
+}
+
+// snapshot copies the slices so the pending writes are not affected when the caller reuses them
+func snapshot(entity interface{}) interface{} {
+	v := reflect.ValueOf(entity)
+	if v.Kind() != reflect.Slice {
+		return entity
+	}
+	c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(c, v)
+	return c.Interface()
+}
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalclickhouse
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type recordingDal struct {
+	dal.Dal
+	written []string
+}
+
+func (r *recordingDal) CreateOrUpdate(entity interface{}, clauses ...dal.Clause) errors.Error {
+	r.written = append(r.written, tableOf(entity, clauses))
+	return nil
+}
+
+func (r *recordingDal) UpdateColumn(entityOrTable interface{}, columnName string, value interface{}, clauses ...dal.Clause) errors.Error {
+	r.written = append(r.written, tableOf(entityOrTable, clauses))
+	return nil
+}
+
+func (r *recordingDal) Begin() dal.Transaction {
+	return &recordingTransaction{r}
+}
+
+type recordingTransaction struct {
+	*recordingDal
+}
+
+func (r *recordingTransaction) Rollback() errors.Error {
+	return nil
+}
+
+func (r *recordingTransaction) Commit() errors.Error {
+	return nil
+}
+
+type toolIssue struct {
+	Id string `gorm:"primaryKey"`
+}
+
+func (toolIssue) TableName() string {
+	return "_tool_issues"
+}
+
+func TestDomainDal(t *testing.T) {
+	primary := &recordingDal{}
+	domain := &recordingDal{}
+	db := NewDomainDal(primary, domain, []string{"issues"})
+
+	assert.Nil(t, db.CreateOrUpdate([]*ticket.Issue{{}}))
+	assert.Nil(t, db.CreateOrUpdate(&toolIssue{}))
+	assert.Nil(t, db.UpdateColumn("issues", "status", "DONE", dal.Where("id = ?", "1")))
+	assert.Equal(t, []string{"issues", "_tool_issues", "issues"}, primary.written)
+	assert.Equal(t, []string{"issues", "issues"}, domain.written)
+
+	domain.written = nil
+	tx := db.Begin()
+	assert.Nil(t, tx.CreateOrUpdate(&ticket.Issue{}))
+	assert.Empty(t, domain.written)
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, []string{"issues"}, domain.written)
+
+	domain.written = nil
+	tx = db.Begin()
+	assert.Nil(t, tx.CreateOrUpdate(&ticket.Issue{}))
+	assert.Nil(t, tx.Rollback())
+	assert.Empty(t, domain.written)
+}
+
+func TestDialector(t *testing.T) {
+	db, err := gorm.Open(Open("tcp://localhost:9000"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Nil(t, err)
+
+	update := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("issues").Where("id = ?", "1").Update("status", "DONE")
+	})
+	assert.Equal(t, "ALTER TABLE `issues` UPDATE `status`='DONE' WHERE id = '1'", update)
+
+	remove := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("id = ?", "1").Delete(&ticket.Issue{})
+	})
+	assert.Equal(t, "DELETE FROM `issues` WHERE id = '1'", remove)
+
+	insert := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Create(&toolIssue{Id: "1"})
+	})
+	assert.Equal(t, "INSERT INTO `_tool_issues` (`id`)", insert)
+}
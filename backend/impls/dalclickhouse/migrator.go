@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalclickhouse
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// Migrator creates the tables with the ReplacingMergeTree engine and leaves the indexes and
+// constraints out, the ordering key being the only index ClickHouse needs
+type Migrator struct {
+	migrator.Migrator
+}
+
+// CurrentDatabase returns the name of the current database
+func (m Migrator) CurrentDatabase() (name string) {
+	_ = m.DB.Raw("SELECT currentDatabase()").Row().Scan(&name)
+	return
+}
+
+// HasTable checks if the table exists
+func (m Migrator) HasTable(value interface{}) bool {
+	var count int64
+	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Raw(
+			"SELECT count() FROM system.tables WHERE database = currentDatabase() AND name = ?",
+			stmt.Table,
+		).Row().Scan(&count)
+	})
+	return count > 0
+}
+
+// HasColumn checks if the column exists
+func (m Migrator) HasColumn(value interface{}, field string) bool {
+	var count int64
+	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		name := field
+		if f := stmt.Schema.LookUpField(field); f != nil {
+			name = f.DBName
+		}
+		return m.DB.Raw(
+			"SELECT count() FROM system.columns WHERE database = currentDatabase() AND table = ? AND name = ?",
+			stmt.Table, name,
+		).Row().Scan(&count)
+	})
+	return count > 0
+}
+
+// FullDataTypeOf returns the column type, ClickHouse fills the missing values with the defaults of the type
+func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	return clause.Expr{SQL: m.DataTypeOf(field)}
+}
+
+// CreateTable creates the tables ordered by their primary keys
+func (m Migrator) CreateTable(values ...interface{}) error {
+	for _, value := range m.ReorderModels(values, false) {
+		tx := m.DB.Session(&gorm.Session{})
+		err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			createTableSQL := "CREATE TABLE IF NOT EXISTS ? ("
+			vars := []interface{}{m.CurrentTable(stmt)}
+			for i, dbName := range stmt.Schema.DBNames {
+				if i > 0 {
+					createTableSQL += ","
+				}
+				createTableSQL += "? ?"
+				vars = append(vars, clause.Column{Name: dbName}, m.FullDataTypeOf(stmt.Schema.FieldsByDBName[dbName]))
+			}
+			createTableSQL += ") ENGINE = ReplacingMergeTree() ORDER BY "
+			if len(stmt.Schema.PrimaryFields) == 0 {
+				createTableSQL += "tuple()"
+			} else {
+				primaryKeys := make([]interface{}, 0, len(stmt.Schema.PrimaryFields))
+				for _, field := range stmt.Schema.PrimaryFields {
+					primaryKeys = append(primaryKeys, clause.Column{Name: field.DBName})
+				}
+				createTableSQL += "?"
+				vars = append(vars, primaryKeys)
+			}
+			return tx.Exec(createTableSQL, vars...).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddColumn adds the column of the field
+func (m Migrator) AddColumn(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		field := stmt.Schema.LookUpField(name)
+		if field == nil {
+			return fmt.Errorf("failed to look up field with name: %s", name)
+		}
+		return m.DB.Exec(
+			"ALTER TABLE ? ADD COLUMN IF NOT EXISTS ? ?",
+			m.CurrentTable(stmt), clause.Column{Name: field.DBName}, m.FullDataTypeOf(field),
+		).Error
+	})
+}
+
+// MigrateColumn keeps the existing columns as they are, the columns of the ordering key can not be altered anyway
+func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	return nil
+}
+
+// HasIndex reports every index as existing since they are not created on ClickHouse
+func (m Migrator) HasIndex(value interface{}, name string) bool {
+	return true
+}
+
+// CreateIndex does nothing, see HasIndex
+func (m Migrator) CreateIndex(value interface{}, name string) error {
+	return nil
+}
+
+// HasConstraint reports every constraint as existing since ClickHouse doesn't enforce them
+func (m Migrator) HasConstraint(value interface{}, name string) bool {
+	return true
+}
+
+// DropTable drops the tables
+func (m Migrator) DropTable(values ...interface{}) error {
+	values = m.ReorderModels(values, false)
+	for i := len(values) - 1; i >= 0; i-- {
+		err := m.RunWithValue(values[i], func(stmt *gorm.Statement) error {
+			return m.DB.Exec("DROP TABLE IF EXISTS ?", m.CurrentTable(stmt)).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenameTable renames the table
+func (m Migrator) RenameTable(oldName, newName interface{}) error {
+	tables := make([]interface{}, 0, 2)
+	for _, name := range []interface{}{oldName, newName} {
+		if table, ok := name.(string); ok {
+			tables = append(tables, clause.Table{Name: table})
+			continue
+		}
+		stmt := &gorm.Statement{DB: m.DB}
+		if err := stmt.Parse(name); err != nil {
+			return err
+		}
+		tables = append(tables, m.CurrentTable(stmt))
+	}
+	return m.DB.Exec("RENAME TABLE ? TO ?", tables...).Error
+}
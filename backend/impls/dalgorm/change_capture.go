@@ -0,0 +1,257 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const changeCaptureSetting = "devlake:change_capture"
+const changeCaptureKeysSetting = "devlake:change_capture_keys"
+const changeCaptureBatchSize = 500
+
+type changeCapture struct {
+	tables map[string]bool
+}
+
+// RegisterChangeCaptureCallbacks records the row-level changes made to the tables by Create, Update and Delete into
+// _devlake_cdc_events, within the transaction of the change if any. The rows changed by Exec are not captured.
+// It must be called once on the gorm.DB, after RegisterTableSchemaCallbacks
+func RegisterChangeCaptureCallbacks(db *gorm.DB, tables []string) errors.Error {
+	cc := &changeCapture{tables: make(map[string]bool, len(tables))}
+	for _, table := range tables {
+		cc.tables[table] = true
+	}
+	callbacks := db.Callback()
+	err := callbacks.Create().After("gorm:create").Register(changeCaptureSetting, cc.afterCreate)
+	if err == nil {
+		err = callbacks.Update().Before("gorm:update").After(tableSchemaSetting).Register(changeCaptureKeysSetting, cc.beforeChange)
+	}
+	if err == nil {
+		err = callbacks.Update().After("gorm:update").Register(changeCaptureSetting, cc.afterUpdate)
+	}
+	if err == nil {
+		err = callbacks.Delete().Before("gorm:delete").After(tableSchemaSetting).Register(changeCaptureKeysSetting, cc.beforeChange)
+	}
+	if err == nil {
+		err = callbacks.Delete().After("gorm:delete").Register(changeCaptureSetting, cc.afterDelete)
+	}
+	return errors.Convert(err)
+}
+
+// captured returns the table changed by the statement if it is captured, the changes of the statements not bound to
+// a model are unknown
+func (cc *changeCapture) captured(db *gorm.DB) (string, bool) {
+	stmt := db.Statement
+	if stmt.Schema == nil || db.DryRun {
+		return "", false
+	}
+	table := stmt.Table
+	if table == "" && stmt.TableExpr != nil {
+		if matched := leadingTablePattern.FindStringSubmatch(stmt.TableExpr.SQL); matched != nil {
+			table = matched[1]
+		}
+	}
+	return table, cc.tables[table]
+}
+
+func (cc *changeCapture) afterCreate(db *gorm.DB) {
+	table, ok := cc.captured(db)
+	if !ok || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+	cc.record(db, table, models.CDC_UPSERT, collectRows(db.Statement.ReflectValue))
+}
+
+// beforeChange keeps the keys of the rows about to be updated or deleted, they are the rows of the model if it has
+// its primary key or else the ones matching the conditions
+func (cc *changeCapture) beforeChange(db *gorm.DB) {
+	if _, ok := cc.captured(db); !ok || db.Error != nil {
+		return
+	}
+	stmt := db.Statement
+	var keys []reflect.Value
+	for _, row := range collectRows(stmt.ReflectValue) {
+		if hasPrimaryKey(db, row) {
+			keys = append(keys, row)
+		}
+	}
+	if len(keys) == 0 {
+		where, ok := stmt.Clauses["WHERE"]
+		if !ok {
+			return
+		}
+		columns := make([]string, len(stmt.Schema.PrimaryFields))
+		for i, field := range stmt.Schema.PrimaryFields {
+			columns[i] = field.DBName
+		}
+		dest := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+		err := cc.session(db).Select(columns).Clauses(where.Expression).Find(dest.Interface()).Error
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		keys = collectRows(dest.Elem())
+	}
+	db.InstanceSet(changeCaptureKeysSetting, keys)
+}
+
+func (cc *changeCapture) afterUpdate(db *gorm.DB) {
+	table, ok := cc.captured(db)
+	if !ok || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+	keys := changedKeys(db)
+	primaryFields := db.Statement.Schema.PrimaryFields
+	// the rows are read again for their updated columns
+	for start := 0; start < len(keys); start += changeCaptureBatchSize {
+		end := start + changeCaptureBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		tuples := make([][]interface{}, 0, end-start)
+		for _, key := range keys[start:end] {
+			tuple := make([]interface{}, len(primaryFields))
+			for i, field := range primaryFields {
+				tuple[i], _ = field.ValueOf(db.Statement.Context, key)
+			}
+			tuples = append(tuples, tuple)
+		}
+		var condition clause.Expression
+		if len(primaryFields) == 1 {
+			values := make([]interface{}, len(tuples))
+			for i, tuple := range tuples {
+				values[i] = tuple[0]
+			}
+			condition = clause.IN{Column: clause.Column{Name: primaryFields[0].DBName}, Values: values}
+		} else {
+			columns := make([]clause.Column, len(primaryFields))
+			for i, field := range primaryFields {
+				columns[i] = clause.Column{Name: field.DBName}
+			}
+			condition = clause.Expr{SQL: "? IN ?", Vars: []interface{}{columns, tuples}}
+		}
+		dest := reflect.New(reflect.SliceOf(db.Statement.Schema.ModelType))
+		err := cc.session(db).Clauses(clause.Where{Exprs: []clause.Expression{condition}}).Find(dest.Interface()).Error
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		cc.record(db, table, models.CDC_UPSERT, collectRows(dest.Elem()))
+	}
+}
+
+func (cc *changeCapture) afterDelete(db *gorm.DB) {
+	table, ok := cc.captured(db)
+	if !ok || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+	cc.record(db, table, models.CDC_DELETE, changedKeys(db))
+}
+
+// session returns a new statement on the table and the connection, i.e. the transaction, of the change
+func (cc *changeCapture) session(db *gorm.DB) *gorm.DB {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	if db.Statement.TableExpr != nil {
+		return tx.Table(db.Statement.TableExpr.SQL, db.Statement.TableExpr.Vars...)
+	}
+	return tx.Table(db.Statement.Table)
+}
+
+// record inserts the events of the rows, the change fails along with the insertion so no change goes unpublished
+func (cc *changeCapture) record(db *gorm.DB, table string, operation string, rows []reflect.Value) {
+	if len(rows) == 0 {
+		return
+	}
+	stmt := db.Statement
+	events := make([]*models.CdcEvent, 0, len(rows))
+	for _, row := range rows {
+		event := &models.CdcEvent{SourceTable: table, Operation: operation}
+		key, err := json.Marshal(fieldValues(db, stmt.Schema.PrimaryFields, row))
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		event.Key = string(key)
+		if operation == models.CDC_UPSERT {
+			value, err := json.Marshal(fieldValues(db, stmt.Schema.Fields, row))
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			event.Row = string(value)
+		}
+		events = append(events, event)
+	}
+	_ = db.AddError(db.Session(&gorm.Session{NewDB: true}).CreateInBatches(events, changeCaptureBatchSize).Error)
+}
+
+func changedKeys(db *gorm.DB) []reflect.Value {
+	keys, _ := db.InstanceGet(changeCaptureKeysSetting)
+	if keys == nil {
+		return nil
+	}
+	return keys.([]reflect.Value)
+}
+
+func hasPrimaryKey(db *gorm.DB, row reflect.Value) bool {
+	for _, field := range db.Statement.Schema.PrimaryFields {
+		if _, zero := field.ValueOf(db.Statement.Context, row); zero {
+			return false
+		}
+	}
+	return len(db.Statement.Schema.PrimaryFields) > 0
+}
+
+func fieldValues(db *gorm.DB, fields []*schema.Field, row reflect.Value) map[string]interface{} {
+	values := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if field.DBName == "" {
+			continue
+		}
+		values[field.DBName], _ = field.ValueOf(db.Statement.Context, row)
+	}
+	return values
+}
+
+// collectRows returns the structs of a model or a slice of models
+func collectRows(value reflect.Value) []reflect.Value {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Struct:
+		return []reflect.Value{value}
+	case reflect.Slice, reflect.Array:
+		rows := make([]reflect.Value, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			row := reflect.Indirect(value.Index(i))
+			if row.Kind() == reflect.Struct {
+				rows = append(rows, row)
+			}
+		}
+		return rows
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestChangeCaptureRows(t *testing.T) {
+	db := dryRunDb(t)
+	assert.Nil(t, RegisterChangeCaptureCallbacks(db, []string{"issues"}))
+	cc := &changeCapture{tables: map[string]bool{"issues": true}}
+
+	issues := []*tableSchemaIssue{{Id: "1", Title: "a"}, {Title: "b"}}
+	stmt := db.Model(&tableSchemaIssue{}).Statement
+	assert.Nil(t, stmt.Parse(stmt.Model))
+	tx := &gorm.DB{Config: db.Config, Statement: stmt}
+	_, ok := cc.captured(tx)
+	assert.False(t, ok, "the changes of a dry run are not captured")
+
+	rows := collectRows(reflect.ValueOf(&issues))
+	assert.Equal(t, 2, len(rows))
+	assert.True(t, hasPrimaryKey(tx, rows[0]))
+	assert.False(t, hasPrimaryKey(tx, rows[1]))
+	assert.Equal(t, 1, len(collectRows(reflect.ValueOf(issues[0]))))
+	assert.Nil(t, collectRows(reflect.ValueOf(map[string]interface{}{"id": "1"})))
+
+	key, err := json.Marshal(fieldValues(tx, stmt.Schema.PrimaryFields, rows[0]))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":"1"}`, string(key))
+	row, err := json.Marshal(fieldValues(tx, stmt.Schema.Fields, rows[0]))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":"1","title":"a"}`, string(row))
+}
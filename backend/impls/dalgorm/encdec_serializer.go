@@ -22,7 +22,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 
@@ -31,12 +37,28 @@ import (
 
 var _ schema.SerializerInterface = (*EncDecSerializer)(nil)
 
+// dataKeyPrefix marks the values encrypted by a data key, i.e. $k<key id>$<base64>. The values without it were
+// encrypted by ENCODE_KEY itself before the data keys were introduced, or before the first key rotation
+const dataKeyPrefix = "$k"
+
+// dataKeysTTL is how long the data keys are used before being reloaded, the other processes pick up a rotated key by it
+const dataKeysTTL = time.Minute
+
 // EncDecSerializer is responsible for field encryption/decryption in Application Level
 // Ref: https://gorm.io/docs/serializer.html
 type EncDecSerializer struct {
 	encKey string
+
+	// the data keys unwrapped by encKey, see models.EncryptionKey
+	sync.RWMutex
+	db       dal.Dal
+	keys     map[uint64]string
+	activeId uint64
+	loadedAt time.Time
 }
 
+var encDec = &EncDecSerializer{}
+
 // Scan implements serializer interface
 func (es *EncDecSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) (err error) {
 	dbValue = models.UnwrapObject(dbValue)
@@ -52,7 +74,7 @@ func (es *EncDecSerializer) Scan(ctx context.Context, field *schema.Field, dst r
 			return fmt.Errorf("failed to decrypt value: %#v", dbValue)
 		}
 
-		decrypted, err := plugin.Decrypt(es.encKey, base64str)
+		decrypted, err := es.decrypt(base64str)
 		if err != nil {
 			return err
 		}
@@ -81,10 +103,131 @@ func (es *EncDecSerializer) Value(ctx context.Context, field *schema.Field, dst
 	default:
 		return nil, fmt.Errorf("failed to encrypt value: %#v", fieldValue)
 	}
-	return plugin.Encrypt(es.encKey, target)
+	return es.encrypt(target)
+}
+
+func (es *EncDecSerializer) encrypt(plainText string) (string, errors.Error) {
+	es.RLock()
+	stale := es.db != nil && time.Since(es.loadedAt) > dataKeysTTL
+	es.RUnlock()
+	if stale {
+		if err := es.load(es.db); err != nil {
+			return "", err
+		}
+	}
+	es.RLock()
+	activeId, key := es.activeId, es.keys[es.activeId]
+	es.RUnlock()
+	if activeId == 0 {
+		return plugin.Encrypt(es.encKey, plainText)
+	}
+	encrypted, err := plugin.Encrypt(key, plainText)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d$%s", dataKeyPrefix, activeId, encrypted), nil
+}
+
+func (es *EncDecSerializer) decrypt(encryptedText string) (string, errors.Error) {
+	if !strings.HasPrefix(encryptedText, dataKeyPrefix) {
+		return plugin.Decrypt(es.encKey, encryptedText)
+	}
+	idAndText := strings.SplitN(encryptedText[len(dataKeyPrefix):], "$", 2)
+	id, err := strconv.ParseUint(idAndText[0], 10, 64)
+	if err != nil || len(idAndText) < 2 {
+		return "", errors.Default.New("malformed encrypted value")
+	}
+	es.RLock()
+	key, ok := es.keys[id]
+	db := es.db
+	es.RUnlock()
+	// the key might be created by another process since the keys were loaded
+	if !ok && db != nil {
+		if err := es.load(db); err != nil {
+			return "", err
+		}
+		es.RLock()
+		key, ok = es.keys[id]
+		es.RUnlock()
+	}
+	if !ok {
+		return "", errors.Default.New(fmt.Sprintf("the data key %d of the encrypted value is not found", id))
+	}
+	return plugin.Decrypt(key, idAndText[1])
+}
+
+func (es *EncDecSerializer) load(db dal.Dal) errors.Error {
+	var encryptionKeys []*models.EncryptionKey
+	// the table is missing until the migrations are applied
+	if db.HasTable(&models.EncryptionKey{}) {
+		err := db.All(&encryptionKeys, dal.Orderby("id"))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to load the data keys")
+		}
+	}
+	keys := make(map[uint64]string, len(encryptionKeys))
+	var activeId uint64
+	for _, encryptionKey := range encryptionKeys {
+		key, err := plugin.Decrypt(es.encKey, encryptionKey.WrappedKey)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to unwrap the data key %d, is it wrapped by another ENCODE_KEY?", encryptionKey.ID))
+		}
+		keys[encryptionKey.ID] = key
+		if encryptionKey.Status == models.ENCRYPTION_KEY_ACTIVE {
+			activeId = encryptionKey.ID
+		}
+	}
+	es.Lock()
+	defer es.Unlock()
+	es.db = db
+	es.keys = keys
+	es.activeId = activeId
+	es.loadedAt = time.Now()
+	return nil
 }
 
 // Init the encdec serializer
 func Init(encKey string) {
-	schema.RegisterSerializer("encdec", &EncDecSerializer{encKey: encKey})
+	encDec.Lock()
+	encDec.encKey = encKey
+	encDec.Unlock()
+	schema.RegisterSerializer("encdec", encDec)
+}
+
+// LoadEncryptionKeys makes the encdec serializer encrypt by the active data key of the database and decrypt by any
+// of its data keys, the values are encrypted by ENCODE_KEY itself until a data key is created by a key rotation
+func LoadEncryptionKeys(db dal.Dal) errors.Error {
+	return encDec.load(db)
+}
+
+// EncryptValue encrypts a value of an encrypted column as the encdec serializer does
+func EncryptValue(plainText string) (string, errors.Error) {
+	return encDec.encrypt(plainText)
+}
+
+// DecryptValue decrypts a value of an encrypted column as the encdec serializer does
+func DecryptValue(encryptedText string) (string, errors.Error) {
+	return encDec.decrypt(encryptedText)
+}
+
+// EncryptionKeyPrefix is the prefix of the values encrypted by the data key
+func EncryptionKeyPrefix(keyId uint64) string {
+	return fmt.Sprintf("%s%d$", dataKeyPrefix, keyId)
+}
+
+// GetEncryptedColumns returns the columns of the model encrypted by the encdec serializer along with its primary key
+func GetEncryptedColumns(model interface{}) (columns []string, primaryKeys []string, err errors.Error) {
+	s, parseErr := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+	if parseErr != nil {
+		return nil, nil, errors.Default.Wrap(parseErr, "failed to parse the model")
+	}
+	for _, field := range s.Fields {
+		if field.DBName != "" && field.TagSettings["SERIALIZER"] == "encdec" {
+			columns = append(columns, field.DBName)
+		}
+	}
+	for _, field := range s.PrimaryFields {
+		primaryKeys = append(primaryKeys, field.DBName)
+	}
+	return columns, primaryKeys, nil
 }
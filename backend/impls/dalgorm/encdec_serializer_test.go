@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type encryptedConnection struct {
+	ID       uint64 `gorm:"primaryKey"`
+	Name     string
+	Token    string `gorm:"serializer:encdec"`
+	Password string `gorm:"column:secret;serializer:encdec"`
+}
+
+func (encryptedConnection) TableName() string {
+	return "connections"
+}
+
+func TestEncryptValueByDataKey(t *testing.T) {
+	es := &EncDecSerializer{encKey: "master"}
+	// encrypted by ENCODE_KEY itself until a data key is activated
+	legacy, err := es.encrypt("secret")
+	assert.Nil(t, err)
+	assert.False(t, strings.HasPrefix(legacy, dataKeyPrefix))
+	plain, err := plugin.Decrypt("master", legacy)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", plain)
+
+	es.keys = map[uint64]string{1: "key1", 2: "key2"}
+	es.activeId = 2
+	encrypted, err := es.encrypt("secret")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "$k2$"))
+
+	// any key decrypts its own values
+	for _, value := range []string{legacy, encrypted} {
+		plain, err = es.decrypt(value)
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", plain)
+	}
+	byKey1, err := plugin.Encrypt("key1", "old")
+	assert.Nil(t, err)
+	plain, err = es.decrypt("$k1$" + byKey1)
+	assert.Nil(t, err)
+	assert.Equal(t, "old", plain)
+
+	_, err = es.decrypt("$k3$" + byKey1)
+	assert.NotNil(t, err)
+	_, err = es.decrypt("$kx$" + byKey1)
+	assert.NotNil(t, err)
+}
+
+func TestGetEncryptedColumns(t *testing.T) {
+	Init("master")
+	columns, primaryKeys, err := GetEncryptedColumns(&encryptedConnection{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"token", "secret"}, columns)
+	assert.Equal(t, []string{"id"}, primaryKeys)
+}
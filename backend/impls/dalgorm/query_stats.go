@@ -0,0 +1,224 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+
+	"gorm.io/gorm"
+)
+
+const queryStatsSetting = "devlake:query_stats"
+const queryStatsBeganSetting = "devlake:query_stats_began"
+const maxQueryStatSqlLength = 2000
+
+var placeholderPattern = regexp.MustCompile(`\$\d+`)
+var placeholderListPattern = regexp.MustCompile(`\(\?(\s*,\s*\?)+\)`)
+var placeholderRowsPattern = regexp.MustCompile(`\(\?\.\.\.\)(\s*,\s*\(\?\.\.\.\))+`)
+
+// QueryStat is the durations of a query issued through the dal by a caller, the queries differing by their
+// parameters or by the lengths of their IN lists and VALUES are the same query
+type QueryStat struct {
+	Sql        string    `json:"sql"`
+	Table      string    `json:"table"`
+	Caller     string    `json:"caller"`
+	Count      int64     `json:"count"`
+	TotalMs    float64   `json:"totalMs"`
+	MaxMs      float64   `json:"maxMs"`
+	AvgMs      float64   `json:"avgMs"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+}
+
+type queryStatKey struct {
+	sql    string
+	caller string
+}
+
+type queryStats struct {
+	sync.Mutex
+	stats    map[queryStatKey]*QueryStat
+	maxStats int
+}
+
+var trackedQueries = &queryStats{stats: make(map[queryStatKey]*QueryStat)}
+
+type queryStatsTracker struct {
+	isTracked func(table string) bool
+}
+
+// RegisterQueryStatsCallbacks tracks the durations of the queries issued on the tables accepted by isTracked, up to
+// maxStats distinct queries are kept, the fastest are dropped beyond that. See GetQueryStats
+func RegisterQueryStatsCallbacks(db *gorm.DB, isTracked func(table string) bool, maxStats int) errors.Error {
+	trackedQueries.Lock()
+	trackedQueries.maxStats = maxStats
+	trackedQueries.Unlock()
+	tracker := &queryStatsTracker{isTracked: isTracked}
+	callbacks := db.Callback()
+	err := callbacks.Create().Before("gorm:create").Register(queryStatsBeganSetting, tracker.before)
+	if err == nil {
+		err = callbacks.Create().After("gorm:create").Register(queryStatsSetting, tracker.after)
+	}
+	if err == nil {
+		err = callbacks.Query().Before("gorm:query").Register(queryStatsBeganSetting, tracker.before)
+	}
+	if err == nil {
+		err = callbacks.Query().After("gorm:query").Register(queryStatsSetting, tracker.after)
+	}
+	if err == nil {
+		err = callbacks.Update().Before("gorm:update").Register(queryStatsBeganSetting, tracker.before)
+	}
+	if err == nil {
+		err = callbacks.Update().After("gorm:update").Register(queryStatsSetting, tracker.after)
+	}
+	if err == nil {
+		err = callbacks.Delete().Before("gorm:delete").Register(queryStatsBeganSetting, tracker.before)
+	}
+	if err == nil {
+		err = callbacks.Delete().After("gorm:delete").Register(queryStatsSetting, tracker.after)
+	}
+	if err == nil {
+		err = callbacks.Row().Before("gorm:row").Register(queryStatsBeganSetting, tracker.before)
+	}
+	if err == nil {
+		err = callbacks.Row().After("gorm:row").Register(queryStatsSetting, tracker.after)
+	}
+	return errors.Convert(err)
+}
+
+func (t *queryStatsTracker) before(db *gorm.DB) {
+	if db.DryRun {
+		return
+	}
+	db.InstanceSet(queryStatsBeganSetting, time.Now())
+}
+
+func (t *queryStatsTracker) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(queryStatsBeganSetting)
+	if !ok {
+		return
+	}
+	duration := time.Since(v.(time.Time))
+	stmt := db.Statement
+	table := stmt.Table
+	if table == "" && stmt.TableExpr != nil {
+		if matched := leadingTablePattern.FindStringSubmatch(stmt.TableExpr.SQL); matched != nil {
+			table = matched[1]
+		}
+	}
+	if !t.isTracked(table) {
+		return
+	}
+	trackedQueries.record(normalizeQuery(stmt.SQL.String()), table, queryCaller(), duration)
+}
+
+func (s *queryStats) record(sql, table, caller string, duration time.Duration) {
+	ms := float64(duration.Microseconds()) / 1000
+	key := queryStatKey{sql: sql, caller: caller}
+	s.Lock()
+	defer s.Unlock()
+	stat, ok := s.stats[key]
+	if !ok {
+		if s.maxStats > 0 && len(s.stats) >= s.maxStats && !s.evictFasterThan(ms) {
+			return
+		}
+		stat = &QueryStat{Sql: sql, Table: table, Caller: caller}
+		s.stats[key] = stat
+	}
+	stat.Count++
+	stat.TotalMs += ms
+	if ms > stat.MaxMs {
+		stat.MaxMs = ms
+	}
+	stat.AvgMs = stat.TotalMs / float64(stat.Count)
+	stat.LastSeenAt = time.Now()
+}
+
+// evictFasterThan drops the query of the shortest max duration to make room for a slower one
+func (s *queryStats) evictFasterThan(ms float64) bool {
+	var fastest *queryStatKey
+	var fastestMs float64
+	for key, stat := range s.stats {
+		if fastest == nil || stat.MaxMs < fastestMs {
+			k := key
+			fastest = &k
+			fastestMs = stat.MaxMs
+		}
+	}
+	if fastest == nil || fastestMs >= ms {
+		return false
+	}
+	delete(s.stats, *fastest)
+	return true
+}
+
+// normalizeQuery makes the queries differing by their parameters only the same, i.e. the placeholders of postgres,
+// the IN lists and the rows of the inserts are collapsed
+func normalizeQuery(sql string) string {
+	sql = placeholderPattern.ReplaceAllString(sql, "?")
+	sql = placeholderListPattern.ReplaceAllString(sql, "(?...)")
+	sql = placeholderRowsPattern.ReplaceAllString(sql, "(?...),...")
+	if len(sql) > maxQueryStatSqlLength {
+		sql = sql[:maxQueryStatSqlLength] + "..."
+	}
+	return sql
+}
+
+// queryCaller returns the first function out of gorm and the dal in the stack, i.e. the code issuing the query
+func queryCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "gorm.io/") &&
+			!strings.Contains(frame.File, "/impls/dalgorm/") &&
+			!strings.Contains(frame.File, "/impls/dalclickhouse/") &&
+			!strings.Contains(frame.File, "/core/dal/") {
+			return fmt.Sprintf("%s %s:%d", frame.Function, filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// GetQueryStats returns the durations of the tracked queries since the start or the last ResetQueryStats
+func GetQueryStats() []*QueryStat {
+	trackedQueries.Lock()
+	defer trackedQueries.Unlock()
+	stats := make([]*QueryStat, 0, len(trackedQueries.stats))
+	for _, stat := range trackedQueries.stats {
+		copied := *stat
+		stats = append(stats, &copied)
+	}
+	return stats
+}
+
+// ResetQueryStats forgets the durations of the tracked queries
+func ResetQueryStats() {
+	trackedQueries.Lock()
+	defer trackedQueries.Unlock()
+	trackedQueries.stats = make(map[queryStatKey]*QueryStat)
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	assert.Equal(
+		t,
+		"SELECT * FROM `issues` WHERE id IN (?...) AND board_id = ?",
+		normalizeQuery("SELECT * FROM `issues` WHERE id IN (?,?,?) AND board_id = ?"),
+	)
+	assert.Equal(
+		t,
+		`SELECT * FROM "issues" WHERE id IN (?...)`,
+		normalizeQuery(`SELECT * FROM "issues" WHERE id IN ($1, $2)`),
+	)
+	assert.Equal(
+		t,
+		"INSERT INTO `issues` (`id`,`title`) VALUES (?...),... ON DUPLICATE KEY UPDATE `title`=VALUES(`title`)",
+		normalizeQuery("INSERT INTO `issues` (`id`,`title`) VALUES (?,?),(?,?),(?,?) ON DUPLICATE KEY UPDATE `title`=VALUES(`title`)"),
+	)
+}
+
+func TestQueryStatsRecord(t *testing.T) {
+	s := &queryStats{stats: make(map[queryStatKey]*QueryStat), maxStats: 2}
+	s.record("SELECT a", "issues", "caller1", 10*time.Millisecond)
+	s.record("SELECT a", "issues", "caller1", 30*time.Millisecond)
+	s.record("SELECT a", "issues", "caller2", 5*time.Millisecond)
+	stat := s.stats[queryStatKey{sql: "SELECT a", caller: "caller1"}]
+	assert.Equal(t, int64(2), stat.Count)
+	assert.Equal(t, 30.0, stat.MaxMs)
+	assert.Equal(t, 20.0, stat.AvgMs)
+
+	// full, a faster query is dropped and a slower one replaces the fastest
+	s.record("SELECT b", "commits", "caller1", time.Millisecond)
+	assert.Len(t, s.stats, 2)
+	assert.NotContains(t, s.stats, queryStatKey{sql: "SELECT b", caller: "caller1"})
+	s.record("SELECT c", "commits", "caller1", 50*time.Millisecond)
+	assert.Len(t, s.stats, 2)
+	assert.Contains(t, s.stats, queryStatKey{sql: "SELECT c", caller: "caller1"})
+	assert.NotContains(t, s.stats, queryStatKey{sql: "SELECT a", caller: "caller2"})
+}
+
+func TestQueryCaller(t *testing.T) {
+	// the frames of the dal are skipped, the test itself included
+	assert.Contains(t, queryCaller(), "testing.tRunner")
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const tableSchemaSetting = "devlake:table_schema"
+
+var leadingTablePattern = regexp.MustCompile("^[`\"]?(\\w+)[`\"]?")
+var joinedTablePattern = regexp.MustCompile("(?i)(\\bjoin\\s+)[`\"]?(\\w+)[`\"]?")
+
+type tableSchema struct {
+	schema string
+	tables map[string]bool
+}
+
+// qualify returns the table qualified by the schema if it is redirected
+func (ts *tableSchema) qualify(stmt *gorm.Statement, table string) (string, bool) {
+	if !ts.tables[table] {
+		return "", false
+	}
+	return stmt.Quote(ts.schema + "." + table), true
+}
+
+// RegisterTableSchemaCallbacks installs the callbacks redirecting the tables of the Dal returned by WithTableSchema,
+// it must be called once on the gorm.DB
+func RegisterTableSchemaCallbacks(db *gorm.DB) errors.Error {
+	callbacks := db.Callback()
+	err := callbacks.Create().Before("gorm:create").Register(tableSchemaSetting, redirectTableSchema)
+	if err == nil {
+		err = callbacks.Query().Before("gorm:query").Register(tableSchemaSetting, redirectTableSchema)
+	}
+	if err == nil {
+		err = callbacks.Update().Before("gorm:update").Register(tableSchemaSetting, redirectTableSchema)
+	}
+	if err == nil {
+		err = callbacks.Delete().Before("gorm:delete").Register(tableSchemaSetting, redirectTableSchema)
+	}
+	if err == nil {
+		err = callbacks.Row().Before("gorm:row").Register(tableSchemaSetting, redirectTableSchema)
+	}
+	return errors.Convert(err)
+}
+
+func redirectTableSchema(db *gorm.DB) {
+	setting, ok := db.Get(tableSchemaSetting)
+	if !ok {
+		return
+	}
+	ts := setting.(*tableSchema)
+	stmt := db.Statement
+	if stmt.TableExpr == nil {
+		if qualified, ok := ts.qualify(stmt, stmt.Table); ok {
+			stmt.TableExpr = &clause.Expr{SQL: qualified}
+		}
+	} else if matched := leadingTablePattern.FindStringSubmatch(stmt.TableExpr.SQL); matched != nil {
+		// the table might be followed by its alias, anything else, i.e. a subquery, is left as is
+		rest := stmt.TableExpr.SQL[len(matched[0]):]
+		if qualified, ok := ts.qualify(stmt, matched[1]); ok && (rest == "" || strings.ContainsAny(rest[:1], " \t\n")) {
+			stmt.TableExpr = &clause.Expr{SQL: qualified + rest, Vars: stmt.TableExpr.Vars}
+		}
+	}
+	for i := range stmt.Joins {
+		stmt.Joins[i].Name = joinedTablePattern.ReplaceAllStringFunc(stmt.Joins[i].Name, func(join string) string {
+			matched := joinedTablePattern.FindStringSubmatch(join)
+			qualified, ok := ts.qualify(stmt, matched[2])
+			if !ok {
+				return join
+			}
+			return matched[1] + qualified
+		})
+	}
+}
+
+// WithTableSchema returns a Dal reading and writing the tables in the schema, see dal.TableSchemaRedirector
+func (d *Dalgorm) WithTableSchema(schema string, tables []string) dal.Dal {
+	ts := &tableSchema{schema: schema, tables: make(map[string]bool, len(tables))}
+	for _, table := range tables {
+		ts.tables[table] = true
+	}
+	return NewDalgorm(d.db.Set(tableSchemaSetting, ts).Session(&gorm.Session{}))
+}
+
+var _ dal.TableSchemaRedirector = (*Dalgorm)(nil)
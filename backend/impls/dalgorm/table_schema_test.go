@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type tableSchemaIssue struct {
+	Id    string `gorm:"primaryKey"`
+	Title string
+}
+
+func (tableSchemaIssue) TableName() string {
+	return "issues"
+}
+
+func dryRunDb(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "merico:merico@tcp(localhost:3306)/lake",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Nil(t, err)
+	assert.Nil(t, RegisterTableSchemaCallbacks(db))
+	return db
+}
+
+func TestWithTableSchema(t *testing.T) {
+	db := dryRunDb(t)
+	redirected := NewDalgorm(db).WithTableSchema("bu_a", []string{"issues", "board_issues"}).(*Dalgorm).db
+
+	stmt := redirected.Find(&[]tableSchemaIssue{}).Statement
+	assert.Equal(t, "SELECT * FROM `bu_a`.`issues`", stmt.SQL.String())
+
+	stmt = redirected.Table("issues i").
+		Joins("left join board_issues bi on bi.issue_id = i.id").
+		Joins("LEFT JOIN `boards` b on b.id = bi.board_id").
+		Where("i.id = ?", "1").
+		Find(&[]map[string]interface{}{}).Statement
+	assert.Equal(
+		t,
+		"SELECT * FROM `bu_a`.`issues` i left join `bu_a`.`board_issues` bi on bi.issue_id = i.id LEFT JOIN `boards` b on b.id = bi.board_id WHERE i.id = ?",
+		stmt.SQL.String(),
+	)
+
+	stmt = redirected.Create(&tableSchemaIssue{Id: "1", Title: "a"}).Statement
+	assert.Equal(t, "INSERT INTO `bu_a`.`issues` (`id`,`title`) VALUES (?,?)", stmt.SQL.String())
+
+	// the redirection doesn't leak to the other sessions
+	stmt = db.Find(&[]tableSchemaIssue{}).Statement
+	assert.Equal(t, "SELECT * FROM `issues`", stmt.SQL.String())
+	stmt = redirected.Table("boards").Find(&[]map[string]interface{}{}).Statement
+	assert.Equal(t, "SELECT * FROM `boards`", stmt.SQL.String())
+}
@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/argocd/models"
+	"strings"
+)
+
+type ScopeRes struct {
+	models.ArgocdApplication
+	TransformationRuleName string `json:"transformationRuleName,omitempty"`
+}
+
+type ScopeReq api.ScopeReq[models.ArgocdApplication]
+
+// PutScope create or update application
+// @Summary create or update application
+// @Description Create or update application
+// @Tags plugins/argocd
+// @Accept application/json
+// @Param connectionId path int true "connection ID"
+// @Param scope body ScopeReq true "json"
+// @Success 200  {object} []models.ArgocdApplication
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/argocd/connections/{connectionId}/scopes [PUT]
+func PutScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return scopeHelper.Put(input)
+}
+
+// UpdateScope patch to application
+// @Summary patch to application
+// @Description patch to application
+// @Tags plugins/argocd
+// @Accept application/json
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path string true "application id"
+// @Param scope body models.ArgocdApplication true "json"
+// @Success 200  {object} models.ArgocdApplication
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/argocd/connections/{connectionId}/scopes/{scopeId} [PATCH]
+func UpdateScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	input.Params["scopeId"] = strings.TrimLeft(input.Params["scopeId"], "/")
+	return scopeHelper.Update(input, "name")
+}
+
+// GetScopeList get applications
+// @Summary get applications
+// @Description get applications
+// @Tags plugins/argocd
+// @Param connectionId path int true "connection ID"
+// @Param pageSize query int false "page size, default 50"
+// @Param page query int false "page size, default 1"
+// @Success 200  {object} api.ListResponse "the page of the scopes under scopes, along with count, page and pageSize"
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/argocd/connections/{connectionId}/scopes/ [GET]
+func GetScopeList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return scopeHelper.GetScopeList(input)
+}
+
+// GetScope get one application
+// @Summary get one application
+// @Description get one application
+// @Tags plugins/argocd
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path string true "application id"
+// @Success 200  {object} ScopeRes
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/argocd/connections/{connectionId}/scopes/{scopeId} [GET]
+func GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	input.Params["scopeId"] = strings.TrimLeft(input.Params["scopeId"], "/")
+	return scopeHelper.GetScope(input, "name")
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*ArgocdApplication)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*ArgocdApiApplication)(nil)
+
+// ArgocdApplication is an application of ArgoCD, its sync history are the deployments
+type ArgocdApplication struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	Name                 string `json:"name" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"name"`
+	Project              string `json:"project" gorm:"type:varchar(255)" mapstructure:"project,omitempty"`
+	RepoUrl              string `json:"repoUrl" gorm:"type:varchar(255)" mapstructure:"repoUrl,omitempty"`
+	Path                 string `json:"path" gorm:"type:varchar(255)" mapstructure:"path,omitempty"`
+	DestServer           string `json:"destServer" gorm:"type:varchar(255)" mapstructure:"destServer,omitempty"`
+	DestNamespace        string `json:"destNamespace" gorm:"type:varchar(255)" mapstructure:"destNamespace,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (ArgocdApplication) TableName() string {
+	return "_tool_argocd_applications"
+}
+
+func (a ArgocdApplication) ScopeId() string {
+	return a.Name
+}
+
+func (a ArgocdApplication) ScopeName() string {
+	return a.Name
+}
+
+// ArgocdApiApplication is an application returned by the api, only the fields of the scope are kept
+type ArgocdApiApplication struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Project string `json:"project"`
+		Source  struct {
+			RepoURL string `json:"repoURL"`
+			Path    string `json:"path"`
+		} `json:"source"`
+		Destination struct {
+			Server    string `json:"server"`
+			Namespace string `json:"namespace"`
+		} `json:"destination"`
+	} `json:"spec"`
+}
+
+func (a ArgocdApiApplication) ConvertApiScope() plugin.ToolLayerScope {
+	return &ArgocdApplication{
+		Name:          a.Metadata.Name,
+		Project:       a.Spec.Project,
+		RepoUrl:       a.Spec.Source.RepoURL,
+		Path:          a.Spec.Source.Path,
+		DestServer:    a.Spec.Destination.Server,
+		DestNamespace: a.Spec.Destination.Namespace,
+	}
+}
+
+// GroupResponse is required by the remote api helper, the applications are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
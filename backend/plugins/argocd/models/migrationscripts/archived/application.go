@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type ArgocdApplication struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	Name                 string `json:"name" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"name"`
+	Project              string `json:"project" gorm:"type:varchar(255)" mapstructure:"project,omitempty"`
+	RepoUrl              string `json:"repoUrl" gorm:"type:varchar(255)" mapstructure:"repoUrl,omitempty"`
+	Path                 string `json:"path" gorm:"type:varchar(255)" mapstructure:"path,omitempty"`
+	DestServer           string `json:"destServer" gorm:"type:varchar(255)" mapstructure:"destServer,omitempty"`
+	DestNamespace        string `json:"destNamespace" gorm:"type:varchar(255)" mapstructure:"destNamespace,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	archived.NoPKModel   `json:"-" mapstructure:"-"`
+}
+
+func (ArgocdApplication) TableName() string {
+	return "_tool_argocd_applications"
+}
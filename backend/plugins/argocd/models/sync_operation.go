@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// phases of the sync operations
+const (
+	PHASE_RUNNING     = "Running"
+	PHASE_TERMINATING = "Terminating"
+	PHASE_SUCCEEDED   = "Succeeded"
+	PHASE_FAILED      = "Failed"
+	PHASE_ERROR       = "Error"
+)
+
+// ArgocdSyncOperation is a sync of an application, the successful ones are kept in the history of the application
+// while only the last one is known when it failed
+type ArgocdSyncOperation struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ApplicationName string `gorm:"primaryKey;type:varchar(255)"`
+	// DeploymentId is the id of the sync in the history of the application
+	DeploymentId int64  `gorm:"primaryKey;autoIncrement:false"`
+	Revision     string `gorm:"type:varchar(255)"`
+	RepoUrl      string `gorm:"type:varchar(255)"`
+	Phase        string `gorm:"type:varchar(20)"`
+	Message      string
+	// HealthStatus is the health of the application collected after its last sync, it is only set on that sync
+	HealthStatus string `gorm:"type:varchar(20)"`
+	InitiatedBy  string `gorm:"type:varchar(255)"`
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+	common.NoPKModel
+}
+
+func (ArgocdSyncOperation) TableName() string {
+	return "_tool_argocd_sync_operations"
+}
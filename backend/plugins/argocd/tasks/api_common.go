@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type ArgocdApiParams struct {
+	ConnectionId    uint64
+	ApplicationName string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *ArgocdTaskData) {
+	data := taskCtx.GetData().(*ArgocdTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: ArgocdApiParams{
+			ConnectionId:    data.Options.ConnectionId,
+			ApplicationName: data.Options.ApplicationName,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_APPLICATION_TABLE = "argocd_api_applications"
+
+var CollectApiApplicationMeta = plugin.SubTaskMeta{
+	Name:             "collectApiApplication",
+	EntryPoint:       CollectApiApplication,
+	EnabledByDefault: true,
+	Description:      "Collect the application with its sync history from ArgoCD api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiApplication(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_APPLICATION_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	// the application only keeps its last syncs (10 by default), the snapshots collected before are kept
+	// when collecting incrementally so that the history of the syncs grows over the collections
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:      data.ApiClient,
+		Incremental:    collectorWithState.IsIncremental(),
+		UrlTemplate:    "api/v1/applications/{{ .Params.ApplicationName }}",
+		ResponseParser: api.GetRawMessageDirectFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
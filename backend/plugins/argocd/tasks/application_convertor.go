@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/argocd/models"
+)
+
+var ConvertApplicationMeta = plugin.SubTaskMeta{
+	Name:             "convertApplication",
+	EntryPoint:       ConvertApplication,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table argocd_applications into domain layer table cicd_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// GetApiApplication fetches an application by its name
+func GetApiApplication(op *ArgocdOptions, apiClient aha.ApiClientAbstract) (*models.ArgocdApiApplication, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("api/v1/applications/%s", op.ApplicationName), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting application detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	body := &models.ArgocdApiApplication{}
+	err = api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func ConvertApplication(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_APPLICATION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ArgocdApplication{}),
+		dal.Where("connection_id = ? AND name = ?", data.Options.ConnectionId, data.Options.ApplicationName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	applicationIdGen := didgen.NewDomainIdGenerator(&models.ArgocdApplication{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.ArgocdApplication{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			application := inputRow.(*models.ArgocdApplication)
+			return []interface{}{
+				&devops.CicdScope{
+					DomainEntity: domainlayer.DomainEntity{Id: applicationIdGen.Generate(application.ConnectionId, application.Name)},
+					Name:         application.Name,
+					Url:          application.Url,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/argocd/models"
+)
+
+var ExtractApiApplicationMeta = plugin.SubTaskMeta{
+	Name:             "extractApiApplication",
+	EntryPoint:       ExtractApiApplication,
+	EnabledByDefault: true,
+	Description:      "Extract the sync history of the raw application data into tool layer table argocd_sync_operations",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type ArgocdApiInitiator struct {
+	Username  string `json:"username"`
+	Automated bool   `json:"automated"`
+}
+
+type ArgocdApiRevisionHistory struct {
+	Id              int64               `json:"id"`
+	Revision        string              `json:"revision"`
+	DeployedAt      *time.Time          `json:"deployedAt"`
+	DeployStartedAt *time.Time          `json:"deployStartedAt"`
+	InitiatedBy     *ArgocdApiInitiator `json:"initiatedBy"`
+	Source          struct {
+		RepoURL string `json:"repoURL"`
+	} `json:"source"`
+}
+
+type ArgocdApiApplicationDetail struct {
+	Spec struct {
+		Source struct {
+			RepoURL string `json:"repoURL"`
+		} `json:"source"`
+	} `json:"spec"`
+	Status struct {
+		Health struct {
+			Status string `json:"status"`
+		} `json:"health"`
+		History        []ArgocdApiRevisionHistory `json:"history"`
+		OperationState *struct {
+			Operation struct {
+				InitiatedBy *ArgocdApiInitiator `json:"initiatedBy"`
+				Sync        struct {
+					Revision string `json:"revision"`
+				} `json:"sync"`
+			} `json:"operation"`
+			Phase      string     `json:"phase"`
+			Message    string     `json:"message"`
+			StartedAt  *time.Time `json:"startedAt"`
+			FinishedAt *time.Time `json:"finishedAt"`
+		} `json:"operationState"`
+	} `json:"status"`
+}
+
+func ExtractApiApplication(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_APPLICATION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiApplication := &ArgocdApiApplicationDetail{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiApplication))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, len(apiApplication.Status.History)+1)
+			var last *models.ArgocdSyncOperation
+			var lastId int64
+			for _, history := range apiApplication.Status.History {
+				lastId = history.Id
+				// the syncs deployed before timeAfter are skipped
+				if data.TimeAfter != nil && history.DeployedAt != nil && history.DeployedAt.Before(*data.TimeAfter) {
+					continue
+				}
+				syncOperation := &models.ArgocdSyncOperation{
+					ConnectionId:    data.Options.ConnectionId,
+					ApplicationName: data.Options.ApplicationName,
+					DeploymentId:    history.Id,
+					Revision:        history.Revision,
+					RepoUrl:         history.Source.RepoURL,
+					Phase:           models.PHASE_SUCCEEDED,
+					InitiatedBy:     initiatorName(history.InitiatedBy),
+					StartedDate:     history.DeployStartedAt,
+					FinishedDate:    history.DeployedAt,
+				}
+				if syncOperation.RepoUrl == "" {
+					syncOperation.RepoUrl = apiApplication.Spec.Source.RepoURL
+				}
+				results = append(results, syncOperation)
+				last = syncOperation
+			}
+			// the history only records the successful syncs, the last operation is added when it did not succeed,
+			// with the id the history would give it
+			operation := apiApplication.Status.OperationState
+			if operation != nil && operation.Phase != models.PHASE_SUCCEEDED {
+				last = &models.ArgocdSyncOperation{
+					ConnectionId:    data.Options.ConnectionId,
+					ApplicationName: data.Options.ApplicationName,
+					DeploymentId:    lastId + 1,
+					Revision:        operation.Operation.Sync.Revision,
+					RepoUrl:         apiApplication.Spec.Source.RepoURL,
+					Phase:           operation.Phase,
+					Message:         operation.Message,
+					InitiatedBy:     initiatorName(operation.Operation.InitiatedBy),
+					StartedDate:     operation.StartedAt,
+					FinishedDate:    operation.FinishedAt,
+				}
+				results = append(results, last)
+			}
+			if last != nil {
+				last.HealthStatus = apiApplication.Status.Health.Status
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func initiatorName(initiator *ArgocdApiInitiator) string {
+	if initiator == nil {
+		return ""
+	}
+	if initiator.Automated {
+		return "automated"
+	}
+	return initiator.Username
+}
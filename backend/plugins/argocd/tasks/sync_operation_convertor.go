@@ -0,0 +1,160 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/argocd/models"
+)
+
+// the revision of a helm chart is its version, only the git revisions are commits
+var commitShaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+var ConvertSyncOperationsMeta = plugin.SubTaskMeta{
+	Name:             "convertSyncOperations",
+	EntryPoint:       ConvertSyncOperations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table argocd_sync_operations into domain layer table cicd_pipelines, cicd_tasks and cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertSyncOperations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_APPLICATION_TABLE)
+	db := taskCtx.GetDal()
+
+	application := &models.ArgocdApplication{}
+	err := db.First(application, dal.Where("connection_id = ? AND name = ?", data.Options.ConnectionId, data.Options.ApplicationName))
+	if err != nil {
+		return err
+	}
+	// every sync of the application deploys to the same destination
+	environment := data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, application.DestNamespace)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ArgocdSyncOperation{}),
+		dal.Where("connection_id = ? AND application_name = ?", data.Options.ConnectionId, data.Options.ApplicationName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	syncIdGen := didgen.NewDomainIdGenerator(&models.ArgocdSyncOperation{})
+	applicationIdGen := didgen.NewDomainIdGenerator(&models.ArgocdApplication{})
+	scopeId := applicationIdGen.Generate(application.ConnectionId, application.Name)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.ArgocdSyncOperation{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			syncOperation := inputRow.(*models.ArgocdSyncOperation)
+			// the old versions of ArgoCD do not record when the deployment started
+			startedDate := syncOperation.StartedDate
+			if startedDate == nil {
+				startedDate = syncOperation.FinishedDate
+			}
+			if startedDate == nil {
+				return nil, nil
+			}
+			id := syncIdGen.Generate(syncOperation.ConnectionId, syncOperation.ApplicationName, syncOperation.DeploymentId)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         fmt.Sprintf("%s#%d", syncOperation.ApplicationName, syncOperation.DeploymentId),
+				Result: devops.GetResult(&devops.ResultRule{
+					Success: []string{models.PHASE_SUCCEEDED},
+					Failed:  []string{models.PHASE_FAILED, models.PHASE_ERROR},
+					Default: "",
+				}, syncOperation.Phase),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{models.PHASE_RUNNING, models.PHASE_TERMINATING},
+					Default:    devops.DONE,
+				}, syncOperation.Phase),
+				Type:        devops.DEPLOYMENT,
+				Environment: environment,
+				CreatedDate: *startedDate,
+				CicdScopeId: scopeId,
+			}
+			if domainPipeline.Status == devops.DONE && syncOperation.FinishedDate != nil {
+				domainPipeline.FinishedDate = syncOperation.FinishedDate
+				domainPipeline.DurationSec = uint64(syncOperation.FinishedDate.Sub(*startedDate).Seconds())
+			}
+			results := []interface{}{
+				domainPipeline,
+				&devops.CICDTask{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         domainPipeline.Name,
+					PipelineId:   id,
+					Result:       domainPipeline.Result,
+					Status:       domainPipeline.Status,
+					Type:         devops.DEPLOYMENT,
+					Environment:  environment,
+					DurationSec:  domainPipeline.DurationSec,
+					StartedDate:  *startedDate,
+					FinishedDate: domainPipeline.FinishedDate,
+					CicdScopeId:  scopeId,
+				},
+			}
+			if commitShaPattern.MatchString(syncOperation.Revision) {
+				domainDeployCommit := &devops.CicdDeploymentCommit{
+					// the id is the one dora derives from the pipeline commit, so that both end up with the same row
+					DomainEntity:     domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", id, syncOperation.RepoUrl)},
+					CicdScopeId:      scopeId,
+					CicdDeploymentId: id,
+					Name:             domainPipeline.Name,
+					Result:           domainPipeline.Result,
+					Status:           domainPipeline.Status,
+					Environment:      environment,
+					CreatedDate:      *startedDate,
+					StartedDate:      startedDate,
+					FinishedDate:     domainPipeline.FinishedDate,
+					CommitSha:        syncOperation.Revision,
+					RepoUrl:          syncOperation.RepoUrl,
+				}
+				if domainPipeline.FinishedDate != nil {
+					durationSec := domainPipeline.DurationSec
+					domainDeployCommit.DurationSec = &durationSec
+				}
+				results = append(results,
+					&devops.CiCDPipelineCommit{
+						PipelineId: id,
+						CommitSha:  syncOperation.Revision,
+						RepoUrl:    syncOperation.RepoUrl,
+					},
+					domainDeployCommit,
+				)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
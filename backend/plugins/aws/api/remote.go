@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the pipelines are not grouped
+// @Tags plugins/aws
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/aws/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		nil,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.AwsConnection) ([]models.AwsApiPipeline, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			return listPipelines(basicRes, &connection, queryData, "")
+		},
+	)
+}
+
+// SearchRemoteScopes filters the pipelines by name
+// @Summary filters the pipelines by name
+// @Description filters the pipelines by name
+// @Tags plugins/aws
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/aws/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.AwsConnection) ([]models.AwsApiPipeline, errors.Error) {
+			return listPipelines(basicRes, &connection, queryData, queryData.Search[0])
+		},
+	)
+}
+
+// listPipelines returns all the pipelines on the first page, the api pages them by a token which can not be turned
+// into a page number, they are filtered by name when a search is given
+func listPipelines(basicRes context2.BasicRes, connection *models.AwsConnection, queryData *api.RemoteQueryData, search string) ([]models.AwsApiPipeline, errors.Error) {
+	if queryData.Page > 1 {
+		return nil, nil
+	}
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	pipelines := make([]models.AwsApiPipeline, 0)
+	nextToken := ""
+	for {
+		reqBody := map[string]interface{}{"maxResults": 1000}
+		if nextToken != "" {
+			reqBody["nextToken"] = nextToken
+		}
+		res, err := apiClient.Post("", nil, reqBody, http.Header{
+			"X-Amz-Target": {"CodePipeline_20150709.ListPipelines"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		var resBody struct {
+			Pipelines []models.AwsApiPipeline `json:"pipelines"`
+			NextToken string                  `json:"nextToken"`
+		}
+		err = api.UnmarshalResponse(res, &resBody)
+		if err != nil {
+			return nil, err
+		}
+		for _, pipeline := range resBody.Pipelines {
+			if search == "" || strings.Contains(pipeline.Name, search) {
+				pipelines = append(pipelines, pipeline)
+			}
+		}
+		if resBody.NextToken == "" {
+			return pipelines, nil
+		}
+		nextToken = resBody.NextToken
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// CreateTransformationRule create transformation rule for Aws
+// @Summary create transformation rule for Aws
+// @Description create transformation rule for Aws
+// @Tags plugins/aws
+// @Accept application/json
+// @Param connectionId path int true "connectionId"
+// @Param transformationRule body models.AwsTransformationRule true "transformation rule"
+// @Success 200  {object} models.AwsTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/aws/connections/{connectionId}/transformation_rules [POST]
+func CreateTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.Create(input)
+}
+
+// UpdateTransformationRule update transformation rule for Aws
+// @Summary update transformation rule for Aws
+// @Description update transformation rule for Aws
+// @Tags plugins/aws
+// @Accept application/json
+// @Param id path int true "id"
+// @Param connectionId path int true "connectionId"
+// @Param transformationRule body models.AwsTransformationRule true "transformation rule"
+// @Success 200  {object} models.AwsTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/aws/connections/{connectionId}/transformation_rules/{id} [PATCH]
+func UpdateTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.Update(input)
+}
+
+// GetTransformationRule return one transformation rule
+// @Summary return one transformation rule
+// @Description return one transformation rule
+// @Tags plugins/aws
+// @Param id path int true "id"
+// @Param connectionId path int true "connectionId"
+// @Success 200  {object} models.AwsTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/aws/connections/{connectionId}/transformation_rules/{id} [GET]
+func GetTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.Get(input)
+}
+
+// GetTransformationRuleList return all transformation rules
+// @Summary return all transformation rules
+// @Description return all transformation rules
+// @Tags plugins/aws
+// @Param connectionId path int true "connectionId"
+// @Param pageSize query int false "page size, default 50"
+// @Param page query int false "page size, default 1"
+// @Success 200  {object} []models.AwsTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/aws/connections/{connectionId}/transformation_rules [GET]
+func GetTransformationRuleList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.List(input)
+}
+
+// BatchTransformationRules create, update and delete transformation rules for Aws in one transaction
+// @Summary create, update and delete transformation rules for Aws in one transaction
+// @Description create, update and delete transformation rules for Aws in one transaction, nothing is saved when any of them fails
+// @Tags plugins/aws
+// @Accept application/json
+// @Param connectionId path int true "connectionId"
+// @Param batch body object true "the rules under create and update, the ids under delete"
+// @Success 200  {object} api.TransformationRuleBatchOutput
+// @Failure 400  {object} api.TransformationRuleBatchOutput "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/aws/connections/{connectionId}/transformation_rules/batch [POST]
+func BatchTransformationRules(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.Batch(input)
+}
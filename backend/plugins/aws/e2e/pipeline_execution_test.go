@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/impl"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+	"github.com/apache/incubator-devlake/plugins/aws/tasks"
+)
+
+func TestAwsPipelineExecutionDataFlow(t *testing.T) {
+
+	var aws impl.Aws
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "aws", aws)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "(?i)release")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "(?i)prod")
+	taskData := &tasks.AwsTaskData{
+		Options: &tasks.AwsOptions{
+			ConnectionId: 1,
+			PipelineName: "checkout",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_aws_pipelines.csv", &models.AwsPipeline{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_aws_api_pipeline_executions.csv", "_raw_aws_api_pipeline_executions")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_aws_api_action_executions.csv", "_raw_aws_api_action_executions")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_aws_api_builds.csv", "_raw_aws_api_builds")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AwsPipelineExecution{})
+	dataflowTester.Subtask(tasks.ExtractApiPipelineExecutionsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.AwsPipelineExecution{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_aws_pipeline_executions.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.AwsActionExecution{})
+	dataflowTester.Subtask(tasks.ExtractApiActionExecutionsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.AwsActionExecution{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_aws_action_executions.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.AwsBuild{})
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.AwsBuild{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_aws_builds.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertPipelineMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+			"created_date",
+			"updated_date",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertPipelineExecutionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertActionExecutionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+			"queued_date",
+			"queued_duration_sec",
+			"runner",
+			"failed_step",
+		},
+	)
+}
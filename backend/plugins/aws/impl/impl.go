@@ -0,0 +1,254 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+	"github.com/apache/incubator-devlake/plugins/aws/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/aws/tasks"
+)
+
+var _ plugin.PluginMeta = (*Aws)(nil)
+var _ plugin.PluginInit = (*Aws)(nil)
+var _ plugin.PluginTask = (*Aws)(nil)
+var _ plugin.PluginApi = (*Aws)(nil)
+var _ plugin.PluginModel = (*Aws)(nil)
+var _ plugin.PluginMigration = (*Aws)(nil)
+var _ plugin.CloseablePluginTask = (*Aws)(nil)
+var _ plugin.PluginSource = (*Aws)(nil)
+
+type Aws string
+
+func (p Aws) Connection() interface{} {
+	return &models.AwsConnection{}
+}
+
+func (p Aws) Scope() interface{} {
+	return &models.AwsPipeline{}
+}
+
+func (p Aws) TransformationRule() interface{} {
+	return &models.AwsTransformationRule{}
+}
+
+func (p Aws) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Aws) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.AwsConnection{},
+		&models.AwsPipeline{},
+		&models.AwsTransformationRule{},
+		&models.AwsPipelineExecution{},
+		&models.AwsActionExecution{},
+		&models.AwsBuild{},
+	}
+}
+
+func (p Aws) Description() string {
+	return "To collect and enrich data from AWS CodePipeline and CodeBuild"
+}
+
+func (p Aws) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiPipelineExecutionsMeta,
+		tasks.ExtractApiPipelineExecutionsMeta,
+		tasks.CollectApiActionExecutionsMeta,
+		tasks.ExtractApiActionExecutionsMeta,
+		tasks.CollectApiBuildsMeta,
+		tasks.ExtractApiBuildsMeta,
+
+		tasks.ConvertPipelineMeta,
+		tasks.ConvertPipelineExecutionsMeta,
+		tasks.ConvertActionExecutionsMeta,
+	}
+}
+
+func (p Aws) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.AwsConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get aws connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get aws API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeAfter time.Time
+	if op.TimeAfter != "" {
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+	}
+	regexEnricher := helper.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(devops.DEPLOYMENT, op.DeploymentPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
+	}
+	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ProductionPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
+	}
+	taskData := &tasks.AwsTaskData{
+		Options:           op,
+		ApiClient:         apiClient,
+		RegexEnricher:     regexEnricher,
+		CodeBuildEndpoint: connection.GetCodeBuildEndpoint(),
+	}
+	if !timeAfter.IsZero() {
+		taskData.TimeAfter = &timeAfter
+		logger.Debug("collect data updated timeAfter %s", timeAfter)
+	}
+
+	return taskData, nil
+}
+
+func (p Aws) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/aws"
+}
+
+func (p Aws) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Aws) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Aws) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/*scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
+	}
+}
+
+func (p Aws) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.AwsTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.AwsOptions,
+	apiClient *helper.ApiClient) errors.Error {
+	var pipeline models.AwsPipeline
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&pipeline, dal.Where(
+		"connection_id = ? AND name = ?",
+		op.ConnectionId, op.PipelineName))
+	if err == nil {
+		if op.TransformationRuleId == 0 {
+			op.TransformationRuleId = pipeline.TransformationRuleId
+		}
+	} else {
+		if db.IsErrorNotFound(err) {
+			var apiPipeline *models.AwsApiPipeline
+			apiPipeline, err = tasks.GetApiPipeline(op, apiClient)
+			if err != nil {
+				return err
+			}
+			logger.Debug(fmt.Sprintf("Current pipeline: %s", op.PipelineName))
+			scope := apiPipeline.ConvertApiScope().(*models.AwsPipeline)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find pipeline %s", op.PipelineName))
+		}
+	}
+	if op.AwsTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.AwsTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.AwsTransformationRule = &transformationRule
+	}
+	if op.AwsTransformationRule == nil {
+		op.AwsTransformationRule = new(models.AwsTransformationRule)
+	}
+	return nil
+}
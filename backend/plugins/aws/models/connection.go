@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+var _ plugin.ApiConnection = (*AwsConnection)(nil)
+var _ plugin.ApiAuthenticator = (*AwsConn)(nil)
+var _ plugin.PrepareApiClient = (*AwsConn)(nil)
+
+// AwsConn holds the credentials to call the CodePipeline and CodeBuild apis of a region, the role is assumed with
+// the access keys, or the credentials of the host DevLake runs on when they are omitted, to reach another account
+type AwsConn struct {
+	Region          string `mapstructure:"region" validate:"required" json:"region" gorm:"type:varchar(50)"`
+	AccessKeyId     string `mapstructure:"accessKeyId" json:"accessKeyId" gorm:"type:varchar(255)"`
+	SecretAccessKey string `mapstructure:"secretAccessKey" json:"secretAccessKey" gorm:"serializer:encdec"`
+	SessionToken    string `mapstructure:"sessionToken" json:"sessionToken" gorm:"serializer:encdec"`
+	// RoleArn is the role to assume, i.e. arn:aws:iam::123456789012:role/devlake
+	RoleArn          string `mapstructure:"roleArn" json:"roleArn" gorm:"type:varchar(255)"`
+	ExternalId       string `mapstructure:"externalId" json:"externalId" gorm:"type:varchar(255)"`
+	Proxy            string `mapstructure:"proxy" json:"proxy"`
+	RateLimitPerHour int    `comment:"api request rate limit per hour" json:"rateLimitPerHour"`
+
+	signer *v4.Signer
+}
+
+// GetEndpoint returns the CodePipeline api of the region, the CodeBuild one is requested by its absolute url
+func (conn AwsConn) GetEndpoint() string {
+	return fmt.Sprintf("https://codepipeline.%s.amazonaws.com/", conn.Region)
+}
+
+// GetCodeBuildEndpoint returns the CodeBuild api of the region
+func (conn *AwsConn) GetCodeBuildEndpoint() string {
+	return fmt.Sprintf("https://codebuild.%s.amazonaws.com/", conn.Region)
+}
+
+func (conn AwsConn) GetProxy() string {
+	return conn.Proxy
+}
+
+func (conn AwsConn) GetRateLimitPerHour() int {
+	return conn.RateLimitPerHour
+}
+
+// PrepareApiClient resolves the credentials once so that the assumed role is cached across the requests
+func (conn *AwsConn) PrepareApiClient(apiClient apihelperabstract.ApiClientAbstract) errors.Error {
+	var creds *credentials.Credentials
+	if conn.AccessKeyId != "" {
+		creds = credentials.NewStaticCredentials(conn.AccessKeyId, conn.SecretAccessKey, conn.SessionToken)
+	}
+	if conn.RoleArn != "" {
+		sess, err := session.NewSession(&aws.Config{
+			Region:      aws.String(conn.Region),
+			Credentials: creds,
+		})
+		if err != nil {
+			return errors.BadInput.Wrap(err, "failed to create aws session")
+		}
+		creds = stscreds.NewCredentials(sess, conn.RoleArn, func(p *stscreds.AssumeRoleProvider) {
+			if conn.ExternalId != "" {
+				p.ExternalID = aws.String(conn.ExternalId)
+			}
+		})
+	}
+	if creds == nil {
+		return errors.BadInput.New("either the access keys or the role arn is required")
+	}
+	conn.signer = v4.NewSigner(creds)
+	return nil
+}
+
+// SetupAuthentication signs the request with signature version 4, the service is the one of the host
+func (conn *AwsConn) SetupAuthentication(req *http.Request) errors.Error {
+	if conn.signer == nil {
+		return errors.Default.New("the aws credentials are not prepared")
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		req.Body.Close()
+	}
+	// the apis only accept the json 1.1 protocol, the operation is sent in the X-Amz-Target header
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	service := strings.SplitN(req.URL.Hostname(), ".", 2)[0]
+	_, err := conn.signer.Sign(req, bytes.NewReader(body), service, conn.Region, time.Now())
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to sign the aws request")
+	}
+	return nil
+}
+
+// AwsConnection holds AwsConn plus ID/Name for database storage
+type AwsConnection struct {
+	helper.BaseConnection `mapstructure:",squash"`
+	AwsConn               `mapstructure:",squash"`
+}
+
+func (AwsConnection) TableName() string {
+	return "_tool_aws_connections"
+}
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// statuses of the pipeline executions and their action executions
+const (
+	STATUS_IN_PROGRESS = "InProgress"
+	STATUS_STOPPING    = "Stopping"
+	STATUS_SUCCEEDED   = "Succeeded"
+	STATUS_FAILED      = "Failed"
+	STATUS_STOPPED     = "Stopped"
+	STATUS_SUPERSEDED  = "Superseded"
+	STATUS_CANCELLED   = "Cancelled"
+	STATUS_ABANDONED   = "Abandoned"
+)
+
+// categories of the actions
+const (
+	CATEGORY_SOURCE = "Source"
+	CATEGORY_BUILD  = "Build"
+	CATEGORY_TEST   = "Test"
+	CATEGORY_DEPLOY = "Deploy"
+)
+
+// PROVIDER_CODEBUILD is the provider of the actions running a CodeBuild build
+const PROVIDER_CODEBUILD = "CodeBuild"
+
+// AwsPipelineExecution is an execution of a pipeline, the revision is the commit of its git source if any
+type AwsPipelineExecution struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ExecutionId    string `gorm:"primaryKey;type:varchar(100)"`
+	PipelineName   string `gorm:"index;type:varchar(255)"`
+	Status         string `gorm:"type:varchar(20)"`
+	TriggerType    string `gorm:"type:varchar(50)"`
+	TriggerDetail  string `gorm:"type:varchar(255)"`
+	RevisionId     string `gorm:"type:varchar(255)"`
+	RevisionUrl    string `gorm:"type:varchar(255)"`
+	RepoUrl        string `gorm:"type:varchar(255)"`
+	StartTime      *time.Time
+	LastUpdateTime *time.Time
+	common.NoPKModel
+}
+
+func (AwsPipelineExecution) TableName() string {
+	return "_tool_aws_pipeline_executions"
+}
+
+// AwsActionExecution is an execution of an action of a stage, the external execution of the CodeBuild actions is
+// the id of their build
+type AwsActionExecution struct {
+	ConnectionId         uint64 `gorm:"primaryKey"`
+	ActionExecutionId    string `gorm:"primaryKey;type:varchar(100)"`
+	PipelineExecutionId  string `gorm:"index;type:varchar(100)"`
+	PipelineName         string `gorm:"index;type:varchar(255)"`
+	StageName            string `gorm:"type:varchar(255)"`
+	ActionName           string `gorm:"type:varchar(255)"`
+	Category             string `gorm:"type:varchar(20)"`
+	Provider             string `gorm:"type:varchar(100)"`
+	Status               string `gorm:"type:varchar(20)"`
+	ExternalExecutionId  string `gorm:"type:varchar(255)"`
+	ExternalExecutionUrl string `gorm:"type:varchar(255)"`
+	Type                 string `gorm:"type:varchar(100)"`
+	Environment          string `gorm:"type:varchar(255)"`
+	StartTime            *time.Time
+	LastUpdateTime       *time.Time
+	common.NoPKModel
+}
+
+func (AwsActionExecution) TableName() string {
+	return "_tool_aws_action_executions"
+}
+
+// AwsBuild is a CodeBuild build run by an action, the runner is the compute type and image of its environment
+type AwsBuild struct {
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	BuildId           string `gorm:"primaryKey;type:varchar(255)"`
+	PipelineName      string `gorm:"index;type:varchar(255)"`
+	ProjectName       string `gorm:"type:varchar(255)"`
+	BuildNumber       int64
+	BuildStatus       string `gorm:"type:varchar(20)"`
+	SourceVersion     string `gorm:"type:varchar(255)"`
+	ComputeType       string `gorm:"type:varchar(100)"`
+	Image             string `gorm:"type:varchar(255)"`
+	FailedPhase       string `gorm:"type:varchar(100)"`
+	QueuedDurationSec *uint64
+	StartTime         *time.Time
+	EndTime           *time.Time
+	common.NoPKModel
+}
+
+func (AwsBuild) TableName() string {
+	return "_tool_aws_builds"
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type AwsPipelineExecution struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ExecutionId    string `gorm:"primaryKey;type:varchar(100)"`
+	PipelineName   string `gorm:"index;type:varchar(255)"`
+	Status         string `gorm:"type:varchar(20)"`
+	TriggerType    string `gorm:"type:varchar(50)"`
+	TriggerDetail  string `gorm:"type:varchar(255)"`
+	RevisionId     string `gorm:"type:varchar(255)"`
+	RevisionUrl    string `gorm:"type:varchar(255)"`
+	RepoUrl        string `gorm:"type:varchar(255)"`
+	StartTime      *time.Time
+	LastUpdateTime *time.Time
+	archived.NoPKModel
+}
+
+func (AwsPipelineExecution) TableName() string {
+	return "_tool_aws_pipeline_executions"
+}
+
+type AwsActionExecution struct {
+	ConnectionId         uint64 `gorm:"primaryKey"`
+	ActionExecutionId    string `gorm:"primaryKey;type:varchar(100)"`
+	PipelineExecutionId  string `gorm:"index;type:varchar(100)"`
+	PipelineName         string `gorm:"index;type:varchar(255)"`
+	StageName            string `gorm:"type:varchar(255)"`
+	ActionName           string `gorm:"type:varchar(255)"`
+	Category             string `gorm:"type:varchar(20)"`
+	Provider             string `gorm:"type:varchar(100)"`
+	Status               string `gorm:"type:varchar(20)"`
+	ExternalExecutionId  string `gorm:"type:varchar(255)"`
+	ExternalExecutionUrl string `gorm:"type:varchar(255)"`
+	Type                 string `gorm:"type:varchar(100)"`
+	Environment          string `gorm:"type:varchar(255)"`
+	StartTime            *time.Time
+	LastUpdateTime       *time.Time
+	archived.NoPKModel
+}
+
+func (AwsActionExecution) TableName() string {
+	return "_tool_aws_action_executions"
+}
+
+type AwsBuild struct {
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	BuildId           string `gorm:"primaryKey;type:varchar(255)"`
+	PipelineName      string `gorm:"index;type:varchar(255)"`
+	ProjectName       string `gorm:"type:varchar(255)"`
+	BuildNumber       int64
+	BuildStatus       string `gorm:"type:varchar(20)"`
+	SourceVersion     string `gorm:"type:varchar(255)"`
+	ComputeType       string `gorm:"type:varchar(100)"`
+	Image             string `gorm:"type:varchar(255)"`
+	FailedPhase       string `gorm:"type:varchar(100)"`
+	QueuedDurationSec *uint64
+	StartTime         *time.Time
+	EndTime           *time.Time
+	archived.NoPKModel
+}
+
+func (AwsBuild) TableName() string {
+	return "_tool_aws_builds"
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*AwsPipeline)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*AwsApiPipeline)(nil)
+
+// AwsPipeline is a CodePipeline pipeline of the region of the connection
+type AwsPipeline struct {
+	ConnectionId         uint64     `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	Name                 string     `json:"name" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"name"`
+	Version              int        `json:"version" mapstructure:"version,omitempty"`
+	Created              *time.Time `json:"created" mapstructure:"created,omitempty"`
+	Updated              *time.Time `json:"updated" mapstructure:"updated,omitempty"`
+	TransformationRuleId uint64     `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (AwsPipeline) TableName() string {
+	return "_tool_aws_pipelines"
+}
+
+func (p AwsPipeline) ScopeId() string {
+	return p.Name
+}
+
+func (p AwsPipeline) ScopeName() string {
+	return p.Name
+}
+
+// AwsApiPipeline is a pipeline summary returned by ListPipelines and GetPipeline, the times are seconds since the epoch
+type AwsApiPipeline struct {
+	Name    string   `json:"name"`
+	Version int      `json:"version"`
+	Created *float64 `json:"created"`
+	Updated *float64 `json:"updated"`
+}
+
+func (p AwsApiPipeline) ConvertApiScope() plugin.ToolLayerScope {
+	return &AwsPipeline{
+		Name:    p.Name,
+		Version: p.Version,
+		Created: EpochSeconds(p.Created),
+		Updated: EpochSeconds(p.Updated),
+	}
+}
+
+// EpochSeconds converts the fractional seconds since the epoch the apis return
+func EpochSeconds(seconds *float64) *time.Time {
+	if seconds == nil || *seconds == 0 {
+		return nil
+	}
+	t := time.UnixMilli(int64(math.Round(*seconds * 1000))).UTC()
+	return &t
+}
+
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_ACTION_EXECUTION_TABLE = "aws_api_action_executions"
+
+var CollectApiActionExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiActionExecutions",
+	EntryPoint:       CollectApiActionExecutions,
+	EnabledByDefault: true,
+	Description:      "Collect the action executions of the pipeline from CodePipeline api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiActionExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ACTION_EXECUTION_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	incremental := collectorWithState.IsIncremental()
+	since := collectorWithState.TimeAfter
+	if incremental {
+		since = collectorWithState.LatestState.LatestSuccessStart
+	}
+	// the action executions of all the executions of the pipeline are listed from the most recent one
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        incremental,
+		Method:             http.MethodPost,
+		Header:             targetHeader("CodePipeline_20150709.ListActionExecutions"),
+		RequestBody: func(reqData *api.RequestData) map[string]interface{} {
+			body := map[string]interface{}{
+				"pipelineName": data.Options.PipelineName,
+				"maxResults":   reqData.Pager.Size,
+			}
+			if reqData.CustomData != nil {
+				body["nextToken"] = reqData.CustomData
+			}
+			return body
+		},
+		GetNextPageCustomData: getNextToken,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			items, err := unmarshalList(res, "actionExecutionDetails")
+			if err != nil || len(items) == 0 {
+				return items, err
+			}
+			return items, finishWhenUpdatedBefore(items[len(items)-1], since)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+var ConvertActionExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "convertActionExecutions",
+	EntryPoint:       ConvertActionExecutions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table aws_action_executions and aws_builds into domain layer table cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertActionExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ACTION_EXECUTION_TABLE)
+	db := taskCtx.GetDal()
+
+	// the builds run by the CodeBuild actions tell how long they were queued, on which host and which phase failed
+	var builds []models.AwsBuild
+	err := db.All(
+		&builds,
+		dal.Where("connection_id = ? AND pipeline_name = ?", data.Options.ConnectionId, data.Options.PipelineName),
+	)
+	if err != nil {
+		return err
+	}
+	buildsById := make(map[string]*models.AwsBuild, len(builds))
+	for i := range builds {
+		buildsById[builds[i].BuildId] = &builds[i]
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AwsActionExecution{}),
+		dal.Where("connection_id = ? AND pipeline_name = ?", data.Options.ConnectionId, data.Options.PipelineName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	actionIdGen := didgen.NewDomainIdGenerator(&models.AwsActionExecution{})
+	executionIdGen := didgen.NewDomainIdGenerator(&models.AwsPipelineExecution{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.AwsPipeline{})
+	scopeId := pipelineIdGen.Generate(data.Options.ConnectionId, data.Options.PipelineName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AwsActionExecution{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			action := inputRow.(*models.AwsActionExecution)
+			if action.StartTime == nil {
+				return nil, nil
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: actionIdGen.Generate(action.ConnectionId, action.ActionExecutionId)},
+				Name:         action.StageName + "/" + action.ActionName,
+				PipelineId:   executionIdGen.Generate(action.ConnectionId, action.PipelineExecutionId),
+				Result:       devops.GetResult(executionResultRule, action.Status),
+				Status:       devops.GetStatus(executionStatusRule, action.Status),
+				Type:         action.Type,
+				Environment:  action.Environment,
+				StartedDate:  *action.StartTime,
+				CicdScopeId:  scopeId,
+			}
+			if domainTask.Status == devops.DONE && action.LastUpdateTime != nil {
+				domainTask.FinishedDate = action.LastUpdateTime
+				domainTask.DurationSec = uint64(action.LastUpdateTime.Sub(*action.StartTime).Seconds())
+			}
+			if build, ok := buildsById[action.ExternalExecutionId]; ok {
+				domainTask.QueuedDate = build.StartTime
+				domainTask.QueuedDurationSec = build.QueuedDurationSec
+				domainTask.Runner = strings.TrimSpace(build.ComputeType + " " + build.Image)
+				domainTask.FailedStep = build.FailedPhase
+			}
+			return []interface{}{domainTask}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
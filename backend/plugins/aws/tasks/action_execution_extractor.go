@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+var ExtractApiActionExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiActionExecutions",
+	EntryPoint:       ExtractApiActionExecutions,
+	EnabledByDefault: true,
+	Description:      "Extract raw action executions data into tool layer table _tool_aws_action_executions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type AwsApiActionExecution struct {
+	PipelineExecutionId string   `json:"pipelineExecutionId"`
+	ActionExecutionId   string   `json:"actionExecutionId"`
+	StageName           string   `json:"stageName"`
+	ActionName          string   `json:"actionName"`
+	Status              string   `json:"status"`
+	StartTime           *float64 `json:"startTime"`
+	LastUpdateTime      *float64 `json:"lastUpdateTime"`
+	Input               struct {
+		ActionTypeId struct {
+			Category string `json:"category"`
+			Provider string `json:"provider"`
+		} `json:"actionTypeId"`
+	} `json:"input"`
+	Output struct {
+		ExecutionResult struct {
+			ExternalExecutionId  string `json:"externalExecutionId"`
+			ExternalExecutionUrl string `json:"externalExecutionUrl"`
+		} `json:"executionResult"`
+	} `json:"output"`
+}
+
+func ExtractApiActionExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ACTION_EXECUTION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiAction := &AwsApiActionExecution{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiAction))
+			if err != nil {
+				return nil, err
+			}
+			action := &models.AwsActionExecution{
+				ConnectionId:         data.Options.ConnectionId,
+				ActionExecutionId:    apiAction.ActionExecutionId,
+				PipelineExecutionId:  apiAction.PipelineExecutionId,
+				PipelineName:         data.Options.PipelineName,
+				StageName:            apiAction.StageName,
+				ActionName:           apiAction.ActionName,
+				Category:             apiAction.Input.ActionTypeId.Category,
+				Provider:             apiAction.Input.ActionTypeId.Provider,
+				Status:               apiAction.Status,
+				ExternalExecutionId:  apiAction.Output.ExecutionResult.ExternalExecutionId,
+				ExternalExecutionUrl: apiAction.Output.ExecutionResult.ExternalExecutionUrl,
+				StartTime:            models.EpochSeconds(apiAction.StartTime),
+				LastUpdateTime:       models.EpochSeconds(apiAction.LastUpdateTime),
+			}
+			if data.TimeAfter != nil && action.StartTime != nil && action.StartTime.Before(*data.TimeAfter) {
+				return nil, nil
+			}
+			// the actions of the Deploy category always deploy, the others are picked by their name
+			if action.Category == models.CATEGORY_DEPLOY {
+				action.Type = devops.DEPLOYMENT
+			} else {
+				action.Type = data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, action.ActionName)
+			}
+			if action.Type == devops.DEPLOYMENT {
+				action.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, action.StageName)
+			}
+			return []interface{}{action}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
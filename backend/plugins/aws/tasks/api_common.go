@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type AwsApiParams struct {
+	ConnectionId uint64
+	PipelineName string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *AwsTaskData) {
+	data := taskCtx.GetData().(*AwsTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: AwsApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			PipelineName: data.Options.PipelineName,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// targetHeader names the operation of the json 1.1 protocol the request calls, i.e.
+// CodePipeline_20150709.ListPipelineExecutions
+func targetHeader(target string) func(reqData *api.RequestData) (http.Header, errors.Error) {
+	return func(reqData *api.RequestData) (http.Header, errors.Error) {
+		return http.Header{"X-Amz-Target": {target}}, nil
+	}
+}
+
+// getNextToken pages the list operations by the token of the previous response, they are done when it is omitted
+func getNextToken(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	body := &struct {
+		NextToken string `json:"nextToken"`
+	}{}
+	err := api.UnmarshalResponse(prevPageResponse, body)
+	if err != nil {
+		return nil, err
+	}
+	if body.NextToken == "" {
+		return nil, api.ErrFinishCollect
+	}
+	return body.NextToken, nil
+}
+
+// unmarshalList returns the items of a list response under the given key
+func unmarshalList(res *http.Response, key string) ([]json.RawMessage, errors.Error) {
+	body := map[string]json.RawMessage{}
+	err := api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	var items []json.RawMessage
+	if body[key] == nil {
+		return items, nil
+	}
+	return items, errors.Convert(json.Unmarshal(body[key], &items))
+}
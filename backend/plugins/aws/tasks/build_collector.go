@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+const RAW_BUILD_TABLE = "aws_api_builds"
+
+var CollectApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBuilds",
+	EntryPoint:       CollectApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Collect the builds run by the CodeBuild actions from CodeBuild api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type BuildInput struct {
+	ExternalExecutionId string
+}
+
+func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	db := taskCtx.GetDal()
+	clauses := []dal.Clause{
+		dal.Select("external_execution_id"),
+		dal.From(&models.AwsActionExecution{}),
+		dal.Where(
+			"connection_id = ? AND pipeline_name = ? AND provider = ? AND external_execution_id != ''",
+			data.Options.ConnectionId, data.Options.PipelineName, models.PROVIDER_CODEBUILD,
+		),
+	}
+	if collectorWithState.IsIncremental() {
+		clauses = append(clauses, dal.Where("last_update_time > ?", *collectorWithState.LatestState.LatestSuccessStart))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(BuildInput{}))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		Incremental:        collectorWithState.IsIncremental(),
+		// the builds are requested from the CodeBuild api of the region rather than the endpoint of the connection
+		UrlTemplate: data.CodeBuildEndpoint,
+		Method:      http.MethodPost,
+		Header:      targetHeader("CodeBuild_20161006.BatchGetBuilds"),
+		RequestBody: func(reqData *api.RequestData) map[string]interface{} {
+			input := reqData.Input.(*BuildInput)
+			return map[string]interface{}{
+				"ids": []string{input.ExternalExecutionId},
+			}
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			return unmarshalList(res, "builds")
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+var ExtractApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBuilds",
+	EntryPoint:       ExtractApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Extract raw builds data into tool layer table _tool_aws_builds",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// the statuses of the phases which failed the build
+var failedPhaseStatuses = map[string]bool{
+	"FAILED":    true,
+	"FAULT":     true,
+	"TIMED_OUT": true,
+}
+
+type AwsApiBuildPhase struct {
+	PhaseType         string  `json:"phaseType"`
+	PhaseStatus       string  `json:"phaseStatus"`
+	DurationInSeconds *uint64 `json:"durationInSeconds"`
+}
+
+type AwsApiBuild struct {
+	Id                    string             `json:"id"`
+	BuildNumber           int64              `json:"buildNumber"`
+	ProjectName           string             `json:"projectName"`
+	BuildStatus           string             `json:"buildStatus"`
+	ResolvedSourceVersion string             `json:"resolvedSourceVersion"`
+	StartTime             *float64           `json:"startTime"`
+	EndTime               *float64           `json:"endTime"`
+	Phases                []AwsApiBuildPhase `json:"phases"`
+	Environment           struct {
+		ComputeType string `json:"computeType"`
+		Image       string `json:"image"`
+	} `json:"environment"`
+}
+
+func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiBuild := &AwsApiBuild{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiBuild))
+			if err != nil {
+				return nil, err
+			}
+			build := &models.AwsBuild{
+				ConnectionId:  data.Options.ConnectionId,
+				BuildId:       apiBuild.Id,
+				PipelineName:  data.Options.PipelineName,
+				ProjectName:   apiBuild.ProjectName,
+				BuildNumber:   apiBuild.BuildNumber,
+				BuildStatus:   apiBuild.BuildStatus,
+				SourceVersion: apiBuild.ResolvedSourceVersion,
+				ComputeType:   apiBuild.Environment.ComputeType,
+				Image:         apiBuild.Environment.Image,
+				StartTime:     models.EpochSeconds(apiBuild.StartTime),
+				EndTime:       models.EpochSeconds(apiBuild.EndTime),
+			}
+			for _, phase := range apiBuild.Phases {
+				// the build waits for a host in the QUEUED phase
+				if phase.PhaseType == "QUEUED" {
+					build.QueuedDurationSec = phase.DurationInSeconds
+				}
+				if build.FailedPhase == "" && failedPhaseStatuses[phase.PhaseStatus] {
+					build.FailedPhase = phase.PhaseType
+				}
+			}
+			return []interface{}{build}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+var ConvertPipelineMeta = plugin.SubTaskMeta{
+	Name:             "convertPipeline",
+	EntryPoint:       ConvertPipeline,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table aws_pipelines into domain layer table cicd_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// GetApiPipeline fetches a pipeline by its name
+func GetApiPipeline(op *AwsOptions, apiClient aha.ApiClientAbstract) (*models.AwsApiPipeline, errors.Error) {
+	res, err := apiClient.Post("", nil, map[string]interface{}{"name": op.PipelineName}, http.Header{
+		"X-Amz-Target": {"CodePipeline_20150709.GetPipeline"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting pipeline detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	body := &struct {
+		Pipeline struct {
+			Name    string `json:"name"`
+			Version int    `json:"version"`
+		} `json:"pipeline"`
+		Metadata struct {
+			Created *float64 `json:"created"`
+			Updated *float64 `json:"updated"`
+		} `json:"metadata"`
+	}{}
+	err = api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AwsApiPipeline{
+		Name:    body.Pipeline.Name,
+		Version: body.Pipeline.Version,
+		Created: body.Metadata.Created,
+		Updated: body.Metadata.Updated,
+	}, nil
+}
+
+func ConvertPipeline(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_EXECUTION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AwsPipeline{}),
+		dal.Where("connection_id = ? AND name = ?", data.Options.ConnectionId, data.Options.PipelineName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.AwsPipeline{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AwsPipeline{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pipeline := inputRow.(*models.AwsPipeline)
+			return []interface{}{
+				&devops.CicdScope{
+					DomainEntity: domainlayer.DomainEntity{Id: pipelineIdGen.Generate(pipeline.ConnectionId, pipeline.Name)},
+					Name:         pipeline.Name,
+					CreatedDate:  pipeline.Created,
+					UpdatedDate:  pipeline.Updated,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
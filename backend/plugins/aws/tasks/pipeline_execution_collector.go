@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+const RAW_PIPELINE_EXECUTION_TABLE = "aws_api_pipeline_executions"
+
+var CollectApiPipelineExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiPipelineExecutions",
+	EntryPoint:       CollectApiPipelineExecutions,
+	EnabledByDefault: true,
+	Description:      "Collect the executions of the pipeline from CodePipeline api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiPipelineExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_EXECUTION_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	incremental := collectorWithState.IsIncremental()
+	since := collectorWithState.TimeAfter
+	if incremental {
+		since = collectorWithState.LatestState.LatestSuccessStart
+	}
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        incremental,
+		Method:             http.MethodPost,
+		Header:             targetHeader("CodePipeline_20150709.ListPipelineExecutions"),
+		RequestBody: func(reqData *api.RequestData) map[string]interface{} {
+			body := map[string]interface{}{
+				"pipelineName": data.Options.PipelineName,
+				"maxResults":   reqData.Pager.Size,
+			}
+			if reqData.CustomData != nil {
+				body["nextToken"] = reqData.CustomData
+			}
+			return body
+		},
+		GetNextPageCustomData: getNextToken,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			items, err := unmarshalList(res, "pipelineExecutionSummaries")
+			if err != nil || len(items) == 0 {
+				return items, err
+			}
+			return items, finishWhenUpdatedBefore(items[len(items)-1], since)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
+
+// finishWhenUpdatedBefore stops the collection once the oldest execution on the page was last updated before since,
+// the executions are listed from the most recent one
+func finishWhenUpdatedBefore(oldest json.RawMessage, since *time.Time) errors.Error {
+	if since == nil {
+		return nil
+	}
+	execution := &struct {
+		LastUpdateTime *float64 `json:"lastUpdateTime"`
+	}{}
+	err := errors.Convert(json.Unmarshal(oldest, execution))
+	if err != nil {
+		return err
+	}
+	lastUpdateTime := models.EpochSeconds(execution.LastUpdateTime)
+	if lastUpdateTime != nil && lastUpdateTime.Before(*since) {
+		return api.ErrFinishCollect
+	}
+	return nil
+}
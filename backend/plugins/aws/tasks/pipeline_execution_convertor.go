@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+var ConvertPipelineExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "convertPipelineExecutions",
+	EntryPoint:       ConvertPipelineExecutions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table aws_pipeline_executions into domain layer table cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+var executionResultRule = &devops.ResultRule{
+	Success: []string{models.STATUS_SUCCEEDED},
+	Failed:  []string{models.STATUS_FAILED},
+	Abort:   []string{models.STATUS_STOPPED, models.STATUS_SUPERSEDED, models.STATUS_CANCELLED, models.STATUS_ABANDONED},
+	Default: "",
+}
+
+var executionStatusRule = &devops.StatusRule{
+	InProgress: []string{models.STATUS_IN_PROGRESS, models.STATUS_STOPPING},
+	Default:    devops.DONE,
+}
+
+func ConvertPipelineExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_EXECUTION_TABLE)
+	db := taskCtx.GetDal()
+
+	// the execution deploys as long as one of its actions does, and to production as long as one of them does
+	var deployActions []models.AwsActionExecution
+	err := db.All(
+		&deployActions,
+		dal.Where("connection_id = ? AND pipeline_name = ? AND type = ?",
+			data.Options.ConnectionId, data.Options.PipelineName, devops.DEPLOYMENT),
+	)
+	if err != nil {
+		return err
+	}
+	environments := make(map[string]string, len(deployActions))
+	for _, action := range deployActions {
+		if environments[action.PipelineExecutionId] != devops.PRODUCTION {
+			environments[action.PipelineExecutionId] = action.Environment
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AwsPipelineExecution{}),
+		dal.Where("connection_id = ? AND pipeline_name = ?", data.Options.ConnectionId, data.Options.PipelineName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	executionIdGen := didgen.NewDomainIdGenerator(&models.AwsPipelineExecution{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.AwsPipeline{})
+	scopeId := pipelineIdGen.Generate(data.Options.ConnectionId, data.Options.PipelineName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AwsPipelineExecution{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			execution := inputRow.(*models.AwsPipelineExecution)
+			if execution.StartTime == nil {
+				return nil, nil
+			}
+			id := executionIdGen.Generate(execution.ConnectionId, execution.ExecutionId)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         execution.PipelineName,
+				Result:       devops.GetResult(executionResultRule, execution.Status),
+				Status:       devops.GetStatus(executionStatusRule, execution.Status),
+				CreatedDate:  *execution.StartTime,
+				CicdScopeId:  scopeId,
+			}
+			if environment, ok := environments[execution.ExecutionId]; ok {
+				domainPipeline.Type = devops.DEPLOYMENT
+				domainPipeline.Environment = environment
+			}
+			if domainPipeline.Status == devops.DONE && execution.LastUpdateTime != nil {
+				domainPipeline.FinishedDate = execution.LastUpdateTime
+				domainPipeline.DurationSec = uint64(execution.LastUpdateTime.Sub(*execution.StartTime).Seconds())
+			}
+			results := []interface{}{domainPipeline}
+			if execution.RevisionId != "" {
+				results = append(results, &devops.CiCDPipelineCommit{
+					PipelineId: id,
+					CommitSha:  execution.RevisionId,
+					RepoUrl:    execution.RepoUrl,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/aws/models"
+)
+
+var ExtractApiPipelineExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPipelineExecutions",
+	EntryPoint:       ExtractApiPipelineExecutions,
+	EnabledByDefault: true,
+	Description:      "Extract raw pipeline executions data into tool layer table _tool_aws_pipeline_executions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// gitHosts are the hosts of the providers of the CodeStar connections, the repositories of the others are unknown
+var gitHosts = map[string]string{
+	"GitHub":    "https://github.com",
+	"GitLab":    "https://gitlab.com",
+	"Bitbucket": "https://bitbucket.org",
+}
+
+type AwsApiSourceRevision struct {
+	ActionName      string `json:"actionName"`
+	RevisionId      string `json:"revisionId"`
+	RevisionSummary string `json:"revisionSummary"`
+	RevisionUrl     string `json:"revisionUrl"`
+}
+
+type AwsApiPipelineExecution struct {
+	PipelineExecutionId string                 `json:"pipelineExecutionId"`
+	Status              string                 `json:"status"`
+	StartTime           *float64               `json:"startTime"`
+	LastUpdateTime      *float64               `json:"lastUpdateTime"`
+	SourceRevisions     []AwsApiSourceRevision `json:"sourceRevisions"`
+	Trigger             struct {
+		TriggerType   string `json:"triggerType"`
+		TriggerDetail string `json:"triggerDetail"`
+	} `json:"trigger"`
+}
+
+func ExtractApiPipelineExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_EXECUTION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiExecution := &AwsApiPipelineExecution{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiExecution))
+			if err != nil {
+				return nil, err
+			}
+			execution := &models.AwsPipelineExecution{
+				ConnectionId:   data.Options.ConnectionId,
+				ExecutionId:    apiExecution.PipelineExecutionId,
+				PipelineName:   data.Options.PipelineName,
+				Status:         apiExecution.Status,
+				TriggerType:    apiExecution.Trigger.TriggerType,
+				TriggerDetail:  apiExecution.Trigger.TriggerDetail,
+				StartTime:      models.EpochSeconds(apiExecution.StartTime),
+				LastUpdateTime: models.EpochSeconds(apiExecution.LastUpdateTime),
+			}
+			// the executions started before timeAfter are skipped
+			if data.TimeAfter != nil && execution.StartTime != nil && execution.StartTime.Before(*data.TimeAfter) {
+				return nil, nil
+			}
+			// the commit is the one of the first git source, the revisions of the other sources are not commits
+			for i := range apiExecution.SourceRevisions {
+				revision := &apiExecution.SourceRevisions[i]
+				if repoUrl := getRepoUrl(revision); repoUrl != "" {
+					execution.RevisionId = revision.RevisionId
+					execution.RevisionUrl = revision.RevisionUrl
+					execution.RepoUrl = repoUrl
+					break
+				}
+			}
+			return []interface{}{execution}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// getRepoUrl returns the repository of a git source revision, the CodeStar connections name it in the
+// FullRepositoryId parameter of their redirect url and their provider in the summary of the revision
+func getRepoUrl(revision *AwsApiSourceRevision) string {
+	u, err := url.Parse(revision.RevisionUrl)
+	if err != nil || revision.RevisionUrl == "" {
+		return ""
+	}
+	if fullRepositoryId := u.Query().Get("FullRepositoryId"); fullRepositoryId != "" {
+		summary := &struct {
+			ProviderType string `json:"ProviderType"`
+		}{}
+		if json.Unmarshal([]byte(revision.RevisionSummary), summary) != nil {
+			return ""
+		}
+		if host, ok := gitHosts[summary.ProviderType]; ok {
+			return fmt.Sprintf("%s/%s", host, fullRepositoryId)
+		}
+		return ""
+	}
+	// the CodeCommit sources link to the commit in the console
+	if strings.Contains(u.Path, "/codecommit/repositories/") {
+		repo := strings.SplitN(strings.SplitN(u.Path, "/codecommit/repositories/", 2)[1], "/", 2)[0]
+		region := u.Query().Get("region")
+		if region == "" {
+			region = strings.SplitN(u.Hostname(), ".", 2)[0]
+		}
+		return fmt.Sprintf("https://git-codecommit.%s.amazonaws.com/v1/repos/%s", region, repo)
+	}
+	// the GitHub version 1 sources link to the commit itself
+	for _, host := range gitHosts {
+		if strings.HasPrefix(revision.RevisionUrl, host+"/") && strings.Contains(u.Path, "/commit/") {
+			return host + strings.SplitN(u.Path, "/commit/", 2)[0]
+		}
+	}
+	return ""
+}
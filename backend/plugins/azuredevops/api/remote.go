@@ -0,0 +1,228 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/tasks"
+)
+
+// the organizations of a user are only listed by the profile service, which lives outside of dev.azure.com
+const (
+	PROFILE_URL  = "https://app.vssps.visualstudio.com/_apis/profile/profiles/me"
+	ACCOUNTS_URL = "https://app.vssps.visualstudio.com/_apis/accounts"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the groups are organizations and their projects
+// @Tags plugins/azuredevops
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/azuredevops/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.AzuredevopsConnection) ([]models.GroupResponse, errors.Error) {
+			// the projects hold the repos, they have no groups under them
+			if strings.Contains(gid, "/") {
+				return nil, nil
+			}
+			apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &connection)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+			}
+			if gid == "" {
+				organizations, err := listOrganizations(apiClient, &connection)
+				if err != nil {
+					return nil, err
+				}
+				groups := make([]models.GroupResponse, 0, len(organizations))
+				for _, organization := range pageOf(organizations, queryData) {
+					groups = append(groups, models.GroupResponse{Id: organization, Name: organization})
+				}
+				return groups, nil
+			}
+			query := url.Values{}
+			query.Set("api-version", tasks.API_VERSION)
+			query.Set("$top", fmt.Sprintf("%v", queryData.PerPage))
+			query.Set("$skip", fmt.Sprintf("%v", (queryData.Page-1)*queryData.PerPage))
+			res, err := apiClient.Get(fmt.Sprintf("%s/_apis/projects", gid), query, nil)
+			if err != nil {
+				return nil, err
+			}
+			resBody := &models.ProjectsResponse{}
+			err = api.UnmarshalResponse(res, resBody)
+			if err != nil {
+				return nil, err
+			}
+			groups := make([]models.GroupResponse, 0, len(resBody.Value))
+			for _, project := range resBody.Value {
+				groups = append(groups, models.GroupResponse{
+					Id:   fmt.Sprintf("%s/%s", gid, project.Id),
+					Name: project.Name,
+				})
+			}
+			return groups, nil
+		},
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.AzuredevopsConnection) ([]models.AzuredevopsApiRepo, errors.Error) {
+			if !strings.Contains(gid, "/") {
+				return nil, nil
+			}
+			apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &connection)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+			}
+			organization := strings.Split(gid, "/")[0]
+			// repos are not paged by the api, the whole list of the project is sliced instead
+			repos, err := listRepos(apiClient, gid, organization)
+			if err != nil {
+				return nil, err
+			}
+			return pageOf(repos, queryData), nil
+		},
+	)
+}
+
+// SearchRemoteScopes use the Search API and only return project
+// @Summary use the Search API and only return project
+// @Description use the Search API and only return project
+// @Tags plugins/azuredevops
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/azuredevops/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.AzuredevopsConnection) ([]models.AzuredevopsApiRepo, errors.Error) {
+			apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &connection)
+			if err != nil {
+				return nil, err
+			}
+			organizations, err := listOrganizations(apiClient, &connection)
+			if err != nil {
+				return nil, err
+			}
+			// there is no search api for repos, the repos of every organization are matched by name
+			s := strings.ToLower(queryData.Search[0])
+			var matched []models.AzuredevopsApiRepo
+			for _, organization := range organizations {
+				repos, err := listRepos(apiClient, organization, organization)
+				if err != nil {
+					return nil, err
+				}
+				for _, repo := range repos {
+					if strings.Contains(strings.ToLower(repo.Name), s) {
+						matched = append(matched, repo)
+					}
+				}
+			}
+			return pageOf(matched, queryData), nil
+		},
+	)
+}
+
+// listOrganizations returns the organization of the connection, or all the organizations
+// the owner of the token is a member of when the connection is not limited to one
+func listOrganizations(apiClient *api.ApiClient, connection *models.AzuredevopsConnection) ([]string, errors.Error) {
+	if connection.Organization != "" {
+		return []string{connection.Organization}, nil
+	}
+	query := url.Values{}
+	query.Set("api-version", tasks.API_VERSION)
+	res, err := apiClient.Get(PROFILE_URL, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	profile := &struct {
+		Id string `json:"id"`
+	}{}
+	err = api.UnmarshalResponse(res, profile)
+	if err != nil {
+		return nil, err
+	}
+	query.Set("memberId", profile.Id)
+	res, err = apiClient.Get(ACCOUNTS_URL, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	resBody := &models.AccountsResponse{}
+	err = api.UnmarshalResponse(res, resBody)
+	if err != nil {
+		return nil, err
+	}
+	organizations := make([]string, 0, len(resBody.Value))
+	for _, account := range resBody.Value {
+		organizations = append(organizations, account.AccountName)
+	}
+	return organizations, nil
+}
+
+// listRepos returns the enabled repos under the path, which is either an organization or a project
+func listRepos(apiClient *api.ApiClient, path string, organization string) ([]models.AzuredevopsApiRepo, errors.Error) {
+	query := url.Values{}
+	query.Set("api-version", tasks.API_VERSION)
+	res, err := apiClient.Get(fmt.Sprintf("%s/_apis/git/repositories", path), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	resBody := &models.ReposResponse{}
+	err = api.UnmarshalResponse(res, resBody)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]models.AzuredevopsApiRepo, 0, len(resBody.Value))
+	for _, repo := range resBody.Value {
+		if repo.IsDisabled {
+			continue
+		}
+		repo.OrganizationId = organization
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+func pageOf[T any](items []T, queryData *api.RemoteQueryData) []T {
+	start := (queryData.Page - 1) * queryData.PerPage
+	if start < 0 || start >= len(items) {
+		return nil
+	}
+	end := start + queryData.PerPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
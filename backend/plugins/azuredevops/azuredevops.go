@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main // must be main for plugin entry point
+
+import (
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/impl"
+	"github.com/spf13/cobra"
+)
+
+// PluginEntry exports for Framework to search and load
+var PluginEntry impl.Azuredevops //nolint
+
+// standalone mode for debugging
+func main() {
+	cmd := &cobra.Command{Use: "azuredevops"}
+	connectionId := cmd.Flags().Uint64P("connectionId", "c", 0, "azuredevops connection id")
+	repositoryId := cmd.Flags().StringP("repositoryId", "r", "", "azuredevops repository id")
+	organizationId := cmd.Flags().StringP("organizationId", "o", "", "azuredevops organization, required when the repo is not a scope yet")
+	projectId := cmd.Flags().StringP("projectId", "p", "", "azuredevops project, required when the repo is not a scope yet")
+	timeAfter := cmd.Flags().StringP("timeAfter", "a", "", "collect data that are created after specified time, ie 2006-05-06T07:08:09Z")
+	deploymentPattern := cmd.Flags().StringP("deployment", "", "", "deployment pattern")
+	productionPattern := cmd.Flags().StringP("production", "", "", "production pattern")
+	issueTypeRequirement := cmd.Flags().StringP("requirement", "", "", "work item type pattern of requirements")
+	issueTypeBug := cmd.Flags().StringP("bug", "", "", "work item type pattern of bugs")
+	issueTypeIncident := cmd.Flags().StringP("incident", "", "", "work item type pattern of incidents")
+	_ = cmd.MarkFlagRequired("connectionId")
+	_ = cmd.MarkFlagRequired("repositoryId")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
+			"connectionId":   *connectionId,
+			"repositoryId":   *repositoryId,
+			"organizationId": *organizationId,
+			"projectId":      *projectId,
+			"timeAfter":      *timeAfter,
+			"transformationRules": map[string]string{
+				"deploymentPattern":    *deploymentPattern,
+				"productionPattern":    *productionPattern,
+				"issueTypeRequirement": *issueTypeRequirement,
+				"issueTypeBug":         *issueTypeBug,
+				"issueTypeIncident":    *issueTypeIncident,
+			},
+		})
+	}
+	runner.RunCmd(cmd)
+}
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/impl"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/tasks"
+)
+
+func TestAzuredevopsBuildDataFlow(t *testing.T) {
+
+	var azuredevops impl.Azuredevops
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azuredevops", azuredevops)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "deploy")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "production")
+	taskData := &tasks.AzuredevopsTaskData{
+		Options: &tasks.AzuredevopsOptions{
+			ConnectionId:   1,
+			OrganizationId: "johndoe",
+			ProjectId:      "7a3fd40e-2aed-4fac-bac9-511bf1a70206",
+			RepositoryId:   "0d50ba13-f9ad-49b0-9b21-d29eda50ca33",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azuredevops_api_builds.csv", "_raw_azuredevops_api_builds")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzuredevopsBuild{})
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzuredevopsBuild{},
+		"./snapshot_tables/_tool_azuredevops_builds.csv",
+		[]string{
+			"connection_id",
+			"repository_id",
+			"azuredevops_id",
+			"build_number",
+			"definition_id",
+			"definition_name",
+			"status",
+			"result",
+			"source_branch",
+			"source_version",
+			"url",
+			"type",
+			"environment",
+			"queue_time",
+			"start_time",
+			"finish_time",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_azuredevops_repos.csv", &models.AzuredevopsRepo{})
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertBuildsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		},
+	)
+}
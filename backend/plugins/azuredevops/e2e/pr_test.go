@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/impl"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/tasks"
+)
+
+func TestAzuredevopsPullRequestDataFlow(t *testing.T) {
+
+	var azuredevops impl.Azuredevops
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azuredevops", azuredevops)
+
+	taskData := &tasks.AzuredevopsTaskData{
+		Options: &tasks.AzuredevopsOptions{
+			ConnectionId:   1,
+			OrganizationId: "johndoe",
+			ProjectId:      "7a3fd40e-2aed-4fac-bac9-511bf1a70206",
+			RepositoryId:   "0d50ba13-f9ad-49b0-9b21-d29eda50ca33",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azuredevops_api_pull_requests.csv", "_raw_azuredevops_api_pull_requests")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzuredevopsPullRequest{})
+	dataflowTester.FlushTabler(&models.AzuredevopsAccount{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzuredevopsPullRequest{},
+		"./snapshot_tables/_tool_azuredevops_pull_requests.csv",
+		[]string{
+			"connection_id",
+			"repository_id",
+			"azuredevops_id",
+			"fork_repo_id",
+			"code_review_id",
+			"status",
+			"title",
+			"description",
+			"url",
+			"is_draft",
+			"merge_status",
+			"created_by_id",
+			"created_by_name",
+			"source_ref_name",
+			"target_ref_name",
+			"source_commit_sha",
+			"target_commit_sha",
+			"merge_commit_sha",
+			"creation_date",
+			"closed_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+	dataflowTester.VerifyTable(
+		models.AzuredevopsAccount{},
+		"./snapshot_tables/_tool_azuredevops_accounts.csv",
+		[]string{
+			"connection_id",
+			"account_id",
+			"display_name",
+			"unique_name",
+			"avatar_url",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_azuredevops_repos.csv", &models.AzuredevopsRepo{})
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequest{},
+		"./snapshot_tables/pull_requests.csv",
+		[]string{
+			"id",
+			"base_repo_id",
+			"head_repo_id",
+			"status",
+			"original_status",
+			"title",
+			"description",
+			"url",
+			"author_name",
+			"author_id",
+			"pull_request_key",
+			"created_date",
+			"merged_date",
+			"closed_date",
+			"merge_commit_sha",
+			"base_ref",
+			"base_commit_sha",
+			"head_ref",
+			"head_commit_sha",
+		},
+	)
+}
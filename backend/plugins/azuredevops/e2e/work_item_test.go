@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/impl"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/tasks"
+)
+
+func TestAzuredevopsWorkItemDataFlow(t *testing.T) {
+
+	var azuredevops impl.Azuredevops
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azuredevops", azuredevops)
+
+	taskData := &tasks.AzuredevopsTaskData{
+		Options: &tasks.AzuredevopsOptions{
+			ConnectionId:   1,
+			OrganizationId: "johndoe",
+			ProjectId:      "7a3fd40e-2aed-4fac-bac9-511bf1a70206",
+			RepositoryId:   "0d50ba13-f9ad-49b0-9b21-d29eda50ca33",
+			AzuredevopsTransformationRule: &models.AzuredevopsTransformationRule{
+				IssueTypeIncident: "Outage",
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azuredevops_api_work_items.csv", "_raw_azuredevops_api_work_items")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzuredevopsWorkItem{})
+	dataflowTester.FlushTabler(&models.AzuredevopsAccount{})
+	dataflowTester.Subtask(tasks.ExtractApiWorkItemsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzuredevopsWorkItem{},
+		"./snapshot_tables/_tool_azuredevops_work_items.csv",
+		[]string{
+			"connection_id",
+			"repository_id",
+			"azuredevops_id",
+			"project_id",
+			"rev",
+			"title",
+			"description",
+			"work_item_type",
+			"state",
+			"std_type",
+			"std_state",
+			"priority",
+			"severity",
+			"story_point",
+			"area_path",
+			"iteration_path",
+			"parent_id",
+			"creator_id",
+			"creator_name",
+			"assignee_id",
+			"assignee_name",
+			"url",
+			"created_date",
+			"changed_date",
+			"resolved_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_azuredevops_repos.csv", &models.AzuredevopsRepo{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_azuredevops_iterations.csv", &models.AzuredevopsIteration{})
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.SprintIssue{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"story_point",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"parent_issue_id",
+			"priority",
+			"creator_id",
+			"creator_name",
+			"assignee_id",
+			"assignee_name",
+			"severity",
+			"component",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		[]string{
+			"board_id",
+			"issue_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.SprintIssue{},
+		"./snapshot_tables/sprint_issues.csv",
+		[]string{
+			"sprint_id",
+			"issue_id",
+		},
+	)
+}
@@ -0,0 +1,283 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/tasks"
+)
+
+var _ plugin.PluginMeta = (*Azuredevops)(nil)
+var _ plugin.PluginInit = (*Azuredevops)(nil)
+var _ plugin.PluginTask = (*Azuredevops)(nil)
+var _ plugin.PluginApi = (*Azuredevops)(nil)
+var _ plugin.PluginModel = (*Azuredevops)(nil)
+var _ plugin.PluginMigration = (*Azuredevops)(nil)
+var _ plugin.CloseablePluginTask = (*Azuredevops)(nil)
+var _ plugin.PluginSource = (*Azuredevops)(nil)
+
+type Azuredevops string
+
+func (p Azuredevops) Connection() interface{} {
+	return &models.AzuredevopsConnection{}
+}
+
+func (p Azuredevops) Scope() interface{} {
+	return &models.AzuredevopsRepo{}
+}
+
+func (p Azuredevops) TransformationRule() interface{} {
+	return &models.AzuredevopsTransformationRule{}
+}
+
+func (p Azuredevops) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Azuredevops) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.AzuredevopsConnection{},
+		&models.AzuredevopsAccount{},
+		&models.AzuredevopsRepo{},
+		&models.AzuredevopsPullRequest{},
+		&models.AzuredevopsPrCommit{},
+		&models.AzuredevopsPrComment{},
+		&models.AzuredevopsBuild{},
+		&models.AzuredevopsTimelineRecord{},
+		&models.AzuredevopsIteration{},
+		&models.AzuredevopsWorkItem{},
+		&models.AzuredevopsTransformationRule{},
+	}
+}
+
+func (p Azuredevops) Description() string {
+	return "To collect and enrich data from Azure DevOps"
+}
+
+func (p Azuredevops) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiPullRequestsMeta,
+		tasks.ExtractApiPullRequestsMeta,
+
+		tasks.CollectApiPrCommitsMeta,
+		tasks.ExtractApiPrCommitsMeta,
+
+		tasks.CollectApiPrThreadsMeta,
+		tasks.ExtractApiPrThreadsMeta,
+
+		tasks.CollectApiBuildsMeta,
+		tasks.ExtractApiBuildsMeta,
+
+		// must run after builds, the timeline of every build is collected
+		tasks.CollectApiTimelineRecordsMeta,
+		tasks.ExtractApiTimelineRecordsMeta,
+
+		tasks.CollectApiIterationsMeta,
+		tasks.ExtractApiIterationsMeta,
+
+		tasks.CollectApiWorkItemsMeta,
+		tasks.ExtractApiWorkItemsMeta,
+
+		tasks.ConvertRepoMeta,
+		tasks.ConvertAccountsMeta,
+		tasks.ConvertPullRequestsMeta,
+		tasks.ConvertPrCommitsMeta,
+		tasks.ConvertPrCommentsMeta,
+		tasks.EnrichPullRequestStatsMeta,
+		tasks.ConvertBuildsMeta,
+		tasks.ConvertTimelineRecordsMeta,
+		tasks.ConvertIterationsMeta,
+		tasks.ConvertWorkItemsMeta,
+	}
+}
+
+func (p Azuredevops) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.AzuredevopsConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get azuredevops connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get azuredevops API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeAfter time.Time
+	if op.TimeAfter != "" {
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+	}
+	regexEnricher := helper.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(devops.DEPLOYMENT, op.DeploymentPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
+	}
+	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ProductionPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
+	}
+	taskData := &tasks.AzuredevopsTaskData{
+		Options:       op,
+		ApiClient:     apiClient,
+		RegexEnricher: regexEnricher,
+	}
+	if !timeAfter.IsZero() {
+		taskData.TimeAfter = &timeAfter
+		logger.Debug("collect data updated timeAfter %s", timeAfter)
+	}
+
+	return taskData, nil
+}
+
+func (p Azuredevops) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/azuredevops"
+}
+
+func (p Azuredevops) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Azuredevops) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Azuredevops) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/:scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
+	}
+}
+
+func (p Azuredevops) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.AzuredevopsTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.AzuredevopsOptions,
+	apiClient *helper.ApiClient) errors.Error {
+	var repo models.AzuredevopsRepo
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&repo, dal.Where(
+		"connection_id = ? AND azuredevops_id = ?",
+		op.ConnectionId, op.RepositoryId))
+	if err == nil {
+		op.OrganizationId = repo.OrganizationId
+		op.ProjectId = repo.ProjectId
+		if op.TransformationRuleId == 0 {
+			op.TransformationRuleId = repo.TransformationRuleId
+		}
+	} else {
+		// the repo has to be looked up under its organization and project when it is not a scope yet
+		if db.IsErrorNotFound(err) && op.OrganizationId != "" && op.ProjectId != "" {
+			var apiRepo *models.AzuredevopsApiRepo
+			apiRepo, err = tasks.GetApiRepo(op, apiClient)
+			if err != nil {
+				return err
+			}
+			logger.Debug(fmt.Sprintf("Current repo: %s", apiRepo.Name))
+			scope := apiRepo.ConvertApiScope().(*models.AzuredevopsRepo)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find repo %s", op.RepositoryId))
+		}
+	}
+	if op.AzuredevopsTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.AzuredevopsTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.AzuredevopsTransformationRule = &transformationRule
+	}
+	if op.AzuredevopsTransformationRule == nil {
+		op.AzuredevopsTransformationRule = new(models.AzuredevopsTransformationRule)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*AzuredevopsConnection)(nil)
+
+// AzuredevopsAccessToken authenticates with a Personal Access Token, which Azure DevOps
+// expects as the password of HTTP Basic Authentication with an empty username
+type AzuredevopsAccessToken api.AccessToken
+
+// SetupAuthentication sets up the request headers for authentication
+func (at *AzuredevopsAccessToken) SetupAuthentication(request *http.Request) errors.Error {
+	request.SetBasicAuth("", at.Token)
+	return nil
+}
+
+// AzuredevopsConn holds the essential information to connect to the Azure DevOps API
+type AzuredevopsConn struct {
+	api.RestConnection     `mapstructure:",squash"`
+	AzuredevopsAccessToken `mapstructure:",squash"`
+	// Organization limits the remote scopes to a single organization, all the organizations
+	// the token has access to are listed when it is empty
+	Organization string `mapstructure:"organization" json:"organization" gorm:"type:varchar(255)"`
+}
+
+// AzuredevopsConnection holds AzuredevopsConn plus ID/Name for database storage
+type AzuredevopsConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	AzuredevopsConn    `mapstructure:",squash"`
+}
+
+func (AzuredevopsConnection) TableName() string {
+	return "_tool_azuredevops_connections"
+}
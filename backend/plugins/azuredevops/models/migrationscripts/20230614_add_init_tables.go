@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models/migrationscripts/archived"
+)
+
+type addInitTables struct{}
+
+func (*addInitTables) Up(basicRes context.BasicRes) errors.Error {
+	// the builds table of the former python implementation has the same name but different columns,
+	// the other tables it left behind are named after the python models and don't collide
+	err := basicRes.GetDal().DropTables("_tool_azuredevops_builds")
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.AzuredevopsConnection{},
+		&archived.AzuredevopsRepo{},
+		&archived.AzuredevopsTransformationRule{},
+		&archived.AzuredevopsAccount{},
+		&archived.AzuredevopsPullRequest{},
+		&archived.AzuredevopsPrCommit{},
+		&archived.AzuredevopsPrComment{},
+		&archived.AzuredevopsBuild{},
+		&archived.AzuredevopsTimelineRecord{},
+		&archived.AzuredevopsIteration{},
+		&archived.AzuredevopsWorkItem{},
+	)
+}
+
+func (*addInitTables) Version() uint64 {
+	return 20230614100000
+}
+
+func (*addInitTables) Name() string {
+	return "azuredevops init schemas"
+}
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type AzuredevopsRepo struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	AzuredevopsId        string `json:"azuredevopsId" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"azuredevopsId"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	OrganizationId       string `json:"organizationId" gorm:"type:varchar(255)" validate:"required" mapstructure:"organizationId"`
+	ProjectId            string `json:"projectId" gorm:"type:varchar(255)" validate:"required" mapstructure:"projectId"`
+	ProjectName          string `json:"projectName" gorm:"type:varchar(255)" mapstructure:"projectName,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	RemoteUrl            string `json:"remoteUrl" gorm:"type:varchar(255)" mapstructure:"remoteUrl,omitempty"`
+	DefaultBranch        string `json:"defaultBranch" gorm:"type:varchar(255)" mapstructure:"defaultBranch,omitempty"`
+	IsFork               bool   `json:"isFork" mapstructure:"isFork,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	archived.NoPKModel   `json:"-" mapstructure:"-"`
+}
+
+func (AzuredevopsRepo) TableName() string {
+	return "_tool_azuredevops_repos"
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type AzuredevopsPullRequest struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	RepositoryId    string `gorm:"primaryKey;type:varchar(255)"`
+	AzuredevopsId   int    `gorm:"primaryKey"`
+	ForkRepoId      string `gorm:"type:varchar(255)"`
+	CodeReviewId    int
+	Status          string `gorm:"type:varchar(100)"`
+	Title           string `gorm:"type:varchar(255)"`
+	Description     string
+	Url             string `gorm:"type:varchar(255)"`
+	IsDraft         bool
+	MergeStatus     string `gorm:"type:varchar(100)"`
+	CreatedById     string `gorm:"type:varchar(255)"`
+	CreatedByName   string `gorm:"type:varchar(255)"`
+	SourceRefName   string `gorm:"type:varchar(255)"`
+	TargetRefName   string `gorm:"type:varchar(255)"`
+	SourceCommitSha string `gorm:"type:varchar(40)"`
+	TargetCommitSha string `gorm:"type:varchar(40)"`
+	MergeCommitSha  string `gorm:"type:varchar(40)"`
+	CreationDate    time.Time
+	ClosedDate      *time.Time
+	common.NoPKModel
+}
+
+func (AzuredevopsPullRequest) TableName() string {
+	return "_tool_azuredevops_pull_requests"
+}
+
+const (
+	PR_STATUS_ACTIVE    = "active"
+	PR_STATUS_ABANDONED = "abandoned"
+	PR_STATUS_COMPLETED = "completed"
+)
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*AzuredevopsRepo)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*AzuredevopsApiRepo)(nil)
+
+type AzuredevopsRepo struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	AzuredevopsId        string `json:"azuredevopsId" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"azuredevopsId"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	OrganizationId       string `json:"organizationId" gorm:"type:varchar(255)" validate:"required" mapstructure:"organizationId"`
+	ProjectId            string `json:"projectId" gorm:"type:varchar(255)" validate:"required" mapstructure:"projectId"`
+	ProjectName          string `json:"projectName" gorm:"type:varchar(255)" mapstructure:"projectName,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	RemoteUrl            string `json:"remoteUrl" gorm:"type:varchar(255)" mapstructure:"remoteUrl,omitempty"`
+	DefaultBranch        string `json:"defaultBranch" gorm:"type:varchar(255)" mapstructure:"defaultBranch,omitempty"`
+	IsFork               bool   `json:"isFork" mapstructure:"isFork,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (AzuredevopsRepo) TableName() string {
+	return "_tool_azuredevops_repos"
+}
+
+func (p AzuredevopsRepo) ScopeId() string {
+	return p.AzuredevopsId
+}
+
+func (p AzuredevopsRepo) ScopeName() string {
+	return p.Name
+}
+
+type AzuredevopsApiRepo struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Project struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+	DefaultBranch string `json:"defaultBranch"`
+	RemoteUrl     string `json:"remoteUrl"`
+	WebUrl        string `json:"webUrl"`
+	IsDisabled    bool   `json:"isDisabled"`
+	IsFork        bool   `json:"isFork"`
+	// OrganizationId is not a part of the response, it is filled by the caller who knows which
+	// organization the repository was listed from
+	OrganizationId string `json:"-"`
+}
+
+func (r AzuredevopsApiRepo) ConvertApiScope() plugin.ToolLayerScope {
+	return &AzuredevopsRepo{
+		AzuredevopsId:  r.Id,
+		Name:           r.Name,
+		OrganizationId: r.OrganizationId,
+		ProjectId:      r.Project.Id,
+		ProjectName:    r.Project.Name,
+		Url:            r.WebUrl,
+		RemoteUrl:      r.RemoteUrl,
+		DefaultBranch:  r.DefaultBranch,
+		IsFork:         r.IsFork,
+	}
+}
+
+// GroupResponse is either an organization or a project, the id of a project is
+// prefixed by its organization as `organization/project`
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
+
+type AccountsResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		AccountId   string `json:"accountId"`
+		AccountName string `json:"accountName"`
+	} `json:"value"`
+}
+
+type ProjectsResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"value"`
+}
+
+type ReposResponse struct {
+	Count int                  `json:"count"`
+	Value []AzuredevopsApiRepo `json:"value"`
+}
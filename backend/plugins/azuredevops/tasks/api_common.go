@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+const API_VERSION = "7.0"
+
+// CONTINUATION_TOKEN_HEADER is returned by the paginated apis as long as there are more pages
+const CONTINUATION_TOKEN_HEADER = "x-ms-continuationtoken"
+
+type AzuredevopsApiParams struct {
+	ConnectionId   uint64
+	OrganizationId string
+	ProjectId      string
+	RepositoryId   string
+}
+
+type AzuredevopsInput struct {
+	AzuredevopsId int
+}
+
+// AzuredevopsIdentity is the identity reference used by all the apis for users
+type AzuredevopsIdentity struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	ImageUrl    string `json:"imageUrl"`
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *AzuredevopsTaskData) {
+	data := taskCtx.GetData().(*AzuredevopsTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: AzuredevopsApiParams{
+			ConnectionId:   data.Options.ConnectionId,
+			OrganizationId: data.Options.OrganizationId,
+			ProjectId:      data.Options.ProjectId,
+			RepositoryId:   data.Options.RepositoryId,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GetQuery pages with `$top` and `$skip`
+func GetQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("api-version", API_VERSION)
+	if reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set("$top", fmt.Sprintf("%v", reqData.Pager.Size))
+		query.Set("$skip", fmt.Sprintf("%v", reqData.Pager.Skip))
+	}
+	return query, nil
+}
+
+// GetContinuationQuery pages with the continuation token returned by the previous page
+func GetContinuationQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("api-version", API_VERSION)
+	query.Set("$top", fmt.Sprintf("%v", reqData.Pager.Size))
+	if token, ok := reqData.CustomData.(string); ok && token != "" {
+		query.Set("continuationToken", token)
+	}
+	return query, nil
+}
+
+// GetNextPageCustomData reads the continuation token from the headers of the previous page
+func GetNextPageCustomData(_ *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	token := prevPageResponse.Header.Get(CONTINUATION_TOKEN_HEADER)
+	if token == "" {
+		return nil, api.ErrFinishCollect
+	}
+	return token, nil
+}
+
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var rawMessages struct {
+		Value []json.RawMessage `json:"value"`
+	}
+	err := api.UnmarshalResponse(res, &rawMessages)
+	if err != nil {
+		return nil, err
+	}
+	return rawMessages.Value, nil
+}
+
+func GetPullRequestsIterator(taskCtx plugin.SubTaskContext) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzuredevopsTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("azuredevops_id"),
+		dal.From(&models.AzuredevopsPullRequest{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(AzuredevopsInput{}))
+}
+
+func GetBuildsIterator(taskCtx plugin.SubTaskContext, collectorWithState *api.ApiCollectorStateManager) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzuredevopsTaskData)
+	clauses := []dal.Clause{
+		dal.Select("azuredevops_id"),
+		dal.From(&models.AzuredevopsBuild{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	}
+	if collectorWithState.IsIncremental() {
+		// builds still running at the last collection need their timelines collected again
+		clauses = append(clauses, dal.Where("(finish_time IS NULL OR finish_time > ?)", *collectorWithState.LatestState.LatestSuccessStart))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(AzuredevopsInput{}))
+}
+
+func ignoreHTTPStatus404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your Personal Access Token")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
+
+// convertIdentity turns an identity into an account, it returns nil for an absent identity
+func convertIdentity(identity *AzuredevopsIdentity, connectionId uint64) *models.AzuredevopsAccount {
+	if identity == nil || identity.Id == "" {
+		return nil
+	}
+	return &models.AzuredevopsAccount{
+		ConnectionId: connectionId,
+		AccountId:    identity.Id,
+		DisplayName:  identity.DisplayName,
+		UniqueName:   identity.UniqueName,
+		AvatarUrl:    identity.ImageUrl,
+	}
+}
+
+// nullableTime treats the zero dates some apis return for absent dates as nil
+func nullableTime(t *api.Iso8601Time) *time.Time {
+	if t == nil || t.ToTime().Year() <= 1 {
+		return nil
+	}
+	return t.ToNullableTime()
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_BUILD_TABLE = "azuredevops_api_builds"
+
+var CollectApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBuilds",
+	EntryPoint:       CollectApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Collect the builds of pipelines running on the repository from Azure DevOps api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/build/builds",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetContinuationQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("repositoryId", data.Options.RepositoryId)
+			query.Set("repositoryType", "TfsGit")
+			// minTime is compared with the queue time when builds are ordered by it
+			query.Set("queryOrder", "queueTimeDescending")
+			if collectorWithState.IsIncremental() {
+				query.Set("minTime", collectorWithState.LatestState.LatestSuccessStart.Format(time.RFC3339))
+			} else if collectorWithState.TimeAfter != nil {
+				query.Set("minTime", collectorWithState.TimeAfter.Format(time.RFC3339))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: GetNextPageCustomData,
+		ResponseParser:        GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
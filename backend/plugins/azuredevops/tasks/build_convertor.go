@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ConvertBuildsMeta = plugin.SubTaskMeta{
+	Name:             "convertBuilds",
+	EntryPoint:       ConvertBuilds,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_builds into domain layer table cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	db := taskCtx.GetDal()
+
+	repo := &models.AzuredevopsRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND azuredevops_id = ?", data.Options.ConnectionId, data.Options.RepositoryId))
+	if err != nil {
+		return err
+	}
+	repoId := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(repo.ConnectionId, repo.AzuredevopsId)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsBuild{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	buildIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsBuild{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AzuredevopsBuild{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			build := inputRow.(*models.AzuredevopsBuild)
+			// builds which are still queued have nothing to report yet
+			if build.QueueTime == nil {
+				return nil, nil
+			}
+			pipelineId := buildIdGen.Generate(build.ConnectionId, build.RepositoryId, build.AzuredevopsId)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: pipelineId},
+				Name:         build.DefinitionName,
+				Result: devops.GetResult(&devops.ResultRule{
+					Failed:  []string{models.BUILD_RESULT_FAILED},
+					Abort:   []string{models.BUILD_RESULT_CANCELED},
+					Success: []string{models.BUILD_RESULT_SUCCEEDED, models.BUILD_RESULT_PARTIALLY_SUCCEEDED},
+					Default: "",
+				}, build.Result),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{models.BUILD_STATUS_IN_PROGRESS, models.BUILD_STATUS_NOT_STARTED, models.BUILD_STATUS_POSTPONED, models.BUILD_STATUS_CANCELLING},
+					Default:    devops.DONE,
+				}, build.Status),
+				Type:        build.Type,
+				Environment: build.Environment,
+				CreatedDate: *build.QueueTime,
+				CicdScopeId: repoId,
+			}
+			if domainPipeline.Status == devops.DONE {
+				domainPipeline.FinishedDate = build.FinishTime
+				if build.FinishTime != nil {
+					domainPipeline.DurationSec = uint64(build.FinishTime.Sub(*build.QueueTime).Seconds())
+				}
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId: pipelineId,
+				CommitSha:  build.SourceVersion,
+				Branch:     build.SourceBranch,
+				RepoId:     repoId,
+				RepoUrl:    repo.Url,
+			}
+			return []interface{}{
+				domainPipeline,
+				domainPipelineCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
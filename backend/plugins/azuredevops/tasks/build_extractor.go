@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ExtractApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBuilds",
+	EntryPoint:       ExtractApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Extract raw builds data into tool layer table azuredevops_builds",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type AzuredevopsApiBuild struct {
+	Id          int    `json:"id"`
+	BuildNumber string `json:"buildNumber"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+	Definition  struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"definition"`
+	SourceBranch  string           `json:"sourceBranch"`
+	SourceVersion string           `json:"sourceVersion"`
+	QueueTime     *api.Iso8601Time `json:"queueTime"`
+	StartTime     *api.Iso8601Time `json:"startTime"`
+	FinishTime    *api.Iso8601Time `json:"finishTime"`
+	Links         struct {
+		Web struct {
+			Href string `json:"href"`
+		} `json:"web"`
+	} `json:"_links"`
+}
+
+func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiBuild := &AzuredevopsApiBuild{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiBuild))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzuredevopsBuild{
+					ConnectionId:   data.Options.ConnectionId,
+					AzuredevopsId:  apiBuild.Id,
+					RepositoryId:   data.Options.RepositoryId,
+					BuildNumber:    apiBuild.BuildNumber,
+					DefinitionId:   apiBuild.Definition.Id,
+					DefinitionName: apiBuild.Definition.Name,
+					Status:         apiBuild.Status,
+					Result:         apiBuild.Result,
+					SourceBranch:   apiBuild.SourceBranch,
+					SourceVersion:  apiBuild.SourceVersion,
+					Url:            apiBuild.Links.Web.Href,
+					Type:           data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, apiBuild.Definition.Name),
+					Environment:    data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, apiBuild.Definition.Name),
+					QueueTime:      nullableTime(apiBuild.QueueTime),
+					StartTime:      nullableTime(apiBuild.StartTime),
+					FinishTime:     nullableTime(apiBuild.FinishTime),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_ITERATION_TABLE = "azuredevops_api_iterations"
+
+var CollectApiIterationsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiIterations",
+	EntryPoint:       CollectApiIterations,
+	EnabledByDefault: true,
+	Description:      "Collect the iterations of the default team of the project from Azure DevOps api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ITERATION_TABLE)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		UrlTemplate:        "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/work/teamsettings/iterations",
+		Query:              GetQuery,
+		ResponseParser:     GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ConvertIterationsMeta = plugin.SubTaskMeta{
+	Name:             "convertIterations",
+	EntryPoint:       ConvertIterations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_iterations into domain layer table sprints and board_sprints",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+var iterationStatuses = map[string]string{
+	models.ITERATION_TIME_FRAME_PAST:    ticket.SPRINT_STATUS_CLOSED,
+	models.ITERATION_TIME_FRAME_CURRENT: ticket.SPRINT_STATUS_ACTIVE,
+	models.ITERATION_TIME_FRAME_FUTURE:  ticket.SPRINT_STATUS_FUTURE,
+}
+
+func ConvertIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ITERATION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsIteration{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsIteration{})
+	boardId := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(data.Options.ConnectionId, data.Options.RepositoryId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AzuredevopsIteration{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			iteration := inputRow.(*models.AzuredevopsIteration)
+			// iterations belong to the project, every repo of the project shares the same sprint
+			sprintId := iterationIdGen.Generate(iteration.ConnectionId, iteration.ProjectId, iteration.AzuredevopsId)
+			domainSprint := &ticket.Sprint{
+				DomainEntity:    domainlayer.DomainEntity{Id: sprintId},
+				Name:            iteration.Name,
+				Url:             iteration.Url,
+				Status:          iterationStatuses[iteration.TimeFrame],
+				StartedDate:     iteration.StartDate,
+				EndedDate:       iteration.FinishDate,
+				OriginalBoardID: boardId,
+			}
+			if domainSprint.Status == ticket.SPRINT_STATUS_CLOSED {
+				domainSprint.CompletedDate = iteration.FinishDate
+			}
+			boardSprint := &ticket.BoardSprint{
+				BoardId:  boardId,
+				SprintId: sprintId,
+			}
+			return []interface{}{
+				domainSprint,
+				boardSprint,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ExtractApiIterationsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiIterations",
+	EntryPoint:       ExtractApiIterations,
+	EnabledByDefault: true,
+	Description:      "Extract raw iterations data into tool layer table azuredevops_iterations",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type AzuredevopsApiIteration struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Url        string `json:"url"`
+	Attributes struct {
+		StartDate  *api.Iso8601Time `json:"startDate"`
+		FinishDate *api.Iso8601Time `json:"finishDate"`
+		TimeFrame  string           `json:"timeFrame"`
+	} `json:"attributes"`
+}
+
+func ExtractApiIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ITERATION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiIteration := &AzuredevopsApiIteration{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiIteration))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzuredevopsIteration{
+					ConnectionId:  data.Options.ConnectionId,
+					AzuredevopsId: apiIteration.Id,
+					RepositoryId:  data.Options.RepositoryId,
+					ProjectId:     data.Options.ProjectId,
+					Name:          apiIteration.Name,
+					Path:          apiIteration.Path,
+					TimeFrame:     apiIteration.Attributes.TimeFrame,
+					Url:           apiIteration.Url,
+					StartDate:     nullableTime(apiIteration.Attributes.StartDate),
+					FinishDate:    nullableTime(apiIteration.Attributes.FinishDate),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
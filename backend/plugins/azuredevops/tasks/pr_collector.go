@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PULL_REQUEST_TABLE = "azuredevops_api_pull_requests"
+
+var CollectApiPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiPullRequests",
+	EntryPoint:       CollectApiPullRequests,
+	EnabledByDefault: true,
+	Description:      "Collect pull requests data from Azure DevOps api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
+	// the pull requests api can't filter by the update time, they are always fully collected
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		UrlTemplate:        "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/git/repositories/{{ .Params.RepositoryId }}/pullrequests",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("searchCriteria.status", "all")
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
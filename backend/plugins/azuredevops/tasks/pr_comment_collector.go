@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PULL_REQUEST_THREAD_TABLE = "azuredevops_api_pull_request_threads"
+
+var CollectApiPrThreadsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiPullRequestThreads",
+	EntryPoint:       CollectApiPullRequestThreads,
+	EnabledByDefault: true,
+	Description:      "Collect the comment threads of pull requests from Azure DevOps api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectApiPullRequestThreads(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_THREAD_TABLE)
+	iterator, err := GetPullRequestsIterator(taskCtx)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	// the threads api is not paginated
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/git/repositories/{{ .Params.RepositoryId }}/pullRequests/{{ .Input.AzuredevopsId }}/threads",
+		Query:              GetQuery,
+		ResponseParser:     GetRawMessageFromResponse,
+		AfterResponse:      ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ConvertPrCommentsMeta = plugin.SubTaskMeta{
+	Name:             "convertPullRequestComments",
+	EntryPoint:       ConvertPullRequestComments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_pull_request_comments into domain layer table pull_request_comments and pull_request_review_threads",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// the statuses of threads which no longer need attention
+var resolvedThreadStatuses = map[string]bool{
+	"fixed":    true,
+	"wontFix":  true,
+	"closed":   true,
+	"byDesign": true,
+}
+
+type azuredevopsThreadCommentCount struct {
+	PullRequestId int
+	ThreadId      int
+	CommentCount  int
+}
+
+type azuredevopsThreadKey struct {
+	pullRequestId int
+	threadId      int
+}
+
+func ConvertPullRequestComments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_THREAD_TABLE)
+	db := taskCtx.GetDal()
+
+	var commentCounts []azuredevopsThreadCommentCount
+	err := db.All(
+		&commentCounts,
+		dal.Select("pull_request_id, thread_id, COUNT(*) AS comment_count"),
+		dal.From(&models.AzuredevopsPrComment{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+		dal.Groupby("pull_request_id, thread_id"),
+	)
+	if err != nil {
+		return err
+	}
+	commentCountMap := make(map[azuredevopsThreadKey]int, len(commentCounts))
+	for _, c := range commentCounts {
+		commentCountMap[azuredevopsThreadKey{c.PullRequestId, c.ThreadId}] = c.CommentCount
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsPrComment{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsPullRequest{})
+	commentIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsPrComment{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsAccount{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AzuredevopsPrComment{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			comment := inputRow.(*models.AzuredevopsPrComment)
+			prId := prIdGen.Generate(comment.ConnectionId, comment.RepositoryId, comment.PullRequestId)
+			// threads have no table of their own, their ids are the ids of their comments without the comment part
+			threadId := commentIdGen.Generate(comment.ConnectionId, comment.RepositoryId, comment.PullRequestId, comment.ThreadId)
+			domainComment := &code.PullRequestComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: commentIdGen.Generate(comment.ConnectionId, comment.RepositoryId, comment.PullRequestId, comment.ThreadId, comment.AzuredevopsId),
+				},
+				PullRequestId: prId,
+				Body:          comment.Content,
+				CreatedDate:   comment.PublishedDate,
+				Position:      comment.FileLine,
+				Status:        comment.ThreadStatus,
+				ThreadId:      threadId,
+				Type:          code.NORMAL_COMMENT,
+			}
+			if comment.FilePath != "" {
+				domainComment.Type = code.DIFF_COMMENT
+			}
+			if comment.AuthorId != "" {
+				domainComment.AccountId = accountIdGen.Generate(comment.ConnectionId, comment.AuthorId)
+			}
+			if comment.ParentCommentId > 0 {
+				domainComment.ReplyToId = commentIdGen.Generate(comment.ConnectionId, comment.RepositoryId, comment.PullRequestId, comment.ThreadId, comment.ParentCommentId)
+			}
+			results := []interface{}{domainComment}
+			// the first comment of a thread opens it
+			if comment.ParentCommentId == 0 {
+				thread := &code.PullRequestReviewThread{
+					DomainEntity:  domainlayer.DomainEntity{Id: threadId},
+					PullRequestId: prId,
+					Path:          comment.FilePath,
+					Line:          comment.FileLine,
+					IsResolved:    resolvedThreadStatuses[comment.ThreadStatus],
+					AuthorId:      domainComment.AccountId,
+					CommentCount:  commentCountMap[azuredevopsThreadKey{comment.PullRequestId, comment.ThreadId}],
+					CreatedDate:   comment.PublishedDate,
+				}
+				if thread.IsResolved {
+					thread.ResolvedDate = comment.ThreadUpdatedDate
+				}
+				results = append(results, thread)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
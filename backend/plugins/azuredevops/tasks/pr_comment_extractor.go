@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ExtractApiPrThreadsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequestThreads",
+	EntryPoint:       ExtractApiPullRequestThreads,
+	EnabledByDefault: true,
+	Description:      "Extract the comments of raw pull request threads into tool layer table azuredevops_pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// COMMENT_TYPE_SYSTEM marks the comments generated by Azure DevOps, e.g. for votes and pushes
+const COMMENT_TYPE_SYSTEM = "system"
+
+type AzuredevopsApiPrThread struct {
+	Id              int              `json:"id"`
+	Status          string           `json:"status"`
+	IsDeleted       bool             `json:"isDeleted"`
+	LastUpdatedDate *api.Iso8601Time `json:"lastUpdatedDate"`
+	ThreadContext   *struct {
+		FilePath       string `json:"filePath"`
+		RightFileStart *struct {
+			Line int `json:"line"`
+		} `json:"rightFileStart"`
+	} `json:"threadContext"`
+	Comments []struct {
+		Id              int                  `json:"id"`
+		ParentCommentId int                  `json:"parentCommentId"`
+		Author          *AzuredevopsIdentity `json:"author"`
+		Content         string               `json:"content"`
+		CommentType     string               `json:"commentType"`
+		IsDeleted       bool                 `json:"isDeleted"`
+		PublishedDate   api.Iso8601Time      `json:"publishedDate"`
+		LastUpdatedDate *api.Iso8601Time     `json:"lastUpdatedDate"`
+	} `json:"comments"`
+}
+
+func ExtractApiPullRequestThreads(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_THREAD_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			thread := &AzuredevopsApiPrThread{}
+			err := errors.Convert(json.Unmarshal(row.Data, thread))
+			if err != nil {
+				return nil, err
+			}
+			if thread.IsDeleted {
+				return nil, nil
+			}
+			input := &AzuredevopsInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, 2*len(thread.Comments))
+			for _, c := range thread.Comments {
+				if c.IsDeleted || c.CommentType == COMMENT_TYPE_SYSTEM {
+					continue
+				}
+				comment := &models.AzuredevopsPrComment{
+					ConnectionId:      data.Options.ConnectionId,
+					PullRequestId:     input.AzuredevopsId,
+					ThreadId:          thread.Id,
+					AzuredevopsId:     c.Id,
+					RepositoryId:      data.Options.RepositoryId,
+					ParentCommentId:   c.ParentCommentId,
+					Content:           c.Content,
+					CommentType:       c.CommentType,
+					ThreadStatus:      thread.Status,
+					PublishedDate:     c.PublishedDate.ToTime(),
+					LastUpdatedDate:   nullableTime(c.LastUpdatedDate),
+					ThreadUpdatedDate: nullableTime(thread.LastUpdatedDate),
+				}
+				if thread.ThreadContext != nil {
+					comment.FilePath = thread.ThreadContext.FilePath
+					if thread.ThreadContext.RightFileStart != nil {
+						comment.FileLine = thread.ThreadContext.RightFileStart.Line
+					}
+				}
+				if author := convertIdentity(c.Author, data.Options.ConnectionId); author != nil {
+					comment.AuthorId = author.AccountId
+					comment.AuthorName = author.DisplayName
+					results = append(results, author)
+				}
+				results = append(results, comment)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
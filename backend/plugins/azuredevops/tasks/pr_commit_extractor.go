@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ExtractApiPrCommitsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequestCommits",
+	EntryPoint:       ExtractApiPullRequestCommits,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull request commits data into tool layer table azuredevops_pull_request_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type AzuredevopsGitUserDate struct {
+	Name  string          `json:"name"`
+	Email string          `json:"email"`
+	Date  api.Iso8601Time `json:"date"`
+}
+
+type AzuredevopsApiPrCommit struct {
+	CommitId  string                 `json:"commitId"`
+	Author    AzuredevopsGitUserDate `json:"author"`
+	Committer AzuredevopsGitUserDate `json:"committer"`
+	Comment   string                 `json:"comment"`
+}
+
+func ExtractApiPullRequestCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_COMMIT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiCommit := &AzuredevopsApiPrCommit{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiCommit))
+			if err != nil {
+				return nil, err
+			}
+			input := &AzuredevopsInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzuredevopsPrCommit{
+					ConnectionId:   data.Options.ConnectionId,
+					PullRequestId:  input.AzuredevopsId,
+					CommitSha:      apiCommit.CommitId,
+					RepositoryId:   data.Options.RepositoryId,
+					AuthorName:     apiCommit.Author.Name,
+					AuthorEmail:    apiCommit.Author.Email,
+					AuthoredDate:   apiCommit.Author.Date.ToTime(),
+					CommitterName:  apiCommit.Committer.Name,
+					CommitterEmail: apiCommit.Committer.Email,
+					CommittedDate:  apiCommit.Committer.Date.ToTime(),
+					Message:        apiCommit.Comment,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
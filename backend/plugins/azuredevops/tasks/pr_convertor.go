@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ConvertPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "convertPullRequests",
+	EntryPoint:       ConvertPullRequests,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_pull_requests into domain layer table pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
+	db := taskCtx.GetDal()
+
+	repo := &models.AzuredevopsRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND azuredevops_id = ?", data.Options.ConnectionId, data.Options.RepositoryId))
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsPullRequest{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsPullRequest{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsAccount{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AzuredevopsPullRequest{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pr := inputRow.(*models.AzuredevopsPullRequest)
+			domainPr := &code.PullRequest{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: prIdGen.Generate(pr.ConnectionId, pr.RepositoryId, pr.AzuredevopsId),
+				},
+				BaseRepoId:     repoIdGen.Generate(pr.ConnectionId, pr.RepositoryId),
+				HeadRepoId:     repoIdGen.Generate(pr.ConnectionId, pr.RepositoryId),
+				OriginalStatus: pr.Status,
+				Title:          pr.Title,
+				Description:    pr.Description,
+				Url:            fmt.Sprintf("%s/pullrequest/%d", repo.Url, pr.AzuredevopsId),
+				AuthorName:     pr.CreatedByName,
+				PullRequestKey: pr.AzuredevopsId,
+				CreatedDate:    pr.CreationDate,
+				ClosedDate:     pr.ClosedDate,
+				MergeCommitSha: pr.MergeCommitSha,
+				BaseRef:        strings.TrimPrefix(pr.TargetRefName, "refs/heads/"),
+				BaseCommitSha:  pr.TargetCommitSha,
+				HeadRef:        strings.TrimPrefix(pr.SourceRefName, "refs/heads/"),
+				HeadCommitSha:  pr.SourceCommitSha,
+			}
+			if pr.CreatedById != "" {
+				domainPr.AuthorId = accountIdGen.Generate(pr.ConnectionId, pr.CreatedById)
+			}
+			if pr.ForkRepoId != "" {
+				domainPr.HeadRepoId = repoIdGen.Generate(pr.ConnectionId, pr.ForkRepoId)
+			}
+			switch pr.Status {
+			case models.PR_STATUS_ACTIVE:
+				domainPr.Status = code.OPEN
+			case models.PR_STATUS_COMPLETED:
+				domainPr.Status = code.MERGED
+				domainPr.MergedDate = pr.ClosedDate
+			case models.PR_STATUS_ABANDONED:
+				domainPr.Status = code.CLOSED
+			default:
+				domainPr.Status = pr.Status
+			}
+			return []interface{}{
+				domainPr,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ExtractApiPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequests",
+	EntryPoint:       ExtractApiPullRequests,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull requests data into tool layer table azuredevops_pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type AzuredevopsCommitRef struct {
+	CommitId string `json:"commitId"`
+}
+
+type AzuredevopsApiPullRequest struct {
+	PullRequestId int `json:"pullRequestId"`
+	CodeReviewId  int `json:"codeReviewId"`
+	Repository    struct {
+		Id string `json:"id"`
+	} `json:"repository"`
+	ForkSource *struct {
+		Repository struct {
+			Id string `json:"id"`
+		} `json:"repository"`
+	} `json:"forkSource"`
+	Status                string                `json:"status"`
+	Title                 string                `json:"title"`
+	Description           string                `json:"description"`
+	Url                   string                `json:"url"`
+	IsDraft               bool                  `json:"isDraft"`
+	MergeStatus           string                `json:"mergeStatus"`
+	SourceRefName         string                `json:"sourceRefName"`
+	TargetRefName         string                `json:"targetRefName"`
+	CreatedBy             *AzuredevopsIdentity  `json:"createdBy"`
+	Reviewers             []AzuredevopsIdentity `json:"reviewers"`
+	CreationDate          api.Iso8601Time       `json:"creationDate"`
+	ClosedDate            *api.Iso8601Time      `json:"closedDate"`
+	LastMergeSourceCommit *AzuredevopsCommitRef `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit *AzuredevopsCommitRef `json:"lastMergeTargetCommit"`
+	LastMergeCommit       *AzuredevopsCommitRef `json:"lastMergeCommit"`
+}
+
+func ExtractApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiPr := &AzuredevopsApiPullRequest{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiPr))
+			if err != nil {
+				return nil, err
+			}
+			if apiPr.PullRequestId == 0 {
+				return nil, nil
+			}
+			pr := &models.AzuredevopsPullRequest{
+				ConnectionId:  data.Options.ConnectionId,
+				AzuredevopsId: apiPr.PullRequestId,
+				RepositoryId:  data.Options.RepositoryId,
+				CodeReviewId:  apiPr.CodeReviewId,
+				Status:        apiPr.Status,
+				Title:         apiPr.Title,
+				Description:   apiPr.Description,
+				Url:           apiPr.Url,
+				IsDraft:       apiPr.IsDraft,
+				MergeStatus:   apiPr.MergeStatus,
+				SourceRefName: apiPr.SourceRefName,
+				TargetRefName: apiPr.TargetRefName,
+				CreationDate:  apiPr.CreationDate.ToTime(),
+				ClosedDate:    nullableTime(apiPr.ClosedDate),
+			}
+			if apiPr.ForkSource != nil {
+				pr.ForkRepoId = apiPr.ForkSource.Repository.Id
+			}
+			if apiPr.LastMergeSourceCommit != nil {
+				pr.SourceCommitSha = apiPr.LastMergeSourceCommit.CommitId
+			}
+			if apiPr.LastMergeTargetCommit != nil {
+				pr.TargetCommitSha = apiPr.LastMergeTargetCommit.CommitId
+			}
+			if apiPr.LastMergeCommit != nil && apiPr.Status == models.PR_STATUS_COMPLETED {
+				pr.MergeCommitSha = apiPr.LastMergeCommit.CommitId
+			}
+			results := make([]interface{}, 0, len(apiPr.Reviewers)+2)
+			if author := convertIdentity(apiPr.CreatedBy, data.Options.ConnectionId); author != nil {
+				pr.CreatedById = author.AccountId
+				pr.CreatedByName = author.DisplayName
+				results = append(results, author)
+			}
+			for i := range apiPr.Reviewers {
+				if reviewer := convertIdentity(&apiPr.Reviewers[i], data.Options.ConnectionId); reviewer != nil {
+					results = append(results, reviewer)
+				}
+			}
+			results = append(results, pr)
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
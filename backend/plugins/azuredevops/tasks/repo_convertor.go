@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+const RAW_REPOSITORIES_TABLE = "azuredevops_api_repositories"
+
+var ConvertRepoMeta = plugin.SubTaskMeta{
+	Name:             "convertRepo",
+	EntryPoint:       ConvertRepo,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_repos into domain layer table repos, boards and cicd_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CICD},
+}
+
+// GetApiRepo fetches a repository, the organization and the project must be known beforehand
+func GetApiRepo(op *AzuredevopsOptions, apiClient aha.ApiClientAbstract) (*models.AzuredevopsApiRepo, errors.Error) {
+	query := url.Values{"api-version": {API_VERSION}}
+	res, err := apiClient.Get(fmt.Sprintf("%s/%s/_apis/git/repositories/%s", op.OrganizationId, op.ProjectId, op.RepositoryId), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting repo detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	apiRepo := &models.AzuredevopsApiRepo{}
+	err = api.UnmarshalResponse(res, apiRepo)
+	if err != nil {
+		return nil, err
+	}
+	apiRepo.OrganizationId = op.OrganizationId
+	return apiRepo, nil
+}
+
+func ConvertRepo(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REPOSITORIES_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsRepo{}),
+		dal.Where("connection_id = ? AND azuredevops_id = ?", data.Options.ConnectionId, data.Options.RepositoryId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AzuredevopsRepo{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			repository := inputRow.(*models.AzuredevopsRepo)
+			repoId := repoIdGen.Generate(repository.ConnectionId, repository.AzuredevopsId)
+
+			domainRepository := &code.Repo{
+				DomainEntity: domainlayer.DomainEntity{Id: repoId},
+				Name:         repository.Name,
+				Url:          repository.Url,
+			}
+			domainBoard := &ticket.Board{
+				DomainEntity: domainlayer.DomainEntity{Id: repoId},
+				Name:         repository.Name,
+				Url:          fmt.Sprintf("%s/_workitems", projectUrl(repository)),
+			}
+			domainCicdScope := &devops.CicdScope{
+				DomainEntity: domainlayer.DomainEntity{Id: repoId},
+				Name:         repository.Name,
+				Url:          fmt.Sprintf("%s/_build", projectUrl(repository)),
+			}
+			return []interface{}{
+				domainRepository,
+				domainBoard,
+				domainCicdScope,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// projectUrl derives the web url of the project from the web url of the repository,
+// which looks like https://dev.azure.com/{organization}/{project}/_git/{repository}
+func projectUrl(repo *models.AzuredevopsRepo) string {
+	if i := strings.LastIndex(repo.Url, "/_git/"); i >= 0 {
+		return repo.Url[:i]
+	}
+	return repo.Url
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_TIMELINE_RECORD_TABLE = "azuredevops_api_timeline_records"
+
+var CollectApiTimelineRecordsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiTimelineRecords",
+	EntryPoint:       CollectApiTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Collect the timeline records of builds from Azure DevOps api, must run after the builds are extracted",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_RECORD_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+	iterator, err := GetBuildsIterator(taskCtx, collectorWithState)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Incremental: collectorWithState.IsIncremental(),
+		Input:       iterator,
+		UrlTemplate: "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/build/builds/{{ .Input.AzuredevopsId }}/timeline",
+		Query:       GetQuery,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var timeline struct {
+				Records []json.RawMessage `json:"records"`
+			}
+			err := api.UnmarshalResponse(res, &timeline)
+			if err != nil {
+				return nil, err
+			}
+			return timeline.Records, nil
+		},
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
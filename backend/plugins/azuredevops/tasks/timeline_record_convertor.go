@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ConvertTimelineRecordsMeta = plugin.SubTaskMeta{
+	Name:             "convertTimelineRecords",
+	EntryPoint:       ConvertTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_timeline_records into domain layer table cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_RECORD_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsTimelineRecord{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	recordIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsTimelineRecord{})
+	buildIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsBuild{})
+	repoId := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(data.Options.ConnectionId, data.Options.RepositoryId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AzuredevopsTimelineRecord{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			record := inputRow.(*models.AzuredevopsTimelineRecord)
+			// not save to domain layer if the job never started
+			if record.StartTime == nil {
+				return nil, nil
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: recordIdGen.Generate(record.ConnectionId, record.RepositoryId, record.BuildId, record.RecordId),
+				},
+				Name:       record.Name,
+				PipelineId: buildIdGen.Generate(record.ConnectionId, record.RepositoryId, record.BuildId),
+				Result: devops.GetResult(&devops.ResultRule{
+					Failed:  []string{models.RECORD_RESULT_FAILED},
+					Abort:   []string{models.RECORD_RESULT_ABANDONED, models.RECORD_RESULT_CANCELED, models.RECORD_RESULT_SKIPPED},
+					Success: []string{models.RECORD_RESULT_SUCCEEDED, models.RECORD_RESULT_SUCCEEDED_WITH_ISSUES},
+					Default: "",
+				}, record.Result),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{models.RECORD_STATE_PENDING, models.RECORD_STATE_IN_PROGRESS},
+					Default:    devops.DONE,
+				}, record.State),
+				Type:        record.Type,
+				Environment: record.Environment,
+				StartedDate: *record.StartTime,
+				CicdScopeId: repoId,
+			}
+			if domainTask.Status == devops.DONE {
+				domainTask.FinishedDate = record.FinishTime
+				if record.FinishTime != nil {
+					domainTask.DurationSec = uint64(record.FinishTime.Sub(*record.StartTime).Seconds())
+				}
+			}
+			return []interface{}{domainTask}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ExtractApiTimelineRecordsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTimelineRecords",
+	EntryPoint:       ExtractApiTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Extract the jobs of raw build timelines into tool layer table azuredevops_timeline_records",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// RECORD_TYPE_JOB is the only type of timeline records kept, stages and phases group jobs and tasks are their steps
+const RECORD_TYPE_JOB = "Job"
+
+type AzuredevopsApiTimelineRecord struct {
+	Id         string           `json:"id"`
+	ParentId   string           `json:"parentId"`
+	Type       string           `json:"type"`
+	Name       string           `json:"name"`
+	State      string           `json:"state"`
+	Result     string           `json:"result"`
+	StartTime  *api.Iso8601Time `json:"startTime"`
+	FinishTime *api.Iso8601Time `json:"finishTime"`
+}
+
+func ExtractApiTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_RECORD_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiRecord := &AzuredevopsApiTimelineRecord{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiRecord))
+			if err != nil {
+				return nil, err
+			}
+			if apiRecord.Type != RECORD_TYPE_JOB {
+				return nil, nil
+			}
+			input := &AzuredevopsInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzuredevopsTimelineRecord{
+					ConnectionId: data.Options.ConnectionId,
+					RecordId:     apiRecord.Id,
+					BuildId:      input.AzuredevopsId,
+					RepositoryId: data.Options.RepositoryId,
+					ParentId:     apiRecord.ParentId,
+					Name:         apiRecord.Name,
+					State:        apiRecord.State,
+					Result:       apiRecord.Result,
+					Type:         data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, apiRecord.Name),
+					Environment:  data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, apiRecord.Name),
+					StartTime:    nullableTime(apiRecord.StartTime),
+					FinishTime:   nullableTime(apiRecord.FinishTime),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
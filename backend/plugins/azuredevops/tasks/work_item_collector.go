@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_WORK_ITEM_TABLE = "azuredevops_api_work_items"
+
+var CollectApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiWorkItems",
+	EntryPoint:       CollectApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Collect the work items of the project from Azure DevOps api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type workItemBatch struct {
+	Values            []json.RawMessage `json:"values"`
+	ContinuationToken string            `json:"continuationToken"`
+	IsLastBatch       bool              `json:"isLastBatch"`
+}
+
+func CollectApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEM_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	// the reporting api returns the latest revision of every work item changed since startDateTime,
+	// its batches have no fixed size so the page size of 1 only serves to keep fetching until the last batch
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    1,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/wit/reporting/workitemrevisions",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("api-version", API_VERSION)
+			query.Set("includeLatestOnly", "true")
+			query.Set("$expand", "fields")
+			query.Set("$maxPageSize", "200")
+			if token, ok := reqData.CustomData.(string); ok && token != "" {
+				query.Set("continuationToken", token)
+			} else if collectorWithState.IsIncremental() {
+				query.Set("startDateTime", collectorWithState.LatestState.LatestSuccessStart.Format(time.RFC3339))
+			} else if collectorWithState.TimeAfter != nil {
+				query.Set("startDateTime", collectorWithState.TimeAfter.Format(time.RFC3339))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: func(_ *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+			var batch workItemBatch
+			err := api.UnmarshalResponse(prevPageResponse, &batch)
+			if err != nil {
+				return nil, err
+			}
+			if batch.IsLastBatch || batch.ContinuationToken == "" {
+				return nil, api.ErrFinishCollect
+			}
+			return batch.ContinuationToken, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var batch workItemBatch
+			err := api.UnmarshalResponse(res, &batch)
+			if err != nil {
+				return nil, err
+			}
+			return batch.Values, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
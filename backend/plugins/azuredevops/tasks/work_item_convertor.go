@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ConvertWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItems",
+	EntryPoint:       ConvertWorkItems,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_work_items into domain layer table issues, board_issues and sprint_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEM_TABLE)
+	db := taskCtx.GetDal()
+
+	repo := &models.AzuredevopsRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND azuredevops_id = ?", data.Options.ConnectionId, data.Options.RepositoryId))
+	if err != nil {
+		return err
+	}
+	boardId := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(repo.ConnectionId, repo.AzuredevopsId)
+
+	// work items refer to their iteration by path
+	var iterations []models.AzuredevopsIteration
+	err = db.All(
+		&iterations,
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsIteration{})
+	sprintIds := make(map[string]string, len(iterations))
+	for _, iteration := range iterations {
+		sprintIds[iteration.Path] = iterationIdGen.Generate(iteration.ConnectionId, iteration.ProjectId, iteration.AzuredevopsId)
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzuredevopsWorkItem{}),
+		dal.Where("repository_id = ? AND connection_id = ?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	workItemIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItem{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsAccount{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.AzuredevopsWorkItem{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			workItem := inputRow.(*models.AzuredevopsWorkItem)
+			// work items belong to the project, every repo of the project shares the same issue
+			issueId := workItemIdGen.Generate(workItem.ConnectionId, workItem.ProjectId, workItem.AzuredevopsId)
+			issue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: issueId},
+				Url:            fmt.Sprintf("%s/_workitems/edit/%d", projectUrl(repo), workItem.AzuredevopsId),
+				IssueKey:       fmt.Sprintf("%d", workItem.AzuredevopsId),
+				Title:          workItem.Title,
+				Description:    workItem.Description,
+				Type:           workItem.StdType,
+				OriginalType:   workItem.WorkItemType,
+				Status:         workItem.StdState,
+				OriginalStatus: workItem.State,
+				StoryPoint:     workItem.StoryPoint,
+				ResolutionDate: workItem.ResolvedDate,
+				CreatedDate:    workItem.CreatedDate,
+				UpdatedDate:    workItem.ChangedDate,
+				Priority:       workItem.Priority,
+				Severity:       workItem.Severity,
+				CreatorName:    workItem.CreatorName,
+				AssigneeName:   workItem.AssigneeName,
+				Component:      workItem.AreaPath,
+			}
+			if workItem.ResolvedDate != nil && workItem.CreatedDate != nil {
+				issue.LeadTimeMinutes = int64(workItem.ResolvedDate.Sub(*workItem.CreatedDate).Minutes())
+			}
+			if workItem.ParentId > 0 {
+				issue.ParentIssueId = workItemIdGen.Generate(workItem.ConnectionId, workItem.ProjectId, workItem.ParentId)
+			}
+			if workItem.CreatorId != "" {
+				issue.CreatorId = accountIdGen.Generate(workItem.ConnectionId, workItem.CreatorId)
+			}
+			if workItem.AssigneeId != "" {
+				issue.AssigneeId = accountIdGen.Generate(workItem.ConnectionId, workItem.AssigneeId)
+			}
+			results := []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issueId,
+				},
+			}
+			if sprintId, ok := sprintIds[workItem.IterationPath]; ok {
+				results = append(results, &ticket.SprintIssue{
+					SprintId: sprintId,
+					IssueId:  issueId,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,238 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops/models"
+)
+
+var ExtractApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiWorkItems",
+	EntryPoint:       ExtractApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Extract raw work items data into tool layer table azuredevops_work_items",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type AzuredevopsApiWorkItem struct {
+	Id     int                        `json:"id"`
+	Rev    int                        `json:"rev"`
+	Url    string                     `json:"url"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// the types and states of the default process templates (Basic, Agile, Scrum and CMMI)
+var defaultWorkItemTypes = map[string]string{
+	"Bug":                  ticket.BUG,
+	"Epic":                 ticket.REQUIREMENT,
+	"Feature":              ticket.REQUIREMENT,
+	"Issue":                ticket.REQUIREMENT,
+	"Product Backlog Item": ticket.REQUIREMENT,
+	"Requirement":          ticket.REQUIREMENT,
+	"User Story":           ticket.REQUIREMENT,
+	"Task":                 ticket.TASK,
+	"Incident":             ticket.INCIDENT,
+}
+
+var defaultWorkItemStates = map[string]string{
+	"New":         ticket.TODO,
+	"To Do":       ticket.TODO,
+	"Proposed":    ticket.TODO,
+	"Approved":    ticket.TODO,
+	"Active":      ticket.IN_PROGRESS,
+	"Committed":   ticket.IN_PROGRESS,
+	"Doing":       ticket.IN_PROGRESS,
+	"In Progress": ticket.IN_PROGRESS,
+	"Resolved":    ticket.IN_PROGRESS,
+	"Closed":      ticket.DONE,
+	"Completed":   ticket.DONE,
+	"Done":        ticket.DONE,
+	"Removed":     ticket.DONE,
+}
+
+type workItemTypeRule struct {
+	pattern *regexp.Regexp
+	stdType string
+}
+
+func compileWorkItemTypeRules(rule *models.AzuredevopsTransformationRule) ([]workItemTypeRule, errors.Error) {
+	var rules []workItemTypeRule
+	if rule == nil {
+		return rules, nil
+	}
+	for _, typePattern := range [][2]string{
+		{ticket.INCIDENT, rule.IssueTypeIncident},
+		{ticket.BUG, rule.IssueTypeBug},
+		{ticket.REQUIREMENT, rule.IssueTypeRequirement},
+	} {
+		if typePattern[1] == "" {
+			continue
+		}
+		re, err := errors.Convert01(regexp.Compile(typePattern[1]))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid work item type pattern for %s", typePattern[0]))
+		}
+		rules = append(rules, workItemTypeRule{pattern: re, stdType: typePattern[0]})
+	}
+	return rules, nil
+}
+
+func ExtractApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEM_TABLE)
+	typeRules, err := compileWorkItemTypeRules(data.Options.AzuredevopsTransformationRule)
+	if err != nil {
+		return err
+	}
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiWorkItem := &AzuredevopsApiWorkItem{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiWorkItem))
+			if err != nil {
+				return nil, err
+			}
+			fields := apiWorkItem.Fields
+			workItem := &models.AzuredevopsWorkItem{
+				ConnectionId:  data.Options.ConnectionId,
+				AzuredevopsId: apiWorkItem.Id,
+				RepositoryId:  data.Options.RepositoryId,
+				ProjectId:     data.Options.ProjectId,
+				Rev:           apiWorkItem.Rev,
+				Url:           apiWorkItem.Url,
+				Title:         stringField(fields, "System.Title"),
+				Description:   stringField(fields, "System.Description"),
+				WorkItemType:  stringField(fields, "System.WorkItemType"),
+				State:         stringField(fields, "System.State"),
+				Severity:      stringField(fields, "Microsoft.VSTS.Common.Severity"),
+				AreaPath:      stringField(fields, "System.AreaPath"),
+				IterationPath: stringField(fields, "System.IterationPath"),
+				ParentId:      int(floatField(fields, "System.Parent")),
+				CreatedDate:   timeField(fields, "System.CreatedDate"),
+				ChangedDate:   timeField(fields, "System.ChangedDate"),
+			}
+			if priority := floatField(fields, "Microsoft.VSTS.Common.Priority"); priority > 0 {
+				workItem.Priority = fmt.Sprintf("%v", priority)
+			}
+			// the field holding the estimation depends on the process template
+			for _, name := range []string{"Microsoft.VSTS.Scheduling.StoryPoints", "Microsoft.VSTS.Scheduling.Effort", "Microsoft.VSTS.Scheduling.Size"} {
+				if _, ok := fields[name]; ok {
+					workItem.StoryPoint = floatField(fields, name)
+					break
+				}
+			}
+			workItem.StdType = defaultWorkItemTypes[workItem.WorkItemType]
+			for _, rule := range typeRules {
+				if rule.pattern.MatchString(workItem.WorkItemType) {
+					workItem.StdType = rule.stdType
+					break
+				}
+			}
+			workItem.StdState = defaultWorkItemStates[workItem.State]
+			if workItem.StdState == "" {
+				workItem.StdState = ticket.OTHER
+			}
+			if workItem.StdState == ticket.DONE {
+				workItem.ResolvedDate = timeField(fields, "Microsoft.VSTS.Common.ClosedDate")
+				if workItem.ResolvedDate == nil {
+					workItem.ResolvedDate = timeField(fields, "Microsoft.VSTS.Common.ResolvedDate")
+				}
+			}
+
+			results := make([]interface{}, 0, 3)
+			if creator := convertIdentity(identityField(fields, "System.CreatedBy"), data.Options.ConnectionId); creator != nil {
+				workItem.CreatorId = creator.AccountId
+				workItem.CreatorName = creator.DisplayName
+				results = append(results, creator)
+			}
+			if assignee := convertIdentity(identityField(fields, "System.AssignedTo"), data.Options.ConnectionId); assignee != nil {
+				workItem.AssigneeId = assignee.AccountId
+				workItem.AssigneeName = assignee.DisplayName
+				results = append(results, assignee)
+			}
+			results = append(results, workItem)
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func stringField(fields map[string]json.RawMessage, name string) string {
+	var value string
+	if raw, ok := fields[name]; ok {
+		_ = json.Unmarshal(raw, &value)
+	}
+	return value
+}
+
+func floatField(fields map[string]json.RawMessage, name string) float64 {
+	var value float64
+	if raw, ok := fields[name]; ok {
+		_ = json.Unmarshal(raw, &value)
+	}
+	return value
+}
+
+func timeField(fields map[string]json.RawMessage, name string) *time.Time {
+	raw, ok := fields[name]
+	if !ok {
+		return nil
+	}
+	value := &api.Iso8601Time{}
+	if json.Unmarshal(raw, value) != nil {
+		return nil
+	}
+	return nullableTime(value)
+}
+
+// identityField reads an identity field, which is either an identity object or a `Display Name <unique name>`
+// string depending on the api version, the unique name stands in for the id of the latter
+func identityField(fields map[string]json.RawMessage, name string) *AzuredevopsIdentity {
+	raw, ok := fields[name]
+	if !ok {
+		return nil
+	}
+	identity := &AzuredevopsIdentity{}
+	if json.Unmarshal(raw, identity) == nil {
+		return identity
+	}
+	var value string
+	if json.Unmarshal(raw, &value) != nil || value == "" {
+		return nil
+	}
+	identity.DisplayName = value
+	identity.UniqueName = value
+	if start, end := strings.LastIndex(value, "<"), strings.LastIndex(value, ">"); start >= 0 && end > start {
+		identity.DisplayName = strings.TrimSpace(value[:start])
+		identity.UniqueName = value[start+1 : end]
+	}
+	identity.Id = identity.UniqueName
+	return identity
+}
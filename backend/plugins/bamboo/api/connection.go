@@ -122,7 +122,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all bamboo connections
 // @Description Get all bamboo connections
 // @Tags plugins/bamboo
-// @Success 200  {object} []models.BambooConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internel Error"
 // @Router /plugins/bamboo/connections [GET]
@@ -75,6 +75,8 @@ func TestBambooDeployBuildDataFlow(t *testing.T) {
 			"allowed_to_create_version",
 			"allowed_to_set_version_status",
 			"environment",
+			"env_id",
+			"deployment_version_id",
 		),
 	)
 
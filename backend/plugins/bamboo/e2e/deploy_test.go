@@ -20,7 +20,9 @@ package e2e
 import (
 	"testing"
 
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/bamboo/impl"
 	"github.com/apache/incubator-devlake/plugins/bamboo/models"
 	"github.com/apache/incubator-devlake/plugins/bamboo/tasks"
@@ -31,11 +33,15 @@ func TestBambooDeployDataFlow(t *testing.T) {
 	dataflowTester := e2ehelper.NewDataFlowTester(t, "bamboo", bamboo)
 	taskData := &tasks.BambooTaskData{
 		Options: &models.BambooOptions{
-			ConnectionId:             3,
-			ProjectKey:               "TEST1",
-			BambooTransformationRule: new(models.BambooTransformationRule),
+			ConnectionId: 3,
+			ProjectKey:   "TEST1",
+			BambooTransformationRule: &models.BambooTransformationRule{
+				ProductionPattern: "(?i)lake",
+			},
 		},
+		RegexEnricher: helper.NewRegexEnricher(),
 	}
+	taskData.RegexEnricher.TryAdd(devops.PRODUCTION, taskData.Options.ProductionPattern)
 
 	// import raw data table
 	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bamboo_api_deploy.csv", "_raw_bamboo_api_deploy")
@@ -43,8 +49,21 @@ func TestBambooDeployDataFlow(t *testing.T) {
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_bamboo_plans_for_deploy.csv", models.BambooPlan{})
 
 	// verify extraction
+	dataflowTester.FlushTabler(&models.BambooDeployProject{})
 	dataflowTester.FlushTabler(&models.BambooDeployEnvironment{})
 	dataflowTester.Subtask(tasks.ExtractDeployMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.BambooDeployProject{},
+		"./snapshot_tables/_tool_bamboo_deploy_projects.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"deploy_project_id",
+			"name",
+			"description",
+			"plan_key",
+			"project_key",
+		),
+	)
 	dataflowTester.VerifyTable(
 		models.BambooDeployEnvironment{},
 		"./snapshot_tables/_tool_bamboo_deploy_environment.csv",
@@ -66,4 +85,17 @@ func TestBambooDeployDataFlow(t *testing.T) {
 		),
 	)
 
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdEnvironment{})
+	dataflowTester.Subtask(tasks.ConvertDeployEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdEnvironment{},
+		"./snapshot_tables/cicd_environments.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"name",
+			"type",
+		},
+	)
 }
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bamboo/impl"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models"
+	"github.com/apache/incubator-devlake/plugins/bamboo/tasks"
+)
+
+func TestBambooDeployVersionDataFlow(t *testing.T) {
+	var bamboo impl.Bamboo
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bamboo", bamboo)
+	taskData := &tasks.BambooTaskData{
+		Options: &models.BambooOptions{
+			ConnectionId: 3,
+			ProjectKey:   "TEST1",
+			BambooTransformationRule: &models.BambooTransformationRule{
+				RepoMap: map[string]interface{}{
+					"github:GithubRepo:1:1": []interface{}{float64(1736707)},
+				},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bamboo_api_deploy_version.csv", "_raw_bamboo_api_deploy_version")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.BambooDeployVersion{})
+	dataflowTester.Subtask(tasks.ExtractDeployVersionMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.BambooDeployVersion{},
+		"./snapshot_tables/_tool_bamboo_deploy_versions.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"version_id",
+			"deploy_project_id",
+			"name",
+			"plan_branch_name",
+			"plan_result_key",
+			"creator_user_name",
+			"creation_date",
+			"project_key",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_bamboo_plan_builds_for_deploy.csv", &models.BambooPlanBuild{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_bamboo_plan_build_commits_for_deploy.csv", &models.BambooPlanBuildVcsRevision{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_bamboo_deploy_builds_for_deploy.csv", &models.BambooDeployBuild{})
+	dataflowTester.FlushTabler(&devops.CicdRelease{})
+	dataflowTester.Subtask(tasks.ConvertDeployVersionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdRelease{},
+		"./snapshot_tables/cicd_releases.csv",
+		[]string{
+			"id",
+			"name",
+			"version",
+			"cicd_scope_id",
+			"pipeline_id",
+			"commit_sha",
+			"created_date",
+			"published_date",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertDeployBuildCommitsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"result",
+			"status",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+			"commit_sha",
+			"ref_name",
+			"repo_id",
+			"repo_url",
+		},
+	)
+}
@@ -77,6 +77,10 @@ func (p Bamboo) GetTablesInfo() []dal.Tabler {
 		&models.BambooPlanBuild{},
 		&models.BambooPlanBuildVcsRevision{},
 		&models.BambooJobBuild{},
+		&models.BambooDeployProject{},
+		&models.BambooDeployEnvironment{},
+		&models.BambooDeployVersion{},
+		&models.BambooDeployBuild{},
 	}
 }
 
@@ -97,6 +101,8 @@ func (p Bamboo) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractJobBuildMeta,
 		tasks.CollectDeployMeta,
 		tasks.ExtractDeployMeta,
+		tasks.CollectDeployVersionMeta,
+		tasks.ExtractDeployVersionMeta,
 		tasks.CollectDeployBuildMeta,
 		tasks.ExtractDeployBuildMeta,
 
@@ -104,7 +110,10 @@ func (p Bamboo) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertPlanBuildsMeta,
 		tasks.ConvertPlanVcsMeta,
 		tasks.ConvertProjectsMeta,
+		tasks.ConvertDeployEnvironmentsMeta,
+		tasks.ConvertDeployVersionsMeta,
 		tasks.ConvertDeployBuildsMeta,
+		tasks.ConvertDeployBuildCommitsMeta,
 	}
 }
 
@@ -216,6 +225,9 @@ func (p Bamboo) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"PATCH": api.UpdateTransformationRule,
 			"GET":   api.GetTransformationRule,
 		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopeList,
 			"PUT": api.PutScope,
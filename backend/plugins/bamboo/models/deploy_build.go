@@ -39,6 +39,9 @@ type BambooDeployBuild struct {
 	ProjectKey  string `json:"project_key" gorm:"index"`
 	PlanKey     string `json:"plan_key" gorm:"index"`
 	Environment string `gorm:"type:varchar(255)"`
+	// EnvId is the environment the version was deployed to, DeploymentVersionId is the version deployed
+	EnvId               uint64 `json:"env_id" gorm:"index"`
+	DeploymentVersionId uint64 `json:"deployment_version_id"`
 	ApiBambooOperations
 	common.NoPKModel
 }
@@ -60,6 +63,7 @@ type ApiBambooDeployBuild struct {
 	Key                   ApiBambooDeployBuildKey   `json:"key"`
 	Agent                 ApiBambooDeployBuildAgent `json:"agent"`
 	Operations            ApiBambooOperations       `json:"operations"`
+	DeploymentVersion     ApiBambooDeployVersion    `json:"deploymentVersion"`
 }
 
 func (api *ApiBambooDeployBuild) Convert(op *BambooOptions) *BambooDeployBuild {
@@ -75,6 +79,7 @@ func (api *ApiBambooDeployBuild) Convert(op *BambooOptions) *BambooDeployBuild {
 		ExecutedDate:          unixForBambooDeployBuild(api.ExecutedDate),
 		FinishedDate:          unixForBambooDeployBuild(api.FinishedDate),
 		ReasonSummary:         api.ReasonSummary,
+		DeploymentVersionId:   api.DeploymentVersion.ID,
 		ApiBambooOperations:   api.Operations,
 	}
 }
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/core/models/common"
+
+// BambooDeployProject is a deployment project releasing the artifacts of a plan to its environments
+type BambooDeployProject struct {
+	ConnectionId    uint64 `json:"connection_id" gorm:"primaryKey"`
+	DeployProjectId uint64 `json:"deploy_project_id" gorm:"primaryKey"`
+	Name            string `json:"name" gorm:"type:varchar(255)"`
+	Description     string `json:"description"`
+	PlanKey         string `json:"plan_key" gorm:"index;type:varchar(255)"`
+	ProjectKey      string `json:"project_key" gorm:"index;type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (BambooDeployProject) TableName() string {
+	return "_tool_bamboo_deploy_projects"
+}
+
+func (b *BambooDeployProject) Convert(apiProject *ApiBambooDeployProject) {
+	b.DeployProjectId = apiProject.ID
+	b.Name = apiProject.Name
+	b.Description = apiProject.Description
+	b.PlanKey = apiProject.PlanKey.Key
+}
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models/migrationscripts/archived"
+)
+
+type deployBuild20230705 struct {
+	EnvId               uint64 `gorm:"index"`
+	DeploymentVersionId uint64
+}
+
+func (deployBuild20230705) TableName() string {
+	return "_tool_bamboo_deploy_build"
+}
+
+type addDeployProjectsAndVersions struct{}
+
+func (*addDeployProjectsAndVersions) Up(baseRes context.BasicRes) errors.Error {
+	err := baseRes.GetDal().AutoMigrate(&deployBuild20230705{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(baseRes, &archived.BambooDeployProject{}, &archived.BambooDeployVersion{})
+}
+
+func (*addDeployProjectsAndVersions) Version() uint64 {
+	return 20230705100000
+}
+
+func (*addDeployProjectsAndVersions) Name() string {
+	return "add env_id and deployment_version_id to _tool_bamboo_deploy_build and add bamboo deploy projects and versions"
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type BambooDeployProject struct {
+	ConnectionId    uint64 `json:"connection_id" gorm:"primaryKey"`
+	DeployProjectId uint64 `json:"deploy_project_id" gorm:"primaryKey"`
+	Name            string `json:"name" gorm:"type:varchar(255)"`
+	Description     string `json:"description"`
+	PlanKey         string `json:"plan_key" gorm:"index;type:varchar(255)"`
+	ProjectKey      string `json:"project_key" gorm:"index;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (BambooDeployProject) TableName() string {
+	return "_tool_bamboo_deploy_projects"
+}
+
+type BambooDeployVersion struct {
+	ConnectionId    uint64     `json:"connection_id" gorm:"primaryKey"`
+	VersionId       uint64     `json:"version_id" gorm:"primaryKey"`
+	DeployProjectId uint64     `json:"deploy_project_id" gorm:"index"`
+	Name            string     `json:"name" gorm:"type:varchar(255)"`
+	PlanBranchName  string     `json:"planBranchName" gorm:"type:varchar(255)"`
+	PlanResultKey   string     `json:"planResultKey" gorm:"type:varchar(255)"`
+	CreatorUserName string     `json:"creatorUserName" gorm:"type:varchar(255)"`
+	CreationDate    *time.Time `json:"creationDate"`
+	ProjectKey      string     `json:"project_key" gorm:"index;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (BambooDeployVersion) TableName() string {
+	return "_tool_bamboo_deploy_versions"
+}
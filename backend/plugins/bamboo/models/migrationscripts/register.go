@@ -27,5 +27,6 @@ func All() []plugin.MigrationScript {
 		new(addInitTables),
 		new(addConnectionIdToTransformationRule),
 		new(addTypeAndEnvironment),
+		new(addDeployProjectsAndVersions),
 	}
 }
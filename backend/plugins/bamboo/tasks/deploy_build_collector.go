@@ -37,6 +37,7 @@ var _ plugin.SubTaskEntryPoint = CollectDeployBuild
 
 type InputForEnv struct {
 	EnvId   uint64 `json:"env_id"`
+	EnvName string `json:"env_name"`
 	PlanKey string `json:"plan_key"`
 }
 
@@ -44,7 +45,7 @@ func CollectDeployBuild(taskCtx plugin.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOY_BUILD_TABLE)
 	db := taskCtx.GetDal()
 	clauses := []dal.Clause{
-		dal.Select("env_id,plan_key"),
+		dal.Select("env_id,name AS env_name,plan_key"),
 		dal.From(models.BambooDeployEnvironment{}.TableName()),
 		dal.Where("project_key = ? and connection_id=?", data.Options.ProjectKey, data.Options.ConnectionId),
 	}
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models"
+)
+
+var ConvertDeployBuildCommitsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployBuildCommits",
+	EntryPoint:       ConvertDeployBuildCommits,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table bamboo_deploy_build into  domain layer table cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type deployBuildWithCommit struct {
+	models.BambooDeployBuild
+	PlanBranchName string
+	RepositoryId   int
+	RepositoryName string
+	VcsRevisionKey string
+}
+
+// ConvertDeployBuildCommits links every deployment to the commits of the plan build which produced the version
+// deployed, the versions created without a plan build are not linked to any commit
+func ConvertDeployBuildCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOY_BUILD_TABLE)
+	cursor, err := db.Cursor(
+		dal.Select("db.*, v.plan_branch_name, c.repository_id, c.repository_name, c.vcs_revision_key"),
+		dal.From("_tool_bamboo_deploy_build db"),
+		dal.Join(`join _tool_bamboo_deploy_versions v on v.connection_id = db.connection_id and v.version_id = db.deployment_version_id`),
+		dal.Join(`join _tool_bamboo_plan_build_commits c on c.connection_id = v.connection_id and c.plan_build_key = v.plan_result_key`),
+		dal.Where("db.connection_id = ? and db.project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deployBuildIdGen := didgen.NewDomainIdGenerator(&models.BambooDeployBuild{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.BambooProject{})
+	repoMap := getRepoMap(data.Options.RepoMap)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(deployBuildWithCommit{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployBuild := inputRow.(*deployBuildWithCommit)
+			if deployBuild.StartedDate == nil {
+				return nil, nil
+			}
+			deploymentId := deployBuildIdGen.Generate(data.Options.ConnectionId, deployBuild.DeployBuildId)
+			domainDeployCommit := &devops.CicdDeploymentCommit{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: fmt.Sprintf("%s:%s", deploymentId, deployBuild.RepositoryName),
+				},
+				CicdScopeId:      projectIdGen.Generate(data.Options.ConnectionId, deployBuild.ProjectKey),
+				CicdDeploymentId: deploymentId,
+				Name:             deployBuild.DeploymentVersionName,
+				Result:           devops.GetResult(deployBuildResultRule, deployBuild.DeploymentState),
+				Status:           devops.GetStatus(deployBuildStatusRule, deployBuild.LifeCycleState),
+				Environment:      deployBuild.Environment,
+				CreatedDate:      *deployBuild.StartedDate,
+				StartedDate:      deployBuild.StartedDate,
+				FinishedDate:     deployBuild.FinishedDate,
+				CommitSha:        deployBuild.VcsRevisionKey,
+				RefName:          deployBuild.PlanBranchName,
+				RepoId:           repoMap[deployBuild.RepositoryId],
+				RepoUrl:          deployBuild.RepositoryName,
+			}
+			if deployBuild.QueuedDate != nil {
+				domainDeployCommit.CreatedDate = *deployBuild.QueuedDate
+			}
+			if deployBuild.FinishedDate != nil {
+				durationSec := uint64(deployBuild.FinishedDate.Sub(*deployBuild.StartedDate).Seconds())
+				domainDeployCommit.DurationSec = &durationSec
+			}
+			return []interface{}{
+				domainDeployCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -38,6 +38,19 @@ var ConvertDeployBuildsMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 }
 
+// the deployment results and their life cycle are reported in upper case, e.g. SUCCESS and FINISHED
+var deployBuildResultRule = &devops.ResultRule{
+	Failed:  []string{"FAILED"},
+	Success: []string{"SUCCESS"},
+	Abort:   []string{"REPLACED"},
+	Default: "",
+}
+
+var deployBuildStatusRule = &devops.StatusRule{
+	Done:    []string{"FINISHED", "NOT_BUILT"},
+	Default: devops.IN_PROGRESS,
+}
+
 func ConvertDeployBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_JOB_BUILD_TABLE)
@@ -75,16 +88,8 @@ func ConvertDeployBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 
 				Name: deployBuild.DeploymentVersionName,
 
-				Result: devops.GetResult(&devops.ResultRule{
-					Failed:  []string{"Failed"},
-					Success: []string{"Successful"},
-					Default: "",
-				}, deployBuild.DeploymentState),
-
-				Status: devops.GetStatus(&devops.StatusRule{
-					Done:    []string{"Finished"},
-					Default: devops.IN_PROGRESS,
-				}, deployBuild.LifeCycleState),
+				Result: devops.GetResult(deployBuildResultRule, deployBuild.DeploymentState),
+				Status: devops.GetStatus(deployBuildStatusRule, deployBuild.LifeCycleState),
 
 				//DurationSec:  uint64(deployBuild),
 				StartedDate:  *deployBuild.StartedDate,
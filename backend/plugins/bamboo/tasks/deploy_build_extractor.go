@@ -50,7 +50,12 @@ func ExtractDeployBuild(taskCtx plugin.SubTaskContext) errors.Error {
 
 			build := res.Convert(data.Options)
 			build.PlanKey = input.PlanKey
+			build.EnvId = input.EnvId
 			build.Environment = data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, build.DeploymentVersionName)
+			// the versions are usually named after the build, the environment tells better whether it is production
+			if build.Environment == "" {
+				build.Environment = data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, input.EnvName)
+			}
 
 			return []interface{}{
 				build,
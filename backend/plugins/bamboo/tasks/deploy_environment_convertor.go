@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models"
+)
+
+var ConvertDeployEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployEnvironments",
+	EntryPoint:       ConvertDeployEnvironments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table bamboo_deploy_environment into  domain layer table cicd_environments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOY_TABLE)
+	cursor, err := db.Cursor(
+		dal.From(&models.BambooDeployEnvironment{}),
+		dal.Where("connection_id = ? and project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	environmentIdGen := didgen.NewDomainIdGenerator(&models.BambooDeployEnvironment{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.BambooProject{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BambooDeployEnvironment{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			environment := inputRow.(*models.BambooDeployEnvironment)
+			domainEnvironment := &devops.CicdEnvironment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: environmentIdGen.Generate(data.Options.ConnectionId, environment.EnvId),
+				},
+				CicdScopeId: projectIdGen.Generate(data.Options.ConnectionId, environment.ProjectKey),
+				Name:        environment.Name,
+				Type:        data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, environment.Name),
+			}
+			return []interface{}{
+				domainEnvironment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -72,9 +72,15 @@ func ExtractDeploy(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, err
 			}
 
-			results := make([]interface{}, 0, len(res.Environments))
+			results := make([]interface{}, 0, len(res.Environments)+1)
 
 			if Plans[res.PlanKey.Key] {
+				project := &models.BambooDeployProject{}
+				project.Convert(res)
+				project.ConnectionId = data.Options.ConnectionId
+				project.ProjectKey = data.Options.ProjectKey
+				results = append(results, project)
+
 				for _, env := range res.Environments {
 					body := &models.BambooDeployEnvironment{}
 
@@ -101,6 +107,6 @@ var ExtractDeployMeta = plugin.SubTaskMeta{
 	Name:             "ExtractDeploy",
 	EntryPoint:       ExtractDeploy,
 	EnabledByDefault: true,
-	Description:      "Extract raw data into tool layer table _tool_bamboo_deploy_environment",
+	Description:      "Extract raw data into tool layer table _tool_bamboo_deploy_projects and _tool_bamboo_deploy_environment",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 }
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models"
+)
+
+var ConvertDeployVersionsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployVersions",
+	EntryPoint:       ConvertDeployVersions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table bamboo_deploy_versions into  domain layer table cicd_releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type deployVersionWithPlanBuild struct {
+	models.BambooDeployVersion
+	VcsRevisionKey string
+}
+
+// ConvertDeployVersions turns the versions into releases, a release is built from the revision of the plan build
+// producing its artifacts
+func ConvertDeployVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOY_VERSION_TABLE)
+	cursor, err := db.Cursor(
+		dal.Select("v.*, pb.vcs_revision_key"),
+		dal.From("_tool_bamboo_deploy_versions v"),
+		dal.Join(`left join _tool_bamboo_plan_builds pb on pb.connection_id = v.connection_id and pb.plan_build_key = v.plan_result_key`),
+		dal.Where("v.connection_id = ? and v.project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	versionIdGen := didgen.NewDomainIdGenerator(&models.BambooDeployVersion{})
+	planBuildIdGen := didgen.NewDomainIdGenerator(&models.BambooPlanBuild{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.BambooProject{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(deployVersionWithPlanBuild{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			version := inputRow.(*deployVersionWithPlanBuild)
+			if version.CreationDate == nil {
+				return nil, nil
+			}
+			release := &devops.CicdRelease{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: versionIdGen.Generate(data.Options.ConnectionId, version.VersionId),
+				},
+				Name:          version.Name,
+				Version:       version.Name,
+				CicdScopeId:   projectIdGen.Generate(data.Options.ConnectionId, version.ProjectKey),
+				CommitSha:     version.VcsRevisionKey,
+				CreatedDate:   *version.CreationDate,
+				PublishedDate: version.CreationDate,
+			}
+			if version.PlanResultKey != "" {
+				release.PipelineId = planBuildIdGen.Generate(data.Options.ConnectionId, version.PlanResultKey)
+			}
+			return []interface{}{
+				release,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
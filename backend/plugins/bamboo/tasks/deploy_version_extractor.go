@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bamboo/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractDeployVersion
+
+func ExtractDeployVersion(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOY_VERSION_TABLE)
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+
+		Extract: func(resData *helper.RawData) ([]interface{}, errors.Error) {
+			res := &models.ApiBambooDeployVersion{}
+			err := errors.Convert(json.Unmarshal(resData.Data, res))
+			if err != nil {
+				return nil, err
+			}
+
+			input := &InputForDeployProject{}
+			err = errors.Convert(json.Unmarshal(resData.Input, input))
+			if err != nil {
+				return nil, err
+			}
+
+			version := res.Convert(data.Options)
+			version.DeployProjectId = input.DeployProjectId
+
+			return []interface{}{
+				version,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+var ExtractDeployVersionMeta = plugin.SubTaskMeta{
+	Name:             "ExtractDeployVersion",
+	EntryPoint:       ExtractDeployVersion,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table _tool_bamboo_deploy_versions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
@@ -129,7 +129,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all bitbucket connections
 // @Description Get all bitbucket connections
 // @Tags plugins/bitbucket
-// @Success 200  {object} []models.BitbucketConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/bitbucket/connections [GET]
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
+)
+
+func TestBranchRestrictionsDataFlow(t *testing.T) {
+	var bitbucket impl.Bitbucket
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket", bitbucket)
+
+	taskData := &tasks.BitbucketTaskData{
+		Options: &tasks.BitbucketOptions{
+			ConnectionId: 1,
+			FullName:     "likyh/likyhphp",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_api_branch_restrictions.csv", "_raw_bitbucket_api_branch_restrictions")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.BitbucketBranchRestriction{})
+	dataflowTester.Subtask(tasks.ExtractApiBranchRestrictionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.BitbucketBranchRestriction{},
+		"./snapshot_tables/_tool_bitbucket_branch_restrictions.csv",
+		e2ehelper.ColumnWithRawData(
+			"repo_id",
+			"kind",
+			"branch_match_kind",
+			"pattern",
+			"branch_type",
+			"value",
+		),
+	)
+
+	// verify conversion, the restrictions of each branch are merged into a single protection
+	dataflowTester.FlushTabler(&code.RepoBranchProtection{})
+	dataflowTester.Subtask(tasks.ConvertBranchRestrictionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.RepoBranchProtection{},
+		"./snapshot_tables/repo_branch_protections.csv",
+		e2ehelper.ColumnWithRawData(
+			"repo_id",
+			"branch",
+			"required_approving_review_count",
+			"require_code_owner_reviews",
+			"dismiss_stale_reviews",
+			"required_status_checks",
+			"require_up_to_date_branch",
+			"enforce_admins",
+			"allow_force_pushes",
+			"allow_deletions",
+			"require_linear_history",
+			"require_signed_commits",
+			"require_conversation_resolution",
+		),
+	)
+}
@@ -90,6 +90,7 @@ func TestPrDataFlow(t *testing.T) {
 	)
 
 	// verify pr conversion
+	dataflowTester.FlushTabler(&models.BitbucketPrDiffstat{})
 	dataflowTester.FlushTabler(&code.PullRequest{})
 	dataflowTester.Subtask(tasks.ConvertPullRequestsMeta, taskData)
 	dataflowTester.VerifyTable(
@@ -94,7 +94,6 @@ func TestRepoDataFlow(t *testing.T) {
 			"owner_id",
 			"language",
 			"forked_from",
-			"deleted",
 		),
 	)
 	dataflowTester.VerifyTable(
@@ -65,6 +65,7 @@ func (p Bitbucket) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.BitbucketConnection{},
 		&models.BitbucketAccount{},
+		&models.BitbucketBranchRestriction{},
 		&models.BitbucketCommit{},
 		&models.BitbucketPullRequest{},
 		&models.BitbucketIssue{},
@@ -91,6 +92,9 @@ func (p Bitbucket) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectApiPrCommitsMeta,
 		tasks.ExtractApiPrCommitsMeta,
 
+		tasks.CollectApiPrDiffstatsMeta,
+		tasks.ExtractApiPrDiffstatsMeta,
+
 		tasks.CollectApiCommitsMeta,
 		tasks.ExtractApiCommitsMeta,
 
@@ -110,17 +114,22 @@ func (p Bitbucket) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectPipelineStepsMeta,
 		tasks.ExtractPipelineStepsMeta,
 
+		tasks.CollectApiBranchRestrictionsMeta,
+		tasks.ExtractApiBranchRestrictionsMeta,
+
 		tasks.ConvertRepoMeta,
 		tasks.ConvertAccountsMeta,
 		tasks.ConvertPullRequestsMeta,
 		tasks.ConvertPrCommentsMeta,
 		tasks.ConvertPrCommitsMeta,
 		tasks.ConvertCommitsMeta,
+		tasks.EnrichPullRequestStatsMeta,
 		tasks.ConvertIssuesMeta,
 		tasks.ConvertIssueCommentsMeta,
 		tasks.ConvertPipelineMeta,
 		tasks.ConvertPipelineStepMeta,
 		tasks.ConvertiDeploymentMeta,
+		tasks.ConvertBranchRestrictionsMeta,
 	}
 }
 
@@ -225,6 +234,9 @@ func (p Bitbucket) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 			"PATCH": api.UpdateTransformationRule,
 			"GET":   api.GetTransformationRule,
 		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
 	}
 }
 
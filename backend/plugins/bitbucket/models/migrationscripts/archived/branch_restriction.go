@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type BitbucketBranchRestriction struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	BitbucketId     int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId          string `gorm:"index;type:varchar(255)"`
+	Kind            string `gorm:"type:varchar(100)"`
+	BranchMatchKind string `gorm:"type:varchar(100)"`
+	Pattern         string `gorm:"type:varchar(255)"`
+	BranchType      string `gorm:"type:varchar(100)"`
+	Value           int
+	archived.NoPKModel
+}
+
+func (BitbucketBranchRestriction) TableName() string {
+	return "_tool_bitbucket_branch_restrictions"
+}
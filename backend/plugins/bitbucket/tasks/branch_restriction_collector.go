@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_BRANCH_RESTRICTION_TABLE = "bitbucket_api_branch_restrictions"
+
+var CollectApiBranchRestrictionsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBranchRestrictions",
+	EntryPoint:       CollectApiBranchRestrictions,
+	EnabledByDefault: false,
+	Description:      "Collect branch restriction data from bitbucket api, requires admin permission on the repo",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func CollectApiBranchRestrictions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_RESTRICTION_TABLE)
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        false,
+		UrlTemplate:        "repositories/{{ .Params.FullName }}/branch-restrictions",
+		Query: GetQueryFields(
+			`values.id,values.kind,values.branch_match_kind,values.pattern,values.branch_type,values.value,` +
+				`page,pagelen,size`),
+		ResponseParser: GetRawMessageFromResponse,
+		GetTotalPages:  GetTotalPagesFromResponse,
+		AfterResponse: func(res *http.Response) errors.Error {
+			// the restrictions are only visible to the admins of the repo
+			if res.StatusCode == http.StatusForbidden {
+				return helper.ErrIgnoreAndContinue
+			}
+			return ignoreHTTPStatus404(res)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
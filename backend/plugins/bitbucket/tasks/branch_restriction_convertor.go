@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+var ConvertBranchRestrictionsMeta = plugin.SubTaskMeta{
+	Name:             "convertBranchRestrictions",
+	EntryPoint:       ConvertBranchRestrictions,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table _tool_bitbucket_branch_restrictions into domain layer table repo_branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertBranchRestrictions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_RESTRICTION_TABLE)
+	db := taskCtx.GetDal()
+
+	// the restrictions are converted per branch, the first restriction of each branch stands for all of them
+	cursor, err := db.Cursor(
+		dal.From(&models.BitbucketBranchRestriction{}),
+		dal.Where(`connection_id = ? AND repo_id = ? AND bitbucket_id IN (
+			SELECT MIN(bitbucket_id) FROM _tool_bitbucket_branch_restrictions
+			WHERE connection_id = ? AND repo_id = ?
+			GROUP BY branch_match_kind, pattern, branch_type
+		)`, data.Options.ConnectionId, data.Options.FullName, data.Options.ConnectionId, data.Options.FullName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	protectionIdGen := didgen.NewDomainIdGenerator(&models.BitbucketBranchRestriction{})
+	repoId := didgen.NewDomainIdGenerator(&models.BitbucketRepo{}).Generate(data.Options.ConnectionId, data.Options.FullName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.BitbucketBranchRestriction{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			first := inputRow.(*models.BitbucketBranchRestriction)
+			var restrictions []models.BitbucketBranchRestriction
+			err := db.All(&restrictions, dal.Where(
+				"connection_id = ? AND repo_id = ? AND branch_match_kind = ? AND pattern = ? AND branch_type = ?",
+				first.ConnectionId, first.RepoId, first.BranchMatchKind, first.Pattern, first.BranchType,
+			))
+			if err != nil {
+				return nil, err
+			}
+			branch := first.Pattern
+			if first.BranchMatchKind == "branching_model" {
+				branch = first.BranchType
+			}
+			protection := convertBranchRestrictions(restrictions)
+			protection.Id = protectionIdGen.Generate(data.Options.ConnectionId, data.Options.FullName, branch)
+			protection.RepoId = repoId
+			protection.Branch = branch
+			return []interface{}{protection}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// convertBranchRestrictions merges the restrictions of a branch, force pushes and deletions are allowed unless
+// restricted. Bitbucket doesn't name the builds required to pass, so RequiredStatusChecks is left empty.
+func convertBranchRestrictions(restrictions []models.BitbucketBranchRestriction) *code.RepoBranchProtection {
+	protection := &code.RepoBranchProtection{
+		AllowForcePushes: true,
+		AllowDeletions:   true,
+	}
+	for _, restriction := range restrictions {
+		switch restriction.Kind {
+		case "require_approvals_to_merge":
+			protection.RequiredApprovingReviewCount = restriction.Value
+		case "require_default_reviewer_approvals_to_merge":
+			protection.RequireCodeOwnerReviews = true
+		case "reset_pullrequest_approvals_on_change":
+			protection.DismissStaleReviews = true
+		case "require_tasks_to_be_completed":
+			protection.RequireConversationResolution = true
+		case "force":
+			protection.AllowForcePushes = false
+		case "delete":
+			protection.AllowDeletions = false
+		}
+	}
+	return protection
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+type bitbucketApiBranchRestrictionResponse struct {
+	Id              int    `json:"id"`
+	Kind            string `json:"kind"`
+	BranchMatchKind string `json:"branch_match_kind"`
+	Pattern         string `json:"pattern"`
+	BranchType      string `json:"branch_type"`
+	Value           *int   `json:"value"`
+}
+
+var ExtractApiBranchRestrictionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBranchRestrictions",
+	EntryPoint:       ExtractApiBranchRestrictions,
+	EnabledByDefault: false,
+	Description:      "Extract raw branch restriction data into tool layer table _tool_bitbucket_branch_restrictions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ExtractApiBranchRestrictions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_RESTRICTION_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			response := &bitbucketApiBranchRestrictionResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, response))
+			if err != nil {
+				return nil, err
+			}
+			restriction := &models.BitbucketBranchRestriction{
+				ConnectionId:    data.Options.ConnectionId,
+				BitbucketId:     response.Id,
+				RepoId:          data.Options.FullName,
+				Kind:            response.Kind,
+				BranchMatchKind: response.BranchMatchKind,
+				Pattern:         response.Pattern,
+				BranchType:      response.BranchType,
+			}
+			if response.Value != nil {
+				restriction.Value = *response.Value
+			}
+			return []interface{}{restriction}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
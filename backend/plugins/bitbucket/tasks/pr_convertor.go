@@ -39,11 +39,35 @@ var ConvertPullRequestsMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
 }
 
+type bitbucketPrDiffstatSum struct {
+	PullRequestId int
+	Additions     int
+	Deletions     int
+	ChangedFiles  int
+}
+
 func ConvertPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
 	db := taskCtx.GetDal()
 	repoId := data.Options.FullName
 
+	// the diff stats of pull requests are summed up from their files
+	var diffstats []bitbucketPrDiffstatSum
+	err := db.All(
+		&diffstats,
+		dal.Select("pull_request_id, SUM(additions) AS additions, SUM(deletions) AS deletions, COUNT(*) AS changed_files"),
+		dal.From(&models.BitbucketPrDiffstat{}),
+		dal.Where("repo_id = ? and connection_id = ?", repoId, data.Options.ConnectionId),
+		dal.Groupby("pull_request_id"),
+	)
+	if err != nil {
+		return err
+	}
+	diffstatMap := make(map[int]*bitbucketPrDiffstatSum, len(diffstats))
+	for i := range diffstats {
+		diffstatMap[diffstats[i].PullRequestId] = &diffstats[i]
+	}
+
 	cursor, err := db.Cursor(
 		dal.From(&models.BitbucketPullRequest{}),
 		dal.Where("repo_id = ? and connection_id = ?", repoId, data.Options.ConnectionId),
@@ -87,6 +111,11 @@ func ConvertPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 				HeadRef:        pr.HeadRef,
 				HeadCommitSha:  pr.HeadCommitSha,
 			}
+			if diffstat := diffstatMap[pr.BitbucketId]; diffstat != nil {
+				domainPr.Additions = diffstat.Additions
+				domainPr.Deletions = diffstat.Deletions
+				domainPr.ChangedFiles = diffstat.ChangedFiles
+			}
 			switch pr.State {
 			case "OPEN":
 				domainPr.Status = code.OPEN
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PULL_REQUEST_DIFFSTATS_TABLE = "bitbucket_api_pull_request_diffstats"
+
+var CollectApiPrDiffstatsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiPullRequestDiffstats",
+	EntryPoint:       CollectApiPullRequestDiffstats,
+	EnabledByDefault: true,
+	Description:      "Collect PullRequestDiffstats data from Bitbucket api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectApiPullRequestDiffstats(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_DIFFSTATS_TABLE)
+	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := GetPullRequestsIterator(taskCtx, collectorWithState)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:             data.ApiClient,
+		PageSize:              100,
+		Incremental:           collectorWithState.IsIncremental(),
+		Input:                 iterator,
+		UrlTemplate:           "repositories/{{ .Params.FullName }}/pullrequests/{{ .Input.BitbucketId }}/diffstat",
+		GetNextPageCustomData: GetNextPageCustomData,
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("pagelen", fmt.Sprintf("%v", reqData.Pager.Size))
+			query.Set("fields", "next,values.status,values.lines_added,values.lines_removed,values.old.path,values.new.path")
+
+			if reqData.CustomData != nil {
+				query.Set("page", reqData.CustomData.(string))
+			}
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+		// the diff of some pr can't be generated anymore, e.g. its source branch was deleted
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
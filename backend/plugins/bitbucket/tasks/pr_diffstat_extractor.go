@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+var ExtractApiPrDiffstatsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequestDiffstats",
+	EntryPoint:       ExtractApiPullRequestDiffstats,
+	EnabledByDefault: true,
+	Description:      "Extract raw PullRequestDiffstats data into tool layer table bitbucket_pull_request_diffstats",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type ApiPrDiffstatResponse struct {
+	Status       string `json:"status"`
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+	Old          *struct {
+		Path string `json:"path"`
+	} `json:"old"`
+	New *struct {
+		Path string `json:"path"`
+	} `json:"new"`
+}
+
+func ExtractApiPullRequestDiffstats(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_DIFFSTATS_TABLE)
+	repoId := data.Options.FullName
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			apiDiffstat := &ApiPrDiffstatResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiDiffstat))
+			if err != nil {
+				return nil, err
+			}
+			pull := &BitbucketInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, pull))
+			if err != nil {
+				return nil, err
+			}
+			// deleted files only have the old path
+			filePath := ""
+			if apiDiffstat.New != nil {
+				filePath = apiDiffstat.New.Path
+			} else if apiDiffstat.Old != nil {
+				filePath = apiDiffstat.Old.Path
+			}
+			return []interface{}{
+				&models.BitbucketPrDiffstat{
+					ConnectionId:  data.Options.ConnectionId,
+					RepoId:        repoId,
+					PullRequestId: pull.BitbucketId,
+					FilePath:      filePath,
+					Status:        apiDiffstat.Status,
+					Additions:     apiDiffstat.LinesAdded,
+					Deletions:     apiDiffstat.LinesRemoved,
+				},
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
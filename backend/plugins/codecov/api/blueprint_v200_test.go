@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/codecov/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	connection := &models.CodecovConnection{
+		BaseConnection: helper.BaseConnection{
+			Name: "codecov-test",
+			Model: common.Model{
+				ID: 1,
+			},
+		},
+		CodecovConn: models.CodecovConn{
+			RestConnection: helper.RestConnection{
+				Endpoint:         "https://api.codecov.io/api/v2/",
+				Proxy:            "",
+				RateLimitPerHour: 0,
+			},
+			CodecovToken: models.CodecovToken{
+				Token: "secret",
+			},
+			Service: "github",
+		},
+	}
+	// Refresh Global Variables and set the sql mock
+	basicRes = NewMockBasicRes()
+	bs := &plugin.BlueprintScopeV200{
+		Entities: []string{plugin.DOMAIN_TYPE_QA},
+		Id:       "example/checkout",
+	}
+	bpScopes := make([]*plugin.BlueprintScopeV200, 0)
+	bpScopes = append(bpScopes, bs)
+	syncPolicy := &plugin.BlueprintSyncPolicy{}
+
+	plan := make(plugin.PipelinePlan, len(bpScopes))
+	plan, err := makeDataSourcePipelinePlanV200(nil, plan, bpScopes, connection, syncPolicy)
+	assert.Nil(t, err)
+
+	expectPlan := plugin.PipelinePlan{
+		plugin.PipelineStage{
+			{
+				Plugin:   "codecov",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"fullName":     "example/checkout",
+					"connectionId": uint64(1),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+}
+
+// NewMockBasicRes FIXME ...
+func NewMockBasicRes() *mockcontext.BasicRes {
+	testCodecovRepo := &models.CodecovRepo{
+		ConnectionId:  1,
+		FullName:      "example/checkout",
+		Owner:         "example",
+		Name:          "checkout",
+		Language:      "go",
+		DefaultBranch: "main",
+	}
+
+	mockRes := new(mockcontext.BasicRes)
+	mockDal := new(mockdal.Dal)
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dst := args.Get(0).(*models.CodecovRepo)
+		*dst = *testCodecovRepo
+	}).Return(nil).Once()
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
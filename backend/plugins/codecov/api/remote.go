@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/codecov/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the repos are grouped by owners
+// @Tags plugins/codecov
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/codecov/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.CodecovConnection) ([]models.CodecovApiOwner, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			return listOwners(basicRes, &connection, queryData)
+		},
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.CodecovConnection) ([]models.CodecovApiRepo, errors.Error) {
+			if gid == "" {
+				return nil, nil
+			}
+			return listRepos(basicRes, &connection, gid, queryData, "")
+		},
+	)
+}
+
+// SearchRemoteScopes filters the repos of every owner by name
+// @Summary filters the repos of every owner by name
+// @Description filters the repos of every owner by name
+// @Tags plugins/codecov
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/codecov/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.CodecovConnection) ([]models.CodecovApiRepo, errors.Error) {
+			// the api searches the repos of a single owner, the first page of each owner is merged
+			if queryData.Page > 1 {
+				return nil, nil
+			}
+			owners, err := listOwners(basicRes, &connection, &api.RemoteQueryData{Page: 1, PerPage: 100})
+			if err != nil {
+				return nil, err
+			}
+			repos := make([]models.CodecovApiRepo, 0)
+			for _, owner := range owners {
+				found, err := listRepos(basicRes, &connection, owner.Username, queryData, queryData.Search[0])
+				if err != nil {
+					return nil, err
+				}
+				repos = append(repos, found...)
+			}
+			return repos, nil
+		},
+	)
+}
+
+func initialQuery(queryData *api.RemoteQueryData) url.Values {
+	query := url.Values{}
+	query.Set("page", fmt.Sprintf("%v", queryData.Page))
+	query.Set("page_size", fmt.Sprintf("%v", queryData.PerPage))
+	return query
+}
+
+// listOwners returns a page of the users and organizations of the service the token can see
+func listOwners(basicRes context2.BasicRes, connection *models.CodecovConnection, queryData *api.RemoteQueryData) ([]models.CodecovApiOwner, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	res, err := apiClient.Get(fmt.Sprintf("%s/", connection.Service), initialQuery(queryData), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody struct {
+		Results []models.CodecovApiOwner `json:"results"`
+	}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	return resBody.Results, nil
+}
+
+// listRepos returns a page of the repos of the owner, filtered by name when a search is given
+func listRepos(basicRes context2.BasicRes, connection *models.CodecovConnection, owner string, queryData *api.RemoteQueryData, search string) ([]models.CodecovApiRepo, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	query := initialQuery(queryData)
+	if search != "" {
+		query.Set("search", search)
+	}
+	res, err := apiClient.Get(fmt.Sprintf("%s/%s/repos", connection.Service, owner), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody struct {
+		Results []models.CodecovApiRepo `json:"results"`
+	}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	return resBody.Results, nil
+}
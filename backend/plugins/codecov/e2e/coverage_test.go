@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/codecov/impl"
+	"github.com/apache/incubator-devlake/plugins/codecov/models"
+	"github.com/apache/incubator-devlake/plugins/codecov/tasks"
+)
+
+func TestCodecovCoverageDataFlow(t *testing.T) {
+
+	var codecov impl.Codecov
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "codecov", codecov)
+
+	taskData := &tasks.CodecovTaskData{
+		Options: &tasks.CodecovOptions{
+			ConnectionId: 1,
+			FullName:     "example/checkout",
+		},
+		Service: "github",
+	}
+
+	// import raw data table, the repo is the one collected by the github plugin
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/repos.csv", &code.Repo{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_codecov_api_commits.csv", "_raw_codecov_api_commits")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.CodecovCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiCommitsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.CodecovCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_codecov_commits.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion, the pending commit has no report processed yet
+	dataflowTester.FlushTabler(&qa.QaCoverage{})
+	dataflowTester.Subtask(tasks.ConvertCoveragesMeta, taskData)
+	dataflowTester.VerifyTable(
+		qa.QaCoverage{},
+		"./snapshot_tables/qa_coverages.csv",
+		[]string{
+			"id",
+			"tool",
+			"repo_id",
+			"commit_sha",
+			"branch",
+			"pipeline_id",
+			"test_run_id",
+			"lines_total",
+			"lines_covered",
+			"branches_total",
+			"branches_covered",
+			"line_coverage",
+			"branch_coverage",
+			"created_date",
+		},
+	)
+}
@@ -0,0 +1,204 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/codecov/api"
+	"github.com/apache/incubator-devlake/plugins/codecov/models"
+	"github.com/apache/incubator-devlake/plugins/codecov/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/codecov/tasks"
+)
+
+var _ plugin.PluginMeta = (*Codecov)(nil)
+var _ plugin.PluginInit = (*Codecov)(nil)
+var _ plugin.PluginTask = (*Codecov)(nil)
+var _ plugin.PluginApi = (*Codecov)(nil)
+var _ plugin.PluginModel = (*Codecov)(nil)
+var _ plugin.PluginMigration = (*Codecov)(nil)
+var _ plugin.CloseablePluginTask = (*Codecov)(nil)
+var _ plugin.PluginSource = (*Codecov)(nil)
+
+type Codecov string
+
+func (p Codecov) Connection() interface{} {
+	return &models.CodecovConnection{}
+}
+
+func (p Codecov) Scope() interface{} {
+	return &models.CodecovRepo{}
+}
+
+func (p Codecov) TransformationRule() interface{} {
+	return nil
+}
+
+func (p Codecov) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Codecov) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.CodecovConnection{},
+		&models.CodecovRepo{},
+		&models.CodecovCommit{},
+	}
+}
+
+func (p Codecov) Description() string {
+	return "To collect and enrich data from Codecov"
+}
+
+func (p Codecov) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiCommitsMeta,
+		tasks.ExtractApiCommitsMeta,
+
+		tasks.ConvertCoveragesMeta,
+	}
+}
+
+func (p Codecov) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.CodecovConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get codecov connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get codecov API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, connection, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &tasks.CodecovTaskData{
+		Options:   op,
+		ApiClient: apiClient,
+		Service:   connection.Service,
+	}
+	if op.TimeAfter != "" {
+		var timeAfter time.Time
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+		taskData.TimeAfter = &timeAfter
+		logger.Debug("collect data updated timeAfter %s", timeAfter)
+	}
+	return taskData, nil
+}
+
+func (p Codecov) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/codecov"
+}
+
+func (p Codecov) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Codecov) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Codecov) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/*scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+	}
+}
+
+func (p Codecov) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.CodecovTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.CodecovOptions,
+	connection *models.CodecovConnection,
+	apiClient *helper.ApiClient) errors.Error {
+	var repo models.CodecovRepo
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&repo, dal.Where(
+		"connection_id = ? AND full_name = ?",
+		op.ConnectionId, op.FullName))
+	if err == nil {
+		return nil
+	}
+	if !db.IsErrorNotFound(err) {
+		return errors.Default.Wrap(err, fmt.Sprintf("fail to find repo %s", op.FullName))
+	}
+	var apiRepo *models.CodecovApiRepo
+	apiRepo, err = tasks.GetApiRepo(op, connection.Service, apiClient)
+	if err != nil {
+		return err
+	}
+	logger.Debug(fmt.Sprintf("Current repo: %s", op.FullName))
+	scope := apiRepo.ConvertApiScope().(*models.CodecovRepo)
+	scope.ConnectionId = op.ConnectionId
+	return db.CreateIfNotExist(scope)
+}
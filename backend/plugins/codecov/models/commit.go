@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// CodecovCommit is a commit of the repo along with the totals of the coverage reports uploaded for it
+type CodecovCommit struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoFullName string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha    string `gorm:"primaryKey;type:varchar(40)"`
+	Branch       string `gorm:"type:varchar(255)"`
+	Message      string
+	State        string `gorm:"type:varchar(20)"`
+	CiPassed     bool
+	Timestamp    *time.Time
+	Files        int
+	Lines        int
+	Hits         int
+	Misses       int
+	Partials     int
+	Branches     int
+	Methods      int
+	Coverage     float64
+	common.NoPKModel
+}
+
+func (CodecovCommit) TableName() string {
+	return "_tool_codecov_commits"
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*CodecovConnection)(nil)
+
+// CodecovToken authenticates with an API access token generated in the settings of the user
+type CodecovToken api.AccessToken
+
+// SetupAuthentication sets up the request headers for authentication
+func (t *CodecovToken) SetupAuthentication(request *http.Request) errors.Error {
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.Token))
+	return nil
+}
+
+// CodecovConn holds the essential information to connect to the Codecov API, the endpoint is the url of the v2 api,
+// i.e. https://api.codecov.io/api/v2/, and the service is the git hosting the repos are on, i.e. github or gitlab
+type CodecovConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	CodecovToken       `mapstructure:",squash"`
+	Service            string `mapstructure:"service" validate:"required" json:"service"`
+}
+
+// CodecovConnection holds CodecovConn plus ID/Name for database storage
+type CodecovConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	CodecovConn        `mapstructure:",squash"`
+}
+
+func (CodecovConnection) TableName() string {
+	return "_tool_codecov_connections"
+}
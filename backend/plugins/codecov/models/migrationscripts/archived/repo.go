@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type CodecovRepo struct {
+	ConnectionId       uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	FullName           string `json:"fullName" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"fullName"`
+	Owner              string `json:"owner" gorm:"type:varchar(255)" mapstructure:"owner,omitempty"`
+	Name               string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Language           string `json:"language" gorm:"type:varchar(100)" mapstructure:"language,omitempty"`
+	DefaultBranch      string `json:"defaultBranch" gorm:"type:varchar(255)" mapstructure:"defaultBranch,omitempty"`
+	Private            bool   `json:"private" mapstructure:"private,omitempty"`
+	archived.NoPKModel `json:"-" mapstructure:"-"`
+}
+
+func (CodecovRepo) TableName() string {
+	return "_tool_codecov_repos"
+}
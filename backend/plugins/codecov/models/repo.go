@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*CodecovRepo)(nil)
+var _ plugin.ApiGroup = (*CodecovApiOwner)(nil)
+var _ plugin.ApiScope = (*CodecovApiRepo)(nil)
+
+// CodecovRepo is a repo reporting its coverage to Codecov, the FullName is the one of the repo on its service,
+// i.e. example/checkout
+type CodecovRepo struct {
+	ConnectionId     uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	FullName         string `json:"fullName" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"fullName"`
+	Owner            string `json:"owner" gorm:"type:varchar(255)" mapstructure:"owner,omitempty"`
+	Name             string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Language         string `json:"language" gorm:"type:varchar(100)" mapstructure:"language,omitempty"`
+	DefaultBranch    string `json:"defaultBranch" gorm:"type:varchar(255)" mapstructure:"defaultBranch,omitempty"`
+	Private          bool   `json:"private" mapstructure:"private,omitempty"`
+	common.NoPKModel `json:"-" mapstructure:"-"`
+}
+
+func (CodecovRepo) TableName() string {
+	return "_tool_codecov_repos"
+}
+
+func (r CodecovRepo) ScopeId() string {
+	return r.FullName
+}
+
+func (r CodecovRepo) ScopeName() string {
+	return r.FullName
+}
+
+// CodecovApiRepo is a repo returned by the api, the owner is the one of the author of the repo
+type CodecovApiRepo struct {
+	Name     string `json:"name"`
+	Private  bool   `json:"private"`
+	Language string `json:"language"`
+	Branch   string `json:"branch"`
+	Author   struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+func (r CodecovApiRepo) ConvertApiScope() plugin.ToolLayerScope {
+	return &CodecovRepo{
+		FullName:      fmt.Sprintf("%s/%s", r.Author.Username, r.Name),
+		Owner:         r.Author.Username,
+		Name:          r.Name,
+		Language:      r.Language,
+		DefaultBranch: r.Branch,
+		Private:       r.Private,
+	}
+}
+
+// CodecovApiOwner is a user or an organization of the service, the repos are grouped by their owner
+type CodecovApiOwner struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+func (o CodecovApiOwner) GroupId() string {
+	return o.Username
+}
+
+func (o CodecovApiOwner) GroupName() string {
+	return o.Username
+}
@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/codecov/models"
+)
+
+type CodecovApiParams struct {
+	ConnectionId uint64
+	FullName     string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *CodecovTaskData) {
+	data := taskCtx.GetData().(*CodecovTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: CodecovApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			FullName:     data.Options.FullName,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// repoUrl prefixes the path with the repo of the scope, the repos are reached through the service and their owner
+func repoUrl(data *CodecovTaskData, path string) string {
+	return fmt.Sprintf("%s/%s", repoPath(data.Service, data.Options.FullName), path)
+}
+
+// repoPath returns the path of the repo detail, the full name holds both the owner and the name of the repo
+func repoPath(service string, fullName string) string {
+	ownerAndName := strings.SplitN(fullName, "/", 2)
+	return fmt.Sprintf("%s/%s/repos/%s", service, ownerAndName[0], ownerAndName[len(ownerAndName)-1])
+}
+
+// pageQuery pages the lists by their page number
+func pageQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
+	query.Set("page_size", fmt.Sprintf("%v", reqData.Pager.Size))
+	return query, nil
+}
+
+// nextPage finishes the collection once the api has no next page to offer
+func nextPage(reqData *api.RequestData, res *http.Response) (interface{}, errors.Error) {
+	body := &struct {
+		Next *string `json:"next"`
+	}{}
+	err := api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	if body.Next == nil || *body.Next == "" {
+		return nil, api.ErrFinishCollect
+	}
+	return nil, nil
+}
+
+// parseResults returns the results of a page, the collection is finished once the oldest result on the page was
+// created before since, the lists are ordered from the most recent result
+func parseResults(timeField string, since *time.Time) func(res *http.Response) ([]json.RawMessage, errors.Error) {
+	return func(res *http.Response) ([]json.RawMessage, errors.Error) {
+		body := &struct {
+			Results []json.RawMessage `json:"results"`
+		}{}
+		err := api.UnmarshalResponse(res, body)
+		if err != nil || len(body.Results) == 0 || since == nil {
+			return body.Results, err
+		}
+		oldest := map[string]json.RawMessage{}
+		err = errors.Convert(json.Unmarshal(body.Results[len(body.Results)-1], &oldest))
+		if err != nil {
+			return nil, err
+		}
+		var oldestTime *api.Iso8601Time
+		if oldest[timeField] != nil {
+			err = errors.Convert(json.Unmarshal(oldest[timeField], &oldestTime))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if oldestTime != nil && oldestTime.ToTime().Before(*since) {
+			return body.Results, api.ErrFinishCollect
+		}
+		return body.Results, nil
+	}
+}
+
+// GetApiRepo fetches a repo of the service by its full name
+func GetApiRepo(op *CodecovOptions, service string, apiClient aha.ApiClientAbstract) (*models.CodecovApiRepo, errors.Error) {
+	res, err := apiClient.Get(repoPath(service, op.FullName), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting repo detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	body := &models.CodecovApiRepo{}
+	err = api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
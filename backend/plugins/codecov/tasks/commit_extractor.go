@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/codecov/models"
+)
+
+var ExtractApiCommitsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiCommits",
+	EntryPoint:       ExtractApiCommits,
+	EnabledByDefault: true,
+	Description:      "Extract raw commits data into tool layer table _tool_codecov_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_QA},
+}
+
+type CodecovApiCommit struct {
+	CommitId  string           `json:"commitid"`
+	Message   string           `json:"message"`
+	Timestamp *api.Iso8601Time `json:"timestamp"`
+	CiPassed  bool             `json:"ci_passed"`
+	Branch    string           `json:"branch"`
+	State     string           `json:"state"`
+	// the totals are missing until a report was processed for the commit
+	Totals *struct {
+		Files    int     `json:"files"`
+		Lines    int     `json:"lines"`
+		Hits     int     `json:"hits"`
+		Misses   int     `json:"misses"`
+		Partials int     `json:"partials"`
+		Branches int     `json:"branches"`
+		Methods  int     `json:"methods"`
+		Coverage float64 `json:"coverage"`
+	} `json:"totals"`
+}
+
+func ExtractApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiCommit := &CodecovApiCommit{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiCommit))
+			if err != nil {
+				return nil, err
+			}
+			// the commits made before timeAfter are skipped
+			if data.TimeAfter != nil && apiCommit.Timestamp != nil && apiCommit.Timestamp.ToTime().Before(*data.TimeAfter) {
+				return nil, nil
+			}
+			commit := &models.CodecovCommit{
+				ConnectionId: data.Options.ConnectionId,
+				RepoFullName: data.Options.FullName,
+				CommitSha:    apiCommit.CommitId,
+				Branch:       apiCommit.Branch,
+				Message:      apiCommit.Message,
+				State:        apiCommit.State,
+				CiPassed:     apiCommit.CiPassed,
+				Timestamp:    apiCommit.Timestamp.ToNullableTime(),
+			}
+			if apiCommit.Totals != nil {
+				commit.Files = apiCommit.Totals.Files
+				commit.Lines = apiCommit.Totals.Lines
+				commit.Hits = apiCommit.Totals.Hits
+				commit.Misses = apiCommit.Totals.Misses
+				commit.Partials = apiCommit.Totals.Partials
+				commit.Branches = apiCommit.Totals.Branches
+				commit.Methods = apiCommit.Totals.Methods
+				commit.Coverage = apiCommit.Totals.Coverage
+			}
+			return []interface{}{commit}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/codecov/models"
+)
+
+var ConvertCoveragesMeta = plugin.SubTaskMeta{
+	Name:             "convertCoverages",
+	EntryPoint:       ConvertCoverages,
+	EnabledByDefault: true,
+	Description:      "Convert the coverage totals of tool layer table codecov_commits into domain layer table qa_coverages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_QA},
+}
+
+// the hosts of the services supported by codecov, the repos are named after their owner on the service
+var serviceHosts = map[string]string{
+	"github":    "https://github.com/",
+	"gitlab":    "https://gitlab.com/",
+	"bitbucket": "https://bitbucket.org/",
+}
+
+// findRepoId looks up the repo among the ones collected by the scm plugins, the coverages are keyed by the commit
+// alone when it was not collected
+func findRepoId(db dal.Dal, data *CodecovTaskData) (string, errors.Error) {
+	if serviceHosts[data.Service] == "" {
+		return "", nil
+	}
+	repo := &code.Repo{}
+	err := db.First(repo, dal.Where("url = ?", serviceHosts[data.Service]+data.Options.FullName))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return repo.Id, nil
+}
+
+func ConvertCoverages(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_TABLE)
+	db := taskCtx.GetDal()
+	repoId, err := findRepoId(db, data)
+	if err != nil {
+		return err
+	}
+	// the commits without any processed report have no coverage to tell
+	cursor, err := db.Cursor(
+		dal.From(&models.CodecovCommit{}),
+		dal.Where("connection_id = ? AND repo_full_name = ? AND lines > 0", data.Options.ConnectionId, data.Options.FullName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	commitIdGen := didgen.NewDomainIdGenerator(&models.CodecovCommit{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.CodecovCommit{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			commit := inputRow.(*models.CodecovCommit)
+			coverage := &qa.QaCoverage{
+				DomainEntity:  domainlayer.DomainEntity{Id: commitIdGen.Generate(commit.ConnectionId, commit.RepoFullName, commit.CommitSha)},
+				Tool:          "codecov",
+				RepoId:        repoId,
+				CommitSha:     commit.CommitSha,
+				Branch:        commit.Branch,
+				LinesTotal:    commit.Lines,
+				LinesCovered:  commit.Hits,
+				BranchesTotal: commit.Branches,
+				LineCoverage:  commit.Coverage,
+				CreatedDate:   commit.Timestamp,
+			}
+			return []interface{}{coverage}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/datadog/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the services are not grouped
+// @Tags plugins/datadog
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/datadog/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		nil,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.DatadogConnection) ([]models.DatadogApiService, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			return listServices(basicRes, &connection, queryData, "")
+		},
+	)
+}
+
+// SearchRemoteScopes use the Search API and only return service
+// @Summary use the Search API and only return service
+// @Description use the Search API and only return service
+// @Tags plugins/datadog
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/datadog/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.DatadogConnection) ([]models.DatadogApiService, errors.Error) {
+			return listServices(basicRes, &connection, queryData, queryData.Search[0])
+		},
+	)
+}
+
+// listServices returns a page of the service catalog, the api can not search by name so that the page is filtered
+// when a search is given
+func listServices(basicRes context2.BasicRes, connection *models.DatadogConnection, queryData *api.RemoteQueryData, search string) ([]models.DatadogApiService, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	query := url.Values{}
+	query.Set("page[number]", fmt.Sprintf("%v", queryData.Page-1))
+	query.Set("page[size]", fmt.Sprintf("%v", queryData.PerPage))
+	res, err := apiClient.Get("api/v2/services/definitions", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody struct {
+		Data []models.DatadogApiService `json:"data"`
+	}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	if search == "" {
+		return resBody.Data, nil
+	}
+	services := make([]models.DatadogApiService, 0)
+	for _, service := range resBody.Data {
+		if strings.Contains(service.Attributes.Schema.DdService, search) {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
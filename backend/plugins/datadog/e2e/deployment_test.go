@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/datadog/impl"
+	"github.com/apache/incubator-devlake/plugins/datadog/models"
+	"github.com/apache/incubator-devlake/plugins/datadog/tasks"
+)
+
+func TestDatadogDeploymentDataFlow(t *testing.T) {
+
+	var datadog impl.Datadog
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "datadog", datadog)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "(?i)deploy")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "prod")
+	taskData := &tasks.DatadogTaskData{
+		Options: &tasks.DatadogOptions{
+			ConnectionId: 1,
+			ServiceName:  "checkout",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_datadog_services.csv", &models.DatadogService{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_datadog_api_events.csv", "_raw_datadog_api_events")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.DatadogEvent{})
+	dataflowTester.Subtask(tasks.ExtractApiEventsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.DatadogEvent{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_datadog_events.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertServiceMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	// only the events which are not monitor alerts and whose title matches the deployment pattern are deployments
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"result",
+			"status",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+			"commit_sha",
+			"ref_name",
+			"repo_id",
+			"repo_url",
+		},
+	)
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/datadog/impl"
+	"github.com/apache/incubator-devlake/plugins/datadog/models"
+	"github.com/apache/incubator-devlake/plugins/datadog/tasks"
+)
+
+func TestDatadogMonitorAlertDataFlow(t *testing.T) {
+
+	var datadog impl.Datadog
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "datadog", datadog)
+
+	taskData := &tasks.DatadogTaskData{
+		Options: &tasks.DatadogOptions{
+			ConnectionId: 1,
+			ServiceName:  "checkout",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_datadog_services.csv", &models.DatadogService{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_datadog_events.csv", &models.DatadogEvent{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_datadog_api_monitors.csv", "_raw_datadog_api_monitors")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.DatadogMonitor{})
+	dataflowTester.Subtask(tasks.ExtractApiMonitorsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.DatadogMonitor{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_datadog_monitors.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertServiceMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Board{},
+		"./snapshot_tables/boards.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	// the re-triggering and the warning of the monitors do not start incidents
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertMonitorAlertsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"status",
+			"original_status",
+			"priority",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"creator_name",
+			"assignee_name",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		[]string{
+			"board_id",
+			"issue_id",
+		},
+	)
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*DatadogConnection)(nil)
+
+// DatadogKeys authenticates with an API key and an application key, both are required by the read endpoints
+type DatadogKeys struct {
+	ApiKey         string `mapstructure:"apiKey" validate:"required" json:"apiKey" gorm:"serializer:encdec"`
+	ApplicationKey string `mapstructure:"applicationKey" validate:"required" json:"applicationKey" gorm:"serializer:encdec"`
+}
+
+// SetupAuthentication sets up the request headers for authentication
+func (k *DatadogKeys) SetupAuthentication(request *http.Request) errors.Error {
+	request.Header.Set("DD-API-KEY", k.ApiKey)
+	request.Header.Set("DD-APPLICATION-KEY", k.ApplicationKey)
+	return nil
+}
+
+// DatadogConn holds the essential information to connect to the Datadog API,
+// the endpoint is the api of the site, i.e. https://api.datadoghq.com/ or https://api.datadoghq.eu/
+type DatadogConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	DatadogKeys        `mapstructure:",squash"`
+}
+
+// DatadogConnection holds DatadogConn plus ID/Name for database storage
+type DatadogConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	DatadogConn        `mapstructure:",squash"`
+}
+
+func (DatadogConnection) TableName() string {
+	return "_tool_datadog_connections"
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// alert types of the events, a monitor posts an error event when it triggers and a success event when it recovers
+const (
+	ALERT_TYPE_ERROR   = "error"
+	ALERT_TYPE_WARNING = "warning"
+	ALERT_TYPE_INFO    = "info"
+	ALERT_TYPE_SUCCESS = "success"
+)
+
+// DatadogEvent is an event of the event stream tagged with the service, either posted by a deployment
+// tool or by a monitor transition when MonitorId is set
+type DatadogEvent struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	DatadogId      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	ServiceName    string `gorm:"index;type:varchar(255)"`
+	Title          string
+	Text           string
+	AlertType      string `gorm:"type:varchar(20)"`
+	Priority       string `gorm:"type:varchar(20)"`
+	SourceTypeName string `gorm:"type:varchar(100)"`
+	MonitorId      uint64 `gorm:"index"`
+	// Env, Version, CommitSha and RepoUrl are read from the `env`, `version`, `git.commit.sha` and
+	// `git.repository_url` tags of the event
+	Env          string `gorm:"type:varchar(100)"`
+	Version      string `gorm:"type:varchar(100)"`
+	CommitSha    string `gorm:"type:varchar(40)"`
+	RepoUrl      string `gorm:"type:varchar(255)"`
+	Url          string `gorm:"type:varchar(255)"`
+	DateHappened time.Time
+	common.NoPKModel
+}
+
+func (DatadogEvent) TableName() string {
+	return "_tool_datadog_events"
+}
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type BaseConnection struct {
+	Name string `gorm:"type:varchar(100);uniqueIndex" json:"name" validate:"required"`
+	archived.Model
+}
+
+type RestConnection struct {
+	Endpoint         string `mapstructure:"endpoint" validate:"required" json:"endpoint"`
+	Proxy            string `mapstructure:"proxy" json:"proxy"`
+	RateLimitPerHour int    `comment:"api request rate limit per hour" json:"rateLimitPerHour"`
+}
+
+type DatadogKeys struct {
+	ApiKey         string `mapstructure:"apiKey" validate:"required" json:"apiKey" encrypt:"yes"`
+	ApplicationKey string `mapstructure:"applicationKey" validate:"required" json:"applicationKey" encrypt:"yes"`
+}
+
+type DatadogConn struct {
+	RestConnection `mapstructure:",squash"`
+	DatadogKeys    `mapstructure:",squash"`
+}
+
+type DatadogConnection struct {
+	BaseConnection `mapstructure:",squash"`
+	DatadogConn    `mapstructure:",squash"`
+}
+
+func (DatadogConnection) TableName() string {
+	return "_tool_datadog_connections"
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type DatadogEvent struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	DatadogId      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	ServiceName    string `gorm:"index;type:varchar(255)"`
+	Title          string
+	Text           string
+	AlertType      string `gorm:"type:varchar(20)"`
+	Priority       string `gorm:"type:varchar(20)"`
+	SourceTypeName string `gorm:"type:varchar(100)"`
+	MonitorId      uint64 `gorm:"index"`
+	Env            string `gorm:"type:varchar(100)"`
+	Version        string `gorm:"type:varchar(100)"`
+	CommitSha      string `gorm:"type:varchar(40)"`
+	RepoUrl        string `gorm:"type:varchar(255)"`
+	Url            string `gorm:"type:varchar(255)"`
+	DateHappened   time.Time
+	archived.NoPKModel
+}
+
+func (DatadogEvent) TableName() string {
+	return "_tool_datadog_events"
+}
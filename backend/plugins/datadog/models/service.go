@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*DatadogService)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*DatadogApiService)(nil)
+
+// DatadogService is a service of the service catalog, the events and the monitors are picked by its `service` tag
+type DatadogService struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	Name                 string `json:"name" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"name"`
+	Description          string `json:"description" mapstructure:"description,omitempty"`
+	Team                 string `json:"team" gorm:"type:varchar(255)" mapstructure:"team,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (DatadogService) TableName() string {
+	return "_tool_datadog_services"
+}
+
+func (s DatadogService) ScopeId() string {
+	return s.Name
+}
+
+func (s DatadogService) ScopeName() string {
+	return s.Name
+}
+
+// DatadogApiService is a service definition of the service catalog
+type DatadogApiService struct {
+	Attributes struct {
+		Schema struct {
+			DdService   string `json:"dd-service"`
+			Description string `json:"description"`
+			Team        string `json:"team"`
+		} `json:"schema"`
+	} `json:"attributes"`
+}
+
+func (s DatadogApiService) ConvertApiScope() plugin.ToolLayerScope {
+	return &DatadogService{
+		Name:        s.Attributes.Schema.DdService,
+		Description: s.Attributes.Schema.Description,
+		Team:        s.Attributes.Schema.Team,
+	}
+}
+
+// GroupResponse is required by the remote api helper, the services are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
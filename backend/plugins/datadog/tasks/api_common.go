@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type DatadogApiParams struct {
+	ConnectionId uint64
+	ServiceName  string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *DatadogTaskData) {
+	data := taskCtx.GetData().(*DatadogTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: DatadogApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ServiceName:  data.Options.ServiceName,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// serviceTag is the tag picking the events and the monitors of the service
+func serviceTag(serviceName string) string {
+	return "service:" + serviceName
+}
+
+// tagValue returns the value of the first `key:value` tag with the given key
+func tagValue(tags []string, key string) string {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, key+":") {
+			return strings.TrimPrefix(tag, key+":")
+		}
+	}
+	return ""
+}
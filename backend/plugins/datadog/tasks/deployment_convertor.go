@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/datadog/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert the deployment events of tool layer table datadog_events into domain layer table cicd_pipelines, cicd_tasks and cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EVENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.DatadogEvent{}),
+		dal.Where("connection_id = ? AND service_name = ? AND monitor_id = 0", data.Options.ConnectionId, data.Options.ServiceName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	eventIdGen := didgen.NewDomainIdGenerator(&models.DatadogEvent{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.DatadogService{})
+	scopeId := serviceIdGen.Generate(data.Options.ConnectionId, data.Options.ServiceName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.DatadogEvent{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			event := inputRow.(*models.DatadogEvent)
+			if data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, event.Title) == "" {
+				return nil, nil
+			}
+			// a deployment event is posted once the deployment is over, it is both the pipeline and its only task
+			result := devops.SUCCESS
+			if event.AlertType == models.ALERT_TYPE_ERROR {
+				result = devops.FAILURE
+			}
+			environment := data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, event.Env)
+			id := eventIdGen.Generate(event.ConnectionId, event.DatadogId)
+			results := []interface{}{
+				&devops.CICDPipeline{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         event.Title,
+					Result:       result,
+					Status:       devops.DONE,
+					Type:         devops.DEPLOYMENT,
+					Environment:  environment,
+					CreatedDate:  event.DateHappened,
+					FinishedDate: &event.DateHappened,
+					CicdScopeId:  scopeId,
+				},
+				&devops.CICDTask{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         event.Title,
+					PipelineId:   id,
+					Result:       result,
+					Status:       devops.DONE,
+					Type:         devops.DEPLOYMENT,
+					Environment:  environment,
+					StartedDate:  event.DateHappened,
+					FinishedDate: &event.DateHappened,
+					CicdScopeId:  scopeId,
+				},
+			}
+			// the commit is only known when the event is tagged with the source code integration tags
+			if event.CommitSha != "" && event.RepoUrl != "" {
+				durationSec := uint64(0)
+				results = append(results,
+					&devops.CiCDPipelineCommit{
+						PipelineId: id,
+						CommitSha:  event.CommitSha,
+						RepoUrl:    event.RepoUrl,
+					},
+					// the id is the one dora derives from the pipeline commit, so that both end up with the same row
+					&devops.CicdDeploymentCommit{
+						DomainEntity:     domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", id, event.RepoUrl)},
+						CicdScopeId:      scopeId,
+						CicdDeploymentId: id,
+						Name:             event.Title,
+						Result:           result,
+						Status:           devops.DONE,
+						Environment:      environment,
+						CreatedDate:      event.DateHappened,
+						StartedDate:      &event.DateHappened,
+						FinishedDate:     &event.DateHappened,
+						DurationSec:      &durationSec,
+						CommitSha:        event.CommitSha,
+						RepoUrl:          event.RepoUrl,
+					},
+				)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
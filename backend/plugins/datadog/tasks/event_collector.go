@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_EVENT_TABLE = "datadog_api_events"
+
+// the event stream requires a time range, it is collected from 90 days ago by default
+const defaultEventDays = 90
+
+var CollectApiEventsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiEvents",
+	EntryPoint:       CollectApiEvents,
+	EnabledByDefault: true,
+	Description:      "Collect the events tagged with the service from Datadog api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiEvents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EVENT_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now().AddDate(0, 0, -defaultEventDays)
+	if collectorWithState.IsIncremental() {
+		start = *collectorWithState.LatestState.LatestSuccessStart
+	} else if collectorWithState.TimeAfter != nil {
+		start = *collectorWithState.TimeAfter
+	}
+	end := time.Now()
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient: data.ApiClient,
+		// the api returns the events by pages of 1000, the page size can not be changed
+		PageSize:    1000,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "api/v1/events",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("start", fmt.Sprintf("%d", start.Unix()))
+			query.Set("end", fmt.Sprintf("%d", end.Unix()))
+			query.Set("tags", serviceTag(data.Options.ServiceName))
+			query.Set("unaggregated", "true")
+			query.Set("exclude_aggregate", "true")
+			query.Set("page", fmt.Sprintf("%d", reqData.Pager.Page-1))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var body struct {
+				Events []json.RawMessage `json:"events"`
+			}
+			err := api.UnmarshalResponse(res, &body)
+			if err != nil {
+				return nil, err
+			}
+			return body.Events, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
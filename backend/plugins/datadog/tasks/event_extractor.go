@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/datadog/models"
+)
+
+var ExtractApiEventsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiEvents",
+	EntryPoint:       ExtractApiEvents,
+	EnabledByDefault: true,
+	Description:      "Extract raw events data into tool layer table datadog_events",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
+}
+
+type DatadogApiEvent struct {
+	Id             uint64   `json:"id"`
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	DateHappened   int64    `json:"date_happened"`
+	AlertType      string   `json:"alert_type"`
+	Priority       string   `json:"priority"`
+	SourceTypeName string   `json:"source_type_name"`
+	MonitorId      *uint64  `json:"monitor_id"`
+	Tags           []string `json:"tags"`
+	Url            string   `json:"url"`
+}
+
+func ExtractApiEvents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EVENT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiEvent := &DatadogApiEvent{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiEvent))
+			if err != nil {
+				return nil, err
+			}
+			event := &models.DatadogEvent{
+				ConnectionId:   data.Options.ConnectionId,
+				DatadogId:      apiEvent.Id,
+				ServiceName:    data.Options.ServiceName,
+				Title:          apiEvent.Title,
+				Text:           apiEvent.Text,
+				AlertType:      apiEvent.AlertType,
+				Priority:       apiEvent.Priority,
+				SourceTypeName: apiEvent.SourceTypeName,
+				Env:            tagValue(apiEvent.Tags, "env"),
+				Version:        tagValue(apiEvent.Tags, "version"),
+				CommitSha:      tagValue(apiEvent.Tags, "git.commit.sha"),
+				RepoUrl:        tagValue(apiEvent.Tags, "git.repository_url"),
+				Url:            apiEvent.Url,
+				DateHappened:   time.Unix(apiEvent.DateHappened, 0).UTC(),
+			}
+			if apiEvent.MonitorId != nil {
+				event.MonitorId = *apiEvent.MonitorId
+			}
+			return []interface{}{event}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
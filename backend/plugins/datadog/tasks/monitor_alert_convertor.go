@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/datadog/models"
+)
+
+var ConvertMonitorAlertsMeta = plugin.SubTaskMeta{
+	Name:             "convertMonitorAlerts",
+	EntryPoint:       ConvertMonitorAlerts,
+	EnabledByDefault: true,
+	Description:      "Convert the monitor alerts of tool layer table datadog_events into domain layer table issues and board_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// ConvertMonitorAlerts turns each monitor triggering into an incident, which is resolved by the next recovery of the monitor
+func ConvertMonitorAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EVENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.DatadogEvent{}),
+		dal.Where(
+			"connection_id = ? AND service_name = ? AND monitor_id != 0 AND alert_type = ?",
+			data.Options.ConnectionId, data.Options.ServiceName, models.ALERT_TYPE_ERROR,
+		),
+		dal.Orderby("date_happened"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	eventIdGen := didgen.NewDomainIdGenerator(&models.DatadogEvent{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.DatadogService{})
+	boardId := serviceIdGen.Generate(data.Options.ConnectionId, data.Options.ServiceName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.DatadogEvent{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			event := inputRow.(*models.DatadogEvent)
+
+			// a monitor re-triggering while it is still alerting does not start another incident
+			previous := &models.DatadogEvent{}
+			err := db.First(previous,
+				dal.Where(
+					"connection_id = ? AND monitor_id = ? AND date_happened < ? AND alert_type IN ?",
+					event.ConnectionId, event.MonitorId, event.DateHappened, []string{models.ALERT_TYPE_ERROR, models.ALERT_TYPE_SUCCESS},
+				),
+				dal.Orderby("date_happened DESC"),
+			)
+			if err != nil && !db.IsErrorNotFound(err) {
+				return nil, err
+			}
+			if err == nil && previous.AlertType == models.ALERT_TYPE_ERROR {
+				return nil, nil
+			}
+
+			domainIssue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: eventIdGen.Generate(event.ConnectionId, event.DatadogId)},
+				Url:            event.Url,
+				IssueKey:       fmt.Sprintf("%d", event.DatadogId),
+				Title:          event.Title,
+				Description:    event.Text,
+				Type:           ticket.INCIDENT,
+				Status:         ticket.IN_PROGRESS,
+				OriginalStatus: event.AlertType,
+				CreatedDate:    &event.DateHappened,
+				UpdatedDate:    &event.DateHappened,
+			}
+			monitor := &models.DatadogMonitor{}
+			err = db.First(monitor, dal.Where("connection_id = ? AND datadog_id = ?", event.ConnectionId, event.MonitorId))
+			if err != nil && !db.IsErrorNotFound(err) {
+				return nil, err
+			}
+			if err == nil {
+				domainIssue.Title = monitor.Name
+				if monitor.Priority > 0 {
+					domainIssue.Priority = fmt.Sprintf("P%d", monitor.Priority)
+				}
+			}
+
+			recovery := &models.DatadogEvent{}
+			err = db.First(recovery,
+				dal.Where(
+					"connection_id = ? AND monitor_id = ? AND date_happened > ? AND alert_type = ?",
+					event.ConnectionId, event.MonitorId, event.DateHappened, models.ALERT_TYPE_SUCCESS,
+				),
+				dal.Orderby("date_happened"),
+			)
+			if err != nil && !db.IsErrorNotFound(err) {
+				return nil, err
+			}
+			if err == nil {
+				domainIssue.Status = ticket.DONE
+				domainIssue.OriginalStatus = recovery.AlertType
+				domainIssue.ResolutionDate = &recovery.DateHappened
+				domainIssue.UpdatedDate = &recovery.DateHappened
+				domainIssue.LeadTimeMinutes = int64(recovery.DateHappened.Sub(event.DateHappened).Minutes())
+			}
+			return []interface{}{
+				domainIssue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: domainIssue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_MONITOR_TABLE = "datadog_api_monitors"
+
+var CollectApiMonitorsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiMonitors",
+	EntryPoint:       CollectApiMonitors,
+	EnabledByDefault: true,
+	Description:      "Collect the monitors tagged with the service from Datadog api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiMonitors(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_MONITOR_TABLE)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		UrlTemplate:        "api/v1/monitor",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("monitor_tags", serviceTag(data.Options.ServiceName))
+			query.Set("page", fmt.Sprintf("%d", reqData.Pager.Page-1))
+			query.Set("page_size", fmt.Sprintf("%d", reqData.Pager.Size))
+			return query, nil
+		},
+		ResponseParser: api.GetRawMessageArrayFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/datadog/models"
+)
+
+var ExtractApiMonitorsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiMonitors",
+	EntryPoint:       ExtractApiMonitors,
+	EnabledByDefault: true,
+	Description:      "Extract raw monitors data into tool layer table datadog_monitors",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type DatadogApiMonitor struct {
+	Id           uint64    `json:"id"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	Query        string    `json:"query"`
+	Priority     *int      `json:"priority"`
+	OverallState string    `json:"overall_state"`
+	Created      time.Time `json:"created"`
+	Modified     time.Time `json:"modified"`
+}
+
+func ExtractApiMonitors(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_MONITOR_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiMonitor := &DatadogApiMonitor{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiMonitor))
+			if err != nil {
+				return nil, err
+			}
+			monitor := &models.DatadogMonitor{
+				ConnectionId: data.Options.ConnectionId,
+				DatadogId:    apiMonitor.Id,
+				ServiceName:  data.Options.ServiceName,
+				Name:         apiMonitor.Name,
+				Type:         apiMonitor.Type,
+				Query:        apiMonitor.Query,
+				OverallState: apiMonitor.OverallState,
+				CreatedDate:  apiMonitor.Created,
+				UpdatedDate:  apiMonitor.Modified,
+			}
+			if apiMonitor.Priority != nil {
+				monitor.Priority = *apiMonitor.Priority
+			}
+			return []interface{}{monitor}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
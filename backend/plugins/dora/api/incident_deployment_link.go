@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+// @Summary explain the incident linkage of a project
+// @Description get why each incident of the project was attributed to a deployment, the incidents no rule attributed have an empty deploymentId
+// @Tags plugins/dora
+// @Param projectName query string true "project name"
+// @Param issueId query string false "only explain the incident"
+// @Success 200  {object} []models.IncidentDeploymentLink
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/dora/incident-deployment-links [GET]
+func GetIncidentDeploymentLinks(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	projectName := input.Query.Get("projectName")
+	if projectName == "" {
+		return nil, errors.BadInput.New("projectName is required")
+	}
+	clauses := []dal.Clause{
+		dal.Where("project_name = ?", projectName),
+		dal.Orderby("issue_id"),
+	}
+	if issueId := input.Query.Get("issueId"); issueId != "" {
+		clauses = append(clauses, dal.Where("issue_id = ?", issueId))
+	}
+	links := make([]*models.IncidentDeploymentLink, 0)
+	err := context.GetReplicaDal(basicRes).All(&links, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: links, Status: http.StatusOK}, nil
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
+)
+
+func TestGenerateCommitDeploymentsDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./commit_deployments/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./commit_deployments/cicd_deployment_commits.csv", &devops.CicdDeploymentCommit{})
+	dataflowTester.ImportCsvIntoTabler("./commit_deployments/commits_diffs.csv", &code.CommitsDiff{})
+
+	// verify generator, failed and unfinished deployments and the deployments of other projects are ignored,
+	// and the commits shipped again stay linked to the first deployment
+	dataflowTester.FlushTabler(&devops.CicdCommitDeployment{})
+	dataflowTester.Subtask(tasks.GenerateCommitDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdCommitDeployment{},
+		"./commit_deployments/cicd_commit_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"repo_id",
+			"deployment_commit_id",
+			"cicd_deployment_id",
+			"deployed_date",
+		),
+	)
+}
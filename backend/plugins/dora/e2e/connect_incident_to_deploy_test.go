@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/dora/impl"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
 	"github.com/apache/incubator-devlake/plugins/dora/tasks"
 )
 
@@ -49,9 +50,51 @@ func TestConnectIncidentToDeploymentDataFlow(t *testing.T) {
 
 	// verify converter
 	dataflowTester.FlushTabler(&crossdomain.ProjectIssueMetric{})
+	dataflowTester.FlushTabler(&models.IncidentDeploymentLink{})
 	dataflowTester.Subtask(tasks.ConnectIncidentToDeploymentMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectIssueMetric{}, e2ehelper.TableOptions{
 		CSVRelPath:  "./snapshot_tables/project_issue_metrics.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+	dataflowTester.VerifyTableWithOptions(&models.IncidentDeploymentLink{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_dora_incident_deployment_links.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
+
+func TestConnectIncidentToDeploymentByLinkageRulesDataFlow(t *testing.T) {
+	var plugin impl.Dora
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "dora", plugin)
+
+	taskData := &tasks.DoraTaskData{
+		Options: &tasks.DoraOptions{
+			ProjectName: "project1",
+			IncidentLinkageRules: []tasks.IncidentLinkageRule{
+				{Type: tasks.LINKAGE_RULE_FIELD_REFERENCE, Field: "deployment"},
+				{Type: tasks.LINKAGE_RULE_LABEL_MATCH, Pattern: "^release:(.+)$"},
+				{Type: tasks.LINKAGE_RULE_SERVICE_MAPPING, WindowHours: 72, Services: map[string]string{"web": "repo2"}},
+				{Type: tasks.LINKAGE_RULE_TIME_WINDOW, WindowHours: 48},
+			},
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./incident_linkage/cicd_deployment_commits.csv", &devops.CicdDeploymentCommit{})
+	dataflowTester.ImportCsvIntoTabler("./incident_linkage/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./incident_linkage/board_issues.csv", &ticket.BoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./incident_linkage/issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./incident_linkage/issue_labels.csv", &ticket.IssueLabel{})
+	dataflowTester.ImportCsvIntoTabler("./incident_linkage/custom_fields.csv", &crossdomain.CustomField{})
+
+	// verify converter
+	dataflowTester.FlushTabler(&crossdomain.ProjectIssueMetric{})
+	dataflowTester.FlushTabler(&models.IncidentDeploymentLink{})
+	dataflowTester.Subtask(tasks.ConnectIncidentToDeploymentMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ProjectIssueMetric{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./incident_linkage/project_issue_metrics.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.IncidentDeploymentLink{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./incident_linkage/_tool_dora_incident_deployment_links.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
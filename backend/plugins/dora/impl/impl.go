@@ -20,15 +20,20 @@ package impl
 import (
 	"encoding/json"
 
+	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/dora/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
 	"github.com/apache/incubator-devlake/plugins/dora/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/dora/tasks"
 )
 
 // make sure interface is implemented
 var _ plugin.PluginMeta = (*Dora)(nil)
+var _ plugin.PluginInit = (*Dora)(nil)
+var _ plugin.PluginApi = (*Dora)(nil)
 var _ plugin.PluginTask = (*Dora)(nil)
 var _ plugin.PluginModel = (*Dora)(nil)
 var _ plugin.PluginMetric = (*Dora)(nil)
@@ -64,8 +69,19 @@ func (p Dora) RequiredDataEntities() (data []map[string]interface{}, err errors.
 	}, nil
 }
 
+func (p Dora) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
 func (p Dora) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.IncidentDeploymentLink{},
+		&models.DoraDeployment{},
+		&models.DoraChange{},
+		&models.DoraIncident{},
+		&models.DoraMonthlyMetric{},
+	}
 }
 
 func (p Dora) IsProjectMetric() bool {
@@ -84,9 +100,11 @@ func (p Dora) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.DeploymentCommitsGeneratorMeta,
 		tasks.EnrichPrevSuccessDeploymentCommitMeta,
+		tasks.GenerateCommitDeploymentsMeta,
 		tasks.EnrichTaskEnvMeta,
 		tasks.CalculateChangeLeadTimeMeta,
 		tasks.ConnectIncidentToDeploymentMeta,
+		tasks.RefreshDoraMetricsMeta,
 	}
 }
 
@@ -109,6 +127,14 @@ func (p Dora) MigrationScripts() []plugin.MigrationScript {
 	return migrationscripts.All()
 }
 
+func (p Dora) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"incident-deployment-links": {
+			"GET": api.GetIncidentDeploymentLinks,
+		},
+	}
+}
+
 func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (plugin.PipelinePlan, errors.Error) {
 	op := &tasks.DoraOptions{}
 	err := json.Unmarshal(options, op)
@@ -143,11 +169,14 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 			{
 				Plugin: "dora",
 				Options: map[string]interface{}{
-					"projectName": projectName,
+					"projectName":          projectName,
+					"incidentLinkageRules": op.IncidentLinkageRules,
 				},
 				Subtasks: []string{
+					"generateCommitDeployments",
 					"calculateChangeLeadTime",
 					"ConnectIncidentToDeployment",
+					"refreshDoraMetrics",
 				},
 			},
 		},
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/dora/tasks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,8 +30,13 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 	var dora Dora
 	const projectName = "TestMakePlanV200-project"
 	// mock dora plugin as a metric plugin
+	incidentLinkageRules := []tasks.IncidentLinkageRule{
+		{Type: tasks.LINKAGE_RULE_SERVICE_MAPPING, Services: map[string]string{"api": "github:GithubRepo:1:1"}},
+		{Type: tasks.LINKAGE_RULE_TIME_WINDOW, WindowHours: 24},
+	}
 	option := map[string]interface{}{
-		"projectName": projectName,
+		"projectName":          projectName,
+		"incidentLinkageRules": incidentLinkageRules,
 	}
 
 	optionJson, err := json.Marshal(option)
@@ -39,12 +45,12 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 	doraOutputPlan := plugin.PipelinePlan{
 		plugin.PipelineStage{
 			{
-				Plugin:   "dora",
+				Plugin: "dora",
 				Subtasks: []string{
 					"generateDeploymentCommits",
 					"enrichPrevSuccessDeploymentCommits",
 				},
-				Options:  map[string]interface{}{"projectName": projectName},
+				Options: map[string]interface{}{"projectName": projectName},
 			},
 		},
 		plugin.PipelineStage{
@@ -56,12 +62,17 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 		},
 		plugin.PipelineStage{
 			{
-				Plugin:  "dora",
+				Plugin: "dora",
 				Subtasks: []string{
+					"generateCommitDeployments",
 					"calculateChangeLeadTime",
 					"ConnectIncidentToDeployment",
+					"refreshDoraMetrics",
+				},
+				Options: map[string]interface{}{
+					"projectName":          projectName,
+					"incidentLinkageRules": incidentLinkageRules,
 				},
-				Options: map[string]interface{}{"projectName": projectName},
 			},
 		},
 	}
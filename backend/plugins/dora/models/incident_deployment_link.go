@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IncidentDeploymentLink explains why an incident of a project was attributed to a deployment, the incidents no rule
+// attributed are kept with an empty DeploymentId
+type IncidentDeploymentLink struct {
+	ProjectName  string `gorm:"primaryKey;type:varchar(100)" json:"projectName"`
+	IssueId      string `gorm:"primaryKey;type:varchar(255)" json:"issueId"`
+	DeploymentId string `gorm:"type:varchar(255)" json:"deploymentId"`
+	// RuleIndex is the position of the rule in the incidentLinkageRules of the project, -1 if no rule matched
+	RuleIndex int    `json:"ruleIndex"`
+	RuleType  string `gorm:"type:varchar(50)" json:"ruleType"`
+	Reason    string `json:"reason"`
+	common.NoPKModel
+}
+
+func (IncidentDeploymentLink) TableName() string {
+	return "_tool_dora_incident_deployment_links"
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// DoraDeployment is a production deployment of a project as counted by the DORA metrics, the deployment commits of
+// one deployment are merged into it and it finished along with the last of them
+type DoraDeployment struct {
+	ProjectName   string     `gorm:"primaryKey;type:varchar(100)" json:"projectName"`
+	DeploymentId  string     `gorm:"primaryKey;type:varchar(255)" json:"deploymentId"`
+	FinishedDate  *time.Time `gorm:"index" json:"finishedDate"`
+	IncidentCount int        `json:"incidentCount"`
+	common.NoPKModel
+}
+
+func (DoraDeployment) TableName() string {
+	return "_tool_dora_deployments"
+}
+
+// DoraChange is a pull request of a project deployed to production, its cycle time is the lead time for the change
+type DoraChange struct {
+	ProjectName     string     `gorm:"primaryKey;type:varchar(100)" json:"projectName"`
+	PullRequestId   string     `gorm:"primaryKey;type:varchar(255)" json:"pullRequestId"`
+	DeploymentId    string     `gorm:"type:varchar(255)" json:"deploymentId"`
+	DeployedDate    *time.Time `gorm:"index" json:"deployedDate"`
+	LeadTimeMinutes int64      `json:"leadTimeMinutes"`
+	common.NoPKModel
+}
+
+func (DoraChange) TableName() string {
+	return "_tool_dora_changes"
+}
+
+// DoraIncident is an incident of a project, RestoreMinutes is nil until it is resolved
+type DoraIncident struct {
+	ProjectName    string     `gorm:"primaryKey;type:varchar(100)" json:"projectName"`
+	IssueId        string     `gorm:"primaryKey;type:varchar(255)" json:"issueId"`
+	CreatedDate    *time.Time `gorm:"index" json:"createdDate"`
+	RestoreMinutes *int64     `json:"restoreMinutes"`
+	common.NoPKModel
+}
+
+func (DoraIncident) TableName() string {
+	return "_tool_dora_incidents"
+}
+
+// DoraMonthlyMetric holds the four DORA metrics of a project for a calendar month, Month joins calendar_months.month.
+// The medians are the lower ones, as computed by the dashboards, and are nil for the months without any sample
+type DoraMonthlyMetric struct {
+	ProjectName                 string    `gorm:"primaryKey;type:varchar(100)" json:"projectName"`
+	Month                       string    `gorm:"primaryKey;type:varchar(5)" json:"month"`
+	MonthTimestamp              time.Time `json:"monthTimestamp"`
+	DeploymentCount             int       `json:"deploymentCount"`
+	DeploymentDays              int       `json:"deploymentDays"`
+	FailedDeploymentCount       int       `json:"failedDeploymentCount"`
+	ChangeFailureRate           *float64  `json:"changeFailureRate"`
+	MedianChangeLeadTimeMinutes *int64    `json:"medianChangeLeadTimeMinutes"`
+	IncidentCount               int       `json:"incidentCount"`
+	MedianTimeToRestoreMinutes  *int64    `json:"medianTimeToRestoreMinutes"`
+	common.NoPKModel
+}
+
+func (DoraMonthlyMetric) TableName() string {
+	return "_tool_dora_monthly_metrics"
+}
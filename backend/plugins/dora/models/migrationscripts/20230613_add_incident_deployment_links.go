@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type incidentDeploymentLink20230613 struct {
+	ProjectName  string `gorm:"primaryKey;type:varchar(100)"`
+	IssueId      string `gorm:"primaryKey;type:varchar(255)"`
+	DeploymentId string `gorm:"type:varchar(255)"`
+	RuleIndex    int
+	RuleType     string `gorm:"type:varchar(50)"`
+	Reason       string
+	archived.NoPKModel
+}
+
+func (incidentDeploymentLink20230613) TableName() string {
+	return "_tool_dora_incident_deployment_links"
+}
+
+type addIncidentDeploymentLinks struct{}
+
+func (*addIncidentDeploymentLinks) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &incidentDeploymentLink20230613{})
+}
+
+func (*addIncidentDeploymentLinks) Version() uint64 {
+	return 20230613100000
+}
+
+func (*addIncidentDeploymentLinks) Name() string {
+	return "add _tool_dora_incident_deployment_links table"
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type doraDeployment20230728 struct {
+	ProjectName   string     `gorm:"primaryKey;type:varchar(100)"`
+	DeploymentId  string     `gorm:"primaryKey;type:varchar(255)"`
+	FinishedDate  *time.Time `gorm:"index"`
+	IncidentCount int
+	archived.NoPKModel
+}
+
+func (doraDeployment20230728) TableName() string {
+	return "_tool_dora_deployments"
+}
+
+type doraChange20230728 struct {
+	ProjectName     string     `gorm:"primaryKey;type:varchar(100)"`
+	PullRequestId   string     `gorm:"primaryKey;type:varchar(255)"`
+	DeploymentId    string     `gorm:"type:varchar(255)"`
+	DeployedDate    *time.Time `gorm:"index"`
+	LeadTimeMinutes int64
+	archived.NoPKModel
+}
+
+func (doraChange20230728) TableName() string {
+	return "_tool_dora_changes"
+}
+
+type doraIncident20230728 struct {
+	ProjectName    string     `gorm:"primaryKey;type:varchar(100)"`
+	IssueId        string     `gorm:"primaryKey;type:varchar(255)"`
+	CreatedDate    *time.Time `gorm:"index"`
+	RestoreMinutes *int64
+	archived.NoPKModel
+}
+
+func (doraIncident20230728) TableName() string {
+	return "_tool_dora_incidents"
+}
+
+type doraMonthlyMetric20230728 struct {
+	ProjectName                 string `gorm:"primaryKey;type:varchar(100)"`
+	Month                       string `gorm:"primaryKey;type:varchar(5)"`
+	MonthTimestamp              time.Time
+	DeploymentCount             int
+	DeploymentDays              int
+	FailedDeploymentCount       int
+	ChangeFailureRate           *float64
+	MedianChangeLeadTimeMinutes *int64
+	IncidentCount               int
+	MedianTimeToRestoreMinutes  *int64
+	archived.NoPKModel
+}
+
+func (doraMonthlyMetric20230728) TableName() string {
+	return "_tool_dora_monthly_metrics"
+}
+
+type addDoraMetricTables struct{}
+
+func (*addDoraMetricTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&doraDeployment20230728{},
+		&doraChange20230728{},
+		&doraIncident20230728{},
+		&doraMonthlyMetric20230728{},
+	)
+}
+
+func (*addDoraMetricTables) Version() uint64 {
+	return 20230728100000
+}
+
+func (*addDoraMetricTables) Name() string {
+	return "add the materialized dora metric tables"
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var GenerateCommitDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "generateCommitDeployments",
+	EntryPoint:       GenerateCommitDeployments,
+	EnabledByDefault: false,
+	Description:      "link every commit to the first successful deployment shipping it in cicd_commit_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE},
+}
+
+type shippedCommit struct {
+	common.RawDataOrigin
+	CommitSha          string
+	CicdScopeId        string
+	Environment        string
+	RepoId             string
+	DeploymentCommitId string
+	CicdDeploymentId   string
+	FinishedDate       *time.Time
+}
+
+// GenerateCommitDeployments depends on commits_diffs generated by the refdiff plugin for consecutive successful
+// deployments, so it must be executed after calculateDeploymentCommitsDiff
+func GenerateCommitDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	// the commits shipped by a deployment are the ones between its commit and the commit of the previous one,
+	// deployments are sorted by finished_date so the first deployment shipping a commit comes first
+	cursor, err := db.Cursor(
+		dal.Select(`cd.commit_sha, dc.cicd_scope_id, dc.environment, dc.repo_id,
+			dc.id AS deployment_commit_id, dc.cicd_deployment_id, dc.finished_date`),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("LEFT JOIN cicd_deployment_commits p ON (dc.prev_success_deployment_commit_id = p.id)"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = dc.cicd_scope_id)"),
+		dal.Join("INNER JOIN commits_diffs cd ON (cd.new_commit_sha = dc.commit_sha AND cd.old_commit_sha = COALESCE (p.commit_sha, ''))"),
+		dal.Where(
+			"dc.finished_date IS NOT NULL AND pm.project_name = ? AND dc.result = ?",
+			data.Options.ProjectName, devops.SUCCESS,
+		),
+		dal.Orderby("dc.finished_date, dc.id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: DoraApiParams{
+			ProjectName: data.Options.ProjectName,
+		},
+		Table: "cicd_deployment_commits",
+	}
+	// the linkage isn't derived from raw data, stamp it with the table and params of the project so that the
+	// records of the previous run get deleted before saving
+	rawDataSubTask, err := api.NewRawDataSubTask(rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+	origin := common.RawDataOrigin{
+		RawDataTable:  rawDataSubTask.GetTable(),
+		RawDataParams: rawDataSubTask.GetParams(),
+	}
+
+	shipped := make(map[string]bool)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		BatchSize:          500,
+		InputRowType:       reflect.TypeOf(shippedCommit{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			commit := inputRow.(*shippedCommit)
+			key := commit.CommitSha + "\xff" + commit.CicdScopeId + "\xff" + commit.Environment
+			if shipped[key] {
+				return nil, nil
+			}
+			shipped[key] = true
+			commit.RawDataOrigin = origin
+			return []interface{}{
+				&devops.CicdCommitDeployment{
+					CommitSha:          commit.CommitSha,
+					CicdScopeId:        commit.CicdScopeId,
+					Environment:        commit.Environment,
+					RepoId:             commit.RepoId,
+					DeploymentCommitId: commit.DeploymentCommitId,
+					CicdDeploymentId:   commit.CicdDeploymentId,
+					DeployedDate:       commit.FinishedDate,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,309 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+// RefreshDoraMetricsMeta contains metadata for the RefreshDoraMetrics subtask.
+var RefreshDoraMetricsMeta = plugin.SubTaskMeta{
+	Name:             "refreshDoraMetrics",
+	EntryPoint:       RefreshDoraMetrics,
+	EnabledByDefault: true,
+	Description:      "Materialize the deployment frequency, lead time for changes, change failure rate and time to restore service of the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET},
+}
+
+type doraDeploymentRow struct {
+	DeploymentId  string
+	FinishedDate  *time.Time
+	IncidentCount int
+	common.RawDataOrigin
+}
+
+type doraChangeRow struct {
+	PullRequestId   string
+	DeploymentId    string
+	DeployedDate    *time.Time
+	LeadTimeMinutes int64
+	common.RawDataOrigin
+}
+
+type doraIncidentRow struct {
+	IssueId        string
+	CreatedDate    *time.Time
+	RestoreMinutes *int64
+	common.RawDataOrigin
+}
+
+// RefreshDoraMetrics rebuilds the materialized DORA tables of the project from the domain layer, it runs after the
+// deployments, the change lead times and the incident attributions of the project were calculated
+func RefreshDoraMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	projectName := data.Options.ProjectName
+	// the rows of a project left without deployments, changes or incidents would otherwise never be deleted
+	for _, table := range []dal.Tabler{
+		&models.DoraDeployment{},
+		&models.DoraChange{},
+		&models.DoraIncident{},
+		&models.DoraMonthlyMetric{},
+	} {
+		err := db.Delete(table, dal.Where("project_name = ?", projectName))
+		if err != nil {
+			return errors.Default.Wrap(err, "error deleting the outdated rows of "+table.TableName())
+		}
+	}
+	err := refreshDoraDeployments(taskCtx, projectName)
+	if err != nil {
+		return err
+	}
+	err = refreshDoraChanges(taskCtx, projectName)
+	if err != nil {
+		return err
+	}
+	err = refreshDoraIncidents(taskCtx, projectName)
+	if err != nil {
+		return err
+	}
+	return refreshDoraMonthlyMetrics(taskCtx, projectName)
+}
+
+func refreshDoraDeployments(taskCtx plugin.SubTaskContext, projectName string) errors.Error {
+	db := taskCtx.GetDal()
+	// GitLab and BitBucket may generate one deployment per commit of a pipeline, they count as one which finished
+	// along with the last of them
+	cursor, err := db.Cursor(
+		dal.Select("cdc.cicd_deployment_id AS deployment_id, MAX(cdc.finished_date) AS finished_date, COUNT(DISTINCT i.id) AS incident_count"),
+		dal.From("cicd_deployment_commits cdc"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = cdc.cicd_scope_id AND pm.table = 'cicd_scopes'"),
+		dal.Join("LEFT JOIN project_issue_metrics pim ON pim.deployment_id = cdc.cicd_deployment_id AND pim.project_name = pm.project_name"),
+		dal.Join("LEFT JOIN issues i ON i.id = pim.id AND i.type = ?", ticket.INCIDENT),
+		dal.Where("pm.project_name = ? AND cdc.result = ? AND cdc.environment = ?", projectName, devops.SUCCESS, devops.PRODUCTION),
+		dal.Groupby("cdc.cicd_deployment_id"),
+	)
+	if err != nil {
+		return err
+	}
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:    taskCtx,
+			Params: DoraApiParams{ProjectName: projectName},
+			Table:  "cicd_deployment_commits",
+		},
+		InputRowType: reflect.TypeOf(doraDeploymentRow{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			row := inputRow.(*doraDeploymentRow)
+			return []interface{}{&models.DoraDeployment{
+				ProjectName:   projectName,
+				DeploymentId:  row.DeploymentId,
+				FinishedDate:  row.FinishedDate,
+				IncidentCount: row.IncidentCount,
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func refreshDoraChanges(taskCtx plugin.SubTaskContext, projectName string) errors.Error {
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("pr.id AS pull_request_id, cdc.cicd_deployment_id AS deployment_id, cdc.finished_date AS deployed_date, ppm.pr_cycle_time AS lead_time_minutes"),
+		dal.From("pull_requests pr"),
+		dal.Join("JOIN project_pr_metrics ppm ON ppm.id = pr.id"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos' AND pm.project_name = ppm.project_name"),
+		dal.Join("JOIN cicd_deployment_commits cdc ON cdc.id = ppm.deployment_commit_id"),
+		dal.Where("pm.project_name = ? AND pr.merged_date IS NOT NULL AND ppm.pr_cycle_time IS NOT NULL", projectName),
+	)
+	if err != nil {
+		return err
+	}
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:    taskCtx,
+			Params: DoraApiParams{ProjectName: projectName},
+			Table:  "pull_requests",
+		},
+		InputRowType: reflect.TypeOf(doraChangeRow{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			row := inputRow.(*doraChangeRow)
+			return []interface{}{&models.DoraChange{
+				ProjectName:     projectName,
+				PullRequestId:   row.PullRequestId,
+				DeploymentId:    row.DeploymentId,
+				DeployedDate:    row.DeployedDate,
+				LeadTimeMinutes: row.LeadTimeMinutes,
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func refreshDoraIncidents(taskCtx plugin.SubTaskContext, projectName string) errors.Error {
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT i.id AS issue_id, i.created_date, i.lead_time_minutes AS restore_minutes"),
+		dal.From("issues i"),
+		dal.Join("JOIN board_issues bi ON bi.issue_id = i.id"),
+		dal.Join("JOIN project_mapping pm ON pm.row_id = bi.board_id AND pm.table = 'boards'"),
+		dal.Where("pm.project_name = ? AND i.type = ?", projectName, ticket.INCIDENT),
+	)
+	if err != nil {
+		return err
+	}
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:    taskCtx,
+			Params: DoraApiParams{ProjectName: projectName},
+			Table:  "issues",
+		},
+		InputRowType: reflect.TypeOf(doraIncidentRow{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			row := inputRow.(*doraIncidentRow)
+			return []interface{}{&models.DoraIncident{
+				ProjectName:    projectName,
+				IssueId:        row.IssueId,
+				CreatedDate:    row.CreatedDate,
+				RestoreMinutes: row.RestoreMinutes,
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// refreshDoraMonthlyMetrics aggregates the materialized rows of the project by calendar month
+func refreshDoraMonthlyMetrics(taskCtx plugin.SubTaskContext, projectName string) errors.Error {
+	db := taskCtx.GetDal()
+	var deployments []*models.DoraDeployment
+	err := db.All(&deployments, dal.Where("project_name = ? AND finished_date IS NOT NULL", projectName))
+	if err != nil {
+		return err
+	}
+	var changes []*models.DoraChange
+	err = db.All(&changes, dal.Where("project_name = ? AND deployed_date IS NOT NULL", projectName))
+	if err != nil {
+		return err
+	}
+	var incidents []*models.DoraIncident
+	err = db.All(&incidents, dal.Where("project_name = ? AND created_date IS NOT NULL", projectName))
+	if err != nil {
+		return err
+	}
+	metrics := aggregateDoraMonthlyMetrics(projectName, deployments, changes, incidents)
+	if len(metrics) == 0 {
+		return nil
+	}
+	return db.CreateOrUpdate(metrics)
+}
+
+func aggregateDoraMonthlyMetrics(
+	projectName string,
+	deployments []*models.DoraDeployment,
+	changes []*models.DoraChange,
+	incidents []*models.DoraIncident,
+) []*models.DoraMonthlyMetric {
+	months := make(map[string]*models.DoraMonthlyMetric)
+	monthOf := func(t time.Time) *models.DoraMonthlyMetric {
+		t = t.UTC()
+		month := t.Format("06/01")
+		if metric, ok := months[month]; ok {
+			return metric
+		}
+		metric := &models.DoraMonthlyMetric{
+			ProjectName:    projectName,
+			Month:          month,
+			MonthTimestamp: time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC),
+		}
+		months[month] = metric
+		return metric
+	}
+	deploymentDays := make(map[string]map[string]bool)
+	for _, deployment := range deployments {
+		metric := monthOf(*deployment.FinishedDate)
+		metric.DeploymentCount++
+		if deployment.IncidentCount > 0 {
+			metric.FailedDeploymentCount++
+		}
+		if deploymentDays[metric.Month] == nil {
+			deploymentDays[metric.Month] = make(map[string]bool)
+		}
+		deploymentDays[metric.Month][deployment.FinishedDate.UTC().Format("2006-01-02")] = true
+	}
+	leadTimes := make(map[string][]int64)
+	for _, change := range changes {
+		metric := monthOf(*change.DeployedDate)
+		leadTimes[metric.Month] = append(leadTimes[metric.Month], change.LeadTimeMinutes)
+	}
+	restoreTimes := make(map[string][]int64)
+	for _, incident := range incidents {
+		metric := monthOf(*incident.CreatedDate)
+		metric.IncidentCount++
+		if incident.RestoreMinutes != nil {
+			restoreTimes[metric.Month] = append(restoreTimes[metric.Month], *incident.RestoreMinutes)
+		}
+	}
+	metrics := make([]*models.DoraMonthlyMetric, 0, len(months))
+	for month, metric := range months {
+		metric.DeploymentDays = len(deploymentDays[month])
+		if metric.DeploymentCount > 0 {
+			rate := float64(metric.FailedDeploymentCount) / float64(metric.DeploymentCount)
+			metric.ChangeFailureRate = &rate
+		}
+		metric.MedianChangeLeadTimeMinutes = lowerMedian(leadTimes[month])
+		metric.MedianTimeToRestoreMinutes = lowerMedian(restoreTimes[month])
+		metrics = append(metrics, metric)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].MonthTimestamp.Before(metrics[j].MonthTimestamp)
+	})
+	return metrics
+}
+
+// lowerMedian returns the greatest value whose percent_rank is at most 0.5, the median the dashboards used to compute
+func lowerMedian(values []int64) *int64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	median := values[(len(values)-1)/2]
+	return &median
+}
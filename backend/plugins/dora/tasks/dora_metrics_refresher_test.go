@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowerMedian(t *testing.T) {
+	assert.Nil(t, lowerMedian(nil))
+	assert.Equal(t, int64(7), *lowerMedian([]int64{7}))
+	assert.Equal(t, int64(2), *lowerMedian([]int64{4, 2}))
+	assert.Equal(t, int64(3), *lowerMedian([]int64{5, 1, 3}))
+	assert.Equal(t, int64(2), *lowerMedian([]int64{9, 2, 1, 2}))
+}
+
+func TestAggregateDoraMonthlyMetrics(t *testing.T) {
+	date := func(value string) *time.Time {
+		d, err := time.Parse(time.RFC3339, value)
+		assert.Nil(t, err)
+		return &d
+	}
+	minutes := func(value int64) *int64 { return &value }
+	metrics := aggregateDoraMonthlyMetrics(
+		"project",
+		[]*models.DoraDeployment{
+			{DeploymentId: "d1", FinishedDate: date("2023-06-01T10:00:00Z")},
+			{DeploymentId: "d2", FinishedDate: date("2023-06-01T18:00:00Z"), IncidentCount: 2},
+			{DeploymentId: "d3", FinishedDate: date("2023-06-20T10:00:00Z")},
+			{DeploymentId: "d4", FinishedDate: date("2023-06-21T10:00:00Z")},
+		},
+		[]*models.DoraChange{
+			{PullRequestId: "pr1", DeployedDate: date("2023-06-01T10:00:00Z"), LeadTimeMinutes: 60},
+			{PullRequestId: "pr2", DeployedDate: date("2023-06-20T10:00:00Z"), LeadTimeMinutes: 30},
+			{PullRequestId: "pr3", DeployedDate: date("2023-07-02T10:00:00Z"), LeadTimeMinutes: 90},
+		},
+		[]*models.DoraIncident{
+			{IssueId: "i1", CreatedDate: date("2023-05-31T23:00:00Z"), RestoreMinutes: minutes(120)},
+			{IssueId: "i2", CreatedDate: date("2023-07-03T10:00:00Z")},
+		},
+	)
+	assert.Equal(t, 3, len(metrics))
+
+	may, june, july := metrics[0], metrics[1], metrics[2]
+	assert.Equal(t, "23/05", may.Month)
+	assert.Equal(t, 0, may.DeploymentCount)
+	assert.Nil(t, may.ChangeFailureRate)
+	assert.Equal(t, 1, may.IncidentCount)
+	assert.Equal(t, int64(120), *may.MedianTimeToRestoreMinutes)
+
+	assert.Equal(t, "23/06", june.Month)
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), june.MonthTimestamp)
+	assert.Equal(t, 4, june.DeploymentCount)
+	assert.Equal(t, 3, june.DeploymentDays)
+	assert.Equal(t, 1, june.FailedDeploymentCount)
+	assert.Equal(t, 0.25, *june.ChangeFailureRate)
+	assert.Equal(t, int64(30), *june.MedianChangeLeadTimeMinutes)
+	assert.Nil(t, june.MedianTimeToRestoreMinutes)
+
+	assert.Equal(t, "23/07", july.Month)
+	assert.Equal(t, int64(90), *july.MedianChangeLeadTimeMinutes)
+	assert.Equal(t, 1, july.IncidentCount)
+	assert.Nil(t, july.MedianTimeToRestoreMinutes)
+}
@@ -25,7 +25,6 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
-	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
@@ -35,7 +34,7 @@ var ConnectIncidentToDeploymentMeta = plugin.SubTaskMeta{
 	Name:             "ConnectIncidentToDeployment",
 	EntryPoint:       ConnectIncidentToDeployment,
 	EnabledByDefault: true,
-	Description:      "Connect incident issue to deployment",
+	Description:      "Connect incident issue to deployment by the incident linkage rules of the project",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 }
 
@@ -57,6 +56,10 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 			"INCIDENT", data.Options.ProjectName, "boards",
 		),
 	}
+	linker, err := newIncidentLinker(db, data.Options.ProjectName, data.Options.IncidentLinkageRules)
+	if err != nil {
+		return err
+	}
 	cursor, err := db.Cursor(clauses...)
 	if err != nil {
 		return err
@@ -82,37 +85,15 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 				ProjectName: data.Options.ProjectName,
 			}
 
-			cicdDeploymentCommit := &devops.CicdDeploymentCommit{}
-			cicdDeploymentCommitClauses := []dal.Clause{
-				dal.Select("cicd_deployment_commits.cicd_deployment_id as id, cicd_deployment_commits.finished_date as finished_date"),
-				dal.From(cicdDeploymentCommit),
-				dal.Join("left join project_mapping pm on cicd_deployment_commits.cicd_scope_id = pm.row_id"),
-				dal.Where(
-					`cicd_deployment_commits.finished_date < ?
-					    and cicd_deployment_commits.result = ?
-						and cicd_deployment_commits.environment = ?
-						and pm.table = ?
-						and pm.project_name = ?`,
-					issue.CreatedDate, devops.SUCCESS, devops.PRODUCTION, "cicd_scopes", data.Options.ProjectName,
-				),
-				dal.Orderby("finished_date DESC"),
-				dal.Limit(1),
-			}
-
-			scdc := &simpleCicdDeploymentCommit{}
-			err = db.All(scdc, cicdDeploymentCommitClauses...)
+			link, err := linker.link(issue)
 			if err != nil {
-				if db.IsErrorNotFound(err) {
-					return nil, nil
-				} else {
-					return nil, err
-				}
+				return nil, err
 			}
-			if scdc.Id != "" {
-				projectIssueMetric.DeploymentId = scdc.Id
-				return []interface{}{projectIssueMetric}, nil
+			if link.DeploymentId == "" {
+				return []interface{}{link}, nil
 			}
-			return nil, nil
+			projectIssueMetric.DeploymentId = link.DeploymentId
+			return []interface{}{projectIssueMetric, link}, nil
 		},
 	})
 	if err != nil {
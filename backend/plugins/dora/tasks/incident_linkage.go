@@ -0,0 +1,233 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/dora/models"
+)
+
+const (
+	// LINKAGE_RULE_TIME_WINDOW links the incident to the latest deployment finished before it
+	LINKAGE_RULE_TIME_WINDOW = "timeWindow"
+	// LINKAGE_RULE_FIELD_REFERENCE links the incident to the deployment named by one of its custom fields
+	LINKAGE_RULE_FIELD_REFERENCE = "fieldReference"
+	// LINKAGE_RULE_LABEL_MATCH links the incident to the deployment named by one of its labels
+	LINKAGE_RULE_LABEL_MATCH = "labelMatch"
+	// LINKAGE_RULE_SERVICE_MAPPING links the incident to the latest deployment of the repo its component is mapped to
+	LINKAGE_RULE_SERVICE_MAPPING = "serviceMapping"
+)
+
+// IncidentLinkageRule attributes incidents to deployments, the rules of a project are evaluated in order and the
+// first one finding a successful production deployment finished before the incident links them
+type IncidentLinkageRule struct {
+	Type string `mapstructure:"type" json:"type"`
+	// WindowHours only keeps the deployments finished within the hours before the incident, 0 means no limit
+	WindowHours int `mapstructure:"windowHours" json:"windowHours"`
+	// Field is the name of the custom field holding the id or the name of the deployment, for fieldReference
+	Field string `mapstructure:"field" json:"field"`
+	// Pattern matches the labels of the incident, the first group or the whole label is the name or the ref of the
+	// deployment, for labelMatch
+	Pattern string `mapstructure:"pattern" json:"pattern"`
+	// Services maps the components of the incidents to the ids or the urls of the repos deployed, for serviceMapping
+	Services map[string]string `mapstructure:"services" json:"services"`
+}
+
+// DefaultIncidentLinkageRules links the incidents to the latest deployment before them when no rule is configured
+var DefaultIncidentLinkageRules = []IncidentLinkageRule{{Type: LINKAGE_RULE_TIME_WINDOW}}
+
+type incidentLinkageRule struct {
+	IncidentLinkageRule
+	index   int
+	pattern *regexp.Regexp
+}
+
+type incidentLinker struct {
+	db          dal.Dal
+	projectName string
+	rules       []*incidentLinkageRule
+}
+
+func compileIncidentLinkageRules(rules []IncidentLinkageRule) ([]*incidentLinkageRule, errors.Error) {
+	if len(rules) == 0 {
+		rules = DefaultIncidentLinkageRules
+	}
+	compiled := make([]*incidentLinkageRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.WindowHours < 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("incidentLinkageRules[%d]: windowHours can not be negative", i))
+		}
+		c := &incidentLinkageRule{IncidentLinkageRule: rule, index: i}
+		switch rule.Type {
+		case LINKAGE_RULE_TIME_WINDOW:
+		case LINKAGE_RULE_FIELD_REFERENCE:
+			if rule.Field == "" {
+				return nil, errors.BadInput.New(fmt.Sprintf("incidentLinkageRules[%d]: field is required", i))
+			}
+		case LINKAGE_RULE_LABEL_MATCH:
+			if rule.Pattern == "" {
+				return nil, errors.BadInput.New(fmt.Sprintf("incidentLinkageRules[%d]: pattern is required", i))
+			}
+			var err error
+			c.pattern, err = regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("incidentLinkageRules[%d]: invalid pattern", i))
+			}
+		case LINKAGE_RULE_SERVICE_MAPPING:
+			if len(rule.Services) == 0 {
+				return nil, errors.BadInput.New(fmt.Sprintf("incidentLinkageRules[%d]: services are required", i))
+			}
+		default:
+			return nil, errors.BadInput.New(fmt.Sprintf("incidentLinkageRules[%d]: unknown type %s", i, rule.Type))
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+func newIncidentLinker(db dal.Dal, projectName string, rules []IncidentLinkageRule) (*incidentLinker, errors.Error) {
+	compiled, err := compileIncidentLinkageRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return &incidentLinker{db: db, projectName: projectName, rules: compiled}, nil
+}
+
+// link evaluates the rules against the incident, the link returned has an empty DeploymentId if no rule matched
+func (l *incidentLinker) link(issue *ticket.Issue) (*models.IncidentDeploymentLink, errors.Error) {
+	for _, rule := range l.rules {
+		deploymentId, reason, err := l.evaluate(rule, issue)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to evaluate incidentLinkageRules[%d]", rule.index))
+		}
+		if deploymentId != "" {
+			return &models.IncidentDeploymentLink{
+				ProjectName:  l.projectName,
+				IssueId:      issue.Id,
+				DeploymentId: deploymentId,
+				RuleIndex:    rule.index,
+				RuleType:     rule.Type,
+				Reason:       reason,
+			}, nil
+		}
+	}
+	return &models.IncidentDeploymentLink{
+		ProjectName: l.projectName,
+		IssueId:     issue.Id,
+		RuleIndex:   -1,
+		Reason:      "no rule found a successful production deployment finished before the incident",
+	}, nil
+}
+
+func (l *incidentLinker) evaluate(rule *incidentLinkageRule, issue *ticket.Issue) (string, string, errors.Error) {
+	switch rule.Type {
+	case LINKAGE_RULE_FIELD_REFERENCE:
+		var values []string
+		err := l.db.Pluck("string_value", &values,
+			dal.From(&crossdomain.CustomField{}),
+			dal.Where("entity_type = ? AND entity_id = ? AND field_name = ?", "issues", issue.Id, rule.Field),
+		)
+		if err != nil || len(values) == 0 || values[0] == "" {
+			return "", "", err
+		}
+		deploymentId, err := l.latestDeployment(rule, issue, dal.Where(
+			"(cdc.cicd_deployment_id = ? OR cdc.name = ?)", values[0], values[0],
+		))
+		return deploymentId, fmt.Sprintf("the field %s of the incident references the deployment %s", rule.Field, values[0]), err
+	case LINKAGE_RULE_LABEL_MATCH:
+		var labels []string
+		err := l.db.Pluck("label_name", &labels,
+			dal.From(&ticket.IssueLabel{}),
+			dal.Where("issue_id = ?", issue.Id),
+			dal.Orderby("label_name"),
+		)
+		if err != nil {
+			return "", "", err
+		}
+		for _, label := range labels {
+			match := rule.pattern.FindStringSubmatch(label)
+			if match == nil {
+				continue
+			}
+			name := match[0]
+			if len(match) > 1 {
+				name = match[1]
+			}
+			deploymentId, err := l.latestDeployment(rule, issue, dal.Where("(cdc.name = ? OR cdc.ref_name = ?)", name, name))
+			if err != nil || deploymentId != "" {
+				return deploymentId, fmt.Sprintf("the label %s of the incident matches the deployment %s", label, name), err
+			}
+		}
+		return "", "", nil
+	case LINKAGE_RULE_SERVICE_MAPPING:
+		repo, ok := rule.Services[issue.Component]
+		if !ok || issue.Component == "" {
+			return "", "", nil
+		}
+		deploymentId, err := l.latestDeployment(rule, issue, dal.Where("(cdc.repo_id = ? OR cdc.repo_url = ?)", repo, repo))
+		return deploymentId, fmt.Sprintf("the component %s of the incident is mapped to the repo %s", issue.Component, repo), err
+	default:
+		deploymentId, err := l.latestDeployment(rule, issue)
+		reason := "the deployment is the latest one finished before the incident"
+		if rule.WindowHours > 0 {
+			reason = fmt.Sprintf("the deployment is the latest one finished within %d hours before the incident", rule.WindowHours)
+		}
+		return deploymentId, reason, err
+	}
+}
+
+// latestDeployment returns the latest successful production deployment of the project finished before the incident
+// and within the window of the rule
+func (l *incidentLinker) latestDeployment(rule *incidentLinkageRule, issue *ticket.Issue, clauses ...dal.Clause) (string, errors.Error) {
+	if issue.CreatedDate == nil {
+		return "", nil
+	}
+	clauses = append([]dal.Clause{
+		dal.Select("cdc.cicd_deployment_id as id, cdc.finished_date as finished_date"),
+		dal.From("cicd_deployment_commits cdc"),
+		dal.Join("left join project_mapping pm on cdc.cicd_scope_id = pm.row_id"),
+		dal.Where(
+			`cdc.finished_date < ?
+				and cdc.result = ?
+				and cdc.environment = ?
+				and pm.table = ?
+				and pm.project_name = ?`,
+			issue.CreatedDate, devops.SUCCESS, devops.PRODUCTION, "cicd_scopes", l.projectName,
+		),
+		dal.Orderby("cdc.finished_date DESC"),
+		dal.Limit(1),
+	}, clauses...)
+	if rule.WindowHours > 0 {
+		since := issue.CreatedDate.Add(-time.Duration(rule.WindowHours) * time.Hour)
+		clauses = append(clauses, dal.Where("cdc.finished_date >= ?", since))
+	}
+	scdc := &simpleCicdDeploymentCommit{}
+	err := l.db.All(scdc, clauses...)
+	if err != nil && !l.db.IsErrorNotFound(err) {
+		return "", err
+	}
+	return scdc.Id, nil
+}
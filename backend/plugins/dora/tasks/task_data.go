@@ -37,6 +37,8 @@ type DoraOptions struct {
 	Since               string
 	ProjectName         string `json:"projectName"`
 	TransformationRules `mapstructure:"transformationRules" json:"transformationRules"`
+	// IncidentLinkageRules attribute the incidents of the project to deployments, DefaultIncidentLinkageRules if empty
+	IncidentLinkageRules []IncidentLinkageRule `mapstructure:"incidentLinkageRules" json:"incidentLinkageRules"`
 }
 
 type DoraTaskData struct {
@@ -49,6 +51,10 @@ func DecodeAndValidateTaskOptions(options map[string]interface{}) (*DoraOptions,
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error decoding DORA task options")
 	}
+	_, err = compileIncidentLinkageRules(op.IncidentLinkageRules)
+	if err != nil {
+		return nil, err
+	}
 
 	return &op, nil
 }
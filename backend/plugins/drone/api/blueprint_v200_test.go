@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	connection := &models.DroneConnection{
+		BaseConnection: helper.BaseConnection{
+			Name: "drone-test",
+			Model: common.Model{
+				ID: 1,
+			},
+		},
+		DroneConn: models.DroneConn{
+			RestConnection: helper.RestConnection{
+				Endpoint:         "https://drone.example.com/",
+				Proxy:            "",
+				RateLimitPerHour: 0,
+			},
+			AccessToken: helper.AccessToken{
+				Token: "secret",
+			},
+		},
+	}
+	mockMeta := mockplugin.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/drone")
+	err := plugin.RegisterPlugin("drone", mockMeta)
+	assert.Nil(t, err)
+	// Refresh Global Variables and set the sql mock
+	basicRes = NewMockBasicRes()
+	bs := &plugin.BlueprintScopeV200{
+		Entities: []string{"CICD"},
+		Id:       "octocat/hello-world",
+	}
+	bpScopes := make([]*plugin.BlueprintScopeV200, 0)
+	bpScopes = append(bpScopes, bs)
+	syncPolicy := &plugin.BlueprintSyncPolicy{}
+
+	plan := make(plugin.PipelinePlan, len(bpScopes))
+	plan, err = makeDataSourcePipelinePlanV200(nil, plan, bpScopes, connection, syncPolicy)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes()
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	expectPlan := plugin.PipelinePlan{
+		plugin.PipelineStage{
+			{
+				Plugin:   "drone",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"fullName":             "octocat/hello-world",
+					"connectionId":         uint64(1),
+					"transformationRuleId": uint64(1),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := make([]plugin.Scope, 0)
+	scopeCicd := &devops.CicdScope{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: "drone:DroneRepo:1:octocat/hello-world",
+		},
+		Name: "octocat/hello-world",
+		Url:  "https://drone.example.com/octocat/hello-world",
+	}
+	expectScopes = append(expectScopes, scopeCicd)
+	assert.Equal(t, expectScopes, scopes)
+}
+
+// NewMockBasicRes FIXME ...
+func NewMockBasicRes() *mockcontext.BasicRes {
+	testDroneRepo := &models.DroneRepo{
+		ConnectionId:         1,
+		DroneId:              "octocat/hello-world",
+		Name:                 "hello-world",
+		Namespace:            "octocat",
+		Url:                  "https://drone.example.com/octocat/hello-world",
+		CloneUrl:             "https://gitea.example.com/octocat/hello-world.git",
+		DefaultBranch:        "main",
+		TransformationRuleId: 1,
+	}
+
+	mockRes := new(mockcontext.BasicRes)
+	mockDal := new(mockdal.Dal)
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dst := args.Get(0).(*models.DroneRepo)
+		*dst = *testDroneRepo
+	}).Return(nil).Once()
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
@@ -0,0 +1,159 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the repos are grouped by their namespaces
+// @Tags plugins/drone
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/drone/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.DroneConnection) ([]models.GroupResponse, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			repos, err := listRepos(basicRes, &connection)
+			if err != nil {
+				return nil, err
+			}
+			groups := make([]models.GroupResponse, 0)
+			for i, repo := range repos {
+				if i == 0 || repos[i-1].Namespace != repo.Namespace {
+					groups = append(groups, models.GroupResponse{Id: repo.Namespace, Name: repo.Namespace})
+				}
+			}
+			return pageOf(groups, queryData), nil
+		},
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.DroneConnection) ([]models.DroneApiRepo, errors.Error) {
+			if gid == "" {
+				return nil, nil
+			}
+			repos, err := listRepos(basicRes, &connection)
+			if err != nil {
+				return nil, err
+			}
+			matched := make([]models.DroneApiRepo, 0)
+			for _, repo := range repos {
+				if repo.Namespace == gid {
+					matched = append(matched, repo)
+				}
+			}
+			return pageOf(matched, queryData), nil
+		},
+	)
+}
+
+// SearchRemoteScopes use the Search API and only return repo
+// @Summary use the Search API and only return repo
+// @Description use the Search API and only return repo
+// @Tags plugins/drone
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/drone/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.DroneConnection) ([]models.DroneApiRepo, errors.Error) {
+			repos, err := listRepos(basicRes, &connection)
+			if err != nil {
+				return nil, err
+			}
+			// the api has no search, the repos are matched by their full names here
+			search := strings.ToLower(queryData.Search[0])
+			matched := make([]models.DroneApiRepo, 0)
+			for _, repo := range repos {
+				if strings.Contains(strings.ToLower(repo.Namespace+"/"+repo.Name), search) {
+					matched = append(matched, repo)
+				}
+			}
+			return pageOf(matched, queryData), nil
+		},
+	)
+}
+
+// listRepos returns the active repos of the account of the connection sorted by their full names,
+// the api returns all of them at once and Woodpecker names the namespace `owner`
+func listRepos(basicRes context2.BasicRes, connection *models.DroneConnection) ([]models.DroneApiRepo, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	res, err := apiClient.Get("api/user/repos", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody []models.DroneApiRepo
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]models.DroneApiRepo, 0, len(resBody))
+	for _, repo := range resBody {
+		if !repo.Active {
+			continue
+		}
+		if repo.Namespace == "" {
+			repo.Namespace = repo.Owner
+		}
+		repos = append(repos, repo)
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		if repos[i].Namespace != repos[j].Namespace {
+			return repos[i].Namespace < repos[j].Namespace
+		}
+		return repos[i].Name < repos[j].Name
+	})
+	return repos, nil
+}
+
+func pageOf[T any](items []T, queryData *api.RemoteQueryData) []T {
+	start := (queryData.Page - 1) * queryData.PerPage
+	if start < 0 || start >= len(items) {
+		return nil
+	}
+	end := start + queryData.PerPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
@@ -0,0 +1,195 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/impl"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+	"github.com/apache/incubator-devlake/plugins/drone/tasks"
+)
+
+func TestDroneBuildDataFlow(t *testing.T) {
+
+	var drone impl.Drone
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "drone", drone)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "deploy")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "prod")
+	taskData := &tasks.DroneTaskData{
+		Options: &tasks.DroneOptions{
+			ConnectionId: 1,
+			FullName:     "octocat/hello-world",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_drone_api_builds.csv", "_raw_drone_api_builds")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_drone_api_build_stages.csv", "_raw_drone_api_build_stages")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.DroneBuild{})
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.DroneBuild{},
+		"./snapshot_tables/_tool_drone_builds.csv",
+		[]string{
+			"connection_id",
+			"repo_id",
+			"number",
+			"status",
+			"event",
+			"ref",
+			"branch",
+			"commit_sha",
+			"message",
+			"author_name",
+			"author_email",
+			"deploy_to",
+			"link",
+			"type",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	dataflowTester.FlushTabler(&models.DroneStep{})
+	dataflowTester.Subtask(tasks.ExtractApiStagesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.DroneStep{},
+		"./snapshot_tables/_tool_drone_steps.csv",
+		[]string{
+			"connection_id",
+			"repo_id",
+			"build_number",
+			"stage_number",
+			"step_number",
+			"stage_name",
+			"name",
+			"status",
+			"exit_code",
+			"type",
+			"environment",
+			"started_date",
+			"finished_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_drone_repos.csv", &models.DroneRepo{})
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertRepoMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertBuildsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"result",
+			"status",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+			"commit_sha",
+			"ref_name",
+			"repo_id",
+			"repo_url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertStepsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"environment",
+			"duration_sec",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+}
@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type DroneBuild struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       string `gorm:"primaryKey;type:varchar(255)"`
+	Number       int    `gorm:"primaryKey;autoIncrement:false"`
+	Status       string `gorm:"type:varchar(100)"`
+	Event        string `gorm:"type:varchar(100)"`
+	Ref          string `gorm:"type:varchar(255)"`
+	Branch       string `gorm:"type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(40)"`
+	Message      string
+	AuthorName   string `gorm:"type:varchar(255)"`
+	AuthorEmail  string `gorm:"type:varchar(255)"`
+	// DeployTo is the target environment of promotions and rollbacks
+	DeployTo     string `gorm:"type:varchar(255)"`
+	Link         string `gorm:"type:varchar(255)"`
+	Type         string `gorm:"type:varchar(100)"`
+	Environment  string `gorm:"type:varchar(255)"`
+	CreatedDate  *time.Time
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+	common.NoPKModel
+}
+
+func (DroneBuild) TableName() string {
+	return "_tool_drone_builds"
+}
+
+// the statuses shared by builds, stages and steps
+const (
+	STATUS_PENDING                 = "pending"
+	STATUS_RUNNING                 = "running"
+	STATUS_SUCCESS                 = "success"
+	STATUS_FAILURE                 = "failure"
+	STATUS_ERROR                   = "error"
+	STATUS_KILLED                  = "killed"
+	STATUS_SKIPPED                 = "skipped"
+	STATUS_BLOCKED                 = "blocked"
+	STATUS_DECLINED                = "declined"
+	STATUS_WAITING_ON_DEPENDENCIES = "waiting_on_dependencies"
+)
+
+// the events deploying a build, promote and rollback are the ones of Drone, deployment the one of Woodpecker
+const (
+	EVENT_PROMOTE    = "promote"
+	EVENT_ROLLBACK   = "rollback"
+	EVENT_DEPLOYMENT = "deployment"
+)
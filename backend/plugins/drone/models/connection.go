@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*DroneConnection)(nil)
+
+// DroneConn holds the essential information to connect to the Drone API, Woodpecker servers
+// forked from Drone serve the same api and are connected the same way
+type DroneConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	api.AccessToken    `mapstructure:",squash"`
+}
+
+// DroneConnection holds DroneConn plus ID/Name for database storage
+type DroneConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	DroneConn          `mapstructure:",squash"`
+}
+
+func (DroneConnection) TableName() string {
+	return "_tool_drone_connections"
+}
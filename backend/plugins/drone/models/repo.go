@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*DroneRepo)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*DroneApiRepo)(nil)
+
+// DroneRepo is a repository activated on the server, it is identified by its slug `namespace/name`
+type DroneRepo struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	DroneId              string `json:"droneId" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"droneId"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Namespace            string `json:"namespace" gorm:"type:varchar(255)" mapstructure:"namespace,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	CloneUrl             string `json:"cloneUrl" gorm:"type:varchar(255)" mapstructure:"cloneUrl,omitempty"`
+	DefaultBranch        string `json:"defaultBranch" gorm:"type:varchar(255)" mapstructure:"defaultBranch,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (DroneRepo) TableName() string {
+	return "_tool_drone_repos"
+}
+
+func (p DroneRepo) ScopeId() string {
+	return p.DroneId
+}
+
+func (p DroneRepo) ScopeName() string {
+	return p.Name
+}
+
+// DroneApiRepo is the repo entity of the api, the fields Woodpecker renamed are kept next to the ones of Drone
+type DroneApiRepo struct {
+	Namespace     string `json:"namespace"`
+	Owner         string `json:"owner"`
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	FullName      string `json:"full_name"`
+	Link          string `json:"link"`
+	LinkUrl       string `json:"link_url"`
+	GitHttpUrl    string `json:"git_http_url"`
+	CloneUrl      string `json:"clone_url"`
+	DefaultBranch string `json:"default_branch"`
+	Active        bool   `json:"active"`
+}
+
+func (r DroneApiRepo) ConvertApiScope() plugin.ToolLayerScope {
+	repo := &DroneRepo{
+		DroneId:       r.Slug,
+		Name:          r.Name,
+		Namespace:     r.Namespace,
+		Url:           r.Link,
+		CloneUrl:      r.GitHttpUrl,
+		DefaultBranch: r.DefaultBranch,
+	}
+	if repo.DroneId == "" {
+		repo.DroneId = r.FullName
+		repo.Namespace = r.Owner
+		repo.Url = r.LinkUrl
+		repo.CloneUrl = r.CloneUrl
+	}
+	return repo
+}
+
+// GroupResponse is required by the remote api helper, the repos are grouped by their namespaces
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
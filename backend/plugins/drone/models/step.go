@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// DroneStep is a step of a stage of a build, stages are the pipelines declared in the yaml of the repo
+type DroneStep struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       string `gorm:"primaryKey;type:varchar(255)"`
+	BuildNumber  int    `gorm:"primaryKey;autoIncrement:false"`
+	StageNumber  int    `gorm:"primaryKey;autoIncrement:false"`
+	StepNumber   int    `gorm:"primaryKey;autoIncrement:false"`
+	StageName    string `gorm:"type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Status       string `gorm:"type:varchar(100)"`
+	ExitCode     int
+	Type         string `gorm:"type:varchar(100)"`
+	Environment  string `gorm:"type:varchar(255)"`
+	StartedDate  *time.Time
+	FinishedDate *time.Time
+	common.NoPKModel
+}
+
+func (DroneStep) TableName() string {
+	return "_tool_drone_steps"
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type DroneApiParams struct {
+	ConnectionId uint64
+	FullName     string
+}
+
+type DroneInput struct {
+	Number int
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *DroneTaskData) {
+	data := taskCtx.GetData().(*DroneTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: DroneApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			FullName:     data.Options.FullName,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GetQuery pages with `page` and `per_page`, Woodpecker reads the page size from `perPage` instead
+func GetQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	if reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
+		query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
+		query.Set("perPage", fmt.Sprintf("%v", reqData.Pager.Size))
+	}
+	return query, nil
+}
+
+func ignoreHTTPStatus404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your AccessToken")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
+
+// unixTime converts the unix timestamps of the api, which are 0 until the moment they stand for is reached
+func unixTime(seconds int64) *time.Time {
+	if seconds <= 0 {
+		return nil
+	}
+	t := time.Unix(seconds, 0).UTC()
+	return &t
+}
+
+// firstNonZero picks the value of whichever of Drone and Woodpecker filled the field
+func firstNonZero[T comparable](values ...T) T {
+	var zero T
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
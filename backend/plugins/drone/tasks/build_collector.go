@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+const RAW_BUILD_TABLE = "drone_api_builds"
+
+var CollectApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBuilds",
+	EntryPoint:       CollectApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Collect builds data from Drone api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type SimpleDroneApiBuild struct {
+	Number    int
+	Created   int64 `json:"created"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	db := taskCtx.GetDal()
+	collector, err := api.NewStatefulApiCollectorForFinalizableEntity(api.FinalizableApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		TimeAfter:          data.TimeAfter,
+		CollectNewRecordsByList: api.FinalizableApiCollectorListArgs{
+			PageSize:    50,
+			Concurrency: 5,
+			FinalizableApiCollectorCommonArgs: api.FinalizableApiCollectorCommonArgs{
+				UrlTemplate: "api/repos/{{ .Params.FullName }}/builds",
+				Query: func(reqData *api.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
+					return GetQuery(reqData)
+				},
+				ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+					var builds []json.RawMessage
+					err := api.UnmarshalResponse(res, &builds)
+					if err != nil {
+						return nil, err
+					}
+					return builds, nil
+				},
+			},
+			GetCreated: func(item json.RawMessage) (time.Time, errors.Error) {
+				build := &SimpleDroneApiBuild{}
+				err := json.Unmarshal(item, build)
+				if err != nil {
+					return time.Time{}, errors.BadInput.Wrap(err, "failed to unmarshal drone build")
+				}
+				return time.Unix(firstNonZero(build.Created, build.CreatedAt), 0), nil
+			},
+		},
+		CollectUnfinishedDetails: api.FinalizableApiCollectorDetailArgs{
+			BuildInputIterator: func() (api.Iterator, errors.Error) {
+				cursor, err := db.Cursor(
+					dal.Select("number"),
+					dal.From(&models.DroneBuild{}),
+					dal.Where(
+						"repo_id = ? AND connection_id = ? AND status IN (?)",
+						data.Options.FullName, data.Options.ConnectionId,
+						[]string{models.STATUS_PENDING, models.STATUS_RUNNING, models.STATUS_BLOCKED, models.STATUS_WAITING_ON_DEPENDENCIES},
+					),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(DroneInput{}))
+			},
+			FinalizableApiCollectorCommonArgs: api.FinalizableApiCollectorCommonArgs{
+				UrlTemplate: "api/repos/{{ .Params.FullName }}/builds/{{ .Input.Number }}",
+				ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+					body, err := io.ReadAll(res.Body)
+					if err != nil {
+						return nil, errors.Convert(err)
+					}
+					res.Body.Close()
+					return []json.RawMessage{body}, nil
+				},
+				AfterResponse: ignoreHTTPStatus404,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+var ConvertBuildsMeta = plugin.SubTaskMeta{
+	Name:             "convertBuilds",
+	EntryPoint:       ConvertBuilds,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table drone_builds into domain layer table cicd_pipelines, cicd_pipeline_commits and cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	db := taskCtx.GetDal()
+
+	repo := &models.DroneRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND drone_id = ?", data.Options.ConnectionId, data.Options.FullName))
+	if err != nil {
+		return err
+	}
+	repoId := didgen.NewDomainIdGenerator(&models.DroneRepo{}).Generate(repo.ConnectionId, repo.DroneId)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.DroneBuild{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.FullName, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	buildIdGen := didgen.NewDomainIdGenerator(&models.DroneBuild{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.DroneBuild{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			build := inputRow.(*models.DroneBuild)
+			if build.CreatedDate == nil {
+				return nil, nil
+			}
+			pipelineId := buildIdGen.Generate(build.ConnectionId, build.RepoId, build.Number)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: pipelineId},
+				Name:         fmt.Sprintf("%s#%d", build.RepoId, build.Number),
+				Result: devops.GetResult(&devops.ResultRule{
+					Failed:  []string{models.STATUS_FAILURE, models.STATUS_ERROR},
+					Abort:   []string{models.STATUS_KILLED, models.STATUS_DECLINED, models.STATUS_SKIPPED},
+					Success: []string{models.STATUS_SUCCESS},
+					Default: "",
+				}, build.Status),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{models.STATUS_PENDING, models.STATUS_RUNNING, models.STATUS_BLOCKED, models.STATUS_WAITING_ON_DEPENDENCIES},
+					Default:    devops.DONE,
+				}, build.Status),
+				Type:        build.Type,
+				Environment: build.Environment,
+				CreatedDate: *build.CreatedDate,
+				CicdScopeId: repoId,
+			}
+			if domainPipeline.Status == devops.DONE {
+				domainPipeline.FinishedDate = build.FinishedDate
+				if build.FinishedDate != nil {
+					domainPipeline.DurationSec = uint64(build.FinishedDate.Sub(*build.CreatedDate).Seconds())
+				}
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId: pipelineId,
+				CommitSha:  build.CommitSha,
+				Branch:     build.Branch,
+				RepoUrl:    repo.CloneUrl,
+			}
+			results := []interface{}{
+				domainPipeline,
+				domainPipelineCommit,
+			}
+			if build.Type == devops.DEPLOYMENT {
+				// the id is the one dora derives from the pipeline commit, so that both end up with the same row
+				domainDeployCommit := &devops.CicdDeploymentCommit{
+					DomainEntity:     domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", pipelineId, repo.CloneUrl)},
+					CicdScopeId:      repoId,
+					CicdDeploymentId: pipelineId,
+					Name:             domainPipeline.Name,
+					Result:           domainPipeline.Result,
+					Status:           domainPipeline.Status,
+					Environment:      build.Environment,
+					CreatedDate:      *build.CreatedDate,
+					StartedDate:      build.StartedDate,
+					FinishedDate:     domainPipeline.FinishedDate,
+					CommitSha:        build.CommitSha,
+					RefName:          build.Branch,
+					RepoUrl:          repo.CloneUrl,
+				}
+				if domainPipeline.FinishedDate != nil {
+					durationSec := domainPipeline.DurationSec
+					domainDeployCommit.DurationSec = &durationSec
+				}
+				results = append(results, domainDeployCommit)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+var ExtractApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBuilds",
+	EntryPoint:       ExtractApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Extract raw builds data into tool layer table drone_builds",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// DroneApiBuild is the build entity of the api, Woodpecker renamed some of the fields of Drone so both are kept
+type DroneApiBuild struct {
+	Number      int    `json:"number"`
+	Status      string `json:"status"`
+	Event       string `json:"event"`
+	Ref         string `json:"ref"`
+	Message     string `json:"message"`
+	AuthorEmail string `json:"author_email"`
+	DeployTo    string `json:"deploy_to"`
+	Target      string `json:"target"`
+	After       string `json:"after"`
+	Link        string `json:"link"`
+	AuthorLogin string `json:"author_login"`
+	Created     int64  `json:"created"`
+	Started     int64  `json:"started"`
+	Finished    int64  `json:"finished"`
+	Branch      string `json:"branch"`
+	Commit      string `json:"commit"`
+	LinkUrl     string `json:"link_url"`
+	Author      string `json:"author"`
+	CreatedAt   int64  `json:"created_at"`
+	StartedAt   int64  `json:"started_at"`
+	FinishedAt  int64  `json:"finished_at"`
+}
+
+func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiBuild := &DroneApiBuild{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiBuild))
+			if err != nil {
+				return nil, err
+			}
+			build := &models.DroneBuild{
+				ConnectionId: data.Options.ConnectionId,
+				RepoId:       data.Options.FullName,
+				Number:       apiBuild.Number,
+				Status:       apiBuild.Status,
+				Event:        apiBuild.Event,
+				Ref:          apiBuild.Ref,
+				Branch:       firstNonZero(apiBuild.Target, apiBuild.Branch),
+				CommitSha:    firstNonZero(apiBuild.After, apiBuild.Commit),
+				Message:      apiBuild.Message,
+				AuthorName:   firstNonZero(apiBuild.AuthorLogin, apiBuild.Author),
+				AuthorEmail:  apiBuild.AuthorEmail,
+				DeployTo:     apiBuild.DeployTo,
+				Link:         firstNonZero(apiBuild.Link, apiBuild.LinkUrl),
+				CreatedDate:  unixTime(firstNonZero(apiBuild.Created, apiBuild.CreatedAt)),
+				StartedDate:  unixTime(firstNonZero(apiBuild.Started, apiBuild.StartedAt)),
+				FinishedDate: unixTime(firstNonZero(apiBuild.Finished, apiBuild.FinishedAt)),
+			}
+			// promotions and rollbacks deploy the build to the environment they target
+			switch build.Event {
+			case models.EVENT_PROMOTE, models.EVENT_ROLLBACK, models.EVENT_DEPLOYMENT:
+				build.Type = devops.DEPLOYMENT
+				build.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, build.DeployTo)
+			}
+			return []interface{}{build}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+const RAW_REPO_TABLE = "drone_api_repos"
+
+var ConvertRepoMeta = plugin.SubTaskMeta{
+	Name:             "convertRepo",
+	EntryPoint:       ConvertRepo,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table drone_repos into domain layer table cicd_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// GetApiRepo fetches a repo by its full name `namespace/name`
+func GetApiRepo(op *DroneOptions, apiClient aha.ApiClientAbstract) (*models.DroneApiRepo, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("api/repos/%s", op.FullName), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting repo detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	apiRepo := &models.DroneApiRepo{}
+	err = api.UnmarshalResponse(res, apiRepo)
+	if err != nil {
+		return nil, err
+	}
+	return apiRepo, nil
+}
+
+func ConvertRepo(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REPO_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.DroneRepo{}),
+		dal.Where("connection_id = ? AND drone_id = ?", data.Options.ConnectionId, data.Options.FullName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoIdGen := didgen.NewDomainIdGenerator(&models.DroneRepo{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.DroneRepo{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			repo := inputRow.(*models.DroneRepo)
+			domainCicdScope := &devops.CicdScope{
+				DomainEntity: domainlayer.DomainEntity{Id: repoIdGen.Generate(repo.ConnectionId, repo.DroneId)},
+				Name:         repo.DroneId,
+				Url:          repo.Url,
+			}
+			return []interface{}{
+				domainCicdScope,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
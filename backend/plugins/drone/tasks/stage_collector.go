@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+const RAW_STAGE_TABLE = "drone_api_build_stages"
+
+var CollectApiStagesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiStages",
+	EntryPoint:       CollectApiStages,
+	EnabledByDefault: true,
+	Description:      "Collect the stages and steps of builds from Drone api, must run after the builds are extracted",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiStages(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_STAGE_TABLE)
+	db := taskCtx.GetDal()
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+	clauses := []dal.Clause{
+		dal.Select("number"),
+		dal.From(&models.DroneBuild{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.FullName, data.Options.ConnectionId),
+	}
+	if collectorWithState.IsIncremental() {
+		// builds still running at the last collection need their stages collected again
+		clauses = append(clauses, dal.Where("(finished_date IS NULL OR finished_date > ?)", *collectorWithState.LatestState.LatestSuccessStart))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(DroneInput{}))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Incremental: collectorWithState.IsIncremental(),
+		Input:       iterator,
+		UrlTemplate: "api/repos/{{ .Params.FullName }}/builds/{{ .Input.Number }}",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			// Drone names the stages of a build `stages`, Woodpecker names them `procs`
+			var build struct {
+				Stages []json.RawMessage `json:"stages"`
+				Procs  []json.RawMessage `json:"procs"`
+			}
+			err := api.UnmarshalResponse(res, &build)
+			if err != nil {
+				return nil, err
+			}
+			return append(build.Stages, build.Procs...), nil
+		},
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+var ExtractApiStagesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiStages",
+	EntryPoint:       ExtractApiStages,
+	EnabledByDefault: true,
+	Description:      "Extract raw build stages data into tool layer table drone_steps",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// DroneApiStep is a step of Drone, or a child proc of Woodpecker
+type DroneApiStep struct {
+	Number    int    `json:"number"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	ExitCode  int    `json:"exit_code"`
+	Started   int64  `json:"started"`
+	Stopped   int64  `json:"stopped"`
+	Pid       int    `json:"pid"`
+	State     string `json:"state"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+}
+
+// DroneApiStage is a stage of Drone, or a top level proc of Woodpecker whose steps are its children
+type DroneApiStage struct {
+	DroneApiStep
+	Steps    []DroneApiStep `json:"steps"`
+	Children []DroneApiStep `json:"children"`
+}
+
+func ExtractApiStages(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_STAGE_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &DroneInput{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			apiStage := &DroneApiStage{}
+			err = errors.Convert(json.Unmarshal(row.Data, apiStage))
+			if err != nil {
+				return nil, err
+			}
+			apiSteps := append(apiStage.Steps, apiStage.Children...)
+			results := make([]interface{}, 0, len(apiSteps))
+			for _, apiStep := range apiSteps {
+				results = append(results, &models.DroneStep{
+					ConnectionId: data.Options.ConnectionId,
+					RepoId:       data.Options.FullName,
+					BuildNumber:  input.Number,
+					StageNumber:  firstNonZero(apiStage.Number, apiStage.Pid),
+					StepNumber:   firstNonZero(apiStep.Number, apiStep.Pid),
+					StageName:    apiStage.Name,
+					Name:         apiStep.Name,
+					Status:       firstNonZero(apiStep.Status, apiStep.State),
+					ExitCode:     apiStep.ExitCode,
+					Type:         data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, apiStep.Name),
+					Environment:  data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, apiStep.Name),
+					StartedDate:  unixTime(firstNonZero(apiStep.Started, apiStep.StartTime)),
+					FinishedDate: unixTime(firstNonZero(apiStep.Stopped, apiStep.EndTime)),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/drone/models"
+)
+
+var ConvertStepsMeta = plugin.SubTaskMeta{
+	Name:             "convertSteps",
+	EntryPoint:       ConvertSteps,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table drone_steps into domain layer table cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertSteps(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_STAGE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.DroneStep{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.FullName, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoId := didgen.NewDomainIdGenerator(&models.DroneRepo{}).Generate(data.Options.ConnectionId, data.Options.FullName)
+	buildIdGen := didgen.NewDomainIdGenerator(&models.DroneBuild{})
+	stepIdGen := didgen.NewDomainIdGenerator(&models.DroneStep{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.DroneStep{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			step := inputRow.(*models.DroneStep)
+			// steps which never started, as the ones following a failed step, did not run at all
+			if step.StartedDate == nil {
+				return nil, nil
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: stepIdGen.Generate(step.ConnectionId, step.RepoId, step.BuildNumber, step.StageNumber, step.StepNumber),
+				},
+				Name:       step.Name,
+				PipelineId: buildIdGen.Generate(step.ConnectionId, step.RepoId, step.BuildNumber),
+				Result: devops.GetResult(&devops.ResultRule{
+					Failed:  []string{models.STATUS_FAILURE, models.STATUS_ERROR},
+					Abort:   []string{models.STATUS_KILLED, models.STATUS_DECLINED, models.STATUS_SKIPPED},
+					Success: []string{models.STATUS_SUCCESS},
+					Default: "",
+				}, step.Status),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{models.STATUS_PENDING, models.STATUS_RUNNING, models.STATUS_BLOCKED, models.STATUS_WAITING_ON_DEPENDENCIES},
+					Default:    devops.DONE,
+				}, step.Status),
+				Type:        step.Type,
+				Environment: step.Environment,
+				StartedDate: *step.StartedDate,
+				CicdScopeId: repoId,
+			}
+			if domainTask.Status == devops.DONE {
+				domainTask.FinishedDate = step.FinishedDate
+				if step.FinishedDate != nil {
+					domainTask.DurationSec = uint64(step.FinishedDate.Sub(*step.StartedDate).Seconds())
+				}
+			}
+			return []interface{}{
+				domainTask,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
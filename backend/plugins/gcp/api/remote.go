@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+	"github.com/apache/incubator-devlake/plugins/gcp/tasks"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the projects are not grouped
+// @Tags plugins/gcp
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gcp/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		nil,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.GcpConnection) ([]models.GcpApiProject, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			return listProjects(basicRes, &connection, queryData, "")
+		},
+	)
+}
+
+// SearchRemoteScopes filters the projects by id or name
+// @Summary filters the projects by id or name
+// @Description filters the projects by id or name
+// @Tags plugins/gcp
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gcp/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.GcpConnection) ([]models.GcpApiProject, errors.Error) {
+			return listProjects(basicRes, &connection, queryData, queryData.Search[0])
+		},
+	)
+}
+
+// listProjects returns all the projects on the first page, the api pages them by a token which can not be turned
+// into a page number, they are filtered by id or name when a search is given
+func listProjects(basicRes context2.BasicRes, connection *models.GcpConnection, queryData *api.RemoteQueryData, search string) ([]models.GcpApiProject, errors.Error) {
+	if queryData.Page > 1 {
+		return nil, nil
+	}
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	projects := make([]models.GcpApiProject, 0)
+	query := url.Values{"pageSize": {"500"}}
+	for {
+		res, err := apiClient.Get(tasks.RESOURCE_MANAGER_ENDPOINT+"projects", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var resBody struct {
+			Projects      []models.GcpApiProject `json:"projects"`
+			NextPageToken string                 `json:"nextPageToken"`
+		}
+		err = api.UnmarshalResponse(res, &resBody)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range resBody.Projects {
+			if search == "" || strings.Contains(project.ProjectId, search) || strings.Contains(project.Name, search) {
+				projects = append(projects, project)
+			}
+		}
+		if resBody.NextPageToken == "" {
+			return projects, nil
+		}
+		query.Set("pageToken", resBody.NextPageToken)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gcp/impl"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+	"github.com/apache/incubator-devlake/plugins/gcp/tasks"
+)
+
+func TestGcpBuildDataFlow(t *testing.T) {
+
+	var gcp impl.Gcp
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gcp", gcp)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "(?i)deploy|rollout")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "(?i)prod")
+	taskData := &tasks.GcpTaskData{
+		Options: &tasks.GcpOptions{
+			ConnectionId: 1,
+			ProjectId:    "checkout-prod",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_gcp_projects.csv", &models.GcpProject{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gcp_api_triggers.csv", "_raw_gcp_api_triggers")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gcp_api_builds.csv", "_raw_gcp_api_builds")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GcpTrigger{})
+	dataflowTester.Subtask(tasks.ExtractApiTriggersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.GcpTrigger{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gcp_triggers.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.GcpBuild{})
+	dataflowTester.FlushTabler(&models.GcpBuildStep{})
+	dataflowTester.FlushTabler(&models.GcpBuildArtifact{})
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.GcpBuild{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gcp_builds.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.GcpBuildStep{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gcp_build_steps.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.GcpBuildArtifact{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gcp_build_artifacts.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertProjectMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+			"created_date",
+			"updated_date",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertBuildsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertBuildStepsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// statuses of the builds and their steps
+const (
+	STATUS_UNKNOWN        = "STATUS_UNKNOWN"
+	STATUS_PENDING        = "PENDING"
+	STATUS_QUEUED         = "QUEUED"
+	STATUS_WORKING        = "WORKING"
+	STATUS_SUCCESS        = "SUCCESS"
+	STATUS_FAILURE        = "FAILURE"
+	STATUS_INTERNAL_ERROR = "INTERNAL_ERROR"
+	STATUS_TIMEOUT        = "TIMEOUT"
+	STATUS_CANCELLED      = "CANCELLED"
+	STATUS_EXPIRED        = "EXPIRED"
+)
+
+// types of the artifacts
+const (
+	ARTIFACT_TYPE_IMAGE  = "IMAGE"
+	ARTIFACT_TYPE_OBJECT = "OBJECT"
+)
+
+// GcpTrigger is a build trigger of the project, the repository is the one it builds
+type GcpTrigger struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	TriggerId    string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId    string `gorm:"index;type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	RepoUrl      string `gorm:"type:varchar(255)"`
+	Branch       string `gorm:"type:varchar(255)"`
+	Disabled     bool
+	CreateTime   *time.Time
+	common.NoPKModel
+}
+
+func (GcpTrigger) TableName() string {
+	return "_tool_gcp_triggers"
+}
+
+// GcpBuild is a build of the project, the commit and branch come from its substitutions
+type GcpBuild struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	BuildId       string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId     string `gorm:"index;type:varchar(255)"`
+	TriggerId     string `gorm:"index;type:varchar(100)"`
+	Status        string `gorm:"type:varchar(20)"`
+	CommitSha     string `gorm:"type:varchar(40)"`
+	Branch        string `gorm:"type:varchar(255)"`
+	RepoUrl       string `gorm:"type:varchar(255)"`
+	Tags          string `gorm:"type:varchar(255)"`
+	Substitutions string
+	LogUrl        string `gorm:"type:varchar(255)"`
+	CreateTime    *time.Time
+	StartTime     *time.Time
+	FinishTime    *time.Time
+	common.NoPKModel
+}
+
+func (GcpBuild) TableName() string {
+	return "_tool_gcp_builds"
+}
+
+// GcpBuildStep is a step of a build, the name is the image of its builder
+type GcpBuildStep struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	BuildId      string `gorm:"primaryKey;type:varchar(100)"`
+	StepIndex    int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    string `gorm:"index;type:varchar(255)"`
+	StepId       string `gorm:"type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Status       string `gorm:"type:varchar(20)"`
+	StartTime    *time.Time
+	EndTime      *time.Time
+	common.NoPKModel
+}
+
+func (GcpBuildStep) TableName() string {
+	return "_tool_gcp_build_steps"
+}
+
+// GcpBuildArtifact is an image pushed or an object uploaded by a build
+type GcpBuildArtifact struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	BuildId      string `gorm:"primaryKey;type:varchar(100)"`
+	Name         string `gorm:"primaryKey;type:varchar(255)"`
+	ProjectId    string `gorm:"index;type:varchar(255)"`
+	Type         string `gorm:"type:varchar(20)"`
+	Digest       string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (GcpBuildArtifact) TableName() string {
+	return "_tool_gcp_build_artifacts"
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+var _ plugin.ApiConnection = (*GcpConnection)(nil)
+var _ plugin.ApiAuthenticator = (*GcpConn)(nil)
+var _ plugin.PrepareApiClient = (*GcpConn)(nil)
+
+// cloudPlatformScope is the oauth scope granting the roles of the service account on all the apis
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// GcpConn holds the service account key to call the Cloud Build api, i.e. https://cloudbuild.googleapis.com/v1/
+type GcpConn struct {
+	helper.RestConnection `mapstructure:",squash"`
+	// ServiceAccountKey is the json key file of the service account
+	ServiceAccountKey string `mapstructure:"serviceAccountKey" validate:"required" json:"serviceAccountKey" gorm:"serializer:encdec"`
+
+	tokenSource oauth2.TokenSource
+}
+
+// serviceAccountKey is the part of the json key file needed to sign the token requests
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyId string `json:"private_key_id"`
+	TokenUri     string `json:"token_uri"`
+}
+
+// PrepareApiClient parses the key once, the access tokens are then reused until they expire
+func (conn *GcpConn) PrepareApiClient(apiClient apihelperabstract.ApiClientAbstract) errors.Error {
+	key := &serviceAccountKey{}
+	err := json.Unmarshal([]byte(conn.ServiceAccountKey), key)
+	if err != nil {
+		return errors.BadInput.Wrap(err, "the service account key is not a json key file")
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return errors.BadInput.New("the service account key misses the client_email or the private_key")
+	}
+	config := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyId,
+		Scopes:       []string{cloudPlatformScope},
+		TokenURL:     key.TokenUri,
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = "https://oauth2.googleapis.com/token"
+	}
+	conn.tokenSource = config.TokenSource(context.Background())
+	return nil
+}
+
+// SetupAuthentication sets the access token of the service account, it is refreshed when it expires
+func (conn *GcpConn) SetupAuthentication(req *http.Request) errors.Error {
+	if conn.tokenSource == nil {
+		return errors.Default.New("the service account key is not prepared")
+	}
+	token, err := conn.tokenSource.Token()
+	if err != nil {
+		return errors.Unauthorized.Wrap(err, "failed to request an access token for the service account")
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	return nil
+}
+
+// GcpConnection holds GcpConn plus ID/Name for database storage
+type GcpConnection struct {
+	helper.BaseConnection `mapstructure:",squash"`
+	GcpConn               `mapstructure:",squash"`
+}
+
+func (GcpConnection) TableName() string {
+	return "_tool_gcp_connections"
+}
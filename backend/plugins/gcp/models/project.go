@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*GcpProject)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*GcpApiProject)(nil)
+
+// GcpProject is a project the builds of are collected
+type GcpProject struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	ProjectId            string `json:"projectId" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"projectId"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	ProjectNumber        string `json:"projectNumber" gorm:"type:varchar(100)" mapstructure:"projectNumber,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (GcpProject) TableName() string {
+	return "_tool_gcp_projects"
+}
+
+func (p GcpProject) ScopeId() string {
+	return p.ProjectId
+}
+
+func (p GcpProject) ScopeName() string {
+	return p.Name
+}
+
+// GcpApiProject is a project returned by the Resource Manager api
+type GcpApiProject struct {
+	ProjectId     string `json:"projectId"`
+	Name          string `json:"name"`
+	ProjectNumber string `json:"projectNumber"`
+}
+
+func (p GcpApiProject) ConvertApiScope() plugin.ToolLayerScope {
+	return &GcpProject{
+		ProjectId:     p.ProjectId,
+		Name:          p.Name,
+		ProjectNumber: p.ProjectNumber,
+	}
+}
+
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type GcpApiParams struct {
+	ConnectionId uint64
+	ProjectId    string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *GcpTaskData) {
+	data := taskCtx.GetData().(*GcpTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: GcpApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ProjectId:    data.Options.ProjectId,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// pageQuery sets the size of the page and the token of the previous response to request the next one
+func pageQuery(reqData *api.RequestData) url.Values {
+	query := url.Values{}
+	query.Set("pageSize", fmt.Sprintf("%v", reqData.Pager.Size))
+	if reqData.CustomData != nil {
+		query.Set("pageToken", reqData.CustomData.(string))
+	}
+	return query
+}
+
+// getNextPageToken pages the lists by the token of the previous response, they are done when it is omitted
+func getNextPageToken(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	body := &struct {
+		NextPageToken string `json:"nextPageToken"`
+	}{}
+	err := api.UnmarshalResponse(prevPageResponse, body)
+	if err != nil {
+		return nil, err
+	}
+	if body.NextPageToken == "" {
+		return nil, api.ErrFinishCollect
+	}
+	return body.NextPageToken, nil
+}
+
+// unmarshalList returns the items of a list response under the given key
+func unmarshalList(res *http.Response, key string) ([]json.RawMessage, errors.Error) {
+	body := map[string]json.RawMessage{}
+	err := api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	var items []json.RawMessage
+	if body[key] == nil {
+		return items, nil
+	}
+	return items, errors.Convert(json.Unmarshal(body[key], &items))
+}
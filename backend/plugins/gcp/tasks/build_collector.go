@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_BUILD_TABLE = "gcp_api_builds"
+
+var CollectApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBuilds",
+	EntryPoint:       CollectApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Collect the builds of the project from Cloud Build api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	incremental := collectorWithState.IsIncremental()
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        incremental,
+		UrlTemplate:        "projects/{{ .Params.ProjectId }}/builds",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := pageQuery(reqData)
+			since := collectorWithState.TimeAfter
+			if incremental {
+				since = collectorWithState.LatestState.LatestSuccessStart
+			}
+			if since != nil {
+				query.Set("filter", fmt.Sprintf(`create_time>"%s"`, since.UTC().Format(time.RFC3339)))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: getNextPageToken,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			return unmarshalList(res, "builds")
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
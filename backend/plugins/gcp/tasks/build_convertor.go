@@ -0,0 +1,174 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+)
+
+var ConvertBuildsMeta = plugin.SubTaskMeta{
+	Name:             "convertBuilds",
+	EntryPoint:       ConvertBuilds,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gcp_builds into domain layer table cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+var buildResultRule = &devops.ResultRule{
+	Success: []string{models.STATUS_SUCCESS},
+	Failed:  []string{models.STATUS_FAILURE, models.STATUS_INTERNAL_ERROR, models.STATUS_TIMEOUT},
+	Abort:   []string{models.STATUS_CANCELLED, models.STATUS_EXPIRED},
+	Default: "",
+}
+
+var buildStatusRule = &devops.StatusRule{
+	InProgress: []string{models.STATUS_PENDING, models.STATUS_QUEUED, models.STATUS_WORKING},
+	Default:    devops.DONE,
+}
+
+// loadTriggers returns the triggers of the project by their id
+func loadTriggers(db dal.Dal, data *GcpTaskData) (map[string]*models.GcpTrigger, errors.Error) {
+	var triggers []models.GcpTrigger
+	err := db.All(
+		&triggers,
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	triggersById := make(map[string]*models.GcpTrigger, len(triggers))
+	for i := range triggers {
+		triggersById[triggers[i].TriggerId] = &triggers[i]
+	}
+	return triggersById, nil
+}
+
+// getBuildTargets returns the name of the trigger and the tags of a build which tell whether it deploys and where to
+func getBuildTargets(build *models.GcpBuild, trigger *models.GcpTrigger) []string {
+	targets := make([]string, 0)
+	if trigger != nil {
+		targets = append(targets, trigger.Name)
+	}
+	if build.Tags != "" {
+		targets = append(targets, strings.Split(build.Tags, ",")...)
+	}
+	return targets
+}
+
+func ConvertBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	db := taskCtx.GetDal()
+
+	triggers, err := loadTriggers(db, data)
+	if err != nil {
+		return err
+	}
+	// the build deploys as long as one of its steps does
+	var deploySteps []models.GcpBuildStep
+	err = db.All(
+		&deploySteps,
+		dal.Select("build_id, step_id"),
+		dal.Where("connection_id = ? AND project_id = ? AND step_id != ''", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	deployBuilds := make(map[string]bool)
+	for _, step := range deploySteps {
+		if data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, step.StepId) != "" {
+			deployBuilds[step.BuildId] = true
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GcpBuild{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	buildIdGen := didgen.NewDomainIdGenerator(&models.GcpBuild{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GcpProject{})
+	scopeId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GcpBuild{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			build := inputRow.(*models.GcpBuild)
+			if build.CreateTime == nil {
+				return nil, nil
+			}
+			trigger := triggers[build.TriggerId]
+			id := buildIdGen.Generate(build.ConnectionId, build.BuildId)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				// the builds submitted without a trigger are named by their id
+				Name:        build.BuildId,
+				Result:      devops.GetResult(buildResultRule, build.Status),
+				Status:      devops.GetStatus(buildStatusRule, build.Status),
+				CreatedDate: *build.CreateTime,
+				CicdScopeId: scopeId,
+			}
+			if trigger != nil {
+				domainPipeline.Name = trigger.Name
+			}
+			targets := getBuildTargets(build, trigger)
+			if deployBuilds[build.BuildId] || data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, targets...) != "" {
+				domainPipeline.Type = devops.DEPLOYMENT
+				domainPipeline.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, targets...)
+			}
+			if domainPipeline.Status == devops.DONE && build.FinishTime != nil {
+				domainPipeline.FinishedDate = build.FinishTime
+				domainPipeline.DurationSec = uint64(build.FinishTime.Sub(*build.CreateTime).Seconds())
+			}
+			results := []interface{}{domainPipeline}
+			if build.CommitSha != "" {
+				repoUrl := build.RepoUrl
+				if repoUrl == "" && trigger != nil {
+					repoUrl = trigger.RepoUrl
+				}
+				results = append(results, &devops.CiCDPipelineCommit{
+					PipelineId: id,
+					CommitSha:  build.CommitSha,
+					Branch:     build.Branch,
+					RepoUrl:    repoUrl,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,180 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+)
+
+var ExtractApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBuilds",
+	EntryPoint:       ExtractApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Extract raw builds data into tool layer table _tool_gcp_builds, _tool_gcp_build_steps and _tool_gcp_build_artifacts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type GcpApiTiming struct {
+	StartTime *time.Time `json:"startTime"`
+	EndTime   *time.Time `json:"endTime"`
+}
+
+type GcpApiBuildStep struct {
+	Id     string       `json:"id"`
+	Name   string       `json:"name"`
+	Status string       `json:"status"`
+	Timing GcpApiTiming `json:"timing"`
+}
+
+type GcpApiBuild struct {
+	Id             string            `json:"id"`
+	Status         string            `json:"status"`
+	BuildTriggerId string            `json:"buildTriggerId"`
+	Substitutions  map[string]string `json:"substitutions"`
+	Tags           []string          `json:"tags"`
+	LogUrl         string            `json:"logUrl"`
+	CreateTime     *time.Time        `json:"createTime"`
+	StartTime      *time.Time        `json:"startTime"`
+	FinishTime     *time.Time        `json:"finishTime"`
+	Steps          []GcpApiBuildStep `json:"steps"`
+	Source         struct {
+		GitSource *struct {
+			Url      string `json:"url"`
+			Revision string `json:"revision"`
+		} `json:"gitSource"`
+		RepoSource *struct {
+			CommitSha  string `json:"commitSha"`
+			BranchName string `json:"branchName"`
+		} `json:"repoSource"`
+	} `json:"source"`
+	Results struct {
+		Images []struct {
+			Name   string `json:"name"`
+			Digest string `json:"digest"`
+		} `json:"images"`
+	} `json:"results"`
+	Artifacts struct {
+		Objects *struct {
+			Location string   `json:"location"`
+			Paths    []string `json:"paths"`
+		} `json:"objects"`
+	} `json:"artifacts"`
+}
+
+func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiBuild := &GcpApiBuild{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiBuild))
+			if err != nil {
+				return nil, err
+			}
+			// the builds created before timeAfter are skipped
+			if data.TimeAfter != nil && apiBuild.CreateTime != nil && apiBuild.CreateTime.Before(*data.TimeAfter) {
+				return nil, nil
+			}
+			build := &models.GcpBuild{
+				ConnectionId: data.Options.ConnectionId,
+				BuildId:      apiBuild.Id,
+				ProjectId:    data.Options.ProjectId,
+				TriggerId:    apiBuild.BuildTriggerId,
+				Status:       apiBuild.Status,
+				CommitSha:    apiBuild.Substitutions["COMMIT_SHA"],
+				Branch:       apiBuild.Substitutions["BRANCH_NAME"],
+				Tags:         strings.Join(apiBuild.Tags, ","),
+				LogUrl:       apiBuild.LogUrl,
+				CreateTime:   apiBuild.CreateTime,
+				StartTime:    apiBuild.StartTime,
+				FinishTime:   apiBuild.FinishTime,
+			}
+			if len(apiBuild.Substitutions) > 0 {
+				substitutions, err := json.Marshal(apiBuild.Substitutions)
+				if err != nil {
+					return nil, errors.Convert(err)
+				}
+				build.Substitutions = string(substitutions)
+			}
+			// the builds submitted without a trigger name their source themselves
+			if apiBuild.Source.GitSource != nil {
+				build.RepoUrl = apiBuild.Source.GitSource.Url
+				if build.CommitSha == "" {
+					build.CommitSha = apiBuild.Source.GitSource.Revision
+				}
+			} else if apiBuild.Source.RepoSource != nil {
+				if build.CommitSha == "" {
+					build.CommitSha = apiBuild.Source.RepoSource.CommitSha
+				}
+				if build.Branch == "" {
+					build.Branch = apiBuild.Source.RepoSource.BranchName
+				}
+			}
+			results := make([]interface{}, 0, len(apiBuild.Steps)+len(apiBuild.Results.Images)+1)
+			results = append(results, build)
+			for i, apiStep := range apiBuild.Steps {
+				results = append(results, &models.GcpBuildStep{
+					ConnectionId: data.Options.ConnectionId,
+					BuildId:      apiBuild.Id,
+					StepIndex:    i,
+					ProjectId:    data.Options.ProjectId,
+					StepId:       apiStep.Id,
+					Name:         apiStep.Name,
+					Status:       apiStep.Status,
+					StartTime:    apiStep.Timing.StartTime,
+					EndTime:      apiStep.Timing.EndTime,
+				})
+			}
+			for _, image := range apiBuild.Results.Images {
+				results = append(results, &models.GcpBuildArtifact{
+					ConnectionId: data.Options.ConnectionId,
+					BuildId:      apiBuild.Id,
+					Name:         image.Name,
+					ProjectId:    data.Options.ProjectId,
+					Type:         models.ARTIFACT_TYPE_IMAGE,
+					Digest:       image.Digest,
+				})
+			}
+			// the objects are uploaded by their base name into the location
+			if objects := apiBuild.Artifacts.Objects; objects != nil {
+				for _, p := range objects.Paths {
+					results = append(results, &models.GcpBuildArtifact{
+						ConnectionId: data.Options.ConnectionId,
+						BuildId:      apiBuild.Id,
+						Name:         strings.TrimSuffix(objects.Location, "/") + "/" + path.Base(p),
+						ProjectId:    data.Options.ProjectId,
+						Type:         models.ARTIFACT_TYPE_OBJECT,
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+)
+
+var ConvertBuildStepsMeta = plugin.SubTaskMeta{
+	Name:             "convertBuildSteps",
+	EntryPoint:       ConvertBuildSteps,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gcp_build_steps into domain layer table cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertBuildSteps(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	db := taskCtx.GetDal()
+
+	// the deploy steps deploy to the environment of their build
+	triggers, err := loadTriggers(db, data)
+	if err != nil {
+		return err
+	}
+	var builds []models.GcpBuild
+	err = db.All(
+		&builds,
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	environments := make(map[string]string, len(builds))
+	for i := range builds {
+		targets := getBuildTargets(&builds[i], triggers[builds[i].TriggerId])
+		environments[builds[i].BuildId] = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, targets...)
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GcpBuildStep{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	stepIdGen := didgen.NewDomainIdGenerator(&models.GcpBuildStep{})
+	buildIdGen := didgen.NewDomainIdGenerator(&models.GcpBuild{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GcpProject{})
+	scopeId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GcpBuildStep{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			step := inputRow.(*models.GcpBuildStep)
+			// the steps queued or skipped never started
+			if step.StartTime == nil {
+				return nil, nil
+			}
+			// the steps without an id are named by the image of their builder
+			name := step.StepId
+			if name == "" {
+				name = step.Name
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: stepIdGen.Generate(step.ConnectionId, step.BuildId, step.StepIndex)},
+				Name:         name,
+				PipelineId:   buildIdGen.Generate(step.ConnectionId, step.BuildId),
+				Result:       devops.GetResult(buildResultRule, step.Status),
+				Status:       devops.GetStatus(buildStatusRule, step.Status),
+				Type:         data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, step.StepId),
+				StartedDate:  *step.StartTime,
+				CicdScopeId:  scopeId,
+			}
+			if domainTask.Type == devops.DEPLOYMENT {
+				domainTask.Environment = environments[step.BuildId]
+			}
+			if domainTask.Status == devops.DONE && step.EndTime != nil {
+				domainTask.FinishedDate = step.EndTime
+				domainTask.DurationSec = uint64(step.EndTime.Sub(*step.StartTime).Seconds())
+			}
+			return []interface{}{domainTask}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
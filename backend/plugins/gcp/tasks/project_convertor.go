@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+)
+
+// RESOURCE_MANAGER_ENDPOINT is the api the projects are requested from, the endpoint of the connection is the
+// Cloud Build one
+const RESOURCE_MANAGER_ENDPOINT = "https://cloudresourcemanager.googleapis.com/v1/"
+
+var ConvertProjectMeta = plugin.SubTaskMeta{
+	Name:             "convertProject",
+	EntryPoint:       ConvertProject,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gcp_projects into domain layer table cicd_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// GetApiProject fetches a project by its id
+func GetApiProject(op *GcpOptions, apiClient aha.ApiClientAbstract) (*models.GcpApiProject, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("%sprojects/%s", RESOURCE_MANAGER_ENDPOINT, op.ProjectId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting project detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	project := &models.GcpApiProject{}
+	err = api.UnmarshalResponse(res, project)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func ConvertProject(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILD_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GcpProject{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GcpProject{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GcpProject{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			project := inputRow.(*models.GcpProject)
+			return []interface{}{
+				&devops.CicdScope{
+					DomainEntity: domainlayer.DomainEntity{Id: projectIdGen.Generate(project.ConnectionId, project.ProjectId)},
+					Name:         project.Name,
+					Url:          fmt.Sprintf("https://console.cloud.google.com/cloud-build/builds?project=%s", project.ProjectId),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
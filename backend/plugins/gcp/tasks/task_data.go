@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+)
+
+type GcpOptions struct {
+	ConnectionId                  uint64   `json:"connectionId" mapstructure:"connectionId,omitempty"`
+	Tasks                         []string `json:"tasks,omitempty" mapstructure:",omitempty"`
+	ProjectId                     string   `json:"projectId" mapstructure:"projectId"`
+	TimeAfter                     string   `json:"timeAfter" mapstructure:"timeAfter,omitempty"`
+	TransformationRuleId          uint64   `json:"transformationRuleId" mapstructure:"transformationRuleId,omitempty"`
+	*models.GcpTransformationRule `mapstructure:"transformationRules,omitempty" json:"transformationRules"`
+}
+
+type GcpTaskData struct {
+	Options       *GcpOptions
+	ApiClient     *api.ApiAsyncClient
+	TimeAfter     *time.Time
+	RegexEnricher *api.RegexEnricher
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*GcpOptions, errors.Error) {
+	op, err := DecodeTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateTaskOptions(op)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func DecodeTaskOptions(options map[string]interface{}) (*GcpOptions, errors.Error) {
+	var op GcpOptions
+	err := api.Decode(options, &op, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+func EncodeTaskOptions(op *GcpOptions) (map[string]interface{}, errors.Error) {
+	var result map[string]interface{}
+	err := api.Decode(op, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func ValidateTaskOptions(op *GcpOptions) errors.Error {
+	if op.ProjectId == "" {
+		return errors.BadInput.New("projectId is required for Cloud Build")
+	}
+	if op.ConnectionId == 0 {
+		return errors.BadInput.New("connectionId is invalid")
+	}
+	return nil
+}
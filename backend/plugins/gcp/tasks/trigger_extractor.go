@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gcp/models"
+)
+
+var ExtractApiTriggersMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTriggers",
+	EntryPoint:       ExtractApiTriggers,
+	EnabledByDefault: true,
+	Description:      "Extract raw triggers data into tool layer table _tool_gcp_triggers",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type GcpApiTrigger struct {
+	Id          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Disabled    bool       `json:"disabled"`
+	CreateTime  *time.Time `json:"createTime"`
+	Github      *struct {
+		Owner string `json:"owner"`
+		Name  string `json:"name"`
+		Push  *struct {
+			Branch string `json:"branch"`
+		} `json:"push"`
+	} `json:"github"`
+	TriggerTemplate *struct {
+		ProjectId  string `json:"projectId"`
+		RepoName   string `json:"repoName"`
+		BranchName string `json:"branchName"`
+	} `json:"triggerTemplate"`
+	SourceToBuild *struct {
+		Uri string `json:"uri"`
+		Ref string `json:"ref"`
+	} `json:"sourceToBuild"`
+}
+
+func ExtractApiTriggers(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TRIGGER_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiTrigger := &GcpApiTrigger{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiTrigger))
+			if err != nil {
+				return nil, err
+			}
+			trigger := &models.GcpTrigger{
+				ConnectionId: data.Options.ConnectionId,
+				TriggerId:    apiTrigger.Id,
+				ProjectId:    data.Options.ProjectId,
+				Name:         apiTrigger.Name,
+				Description:  apiTrigger.Description,
+				Disabled:     apiTrigger.Disabled,
+				CreateTime:   apiTrigger.CreateTime,
+			}
+			// the triggers either watch a GitHub repository, a Cloud Source repository or build a given one manually
+			if apiTrigger.Github != nil {
+				trigger.RepoUrl = fmt.Sprintf("https://github.com/%s/%s", apiTrigger.Github.Owner, apiTrigger.Github.Name)
+				if apiTrigger.Github.Push != nil {
+					trigger.Branch = apiTrigger.Github.Push.Branch
+				}
+			} else if apiTrigger.TriggerTemplate != nil {
+				projectId := apiTrigger.TriggerTemplate.ProjectId
+				if projectId == "" {
+					projectId = data.Options.ProjectId
+				}
+				trigger.RepoUrl = fmt.Sprintf("https://source.developers.google.com/p/%s/r/%s", projectId, apiTrigger.TriggerTemplate.RepoName)
+				trigger.Branch = apiTrigger.TriggerTemplate.BranchName
+			} else if apiTrigger.SourceToBuild != nil {
+				trigger.RepoUrl = apiTrigger.SourceToBuild.Uri
+				trigger.Branch = strings.TrimPrefix(apiTrigger.SourceToBuild.Ref, "refs/heads/")
+			}
+			return []interface{}{trigger}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
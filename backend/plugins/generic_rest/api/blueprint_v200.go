@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/tasks"
+	"github.com/go-playground/validator/v10"
+)
+
+func MakeDataSourcePipelinePlanV200(subtaskMetas []plugin.SubTaskMeta, connectionId uint64, bpScopes []*plugin.BlueprintScopeV200, syncPolicy *plugin.BlueprintSyncPolicy) (plugin.PipelinePlan, []plugin.Scope, errors.Error) {
+	connectionHelper := helper.NewConnectionHelper(basicRes, validator.New())
+	connection := &models.GenericRestConnection{}
+	err := connectionHelper.FirstById(connection, connectionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan := make(plugin.PipelinePlan, len(bpScopes))
+	plan, err = makeDataSourcePipelinePlanV200(subtaskMetas, plan, bpScopes, connection, syncPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopes, err := makeScopesV200(bpScopes, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, scopes, nil
+}
+
+func makeDataSourcePipelinePlanV200(
+	subtaskMetas []plugin.SubTaskMeta,
+	plan plugin.PipelinePlan,
+	bpScopes []*plugin.BlueprintScopeV200,
+	connection *models.GenericRestConnection,
+	syncPolicy *plugin.BlueprintSyncPolicy,
+) (plugin.PipelinePlan, errors.Error) {
+	for i, bpScope := range bpScopes {
+		stage := plan[i]
+		if stage == nil {
+			stage = plugin.PipelineStage{}
+		}
+		scope := &models.GenericRestScope{}
+		// get scope from db
+		err := basicRes.GetDal().First(scope, dal.Where(`connection_id = ? AND scope_key = ?`, connection.ID, bpScope.Id))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find scope %s", bpScope.Id))
+		}
+
+		// construct task options for generic_rest, the records are collected as a whole every time
+		op := &tasks.GenericRestOptions{
+			ConnectionId:         scope.ConnectionId,
+			ScopeKey:             scope.ScopeKey,
+			TransformationRuleId: scope.TransformationRuleId,
+		}
+		options, err := tasks.EncodeTaskOptions(op)
+		if err != nil {
+			return nil, err
+		}
+
+		subtasks, err := helper.MakePipelinePlanSubtasks(subtaskMetas, bpScope.Entities)
+		if err != nil {
+			return nil, err
+		}
+		stage = append(stage, &plugin.PipelineTask{
+			Plugin:   "generic_rest",
+			Subtasks: subtasks,
+			Options:  options,
+		})
+		plan[i] = stage
+	}
+	return plan, nil
+}
+
+func makeScopesV200(bpScopes []*plugin.BlueprintScopeV200, connection *models.GenericRestConnection) ([]plugin.Scope, errors.Error) {
+	scopes := make([]plugin.Scope, 0)
+	for _, bpScope := range bpScopes {
+		scope := &models.GenericRestScope{}
+		// get scope from db
+		err := basicRes.GetDal().First(scope, dal.Where(`connection_id = ? AND scope_key = ?`, connection.ID, bpScope.Id))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find scope %s", bpScope.Id))
+		}
+		domainEntity := domainlayer.DomainEntity{
+			Id: didgen.NewDomainIdGenerator(&models.GenericRestScope{}).Generate(connection.ID, scope.ScopeKey),
+		}
+		// the records of a scope are mapped into one entity, the scope is a board or a cicd scope accordingly
+		rule := &models.GenericRestTransformationRule{}
+		err = basicRes.GetDal().First(rule, dal.Where(`id = ?`, scope.TransformationRuleId))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find the transformation rule of scope %s", bpScope.Id))
+		}
+		if rule.Entity == models.ENTITY_ISSUES {
+			if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_TICKET) {
+				scopes = append(scopes, &ticket.Board{
+					DomainEntity: domainEntity,
+					Name:         scope.Name,
+					Url:          scope.Url,
+				})
+			}
+		} else if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_CICD) {
+			scopes = append(scopes, &devops.CicdScope{
+				DomainEntity: domainEntity,
+				Name:         scope.Name,
+				Url:          scope.Url,
+			})
+		}
+	}
+	return scopes, nil
+}
@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	connection := &models.GenericRestConnection{
+		BaseConnection: helper.BaseConnection{
+			Name: "generic-rest-test",
+			Model: common.Model{
+				ID: 1,
+			},
+		},
+		GenericRestConn: models.GenericRestConn{
+			RestConnection: helper.RestConnection{
+				Endpoint:         "https://deploy.example.com/api/",
+				Proxy:            "",
+				RateLimitPerHour: 0,
+			},
+			GenericRestAccessToken: models.GenericRestAccessToken{
+				Token:       "secret",
+				TokenHeader: "PRIVATE-TOKEN",
+			},
+		},
+	}
+	mockMeta := mockplugin.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/generic_rest")
+	err := plugin.RegisterPlugin("generic_rest", mockMeta)
+	assert.Nil(t, err)
+	// Refresh Global Variables and set the sql mock
+	basicRes = NewMockBasicRes()
+	bs := &plugin.BlueprintScopeV200{
+		Entities: []string{"CICD"},
+		Id:       "shipit",
+	}
+	bpScopes := make([]*plugin.BlueprintScopeV200, 0)
+	bpScopes = append(bpScopes, bs)
+	syncPolicy := &plugin.BlueprintSyncPolicy{}
+
+	plan := make(plugin.PipelinePlan, len(bpScopes))
+	plan, err = makeDataSourcePipelinePlanV200(nil, plan, bpScopes, connection, syncPolicy)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes()
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	expectPlan := plugin.PipelinePlan{
+		plugin.PipelineStage{
+			{
+				Plugin:   "generic_rest",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"scopeKey":             "shipit",
+					"connectionId":         uint64(1),
+					"transformationRuleId": uint64(1),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := make([]plugin.Scope, 0)
+	scopeCicd := &devops.CicdScope{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: "generic_rest:GenericRestScope:1:shipit",
+		},
+		Name: "Shipit deployments",
+		Url:  "https://deploy.example.com/shipit",
+	}
+	expectScopes = append(expectScopes, scopeCicd)
+	assert.Equal(t, expectScopes, scopes)
+}
+
+// NewMockBasicRes FIXME ...
+func NewMockBasicRes() *mockcontext.BasicRes {
+	testGenericRestScope := &models.GenericRestScope{
+		ConnectionId:         1,
+		ScopeKey:             "shipit",
+		Name:                 "Shipit deployments",
+		Url:                  "https://deploy.example.com/shipit",
+		TransformationRuleId: 1,
+	}
+	testGenericRestTransformationRule := &models.GenericRestTransformationRule{
+		Name:   "shipit",
+		Path:   "deployments",
+		Entity: models.ENTITY_CICD_PIPELINES,
+		IdPath: "id",
+	}
+
+	mockRes := new(mockcontext.BasicRes)
+	mockDal := new(mockdal.Dal)
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		switch dst := args.Get(0).(type) {
+		case *models.GenericRestScope:
+			*dst = *testGenericRestScope
+		case *models.GenericRestTransformationRule:
+			*dst = *testGenericRestTransformationRule
+		}
+	}).Return(nil)
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
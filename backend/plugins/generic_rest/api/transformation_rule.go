@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/tasks"
+)
+
+// validateTransformationRule applies the body to the rule and rejects the endpoints the pipelines would fail to collect or map
+func validateTransformationRule(input *plugin.ApiResourceInput, rule *models.GenericRestTransformationRule) errors.Error {
+	err := api.DecodeMapStruct(input.Body, rule, false)
+	if err != nil {
+		return errors.BadInput.Wrap(err, "could not decode the transformation rule")
+	}
+	return tasks.ValidateTransformationRule(rule)
+}
+
+// CreateTransformationRule create transformation rule for GenericRest
+// @Summary create transformation rule for GenericRest
+// @Description create transformation rule for GenericRest
+// @Tags plugins/generic_rest
+// @Accept application/json
+// @Param connectionId path int true "connectionId"
+// @Param transformationRule body models.GenericRestTransformationRule true "transformation rule"
+// @Success 200  {object} models.GenericRestTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/generic_rest/connections/{connectionId}/transformation_rules [POST]
+func CreateTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	err := validateTransformationRule(input, &models.GenericRestTransformationRule{})
+	if err != nil {
+		return nil, err
+	}
+	return trHelper.Create(input)
+}
+
+// UpdateTransformationRule update transformation rule for GenericRest
+// @Summary update transformation rule for GenericRest
+// @Description update transformation rule for GenericRest
+// @Tags plugins/generic_rest
+// @Accept application/json
+// @Param id path int true "id"
+// @Param connectionId path int true "connectionId"
+// @Param transformationRule body models.GenericRestTransformationRule true "transformation rule"
+// @Success 200  {object} models.GenericRestTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/generic_rest/connections/{connectionId}/transformation_rules/{id} [PATCH]
+func UpdateTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	rule := &models.GenericRestTransformationRule{}
+	err := basicRes.GetDal().First(rule, dal.Where("id = ?", input.Params["id"]))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error on getting TransformationRule")
+	}
+	err = validateTransformationRule(input, rule)
+	if err != nil {
+		return nil, err
+	}
+	return trHelper.Update(input)
+}
+
+// GetTransformationRule return one transformation rule
+// @Summary return one transformation rule
+// @Description return one transformation rule
+// @Tags plugins/generic_rest
+// @Param id path int true "id"
+// @Param connectionId path int true "connectionId"
+// @Success 200  {object} models.GenericRestTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/generic_rest/connections/{connectionId}/transformation_rules/{id} [GET]
+func GetTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.Get(input)
+}
+
+// GetTransformationRuleList return all transformation rules
+// @Summary return all transformation rules
+// @Description return all transformation rules
+// @Tags plugins/generic_rest
+// @Param connectionId path int true "connectionId"
+// @Param pageSize query int false "page size, default 50"
+// @Param page query int false "page size, default 1"
+// @Success 200  {object} []models.GenericRestTransformationRule
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/generic_rest/connections/{connectionId}/transformation_rules [GET]
+func GetTransformationRuleList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.List(input)
+}
+
+// BatchTransformationRules create, update and delete transformation rules for GenericRest in one transaction
+// @Summary create, update and delete transformation rules for GenericRest in one transaction
+// @Description create, update and delete transformation rules for GenericRest in one transaction, nothing is saved when any of them fails
+// @Tags plugins/generic_rest
+// @Accept application/json
+// @Param connectionId path int true "connectionId"
+// @Param batch body object true "the rules under create and update, the ids under delete"
+// @Success 200  {object} api.TransformationRuleBatchOutput
+// @Failure 400  {object} api.TransformationRuleBatchOutput "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/generic_rest/connections/{connectionId}/transformation_rules/batch [POST]
+func BatchTransformationRules(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.Batch(input)
+}
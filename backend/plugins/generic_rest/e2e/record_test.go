@@ -0,0 +1,159 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/impl"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/tasks"
+)
+
+func TestGenericRestRecordDataFlow(t *testing.T) {
+
+	var genericRest impl.GenericRest
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "generic_rest", genericRest)
+
+	deploymentTaskData := &tasks.GenericRestTaskData{
+		Options: &tasks.GenericRestOptions{
+			ConnectionId: 1,
+			ScopeKey:     "shipit",
+			GenericRestTransformationRule: &models.GenericRestTransformationRule{
+				Path:       "apps/{{ .Params.ScopeKey }}/deployments",
+				Pagination: models.PAGINATION_PAGE,
+				Entity:     models.ENTITY_CICD_PIPELINES,
+				IdPath:     "id",
+				FieldMapping: map[string]interface{}{
+					"name":          "app.name",
+					"result":        "state",
+					"status":        "state",
+					"type":          "kind",
+					"environment":   "target",
+					"created_date":  "started_at",
+					"finished_date": "finished_at",
+				},
+				ValueMapping: map[string]interface{}{
+					"result": map[string]interface{}{"success": devops.SUCCESS, "failed": devops.FAILURE, "running": ""},
+					"status": map[string]interface{}{"success": devops.DONE, "failed": devops.DONE, "running": devops.IN_PROGRESS},
+					"type":   map[string]interface{}{"deploy": devops.DEPLOYMENT},
+				},
+			},
+		},
+	}
+	incidentTaskData := &tasks.GenericRestTaskData{
+		Options: &tasks.GenericRestOptions{
+			ConnectionId: 1,
+			ScopeKey:     "incidents",
+			GenericRestTransformationRule: &models.GenericRestTransformationRule{
+				Path:           "incidents",
+				DataPath:       "data",
+				Pagination:     models.PAGINATION_CURSOR,
+				NextCursorPath: "meta.next",
+				Entity:         models.ENTITY_ISSUES,
+				IdPath:         "key",
+				FieldMapping: map[string]interface{}{
+					"url":             "links.self",
+					"title":           "summary",
+					"description":     "details",
+					"type":            "category",
+					"original_type":   "category",
+					"status":          "state",
+					"original_status": "state",
+					"story_point":     "estimate",
+					"resolution_date": "resolved_at",
+					"created_date":    "opened_at",
+					"priority":        "severity.level",
+					"assignee_name":   "owner.name",
+				},
+				ValueMapping: map[string]interface{}{
+					"type":   map[string]interface{}{"incident": ticket.INCIDENT},
+					"status": map[string]interface{}{"open": ticket.TODO, "investigating": ticket.IN_PROGRESS, "resolved": ticket.DONE},
+				},
+			},
+		},
+	}
+	for _, taskData := range []*tasks.GenericRestTaskData{deploymentTaskData, incidentTaskData} {
+		err := tasks.ValidateTransformationRule(taskData.Options.GenericRestTransformationRule)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_generic_rest_api_records.csv", "_raw_generic_rest_api_records")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_generic_rest_scopes.csv", &models.GenericRestScope{})
+
+	// verify the records of a scope are mapped into pipelines
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.Subtask(tasks.ExtractRecordsMeta, deploymentTaskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CICDPipeline{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cicd_pipelines.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify the records of another scope are mapped into issues
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ExtractRecordsMeta, incidentTaskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"story_point",
+			"resolution_date",
+			"created_date",
+			"priority",
+			"assignee_name",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		[]string{"board_id", "issue_id"},
+	)
+
+	// verify the scopes are converted by the entities of their records
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertScopeMeta, deploymentTaskData)
+	dataflowTester.Subtask(tasks.ConvertScopeMeta, incidentTaskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{"id", "name", "description", "url"},
+	)
+	dataflowTester.VerifyTable(
+		ticket.Board{},
+		"./snapshot_tables/boards.csv",
+		[]string{"id", "name", "description", "url"},
+	)
+}
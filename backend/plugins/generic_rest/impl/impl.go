@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/tasks"
+)
+
+var _ plugin.PluginMeta = (*GenericRest)(nil)
+var _ plugin.PluginInit = (*GenericRest)(nil)
+var _ plugin.PluginTask = (*GenericRest)(nil)
+var _ plugin.PluginApi = (*GenericRest)(nil)
+var _ plugin.PluginModel = (*GenericRest)(nil)
+var _ plugin.PluginMigration = (*GenericRest)(nil)
+var _ plugin.CloseablePluginTask = (*GenericRest)(nil)
+var _ plugin.PluginSource = (*GenericRest)(nil)
+
+type GenericRest string
+
+func (p GenericRest) Connection() interface{} {
+	return &models.GenericRestConnection{}
+}
+
+func (p GenericRest) Scope() interface{} {
+	return &models.GenericRestScope{}
+}
+
+func (p GenericRest) TransformationRule() interface{} {
+	return &models.GenericRestTransformationRule{}
+}
+
+func (p GenericRest) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p GenericRest) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.GenericRestConnection{},
+		&models.GenericRestScope{},
+		&models.GenericRestTransformationRule{},
+	}
+}
+
+func (p GenericRest) Description() string {
+	return "To collect the records of the internal tools from their REST apis and map them into the domain layer by configuration"
+}
+
+func (p GenericRest) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectRecordsMeta,
+		tasks.ExtractRecordsMeta,
+		tasks.ConvertScopeMeta,
+	}
+}
+
+func (p GenericRest) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.GenericRestConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get generic_rest connection by the given connection ID")
+	}
+	err = EnrichOptions(taskCtx, op)
+	if err != nil {
+		return nil, err
+	}
+	err = tasks.ValidateTransformationRule(op.GenericRestTransformationRule)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get generic_rest API client instance")
+	}
+	return &tasks.GenericRestTaskData{
+		Options:   op,
+		ApiClient: apiClient,
+	}, nil
+}
+
+func (p GenericRest) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/generic_rest"
+}
+
+func (p GenericRest) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p GenericRest) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p GenericRest) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/:scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
+	}
+}
+
+func (p GenericRest) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.GenericRestTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+// EnrichOptions loads the transformation rule of the scope, the scopes are declared by the user
+// so the ones given to the standalone runs are saved on the fly
+func EnrichOptions(taskCtx plugin.TaskContext, op *tasks.GenericRestOptions) errors.Error {
+	var scope models.GenericRestScope
+	db := taskCtx.GetDal()
+	err := db.First(&scope, dal.Where(
+		"connection_id = ? AND scope_key = ?",
+		op.ConnectionId, op.ScopeKey))
+	if err == nil {
+		if op.TransformationRuleId == 0 {
+			op.TransformationRuleId = scope.TransformationRuleId
+		}
+	} else {
+		if db.IsErrorNotFound(err) {
+			scope = models.GenericRestScope{
+				ConnectionId: op.ConnectionId,
+				ScopeKey:     op.ScopeKey,
+				Name:         op.ScopeKey,
+			}
+			err = db.CreateIfNotExist(&scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find scope %s", op.ScopeKey))
+		}
+	}
+	if op.GenericRestTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.GenericRestTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.GenericRestTransformationRule = &transformationRule
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*GenericRestConnection)(nil)
+
+// GenericRestAccessToken authenticates with a token sent in a configurable header, i.e. `PRIVATE-TOKEN`,
+// nothing is sent when the token is empty so that the apis open to the internal network can be collected as well
+type GenericRestAccessToken struct {
+	Token string `mapstructure:"token" json:"token" gorm:"serializer:encdec"`
+	// TokenHeader is the header carrying the token, it is `Authorization` when omitted
+	TokenHeader string `mapstructure:"tokenHeader" json:"tokenHeader" gorm:"type:varchar(100)"`
+	// TokenScheme is prepended to the token with a space, i.e. `Bearer`
+	TokenScheme string `mapstructure:"tokenScheme" json:"tokenScheme" gorm:"type:varchar(100)"`
+}
+
+// SetupAuthentication sets up the request headers for authentication
+func (at *GenericRestAccessToken) SetupAuthentication(request *http.Request) errors.Error {
+	if at.Token == "" {
+		return nil
+	}
+	header := at.TokenHeader
+	if header == "" {
+		header = "Authorization"
+	}
+	value := at.Token
+	if at.TokenScheme != "" {
+		value = fmt.Sprintf("%s %s", at.TokenScheme, at.Token)
+	}
+	request.Header.Set(header, value)
+	return nil
+}
+
+// GenericRestConn holds the essential information to connect to an internal REST api,
+// the endpoint is the base url the paths of the transformation rules are relative to
+type GenericRestConn struct {
+	api.RestConnection     `mapstructure:",squash"`
+	GenericRestAccessToken `mapstructure:",squash"`
+}
+
+// GenericRestConnection holds GenericRestConn plus ID/Name for database storage
+type GenericRestConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	GenericRestConn    `mapstructure:",squash"`
+}
+
+func (GenericRestConnection) TableName() string {
+	return "_tool_generic_rest_connections"
+}
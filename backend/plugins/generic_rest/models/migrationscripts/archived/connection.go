@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type BaseConnection struct {
+	Name string `gorm:"type:varchar(100);uniqueIndex" json:"name" validate:"required"`
+	archived.Model
+}
+
+type RestConnection struct {
+	Endpoint         string `mapstructure:"endpoint" validate:"required" json:"endpoint"`
+	Proxy            string `mapstructure:"proxy" json:"proxy"`
+	RateLimitPerHour int    `comment:"api request rate limit per hour" json:"rateLimitPerHour"`
+}
+
+type GenericRestAccessToken struct {
+	Token       string `mapstructure:"token" json:"token" encrypt:"yes"`
+	TokenHeader string `mapstructure:"tokenHeader" json:"tokenHeader" gorm:"type:varchar(100)"`
+	TokenScheme string `mapstructure:"tokenScheme" json:"tokenScheme" gorm:"type:varchar(100)"`
+}
+
+type GenericRestConn struct {
+	RestConnection         `mapstructure:",squash"`
+	GenericRestAccessToken `mapstructure:",squash"`
+}
+
+type GenericRestConnection struct {
+	BaseConnection  `mapstructure:",squash"`
+	GenericRestConn `mapstructure:",squash"`
+}
+
+func (GenericRestConnection) TableName() string {
+	return "_tool_generic_rest_connections"
+}
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"gorm.io/datatypes"
+)
+
+type GenericRestTransformationRule struct {
+	archived.Model   `mapstructure:"-"`
+	ConnectionId     uint64            `mapstructure:"connectionId" json:"connectionId"`
+	Name             string            `mapstructure:"name" json:"name" gorm:"type:varchar(255);index:idx_name_generic_rest,unique" validate:"required"`
+	Path             string            `mapstructure:"path" json:"path" gorm:"type:varchar(255)" validate:"required"`
+	DataPath         string            `mapstructure:"dataPath,omitempty" json:"dataPath" gorm:"type:varchar(255)"`
+	Pagination       string            `mapstructure:"pagination,omitempty" json:"pagination" gorm:"type:varchar(20)"`
+	PageParam        string            `mapstructure:"pageParam,omitempty" json:"pageParam" gorm:"type:varchar(100)"`
+	PageSizeParam    string            `mapstructure:"pageSizeParam,omitempty" json:"pageSizeParam" gorm:"type:varchar(100)"`
+	PageSize         int               `mapstructure:"pageSize,omitempty" json:"pageSize"`
+	CursorParam      string            `mapstructure:"cursorParam,omitempty" json:"cursorParam" gorm:"type:varchar(100)"`
+	NextCursorPath   string            `mapstructure:"nextCursorPath,omitempty" json:"nextCursorPath" gorm:"type:varchar(255)"`
+	Entity           string            `mapstructure:"entity" json:"entity" gorm:"type:varchar(100)" validate:"required"`
+	IdPath           string            `mapstructure:"idPath" json:"idPath" gorm:"type:varchar(255)" validate:"required"`
+	FieldMapping     datatypes.JSONMap `mapstructure:"fieldMapping,omitempty" json:"fieldMapping" swaggertype:"object" format:"json"`
+	ValueMapping     datatypes.JSONMap `mapstructure:"valueMapping,omitempty" json:"valueMapping" swaggertype:"object" format:"json"`
+	PipelineScopeKey string            `mapstructure:"pipelineScopeKey,omitempty" json:"pipelineScopeKey" gorm:"type:varchar(255)"`
+}
+
+func (GenericRestTransformationRule) TableName() string {
+	return "_tool_generic_rest_transformation_rules"
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*GenericRestScope)(nil)
+
+// GenericRestScope is declared by the user since there is nothing to list the scopes from,
+// the records of the endpoint of its transformation rule are collected with it,
+// the key is available to the path of the endpoint as `{{ .Params.ScopeKey }}`
+type GenericRestScope struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	ScopeKey             string `json:"scopeKey" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"scopeKey"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (GenericRestScope) TableName() string {
+	return "_tool_generic_rest_scopes"
+}
+
+func (s GenericRestScope) ScopeId() string {
+	return s.ScopeKey
+}
+
+func (s GenericRestScope) ScopeName() string {
+	return s.Name
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"gorm.io/datatypes"
+)
+
+// the pagination styles of the endpoints
+const (
+	PAGINATION_NONE   = "none"
+	PAGINATION_PAGE   = "page"
+	PAGINATION_OFFSET = "offset"
+	PAGINATION_CURSOR = "cursor"
+)
+
+// the domain entities the records can be mapped into
+const (
+	ENTITY_ISSUES         = "issues"
+	ENTITY_CICD_PIPELINES = "cicd_pipelines"
+	ENTITY_CICD_TASKS     = "cicd_tasks"
+)
+
+// GenericRestTransformationRule declares the endpoint to collect and how its records are mapped into the domain layer,
+// the paths picking the values out of the responses are GJSON paths, i.e. `data.items` or `owner.name`
+type GenericRestTransformationRule struct {
+	common.Model `mapstructure:"-"`
+	ConnectionId uint64 `mapstructure:"connectionId" json:"connectionId"`
+	Name         string `mapstructure:"name" json:"name" gorm:"type:varchar(255);index:idx_name_generic_rest,unique" validate:"required"`
+	// Path is relative to the endpoint of the connection and is a go template, i.e. `teams/{{ .Params.ScopeKey }}/incidents?state=all`
+	Path string `mapstructure:"path" json:"path" gorm:"type:varchar(255)" validate:"required"`
+	// DataPath picks the records out of a response, the response is the list of the records when it is omitted
+	DataPath string `mapstructure:"dataPath,omitempty" json:"dataPath" gorm:"type:varchar(255)"`
+	// Pagination is one of none/page/offset/cursor
+	Pagination string `mapstructure:"pagination,omitempty" json:"pagination" gorm:"type:varchar(20)"`
+	// PageParam is the query parameter of the page number or the offset, `page` or `offset` by default
+	PageParam string `mapstructure:"pageParam,omitempty" json:"pageParam" gorm:"type:varchar(100)"`
+	// PageSizeParam is the query parameter of the page size, `limit` by default
+	PageSizeParam string `mapstructure:"pageSizeParam,omitempty" json:"pageSizeParam" gorm:"type:varchar(100)"`
+	PageSize      int    `mapstructure:"pageSize,omitempty" json:"pageSize"`
+	// CursorParam is the query parameter the cursor is sent back with, `cursor` by default
+	CursorParam string `mapstructure:"cursorParam,omitempty" json:"cursorParam" gorm:"type:varchar(100)"`
+	// NextCursorPath picks the cursor of the next page out of a response, the collection stops when it is empty
+	NextCursorPath string `mapstructure:"nextCursorPath,omitempty" json:"nextCursorPath" gorm:"type:varchar(255)"`
+	// Entity is the domain table the records are mapped into, one of issues/cicd_pipelines/cicd_tasks
+	Entity string `mapstructure:"entity" json:"entity" gorm:"type:varchar(100)" validate:"required"`
+	// IdPath picks the id of a record, the domain id is generated from it
+	IdPath string `mapstructure:"idPath" json:"idPath" gorm:"type:varchar(255)" validate:"required"`
+	// FieldMapping maps the columns of the domain table to the paths of their values in a record,
+	// i.e. {"title": "summary", "created_date": "created_at"}
+	FieldMapping datatypes.JSONMap `mapstructure:"fieldMapping,omitempty" json:"fieldMapping" swaggertype:"object" format:"json"`
+	// ValueMapping translates the values of the columns, i.e. {"result": {"passed": "SUCCESS", "failed": "FAILURE"}},
+	// the values missing from it are kept as they are
+	ValueMapping datatypes.JSONMap `mapstructure:"valueMapping,omitempty" json:"valueMapping" swaggertype:"object" format:"json"`
+	// PipelineScopeKey is the scope the pipelines of the cicd_tasks are collected with, it is the scope of the tasks by default
+	PipelineScopeKey string `mapstructure:"pipelineScopeKey,omitempty" json:"pipelineScopeKey" gorm:"type:varchar(255)"`
+}
+
+func (GenericRestTransformationRule) TableName() string {
+	return "_tool_generic_rest_transformation_rules"
+}
@@ -0,0 +1,191 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/tidwall/gjson"
+	"gorm.io/gorm/schema"
+)
+
+// entityTypes are the domain tables the records can be mapped into
+var entityTypes = map[string]reflect.Type{
+	models.ENTITY_ISSUES:         reflect.TypeOf(ticket.Issue{}),
+	models.ENTITY_CICD_PIPELINES: reflect.TypeOf(devops.CICDPipeline{}),
+	models.ENTITY_CICD_TASKS:     reflect.TypeOf(devops.CICDTask{}),
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// columnIndex returns the index of the field of the column, the embedded fields like the id are not mappable
+func columnIndex(entityType reflect.Type, column string) (int, bool) {
+	naming := schema.NamingStrategy{}
+	for i := 0; i < entityType.NumField(); i++ {
+		field := entityType.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		name := schema.ParseTagSetting(field.Tag.Get("gorm"), ";")["COLUMN"]
+		if name == "" {
+			name = naming.ColumnName("", field.Name)
+		}
+		if name == column {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func isSupportedType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType == timeType {
+		return true
+	}
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// ValidateFieldMapping checks the entity and that every column of the mappings exists in its table
+func ValidateFieldMapping(entity string, fieldMapping map[string]interface{}, valueMapping map[string]interface{}) errors.Error {
+	entityType, ok := entityTypes[entity]
+	if !ok {
+		return errors.BadInput.New(fmt.Sprintf("unknown entity %s, it should be one of issues/cicd_pipelines/cicd_tasks", entity))
+	}
+	for column, path := range fieldMapping {
+		if _, ok := path.(string); !ok {
+			return errors.BadInput.New(fmt.Sprintf("the path of %s should be a string", column))
+		}
+		i, ok := columnIndex(entityType, column)
+		if !ok {
+			return errors.BadInput.New(fmt.Sprintf("unknown column %s of %s", column, entity))
+		}
+		if !isSupportedType(entityType.Field(i).Type) {
+			return errors.BadInput.New(fmt.Sprintf("column %s of %s is not mappable", column, entity))
+		}
+	}
+	for column, values := range valueMapping {
+		if _, ok := values.(map[string]interface{}); !ok {
+			return errors.BadInput.New(fmt.Sprintf("the value mapping of %s should be an object", column))
+		}
+		if _, ok := fieldMapping[column]; !ok {
+			return errors.BadInput.New(fmt.Sprintf("column %s of the value mapping is not in the field mapping", column))
+		}
+	}
+	return nil
+}
+
+// MapRecord fills the entity with the values the field mapping picks out of the record,
+// the values missing from the record leave their fields untouched
+func MapRecord(record gjson.Result, fieldMapping map[string]interface{}, valueMapping map[string]interface{}, entity interface{}) errors.Error {
+	value := reflect.ValueOf(entity).Elem()
+	for column, path := range fieldMapping {
+		i, ok := columnIndex(value.Type(), column)
+		if !ok {
+			return errors.BadInput.New(fmt.Sprintf("unknown column %s", column))
+		}
+		result := record.Get(path.(string))
+		if values, ok := valueMapping[column].(map[string]interface{}); ok {
+			if mapped, ok := values[result.String()]; ok {
+				result = gjson.Parse(toJson(mapped))
+			}
+		}
+		err := setField(value.Field(i), result)
+		if err != nil {
+			return errors.BadInput.Wrap(err, fmt.Sprintf("failed to map %s", column))
+		}
+	}
+	return nil
+}
+
+func toJson(v interface{}) string {
+	if s, ok := v.(string); ok {
+		// keep the strings from being parsed as numbers
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func setField(field reflect.Value, result gjson.Result) errors.Error {
+	if !result.Exists() || result.Type == gjson.Null {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		err := setField(elem.Elem(), result)
+		if err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if field.Type() == timeType {
+		t, err := toTime(result)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(result.String())
+	case reflect.Bool:
+		field.SetBool(result.Bool())
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(result.Float())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(result.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(result.Uint())
+	default:
+		return errors.Default.New(fmt.Sprintf("unsupported type %s", field.Type()))
+	}
+	return nil
+}
+
+// toTime accepts the formats of api.ConvertStringToTime and epoch seconds or milliseconds
+func toTime(result gjson.Result) (time.Time, errors.Error) {
+	if result.Type == gjson.Number {
+		epoch := result.Int()
+		// the milliseconds are beyond the year 5000 in seconds
+		if epoch > 100000000000 {
+			return time.UnixMilli(epoch).UTC(), nil
+		}
+		return time.Unix(epoch, 0).UTC(), nil
+	}
+	t, err := api.ConvertStringToTime(result.String())
+	if err != nil {
+		return time.Time{}, errors.BadInput.Wrap(err, fmt.Sprintf("unrecognized time %s", result.String()))
+	}
+	return t, nil
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMapRecord(t *testing.T) {
+	record := gjson.Parse(`{
+		"job": {"name": "unit tests", "queued": 1688378400, "started": "2023-07-03T10:01:00Z", "finished": 1688378700000},
+		"outcome": "passed",
+		"runner": null
+	}`)
+	task := &devops.CICDTask{}
+	err := MapRecord(record, map[string]interface{}{
+		"name":          "job.name",
+		"result":        "outcome",
+		"runner":        "runner",
+		"queued_date":   "job.queued",
+		"started_date":  "job.started",
+		"finished_date": "job.finished",
+		"failed_step":   "missing",
+	}, map[string]interface{}{
+		"result": map[string]interface{}{"passed": devops.SUCCESS},
+	}, task)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "unit tests", task.Name)
+	assert.Equal(t, devops.SUCCESS, task.Result)
+	assert.Equal(t, "", task.Runner)
+	assert.Equal(t, "", task.FailedStep)
+	assert.Equal(t, time.Date(2023, 7, 3, 10, 0, 0, 0, time.UTC), *task.QueuedDate)
+	assert.Equal(t, time.Date(2023, 7, 3, 10, 1, 0, 0, time.UTC), task.StartedDate.UTC())
+	assert.Equal(t, time.Date(2023, 7, 3, 10, 5, 0, 0, time.UTC), *task.FinishedDate)
+}
+
+func TestValidateFieldMapping(t *testing.T) {
+	assert.Nil(t, ValidateFieldMapping(models.ENTITY_ISSUES, map[string]interface{}{"icon_url": "icon", "story_point": "points"}, nil))
+	// unknown entities, columns or paths
+	assert.NotNil(t, ValidateFieldMapping("deployments", nil, nil))
+	assert.NotNil(t, ValidateFieldMapping(models.ENTITY_ISSUES, map[string]interface{}{"summary": "title"}, nil))
+	assert.NotNil(t, ValidateFieldMapping(models.ENTITY_ISSUES, map[string]interface{}{"title": 1}, nil))
+	// the ids are generated from the idPath only
+	assert.NotNil(t, ValidateFieldMapping(models.ENTITY_ISSUES, map[string]interface{}{"id": "id"}, nil))
+	// the values can only be translated for the mapped columns
+	assert.NotNil(t, ValidateFieldMapping(models.ENTITY_CICD_PIPELINES, map[string]interface{}{"name": "name"}, map[string]interface{}{
+		"result": map[string]interface{}{"passed": devops.SUCCESS},
+	}))
+}
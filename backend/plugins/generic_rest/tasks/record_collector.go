@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+)
+
+const RAW_RECORD_TABLE = "generic_rest_api_records"
+
+var _ plugin.SubTaskEntryPoint = CollectRecords
+
+var CollectRecordsMeta = plugin.SubTaskMeta{
+	Name:             "collectRecords",
+	EntryPoint:       CollectRecords,
+	EnabledByDefault: true,
+	Description:      "Collect the records of the endpoint declared by the transformation rule",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_RECORD_TABLE)
+	rule := data.Options.GenericRestTransformationRule
+	args := api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		UrlTemplate:        rule.Path,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			body, err := readBody(res)
+			if err != nil {
+				return nil, err
+			}
+			return GetRecords(body, rule.DataPath)
+		},
+	}
+	switch rule.Pagination {
+	case models.PAGINATION_PAGE, models.PAGINATION_OFFSET:
+		args.PageSize = rule.PageSize
+		// the pages are requested one by one, the internal apis are not expected to bear the parallel paging
+		args.Concurrency = 1
+		args.Query = func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			if rule.Pagination == models.PAGINATION_PAGE {
+				query.Set(rule.PageParam, strconv.Itoa(reqData.Pager.Page))
+			} else {
+				query.Set(rule.PageParam, strconv.Itoa(reqData.Pager.Skip))
+			}
+			query.Set(rule.PageSizeParam, strconv.Itoa(reqData.Pager.Size))
+			return query, nil
+		}
+	case models.PAGINATION_CURSOR:
+		args.PageSize = rule.PageSize
+		args.Query = func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set(rule.PageSizeParam, strconv.Itoa(reqData.Pager.Size))
+			if cursor, ok := reqData.CustomData.(string); ok && cursor != "" {
+				query.Set(rule.CursorParam, cursor)
+			}
+			return query, nil
+		}
+		args.GetNextPageCustomData = func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+			body, err := readBody(prevPageResponse)
+			if err != nil {
+				return nil, err
+			}
+			cursor := body.Get(rule.NextCursorPath).String()
+			if cursor == "" {
+				return nil, api.ErrFinishCollect
+			}
+			return cursor, nil
+		}
+	}
+	collector, err := api.NewApiCollector(args)
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+	"github.com/tidwall/gjson"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractRecords
+
+var ExtractRecordsMeta = plugin.SubTaskMeta{
+	Name:             "extractRecords",
+	EntryPoint:       ExtractRecords,
+	EnabledByDefault: true,
+	Description:      "Map the raw records into the domain table declared by the transformation rule",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CICD},
+}
+
+// ExtractRecords maps the records straight into the domain layer, there is no tool layer table
+// since the shape of the records is only known by the field mapping
+func ExtractRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_RECORD_TABLE)
+	op := data.Options
+	rule := op.GenericRestTransformationRule
+	idGen := didgen.NewDomainIdGenerator(&models.GenericRestScope{})
+	scopeId := idGen.Generate(op.ConnectionId, op.ScopeKey)
+	pipelineScopeKey := rule.PipelineScopeKey
+	if pipelineScopeKey == "" {
+		pipelineScopeKey = op.ScopeKey
+	}
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			record := gjson.ParseBytes(row.Data)
+			recordId := record.Get(rule.IdPath).String()
+			if recordId == "" {
+				return nil, errors.BadInput.New(fmt.Sprintf("no id at `%s` of the record %s", rule.IdPath, string(row.Data)))
+			}
+			domainEntity := domainlayer.DomainEntity{
+				Id: idGen.Generate(op.ConnectionId, op.ScopeKey, recordId),
+			}
+			switch rule.Entity {
+			case models.ENTITY_ISSUES:
+				issue := &ticket.Issue{DomainEntity: domainEntity}
+				err := MapRecord(record, rule.FieldMapping, rule.ValueMapping, issue)
+				if err != nil {
+					return nil, err
+				}
+				if issue.IssueKey == "" {
+					issue.IssueKey = recordId
+				}
+				return []interface{}{
+					issue,
+					&ticket.BoardIssue{
+						BoardId: scopeId,
+						IssueId: issue.Id,
+					},
+				}, nil
+			case models.ENTITY_CICD_PIPELINES:
+				pipeline := &devops.CICDPipeline{DomainEntity: domainEntity}
+				err := MapRecord(record, rule.FieldMapping, rule.ValueMapping, pipeline)
+				if err != nil {
+					return nil, err
+				}
+				pipeline.CicdScopeId = scopeId
+				if pipeline.DurationSec == 0 && pipeline.FinishedDate != nil && !pipeline.CreatedDate.IsZero() {
+					pipeline.DurationSec = uint64(pipeline.FinishedDate.Sub(pipeline.CreatedDate).Seconds())
+				}
+				return []interface{}{pipeline}, nil
+			case models.ENTITY_CICD_TASKS:
+				task := &devops.CICDTask{DomainEntity: domainEntity}
+				err := MapRecord(record, rule.FieldMapping, rule.ValueMapping, task)
+				if err != nil {
+					return nil, err
+				}
+				// the pipeline id is picked out of the record as is, it is turned into the id of the domain pipeline
+				if task.PipelineId != "" {
+					task.PipelineId = idGen.Generate(op.ConnectionId, pipelineScopeKey, task.PipelineId)
+				}
+				task.CicdScopeId = scopeId
+				if task.DurationSec == 0 && task.FinishedDate != nil && !task.StartedDate.IsZero() {
+					task.DurationSec = uint64(task.FinishedDate.Sub(task.StartedDate).Seconds())
+				}
+				return []interface{}{task}, nil
+			}
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown entity %s", rule.Entity))
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertScope
+
+var ConvertScopeMeta = plugin.SubTaskMeta{
+	Name:             "convertScope",
+	EntryPoint:       ConvertScope,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table generic_rest_scopes into domain layer table boards or cicd_scopes by the entity of the records",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertScope(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_RECORD_TABLE)
+	db := taskCtx.GetDal()
+	entity := data.Options.GenericRestTransformationRule.Entity
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GenericRestScope{}),
+		dal.Where("connection_id = ? AND scope_key = ?", data.Options.ConnectionId, data.Options.ScopeKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	scopeIdGen := didgen.NewDomainIdGenerator(&models.GenericRestScope{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GenericRestScope{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			scope := inputRow.(*models.GenericRestScope)
+			domainEntity := domainlayer.DomainEntity{Id: scopeIdGen.Generate(scope.ConnectionId, scope.ScopeKey)}
+			if entity == models.ENTITY_ISSUES {
+				return []interface{}{
+					&ticket.Board{
+						DomainEntity: domainEntity,
+						Name:         scope.Name,
+						Url:          scope.Url,
+					},
+				}, nil
+			}
+			return []interface{}{
+				&devops.CicdScope{
+					DomainEntity: domainEntity,
+					Name:         scope.Name,
+					Url:          scope.Url,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/tidwall/gjson"
+)
+
+type GenericRestApiParams struct {
+	ConnectionId uint64
+	ScopeKey     string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *GenericRestTaskData) {
+	data := taskCtx.GetData().(*GenericRestTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: GenericRestApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ScopeKey:     data.Options.ScopeKey,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// readBody parses the body of the response, the body is restored so that it can be read again
+func readBody(res *http.Response) (gjson.Result, errors.Error) {
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return gjson.Result{}, errors.Default.Wrap(err, fmt.Sprintf("error reading response from %s", res.Request.URL.String()))
+	}
+	res.Body = io.NopCloser(bytes.NewBuffer(body))
+	if !gjson.ValidBytes(body) {
+		return gjson.Result{}, errors.Default.New(fmt.Sprintf("error decoding response from %s: raw response: %s", res.Request.URL.String(), string(body)))
+	}
+	return gjson.ParseBytes(body), nil
+}
+
+// GetRecords picks the records out of a response by the data path
+func GetRecords(body gjson.Result, dataPath string) ([]json.RawMessage, errors.Error) {
+	if dataPath != "" {
+		body = body.Get(dataPath)
+	}
+	if !body.Exists() || body.Type == gjson.Null {
+		return nil, nil
+	}
+	if !body.IsArray() {
+		return nil, errors.Default.New(fmt.Sprintf("the records at `%s` are not a list", dataPath))
+	}
+	var records []json.RawMessage
+	for _, record := range body.Array() {
+		records = append(records, json.RawMessage(record.Raw))
+	}
+	return records, nil
+}
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/generic_rest/models"
+)
+
+type GenericRestOptions struct {
+	ConnectionId                          uint64   `json:"connectionId" mapstructure:"connectionId,omitempty"`
+	Tasks                                 []string `json:"tasks,omitempty" mapstructure:",omitempty"`
+	ScopeKey                              string   `json:"scopeKey" mapstructure:"scopeKey"`
+	TransformationRuleId                  uint64   `json:"transformationRuleId" mapstructure:"transformationRuleId,omitempty"`
+	*models.GenericRestTransformationRule `mapstructure:"transformationRules,omitempty" json:"transformationRules"`
+}
+
+type GenericRestTaskData struct {
+	Options   *GenericRestOptions
+	ApiClient *api.ApiAsyncClient
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*GenericRestOptions, errors.Error) {
+	op, err := DecodeTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateTaskOptions(op)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func DecodeTaskOptions(options map[string]interface{}) (*GenericRestOptions, errors.Error) {
+	var op GenericRestOptions
+	err := api.Decode(options, &op, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+func EncodeTaskOptions(op *GenericRestOptions) (map[string]interface{}, errors.Error) {
+	var result map[string]interface{}
+	err := api.Decode(op, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func ValidateTaskOptions(op *GenericRestOptions) errors.Error {
+	if op.ScopeKey == "" {
+		return errors.BadInput.New("scopeKey is required for GenericRest execution")
+	}
+	if op.ConnectionId == 0 {
+		return errors.BadInput.New("connectionId is invalid")
+	}
+	return nil
+}
+
+// ValidateTransformationRule makes sure the endpoint can be collected and its records can be mapped
+// before the pipeline starts, the defaults of the pagination are filled in as well
+func ValidateTransformationRule(rule *models.GenericRestTransformationRule) errors.Error {
+	if rule == nil {
+		return errors.BadInput.New("a transformation rule declaring the endpoint is required")
+	}
+	if rule.Path == "" {
+		return errors.BadInput.New("path of the endpoint is required")
+	}
+	if rule.IdPath == "" {
+		return errors.BadInput.New("idPath is required to identify the records")
+	}
+	switch rule.Pagination {
+	case "", models.PAGINATION_NONE:
+		rule.Pagination = models.PAGINATION_NONE
+	case models.PAGINATION_PAGE, models.PAGINATION_OFFSET:
+		if rule.PageParam == "" {
+			rule.PageParam = rule.Pagination
+		}
+	case models.PAGINATION_CURSOR:
+		if rule.NextCursorPath == "" {
+			return errors.BadInput.New("nextCursorPath is required by the cursor pagination")
+		}
+		if rule.CursorParam == "" {
+			rule.CursorParam = "cursor"
+		}
+	default:
+		return errors.BadInput.New(fmt.Sprintf("unknown pagination %s, it should be one of none/page/offset/cursor", rule.Pagination))
+	}
+	if rule.Pagination != models.PAGINATION_NONE {
+		if rule.PageSizeParam == "" {
+			rule.PageSizeParam = "limit"
+		}
+		if rule.PageSize <= 0 {
+			rule.PageSize = 100
+		}
+	}
+	return ValidateFieldMapping(rule.Entity, rule.FieldMapping, rule.ValueMapping)
+}
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+	"github.com/apache/incubator-devlake/plugins/gerrit/tasks"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the projects of Gerrit are not grouped
+// @Tags plugins/gerrit
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gerrit/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.GerritConnection) ([]models.GroupResponse, errors.Error) {
+			return nil, nil
+		},
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.GerritConnection) ([]models.GerritApiProject, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &connection)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+			}
+			return listProjects(apiClient, &connection, url.Values{}, queryData)
+		},
+	)
+}
+
+// SearchRemoteScopes use the Search API and only return project
+// @Summary use the Search API and only return project
+// @Description use the Search API and only return project
+// @Tags plugins/gerrit
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gerrit/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.GerritConnection) ([]models.GerritApiProject, errors.Error) {
+			apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &connection)
+			if err != nil {
+				return nil, err
+			}
+			// `m` matches the substring of project names case-insensitively
+			query := url.Values{}
+			query.Set("m", queryData.Search[0])
+			return listProjects(apiClient, &connection, query, queryData)
+		},
+	)
+}
+
+// listProjects returns a page of the projects visible to the account of the connection,
+// the api lists them as a map keyed by their names which is sorted back into a list
+func listProjects(apiClient *api.ApiClient, connection *models.GerritConnection, query url.Values, queryData *api.RemoteQueryData) ([]models.GerritApiProject, errors.Error) {
+	query.Set("d", "")
+	query.Set("n", fmt.Sprintf("%v", queryData.PerPage))
+	query.Set("S", fmt.Sprintf("%v", (queryData.Page-1)*queryData.PerPage))
+	res, err := apiClient.Get("a/projects/", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	resBody := map[string]models.GerritApiProject{}
+	err = tasks.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]models.GerritApiProject, 0, len(resBody))
+	for name, project := range resBody {
+		project.Name = name
+		project.Url = tasks.ProjectUrl(connection.Endpoint, name)
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+	return projects, nil
+}
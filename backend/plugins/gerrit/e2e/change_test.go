@@ -0,0 +1,258 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gerrit/impl"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+	"github.com/apache/incubator-devlake/plugins/gerrit/tasks"
+)
+
+func TestGerritChangeDataFlow(t *testing.T) {
+
+	var gerrit impl.Gerrit
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gerrit", gerrit)
+
+	taskData := &tasks.GerritTaskData{
+		Options: &tasks.GerritOptions{
+			ConnectionId: 1,
+			ProjectName:  "platform/build",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gerrit_api_changes.csv", "_raw_gerrit_api_changes")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GerritChange{})
+	dataflowTester.FlushTabler(&models.GerritPatchSet{})
+	dataflowTester.FlushTabler(&models.GerritChangeMessage{})
+	dataflowTester.FlushTabler(&models.GerritReview{})
+	dataflowTester.FlushTabler(&models.GerritAccount{})
+	dataflowTester.Subtask(tasks.ExtractApiChangesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GerritChange{},
+		"./snapshot_tables/_tool_gerrit_changes.csv",
+		[]string{
+			"connection_id",
+			"change_number",
+			"gerrit_id",
+			"project_name",
+			"branch",
+			"change_id",
+			"subject",
+			"status",
+			"owner_id",
+			"owner_name",
+			"current_revision",
+			"current_patch_set",
+			"insertions",
+			"deletions",
+			"created_date",
+			"updated_date",
+			"submitted_date",
+			"submitter_id",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+	dataflowTester.VerifyTable(
+		models.GerritPatchSet{},
+		"./snapshot_tables/_tool_gerrit_patch_sets.csv",
+		[]string{
+			"connection_id",
+			"change_number",
+			"patch_set_number",
+			"commit_sha",
+			"ref",
+			"kind",
+			"uploader_id",
+			"message",
+			"author_name",
+			"author_email",
+			"authored_date",
+			"created_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+	dataflowTester.VerifyTable(
+		models.GerritChangeMessage{},
+		"./snapshot_tables/_tool_gerrit_change_messages.csv",
+		[]string{
+			"connection_id",
+			"change_number",
+			"gerrit_id",
+			"author_id",
+			"patch_set_number",
+			"tag",
+			"message",
+			"date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+	dataflowTester.VerifyTable(
+		models.GerritReview{},
+		"./snapshot_tables/_tool_gerrit_reviews.csv",
+		[]string{
+			"connection_id",
+			"change_number",
+			"account_id",
+			"label",
+			"value",
+			"date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+	dataflowTester.VerifyTable(
+		models.GerritAccount{},
+		"./snapshot_tables/_tool_gerrit_accounts.csv",
+		[]string{
+			"connection_id",
+			"account_id",
+			"name",
+			"email",
+			"username",
+			"avatar_url",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_gerrit_projects.csv", &models.GerritProject{})
+	dataflowTester.FlushTabler(&code.Repo{})
+	dataflowTester.Subtask(tasks.ConvertProjectMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.Repo{},
+		"./snapshot_tables/repos.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertChangesMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequest{},
+		"./snapshot_tables/pull_requests.csv",
+		[]string{
+			"id",
+			"base_repo_id",
+			"head_repo_id",
+			"status",
+			"original_status",
+			"title",
+			"description",
+			"url",
+			"author_name",
+			"author_id",
+			"pull_request_key",
+			"created_date",
+			"merged_date",
+			"closed_date",
+			"merge_commit_sha",
+			"base_ref",
+			"base_commit_sha",
+			"head_ref",
+			"head_commit_sha",
+			"additions",
+			"deletions",
+		},
+	)
+
+	dataflowTester.FlushTabler(&code.PullRequestCommit{})
+	dataflowTester.Subtask(tasks.ConvertPatchSetsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestCommit{},
+		"./snapshot_tables/pull_request_commits.csv",
+		[]string{
+			"commit_sha",
+			"pull_request_id",
+			"commit_author_name",
+			"commit_author_email",
+			"commit_authored_date",
+		},
+	)
+
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertChangeMessagesMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestComment{},
+		"./snapshot_tables/pull_request_comments.csv",
+		[]string{
+			"id",
+			"pull_request_id",
+			"body",
+			"account_id",
+			"created_date",
+			"type",
+		},
+	)
+
+	dataflowTester.FlushTabler(&code.PullRequestReviewEvent{})
+	dataflowTester.Subtask(tasks.ConvertReviewsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestReviewEvent{},
+		"./snapshot_tables/pull_request_review_events.csv",
+		[]string{
+			"id",
+			"pull_request_id",
+			"type",
+			"original_type",
+			"reviewer_id",
+			"actor_id",
+			"review_id",
+			"created_date",
+		},
+	)
+
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.Subtask(tasks.ConvertAccountsMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.Account{},
+		"./snapshot_tables/accounts.csv",
+		[]string{
+			"id",
+			"email",
+			"full_name",
+			"user_name",
+			"avatar_url",
+		},
+	)
+}
@@ -0,0 +1,248 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/gerrit/tasks"
+)
+
+var _ plugin.PluginMeta = (*Gerrit)(nil)
+var _ plugin.PluginInit = (*Gerrit)(nil)
+var _ plugin.PluginTask = (*Gerrit)(nil)
+var _ plugin.PluginApi = (*Gerrit)(nil)
+var _ plugin.PluginModel = (*Gerrit)(nil)
+var _ plugin.PluginMigration = (*Gerrit)(nil)
+var _ plugin.CloseablePluginTask = (*Gerrit)(nil)
+var _ plugin.PluginSource = (*Gerrit)(nil)
+
+type Gerrit string
+
+func (p Gerrit) Connection() interface{} {
+	return &models.GerritConnection{}
+}
+
+func (p Gerrit) Scope() interface{} {
+	return &models.GerritProject{}
+}
+
+func (p Gerrit) TransformationRule() interface{} {
+	return &models.GerritTransformationRule{}
+}
+
+func (p Gerrit) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Gerrit) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.GerritConnection{},
+		&models.GerritAccount{},
+		&models.GerritProject{},
+		&models.GerritChange{},
+		&models.GerritPatchSet{},
+		&models.GerritChangeMessage{},
+		&models.GerritReview{},
+		&models.GerritTransformationRule{},
+	}
+}
+
+func (p Gerrit) Description() string {
+	return "To collect and enrich data from Gerrit"
+}
+
+func (p Gerrit) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiChangesMeta,
+		tasks.ExtractApiChangesMeta,
+
+		tasks.ConvertProjectMeta,
+		tasks.ConvertAccountsMeta,
+		tasks.ConvertChangesMeta,
+		tasks.ConvertPatchSetsMeta,
+		tasks.ConvertChangeMessagesMeta,
+		tasks.ConvertReviewsMeta,
+		tasks.EnrichPullRequestStatsMeta,
+	}
+}
+
+func (p Gerrit) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.GerritConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get gerrit connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get gerrit API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, connection, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeAfter time.Time
+	if op.TimeAfter != "" {
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+	}
+	taskData := &tasks.GerritTaskData{
+		Options:   op,
+		ApiClient: apiClient,
+	}
+	if !timeAfter.IsZero() {
+		taskData.TimeAfter = &timeAfter
+		logger.Debug("collect data updated timeAfter %s", timeAfter)
+	}
+
+	return taskData, nil
+}
+
+func (p Gerrit) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/gerrit"
+}
+
+func (p Gerrit) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Gerrit) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Gerrit) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/*scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
+	}
+}
+
+func (p Gerrit) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.GerritTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.GerritOptions,
+	connection *models.GerritConnection,
+	apiClient *helper.ApiClient) errors.Error {
+	var project models.GerritProject
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&project, dal.Where(
+		"connection_id = ? AND gerrit_id = ?",
+		op.ConnectionId, op.ProjectName))
+	if err == nil {
+		if op.TransformationRuleId == 0 {
+			op.TransformationRuleId = project.TransformationRuleId
+		}
+	} else {
+		if db.IsErrorNotFound(err) {
+			var apiProject *models.GerritApiProject
+			apiProject, err = tasks.GetApiProject(op.ProjectName, apiClient)
+			if err != nil {
+				return err
+			}
+			logger.Debug(fmt.Sprintf("Current project: %s", apiProject.Name))
+			apiProject.Url = tasks.ProjectUrl(connection.Endpoint, apiProject.Name)
+			scope := apiProject.ConvertApiScope().(*models.GerritProject)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find project %s", op.ProjectName))
+		}
+	}
+	if op.GerritTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.GerritTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.GerritTransformationRule = &transformationRule
+	}
+	if op.GerritTransformationRule == nil {
+		op.GerritTransformationRule = new(models.GerritTransformationRule)
+	}
+	return nil
+}
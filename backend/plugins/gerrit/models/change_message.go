@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GerritChangeMessage is a message posted on a change, either by a reviewer or by Gerrit itself
+// when the change is uploaded, submitted, abandoned and so on
+type GerritChangeMessage struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ChangeNumber   int    `gorm:"primaryKey;autoIncrement:false"`
+	GerritId       string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorId       int
+	PatchSetNumber int
+	Tag            string `gorm:"type:varchar(255)"`
+	Message        string
+	Date           time.Time
+	common.NoPKModel
+}
+
+func (GerritChangeMessage) TableName() string {
+	return "_tool_gerrit_change_messages"
+}
+
+// the tags of messages generated by Gerrit start with `autogenerated:gerrit:`
+const (
+	MESSAGE_TAG_AUTOGENERATED = "autogenerated:"
+	MESSAGE_TAG_MERGED        = "autogenerated:gerrit:merged"
+	MESSAGE_TAG_ABANDON       = "autogenerated:gerrit:abandon"
+)
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*GerritProject)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*GerritApiProject)(nil)
+
+// GerritProject is a repository hosted by Gerrit, it is identified by its name which may contain `/`
+type GerritProject struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	GerritId             string `json:"gerritId" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"gerritId"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Description          string `json:"description" mapstructure:"description,omitempty"`
+	State                string `json:"state" gorm:"type:varchar(100)" mapstructure:"state,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (GerritProject) TableName() string {
+	return "_tool_gerrit_projects"
+}
+
+func (p GerritProject) ScopeId() string {
+	return p.GerritId
+}
+
+func (p GerritProject) ScopeName() string {
+	return p.Name
+}
+
+// GerritApiProject is the ProjectInfo entity of the Gerrit api
+type GerritApiProject struct {
+	// Name is the key of the map listing the projects, the entity only carries it when fetched alone
+	Name        string `json:"name"`
+	Id          string `json:"id"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	// Url is not a part of the response, it is filled by the caller who knows the endpoint
+	Url string `json:"-"`
+}
+
+func (p GerritApiProject) ConvertApiScope() plugin.ToolLayerScope {
+	return &GerritProject{
+		GerritId:    p.Name,
+		Name:        p.Name,
+		Description: p.Description,
+		State:       p.State,
+		Url:         p.Url,
+	}
+}
+
+// GroupResponse is required by the remote api helper, projects of Gerrit are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+// XSSI_PREFIX is prepended to every json response of Gerrit to prevent them from being executed as scripts
+const XSSI_PREFIX = ")]}'"
+
+type GerritApiParams struct {
+	ConnectionId uint64
+	ProjectName  string
+}
+
+// GerritAccountInfo is the AccountInfo entity of the api, it is detailed when `DETAILED_ACCOUNTS` is requested
+type GerritAccountInfo struct {
+	AccountId int    `json:"_account_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+	Avatars   []struct {
+		Url string `json:"url"`
+	} `json:"avatars"`
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *GerritTaskData) {
+	data := taskCtx.GetData().(*GerritTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: GerritApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ProjectName:  data.Options.ProjectName,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GetQuery pages with `n` and `S`, the number of results to return and to skip
+func GetQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	if reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set("n", fmt.Sprintf("%v", reqData.Pager.Size))
+		query.Set("S", fmt.Sprintf("%v", reqData.Pager.Skip))
+	}
+	return query, nil
+}
+
+// UnmarshalResponse works like api.UnmarshalResponse but strips the XSSI prefix off the body first
+func UnmarshalResponse(res *http.Response, v interface{}) errors.Error {
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error reading response from %s", res.Request.URL.String()))
+	}
+	resBody = bytes.TrimPrefix(bytes.TrimSpace(resBody), []byte(XSSI_PREFIX))
+	err = json.Unmarshal(resBody, v)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error decoding response from %s: raw response: %s", res.Request.URL.String(), string(resBody)))
+	}
+	return nil
+}
+
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var rawMessages []json.RawMessage
+	err := UnmarshalResponse(res, &rawMessages)
+	if err != nil {
+		return nil, err
+	}
+	return rawMessages, nil
+}
+
+// convertAccount turns an account into the tool layer, it returns nil for an absent account
+func convertAccount(account *GerritAccountInfo, connectionId uint64) *models.GerritAccount {
+	if account == nil || account.AccountId == 0 {
+		return nil
+	}
+	result := &models.GerritAccount{
+		ConnectionId: connectionId,
+		AccountId:    account.AccountId,
+		Name:         account.Name,
+		Email:        account.Email,
+		Username:     account.Username,
+	}
+	if len(account.Avatars) > 0 {
+		result.AvatarUrl = account.Avatars[len(account.Avatars)-1].Url
+	}
+	return result
+}
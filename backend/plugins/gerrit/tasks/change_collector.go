@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_CHANGE_TABLE = "gerrit_api_changes"
+
+// QUERY_TIME_FORMAT is the format of timestamps in search operators, they are interpreted as UTC
+const QUERY_TIME_FORMAT = "2006-01-02 15:04:05"
+
+// changeOptions asks for everything extractChanges needs so that one request per page is enough
+var changeOptions = []string{"ALL_REVISIONS", "ALL_COMMITS", "DETAILED_ACCOUNTS", "DETAILED_LABELS", "MESSAGES"}
+
+var CollectApiChangesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiChanges",
+	EntryPoint:       CollectApiChanges,
+	EnabledByDefault: true,
+	Description:      "Collect changes data along with their patch sets, messages and votes from Gerrit api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectApiChanges(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "a/changes/",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			// `after` is compared with the update time of changes, which are listed from the latest updated
+			q := fmt.Sprintf("project:%s", data.Options.ProjectName)
+			if collectorWithState.IsIncremental() {
+				q += fmt.Sprintf(` after:"%s"`, collectorWithState.LatestState.LatestSuccessStart.UTC().Format(QUERY_TIME_FORMAT))
+			} else if collectorWithState.TimeAfter != nil {
+				q += fmt.Sprintf(` after:"%s"`, collectorWithState.TimeAfter.UTC().Format(QUERY_TIME_FORMAT))
+			}
+			query.Set("q", q)
+			for _, o := range changeOptions {
+				query.Add("o", o)
+			}
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
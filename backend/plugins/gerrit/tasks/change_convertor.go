@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertChangesMeta = plugin.SubTaskMeta{
+	Name:             "convertChanges",
+	EntryPoint:       ConvertChanges,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_changes into domain layer table pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertChanges(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+
+	project := &models.GerritProject{}
+	err := db.First(project, dal.Where("connection_id = ? AND gerrit_id = ?", data.Options.ConnectionId, data.Options.ProjectName))
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GerritChange{}),
+		dal.Where("project_name = ? AND connection_id = ?", data.Options.ProjectName, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GerritProject{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GerritAccount{})
+	projectId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritChange{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			change := inputRow.(*models.GerritChange)
+			domainPr := &code.PullRequest{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: changeIdGen.Generate(change.ConnectionId, change.ChangeNumber),
+				},
+				BaseRepoId:     projectId,
+				HeadRepoId:     projectId,
+				OriginalStatus: change.Status,
+				Title:          change.Subject,
+				Url:            changeUrl(project, change.ChangeNumber),
+				AuthorName:     change.OwnerName,
+				PullRequestKey: change.ChangeNumber,
+				CreatedDate:    change.CreatedDate,
+				BaseRef:        change.Branch,
+				HeadCommitSha:  change.CurrentRevision,
+				Additions:      change.Insertions,
+				Deletions:      change.Deletions,
+			}
+			if change.OwnerId != 0 {
+				domainPr.AuthorId = accountIdGen.Generate(change.ConnectionId, change.OwnerId)
+			}
+			// patch sets are pushed to refs/changes/{last two digits of the change}/{change}/{patch set}
+			if change.CurrentPatchSet != 0 {
+				domainPr.HeadRef = fmt.Sprintf("refs/changes/%02d/%d/%d", change.ChangeNumber%100, change.ChangeNumber, change.CurrentPatchSet)
+			}
+			switch change.Status {
+			case models.CHANGE_STATUS_NEW:
+				domainPr.Status = code.OPEN
+			case models.CHANGE_STATUS_MERGED:
+				domainPr.Status = code.MERGED
+				domainPr.MergedDate = change.SubmittedDate
+				domainPr.ClosedDate = change.SubmittedDate
+				domainPr.MergeCommitSha = change.CurrentRevision
+			case models.CHANGE_STATUS_ABANDONED:
+				domainPr.Status = code.CLOSED
+				domainPr.ClosedDate = &change.UpdatedDate
+			default:
+				domainPr.Status = change.Status
+			}
+			return []interface{}{
+				domainPr,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ExtractApiChangesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiChanges",
+	EntryPoint:       ExtractApiChanges,
+	EnabledByDefault: true,
+	Description:      "Extract raw changes data into tool layer tables of changes, patch sets, messages, reviews and accounts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW, plugin.DOMAIN_TYPE_CROSS},
+}
+
+type GerritApiRevision struct {
+	Number   int                `json:"_number"`
+	Created  api.Iso8601Time    `json:"created"`
+	Uploader *GerritAccountInfo `json:"uploader"`
+	Ref      string             `json:"ref"`
+	Kind     string             `json:"kind"`
+	Commit   *struct {
+		Author struct {
+			Name  string           `json:"name"`
+			Email string           `json:"email"`
+			Date  *api.Iso8601Time `json:"date"`
+		} `json:"author"`
+		Message string `json:"message"`
+	} `json:"commit"`
+}
+
+type GerritApiChangeMessage struct {
+	Id             string             `json:"id"`
+	Author         *GerritAccountInfo `json:"author"`
+	Date           api.Iso8601Time    `json:"date"`
+	Message        string             `json:"message"`
+	RevisionNumber int                `json:"_revision_number"`
+	Tag            string             `json:"tag"`
+}
+
+type GerritApiApproval struct {
+	GerritAccountInfo
+	Value *int             `json:"value"`
+	Date  *api.Iso8601Time `json:"date"`
+}
+
+type GerritApiChange struct {
+	Id              string                       `json:"id"`
+	Project         string                       `json:"project"`
+	Branch          string                       `json:"branch"`
+	ChangeId        string                       `json:"change_id"`
+	Subject         string                       `json:"subject"`
+	Status          string                       `json:"status"`
+	Created         api.Iso8601Time              `json:"created"`
+	Updated         api.Iso8601Time              `json:"updated"`
+	Submitted       *api.Iso8601Time             `json:"submitted"`
+	Submitter       *GerritAccountInfo           `json:"submitter"`
+	Insertions      int                          `json:"insertions"`
+	Deletions       int                          `json:"deletions"`
+	Number          int                          `json:"_number"`
+	Owner           *GerritAccountInfo           `json:"owner"`
+	CurrentRevision string                       `json:"current_revision"`
+	Revisions       map[string]GerritApiRevision `json:"revisions"`
+	Messages        []GerritApiChangeMessage     `json:"messages"`
+	Labels          map[string]struct {
+		All []GerritApiApproval `json:"all"`
+	} `json:"labels"`
+}
+
+func ExtractApiChanges(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiChange := &GerritApiChange{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiChange))
+			if err != nil {
+				return nil, err
+			}
+			if apiChange.Number == 0 {
+				return nil, nil
+			}
+			connectionId := data.Options.ConnectionId
+			results := make([]interface{}, 0, len(apiChange.Revisions)+len(apiChange.Messages)+2)
+			// the same account shows up many times in a change, it is only emitted once
+			accounts := map[int]bool{}
+			addAccount := func(info *GerritAccountInfo) {
+				if account := convertAccount(info, connectionId); account != nil && !accounts[account.AccountId] {
+					accounts[account.AccountId] = true
+					results = append(results, account)
+				}
+			}
+
+			change := &models.GerritChange{
+				ConnectionId:    connectionId,
+				ChangeNumber:    apiChange.Number,
+				GerritId:        apiChange.Id,
+				ProjectName:     apiChange.Project,
+				Branch:          apiChange.Branch,
+				ChangeId:        apiChange.ChangeId,
+				Subject:         apiChange.Subject,
+				Status:          apiChange.Status,
+				CurrentRevision: apiChange.CurrentRevision,
+				Insertions:      apiChange.Insertions,
+				Deletions:       apiChange.Deletions,
+				CreatedDate:     apiChange.Created.ToTime(),
+				UpdatedDate:     apiChange.Updated.ToTime(),
+				SubmittedDate:   apiChange.Submitted.ToNullableTime(),
+			}
+			if apiChange.Owner != nil {
+				change.OwnerId = apiChange.Owner.AccountId
+				change.OwnerName = apiChange.Owner.Name
+				addAccount(apiChange.Owner)
+			}
+			if apiChange.Submitter != nil {
+				change.SubmitterId = apiChange.Submitter.AccountId
+				addAccount(apiChange.Submitter)
+			}
+			if current, ok := apiChange.Revisions[apiChange.CurrentRevision]; ok {
+				change.CurrentPatchSet = current.Number
+			}
+			results = append(results, change)
+
+			for sha, revision := range apiChange.Revisions {
+				patchSet := &models.GerritPatchSet{
+					ConnectionId:   connectionId,
+					ChangeNumber:   apiChange.Number,
+					PatchSetNumber: revision.Number,
+					CommitSha:      sha,
+					Ref:            revision.Ref,
+					Kind:           revision.Kind,
+					CreatedDate:    revision.Created.ToTime(),
+				}
+				if revision.Uploader != nil {
+					patchSet.UploaderId = revision.Uploader.AccountId
+					addAccount(revision.Uploader)
+				}
+				if revision.Commit != nil {
+					patchSet.Message = revision.Commit.Message
+					patchSet.AuthorName = revision.Commit.Author.Name
+					patchSet.AuthorEmail = revision.Commit.Author.Email
+					patchSet.AuthoredDate = revision.Commit.Author.Date.ToNullableTime()
+				}
+				results = append(results, patchSet)
+			}
+
+			for _, apiMessage := range apiChange.Messages {
+				message := &models.GerritChangeMessage{
+					ConnectionId:   connectionId,
+					ChangeNumber:   apiChange.Number,
+					GerritId:       apiMessage.Id,
+					PatchSetNumber: apiMessage.RevisionNumber,
+					Tag:            apiMessage.Tag,
+					Message:        apiMessage.Message,
+					Date:           apiMessage.Date.ToTime(),
+				}
+				if apiMessage.Author != nil {
+					message.AuthorId = apiMessage.Author.AccountId
+					addAccount(apiMessage.Author)
+				}
+				results = append(results, message)
+			}
+
+			for label, votes := range apiChange.Labels {
+				for i := range votes.All {
+					approval := &votes.All[i]
+					// permitted voters who have not been asked to review carry no value at all
+					if approval.Value == nil {
+						continue
+					}
+					results = append(results, &models.GerritReview{
+						ConnectionId: connectionId,
+						ChangeNumber: apiChange.Number,
+						AccountId:    approval.AccountId,
+						Label:        label,
+						Value:        *approval.Value,
+						Date:         approval.Date.ToNullableTime(),
+					})
+					addAccount(&approval.GerritAccountInfo)
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertChangeMessagesMeta = plugin.SubTaskMeta{
+	Name:             "convertChangeMessages",
+	EntryPoint:       ConvertChangeMessages,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_change_messages into domain layer table pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// ConvertChangeMessages keeps the messages written by people, the ones Gerrit generates on uploads,
+// submissions and so on are tagged and left out
+func ConvertChangeMessages(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("m.*"),
+		dal.From("_tool_gerrit_change_messages m"),
+		dal.Join("LEFT JOIN _tool_gerrit_changes c ON c.connection_id = m.connection_id AND c.change_number = m.change_number"),
+		dal.Where("c.project_name = ? AND m.connection_id = ? AND m.tag NOT LIKE ?", data.Options.ProjectName, data.Options.ConnectionId, models.MESSAGE_TAG_AUTOGENERATED+"%"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	messageIdGen := didgen.NewDomainIdGenerator(&models.GerritChangeMessage{})
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GerritAccount{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritChangeMessage{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			message := inputRow.(*models.GerritChangeMessage)
+			comment := &code.PullRequestComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: messageIdGen.Generate(message.ConnectionId, message.ChangeNumber, message.GerritId),
+				},
+				PullRequestId: changeIdGen.Generate(message.ConnectionId, message.ChangeNumber),
+				Body:          message.Message,
+				CreatedDate:   message.Date,
+				Type:          code.NORMAL_COMMENT,
+			}
+			if message.AuthorId != 0 {
+				comment.AccountId = accountIdGen.Generate(message.ConnectionId, message.AuthorId)
+			}
+			return []interface{}{
+				comment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertPatchSetsMeta = plugin.SubTaskMeta{
+	Name:             "convertPatchSets",
+	EntryPoint:       ConvertPatchSets,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_patch_sets into domain layer table pull_request_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// ConvertPatchSets turns every patch set into a commit of the pull request, each of them
+// is a revision of the same commit amended by the author
+func ConvertPatchSets(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("ps.*"),
+		dal.From("_tool_gerrit_patch_sets ps"),
+		dal.Join("LEFT JOIN _tool_gerrit_changes c ON c.connection_id = ps.connection_id AND c.change_number = ps.change_number"),
+		dal.Where("c.project_name = ? AND ps.connection_id = ?", data.Options.ProjectName, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritPatchSet{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			patchSet := inputRow.(*models.GerritPatchSet)
+			prCommit := &code.PullRequestCommit{
+				CommitSha:          patchSet.CommitSha,
+				PullRequestId:      changeIdGen.Generate(patchSet.ConnectionId, patchSet.ChangeNumber),
+				CommitAuthorName:   patchSet.AuthorName,
+				CommitAuthorEmail:  patchSet.AuthorEmail,
+				CommitAuthoredDate: patchSet.CreatedDate,
+			}
+			if patchSet.AuthoredDate != nil {
+				prCommit.CommitAuthoredDate = *patchSet.AuthoredDate
+			}
+			return []interface{}{
+				prCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
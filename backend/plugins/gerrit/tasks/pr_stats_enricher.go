@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var EnrichPullRequestStatsMeta = plugin.SubTaskMeta{
+	Name:             "enrichPullRequestStats",
+	EntryPoint:       EnrichPullRequestStats,
+	EnabledByDefault: true,
+	Description:      "Fill the review rounds of pull_requests from their commits and comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func EnrichPullRequestStats(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	enricher, err := api.NewPullRequestStatsEnricher(api.PullRequestStatsEnricherArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		RepoId:             didgen.NewDomainIdGenerator(&models.GerritProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectName),
+	})
+	if err != nil {
+		return err
+	}
+	return enricher.Execute()
+}
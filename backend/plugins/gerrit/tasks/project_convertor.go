@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+const RAW_PROJECT_TABLE = "gerrit_api_projects"
+
+var ConvertProjectMeta = plugin.SubTaskMeta{
+	Name:             "convertProject",
+	EntryPoint:       ConvertProject,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_projects into domain layer table repos",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+// GetApiProject fetches a project, its name has to be escaped as it may contain `/`
+func GetApiProject(projectName string, apiClient aha.ApiClientAbstract) (*models.GerritApiProject, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("a/projects/%s", url.PathEscape(projectName)), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting project detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	apiProject := &models.GerritApiProject{}
+	err = UnmarshalResponse(res, apiProject)
+	if err != nil {
+		return nil, err
+	}
+	return apiProject, nil
+}
+
+// ProjectUrl is the page of the project in the web ui of Gerrit
+func ProjectUrl(endpoint string, projectName string) string {
+	return fmt.Sprintf("%s/admin/repos/%s", strings.TrimSuffix(endpoint, "/"), projectName)
+}
+
+// changeUrl derives the web url of a change from the web url of its project,
+// which looks like https://gerrit.example.com/admin/repos/{project}
+func changeUrl(project *models.GerritProject, changeNumber int) string {
+	serverUrl := project.Url
+	if i := strings.LastIndex(serverUrl, "/admin/repos/"); i >= 0 {
+		serverUrl = serverUrl[:i]
+	}
+	return fmt.Sprintf("%s/c/%s/+/%d", serverUrl, project.GerritId, changeNumber)
+}
+
+func ConvertProject(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROJECT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GerritProject{}),
+		dal.Where("connection_id = ? AND gerrit_id = ?", data.Options.ConnectionId, data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GerritProject{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritProject{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			project := inputRow.(*models.GerritProject)
+			return []interface{}{
+				&code.Repo{
+					DomainEntity: domainlayer.DomainEntity{Id: projectIdGen.Generate(project.ConnectionId, project.GerritId)},
+					Name:         project.Name,
+					Description:  project.Description,
+					Url:          project.Url,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
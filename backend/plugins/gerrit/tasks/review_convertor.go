@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertReviewsMeta = plugin.SubTaskMeta{
+	Name:             "convertReviews",
+	EntryPoint:       ConvertReviews,
+	EnabledByDefault: true,
+	Description:      "Convert the Code-Review votes of tool layer table gerrit_reviews into domain layer table pull_request_review_events",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type reviewWithChange struct {
+	models.GerritReview
+	ChangeCreatedDate time.Time
+}
+
+// ConvertReviews only looks at the Code-Review label, the meaning of the other labels is up to the projects.
+// Reviewers who have not voted yet are requested reviews, they carry no date so the one of the change is used
+func ConvertReviews(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("r.*, c.created_date AS change_created_date"),
+		dal.From("_tool_gerrit_reviews r"),
+		dal.Join("LEFT JOIN _tool_gerrit_changes c ON c.connection_id = r.connection_id AND c.change_number = r.change_number"),
+		dal.Where("c.project_name = ? AND r.connection_id = ? AND r.label = ?", data.Options.ProjectName, data.Options.ConnectionId, models.LABEL_CODE_REVIEW),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	reviewIdGen := didgen.NewDomainIdGenerator(&models.GerritReview{})
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GerritAccount{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(reviewWithChange{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			review := inputRow.(*reviewWithChange)
+			reviewId := reviewIdGen.Generate(review.ConnectionId, review.ChangeNumber, review.AccountId, review.Label)
+			event := &code.PullRequestReviewEvent{
+				DomainEntity:  domainlayer.DomainEntity{Id: reviewId},
+				PullRequestId: changeIdGen.Generate(review.ConnectionId, review.ChangeNumber),
+				OriginalType:  fmt.Sprintf("%s%+d", review.Label, review.Value),
+				ReviewerId:    accountIdGen.Generate(review.ConnectionId, review.AccountId),
+				ActorId:       accountIdGen.Generate(review.ConnectionId, review.AccountId),
+				ReviewId:      reviewId,
+				CreatedDate:   review.ChangeCreatedDate,
+			}
+			if review.Date != nil {
+				event.CreatedDate = *review.Date
+			}
+			switch {
+			case review.Value > 0:
+				event.Type = code.REVIEW_APPROVED
+			case review.Value < 0:
+				event.Type = code.REVIEW_CHANGES_REQUESTED
+			default:
+				event.Type = code.REVIEW_REQUESTED
+				event.OriginalType = review.Label
+			}
+			return []interface{}{
+				event,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -51,6 +51,8 @@ func (p GitExtractor) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectGitCommitMeta,
 		tasks.CollectGitBranchMeta,
 		tasks.CollectGitTagMeta,
+		tasks.CollectGitCodeOwnersMeta,
+		tasks.CollectGitDependenciesMeta,
 		tasks.CollectGitDiffLineMeta,
 	}
 }
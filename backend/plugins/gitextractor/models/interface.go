@@ -31,5 +31,7 @@ type Store interface {
 	CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error
 	CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error
 	RepoSnapshot(snapshot *code.RepoSnapshot) errors.Error
+	CodeOwners(codeOwner *code.CodeOwner) errors.Error
+	RepoDependencies(repoDependency *code.RepoDependency) errors.Error
 	Close() errors.Error
 }
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+// CodeOwnersPaths are the locations GitHub and GitLab look up the CODEOWNERS file at, the first one found is used
+var CodeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// GitLab sections, e.g. `[Docs]`, `^[Optional][2] @default-owner`
+var codeOwnersSectionPattern = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$`)
+
+// CodeOwnerRule is a line of a CODEOWNERS file, a rule without owners leaves the matching paths unowned
+type CodeOwnerRule struct {
+	LineNo  int
+	Pattern string
+	Section string
+	Owners  []string
+}
+
+// ParseCodeOwners parses the rules of a CODEOWNERS file in their order, the rules of a GitLab section without
+// owners of their own are owned by the default owners of the section
+func ParseCodeOwners(content string) []*CodeOwnerRule {
+	var rules []*CodeOwnerRule
+	var section string
+	var sectionOwners []string
+	for i, line := range strings.Split(content, "\n") {
+		fields := codeOwnersFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if match := codeOwnersSectionPattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			section = match[1]
+			sectionOwners = codeOwnersFields(match[2])
+			continue
+		}
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+		rules = append(rules, &CodeOwnerRule{
+			LineNo:  i + 1,
+			Pattern: fields[0],
+			Section: section,
+			Owners:  owners,
+		})
+	}
+	return rules
+}
+
+// codeOwnersFields splits a line into its pattern and owners, up to the comment
+func codeOwnersFields(line string) []string {
+	var fields []string
+	for _, field := range strings.Fields(line) {
+		if strings.HasPrefix(field, "#") {
+			break
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// CodeOwnerType tells a team (a GitHub team or a GitLab group) from a user or an email
+func CodeOwnerType(owner string) string {
+	switch {
+	case strings.HasPrefix(owner, "@") && strings.Contains(owner, "/"):
+		return code.CODE_OWNER_TEAM
+	case strings.HasPrefix(owner, "@"):
+		return code.CODE_OWNER_USER
+	case strings.Contains(owner, "@"):
+		return code.CODE_OWNER_EMAIL
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	rules := ParseCodeOwners(`# global owners
+*       @apache/devlake-committers
+
+/backend/plugins/github/ @alice bob@example.com # the github plugin
+/config-ui/
+
+[Docs][2] @apache/docs
+*.md
+/docs/ @carol
+`)
+	assert.Equal(t, []*CodeOwnerRule{
+		{LineNo: 2, Pattern: "*", Owners: []string{"@apache/devlake-committers"}},
+		{LineNo: 4, Pattern: "/backend/plugins/github/", Owners: []string{"@alice", "bob@example.com"}},
+		{LineNo: 5, Pattern: "/config-ui/"},
+		{LineNo: 8, Pattern: "*.md", Section: "Docs", Owners: []string{"@apache/docs"}},
+		{LineNo: 9, Pattern: "/docs/", Section: "Docs", Owners: []string{"@carol"}},
+	}, rules)
+}
+
+func TestCodeOwnerType(t *testing.T) {
+	assert.Equal(t, code.CODE_OWNER_TEAM, CodeOwnerType("@apache/devlake-committers"))
+	assert.Equal(t, code.CODE_OWNER_USER, CodeOwnerType("@alice"))
+	assert.Equal(t, code.CODE_OWNER_EMAIL, CodeOwnerType("bob@example.com"))
+}
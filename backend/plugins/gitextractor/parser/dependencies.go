@@ -0,0 +1,258 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+// DependencyManifestDirsToSkip are the directories holding the manifests of the dependencies themselves
+var DependencyManifestDirsToSkip = []string{"node_modules", "vendor", ".git"}
+
+// Dependency is a package declared by a manifest
+type Dependency struct {
+	Ecosystem   string
+	PackageName string
+	Version     string
+	Scope       string
+}
+
+// ManifestEcosystem returns the ecosystem of the manifest at the path, or an empty string if it isn't a manifest
+func ManifestEcosystem(filePath string) string {
+	name := path.Base(filePath)
+	switch {
+	case name == "go.mod":
+		return code.ECOSYSTEM_GOLANG
+	case name == "package.json":
+		return code.ECOSYSTEM_NPM
+	case name == "pom.xml":
+		return code.ECOSYSTEM_MAVEN
+	case strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt"):
+		return code.ECOSYSTEM_PYPI
+	}
+	return ""
+}
+
+// ParseManifest parses the dependencies declared by the manifest at the path, sorted by their names
+func ParseManifest(filePath string, content []byte) ([]*Dependency, errors.Error) {
+	var dependencies []*Dependency
+	var err errors.Error
+	switch ManifestEcosystem(filePath) {
+	case code.ECOSYSTEM_GOLANG:
+		dependencies = parseGoMod(string(content))
+	case code.ECOSYSTEM_NPM:
+		dependencies, err = parsePackageJson(content)
+	case code.ECOSYSTEM_MAVEN:
+		dependencies, err = parsePomXml(content)
+	case code.ECOSYSTEM_PYPI:
+		dependencies = parseRequirementsTxt(string(content))
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to parse "+filePath)
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		return dependencies[i].PackageName < dependencies[j].PackageName
+	})
+	return dependencies, nil
+}
+
+func parseGoMod(content string) []*Dependency {
+	var dependencies []*Dependency
+	inRequireBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.SplitN(line, "//", 2)[0])
+		switch {
+		case line == "require (":
+			inRequireBlock = true
+			continue
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inRequireBlock:
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem:   code.ECOSYSTEM_GOLANG,
+			PackageName: fields[0],
+			Version:     fields[1],
+			Scope:       code.DEPENDENCY_SCOPE_RUNTIME,
+		})
+	}
+	return dependencies
+}
+
+func parsePackageJson(content []byte) ([]*Dependency, errors.Error) {
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+	}
+	err := json.Unmarshal(content, &manifest)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	var dependencies []*Dependency
+	seen := make(map[string]bool)
+	add := func(declared map[string]string, scope string) {
+		for name, version := range declared {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			dependencies = append(dependencies, &Dependency{
+				Ecosystem:   code.ECOSYSTEM_NPM,
+				PackageName: name,
+				Version:     version,
+				Scope:       scope,
+			})
+		}
+	}
+	add(manifest.Dependencies, code.DEPENDENCY_SCOPE_RUNTIME)
+	add(manifest.OptionalDependencies, code.DEPENDENCY_SCOPE_RUNTIME)
+	add(manifest.PeerDependencies, code.DEPENDENCY_SCOPE_RUNTIME)
+	add(manifest.DevDependencies, code.DEPENDENCY_SCOPE_DEVELOPMENT)
+	return dependencies, nil
+}
+
+type pomDependency struct {
+	GroupId    string `xml:"groupId"`
+	ArtifactId string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+type pomProperties map[string]string
+
+func (p *pomProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = make(pomProperties)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err = d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+func parsePomXml(content []byte) ([]*Dependency, errors.Error) {
+	var manifest struct {
+		Version    string        `xml:"version"`
+		Properties pomProperties `xml:"properties"`
+		Parent     struct {
+			Version string `xml:"version"`
+		} `xml:"parent"`
+		Dependencies        []pomDependency `xml:"dependencies>dependency"`
+		ManagedDependencies []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	}
+	err := xml.Unmarshal(content, &manifest)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	properties := manifest.Properties
+	if properties == nil {
+		properties = make(pomProperties)
+	}
+	properties["project.version"] = manifest.Version
+	properties["project.parent.version"] = manifest.Parent.Version
+	// the versions are often declared by the dependencyManagement and inherited by the dependencies
+	managedVersions := make(map[string]string)
+	for _, managed := range manifest.ManagedDependencies {
+		managedVersions[managed.GroupId+":"+managed.ArtifactId] = managed.Version
+	}
+	var dependencies []*Dependency
+	for _, dependency := range manifest.Dependencies {
+		name := dependency.GroupId + ":" + dependency.ArtifactId
+		version := dependency.Version
+		if version == "" {
+			version = managedVersions[name]
+		}
+		version = pomPropertyPattern.ReplaceAllStringFunc(version, func(reference string) string {
+			if value, ok := properties[reference[2:len(reference)-1]]; ok && value != "" {
+				return value
+			}
+			return reference
+		})
+		scope := code.DEPENDENCY_SCOPE_RUNTIME
+		if dependency.Scope == "test" || dependency.Scope == "provided" {
+			scope = code.DEPENDENCY_SCOPE_DEVELOPMENT
+		}
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem:   code.ECOSYSTEM_MAVEN,
+			PackageName: name,
+			Version:     strings.TrimSpace(version),
+			Scope:       scope,
+		})
+	}
+	return dependencies, nil
+}
+
+// e.g. `requests[security]==2.28.1 ; python_version >= "3.7"`, the extras and markers are dropped
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*([^;]*)`)
+
+func parseRequirementsTxt(content string) []*Dependency {
+	var dependencies []*Dependency
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.SplitN(line, "#", 2)[0])
+		// options like `-r other.txt` or `-e .` and urls don't declare a package
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+		match := requirementPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		version := strings.TrimSpace(match[2])
+		// pinned versions are kept bare so they can be compared with the ones of the other ecosystems
+		if strings.HasPrefix(version, "==") && !strings.Contains(version, ",") {
+			version = strings.TrimSpace(strings.TrimPrefix(version, "=="))
+		}
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem:   code.ECOSYSTEM_PYPI,
+			PackageName: strings.ToLower(match[1]),
+			Version:     version,
+			Scope:       code.DEPENDENCY_SCOPE_RUNTIME,
+		})
+	}
+	return dependencies
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestEcosystem(t *testing.T) {
+	assert.Equal(t, code.ECOSYSTEM_GOLANG, ManifestEcosystem("backend/go.mod"))
+	assert.Equal(t, code.ECOSYSTEM_NPM, ManifestEcosystem("config-ui/package.json"))
+	assert.Equal(t, code.ECOSYSTEM_MAVEN, ManifestEcosystem("pom.xml"))
+	assert.Equal(t, code.ECOSYSTEM_PYPI, ManifestEcosystem("requirements-dev.txt"))
+	assert.Equal(t, "", ManifestEcosystem("README.md"))
+}
+
+func TestParseGoMod(t *testing.T) {
+	dependencies, err := ParseManifest("go.mod", []byte(`module github.com/apache/incubator-devlake
+
+go 1.19
+
+require github.com/spf13/cobra v1.5.0
+
+require (
+	github.com/go-git/go-git/v5 v5.4.2
+	golang.org/x/sync v0.1.0 // indirect
+)
+
+replace (
+	github.com/go-git/go-git/v5 => ../go-git
+)
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.ECOSYSTEM_GOLANG, PackageName: "github.com/go-git/go-git/v5", Version: "v5.4.2", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+		{Ecosystem: code.ECOSYSTEM_GOLANG, PackageName: "github.com/spf13/cobra", Version: "v1.5.0", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+		{Ecosystem: code.ECOSYSTEM_GOLANG, PackageName: "golang.org/x/sync", Version: "v0.1.0", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+	}, dependencies)
+}
+
+func TestParsePackageJson(t *testing.T) {
+	dependencies, err := ParseManifest("package.json", []byte(`{
+  "name": "config-ui",
+  "dependencies": {"react": "^17.0.2", "@blueprintjs/core": "4.11.0"},
+  "devDependencies": {"typescript": "~4.8.4", "react": "^17.0.2"}
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.ECOSYSTEM_NPM, PackageName: "@blueprintjs/core", Version: "4.11.0", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+		{Ecosystem: code.ECOSYSTEM_NPM, PackageName: "react", Version: "^17.0.2", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+		{Ecosystem: code.ECOSYSTEM_NPM, PackageName: "typescript", Version: "~4.8.4", Scope: code.DEPENDENCY_SCOPE_DEVELOPMENT},
+	}, dependencies)
+
+	_, err = ParseManifest("package.json", []byte(`{`))
+	assert.NotNil(t, err)
+}
+
+func TestParsePomXml(t *testing.T) {
+	dependencies, err := ParseManifest("pom.xml", []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <version>1.2.0</version>
+  <properties>
+    <log4j.version>2.14.1</log4j.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>junit</groupId>
+        <artifactId>junit</artifactId>
+        <version>4.13.2</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.apache.logging.log4j</groupId>
+      <artifactId>log4j-core</artifactId>
+      <version>${log4j.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <scope>test</scope>
+    </dependency>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>sibling</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+  </dependencies>
+</project>
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.ECOSYSTEM_MAVEN, PackageName: "com.example:sibling", Version: "1.2.0", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+		{Ecosystem: code.ECOSYSTEM_MAVEN, PackageName: "junit:junit", Version: "4.13.2", Scope: code.DEPENDENCY_SCOPE_DEVELOPMENT},
+		{Ecosystem: code.ECOSYSTEM_MAVEN, PackageName: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+	}, dependencies)
+}
+
+func TestParseRequirementsTxt(t *testing.T) {
+	dependencies, err := ParseManifest("requirements.txt", []byte(`# runtime
+-r base.txt
+Requests[security]==2.28.1 ; python_version >= "3.7"
+sqlmodel>=0.0.8,<0.1
+fire
+git+https://github.com/apache/incubator-devlake.git#egg=pydevlake
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.ECOSYSTEM_PYPI, PackageName: "fire", Version: "", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+		{Ecosystem: code.ECOSYSTEM_PYPI, PackageName: "requests", Version: "2.28.1", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+		{Ecosystem: code.ECOSYSTEM_PYPI, PackageName: "sqlmodel", Version: ">=0.0.8,<0.1", Scope: code.DEPENDENCY_SCOPE_RUNTIME},
+	}, dependencies)
+}
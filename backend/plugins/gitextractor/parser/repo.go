@@ -61,6 +61,14 @@ func (r *GitRepo) CollectAll(subtaskCtx plugin.SubTaskContext) errors.Error {
 	if err != nil {
 		return err
 	}
+	err = r.CollectCodeOwners(subtaskCtx)
+	if err != nil {
+		return err
+	}
+	err = r.CollectDependencies(subtaskCtx)
+	if err != nil {
+		return err
+	}
 	return r.CollectDiffLine(subtaskCtx)
 }
 
@@ -213,6 +221,123 @@ func (r *GitRepo) CollectBranches(subtaskCtx plugin.SubTaskContext) errors.Error
 	}))
 }
 
+// CollectCodeOwners Collect the rules of the CODEOWNERS file of the default branch
+func (r *GitRepo) CollectCodeOwners(subtaskCtx plugin.SubTaskContext) errors.Error {
+	head, err := r.repo.Head()
+	if err != nil {
+		// an empty repo has no files
+		if git.IsErrorCode(err, git.ErrorCodeUnbornBranch) {
+			return nil
+		}
+		return errors.Convert(err)
+	}
+	commit, err := r.repo.LookupCommit(head.Target())
+	if err != nil {
+		return errors.Convert(err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	for _, path := range CodeOwnersPaths {
+		entry, err := tree.EntryByPath(path)
+		if err != nil || entry.Type != git.ObjectBlob {
+			continue
+		}
+		blob, err := r.repo.LookupBlob(entry.Id)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		for _, rule := range ParseCodeOwners(string(blob.Contents())) {
+			owners := rule.Owners
+			// keep the unowned rules, they override the previous rules matching the same paths
+			if len(owners) == 0 {
+				owners = []string{""}
+			}
+			for _, owner := range owners {
+				err1 := r.store.CodeOwners(&code.CodeOwner{
+					RepoId:    r.id,
+					LineNo:    rule.LineNo,
+					Owner:     owner,
+					Pattern:   rule.Pattern,
+					OwnerType: CodeOwnerType(owner),
+					Section:   rule.Section,
+					FilePath:  path,
+					CommitSha: commit.Id().String(),
+				})
+				if err1 != nil {
+					return err1
+				}
+			}
+			subtaskCtx.IncProgress(1)
+		}
+		return nil
+	}
+	r.logger.Info("no CODEOWNERS file found in %s", r.id)
+	return nil
+}
+
+// CollectDependencies Collect the dependencies declared by the manifests of the default branch
+func (r *GitRepo) CollectDependencies(subtaskCtx plugin.SubTaskContext) errors.Error {
+	head, err := r.repo.Head()
+	if err != nil {
+		// an empty repo has no files
+		if git.IsErrorCode(err, git.ErrorCodeUnbornBranch) {
+			return nil
+		}
+		return errors.Convert(err)
+	}
+	commit, err := r.repo.LookupCommit(head.Target())
+	if err != nil {
+		return errors.Convert(err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	err = tree.Walk(func(root string, entry *git.TreeEntry) error {
+		if entry.Type == git.ObjectTree {
+			for _, dir := range DependencyManifestDirsToSkip {
+				if entry.Name == dir {
+					return git.TreeWalkSkip
+				}
+			}
+			return nil
+		}
+		manifestPath := root + entry.Name
+		if entry.Type != git.ObjectBlob || ManifestEcosystem(manifestPath) == "" {
+			return nil
+		}
+		blob, err := r.repo.LookupBlob(entry.Id)
+		if err != nil {
+			return err
+		}
+		dependencies, err1 := ParseManifest(manifestPath, blob.Contents())
+		// a malformed manifest shouldn't prevent the others from being collected
+		if err1 != nil {
+			r.logger.Warn(err1, "skip the manifest %s of %s", manifestPath, r.id)
+			return nil
+		}
+		for _, dependency := range dependencies {
+			err1 := r.store.RepoDependencies(&code.RepoDependency{
+				RepoId:       r.id,
+				ManifestPath: manifestPath,
+				Ecosystem:    dependency.Ecosystem,
+				PackageName:  dependency.PackageName,
+				Version:      dependency.Version,
+				Scope:        dependency.Scope,
+				CommitSha:    commit.Id().String(),
+			})
+			if err1 != nil {
+				return err1
+			}
+		}
+		subtaskCtx.IncProgress(1)
+		return nil
+	})
+	return errors.Convert(err)
+}
+
 // CollectCommits Collect data from each commit, we can also get the diff line
 func (r *GitRepo) CollectCommits(subtaskCtx plugin.SubTaskContext) errors.Error {
 	opts, err := getDiffOpts()
@@ -356,6 +481,14 @@ func (r *GitRepo) storeCommitFilesFromDiff(commitSha string, diff *git.Diff, com
 				return nil, err
 			}
 		}
+		// every file is attributed to a component, not only the last one of the commit
+		if commitFileComponent != nil {
+			err = r.store.CommitFileComponents(commitFileComponent)
+			if err != nil {
+				r.logger.Error(err, "CommitFileComponents error")
+				return nil, err
+			}
+		}
 
 		commitFile = new(code.CommitFile)
 		commitFile.CommitSha = commitSha
@@ -86,6 +86,8 @@ type CsvStore struct {
 	commitFileComponentWriter *csvWriter
 	commitLineChangeWriter    *csvWriter
 	snapshotWriter            *csvWriter
+	codeOwnerWriter           *csvWriter
+	repoDependencyWriter      *csvWriter
 }
 
 func NewCsvStore(dir string) (*CsvStore, errors.Error) {
@@ -129,6 +131,14 @@ func NewCsvStore(dir string) (*CsvStore, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	s.codeOwnerWriter, err = newCsvWriter(filepath.Join(dir, "code_owners.csv"), code.CodeOwner{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	s.repoDependencyWriter, err = newCsvWriter(filepath.Join(dir, "repo_dependencies.csv"), code.RepoDependency{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
 	return s, nil
 }
 
@@ -160,6 +170,14 @@ func (c *CsvStore) RepoSnapshot(ss *code.RepoSnapshot) errors.Error {
 	return c.snapshotWriter.Write(ss)
 }
 
+func (c *CsvStore) CodeOwners(codeOwner *code.CodeOwner) errors.Error {
+	return c.codeOwnerWriter.Write(codeOwner)
+}
+
+func (c *CsvStore) RepoDependencies(repoDependency *code.RepoDependency) errors.Error {
+	return c.repoDependencyWriter.Write(repoDependency)
+}
+
 func (c *CsvStore) CommitParents(pp []*code.CommitParent) errors.Error {
 	var err error
 	for _, p := range pp {
@@ -190,5 +208,11 @@ func (c *CsvStore) Close() errors.Error {
 	if c.snapshotWriter != nil {
 		c.snapshotWriter.Close()
 	}
+	if c.codeOwnerWriter != nil {
+		c.codeOwnerWriter.Close()
+	}
+	if c.repoDependencyWriter != nil {
+		c.repoDependencyWriter.Close()
+	}
 	return nil
 }
@@ -133,6 +133,24 @@ func (d *Database) CommitLineChange(commitLineChange *code.CommitLineChange) err
 	return batch.Add(commitLineChange)
 }
 
+func (d *Database) CodeOwners(codeOwner *code.CodeOwner) errors.Error {
+	batch, err := d.driver.ForType(reflect.TypeOf(codeOwner))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&codeOwner.RawDataOrigin)
+	return batch.Add(codeOwner)
+}
+
+func (d *Database) RepoDependencies(repoDependency *code.RepoDependency) errors.Error {
+	batch, err := d.driver.ForType(reflect.TypeOf(repoDependency))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&repoDependency.RawDataOrigin)
+	return batch.Add(repoDependency)
+}
+
 func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 	if len(pp) == 0 {
 		return nil
@@ -84,6 +84,18 @@ func CollectGitTags(subTaskCtx plugin.SubTaskContext) errors.Error {
 	return repo.CollectTags(subTaskCtx)
 }
 
+func CollectGitCodeOwners(subTaskCtx plugin.SubTaskContext) errors.Error {
+	repo := getGitRepo(subTaskCtx)
+	subTaskCtx.SetProgress(0, -1)
+	return repo.CollectCodeOwners(subTaskCtx)
+}
+
+func CollectGitDependencies(subTaskCtx plugin.SubTaskContext) errors.Error {
+	repo := getGitRepo(subTaskCtx)
+	subTaskCtx.SetProgress(0, -1)
+	return repo.CollectDependencies(subTaskCtx)
+}
+
 func CollectGitDiffLines(subTaskCtx plugin.SubTaskContext) errors.Error {
 	repo := getGitRepo(subTaskCtx)
 	if count, err := repo.CountTags(); err != nil {
@@ -128,6 +140,22 @@ var CollectGitTagMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 }
 
+var CollectGitCodeOwnersMeta = plugin.SubTaskMeta{
+	Name:             "collectGitCodeOwners",
+	EntryPoint:       CollectGitCodeOwners,
+	EnabledByDefault: true,
+	Description:      "collect the rules of the CODEOWNERS file into Domain Layer Tables",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+var CollectGitDependenciesMeta = plugin.SubTaskMeta{
+	Name:             "collectGitDependencies",
+	EntryPoint:       CollectGitDependencies,
+	EnabledByDefault: true,
+	Description:      "collect the dependencies declared by the manifests into Domain Layer Tables",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
 var CollectGitDiffLineMeta = plugin.SubTaskMeta{
 	Name:             "collectDiffLine",
 	EntryPoint:       CollectGitDiffLines,
@@ -141,6 +141,26 @@ func makeDataSourcePipelinePlanV200(
 
 		}
 		plan[i] = stage
+
+		// enrich the pull requests and generate deployments once gitextractor collected the commits and tags
+		var nextStageSubtasks []string
+		if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_CODE_REVIEW) {
+			nextStageSubtasks = append(nextStageSubtasks, tasks.EnrichPullRequestStatsMeta.Name)
+		}
+		if transformationRule.ReleaseDeploymentPattern != "" && utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_CICD) {
+			nextStageSubtasks = append(nextStageSubtasks, tasks.GenerateReleaseDeploymentsMeta.Name)
+		}
+		if len(nextStageSubtasks) > 0 {
+			j := i + 1
+			if j == len(plan) {
+				plan = append(plan, nil)
+			}
+			plan[j] = append(plan[j], &plugin.PipelineTask{
+				Plugin:   "github",
+				Subtasks: nextStageSubtasks,
+				Options:  options,
+			})
+		}
 	}
 	return plan, nil
 }
@@ -164,7 +164,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all github connections
 // @Description Get all github connections
 // @Tags plugins/github
-// @Success 200  {object} []models.GithubConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/github/connections [GET]
@@ -81,3 +81,18 @@ func GetTransformationRule(input *plugin.ApiResourceInput) (*plugin.ApiResourceO
 func GetTransformationRuleList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	return trHelper.List(input)
 }
+
+// BatchTransformationRules create, update and delete transformation rules for Github in one transaction
+// @Summary create, update and delete transformation rules for Github in one transaction
+// @Description create, update and delete transformation rules for Github in one transaction, nothing is saved when any of them fails
+// @Tags plugins/github
+// @Accept application/json
+// @Param connectionId path int true "connectionId"
+// @Param batch body object true "the rules under create and update, the ids under delete"
+// @Success 200  {object} api.TransformationRuleBatchOutput
+// @Failure 400  {object} api.TransformationRuleBatchOutput "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/github/connections/{connectionId}/transformation_rules/batch [POST]
+func BatchTransformationRules(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return trHelper.Batch(input)
+}
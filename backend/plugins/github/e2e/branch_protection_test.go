@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestBranchProtectionDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_branch_protections.csv", "_raw_"+tasks.RAW_BRANCH_PROTECTION_TABLE)
+
+	columns := []string{
+		"required_approving_review_count",
+		"require_code_owner_reviews",
+		"dismiss_stale_reviews",
+		"required_status_checks",
+		"require_up_to_date_branch",
+		"enforce_admins",
+		"allow_force_pushes",
+		"allow_deletions",
+		"require_linear_history",
+		"require_signed_commits",
+		"require_conversation_resolution",
+	}
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubBranchProtection{})
+	dataflowTester.Subtask(tasks.ExtractBranchProtectionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubBranchProtection{},
+		"./snapshot_tables/_tool_github_branch_protections.csv",
+		e2ehelper.ColumnWithRawData(columns...),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.RepoBranchProtection{})
+	dataflowTester.Subtask(tasks.ConvertBranchProtectionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.RepoBranchProtection{},
+		"./snapshot_tables/repo_branch_protections.csv",
+		e2ehelper.ColumnWithRawData(append([]string{"repo_id", "branch"}, columns...)...),
+	)
+}
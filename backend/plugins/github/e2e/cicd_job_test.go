@@ -65,6 +65,14 @@ func TestGithubCICDJobDataFlow(t *testing.T) {
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
 
+	// verify step extraction
+	dataflowTester.FlushTabler(&models.GithubJobStep{})
+	dataflowTester.Subtask(tasks.ExtractJobStepsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubJobStep{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_job_steps.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
 	dataflowTester.FlushTabler(&devops.CICDTask{})
 	dataflowTester.Subtask(tasks.ConvertJobsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&devops.CICDTask{}, e2ehelper.TableOptions{
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestDependencyDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_dependencies.csv", "_raw_"+tasks.RAW_DEPENDENCY_TABLE)
+
+	// verify extraction, the repo itself and the packages without a purl are skipped
+	dataflowTester.FlushTabler(&models.GithubDependency{})
+	dataflowTester.Subtask(tasks.ExtractDependenciesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubDependency{},
+		"./snapshot_tables/_tool_github_dependencies.csv",
+		e2ehelper.ColumnWithRawData(
+			"version",
+			"purl",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.RepoDependency{})
+	dataflowTester.Subtask(tasks.ConvertDependenciesMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.RepoDependency{},
+		"./snapshot_tables/repo_dependencies.csv",
+		e2ehelper.ColumnWithRawData(
+			"version",
+			"scope",
+			"commit_sha",
+		),
+	)
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestEnvironmentDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "prod.*")
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_environments.csv", "_raw_"+tasks.RAW_ENVIRONMENT_TABLE)
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubEnvironment{})
+	dataflowTester.Subtask(tasks.ExtractEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubEnvironment{},
+		"./snapshot_tables/_tool_github_environments.csv",
+		e2ehelper.ColumnWithRawData(
+			"repo_id",
+			"name",
+			"url",
+			"wait_timer",
+			"reviewers",
+			"branch_policy",
+			"github_created_at",
+			"github_updated_at",
+		),
+	)
+
+	// verify conversion, only the configured protection rules become gates
+	dataflowTester.FlushTabler(&devops.CicdEnvironment{})
+	dataflowTester.FlushTabler(&devops.CicdEnvironmentGate{})
+	dataflowTester.Subtask(tasks.ConvertEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdEnvironment{},
+		"./snapshot_tables/cicd_environments.csv",
+		e2ehelper.ColumnWithRawData(
+			"cicd_scope_id",
+			"name",
+			"type",
+			"url",
+			"created_date",
+			"updated_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CicdEnvironmentGate{},
+		"./snapshot_tables/cicd_environment_gates.csv",
+		e2ehelper.ColumnWithRawData(
+			"value",
+		),
+	)
+}
@@ -84,6 +84,8 @@ func TestPrEnrichIssueDataFlow(t *testing.T) {
 			"issue_id",
 			"pull_request_key",
 			"issue_key",
+			"confidence",
+			"link_rule",
 			"_raw_data_params",
 			"_raw_data_table",
 			"_raw_data_id",
@@ -82,6 +82,7 @@ func TestPrReviewDataFlow(t *testing.T) {
 	)
 
 	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.FlushTabler(&code.PullRequestReviewEvent{})
 	dataflowTester.Subtask(tasks.ConvertPullRequestReviewsMeta, taskData)
 	dataflowTester.VerifyTable(
 		code.PullRequestComment{},
@@ -98,4 +99,17 @@ func TestPrReviewDataFlow(t *testing.T) {
 			"status",
 		},
 	)
+	dataflowTester.VerifyTable(
+		code.PullRequestReviewEvent{},
+		"./snapshot_tables/pull_request_review_events.csv",
+		[]string{
+			"pull_request_id",
+			"type",
+			"original_type",
+			"reviewer_id",
+			"actor_id",
+			"review_id",
+			"created_date",
+		},
+	)
 }
@@ -165,3 +165,37 @@ func TestPrDataFlow(t *testing.T) {
 		},
 	)
 }
+
+func TestPrStatsDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_pull_request_stats.csv", "_raw_github_api_pull_request_stats")
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_pull_requests.csv", models.GithubPullRequest{})
+
+	// verify pr stats extraction
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestStatsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubPullRequest{},
+		"./snapshot_tables/_tool_github_pull_requests_stats.csv",
+		[]string{
+			"connection_id",
+			"github_id",
+			"number",
+			"additions",
+			"deletions",
+			"changed_files",
+			"_raw_data_table",
+			"_raw_data_id",
+		},
+	)
+}
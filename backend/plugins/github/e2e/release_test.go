@@ -0,0 +1,174 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestReleaseDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_releases.csv", "_raw_"+tasks.RAW_RELEASE_TABLE)
+	// the tags cloned by gitextractor, the one of another repo must not be picked up
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/refs.csv", &code.Ref{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubRelease{})
+	dataflowTester.FlushTabler(&models.GithubReleaseAsset{})
+	dataflowTester.Subtask(tasks.ExtractReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GithubRelease{},
+		"./snapshot_tables/_tool_github_releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"repo_id",
+			"tag_name",
+			"name",
+			"body",
+			"target_commitish",
+			"draft",
+			"prerelease",
+			"author_id",
+			"url",
+			"github_created_at",
+			"published_at",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.GithubReleaseAsset{},
+		"./snapshot_tables/_tool_github_release_assets.csv",
+		e2ehelper.ColumnWithRawData(
+			"release_id",
+			"name",
+			"content_type",
+			"size",
+			"download_url",
+			"github_created_at",
+		),
+	)
+
+	// verify conversion, drafts are skipped
+	dataflowTester.FlushTabler(&devops.CicdRelease{})
+	dataflowTester.FlushTabler(&devops.CicdArtifact{})
+	dataflowTester.FlushTabler(&devops.CicdReleaseArtifact{})
+	dataflowTester.Subtask(tasks.ConvertReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdRelease{},
+		"./snapshot_tables/cicd_releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"version",
+			"tag_name",
+			"description",
+			"url",
+			"is_prerelease",
+			"cicd_scope_id",
+			"pipeline_id",
+			"repo_id",
+			"commit_sha",
+			"prev_release_id",
+			"author_id",
+			"created_date",
+			"published_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CicdArtifact{},
+		"./snapshot_tables/cicd_artifacts.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"type",
+			"version",
+			"digest",
+			"registry",
+			"url",
+			"size_bytes",
+			"cicd_scope_id",
+			"pipeline_id",
+			"repo_id",
+			"commit_sha",
+			"created_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CicdReleaseArtifact{},
+		"./snapshot_tables/cicd_release_artifacts.csv",
+		e2ehelper.ColumnWithRawData(),
+	)
+}
+
+func TestReleaseDeploymentDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+			GithubTransformationRule: &models.GithubTransformationRule{
+				ReleaseDeploymentPattern: `^v\d+\.\d+\.\d+$`,
+			},
+		},
+	}
+
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_repos.csv", &models.GithubRepo{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/cicd_releases.csv", &devops.CicdRelease{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/refs.csv", &code.Ref{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/commits.csv", &code.Commit{})
+
+	// the prerelease, the tag of the release and the tags not matching the pattern are not deployments
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.GenerateReleaseDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"result",
+			"status",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"commit_sha",
+			"ref_name",
+			"repo_id",
+			"repo_url",
+		),
+	)
+}
@@ -67,7 +67,6 @@ func TestRepoDataFlow(t *testing.T) {
 			"forked_from",
 			"created_date",
 			"updated_date",
-			"deleted",
 			"_raw_data_params",
 			"_raw_data_table",
 			"_raw_data_id",
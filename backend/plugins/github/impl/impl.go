@@ -64,6 +64,9 @@ func (p Github) Init(basicRes context.BasicRes) errors.Error {
 func (p Github) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.GithubConnection{},
+		&models.GithubEnvironment{},
+		&models.GithubDependency{},
+		&models.GithubBranchProtection{},
 		&models.GithubAccount{},
 		&models.GithubAccountOrg{},
 		&models.GithubCommit{},
@@ -73,6 +76,7 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubIssueEvent{},
 		&models.GithubIssueLabel{},
 		&models.GithubJob{},
+		&models.GithubJobStep{},
 		&models.GithubMilestone{},
 		&models.GithubPrComment{},
 		&models.GithubPrCommit{},
@@ -80,6 +84,8 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubPrLabel{},
 		&models.GithubPrReview{},
 		&models.GithubPullRequest{},
+		&models.GithubRelease{},
+		&models.GithubReleaseAsset{},
 		&models.GithubRepo{},
 		&models.GithubRepoAccount{},
 		&models.GithubRepoCommit{},
@@ -98,6 +104,8 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiIssuesMeta,
 		tasks.CollectApiPullRequestsMeta,
 		tasks.ExtractApiPullRequestsMeta,
+		tasks.CollectApiPullRequestStatsMeta,
+		tasks.ExtractApiPullRequestStatsMeta,
 		tasks.CollectApiCommentsMeta,
 		tasks.ExtractApiCommentsMeta,
 		tasks.CollectApiEventsMeta,
@@ -114,6 +122,14 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiCommitStatsMeta,
 		tasks.CollectMilestonesMeta,
 		tasks.ExtractMilestonesMeta,
+		tasks.CollectReleasesMeta,
+		tasks.ExtractReleasesMeta,
+		tasks.CollectEnvironmentsMeta,
+		tasks.ExtractEnvironmentsMeta,
+		tasks.CollectDependenciesMeta,
+		tasks.ExtractDependenciesMeta,
+		tasks.CollectBranchProtectionsMeta,
+		tasks.ExtractBranchProtectionsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
 		tasks.CollectAccountOrgMeta,
@@ -123,9 +139,15 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertRunsMeta,
 		tasks.CollectJobsMeta,
 		tasks.ExtractJobsMeta,
+		tasks.ExtractJobStepsMeta,
 		tasks.ConvertJobsMeta,
+		tasks.ConvertReleasesMeta,
+		tasks.GenerateReleaseDeploymentsMeta,
+		tasks.ConvertEnvironmentsMeta,
+		tasks.ConvertDependenciesMeta,
 		tasks.EnrichPullRequestIssuesMeta,
 		tasks.ConvertRepoMeta,
+		tasks.ConvertBranchProtectionsMeta,
 		tasks.ConvertIssuesMeta,
 		tasks.ConvertCommitsMeta,
 		tasks.ConvertIssueLabelsMeta,
@@ -136,8 +158,11 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertPullRequestIssuesMeta,
 		tasks.ConvertIssueCommentsMeta,
 		tasks.ConvertPullRequestCommentsMeta,
+		tasks.EnrichPullRequestStatsMeta,
 		tasks.ConvertMilestonesMeta,
+		tasks.EnrichSprintHistoriesMeta,
 		tasks.ConvertAccountsMeta,
+		tasks.ReconcileDeletedRecordsMeta,
 	}
 }
 
@@ -230,6 +255,9 @@ func (p Github) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"PATCH": api.UpdateTransformationRule,
 			"GET":   api.GetTransformationRule,
 		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
 		"connections/:connectionId/proxy/rest/*path": {
 			"GET": api.Proxy,
 		},
@@ -29,6 +29,7 @@ type GithubIssueEvent struct {
 	Type            string    `gorm:"type:varchar(255);comment:Events that can occur to an issue, ex. assigned, closed, labeled, etc."`
 	AuthorUsername  string    `gorm:"type:varchar(255)"`
 	GithubCreatedAt time.Time `gorm:"index"`
+	MilestoneTitle  string    `gorm:"type:varchar(255);comment:Title of the milestone of milestoned and demilestoned events"`
 	common.NoPKModel
 }
 
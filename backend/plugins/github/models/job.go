@@ -26,28 +26,30 @@ import (
 
 type GithubJob struct {
 	common.NoPKModel
-	ConnectionId  uint64         `gorm:"primaryKey"`
-	RepoId        int            `gorm:"primaryKey"`
-	ID            int            `json:"id" gorm:"primaryKey;autoIncrement:false"`
-	RunID         int            `json:"run_id"`
-	RunURL        string         `json:"run_url" gorm:"type:varchar(255)"`
-	NodeID        string         `json:"node_id" gorm:"type:varchar(255)"`
-	HeadSha       string         `json:"head_sha" gorm:"type:varchar(255)"`
-	URL           string         `json:"url" gorm:"type:varchar(255)"`
-	HTMLURL       string         `json:"html_url" gorm:"type:varchar(255)"`
-	Status        string         `json:"status" gorm:"type:varchar(255)"`
-	Conclusion    string         `json:"conclusion" gorm:"type:varchar(255)"`
-	StartedAt     *time.Time     `json:"started_at"`
-	CompletedAt   *time.Time     `json:"completed_at"`
-	Name          string         `json:"name" gorm:"type:varchar(255)"`
-	Steps         datatypes.JSON `json:"steps"`
-	CheckRunURL   string         `json:"check_run_url" gorm:"type:varchar(255)"`
-	Labels        datatypes.JSON `json:"labels"`
-	RunnerID      int            `json:"runner_id"`
-	RunnerName    string         `json:"runner_name" gorm:"type:varchar(255)"`
-	RunnerGroupID int            `json:"runner_group_id"`
-	Type          string         `json:"type" gorm:"type:varchar(255)"`
-	Environment   string         `gorm:"type:varchar(255)"`
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey"`
+	ID           int    `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	RunID        int    `json:"run_id"`
+	RunURL       string `json:"run_url" gorm:"type:varchar(255)"`
+	NodeID       string `json:"node_id" gorm:"type:varchar(255)"`
+	HeadSha      string `json:"head_sha" gorm:"type:varchar(255)"`
+	URL          string `json:"url" gorm:"type:varchar(255)"`
+	HTMLURL      string `json:"html_url" gorm:"type:varchar(255)"`
+	Status       string `json:"status" gorm:"type:varchar(255)"`
+	Conclusion   string `json:"conclusion" gorm:"type:varchar(255)"`
+	// GithubCreatedAt is when the job was queued
+	GithubCreatedAt *time.Time     `json:"created_at"`
+	StartedAt       *time.Time     `json:"started_at"`
+	CompletedAt     *time.Time     `json:"completed_at"`
+	Name            string         `json:"name" gorm:"type:varchar(255)"`
+	Steps           datatypes.JSON `json:"steps"`
+	CheckRunURL     string         `json:"check_run_url" gorm:"type:varchar(255)"`
+	Labels          datatypes.JSON `json:"labels"`
+	RunnerID        int            `json:"runner_id"`
+	RunnerName      string         `json:"runner_name" gorm:"type:varchar(255)"`
+	RunnerGroupID   int            `json:"runner_group_id"`
+	Type            string         `json:"type" gorm:"type:varchar(255)"`
+	Environment     string         `gorm:"type:varchar(255)"`
 }
 
 func (GithubJob) TableName() string {
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type githubIssueEvent20230609 struct {
+	MilestoneTitle string `gorm:"type:varchar(255)"`
+}
+
+func (githubIssueEvent20230609) TableName() string {
+	return "_tool_github_issue_events"
+}
+
+type addMilestoneTitleToIssueEvents struct{}
+
+func (*addMilestoneTitleToIssueEvents) Up(baseRes context.BasicRes) errors.Error {
+	return baseRes.GetDal().AutoMigrate(&githubIssueEvent20230609{})
+}
+
+func (*addMilestoneTitleToIssueEvents) Version() uint64 {
+	return 20230609101500
+}
+
+func (*addMilestoneTitleToIssueEvents) Name() string {
+	return "add milestone_title to _tool_github_issue_events"
+}
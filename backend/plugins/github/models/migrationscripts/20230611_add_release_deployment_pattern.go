@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type githubTransformationRule20230611 struct {
+	ReleaseDeploymentPattern string `gorm:"type:varchar(255)"`
+}
+
+func (githubTransformationRule20230611) TableName() string {
+	return "_tool_github_transformation_rules"
+}
+
+type addReleaseDeploymentPattern struct{}
+
+func (*addReleaseDeploymentPattern) Up(baseRes context.BasicRes) errors.Error {
+	return baseRes.GetDal().AutoMigrate(&githubTransformationRule20230611{})
+}
+
+func (*addReleaseDeploymentPattern) Version() uint64 {
+	return 20230611100000
+}
+
+func (*addReleaseDeploymentPattern) Name() string {
+	return "add release_deployment_pattern to _tool_github_transformation_rules"
+}
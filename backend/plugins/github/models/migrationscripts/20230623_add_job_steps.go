@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/github/models/migrationscripts/archived"
+)
+
+type githubJob20230623 struct {
+	GithubCreatedAt *time.Time
+}
+
+func (githubJob20230623) TableName() string {
+	return "_tool_github_jobs"
+}
+
+type addJobSteps struct{}
+
+func (*addJobSteps) Up(baseRes context.BasicRes) errors.Error {
+	err := baseRes.GetDal().AutoMigrate(&githubJob20230623{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(baseRes, &archived.GithubJobStep{})
+}
+
+func (*addJobSteps) Version() uint64 {
+	return 20230623100000
+}
+
+func (*addJobSteps) Name() string {
+	return "add github_created_at to _tool_github_jobs and add _tool_github_job_steps"
+}
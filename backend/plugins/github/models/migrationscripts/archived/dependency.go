@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+// GithubDependency is a package reported by the dependency graph of a repo
+type GithubDependency struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey;autoIncrement:false"`
+	// Ecosystem is the package-url type of the package, e.g. maven or npm
+	Ecosystem   string `gorm:"primaryKey;type:varchar(50)"`
+	PackageName string `gorm:"primaryKey;type:varchar(200)"`
+	Version     string `gorm:"type:varchar(255)"`
+	Purl        string `gorm:"type:varchar(500)"`
+	archived.NoPKModel
+}
+
+func (GithubDependency) TableName() string {
+	return "_tool_github_dependencies"
+}
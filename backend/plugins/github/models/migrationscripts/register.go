@@ -38,5 +38,13 @@ func All() []plugin.MigrationScript {
 		new(addEnvToRunAndJob),
 		new(addGithubCommitAuthorInfo),
 		new(fixRunNameToText),
+		new(addBranchProtections),
+		new(addMilestoneTitleToIssueEvents),
+		new(addReleases),
+		new(addEnvironments),
+		new(addReleaseDeploymentPattern),
+		new(addPullRequestChangedFiles),
+		new(addDependencies),
+		new(addJobSteps),
 	}
 }
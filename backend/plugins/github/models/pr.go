@@ -36,6 +36,7 @@ type GithubPullRequest struct {
 	// In order to get the following fields, we need to collect PRs individually from GitHub
 	Additions      int
 	Deletions      int
+	ChangedFiles   int
 	Comments       int
 	Commits        int
 	ReviewComments int
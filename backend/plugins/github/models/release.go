@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type GithubRelease struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GithubId        int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId          int    `gorm:"index"`
+	TagName         string `gorm:"type:varchar(255)"`
+	Name            string `gorm:"type:varchar(255)"`
+	Body            string
+	TargetCommitish string `gorm:"type:varchar(255)"`
+	Draft           bool
+	Prerelease      bool
+	AuthorId        int
+	Url             string `gorm:"type:varchar(255)"`
+	GithubCreatedAt time.Time
+	PublishedAt     *time.Time
+	common.NoPKModel
+}
+
+func (GithubRelease) TableName() string {
+	return "_tool_github_releases"
+}
+
+type GithubReleaseAsset struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GithubId        int    `gorm:"primaryKey;autoIncrement:false"`
+	ReleaseId       int    `gorm:"index"`
+	Name            string `gorm:"type:varchar(255)"`
+	ContentType     string `gorm:"type:varchar(255)"`
+	Size            int64
+	DownloadUrl     string `gorm:"type:varchar(255)"`
+	GithubCreatedAt time.Time
+	common.NoPKModel
+}
+
+func (GithubReleaseAsset) TableName() string {
+	return "_tool_github_release_assets"
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_BRANCH_PROTECTION_TABLE = "github_api_branch_protections"
+
+var CollectBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "collectBranchProtections",
+	EntryPoint:       CollectBranchProtections,
+	EnabledByDefault: false,
+	Description:      "Collect protection rules of the protected branches from Github api, requires admin permission on the repo",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type ProtectedBranch struct {
+	Name string `json:"name"`
+}
+
+func CollectBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	branches, err := listProtectedBranches(data.ApiClient, data.Options.Name)
+	if err != nil {
+		return err
+	}
+	iterator := api.NewQueueIterator()
+	for i := range branches {
+		iterator.Push(&branches[i])
+	}
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_BRANCH_PROTECTION_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		Input:       iterator,
+		UrlTemplate: "repos/{{ .Params.Name }}/branches/{{ .Input.Name }}/protection",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				return nil, errors.Convert(err)
+			}
+			res.Body.Close()
+			return []json.RawMessage{body}, nil
+		},
+		AfterResponse: func(res *http.Response) errors.Error {
+			// the protection of a branch is only visible to the admins of the repo
+			if res.StatusCode == http.StatusForbidden {
+				return api.ErrIgnoreAndContinue
+			}
+			return ignoreHTTPStatus404(res)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+// listProtectedBranches pages through the protected branches of the repo, the list is needed up front because
+// the protection of each branch has to be requested separately
+func listProtectedBranches(apiClient *api.ApiAsyncClient, repoName string) ([]ProtectedBranch, errors.Error) {
+	var protectedBranches []ProtectedBranch
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("protected", "true")
+		query.Set("page", fmt.Sprintf("%v", page))
+		query.Set("per_page", "100")
+		res, err := apiClient.Get(fmt.Sprintf("repos/%s/branches", repoName), query, nil)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode == http.StatusUnauthorized {
+			return nil, errors.Unauthorized.New("authentication failed, please check your AccessToken")
+		}
+		if res.StatusCode != http.StatusOK {
+			return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("unexpected status code %d when listing protected branches of %s", res.StatusCode, repoName))
+		}
+		var branches []ProtectedBranch
+		err = api.UnmarshalResponse(res, &branches)
+		if err != nil {
+			return nil, err
+		}
+		protectedBranches = append(protectedBranches, branches...)
+		if len(branches) < 100 {
+			return protectedBranches, nil
+		}
+	}
+}
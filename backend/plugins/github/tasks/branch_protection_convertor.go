@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "convertBranchProtections",
+	EntryPoint:       ConvertBranchProtections,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table _tool_github_branch_protections into domain layer table repo_branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubBranchProtection{}),
+		dal.Where("repo_id = ? and connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	protectionIdGen := didgen.NewDomainIdGenerator(&models.GithubBranchProtection{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.GithubBranchProtection{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_BRANCH_PROTECTION_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			protection := inputRow.(*models.GithubBranchProtection)
+			return []interface{}{
+				&code.RepoBranchProtection{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: protectionIdGen.Generate(protection.ConnectionId, protection.RepoId, protection.Branch),
+					},
+					RepoId:                        repoIdGen.Generate(protection.ConnectionId, protection.RepoId),
+					Branch:                        protection.Branch,
+					RequiredApprovingReviewCount:  protection.RequiredApprovingReviewCount,
+					RequireCodeOwnerReviews:       protection.RequireCodeOwnerReviews,
+					DismissStaleReviews:           protection.DismissStaleReviews,
+					RequiredStatusChecks:          protection.RequiredStatusChecks,
+					RequireUpToDateBranch:         protection.RequireUpToDateBranch,
+					EnforceAdmins:                 protection.EnforceAdmins,
+					AllowForcePushes:              protection.AllowForcePushes,
+					AllowDeletions:                protection.AllowDeletions,
+					RequireLinearHistory:          protection.RequireLinearHistory,
+					RequireSignedCommits:          protection.RequireSignedCommits,
+					RequireConversationResolution: protection.RequireConversationResolution,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "extractBranchProtections",
+	EntryPoint:       ExtractBranchProtections,
+	EnabledByDefault: false,
+	Description:      "Extract raw branch protection data into tool layer table _tool_github_branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type githubEnabledSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+type BranchProtectionResponse struct {
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	EnforceAdmins                  githubEnabledSetting `json:"enforce_admins"`
+	RequiredSignatures             githubEnabledSetting `json:"required_signatures"`
+	RequiredLinearHistory          githubEnabledSetting `json:"required_linear_history"`
+	AllowForcePushes               githubEnabledSetting `json:"allow_force_pushes"`
+	AllowDeletions                 githubEnabledSetting `json:"allow_deletions"`
+	RequiredConversationResolution githubEnabledSetting `json:"required_conversation_resolution"`
+}
+
+func ExtractBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_BRANCH_PROTECTION_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			branch := &ProtectedBranch{}
+			err := errors.Convert(json.Unmarshal(row.Input, branch))
+			if err != nil {
+				return nil, err
+			}
+			response := &BranchProtectionResponse{}
+			err = errors.Convert(json.Unmarshal(row.Data, response))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				convertGithubBranchProtection(response, data.Options.ConnectionId, data.Options.GithubId, branch.Name),
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func convertGithubBranchProtection(response *BranchProtectionResponse, connectionId uint64, repoId int, branch string) *models.GithubBranchProtection {
+	protection := &models.GithubBranchProtection{
+		ConnectionId:                  connectionId,
+		RepoId:                        repoId,
+		Branch:                        branch,
+		EnforceAdmins:                 response.EnforceAdmins.Enabled,
+		AllowForcePushes:              response.AllowForcePushes.Enabled,
+		AllowDeletions:                response.AllowDeletions.Enabled,
+		RequireLinearHistory:          response.RequiredLinearHistory.Enabled,
+		RequireSignedCommits:          response.RequiredSignatures.Enabled,
+		RequireConversationResolution: response.RequiredConversationResolution.Enabled,
+	}
+	if response.RequiredStatusChecks != nil {
+		protection.RequiredStatusChecks = strings.Join(response.RequiredStatusChecks.Contexts, ",")
+		protection.RequireUpToDateBranch = response.RequiredStatusChecks.Strict
+	}
+	if response.RequiredPullRequestReviews != nil {
+		protection.RequiredApprovingReviewCount = response.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		protection.RequireCodeOwnerReviews = response.RequiredPullRequestReviews.RequireCodeOwnerReviews
+		protection.DismissStaleReviews = response.RequiredPullRequestReviews.DismissStaleReviews
+	}
+	return protection
+}
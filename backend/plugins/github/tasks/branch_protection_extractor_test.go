@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertGithubBranchProtection(t *testing.T) {
+	response := &BranchProtectionResponse{}
+	err := json.Unmarshal([]byte(`{
+		"required_status_checks": {"strict": true, "contexts": ["ci/build", "ci/test"]},
+		"required_pull_request_reviews": {
+			"dismiss_stale_reviews": true,
+			"require_code_owner_reviews": false,
+			"required_approving_review_count": 2
+		},
+		"enforce_admins": {"enabled": true},
+		"required_signatures": {"enabled": false},
+		"required_linear_history": {"enabled": true},
+		"allow_force_pushes": {"enabled": false},
+		"allow_deletions": {"enabled": false},
+		"required_conversation_resolution": {"enabled": true}
+	}`), response)
+	assert.Nil(t, err)
+	assert.Equal(t, &models.GithubBranchProtection{
+		ConnectionId:                  1,
+		RepoId:                        134018330,
+		Branch:                        "main",
+		RequiredApprovingReviewCount:  2,
+		DismissStaleReviews:           true,
+		RequiredStatusChecks:          "ci/build,ci/test",
+		RequireUpToDateBranch:         true,
+		EnforceAdmins:                 true,
+		RequireLinearHistory:          true,
+		RequireConversationResolution: true,
+	}, convertGithubBranchProtection(response, 1, 134018330, "main"))
+}
+
+func TestConvertGithubBranchProtectionWithoutChecksAndReviews(t *testing.T) {
+	response := &BranchProtectionResponse{}
+	err := json.Unmarshal([]byte(`{
+		"enforce_admins": {"enabled": false},
+		"allow_force_pushes": {"enabled": true},
+		"allow_deletions": {"enabled": true}
+	}`), response)
+	assert.Nil(t, err)
+	assert.Equal(t, &models.GithubBranchProtection{
+		ConnectionId:     1,
+		RepoId:           134018330,
+		Branch:           "release/*",
+		AllowForcePushes: true,
+		AllowDeletions:   true,
+	}, convertGithubBranchProtection(response, 1, 134018330, "release/*"))
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package tasks
 
 import (
+	"encoding/json"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
@@ -28,6 +29,8 @@ import (
 	"github.com/apache/incubator-devlake/plugins/github/models"
 	"reflect"
 	"strings"
+
+	"gorm.io/datatypes"
 )
 
 var ConvertJobsMeta = plugin.SubTaskMeta{
@@ -85,7 +88,18 @@ func ConvertJobs(taskCtx plugin.SubTaskContext) (err errors.Error) {
 				CicdScopeId:  repoIdGen.Generate(data.Options.ConnectionId, line.RepoId),
 				Type:         line.Type,
 				Environment:  line.Environment,
+				Runner:       line.RunnerName,
+				QueuedDate:   line.GithubCreatedAt,
+			}
+			if line.GithubCreatedAt != nil && line.StartedAt.After(*line.GithubCreatedAt) {
+				queuedDurationSec := uint64(line.StartedAt.Sub(*line.GithubCreatedAt).Seconds())
+				domainJob.QueuedDurationSec = &queuedDurationSec
+			}
+			failedStep, err := getFailedStep(line.Steps)
+			if err != nil {
+				return nil, err
 			}
+			domainJob.FailedStep = failedStep
 
 			if strings.Contains(line.Conclusion, "SUCCESS") {
 				domainJob.Result = devops.SUCCESS
@@ -115,3 +129,21 @@ func ConvertJobs(taskCtx plugin.SubTaskContext) (err errors.Error) {
 
 	return converter.Execute()
 }
+
+// getFailedStep returns the name of the first failed step of the job
+func getFailedStep(steps datatypes.JSON) (string, errors.Error) {
+	if len(steps) == 0 {
+		return "", nil
+	}
+	var jobSteps []models.GithubJobStep
+	err := errors.Convert(json.Unmarshal(steps, &jobSteps))
+	if err != nil {
+		return "", err
+	}
+	for _, step := range jobSteps {
+		if strings.EqualFold(step.Conclusion, "failure") {
+			return step.Name, nil
+		}
+	}
+	return "", nil
+}
@@ -58,28 +58,29 @@ func ExtractJobs(taskCtx plugin.SubTaskContext) errors.Error {
 
 			results := make([]interface{}, 0, 1)
 			githubJobResult := &models.GithubJob{
-				ConnectionId:  data.Options.ConnectionId,
-				RepoId:        repoId,
-				ID:            githubJob.ID,
-				RunID:         githubJob.RunID,
-				RunURL:        githubJob.RunURL,
-				NodeID:        githubJob.NodeID,
-				HeadSha:       githubJob.HeadSha,
-				URL:           githubJob.URL,
-				HTMLURL:       githubJob.HTMLURL,
-				Status:        strings.ToUpper(githubJob.Status),
-				Conclusion:    strings.ToUpper(githubJob.Conclusion),
-				StartedAt:     githubJob.StartedAt,
-				CompletedAt:   githubJob.CompletedAt,
-				Name:          githubJob.Name,
-				Steps:         githubJob.Steps,
-				CheckRunURL:   githubJob.CheckRunURL,
-				Labels:        githubJob.Labels,
-				RunnerID:      githubJob.RunID,
-				RunnerName:    githubJob.RunnerName,
-				RunnerGroupID: githubJob.RunnerGroupID,
-				Type:          data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, githubJob.Name),
-				Environment:   data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, githubJob.Name),
+				ConnectionId:    data.Options.ConnectionId,
+				RepoId:          repoId,
+				ID:              githubJob.ID,
+				RunID:           githubJob.RunID,
+				RunURL:          githubJob.RunURL,
+				NodeID:          githubJob.NodeID,
+				HeadSha:         githubJob.HeadSha,
+				URL:             githubJob.URL,
+				HTMLURL:         githubJob.HTMLURL,
+				Status:          strings.ToUpper(githubJob.Status),
+				Conclusion:      strings.ToUpper(githubJob.Conclusion),
+				GithubCreatedAt: githubJob.GithubCreatedAt,
+				StartedAt:       githubJob.StartedAt,
+				CompletedAt:     githubJob.CompletedAt,
+				Name:            githubJob.Name,
+				Steps:           githubJob.Steps,
+				CheckRunURL:     githubJob.CheckRunURL,
+				Labels:          githubJob.Labels,
+				RunnerID:        githubJob.RunnerID,
+				RunnerName:      githubJob.RunnerName,
+				RunnerGroupID:   githubJob.RunnerGroupID,
+				Type:            data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, githubJob.Name),
+				Environment:     data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, githubJob.Name),
 			}
 			results = append(results, githubJobResult)
 			return results, nil
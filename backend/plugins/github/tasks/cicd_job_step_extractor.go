@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractJobStepsMeta = plugin.SubTaskMeta{
+	Name:             "extractJobSteps",
+	EntryPoint:       ExtractJobSteps,
+	EnabledByDefault: false,
+	Description:      "Extract the steps of raw job data into tool layer table github_job_steps",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type GithubApiJobWithSteps struct {
+	ID    int                    `json:"id"`
+	Steps []models.GithubJobStep `json:"steps"`
+}
+
+func ExtractJobSteps(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoId := data.Options.GithubId
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_JOB_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			githubJob := &GithubApiJobWithSteps{}
+			err := errors.Convert(json.Unmarshal(row.Data, githubJob))
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]interface{}, 0, len(githubJob.Steps))
+			for _, step := range githubJob.Steps {
+				githubJobStep := &models.GithubJobStep{
+					ConnectionId: data.Options.ConnectionId,
+					RepoId:       repoId,
+					JobId:        githubJob.ID,
+					Number:       step.Number,
+					Name:         step.Name,
+					Status:       strings.ToUpper(step.Status),
+					Conclusion:   strings.ToUpper(step.Conclusion),
+					StartedAt:    step.StartedAt,
+					CompletedAt:  step.CompletedAt,
+				}
+				if step.StartedAt != nil && step.CompletedAt != nil {
+					githubJobStep.DurationSec = uint64(step.CompletedAt.Sub(*step.StartedAt).Seconds())
+				}
+				results = append(results, githubJobStep)
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_DEPENDENCY_TABLE = "github_api_dependencies"
+
+var CollectDependenciesMeta = plugin.SubTaskMeta{
+	Name:             "collectDependencies",
+	EntryPoint:       CollectDependencies,
+	EnabledByDefault: true,
+	Description:      "Collect the SBOM of the dependency graph from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func CollectDependencies(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPENDENCY_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		Incremental: false,
+		// the SBOM is exported as a whole, it isn't paginated
+		UrlTemplate: "repos/{{ .Params.Name }}/dependency-graph/sbom",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var body struct {
+				Sbom struct {
+					Packages []json.RawMessage `json:"packages"`
+				} `json:"sbom"`
+			}
+			err := api.UnmarshalResponse(res, &body)
+			if err != nil {
+				return nil, err
+			}
+			return body.Sbom.Packages, nil
+		},
+		// the dependency graph may be disabled for the repo
+		AfterResponse: ignoreHTTPStatus404,
+	})
+
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
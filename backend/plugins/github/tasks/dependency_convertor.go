@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertDependenciesMeta = plugin.SubTaskMeta{
+	Name:             "convertDependencies",
+	EntryPoint:       ConvertDependencies,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_dependencies into domain layer table repo_dependencies",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertDependencies(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubDependency{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.GithubId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPENDENCY_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubDependency{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			githubDependency := inputRow.(*models.GithubDependency)
+			// the dependency graph merges the packages of all the manifests and doesn't tell their scopes
+			return []interface{}{
+				&code.RepoDependency{
+					RepoId:      repoIdGen.Generate(data.Options.ConnectionId, githubDependency.RepoId),
+					Ecosystem:   githubDependency.Ecosystem,
+					PackageName: githubDependency.PackageName,
+					Version:     githubDependency.Version,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractDependenciesMeta = plugin.SubTaskMeta{
+	Name:             "extractDependencies",
+	EntryPoint:       ExtractDependencies,
+	EnabledByDefault: true,
+	Description:      "Extract raw SBOM packages into tool layer table github_dependencies",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+type SbomPackage struct {
+	SpdxId       string `json:"SPDXID"`
+	Name         string `json:"name"`
+	VersionInfo  string `json:"versionInfo"`
+	ExternalRefs []struct {
+		ReferenceCategory string `json:"referenceCategory"`
+		ReferenceType     string `json:"referenceType"`
+		ReferenceLocator  string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+func ExtractDependencies(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPENDENCY_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			sbomPackage := &SbomPackage{}
+			err := errors.Convert(json.Unmarshal(row.Data, sbomPackage))
+			if err != nil {
+				return nil, err
+			}
+			for _, ref := range sbomPackage.ExternalRefs {
+				if ref.ReferenceType != "purl" {
+					continue
+				}
+				dependency := parsePurl(ref.ReferenceLocator)
+				// the repo itself is described as a package of the SBOM
+				if dependency == nil || dependency.Ecosystem == "github" {
+					return nil, nil
+				}
+				dependency.ConnectionId = data.Options.ConnectionId
+				dependency.RepoId = data.Options.GithubId
+				if dependency.Version == "" {
+					dependency.Version = sbomPackage.VersionInfo
+				}
+				return []interface{}{dependency}, nil
+			}
+			// a package without a purl can't be attributed to an ecosystem
+			return nil, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+// parsePurl parses a package-url like `pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1`,
+// maven packages are named `groupId:artifactId` the way the manifests declare them
+func parsePurl(purl string) *models.GithubDependency {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return nil
+	}
+	// the qualifiers and the subpath don't identify the package
+	coordinates := strings.SplitN(strings.SplitN(strings.TrimPrefix(purl, "pkg:"), "?", 2)[0], "#", 2)[0]
+	ecosystemAndName, version, _ := strings.Cut(coordinates, "@")
+	segments := strings.Split(ecosystemAndName, "/")
+	if len(segments) < 2 {
+		return nil
+	}
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	if unescaped, err := url.PathUnescape(version); err == nil {
+		version = unescaped
+	}
+	ecosystem := strings.ToLower(segments[0])
+	separator := "/"
+	if ecosystem == code.ECOSYSTEM_MAVEN {
+		separator = ":"
+	}
+	return &models.GithubDependency{
+		Ecosystem:   ecosystem,
+		PackageName: strings.Join(segments[1:], separator),
+		Version:     version,
+		Purl:        purl,
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "convertEnvironments",
+	EntryPoint:       ConvertEnvironments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_environments into domain layer table cicd_environments and cicd_environment_gates",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubEnvironment{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.GithubId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	environmentIdGen := didgen.NewDomainIdGenerator(&models.GithubEnvironment{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_ENVIRONMENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubEnvironment{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			githubEnvironment := inputRow.(*models.GithubEnvironment)
+			environment := &devops.CicdEnvironment{
+				DomainEntity: domainlayer.DomainEntity{Id: environmentIdGen.Generate(data.Options.ConnectionId, githubEnvironment.GithubId)},
+				CicdScopeId:  repoIdGen.Generate(data.Options.ConnectionId, githubEnvironment.RepoId),
+				Name:         githubEnvironment.Name,
+				Type:         data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, githubEnvironment.Name),
+				Url:          githubEnvironment.Url,
+				CreatedDate:  &githubEnvironment.GithubCreatedAt,
+				UpdatedDate:  &githubEnvironment.GithubUpdatedAt,
+			}
+			results := []interface{}{environment}
+			if githubEnvironment.Reviewers != "" {
+				results = append(results, &devops.CicdEnvironmentGate{
+					EnvironmentId: environment.Id,
+					Type:          devops.GATE_REQUIRED_REVIEWERS,
+					Value:         githubEnvironment.Reviewers,
+				})
+			}
+			if githubEnvironment.WaitTimer > 0 {
+				results = append(results, &devops.CicdEnvironmentGate{
+					EnvironmentId: environment.Id,
+					Type:          devops.GATE_WAIT_TIMER,
+					Value:         strconv.Itoa(githubEnvironment.WaitTimer),
+				})
+			}
+			if githubEnvironment.BranchPolicy != "" {
+				results = append(results, &devops.CicdEnvironmentGate{
+					EnvironmentId: environment.Id,
+					Type:          devops.GATE_BRANCH_POLICY,
+					Value:         githubEnvironment.BranchPolicy,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "extractEnvironments",
+	EntryPoint:       ExtractEnvironments,
+	EnabledByDefault: true,
+	Description:      "Extract raw environment data into tool layer table github_environments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type EnvironmentResponse struct {
+	Id              int             `json:"id"`
+	Name            string          `json:"name"`
+	HtmlUrl         string          `json:"html_url"`
+	CreatedAt       api.Iso8601Time `json:"created_at"`
+	UpdatedAt       api.Iso8601Time `json:"updated_at"`
+	ProtectionRules []struct {
+		Type      string `json:"type"`
+		WaitTimer int    `json:"wait_timer"`
+		Reviewers []struct {
+			Type     string `json:"type"`
+			Reviewer struct {
+				Login string `json:"login"`
+				Slug  string `json:"slug"`
+			} `json:"reviewer"`
+		} `json:"reviewers"`
+	} `json:"protection_rules"`
+	DeploymentBranchPolicy *struct {
+		ProtectedBranches    bool `json:"protected_branches"`
+		CustomBranchPolicies bool `json:"custom_branch_policies"`
+	} `json:"deployment_branch_policy"`
+}
+
+func ExtractEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_ENVIRONMENT_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			response := &EnvironmentResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, response))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{convertGithubEnvironment(response, data.Options.ConnectionId, data.Options.GithubId)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+func convertGithubEnvironment(response *EnvironmentResponse, connectionId uint64, repoId int) *models.GithubEnvironment {
+	environment := &models.GithubEnvironment{
+		ConnectionId:    connectionId,
+		GithubId:        response.Id,
+		RepoId:          repoId,
+		Name:            response.Name,
+		Url:             response.HtmlUrl,
+		GithubCreatedAt: response.CreatedAt.ToTime(),
+		GithubUpdatedAt: response.UpdatedAt.ToTime(),
+	}
+	var reviewers []string
+	for _, rule := range response.ProtectionRules {
+		switch rule.Type {
+		case "wait_timer":
+			environment.WaitTimer = rule.WaitTimer
+		case "required_reviewers":
+			for _, reviewer := range rule.Reviewers {
+				// users have a login, teams a slug
+				if reviewer.Type == "Team" {
+					reviewers = append(reviewers, reviewer.Reviewer.Slug)
+				} else {
+					reviewers = append(reviewers, reviewer.Reviewer.Login)
+				}
+			}
+		}
+	}
+	environment.Reviewers = strings.Join(reviewers, ",")
+	if policy := response.DeploymentBranchPolicy; policy != nil {
+		if policy.ProtectedBranches {
+			environment.BranchPolicy = "protected"
+		} else if policy.CustomBranchPolicies {
+			environment.BranchPolicy = "custom"
+		}
+	}
+	return environment
+}
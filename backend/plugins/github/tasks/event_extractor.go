@@ -40,6 +40,9 @@ type IssueEvent struct {
 	Issue    struct {
 		Id int
 	}
+	Milestone *struct {
+		Title string
+	}
 	GithubCreatedAt api.Iso8601Time `json:"created_at"`
 }
 
@@ -72,6 +75,9 @@ func ExtractApiEvents(taskCtx plugin.SubTaskContext) errors.Error {
 				Type:            body.Event,
 				GithubCreatedAt: body.GithubCreatedAt.ToTime(),
 			}
+			if body.Milestone != nil {
+				githubIssueEvent.MilestoneTitle = body.Milestone.Title
+			}
 
 			if body.Actor != nil {
 				githubIssueEvent.AuthorUsername = body.Actor.Login
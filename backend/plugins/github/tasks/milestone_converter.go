@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/github/models"
 	"reflect"
+	"strings"
 )
 
 var ConvertMilestonesMeta = plugin.SubTaskMeta{
@@ -85,7 +86,7 @@ func ConvertMilestones(taskCtx plugin.SubTaskContext) errors.Error {
 				DomainEntity:    domainlayer.DomainEntity{Id: domainSprintId},
 				Name:            response.GithubMilestone.Title,
 				Url:             response.GithubMilestone.URL,
-				Status:          response.GithubMilestone.State,
+				Status:          getStdSprintStatus(response.GithubMilestone.State),
 				StartedDate:     &response.GithubMilestone.CreatedAt, //GitHub doesn't give us a "start date"
 				EndedDate:       response.GithubMilestone.ClosedAt,
 				CompletedDate:   response.GithubMilestone.ClosedAt,
@@ -108,3 +109,15 @@ func ConvertMilestones(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return converter.Execute()
 }
+
+// getStdSprintStatus maps the state of a milestone to the standard status of sprints
+func getStdSprintStatus(state string) string {
+	switch state {
+	case "open":
+		return ticket.SPRINT_STATUS_ACTIVE
+	case "closed":
+		return ticket.SPRINT_STATUS_CLOSED
+	default:
+		return strings.ToUpper(state)
+	}
+}
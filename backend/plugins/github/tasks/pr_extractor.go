@@ -52,7 +52,11 @@ type GithubApiPullRequest struct {
 	GithubCreatedAt api.Iso8601Time        `json:"created_at"`
 	GithubUpdatedAt api.Iso8601Time        `json:"updated_at"`
 	MergeCommitSha  string                 `json:"merge_commit_sha"`
-	Head            struct {
+	// the diff stats are only returned when pull requests are collected individually
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+	ChangedFiles int `json:"changed_files"`
+	Head         struct {
 		Ref  string         `json:"ref"`
 		Sha  string         `json:"sha"`
 		Repo *GithubApiRepo `json:"repo"`
@@ -179,6 +183,9 @@ func convertGithubPullRequest(pull *GithubApiPullRequest, connId uint64, repoId
 		BaseCommitSha:   pull.Base.Sha,
 		HeadRef:         pull.Head.Ref,
 		HeadCommitSha:   pull.Head.Sha,
+		Additions:       pull.Additions,
+		Deletions:       pull.Deletions,
+		ChangedFiles:    pull.ChangedFiles,
 	}
 	if pull.Head.Repo != nil {
 		githubPull.HeadRepoId = pull.Head.Repo.GithubId
@@ -72,6 +72,8 @@ func ConvertPullRequestIssues(taskCtx plugin.SubTaskContext) errors.Error {
 				IssueId:        issueIdGen.Generate(data.Options.ConnectionId, githubPrIssue.IssueId),
 				IssueKey:       githubPrIssue.IssueNumber,
 				PullRequestKey: githubPrIssue.PullRequestNumber,
+				Confidence:     1,
+				LinkRule:       PR_BODY_CLOSE_RULE,
 			}
 			return []interface{}{
 				pullRequestIssue,
@@ -24,11 +24,13 @@ import (
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/github/models"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 )
 
+// PR_BODY_CLOSE_RULE is the link rule of the issues closed by a pull request
+const PR_BODY_CLOSE_RULE = "prBodyClosePattern"
+
 var EnrichPullRequestIssuesMeta = plugin.SubTaskMeta{
 	Name:             "enrichPullRequestIssues",
 	EntryPoint:       EnrichPullRequestIssues,
@@ -42,17 +44,23 @@ func EnrichPullRequestIssues(taskCtx plugin.SubTaskContext) (err errors.Error) {
 	data := taskCtx.GetData().(*GithubTaskData)
 	repoId := data.Options.GithubId
 
-	var prBodyCloseRegex *regexp.Regexp
-	prBodyClosePattern := data.Options.PrBodyClosePattern
 	//the pattern before the issue number, sometimes, the issue number is #1098, sometimes it is https://xxx/#1098
-	prBodyClosePattern = strings.Replace(prBodyClosePattern, "%s", data.Options.Name, 1)
+	prBodyClosePattern := strings.Replace(data.Options.PrBodyClosePattern, "%s", data.Options.Name, 1)
+	var engine *api.LinkEngine
 	if len(prBodyClosePattern) > 0 {
-		prBodyCloseRegex, err = errors.Convert01(regexp.Compile(prBodyClosePattern))
+		engine, err = api.NewLinkEngine([]api.LinkRule{
+			{
+				Name:       PR_BODY_CLOSE_RULE,
+				Sources:    []string{api.LINK_SOURCE_PR_DESCRIPTION},
+				Pattern:    prBodyClosePattern,
+				KeyPattern: `(?:#|/issues/)(\d+)`,
+				Confidence: 1,
+			},
+		})
 		if err != nil {
 			return errors.Default.Wrap(err, "regexp Compile prBodyClosePattern failed")
 		}
 	}
-	charPattern := regexp.MustCompile(`[\/a-zA-Z\s,]+`)
 	cursor, err := db.Cursor(dal.From(&models.GithubPullRequest{}), dal.Where("repo_id = ? and connection_id = ?", repoId, data.Options.ConnectionId))
 	if err != nil {
 		return err
@@ -75,26 +83,14 @@ func EnrichPullRequestIssues(taskCtx plugin.SubTaskContext) (err errors.Error) {
 			githubPullRequst := inputRow.(*models.GithubPullRequest)
 			results := make([]interface{}, 0, 1)
 
-			//find the matched string in body
-			issueNumberListStr := ""
-
-			if prBodyCloseRegex != nil {
-				issueNumberListStr = prBodyCloseRegex.FindString(githubPullRequst.Body)
-			}
-
-			if issueNumberListStr == "" {
+			if engine == nil {
 				return nil, nil
 			}
-
-			issueNumberListStr = charPattern.ReplaceAllString(issueNumberListStr, "#")
-			//split the string by '#'
-			issueNumberList := strings.Split(issueNumberListStr, "#")
-
-			for _, issueNumberStr := range issueNumberList {
+			links := engine.Match(map[string]string{api.LINK_SOURCE_PR_DESCRIPTION: githubPullRequst.Body})
+			for _, link := range links {
 				issue := &models.GithubIssue{}
-				issueNumberStr = strings.TrimSpace(issueNumberStr)
-				//change the issueNumberStr to int, if cannot be changed, just continue
-				issueNumber, numFormatErr := strconv.Atoi(issueNumberStr)
+				//change the issue key to int, if cannot be changed, just continue
+				issueNumber, numFormatErr := strconv.Atoi(link.IssueKey)
 				if numFormatErr != nil {
 					continue
 				}
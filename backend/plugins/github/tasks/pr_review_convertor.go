@@ -37,6 +37,13 @@ var ConvertPullRequestReviewsMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
 }
 
+var reviewEventTypes = map[string]string{
+	"APPROVED":          code.REVIEW_APPROVED,
+	"CHANGES_REQUESTED": code.REVIEW_CHANGES_REQUESTED,
+	"COMMENTED":         code.REVIEW_COMMENTED,
+	"DISMISSED":         code.REVIEW_DISMISSED,
+}
+
 func ConvertPullRequestReviews(taskCtx plugin.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	data := taskCtx.GetData().(*GithubTaskData)
@@ -84,9 +91,23 @@ func ConvertPullRequestReviews(taskCtx plugin.SubTaskContext) errors.Error {
 			if githubPullRequestReview.GithubSubmitAt != nil {
 				domainPrReview.CreatedDate = *githubPullRequestReview.GithubSubmitAt
 			}
-			return []interface{}{
+			results := []interface{}{
 				domainPrReview,
-			}, nil
+			}
+			// pending reviews are not submitted yet, they don't count as a review event
+			if reviewEventType, ok := reviewEventTypes[githubPullRequestReview.State]; ok {
+				results = append(results, &code.PullRequestReviewEvent{
+					DomainEntity:  domainPrReview.DomainEntity,
+					PullRequestId: domainPrReview.PullRequestId,
+					Type:          reviewEventType,
+					OriginalType:  githubPullRequestReview.State,
+					ReviewerId:    domainPrReview.AccountId,
+					ActorId:       domainPrReview.AccountId,
+					ReviewId:      domainPrReview.Id,
+					CreatedDate:   domainPrReview.CreatedDate,
+				})
+			}
+			return results, nil
 		},
 	})
 	if err != nil {
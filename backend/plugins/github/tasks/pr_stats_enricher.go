@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var EnrichPullRequestStatsMeta = plugin.SubTaskMeta{
+	Name:             "enrichPullRequestStats",
+	EntryPoint:       EnrichPullRequestStats,
+	EnabledByDefault: false, // it should be executed after gitextractor collected the commits, the blueprint schedules it in the next stage
+	Description:      "Fill the diff stats and review rounds of pull_requests from their commits and comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func EnrichPullRequestStats(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	enricher, err := api.NewPullRequestStatsEnricher(api.PullRequestStatsEnricherArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_PULL_REQUEST_TABLE,
+		},
+		RepoId: didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId),
+	})
+	if err != nil {
+		return err
+	}
+	return enricher.Execute()
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractApiPullRequestStatsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequestStats",
+	EntryPoint:       ExtractApiPullRequestStats,
+	EnabledByDefault: true,
+	Description:      "Extract the diff stats of individually collected PullRequests into tool layer table github_pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ExtractApiPullRequestStats(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	db := taskCtx.GetDal()
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_PULL_REQUEST_STATS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &GithubApiPullRequest{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			if body.GithubId == 0 {
+				return nil, nil
+			}
+			// the pull request keeps the raw data origin of extractApiPullRequests, only its diff stats are updated
+			githubPr := &models.GithubPullRequest{}
+			err = db.First(githubPr, dal.Where("connection_id = ? AND github_id = ?", data.Options.ConnectionId, body.GithubId))
+			if db.IsErrorNotFound(err) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			githubPr.Additions = body.Additions
+			githubPr.Deletions = body.Deletions
+			githubPr.ChangedFiles = body.ChangedFiles
+			return []interface{}{githubPr}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertReleasesMeta = plugin.SubTaskMeta{
+	Name:             "convertReleases",
+	EntryPoint:       ConvertReleases,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_releases and github_release_assets into domain layer table cicd_releases and cicd_artifacts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+var commitShaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+type releaseWithTagCommit struct {
+	models.GithubRelease
+	// TagCommitSha is the commit of the tag as found by gitextractor, empty if the repo wasn't cloned
+	TagCommitSha string
+}
+
+func ConvertReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	repoId := repoIdGen.Generate(data.Options.ConnectionId, data.Options.GithubId)
+
+	cursor, err := db.Cursor(
+		dal.Select("gr.*, r.commit_sha AS tag_commit_sha"),
+		dal.From("_tool_github_releases gr"),
+		dal.Join("LEFT JOIN refs r ON r.repo_id = ? AND r.name = CONCAT('refs/tags/', gr.tag_name)", repoId),
+		// drafts are not released yet
+		dal.Where("gr.connection_id = ? AND gr.repo_id = ? AND gr.draft = ?", data.Options.ConnectionId, data.Options.GithubId, false),
+		dal.Orderby("gr.published_at ASC, gr.github_id ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	releaseIdGen := didgen.NewDomainIdGenerator(&models.GithubRelease{})
+	assetIdGen := didgen.NewDomainIdGenerator(&models.GithubReleaseAsset{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GithubAccount{})
+	prevReleaseId := ""
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(releaseWithTagCommit{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			githubRelease := inputRow.(*releaseWithTagCommit)
+			release := &devops.CicdRelease{
+				DomainEntity:  domainlayer.DomainEntity{Id: releaseIdGen.Generate(data.Options.ConnectionId, githubRelease.GithubId)},
+				Name:          githubRelease.Name,
+				Version:       strings.TrimPrefix(githubRelease.TagName, "v"),
+				TagName:       githubRelease.TagName,
+				Description:   githubRelease.Body,
+				Url:           githubRelease.Url,
+				IsPrerelease:  githubRelease.Prerelease,
+				CicdScopeId:   repoId,
+				RepoId:        repoId,
+				CommitSha:     githubRelease.TagCommitSha,
+				PrevReleaseId: prevReleaseId,
+				CreatedDate:   githubRelease.GithubCreatedAt,
+				PublishedDate: githubRelease.PublishedAt,
+			}
+			// the target is either a branch or a commit, a branch moves on and can't tell which commit was released
+			if release.CommitSha == "" && commitShaPattern.MatchString(githubRelease.TargetCommitish) {
+				release.CommitSha = githubRelease.TargetCommitish
+			}
+			if githubRelease.AuthorId != 0 {
+				release.AuthorId = accountIdGen.Generate(data.Options.ConnectionId, githubRelease.AuthorId)
+			}
+			prevReleaseId = release.Id
+			results := []interface{}{release}
+
+			var assets []models.GithubReleaseAsset
+			err := db.All(&assets, dal.Where("connection_id = ? AND release_id = ?", data.Options.ConnectionId, githubRelease.GithubId))
+			if err != nil {
+				return nil, err
+			}
+			for _, asset := range assets {
+				artifact := &devops.CicdArtifact{
+					DomainEntity: domainlayer.DomainEntity{Id: assetIdGen.Generate(data.Options.ConnectionId, asset.GithubId)},
+					Name:         asset.Name,
+					Type:         getArtifactType(asset.ContentType),
+					Version:      release.Version,
+					Registry:     "github",
+					Url:          asset.DownloadUrl,
+					SizeBytes:    asset.Size,
+					CicdScopeId:  repoId,
+					RepoId:       repoId,
+					CommitSha:    release.CommitSha,
+					CreatedDate:  asset.GithubCreatedAt,
+				}
+				results = append(results, artifact, &devops.CicdReleaseArtifact{
+					ReleaseId:  release.Id,
+					ArtifactId: artifact.Id,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getArtifactType tells archives from other binaries by the content type of the asset
+func getArtifactType(contentType string) string {
+	switch contentType {
+	case "application/zip", "application/gzip", "application/x-gzip", "application/x-tar", "application/x-xz", "application/x-bzip2":
+		return devops.ARTIFACT_TYPE_ARCHIVE
+	case "application/octet-stream", "application/x-executable", "application/x-msdownload":
+		return devops.ARTIFACT_TYPE_BINARY
+	default:
+		return devops.ARTIFACT_TYPE_OTHER
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var GenerateReleaseDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "generateReleaseDeployments",
+	EntryPoint:       GenerateReleaseDeployments,
+	EnabledByDefault: false, // it should be executed after gitextractor collected the tags, the blueprint schedules it in the next stage
+	Description:      "Generate cicd_deployment_commits from the releases and tags matching the releaseDeploymentPattern",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func GenerateReleaseDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	if data.Options.GithubTransformationRule == nil || data.Options.ReleaseDeploymentPattern == "" {
+		taskCtx.GetLogger().Info("releaseDeploymentPattern is not set, skip generating deployments from releases")
+		return nil
+	}
+	repo := &models.GithubRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND github_id = ?", data.Options.ConnectionId, data.Options.GithubId))
+	if err != nil {
+		return err
+	}
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+	generator, err := api.NewReleaseDeploymentGenerator(api.ReleaseDeploymentGeneratorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		CicdScopeId: repoId,
+		RepoId:      repoId,
+		RepoUrl:     repo.HTMLUrl,
+		Pattern:     data.Options.ReleaseDeploymentPattern,
+	})
+	if err != nil {
+		return err
+	}
+	return generator.Execute()
+}
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractReleasesMeta = plugin.SubTaskMeta{
+	Name:             "extractReleases",
+	EntryPoint:       ExtractReleases,
+	EnabledByDefault: true,
+	Description:      "Extract raw release data into tool layer table github_releases and github_release_assets",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type ReleaseResponse struct {
+	Id              int              `json:"id"`
+	HtmlUrl         string           `json:"html_url"`
+	TagName         string           `json:"tag_name"`
+	TargetCommitish string           `json:"target_commitish"`
+	Name            string           `json:"name"`
+	Body            string           `json:"body"`
+	Draft           bool             `json:"draft"`
+	Prerelease      bool             `json:"prerelease"`
+	CreatedAt       api.Iso8601Time  `json:"created_at"`
+	PublishedAt     *api.Iso8601Time `json:"published_at"`
+	Author          *struct {
+		Id    int    `json:"id"`
+		Login string `json:"login"`
+	} `json:"author"`
+	Assets []struct {
+		Id                 int             `json:"id"`
+		Name               string          `json:"name"`
+		ContentType        string          `json:"content_type"`
+		Size               int64           `json:"size"`
+		BrowserDownloadUrl string          `json:"browser_download_url"`
+		CreatedAt          api.Iso8601Time `json:"created_at"`
+	} `json:"assets"`
+}
+
+func ExtractReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			response := &ReleaseResponse{}
+			err := errors.Convert(json.Unmarshal(row.Data, response))
+			if err != nil {
+				return nil, err
+			}
+			release := &models.GithubRelease{
+				ConnectionId:    data.Options.ConnectionId,
+				GithubId:        response.Id,
+				RepoId:          data.Options.GithubId,
+				TagName:         response.TagName,
+				Name:            response.Name,
+				Body:            response.Body,
+				TargetCommitish: response.TargetCommitish,
+				Draft:           response.Draft,
+				Prerelease:      response.Prerelease,
+				Url:             response.HtmlUrl,
+				GithubCreatedAt: response.CreatedAt.ToTime(),
+				PublishedAt:     api.Iso8601TimeToTime(response.PublishedAt),
+			}
+			if response.Author != nil {
+				release.AuthorId = response.Author.Id
+			}
+			results := []interface{}{release}
+			for _, asset := range response.Assets {
+				results = append(results, &models.GithubReleaseAsset{
+					ConnectionId:    data.Options.ConnectionId,
+					GithubId:        asset.Id,
+					ReleaseId:       response.Id,
+					Name:            asset.Name,
+					ContentType:     asset.ContentType,
+					Size:            asset.Size,
+					DownloadUrl:     asset.BrowserDownloadUrl,
+					GithubCreatedAt: asset.CreatedAt.ToTime(),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var EnrichSprintHistoriesMeta = plugin.SubTaskMeta{
+	Name:             "enrichSprintHistories",
+	EntryPoint:       EnrichSprintHistories,
+	EnabledByDefault: true,
+	Description:      "generate sprint_issue_histories and sprint_scopes for the milestones of the repo",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type milestoneEvent struct {
+	IssueId         int
+	Type            string
+	MilestoneId     int
+	GithubCreatedAt time.Time
+}
+
+// EnrichSprintHistories treats milestones as sprints, the milestoned and demilestoned events of the issues make up
+// their histories
+func EnrichSprintHistories(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	db := taskCtx.GetDal()
+	// events only carry the title of the milestone, which is unique within a repo
+	var events []milestoneEvent
+	err := db.All(
+		&events,
+		dal.Select("e.issue_id, e.type, m.milestone_id, e.github_created_at"),
+		dal.From("_tool_github_issue_events e"),
+		dal.Join(`INNER JOIN _tool_github_issues i ON (i.connection_id = e.connection_id AND i.github_id = e.issue_id)`),
+		dal.Join(`INNER JOIN _tool_github_milestones m ON (
+			m.connection_id = e.connection_id AND m.repo_id = i.repo_id AND m.title = e.milestone_title
+		)`),
+		dal.Where(
+			"e.connection_id = ? AND i.repo_id = ? AND e.type IN ('milestoned', 'demilestoned')",
+			data.Options.ConnectionId, data.Options.GithubId,
+		),
+		dal.Orderby("e.github_created_at"),
+	)
+	if err != nil {
+		return err
+	}
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GithubIssue{})
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.GithubMilestone{})
+	changes := make([]api.SprintChange, 0, len(events))
+	for _, event := range events {
+		change := api.SprintChange{
+			IssueId:     issueIdGen.Generate(data.Options.ConnectionId, event.IssueId),
+			CreatedDate: event.GithubCreatedAt,
+		}
+		sprintId := sprintIdGen.Generate(data.Options.ConnectionId, event.MilestoneId)
+		if event.Type == "milestoned" {
+			change.ToSprintIds = sprintId
+		} else {
+			change.FromSprintIds = sprintId
+		}
+		changes = append(changes, change)
+	}
+
+	enricher, err := api.NewSprintEnricher(api.SprintEnricherArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_MILESTONE_TABLE,
+		},
+		BoardId:       didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId),
+		SprintChanges: changes,
+	})
+	if err != nil {
+		return err
+	}
+	return enricher.Execute()
+}
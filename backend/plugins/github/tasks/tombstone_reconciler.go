@@ -0,0 +1,160 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ReconcileDeletedRecordsMeta = plugin.SubTaskMeta{
+	Name:             "reconcileDeletedRecords",
+	EntryPoint:       ReconcileDeletedRecords,
+	EnabledByDefault: false,
+	Description:      "Mark repos, issues and pull requests deleted from Github with deleted_at, it lists all ids of the repo and must run after the convertors",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type simpleGithubApiIssue struct {
+	GithubId    int         `json:"id"`
+	PullRequest interface{} `json:"pull_request"`
+}
+
+func ReconcileDeletedRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	db := taskCtx.GetDal()
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+
+	res, err := data.ApiClient.Get(fmt.Sprintf("repos/%s", data.Options.Name), nil, nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		taskCtx.GetLogger().Warn(nil, "repo %s no longer exists upstream", data.Options.Name)
+		return db.UpdateColumn(code.Repo{}.TableName(), "deleted_at", time.Now(), dal.Where("id = ? AND deleted_at IS NULL", repoId))
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("unexpected status when checking repo %s", data.Options.Name))
+	}
+	err = db.UpdateColumn(code.Repo{}.TableName(), "deleted_at", nil, dal.Where("id = ? AND deleted_at IS NOT NULL", repoId))
+	if err != nil {
+		return err
+	}
+
+	// the issues endpoint returns pull requests as well, so one listing covers both
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GithubIssue{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.GithubPullRequest{})
+	var issueIds, prIds []string
+	err = listAllGithubIssues(data, func(issue *simpleGithubApiIssue) {
+		if issue.PullRequest != nil {
+			return
+		}
+		issueIds = append(issueIds, issueIdGen.Generate(data.Options.ConnectionId, issue.GithubId))
+	})
+	if err != nil {
+		return err
+	}
+	err = listAllGithubPullRequestIds(data, func(githubId int) {
+		prIds = append(prIds, prIdGen.Generate(data.Options.ConnectionId, githubId))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = api.MarkDeletedRecords(api.TombstoneArgs{
+		Ctx:     taskCtx,
+		Table:   &ticket.Issue{},
+		Clauses: []dal.Clause{dal.Where("id IN (SELECT issue_id FROM board_issues WHERE board_id = ?)", repoId)},
+		LiveIds: issueIds,
+	})
+	if err != nil {
+		return err
+	}
+	return api.MarkDeletedRecords(api.TombstoneArgs{
+		Ctx:     taskCtx,
+		Table:   &code.PullRequest{},
+		Clauses: []dal.Clause{dal.Where("base_repo_id = ?", repoId)},
+		LiveIds: prIds,
+	})
+}
+
+func listAllGithubIssues(data *GithubTaskData, handle func(issue *simpleGithubApiIssue)) errors.Error {
+	return listAllGithubPages(data, fmt.Sprintf("repos/%s/issues", data.Options.Name), func(res *http.Response) (int, errors.Error) {
+		var items []simpleGithubApiIssue
+		err := api.UnmarshalResponse(res, &items)
+		if err != nil {
+			return 0, err
+		}
+		for i := range items {
+			handle(&items[i])
+		}
+		return len(items), nil
+	})
+}
+
+func listAllGithubPullRequestIds(data *GithubTaskData, handle func(githubId int)) errors.Error {
+	return listAllGithubPages(data, fmt.Sprintf("repos/%s/pulls", data.Options.Name), func(res *http.Response) (int, errors.Error) {
+		var items []simpleGithubApiIssue
+		err := api.UnmarshalResponse(res, &items)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range items {
+			handle(item.GithubId)
+		}
+		return len(items), nil
+	})
+}
+
+// listAllGithubPages requests every page of the endpoint until a page has less items than the page size
+func listAllGithubPages(data *GithubTaskData, path string, parse func(res *http.Response) (int, errors.Error)) errors.Error {
+	const pageSize = 100
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("state", "all")
+		query.Set("page", fmt.Sprintf("%v", page))
+		query.Set("per_page", fmt.Sprintf("%v", pageSize))
+		res, err := data.ApiClient.Get(path, query, nil)
+		if err != nil {
+			return err
+		}
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("unexpected status when listing %s", path))
+		}
+		count, err := parse(res)
+		if err != nil {
+			return err
+		}
+		if count < pageSize {
+			return nil
+		}
+	}
+}
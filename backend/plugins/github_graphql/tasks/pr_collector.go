@@ -74,11 +74,14 @@ type GraphqlQueryPr struct {
 	MergeCommit *struct {
 		Oid string
 	}
-	HeadRefName string
-	HeadRefOid  string
-	BaseRefName string
-	BaseRefOid  string
-	Commits     struct {
+	HeadRefName  string
+	HeadRefOid   string
+	BaseRefName  string
+	BaseRefOid   string
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	Commits      struct {
 		PageInfo   *api.GraphqlQueryPageInfo
 		Nodes      []GraphqlQueryCommit `graphql:"nodes"`
 		TotalCount graphql.Int
@@ -333,6 +336,9 @@ func convertGithubPullRequest(pull GraphqlQueryPr, connId uint64, repoId int) (*
 		BaseCommitSha:   pull.BaseRefOid,
 		HeadRef:         pull.HeadRefName,
 		HeadCommitSha:   pull.HeadRefOid,
+		Additions:       pull.Additions,
+		Deletions:       pull.Deletions,
+		ChangedFiles:    pull.ChangedFiles,
 	}
 	if pull.MergeCommit != nil {
 		githubPull.MergeCommitSha = pull.MergeCommit.Oid
@@ -180,6 +180,24 @@ func makePipelinePlanV200(
 
 		plans = append(plans, stage)
 
+		// enrich the merge requests and generate deployments from the tags once gitextractor collected the commits and tags
+		var nextStageSubtasks []string
+		if utils.StringsContains(scope.Entities, plugin.DOMAIN_TYPE_CODE_REVIEW) {
+			nextStageSubtasks = append(nextStageSubtasks, tasks.EnrichPullRequestStatsMeta.Name)
+		}
+		if transformationRules.ReleaseDeploymentPattern != "" && utils.StringsContains(scope.Entities, plugin.DOMAIN_TYPE_CICD) {
+			nextStageSubtasks = append(nextStageSubtasks, tasks.GenerateReleaseDeploymentsMeta.Name)
+		}
+		if len(nextStageSubtasks) > 0 {
+			plans = append(plans, plugin.PipelineStage{
+				{
+					Plugin:   "gitlab",
+					Subtasks: nextStageSubtasks,
+					Options:  options,
+				},
+			})
+		}
+
 		// refdiff part
 		if transformationRules.Refdiff != nil {
 			task := &plugin.PipelineTask{
@@ -136,7 +136,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all gitlab connections
 // @Description Get all gitlab connections
 // @Tags plugins/gitlab
-// @Success 200  {object} []models.GitlabConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/gitlab/connections [GET]
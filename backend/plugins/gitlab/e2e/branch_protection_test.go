@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabBranchProtectionDataFlow(t *testing.T) {
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    12345678,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_protected_branches.csv", "_raw_gitlab_api_protected_branches")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabBranchProtection{})
+	dataflowTester.Subtask(tasks.ExtractApiBranchProtectionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabBranchProtection{},
+		"./snapshot_tables/_tool_gitlab_branch_protections.csv",
+		e2ehelper.ColumnWithRawData(
+			"gitlab_id",
+			"allow_force_push",
+			"code_owner_approval_required",
+			"push_access_level",
+			"merge_access_level",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.RepoBranchProtection{})
+	dataflowTester.Subtask(tasks.ConvertBranchProtectionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.RepoBranchProtection{},
+		"./snapshot_tables/repo_branch_protections.csv",
+		e2ehelper.ColumnWithRawData(
+			"repo_id",
+			"branch",
+			"required_approving_review_count",
+			"require_code_owner_reviews",
+			"dismiss_stale_reviews",
+			"required_status_checks",
+			"require_up_to_date_branch",
+			"enforce_admins",
+			"allow_force_pushes",
+			"allow_deletions",
+			"require_linear_history",
+			"require_signed_commits",
+			"require_conversation_resolution",
+		),
+	)
+}
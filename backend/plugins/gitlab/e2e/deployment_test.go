@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabDeploymentDataFlow(t *testing.T) {
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	regexEnricher := api.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "(?i)prod")
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    12345678,
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_environments.csv", "_raw_gitlab_api_environments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_deployments.csv", "_raw_gitlab_api_deployments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_deployment_details.csv", "_raw_gitlab_api_deployment_details")
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_gitlab_projects.csv", &models.GitlabProject{})
+
+	// verify environment extraction and conversion, the tier decides the type before the productionPattern
+	dataflowTester.FlushTabler(&models.GitlabEnvironment{})
+	dataflowTester.Subtask(tasks.ExtractApiEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabEnvironment{},
+		"./snapshot_tables/_tool_gitlab_environments.csv",
+		e2ehelper.ColumnWithRawData(
+			"project_id",
+			"name",
+			"slug",
+			"external_url",
+			"state",
+			"tier",
+			"gitlab_created_at",
+			"gitlab_updated_at",
+		),
+	)
+
+	dataflowTester.FlushTabler(&devops.CicdEnvironment{})
+	dataflowTester.Subtask(tasks.ConvertEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdEnvironment{},
+		"./snapshot_tables/cicd_environments.csv",
+		e2ehelper.ColumnWithRawData(
+			"cicd_scope_id",
+			"name",
+			"type",
+			"url",
+			"created_date",
+			"updated_date",
+		),
+	)
+
+	// verify deployment extraction
+	dataflowTester.FlushTabler(&models.GitlabDeployment{})
+	dataflowTester.FlushTabler(&models.GitlabDeploymentApproval{})
+	dataflowTester.Subtask(tasks.ExtractApiDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabDeployment{},
+		"./snapshot_tables/_tool_gitlab_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"project_id",
+			"iid",
+			"ref",
+			"sha",
+			"status",
+			"environment_id",
+			"environment_name",
+			"job_id",
+			"pipeline_id",
+			"username",
+			"gitlab_created_at",
+			"gitlab_updated_at",
+			"started_at",
+			"finished_at",
+		),
+	)
+
+	dataflowTester.Subtask(tasks.ExtractApiDeploymentDetailsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabDeploymentApproval{},
+		"./snapshot_tables/_tool_gitlab_deployment_approvals.csv",
+		e2ehelper.ColumnWithRawData(
+			"project_id",
+			"username",
+			"status",
+			"comment",
+			"gitlab_created_at",
+		),
+	)
+
+	// verify deployment conversion, the deployments run by jobs are grouped by their pipelines
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"result",
+			"status",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+			"ref_name",
+			"repo_id",
+			"repo_url",
+		),
+	)
+
+	dataflowTester.FlushTabler(&devops.CicdDeploymentApproval{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentApprovalsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentApproval{},
+		"./snapshot_tables/cicd_deployment_approvals.csv",
+		e2ehelper.ColumnWithRawData(
+			"cicd_deployment_id",
+			"environment_id",
+			"state",
+			"approver_id",
+			"approver_name",
+			"comment",
+			"requested_date",
+			"approved_date",
+			"waiting_sec",
+		),
+	)
+}
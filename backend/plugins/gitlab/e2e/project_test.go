@@ -65,7 +65,6 @@ func TestGitlabProjectDataFlow(t *testing.T) {
 			"forked_from",
 			"created_date",
 			"updated_date",
-			"deleted",
 		),
 	)
 	dataflowTester.VerifyTable(
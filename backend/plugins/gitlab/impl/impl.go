@@ -72,7 +72,11 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.GitlabConnection{},
 		&models.GitlabAccount{},
+		&models.GitlabBranchProtection{},
 		&models.GitlabCommit{},
+		&models.GitlabDeployment{},
+		&models.GitlabDeploymentApproval{},
+		&models.GitlabEnvironment{},
 		&models.GitlabIssue{},
 		&models.GitlabIssueLabel{},
 		&models.GitlabJob{},
@@ -112,6 +116,12 @@ func (p Gitlab) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiPipelineDetailsMeta,
 		tasks.CollectApiJobsMeta,
 		tasks.ExtractApiJobsMeta,
+		tasks.CollectApiEnvironmentsMeta,
+		tasks.ExtractApiEnvironmentsMeta,
+		tasks.CollectApiDeploymentsMeta,
+		tasks.ExtractApiDeploymentsMeta,
+		tasks.CollectApiDeploymentDetailsMeta,
+		tasks.ExtractApiDeploymentDetailsMeta,
 		tasks.EnrichMergeRequestsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
@@ -124,14 +134,22 @@ func (p Gitlab) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertIssueLabelsMeta,
 		tasks.ConvertMrLabelsMeta,
 		tasks.ConvertCommitsMeta,
+		tasks.EnrichPullRequestStatsMeta,
 		tasks.ConvertPipelineMeta,
 		tasks.ConvertPipelineCommitMeta,
 		tasks.ConvertJobMeta,
+		tasks.ConvertEnvironmentsMeta,
+		tasks.ConvertDeploymentsMeta,
+		tasks.ConvertDeploymentApprovalsMeta,
 		tasks.CollectApiCommitsMeta,
 		tasks.ExtractApiCommitsMeta,
 		tasks.ExtractApiMergeRequestDetailsMeta,
 		tasks.CollectTagMeta,
 		tasks.ExtractTagMeta,
+		tasks.GenerateReleaseDeploymentsMeta,
+		tasks.CollectApiBranchProtectionsMeta,
+		tasks.ExtractApiBranchProtectionsMeta,
+		tasks.ConvertBranchProtectionsMeta,
 	}
 }
 
@@ -279,6 +297,9 @@ func (p Gitlab) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"PATCH": api.UpdateTransformationRule,
 			"GET":   api.GetTransformationRule,
 		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
 		"connections/:connectionId/proxy/rest/*path": {
 			"GET": api.Proxy,
 		},
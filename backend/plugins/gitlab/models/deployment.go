@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// statuses of the deployments
+const (
+	DEPLOYMENT_STATUS_CREATED  = "created"
+	DEPLOYMENT_STATUS_RUNNING  = "running"
+	DEPLOYMENT_STATUS_SUCCESS  = "success"
+	DEPLOYMENT_STATUS_FAILED   = "failed"
+	DEPLOYMENT_STATUS_CANCELED = "canceled"
+	DEPLOYMENT_STATUS_BLOCKED  = "blocked"
+)
+
+// statuses of the deployment approvals
+const (
+	APPROVAL_STATUS_APPROVED = "approved"
+	APPROVAL_STATUS_REJECTED = "rejected"
+)
+
+type GitlabDeployment struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GitlabId        int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId       int    `gorm:"index"`
+	Iid             int
+	Ref             string `gorm:"type:varchar(255)"`
+	Sha             string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	EnvironmentId   int
+	EnvironmentName string `gorm:"type:varchar(255)"`
+	// JobId and PipelineId are the job which deployed and its pipeline, they are 0 when deployed by the api
+	JobId           int
+	PipelineId      int
+	Username        string `gorm:"type:varchar(255)"`
+	GitlabCreatedAt *time.Time
+	GitlabUpdatedAt *time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	common.NoPKModel
+}
+
+func (GitlabDeployment) TableName() string {
+	return "_tool_gitlab_deployments"
+}
+
+// GitlabDeploymentApproval is an approval or a rejection of a deployment to a protected environment
+type GitlabDeploymentApproval struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	DeploymentId    int    `gorm:"primaryKey;autoIncrement:false"`
+	UserId          int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId       int    `gorm:"index"`
+	Username        string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	Comment         string
+	GitlabCreatedAt *time.Time
+	common.NoPKModel
+}
+
+func (GitlabDeploymentApproval) TableName() string {
+	return "_tool_gitlab_deployment_approvals"
+}
@@ -35,5 +35,8 @@ func All() []plugin.MigrationScript {
 		new(addConnectionIdToTransformationRule),
 		new(addGitlabCommitAuthorInfo),
 		new(addTypeEnvToPipeline),
+		new(addBranchProtections),
+		new(addReleaseDeploymentPattern),
+		new(addDeployments),
 	}
 }
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_BRANCH_PROTECTION_TABLE = "gitlab_api_protected_branches"
+
+var CollectApiBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBranchProtections",
+	EntryPoint:       CollectApiBranchProtections,
+	EnabledByDefault: false,
+	Description:      "Collect protected branch data from gitlab api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func CollectApiBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_PROTECTION_TABLE)
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        false,
+		UrlTemplate:        "projects/{{ .Params.ProjectId }}/protected_branches",
+		Query:              GetQuery,
+		GetTotalPages:      GetTotalPagesFromResponse,
+		ResponseParser:     GetRawMessageFromResponse,
+		AfterResponse:      ignoreHTTPStatus403,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "convertBranchProtections",
+	EntryPoint:       ConvertBranchProtections,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table _tool_gitlab_branch_protections into domain layer table repo_branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_PROTECTION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabBranchProtection{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	protectionIdGen := didgen.NewDomainIdGenerator(&models.GitlabBranchProtection{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GitlabProject{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GitlabBranchProtection{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			protection := inputRow.(*models.GitlabBranchProtection)
+			return []interface{}{
+				&code.RepoBranchProtection{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: protectionIdGen.Generate(protection.ConnectionId, protection.ProjectId, protection.Name),
+					},
+					RepoId:                  projectIdGen.Generate(protection.ConnectionId, protection.ProjectId),
+					Branch:                  protection.Name,
+					RequireCodeOwnerReviews: protection.CodeOwnerApprovalRequired,
+					AllowForcePushes:        protection.AllowForcePush,
+					// gitlab doesn't allow deleting protected branches by pushing
+					AllowDeletions: false,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
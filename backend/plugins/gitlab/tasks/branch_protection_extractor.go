@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+type gitlabAccessLevel struct {
+	AccessLevel int `json:"access_level"`
+}
+
+type GitlabApiBranchProtection struct {
+	Id                        int                 `json:"id"`
+	Name                      string              `json:"name"`
+	PushAccessLevels          []gitlabAccessLevel `json:"push_access_levels"`
+	MergeAccessLevels         []gitlabAccessLevel `json:"merge_access_levels"`
+	AllowForcePush            bool                `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool                `json:"code_owner_approval_required"`
+}
+
+var ExtractApiBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBranchProtections",
+	EntryPoint:       ExtractApiBranchProtections,
+	EnabledByDefault: false,
+	Description:      "Extract raw protected branch data into tool layer table _tool_gitlab_branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ExtractApiBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_PROTECTION_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			protection := &GitlabApiBranchProtection{}
+			err := errors.Convert(json.Unmarshal(row.Data, protection))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				convertBranchProtection(protection, data.Options.ConnectionId, data.Options.ProjectId),
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+func convertBranchProtection(protection *GitlabApiBranchProtection, connectionId uint64, projectId int) *models.GitlabBranchProtection {
+	return &models.GitlabBranchProtection{
+		ConnectionId:              connectionId,
+		ProjectId:                 projectId,
+		Name:                      protection.Name,
+		GitlabId:                  protection.Id,
+		AllowForcePush:            protection.AllowForcePush,
+		CodeOwnerApprovalRequired: protection.CodeOwnerApprovalRequired,
+		PushAccessLevel:           lowestAccessLevel(protection.PushAccessLevels),
+		MergeAccessLevel:          lowestAccessLevel(protection.MergeAccessLevels),
+	}
+}
+
+// lowestAccessLevel returns the least privileged role granted by the rules, users and groups granted access are
+// not taken into account
+func lowestAccessLevel(levels []gitlabAccessLevel) int {
+	lowest := 0
+	for _, level := range levels {
+		if level.AccessLevel > 0 && (lowest == 0 || level.AccessLevel < lowest) {
+			lowest = level.AccessLevel
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertDeploymentApprovalsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeploymentApprovals",
+	EntryPoint:       ConvertDeploymentApprovals,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_gitlab_deployment_approvals into domain layer table cicd_deployment_approvals",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type gitlabDeploymentApprovalWithDeployment struct {
+	models.GitlabDeploymentApproval
+	EnvironmentId       int
+	DeploymentCreatedAt *time.Time
+}
+
+func ConvertDeploymentApprovals(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_DETAILS_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("a.*, d.environment_id, d.gitlab_created_at as deployment_created_at"),
+		dal.From("_tool_gitlab_deployment_approvals a"),
+		dal.Join("LEFT JOIN _tool_gitlab_deployments d ON d.connection_id = a.connection_id AND d.gitlab_id = a.deployment_id"),
+		dal.Where("a.project_id = ? and a.connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	approvalIdGen := didgen.NewDomainIdGenerator(&models.GitlabDeploymentApproval{})
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.GitlabDeployment{})
+	environmentIdGen := didgen.NewDomainIdGenerator(&models.GitlabEnvironment{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GitlabAccount{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(gitlabDeploymentApprovalWithDeployment{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			approval := inputRow.(*gitlabDeploymentApprovalWithDeployment)
+			deploymentId := deploymentIdGen.Generate(approval.ConnectionId, approval.DeploymentId)
+			domainApproval := &devops.CicdDeploymentApproval{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: approvalIdGen.Generate(approval.ConnectionId, approval.DeploymentId, approval.UserId),
+				},
+				CicdDeploymentId: deploymentId,
+				EnvironmentId:    environmentIdGen.Generate(approval.ConnectionId, approval.EnvironmentId),
+				ApproverId:       accountIdGen.Generate(approval.ConnectionId, approval.UserId),
+				ApproverName:     approval.Username,
+				Comment:          approval.Comment,
+				RequestedDate:    approval.DeploymentCreatedAt,
+				ApprovedDate:     approval.GitlabCreatedAt,
+			}
+			switch approval.Status {
+			case models.APPROVAL_STATUS_APPROVED:
+				domainApproval.State = devops.APPROVAL_APPROVED
+			case models.APPROVAL_STATUS_REJECTED:
+				domainApproval.State = devops.APPROVAL_REJECTED
+			default:
+				return nil, nil
+			}
+			if approval.DeploymentCreatedAt != nil && approval.GitlabCreatedAt != nil {
+				waitingSec := uint64(approval.GitlabCreatedAt.Sub(*approval.DeploymentCreatedAt).Seconds())
+				domainApproval.WaitingSec = &waitingSec
+			}
+			return []interface{}{domainApproval}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
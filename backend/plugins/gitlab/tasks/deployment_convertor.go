@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_gitlab_deployments into domain layer table cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type gitlabDeploymentWithTier struct {
+	models.GitlabDeployment
+	Tier string
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	project := &models.GitlabProject{}
+	err := db.First(project, dal.Where("gitlab_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("d.*, e.tier"),
+		dal.From("_tool_gitlab_deployments d"),
+		dal.Join("LEFT JOIN _tool_gitlab_environments e ON e.connection_id = d.connection_id AND e.gitlab_id = d.environment_id"),
+		dal.Where("d.project_id = ? and d.connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.GitlabDeployment{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.GitlabPipeline{})
+	projectId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(gitlabDeploymentWithTier{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*gitlabDeploymentWithTier)
+			if deployment.GitlabCreatedAt == nil || deployment.Sha == "" {
+				return nil, nil
+			}
+			id := deploymentIdGen.Generate(deployment.ConnectionId, deployment.GitlabId)
+			deploymentCommit := &devops.CicdDeploymentCommit{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: id,
+				},
+				CicdScopeId:      projectId,
+				CicdDeploymentId: id,
+				Name:             deployment.EnvironmentName,
+				Result: devops.GetResult(&devops.ResultRule{
+					Success: []string{models.DEPLOYMENT_STATUS_SUCCESS},
+					Failed:  []string{models.DEPLOYMENT_STATUS_FAILED},
+					Abort:   []string{models.DEPLOYMENT_STATUS_CANCELED},
+					Default: "",
+				}, deployment.Status),
+				Status: devops.GetStatus(&devops.StatusRule{
+					InProgress: []string{models.DEPLOYMENT_STATUS_CREATED, models.DEPLOYMENT_STATUS_RUNNING, models.DEPLOYMENT_STATUS_BLOCKED},
+					Default:    devops.DONE,
+				}, deployment.Status),
+				Environment:  getEnvironmentType(data.RegexEnricher, deployment.Tier, deployment.EnvironmentName),
+				CreatedDate:  *deployment.GitlabCreatedAt,
+				StartedDate:  deployment.StartedAt,
+				FinishedDate: deployment.FinishedAt,
+				CommitSha:    deployment.Sha,
+				RefName:      deployment.Ref,
+				RepoId:       projectId,
+				RepoUrl:      project.WebUrl,
+			}
+			// deployments run by a job are grouped by the pipeline, like the ones inferred from the jobs
+			if deployment.PipelineId != 0 {
+				deploymentCommit.CicdDeploymentId = pipelineIdGen.Generate(deployment.ConnectionId, deployment.PipelineId)
+			}
+			if deployment.StartedAt != nil && deployment.FinishedAt != nil {
+				duration := uint64(deployment.FinishedAt.Sub(*deployment.StartedAt).Seconds())
+				deploymentCommit.DurationSec = &duration
+			}
+			return []interface{}{deploymentCommit}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ExtractApiDeploymentDetailsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiDeploymentDetails",
+	EntryPoint:       ExtractApiDeploymentDetails,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployment details into tool layer table _tool_gitlab_deployments and _tool_gitlab_deployment_approvals",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiDeploymentDetails(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_DETAILS_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			deployment := &GitlabApiDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, deployment))
+			if err != nil {
+				return nil, err
+			}
+			results := []interface{}{
+				convertDeployment(deployment, data.Options.ConnectionId, data.Options.ProjectId),
+			}
+			for _, approval := range deployment.Approvals {
+				if approval.User == nil {
+					continue
+				}
+				results = append(results, &models.GitlabDeploymentApproval{
+					ConnectionId:    data.Options.ConnectionId,
+					DeploymentId:    deployment.Id,
+					UserId:          approval.User.Id,
+					ProjectId:       data.Options.ProjectId,
+					Username:        approval.User.Username,
+					Status:          approval.Status,
+					Comment:         approval.Comment,
+					GitlabCreatedAt: api.Iso8601TimeToTime(approval.CreatedAt),
+				})
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
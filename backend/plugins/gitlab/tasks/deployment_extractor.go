@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+type GitlabApiDeployment struct {
+	Id          int              `json:"id"`
+	Iid         int              `json:"iid"`
+	Ref         string           `json:"ref"`
+	Sha         string           `json:"sha"`
+	Status      string           `json:"status"`
+	CreatedAt   *api.Iso8601Time `json:"created_at"`
+	UpdatedAt   *api.Iso8601Time `json:"updated_at"`
+	User        *gitlabApiUser   `json:"user"`
+	Environment struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"environment"`
+	// Deployable is the job which deployed, it is null when the deployment was created by the api
+	Deployable *struct {
+		Id         int              `json:"id"`
+		StartedAt  *api.Iso8601Time `json:"started_at"`
+		FinishedAt *api.Iso8601Time `json:"finished_at"`
+		Pipeline   struct {
+			Id int `json:"id"`
+		} `json:"pipeline"`
+	} `json:"deployable"`
+	Approvals []struct {
+		User      *gitlabApiUser   `json:"user"`
+		Status    string           `json:"status"`
+		Comment   string           `json:"comment"`
+		CreatedAt *api.Iso8601Time `json:"created_at"`
+	} `json:"approvals"`
+}
+
+type gitlabApiUser struct {
+	Id       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+var ExtractApiDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiDeployments",
+	EntryPoint:       ExtractApiDeployments,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployment data into tool layer table _tool_gitlab_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			deployment := &GitlabApiDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, deployment))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				convertDeployment(deployment, data.Options.ConnectionId, data.Options.ProjectId),
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+func convertDeployment(deployment *GitlabApiDeployment, connectionId uint64, projectId int) *models.GitlabDeployment {
+	gitlabDeployment := &models.GitlabDeployment{
+		ConnectionId:    connectionId,
+		GitlabId:        deployment.Id,
+		ProjectId:       projectId,
+		Iid:             deployment.Iid,
+		Ref:             deployment.Ref,
+		Sha:             deployment.Sha,
+		Status:          deployment.Status,
+		EnvironmentId:   deployment.Environment.Id,
+		EnvironmentName: deployment.Environment.Name,
+		GitlabCreatedAt: api.Iso8601TimeToTime(deployment.CreatedAt),
+		GitlabUpdatedAt: api.Iso8601TimeToTime(deployment.UpdatedAt),
+	}
+	if deployment.User != nil {
+		gitlabDeployment.Username = deployment.User.Username
+	}
+	if deployment.Deployable != nil {
+		gitlabDeployment.JobId = deployment.Deployable.Id
+		gitlabDeployment.PipelineId = deployment.Deployable.Pipeline.Id
+		gitlabDeployment.StartedAt = api.Iso8601TimeToTime(deployment.Deployable.StartedAt)
+		gitlabDeployment.FinishedAt = api.Iso8601TimeToTime(deployment.Deployable.FinishedAt)
+	}
+	return gitlabDeployment
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "convertEnvironments",
+	EntryPoint:       ConvertEnvironments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_gitlab_environments into domain layer table cicd_environments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ENVIRONMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabEnvironment{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	environmentIdGen := didgen.NewDomainIdGenerator(&models.GitlabEnvironment{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GitlabProject{})
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GitlabEnvironment{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			environment := inputRow.(*models.GitlabEnvironment)
+			return []interface{}{
+				&devops.CicdEnvironment{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: environmentIdGen.Generate(environment.ConnectionId, environment.GitlabId),
+					},
+					CicdScopeId: projectIdGen.Generate(environment.ConnectionId, environment.ProjectId),
+					Name:        environment.Name,
+					Type:        getEnvironmentType(data.RegexEnricher, environment.Tier, environment.Name),
+					Url:         environment.ExternalUrl,
+					CreatedDate: environment.GitlabCreatedAt,
+					UpdatedDate: environment.GitlabUpdatedAt,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getEnvironmentType maps the deployment tier of a gitlab environment to the domain environment types, the
+// productionPattern of the transformation rule is used for the environments without a known tier
+func getEnvironmentType(regexEnricher *helper.RegexEnricher, tier string, name string) string {
+	switch tier {
+	case "production":
+		return devops.PRODUCTION
+	case "staging":
+		return devops.STAGING
+	case "testing":
+		return devops.TESTING
+	}
+	return regexEnricher.ReturnNameIfMatched(devops.PRODUCTION, name)
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+type GitlabApiEnvironment struct {
+	Id          int              `json:"id"`
+	Name        string           `json:"name"`
+	Slug        string           `json:"slug"`
+	ExternalUrl string           `json:"external_url"`
+	State       string           `json:"state"`
+	Tier        string           `json:"tier"`
+	CreatedAt   *api.Iso8601Time `json:"created_at"`
+	UpdatedAt   *api.Iso8601Time `json:"updated_at"`
+}
+
+var ExtractApiEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiEnvironments",
+	EntryPoint:       ExtractApiEnvironments,
+	EnabledByDefault: true,
+	Description:      "Extract raw environment data into tool layer table _tool_gitlab_environments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ENVIRONMENT_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			environment := &GitlabApiEnvironment{}
+			err := errors.Convert(json.Unmarshal(row.Data, environment))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.GitlabEnvironment{
+					ConnectionId:    data.Options.ConnectionId,
+					GitlabId:        environment.Id,
+					ProjectId:       data.Options.ProjectId,
+					Name:            environment.Name,
+					Slug:            environment.Slug,
+					ExternalUrl:     environment.ExternalUrl,
+					State:           environment.State,
+					Tier:            environment.Tier,
+					GitlabCreatedAt: api.Iso8601TimeToTime(environment.CreatedAt),
+					GitlabUpdatedAt: api.Iso8601TimeToTime(environment.UpdatedAt),
+				},
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -85,6 +85,7 @@ func ConvertApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
 				HeadRef:        gitlabMr.SourceBranch,
 				BaseRef:        gitlabMr.TargetBranch,
 				Component:      gitlabMr.Component,
+				ReviewRounds:   gitlabMr.ReviewRounds,
 			}
 			switch gitlabMr.State {
 			case "opened":
@@ -127,33 +127,13 @@ func findEarliestNote(notes []models.GitlabMrNote) (*models.GitlabMrNote, errors
 }
 
 func getReviewRounds(commits []models.GitlabCommit, notes []models.GitlabMrNote) int {
-	i := 0
-	j := 0
-	reviewRounds := 0
-	if len(commits) == 0 && len(notes) == 0 {
-		return 1
+	commitDates := make([]time.Time, 0, len(commits))
+	for _, commit := range commits {
+		commitDates = append(commitDates, commit.AuthoredDate)
 	}
-	// state is used to keep track of previous activity
-	// 0: init, 1: commit, 2: comment
-	// whenever state is switched to comment, we increment reviewRounds by 1
-	state := 0 // 0, 1, 2
-	for i < len(commits) && j < len(notes) {
-		if commits[i].AuthoredDate.Before(notes[j].GitlabCreatedAt) {
-			i++
-			state = 1
-		} else {
-			j++
-			if state != 2 {
-				reviewRounds++
-			}
-			state = 2
-		}
-	}
-	// There's another implicit round of review in 2 scenarios
-	// One: the last state is commit (state == 1)
-	// Two: the last state is comment but there're still commits left
-	if state == 1 || i < len(commits) {
-		reviewRounds++
+	noteDates := make([]time.Time, 0, len(notes))
+	for _, note := range notes {
+		noteDates = append(noteDates, note.GitlabCreatedAt)
 	}
-	return reviewRounds
+	return helper.CountReviewRounds(commitDates, noteDates)
 }
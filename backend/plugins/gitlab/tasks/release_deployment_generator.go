@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var GenerateReleaseDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "generateReleaseDeployments",
+	EntryPoint:       GenerateReleaseDeployments,
+	EnabledByDefault: false, // it should be executed after gitextractor collected the tags, the blueprint schedules it in the next stage
+	Description:      "Generate cicd_deployment_commits from the tags matching the releaseDeploymentPattern",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func GenerateReleaseDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+	if data.Options.GitlabTransformationRule == nil || data.Options.ReleaseDeploymentPattern == "" {
+		taskCtx.GetLogger().Info("releaseDeploymentPattern is not set, skip generating deployments from tags")
+		return nil
+	}
+	project := &models.GitlabProject{}
+	err := db.First(project, dal.Where("connection_id = ? AND gitlab_id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return err
+	}
+	projectId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	generator, err := helper.NewReleaseDeploymentGenerator(helper.ReleaseDeploymentGeneratorArgs{
+		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GitlabApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ProjectId:    data.Options.ProjectId,
+			},
+			Table: RAW_TAG_TABLE,
+		},
+		CicdScopeId: projectId,
+		RepoId:      projectId,
+		RepoUrl:     project.WebUrl,
+		Pattern:     data.Options.ReleaseDeploymentPattern,
+	})
+	if err != nil {
+		return err
+	}
+	return generator.Execute()
+}
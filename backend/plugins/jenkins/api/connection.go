@@ -133,7 +133,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all jenkins connections
 // @Description Get all Jenkins connections
 // @Tags plugins/jenkins
-// @Success 200  {object} []models.JenkinsConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/jenkins/connections [GET]
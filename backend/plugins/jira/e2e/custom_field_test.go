@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jira/impl"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+func TestCustomFieldDataFlow(t *testing.T) {
+	var plugin impl.Jira
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jira", plugin)
+
+	taskData := &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      8,
+			TransformationRules: &tasks.JiraTransformationRule{
+				CustomFieldMappings: tasks.CustomFieldMappings{
+					"customfield_10015": "start_date",
+					"customfield_10020": "sprints",
+					// the field isn't set on any issue
+					"customfield_10017": "team",
+				},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jira_api_issues.csv", "_raw_jira_api_issues")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.JiraIssue{})
+	dataflowTester.FlushTabler(&models.JiraBoardIssue{})
+	dataflowTester.FlushTabler(&models.JiraSprintIssue{})
+	dataflowTester.FlushTabler(&models.JiraIssueComment{})
+	dataflowTester.FlushTabler(&models.JiraIssueChangelogs{})
+	dataflowTester.FlushTabler(&models.JiraIssueChangelogItems{})
+	dataflowTester.FlushTabler(&models.JiraWorklog{})
+	dataflowTester.FlushTabler(&models.JiraAccount{})
+	dataflowTester.FlushTabler(&models.JiraIssueType{})
+	dataflowTester.FlushTabler(&models.JiraIssueLabel{})
+	dataflowTester.FlushTabler(&models.JiraIssueCustomField{})
+	dataflowTester.Subtask(tasks.ExtractIssuesMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		models.JiraIssueCustomField{},
+		"./snapshot_tables/_tool_jira_issue_custom_fields.csv",
+		[]string{
+			"connection_id",
+			"issue_id",
+			"field_id",
+			"field_name",
+			"value",
+		})
+
+	// verify conversion, the values are typed
+	dataflowTester.FlushTabler(&crossdomain.CustomField{})
+	dataflowTester.Subtask(tasks.ConvertIssueCustomFieldsMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		crossdomain.CustomField{},
+		"./snapshot_tables/custom_fields.csv",
+		[]string{
+			"entity_type",
+			"entity_id",
+			"field_name",
+			"field_type",
+			"string_value",
+			"number_value",
+			"date_value",
+		})
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jira/impl"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+func TestServiceRequestDataFlow(t *testing.T) {
+	var plugin impl.Jira
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jira", plugin)
+
+	taskData := &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      8,
+			TransformationRules: &tasks.JiraTransformationRule{
+				IncidentRequestTypes: []string{"Report a system problem"},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jira_api_service_requests.csv", "_raw_jira_api_service_requests")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issues.csv", &models.JiraIssue{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_board_issues.csv", &models.JiraBoardIssue{})
+
+	// verify extraction, every cycle of the sla clocks is kept
+	dataflowTester.FlushTabler(&models.JiraServiceRequest{})
+	dataflowTester.FlushTabler(&models.JiraIssueSla{})
+	dataflowTester.Subtask(tasks.ExtractServiceRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		models.JiraServiceRequest{},
+		"./snapshot_tables/_tool_jira_service_requests.csv",
+		[]string{
+			"connection_id",
+			"issue_id",
+			"issue_key",
+			"service_desk_id",
+			"request_type_id",
+			"request_type_name",
+			"current_status",
+			"issue_updated",
+		})
+	dataflowTester.VerifyTableWithRawData(
+		models.JiraIssueSla{},
+		"./snapshot_tables/_tool_jira_issue_slas.csv",
+		[]string{
+			"connection_id",
+			"issue_id",
+			"sla_id",
+			"cycle",
+			"name",
+			"ongoing",
+			"paused",
+			"breached",
+			"goal_millis",
+			"elapsed_millis",
+			"remaining_millis",
+			"start_time",
+			"stop_time",
+			"breach_time",
+		})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.IssueSla{})
+	dataflowTester.Subtask(tasks.ConvertIssueSlasMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		ticket.IssueSla{},
+		"./snapshot_tables/issue_slas.csv",
+		[]string{
+			"id",
+			"issue_id",
+			"name",
+			"state",
+			"breached",
+			"goal_minutes",
+			"elapsed_minutes",
+			"remaining_minutes",
+			"start_date",
+			"stop_date",
+			"breach_date",
+		})
+
+	// verify the requests of the incident request types are converted as incidents
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertIssuesMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues_for_service_requests.csv",
+		[]string{
+			"id",
+			"type",
+			"original_type",
+		})
+}
@@ -54,6 +54,8 @@ func TestWorklogDataFlow(t *testing.T) {
 			"update_author_id",
 			"time_spent",
 			"time_spent_seconds",
+			"comment",
+			"created",
 			"updated",
 			"started",
 			"issue_updated",
@@ -79,10 +79,13 @@ func (p Jira) GetTablesInfo() []dal.Tabler {
 		&models.JiraIssueChangelogs{},
 		&models.JiraIssueCommit{},
 		&models.JiraIssueLabel{},
+		&models.JiraIssueCustomField{},
+		&models.JiraIssueSla{},
 		&models.JiraIssueType{},
 		&models.JiraProject{},
 		&models.JiraRemotelink{},
 		&models.JiraServerInfo{},
+		&models.JiraServiceRequest{},
 		&models.JiraSprint{},
 		&models.JiraSprintIssue{},
 		&models.JiraStatus{},
@@ -109,6 +112,7 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractIssuesMeta,
 
 		tasks.ConvertIssueLabelsMeta,
+		tasks.ConvertIssueCustomFieldsMeta,
 
 		tasks.CollectIssueCommentsMeta,
 		tasks.ExtractIssueCommentsMeta,
@@ -124,6 +128,9 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectRemotelinksMeta,
 		tasks.ExtractRemotelinksMeta,
 
+		tasks.CollectServiceRequestsMeta,
+		tasks.ExtractServiceRequestsMeta,
+
 		tasks.CollectSprintsMeta,
 		tasks.ExtractSprintsMeta,
 
@@ -133,9 +140,11 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertIssueCommentsMeta,
 		tasks.ConvertWorklogsMeta,
 		tasks.ConvertIssueChangelogsMeta,
+		tasks.ConvertIssueSlasMeta,
 
 		tasks.ConvertSprintsMeta,
 		tasks.ConvertSprintIssuesMeta,
+		tasks.EnrichSprintHistoriesMeta,
 
 		tasks.ConvertIssueCommitsMeta,
 		tasks.ConvertIssueRepoCommitsMeta,
@@ -271,9 +280,7 @@ func (p Jira) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"POST": api.TestConnection,
 		},
 		"echo": {
-			"POST": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-				return &plugin.ApiResourceOutput{Body: input.Body}, nil
-			},
+			"POST": api.Echo,
 		},
 		"connections": {
 			"POST": api.PostConnections,
@@ -303,6 +310,9 @@ func (p Jira) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"PATCH": api.UpdateTransformationRule,
 			"GET":   api.GetTransformationRule,
 		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
 	}
 }
 
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/jira/models/migrationscripts/archived"
+)
+
+var _ plugin.MigrationScript = (*addIssueCustomFields)(nil)
+
+type jiraTransformationRule20230612 struct {
+	CustomFieldMappings json.RawMessage
+}
+
+func (jiraTransformationRule20230612) TableName() string {
+	return "_tool_jira_transformation_rules"
+}
+
+type addIssueCustomFields struct{}
+
+func (*addIssueCustomFields) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&jiraTransformationRule20230612{},
+		&archived.JiraIssueCustomField{},
+	)
+}
+
+func (*addIssueCustomFields) Version() uint64 {
+	return 20230612120000
+}
+
+func (*addIssueCustomFields) Name() string {
+	return "add custom_field_mappings to _tool_jira_transformation_rules and add _tool_jira_issue_custom_fields"
+}
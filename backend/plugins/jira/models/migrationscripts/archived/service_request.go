@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type JiraServiceRequest struct {
+	ConnectionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueId         uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueKey        string `gorm:"type:varchar(255)"`
+	ServiceDeskId   string `gorm:"type:varchar(100)"`
+	RequestTypeId   string `gorm:"type:varchar(100)"`
+	RequestTypeName string `gorm:"type:varchar(255)"`
+	CurrentStatus   string `gorm:"type:varchar(255)"`
+	IssueUpdated    *time.Time
+	archived.NoPKModel
+}
+
+func (JiraServiceRequest) TableName() string {
+	return "_tool_jira_service_requests"
+}
+
+type JiraIssueSla struct {
+	ConnectionId    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	IssueId         uint64 `gorm:"primaryKey;autoIncrement:false"`
+	SlaId           string `gorm:"primaryKey;type:varchar(100)"`
+	Cycle           int    `gorm:"primaryKey;autoIncrement:false"`
+	Name            string `gorm:"type:varchar(255)"`
+	Ongoing         bool
+	Paused          bool
+	Breached        bool
+	GoalMillis      *int64
+	ElapsedMillis   int64
+	RemainingMillis *int64
+	StartTime       *time.Time
+	StopTime        *time.Time
+	BreachTime      *time.Time
+	archived.NoPKModel
+}
+
+func (JiraIssueSla) TableName() string {
+	return "_tool_jira_issue_slas"
+}
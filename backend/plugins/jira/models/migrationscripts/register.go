@@ -37,5 +37,8 @@ func All() []plugin.MigrationScript {
 		new(addChangeTotal20230412),
 		new(expandRemotelinkSelfUrl),
 		new(addDescAndComments),
+		new(addWorklogComment),
+		new(addIssueCustomFields),
+		new(addServiceRequests),
 	}
 }
@@ -34,6 +34,8 @@ type JiraTransformationRule struct {
 	RemotelinkCommitShaPattern string          `mapstructure:"remotelinkCommitShaPattern,omitempty" json:"remotelinkCommitShaPattern" gorm:"type:varchar(255)"`
 	RemotelinkRepoPattern      json.RawMessage `mapstructure:"remotelinkRepoPattern,omitempty" json:"remotelinkRepoPattern"`
 	TypeMappings               json.RawMessage `mapstructure:"typeMappings,omitempty" json:"typeMappings"`
+	CustomFieldMappings        json.RawMessage `mapstructure:"customFieldMappings,omitempty" json:"customFieldMappings"`
+	IncidentRequestTypes       json.RawMessage `mapstructure:"incidentRequestTypes,omitempty" json:"incidentRequestTypes"`
 }
 
 func (r JiraTransformationRule) TableName() string {
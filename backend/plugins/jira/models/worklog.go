@@ -31,6 +31,8 @@ type JiraWorklog struct {
 	UpdateAuthorId   string `gorm:"type:varchar(255)"`
 	TimeSpent        string `gorm:"type:varchar(255)"`
 	TimeSpentSeconds int
+	Comment          string
+	Created          *time.Time
 	Updated          time.Time
 	Started          time.Time
 	IssueUpdated     *time.Time
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+// ServiceRequest is a request of Jira Service Management expanded with its request type and sla clocks
+type ServiceRequest struct {
+	IssueId       uint64 `json:"issueId,string"`
+	IssueKey      string `json:"issueKey"`
+	RequestTypeId string `json:"requestTypeId"`
+	ServiceDeskId string `json:"serviceDeskId"`
+	CurrentStatus struct {
+		Status string `json:"status"`
+	} `json:"currentStatus"`
+	RequestType *struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"requestType"`
+	Sla *struct {
+		Values []SlaClock `json:"values"`
+	} `json:"sla"`
+}
+
+type SlaClock struct {
+	Id              string     `json:"id"`
+	Name            string     `json:"name"`
+	CompletedCycles []SlaCycle `json:"completedCycles"`
+	OngoingCycle    *SlaCycle  `json:"ongoingCycle"`
+}
+
+type SlaCycle struct {
+	StartTime     *SlaDate     `json:"startTime"`
+	StopTime      *SlaDate     `json:"stopTime"`
+	BreachTime    *SlaDate     `json:"breachTime"`
+	Breached      bool         `json:"breached"`
+	Paused        bool         `json:"paused"`
+	GoalDuration  *SlaDuration `json:"goalDuration"`
+	ElapsedTime   *SlaDuration `json:"elapsedTime"`
+	RemainingTime *SlaDuration `json:"remainingTime"`
+}
+
+type SlaDate struct {
+	EpochMillis int64 `json:"epochMillis"`
+}
+
+func (d *SlaDate) ToTime() *time.Time {
+	if d == nil {
+		return nil
+	}
+	t := time.UnixMilli(d.EpochMillis).UTC()
+	return &t
+}
+
+type SlaDuration struct {
+	Millis int64 `json:"millis"`
+}
+
+func (d *SlaDuration) ToMillis() *int64 {
+	if d == nil {
+		return nil
+	}
+	millis := d.Millis
+	return &millis
+}
+
+func (r ServiceRequest) ToToolLayer(connectionId uint64, issueUpdated *time.Time) (*models.JiraServiceRequest, []*models.JiraIssueSla) {
+	request := &models.JiraServiceRequest{
+		ConnectionId:  connectionId,
+		IssueId:       r.IssueId,
+		IssueKey:      r.IssueKey,
+		ServiceDeskId: r.ServiceDeskId,
+		RequestTypeId: r.RequestTypeId,
+		CurrentStatus: r.CurrentStatus.Status,
+		IssueUpdated:  issueUpdated,
+	}
+	if r.RequestType != nil {
+		request.RequestTypeName = r.RequestType.Name
+	}
+	var slas []*models.JiraIssueSla
+	if r.Sla == nil {
+		return request, slas
+	}
+	for _, clock := range r.Sla.Values {
+		for i := range clock.CompletedCycles {
+			slas = append(slas, clock.toToolLayer(connectionId, r.IssueId, i, &clock.CompletedCycles[i], false))
+		}
+		if clock.OngoingCycle != nil {
+			slas = append(slas, clock.toToolLayer(connectionId, r.IssueId, len(clock.CompletedCycles), clock.OngoingCycle, true))
+		}
+	}
+	return request, slas
+}
+
+func (c SlaClock) toToolLayer(connectionId, issueId uint64, index int, cycle *SlaCycle, ongoing bool) *models.JiraIssueSla {
+	sla := &models.JiraIssueSla{
+		ConnectionId:    connectionId,
+		IssueId:         issueId,
+		SlaId:           c.Id,
+		Cycle:           index,
+		Name:            c.Name,
+		Ongoing:         ongoing,
+		Paused:          cycle.Paused,
+		Breached:        cycle.Breached,
+		GoalMillis:      cycle.GoalDuration.ToMillis(),
+		RemainingMillis: cycle.RemainingTime.ToMillis(),
+		StartTime:       cycle.StartTime.ToTime(),
+		StopTime:        cycle.StopTime.ToTime(),
+		BreachTime:      cycle.BreachTime.ToTime(),
+	}
+	if cycle.ElapsedTime != nil {
+		sla.ElapsedMillis = cycle.ElapsedTime.Millis
+	}
+	return sla
+}
@@ -24,17 +24,17 @@ import (
 )
 
 type Worklog struct {
-	Self             string             `json:"self"`
-	Author           *Account           `json:"author"`
-	UpdateAuthor     *Account           `json:"updateAuthor"`
-	Comment          string             `json:"comment"`
-	Created          string             `json:"created"`
-	Updated          helper.Iso8601Time `json:"updated"`
-	Started          helper.Iso8601Time `json:"started"`
-	TimeSpent        string             `json:"timeSpent"`
-	TimeSpentSeconds int                `json:"timeSpentSeconds"`
-	ID               string             `json:"id"`
-	IssueID          uint64             `json:"issueId,string"`
+	Self             string              `json:"self"`
+	Author           *Account            `json:"author"`
+	UpdateAuthor     *Account            `json:"updateAuthor"`
+	Comment          string              `json:"comment"`
+	Created          *helper.Iso8601Time `json:"created"`
+	Updated          helper.Iso8601Time  `json:"updated"`
+	Started          helper.Iso8601Time  `json:"started"`
+	TimeSpent        string              `json:"timeSpent"`
+	TimeSpentSeconds int                 `json:"timeSpentSeconds"`
+	ID               string              `json:"id"`
+	IssueID          uint64              `json:"issueId,string"`
 }
 
 func (w Worklog) ToToolLayer(connectionId uint64, issueUpdated *time.Time) *models.JiraWorklog {
@@ -44,6 +44,8 @@ func (w Worklog) ToToolLayer(connectionId uint64, issueUpdated *time.Time) *mode
 		WorklogId:        w.ID,
 		TimeSpent:        w.TimeSpent,
 		TimeSpentSeconds: w.TimeSpentSeconds,
+		Comment:          w.Comment,
+		Created:          helper.Iso8601TimeToTime(w.Created),
 		Updated:          w.Updated.ToTime(),
 		Started:          w.Started.ToTime(),
 		IssueUpdated:     issueUpdated,
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/plugins/jira/models"
-	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -122,20 +121,8 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 		*/
 		GetTotalPages: GetTotalPagesFromResponse,
 		Concurrency:   10,
-		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
-			var data struct {
-				Issues []json.RawMessage `json:"issues"`
-			}
-			blob, err := io.ReadAll(res.Body)
-			if err != nil {
-				return nil, errors.Convert(err)
-			}
-			err = json.Unmarshal(blob, &data)
-			if err != nil {
-				return nil, errors.Convert(err)
-			}
-			return data.Issues, nil
-		},
+		// the pages of the issues along with their changelogs may be huge, the issues are decoded one at a time
+		ResponseStreamer: api.StreamRawMessageArrayFromResponse("issues"),
 	})
 	if err != nil {
 		return err
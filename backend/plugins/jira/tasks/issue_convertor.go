@@ -41,6 +41,11 @@ var ConvertIssuesMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
+type jiraIssueWithRequestType struct {
+	models.JiraIssue
+	RequestTypeName string
+}
+
 func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	data := taskCtx.GetData().(*JiraTaskData)
@@ -48,11 +53,14 @@ func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
 	jiraIssue := &models.JiraIssue{}
 	// select all issues belongs to the board
 	clauses := []dal.Clause{
-		dal.Select("_tool_jira_issues.*"),
+		dal.Select("_tool_jira_issues.*, _tool_jira_service_requests.request_type_name"),
 		dal.From(jiraIssue),
 		dal.Join(`left join _tool_jira_board_issues
 			on _tool_jira_board_issues.issue_id = _tool_jira_issues.issue_id
 			and _tool_jira_board_issues.connection_id = _tool_jira_issues.connection_id`),
+		dal.Join(`left join _tool_jira_service_requests
+			on _tool_jira_service_requests.issue_id = _tool_jira_issues.issue_id
+			and _tool_jira_service_requests.connection_id = _tool_jira_issues.connection_id`),
 		dal.Where(
 			"_tool_jira_board_issues.connection_id = ? AND _tool_jira_board_issues.board_id = ?",
 			data.Options.ConnectionId,
@@ -69,9 +77,15 @@ func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
 	accountIdGen := didgen.NewDomainIdGenerator(&models.JiraAccount{})
 	boardIdGen := didgen.NewDomainIdGenerator(&models.JiraBoard{})
 	boardId := boardIdGen.Generate(data.Options.ConnectionId, data.Options.BoardId)
+	incidentRequestTypes := make(map[string]bool)
+	if data.Options.TransformationRules != nil {
+		for _, requestType := range data.Options.TransformationRules.IncidentRequestTypes {
+			incidentRequestTypes[requestType] = true
+		}
+	}
 
 	converter, err := api.NewDataConverter(api.DataConverterArgs{
-		InputRowType: reflect.TypeOf(models.JiraIssue{}),
+		InputRowType: reflect.TypeOf(jiraIssueWithRequestType{}),
 		Input:        cursor,
 		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
 			Ctx: taskCtx,
@@ -82,7 +96,7 @@ func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
 			Table: RAW_ISSUE_TABLE,
 		},
 		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
-			jiraIssue := inputRow.(*models.JiraIssue)
+			jiraIssue := inputRow.(*jiraIssueWithRequestType)
 			issue := &ticket.Issue{
 				DomainEntity: domainlayer.DomainEntity{
 					Id: issueIdGen.Generate(jiraIssue.ConnectionId, jiraIssue.IssueId),
@@ -107,6 +121,10 @@ func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
 				TimeSpentMinutes:        jiraIssue.SpentMinutes,
 				OriginalProject:         jiraIssue.ProjectName,
 			}
+			// the service desk requests are incidents by their request types, whatever their issue types are
+			if incidentRequestTypes[jiraIssue.RequestTypeName] {
+				issue.Type = ticket.INCIDENT
+			}
 			if jiraIssue.CreatorAccountId != "" {
 				issue.CreatorId = accountIdGen.Generate(data.Options.ConnectionId, jiraIssue.CreatorAccountId)
 			}
@@ -177,6 +177,25 @@ func extractIssues(data *JiraTaskData, mappings *typeMappings, row *api.RawData)
 		}
 		results = append(results, issueLabel)
 	}
+	if data.Options.TransformationRules != nil {
+		for fieldId, fieldName := range data.Options.TransformationRules.CustomFieldMappings {
+			value, ok := apiIssue.Fields.AllFields[fieldId]
+			if !ok || value == nil {
+				continue
+			}
+			blob, err := json.Marshal(value)
+			if err != nil {
+				return nil, errors.Convert(err)
+			}
+			results = append(results, &models.JiraIssueCustomField{
+				ConnectionId: data.Options.ConnectionId,
+				IssueId:      issue.IssueId,
+				FieldId:      fieldId,
+				FieldName:    fieldName,
+				Value:        string(blob),
+			})
+		}
+	}
 	return results, nil
 }
 
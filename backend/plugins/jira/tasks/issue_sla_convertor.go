@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertIssueSlasMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueSlas",
+	EntryPoint:       ConvertIssueSlas,
+	EnabledByDefault: false,
+	Description:      "convert Jira Service Management sla clocks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIssueSlas(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+	boardId := data.Options.BoardId
+	logger := taskCtx.GetLogger()
+	logger.Info("convert issue slas")
+	// select all sla cycles belongs to the board
+	clauses := []dal.Clause{
+		dal.From(&models.JiraIssueSla{}),
+		dal.Select("_tool_jira_issue_slas.*"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues
+              ON _tool_jira_board_issues.connection_id = _tool_jira_issue_slas.connection_id
+                   AND _tool_jira_board_issues.issue_id = _tool_jira_issue_slas.issue_id`),
+		dal.Where("_tool_jira_board_issues.connection_id = ? AND _tool_jira_board_issues.board_id = ?", connectionId, boardId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		logger.Error(err, "convert issue slas error")
+		return err
+	}
+	defer cursor.Close()
+
+	slaIdGen := didgen.NewDomainIdGenerator(&models.JiraIssueSla{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      boardId,
+			},
+			Table: RAW_SERVICE_REQUEST_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssueSla{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraSla := inputRow.(*models.JiraIssueSla)
+			sla := &ticket.IssueSla{
+				DomainEntity:     domainlayer.DomainEntity{Id: slaIdGen.Generate(jiraSla.ConnectionId, jiraSla.IssueId, jiraSla.SlaId, jiraSla.Cycle)},
+				IssueId:          issueIdGen.Generate(jiraSla.ConnectionId, jiraSla.IssueId),
+				Name:             jiraSla.Name,
+				State:            ticket.SLA_COMPLETED,
+				Breached:         jiraSla.Breached,
+				GoalMinutes:      millisToMinutes(jiraSla.GoalMillis),
+				ElapsedMinutes:   jiraSla.ElapsedMillis / 60000,
+				RemainingMinutes: millisToMinutes(jiraSla.RemainingMillis),
+				StartDate:        jiraSla.StartTime,
+				StopDate:         jiraSla.StopTime,
+				BreachDate:       jiraSla.BreachTime,
+			}
+			if jiraSla.Ongoing {
+				sla.State = ticket.SLA_ONGOING
+				if jiraSla.Paused {
+					sla.State = ticket.SLA_PAUSED
+				}
+			}
+			return []interface{}{sla}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func millisToMinutes(millis *int64) *int64 {
+	if millis == nil {
+		return nil
+	}
+	minutes := *millis / 60000
+	return &minutes
+}
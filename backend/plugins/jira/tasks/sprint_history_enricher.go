@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var EnrichSprintHistoriesMeta = plugin.SubTaskMeta{
+	Name:             "enrichSprintHistories",
+	EntryPoint:       EnrichSprintHistories,
+	EnabledByDefault: true,
+	Description:      "generate sprint_issue_histories and sprint_scopes from the Sprint changelogs of the board",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func EnrichSprintHistories(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	enricher, err := api.NewSprintEnricher(api.SprintEnricherArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_SPRINT_TABLE,
+		},
+		BoardId:         didgen.NewDomainIdGenerator(&models.JiraBoard{}).Generate(data.Options.ConnectionId, data.Options.BoardId),
+		SprintFieldName: "Sprint",
+	})
+	if err != nil {
+		return err
+	}
+	return enricher.Execute()
+}
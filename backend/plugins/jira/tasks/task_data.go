@@ -40,6 +40,9 @@ type TypeMapping struct {
 
 type TypeMappings map[string]TypeMapping
 
+// CustomFieldMappings maps the ids of the Jira fields, e.g. customfield_10015, to the names they are kept under
+type CustomFieldMappings map[string]string
+
 type JiraTransformationRule struct {
 	ConnectionId               uint64       `mapstructure:"connectionId" json:"connectionId"`
 	Name                       string       `gorm:"type:varchar(255)" validate:"required"`
@@ -48,6 +51,10 @@ type JiraTransformationRule struct {
 	RemotelinkCommitShaPattern string       `json:"remotelinkCommitShaPattern"`
 	RemotelinkRepoPattern      []string     `json:"remotelinkRepoPattern"`
 	TypeMappings               TypeMappings `json:"typeMappings"`
+	// CustomFieldMappings picks the custom fields kept by the domain layer table custom_fields
+	CustomFieldMappings CustomFieldMappings `json:"customFieldMappings"`
+	// IncidentRequestTypes are the names of the Jira Service Management request types converted as incidents
+	IncidentRequestTypes []string `json:"incidentRequestTypes"`
 }
 
 func (r *JiraTransformationRule) ToDb() (*models.JiraTransformationRule, errors.Error) {
@@ -59,6 +66,14 @@ func (r *JiraTransformationRule) ToDb() (*models.JiraTransformationRule, errors.
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error marshaling RemotelinkRepoPattern")
 	}
+	customFieldMappings, err := json.Marshal(r.CustomFieldMappings)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error marshaling CustomFieldMappings")
+	}
+	incidentRequestTypes, err := json.Marshal(r.IncidentRequestTypes)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error marshaling IncidentRequestTypes")
+	}
 	rule := &models.JiraTransformationRule{
 		ConnectionId:               r.ConnectionId,
 		Name:                       r.Name,
@@ -67,6 +82,8 @@ func (r *JiraTransformationRule) ToDb() (*models.JiraTransformationRule, errors.
 		RemotelinkCommitShaPattern: r.RemotelinkCommitShaPattern,
 		RemotelinkRepoPattern:      remotelinkRepoPattern,
 		TypeMappings:               blob,
+		CustomFieldMappings:        customFieldMappings,
+		IncidentRequestTypes:       incidentRequestTypes,
 	}
 	if err1 := rule.VerifyRegexp(); err1 != nil {
 		return nil, err1
@@ -90,6 +107,20 @@ func MakeTransformationRules(rule models.JiraTransformationRule) (*JiraTransform
 			return nil, errors.Default.Wrap(err, "error unMarshaling RemotelinkRepoPattern")
 		}
 	}
+	var customFieldMappings CustomFieldMappings
+	if len(rule.CustomFieldMappings) > 0 {
+		err = json.Unmarshal(rule.CustomFieldMappings, &customFieldMappings)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error unMarshaling CustomFieldMappings")
+		}
+	}
+	var incidentRequestTypes []string
+	if len(rule.IncidentRequestTypes) > 0 {
+		err = json.Unmarshal(rule.IncidentRequestTypes, &incidentRequestTypes)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error unMarshaling IncidentRequestTypes")
+		}
+	}
 	result := &JiraTransformationRule{
 		ConnectionId:               rule.ConnectionId,
 		Name:                       rule.Name,
@@ -98,6 +129,8 @@ func MakeTransformationRules(rule models.JiraTransformationRule) (*JiraTransform
 		RemotelinkCommitShaPattern: rule.RemotelinkCommitShaPattern,
 		RemotelinkRepoPattern:      remotelinkRepoPattern,
 		TypeMappings:               typeMapping,
+		CustomFieldMappings:        customFieldMappings,
+		IncidentRequestTypes:       incidentRequestTypes,
 	}
 	return result, nil
 }
@@ -43,6 +43,7 @@ func TestMakeTransformationRules(t *testing.T) {
 				RemotelinkCommitShaPattern: "commit sha pattern",
 				RemotelinkRepoPattern:      []byte(`["abc","efg"]`),
 				TypeMappings:               []byte(`{"10040":{"standardType":"Incident","statusMappings":null}}`),
+				CustomFieldMappings:        []byte(`{"customfield_10015":"start_date"}`),
 			}},
 			&JiraTransformationRule{
 				Name:                       "name",
@@ -54,6 +55,7 @@ func TestMakeTransformationRules(t *testing.T) {
 					StandardType:   "Incident",
 					StatusMappings: nil,
 				}},
+				CustomFieldMappings: CustomFieldMappings{"customfield_10015": "start_date"},
 			},
 			nil,
 		},
@@ -79,9 +79,14 @@ func ConvertWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
 			worklog := &ticket.IssueWorklog{
 				DomainEntity:     domainlayer.DomainEntity{Id: worklogIdGen.Generate(jiraWorklog.ConnectionId, jiraWorklog.IssueId, jiraWorklog.WorklogId)},
 				IssueId:          issueIdGen.Generate(jiraWorklog.ConnectionId, jiraWorklog.IssueId),
+				Comment:          jiraWorklog.Comment,
 				TimeSpentMinutes: jiraWorklog.TimeSpentSeconds / 60,
 				StartedDate:      &jiraWorklog.Started,
-				LoggedDate:       &jiraWorklog.Updated,
+				LoggedDate:       jiraWorklog.Created,
+			}
+			// worklogs collected before created was kept were logged no later than their last update
+			if worklog.LoggedDate == nil {
+				worklog.LoggedDate = &jiraWorklog.Updated
 			}
 			if jiraWorklog.AuthorId != "" {
 				worklog.AuthorId = accountIdGen.Generate(connectionId, jiraWorklog.AuthorId)
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the projects are not grouped
+// @Tags plugins/launchdarkly
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/launchdarkly/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		nil,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.LaunchdarklyConnection) ([]models.LaunchdarklyApiProject, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			return listProjects(basicRes, &connection, queryData, "")
+		},
+	)
+}
+
+// SearchRemoteScopes filters the projects by their name or key
+// @Summary filters the projects by their name or key
+// @Description filters the projects by their name or key
+// @Tags plugins/launchdarkly
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/launchdarkly/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.LaunchdarklyConnection) ([]models.LaunchdarklyApiProject, errors.Error) {
+			return listProjects(basicRes, &connection, queryData, queryData.Search[0])
+		},
+	)
+}
+
+// listProjects returns a page of the projects, filtered by the search query of the api when it is given
+func listProjects(basicRes context2.BasicRes, connection *models.LaunchdarklyConnection, queryData *api.RemoteQueryData, search string) ([]models.LaunchdarklyApiProject, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	query := url.Values{}
+	query.Set("offset", fmt.Sprintf("%v", (queryData.Page-1)*queryData.PerPage))
+	query.Set("limit", fmt.Sprintf("%v", queryData.PerPage))
+	query.Set("sort", "name")
+	if search != "" {
+		query.Set("filter", fmt.Sprintf("query:%s", search))
+	}
+	res, err := apiClient.Get("projects", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody struct {
+		Items []models.LaunchdarklyApiProject `json:"items"`
+	}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	return resBody.Items, nil
+}
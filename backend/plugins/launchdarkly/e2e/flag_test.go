@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/featureflag"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/impl"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/tasks"
+)
+
+func TestLaunchdarklyFlagDataFlow(t *testing.T) {
+
+	var launchdarkly impl.Launchdarkly
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "launchdarkly", launchdarkly)
+
+	// the production pattern is omitted so the critical environments are the production ones
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.STAGING, "stag")
+	_ = regexEnricher.TryAdd(devops.TESTING, "test")
+	taskData := &tasks.LaunchdarklyTaskData{
+		Options: &tasks.LaunchdarklyOptions{
+			ConnectionId: 1,
+			ProjectKey:   "web-shop",
+			LaunchdarklyTransformationRule: &models.LaunchdarklyTransformationRule{
+				StagingPattern: "stag",
+				TestingPattern: "test",
+			},
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_launchdarkly_projects.csv", &models.LaunchdarklyProject{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_launchdarkly_api_environments.csv", "_raw_launchdarkly_api_environments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_launchdarkly_api_flags.csv", "_raw_launchdarkly_api_flags")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_launchdarkly_api_audit_log.csv", "_raw_launchdarkly_api_audit_log")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.LaunchdarklyEnvironment{})
+	dataflowTester.Subtask(tasks.ExtractApiEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.LaunchdarklyEnvironment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_launchdarkly_environments.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.LaunchdarklyFlag{})
+	dataflowTester.FlushTabler(&models.LaunchdarklyFlagEnvironment{})
+	dataflowTester.Subtask(tasks.ExtractApiFlagsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.LaunchdarklyFlag{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_launchdarkly_flags.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.LaunchdarklyFlagEnvironment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_launchdarkly_flag_environments.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// only the changes of the flags are extracted
+	dataflowTester.FlushTabler(&models.LaunchdarklyAuditLogEntry{})
+	dataflowTester.Subtask(tasks.ExtractApiAuditLogMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.LaunchdarklyAuditLogEntry{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_launchdarkly_audit_log_entries.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&featureflag.FeatureFlagScope{})
+	dataflowTester.Subtask(tasks.ConvertProjectMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(featureflag.FeatureFlagScope{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/feature_flag_scopes.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&featureflag.FeatureFlag{})
+	dataflowTester.Subtask(tasks.ConvertFlagsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(featureflag.FeatureFlag{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/feature_flags.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// the toggles are reverted from the collected state of the flags to know whether they were on after each change
+	dataflowTester.FlushTabler(&featureflag.FeatureFlagEvent{})
+	dataflowTester.Subtask(tasks.ConvertFlagEventsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(featureflag.FeatureFlagEvent{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/feature_flag_events.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// LaunchdarklyAuditLogEntry is a change of a flag, the environment is empty for the changes of the flag itself
+// like its creation or archival
+type LaunchdarklyAuditLogEntry struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	LaunchdarklyId string `gorm:"primaryKey;type:varchar(255)"`
+	ProjectKey     string `gorm:"index;type:varchar(255)"`
+	EnvironmentKey string `gorm:"type:varchar(255)"`
+	FlagKey        string `gorm:"index;type:varchar(255)"`
+	// Action is the first of the actions of the change, i.e. updateOn or updateFallthrough
+	Action      string `gorm:"type:varchar(100)"`
+	TitleVerb   string `gorm:"type:varchar(255)"`
+	Description string
+	Comment     string
+	MemberId    string `gorm:"type:varchar(255)"`
+	MemberEmail string `gorm:"type:varchar(255)"`
+	MemberName  string `gorm:"type:varchar(255)"`
+	Date        time.Time
+	common.NoPKModel
+}
+
+func (LaunchdarklyAuditLogEntry) TableName() string {
+	return "_tool_launchdarkly_audit_log_entries"
+}
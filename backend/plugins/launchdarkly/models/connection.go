@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*LaunchdarklyConnection)(nil)
+
+// LaunchdarklyAccessToken authenticates with an api access token, it is sent as is without a scheme
+type LaunchdarklyAccessToken api.AccessToken
+
+// SetupAuthentication sets up the request headers for authentication
+func (at *LaunchdarklyAccessToken) SetupAuthentication(request *http.Request) errors.Error {
+	request.Header.Set("Authorization", at.Token)
+	return nil
+}
+
+// LaunchdarklyConn holds the essential information to connect to the LaunchDarkly API,
+// the endpoint is https://app.launchdarkly.com/api/v2/ or the one of the federal instance
+type LaunchdarklyConn struct {
+	api.RestConnection      `mapstructure:",squash"`
+	LaunchdarklyAccessToken `mapstructure:",squash"`
+}
+
+// LaunchdarklyConnection holds LaunchdarklyConn plus ID/Name for database storage
+type LaunchdarklyConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	LaunchdarklyConn   `mapstructure:",squash"`
+}
+
+func (LaunchdarklyConnection) TableName() string {
+	return "_tool_launchdarkly_connections"
+}
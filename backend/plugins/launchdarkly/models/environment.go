@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// LaunchdarklyEnvironment is an environment of a project, the flags are turned on and off per environment
+type LaunchdarklyEnvironment struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ProjectKey     string `gorm:"primaryKey;type:varchar(255)"`
+	EnvironmentKey string `gorm:"primaryKey;type:varchar(255)"`
+	LaunchdarklyId string `gorm:"type:varchar(255)"`
+	Name           string `gorm:"type:varchar(255)"`
+	Color          string `gorm:"type:varchar(20)"`
+	// Critical is set on the environments whose changes require a confirmation, usually the production ones
+	Critical bool
+	common.NoPKModel
+}
+
+func (LaunchdarklyEnvironment) TableName() string {
+	return "_tool_launchdarkly_environments"
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type LaunchdarklyFlag struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ProjectKey      string `gorm:"primaryKey;type:varchar(255)"`
+	FlagKey         string `gorm:"primaryKey;type:varchar(255)"`
+	Name            string `gorm:"type:varchar(255)"`
+	Description     string
+	Kind            string `gorm:"type:varchar(100)"`
+	Temporary       bool
+	Archived        bool
+	MaintainerId    string `gorm:"type:varchar(255)"`
+	MaintainerEmail string `gorm:"type:varchar(255)"`
+	CreationDate    time.Time
+	ArchivedDate    *time.Time
+	// LastModifiedDate is the latest change of the flag in any of the environments
+	LastModifiedDate *time.Time
+	common.NoPKModel
+}
+
+func (LaunchdarklyFlag) TableName() string {
+	return "_tool_launchdarkly_flags"
+}
+
+// LaunchdarklyFlagEnvironment is the state of a flag in an environment when it was collected
+type LaunchdarklyFlagEnvironment struct {
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	ProjectKey       string `gorm:"primaryKey;type:varchar(255)"`
+	FlagKey          string `gorm:"primaryKey;type:varchar(255)"`
+	EnvironmentKey   string `gorm:"primaryKey;type:varchar(255)"`
+	On               bool
+	Archived         bool
+	LastModifiedDate *time.Time
+	common.NoPKModel
+}
+
+func (LaunchdarklyFlagEnvironment) TableName() string {
+	return "_tool_launchdarkly_flag_environments"
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type LaunchdarklyFlag struct {
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	ProjectKey       string `gorm:"primaryKey;type:varchar(255)"`
+	FlagKey          string `gorm:"primaryKey;type:varchar(255)"`
+	Name             string `gorm:"type:varchar(255)"`
+	Description      string
+	Kind             string `gorm:"type:varchar(100)"`
+	Temporary        bool
+	Archived         bool
+	MaintainerId     string `gorm:"type:varchar(255)"`
+	MaintainerEmail  string `gorm:"type:varchar(255)"`
+	CreationDate     time.Time
+	ArchivedDate     *time.Time
+	LastModifiedDate *time.Time
+	archived.NoPKModel
+}
+
+func (LaunchdarklyFlag) TableName() string {
+	return "_tool_launchdarkly_flags"
+}
+
+type LaunchdarklyFlagEnvironment struct {
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	ProjectKey       string `gorm:"primaryKey;type:varchar(255)"`
+	FlagKey          string `gorm:"primaryKey;type:varchar(255)"`
+	EnvironmentKey   string `gorm:"primaryKey;type:varchar(255)"`
+	On               bool
+	Archived         bool
+	LastModifiedDate *time.Time
+	archived.NoPKModel
+}
+
+func (LaunchdarklyFlagEnvironment) TableName() string {
+	return "_tool_launchdarkly_flag_environments"
+}
+
+type LaunchdarklyAuditLogEntry struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	LaunchdarklyId string `gorm:"primaryKey;type:varchar(255)"`
+	ProjectKey     string `gorm:"index;type:varchar(255)"`
+	EnvironmentKey string `gorm:"type:varchar(255)"`
+	FlagKey        string `gorm:"index;type:varchar(255)"`
+	Action         string `gorm:"type:varchar(100)"`
+	TitleVerb      string `gorm:"type:varchar(255)"`
+	Description    string
+	Comment        string
+	MemberId       string `gorm:"type:varchar(255)"`
+	MemberEmail    string `gorm:"type:varchar(255)"`
+	MemberName     string `gorm:"type:varchar(255)"`
+	Date           time.Time
+	archived.NoPKModel
+}
+
+func (LaunchdarklyAuditLogEntry) TableName() string {
+	return "_tool_launchdarkly_audit_log_entries"
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type LaunchdarklyProject struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	ProjectKey           string `json:"projectKey" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"projectKey"`
+	LaunchdarklyId       string `json:"launchdarklyId" gorm:"type:varchar(255)" mapstructure:"launchdarklyId,omitempty"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	archived.NoPKModel   `json:"-" mapstructure:"-"`
+}
+
+func (LaunchdarklyProject) TableName() string {
+	return "_tool_launchdarkly_projects"
+}
+
+type LaunchdarklyEnvironment struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ProjectKey     string `gorm:"primaryKey;type:varchar(255)"`
+	EnvironmentKey string `gorm:"primaryKey;type:varchar(255)"`
+	LaunchdarklyId string `gorm:"type:varchar(255)"`
+	Name           string `gorm:"type:varchar(255)"`
+	Color          string `gorm:"type:varchar(20)"`
+	Critical       bool
+	archived.NoPKModel
+}
+
+func (LaunchdarklyEnvironment) TableName() string {
+	return "_tool_launchdarkly_environments"
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*LaunchdarklyProject)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*LaunchdarklyApiProject)(nil)
+
+// LaunchdarklyProject is a project of LaunchDarkly, its flags and their changes in every environment are collected with it
+type LaunchdarklyProject struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	ProjectKey           string `json:"projectKey" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"projectKey"`
+	LaunchdarklyId       string `json:"launchdarklyId" gorm:"type:varchar(255)" mapstructure:"launchdarklyId,omitempty"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (LaunchdarklyProject) TableName() string {
+	return "_tool_launchdarkly_projects"
+}
+
+func (p LaunchdarklyProject) ScopeId() string {
+	return p.ProjectKey
+}
+
+func (p LaunchdarklyProject) ScopeName() string {
+	return p.Name
+}
+
+// LaunchdarklyApiProject is the project entity of the api
+type LaunchdarklyApiProject struct {
+	Id   string `json:"_id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+func (p LaunchdarklyApiProject) ConvertApiScope() plugin.ToolLayerScope {
+	return &LaunchdarklyProject{
+		ProjectKey:     p.Key,
+		LaunchdarklyId: p.Id,
+		Name:           p.Name,
+	}
+}
+
+// GroupResponse is required by the remote api helper, the projects are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+type LaunchdarklyApiParams struct {
+	ConnectionId uint64
+	ProjectKey   string
+}
+
+// LaunchdarklyApiMember is the member of the account who made a change or maintains a flag
+type LaunchdarklyApiMember struct {
+	Id        string `json:"_id"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// FullName joins the first and the last name, the email is used by the members who have not set them
+func (m LaunchdarklyApiMember) FullName() string {
+	name := strings.TrimSpace(fmt.Sprintf("%s %s", m.FirstName, m.LastName))
+	if name == "" {
+		return m.Email
+	}
+	return name
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *LaunchdarklyTaskData) {
+	data := taskCtx.GetData().(*LaunchdarklyTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: LaunchdarklyApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ProjectKey:   data.Options.ProjectKey,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GetQuery pages with `offset` and `limit`
+func GetQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	if reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set("offset", fmt.Sprintf("%v", reqData.Pager.Skip))
+		query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	}
+	return query, nil
+}
+
+// GetRawMessageFromResponse reads the entities the api wraps in `items`
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var body struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	err := api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Items, nil
+}
+
+// msToTime converts the dates of the api, they are epoch milliseconds
+func msToTime(ms int64) *time.Time {
+	if ms == 0 {
+		return nil
+	}
+	t := time.UnixMilli(ms).UTC()
+	return &t
+}
+
+// getEnvironmentType maps an environment to the domain environment types by the patterns of the transformation rule,
+// the critical environments are the production ones when the production pattern is omitted
+func getEnvironmentType(data *LaunchdarklyTaskData, environment *models.LaunchdarklyEnvironment) string {
+	if environment == nil {
+		return ""
+	}
+	if environment.Critical && data.Options.ProductionPattern == "" {
+		return devops.PRODUCTION
+	}
+	for _, envType := range []string{devops.PRODUCTION, devops.STAGING, devops.TESTING} {
+		if data.RegexEnricher.ReturnNameIfMatched(envType, environment.EnvironmentKey, environment.Name) != "" {
+			return envType
+		}
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_AUDIT_LOG_TABLE = "launchdarkly_api_audit_log"
+
+var CollectApiAuditLogMeta = plugin.SubTaskMeta{
+	Name:             "collectApiAuditLog",
+	EntryPoint:       CollectApiAuditLog,
+	EnabledByDefault: true,
+	Description:      "Collect the changes of the flags of the project from the audit log of LaunchDarkly api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+// CollectApiAuditLog pages the audit log from the newest entry backwards, each page asks for the entries before the
+// last one of the previous page
+func CollectApiAuditLog(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_AUDIT_LOG_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    20,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "auditlog",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+			query.Set("spec", fmt.Sprintf("proj/%s:env/*:flag/*", data.Options.ProjectKey))
+			if collectorWithState.IsIncremental() {
+				query.Set("after", fmt.Sprintf("%d", collectorWithState.LatestState.LatestSuccessStart.UnixMilli()))
+			} else if collectorWithState.TimeAfter != nil {
+				query.Set("after", fmt.Sprintf("%d", collectorWithState.TimeAfter.UnixMilli()))
+			}
+			if before, ok := reqData.CustomData.(int64); ok {
+				query.Set("before", fmt.Sprintf("%d", before))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+			var body struct {
+				Items []struct {
+					Date int64 `json:"date"`
+				} `json:"items"`
+			}
+			err := api.UnmarshalResponse(prevPageResponse, &body)
+			if err != nil {
+				return nil, err
+			}
+			if len(body.Items) == 0 {
+				return nil, api.ErrFinishCollect
+			}
+			return body.Items[len(body.Items)-1].Date, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+var ExtractApiAuditLogMeta = plugin.SubTaskMeta{
+	Name:             "extractApiAuditLog",
+	EntryPoint:       ExtractApiAuditLog,
+	EnabledByDefault: true,
+	Description:      "Extract raw audit log data into tool layer table launchdarkly_audit_log_entries",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+type LaunchdarklyApiAuditLogEntry struct {
+	Id       string `json:"_id"`
+	Date     int64  `json:"date"`
+	Kind     string `json:"kind"`
+	Accesses []struct {
+		Action   string `json:"action"`
+		Resource string `json:"resource"`
+	} `json:"accesses"`
+	Member      *LaunchdarklyApiMember `json:"member"`
+	TitleVerb   string                 `json:"titleVerb"`
+	Description string                 `json:"description"`
+	Comment     string                 `json:"comment"`
+}
+
+func ExtractApiAuditLog(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_AUDIT_LOG_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiEntry := &LaunchdarklyApiAuditLogEntry{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiEntry))
+			if err != nil {
+				return nil, err
+			}
+			if apiEntry.Kind != "flag" || len(apiEntry.Accesses) == 0 {
+				return nil, nil
+			}
+			resource := parseResource(apiEntry.Accesses[0].Resource)
+			entry := &models.LaunchdarklyAuditLogEntry{
+				ConnectionId:   data.Options.ConnectionId,
+				LaunchdarklyId: apiEntry.Id,
+				ProjectKey:     data.Options.ProjectKey,
+				EnvironmentKey: resource["env"],
+				FlagKey:        resource["flag"],
+				Action:         apiEntry.Accesses[0].Action,
+				TitleVerb:      apiEntry.TitleVerb,
+				Description:    apiEntry.Description,
+				Comment:        apiEntry.Comment,
+				Date:           time.UnixMilli(apiEntry.Date).UTC(),
+			}
+			if apiEntry.Member != nil {
+				entry.MemberId = apiEntry.Member.Id
+				entry.MemberEmail = apiEntry.Member.Email
+				entry.MemberName = apiEntry.Member.FullName()
+			}
+			return []interface{}{entry}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// parseResource splits a resource specifier like `proj/default:env/production:flag/dark-mode` into its keys by
+// their kind, the tags following a `;` and the wildcards are left out
+func parseResource(resource string) map[string]string {
+	keys := make(map[string]string)
+	for _, part := range strings.Split(resource, ":") {
+		kind, key, found := strings.Cut(part, "/")
+		if !found {
+			continue
+		}
+		key, _, _ = strings.Cut(key, ";")
+		if key == "*" {
+			continue
+		}
+		keys[kind] = key
+	}
+	return keys
+}
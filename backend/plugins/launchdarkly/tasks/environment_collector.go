@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_ENVIRONMENT_TABLE = "launchdarkly_api_environments"
+
+var CollectApiEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiEnvironments",
+	EntryPoint:       CollectApiEnvironments,
+	EnabledByDefault: true,
+	Description:      "Collect the environments of the project from LaunchDarkly api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+func CollectApiEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ENVIRONMENT_TABLE)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		UrlTemplate:        "projects/{{ .Params.ProjectKey }}/environments",
+		Query:              GetQuery,
+		ResponseParser:     GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
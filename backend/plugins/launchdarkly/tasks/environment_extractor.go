@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+var ExtractApiEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiEnvironments",
+	EntryPoint:       ExtractApiEnvironments,
+	EnabledByDefault: true,
+	Description:      "Extract raw environments data into tool layer table launchdarkly_environments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+type LaunchdarklyApiEnvironment struct {
+	Id       string `json:"_id"`
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Color    string `json:"color"`
+	Critical bool   `json:"critical"`
+}
+
+func ExtractApiEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ENVIRONMENT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiEnvironment := &LaunchdarklyApiEnvironment{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiEnvironment))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.LaunchdarklyEnvironment{
+					ConnectionId:   data.Options.ConnectionId,
+					ProjectKey:     data.Options.ProjectKey,
+					EnvironmentKey: apiEnvironment.Key,
+					LaunchdarklyId: apiEnvironment.Id,
+					Name:           apiEnvironment.Name,
+					Color:          apiEnvironment.Color,
+					Critical:       apiEnvironment.Critical,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
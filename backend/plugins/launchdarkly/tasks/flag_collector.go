@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_FLAG_TABLE = "launchdarkly_api_flags"
+
+var CollectApiFlagsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiFlags",
+	EntryPoint:       CollectApiFlags,
+	EnabledByDefault: true,
+	Description:      "Collect the flags of the project and their state in every environment from LaunchDarkly api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+func CollectApiFlags(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_FLAG_TABLE)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		UrlTemplate:        "flags/{{ .Params.ProjectKey }}",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			// the archived flags are not listed, their archival is still told by the audit log
+			query.Set("summary", "true")
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/featureflag"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+var ConvertFlagsMeta = plugin.SubTaskMeta{
+	Name:             "convertFlags",
+	EntryPoint:       ConvertFlags,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table launchdarkly_flags into domain layer table feature_flags",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+// ConvertFlags converts the flags, their creator is the author of their creation in the audit log when it is collected
+func ConvertFlags(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_FLAG_TABLE)
+	db := taskCtx.GetDal()
+
+	var creations []models.LaunchdarklyAuditLogEntry
+	err := db.All(&creations, dal.Where(
+		"connection_id = ? AND project_key = ? AND action = ?",
+		data.Options.ConnectionId, data.Options.ProjectKey, "createFlag",
+	))
+	if err != nil {
+		return err
+	}
+	creationByFlag := make(map[string]*models.LaunchdarklyAuditLogEntry, len(creations))
+	for i := range creations {
+		creationByFlag[creations[i].FlagKey] = &creations[i]
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.LaunchdarklyFlag{}),
+		dal.Where("connection_id = ? AND project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	flagIdGen := didgen.NewDomainIdGenerator(&models.LaunchdarklyFlag{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.LaunchdarklyProject{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.LaunchdarklyFlag{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			flag := inputRow.(*models.LaunchdarklyFlag)
+			domainFlag := &featureflag.FeatureFlag{
+				DomainEntity:       domainlayer.DomainEntity{Id: flagIdGen.Generate(flag.ConnectionId, flag.ProjectKey, flag.FlagKey)},
+				FeatureFlagScopeId: projectIdGen.Generate(flag.ConnectionId, flag.ProjectKey),
+				Tool:               "launchdarkly",
+				Key:                flag.FlagKey,
+				Name:               flag.Name,
+				Description:        flag.Description,
+				Kind:               featureflag.KIND_MULTIVARIATE,
+				Status:             featureflag.STATUS_ACTIVE,
+				OriginalStatus:     "live",
+				CreatedDate:        &flag.CreationDate,
+				UpdatedDate:        flag.LastModifiedDate,
+				ArchivedDate:       flag.ArchivedDate,
+			}
+			if flag.Kind == "boolean" {
+				domainFlag.Kind = featureflag.KIND_BOOLEAN
+			}
+			if flag.Archived {
+				domainFlag.Status = featureflag.STATUS_ARCHIVED
+				domainFlag.OriginalStatus = "archived"
+			}
+			if creation, ok := creationByFlag[flag.FlagKey]; ok {
+				domainFlag.CreatorId = creation.MemberId
+				domainFlag.CreatorName = creation.MemberName
+			}
+			return []interface{}{domainFlag}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
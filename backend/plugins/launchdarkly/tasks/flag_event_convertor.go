@@ -0,0 +1,155 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/featureflag"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+var ConvertFlagEventsMeta = plugin.SubTaskMeta{
+	Name:             "convertFlagEvents",
+	EntryPoint:       ConvertFlagEvents,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table launchdarkly_audit_log_entries into domain layer table feature_flag_events",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+// the actions changing who is served which variation of a flag in an environment
+var rolloutActionPrefixes = []string{
+	"updateFallthrough",
+	"updateRules",
+	"updateTargets",
+	"updateContextTargets",
+	"updateMeasuredRollout",
+}
+
+// ConvertFlagEvents converts the changes of the flags, the audit log doesn't tell whether a flag is on after a change
+// so the entries of each flag and environment are walked from the newest to the oldest, starting from the collected
+// state of the flag and reverting every toggle on the way
+func ConvertFlagEvents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_AUDIT_LOG_TABLE)
+	db := taskCtx.GetDal()
+
+	var environments []models.LaunchdarklyEnvironment
+	err := db.All(&environments, dal.Where("connection_id = ? AND project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	environmentByKey := make(map[string]*models.LaunchdarklyEnvironment, len(environments))
+	for i := range environments {
+		environmentByKey[environments[i].EnvironmentKey] = &environments[i]
+	}
+
+	var flagEnvironments []models.LaunchdarklyFlagEnvironment
+	err = db.All(&flagEnvironments, dal.Where("connection_id = ? AND project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	// enabled holds the state of each flag in each environment right after the entry being converted
+	enabled := make(map[string]bool, len(flagEnvironments))
+	for _, flagEnvironment := range flagEnvironments {
+		enabled[flagEnvironment.FlagKey+":"+flagEnvironment.EnvironmentKey] = flagEnvironment.On
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.LaunchdarklyAuditLogEntry{}),
+		dal.Where("connection_id = ? AND project_key = ? AND flag_key != ''", data.Options.ConnectionId, data.Options.ProjectKey),
+		dal.Orderby("flag_key, environment_key, date DESC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	entryIdGen := didgen.NewDomainIdGenerator(&models.LaunchdarklyAuditLogEntry{})
+	flagIdGen := didgen.NewDomainIdGenerator(&models.LaunchdarklyFlag{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.LaunchdarklyAuditLogEntry{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			entry := inputRow.(*models.LaunchdarklyAuditLogEntry)
+			event := &featureflag.FeatureFlagEvent{
+				DomainEntity:      domainlayer.DomainEntity{Id: entryIdGen.Generate(entry.ConnectionId, entry.LaunchdarklyId)},
+				FeatureFlagId:     flagIdGen.Generate(entry.ConnectionId, entry.ProjectKey, entry.FlagKey),
+				OriginalEventType: entry.Action,
+				AuthorId:          entry.MemberId,
+				AuthorName:        entry.MemberName,
+				Comment:           entry.Comment,
+				CreatedDate:       entry.Date,
+			}
+			if entry.EnvironmentKey == "" {
+				switch entry.Action {
+				case "createFlag":
+					event.EventType = featureflag.EVENT_CREATED
+				case "archiveFlag":
+					event.EventType = featureflag.EVENT_ARCHIVED
+				default:
+					// the changes of the name, the description or the variations of a flag are not tracked
+					return nil, nil
+				}
+				return []interface{}{event}, nil
+			}
+
+			event.Environment = getEnvironmentType(data, environmentByKey[entry.EnvironmentKey])
+			event.OriginalEnvironment = entry.EnvironmentKey
+			stateKey := entry.FlagKey + ":" + entry.EnvironmentKey
+			event.Enabled = enabled[stateKey]
+			switch {
+			case entry.Action == "updateOn" && strings.Contains(entry.TitleVerb, "turned off"):
+				event.EventType = featureflag.EVENT_TOGGLED_OFF
+				event.Enabled = false
+				enabled[stateKey] = true
+			case entry.Action == "updateOn":
+				event.EventType = featureflag.EVENT_TOGGLED_ON
+				event.Enabled = true
+				enabled[stateKey] = false
+			case isRolloutAction(entry.Action):
+				event.EventType = featureflag.EVENT_ROLLOUT_CHANGED
+			default:
+				event.EventType = featureflag.EVENT_ENVIRONMENT_CHANGED
+			}
+			return []interface{}{event}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func isRolloutAction(action string) bool {
+	for _, prefix := range rolloutActionPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+var ExtractApiFlagsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiFlags",
+	EntryPoint:       ExtractApiFlags,
+	EnabledByDefault: true,
+	Description:      "Extract raw flags data into tool layer table launchdarkly_flags and launchdarkly_flag_environments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+type LaunchdarklyApiFlag struct {
+	Key          string                 `json:"key"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Kind         string                 `json:"kind"`
+	Temporary    bool                   `json:"temporary"`
+	Archived     bool                   `json:"archived"`
+	ArchivedDate int64                  `json:"archivedDate"`
+	CreationDate int64                  `json:"creationDate"`
+	Maintainer   *LaunchdarklyApiMember `json:"_maintainer"`
+	Environments map[string]struct {
+		On           bool  `json:"on"`
+		Archived     bool  `json:"archived"`
+		LastModified int64 `json:"lastModified"`
+	} `json:"environments"`
+}
+
+func ExtractApiFlags(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_FLAG_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiFlag := &LaunchdarklyApiFlag{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiFlag))
+			if err != nil {
+				return nil, err
+			}
+			flag := &models.LaunchdarklyFlag{
+				ConnectionId: data.Options.ConnectionId,
+				ProjectKey:   data.Options.ProjectKey,
+				FlagKey:      apiFlag.Key,
+				Name:         apiFlag.Name,
+				Description:  apiFlag.Description,
+				Kind:         apiFlag.Kind,
+				Temporary:    apiFlag.Temporary,
+				Archived:     apiFlag.Archived,
+				CreationDate: time.UnixMilli(apiFlag.CreationDate).UTC(),
+				ArchivedDate: msToTime(apiFlag.ArchivedDate),
+			}
+			if apiFlag.Maintainer != nil {
+				flag.MaintainerId = apiFlag.Maintainer.Id
+				flag.MaintainerEmail = apiFlag.Maintainer.Email
+			}
+			results := make([]interface{}, 0, len(apiFlag.Environments)+1)
+			for environmentKey, environment := range apiFlag.Environments {
+				lastModifiedDate := msToTime(environment.LastModified)
+				if lastModifiedDate != nil && (flag.LastModifiedDate == nil || lastModifiedDate.After(*flag.LastModifiedDate)) {
+					flag.LastModifiedDate = lastModifiedDate
+				}
+				results = append(results, &models.LaunchdarklyFlagEnvironment{
+					ConnectionId:     data.Options.ConnectionId,
+					ProjectKey:       data.Options.ProjectKey,
+					FlagKey:          apiFlag.Key,
+					EnvironmentKey:   environmentKey,
+					On:               environment.On,
+					Archived:         environment.Archived,
+					LastModifiedDate: lastModifiedDate,
+				})
+			}
+			return append(results, flag), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
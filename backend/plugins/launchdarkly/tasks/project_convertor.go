@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/featureflag"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/launchdarkly/models"
+)
+
+const RAW_PROJECT_TABLE = "launchdarkly_api_projects"
+
+var ConvertProjectMeta = plugin.SubTaskMeta{
+	Name:             "convertProject",
+	EntryPoint:       ConvertProject,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table launchdarkly_projects into domain layer table feature_flag_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_FEATURE_FLAG},
+}
+
+// GetApiProject fetches a project by its key
+func GetApiProject(op *LaunchdarklyOptions, apiClient aha.ApiClientAbstract) (*models.LaunchdarklyApiProject, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("projects/%s", op.ProjectKey), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting project detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	body := &models.LaunchdarklyApiProject{}
+	err = api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func ConvertProject(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROJECT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.LaunchdarklyProject{}),
+		dal.Where("connection_id = ? AND project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&models.LaunchdarklyProject{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.LaunchdarklyProject{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			project := inputRow.(*models.LaunchdarklyProject)
+			return []interface{}{
+				&featureflag.FeatureFlagScope{
+					DomainEntity: domainlayer.DomainEntity{Id: projectIdGen.Generate(project.ConnectionId, project.ProjectKey)},
+					Name:         project.Name,
+					Tool:         "launchdarkly",
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/linker/tasks"
+)
+
+const defaultPreviewLimit = 20
+
+type PreviewInput struct {
+	ProjectName   string            `json:"projectName" mapstructure:"projectName"`
+	Rules         []helper.LinkRule `json:"rules" mapstructure:"rules"`
+	MinConfidence float64           `json:"minConfidence" mapstructure:"minConfidence"`
+	// Texts are matched instead of the pull requests of the project when given, keyed by source
+	Texts map[string]string `json:"texts" mapstructure:"texts"`
+	Limit int               `json:"limit" mapstructure:"limit"`
+}
+
+type PreviewLink struct {
+	helper.Link
+	IssueIds []string `json:"issueIds"`
+}
+
+type PullRequestPreview struct {
+	PullRequestId string        `json:"pullRequestId"`
+	Title         string        `json:"title"`
+	Links         []PreviewLink `json:"links"`
+}
+
+// Preview shows the links the rules would produce without saving them
+// @Summary      Preview the links produced by the rules
+// @Description  match the given texts, or the latest pull requests of the project, against the rules
+// @Tags 		 plugins/linker
+// @Accept       application/json
+// @Param        body body PreviewInput true "json"
+// @Produce      json
+// @Success      200  {object} []PullRequestPreview
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/linker/preview [post]
+func Preview(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var body PreviewInput
+	err := helper.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode preview input")
+	}
+	rules := body.Rules
+	if len(rules) == 0 {
+		rules = tasks.DefaultLinkRules
+	}
+	engine, err := helper.NewLinkEngine(rules)
+	if err != nil {
+		return nil, err
+	}
+	if body.Texts != nil {
+		return &plugin.ApiResourceOutput{Body: filterLinks(engine.Match(body.Texts), body.MinConfidence, nil), Status: http.StatusOK}, nil
+	}
+	if body.ProjectName == "" {
+		return nil, errors.BadInput.New("either projectName or texts is required")
+	}
+	if body.Limit <= 0 {
+		body.Limit = defaultPreviewLimit
+	}
+
+	db := basicRes.GetDal()
+	issueIds, err := tasks.LoadProjectIssues(db, body.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	var prs []tasks.LinkedPullRequest
+	err = db.All(
+		&prs,
+		dal.Select(tasks.LinkedPullRequestColumns),
+		dal.From("pull_requests pr"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = pr.base_repo_id)"),
+		dal.Where("pm.project_name = ? AND pm.table = 'repos'", body.ProjectName),
+		dal.Orderby("pr.created_date DESC"),
+		dal.Limit(body.Limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	previews := make([]PullRequestPreview, 0, len(prs))
+	for i := range prs {
+		pr := &prs[i]
+		previews = append(previews, PullRequestPreview{
+			PullRequestId: pr.Id,
+			Title:         pr.Title,
+			Links:         filterLinks(engine.Match(tasks.PullRequestTexts(pr)), body.MinConfidence, issueIds),
+		})
+	}
+	return &plugin.ApiResourceOutput{Body: previews, Status: http.StatusOK}, nil
+}
+
+func filterLinks(links []helper.Link, minConfidence float64, issueIds map[string][]string) []PreviewLink {
+	result := make([]PreviewLink, 0, len(links))
+	for _, link := range links {
+		if link.Confidence < minConfidence {
+			continue
+		}
+		result = append(result, PreviewLink{Link: link, IssueIds: issueIds[link.IssueKey]})
+	}
+	return result
+}
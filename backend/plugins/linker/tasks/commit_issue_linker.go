@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var LinkCommitsToIssuesMeta = plugin.SubTaskMeta{
+	Name:             "linkCommitsToIssues",
+	EntryPoint:       LinkCommitsToIssues,
+	EnabledByDefault: true,
+	Description:      "link commits to the issues referenced by their messages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+type commitMessage struct {
+	common.RawDataOrigin
+	Sha     string
+	Message string
+}
+
+func LinkCommitsToIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*LinkerTaskData)
+	issueIds, err := LoadProjectIssues(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: LinkerApiParams{
+			ProjectName: data.Options.ProjectName,
+		},
+		Table: "commits",
+	}
+	rawDataSubTask, err := api.NewRawDataSubTask(rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT c.sha, c.message"),
+		dal.From("commits c"),
+		dal.Join("INNER JOIN repo_commits rc ON (rc.commit_sha = c.sha)"),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = rc.repo_id)"),
+		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		BatchSize:          500,
+		InputRowType:       reflect.TypeOf(commitMessage{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			commit := inputRow.(*commitMessage)
+			commit.RawDataOrigin = common.RawDataOrigin{
+				RawDataTable:  rawDataSubTask.GetTable(),
+				RawDataParams: rawDataSubTask.GetParams(),
+			}
+			var results []interface{}
+			for _, link := range data.Engine.Match(map[string]string{api.LINK_SOURCE_COMMIT_MESSAGE: commit.Message}) {
+				if link.Confidence < data.Options.MinConfidence {
+					continue
+				}
+				for _, issueId := range issueIds[link.IssueKey] {
+					results = append(results, &crossdomain.IssueCommit{
+						IssueId:    issueId,
+						CommitSha:  commit.Sha,
+						Confidence: link.Confidence,
+						LinkRule:   link.Rule,
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
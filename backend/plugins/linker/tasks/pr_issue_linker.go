@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var LinkPullRequestsToIssuesMeta = plugin.SubTaskMeta{
+	Name:             "linkPullRequestsToIssues",
+	EntryPoint:       LinkPullRequestsToIssues,
+	EnabledByDefault: true,
+	Description:      "link pull requests to the issues referenced by their title, description or branch",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// LinkedPullRequest holds the columns of a pull request the rules are applied to
+type LinkedPullRequest struct {
+	common.RawDataOrigin
+	Id             string
+	PullRequestKey int
+	Title          string
+	Description    string
+	HeadRef        string
+}
+
+// LinkedPullRequestColumns selects the LinkedPullRequest of the pull_requests aliased pr
+const LinkedPullRequestColumns = "pr.id, pr.pull_request_key, pr.title, pr.description, pr.head_ref"
+
+type existingLink struct {
+	PullRequestId string
+	IssueId       string
+	Confidence    float64
+}
+
+func LinkPullRequestsToIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*LinkerTaskData)
+	issueIds, err := LoadProjectIssues(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: LinkerApiParams{
+			ProjectName: data.Options.ProjectName,
+		},
+		Table: "pull_requests",
+	}
+	rawDataSubTask, err := api.NewRawDataSubTask(rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+	// links produced by other plugins, e.g. the close pattern of github, are kept unless we are more confident
+	var existingLinks []existingLink
+	err = db.All(
+		&existingLinks,
+		dal.Select("pri.pull_request_id, pri.issue_id, pri.confidence"),
+		dal.From("pull_request_issues pri"),
+		dal.Join("INNER JOIN pull_requests pr ON (pr.id = pri.pull_request_id)"),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.row_id = pr.base_repo_id)"),
+		dal.Where(
+			"pm.project_name = ? AND pm.table = 'repos' AND pri._raw_data_table <> ?",
+			data.Options.ProjectName, rawDataSubTask.GetTable(),
+		),
+	)
+	if err != nil {
+		return err
+	}
+	existingConfidences := make(map[string]float64, len(existingLinks))
+	for _, link := range existingLinks {
+		existingConfidences[link.PullRequestId+":"+link.IssueId] = link.Confidence
+	}
+	cursor, err := db.Cursor(
+		dal.Select(LinkedPullRequestColumns),
+		dal.From("pull_requests pr"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.row_id = pr.base_repo_id)"),
+		dal.Where("pm.project_name = ? AND pm.table = 'repos'", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		BatchSize:          500,
+		InputRowType:       reflect.TypeOf(LinkedPullRequest{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pr := inputRow.(*LinkedPullRequest)
+			// the links belong to the linker, not to the plugin which collected the pull request
+			pr.RawDataOrigin = common.RawDataOrigin{
+				RawDataTable:  rawDataSubTask.GetTable(),
+				RawDataParams: rawDataSubTask.GetParams(),
+			}
+			var results []interface{}
+			for _, link := range data.Engine.Match(PullRequestTexts(pr)) {
+				if link.Confidence < data.Options.MinConfidence {
+					continue
+				}
+				for _, issueId := range issueIds[link.IssueKey] {
+					if confidence, ok := existingConfidences[pr.Id+":"+issueId]; ok && confidence >= link.Confidence {
+						continue
+					}
+					results = append(results, &crossdomain.PullRequestIssue{
+						PullRequestId:  pr.Id,
+						IssueId:        issueId,
+						PullRequestKey: pr.PullRequestKey,
+						IssueKey:       numericKey(link.IssueKey),
+						Confidence:     link.Confidence,
+						LinkRule:       link.Rule,
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// PullRequestTexts returns the texts of the pull request the rules can be applied to
+func PullRequestTexts(pr *LinkedPullRequest) map[string]string {
+	return map[string]string{
+		api.LINK_SOURCE_PR_TITLE:       pr.Title,
+		api.LINK_SOURCE_PR_DESCRIPTION: pr.Description,
+		api.LINK_SOURCE_PR_BRANCH:      pr.HeadRef,
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// DefaultLinkRules are used when a project doesn't define its own rules
+var DefaultLinkRules = []helper.LinkRule{
+	{
+		Name:       "closing-keyword",
+		Sources:    []string{helper.LINK_SOURCE_PR_DESCRIPTION, helper.LINK_SOURCE_COMMIT_MESSAGE},
+		Pattern:    `(?i)(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s+#(?P<key>\d+)`,
+		Confidence: 0.95,
+	},
+	{
+		Name:       "issue-key-in-title",
+		Sources:    []string{helper.LINK_SOURCE_PR_TITLE, helper.LINK_SOURCE_COMMIT_MESSAGE},
+		Pattern:    `\b(?P<key>[A-Z][A-Z0-9]+-\d+)\b`,
+		Confidence: 0.9,
+	},
+	{
+		Name:       "issue-key-in-branch",
+		Sources:    []string{helper.LINK_SOURCE_PR_BRANCH},
+		Pattern:    `(?P<key>[A-Z][A-Z0-9]+-\d+)`,
+		Confidence: 0.8,
+	},
+	{
+		Name:       "issue-key-in-description",
+		Sources:    []string{helper.LINK_SOURCE_PR_DESCRIPTION},
+		Pattern:    `\b(?P<key>[A-Z][A-Z0-9]+-\d+)\b`,
+		Confidence: 0.6,
+	},
+}
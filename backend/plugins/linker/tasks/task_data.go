@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type LinkerApiParams struct {
+	ProjectName string
+}
+
+type LinkerOptions struct {
+	ProjectName string            `json:"projectName" mapstructure:"projectName"`
+	Rules       []helper.LinkRule `json:"rules" mapstructure:"rules"`
+	// MinConfidence drops the links whose confidence is lower
+	MinConfidence float64 `json:"minConfidence" mapstructure:"minConfidence"`
+}
+
+type LinkerTaskData struct {
+	Options *LinkerOptions
+	Engine  *helper.LinkEngine
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*LinkerOptions, errors.Error) {
+	var op LinkerOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error decoding linker task options")
+	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required")
+	}
+	if len(op.Rules) == 0 {
+		op.Rules = DefaultLinkRules
+	}
+	return &op, nil
+}
+
+type projectIssue struct {
+	Id       string
+	IssueKey string
+}
+
+// LoadProjectIssues returns the ids of the issues of the project boards keyed by their issue keys
+func LoadProjectIssues(db dal.Dal, projectName string) (map[string][]string, errors.Error) {
+	var issues []projectIssue
+	err := db.All(
+		&issues,
+		dal.Select("i.id, i.issue_key"),
+		dal.From("issues i"),
+		dal.Join("INNER JOIN board_issues bi ON (bi.issue_id = i.id)"),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = bi.board_id)"),
+		dal.Where("pm.project_name = ?", projectName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	issueIds := make(map[string][]string, len(issues))
+	for _, issue := range issues {
+		issueIds[issue.IssueKey] = append(issueIds[issue.IssueKey], issue.Id)
+	}
+	return issueIds, nil
+}
+
+// numericKey returns the issue key as a number for the legacy integer columns, 0 if it isn't numeric
+func numericKey(issueKey string) int {
+	key, err := strconv.Atoi(issueKey)
+	if err != nil {
+		return 0
+	}
+	return key
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type ComponentInput struct {
+	Name      string `json:"name" mapstructure:"name"`
+	PathRegex string `json:"pathRegex" mapstructure:"pathRegex"`
+}
+
+type componentsInput struct {
+	Components []ComponentInput `json:"components" mapstructure:"components"`
+}
+
+// GetComponents returns the components of a repo
+// @Summary      Get the components of a repo
+// @Description  get the path based components (sub-projects) of a repo
+// @Tags 		 plugins/monorepo
+// @Param        repoId path string true "domain layer id of the repo"
+// @Produce      json
+// @Success      200  {object} []code.Component
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/monorepo/repos/{repoId}/components [get]
+func GetComponents(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var components []code.Component
+	err := basicRes.GetDal().All(&components, dal.Where("repo_id = ?", input.Params["repoId"]), dal.Orderby("name"))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: components, Status: http.StatusOK}, nil
+}
+
+// PutComponents replaces the components of a repo
+// @Summary      Replace the components of a repo
+// @Description  map the paths of a repo to components, gitextractor attributes the changed files to them on the next collection of the repo
+// @Tags 		 plugins/monorepo
+// @Accept       application/json
+// @Param        repoId path string true "domain layer id of the repo"
+// @Param        body body componentsInput true "json"
+// @Produce      json
+// @Success      200  {object} []code.Component
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/monorepo/repos/{repoId}/components [put]
+func PutComponents(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	repoId := input.Params["repoId"]
+	var body componentsInput
+	err := helper.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode components")
+	}
+	components := make([]code.Component, 0, len(body.Components))
+	names := make(map[string]bool, len(body.Components))
+	for _, c := range body.Components {
+		if c.Name == "" || c.PathRegex == "" {
+			return nil, errors.BadInput.New("name and pathRegex are required")
+		}
+		if names[c.Name] {
+			return nil, errors.BadInput.New(fmt.Sprintf("duplicated component %s", c.Name))
+		}
+		names[c.Name] = true
+		// gitextractor would panic on an invalid regex
+		_, err = errors.Convert01(regexp.Compile(c.PathRegex))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid pathRegex of component %s", c.Name))
+		}
+		components = append(components, code.Component{RepoId: repoId, Name: c.Name, PathRegex: c.PathRegex})
+	}
+	tx := basicRes.GetDal().Begin()
+	err = tx.Delete(&code.Component{}, dal.Where("repo_id = ?", repoId))
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	for i := range components {
+		err = tx.Create(&components[i])
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: components, Status: http.StatusOK}, nil
+}
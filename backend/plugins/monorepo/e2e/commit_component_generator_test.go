@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/monorepo/impl"
+	"github.com/apache/incubator-devlake/plugins/monorepo/tasks"
+)
+
+func TestGenerateCommitComponentsDataFlow(t *testing.T) {
+	var plugin impl.Monorepo
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "monorepo", plugin)
+
+	taskData := &tasks.MonorepoTaskData{
+		Options: &tasks.MonorepoOptions{
+			ProjectName: "project1",
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/components.csv", &code.Component{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/commit_files.csv", &code.CommitFile{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/commit_file_components.csv", &code.CommitFileComponent{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/repo_commits.csv", &code.RepoCommit{})
+
+	// verify generator, the Default component of gitextractor, the components of the forks sharing a commit
+	// and the repos of other projects are ignored
+	dataflowTester.FlushTabler(&code.CommitComponent{})
+	dataflowTester.Subtask(tasks.GenerateCommitComponentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.CommitComponent{},
+		"./snapshot_tables/commit_components.csv",
+		e2ehelper.ColumnWithRawData(
+			"repo_id",
+			"commit_sha",
+			"component_name",
+		),
+	)
+}
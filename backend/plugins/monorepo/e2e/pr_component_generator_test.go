@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/monorepo/impl"
+	"github.com/apache/incubator-devlake/plugins/monorepo/tasks"
+)
+
+func TestGeneratePullRequestComponentsDataFlow(t *testing.T) {
+	var plugin impl.Monorepo
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "monorepo", plugin)
+
+	taskData := &tasks.MonorepoTaskData{
+		Options: &tasks.MonorepoOptions{
+			ProjectName: "project1",
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_request_commits.csv", &code.PullRequestCommit{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/commit_components.csv", &code.CommitComponent{})
+
+	// verify generator
+	dataflowTester.FlushTabler(&code.PullRequestComponent{})
+	dataflowTester.Subtask(tasks.GeneratePullRequestComponentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestComponent{},
+		"./snapshot_tables/pull_request_components.csv",
+		e2ehelper.ColumnWithRawData(
+			"pull_request_id",
+			"component_name",
+		),
+	)
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var GenerateCommitComponentsMeta = plugin.SubTaskMeta{
+	Name:             "generateCommitComponents",
+	EntryPoint:       GenerateCommitComponents,
+	EnabledByDefault: true,
+	Description:      "attribute commits to the components of their changed files",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+// GenerateCommitComponents aggregates the commit_file_components attributed by gitextractor with the
+// PathRegex of the components, so the paths are matched only once
+func GenerateCommitComponents(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*MonorepoTaskData)
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: MonorepoApiParams{
+			ProjectName: data.Options.ProjectName,
+		},
+		Table: "commit_file_components",
+	}
+	rawDataSubTask, err := api.NewRawDataSubTask(rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT rc.repo_id, cf.commit_sha, cfc.component_name"),
+		dal.From("commit_file_components cfc"),
+		dal.Join("INNER JOIN commit_files cf ON (cf.id = cfc.commit_file_id)"),
+		dal.Join("INNER JOIN repo_commits rc ON (rc.commit_sha = cf.commit_sha)"),
+		// the files matching none of the components are attributed to "Default", and a commit may be shared
+		// by the forks of a repo, keep only the components of the repo
+		dal.Join("INNER JOIN components c ON (c.repo_id = rc.repo_id AND c.name = cfc.component_name)"),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = rc.repo_id)"),
+		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		BatchSize:          500,
+		InputRowType:       reflect.TypeOf(code.CommitComponent{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			commitComponent := inputRow.(*code.CommitComponent)
+			commitComponent.RawDataOrigin = common.RawDataOrigin{
+				RawDataTable:  rawDataSubTask.GetTable(),
+				RawDataParams: rawDataSubTask.GetParams(),
+			}
+			return []interface{}{commitComponent}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var GenerateDeploymentComponentsMeta = plugin.SubTaskMeta{
+	Name:             "generateDeploymentComponents",
+	EntryPoint:       GenerateDeploymentComponents,
+	EnabledByDefault: true,
+	Description:      "attribute deployments to the components of the commits they shipped",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// GenerateDeploymentComponents attributes a deployment by the commit it deployed, and by all commits it shipped
+// when cicd_commit_deployments were generated by dora, so it must run after dora, see MakeMetricPluginPipelinePlanV200
+func GenerateDeploymentComponents(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*MonorepoTaskData)
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: MonorepoApiParams{
+			ProjectName: data.Options.ProjectName,
+		},
+		Table: "commit_components",
+	}
+	rawDataSubTask, err := api.NewRawDataSubTask(rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT t.deployment_commit_id, t.component_name"),
+		dal.From(
+			`(
+				SELECT dc.id AS deployment_commit_id, cc.component_name, dc.cicd_scope_id
+				FROM cicd_deployment_commits dc
+				INNER JOIN commit_components cc ON (cc.commit_sha = dc.commit_sha AND cc.repo_id = dc.repo_id)
+				UNION
+				SELECT cd.deployment_commit_id, cc.component_name, cd.cicd_scope_id
+				FROM cicd_commit_deployments cd
+				INNER JOIN commit_components cc ON (cc.commit_sha = cd.commit_sha AND cc.repo_id = cd.repo_id)
+			) t`,
+		),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = t.cicd_scope_id)"),
+		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		BatchSize:          500,
+		InputRowType:       reflect.TypeOf(devops.CicdDeploymentCommitComponent{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deploymentComponent := inputRow.(*devops.CicdDeploymentCommitComponent)
+			deploymentComponent.RawDataOrigin = common.RawDataOrigin{
+				RawDataTable:  rawDataSubTask.GetTable(),
+				RawDataParams: rawDataSubTask.GetParams(),
+			}
+			return []interface{}{deploymentComponent}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var GeneratePullRequestComponentsMeta = plugin.SubTaskMeta{
+	Name:             "generatePullRequestComponents",
+	EntryPoint:       GeneratePullRequestComponents,
+	EnabledByDefault: true,
+	Description:      "attribute pull requests to the components of their commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func GeneratePullRequestComponents(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*MonorepoTaskData)
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: MonorepoApiParams{
+			ProjectName: data.Options.ProjectName,
+		},
+		Table: "commit_components",
+	}
+	rawDataSubTask, err := api.NewRawDataSubTask(rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT prc.pull_request_id, cc.component_name"),
+		dal.From("pull_request_commits prc"),
+		dal.Join("INNER JOIN pull_requests pr ON (pr.id = prc.pull_request_id)"),
+		dal.Join("INNER JOIN commit_components cc ON (cc.commit_sha = prc.commit_sha AND cc.repo_id = pr.base_repo_id)"),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = pr.base_repo_id)"),
+		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		BatchSize:          500,
+		InputRowType:       reflect.TypeOf(code.PullRequestComponent{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			prComponent := inputRow.(*code.PullRequestComponent)
+			prComponent.RawDataOrigin = common.RawDataOrigin{
+				RawDataTable:  rawDataSubTask.GetTable(),
+				RawDataParams: rawDataSubTask.GetParams(),
+			}
+			return []interface{}{prComponent}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
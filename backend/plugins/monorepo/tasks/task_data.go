@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type MonorepoApiParams struct {
+	ProjectName string
+}
+
+type MonorepoOptions struct {
+	ProjectName string `json:"projectName" mapstructure:"projectName"`
+}
+
+type MonorepoTaskData struct {
+	Options *MonorepoOptions
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*MonorepoOptions, errors.Error) {
+	var op MonorepoOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error decoding monorepo task options")
+	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required")
+	}
+	return &op, nil
+}
@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the projects are grouped by spaces
+// @Tags plugins/octopus
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/octopus/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.OctopusConnection) ([]models.OctopusApiSpace, errors.Error) {
+			if gid != "" || queryData.Page > 1 {
+				return nil, nil
+			}
+			return listSpaces(basicRes, &connection)
+		},
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.OctopusConnection) ([]models.OctopusApiProject, errors.Error) {
+			if gid == "" || queryData.Page > 1 {
+				return nil, nil
+			}
+			return listProjects(basicRes, &connection, gid, "")
+		},
+	)
+}
+
+// SearchRemoteScopes filters the projects of every space by name
+// @Summary filters the projects of every space by name
+// @Description filters the projects of every space by name
+// @Tags plugins/octopus
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/octopus/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.OctopusConnection) ([]models.OctopusApiProject, errors.Error) {
+			if queryData.Page > 1 {
+				return nil, nil
+			}
+			spaces, err := listSpaces(basicRes, &connection)
+			if err != nil {
+				return nil, err
+			}
+			projects := make([]models.OctopusApiProject, 0)
+			for _, space := range spaces {
+				found, err := listProjects(basicRes, &connection, space.Id, queryData.Search[0])
+				if err != nil {
+					return nil, err
+				}
+				projects = append(projects, found...)
+			}
+			return projects, nil
+		},
+	)
+}
+
+// listSpaces returns all the spaces the api key can see, the `all` endpoints of Octopus are not paged
+func listSpaces(basicRes context2.BasicRes, connection *models.OctopusConnection) ([]models.OctopusApiSpace, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	res, err := apiClient.Get("api/spaces/all", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	spaces := make([]models.OctopusApiSpace, 0)
+	err = api.UnmarshalResponse(res, &spaces)
+	if err != nil {
+		return nil, err
+	}
+	return spaces, nil
+}
+
+// listProjects returns all the projects of the space, filtered by name when a search is given
+func listProjects(basicRes context2.BasicRes, connection *models.OctopusConnection, spaceId string, search string) ([]models.OctopusApiProject, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	res, err := apiClient.Get(fmt.Sprintf("api/%s/projects/all", spaceId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	all := make([]models.OctopusApiProject, 0)
+	err = api.UnmarshalResponse(res, &all)
+	if err != nil {
+		return nil, err
+	}
+	if search == "" {
+		return all, nil
+	}
+	projects := make([]models.OctopusApiProject, 0)
+	for _, project := range all {
+		if strings.Contains(project.Name, search) {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
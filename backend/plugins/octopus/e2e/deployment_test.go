@@ -0,0 +1,211 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/impl"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+	"github.com/apache/incubator-devlake/plugins/octopus/tasks"
+)
+
+func TestOctopusDeploymentDataFlow(t *testing.T) {
+
+	var octopus impl.Octopus
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "octopus", octopus)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "(?i)prod")
+	taskData := &tasks.OctopusTaskData{
+		Options: &tasks.OctopusOptions{
+			ConnectionId: 1,
+			ProjectId:    "Projects-1",
+			SpaceId:      "Spaces-1",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_octopus_projects.csv", &models.OctopusProject{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_octopus_api_environments.csv", "_raw_octopus_api_environments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_octopus_api_tenants.csv", "_raw_octopus_api_tenants")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_octopus_api_releases.csv", "_raw_octopus_api_releases")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_octopus_api_deployments.csv", "_raw_octopus_api_deployments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_octopus_api_tasks.csv", "_raw_octopus_api_tasks")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.OctopusEnvironment{})
+	dataflowTester.Subtask(tasks.ExtractApiEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OctopusEnvironment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_octopus_environments.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.OctopusTenant{})
+	dataflowTester.Subtask(tasks.ExtractApiTenantsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OctopusTenant{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_octopus_tenants.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// the commit of a release comes from the build information first, then from the version controlled process
+	dataflowTester.FlushTabler(&models.OctopusRelease{})
+	dataflowTester.Subtask(tasks.ExtractApiReleasesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OctopusRelease{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_octopus_releases.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.OctopusDeployment{})
+	dataflowTester.Subtask(tasks.ExtractApiDeploymentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OctopusDeployment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_octopus_deployments.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.OctopusTask{})
+	dataflowTester.Subtask(tasks.ExtractApiTasksMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OctopusTask{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_octopus_tasks.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertProjectMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CicdEnvironment{})
+	dataflowTester.Subtask(tasks.ConvertEnvironmentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdEnvironment{},
+		"./snapshot_tables/cicd_environments.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"name",
+			"type",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CicdRelease{})
+	dataflowTester.Subtask(tasks.ConvertReleasesMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdRelease{},
+		"./snapshot_tables/cicd_releases.csv",
+		[]string{
+			"id",
+			"name",
+			"version",
+			"description",
+			"cicd_scope_id",
+			"commit_sha",
+			"prev_release_id",
+			"created_date",
+			"published_date",
+		},
+	)
+
+	// the deployment whose task was removed by the retention policy is skipped
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"started_date",
+			"finished_date",
+			"queued_date",
+			"queued_duration_sec",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"result",
+			"status",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+			"commit_sha",
+			"ref_name",
+			"repo_id",
+			"repo_url",
+		},
+	)
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*OctopusConnection)(nil)
+
+// OctopusApiKey authenticates with an API key of a user or a service account, i.e. API-XXXXXXXX
+type OctopusApiKey api.AccessToken
+
+// SetupAuthentication sets up the request headers for authentication
+func (ak *OctopusApiKey) SetupAuthentication(request *http.Request) errors.Error {
+	request.Header.Set("X-Octopus-ApiKey", ak.Token)
+	return nil
+}
+
+// OctopusConn holds the essential information to connect to the Octopus Deploy server,
+// the endpoint is the url of the server, i.e. https://octopus.example.com/ or https://example.octopus.app/
+type OctopusConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	OctopusApiKey      `mapstructure:",squash"`
+}
+
+// OctopusConnection holds OctopusConn plus ID/Name for database storage
+type OctopusConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	OctopusConn        `mapstructure:",squash"`
+}
+
+func (OctopusConnection) TableName() string {
+	return "_tool_octopus_connections"
+}
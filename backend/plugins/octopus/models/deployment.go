@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// states of the server tasks running the deployments
+const (
+	TASK_STATE_QUEUED     = "Queued"
+	TASK_STATE_EXECUTING  = "Executing"
+	TASK_STATE_CANCELLING = "Cancelling"
+	TASK_STATE_SUCCESS    = "Success"
+	TASK_STATE_FAILED     = "Failed"
+	TASK_STATE_TIMED_OUT  = "TimedOut"
+	TASK_STATE_CANCELED   = "Canceled"
+)
+
+// OctopusDeployment is a deployment of a release to an environment, for a tenant when the project is tenanted
+type OctopusDeployment struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	DeploymentId  string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId     string `gorm:"index;type:varchar(100)"`
+	ReleaseId     string `gorm:"type:varchar(100)"`
+	EnvironmentId string `gorm:"type:varchar(100)"`
+	TenantId      string `gorm:"type:varchar(100)"`
+	TaskId        string `gorm:"type:varchar(100)"`
+	Name          string `gorm:"type:varchar(255)"`
+	DeployedBy    string `gorm:"type:varchar(255)"`
+	Created       *time.Time
+	common.NoPKModel
+}
+
+func (OctopusDeployment) TableName() string {
+	return "_tool_octopus_deployments"
+}
+
+// OctopusTask is the server task running a deployment, it holds the state and the timing of the deployment
+type OctopusTask struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	TaskId        string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId     string `gorm:"index;type:varchar(100)"`
+	DeploymentId  string `gorm:"type:varchar(100)"`
+	State         string `gorm:"type:varchar(20)"`
+	ErrorMessage  string
+	QueueTime     *time.Time
+	StartTime     *time.Time
+	CompletedTime *time.Time
+	common.NoPKModel
+}
+
+func (OctopusTask) TableName() string {
+	return "_tool_octopus_tasks"
+}
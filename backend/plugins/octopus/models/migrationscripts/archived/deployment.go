@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type OctopusDeployment struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	DeploymentId  string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId     string `gorm:"index;type:varchar(100)"`
+	ReleaseId     string `gorm:"type:varchar(100)"`
+	EnvironmentId string `gorm:"type:varchar(100)"`
+	TenantId      string `gorm:"type:varchar(100)"`
+	TaskId        string `gorm:"type:varchar(100)"`
+	Name          string `gorm:"type:varchar(255)"`
+	DeployedBy    string `gorm:"type:varchar(255)"`
+	Created       *time.Time
+	archived.NoPKModel
+}
+
+func (OctopusDeployment) TableName() string {
+	return "_tool_octopus_deployments"
+}
+
+type OctopusTask struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	TaskId        string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId     string `gorm:"index;type:varchar(100)"`
+	DeploymentId  string `gorm:"type:varchar(100)"`
+	State         string `gorm:"type:varchar(20)"`
+	ErrorMessage  string
+	QueueTime     *time.Time
+	StartTime     *time.Time
+	CompletedTime *time.Time
+	archived.NoPKModel
+}
+
+func (OctopusTask) TableName() string {
+	return "_tool_octopus_tasks"
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*OctopusProject)(nil)
+var _ plugin.ApiGroup = (*OctopusApiSpace)(nil)
+var _ plugin.ApiScope = (*OctopusApiProject)(nil)
+
+// OctopusProject is a project of Octopus Deploy, its releases are deployed to the environments of its space
+type OctopusProject struct {
+	ConnectionId uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	ProjectId    string `json:"projectId" gorm:"primaryKey;type:varchar(100)" validate:"required" mapstructure:"projectId"`
+	SpaceId      string `json:"spaceId" gorm:"type:varchar(100)" mapstructure:"spaceId,omitempty"`
+	Name         string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Slug         string `json:"slug" gorm:"type:varchar(255)" mapstructure:"slug,omitempty"`
+	Description  string `json:"description" mapstructure:"description,omitempty"`
+	// RepoUrl is the repository the process of the project is stored in when it is version controlled
+	RepoUrl              string `json:"repoUrl" gorm:"type:varchar(255)" mapstructure:"repoUrl,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (OctopusProject) TableName() string {
+	return "_tool_octopus_projects"
+}
+
+func (p OctopusProject) ScopeId() string {
+	return p.ProjectId
+}
+
+func (p OctopusProject) ScopeName() string {
+	return p.Name
+}
+
+// OctopusApiProject is a project returned by the api, only the fields of the scope are kept
+type OctopusApiProject struct {
+	Id                  string `json:"Id"`
+	SpaceId             string `json:"SpaceId"`
+	Name                string `json:"Name"`
+	Slug                string `json:"Slug"`
+	Description         string `json:"Description"`
+	PersistenceSettings struct {
+		Type string `json:"Type"`
+		Url  string `json:"Url"`
+	} `json:"PersistenceSettings"`
+}
+
+func (p OctopusApiProject) ConvertApiScope() plugin.ToolLayerScope {
+	return &OctopusProject{
+		ProjectId:   p.Id,
+		SpaceId:     p.SpaceId,
+		Name:        p.Name,
+		Slug:        p.Slug,
+		Description: p.Description,
+		RepoUrl:     p.PersistenceSettings.Url,
+	}
+}
+
+// OctopusApiSpace is a space of the server, the projects are grouped by their space
+type OctopusApiSpace struct {
+	Id   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+func (s OctopusApiSpace) GroupId() string {
+	return s.Id
+}
+
+func (s OctopusApiSpace) GroupName() string {
+	return s.Name
+}
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// OctopusRelease is a version of the project, the commit is the one its packages were built from or the one of
+// its version controlled process
+type OctopusRelease struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ReleaseId    string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectId    string `gorm:"index;type:varchar(100)"`
+	ChannelId    string `gorm:"type:varchar(100)"`
+	Version      string `gorm:"type:varchar(255)"`
+	ReleaseNotes string
+	CommitSha    string `gorm:"type:varchar(40)"`
+	Branch       string `gorm:"type:varchar(255)"`
+	RepoUrl      string `gorm:"type:varchar(255)"`
+	Assembled    *time.Time
+	common.NoPKModel
+}
+
+func (OctopusRelease) TableName() string {
+	return "_tool_octopus_releases"
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type OctopusApiParams struct {
+	ConnectionId uint64
+	ProjectId    string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *OctopusTaskData) {
+	data := taskCtx.GetData().(*OctopusTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: OctopusApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ProjectId:    data.Options.ProjectId,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// spaceUrl prefixes the path with the space of the project, the resources of the other spaces are not visible
+func spaceUrl(data *OctopusTaskData, path string) string {
+	return fmt.Sprintf("api/%s/%s", data.Options.SpaceId, path)
+}
+
+// pageQuery pages the lists by skipping the items of the previous pages
+func pageQuery(reqData *api.RequestData) url.Values {
+	query := url.Values{}
+	query.Set("skip", fmt.Sprintf("%v", reqData.Pager.Skip))
+	query.Set("take", fmt.Sprintf("%v", reqData.Pager.Size))
+	return query
+}
+
+// parseItems returns the items of a page, the collection is finished once the oldest item on the page was
+// created before since, the lists are ordered from the most recent item
+func parseItems(timeField string, since *time.Time) func(res *http.Response) ([]json.RawMessage, errors.Error) {
+	return func(res *http.Response) ([]json.RawMessage, errors.Error) {
+		body := &struct {
+			Items []json.RawMessage `json:"Items"`
+		}{}
+		err := api.UnmarshalResponse(res, body)
+		if err != nil || len(body.Items) == 0 || since == nil {
+			return body.Items, err
+		}
+		oldest := map[string]json.RawMessage{}
+		err = errors.Convert(json.Unmarshal(body.Items[len(body.Items)-1], &oldest))
+		if err != nil {
+			return nil, err
+		}
+		var oldestTime *time.Time
+		if oldest[timeField] != nil {
+			err = errors.Convert(json.Unmarshal(oldest[timeField], &oldestTime))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if oldestTime != nil && oldestTime.Before(*since) {
+			return body.Items, api.ErrFinishCollect
+		}
+		return body.Items, nil
+	}
+}
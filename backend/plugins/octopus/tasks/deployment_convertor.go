@@ -0,0 +1,251 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table octopus_deployments into domain layer table cicd_pipelines, cicd_tasks and cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+var taskResultRule = &devops.ResultRule{
+	Success: []string{models.TASK_STATE_SUCCESS},
+	Failed:  []string{models.TASK_STATE_FAILED, models.TASK_STATE_TIMED_OUT},
+	Abort:   []string{models.TASK_STATE_CANCELED},
+	Default: "",
+}
+
+var taskStatusRule = &devops.StatusRule{
+	InProgress: []string{models.TASK_STATE_QUEUED, models.TASK_STATE_EXECUTING, models.TASK_STATE_CANCELLING},
+	Default:    devops.DONE,
+}
+
+// octopusDeploymentContext holds what the deployments refer to by their ids
+type octopusDeploymentContext struct {
+	project      *models.OctopusProject
+	releases     map[string]*models.OctopusRelease
+	environments map[string]*models.OctopusEnvironment
+	tenants      map[string]*models.OctopusTenant
+	tasks        map[string]*models.OctopusTask
+}
+
+func loadDeploymentContext(db dal.Dal, data *OctopusTaskData) (*octopusDeploymentContext, errors.Error) {
+	ctx := &octopusDeploymentContext{
+		project:      &models.OctopusProject{},
+		releases:     make(map[string]*models.OctopusRelease),
+		environments: make(map[string]*models.OctopusEnvironment),
+		tenants:      make(map[string]*models.OctopusTenant),
+		tasks:        make(map[string]*models.OctopusTask),
+	}
+	err := db.First(ctx.project, dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	var releases []models.OctopusRelease
+	err = db.All(&releases, dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	for i := range releases {
+		ctx.releases[releases[i].ReleaseId] = &releases[i]
+	}
+	var environments []models.OctopusEnvironment
+	err = db.All(&environments, dal.Where("connection_id = ? AND space_id = ?", data.Options.ConnectionId, data.Options.SpaceId))
+	if err != nil {
+		return nil, err
+	}
+	for i := range environments {
+		ctx.environments[environments[i].EnvironmentId] = &environments[i]
+	}
+	var tenants []models.OctopusTenant
+	err = db.All(&tenants, dal.Where("connection_id = ? AND space_id = ?", data.Options.ConnectionId, data.Options.SpaceId))
+	if err != nil {
+		return nil, err
+	}
+	for i := range tenants {
+		ctx.tenants[tenants[i].TenantId] = &tenants[i]
+	}
+	var tasks []models.OctopusTask
+	err = db.All(&tasks, dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	for i := range tasks {
+		ctx.tasks[tasks[i].TaskId] = &tasks[i]
+	}
+	return ctx, nil
+}
+
+// name tells which release was deployed where, i.e. `1.4.2 to Production for Acme`
+func (ctx *octopusDeploymentContext) name(deployment *models.OctopusDeployment) string {
+	name := deployment.Name
+	if release := ctx.releases[deployment.ReleaseId]; release != nil {
+		name = release.Version
+	}
+	if environment := ctx.environments[deployment.EnvironmentId]; environment != nil {
+		name = fmt.Sprintf("%s to %s", name, environment.Name)
+	}
+	if tenant := ctx.tenants[deployment.TenantId]; tenant != nil {
+		name = fmt.Sprintf("%s for %s", name, tenant.Name)
+	}
+	return name
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	deploymentCtx, err := loadDeploymentContext(db, data)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.OctopusDeployment{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.OctopusDeployment{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.OctopusProject{})
+	scopeId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OctopusDeployment{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*models.OctopusDeployment)
+			// the state of the deployment is the one of its task, the tasks are removed by the retention policies
+			task := deploymentCtx.tasks[deployment.TaskId]
+			if deployment.Created == nil || task == nil {
+				return nil, nil
+			}
+			environment := ""
+			if octopusEnvironment := deploymentCtx.environments[deployment.EnvironmentId]; octopusEnvironment != nil {
+				environment = data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, octopusEnvironment.Name)
+			}
+			startedDate := task.StartTime
+			if startedDate == nil {
+				startedDate = deployment.Created
+			}
+			id := deploymentIdGen.Generate(deployment.ConnectionId, deployment.DeploymentId)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         deploymentCtx.name(deployment),
+				Result:       devops.GetResult(taskResultRule, task.State),
+				Status:       devops.GetStatus(taskStatusRule, task.State),
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				CreatedDate:  *deployment.Created,
+				CicdScopeId:  scopeId,
+			}
+			if domainPipeline.Status == devops.DONE && task.CompletedTime != nil {
+				domainPipeline.FinishedDate = task.CompletedTime
+				domainPipeline.DurationSec = uint64(task.CompletedTime.Sub(*startedDate).Seconds())
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         domainPipeline.Name,
+				PipelineId:   id,
+				Result:       domainPipeline.Result,
+				Status:       domainPipeline.Status,
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				DurationSec:  domainPipeline.DurationSec,
+				StartedDate:  *startedDate,
+				FinishedDate: domainPipeline.FinishedDate,
+				CicdScopeId:  scopeId,
+				QueuedDate:   task.QueueTime,
+			}
+			if task.QueueTime != nil && task.StartTime != nil {
+				queuedDurationSec := uint64(task.StartTime.Sub(*task.QueueTime).Seconds())
+				domainTask.QueuedDurationSec = &queuedDurationSec
+			}
+			results := []interface{}{domainPipeline, domainTask}
+
+			// the releases built outside of a repository, or by a build server not pushing the build information,
+			// are not linked to a commit
+			release := deploymentCtx.releases[deployment.ReleaseId]
+			if release == nil || release.CommitSha == "" {
+				return results, nil
+			}
+			repoUrl := release.RepoUrl
+			if repoUrl == "" {
+				repoUrl = deploymentCtx.project.RepoUrl
+			}
+			if repoUrl == "" {
+				return results, nil
+			}
+			domainDeployCommit := &devops.CicdDeploymentCommit{
+				// the id is the one dora derives from the pipeline commit, so that both end up with the same row
+				DomainEntity:     domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", id, repoUrl)},
+				CicdScopeId:      scopeId,
+				CicdDeploymentId: id,
+				Name:             domainPipeline.Name,
+				Result:           domainPipeline.Result,
+				Status:           domainPipeline.Status,
+				Environment:      environment,
+				CreatedDate:      *deployment.Created,
+				StartedDate:      startedDate,
+				FinishedDate:     domainPipeline.FinishedDate,
+				CommitSha:        release.CommitSha,
+				RefName:          release.Branch,
+				RepoUrl:          repoUrl,
+			}
+			if domainPipeline.FinishedDate != nil {
+				durationSec := domainPipeline.DurationSec
+				domainDeployCommit.DurationSec = &durationSec
+			}
+			results = append(results,
+				&devops.CiCDPipelineCommit{
+					PipelineId: id,
+					CommitSha:  release.CommitSha,
+					Branch:     release.Branch,
+					RepoUrl:    repoUrl,
+				},
+				domainDeployCommit,
+			)
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
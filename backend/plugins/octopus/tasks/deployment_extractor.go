@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+)
+
+var ExtractApiDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiDeployments",
+	EntryPoint:       ExtractApiDeployments,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployments data into tool layer table _tool_octopus_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type OctopusApiDeployment struct {
+	Id            string     `json:"Id"`
+	ReleaseId     string     `json:"ReleaseId"`
+	EnvironmentId string     `json:"EnvironmentId"`
+	TenantId      string     `json:"TenantId"`
+	TaskId        string     `json:"TaskId"`
+	Name          string     `json:"Name"`
+	DeployedBy    string     `json:"DeployedBy"`
+	Created       *time.Time `json:"Created"`
+}
+
+func ExtractApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiDeployment := &OctopusApiDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiDeployment))
+			if err != nil {
+				return nil, err
+			}
+			// the deployments created before timeAfter are skipped
+			if data.TimeAfter != nil && apiDeployment.Created != nil && apiDeployment.Created.Before(*data.TimeAfter) {
+				return nil, nil
+			}
+			return []interface{}{
+				&models.OctopusDeployment{
+					ConnectionId:  data.Options.ConnectionId,
+					DeploymentId:  apiDeployment.Id,
+					ProjectId:     data.Options.ProjectId,
+					ReleaseId:     apiDeployment.ReleaseId,
+					EnvironmentId: apiDeployment.EnvironmentId,
+					TenantId:      apiDeployment.TenantId,
+					TaskId:        apiDeployment.TaskId,
+					Name:          apiDeployment.Name,
+					DeployedBy:    apiDeployment.DeployedBy,
+					Created:       apiDeployment.Created,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+)
+
+var ConvertEnvironmentsMeta = plugin.SubTaskMeta{
+	Name:             "convertEnvironments",
+	EntryPoint:       ConvertEnvironments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table octopus_environments into domain layer table cicd_environments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertEnvironments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ENVIRONMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.OctopusEnvironment{}),
+		dal.Where("connection_id = ? AND space_id = ?", data.Options.ConnectionId, data.Options.SpaceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	environmentIdGen := didgen.NewDomainIdGenerator(&models.OctopusEnvironment{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.OctopusProject{})
+	scopeId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OctopusEnvironment{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			environment := inputRow.(*models.OctopusEnvironment)
+			return []interface{}{
+				&devops.CicdEnvironment{
+					// the environments are shared by the projects of the space, each project gets its own copy
+					DomainEntity: domainlayer.DomainEntity{
+						Id: environmentIdGen.Generate(environment.ConnectionId, data.Options.ProjectId, environment.EnvironmentId),
+					},
+					CicdScopeId: scopeId,
+					Name:        environment.Name,
+					Type:        data.RegexEnricher.ReturnNameIfMatched(devops.PRODUCTION, environment.Name),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
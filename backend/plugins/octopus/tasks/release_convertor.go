@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+)
+
+var ConvertReleasesMeta = plugin.SubTaskMeta{
+	Name:             "convertReleases",
+	EntryPoint:       ConvertReleases,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table octopus_releases into domain layer table cicd_releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_RELEASE_TABLE)
+	db := taskCtx.GetDal()
+
+	// the releases are ordered by the time they were created so that each one knows the previous one
+	cursor, err := db.Cursor(
+		dal.From(&models.OctopusRelease{}),
+		dal.Where("connection_id = ? AND project_id = ? AND assembled IS NOT NULL", data.Options.ConnectionId, data.Options.ProjectId),
+		dal.Orderby("assembled ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	releaseIdGen := didgen.NewDomainIdGenerator(&models.OctopusRelease{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.OctopusProject{})
+	scopeId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	prevReleaseId := ""
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OctopusRelease{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			octopusRelease := inputRow.(*models.OctopusRelease)
+			release := &devops.CicdRelease{
+				DomainEntity:  domainlayer.DomainEntity{Id: releaseIdGen.Generate(octopusRelease.ConnectionId, octopusRelease.ReleaseId)},
+				Name:          octopusRelease.Version,
+				Version:       octopusRelease.Version,
+				Description:   octopusRelease.ReleaseNotes,
+				CicdScopeId:   scopeId,
+				CommitSha:     octopusRelease.CommitSha,
+				PrevReleaseId: prevReleaseId,
+				CreatedDate:   *octopusRelease.Assembled,
+				PublishedDate: octopusRelease.Assembled,
+			}
+			prevReleaseId = release.Id
+			return []interface{}{release}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+)
+
+var ExtractApiReleasesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiReleases",
+	EntryPoint:       ExtractApiReleases,
+	EnabledByDefault: true,
+	Description:      "Extract raw releases data into tool layer table _tool_octopus_releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type OctopusApiBuildInformation struct {
+	PackageId       string `json:"PackageId"`
+	Version         string `json:"Version"`
+	Branch          string `json:"Branch"`
+	VcsType         string `json:"VcsType"`
+	VcsRoot         string `json:"VcsRoot"`
+	VcsCommitNumber string `json:"VcsCommitNumber"`
+}
+
+type OctopusApiRelease struct {
+	Id                      string                       `json:"Id"`
+	ProjectId               string                       `json:"ProjectId"`
+	ChannelId               string                       `json:"ChannelId"`
+	Version                 string                       `json:"Version"`
+	ReleaseNotes            string                       `json:"ReleaseNotes"`
+	Assembled               *time.Time                   `json:"Assembled"`
+	BuildInformation        []OctopusApiBuildInformation `json:"BuildInformation"`
+	VersionControlReference *struct {
+		GitRef    string `json:"GitRef"`
+		GitCommit string `json:"GitCommit"`
+	} `json:"VersionControlReference"`
+}
+
+func ExtractApiReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_RELEASE_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiRelease := &OctopusApiRelease{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiRelease))
+			if err != nil {
+				return nil, err
+			}
+			release := &models.OctopusRelease{
+				ConnectionId: data.Options.ConnectionId,
+				ReleaseId:    apiRelease.Id,
+				ProjectId:    data.Options.ProjectId,
+				ChannelId:    apiRelease.ChannelId,
+				Version:      apiRelease.Version,
+				ReleaseNotes: apiRelease.ReleaseNotes,
+				Assembled:    apiRelease.Assembled,
+			}
+			// the commit is the one the packages were built from as pushed by the build server, or the one the
+			// version controlled process was taken from, which is in the repository of the project
+			for _, buildInformation := range apiRelease.BuildInformation {
+				if buildInformation.VcsCommitNumber != "" {
+					release.CommitSha = buildInformation.VcsCommitNumber
+					release.Branch = strings.TrimPrefix(buildInformation.Branch, "refs/heads/")
+					release.RepoUrl = buildInformation.VcsRoot
+					break
+				}
+			}
+			if release.CommitSha == "" && apiRelease.VersionControlReference != nil {
+				release.CommitSha = apiRelease.VersionControlReference.GitCommit
+				release.Branch = strings.TrimPrefix(apiRelease.VersionControlReference.GitRef, "refs/heads/")
+			}
+			return []interface{}{release}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_TASK_TABLE = "octopus_api_tasks"
+
+var CollectApiTasksMeta = plugin.SubTaskMeta{
+	Name:             "collectApiTasks",
+	EntryPoint:       CollectApiTasks,
+	EnabledByDefault: true,
+	Description:      "Collect the server tasks running the deployments of the project from Octopus Deploy api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiTasks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TASK_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	incremental := collectorWithState.IsIncremental()
+	since := collectorWithState.TimeAfter
+	if incremental {
+		since = collectorWithState.LatestState.LatestSuccessStart
+	}
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: incremental,
+		UrlTemplate: spaceUrl(data, "tasks"),
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := pageQuery(reqData)
+			query.Set("project", data.Options.ProjectId)
+			// the deployments are run by the tasks named Deploy, the others are health checks, retentions...
+			query.Set("name", "Deploy")
+			return query, nil
+		},
+		ResponseParser: parseItems("QueueTime", since),
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/octopus/models"
+)
+
+var ExtractApiTasksMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTasks",
+	EntryPoint:       ExtractApiTasks,
+	EnabledByDefault: true,
+	Description:      "Extract raw server tasks data into tool layer table _tool_octopus_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type OctopusApiTask struct {
+	Id        string `json:"Id"`
+	Arguments struct {
+		DeploymentId string `json:"DeploymentId"`
+	} `json:"Arguments"`
+	State         string     `json:"State"`
+	ErrorMessage  string     `json:"ErrorMessage"`
+	QueueTime     *time.Time `json:"QueueTime"`
+	StartTime     *time.Time `json:"StartTime"`
+	CompletedTime *time.Time `json:"CompletedTime"`
+}
+
+func ExtractApiTasks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TASK_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiTask := &OctopusApiTask{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiTask))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.OctopusTask{
+					ConnectionId:  data.Options.ConnectionId,
+					TaskId:        apiTask.Id,
+					ProjectId:     data.Options.ProjectId,
+					DeploymentId:  apiTask.Arguments.DeploymentId,
+					State:         apiTask.State,
+					ErrorMessage:  apiTask.ErrorMessage,
+					QueueTime:     apiTask.QueueTime,
+					StartTime:     apiTask.StartTime,
+					CompletedTime: apiTask.CompletedTime,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
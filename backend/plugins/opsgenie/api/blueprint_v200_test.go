@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	connection := &models.OpsgenieConnection{
+		BaseConnection: helper.BaseConnection{
+			Name: "opsgenie-test",
+			Model: common.Model{
+				ID: 1,
+			},
+		},
+		OpsgenieConn: models.OpsgenieConn{
+			RestConnection: helper.RestConnection{
+				Endpoint:         "https://api.opsgenie.com/",
+				Proxy:            "",
+				RateLimitPerHour: 0,
+			},
+			OpsgenieAccessToken: models.OpsgenieAccessToken{
+				Token: "secret",
+			},
+		},
+	}
+	mockMeta := mockplugin.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/opsgenie")
+	err := plugin.RegisterPlugin("opsgenie", mockMeta)
+	assert.Nil(t, err)
+	// Refresh Global Variables and set the sql mock
+	basicRes = NewMockBasicRes()
+	bs := &plugin.BlueprintScopeV200{
+		Entities: []string{"TICKET"},
+		Id:       "5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+	}
+	bpScopes := make([]*plugin.BlueprintScopeV200, 0)
+	bpScopes = append(bpScopes, bs)
+	syncPolicy := &plugin.BlueprintSyncPolicy{}
+
+	plan := make(plugin.PipelinePlan, len(bpScopes))
+	plan, err = makeDataSourcePipelinePlanV200(nil, plan, bpScopes, connection, syncPolicy)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes()
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	expectPlan := plugin.PipelinePlan{
+		plugin.PipelineStage{
+			{
+				Plugin:   "opsgenie",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"serviceId":            "5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+					"connectionId":         uint64(1),
+					"transformationRuleId": uint64(1),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := make([]plugin.Scope, 0)
+	scopeTicket := &ticket.Board{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: "opsgenie:OpsgenieService:1:5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+		},
+		Name:        "checkout",
+		Description: "the checkout of the web shop",
+		Url:         "https://example.app.opsgenie.com/service/5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+	}
+	expectScopes = append(expectScopes, scopeTicket)
+	assert.Equal(t, expectScopes, scopes)
+}
+
+// NewMockBasicRes FIXME ...
+func NewMockBasicRes() *mockcontext.BasicRes {
+	testOpsgenieService := &models.OpsgenieService{
+		ConnectionId:         1,
+		OpsgenieId:           "5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+		Name:                 "checkout",
+		Description:          "the checkout of the web shop",
+		TeamId:               "8d1c3a0e-6f7b-4e2a-9c55-2b7f4a1d9e60",
+		Url:                  "https://example.app.opsgenie.com/service/5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+		TransformationRuleId: 1,
+	}
+
+	mockRes := new(mockcontext.BasicRes)
+	mockDal := new(mockdal.Dal)
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dst := args.Get(0).(*models.OpsgenieService)
+		*dst = *testOpsgenieService
+	}).Return(nil).Once()
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
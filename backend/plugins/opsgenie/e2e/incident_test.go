@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/impl"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/tasks"
+)
+
+func TestOpsgenieIncidentDataFlow(t *testing.T) {
+
+	var opsgenie impl.Opsgenie
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "opsgenie", opsgenie)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(ticket.INCIDENT, "P1|P2")
+	taskData := &tasks.OpsgenieTaskData{
+		Options: &tasks.OpsgenieOptions{
+			ConnectionId: 1,
+			ServiceId:    "5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_opsgenie_services.csv", &models.OpsgenieService{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_teams.csv", "_raw_opsgenie_api_teams")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_incidents.csv", "_raw_opsgenie_api_incidents")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_alerts.csv", "_raw_opsgenie_api_alerts")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.OpsgenieTeam{})
+	dataflowTester.FlushTabler(&models.OpsgenieUser{})
+	dataflowTester.Subtask(tasks.ExtractApiTeamMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieTeam{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_teams.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieUser{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_users.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.OpsgenieIncident{})
+	dataflowTester.FlushTabler(&models.OpsgenieIncidentResponder{})
+	dataflowTester.Subtask(tasks.ExtractApiIncidentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieIncident{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_incidents.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieIncidentResponder{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_incident_responders.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.OpsgenieAlert{})
+	dataflowTester.Subtask(tasks.ExtractApiAlertsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieAlert{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_alerts.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertServiceMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Board{},
+		"./snapshot_tables/boards.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.Subtask(tasks.ConvertUsersMeta, taskData)
+	dataflowTester.VerifyTable(
+		crossdomain.Account{},
+		"./snapshot_tables/accounts.csv",
+		[]string{
+			"id",
+			"email",
+			"full_name",
+			"user_name",
+			"avatar_url",
+			"organization",
+			"created_date",
+			"status",
+		},
+	)
+
+	// both the incidents and the alerts matching the priority pattern are converted into incidents
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueResponder{})
+	dataflowTester.Subtask(tasks.ConvertIncidentsMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertAlertsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"status",
+			"original_status",
+			"priority",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"creator_name",
+			"assignee_name",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		[]string{
+			"board_id",
+			"issue_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.IssueResponder{},
+		"./snapshot_tables/issue_responders.csv",
+		[]string{
+			"issue_id",
+			"account_id",
+			"escalation_policy_id",
+			"escalation_level",
+			"assigned_date",
+			"acknowledged_date",
+			"ack_time_minutes",
+		},
+	)
+}
@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/impl"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/tasks"
+)
+
+func TestOpsgenieOncallDataFlow(t *testing.T) {
+
+	var opsgenie impl.Opsgenie
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "opsgenie", opsgenie)
+
+	taskData := &tasks.OpsgenieTaskData{
+		Options: &tasks.OpsgenieOptions{
+			ConnectionId: 1,
+			ServiceId:    "5a5fe2c4-2f4b-4b43-9f36-0e3a4f2e6b31",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_opsgenie_services.csv", &models.OpsgenieService{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_schedules.csv", "_raw_opsgenie_api_schedules")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_schedule_timelines.csv", "_raw_opsgenie_api_schedule_timelines")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_opsgenie_api_escalations.csv", "_raw_opsgenie_api_escalations")
+
+	// verify extraction, the schedules and the escalations of other teams are skipped
+	dataflowTester.FlushTabler(&models.OpsgenieSchedule{})
+	dataflowTester.Subtask(tasks.ExtractApiSchedulesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieSchedule{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_schedules.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.OpsgenieOncallPeriod{})
+	dataflowTester.FlushTabler(&models.OpsgenieUser{})
+	dataflowTester.Subtask(tasks.ExtractApiTimelinesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieOncallPeriod{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_oncall_periods.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.OpsgenieEscalation{})
+	dataflowTester.FlushTabler(&models.OpsgenieEscalationRule{})
+	dataflowTester.Subtask(tasks.ExtractApiEscalationsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieEscalation{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_escalations.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.OpsgenieEscalationRule{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_opsgenie_escalation_rules.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.OncallSchedule{})
+	dataflowTester.Subtask(tasks.ConvertSchedulesMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.OncallSchedule{},
+		"./snapshot_tables/oncall_schedules.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+			"time_zone",
+		},
+	)
+
+	dataflowTester.FlushTabler(&ticket.OncallShift{})
+	dataflowTester.Subtask(tasks.ConvertOncallPeriodsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.OncallShift{},
+		"./snapshot_tables/oncall_shifts.csv",
+		[]string{
+			"schedule_id",
+			"account_id",
+			"start_date",
+			"end_date",
+		},
+	)
+
+	// the rules of teams are skipped, the delays are relative to the previous level
+	dataflowTester.FlushTabler(&ticket.EscalationPolicy{})
+	dataflowTester.FlushTabler(&ticket.EscalationRule{})
+	dataflowTester.Subtask(tasks.ConvertEscalationsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.EscalationPolicy{},
+		"./snapshot_tables/escalation_policies.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+			"num_loops",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.EscalationRule{},
+		"./snapshot_tables/escalation_rules.csv",
+		[]string{
+			"escalation_policy_id",
+			"level",
+			"target_type",
+			"target_id",
+			"delay_minutes",
+		},
+	)
+}
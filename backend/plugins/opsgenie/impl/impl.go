@@ -0,0 +1,266 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/tasks"
+)
+
+var _ plugin.PluginMeta = (*Opsgenie)(nil)
+var _ plugin.PluginInit = (*Opsgenie)(nil)
+var _ plugin.PluginTask = (*Opsgenie)(nil)
+var _ plugin.PluginApi = (*Opsgenie)(nil)
+var _ plugin.PluginModel = (*Opsgenie)(nil)
+var _ plugin.PluginMigration = (*Opsgenie)(nil)
+var _ plugin.CloseablePluginTask = (*Opsgenie)(nil)
+var _ plugin.PluginSource = (*Opsgenie)(nil)
+
+type Opsgenie string
+
+func (p Opsgenie) Connection() interface{} {
+	return &models.OpsgenieConnection{}
+}
+
+func (p Opsgenie) Scope() interface{} {
+	return &models.OpsgenieService{}
+}
+
+func (p Opsgenie) TransformationRule() interface{} {
+	return &models.OpsgenieTransformationRule{}
+}
+
+func (p Opsgenie) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Opsgenie) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.OpsgenieConnection{},
+		&models.OpsgenieService{},
+		&models.OpsgenieTransformationRule{},
+		&models.OpsgenieTeam{},
+		&models.OpsgenieUser{},
+		&models.OpsgenieIncident{},
+		&models.OpsgenieIncidentResponder{},
+		&models.OpsgenieAlert{},
+		&models.OpsgenieSchedule{},
+		&models.OpsgenieOncallPeriod{},
+		&models.OpsgenieEscalation{},
+		&models.OpsgenieEscalationRule{},
+	}
+}
+
+func (p Opsgenie) Description() string {
+	return "To collect and enrich data from Opsgenie"
+}
+
+func (p Opsgenie) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiTeamMeta,
+		tasks.ExtractApiTeamMeta,
+		tasks.CollectApiIncidentsMeta,
+		tasks.ExtractApiIncidentsMeta,
+		tasks.CollectApiAlertsMeta,
+		tasks.ExtractApiAlertsMeta,
+		tasks.CollectApiSchedulesMeta,
+		tasks.ExtractApiSchedulesMeta,
+		tasks.CollectApiTimelinesMeta,
+		tasks.ExtractApiTimelinesMeta,
+		tasks.CollectApiEscalationsMeta,
+		tasks.ExtractApiEscalationsMeta,
+
+		tasks.ConvertServiceMeta,
+		tasks.ConvertUsersMeta,
+		tasks.ConvertIncidentsMeta,
+		tasks.ConvertAlertsMeta,
+		tasks.ConvertSchedulesMeta,
+		tasks.ConvertOncallPeriodsMeta,
+		tasks.ConvertEscalationsMeta,
+	}
+}
+
+func (p Opsgenie) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.OpsgenieConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get opsgenie connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get opsgenie API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeAfter time.Time
+	if op.TimeAfter != "" {
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+	}
+	regexEnricher := helper.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(ticket.INCIDENT, op.IncidentAlertPriorityPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `incidentAlertPriorityPattern`")
+	}
+	taskData := &tasks.OpsgenieTaskData{
+		Options:       op,
+		ApiClient:     apiClient,
+		RegexEnricher: regexEnricher,
+	}
+	if !timeAfter.IsZero() {
+		taskData.TimeAfter = &timeAfter
+		logger.Debug("collect data updated timeAfter %s", timeAfter)
+	}
+
+	return taskData, nil
+}
+
+func (p Opsgenie) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/opsgenie"
+}
+
+func (p Opsgenie) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Opsgenie) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Opsgenie) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/*scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
+	}
+}
+
+func (p Opsgenie) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.OpsgenieTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.OpsgenieOptions,
+	apiClient *helper.ApiClient) errors.Error {
+	var service models.OpsgenieService
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&service, dal.Where(
+		"connection_id = ? AND opsgenie_id = ?",
+		op.ConnectionId, op.ServiceId))
+	if err == nil {
+		if op.TransformationRuleId == 0 {
+			op.TransformationRuleId = service.TransformationRuleId
+		}
+	} else {
+		if db.IsErrorNotFound(err) {
+			var apiService *models.OpsgenieApiService
+			apiService, err = tasks.GetApiService(op, apiClient)
+			if err != nil {
+				return err
+			}
+			logger.Debug(fmt.Sprintf("Current service: %s", op.ServiceId))
+			scope := apiService.ConvertApiScope().(*models.OpsgenieService)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find service %s", op.ServiceId))
+		}
+	}
+	if op.OpsgenieTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.OpsgenieTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.OpsgenieTransformationRule = &transformationRule
+	}
+	if op.OpsgenieTransformationRule == nil {
+		op.OpsgenieTransformationRule = new(models.OpsgenieTransformationRule)
+	}
+	return nil
+}
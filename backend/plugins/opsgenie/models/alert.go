@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type OpsgenieAlert struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	OpsgenieId   string `gorm:"primaryKey;type:varchar(255)"`
+	ServiceId    string `gorm:"index;type:varchar(255)"`
+	TinyId       string `gorm:"type:varchar(100)"`
+	Alias        string `gorm:"type:varchar(512)"`
+	Message      string
+	Status       string `gorm:"type:varchar(100)"`
+	Priority     string `gorm:"type:varchar(100)"`
+	Source       string `gorm:"type:varchar(255)"`
+	Owner        string `gorm:"type:varchar(255)"`
+	Count        int
+	CreatedDate  time.Time
+	UpdatedDate  time.Time
+	// AcknowledgedBy is the username of the user who acknowledged the alert first
+	AcknowledgedBy string `gorm:"type:varchar(255)"`
+	AckTimeMs      int64
+	ClosedBy       string `gorm:"type:varchar(255)"`
+	CloseTimeMs    int64
+	common.NoPKModel
+}
+
+func (OpsgenieAlert) TableName() string {
+	return "_tool_opsgenie_alerts"
+}
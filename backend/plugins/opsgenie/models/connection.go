@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*OpsgenieConnection)(nil)
+
+// OpsgenieAccessToken authenticates with an API key of an API integration
+type OpsgenieAccessToken api.AccessToken
+
+// SetupAuthentication sets up the request headers for authentication
+func (at *OpsgenieAccessToken) SetupAuthentication(request *http.Request) errors.Error {
+	request.Header.Set("Authorization", fmt.Sprintf("GenieKey %s", at.Token))
+	return nil
+}
+
+// OpsgenieConn holds the essential information to connect to the Opsgenie API,
+// the endpoint is https://api.opsgenie.com/ or https://api.eu.opsgenie.com/ for the EU instance
+type OpsgenieConn struct {
+	api.RestConnection  `mapstructure:",squash"`
+	OpsgenieAccessToken `mapstructure:",squash"`
+}
+
+// OpsgenieConnection holds OpsgenieConn plus ID/Name for database storage
+type OpsgenieConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	OpsgenieConn       `mapstructure:",squash"`
+}
+
+func (OpsgenieConnection) TableName() string {
+	return "_tool_opsgenie_connections"
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	RECIPIENT_TYPE_USER     = "user"
+	RECIPIENT_TYPE_SCHEDULE = "schedule"
+	RECIPIENT_TYPE_TEAM     = "team"
+)
+
+type OpsgenieEscalation struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	OpsgenieId   string `gorm:"primaryKey;type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	OwnerTeamId  string `gorm:"type:varchar(255)"`
+	RepeatCount  int
+	common.NoPKModel
+}
+
+func (OpsgenieEscalation) TableName() string {
+	return "_tool_opsgenie_escalations"
+}
+
+// OpsgenieEscalationRule notifies its recipient DelayMinutes after the alert was created
+type OpsgenieEscalationRule struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	EscalationId  string `gorm:"primaryKey;type:varchar(255)"`
+	Position      int    `gorm:"primaryKey"`
+	Condition     string `gorm:"type:varchar(100)"`
+	NotifyType    string `gorm:"type:varchar(100)"`
+	RecipientType string `gorm:"type:varchar(100)"`
+	RecipientId   string `gorm:"type:varchar(255)"`
+	DelayMinutes  int
+	common.NoPKModel
+}
+
+func (OpsgenieEscalationRule) TableName() string {
+	return "_tool_opsgenie_escalation_rules"
+}
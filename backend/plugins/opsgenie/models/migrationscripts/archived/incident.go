@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type OpsgenieIncident struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	OpsgenieId   string `gorm:"primaryKey;type:varchar(255)"`
+	ServiceId    string `gorm:"index;type:varchar(255)"`
+	TinyId       string `gorm:"type:varchar(100)"`
+	Message      string
+	Description  string
+	Status       string `gorm:"type:varchar(100)"`
+	Priority     string `gorm:"type:varchar(100)"`
+	OwnerTeamId  string `gorm:"type:varchar(255)"`
+	Url          string `gorm:"type:varchar(255)"`
+	CreatedDate  time.Time
+	UpdatedDate  time.Time
+	archived.NoPKModel
+}
+
+func (OpsgenieIncident) TableName() string {
+	return "_tool_opsgenie_incidents"
+}
+
+type OpsgenieIncidentResponder struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	IncidentId    string `gorm:"primaryKey;type:varchar(255)"`
+	ResponderType string `gorm:"primaryKey;type:varchar(100)"`
+	ResponderId   string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (OpsgenieIncidentResponder) TableName() string {
+	return "_tool_opsgenie_incident_responders"
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type OpsgenieSchedule struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	OpsgenieId   string `gorm:"primaryKey;type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	Timezone     string `gorm:"type:varchar(100)"`
+	Enabled      bool
+	OwnerTeamId  string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (OpsgenieSchedule) TableName() string {
+	return "_tool_opsgenie_schedules"
+}
+
+type OpsgenieOncallPeriod struct {
+	ConnectionId uint64    `gorm:"primaryKey"`
+	ScheduleId   string    `gorm:"primaryKey;type:varchar(255)"`
+	UserId       string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate    time.Time `gorm:"primaryKey"`
+	EndDate      *time.Time
+	RotationName string `gorm:"type:varchar(255)"`
+	Type         string `gorm:"type:varchar(100)"`
+	archived.NoPKModel
+}
+
+func (OpsgenieOncallPeriod) TableName() string {
+	return "_tool_opsgenie_oncall_periods"
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*OpsgenieService)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*OpsgenieApiService)(nil)
+
+// OpsgenieService is a service of the incident management, the incidents impacting it
+// and the alerts, schedules and escalations of its team are collected with it
+type OpsgenieService struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	OpsgenieId           string `json:"opsgenieId" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"opsgenieId"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Description          string `json:"description" mapstructure:"description,omitempty"`
+	TeamId               string `json:"teamId" gorm:"type:varchar(255)" mapstructure:"teamId,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (OpsgenieService) TableName() string {
+	return "_tool_opsgenie_services"
+}
+
+func (s OpsgenieService) ScopeId() string {
+	return s.OpsgenieId
+}
+
+func (s OpsgenieService) ScopeName() string {
+	return s.Name
+}
+
+// OpsgenieApiService is the service entity of the api
+type OpsgenieApiService struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TeamId      string `json:"teamId"`
+	Links       struct {
+		Web string `json:"web"`
+	} `json:"links"`
+}
+
+func (s OpsgenieApiService) ConvertApiScope() plugin.ToolLayerScope {
+	return &OpsgenieService{
+		OpsgenieId:  s.Id,
+		Name:        s.Name,
+		Description: s.Description,
+		TeamId:      s.TeamId,
+		Url:         s.Links.Web,
+	}
+}
+
+// GroupResponse is required by the remote api helper, the services are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+const RAW_ALERT_TABLE = "opsgenie_api_alerts"
+
+var CollectApiAlertsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiAlerts",
+	EntryPoint:       CollectApiAlerts,
+	EnabledByDefault: true,
+	Description:      "Collect the alerts of the team owning the service from Opsgenie api, must run after the team is extracted",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ALERT_TABLE)
+	service, err := getService(taskCtx, data)
+	if err != nil {
+		return err
+	}
+	// alerts are not tied to services, they are searched by the name of the team they are routed to
+	team := &models.OpsgenieTeam{}
+	db := taskCtx.GetDal()
+	err = db.First(team, dal.Where("connection_id = ? AND opsgenie_id = ?", data.Options.ConnectionId, service.TeamId))
+	if db.IsErrorNotFound(err) {
+		taskCtx.GetLogger().Info("no team found for service %s, skip collecting alerts", service.OpsgenieId)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "v2/alerts",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("query", searchQuery(collectorWithState, fmt.Sprintf(`teams:"%s"`, team.Name)))
+			query.Set("sort", "createdAt")
+			query.Set("order", "desc")
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertAlertsMeta = plugin.SubTaskMeta{
+	Name:             "convertAlerts",
+	EntryPoint:       ConvertAlerts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table opsgenie_alerts matching the incident priority pattern into domain layer table issues, board_issues and issue_responders",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// alertWithAcknowledger is an alert joined with the user who acknowledged it
+type alertWithAcknowledger struct {
+	models.OpsgenieAlert
+	AcknowledgerId string
+}
+
+func ConvertAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ALERT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("a.*, u.opsgenie_id AS acknowledger_id"),
+		dal.From("_tool_opsgenie_alerts AS a"),
+		dal.Join("LEFT JOIN _tool_opsgenie_users AS u ON u.connection_id = a.connection_id AND u.username = a.acknowledged_by"),
+		dal.Where("a.connection_id = ? AND a.service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	alertIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieAlert{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieService{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieUser{})
+	boardId := serviceIdGen.Generate(data.Options.ConnectionId, data.Options.ServiceId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(alertWithAcknowledger{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			alert := inputRow.(*alertWithAcknowledger)
+			// only the alerts of the priorities configured by the transformation rule are incidents
+			if data.RegexEnricher.ReturnNameIfMatched(ticket.INCIDENT, alert.Priority) == "" {
+				return nil, nil
+			}
+			domainIssue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: alertIdGen.Generate(alert.ConnectionId, alert.OpsgenieId)},
+				IssueKey:       alert.TinyId,
+				Title:          alert.Message,
+				Type:           ticket.INCIDENT,
+				OriginalStatus: alert.Status,
+				Priority:       alert.Priority,
+				CreatorName:    alert.Source,
+				AssigneeName:   alert.Owner,
+				CreatedDate:    &alert.CreatedDate,
+				UpdatedDate:    &alert.UpdatedDate,
+			}
+			switch {
+			case alert.Status == models.STATUS_CLOSED:
+				domainIssue.Status = ticket.DONE
+				if alert.CloseTimeMs > 0 {
+					resolutionDate := alert.CreatedDate.Add(time.Duration(alert.CloseTimeMs) * time.Millisecond)
+					domainIssue.ResolutionDate = &resolutionDate
+					domainIssue.LeadTimeMinutes = alert.CloseTimeMs / time.Minute.Milliseconds()
+				}
+			case alert.AckTimeMs > 0:
+				domainIssue.Status = ticket.IN_PROGRESS
+			default:
+				domainIssue.Status = ticket.TODO
+			}
+			results := []interface{}{
+				domainIssue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: domainIssue.Id,
+				},
+			}
+			if alert.AcknowledgerId != "" && alert.AckTimeMs > 0 {
+				acknowledgedDate := alert.CreatedDate.Add(time.Duration(alert.AckTimeMs) * time.Millisecond)
+				ackTimeMinutes := alert.AckTimeMs / time.Minute.Milliseconds()
+				results = append(results, &ticket.IssueResponder{
+					IssueId:          domainIssue.Id,
+					AccountId:        userIdGen.Generate(alert.ConnectionId, alert.AcknowledgerId),
+					AssignedDate:     &alert.CreatedDate,
+					AcknowledgedDate: &acknowledgedDate,
+					AckTimeMinutes:   &ackTimeMinutes,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractApiAlertsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiAlerts",
+	EntryPoint:       ExtractApiAlerts,
+	EnabledByDefault: true,
+	Description:      "Extract raw alerts data into tool layer table opsgenie_alerts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type OpsgenieApiAlert struct {
+	Id        string    `json:"id"`
+	TinyId    string    `json:"tinyId"`
+	Alias     string    `json:"alias"`
+	Message   string    `json:"message"`
+	Status    string    `json:"status"`
+	Priority  string    `json:"priority"`
+	Source    string    `json:"source"`
+	Owner     string    `json:"owner"`
+	Count     int       `json:"count"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Report    struct {
+		AckTime        int64  `json:"ackTime"`
+		CloseTime      int64  `json:"closeTime"`
+		AcknowledgedBy string `json:"acknowledgedBy"`
+		ClosedBy       string `json:"closedBy"`
+	} `json:"report"`
+}
+
+func ExtractApiAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ALERT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiAlert := &OpsgenieApiAlert{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiAlert))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{&models.OpsgenieAlert{
+				ConnectionId:   data.Options.ConnectionId,
+				OpsgenieId:     apiAlert.Id,
+				ServiceId:      data.Options.ServiceId,
+				TinyId:         apiAlert.TinyId,
+				Alias:          apiAlert.Alias,
+				Message:        apiAlert.Message,
+				Status:         apiAlert.Status,
+				Priority:       apiAlert.Priority,
+				Source:         apiAlert.Source,
+				Owner:          apiAlert.Owner,
+				Count:          apiAlert.Count,
+				CreatedDate:    apiAlert.CreatedAt,
+				UpdatedDate:    apiAlert.UpdatedAt,
+				AcknowledgedBy: apiAlert.Report.AcknowledgedBy,
+				AckTimeMs:      apiAlert.Report.AckTime,
+				ClosedBy:       apiAlert.Report.ClosedBy,
+				CloseTimeMs:    apiAlert.Report.CloseTime,
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
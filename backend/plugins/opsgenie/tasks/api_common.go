@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+type OpsgenieApiParams struct {
+	ConnectionId uint64
+	ServiceId    string
+}
+
+type OpsgenieInput struct {
+	OpsgenieId string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *OpsgenieTaskData) {
+	data := taskCtx.GetData().(*OpsgenieTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: OpsgenieApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ServiceId:    data.Options.ServiceId,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GetQuery pages with `offset` and `limit`, the api accepts up to 100 entities per page
+func GetQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	if reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set("offset", fmt.Sprintf("%v", reqData.Pager.Skip))
+		query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	}
+	return query, nil
+}
+
+// GetRawMessageFromResponse reads the entities the api wraps in `data`
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var body struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	err := api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Data, nil
+}
+
+// GetRawMessageFromDetailResponse reads the single entity the api wraps in `data`
+func GetRawMessageFromDetailResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	err := api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	return []json.RawMessage{body.Data}, nil
+}
+
+func ignoreHTTPStatus404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your api key")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
+
+// getService returns the service of the task, the collectors of the alerts, the schedules and the escalations
+// follow the team owning it
+func getService(taskCtx plugin.SubTaskContext, data *OpsgenieTaskData) (*models.OpsgenieService, errors.Error) {
+	service := &models.OpsgenieService{}
+	err := taskCtx.GetDal().First(service, dal.Where("connection_id = ? AND opsgenie_id = ?", data.Options.ConnectionId, data.Options.ServiceId))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find service %s", data.Options.ServiceId))
+	}
+	return service, nil
+}
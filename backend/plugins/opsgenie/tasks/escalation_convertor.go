@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertEscalationsMeta = plugin.SubTaskMeta{
+	Name:             "convertEscalations",
+	EntryPoint:       ConvertEscalations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table opsgenie_escalations and opsgenie_escalation_rules into domain layer table escalation_policies and escalation_rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertEscalations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ESCALATION_TABLE)
+	service, err := getService(taskCtx, data)
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.OpsgenieEscalation{}),
+		dal.Where("connection_id = ? AND owner_team_id = ?", data.Options.ConnectionId, service.TeamId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	escalationIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieEscalation{})
+	scheduleIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieSchedule{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieUser{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OpsgenieEscalation{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			escalation := inputRow.(*models.OpsgenieEscalation)
+			domainPolicy := &ticket.EscalationPolicy{
+				DomainEntity: domainlayer.DomainEntity{Id: escalationIdGen.Generate(escalation.ConnectionId, escalation.OpsgenieId)},
+				Name:         escalation.Name,
+				Description:  escalation.Description,
+				NumLoops:     escalation.RepeatCount,
+			}
+			results := []interface{}{domainPolicy}
+
+			rules := make([]models.OpsgenieEscalationRule, 0)
+			err := db.All(&rules,
+				dal.Where("connection_id = ? AND escalation_id = ?", escalation.ConnectionId, escalation.OpsgenieId),
+				dal.Orderby("position"),
+			)
+			if err != nil {
+				return nil, err
+			}
+			// the delays of Opsgenie count from the creation of the alert, the domain counts from the previous level
+			previousDelay := 0
+			for _, rule := range rules {
+				domainRule := &ticket.EscalationRule{
+					EscalationPolicyId: domainPolicy.Id,
+					Level:              rule.Position + 1,
+					DelayMinutes:       rule.DelayMinutes - previousDelay,
+				}
+				previousDelay = rule.DelayMinutes
+				switch rule.RecipientType {
+				case models.RECIPIENT_TYPE_SCHEDULE:
+					domainRule.TargetType = ticket.ESCALATION_TARGET_SCHEDULE
+					domainRule.TargetId = scheduleIdGen.Generate(rule.ConnectionId, rule.RecipientId)
+				case models.RECIPIENT_TYPE_USER:
+					domainRule.TargetType = ticket.ESCALATION_TARGET_ACCOUNT
+					domainRule.TargetId = userIdGen.Generate(rule.ConnectionId, rule.RecipientId)
+				default:
+					continue
+				}
+				results = append(results, domainRule)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
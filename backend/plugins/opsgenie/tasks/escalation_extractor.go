@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractApiEscalationsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiEscalations",
+	EntryPoint:       ExtractApiEscalations,
+	EnabledByDefault: true,
+	Description:      "Extract raw escalations data of the team owning the service into tool layer table opsgenie_escalations and opsgenie_escalation_rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type OpsgenieApiEscalation struct {
+	Id          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	OwnerTeam   OpsgenieApiTeamRef `json:"ownerTeam"`
+	Rules       []struct {
+		Condition  string `json:"condition"`
+		NotifyType string `json:"notifyType"`
+		Delay      struct {
+			TimeAmount int    `json:"timeAmount"`
+			TimeUnit   string `json:"timeUnit"`
+		} `json:"delay"`
+		Recipient struct {
+			Type string `json:"type"`
+			Id   string `json:"id"`
+		} `json:"recipient"`
+	} `json:"rules"`
+	Repeat struct {
+		Count int `json:"count"`
+	} `json:"repeat"`
+}
+
+func ExtractApiEscalations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ESCALATION_TABLE)
+	service, err := getService(taskCtx, data)
+	if err != nil {
+		return err
+	}
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiEscalation := &OpsgenieApiEscalation{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiEscalation))
+			if err != nil {
+				return nil, err
+			}
+			if service.TeamId == "" || apiEscalation.OwnerTeam.Id != service.TeamId {
+				return nil, nil
+			}
+			results := make([]interface{}, 0, len(apiEscalation.Rules)+1)
+			results = append(results, &models.OpsgenieEscalation{
+				ConnectionId: data.Options.ConnectionId,
+				OpsgenieId:   apiEscalation.Id,
+				Name:         apiEscalation.Name,
+				Description:  apiEscalation.Description,
+				OwnerTeamId:  apiEscalation.OwnerTeam.Id,
+				RepeatCount:  apiEscalation.Repeat.Count,
+			})
+			for i, rule := range apiEscalation.Rules {
+				results = append(results, &models.OpsgenieEscalationRule{
+					ConnectionId:  data.Options.ConnectionId,
+					EscalationId:  apiEscalation.Id,
+					Position:      i,
+					Condition:     rule.Condition,
+					NotifyType:    rule.NotifyType,
+					RecipientType: rule.Recipient.Type,
+					RecipientId:   rule.Recipient.Id,
+					DelayMinutes:  delayInMinutes(rule.Delay.TimeAmount, rule.Delay.TimeUnit),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// delayInMinutes converts the delay of a rule, the api expresses it in minutes by default
+func delayInMinutes(amount int, unit string) int {
+	switch unit {
+	case "hours":
+		return amount * 60
+	case "days":
+		return amount * 60 * 24
+	}
+	return amount
+}
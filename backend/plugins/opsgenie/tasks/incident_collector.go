@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_INCIDENT_TABLE = "opsgenie_api_incidents"
+
+var CollectApiIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiIncidents",
+	EntryPoint:       CollectApiIncidents,
+	EnabledByDefault: true,
+	Description:      "Collect the incidents impacting the service from Opsgenie api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_INCIDENT_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "v1/incidents",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("query", searchQuery(collectorWithState, fmt.Sprintf(`impactedServices:"%s"`, data.Options.ServiceId)))
+			query.Set("sort", "createdAt")
+			query.Set("order", "desc")
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
+
+// searchQuery narrows the search of incidents and alerts down to the ones updated since the last collection,
+// or created after timeAfter when collecting from scratch, the api compares times as epoch milliseconds
+func searchQuery(collectorWithState *api.ApiCollectorStateManager, conditions ...string) string {
+	if collectorWithState.IsIncremental() {
+		conditions = append(conditions, fmt.Sprintf("updatedAt>=%d", collectorWithState.LatestState.LatestSuccessStart.UnixMilli()))
+	} else if collectorWithState.TimeAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("createdAt>=%d", collectorWithState.TimeAfter.UnixMilli()))
+	}
+	return strings.Join(conditions, " AND ")
+}
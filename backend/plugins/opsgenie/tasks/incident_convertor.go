@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "convertIncidents",
+	EntryPoint:       ConvertIncidents,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table opsgenie_incidents into domain layer table issues, board_issues and issue_responders",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_INCIDENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.OpsgenieIncident{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	incidentIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieIncident{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieService{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieUser{})
+	boardId := serviceIdGen.Generate(data.Options.ConnectionId, data.Options.ServiceId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OpsgenieIncident{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			incident := inputRow.(*models.OpsgenieIncident)
+			domainIssue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: incidentIdGen.Generate(incident.ConnectionId, incident.OpsgenieId)},
+				Url:            incident.Url,
+				IssueKey:       incident.TinyId,
+				Title:          incident.Message,
+				Description:    incident.Description,
+				Type:           ticket.INCIDENT,
+				OriginalStatus: incident.Status,
+				Priority:       incident.Priority,
+				CreatedDate:    &incident.CreatedDate,
+				UpdatedDate:    &incident.UpdatedDate,
+			}
+			switch incident.Status {
+			case models.STATUS_OPEN:
+				domainIssue.Status = ticket.TODO
+			case models.STATUS_RESOLVED, models.STATUS_CLOSED:
+				// the api does not expose the resolution time, the last update of a resolved incident is the closest
+				domainIssue.Status = ticket.DONE
+				domainIssue.ResolutionDate = &incident.UpdatedDate
+				domainIssue.LeadTimeMinutes = int64(incident.UpdatedDate.Sub(incident.CreatedDate).Minutes())
+			default:
+				domainIssue.Status = ticket.OTHER
+			}
+			results := []interface{}{
+				domainIssue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: domainIssue.Id,
+				},
+			}
+
+			responders := make([]models.OpsgenieIncidentResponder, 0)
+			err := db.All(&responders, dal.Where(
+				"connection_id = ? AND incident_id = ? AND responder_type = ?",
+				incident.ConnectionId, incident.OpsgenieId, models.RESPONDER_TYPE_USER,
+			))
+			if err != nil {
+				return nil, err
+			}
+			for _, responder := range responders {
+				results = append(results, &ticket.IssueResponder{
+					IssueId:      domainIssue.Id,
+					AccountId:    userIdGen.Generate(responder.ConnectionId, responder.ResponderId),
+					AssignedDate: &incident.CreatedDate,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractApiIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiIncidents",
+	EntryPoint:       ExtractApiIncidents,
+	EnabledByDefault: true,
+	Description:      "Extract raw incidents data into tool layer table opsgenie_incidents and opsgenie_incident_responders",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type OpsgenieApiIncident struct {
+	Id          string    `json:"id"`
+	TinyId      string    `json:"tinyId"`
+	Message     string    `json:"message"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	Priority    string    `json:"priority"`
+	OwnerTeam   string    `json:"ownerTeam"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Responders  []struct {
+		Type string `json:"type"`
+		Id   string `json:"id"`
+	} `json:"responders"`
+	Links struct {
+		Web string `json:"web"`
+	} `json:"links"`
+}
+
+func ExtractApiIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_INCIDENT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiIncident := &OpsgenieApiIncident{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiIncident))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, len(apiIncident.Responders)+1)
+			results = append(results, &models.OpsgenieIncident{
+				ConnectionId: data.Options.ConnectionId,
+				OpsgenieId:   apiIncident.Id,
+				ServiceId:    data.Options.ServiceId,
+				TinyId:       apiIncident.TinyId,
+				Message:      apiIncident.Message,
+				Description:  apiIncident.Description,
+				Status:       apiIncident.Status,
+				Priority:     apiIncident.Priority,
+				OwnerTeamId:  apiIncident.OwnerTeam,
+				Url:          apiIncident.Links.Web,
+				CreatedDate:  apiIncident.CreatedAt,
+				UpdatedDate:  apiIncident.UpdatedAt,
+			})
+			for _, responder := range apiIncident.Responders {
+				results = append(results, &models.OpsgenieIncidentResponder{
+					ConnectionId:  data.Options.ConnectionId,
+					IncidentId:    apiIncident.Id,
+					ResponderType: responder.Type,
+					ResponderId:   responder.Id,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertOncallPeriodsMeta = plugin.SubTaskMeta{
+	Name:             "convertOncallPeriods",
+	EntryPoint:       ConvertOncallPeriods,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table opsgenie_oncall_periods into domain layer table oncall_shifts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertOncallPeriods(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_TABLE)
+	service, err := getService(taskCtx, data)
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("p.*"),
+		dal.From("_tool_opsgenie_oncall_periods AS p"),
+		dal.Join("JOIN _tool_opsgenie_schedules AS s ON s.connection_id = p.connection_id AND s.opsgenie_id = p.schedule_id"),
+		dal.Where("p.connection_id = ? AND s.owner_team_id = ?", data.Options.ConnectionId, service.TeamId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	scheduleIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieSchedule{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieUser{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OpsgenieOncallPeriod{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			period := inputRow.(*models.OpsgenieOncallPeriod)
+			return []interface{}{
+				&ticket.OncallShift{
+					ScheduleId: scheduleIdGen.Generate(period.ConnectionId, period.ScheduleId),
+					AccountId:  userIdGen.Generate(period.ConnectionId, period.UserId),
+					StartDate:  period.StartDate,
+					EndDate:    period.EndDate,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
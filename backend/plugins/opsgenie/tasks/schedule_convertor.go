@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ConvertSchedulesMeta = plugin.SubTaskMeta{
+	Name:             "convertSchedules",
+	EntryPoint:       ConvertSchedules,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table opsgenie_schedules into domain layer table oncall_schedules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertSchedules(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SCHEDULE_TABLE)
+	service, err := getService(taskCtx, data)
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.OpsgenieSchedule{}),
+		dal.Where("connection_id = ? AND owner_team_id = ?", data.Options.ConnectionId, service.TeamId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	scheduleIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieSchedule{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OpsgenieSchedule{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			schedule := inputRow.(*models.OpsgenieSchedule)
+			return []interface{}{
+				&ticket.OncallSchedule{
+					DomainEntity: domainlayer.DomainEntity{Id: scheduleIdGen.Generate(schedule.ConnectionId, schedule.OpsgenieId)},
+					Name:         schedule.Name,
+					Description:  schedule.Description,
+					TimeZone:     schedule.Timezone,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractApiSchedulesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiSchedules",
+	EntryPoint:       ExtractApiSchedules,
+	EnabledByDefault: true,
+	Description:      "Extract raw schedules data of the team owning the service into tool layer table opsgenie_schedules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type OpsgenieApiTeamRef struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type OpsgenieApiSchedule struct {
+	Id          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Timezone    string             `json:"timezone"`
+	Enabled     bool               `json:"enabled"`
+	OwnerTeam   OpsgenieApiTeamRef `json:"ownerTeam"`
+}
+
+func ExtractApiSchedules(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SCHEDULE_TABLE)
+	service, err := getService(taskCtx, data)
+	if err != nil {
+		return err
+	}
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiSchedule := &OpsgenieApiSchedule{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiSchedule))
+			if err != nil {
+				return nil, err
+			}
+			if service.TeamId == "" || apiSchedule.OwnerTeam.Id != service.TeamId {
+				return nil, nil
+			}
+			return []interface{}{&models.OpsgenieSchedule{
+				ConnectionId: data.Options.ConnectionId,
+				OpsgenieId:   apiSchedule.Id,
+				Name:         apiSchedule.Name,
+				Description:  apiSchedule.Description,
+				Timezone:     apiSchedule.Timezone,
+				Enabled:      apiSchedule.Enabled,
+				OwnerTeamId:  apiSchedule.OwnerTeam.Id,
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+const RAW_SERVICE_TABLE = "opsgenie_api_services"
+
+var ConvertServiceMeta = plugin.SubTaskMeta{
+	Name:             "convertService",
+	EntryPoint:       ConvertService,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table opsgenie_services into domain layer table boards",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// GetApiService fetches a service by its id
+func GetApiService(op *OpsgenieOptions, apiClient aha.ApiClientAbstract) (*models.OpsgenieApiService, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("v1/services/%s", op.ServiceId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting service detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	body := &struct {
+		Data models.OpsgenieApiService `json:"data"`
+	}{}
+	err = api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	return &body.Data, nil
+}
+
+func ConvertService(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVICE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.OpsgenieService{}),
+		dal.Where("connection_id = ? AND opsgenie_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.OpsgenieService{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.OpsgenieService{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			service := inputRow.(*models.OpsgenieService)
+			return []interface{}{
+				&ticket.Board{
+					DomainEntity: domainlayer.DomainEntity{Id: serviceIdGen.Generate(service.ConnectionId, service.OpsgenieId)},
+					Name:         service.Name,
+					Description:  service.Description,
+					Url:          service.Url,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
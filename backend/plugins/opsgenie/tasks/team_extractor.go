@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractApiTeamMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTeam",
+	EntryPoint:       ExtractApiTeam,
+	EnabledByDefault: true,
+	Description:      "Extract raw team data into tool layer table opsgenie_teams and opsgenie_users",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+}
+
+type OpsgenieApiUser struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type OpsgenieApiTeam struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Members     []struct {
+		User OpsgenieApiUser `json:"user"`
+		Role string          `json:"role"`
+	} `json:"members"`
+}
+
+func ExtractApiTeam(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEAM_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiTeam := &OpsgenieApiTeam{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiTeam))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, len(apiTeam.Members)+1)
+			results = append(results, &models.OpsgenieTeam{
+				ConnectionId: data.Options.ConnectionId,
+				OpsgenieId:   apiTeam.Id,
+				Name:         apiTeam.Name,
+				Description:  apiTeam.Description,
+			})
+			for _, member := range apiTeam.Members {
+				results = append(results, &models.OpsgenieUser{
+					ConnectionId: data.Options.ConnectionId,
+					OpsgenieId:   member.User.Id,
+					Username:     member.User.Username,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+const RAW_TIMELINE_TABLE = "opsgenie_api_schedule_timelines"
+
+// the on-call periods are collected for the last 90 days when no timeAfter is given
+const defaultTimelineDays = 90
+
+var CollectApiTimelinesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiTimelines",
+	EntryPoint:       CollectApiTimelines,
+	EnabledByDefault: true,
+	Description:      "Collect the final timelines of the on-call schedules from Opsgenie api, must run after the schedules are extracted",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiTimelines(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_TABLE)
+	service, err := getService(taskCtx, data)
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("opsgenie_id"),
+		dal.From(&models.OpsgenieSchedule{}),
+		dal.Where("connection_id = ? AND owner_team_id = ?", data.Options.ConnectionId, service.TeamId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(OpsgenieInput{}))
+	if err != nil {
+		return err
+	}
+
+	until := time.Now().UTC()
+	since := until.AddDate(0, 0, -defaultTimelineDays)
+	if data.TimeAfter != nil {
+		since = data.TimeAfter.UTC()
+	}
+	days := int(math.Ceil(until.Sub(since).Hours() / 24))
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "v2/schedules/{{ .Input.OpsgenieId }}/timeline",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("date", since.Format(time.RFC3339))
+			query.Set("interval", fmt.Sprintf("%d", days))
+			query.Set("intervalUnit", "days")
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromDetailResponse,
+		AfterResponse:  ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
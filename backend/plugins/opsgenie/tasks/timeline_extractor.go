@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
+)
+
+var ExtractApiTimelinesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTimelines",
+	EntryPoint:       ExtractApiTimelines,
+	EnabledByDefault: true,
+	Description:      "Extract raw schedule timelines data into tool layer table opsgenie_oncall_periods",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+}
+
+type OpsgenieApiTimeline struct {
+	Parent struct {
+		Id string `json:"id"`
+	} `json:"_parent"`
+	FinalTimeline struct {
+		Rotations []struct {
+			Name    string `json:"name"`
+			Periods []struct {
+				StartDate time.Time  `json:"startDate"`
+				EndDate   *time.Time `json:"endDate"`
+				Type      string     `json:"type"`
+				Recipient struct {
+					Id   string `json:"id"`
+					Type string `json:"type"`
+					Name string `json:"name"`
+				} `json:"recipient"`
+			} `json:"periods"`
+		} `json:"rotations"`
+	} `json:"finalTimeline"`
+}
+
+func ExtractApiTimelines(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiTimeline := &OpsgenieApiTimeline{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiTimeline))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0)
+			for _, rotation := range apiTimeline.FinalTimeline.Rotations {
+				for _, period := range rotation.Periods {
+					// periods nobody is on-call for have no user recipient
+					if period.Recipient.Type != models.RECIPIENT_TYPE_USER || period.Recipient.Id == "" {
+						continue
+					}
+					results = append(results, &models.OpsgenieOncallPeriod{
+						ConnectionId: data.Options.ConnectionId,
+						ScheduleId:   apiTimeline.Parent.Id,
+						UserId:       period.Recipient.Id,
+						StartDate:    period.StartDate,
+						EndDate:      period.EndDate,
+						RotationName: rotation.Name,
+						Type:         period.Type,
+					}, &models.OpsgenieUser{
+						ConnectionId: data.Options.ConnectionId,
+						OpsgenieId:   period.Recipient.Id,
+						Username:     period.Recipient.Name,
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
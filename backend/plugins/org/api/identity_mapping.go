@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+type identityMappingsInput struct {
+	Mappings []models.IdentityMapping `json:"mappings"`
+}
+
+// GetIdentityMappings returns all manual account to user mappings
+// @Summary      Get manual identity mappings
+// @Description  get the accounts assigned to users manually, they take precedence over the identity rules
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} []models.IdentityMapping
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/identity_mappings [get]
+func (h *Handlers) GetIdentityMappings(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	mappings, err := h.store.findAllIdentityMappings()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: mappings, Status: http.StatusOK}, nil
+}
+
+// PutIdentityMappings saves manual account to user mappings and applies them to user_accounts right away
+// @Summary      Save manual identity mappings
+// @Description  assign accounts to users manually, existing mappings of the same accounts are replaced
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        body body identityMappingsInput true "json"
+// @Produce      json
+// @Success      200  {object} []models.IdentityMapping
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/identity_mappings [put]
+func (h *Handlers) PutIdentityMappings(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var body identityMappingsInput
+	err := helper.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode identity mappings")
+	}
+	var items []interface{}
+	for i := range body.Mappings {
+		mapping := &body.Mappings[i]
+		if mapping.AccountId == "" || mapping.UserId == "" {
+			return nil, errors.BadInput.New("accountId and userId are required")
+		}
+		items = append(items, mapping, &crossdomain.UserAccount{
+			UserId:    mapping.UserId,
+			AccountId: mapping.AccountId,
+		})
+	}
+	err = h.store.save(items)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: body.Mappings, Status: http.StatusOK}, nil
+}
+
+// DeleteIdentityMapping removes the manual mapping of an account, the account will be resolved by the identity rules
+// in the next run
+// @Summary      Delete a manual identity mapping
+// @Description  delete the manual mapping of an account along with its user_accounts record
+// @Tags 		 plugins/org
+// @Param        accountId path string true "account id"
+// @Success      200
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/identity_mappings/{accountId} [delete]
+func (h *Handlers) DeleteIdentityMapping(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	accountId := input.Params["accountId"]
+	if accountId == "" {
+		return nil, errors.BadInput.New("accountId is required")
+	}
+	err := h.store.deleteIdentityMapping(accountId)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
 	"reflect"
 )
 
@@ -32,18 +33,39 @@ type store interface {
 	findAllAccounts() ([]account, errors.Error)
 	findAllUserAccounts() ([]userAccount, errors.Error)
 	findAllProjectMapping() ([]projectMapping, errors.Error)
+	findAllIdentityMappings() ([]models.IdentityMapping, errors.Error)
+	findAllDomainTeams() ([]crossdomain.Team, errors.Error)
+	findTeamMembers(teamId string, effective bool) ([]crossdomain.User, errors.Error)
+	replaceTeamMembers(teamId string, userIds []string) errors.Error
+	deleteTeam(teamId string) errors.Error
+	replaceTeamClosures(closures []*crossdomain.TeamClosure) errors.Error
+	findTeamScopes(teamId string) ([]crossdomain.TeamScope, errors.Error)
+	replaceTeamScopes(teamId string, scopes []crossdomain.TeamScope) errors.Error
+	deleteIdentityMapping(accountId string) errors.Error
 	deleteAll(i interface{}) errors.Error
 	save(items []interface{}) errors.Error
+	transaction(fn func(tx store) errors.Error) errors.Error
 }
 
 type dbStore struct {
-	db     dal.Dal
-	driver *helper.BatchSaveDivider
+	db       dal.Dal
+	basicRes context.BasicRes
+	driver   *helper.BatchSaveDivider
 }
 
 func NewDbStore(db dal.Dal, basicRes context.BasicRes) *dbStore {
 	driver := helper.NewBatchSaveDivider(basicRes, 1000, "", "")
-	return &dbStore{db: db, driver: driver}
+	return &dbStore{db: db, basicRes: basicRes, driver: driver}
+}
+
+// txBasicRes hands the transaction to the BatchSaveDivider so that batches are saved within it
+type txBasicRes struct {
+	context.BasicRes
+	tx dal.Dal
+}
+
+func (r txBasicRes) GetDal() dal.Dal {
+	return r.tx
 }
 
 func (d *dbStore) findAllUsers() ([]user, errors.Error) {
@@ -104,6 +126,118 @@ func (d *dbStore) findAllProjectMapping() ([]projectMapping, errors.Error) {
 	var pm *projectMapping
 	return pm.fromDomainLayer(mapping), nil
 }
+
+func (d *dbStore) findAllIdentityMappings() ([]models.IdentityMapping, errors.Error) {
+	var mappings []models.IdentityMapping
+	err := d.db.All(&mappings)
+	if err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// deleteIdentityMapping deletes the manual mapping of the account and the user_accounts record derived from it
+func (d *dbStore) deleteIdentityMapping(accountId string) errors.Error {
+	err := d.db.Delete(&crossdomain.UserAccount{}, dal.Where("account_id = ?", accountId))
+	if err != nil {
+		return err
+	}
+	return d.db.Delete(&models.IdentityMapping{}, dal.Where("account_id = ?", accountId))
+}
+
+func (d *dbStore) findAllDomainTeams() ([]crossdomain.Team, errors.Error) {
+	var teams []crossdomain.Team
+	err := d.db.All(&teams, dal.Orderby("sorting_index, id"))
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// findTeamMembers returns the direct members of the team, or the members of the team and all its descendants if
+// effective is true
+func (d *dbStore) findTeamMembers(teamId string, effective bool) ([]crossdomain.User, errors.Error) {
+	clauses := []dal.Clause{
+		dal.Select("DISTINCT u.*"),
+		dal.From("users u"),
+		dal.Join("JOIN team_users tu ON tu.user_id = u.id"),
+	}
+	if effective {
+		clauses = append(clauses,
+			dal.Join("JOIN team_closures tc ON tc.descendant_id = tu.team_id"),
+			dal.Where("tc.ancestor_id = ?", teamId),
+		)
+	} else {
+		clauses = append(clauses, dal.Where("tu.team_id = ?", teamId))
+	}
+	clauses = append(clauses, dal.Orderby("u.id"))
+	var users []crossdomain.User
+	err := d.db.All(&users, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (d *dbStore) replaceTeamMembers(teamId string, userIds []string) errors.Error {
+	err := d.db.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ?", teamId))
+	if err != nil {
+		return err
+	}
+	var items []interface{}
+	for _, userId := range userIds {
+		items = append(items, &crossdomain.TeamUser{TeamId: teamId, UserId: userId})
+	}
+	return d.save(items)
+}
+
+// deleteTeam deletes the team along with its memberships and scopes
+func (d *dbStore) deleteTeam(teamId string) errors.Error {
+	err := d.db.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ?", teamId))
+	if err != nil {
+		return err
+	}
+	err = d.db.Delete(&crossdomain.TeamScope{}, dal.Where("team_id = ?", teamId))
+	if err != nil {
+		return err
+	}
+	return d.db.Delete(&crossdomain.Team{}, dal.Where("id = ?", teamId))
+}
+
+func (d *dbStore) replaceTeamClosures(closures []*crossdomain.TeamClosure) errors.Error {
+	err := d.deleteAll(&crossdomain.TeamClosure{})
+	if err != nil {
+		return err
+	}
+	var items []interface{}
+	for _, closure := range closures {
+		items = append(items, closure)
+	}
+	return d.save(items)
+}
+
+func (d *dbStore) findTeamScopes(teamId string) ([]crossdomain.TeamScope, errors.Error) {
+	var scopes []crossdomain.TeamScope
+	err := d.db.All(&scopes, dal.Where("team_id = ?", teamId), dal.Orderby("row_id"))
+	if err != nil {
+		return nil, err
+	}
+	return scopes, nil
+}
+
+func (d *dbStore) replaceTeamScopes(teamId string, scopes []crossdomain.TeamScope) errors.Error {
+	err := d.db.Delete(&crossdomain.TeamScope{}, dal.Where("team_id = ?", teamId))
+	if err != nil {
+		return err
+	}
+	var items []interface{}
+	for i := range scopes {
+		scopes[i].TeamId = teamId
+		items = append(items, &scopes[i])
+	}
+	return d.save(items)
+}
+
 func (d *dbStore) deleteAll(i interface{}) errors.Error {
 	return d.db.Delete(i, dal.Where("1=1"))
 }
@@ -122,3 +256,15 @@ func (d *dbStore) save(items []interface{}) errors.Error {
 	d.driver.Close()
 	return nil
 }
+
+// transaction runs fn against a store bound to a new database transaction, which is committed if fn succeeds and
+// rolled back otherwise
+func (d *dbStore) transaction(fn func(tx store) errors.Error) errors.Error {
+	tx := d.db.Begin()
+	err := fn(NewDbStore(tx, txBasicRes{BasicRes: d.basicRes, tx: tx}))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -49,6 +50,18 @@ func (h *Handlers) GetTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceO
 			return nil, err
 		}
 	}
+	var domainTeams []crossdomain.Team
+	for _, tm := range t.toDomainLayer(teams) {
+		domainTeams = append(domainTeams, *tm)
+	}
+	closures, err := buildTeamClosures(domainTeams)
+	if err != nil {
+		return nil, err
+	}
+	paths := teamPaths(closures)
+	for i := range teams {
+		teams[i].Path = paths[teams[i].Id]
+	}
 	blob, err := errors.Convert01(gocsv.MarshalBytes(teams))
 	if err != nil {
 		return nil, err
@@ -82,14 +95,31 @@ func (h *Handlers) CreateTeam(input *plugin.ApiResourceInput) (*plugin.ApiResour
 	}
 	var t *team
 	var items []interface{}
+	var teams []crossdomain.Team
 	for _, tm := range t.toDomainLayer(tt) {
 		items = append(items, tm)
+		teams = append(teams, *tm)
 	}
-	err = h.store.deleteAll(&crossdomain.Team{})
+	for _, tm := range teams {
+		if tm.ParentId != "" && findTeam(teams, tm.ParentId) < 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("parent team %s of team %s not found", tm.ParentId, tm.Id))
+		}
+	}
+	closures, err := buildTeamClosures(teams)
 	if err != nil {
 		return nil, err
 	}
-	err = h.store.save(items)
+	err = h.store.transaction(func(tx store) errors.Error {
+		err := tx.deleteAll(&crossdomain.Team{})
+		if err != nil {
+			return err
+		}
+		err = tx.save(items)
+		if err != nil {
+			return err
+		}
+		return tx.replaceTeamClosures(closures)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,355 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type teamInput struct {
+	Id           string  `json:"id"`
+	Name         *string `json:"name"`
+	Alias        *string `json:"alias"`
+	ParentId     *string `json:"parentId"`
+	SortingIndex *int    `json:"sortingIndex"`
+}
+
+type teamMembersInput struct {
+	UserIds []string `json:"userIds"`
+}
+
+type teamScopesInput struct {
+	Scopes []struct {
+		Table string `json:"table"`
+		RowId string `json:"rowId"`
+	} `json:"scopes"`
+}
+
+// ListTeams returns all teams
+// @Summary      List teams
+// @Description  list all teams ordered by sortingIndex
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} []crossdomain.Team
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [get]
+func (h *Handlers) ListTeams(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	teams, err := h.store.findAllDomainTeams()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: teams, Status: http.StatusOK}, nil
+}
+
+// GetTeamById returns a single team
+// @Summary      Get a team
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Produce      json
+// @Success      200  {object} crossdomain.Team
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [get]
+func (h *Handlers) GetTeamById(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	teams, err := h.store.findAllDomainTeams()
+	if err != nil {
+		return nil, err
+	}
+	i := findTeam(teams, input.Params["teamId"])
+	if i < 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("team %s not found", input.Params["teamId"]))
+	}
+	return &plugin.ApiResourceOutput{Body: teams[i], Status: http.StatusOK}, nil
+}
+
+// PostTeam creates a team
+// @Summary      Create a team
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        body body teamInput true "json"
+// @Produce      json
+// @Success      200  {object} crossdomain.Team
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [post]
+func (h *Handlers) PostTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var body teamInput
+	err := helper.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode team")
+	}
+	if body.Id == "" || body.Name == nil || *body.Name == "" {
+		return nil, errors.BadInput.New("id and name are required")
+	}
+	teams, err := h.store.findAllDomainTeams()
+	if err != nil {
+		return nil, err
+	}
+	if findTeam(teams, body.Id) >= 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("team %s already exists", body.Id))
+	}
+	teams = append(teams, crossdomain.Team{DomainEntity: domainlayer.DomainEntity{Id: body.Id}})
+	return h.saveTeam(teams, len(teams)-1, &body)
+}
+
+// PatchTeam updates a team, fields absent from the body are left unchanged
+// @Summary      Update a team
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        teamId path string true "team id"
+// @Param        body body teamInput true "json"
+// @Produce      json
+// @Success      200  {object} crossdomain.Team
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [patch]
+func (h *Handlers) PatchTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var body teamInput
+	err := helper.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode team")
+	}
+	teams, err := h.store.findAllDomainTeams()
+	if err != nil {
+		return nil, err
+	}
+	i := findTeam(teams, input.Params["teamId"])
+	if i < 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("team %s not found", input.Params["teamId"]))
+	}
+	return h.saveTeam(teams, i, &body)
+}
+
+// DeleteTeam deletes a team without sub teams
+// @Summary      Delete a team
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [delete]
+func (h *Handlers) DeleteTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	teamId := input.Params["teamId"]
+	teams, err := h.store.findAllDomainTeams()
+	if err != nil {
+		return nil, err
+	}
+	i := findTeam(teams, teamId)
+	if i < 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("team %s not found", teamId))
+	}
+	for _, t := range teams {
+		if t.ParentId == teamId {
+			return nil, errors.BadInput.New(fmt.Sprintf("team %s has sub teams, move or delete them first", teamId))
+		}
+	}
+	teams = append(teams[:i], teams[i+1:]...)
+	closures, err := buildTeamClosures(teams)
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.transaction(func(tx store) errors.Error {
+		err := tx.deleteTeam(teamId)
+		if err != nil {
+			return err
+		}
+		return tx.replaceTeamClosures(closures)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+// GetTeamMembers returns the members of a team
+// @Summary      Get team members
+// @Description  get the direct members of a team, or the members of the team and all of its sub teams if effective is true
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Param        effective query bool false "include members of sub teams"
+// @Produce      json
+// @Success      200  {object} []crossdomain.User
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/members [get]
+func (h *Handlers) GetTeamMembers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	err := h.checkTeamExists(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	users, err := h.store.findTeamMembers(input.Params["teamId"], input.Query.Get("effective") == "true")
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: users, Status: http.StatusOK}, nil
+}
+
+// PutTeamMembers replaces the direct members of a team
+// @Summary      Set team members
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        teamId path string true "team id"
+// @Param        body body teamMembersInput true "json"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/members [put]
+func (h *Handlers) PutTeamMembers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var body teamMembersInput
+	err := helper.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode team members")
+	}
+	teamId := input.Params["teamId"]
+	err = h.checkTeamExists(teamId)
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.transaction(func(tx store) errors.Error {
+		return tx.replaceTeamMembers(teamId, body.UserIds)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+// GetTeamScopes returns the scopes owned by a team
+// @Summary      Get team scopes
+// @Description  get the repos, boards and other domain layer scopes owned by a team
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Produce      json
+// @Success      200  {object} []crossdomain.TeamScope
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/scopes [get]
+func (h *Handlers) GetTeamScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	err := h.checkTeamExists(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := h.store.findTeamScopes(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: scopes, Status: http.StatusOK}, nil
+}
+
+// PutTeamScopes replaces the scopes owned by a team
+// @Summary      Set team scopes
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        teamId path string true "team id"
+// @Param        body body teamScopesInput true "json"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/scopes [put]
+func (h *Handlers) PutTeamScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var body teamScopesInput
+	err := helper.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode team scopes")
+	}
+	teamId := input.Params["teamId"]
+	err = h.checkTeamExists(teamId)
+	if err != nil {
+		return nil, err
+	}
+	scopes := make([]crossdomain.TeamScope, 0, len(body.Scopes))
+	for _, scope := range body.Scopes {
+		if scope.Table == "" || scope.RowId == "" {
+			return nil, errors.BadInput.New("table and rowId are required")
+		}
+		scopes = append(scopes, crossdomain.TeamScope{Table: scope.Table, RowId: scope.RowId})
+	}
+	err = h.store.transaction(func(tx store) errors.Error {
+		return tx.replaceTeamScopes(teamId, scopes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+func (h *Handlers) checkTeamExists(teamId string) errors.Error {
+	teams, err := h.store.findAllDomainTeams()
+	if err != nil {
+		return err
+	}
+	if findTeam(teams, teamId) < 0 {
+		return errors.NotFound.New(fmt.Sprintf("team %s not found", teamId))
+	}
+	return nil
+}
+
+// saveTeam applies the input to teams[i], then saves it if the hierarchy remains valid
+func (h *Handlers) saveTeam(teams []crossdomain.Team, i int, body *teamInput) (*plugin.ApiResourceOutput, errors.Error) {
+	t := &teams[i]
+	if body.Name != nil {
+		t.Name = *body.Name
+	}
+	if body.Alias != nil {
+		t.Alias = *body.Alias
+	}
+	if body.ParentId != nil {
+		if *body.ParentId != "" && findTeam(teams, *body.ParentId) < 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("parent team %s not found", *body.ParentId))
+		}
+		t.ParentId = *body.ParentId
+	}
+	if body.SortingIndex != nil {
+		t.SortingIndex = *body.SortingIndex
+	}
+	closures, err := buildTeamClosures(teams)
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.transaction(func(tx store) errors.Error {
+		err := tx.save([]interface{}{t})
+		if err != nil {
+			return err
+		}
+		return tx.replaceTeamClosures(closures)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: t, Status: http.StatusOK}, nil
+}
+
+func findTeam(teams []crossdomain.Team, teamId string) int {
+	for i, t := range teams {
+		if t.Id == teamId {
+			return i
+		}
+	}
+	return -1
+}
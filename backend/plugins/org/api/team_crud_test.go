@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTeamStore keeps the teams in memory, methods not overridden panic through the nil embedded store
+type fakeTeamStore struct {
+	store
+	teams     []crossdomain.Team
+	members   map[string][]string
+	committed bool
+}
+
+func (s *fakeTeamStore) findAllDomainTeams() ([]crossdomain.Team, errors.Error) {
+	return s.teams, nil
+}
+
+func (s *fakeTeamStore) replaceTeamMembers(teamId string, userIds []string) errors.Error {
+	s.members[teamId] = userIds
+	return nil
+}
+
+func (s *fakeTeamStore) transaction(fn func(tx store) errors.Error) errors.Error {
+	err := fn(s)
+	s.committed = err == nil
+	return err
+}
+
+func TestPutTeamMembers(t *testing.T) {
+	s := &fakeTeamStore{teams: []crossdomain.Team{newTeam("1", "")}, members: map[string][]string{}}
+	h := &Handlers{store: s}
+
+	output, err := h.PutTeamMembers(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "1"},
+		Body:   map[string]interface{}{"userIds": []string{"u1", "u2"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, output.Status)
+	assert.Equal(t, []string{"u1", "u2"}, s.members["1"])
+	assert.True(t, s.committed)
+}
+
+func TestPutTeamMembersUnknownTeam(t *testing.T) {
+	s := &fakeTeamStore{teams: []crossdomain.Team{newTeam("1", "")}, members: map[string][]string{}}
+	h := &Handlers{store: s}
+
+	_, err := h.PutTeamMembers(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "2"},
+		Body:   map[string]interface{}{"userIds": []string{"u1"}},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.GetType().GetHttpCode())
+	assert.Empty(t, s.members)
+}
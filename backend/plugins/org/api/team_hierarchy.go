@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+)
+
+// buildTeamClosures computes the closure rows of the team hierarchy, a team whose parent doesn't exist is a root.
+// It fails if the hierarchy contains a cycle.
+func buildTeamClosures(teams []crossdomain.Team) ([]*crossdomain.TeamClosure, errors.Error) {
+	parents := make(map[string]string, len(teams))
+	ids := make([]string, 0, len(teams))
+	for _, t := range teams {
+		parents[t.Id] = t.ParentId
+		ids = append(ids, t.Id)
+	}
+	sort.Strings(ids)
+	var closures []*crossdomain.TeamClosure
+	for _, id := range ids {
+		visited := map[string]bool{}
+		depth := 0
+		for ancestor := id; ancestor != ""; ancestor = parents[ancestor] {
+			if _, ok := parents[ancestor]; !ok {
+				break
+			}
+			if visited[ancestor] {
+				return nil, errors.BadInput.New(fmt.Sprintf("team %s is its own ancestor", ancestor))
+			}
+			visited[ancestor] = true
+			closures = append(closures, &crossdomain.TeamClosure{
+				AncestorId:   ancestor,
+				DescendantId: id,
+				Depth:        depth,
+			})
+			depth++
+		}
+	}
+	return closures, nil
+}
+
+// teamPaths joins the ancestors of every team from the root down to the team itself, expecting the closures of
+// each team ordered by depth as returned by buildTeamClosures
+func teamPaths(closures []*crossdomain.TeamClosure) map[string]string {
+	ancestors := make(map[string][]string)
+	for _, c := range closures {
+		ancestors[c.DescendantId] = append([]string{c.AncestorId}, ancestors[c.DescendantId]...)
+	}
+	paths := make(map[string]string, len(ancestors))
+	for id, ids := range ancestors {
+		paths[id] = strings.Join(ids, "/")
+	}
+	return paths
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTeam(id, parentId string) crossdomain.Team {
+	return crossdomain.Team{DomainEntity: domainlayer.DomainEntity{Id: id}, ParentId: parentId}
+}
+
+func TestBuildTeamClosures(t *testing.T) {
+	closures, err := buildTeamClosures([]crossdomain.Team{
+		newTeam("1", "2"),
+		newTeam("2", ""),
+		newTeam("3", "1"),
+		newTeam("4", "missing"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []*crossdomain.TeamClosure{
+		{AncestorId: "1", DescendantId: "1", Depth: 0},
+		{AncestorId: "2", DescendantId: "1", Depth: 1},
+		{AncestorId: "2", DescendantId: "2", Depth: 0},
+		{AncestorId: "3", DescendantId: "3", Depth: 0},
+		{AncestorId: "1", DescendantId: "3", Depth: 1},
+		{AncestorId: "2", DescendantId: "3", Depth: 2},
+		{AncestorId: "4", DescendantId: "4", Depth: 0},
+	}, closures)
+}
+
+func TestBuildTeamClosuresCycle(t *testing.T) {
+	_, err := buildTeamClosures([]crossdomain.Team{
+		newTeam("1", "2"),
+		newTeam("2", "1"),
+	})
+	assert.NotNil(t, err)
+}
+
+func TestTeamPaths(t *testing.T) {
+	closures, err := buildTeamClosures([]crossdomain.Team{
+		newTeam("1", "2"),
+		newTeam("2", ""),
+		newTeam("3", "1"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"1": "2/1",
+		"2": "2",
+		"3": "2/1/3",
+	}, teamPaths(closures))
+}
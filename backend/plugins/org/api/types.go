@@ -210,6 +210,8 @@ type team struct {
 	Alias        string
 	ParentId     string
 	SortingIndex int
+	// Path lists the ids from the root team down to this one separated by slashes, it is ignored on import
+	Path string
 }
 
 func (*team) fromDomainLayer(tt []crossdomain.Team) []team {
@@ -24,6 +24,8 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/org/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/apache/incubator-devlake/plugins/org/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/org/tasks"
 )
 
@@ -32,6 +34,7 @@ var _ plugin.PluginInit = (*Org)(nil)
 var _ plugin.PluginTask = (*Org)(nil)
 var _ plugin.PluginModel = (*Org)(nil)
 var _ plugin.ProjectMapper = (*Org)(nil)
+var _ plugin.PluginMigration = (*Org)(nil)
 
 type Org struct {
 	handlers *api.Handlers
@@ -43,7 +46,9 @@ func (p *Org) Init(basicRes context.BasicRes) errors.Error {
 }
 
 func (p Org) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.IdentityMapping{},
+	}
 }
 
 func (p Org) Description() string {
@@ -53,6 +58,7 @@ func (p Org) Description() string {
 func (p Org) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ConnectUserAccountsExactMeta,
+		tasks.ResolveUserIdentitiesMeta,
 		tasks.SetProjectMappingMeta,
 	}
 }
@@ -94,6 +100,10 @@ func (p Org) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/org"
 }
 
+func (p Org) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
 func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	return map[string]map[string]plugin.ApiResourceHandler{
 		"teams.csv": {
@@ -113,5 +123,29 @@ func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": p.handlers.GetProjectMapping,
 			"PUT": p.handlers.CreateProjectMapping,
 		},
+		"teams": {
+			"GET":  p.handlers.ListTeams,
+			"POST": p.handlers.PostTeam,
+		},
+		"teams/:teamId": {
+			"GET":    p.handlers.GetTeamById,
+			"PATCH":  p.handlers.PatchTeam,
+			"DELETE": p.handlers.DeleteTeam,
+		},
+		"teams/:teamId/members": {
+			"GET": p.handlers.GetTeamMembers,
+			"PUT": p.handlers.PutTeamMembers,
+		},
+		"teams/:teamId/scopes": {
+			"GET": p.handlers.GetTeamScopes,
+			"PUT": p.handlers.PutTeamScopes,
+		},
+		"identity_mappings": {
+			"GET": p.handlers.GetIdentityMappings,
+			"PUT": p.handlers.PutIdentityMappings,
+		},
+		"identity_mappings/:accountId": {
+			"DELETE": p.handlers.DeleteIdentityMapping,
+		},
 	}
 }
@@ -0,0 +1,275 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+// fields of accounts which can be used by the identity matching rules
+const (
+	IDENTITY_FIELD_EMAIL     = "email"
+	IDENTITY_FIELD_USER_NAME = "userName"
+	IDENTITY_FIELD_FULL_NAME = "fullName"
+)
+
+// IdentityRule declares that two accounts with the same value of Field belong to the same person
+type IdentityRule struct {
+	Field      string `json:"field"`
+	IgnoreCase bool   `json:"ignoreCase"`
+}
+
+// DefaultIdentityRules are used when no rules are given in the options
+var DefaultIdentityRules = []IdentityRule{
+	{Field: IDENTITY_FIELD_EMAIL, IgnoreCase: true},
+}
+
+var ResolveUserIdentitiesMeta = plugin.SubTaskMeta{
+	Name:             "resolveUserIdentities",
+	EntryPoint:       ResolveUserIdentities,
+	EnabledByDefault: false,
+	Description:      "merge accounts across plugins into users by the identity rules and the manual mappings",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+func ResolveUserIdentities(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*TaskData)
+	rules := data.Options.IdentityRules
+	if len(rules) == 0 {
+		rules = DefaultIdentityRules
+	}
+	for _, rule := range rules {
+		if identityValue(rule.Field, "", "", "") == nil {
+			return errors.BadInput.New(fmt.Sprintf("unknown identity field %s", rule.Field))
+		}
+	}
+	var users []crossdomain.User
+	err := db.All(&users)
+	if err != nil {
+		return err
+	}
+	var accounts []crossdomain.Account
+	err = db.All(&accounts)
+	if err != nil {
+		return err
+	}
+	var userAccounts []crossdomain.UserAccount
+	err = db.All(&userAccounts)
+	if err != nil {
+		return err
+	}
+	var mappings []models.IdentityMapping
+	err = db.All(&mappings, dal.Where("user_id != ''"))
+	if err != nil {
+		return err
+	}
+
+	newUsers, newUserAccounts := ResolveIdentities(users, accounts, userAccounts, mappings, rules)
+	taskCtx.GetLogger().Info("resolved %d accounts, created %d users", len(newUserAccounts), len(newUsers))
+
+	divider := api.NewBatchSaveDivider(taskCtx, 500, "", "")
+	userBatch, err := divider.ForType(reflect.TypeOf(&crossdomain.User{}))
+	if err != nil {
+		return err
+	}
+	for _, u := range newUsers {
+		if err = userBatch.Add(u); err != nil {
+			return err
+		}
+	}
+	userAccountBatch, err := divider.ForType(reflect.TypeOf(&crossdomain.UserAccount{}))
+	if err != nil {
+		return err
+	}
+	for _, ua := range newUserAccounts {
+		if err = userAccountBatch.Add(ua); err != nil {
+			return err
+		}
+	}
+	return divider.Close()
+}
+
+// ResolveIdentities groups the accounts which are not mapped to a user yet with the users and the other accounts
+// matching any of the rules. Every group is assigned to a single user, picked by priority:
+//  1. the user of a manually mapped account in the group
+//  2. the user of an already mapped account in the group
+//  3. a user matching the group
+//  4. a new user created from the first account of the group
+//
+// Manual mappings always win, even over existing user_accounts. It returns the users to be created and the
+// user_accounts to be saved.
+func ResolveIdentities(
+	users []crossdomain.User,
+	accounts []crossdomain.Account,
+	userAccounts []crossdomain.UserAccount,
+	mappings []models.IdentityMapping,
+	rules []IdentityRule,
+) ([]*crossdomain.User, []*crossdomain.UserAccount) {
+	manual := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		manual[m.AccountId] = m.UserId
+	}
+	existing := make(map[string]string, len(userAccounts))
+	for _, ua := range userAccounts {
+		existing[ua.AccountId] = ua.UserId
+	}
+
+	groups := newDisjointSet()
+	owners := make(map[string]string)
+	link := func(node string, rule IdentityRule, value *string) {
+		if value == nil || *value == "" {
+			return
+		}
+		key := rule.Field + "\xff" + *value
+		if rule.IgnoreCase {
+			key = strings.ToLower(key)
+		}
+		if owner, ok := owners[key]; ok {
+			groups.union(owner, node)
+		} else {
+			owners[key] = node
+		}
+	}
+	for _, u := range users {
+		node := "user\xff" + u.Id
+		groups.find(node)
+		for _, rule := range rules {
+			link(node, rule, identityValue(rule.Field, u.Email, u.Name, u.Name))
+		}
+	}
+	for _, a := range accounts {
+		node := "account\xff" + a.Id
+		groups.find(node)
+		for _, rule := range rules {
+			link(node, rule, identityValue(rule.Field, a.Email, a.UserName, a.FullName))
+		}
+	}
+
+	// accounts are sorted by id so the results are stable across runs
+	sorted := make([]crossdomain.Account, len(accounts))
+	copy(sorted, accounts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Id < sorted[j].Id })
+	sortedUsers := make([]crossdomain.User, len(users))
+	copy(sortedUsers, users)
+	sort.Slice(sortedUsers, func(i, j int) bool { return sortedUsers[i].Id < sortedUsers[j].Id })
+
+	groupUser := make(map[string]string)
+	pick := func(ids map[string]string) {
+		for _, a := range sorted {
+			root := groups.find("account\xff" + a.Id)
+			if userId, ok := ids[a.Id]; ok && groupUser[root] == "" {
+				groupUser[root] = userId
+			}
+		}
+	}
+	pick(manual)
+	pick(existing)
+	for _, u := range sortedUsers {
+		root := groups.find("user\xff" + u.Id)
+		if groupUser[root] == "" {
+			groupUser[root] = u.Id
+		}
+	}
+
+	var newUsers []*crossdomain.User
+	var result []*crossdomain.UserAccount
+	for _, a := range sorted {
+		userId, ok := manual[a.Id]
+		if !ok {
+			if _, mapped := existing[a.Id]; mapped {
+				continue
+			}
+			root := groups.find("account\xff" + a.Id)
+			userId = groupUser[root]
+			if userId == "" {
+				userId = fmt.Sprintf("org:User:%s", a.Id)
+				name := a.FullName
+				if name == "" {
+					name = a.UserName
+				}
+				newUsers = append(newUsers, &crossdomain.User{
+					DomainEntity: domainlayer.DomainEntity{Id: userId},
+					Email:        a.Email,
+					Name:         name,
+				})
+				groupUser[root] = userId
+			}
+		} else if existing[a.Id] == userId {
+			continue
+		}
+		result = append(result, &crossdomain.UserAccount{
+			UserId:    userId,
+			AccountId: a.Id,
+		})
+	}
+	return newUsers, result
+}
+
+// identityValue returns the value of the field, or nil if the field is unknown
+func identityValue(field, email, userName, fullName string) *string {
+	switch field {
+	case IDENTITY_FIELD_EMAIL:
+		return &email
+	case IDENTITY_FIELD_USER_NAME:
+		return &userName
+	case IDENTITY_FIELD_FULL_NAME:
+		return &fullName
+	}
+	return nil
+}
+
+type disjointSet struct {
+	parent map[string]string
+}
+
+func newDisjointSet() *disjointSet {
+	return &disjointSet{parent: make(map[string]string)}
+}
+
+func (s *disjointSet) find(x string) string {
+	p, ok := s.parent[x]
+	if !ok {
+		s.parent[x] = x
+		return x
+	}
+	if p == x {
+		return x
+	}
+	root := s.find(p)
+	s.parent[x] = root
+	return root
+}
+
+func (s *disjointSet) union(a, b string) {
+	ra, rb := s.find(a), s.find(b)
+	if ra != rb {
+		s.parent[rb] = ra
+	}
+}
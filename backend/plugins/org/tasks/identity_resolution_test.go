@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func account(id, email, userName, fullName string) crossdomain.Account {
+	return crossdomain.Account{
+		DomainEntity: domainlayer.DomainEntity{Id: id},
+		Email:        email,
+		UserName:     userName,
+		FullName:     fullName,
+	}
+}
+
+func TestResolveIdentities(t *testing.T) {
+	users := []crossdomain.User{
+		{DomainEntity: domainlayer.DomainEntity{Id: "1"}, Email: "alice@example.com", Name: "Alice"},
+	}
+	accounts := []crossdomain.Account{
+		account("github:GithubAccount:1:1", "Alice@Example.com", "alice", "Alice A"),
+		account("jira:JiraAccount:1:a", "", "alice", ""),
+		account("gitlab:GitlabAccount:1:2", "bob@example.com", "bob", "Bob"),
+		account("github:GithubAccount:1:2", "bob@example.com", "bobby", "Bob B"),
+		account("jira:JiraAccount:1:c", "carol@example.com", "carol", "Carol"),
+		account("jira:JiraAccount:1:d", "dave@example.com", "dave", "Dave"),
+	}
+	userAccounts := []crossdomain.UserAccount{
+		{UserId: "3", AccountId: "jira:JiraAccount:1:c"},
+	}
+	mappings := []models.IdentityMapping{
+		{AccountId: "jira:JiraAccount:1:d", UserId: "1"},
+	}
+	rules := []IdentityRule{
+		{Field: IDENTITY_FIELD_EMAIL, IgnoreCase: true},
+		{Field: IDENTITY_FIELD_USER_NAME},
+	}
+
+	newUsers, result := ResolveIdentities(users, accounts, userAccounts, mappings, rules)
+
+	assert.Equal(t, []*crossdomain.User{
+		{DomainEntity: domainlayer.DomainEntity{Id: "org:User:github:GithubAccount:1:2"}, Email: "bob@example.com", Name: "Bob B"},
+	}, newUsers)
+	assert.Equal(t, []*crossdomain.UserAccount{
+		{UserId: "1", AccountId: "github:GithubAccount:1:1"},
+		{UserId: "org:User:github:GithubAccount:1:2", AccountId: "github:GithubAccount:1:2"},
+		{UserId: "org:User:github:GithubAccount:1:2", AccountId: "gitlab:GitlabAccount:1:2"},
+		{UserId: "1", AccountId: "jira:JiraAccount:1:a"},
+		{UserId: "1", AccountId: "jira:JiraAccount:1:d"},
+	}, result)
+}
+
+func TestResolveIdentitiesManualMappingWins(t *testing.T) {
+	accounts := []crossdomain.Account{
+		account("github:GithubAccount:1:1", "alice@example.com", "", ""),
+		account("gitlab:GitlabAccount:1:1", "alice@example.com", "", ""),
+	}
+	userAccounts := []crossdomain.UserAccount{
+		{UserId: "2", AccountId: "gitlab:GitlabAccount:1:1"},
+	}
+	mappings := []models.IdentityMapping{
+		{AccountId: "gitlab:GitlabAccount:1:1", UserId: "1"},
+	}
+
+	newUsers, result := ResolveIdentities(nil, accounts, userAccounts, mappings, DefaultIdentityRules)
+
+	assert.Empty(t, newUsers)
+	assert.Equal(t, []*crossdomain.UserAccount{
+		{UserId: "1", AccountId: "github:GithubAccount:1:1"},
+		{UserId: "1", AccountId: "gitlab:GitlabAccount:1:1"},
+	}, result)
+}
@@ -124,7 +124,10 @@ func makeScopesV200(bpScopes []*plugin.BlueprintScopeV200, connection *models.Pa
 				DomainEntity: domainlayer.DomainEntity{
 					Id: didgen.NewDomainIdGenerator(&models.Service{}).Generate(connection.ID, service.Id),
 				},
-				Name: service.Name,
+				Name:        service.Name,
+				Description: service.Description,
+				Url:         service.Url,
+				CreatedDate: service.CreatedDate,
 			}
 			scopes = append(scopes, scopeTicket)
 		}
@@ -99,4 +99,13 @@ func TestIncidentDataFlow(t *testing.T) {
 			IgnoreFields: []string{"original_project"},
 		},
 	)
+	dataflowTester.FlushTabler(&ticket.IssueResponder{})
+	dataflowTester.Subtask(tasks.ConvertAssignmentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.IssueResponder{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/issue_responders.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
 }
@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/impl"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/tasks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestServiceDataFlow(t *testing.T) {
+	var plugin impl.PagerDuty
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "pagerduty", plugin)
+	rule := models.PagerdutyTransformationRule{
+		Name: "rule1",
+	}
+	options := tasks.PagerDutyOptions{
+		ConnectionId:                1,
+		ServiceId:                   "PIKL83L",
+		ServiceName:                 "DevService",
+		Tasks:                       nil,
+		PagerdutyTransformationRule: &rule,
+	}
+	taskData := &tasks.PagerDutyTaskData{
+		Options: &options,
+	}
+
+	dataflowTester.FlushTabler(&models.PagerdutyTransformationRule{})
+	dataflowTester.FlushTabler(&models.Service{})
+	// tx-rule
+	require.NoError(t, dataflowTester.Dal.CreateOrUpdate(&rule))
+	service := models.Service{
+		ConnectionId:         options.ConnectionId,
+		Url:                  fmt.Sprintf("https://keon-test.pagerduty.com/service-directory/%s", options.ServiceId),
+		Id:                   options.ServiceId,
+		TransformationRuleId: rule.ID,
+		Name:                 options.ServiceName,
+	}
+	// scope
+	require.NoError(t, dataflowTester.Dal.CreateOrUpdate(&service))
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_services.csv", "_raw_pagerduty_services")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_escalation_policies.csv", "_raw_pagerduty_escalation_policies")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_schedules.csv", "_raw_pagerduty_schedules")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_oncalls.csv", "_raw_pagerduty_oncalls")
+
+	// verify service extraction, the transformation rule set on the scope must be kept
+	dataflowTester.Subtask(tasks.ExtractServicesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.Service{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_services.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify escalation policy extraction
+	dataflowTester.FlushTabler(&models.EscalationPolicy{})
+	dataflowTester.FlushTabler(&models.EscalationRule{})
+	dataflowTester.Subtask(tasks.ExtractEscalationPoliciesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.EscalationPolicy{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_escalation_policies.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		models.EscalationRule{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_escalation_rules.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify schedule extraction
+	dataflowTester.FlushTabler(&models.Schedule{})
+	dataflowTester.FlushTabler(&models.ScheduleUser{})
+	dataflowTester.FlushTabler(&models.User{})
+	dataflowTester.Subtask(tasks.ExtractSchedulesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.Schedule{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_schedules.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		models.ScheduleUser{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_schedule_users.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify on-call extraction, shifts without a schedule are skipped
+	dataflowTester.FlushTabler(&models.Oncall{})
+	dataflowTester.Subtask(tasks.ExtractOncallsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.Oncall{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_oncalls.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify service conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertServicesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.Board{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/boards.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	dataflowTester.FlushTabler(&ticket.EscalationPolicy{})
+	dataflowTester.Subtask(tasks.ConvertEscalationPoliciesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.EscalationPolicy{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/escalation_policies.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.FlushTabler(&ticket.EscalationRule{})
+	dataflowTester.Subtask(tasks.ConvertEscalationRulesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.EscalationRule{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/escalation_rules.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.FlushTabler(&ticket.OncallSchedule{})
+	dataflowTester.Subtask(tasks.ConvertSchedulesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.OncallSchedule{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/oncall_schedules.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.FlushTabler(&ticket.OncallShift{})
+	dataflowTester.Subtask(tasks.ConvertOncallsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		ticket.OncallShift{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/oncall_shifts.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+}
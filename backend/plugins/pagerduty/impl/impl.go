@@ -56,8 +56,21 @@ func (p PagerDuty) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.CollectIncidentsMeta,
 		tasks.ExtractIncidentsMeta,
+		tasks.CollectServicesMeta,
+		tasks.ExtractServicesMeta,
+		tasks.CollectEscalationPoliciesMeta,
+		tasks.ExtractEscalationPoliciesMeta,
+		tasks.CollectSchedulesMeta,
+		tasks.ExtractSchedulesMeta,
+		tasks.CollectOncallsMeta,
+		tasks.ExtractOncallsMeta,
 		tasks.ConvertIncidentsMeta,
+		tasks.ConvertAssignmentsMeta,
 		tasks.ConvertServicesMeta,
+		tasks.ConvertEscalationPoliciesMeta,
+		tasks.ConvertEscalationRulesMeta,
+		tasks.ConvertSchedulesMeta,
+		tasks.ConvertOncallsMeta,
 	}
 }
 
@@ -67,6 +80,11 @@ func (p PagerDuty) GetTablesInfo() []dal.Tabler {
 		models.Incident{},
 		models.User{},
 		models.Assignment{},
+		models.EscalationPolicy{},
+		models.EscalationRule{},
+		models.Schedule{},
+		models.ScheduleUser{},
+		models.Oncall{},
 	}
 }
 
@@ -155,6 +173,9 @@ func (p PagerDuty) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 			"PATCH": api.UpdateTransformationRule,
 			"GET":   api.GetTransformationRule,
 		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
 	}
 }
 
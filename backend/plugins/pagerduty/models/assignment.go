@@ -28,6 +28,7 @@ type Assignment struct {
 	UserId         string `gorm:"primaryKey"`
 	IncidentNumber int    `gorm:"primaryKey"`
 	AssignedAt     time.Time
+	AcknowledgedAt *time.Time
 }
 
 func (Assignment) TableName() string {
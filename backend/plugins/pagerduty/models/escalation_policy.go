@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	TargetTypeUser     = "user_reference"
+	TargetTypeSchedule = "schedule_reference"
+)
+
+type EscalationPolicy struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Id           string `gorm:"primaryKey"`
+	Url          string
+	Name         string
+	Description  string
+	NumLoops     int
+}
+
+func (EscalationPolicy) TableName() string {
+	return "_tool_pagerduty_escalation_policies"
+}
+
+// EscalationRule is one target of a level of an escalation policy, a level notifies all its targets at once
+type EscalationRule struct {
+	common.NoPKModel
+	ConnectionId       uint64 `gorm:"primaryKey"`
+	EscalationPolicyId string `gorm:"primaryKey"`
+	RuleId             string `gorm:"primaryKey"`
+	TargetId           string `gorm:"primaryKey"`
+	TargetType         string // TargetTypeUser or TargetTypeSchedule
+	Level              int
+	DelayInMinutes     int
+}
+
+func (EscalationRule) TableName() string {
+	return "_tool_pagerduty_escalation_rules"
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type assignment20230610 struct {
+	AcknowledgedAt *time.Time
+}
+
+func (assignment20230610) TableName() string {
+	return "_tool_pagerduty_assignments"
+}
+
+type addAcknowledgedAtToAssignments struct{}
+
+func (*addAcknowledgedAtToAssignments) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&assignment20230610{})
+}
+
+func (*addAcknowledgedAtToAssignments) Version() uint64 {
+	return 20230610093000
+}
+
+func (*addAcknowledgedAtToAssignments) Name() string {
+	return "add acknowledged_at to _tool_pagerduty_assignments"
+}
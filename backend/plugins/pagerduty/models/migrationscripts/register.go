@@ -28,5 +28,7 @@ func All() []plugin.MigrationScript {
 		new(addEndpointAndProxyToConnection),
 		new(addPagerdutyConnectionFields20230123),
 		new(addTransformationRulesToService20230303),
+		new(addAcknowledgedAtToAssignments),
+		new(addEscalationPoliciesAndSchedules),
 	}
 }
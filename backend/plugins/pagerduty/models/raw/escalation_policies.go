@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raw
+
+type EscalationPolicies struct {
+	// Description corresponds to the JSON schema field "description".
+	Description *string `json:"description,omitempty"`
+
+	// EscalationRules corresponds to the JSON schema field "escalation_rules".
+	EscalationRules []EscalationPoliciesEscalationRulesElem `json:"escalation_rules,omitempty"`
+
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty"`
+
+	// NumLoops corresponds to the JSON schema field "num_loops".
+	NumLoops *int `json:"num_loops,omitempty"`
+
+	// OnCallHandoffNotifications corresponds to the JSON schema field "on_call_handoff_notifications".
+	OnCallHandoffNotifications *string `json:"on_call_handoff_notifications,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Services corresponds to the JSON schema field "services".
+	Services []EscalationPoliciesServicesElem `json:"services,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Teams corresponds to the JSON schema field "teams".
+	Teams []EscalationPoliciesTeamsElem `json:"teams,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type EscalationPoliciesEscalationRulesElem struct {
+	// EscalationDelayInMinutes corresponds to the JSON schema field "escalation_delay_in_minutes".
+	EscalationDelayInMinutes *int `json:"escalation_delay_in_minutes,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Targets corresponds to the JSON schema field "targets".
+	Targets []EscalationPoliciesEscalationRulesElemTargetsElem `json:"targets,omitempty"`
+}
+
+type EscalationPoliciesEscalationRulesElemTargetsElem struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type EscalationPoliciesServicesElem struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type EscalationPoliciesTeamsElem struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raw
+
+import "time"
+
+type Oncalls struct {
+	// End corresponds to the JSON schema field "end".
+	End *time.Time `json:"end,omitempty"`
+
+	// EscalationLevel corresponds to the JSON schema field "escalation_level".
+	EscalationLevel *int `json:"escalation_level,omitempty"`
+
+	// EscalationPolicy corresponds to the JSON schema field "escalation_policy".
+	EscalationPolicy *OncallsEscalationPolicy `json:"escalation_policy,omitempty"`
+
+	// Schedule corresponds to the JSON schema field "schedule".
+	Schedule *OncallsSchedule `json:"schedule,omitempty"`
+
+	// Start corresponds to the JSON schema field "start".
+	Start *time.Time `json:"start,omitempty"`
+
+	// User corresponds to the JSON schema field "user".
+	User *OncallsUser `json:"user,omitempty"`
+}
+
+type OncallsEscalationPolicy struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type OncallsSchedule struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type OncallsUser struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raw
+
+type Schedules struct {
+	// Description corresponds to the JSON schema field "description".
+	Description *string `json:"description,omitempty"`
+
+	// EscalationPolicies corresponds to the JSON schema field "escalation_policies".
+	EscalationPolicies []SchedulesEscalationPoliciesElem `json:"escalation_policies,omitempty"`
+
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Teams corresponds to the JSON schema field "teams".
+	Teams []SchedulesTeamsElem `json:"teams,omitempty"`
+
+	// TimeZone corresponds to the JSON schema field "time_zone".
+	TimeZone *string `json:"time_zone,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+
+	// Users corresponds to the JSON schema field "users".
+	Users []SchedulesUsersElem `json:"users,omitempty"`
+}
+
+type SchedulesEscalationPoliciesElem struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type SchedulesTeamsElem struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
+
+type SchedulesUsersElem struct {
+	// HtmlUrl corresponds to the JSON schema field "html_url".
+	HtmlUrl *string `json:"html_url,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id *string `json:"id,omitempty"`
+
+	// Self corresponds to the JSON schema field "self".
+	Self *string `json:"self,omitempty"`
+
+	// Summary corresponds to the JSON schema field "summary".
+	Summary *string `json:"summary,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type *string `json:"type,omitempty"`
+}
@@ -19,15 +19,20 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"time"
 )
 
 type Service struct {
 	common.NoPKModel
-	ConnectionId         uint64 `json:"connection_id" mapstructure:"connectionId,omitempty" gorm:"primaryKey" `
-	Url                  string `json:"url" mapstructure:"url"`
-	Id                   string `json:"id" mapstructure:"id" gorm:"primaryKey" `
-	TransformationRuleId uint64 `json:"transformation_rule_id" mapstructure:"transformation_rule_id,omitempty"` //keys to PagerdutyTransformationRules.ID
-	Name                 string `json:"name" mapstructure:"name"`
+	ConnectionId         uint64     `json:"connection_id" mapstructure:"connectionId,omitempty" gorm:"primaryKey" `
+	Url                  string     `json:"url" mapstructure:"url"`
+	Id                   string     `json:"id" mapstructure:"id" gorm:"primaryKey" `
+	TransformationRuleId uint64     `json:"transformation_rule_id" mapstructure:"transformation_rule_id,omitempty"` //keys to PagerdutyTransformationRules.ID
+	Name                 string     `json:"name" mapstructure:"name"`
+	Description          string     `json:"description" mapstructure:"description"`
+	Status               string     `json:"status" mapstructure:"status"`
+	EscalationPolicyId   string     `json:"escalation_policy_id" mapstructure:"escalation_policy_id"`
+	CreatedDate          *time.Time `json:"created_date" mapstructure:"created_date"`
 }
 
 func (Service) TableName() string {
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"reflect"
+)
+
+var ConvertEscalationPoliciesMeta = plugin.SubTaskMeta{
+	Name:             "convertEscalationPolicies",
+	EntryPoint:       ConvertEscalationPolicies,
+	EnabledByDefault: true,
+	Description:      "Convert escalation policies into domain layer table escalation_policies",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertEscalationPolicies(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("p.*"),
+		dal.From("_tool_pagerduty_escalation_policies AS p"),
+		dal.Join(`JOIN _tool_pagerduty_services AS s ON s.connection_id = p.connection_id AND s.escalation_policy_id = p.id`),
+		dal.Where("s.connection_id = ? AND s.id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	policyIdGen := didgen.NewDomainIdGenerator(&models.EscalationPolicy{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ESCALATION_POLICIES_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.EscalationPolicy{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			policy := inputRow.(*models.EscalationPolicy)
+			return []interface{}{
+				&ticket.EscalationPolicy{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: policyIdGen.Generate(policy.ConnectionId, policy.Id),
+					},
+					Name:        policy.Name,
+					Description: policy.Description,
+					Url:         policy.Url,
+					NumLoops:    policy.NumLoops,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractEscalationPolicies
+
+func ExtractEscalationPolicies(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ESCALATION_POLICIES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			policyRaw := &raw.EscalationPolicies{}
+			err := errors.Convert(json.Unmarshal(row.Data, policyRaw))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, len(policyRaw.EscalationRules)+1)
+			policy := &models.EscalationPolicy{
+				ConnectionId: data.Options.ConnectionId,
+				Id:           *policyRaw.Id,
+				Url:          resolve(policyRaw.HtmlUrl),
+				Name:         resolve(policyRaw.Name),
+				Description:  resolve(policyRaw.Description),
+				NumLoops:     resolve(policyRaw.NumLoops),
+			}
+			results = append(results, policy)
+			for i, ruleRaw := range policyRaw.EscalationRules {
+				for _, targetRaw := range ruleRaw.Targets {
+					results = append(results, &models.EscalationRule{
+						ConnectionId:       data.Options.ConnectionId,
+						EscalationPolicyId: policy.Id,
+						RuleId:             resolve(ruleRaw.Id),
+						TargetId:           resolve(targetRaw.Id),
+						TargetType:         resolve(targetRaw.Type),
+						Level:              i + 1,
+						DelayInMinutes:     resolve(ruleRaw.EscalationDelayInMinutes),
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+var ExtractEscalationPoliciesMeta = plugin.SubTaskMeta{
+	Name:             "extractEscalationPolicies",
+	EntryPoint:       ExtractEscalationPolicies,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty escalation policies and their rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
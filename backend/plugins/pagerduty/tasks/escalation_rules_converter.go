@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"reflect"
+)
+
+var ConvertEscalationRulesMeta = plugin.SubTaskMeta{
+	Name:             "convertEscalationRules",
+	EntryPoint:       ConvertEscalationRules,
+	EnabledByDefault: true,
+	Description:      "Convert escalation rules into domain layer table escalation_rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertEscalationRules(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	clauses := append([]dal.Clause{
+		dal.Select("r.*"),
+		dal.From("_tool_pagerduty_escalation_rules AS r"),
+	}, serviceEscalationRuleClauses(data)...)
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	policyIdGen := didgen.NewDomainIdGenerator(&models.EscalationPolicy{})
+	scheduleIdGen := didgen.NewDomainIdGenerator(&models.Schedule{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.User{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ESCALATION_POLICIES_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.EscalationRule{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			rule := inputRow.(*models.EscalationRule)
+			domainRule := &ticket.EscalationRule{
+				EscalationPolicyId: policyIdGen.Generate(rule.ConnectionId, rule.EscalationPolicyId),
+				Level:              rule.Level,
+				DelayMinutes:       rule.DelayInMinutes,
+			}
+			switch rule.TargetType {
+			case models.TargetTypeSchedule:
+				domainRule.TargetType = ticket.ESCALATION_TARGET_SCHEDULE
+				domainRule.TargetId = scheduleIdGen.Generate(rule.ConnectionId, rule.TargetId)
+			case models.TargetTypeUser:
+				domainRule.TargetType = ticket.ESCALATION_TARGET_ACCOUNT
+				domainRule.TargetId = userIdGen.Generate(rule.ConnectionId, rule.TargetId)
+			default:
+				return nil, nil
+			}
+			return []interface{}{
+				domainRule,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
 	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+	"time"
 )
 
 var _ plugin.SubTaskEntryPoint = ExtractIncidents
@@ -71,6 +72,7 @@ func ExtractIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 					UserId:         *userRaw.Id,
 					IncidentNumber: *incidentRaw.IncidentNumber,
 					AssignedAt:     *assignmentRaw.At,
+					AcknowledgedAt: getAcknowledgedAt(incidentRaw, *userRaw.Id),
 				})
 				results = append(results, &models.User{
 					ConnectionId: data.Options.ConnectionId,
@@ -88,6 +90,20 @@ func ExtractIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 	return extractor.Execute()
 }
 
+// getAcknowledgedAt returns the first time the user acknowledged the incident, nil if they never did
+func getAcknowledgedAt(incidentRaw *raw.Incidents, userId string) *time.Time {
+	var acknowledgedAt *time.Time
+	for _, acknowledgement := range incidentRaw.Acknowledgements {
+		if acknowledgement.Acknowledger == nil || acknowledgement.At == nil || resolve(acknowledgement.Acknowledger.Id) != userId {
+			continue
+		}
+		if acknowledgedAt == nil || acknowledgement.At.Before(*acknowledgedAt) {
+			acknowledgedAt = acknowledgement.At
+		}
+	}
+	return acknowledgedAt
+}
+
 func resolve[T any](t *T) T {
 	if t == nil {
 		return *new(T)
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const RAW_ONCALLS_TABLE = "pagerduty_oncalls"
+
+// the oncalls api refuses time ranges longer than 90 days
+const maxOncallsRange = 90 * 24 * time.Hour
+
+var _ plugin.SubTaskEntryPoint = CollectOncalls
+
+type collectedOncalls struct {
+	pagingInfo
+	Oncalls []json.RawMessage `json:"oncalls"`
+}
+
+func CollectOncalls(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	db := taskCtx.GetDal()
+	iterator, err := newServiceScheduleIterator(db, data)
+	if err != nil {
+		return err
+	}
+	until := time.Now()
+	since := until.Add(-maxOncallsRange)
+	if data.TimeAfter != nil && data.TimeAfter.After(since) {
+		since = *data.TimeAfter
+	}
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ONCALLS_TABLE,
+		},
+		ApiClient:   data.Client,
+		Input:       iterator,
+		PageSize:    100,
+		UrlTemplate: "oncalls",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("schedule_ids[]", reqData.Input.(*simplifiedSchedule).Id)
+			query.Set("since", since.Format(time.RFC3339))
+			query.Set("until", until.Format(time.RFC3339))
+			query.Set("limit", fmt.Sprintf("%d", reqData.Pager.Size))
+			query.Set("offset", fmt.Sprintf("%d", reqData.Pager.Skip))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			rawResult := collectedOncalls{}
+			err := api.UnmarshalResponse(res, &rawResult)
+			return rawResult.Oncalls, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectOncallsMeta = plugin.SubTaskMeta{
+	Name:             "collectOncalls",
+	EntryPoint:       CollectOncalls,
+	EnabledByDefault: true,
+	Description:      "Collect PagerDuty on-call shifts of the schedules the service escalates to",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"reflect"
+)
+
+var ConvertOncallsMeta = plugin.SubTaskMeta{
+	Name:             "convertOncalls",
+	EntryPoint:       ConvertOncalls,
+	EnabledByDefault: true,
+	Description:      "Convert on-call shifts into domain layer table oncall_shifts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertOncalls(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	clauses := append([]dal.Clause{
+		dal.Select("DISTINCT o.*"),
+		dal.From("_tool_pagerduty_oncalls AS o"),
+		dal.Join(`JOIN _tool_pagerduty_escalation_rules AS r ON r.connection_id = o.connection_id AND r.target_id = o.schedule_id`),
+		dal.Where("r.target_type = ?", models.TargetTypeSchedule),
+	}, serviceEscalationRuleClauses(data)...)
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	scheduleIdGen := didgen.NewDomainIdGenerator(&models.Schedule{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.User{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ONCALLS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.Oncall{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			oncall := inputRow.(*models.Oncall)
+			return []interface{}{
+				&ticket.OncallShift{
+					ScheduleId: scheduleIdGen.Generate(oncall.ConnectionId, oncall.ScheduleId),
+					AccountId:  userIdGen.Generate(oncall.ConnectionId, oncall.UserId),
+					StartDate:  oncall.Start,
+					EndDate:    oncall.End,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractOncalls
+
+func ExtractOncalls(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ONCALLS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			oncallRaw := &raw.Oncalls{}
+			err := errors.Convert(json.Unmarshal(row.Data, oncallRaw))
+			if err != nil {
+				return nil, err
+			}
+			// shifts without a schedule come from users targeted directly by an escalation rule
+			if oncallRaw.Schedule == nil || oncallRaw.User == nil || oncallRaw.Start == nil {
+				return nil, nil
+			}
+			return []interface{}{
+				&models.Oncall{
+					ConnectionId:    data.Options.ConnectionId,
+					ScheduleId:      *oncallRaw.Schedule.Id,
+					UserId:          *oncallRaw.User.Id,
+					Start:           *oncallRaw.Start,
+					End:             oncallRaw.End,
+					EscalationLevel: resolve(oncallRaw.EscalationLevel),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+var ExtractOncallsMeta = plugin.SubTaskMeta{
+	Name:             "extractOncalls",
+	EntryPoint:       ExtractOncalls,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty on-call shifts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
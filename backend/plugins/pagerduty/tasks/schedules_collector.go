@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"net/http"
+	"reflect"
+)
+
+const RAW_SCHEDULES_TABLE = "pagerduty_schedules"
+
+var _ plugin.SubTaskEntryPoint = CollectSchedules
+
+type (
+	collectedSchedule struct {
+		Schedule json.RawMessage `json:"schedule"`
+	}
+	simplifiedSchedule struct {
+		Id string
+	}
+)
+
+func CollectSchedules(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	db := taskCtx.GetDal()
+	iterator, err := newServiceScheduleIterator(db, data)
+	if err != nil {
+		return err
+	}
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_SCHEDULES_TABLE,
+		},
+		ApiClient:   data.Client,
+		Input:       iterator,
+		UrlTemplate: "schedules/{{ .Input.Id }}",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			rawResult := collectedSchedule{}
+			err := api.UnmarshalResponse(res, &rawResult)
+			return []json.RawMessage{rawResult.Schedule}, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+// serviceEscalationRuleClauses restricts the escalation rules aliased `r` to the ones of the escalation policy of the service
+func serviceEscalationRuleClauses(data *PagerDutyTaskData) []dal.Clause {
+	return []dal.Clause{
+		dal.Join(`JOIN _tool_pagerduty_services s ON (s.connection_id = r.connection_id AND s.escalation_policy_id = r.escalation_policy_id)`),
+		dal.Where("s.connection_id = ? AND s.id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	}
+}
+
+// newServiceScheduleIterator iterates the ids of the schedules the escalation policy of the service escalates to
+func newServiceScheduleIterator(db dal.Dal, data *PagerDutyTaskData) (*api.DalCursorIterator, errors.Error) {
+	clauses := append([]dal.Clause{
+		dal.Select("DISTINCT r.target_id AS id"),
+		dal.From("_tool_pagerduty_escalation_rules r"),
+		dal.Where("r.target_type = ?", models.TargetTypeSchedule),
+	}, serviceEscalationRuleClauses(data)...)
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(simplifiedSchedule{}))
+}
+
+var CollectSchedulesMeta = plugin.SubTaskMeta{
+	Name:             "collectSchedules",
+	EntryPoint:       CollectSchedules,
+	EnabledByDefault: true,
+	Description:      "Collect PagerDuty on-call schedules the service escalates to",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"reflect"
+)
+
+var ConvertSchedulesMeta = plugin.SubTaskMeta{
+	Name:             "convertSchedules",
+	EntryPoint:       ConvertSchedules,
+	EnabledByDefault: true,
+	Description:      "Convert schedules into domain layer table oncall_schedules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertSchedules(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	clauses := append([]dal.Clause{
+		dal.Select("DISTINCT sc.*"),
+		dal.From("_tool_pagerduty_schedules AS sc"),
+		dal.Join(`JOIN _tool_pagerduty_escalation_rules AS r ON r.connection_id = sc.connection_id AND r.target_id = sc.id`),
+		dal.Where("r.target_type = ?", models.TargetTypeSchedule),
+	}, serviceEscalationRuleClauses(data)...)
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	scheduleIdGen := didgen.NewDomainIdGenerator(&models.Schedule{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_SCHEDULES_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.Schedule{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			schedule := inputRow.(*models.Schedule)
+			return []interface{}{
+				&ticket.OncallSchedule{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: scheduleIdGen.Generate(schedule.ConnectionId, schedule.Id),
+					},
+					Name:        schedule.Name,
+					Description: schedule.Description,
+					Url:         schedule.Url,
+					TimeZone:    schedule.TimeZone,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
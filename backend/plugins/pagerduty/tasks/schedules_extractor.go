@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractSchedules
+
+func ExtractSchedules(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_SCHEDULES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			scheduleRaw := &raw.Schedules{}
+			err := errors.Convert(json.Unmarshal(row.Data, scheduleRaw))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, 2*len(scheduleRaw.Users)+1)
+			schedule := &models.Schedule{
+				ConnectionId: data.Options.ConnectionId,
+				Id:           *scheduleRaw.Id,
+				Url:          resolve(scheduleRaw.HtmlUrl),
+				Name:         resolve(scheduleRaw.Name),
+				Description:  resolve(scheduleRaw.Description),
+				TimeZone:     resolve(scheduleRaw.TimeZone),
+			}
+			results = append(results, schedule)
+			for _, userRaw := range scheduleRaw.Users {
+				results = append(results, &models.ScheduleUser{
+					ConnectionId: data.Options.ConnectionId,
+					ScheduleId:   schedule.Id,
+					UserId:       *userRaw.Id,
+				})
+				results = append(results, &models.User{
+					ConnectionId: data.Options.ConnectionId,
+					Id:           *userRaw.Id,
+					Url:          resolve(userRaw.HtmlUrl),
+					Name:         resolve(userRaw.Summary),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+var ExtractSchedulesMeta = plugin.SubTaskMeta{
+	Name:             "extractSchedules",
+	EntryPoint:       ExtractSchedules,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty on-call schedules and their users",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -35,7 +35,7 @@ func ConvertServices(taskCtx plugin.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs := &helper.RawDataSubTaskArgs{
 		Ctx:     taskCtx,
 		Options: data.Options,
-		Table:   "pagerduty_services",
+		Table:   RAW_SERVICES_TABLE,
 	}
 	clauses := []dal.Clause{
 		dal.Select("services.*"),
@@ -58,8 +58,10 @@ func ConvertServices(taskCtx plugin.SubTaskContext) errors.Error {
 				DomainEntity: domainlayer.DomainEntity{
 					Id: didgen.NewDomainIdGenerator(service).Generate(service.ConnectionId, service.Id),
 				},
-				Name: service.Name,
-				Url:  service.Url,
+				Name:        service.Name,
+				Description: service.Description,
+				Url:         service.Url,
+				CreatedDate: service.CreatedDate,
 			}
 			return []interface{}{
 				domainBoard,
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"net/http"
+)
+
+const RAW_SERVICES_TABLE = "pagerduty_services"
+
+var _ plugin.SubTaskEntryPoint = CollectServices
+
+type collectedService struct {
+	Service json.RawMessage `json:"service"`
+}
+
+func CollectServices(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_SERVICES_TABLE,
+		},
+		ApiClient:   data.Client,
+		UrlTemplate: "services/{{ .Params.ScopeId }}",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			rawResult := collectedService{}
+			err := api.UnmarshalResponse(res, &rawResult)
+			return []json.RawMessage{rawResult.Service}, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectServicesMeta = plugin.SubTaskMeta{
+	Name:             "collectServices",
+	EntryPoint:       CollectServices,
+	EnabledByDefault: true,
+	Description:      "Collect PagerDuty services",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractServices
+
+func ExtractServices(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	db := taskCtx.GetDal()
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_SERVICES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			serviceRaw := &raw.Services{}
+			err := errors.Convert(json.Unmarshal(row.Data, serviceRaw))
+			if err != nil {
+				return nil, err
+			}
+			// the service is the scope, keep the fields set through the scope api such as the transformation rule
+			service := &models.Service{}
+			err = db.First(service, dal.Where("connection_id = ? AND id = ?", data.Options.ConnectionId, *serviceRaw.Id))
+			if err != nil && !db.IsErrorNotFound(err) {
+				return nil, err
+			}
+			service.ConnectionId = data.Options.ConnectionId
+			service.Id = *serviceRaw.Id
+			service.Url = resolve(serviceRaw.HtmlUrl)
+			service.Name = resolve(serviceRaw.Name)
+			service.Description = resolve(serviceRaw.Description)
+			service.Status = resolve(serviceRaw.Status)
+			service.CreatedDate = serviceRaw.CreatedAt
+			if serviceRaw.EscalationPolicy != nil {
+				service.EscalationPolicyId = resolve(serviceRaw.EscalationPolicy.Id)
+			}
+			return []interface{}{service}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+var ExtractServicesMeta = plugin.SubTaskMeta{
+	Name:             "extractServices",
+	EntryPoint:       ExtractServices,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty services",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
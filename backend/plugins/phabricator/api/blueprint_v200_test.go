@@ -0,0 +1,163 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	connection := &models.PhabricatorConnection{
+		BaseConnection: helper.BaseConnection{
+			Name: "phabricator-test",
+			Model: common.Model{
+				ID: 1,
+			},
+		},
+		PhabricatorConn: models.PhabricatorConn{
+			RestConnection: helper.RestConnection{
+				Endpoint:         "https://phabricator.example.com/",
+				Proxy:            "",
+				RateLimitPerHour: 0,
+			},
+			PhabricatorConduitToken: models.PhabricatorConduitToken{
+				Token: "api-secret",
+			},
+		},
+	}
+	mockMeta := mockplugin.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/phabricator")
+	err := plugin.RegisterPlugin("phabricator", mockMeta)
+	assert.Nil(t, err)
+	// Refresh Global Variables and set the sql mock
+	basicRes = NewMockBasicRes()
+	bs := &plugin.BlueprintScopeV200{
+		Entities: []string{"CODEREVIEW", "TICKET"},
+		Id:       "PHID-REPO-abcdefghijklmnopqrst",
+	}
+	bpScopes := make([]*plugin.BlueprintScopeV200, 0)
+	bpScopes = append(bpScopes, bs)
+	syncPolicy := &plugin.BlueprintSyncPolicy{}
+
+	plan := make(plugin.PipelinePlan, len(bpScopes))
+	plan, err = makeDataSourcePipelinePlanV200(nil, plan, bpScopes, connection, syncPolicy)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes()
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	repoId := "phabricator:PhabricatorRepository:1:PHID-REPO-abcdefghijklmnopqrst"
+	expectPlan := plugin.PipelinePlan{
+		plugin.PipelineStage{
+			{
+				Plugin:   "phabricator",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"repositoryPhid": "PHID-REPO-abcdefghijklmnopqrst",
+					"connectionId":   uint64(1),
+				},
+			},
+		},
+		plugin.PipelineStage{
+			{
+				Plugin: "refdiff",
+				Options: map[string]interface{}{
+					"repoId":      repoId,
+					"tagsLimit":   10,
+					"tagsOrder":   "reverse semver",
+					"tagsPattern": "pattern",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := make([]plugin.Scope, 0)
+	scopeRepo := &code.Repo{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: repoId,
+		},
+		Name: "Platform Build",
+		Url:  "https://phabricator.example.com/diffusion/12/",
+	}
+	scopeBoard := &ticket.Board{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: repoId,
+		},
+		Name: "Platform Build",
+		Url:  "https://phabricator.example.com/diffusion/12/",
+	}
+	expectScopes = append(expectScopes, scopeRepo, scopeBoard)
+	assert.Equal(t, expectScopes, scopes)
+}
+
+// NewMockBasicRes FIXME ...
+func NewMockBasicRes() *mockcontext.BasicRes {
+	testPhabricatorRepository := &models.PhabricatorRepository{
+		ConnectionId:         1,
+		Phid:                 "PHID-REPO-abcdefghijklmnopqrst",
+		PhabricatorId:        12,
+		Name:                 "Platform Build",
+		Callsign:             "PB",
+		ShortName:            "platform-build",
+		Url:                  "https://phabricator.example.com/diffusion/12/",
+		TransformationRuleId: 1,
+	}
+
+	testTransformationRule := &models.PhabricatorTransformationRule{
+		Model: common.Model{
+			ID: 1,
+		},
+		Name: "Phabricator transformation rule",
+		Refdiff: map[string]interface{}{
+			"tagsPattern": "pattern",
+			"tagsLimit":   10,
+			"tagsOrder":   "reverse semver",
+		},
+	}
+	mockRes := new(mockcontext.BasicRes)
+	mockDal := new(mockdal.Dal)
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dst := args.Get(0).(*models.PhabricatorRepository)
+		*dst = *testPhabricatorRepository
+	}).Return(nil).Once()
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dst := args.Get(0).(*models.PhabricatorTransformationRule)
+		*dst = *testTransformationRule
+	}).Return(nil).Once()
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
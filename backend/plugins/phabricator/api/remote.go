@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+	"github.com/apache/incubator-devlake/plugins/phabricator/tasks"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the repositories of Diffusion are not grouped
+// @Tags plugins/phabricator
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/phabricator/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.PhabricatorConnection) ([]models.GroupResponse, errors.Error) {
+			return nil, nil
+		},
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.PhabricatorConnection) ([]models.PhabricatorApiRepository, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &connection)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+			}
+			return listRepositories(apiClient, &connection, url.Values{}, queryData)
+		},
+	)
+}
+
+// SearchRemoteScopes use the Search API and only return repository
+// @Summary use the Search API and only return repository
+// @Description use the Search API and only return repository
+// @Tags plugins/phabricator
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/phabricator/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.PhabricatorConnection) ([]models.PhabricatorApiRepository, errors.Error) {
+			apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &connection)
+			if err != nil {
+				return nil, err
+			}
+			query := url.Values{}
+			query.Set("constraints[query]", queryData.Search[0])
+			return listRepositories(apiClient, &connection, query, queryData)
+		},
+	)
+}
+
+// listRepositories returns a page of the active repositories, Conduit pages with a cursor so the pages before
+// the requested one are walked through
+func listRepositories(apiClient *api.ApiClient, connection *models.PhabricatorConnection, query url.Values, queryData *api.RemoteQueryData) ([]models.PhabricatorApiRepository, errors.Error) {
+	query.Set("constraints[status]", "active")
+	query.Set("order", "name")
+	query.Set("limit", fmt.Sprintf("%v", queryData.PerPage))
+	for page := 1; ; page++ {
+		res, err := apiClient.Get("diffusion.repository.search", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		result := &struct {
+			Data   []models.PhabricatorApiRepository `json:"data"`
+			Cursor struct {
+				After *string `json:"after"`
+			} `json:"cursor"`
+		}{}
+		err = tasks.UnmarshalResponse(res, result)
+		if err != nil {
+			return nil, err
+		}
+		if page >= queryData.Page {
+			for i := range result.Data {
+				result.Data[i].Url = tasks.RepositoryUrl(connection.Endpoint, result.Data[i].Id)
+			}
+			return result.Data, nil
+		}
+		if result.Cursor.After == nil || strings.TrimSpace(*result.Cursor.After) == "" {
+			return nil, nil
+		}
+		query.Set("after", *result.Cursor.After)
+	}
+}
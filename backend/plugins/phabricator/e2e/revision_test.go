@@ -0,0 +1,263 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/phabricator/impl"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+	"github.com/apache/incubator-devlake/plugins/phabricator/tasks"
+)
+
+func TestPhabricatorRevisionDataFlow(t *testing.T) {
+
+	var phabricator impl.Phabricator
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "phabricator", phabricator)
+
+	taskData := &tasks.PhabricatorTaskData{
+		Options: &tasks.PhabricatorOptions{
+			ConnectionId:   1,
+			RepositoryPhid: "PHID-REPO-abcdefghijklmnopqrst",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_phabricator_api_revisions.csv", "_raw_phabricator_api_revisions")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_phabricator_api_diffs.csv", "_raw_phabricator_api_diffs")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_phabricator_api_transactions.csv", "_raw_phabricator_api_transactions")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.PhabricatorRevision{})
+	dataflowTester.FlushTabler(&models.PhabricatorRevisionReviewer{})
+	dataflowTester.Subtask(tasks.ExtractApiRevisionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.PhabricatorRevision{},
+		"./snapshot_tables/_tool_phabricator_revisions.csv",
+		[]string{
+			"connection_id",
+			"phabricator_id",
+			"phid",
+			"repository_phid",
+			"title",
+			"summary",
+			"test_plan",
+			"uri",
+			"author_phid",
+			"status",
+			"status_name",
+			"closed",
+			"diff_phid",
+			"created_date",
+			"updated_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+	dataflowTester.VerifyTable(
+		models.PhabricatorRevisionReviewer{},
+		"./snapshot_tables/_tool_phabricator_revision_reviewers.csv",
+		[]string{
+			"connection_id",
+			"revision_id",
+			"reviewer_phid",
+			"status",
+			"is_blocking",
+			"actor_phid",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	dataflowTester.FlushTabler(&models.PhabricatorDiff{})
+	dataflowTester.FlushTabler(&models.PhabricatorDiffCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiDiffsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.PhabricatorDiff{},
+		"./snapshot_tables/_tool_phabricator_diffs.csv",
+		[]string{
+			"connection_id",
+			"phabricator_id",
+			"phid",
+			"revision_phid",
+			"author_phid",
+			"repository_phid",
+			"branch",
+			"onto_branch",
+			"base_commit_sha",
+			"created_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+	dataflowTester.VerifyTable(
+		models.PhabricatorDiffCommit{},
+		"./snapshot_tables/_tool_phabricator_diff_commits.csv",
+		[]string{
+			"connection_id",
+			"diff_id",
+			"commit_sha",
+			"message",
+			"author_name",
+			"author_email",
+			"authored_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	dataflowTester.FlushTabler(&models.PhabricatorTransaction{})
+	dataflowTester.Subtask(tasks.ExtractApiTransactionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.PhabricatorTransaction{},
+		"./snapshot_tables/_tool_phabricator_transactions.csv",
+		[]string{
+			"connection_id",
+			"phabricator_id",
+			"phid",
+			"revision_id",
+			"type",
+			"author_phid",
+			"comment",
+			"path",
+			"line",
+			"created_date",
+			"_raw_data_params",
+			"_raw_data_table",
+			"_raw_data_id",
+			"_raw_data_remark",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_phabricator_repositories.csv", &models.PhabricatorRepository{})
+	dataflowTester.FlushTabler(&code.Repo{})
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&crossdomain.BoardRepo{})
+	dataflowTester.Subtask(tasks.ConvertRepositoryMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.Repo{},
+		"./snapshot_tables/repos.csv",
+		[]string{
+			"id",
+			"name",
+			"url",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.Board{},
+		"./snapshot_tables/boards.csv",
+		[]string{
+			"id",
+			"name",
+			"url",
+		},
+	)
+	dataflowTester.VerifyTable(
+		crossdomain.BoardRepo{},
+		"./snapshot_tables/board_repos.csv",
+		[]string{
+			"board_id",
+			"repo_id",
+		},
+	)
+
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_phabricator_users.csv", &models.PhabricatorUser{})
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertRevisionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequest{},
+		"./snapshot_tables/pull_requests.csv",
+		[]string{
+			"id",
+			"base_repo_id",
+			"head_repo_id",
+			"status",
+			"original_status",
+			"title",
+			"description",
+			"url",
+			"author_name",
+			"author_id",
+			"pull_request_key",
+			"created_date",
+			"merged_date",
+			"closed_date",
+			"base_ref",
+			"base_commit_sha",
+			"head_ref",
+		},
+	)
+
+	dataflowTester.FlushTabler(&code.PullRequestCommit{})
+	dataflowTester.Subtask(tasks.ConvertDiffCommitsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestCommit{},
+		"./snapshot_tables/pull_request_commits.csv",
+		[]string{
+			"commit_sha",
+			"pull_request_id",
+			"commit_author_name",
+			"commit_author_email",
+			"commit_authored_date",
+		},
+	)
+
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.FlushTabler(&code.PullRequestReviewEvent{})
+	dataflowTester.Subtask(tasks.ConvertTransactionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestComment{},
+		"./snapshot_tables/pull_request_comments.csv",
+		[]string{
+			"id",
+			"pull_request_id",
+			"body",
+			"account_id",
+			"created_date",
+			"type",
+			"position",
+		},
+	)
+	dataflowTester.VerifyTable(
+		code.PullRequestReviewEvent{},
+		"./snapshot_tables/pull_request_review_events.csv",
+		[]string{
+			"id",
+			"pull_request_id",
+			"type",
+			"original_type",
+			"reviewer_id",
+			"actor_id",
+			"review_id",
+			"created_date",
+		},
+	)
+}
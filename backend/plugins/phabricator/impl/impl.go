@@ -0,0 +1,274 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/phabricator/tasks"
+)
+
+var _ plugin.PluginMeta = (*Phabricator)(nil)
+var _ plugin.PluginInit = (*Phabricator)(nil)
+var _ plugin.PluginTask = (*Phabricator)(nil)
+var _ plugin.PluginApi = (*Phabricator)(nil)
+var _ plugin.PluginModel = (*Phabricator)(nil)
+var _ plugin.PluginMigration = (*Phabricator)(nil)
+var _ plugin.CloseablePluginTask = (*Phabricator)(nil)
+var _ plugin.PluginSource = (*Phabricator)(nil)
+
+type Phabricator string
+
+func (p Phabricator) Connection() interface{} {
+	return &models.PhabricatorConnection{}
+}
+
+func (p Phabricator) Scope() interface{} {
+	return &models.PhabricatorRepository{}
+}
+
+func (p Phabricator) TransformationRule() interface{} {
+	return &models.PhabricatorTransformationRule{}
+}
+
+func (p Phabricator) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Phabricator) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.PhabricatorConnection{},
+		&models.PhabricatorRepository{},
+		&models.PhabricatorUser{},
+		&models.PhabricatorRevision{},
+		&models.PhabricatorRevisionReviewer{},
+		&models.PhabricatorDiff{},
+		&models.PhabricatorDiffCommit{},
+		&models.PhabricatorTransaction{},
+		&models.PhabricatorTask{},
+		&models.PhabricatorRevisionTask{},
+		&models.PhabricatorTransformationRule{},
+	}
+}
+
+func (p Phabricator) Description() string {
+	return "To collect and enrich data from Phabricator"
+}
+
+func (p Phabricator) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiRevisionsMeta,
+		tasks.ExtractApiRevisionsMeta,
+		tasks.CollectApiDiffsMeta,
+		tasks.ExtractApiDiffsMeta,
+		tasks.CollectApiTransactionsMeta,
+		tasks.ExtractApiTransactionsMeta,
+		tasks.CollectApiRevisionTasksMeta,
+		tasks.ExtractApiRevisionTasksMeta,
+		tasks.CollectApiTasksMeta,
+		tasks.ExtractApiTasksMeta,
+		tasks.CollectApiUsersMeta,
+		tasks.ExtractApiUsersMeta,
+
+		tasks.ConvertRepositoryMeta,
+		tasks.ConvertUsersMeta,
+		tasks.ConvertRevisionsMeta,
+		tasks.ConvertDiffCommitsMeta,
+		tasks.ConvertTransactionsMeta,
+		tasks.ConvertTasksMeta,
+		tasks.ConvertRevisionTasksMeta,
+		tasks.EnrichPullRequestStatsMeta,
+	}
+}
+
+func (p Phabricator) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.PhabricatorConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get phabricator connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get phabricator API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, connection, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeAfter time.Time
+	if op.TimeAfter != "" {
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+	}
+	regexEnricher := helper.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(ticket.BUG, op.IssueTypeBug); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `issueTypeBug`")
+	}
+	if err := regexEnricher.TryAdd(ticket.INCIDENT, op.IssueTypeIncident); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `issueTypeIncident`")
+	}
+	if err := regexEnricher.TryAdd(ticket.REQUIREMENT, op.IssueTypeRequirement); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `issueTypeRequirement`")
+	}
+	taskData := &tasks.PhabricatorTaskData{
+		Options:       op,
+		ApiClient:     apiClient,
+		RegexEnricher: regexEnricher,
+	}
+	if !timeAfter.IsZero() {
+		taskData.TimeAfter = &timeAfter
+		logger.Debug("collect data updated timeAfter %s", timeAfter)
+	}
+
+	return taskData, nil
+}
+
+func (p Phabricator) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/phabricator"
+}
+
+func (p Phabricator) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Phabricator) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Phabricator) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/:scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
+	}
+}
+
+func (p Phabricator) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.PhabricatorTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.PhabricatorOptions,
+	connection *models.PhabricatorConnection,
+	apiClient *helper.ApiClient) errors.Error {
+	var repository models.PhabricatorRepository
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&repository, dal.Where(
+		"connection_id = ? AND phid = ?",
+		op.ConnectionId, op.RepositoryPhid))
+	if err == nil {
+		if op.TransformationRuleId == 0 {
+			op.TransformationRuleId = repository.TransformationRuleId
+		}
+	} else {
+		if db.IsErrorNotFound(err) {
+			var apiRepository *models.PhabricatorApiRepository
+			apiRepository, err = tasks.GetApiRepository(op.RepositoryPhid, apiClient)
+			if err != nil {
+				return err
+			}
+			logger.Debug(fmt.Sprintf("Current repository: %s", apiRepository.Fields.Name))
+			apiRepository.Url = tasks.RepositoryUrl(connection.Endpoint, apiRepository.Id)
+			scope := apiRepository.ConvertApiScope().(*models.PhabricatorRepository)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find repository %s", op.RepositoryPhid))
+		}
+	}
+	if op.PhabricatorTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.PhabricatorTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.PhabricatorTransformationRule = &transformationRule
+	}
+	if op.PhabricatorTransformationRule == nil {
+		op.PhabricatorTransformationRule = new(models.PhabricatorTransformationRule)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*PhabricatorConnection)(nil)
+
+// PhabricatorConduitToken authenticates with a Conduit api token, Conduit reads it from the `api.token` parameter
+// rather than from a header
+type PhabricatorConduitToken api.AccessToken
+
+// SetupAuthentication adds the token to the query of the request
+func (ct *PhabricatorConduitToken) SetupAuthentication(request *http.Request) errors.Error {
+	query := request.URL.Query()
+	query.Set("api.token", ct.Token)
+	request.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// PhabricatorConn holds the essential information to connect to the Conduit API of Phabricator,
+// the endpoint looks like https://phabricator.example.com/api/
+type PhabricatorConn struct {
+	api.RestConnection      `mapstructure:",squash"`
+	PhabricatorConduitToken `mapstructure:",squash"`
+}
+
+// PhabricatorConnection holds PhabricatorConn plus ID/Name for database storage
+type PhabricatorConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	PhabricatorConn    `mapstructure:",squash"`
+}
+
+func (PhabricatorConnection) TableName() string {
+	return "_tool_phabricator_connections"
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type PhabricatorDiff struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	PhabricatorId  int    `gorm:"primaryKey;autoIncrement:false"`
+	Phid           string `gorm:"type:varchar(100)"`
+	RevisionPhid   string `gorm:"index;type:varchar(100)"`
+	AuthorPhid     string `gorm:"type:varchar(100)"`
+	RepositoryPhid string `gorm:"type:varchar(100)"`
+	Branch         string `gorm:"type:varchar(255)"`
+	OntoBranch     string `gorm:"type:varchar(255)"`
+	BaseCommitSha  string `gorm:"type:varchar(40)"`
+	CreatedDate    time.Time
+	archived.NoPKModel
+}
+
+func (PhabricatorDiff) TableName() string {
+	return "_tool_phabricator_diffs"
+}
+
+type PhabricatorDiffCommit struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	DiffId       int    `gorm:"primaryKey;autoIncrement:false"`
+	CommitSha    string `gorm:"primaryKey;type:varchar(40)"`
+	Message      string
+	AuthorName   string `gorm:"type:varchar(255)"`
+	AuthorEmail  string `gorm:"type:varchar(255)"`
+	AuthoredDate *time.Time
+	archived.NoPKModel
+}
+
+func (PhabricatorDiffCommit) TableName() string {
+	return "_tool_phabricator_diff_commits"
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type PhabricatorRevision struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	PhabricatorId  int    `gorm:"primaryKey;autoIncrement:false"`
+	Phid           string `gorm:"index;type:varchar(100)"`
+	RepositoryPhid string `gorm:"index;type:varchar(100)"`
+	Title          string
+	Summary        string
+	TestPlan       string
+	Uri            string `gorm:"type:varchar(255)"`
+	AuthorPhid     string `gorm:"type:varchar(100)"`
+	Status         string `gorm:"type:varchar(100)"`
+	StatusName     string `gorm:"type:varchar(100)"`
+	Closed         bool
+	DiffPhid       string `gorm:"type:varchar(100)"`
+	CreatedDate    time.Time
+	UpdatedDate    time.Time
+	archived.NoPKModel
+}
+
+func (PhabricatorRevision) TableName() string {
+	return "_tool_phabricator_revisions"
+}
+
+type PhabricatorRevisionReviewer struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RevisionId   int    `gorm:"primaryKey;autoIncrement:false"`
+	ReviewerPhid string `gorm:"primaryKey;type:varchar(100)"`
+	Status       string `gorm:"type:varchar(100)"`
+	IsBlocking   bool
+	ActorPhid    string `gorm:"type:varchar(100)"`
+	archived.NoPKModel
+}
+
+func (PhabricatorRevisionReviewer) TableName() string {
+	return "_tool_phabricator_revision_reviewers"
+}
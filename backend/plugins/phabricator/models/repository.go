@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*PhabricatorRepository)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*PhabricatorApiRepository)(nil)
+
+// PhabricatorRepository is a repository hosted by Diffusion, the revisions of Differential are collected by the
+// repository they are attached to
+type PhabricatorRepository struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	Phid                 string `json:"phid" gorm:"primaryKey;type:varchar(100)" validate:"required" mapstructure:"phid"`
+	PhabricatorId        int    `json:"phabricatorId" mapstructure:"phabricatorId,omitempty"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Callsign             string `json:"callsign" gorm:"type:varchar(100)" mapstructure:"callsign,omitempty"`
+	ShortName            string `json:"shortName" gorm:"type:varchar(255)" mapstructure:"shortName,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (PhabricatorRepository) TableName() string {
+	return "_tool_phabricator_repositories"
+}
+
+func (r PhabricatorRepository) ScopeId() string {
+	return r.Phid
+}
+
+func (r PhabricatorRepository) ScopeName() string {
+	return r.Name
+}
+
+// PhabricatorApiRepository is the repository entity returned by `diffusion.repository.search`
+type PhabricatorApiRepository struct {
+	Id     int    `json:"id"`
+	Phid   string `json:"phid"`
+	Fields struct {
+		Name      string `json:"name"`
+		Callsign  string `json:"callsign"`
+		ShortName string `json:"shortName"`
+	} `json:"fields"`
+	// Url is not a part of the response, it is filled by the caller who knows the endpoint
+	Url string `json:"-"`
+}
+
+func (r PhabricatorApiRepository) ConvertApiScope() plugin.ToolLayerScope {
+	return &PhabricatorRepository{
+		Phid:          r.Phid,
+		PhabricatorId: r.Id,
+		Name:          r.Fields.Name,
+		Callsign:      r.Fields.Callsign,
+		ShortName:     r.Fields.ShortName,
+		Url:           r.Url,
+	}
+}
+
+// GroupResponse is required by the remote api helper, repositories of Diffusion are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
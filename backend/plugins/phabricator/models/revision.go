@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// PhabricatorRevision is a revision of Differential, it is known as `D123` by its id
+type PhabricatorRevision struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	PhabricatorId  int    `gorm:"primaryKey;autoIncrement:false"`
+	Phid           string `gorm:"index;type:varchar(100)"`
+	RepositoryPhid string `gorm:"index;type:varchar(100)"`
+	Title          string
+	Summary        string
+	TestPlan       string
+	Uri            string `gorm:"type:varchar(255)"`
+	AuthorPhid     string `gorm:"type:varchar(100)"`
+	Status         string `gorm:"type:varchar(100)"`
+	StatusName     string `gorm:"type:varchar(100)"`
+	Closed         bool
+	// DiffPhid is the active diff of the revision, the one the reviewers are looking at
+	DiffPhid    string `gorm:"type:varchar(100)"`
+	CreatedDate time.Time
+	UpdatedDate time.Time
+	common.NoPKModel
+}
+
+func (PhabricatorRevision) TableName() string {
+	return "_tool_phabricator_revisions"
+}
+
+const (
+	REVISION_STATUS_DRAFT           = "draft"
+	REVISION_STATUS_NEEDS_REVIEW    = "needs-review"
+	REVISION_STATUS_NEEDS_REVISION  = "needs-revision"
+	REVISION_STATUS_CHANGES_PLANNED = "changes-planned"
+	REVISION_STATUS_ACCEPTED        = "accepted"
+	REVISION_STATUS_PUBLISHED       = "published"
+	REVISION_STATUS_ABANDONED       = "abandoned"
+)
+
+// PhabricatorRevisionReviewer is a user or a project asked to review a revision,
+// the status tells whether the reviewer has accepted or rejected it yet
+type PhabricatorRevisionReviewer struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RevisionId   int    `gorm:"primaryKey;autoIncrement:false"`
+	ReviewerPhid string `gorm:"primaryKey;type:varchar(100)"`
+	Status       string `gorm:"type:varchar(100)"`
+	IsBlocking   bool
+	ActorPhid    string `gorm:"type:varchar(100)"`
+	common.NoPKModel
+}
+
+func (PhabricatorRevisionReviewer) TableName() string {
+	return "_tool_phabricator_revision_reviewers"
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// PhabricatorTransaction is a change made to a revision, like a comment, an inline comment on the diff or a review
+// action. Path and Line are only set for the inline comments
+type PhabricatorTransaction struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	PhabricatorId int    `gorm:"primaryKey;autoIncrement:false"`
+	Phid          string `gorm:"type:varchar(100)"`
+	RevisionId    int    `gorm:"index"`
+	Type          string `gorm:"type:varchar(100)"`
+	AuthorPhid    string `gorm:"type:varchar(100)"`
+	Comment       string
+	Path          string
+	Line          int
+	CreatedDate   time.Time
+	common.NoPKModel
+}
+
+func (PhabricatorTransaction) TableName() string {
+	return "_tool_phabricator_transactions"
+}
+
+const (
+	TRANSACTION_TYPE_COMMENT         = "comment"
+	TRANSACTION_TYPE_INLINE          = "inline"
+	TRANSACTION_TYPE_ACCEPT          = "accept"
+	TRANSACTION_TYPE_REJECT          = "reject"
+	TRANSACTION_TYPE_REQUEST_CHANGES = "request-changes"
+	TRANSACTION_TYPE_RESIGN          = "resign"
+	TRANSACTION_TYPE_REVIEWERS       = "reviewers"
+)
@@ -0,0 +1,178 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+type PhabricatorApiParams struct {
+	ConnectionId   uint64
+	RepositoryPhid string
+}
+
+// RevisionInput is the input of the collectors requesting the diffs, the transactions and the tasks of each revision
+type RevisionInput struct {
+	PhabricatorId int
+	Phid          string
+}
+
+// conduitResponse is the envelope of every Conduit response, a failure is reported by `error_code`
+// while the status is still 200
+type conduitResponse struct {
+	Result    json.RawMessage `json:"result"`
+	ErrorCode *string         `json:"error_code"`
+	ErrorInfo *string         `json:"error_info"`
+}
+
+// conduitSearchResult is the result of the `*.search` methods, the next page is requested with the `after` cursor
+// which is null on the last page
+type conduitSearchResult struct {
+	Data   []json.RawMessage `json:"data"`
+	Cursor struct {
+		After json.RawMessage `json:"after"`
+	} `json:"cursor"`
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *PhabricatorTaskData) {
+	data := taskCtx.GetData().(*PhabricatorTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: PhabricatorApiParams{
+			ConnectionId:   data.Options.ConnectionId,
+			RepositoryPhid: data.Options.RepositoryPhid,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GetQuery pages with `limit` and the `after` cursor returned by the previous page
+func GetQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	if reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	}
+	if after, ok := reqData.CustomData.(string); ok && after != "" {
+		query.Set("after", after)
+	}
+	return query, nil
+}
+
+// UnmarshalResponse reads the result of a Conduit response into v. The url of the request is left out of the errors
+// as it carries the api token
+func UnmarshalResponse(res *http.Response, v interface{}) errors.Error {
+	body := &conduitResponse{}
+	err := api.UnmarshalResponse(res, body)
+	if err != nil {
+		return err
+	}
+	if body.ErrorCode != nil {
+		errorInfo := ""
+		if body.ErrorInfo != nil {
+			errorInfo = *body.ErrorInfo
+		}
+		return errors.Default.New(fmt.Sprintf("conduit error %s when calling %s: %s", *body.ErrorCode, res.Request.URL.Path, errorInfo))
+	}
+	return errors.Convert(json.Unmarshal(body.Result, v))
+}
+
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	result := &conduitSearchResult{}
+	err := UnmarshalResponse(res, result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// GetNextPageCursor returns the `after` cursor of the previous page, the collection finishes when it is null
+func GetNextPageCursor(_ *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	result := &conduitSearchResult{}
+	err := UnmarshalResponse(prevPageResponse, result)
+	if err != nil {
+		return nil, err
+	}
+	after := strings.Trim(string(result.Cursor.After), `"`)
+	if after == "" || after == "null" {
+		return nil, api.ErrFinishCollect
+	}
+	return after, nil
+}
+
+// GetRevisionsIterator iterates the revisions of the repository, only the ones updated since the last collection
+// are iterated when collecting incrementally
+func GetRevisionsIterator(taskCtx plugin.SubTaskContext, collectorWithState *api.ApiCollectorStateManager) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PhabricatorTaskData)
+	clauses := []dal.Clause{
+		dal.Select("phabricator_id, phid"),
+		dal.From(&models.PhabricatorRevision{}),
+		dal.Where("connection_id = ? AND repository_phid = ?", data.Options.ConnectionId, data.Options.RepositoryPhid),
+	}
+	if collectorWithState.IsIncremental() {
+		clauses = append(clauses, dal.Where("updated_date > ?", *collectorWithState.LatestState.LatestSuccessStart))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(RevisionInput{}))
+}
+
+// secToTime converts the dates of the api, they are epoch seconds
+func secToTime(sec int64) *time.Time {
+	if sec == 0 {
+		return nil
+	}
+	t := time.Unix(sec, 0).UTC()
+	return &t
+}
+
+// ServerUrl is the web ui of Phabricator, the endpoint of the connection is its `/api/`
+func ServerUrl(endpoint string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(endpoint, "/"), "/api")
+}
+
+// RepositoryUrl is the page of the repository in Diffusion, it is addressed by its id as not every
+// repository has got a callsign or a short name
+func RepositoryUrl(endpoint string, repositoryId int) string {
+	return fmt.Sprintf("%s/diffusion/%d/", ServerUrl(endpoint), repositoryId)
+}
+
+// taskUrl derives the web url of a task from the web url of the repository,
+// which looks like https://phabricator.example.com/diffusion/{id}/
+func taskUrl(repository *models.PhabricatorRepository, taskId int) string {
+	serverUrl := repository.Url
+	if i := strings.LastIndex(serverUrl, "/diffusion/"); i >= 0 {
+		serverUrl = serverUrl[:i]
+	}
+	return fmt.Sprintf("%s/T%d", serverUrl, taskId)
+}
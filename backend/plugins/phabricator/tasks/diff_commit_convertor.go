@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ConvertDiffCommitsMeta = plugin.SubTaskMeta{
+	Name:             "convertDiffCommits",
+	EntryPoint:       ConvertDiffCommits,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table phabricator_diff_commits into domain layer table pull_request_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type diffCommitWithRevision struct {
+	models.PhabricatorDiffCommit
+	RevisionId      int
+	DiffCreatedDate time.Time
+}
+
+// ConvertDiffCommits turns the local commits of every diff into commits of the pull request, the diffs uploaded
+// without `arc` carry no commits
+func ConvertDiffCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DIFF_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("dc.*, r.phabricator_id AS revision_id, d.created_date AS diff_created_date"),
+		dal.From("_tool_phabricator_diff_commits dc"),
+		dal.Join("LEFT JOIN _tool_phabricator_diffs d ON d.connection_id = dc.connection_id AND d.phabricator_id = dc.diff_id"),
+		dal.Join("LEFT JOIN _tool_phabricator_revisions r ON r.connection_id = d.connection_id AND r.phid = d.revision_phid"),
+		dal.Where("r.repository_phid = ? AND dc.connection_id = ?", data.Options.RepositoryPhid, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	revisionIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorRevision{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(diffCommitWithRevision{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			diffCommit := inputRow.(*diffCommitWithRevision)
+			prCommit := &code.PullRequestCommit{
+				CommitSha:          diffCommit.CommitSha,
+				PullRequestId:      revisionIdGen.Generate(diffCommit.ConnectionId, diffCommit.RevisionId),
+				CommitAuthorName:   diffCommit.AuthorName,
+				CommitAuthorEmail:  diffCommit.AuthorEmail,
+				CommitAuthoredDate: diffCommit.DiffCreatedDate,
+			}
+			if diffCommit.AuthoredDate != nil {
+				prCommit.CommitAuthoredDate = *diffCommit.AuthoredDate
+			}
+			return []interface{}{
+				prCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
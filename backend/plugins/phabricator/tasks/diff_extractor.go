@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ExtractApiDiffsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiDiffs",
+	EntryPoint:       ExtractApiDiffs,
+	EnabledByDefault: true,
+	Description:      "Extract raw diffs data into tool layer table phabricator_diffs and phabricator_diff_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// PhabricatorApiDiff is the diff entity returned by `differential.diff.search`
+type PhabricatorApiDiff struct {
+	Id     int    `json:"id"`
+	Phid   string `json:"phid"`
+	Fields struct {
+		RevisionPhid   string `json:"revisionPHID"`
+		AuthorPhid     string `json:"authorPHID"`
+		RepositoryPhid string `json:"repositoryPHID"`
+		// Refs tells the branch the diff was uploaded from, the branch it is meant to land onto
+		// and the commit it is based on
+		Refs []struct {
+			Type       string `json:"type"`
+			Name       string `json:"name"`
+			Identifier string `json:"identifier"`
+		} `json:"refs"`
+		DateCreated int64 `json:"dateCreated"`
+	} `json:"fields"`
+	Attachments struct {
+		Commits struct {
+			Commits []struct {
+				Identifier string `json:"identifier"`
+				Message    string `json:"message"`
+				Author     struct {
+					Name  string `json:"name"`
+					Email string `json:"email"`
+					Epoch int64  `json:"epoch"`
+				} `json:"author"`
+			} `json:"commits"`
+		} `json:"commits"`
+	} `json:"attachments"`
+}
+
+func ExtractApiDiffs(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DIFF_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiDiff := &PhabricatorApiDiff{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiDiff))
+			if err != nil {
+				return nil, err
+			}
+			diff := &models.PhabricatorDiff{
+				ConnectionId:   data.Options.ConnectionId,
+				PhabricatorId:  apiDiff.Id,
+				Phid:           apiDiff.Phid,
+				RevisionPhid:   apiDiff.Fields.RevisionPhid,
+				AuthorPhid:     apiDiff.Fields.AuthorPhid,
+				RepositoryPhid: apiDiff.Fields.RepositoryPhid,
+				CreatedDate:    time.Unix(apiDiff.Fields.DateCreated, 0).UTC(),
+			}
+			for _, ref := range apiDiff.Fields.Refs {
+				switch ref.Type {
+				case "branch":
+					diff.Branch = ref.Name
+				case "onto":
+					diff.OntoBranch = ref.Name
+				case "base":
+					diff.BaseCommitSha = ref.Identifier
+				}
+			}
+			results := []interface{}{diff}
+			for _, commit := range apiDiff.Attachments.Commits.Commits {
+				results = append(results, &models.PhabricatorDiffCommit{
+					ConnectionId: data.Options.ConnectionId,
+					DiffId:       apiDiff.Id,
+					CommitSha:    commit.Identifier,
+					Message:      commit.Message,
+					AuthorName:   commit.Author.Name,
+					AuthorEmail:  commit.Author.Email,
+					AuthoredDate: secToTime(commit.Author.Epoch),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
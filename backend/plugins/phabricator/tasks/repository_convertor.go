@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+const RAW_REPOSITORY_TABLE = "phabricator_api_repositories"
+
+var ConvertRepositoryMeta = plugin.SubTaskMeta{
+	Name:             "convertRepository",
+	EntryPoint:       ConvertRepository,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table phabricator_repositories into domain layer table repos and boards",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET},
+}
+
+// GetApiRepository fetches a repository by its phid
+func GetApiRepository(repositoryPhid string, apiClient aha.ApiClientAbstract) (*models.PhabricatorApiRepository, errors.Error) {
+	query := url.Values{}
+	query.Set("constraints[phids][0]", repositoryPhid)
+	res, err := apiClient.Get("diffusion.repository.search", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting repository detail %d %s",
+			res.StatusCode, res.Request.URL.Path,
+		))
+	}
+	result := &struct {
+		Data []models.PhabricatorApiRepository `json:"data"`
+	}{}
+	err = UnmarshalResponse(res, result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("repository %s not found", repositoryPhid))
+	}
+	return &result.Data[0], nil
+}
+
+// ConvertRepository turns the repository into a repo and a board, the board holds the Maniphest tasks
+// linked to its revisions
+func ConvertRepository(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REPOSITORY_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.PhabricatorRepository{}),
+		dal.Where("connection_id = ? AND phid = ?", data.Options.ConnectionId, data.Options.RepositoryPhid),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repositoryIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorRepository{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.PhabricatorRepository{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			repository := inputRow.(*models.PhabricatorRepository)
+			repositoryId := repositoryIdGen.Generate(repository.ConnectionId, repository.Phid)
+			return []interface{}{
+				&code.Repo{
+					DomainEntity: domainlayer.DomainEntity{Id: repositoryId},
+					Name:         repository.Name,
+					Url:          repository.Url,
+				},
+				&ticket.Board{
+					DomainEntity: domainlayer.DomainEntity{Id: repositoryId},
+					Name:         repository.Name,
+					Url:          repository.Url,
+				},
+				&crossdomain.BoardRepo{
+					BoardId: repositoryId,
+					RepoId:  repositoryId,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
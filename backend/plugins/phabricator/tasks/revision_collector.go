@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_REVISION_TABLE = "phabricator_api_revisions"
+
+var CollectApiRevisionsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiRevisions",
+	EntryPoint:       CollectApiRevisions,
+	EnabledByDefault: true,
+	Description:      "Collect revisions data along with their reviewers from Phabricator api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectApiRevisions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REVISION_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "differential.revision.search",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("constraints[repositoryPHIDs][0]", data.Options.RepositoryPhid)
+			query.Set("attachments[reviewers]", "1")
+			query.Set("order", "updated")
+			// `modifiedStart` is compared with the update time of revisions in epoch seconds
+			if collectorWithState.IsIncremental() {
+				query.Set("constraints[modifiedStart]", fmt.Sprintf("%d", collectorWithState.LatestState.LatestSuccessStart.Unix()))
+			} else if collectorWithState.TimeAfter != nil {
+				query.Set("constraints[modifiedStart]", fmt.Sprintf("%d", collectorWithState.TimeAfter.Unix()))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: GetNextPageCursor,
+		ResponseParser:        GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
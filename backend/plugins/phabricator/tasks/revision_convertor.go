@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ConvertRevisionsMeta = plugin.SubTaskMeta{
+	Name:             "convertRevisions",
+	EntryPoint:       ConvertRevisions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table phabricator_revisions into domain layer table pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type revisionWithDiff struct {
+	models.PhabricatorRevision
+	Branch        string
+	OntoBranch    string
+	BaseCommitSha string
+	AuthorName    string
+}
+
+// ConvertRevisions takes the branches of a revision from its active diff. The api doesn't tell when a revision is
+// closed, a published or abandoned revision is taken as closed when it was last updated
+func ConvertRevisions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REVISION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("r.*, d.branch, d.onto_branch, d.base_commit_sha, u.real_name AS author_name"),
+		dal.From("_tool_phabricator_revisions r"),
+		dal.Join("LEFT JOIN _tool_phabricator_diffs d ON d.connection_id = r.connection_id AND d.phid = r.diff_phid"),
+		dal.Join("LEFT JOIN _tool_phabricator_users u ON u.connection_id = r.connection_id AND u.phid = r.author_phid"),
+		dal.Where("r.repository_phid = ? AND r.connection_id = ?", data.Options.RepositoryPhid, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	revisionIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorRevision{})
+	repositoryIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorRepository{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorUser{})
+	repositoryId := repositoryIdGen.Generate(data.Options.ConnectionId, data.Options.RepositoryPhid)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(revisionWithDiff{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			revision := inputRow.(*revisionWithDiff)
+			domainPr := &code.PullRequest{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: revisionIdGen.Generate(revision.ConnectionId, revision.PhabricatorId),
+				},
+				BaseRepoId:     repositoryId,
+				HeadRepoId:     repositoryId,
+				OriginalStatus: revision.Status,
+				Title:          revision.Title,
+				Description:    revision.Summary,
+				Url:            revision.Uri,
+				AuthorName:     revision.AuthorName,
+				PullRequestKey: revision.PhabricatorId,
+				CreatedDate:    revision.CreatedDate,
+				HeadRef:        revision.Branch,
+				BaseRef:        revision.OntoBranch,
+				BaseCommitSha:  revision.BaseCommitSha,
+			}
+			if revision.AuthorPhid != "" {
+				domainPr.AuthorId = userIdGen.Generate(revision.ConnectionId, revision.AuthorPhid)
+			}
+			switch revision.Status {
+			case models.REVISION_STATUS_PUBLISHED:
+				domainPr.Status = code.MERGED
+				domainPr.MergedDate = &revision.UpdatedDate
+				domainPr.ClosedDate = &revision.UpdatedDate
+			case models.REVISION_STATUS_ABANDONED:
+				domainPr.Status = code.CLOSED
+				domainPr.ClosedDate = &revision.UpdatedDate
+			default:
+				domainPr.Status = code.OPEN
+			}
+			return []interface{}{
+				domainPr,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
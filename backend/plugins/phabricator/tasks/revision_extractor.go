@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ExtractApiRevisionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiRevisions",
+	EntryPoint:       ExtractApiRevisions,
+	EnabledByDefault: true,
+	Description:      "Extract raw revisions data into tool layer table phabricator_revisions and phabricator_revision_reviewers",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// PhabricatorApiRevision is the revision entity returned by `differential.revision.search`
+type PhabricatorApiRevision struct {
+	Id     int    `json:"id"`
+	Phid   string `json:"phid"`
+	Fields struct {
+		Title          string `json:"title"`
+		Uri            string `json:"uri"`
+		AuthorPhid     string `json:"authorPHID"`
+		RepositoryPhid string `json:"repositoryPHID"`
+		DiffPhid       string `json:"diffPHID"`
+		Summary        string `json:"summary"`
+		TestPlan       string `json:"testPlan"`
+		Status         struct {
+			Value  string `json:"value"`
+			Name   string `json:"name"`
+			Closed bool   `json:"closed"`
+		} `json:"status"`
+		DateCreated  int64 `json:"dateCreated"`
+		DateModified int64 `json:"dateModified"`
+	} `json:"fields"`
+	Attachments struct {
+		Reviewers struct {
+			Reviewers []struct {
+				ReviewerPhid string `json:"reviewerPHID"`
+				Status       string `json:"status"`
+				IsBlocking   bool   `json:"isBlocking"`
+				ActorPhid    string `json:"actorPHID"`
+			} `json:"reviewers"`
+		} `json:"reviewers"`
+	} `json:"attachments"`
+}
+
+func ExtractApiRevisions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REVISION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiRevision := &PhabricatorApiRevision{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiRevision))
+			if err != nil {
+				return nil, err
+			}
+			revision := &models.PhabricatorRevision{
+				ConnectionId:   data.Options.ConnectionId,
+				PhabricatorId:  apiRevision.Id,
+				Phid:           apiRevision.Phid,
+				RepositoryPhid: apiRevision.Fields.RepositoryPhid,
+				Title:          apiRevision.Fields.Title,
+				Summary:        apiRevision.Fields.Summary,
+				TestPlan:       apiRevision.Fields.TestPlan,
+				Uri:            apiRevision.Fields.Uri,
+				AuthorPhid:     apiRevision.Fields.AuthorPhid,
+				Status:         apiRevision.Fields.Status.Value,
+				StatusName:     apiRevision.Fields.Status.Name,
+				Closed:         apiRevision.Fields.Status.Closed,
+				DiffPhid:       apiRevision.Fields.DiffPhid,
+				CreatedDate:    time.Unix(apiRevision.Fields.DateCreated, 0).UTC(),
+				UpdatedDate:    time.Unix(apiRevision.Fields.DateModified, 0).UTC(),
+			}
+			results := []interface{}{revision}
+			for _, reviewer := range apiRevision.Attachments.Reviewers.Reviewers {
+				results = append(results, &models.PhabricatorRevisionReviewer{
+					ConnectionId: data.Options.ConnectionId,
+					RevisionId:   apiRevision.Id,
+					ReviewerPhid: reviewer.ReviewerPhid,
+					Status:       reviewer.Status,
+					IsBlocking:   reviewer.IsBlocking,
+					ActorPhid:    reviewer.ActorPhid,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+// REVISION_TASK_RULE is the rule of the links Phabricator keeps between revisions and tasks
+const REVISION_TASK_RULE = "phabricatorRevisionTask"
+
+var ConvertRevisionTasksMeta = plugin.SubTaskMeta{
+	Name:             "convertRevisionTasks",
+	EntryPoint:       ConvertRevisionTasks,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table phabricator_revision_tasks into domain layer table pull_request_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+type revisionTaskWithIds struct {
+	models.PhabricatorRevisionTask
+	RevisionId int
+	TaskId     int
+}
+
+func ConvertRevisionTasks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REVISION_TASK_TABLE)
+	db := taskCtx.GetDal()
+
+	// the links to the tasks which could not be collected are left out
+	cursor, err := db.Cursor(
+		dal.Select("rt.*, r.phabricator_id AS revision_id, t.phabricator_id AS task_id"),
+		dal.From("_tool_phabricator_revision_tasks rt"),
+		dal.Join("JOIN _tool_phabricator_revisions r ON r.connection_id = rt.connection_id AND r.phid = rt.revision_phid"),
+		dal.Join("JOIN _tool_phabricator_tasks t ON t.connection_id = rt.connection_id AND t.phid = rt.task_phid"),
+		dal.Where("r.repository_phid = ? AND rt.connection_id = ?", data.Options.RepositoryPhid, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	revisionIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorRevision{})
+	taskIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorTask{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(revisionTaskWithIds{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			revisionTask := inputRow.(*revisionTaskWithIds)
+			return []interface{}{
+				&crossdomain.PullRequestIssue{
+					PullRequestId:  revisionIdGen.Generate(revisionTask.ConnectionId, revisionTask.RevisionId),
+					IssueId:        taskIdGen.Generate(revisionTask.ConnectionId, revisionTask.TaskId),
+					PullRequestKey: revisionTask.RevisionId,
+					IssueKey:       revisionTask.TaskId,
+					Confidence:     1,
+					LinkRule:       REVISION_TASK_RULE,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ExtractApiRevisionTasksMeta = plugin.SubTaskMeta{
+	Name:             "extractApiRevisionTasks",
+	EntryPoint:       ExtractApiRevisionTasks,
+	EnabledByDefault: true,
+	Description:      "Extract raw edges data into tool layer table phabricator_revision_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// PhabricatorApiEdge is the edge entity returned by `edge.search`
+type PhabricatorApiEdge struct {
+	SourcePhid      string `json:"sourcePHID"`
+	EdgeType        string `json:"edgeType"`
+	DestinationPhid string `json:"destinationPHID"`
+}
+
+func ExtractApiRevisionTasks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REVISION_TASK_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiEdge := &PhabricatorApiEdge{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiEdge))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.PhabricatorRevisionTask{
+					ConnectionId: data.Options.ConnectionId,
+					RevisionPhid: apiEdge.SourcePhid,
+					TaskPhid:     apiEdge.DestinationPhid,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_TASK_TABLE = "phabricator_api_tasks"
+
+var CollectApiTasksMeta = plugin.SubTaskMeta{
+	Name:             "collectApiTasks",
+	EntryPoint:       CollectApiTasks,
+	EnabledByDefault: true,
+	Description:      "Collect the Maniphest tasks linked to the revisions from Phabricator api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+}
+
+// CollectApiTasks requests the linked tasks by batches of their phids, the tasks are collected in full every time
+// as they are updated independently of the revisions
+func CollectApiTasks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TASK_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT rt.task_phid"),
+		dal.From("_tool_phabricator_revision_tasks rt"),
+		dal.Join("LEFT JOIN _tool_phabricator_revisions r ON r.connection_id = rt.connection_id AND r.phid = rt.revision_phid"),
+		dal.Where("r.repository_phid = ? AND rt.connection_id = ?", data.Options.RepositoryPhid, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewBatchedDalCursorIterator(db, cursor, reflect.TypeOf(""), 100)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Input:              iterator,
+		UrlTemplate:        "maniphest.search",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			for i, phid := range reqData.Input.([]interface{}) {
+				query.Set(fmt.Sprintf("constraints[phids][%d]", i), *phid.(*string))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: GetNextPageCursor,
+		ResponseParser:        GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
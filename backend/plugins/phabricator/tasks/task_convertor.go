@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ConvertTasksMeta = plugin.SubTaskMeta{
+	Name:             "convertTasks",
+	EntryPoint:       ConvertTasks,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table phabricator_tasks into domain layer table issues and board_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type taskWithUsers struct {
+	models.PhabricatorTask
+	AuthorName string
+	OwnerName  string
+}
+
+// ConvertTasks puts the tasks linked to the revisions of the repository onto its board, the subtype of a task is
+// matched against the issue type patterns of the transformation rule. Maniphest only tells whether a task is open
+// or closed, any other open status is taken as in progress
+func ConvertTasks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TASK_TABLE)
+	db := taskCtx.GetDal()
+
+	repository := &models.PhabricatorRepository{}
+	err := db.First(repository, dal.Where("connection_id = ? AND phid = ?", data.Options.ConnectionId, data.Options.RepositoryPhid))
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("t.*, a.real_name AS author_name, o.real_name AS owner_name"),
+		dal.From("_tool_phabricator_tasks t"),
+		dal.Join("LEFT JOIN _tool_phabricator_users a ON a.connection_id = t.connection_id AND a.phid = t.author_phid"),
+		dal.Join("LEFT JOIN _tool_phabricator_users o ON o.connection_id = t.connection_id AND o.phid = t.owner_phid"),
+		dal.Where(`t.connection_id = ? AND t.phid IN (
+			SELECT rt.task_phid FROM _tool_phabricator_revision_tasks rt
+			JOIN _tool_phabricator_revisions r ON r.connection_id = rt.connection_id AND r.phid = rt.revision_phid
+			WHERE r.connection_id = ? AND r.repository_phid = ?
+		)`, data.Options.ConnectionId, data.Options.ConnectionId, data.Options.RepositoryPhid),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	taskIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorTask{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorUser{})
+	boardId := didgen.NewDomainIdGenerator(&models.PhabricatorRepository{}).Generate(data.Options.ConnectionId, data.Options.RepositoryPhid)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(taskWithUsers{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			task := inputRow.(*taskWithUsers)
+			issue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: taskIdGen.Generate(task.ConnectionId, task.PhabricatorId)},
+				Url:            taskUrl(repository, task.PhabricatorId),
+				IssueKey:       strconv.Itoa(task.PhabricatorId),
+				Title:          task.Name,
+				Description:    task.Description,
+				Type:           ticket.TASK,
+				OriginalType:   task.Subtype,
+				OriginalStatus: task.Status,
+				StoryPoint:     task.Points,
+				Priority:       task.Priority,
+				ResolutionDate: task.ClosedDate,
+				CreatedDate:    &task.CreatedDate,
+				UpdatedDate:    &task.UpdatedDate,
+				CreatorName:    task.AuthorName,
+				AssigneeName:   task.OwnerName,
+			}
+			for _, issueType := range []string{ticket.BUG, ticket.INCIDENT, ticket.REQUIREMENT} {
+				if data.RegexEnricher.ReturnNameIfMatched(issueType, task.Subtype) != "" {
+					issue.Type = issueType
+					break
+				}
+			}
+			switch {
+			case task.ClosedDate != nil:
+				issue.Status = ticket.DONE
+				issue.LeadTimeMinutes = int64(task.ClosedDate.Sub(task.CreatedDate).Minutes())
+			case task.Status == "open":
+				issue.Status = ticket.TODO
+			default:
+				issue.Status = ticket.IN_PROGRESS
+			}
+			if task.AuthorPhid != "" {
+				issue.CreatorId = userIdGen.Generate(task.ConnectionId, task.AuthorPhid)
+			}
+			if task.OwnerPhid != "" {
+				issue.AssigneeId = userIdGen.Generate(task.ConnectionId, task.OwnerPhid)
+			}
+			return []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ExtractApiTasksMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTasks",
+	EntryPoint:       ExtractApiTasks,
+	EnabledByDefault: true,
+	Description:      "Extract raw tasks data into tool layer table phabricator_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+}
+
+// PhabricatorApiTask is the task entity returned by `maniphest.search`, the points are a string or null
+type PhabricatorApiTask struct {
+	Id     int    `json:"id"`
+	Phid   string `json:"phid"`
+	Fields struct {
+		Name        string `json:"name"`
+		Description struct {
+			Raw string `json:"raw"`
+		} `json:"description"`
+		AuthorPhid string `json:"authorPHID"`
+		OwnerPhid  string `json:"ownerPHID"`
+		Status     struct {
+			Value string `json:"value"`
+			Name  string `json:"name"`
+		} `json:"status"`
+		Priority struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		Points       json.Number `json:"points"`
+		Subtype      string      `json:"subtype"`
+		DateCreated  int64       `json:"dateCreated"`
+		DateModified int64       `json:"dateModified"`
+		DateClosed   int64       `json:"dateClosed"`
+	} `json:"fields"`
+}
+
+func ExtractApiTasks(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TASK_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiTask := &PhabricatorApiTask{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiTask))
+			if err != nil {
+				return nil, err
+			}
+			task := &models.PhabricatorTask{
+				ConnectionId:  data.Options.ConnectionId,
+				PhabricatorId: apiTask.Id,
+				Phid:          apiTask.Phid,
+				Name:          apiTask.Fields.Name,
+				Description:   apiTask.Fields.Description.Raw,
+				AuthorPhid:    apiTask.Fields.AuthorPhid,
+				OwnerPhid:     apiTask.Fields.OwnerPhid,
+				Status:        apiTask.Fields.Status.Value,
+				StatusName:    apiTask.Fields.Status.Name,
+				Priority:      apiTask.Fields.Priority.Name,
+				Subtype:       apiTask.Fields.Subtype,
+				CreatedDate:   time.Unix(apiTask.Fields.DateCreated, 0).UTC(),
+				UpdatedDate:   time.Unix(apiTask.Fields.DateModified, 0).UTC(),
+				ClosedDate:    secToTime(apiTask.Fields.DateClosed),
+			}
+			if apiTask.Fields.Points != "" {
+				task.Points, err = errors.Convert01(apiTask.Fields.Points.Float64())
+				if err != nil {
+					return nil, err
+				}
+			}
+			return []interface{}{task}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ConvertTransactionsMeta = plugin.SubTaskMeta{
+	Name:             "convertTransactions",
+	EntryPoint:       ConvertTransactions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table phabricator_transactions into domain layer table pull_request_comments and pull_request_review_events",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// ConvertTransactions turns the comments into pull request comments and the review actions into review events,
+// a comment left along with an action is a transaction of its own
+func ConvertTransactions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TRANSACTION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("t.*"),
+		dal.From("_tool_phabricator_transactions t"),
+		dal.Join("LEFT JOIN _tool_phabricator_revisions r ON r.connection_id = t.connection_id AND r.phabricator_id = t.revision_id"),
+		dal.Where(
+			"r.repository_phid = ? AND t.connection_id = ? AND t.type IN ?",
+			data.Options.RepositoryPhid, data.Options.ConnectionId,
+			[]string{
+				models.TRANSACTION_TYPE_COMMENT,
+				models.TRANSACTION_TYPE_INLINE,
+				models.TRANSACTION_TYPE_ACCEPT,
+				models.TRANSACTION_TYPE_REJECT,
+				models.TRANSACTION_TYPE_REQUEST_CHANGES,
+			},
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	transactionIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorTransaction{})
+	revisionIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorRevision{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.PhabricatorUser{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.PhabricatorTransaction{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			transaction := inputRow.(*models.PhabricatorTransaction)
+			transactionId := transactionIdGen.Generate(transaction.ConnectionId, transaction.PhabricatorId)
+			pullRequestId := revisionIdGen.Generate(transaction.ConnectionId, transaction.RevisionId)
+			authorId := ""
+			if transaction.AuthorPhid != "" {
+				authorId = userIdGen.Generate(transaction.ConnectionId, transaction.AuthorPhid)
+			}
+			switch transaction.Type {
+			case models.TRANSACTION_TYPE_COMMENT, models.TRANSACTION_TYPE_INLINE:
+				comment := &code.PullRequestComment{
+					DomainEntity:  domainlayer.DomainEntity{Id: transactionId},
+					PullRequestId: pullRequestId,
+					Body:          transaction.Comment,
+					AccountId:     authorId,
+					CreatedDate:   transaction.CreatedDate,
+					Type:          code.NORMAL_COMMENT,
+				}
+				if transaction.Type == models.TRANSACTION_TYPE_INLINE {
+					comment.Type = code.DIFF_COMMENT
+					comment.Position = transaction.Line
+				}
+				return []interface{}{comment}, nil
+			default:
+				event := &code.PullRequestReviewEvent{
+					DomainEntity:  domainlayer.DomainEntity{Id: transactionId},
+					PullRequestId: pullRequestId,
+					Type:          code.REVIEW_CHANGES_REQUESTED,
+					OriginalType:  transaction.Type,
+					ReviewerId:    authorId,
+					ActorId:       authorId,
+					ReviewId:      transactionId,
+					CreatedDate:   transaction.CreatedDate,
+				}
+				if transaction.Type == models.TRANSACTION_TYPE_ACCEPT {
+					event.Type = code.REVIEW_APPROVED
+				}
+				return []interface{}{event}, nil
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ExtractApiTransactionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTransactions",
+	EntryPoint:       ExtractApiTransactions,
+	EnabledByDefault: true,
+	Description:      "Extract raw transactions data into tool layer table phabricator_transactions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// PhabricatorApiTransaction is the transaction entity returned by `transaction.search`, the type is null for
+// the transactions the api doesn't describe yet
+type PhabricatorApiTransaction struct {
+	Id          int    `json:"id"`
+	Phid        string `json:"phid"`
+	Type        string `json:"type"`
+	AuthorPhid  string `json:"authorPHID"`
+	DateCreated int64  `json:"dateCreated"`
+	Comments    []struct {
+		Removed bool `json:"removed"`
+		Content struct {
+			Raw string `json:"raw"`
+		} `json:"content"`
+	} `json:"comments"`
+	Fields struct {
+		Path string `json:"path"`
+		Line int    `json:"line"`
+	} `json:"fields"`
+}
+
+func ExtractApiTransactions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TRANSACTION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiTransaction := &PhabricatorApiTransaction{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiTransaction))
+			if err != nil {
+				return nil, err
+			}
+			if apiTransaction.Type == "" {
+				return nil, nil
+			}
+			input := &RevisionInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			transaction := &models.PhabricatorTransaction{
+				ConnectionId:  data.Options.ConnectionId,
+				PhabricatorId: apiTransaction.Id,
+				Phid:          apiTransaction.Phid,
+				RevisionId:    input.PhabricatorId,
+				Type:          apiTransaction.Type,
+				AuthorPhid:    apiTransaction.AuthorPhid,
+				Path:          apiTransaction.Fields.Path,
+				Line:          apiTransaction.Fields.Line,
+				CreatedDate:   time.Unix(apiTransaction.DateCreated, 0).UTC(),
+			}
+			// the first comment is the latest version of an edited comment
+			if len(apiTransaction.Comments) > 0 && !apiTransaction.Comments[0].Removed {
+				transaction.Comment = apiTransaction.Comments[0].Content.Raw
+			}
+			return []interface{}{transaction}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
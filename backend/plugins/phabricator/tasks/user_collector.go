@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_USER_TABLE = "phabricator_api_users"
+
+var CollectApiUsersMeta = plugin.SubTaskMeta{
+	Name:             "collectApiUsers",
+	EntryPoint:       CollectApiUsers,
+	EnabledByDefault: true,
+	Description:      "Collect the users appearing in the revisions and the tasks from Phabricator api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// usersOfRepository lists the phids of the authors, the reviewers and the task owners of a repository, reviewers
+// may also be projects or packages which are filtered out by the type in their phid
+const usersOfRepository = `(
+	SELECT r.author_phid AS phid FROM _tool_phabricator_revisions r
+		WHERE r.connection_id = ? AND r.repository_phid = ?
+	UNION SELECT rr.reviewer_phid FROM _tool_phabricator_revision_reviewers rr
+		JOIN _tool_phabricator_revisions r ON r.connection_id = rr.connection_id AND r.phabricator_id = rr.revision_id
+		WHERE r.connection_id = ? AND r.repository_phid = ?
+	UNION SELECT t.author_phid FROM _tool_phabricator_transactions t
+		JOIN _tool_phabricator_revisions r ON r.connection_id = t.connection_id AND r.phabricator_id = t.revision_id
+		WHERE r.connection_id = ? AND r.repository_phid = ?
+	UNION SELECT t.author_phid FROM _tool_phabricator_tasks t
+		JOIN _tool_phabricator_revision_tasks rt ON rt.connection_id = t.connection_id AND rt.task_phid = t.phid
+		JOIN _tool_phabricator_revisions r ON r.connection_id = rt.connection_id AND r.phid = rt.revision_phid
+		WHERE r.connection_id = ? AND r.repository_phid = ?
+	UNION SELECT t.owner_phid FROM _tool_phabricator_tasks t
+		JOIN _tool_phabricator_revision_tasks rt ON rt.connection_id = t.connection_id AND rt.task_phid = t.phid
+		JOIN _tool_phabricator_revisions r ON r.connection_id = rt.connection_id AND r.phid = rt.revision_phid
+		WHERE r.connection_id = ? AND r.repository_phid = ?
+) u`
+
+func CollectApiUsers(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_USER_TABLE)
+	db := taskCtx.GetDal()
+
+	params := make([]interface{}, 0, 10)
+	for i := 0; i < 5; i++ {
+		params = append(params, data.Options.ConnectionId, data.Options.RepositoryPhid)
+	}
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT u.phid"),
+		dal.From(usersOfRepository, params...),
+		dal.Where("u.phid LIKE ?", "PHID-USER-%"),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewBatchedDalCursorIterator(db, cursor, reflect.TypeOf(""), 100)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Input:              iterator,
+		UrlTemplate:        "user.search",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			for i, phid := range reqData.Input.([]interface{}) {
+				query.Set(fmt.Sprintf("constraints[phids][%d]", i), *phid.(*string))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: GetNextPageCursor,
+		ResponseParser:        GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/phabricator/models"
+)
+
+var ExtractApiUsersMeta = plugin.SubTaskMeta{
+	Name:             "extractApiUsers",
+	EntryPoint:       ExtractApiUsers,
+	EnabledByDefault: true,
+	Description:      "Extract raw users data into tool layer table phabricator_users",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// PhabricatorApiUser is the user entity returned by `user.search`
+type PhabricatorApiUser struct {
+	Phid   string `json:"phid"`
+	Fields struct {
+		Username string `json:"username"`
+		RealName string `json:"realName"`
+	} `json:"fields"`
+}
+
+func ExtractApiUsers(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_USER_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiUser := &PhabricatorApiUser{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiUser))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.PhabricatorUser{
+					ConnectionId: data.Options.ConnectionId,
+					Phid:         apiUser.Phid,
+					Username:     apiUser.Fields.Username,
+					RealName:     apiUser.Fields.RealName,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the services are not grouped
+// @Tags plugins/servicenow
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/servicenow/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		nil,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.ServicenowConnection) ([]models.ServicenowApiService, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			return listServices(basicRes, &connection, queryData, "")
+		},
+	)
+}
+
+// SearchRemoteScopes use the Search API and only return service
+// @Summary use the Search API and only return service
+// @Description use the Search API and only return service
+// @Tags plugins/servicenow
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/servicenow/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.ServicenowConnection) ([]models.ServicenowApiService, errors.Error) {
+			return listServices(basicRes, &connection, queryData, fmt.Sprintf("nameLIKE%s", queryData.Search[0]))
+		},
+	)
+}
+
+// listServices returns a page of the business services, filtered by the search query of the api when it is given
+func listServices(basicRes context2.BasicRes, connection *models.ServicenowConnection, queryData *api.RemoteQueryData, search string) ([]models.ServicenowApiService, errors.Error) {
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	query := url.Values{}
+	query.Set("sysparm_offset", fmt.Sprintf("%v", (queryData.Page-1)*queryData.PerPage))
+	query.Set("sysparm_limit", fmt.Sprintf("%v", queryData.PerPage))
+	query.Set("sysparm_fields", "sys_id,name,short_description")
+	sysparmQuery := "ORDERBYname"
+	if search != "" {
+		sysparmQuery = search + "^" + sysparmQuery
+	}
+	query.Set("sysparm_query", sysparmQuery)
+	res, err := apiClient.Get("api/now/table/cmdb_ci_service", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody struct {
+		Result []models.ServicenowApiService `json:"result"`
+	}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	return resBody.Result, nil
+}
@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/impl"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+	"github.com/apache/incubator-devlake/plugins/servicenow/tasks"
+)
+
+func TestServicenowChangeRequestDataFlow(t *testing.T) {
+
+	var servicenow impl.Servicenow
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "servicenow", servicenow)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "Software")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "prod")
+	taskData := &tasks.ServicenowTaskData{
+		Options: &tasks.ServicenowOptions{
+			ConnectionId: 1,
+			ServiceId:    "1c832706732023002728660c4cf6a7b9",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_servicenow_services.csv", &models.ServicenowService{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_servicenow_api_change_requests.csv", "_raw_servicenow_api_change_requests")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_servicenow_api_approvals.csv", "_raw_servicenow_api_approvals")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.ServicenowChangeRequest{})
+	dataflowTester.Subtask(tasks.ExtractApiChangeRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.ServicenowChangeRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_servicenow_change_requests.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.ServicenowApproval{})
+	dataflowTester.Subtask(tasks.ExtractApiApprovalsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.ServicenowApproval{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_servicenow_approvals.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertServiceMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	// only the changes of the software category which have been implemented are deployments
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertChangeRequestsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CicdDeploymentApproval{})
+	dataflowTester.Subtask(tasks.ConvertApprovalsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentApproval{},
+		"./snapshot_tables/cicd_deployment_approvals.csv",
+		[]string{
+			"id",
+			"cicd_deployment_id",
+			"environment_id",
+			"state",
+			"approver_id",
+			"approver_name",
+			"comment",
+			"requested_date",
+			"approved_date",
+			"waiting_sec",
+		},
+	)
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/servicenow/impl"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+	"github.com/apache/incubator-devlake/plugins/servicenow/tasks"
+)
+
+func TestServicenowIncidentDataFlow(t *testing.T) {
+
+	var servicenow impl.Servicenow
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "servicenow", servicenow)
+
+	taskData := &tasks.ServicenowTaskData{
+		Options: &tasks.ServicenowOptions{
+			ConnectionId: 1,
+			ServiceId:    "1c832706732023002728660c4cf6a7b9",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_servicenow_services.csv", &models.ServicenowService{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_servicenow_api_incidents.csv", "_raw_servicenow_api_incidents")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.ServicenowIncident{})
+	dataflowTester.Subtask(tasks.ExtractApiIncidentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.ServicenowIncident{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_servicenow_incidents.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertServiceMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Board{},
+		"./snapshot_tables/boards.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertIncidentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"status",
+			"original_status",
+			"priority",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"creator_name",
+			"assignee_name",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		[]string{
+			"board_id",
+			"issue_id",
+		},
+	)
+}
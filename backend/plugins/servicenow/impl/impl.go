@@ -0,0 +1,254 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/servicenow/tasks"
+)
+
+var _ plugin.PluginMeta = (*Servicenow)(nil)
+var _ plugin.PluginInit = (*Servicenow)(nil)
+var _ plugin.PluginTask = (*Servicenow)(nil)
+var _ plugin.PluginApi = (*Servicenow)(nil)
+var _ plugin.PluginModel = (*Servicenow)(nil)
+var _ plugin.PluginMigration = (*Servicenow)(nil)
+var _ plugin.CloseablePluginTask = (*Servicenow)(nil)
+var _ plugin.PluginSource = (*Servicenow)(nil)
+
+type Servicenow string
+
+func (p Servicenow) Connection() interface{} {
+	return &models.ServicenowConnection{}
+}
+
+func (p Servicenow) Scope() interface{} {
+	return &models.ServicenowService{}
+}
+
+func (p Servicenow) TransformationRule() interface{} {
+	return &models.ServicenowTransformationRule{}
+}
+
+func (p Servicenow) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Servicenow) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.ServicenowConnection{},
+		&models.ServicenowService{},
+		&models.ServicenowTransformationRule{},
+		&models.ServicenowChangeRequest{},
+		&models.ServicenowApproval{},
+		&models.ServicenowIncident{},
+	}
+}
+
+func (p Servicenow) Description() string {
+	return "To collect and enrich data from ServiceNow"
+}
+
+func (p Servicenow) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiChangeRequestsMeta,
+		tasks.ExtractApiChangeRequestsMeta,
+		tasks.CollectApiApprovalsMeta,
+		tasks.ExtractApiApprovalsMeta,
+		tasks.CollectApiIncidentsMeta,
+		tasks.ExtractApiIncidentsMeta,
+
+		tasks.ConvertServiceMeta,
+		tasks.ConvertChangeRequestsMeta,
+		tasks.ConvertApprovalsMeta,
+		tasks.ConvertIncidentsMeta,
+	}
+}
+
+func (p Servicenow) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.ServicenowConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get servicenow connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get servicenow API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeAfter time.Time
+	if op.TimeAfter != "" {
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+	}
+	regexEnricher := helper.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(devops.DEPLOYMENT, op.DeploymentPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
+	}
+	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ProductionPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
+	}
+	taskData := &tasks.ServicenowTaskData{
+		Options:       op,
+		ApiClient:     apiClient,
+		RegexEnricher: regexEnricher,
+	}
+	if !timeAfter.IsZero() {
+		taskData.TimeAfter = &timeAfter
+		logger.Debug("collect data updated timeAfter %s", timeAfter)
+	}
+
+	return taskData, nil
+}
+
+func (p Servicenow) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/servicenow"
+}
+
+func (p Servicenow) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Servicenow) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Servicenow) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/*scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
+	}
+}
+
+func (p Servicenow) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.ServicenowTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.ServicenowOptions,
+	apiClient *helper.ApiClient) errors.Error {
+	var service models.ServicenowService
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&service, dal.Where(
+		"connection_id = ? AND sys_id = ?",
+		op.ConnectionId, op.ServiceId))
+	if err == nil {
+		if op.TransformationRuleId == 0 {
+			op.TransformationRuleId = service.TransformationRuleId
+		}
+	} else {
+		if db.IsErrorNotFound(err) {
+			var apiService *models.ServicenowApiService
+			apiService, err = tasks.GetApiService(op, apiClient)
+			if err != nil {
+				return err
+			}
+			logger.Debug(fmt.Sprintf("Current service: %s", op.ServiceId))
+			scope := apiService.ConvertApiScope().(*models.ServicenowService)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find service %s", op.ServiceId))
+		}
+	}
+	if op.ServicenowTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.ServicenowTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.ServicenowTransformationRule = &transformationRule
+	}
+	if op.ServicenowTransformationRule == nil {
+		op.ServicenowTransformationRule = new(models.ServicenowTransformationRule)
+	}
+	return nil
+}
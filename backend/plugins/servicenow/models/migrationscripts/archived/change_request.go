@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type ServicenowChangeRequest struct {
+	ConnectionId        uint64 `gorm:"primaryKey"`
+	SysId               string `gorm:"primaryKey;type:varchar(32)"`
+	ServiceId           string `gorm:"index;type:varchar(32)"`
+	Number              string `gorm:"type:varchar(40)"`
+	ShortDescription    string
+	Description         string
+	Type                string `gorm:"type:varchar(40)"`
+	Category            string `gorm:"type:varchar(100)"`
+	State               string `gorm:"type:varchar(40)"`
+	Approval            string `gorm:"type:varchar(40)"`
+	Risk                string `gorm:"type:varchar(40)"`
+	Priority            string `gorm:"type:varchar(40)"`
+	CloseCode           string `gorm:"type:varchar(40)"`
+	CmdbCiName          string `gorm:"type:varchar(255)"`
+	AssignmentGroupName string `gorm:"type:varchar(255)"`
+	RequestedByName     string `gorm:"type:varchar(255)"`
+	StartDate           *time.Time
+	EndDate             *time.Time
+	WorkStartDate       *time.Time
+	WorkEndDate         *time.Time
+	ClosedDate          *time.Time
+	CreatedDate         time.Time
+	UpdatedDate         time.Time
+	archived.NoPKModel
+}
+
+func (ServicenowChangeRequest) TableName() string {
+	return "_tool_servicenow_change_requests"
+}
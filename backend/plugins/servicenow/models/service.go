@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*ServicenowService)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*ServicenowApiService)(nil)
+
+// ServicenowService is a business service of the CMDB, the change requests and the incidents
+// referring to it are collected with it
+type ServicenowService struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	SysId                string `json:"sysId" gorm:"primaryKey;type:varchar(32)" validate:"required" mapstructure:"sysId"`
+	Name                 string `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Description          string `json:"description" mapstructure:"description,omitempty"`
+	Url                  string `json:"url" gorm:"type:varchar(255)" mapstructure:"url,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (ServicenowService) TableName() string {
+	return "_tool_servicenow_services"
+}
+
+func (s ServicenowService) ScopeId() string {
+	return s.SysId
+}
+
+func (s ServicenowService) ScopeName() string {
+	return s.Name
+}
+
+// ServicenowApiService is the record of the table cmdb_ci_service
+type ServicenowApiService struct {
+	SysId            string `json:"sys_id"`
+	Name             string `json:"name"`
+	ShortDescription string `json:"short_description"`
+}
+
+func (s ServicenowApiService) ConvertApiScope() plugin.ToolLayerScope {
+	return &ServicenowService{
+		SysId:       s.SysId,
+		Name:        s.Name,
+		Description: s.ShortDescription,
+	}
+}
+
+// GroupResponse is required by the remote api helper, the services are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
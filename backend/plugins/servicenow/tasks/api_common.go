@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// the Table API formats the date times in UTC when the display values are not requested
+const servicenowTimeFormat = "2006-01-02 15:04:05"
+
+type ServicenowApiParams struct {
+	ConnectionId uint64
+	ServiceId    string
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *ServicenowTaskData) {
+	data := taskCtx.GetData().(*ServicenowTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: ServicenowApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ServiceId:    data.Options.ServiceId,
+		},
+		Table: Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
+// GetQuery pages with `sysparm_offset` and `sysparm_limit`, and keeps the values of the fields instead of the
+// labels and the links of the references
+func GetQuery(reqData *api.RequestData, fields []string, conditions ...string) (url.Values, errors.Error) {
+	query := url.Values{}
+	if reqData.Pager != nil && reqData.Pager.Size > 0 {
+		query.Set("sysparm_offset", fmt.Sprintf("%v", reqData.Pager.Skip))
+		query.Set("sysparm_limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	}
+	query.Set("sysparm_display_value", "false")
+	query.Set("sysparm_exclude_reference_link", "true")
+	query.Set("sysparm_fields", strings.Join(fields, ","))
+	query.Set("sysparm_query", strings.Join(append(conditions, "ORDERBYsys_created_on"), "^"))
+	return query, nil
+}
+
+// updatedAfter narrows the records down to the ones updated since the last collection, or created after
+// timeAfter when collecting from scratch
+func updatedAfter(collectorWithState *api.ApiCollectorStateManager) []string {
+	if collectorWithState.IsIncremental() {
+		return []string{fmt.Sprintf("sys_updated_on>=%s", collectorWithState.LatestState.LatestSuccessStart.UTC().Format(servicenowTimeFormat))}
+	}
+	if collectorWithState.TimeAfter != nil {
+		return []string{fmt.Sprintf("sys_created_on>=%s", collectorWithState.TimeAfter.UTC().Format(servicenowTimeFormat))}
+	}
+	return nil
+}
+
+// GetRawMessageFromResponse reads the records the api wraps in `result`
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var body struct {
+		Result []json.RawMessage `json:"result"`
+	}
+	err := api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Result, nil
+}
+
+// servicenowTime is a date time of the api, the unset ones are empty strings
+type servicenowTime struct {
+	time *time.Time
+}
+
+func (t *servicenowTime) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	parsed, err := time.Parse(servicenowTimeFormat, s)
+	if err != nil {
+		return err
+	}
+	t.time = &parsed
+	return nil
+}
+
+// ToNullableTime returns nil for the unset date times
+func (t servicenowTime) ToNullableTime() *time.Time {
+	return t.time
+}
+
+// ToTime returns the zero time for the unset date times
+func (t servicenowTime) ToTime() time.Time {
+	if t.time == nil {
+		return time.Time{}
+	}
+	return *t.time
+}
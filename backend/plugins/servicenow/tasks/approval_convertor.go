@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+var ConvertApprovalsMeta = plugin.SubTaskMeta{
+	Name:             "convertApprovals",
+	EntryPoint:       ConvertApprovals,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table servicenow_approvals into domain layer table cicd_deployment_approvals",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertApprovals(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_APPROVAL_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("a.*"),
+		dal.From("_tool_servicenow_approvals a"),
+		dal.Join("LEFT JOIN _tool_servicenow_change_requests c ON c.connection_id = a.connection_id AND c.sys_id = a.change_request_id"),
+		dal.Where("a.connection_id = ? AND c.service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	approvalIdGen := didgen.NewDomainIdGenerator(&models.ServicenowApproval{})
+	changeIdGen := didgen.NewDomainIdGenerator(&models.ServicenowChangeRequest{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.ServicenowApproval{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			approval := inputRow.(*models.ServicenowApproval)
+			domainApproval := &devops.CicdDeploymentApproval{
+				DomainEntity:     domainlayer.DomainEntity{Id: approvalIdGen.Generate(approval.ConnectionId, approval.SysId)},
+				CicdDeploymentId: changeIdGen.Generate(approval.ConnectionId, approval.ChangeRequestId),
+				ApproverName:     approval.ApproverName,
+				Comment:          approval.Comments,
+				RequestedDate:    &approval.CreatedDate,
+			}
+			switch approval.State {
+			case models.APPROVAL_STATE_REQUESTED:
+				domainApproval.State = devops.APPROVAL_PENDING
+			case models.APPROVAL_STATE_APPROVED:
+				domainApproval.State = devops.APPROVAL_APPROVED
+			case models.APPROVAL_STATE_REJECTED:
+				domainApproval.State = devops.APPROVAL_REJECTED
+			default:
+				// approvals which were not required or cancelled never gated the change
+				return nil, nil
+			}
+			if domainApproval.State != devops.APPROVAL_PENDING {
+				// the decision is the last update of the approval record
+				domainApproval.ApprovedDate = &approval.UpdatedDate
+				waitingSec := uint64(approval.UpdatedDate.Sub(approval.CreatedDate).Seconds())
+				domainApproval.WaitingSec = &waitingSec
+			}
+			return []interface{}{domainApproval}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+var ExtractApiApprovalsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiApprovals",
+	EntryPoint:       ExtractApiApprovals,
+	EnabledByDefault: true,
+	Description:      "Extract raw approvals data into tool layer table servicenow_approvals",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type ServicenowApiApproval struct {
+	SysId        string         `json:"sys_id"`
+	Sysapproval  string         `json:"sysapproval"`
+	Approver     string         `json:"approver"`
+	ApproverName string         `json:"approver.name"`
+	State        string         `json:"state"`
+	Comments     string         `json:"comments"`
+	SysCreatedOn servicenowTime `json:"sys_created_on"`
+	SysUpdatedOn servicenowTime `json:"sys_updated_on"`
+}
+
+func ExtractApiApprovals(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_APPROVAL_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiApproval := &ServicenowApiApproval{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiApproval))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{&models.ServicenowApproval{
+				ConnectionId:    data.Options.ConnectionId,
+				SysId:           apiApproval.SysId,
+				ChangeRequestId: apiApproval.Sysapproval,
+				ApproverId:      apiApproval.Approver,
+				ApproverName:    apiApproval.ApproverName,
+				State:           apiApproval.State,
+				Comments:        apiApproval.Comments,
+				CreatedDate:     apiApproval.SysCreatedOn.ToTime(),
+				UpdatedDate:     apiApproval.SysUpdatedOn.ToTime(),
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+var ConvertChangeRequestsMeta = plugin.SubTaskMeta{
+	Name:             "convertChangeRequests",
+	EntryPoint:       ConvertChangeRequests,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table servicenow_change_requests into domain layer table cicd_pipelines and cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertChangeRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_REQUEST_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ServicenowChangeRequest{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	changeIdGen := didgen.NewDomainIdGenerator(&models.ServicenowChangeRequest{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.ServicenowService{})
+	scopeId := serviceIdGen.Generate(data.Options.ConnectionId, data.Options.ServiceId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.ServicenowChangeRequest{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			change := inputRow.(*models.ServicenowChangeRequest)
+			// changes of other categories, e.g. hardware or network, are not deployments of the service
+			if data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.DEPLOYMENT, change.Category) == "" {
+				return nil, nil
+			}
+			// nothing has been deployed before the change reaches the implement state
+			switch change.State {
+			case models.CHANGE_STATE_NEW, models.CHANGE_STATE_ASSESS, models.CHANGE_STATE_AUTHORIZE, models.CHANGE_STATE_SCHEDULED:
+				return nil, nil
+			}
+			status := devops.GetStatus(&devops.StatusRule{
+				Done:    []string{models.CHANGE_STATE_REVIEW, models.CHANGE_STATE_CLOSED, models.CHANGE_STATE_CANCELED},
+				Default: devops.IN_PROGRESS,
+			}, change.State)
+			result := devops.GetResult(&devops.ResultRule{
+				Success: []string{models.CLOSE_CODE_SUCCESSFUL, models.CLOSE_CODE_SUCCESSFUL_ISSUES},
+				Failed:  []string{models.CLOSE_CODE_UNSUCCESSFUL},
+				Default: "",
+			}, change.CloseCode)
+			if change.State == models.CHANGE_STATE_CANCELED {
+				result = devops.ABORT
+			}
+			startedDate := change.CreatedDate
+			if change.WorkStartDate != nil {
+				startedDate = *change.WorkStartDate
+			}
+			var finishedDate *time.Time
+			var durationSec uint64
+			if status == devops.DONE {
+				finishedDate = change.WorkEndDate
+				if finishedDate == nil {
+					finishedDate = change.ClosedDate
+				}
+				if finishedDate != nil {
+					durationSec = uint64(finishedDate.Sub(startedDate).Seconds())
+				}
+			}
+			environment := data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, change.CmdbCiName)
+
+			// a change request is a single step deployment, it is both the pipeline and its only task
+			id := changeIdGen.Generate(change.ConnectionId, change.SysId)
+			return []interface{}{
+				&devops.CICDPipeline{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         change.Number,
+					Result:       result,
+					Status:       status,
+					Type:         devops.DEPLOYMENT,
+					Environment:  environment,
+					DurationSec:  durationSec,
+					CreatedDate:  startedDate,
+					FinishedDate: finishedDate,
+					CicdScopeId:  scopeId,
+				},
+				&devops.CICDTask{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         change.ShortDescription,
+					PipelineId:   id,
+					Result:       result,
+					Status:       status,
+					Type:         devops.DEPLOYMENT,
+					Environment:  environment,
+					DurationSec:  durationSec,
+					StartedDate:  startedDate,
+					FinishedDate: finishedDate,
+					CicdScopeId:  scopeId,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
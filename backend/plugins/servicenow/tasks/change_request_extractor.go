@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+var ExtractApiChangeRequestsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiChangeRequests",
+	EntryPoint:       ExtractApiChangeRequests,
+	EnabledByDefault: true,
+	Description:      "Extract raw change requests data into tool layer table servicenow_change_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type ServicenowApiChangeRequest struct {
+	SysId               string         `json:"sys_id"`
+	Number              string         `json:"number"`
+	ShortDescription    string         `json:"short_description"`
+	Description         string         `json:"description"`
+	Type                string         `json:"type"`
+	Category            string         `json:"category"`
+	State               string         `json:"state"`
+	Approval            string         `json:"approval"`
+	Risk                string         `json:"risk"`
+	Priority            string         `json:"priority"`
+	CloseCode           string         `json:"close_code"`
+	CmdbCiName          string         `json:"cmdb_ci.name"`
+	AssignmentGroupName string         `json:"assignment_group.name"`
+	RequestedByName     string         `json:"requested_by.name"`
+	StartDate           servicenowTime `json:"start_date"`
+	EndDate             servicenowTime `json:"end_date"`
+	WorkStart           servicenowTime `json:"work_start"`
+	WorkEnd             servicenowTime `json:"work_end"`
+	ClosedAt            servicenowTime `json:"closed_at"`
+	SysCreatedOn        servicenowTime `json:"sys_created_on"`
+	SysUpdatedOn        servicenowTime `json:"sys_updated_on"`
+}
+
+func ExtractApiChangeRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_REQUEST_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiChange := &ServicenowApiChangeRequest{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiChange))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{&models.ServicenowChangeRequest{
+				ConnectionId:        data.Options.ConnectionId,
+				SysId:               apiChange.SysId,
+				ServiceId:           data.Options.ServiceId,
+				Number:              apiChange.Number,
+				ShortDescription:    apiChange.ShortDescription,
+				Description:         apiChange.Description,
+				Type:                apiChange.Type,
+				Category:            apiChange.Category,
+				State:               apiChange.State,
+				Approval:            apiChange.Approval,
+				Risk:                apiChange.Risk,
+				Priority:            apiChange.Priority,
+				CloseCode:           apiChange.CloseCode,
+				CmdbCiName:          apiChange.CmdbCiName,
+				AssignmentGroupName: apiChange.AssignmentGroupName,
+				RequestedByName:     apiChange.RequestedByName,
+				StartDate:           apiChange.StartDate.ToNullableTime(),
+				EndDate:             apiChange.EndDate.ToNullableTime(),
+				WorkStartDate:       apiChange.WorkStart.ToNullableTime(),
+				WorkEndDate:         apiChange.WorkEnd.ToNullableTime(),
+				ClosedDate:          apiChange.ClosedAt.ToNullableTime(),
+				CreatedDate:         apiChange.SysCreatedOn.ToTime(),
+				UpdatedDate:         apiChange.SysUpdatedOn.ToTime(),
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
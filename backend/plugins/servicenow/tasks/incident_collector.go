@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_INCIDENT_TABLE = "servicenow_api_incidents"
+
+// incidentFields are the fields of the incidents collected, `caused_by` is the change request causing the incident
+var incidentFields = []string{
+	"sys_id", "number", "short_description", "description", "state", "priority", "urgency", "impact", "category",
+	"close_code", "assigned_to", "assigned_to.name", "opened_by.name", "caused_by", "opened_at", "resolved_at",
+	"closed_at", "sys_created_on", "sys_updated_on",
+}
+
+var CollectApiIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiIncidents",
+	EntryPoint:       CollectApiIncidents,
+	EnabledByDefault: true,
+	Description:      "Collect the incidents of the business service from ServiceNow api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_INCIDENT_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "api/now/table/incident",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			conditions := append([]string{fmt.Sprintf("business_service=%s", data.Options.ServiceId)}, updatedAfter(collectorWithState)...)
+			return GetQuery(reqData, incidentFields, conditions...)
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+var ConvertIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "convertIncidents",
+	EntryPoint:       ConvertIncidents,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table servicenow_incidents into domain layer table issues and board_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_INCIDENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ServicenowIncident{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	incidentIdGen := didgen.NewDomainIdGenerator(&models.ServicenowIncident{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.ServicenowService{})
+	boardId := serviceIdGen.Generate(data.Options.ConnectionId, data.Options.ServiceId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.ServicenowIncident{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			incident := inputRow.(*models.ServicenowIncident)
+			createdDate := incident.CreatedDate
+			if incident.OpenedDate != nil {
+				createdDate = *incident.OpenedDate
+			}
+			domainIssue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: incidentIdGen.Generate(incident.ConnectionId, incident.SysId)},
+				IssueKey:       incident.Number,
+				Title:          incident.ShortDescription,
+				Description:    incident.Description,
+				Type:           ticket.INCIDENT,
+				OriginalStatus: incident.State,
+				Priority:       incident.Priority,
+				CreatorName:    incident.OpenedByName,
+				AssigneeName:   incident.AssignedToName,
+				CreatedDate:    &createdDate,
+				UpdatedDate:    &incident.UpdatedDate,
+			}
+			switch incident.State {
+			case models.INCIDENT_STATE_NEW:
+				domainIssue.Status = ticket.TODO
+			case models.INCIDENT_STATE_IN_PROGRESS, models.INCIDENT_STATE_ON_HOLD:
+				domainIssue.Status = ticket.IN_PROGRESS
+			case models.INCIDENT_STATE_RESOLVED, models.INCIDENT_STATE_CLOSED:
+				domainIssue.Status = ticket.DONE
+				// the service is restored once the incident is resolved, closing only happens later
+				domainIssue.ResolutionDate = incident.ResolvedDate
+				if domainIssue.ResolutionDate == nil {
+					domainIssue.ResolutionDate = incident.ClosedDate
+				}
+				if domainIssue.ResolutionDate != nil {
+					domainIssue.LeadTimeMinutes = int64(domainIssue.ResolutionDate.Sub(createdDate).Minutes())
+				}
+			default:
+				domainIssue.Status = ticket.OTHER
+			}
+			return []interface{}{
+				domainIssue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: domainIssue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
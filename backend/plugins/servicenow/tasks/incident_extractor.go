@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+var ExtractApiIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiIncidents",
+	EntryPoint:       ExtractApiIncidents,
+	EnabledByDefault: true,
+	Description:      "Extract raw incidents data into tool layer table servicenow_incidents",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type ServicenowApiIncident struct {
+	SysId            string         `json:"sys_id"`
+	Number           string         `json:"number"`
+	ShortDescription string         `json:"short_description"`
+	Description      string         `json:"description"`
+	State            string         `json:"state"`
+	Priority         string         `json:"priority"`
+	Urgency          string         `json:"urgency"`
+	Impact           string         `json:"impact"`
+	Category         string         `json:"category"`
+	CloseCode        string         `json:"close_code"`
+	AssignedTo       string         `json:"assigned_to"`
+	AssignedToName   string         `json:"assigned_to.name"`
+	OpenedByName     string         `json:"opened_by.name"`
+	CausedBy         string         `json:"caused_by"`
+	OpenedAt         servicenowTime `json:"opened_at"`
+	ResolvedAt       servicenowTime `json:"resolved_at"`
+	ClosedAt         servicenowTime `json:"closed_at"`
+	SysCreatedOn     servicenowTime `json:"sys_created_on"`
+	SysUpdatedOn     servicenowTime `json:"sys_updated_on"`
+}
+
+func ExtractApiIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_INCIDENT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiIncident := &ServicenowApiIncident{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiIncident))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{&models.ServicenowIncident{
+				ConnectionId:     data.Options.ConnectionId,
+				SysId:            apiIncident.SysId,
+				ServiceId:        data.Options.ServiceId,
+				Number:           apiIncident.Number,
+				ShortDescription: apiIncident.ShortDescription,
+				Description:      apiIncident.Description,
+				State:            apiIncident.State,
+				Priority:         apiIncident.Priority,
+				Urgency:          apiIncident.Urgency,
+				Impact:           apiIncident.Impact,
+				Category:         apiIncident.Category,
+				CloseCode:        apiIncident.CloseCode,
+				AssignedToId:     apiIncident.AssignedTo,
+				AssignedToName:   apiIncident.AssignedToName,
+				OpenedByName:     apiIncident.OpenedByName,
+				CausedById:       apiIncident.CausedBy,
+				OpenedDate:       apiIncident.OpenedAt.ToNullableTime(),
+				ResolvedDate:     apiIncident.ResolvedAt.ToNullableTime(),
+				ClosedDate:       apiIncident.ClosedAt.ToNullableTime(),
+				CreatedDate:      apiIncident.SysCreatedOn.ToTime(),
+				UpdatedDate:      apiIncident.SysUpdatedOn.ToTime(),
+			}}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/servicenow/models"
+)
+
+const RAW_SERVICE_TABLE = "servicenow_api_services"
+
+var ConvertServiceMeta = plugin.SubTaskMeta{
+	Name:             "convertService",
+	EntryPoint:       ConvertService,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table servicenow_services into domain layer table boards and cicd_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CICD},
+}
+
+// GetApiService fetches a business service by its sys_id
+func GetApiService(op *ServicenowOptions, apiClient aha.ApiClientAbstract) (*models.ServicenowApiService, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("api/now/table/cmdb_ci_service/%s", op.ServiceId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting service detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	body := &struct {
+		Result models.ServicenowApiService `json:"result"`
+	}{}
+	err = api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	return &body.Result, nil
+}
+
+func ConvertService(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVICE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.ServicenowService{}),
+		dal.Where("connection_id = ? AND sys_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.ServicenowService{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.ServicenowService{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			service := inputRow.(*models.ServicenowService)
+			// the service is both the board of the incidents and the cicd scope of the changes
+			id := serviceIdGen.Generate(service.ConnectionId, service.SysId)
+			return []interface{}{
+				&ticket.Board{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         service.Name,
+					Description:  service.Description,
+					Url:          service.Url,
+				},
+				&devops.CicdScope{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         service.Name,
+					Description:  service.Description,
+					Url:          service.Url,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
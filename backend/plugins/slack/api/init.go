@@ -20,11 +20,14 @@ package api
 import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
 	"github.com/go-playground/validator/v10"
 )
 
 var vld *validator.Validate
 var connectionHelper *api.ConnectionApiHelper
+var scopeHelper *api.ScopeApiHelper[models.SlackConnection, models.SlackChannel, models.SlackTransformationRule]
+var trHelper *api.TransformationRuleHelper[models.SlackTransformationRule]
 var basicRes context.BasicRes
 
 func Init(br context.BasicRes) {
@@ -34,4 +37,13 @@ func Init(br context.BasicRes) {
 		basicRes,
 		vld,
 	)
+	scopeHelper = api.NewScopeHelper[models.SlackConnection, models.SlackChannel, models.SlackTransformationRule](
+		basicRes,
+		vld,
+		connectionHelper,
+	)
+	trHelper = api.NewTransformationRuleHelper[models.SlackTransformationRule](
+		basicRes,
+		vld,
+	)
 }
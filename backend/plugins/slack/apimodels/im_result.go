@@ -27,6 +27,11 @@ type SlackChannelApiResult struct {
 	} `json:"response_metadata"`
 }
 
+type SlackChannelInfoApiResult struct {
+	Ok      bool            `json:"ok"`
+	Channel json.RawMessage `json:"channel"`
+}
+
 type SlackChannelMessageApiResult struct {
 	Ok               bool              `json:"ok"`
 	Messages         []json.RawMessage `json:"messages"`
@@ -150,6 +155,12 @@ type SlackChannelMessageResultItem struct {
 		IsLocked        bool     `json:"is_locked"`
 		Subscribed      bool     `json:"subscribed"`
 	} `json:"root"`
+	Attachments []struct {
+		Fallback string `json:"fallback"`
+		Pretext  string `json:"pretext"`
+		Title    string `json:"title"`
+		Text     string `json:"text"`
+	} `json:"attachments"`
 	Reactions []struct {
 		Name  string   `json:"name"`
 		Users []string `json:"users"`
@@ -0,0 +1,178 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/impl"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"github.com/apache/incubator-devlake/plugins/slack/tasks"
+)
+
+func TestSlackMessageDataFlow(t *testing.T) {
+
+	var slack impl.Slack
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "slack", slack)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "(?i)^deploy")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "(?i)to production")
+	_ = regexEnricher.TryAdd(devops.FAILURE, "(?i)failed")
+	_ = regexEnricher.TryAdd(ticket.INCIDENT, "^:rotating_light:")
+	_ = regexEnricher.TryAdd(ticket.DONE, "(?i)resolved")
+	taskData := &tasks.SlackTaskData{
+		Options: &tasks.SlackOptions{
+			ConnectionId: 1,
+			ChannelId:    "C0520K6J3RS",
+			SlackTransformationRule: &models.SlackTransformationRule{
+				DeploymentPattern: "(?i)^deploy",
+				IncidentPattern:   "^:rotating_light:",
+			},
+		},
+		RegexEnricher:  regexEnricher,
+		CommitShaRegex: regexp.MustCompile(`\b([0-9a-f]{40})\b`),
+		RepoUrlRegex:   regexp.MustCompile(`<(https://github\.com/[^/]+/[^/|>]+)`),
+	}
+
+	// import tool layer tables
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_slack_channels.csv", &models.SlackChannel{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_slack_channel_messages.csv", &models.SlackChannelMessage{})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertChannelMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.Board{},
+		"./snapshot_tables/boards.csv",
+		[]string{
+			"id",
+			"name",
+		},
+	)
+
+	// only the announcements matching the deployment pattern are deployments, their commit is known when both
+	// the sha and the repo are told
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"environment",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"repo_url",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"result",
+			"status",
+			"environment",
+			"created_date",
+			"started_date",
+			"finished_date",
+			"duration_sec",
+			"commit_sha",
+			"repo_url",
+		},
+	)
+
+	// the incidents are resolved by the first reply in their thread matching the resolved pattern
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertIncidentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		[]string{
+			"id",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"status",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+		},
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		[]string{
+			"board_id",
+			"issue_id",
+		},
+	)
+}
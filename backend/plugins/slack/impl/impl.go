@@ -19,10 +19,14 @@ package impl
 
 import (
 	"fmt"
+	"regexp"
+	"time"
+
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
-
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/slack/api"
@@ -38,9 +42,22 @@ var _ plugin.PluginApi = (*Slack)(nil)
 var _ plugin.PluginModel = (*Slack)(nil)
 var _ plugin.PluginMigration = (*Slack)(nil)
 var _ plugin.CloseablePluginTask = (*Slack)(nil)
+var _ plugin.PluginSource = (*Slack)(nil)
 
 type Slack struct{}
 
+func (p Slack) Connection() interface{} {
+	return &models.SlackConnection{}
+}
+
+func (p Slack) Scope() interface{} {
+	return &models.SlackChannel{}
+}
+
+func (p Slack) TransformationRule() interface{} {
+	return &models.SlackTransformationRule{}
+}
+
 func (p Slack) Init(basicRes context.BasicRes) errors.Error {
 	api.Init(basicRes)
 	return nil
@@ -49,6 +66,9 @@ func (p Slack) Init(basicRes context.BasicRes) errors.Error {
 func (p Slack) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.SlackConnection{},
+		&models.SlackChannel{},
+		&models.SlackChannelMessage{},
+		&models.SlackTransformationRule{},
 	}
 }
 
@@ -66,12 +86,16 @@ func (p Slack) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectThreadMeta,
 		tasks.ExtractThreadMeta,
+
+		tasks.ConvertChannelMeta,
+		tasks.ConvertDeploymentsMeta,
+		tasks.ConvertIncidentsMeta,
 	}
 }
 
 func (p Slack) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
-	var op tasks.SlackOptions
-	if err := helper.Decode(options, &op, nil); err != nil {
+	op, err := tasks.DecodeTaskOptions(options)
+	if err != nil {
 		return nil, err
 	}
 
@@ -80,7 +104,11 @@ func (p Slack) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]in
 		nil,
 	)
 	connection := &models.SlackConnection{}
-	err := connectionHelper.FirstById(connection, op.ConnectionId)
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+	err = EnrichOptions(taskCtx, op)
 	if err != nil {
 		return nil, err
 	}
@@ -89,10 +117,44 @@ func (p Slack) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]in
 	if err != nil {
 		return nil, err
 	}
-	return &tasks.SlackTaskData{
-		Options:   &op,
-		ApiClient: apiClient,
-	}, nil
+	taskData := &tasks.SlackTaskData{
+		Options:       op,
+		ApiClient:     apiClient,
+		RegexEnricher: helper.NewRegexEnricher(),
+	}
+	if op.TimeAfter != "" {
+		var timeAfter time.Time
+		timeAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.TimeAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `timeAfter`")
+		}
+		taskData.TimeAfter = &timeAfter
+	}
+	rule := op.SlackTransformationRule
+	for name, pattern := range map[string]string{
+		devops.DEPLOYMENT: rule.DeploymentPattern,
+		devops.PRODUCTION: rule.ProductionPattern,
+		devops.FAILURE:    rule.FailurePattern,
+		ticket.INCIDENT:   rule.IncidentPattern,
+		ticket.DONE:       rule.ResolvedPattern,
+	} {
+		if err = taskData.RegexEnricher.TryAdd(name, pattern); err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid pattern `%s`", pattern))
+		}
+	}
+	if rule.CommitShaPattern != "" {
+		taskData.CommitShaRegex, err = errors.Convert01(regexp.Compile(rule.CommitShaPattern))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `commitShaPattern`")
+		}
+	}
+	if rule.RepoUrlPattern != "" {
+		taskData.RepoUrlRegex, err = errors.Convert01(regexp.Compile(rule.RepoUrlPattern))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `repoUrlPattern`")
+		}
+	}
+	return taskData, nil
 }
 
 func (p Slack) RootPkgPath() string {
@@ -103,6 +165,10 @@ func (p Slack) MigrationScripts() []plugin.MigrationScript {
 	return migrationscripts.All()
 }
 
+func (p Slack) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
 func (p Slack) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	return map[string]map[string]plugin.ApiResourceHandler{
 		"test": {
@@ -117,6 +183,25 @@ func (p Slack) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"DELETE": api.DeleteConnection,
 			"GET":    api.GetConnection,
 		},
+		"connections/:connectionId/scopes/:scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"connections/:connectionId/transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
+		"connections/:connectionId/transformation_rules/batch": {
+			"POST": api.BatchTransformationRules,
+		},
 	}
 }
 
@@ -128,3 +213,28 @@ func (p Slack) Close(taskCtx plugin.TaskContext) errors.Error {
 	data.ApiClient.Release()
 	return nil
 }
+
+// EnrichOptions picks the transformation rule of the channel when the options do not tell it
+func EnrichOptions(taskCtx plugin.TaskContext, op *tasks.SlackOptions) errors.Error {
+	db := taskCtx.GetDal()
+	if op.ChannelId != "" && op.TransformationRuleId == 0 {
+		var channel models.SlackChannel
+		err := db.First(&channel, dal.Where("connection_id = ? AND id = ?", op.ConnectionId, op.ChannelId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find channel %s", op.ChannelId))
+		}
+		op.TransformationRuleId = channel.TransformationRuleId
+	}
+	if op.SlackTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.SlackTransformationRule
+		err := db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get transformationRule")
+		}
+		op.SlackTransformationRule = &transformationRule
+	}
+	if op.SlackTransformationRule == nil {
+		op.SlackTransformationRule = new(models.SlackTransformationRule)
+	}
+	return nil
+}
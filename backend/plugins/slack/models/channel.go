@@ -19,13 +19,17 @@ package models
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
 )
 
+var _ plugin.ToolLayerScope = (*SlackChannel)(nil)
+var _ plugin.ApiScope = (*SlackChannel)(nil)
+
 type SlackChannel struct {
-	common.NoPKModel   `json:"-"`
-	ConnectionId       uint64 `gorm:"primaryKey"`
-	Id                 string `json:"id" gorm:"primaryKey"`
-	Name               string `json:"name"`
+	common.NoPKModel   `json:"-" mapstructure:"-"`
+	ConnectionId       uint64 `json:"connectionId" gorm:"primaryKey" mapstructure:"connectionId,omitempty"`
+	Id                 string `json:"id" gorm:"primaryKey" mapstructure:"id"`
+	Name               string `json:"name" mapstructure:"name,omitempty"`
 	IsChannel          bool   `json:"is_channel"`
 	IsGroup            bool   `json:"is_group"`
 	IsIm               bool   `json:"is_im"`
@@ -45,8 +49,22 @@ type SlackChannel struct {
 	IsExtShared        bool   `json:"is_ext_shared"`
 	IsMember           bool   `json:"is_member"`
 	NumMembers         int    `json:"num_members"`
+	// TransformationRuleId picks the messages announcing deployments and incidents when the channel is a scope
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
 }
 
 func (SlackChannel) TableName() string {
 	return "_tool_slack_channels"
 }
+
+func (c SlackChannel) ScopeId() string {
+	return c.Id
+}
+
+func (c SlackChannel) ScopeName() string {
+	return c.Name
+}
+
+func (c SlackChannel) ConvertApiScope() plugin.ToolLayerScope {
+	return c
+}
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type SlackTransformationRule struct {
+	archived.Model    `mapstructure:"-"`
+	ConnectionId      uint64 `mapstructure:"connectionId" json:"connectionId"`
+	Name              string `mapstructure:"name" json:"name" gorm:"type:varchar(255);index:idx_name_slack,unique" validate:"required"`
+	DeploymentPattern string `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
+	ProductionPattern string `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
+	FailurePattern    string `mapstructure:"failurePattern,omitempty" json:"failurePattern" gorm:"type:varchar(255)"`
+	CommitShaPattern  string `mapstructure:"commitShaPattern,omitempty" json:"commitShaPattern" gorm:"type:varchar(255)"`
+	RepoUrlPattern    string `mapstructure:"repoUrlPattern,omitempty" json:"repoUrlPattern" gorm:"type:varchar(255)"`
+	IncidentPattern   string `mapstructure:"incidentPattern,omitempty" json:"incidentPattern" gorm:"type:varchar(255)"`
+	ResolvedPattern   string `mapstructure:"resolvedPattern,omitempty" json:"resolvedPattern" gorm:"type:varchar(255)"`
+}
+
+func (SlackTransformationRule) TableName() string {
+	return "_tool_slack_transformation_rules"
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type SlackTransformationRule struct {
+	common.Model `mapstructure:"-"`
+	ConnectionId uint64 `mapstructure:"connectionId" json:"connectionId"`
+	Name         string `mapstructure:"name" json:"name" gorm:"type:varchar(255);index:idx_name_slack,unique" validate:"required"`
+	// DeploymentPattern picks the messages announcing a deployment by their text, i.e. `(?i)deployed`
+	DeploymentPattern string `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
+	// ProductionPattern picks the deployments to production by the text of their message, i.e. `(?i)to production`,
+	// all of them are deployed to production when it is omitted
+	ProductionPattern string `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
+	// FailurePattern picks the deployments which failed by the text of their message, i.e. `(?i)failed`
+	FailurePattern string `mapstructure:"failurePattern,omitempty" json:"failurePattern" gorm:"type:varchar(255)"`
+	// CommitShaPattern captures the sha of the deployed commit in its first group, i.e. `\b([0-9a-f]{40})\b`
+	CommitShaPattern string `mapstructure:"commitShaPattern,omitempty" json:"commitShaPattern" gorm:"type:varchar(255)"`
+	// RepoUrlPattern captures the url of the deployed repo in its first group, i.e. `<(https://github\.com/[^/]+/[^/|>]+)`
+	RepoUrlPattern string `mapstructure:"repoUrlPattern,omitempty" json:"repoUrlPattern" gorm:"type:varchar(255)"`
+	// IncidentPattern picks the messages opening an incident by their text, i.e. `(?i)^:rotating_light:`
+	IncidentPattern string `mapstructure:"incidentPattern,omitempty" json:"incidentPattern" gorm:"type:varchar(255)"`
+	// ResolvedPattern picks the reply in the thread of an incident which resolves it, i.e. `(?i)resolved`
+	ResolvedPattern string `mapstructure:"resolvedPattern,omitempty" json:"resolvedPattern" gorm:"type:varchar(255)"`
+}
+
+func (SlackTransformationRule) TableName() string {
+	return "_tool_slack_transformation_rules"
+}
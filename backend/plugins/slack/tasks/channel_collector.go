@@ -32,22 +32,41 @@ const RAW_CHANNEL_TABLE = "slack_channel"
 
 var _ plugin.SubTaskEntryPoint = CollectChannel
 
-// CollectChannel collect all channels that bot is in
+// CollectChannel collect all channels that bot is in, or only the configured one
 func CollectChannel(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*SlackTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANNEL_TABLE)
+	if data.Options.ChannelId != "" {
+		collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+			RawDataSubTaskArgs: *rawDataSubTaskArgs,
+			ApiClient:          data.ApiClient,
+			UrlTemplate:        "conversations.info",
+			Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+				query := url.Values{}
+				query.Set("channel", data.Options.ChannelId)
+				return query, nil
+			},
+			ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+				body := &apimodels.SlackChannelInfoApiResult{}
+				err := api.UnmarshalResponse(res, body)
+				if err != nil {
+					return nil, err
+				}
+				return []json.RawMessage{body.Channel}, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return collector.Execute()
+	}
+
 	pageSize := 100
 	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: SlackApiParams{
-				ConnectionId: data.Options.ConnectionId,
-			},
-			Table: RAW_CHANNEL_TABLE,
-		},
-		ApiClient:   data.ApiClient,
-		Incremental: false,
-		UrlTemplate: "conversations.list",
-		PageSize:    pageSize,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Incremental:        false,
+		UrlTemplate:        "conversations.list",
+		PageSize:           pageSize,
 		GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
 			res := apimodels.SlackChannelMessageApiResult{}
 			err := api.UnmarshalResponse(prevPageResponse, &res)
@@ -88,4 +107,5 @@ var CollectChannelMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectChannel,
 	EnabledByDefault: true,
 	Description:      "Collect channels from Slack api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
 }
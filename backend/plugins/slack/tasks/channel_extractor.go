@@ -28,15 +28,9 @@ import (
 var _ plugin.SubTaskEntryPoint = ExtractChannel
 
 func ExtractChannel(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*SlackTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANNEL_TABLE)
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: SlackApiParams{
-				ConnectionId: data.Options.ConnectionId,
-			},
-			Table: RAW_CHANNEL_TABLE,
-		},
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
 		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
 			body := &models.SlackChannel{}
 			err := errors.Convert(json.Unmarshal(row.Data, body))
@@ -44,6 +38,8 @@ func ExtractChannel(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, err
 			}
 			body.ConnectionId = data.Options.ConnectionId
+			// the configured channel is a scope, its transformation rule is kept
+			body.TransformationRuleId = data.Options.TransformationRuleId
 			return []interface{}{body}, nil
 		},
 	})
@@ -59,4 +55,5 @@ var ExtractChannelMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractChannel,
 	EnabledByDefault: true,
 	Description:      "Extract raw channel data into tool layer table",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
 }
@@ -39,7 +39,7 @@ type ChannelInput struct {
 }
 
 func CollectChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*SlackTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANNEL_MESSAGE_TABLE)
 	db := taskCtx.GetDal()
 
 	clauses := []dal.Clause{
@@ -47,6 +47,9 @@ func CollectChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
 		dal.From("_tool_slack_channels"),
 		dal.Where("connection_id=?", data.Options.ConnectionId),
 	}
+	if data.Options.ChannelId != "" {
+		clauses = append(clauses, dal.Where("id=?", data.Options.ChannelId))
+	}
 
 	// construct the input iterator
 	cursor, err := db.Cursor(clauses...)
@@ -61,18 +64,12 @@ func CollectChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
 
 	pageSize := 100
 	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: SlackApiParams{
-				ConnectionId: data.Options.ConnectionId,
-			},
-			Table: RAW_CHANNEL_MESSAGE_TABLE,
-		},
-		ApiClient:   data.ApiClient,
-		Incremental: false,
-		Input:       iterator,
-		UrlTemplate: "conversations.history",
-		PageSize:    pageSize,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Incremental:        false,
+		Input:              iterator,
+		UrlTemplate:        "conversations.history",
+		PageSize:           pageSize,
 		GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
 			res := apimodels.SlackChannelMessageApiResult{}
 			err := api.UnmarshalResponse(prevPageResponse, &res)
@@ -89,6 +86,10 @@ func CollectChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
 			query := url.Values{}
 			query.Set("channel", input.ChannelId)
 			query.Set("limit", strconv.Itoa(pageSize))
+			// the messages posted before timeAfter are skipped
+			if data.TimeAfter != nil {
+				query.Set("oldest", strconv.FormatInt(data.TimeAfter.Unix(), 10))
+			}
 			if pageToken, ok := reqData.CustomData.(string); ok && pageToken != "" {
 				query.Set("cursor", reqData.CustomData.(string))
 			}
@@ -115,4 +116,5 @@ var CollectChannelMessageMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectChannelMessage,
 	EnabledByDefault: true,
 	Description:      "Collect channel message from Slack api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
 }
@@ -24,20 +24,15 @@ import (
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/slack/apimodels"
 	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"strings"
 )
 
 var _ plugin.SubTaskEntryPoint = ExtractChannelMessage
 
 func ExtractChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*SlackTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANNEL_MESSAGE_TABLE)
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: SlackApiParams{
-				ConnectionId: data.Options.ConnectionId,
-			},
-			Table: RAW_CHANNEL_MESSAGE_TABLE,
-		},
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
 		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
 			channel := &ChannelInput{}
 			err := errors.Convert(json.Unmarshal(row.Input, channel))
@@ -59,7 +54,7 @@ func ExtractChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
 			message.Ts = body.Ts
 			message.ThreadTs = body.ThreadTs
 			message.User = body.User
-			message.Text = body.Text
+			message.Text = messageText(body)
 			message.Team = body.Team
 			message.ReplyCount = body.ReplyCount
 			message.ReplyUsersCount = body.ReplyUsersCount
@@ -77,9 +72,30 @@ func ExtractChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
 	return extractor.Execute()
 }
 
+// messageText returns the text of the message, the bots often post attachments only, their texts are joined instead
+func messageText(body *apimodels.SlackChannelMessageResultItem) string {
+	if body.Text != "" || len(body.Attachments) == 0 {
+		return body.Text
+	}
+	texts := make([]string, 0, len(body.Attachments))
+	for _, attachment := range body.Attachments {
+		if attachment.Fallback != "" {
+			texts = append(texts, attachment.Fallback)
+			continue
+		}
+		for _, text := range []string{attachment.Pretext, attachment.Title, attachment.Text} {
+			if text != "" {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
 var ExtractChannelMessageMeta = plugin.SubTaskMeta{
 	Name:             "extractChannelMessage",
 	EntryPoint:       ExtractChannelMessage,
 	EnabledByDefault: true,
 	Description:      "Extract raw channel messages data into tool layer table",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
 }
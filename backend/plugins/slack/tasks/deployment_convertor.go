@@ -0,0 +1,145 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert the messages of tool layer table slack_channel_messages announcing deployments into domain layer table cicd_pipelines, cicd_tasks and cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANNEL_MESSAGE_TABLE)
+	if data.Options.ChannelId == "" || data.Options.DeploymentPattern == "" {
+		return nil
+	}
+	db := taskCtx.GetDal()
+	// the replies in the threads are not announcements
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackChannelMessage{}),
+		dal.Where("connection_id = ? AND channel_id = ? AND (thread_ts = '' OR thread_ts = ts)", data.Options.ConnectionId, data.Options.ChannelId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	messageIdGen := didgen.NewDomainIdGenerator(&models.SlackChannelMessage{})
+	channelIdGen := didgen.NewDomainIdGenerator(&models.SlackChannel{})
+	scopeId := channelIdGen.Generate(data.Options.ConnectionId, data.Options.ChannelId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.SlackChannelMessage{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			message := inputRow.(*models.SlackChannelMessage)
+			if data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, message.Text) == "" {
+				return nil, nil
+			}
+			postedDate, err := tsToTime(message.Ts)
+			if err != nil {
+				return nil, err
+			}
+			// a deployment is announced once it is over, it is both the pipeline and its only task
+			result := devops.SUCCESS
+			if data.RegexEnricher.ReturnNameIfMatched(devops.FAILURE, message.Text) != "" {
+				result = devops.FAILURE
+			}
+			environment := data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, message.Text)
+			name := firstLine(message.Text)
+			id := messageIdGen.Generate(message.ConnectionId, message.ChannelId, message.Ts)
+			results := []interface{}{
+				&devops.CICDPipeline{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         name,
+					Result:       result,
+					Status:       devops.DONE,
+					Type:         devops.DEPLOYMENT,
+					Environment:  environment,
+					CreatedDate:  postedDate,
+					FinishedDate: &postedDate,
+					CicdScopeId:  scopeId,
+				},
+				&devops.CICDTask{
+					DomainEntity: domainlayer.DomainEntity{Id: id},
+					Name:         name,
+					PipelineId:   id,
+					Result:       result,
+					Status:       devops.DONE,
+					Type:         devops.DEPLOYMENT,
+					Environment:  environment,
+					StartedDate:  postedDate,
+					FinishedDate: &postedDate,
+					CicdScopeId:  scopeId,
+				},
+			}
+			// the commit is only known when the announcement tells both the sha and the repo
+			commitSha := firstGroup(data.CommitShaRegex, message.Text)
+			repoUrl := firstGroup(data.RepoUrlRegex, message.Text)
+			if commitSha != "" && repoUrl != "" {
+				durationSec := uint64(0)
+				results = append(results,
+					&devops.CiCDPipelineCommit{
+						PipelineId: id,
+						CommitSha:  commitSha,
+						RepoUrl:    repoUrl,
+					},
+					// the id is the one dora derives from the pipeline commit, so that both end up with the same row
+					&devops.CicdDeploymentCommit{
+						DomainEntity:     domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", id, repoUrl)},
+						CicdScopeId:      scopeId,
+						CicdDeploymentId: id,
+						Name:             name,
+						Result:           result,
+						Status:           devops.DONE,
+						Environment:      environment,
+						CreatedDate:      postedDate,
+						StartedDate:      &postedDate,
+						FinishedDate:     &postedDate,
+						DurationSec:      &durationSec,
+						CommitSha:        commitSha,
+						RepoUrl:          repoUrl,
+					},
+				)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
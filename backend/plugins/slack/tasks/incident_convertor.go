@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var ConvertIncidentsMeta = plugin.SubTaskMeta{
+	Name:             "convertIncidents",
+	EntryPoint:       ConvertIncidents,
+	EnabledByDefault: true,
+	Description:      "Convert the messages of tool layer table slack_channel_messages opening incidents into domain layer table issues and board_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// ConvertIncidents turns each message matching the incident pattern into an incident, which is resolved by the first
+// reply in its thread matching the resolved pattern
+func ConvertIncidents(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANNEL_MESSAGE_TABLE)
+	if data.Options.ChannelId == "" || data.Options.IncidentPattern == "" {
+		return nil
+	}
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackChannelMessage{}),
+		dal.Where("connection_id = ? AND channel_id = ? AND (thread_ts = '' OR thread_ts = ts)", data.Options.ConnectionId, data.Options.ChannelId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	messageIdGen := didgen.NewDomainIdGenerator(&models.SlackChannelMessage{})
+	channelIdGen := didgen.NewDomainIdGenerator(&models.SlackChannel{})
+	boardId := channelIdGen.Generate(data.Options.ConnectionId, data.Options.ChannelId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.SlackChannelMessage{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			message := inputRow.(*models.SlackChannelMessage)
+			if data.RegexEnricher.ReturnNameIfMatched(ticket.INCIDENT, message.Text) == "" {
+				return nil, nil
+			}
+			postedDate, err := tsToTime(message.Ts)
+			if err != nil {
+				return nil, err
+			}
+			domainIssue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{Id: messageIdGen.Generate(message.ConnectionId, message.ChannelId, message.Ts)},
+				IssueKey:     message.Ts,
+				Title:        firstLine(message.Text),
+				Description:  message.Text,
+				Type:         ticket.INCIDENT,
+				Status:       ticket.IN_PROGRESS,
+				CreatedDate:  &postedDate,
+				UpdatedDate:  &postedDate,
+			}
+
+			var replies []models.SlackChannelMessage
+			err = db.All(&replies,
+				dal.Where(
+					"connection_id = ? AND channel_id = ? AND thread_ts = ? AND ts != thread_ts",
+					message.ConnectionId, message.ChannelId, message.Ts,
+				),
+				dal.Orderby("ts"),
+			)
+			if err != nil {
+				return nil, err
+			}
+			for _, reply := range replies {
+				if data.RegexEnricher.ReturnNameIfMatched(ticket.DONE, reply.Text) == "" {
+					continue
+				}
+				resolutionDate, err := tsToTime(reply.Ts)
+				if err != nil {
+					return nil, err
+				}
+				domainIssue.Status = ticket.DONE
+				domainIssue.ResolutionDate = &resolutionDate
+				domainIssue.UpdatedDate = &resolutionDate
+				domainIssue.LeadTimeMinutes = int64(resolutionDate.Sub(postedDate).Minutes())
+				break
+			}
+			return []interface{}{
+				domainIssue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: domainIssue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
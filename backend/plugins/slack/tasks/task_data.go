@@ -18,18 +18,107 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
 )
 
 type SlackApiParams struct {
 	ConnectionId uint64 `json:"connectionId"`
+	ChannelId    string `json:"channelId,omitempty"`
 }
 
 type SlackOptions struct {
-	ConnectionId uint64 `json:"connectionId"`
+	ConnectionId uint64 `json:"connectionId" mapstructure:"connectionId"`
+	// ChannelId limits the collection to a single channel, all the channels the bot is in are collected when it is omitted
+	ChannelId                       string `json:"channelId" mapstructure:"channelId,omitempty"`
+	TimeAfter                       string `json:"timeAfter" mapstructure:"timeAfter,omitempty"`
+	TransformationRuleId            uint64 `json:"transformationRuleId" mapstructure:"transformationRuleId,omitempty"`
+	*models.SlackTransformationRule `mapstructure:"transformationRules,omitempty" json:"transformationRules"`
 }
 
 type SlackTaskData struct {
-	Options   *SlackOptions
-	ApiClient *helper.ApiAsyncClient
+	Options       *SlackOptions
+	ApiClient     *helper.ApiAsyncClient
+	TimeAfter     *time.Time
+	RegexEnricher *helper.RegexEnricher
+	// CommitShaRegex and RepoUrlRegex capture what a deployment deployed in their first group
+	CommitShaRegex *regexp.Regexp
+	RepoUrlRegex   *regexp.Regexp
+}
+
+func DecodeTaskOptions(options map[string]interface{}) (*SlackOptions, errors.Error) {
+	var op SlackOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, err
+	}
+	if op.ConnectionId == 0 {
+		return nil, errors.BadInput.New("connectionId is invalid")
+	}
+	return &op, nil
+}
+
+func EncodeTaskOptions(op *SlackOptions) (map[string]interface{}, errors.Error) {
+	var result map[string]interface{}
+	err := helper.Decode(op, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateRawDataSubTaskArgs keys the raw data by the channel, the ones of the other channels are kept on collection
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, table string) (*helper.RawDataSubTaskArgs, *SlackTaskData) {
+	data := taskCtx.GetData().(*SlackTaskData)
+	return &helper.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: SlackApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ChannelId:    data.Options.ChannelId,
+		},
+		Table: table,
+	}, data
+}
+
+// tsToTime converts the ts of a message, the seconds since epoch followed by the microseconds, i.e. 1687946400.000100
+func tsToTime(ts string) (time.Time, errors.Error) {
+	seconds, micros, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Default.Wrap(err, fmt.Sprintf("invalid ts %s", ts))
+	}
+	usec := int64(0)
+	if micros != "" {
+		usec, err = strconv.ParseInt(micros, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.Default.Wrap(err, fmt.Sprintf("invalid ts %s", ts))
+		}
+	}
+	return time.Unix(sec, usec*int64(time.Microsecond)).UTC(), nil
+}
+
+// firstGroup returns the first group captured by the regex in the text, nothing is captured when the regex is omitted
+func firstGroup(regex *regexp.Regexp, text string) string {
+	if regex == nil {
+		return ""
+	}
+	matches := regex.FindStringSubmatch(text)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// firstLine returns the first line of the text of a message, it names what the message announces
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return line
 }
@@ -40,13 +40,18 @@ type ThreadInput struct {
 }
 
 func CollectThread(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*SlackTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_THREAD_TABLE)
 	db := taskCtx.GetDal()
 
+	// only the parents of the threads are picked, the threads of the bots are collected as well since the
+	// incidents they open are resolved in there
 	clauses := []dal.Clause{
 		dal.Select("thread_ts, channel_id"),
 		dal.From("_tool_slack_channel_messages"),
-		dal.Where("connection_id=? AND thread_ts!='' AND subtype=''", data.Options.ConnectionId),
+		dal.Where("connection_id=? AND thread_ts=ts AND subtype IN ?", data.Options.ConnectionId, []string{"", "bot_message"}),
+	}
+	if data.Options.ChannelId != "" {
+		clauses = append(clauses, dal.Where("channel_id=?", data.Options.ChannelId))
 	}
 
 	// construct the input iterator
@@ -62,18 +67,12 @@ func CollectThread(taskCtx plugin.SubTaskContext) errors.Error {
 
 	pageSize := 50
 	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: SlackApiParams{
-				ConnectionId: data.Options.ConnectionId,
-			},
-			Table: RAW_THREAD_TABLE,
-		},
-		ApiClient:   data.ApiClient,
-		Incremental: false,
-		Input:       iterator,
-		UrlTemplate: "conversations.replies",
-		PageSize:    pageSize,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Incremental:        false,
+		Input:              iterator,
+		UrlTemplate:        "conversations.replies",
+		PageSize:           pageSize,
 		GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
 			res := apimodels.SlackThreadsApiResult{}
 			err := api.UnmarshalResponse(prevPageResponse, &res)
@@ -118,4 +117,5 @@ var CollectThreadMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectThread,
 	EnabledByDefault: true,
 	Description:      "Collect thread from Slack api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
 }
@@ -29,15 +29,9 @@ import (
 var _ plugin.SubTaskEntryPoint = ExtractThread
 
 func ExtractThread(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*SlackTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_THREAD_TABLE)
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: SlackApiParams{
-				ConnectionId: data.Options.ConnectionId,
-			},
-			Table: RAW_THREAD_TABLE,
-		},
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
 		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
 			threadInput := &ThreadInput{}
 			err := errors.Convert(json.Unmarshal(row.Input, threadInput))
@@ -59,7 +53,7 @@ func ExtractThread(taskCtx plugin.SubTaskContext) errors.Error {
 			message.Ts = body.Ts
 			message.ThreadTs = body.ThreadTs
 			message.User = body.User
-			message.Text = body.Text
+			message.Text = messageText(body)
 			message.Team = body.Team
 			message.ReplyCount = body.ReplyCount
 			message.ReplyUsersCount = body.ReplyUsersCount
@@ -82,4 +76,5 @@ var ExtractThreadMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractThread,
 	EnabledByDefault: true,
 	Description:      "Extract raw thread messages data into tool layer table",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
 }
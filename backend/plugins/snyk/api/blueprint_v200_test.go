@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/snyk/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMakeDataSourcePipelinePlanV200(t *testing.T) {
+	connection := &models.SnykConnection{
+		BaseConnection: helper.BaseConnection{
+			Name: "snyk-test",
+			Model: common.Model{
+				ID: 1,
+			},
+		},
+		SnykConn: models.SnykConn{
+			RestConnection: helper.RestConnection{
+				Endpoint:         "https://api.snyk.io/v1/",
+				Proxy:            "",
+				RateLimitPerHour: 0,
+			},
+			SnykToken: models.SnykToken{
+				Token: "secret",
+			},
+		},
+	}
+	mockMeta := mockplugin.NewPluginMeta(t)
+	mockMeta.On("RootPkgPath").Return("github.com/apache/incubator-devlake/plugins/snyk")
+	err := plugin.RegisterPlugin("snyk", mockMeta)
+	assert.Nil(t, err)
+	// Refresh Global Variables and set the sql mock
+	basicRes = NewMockBasicRes()
+	bs := &plugin.BlueprintScopeV200{
+		Entities: []string{plugin.DOMAIN_TYPE_SECURITY},
+		Id:       "4a72d1db-b465-4764-99e1-ecedad03b06a",
+	}
+	bpScopes := make([]*plugin.BlueprintScopeV200, 0)
+	bpScopes = append(bpScopes, bs)
+	syncPolicy := &plugin.BlueprintSyncPolicy{}
+
+	plan := make(plugin.PipelinePlan, len(bpScopes))
+	plan, err = makeDataSourcePipelinePlanV200(nil, plan, bpScopes, connection, syncPolicy)
+	assert.Nil(t, err)
+	basicRes = NewMockBasicRes()
+	scopes, err := makeScopesV200(bpScopes, connection)
+	assert.Nil(t, err)
+
+	expectPlan := plugin.PipelinePlan{
+		plugin.PipelineStage{
+			{
+				Plugin:   "snyk",
+				Subtasks: []string{},
+				Options: map[string]interface{}{
+					"projectId":    "4a72d1db-b465-4764-99e1-ecedad03b06a",
+					"orgId":        "689ce7f9-7943-4a71-b704-2ba575f01089",
+					"connectionId": uint64(1),
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectPlan, plan)
+
+	expectScopes := make([]plugin.Scope, 0)
+	securityScope := &security.SecurityScope{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: "snyk:SnykProject:1:4a72d1db-b465-4764-99e1-ecedad03b06a",
+		},
+		Name: "example/checkout:package.json",
+		Tool: "snyk",
+		Url:  "https://app.snyk.io/org/example/project/4a72d1db-b465-4764-99e1-ecedad03b06a",
+	}
+	expectScopes = append(expectScopes, securityScope)
+	assert.Equal(t, expectScopes, scopes)
+}
+
+// NewMockBasicRes FIXME ...
+func NewMockBasicRes() *mockcontext.BasicRes {
+	testSnykProject := &models.SnykProject{
+		ConnectionId:  1,
+		ProjectId:     "4a72d1db-b465-4764-99e1-ecedad03b06a",
+		OrgId:         "689ce7f9-7943-4a71-b704-2ba575f01089",
+		Name:          "example/checkout:package.json",
+		Type:          "npm",
+		Origin:        "github",
+		Branch:        "main",
+		RemoteRepoUrl: "https://github.com/example/checkout",
+		BrowseUrl:     "https://app.snyk.io/org/example/project/4a72d1db-b465-4764-99e1-ecedad03b06a",
+	}
+
+	mockRes := new(mockcontext.BasicRes)
+	mockDal := new(mockdal.Dal)
+
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dst := args.Get(0).(*models.SnykProject)
+		*dst = *testSnykProject
+	}).Return(nil).Once()
+
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+
+	return mockRes
+}
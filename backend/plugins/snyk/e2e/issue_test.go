@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/snyk/impl"
+	"github.com/apache/incubator-devlake/plugins/snyk/models"
+	"github.com/apache/incubator-devlake/plugins/snyk/tasks"
+)
+
+func TestSnykIssueDataFlow(t *testing.T) {
+
+	var snyk impl.Snyk
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "snyk", snyk)
+
+	taskData := &tasks.SnykTaskData{
+		Options: &tasks.SnykOptions{
+			ConnectionId: 1,
+			ProjectId:    "4a72d1db-b465-4764-99e1-ecedad03b06a",
+			OrgId:        "689ce7f9-7943-4a71-b704-2ba575f01089",
+		},
+	}
+
+	// import raw data table, the repo and its pull requests are the ones collected by the github plugin
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_snyk_projects.csv", &models.SnykProject{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/repos.csv", &code.Repo{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_snyk_api_issues.csv", "_raw_snyk_api_issues")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SnykIssue{})
+	dataflowTester.Subtask(tasks.ExtractApiIssuesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.SnykIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_snyk_issues.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion, the project is linked to the repo it was imported from by its name
+	dataflowTester.FlushTabler(&security.SecurityScope{})
+	dataflowTester.Subtask(tasks.ConvertProjectMeta, taskData)
+	dataflowTester.VerifyTable(
+		security.SecurityScope{},
+		"./snapshot_tables/security_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"tool",
+			"url",
+			"repo_id",
+			"created_date",
+			"updated_date",
+		},
+	)
+
+	// the license issues are not vulnerabilities
+	dataflowTester.FlushTabler(&security.SecurityVulnerability{})
+	dataflowTester.Subtask(tasks.ConvertIssuesMeta, taskData)
+	dataflowTester.VerifyTable(
+		security.SecurityVulnerability{},
+		"./snapshot_tables/security_vulnerabilities.csv",
+		[]string{
+			"id",
+			"security_scope_id",
+			"tool",
+			"scan_type",
+			"title",
+			"description",
+			"url",
+			"severity",
+			"cwe",
+			"cve",
+			"repo_id",
+			"component",
+			"component_version",
+			"fixed_version",
+			"status",
+			"original_status",
+			"created_date",
+			"updated_date",
+		},
+	)
+
+	// only the pull requests opened by snyk for this project are linked
+	dataflowTester.FlushTabler(&security.SecurityVulnerabilityPullRequest{})
+	dataflowTester.Subtask(tasks.ConvertFixPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(security.SecurityVulnerabilityPullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/security_vulnerability_pull_requests.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,200 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/snyk/api"
+	"github.com/apache/incubator-devlake/plugins/snyk/models"
+	"github.com/apache/incubator-devlake/plugins/snyk/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/snyk/tasks"
+)
+
+var _ plugin.PluginMeta = (*Snyk)(nil)
+var _ plugin.PluginInit = (*Snyk)(nil)
+var _ plugin.PluginTask = (*Snyk)(nil)
+var _ plugin.PluginApi = (*Snyk)(nil)
+var _ plugin.PluginModel = (*Snyk)(nil)
+var _ plugin.PluginMigration = (*Snyk)(nil)
+var _ plugin.CloseablePluginTask = (*Snyk)(nil)
+var _ plugin.PluginSource = (*Snyk)(nil)
+
+type Snyk string
+
+func (p Snyk) Connection() interface{} {
+	return &models.SnykConnection{}
+}
+
+func (p Snyk) Scope() interface{} {
+	return &models.SnykProject{}
+}
+
+func (p Snyk) TransformationRule() interface{} {
+	return nil
+}
+
+func (p Snyk) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Snyk) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.SnykConnection{},
+		&models.SnykProject{},
+		&models.SnykIssue{},
+	}
+}
+
+func (p Snyk) Description() string {
+	return "To collect and enrich data from Snyk"
+}
+
+func (p Snyk) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectApiIssuesMeta,
+		tasks.ExtractApiIssuesMeta,
+
+		tasks.ConvertProjectMeta,
+		tasks.ConvertIssuesMeta,
+		tasks.ConvertFixPullRequestsMeta,
+	}
+}
+
+func (p Snyk) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.SnykConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get snyk connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get snyk API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tasks.SnykTaskData{
+		Options:   op,
+		ApiClient: apiClient,
+	}, nil
+}
+
+func (p Snyk) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/snyk"
+}
+
+func (p Snyk) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Snyk) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (pp plugin.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
+func (p Snyk) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/:scopeId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+	}
+}
+
+func (p Snyk) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.SnykTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.SnykOptions,
+	apiClient *helper.ApiClient) errors.Error {
+	var project models.SnykProject
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	err := db.First(&project, dal.Where(
+		"connection_id = ? AND project_id = ?",
+		op.ConnectionId, op.ProjectId))
+	if err == nil {
+		if op.OrgId == "" {
+			op.OrgId = project.OrgId
+		}
+		return nil
+	}
+	if !db.IsErrorNotFound(err) {
+		return errors.Default.Wrap(err, fmt.Sprintf("fail to find project %s", op.ProjectId))
+	}
+	// the projects are only reachable through their org
+	if op.OrgId == "" {
+		return errors.BadInput.New("orgId is required when the project is not added as a scope")
+	}
+	var apiProject *models.SnykApiProject
+	apiProject, err = tasks.GetApiProject(op, apiClient)
+	if err != nil {
+		return err
+	}
+	logger.Debug(fmt.Sprintf("Current project: %s", op.ProjectId))
+	scope := apiProject.ConvertApiScope().(*models.SnykProject)
+	scope.ConnectionId = op.ConnectionId
+	return db.CreateIfNotExist(scope)
+}
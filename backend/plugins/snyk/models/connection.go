@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*SnykConnection)(nil)
+
+// SnykToken authenticates with the API token of a user or a service account
+type SnykToken api.AccessToken
+
+// SetupAuthentication sets up the request headers for authentication
+func (t *SnykToken) SetupAuthentication(request *http.Request) errors.Error {
+	request.Header.Set("Authorization", fmt.Sprintf("token %s", t.Token))
+	return nil
+}
+
+// SnykConn holds the essential information to connect to the Snyk API,
+// the endpoint is the url of the v1 api, i.e. https://api.snyk.io/v1/ or https://api.eu.snyk.io/v1/
+type SnykConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	SnykToken          `mapstructure:",squash"`
+}
+
+// SnykConnection holds SnykConn plus ID/Name for database storage
+type SnykConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	SnykConn           `mapstructure:",squash"`
+}
+
+func (SnykConnection) TableName() string {
+	return "_tool_snyk_connections"
+}
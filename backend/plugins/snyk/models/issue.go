@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// types of the issues found in a project
+const (
+	ISSUE_TYPE_VULN          = "vuln"
+	ISSUE_TYPE_LICENSE       = "license"
+	ISSUE_TYPE_CONFIGURATION = "configuration"
+)
+
+// SnykIssue is an issue aggregated over the paths it is introduced through, the same issue id is reported by
+// every project depending on the vulnerable package
+type SnykIssue struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ProjectId       string `gorm:"primaryKey;type:varchar(100)"`
+	IssueId         string `gorm:"primaryKey;type:varchar(255)"`
+	IssueType       string `gorm:"type:varchar(20)"`
+	Title           string `gorm:"type:varchar(255)"`
+	Description     string
+	Url             string `gorm:"type:varchar(255)"`
+	Severity        string `gorm:"type:varchar(20)"`
+	Cve             string `gorm:"type:varchar(255)"`
+	Cwe             string `gorm:"type:varchar(255)"`
+	CvssScore       float64
+	ExploitMaturity string `gorm:"type:varchar(100)"`
+	PriorityScore   int
+	PkgName         string `gorm:"type:varchar(255)"`
+	PkgVersions     string `gorm:"type:varchar(255)"`
+	FixedIn         string `gorm:"type:varchar(255)"`
+	IsUpgradable    bool
+	IsPatchable     bool
+	IsPinnable      bool
+	IsIgnored       bool
+	IsPatched       bool
+	IntroducedDate  *time.Time
+	PublicationTime *time.Time
+	common.NoPKModel
+}
+
+func (SnykIssue) TableName() string {
+	return "_tool_snyk_issues"
+}
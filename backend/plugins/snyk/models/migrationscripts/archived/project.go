@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type SnykProject struct {
+	ConnectionId       uint64     `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	ProjectId          string     `json:"projectId" gorm:"primaryKey;type:varchar(100)" validate:"required" mapstructure:"projectId"`
+	OrgId              string     `json:"orgId" gorm:"type:varchar(100)" mapstructure:"orgId,omitempty"`
+	Name               string     `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	Type               string     `json:"type" gorm:"type:varchar(100)" mapstructure:"type,omitempty"`
+	Origin             string     `json:"origin" gorm:"type:varchar(100)" mapstructure:"origin,omitempty"`
+	Branch             string     `json:"branch" gorm:"type:varchar(255)" mapstructure:"branch,omitempty"`
+	RemoteRepoUrl      string     `json:"remoteRepoUrl" gorm:"type:varchar(255)" mapstructure:"remoteRepoUrl,omitempty"`
+	BrowseUrl          string     `json:"browseUrl" gorm:"type:varchar(255)" mapstructure:"browseUrl,omitempty"`
+	Created            *time.Time `json:"created" mapstructure:"created,omitempty"`
+	LastTestedDate     *time.Time `json:"lastTestedDate" mapstructure:"lastTestedDate,omitempty"`
+	archived.NoPKModel `json:"-" mapstructure:"-"`
+}
+
+func (SnykProject) TableName() string {
+	return "_tool_snyk_projects"
+}
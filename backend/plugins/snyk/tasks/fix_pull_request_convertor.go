@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/snyk/models"
+)
+
+var ConvertFixPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "convertFixPullRequests",
+	EntryPoint:       ConvertFixPullRequests,
+	EnabledByDefault: true,
+	Description:      "Link the pull requests opened by snyk to the vulnerabilities they fix into domain layer table security_vulnerability_pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+}
+
+// the pull requests opened by snyk keep what they fix in a hidden comment of their description,
+// i.e. <!--- (snyk:metadata:{"projectPublicId":"...","vulns":["SNYK-JS-LODASH-1018905"],...}) --->
+var fixMetadataPattern = regexp.MustCompile(`(?s)snyk:metadata:(\{.*?\})\)`)
+
+type snykFixMetadata struct {
+	ProjectPublicId string   `json:"projectPublicId"`
+	Vulns           []string `json:"vulns"`
+}
+
+func ConvertFixPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUE_TABLE)
+	db := taskCtx.GetDal()
+	project, err := loadProject(db, data)
+	if err != nil {
+		return err
+	}
+	// the pull requests are the ones of the repo the project was imported from, collected by the scm plugins
+	repoId, err := findRepoId(db, project)
+	if err != nil || repoId == "" {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&code.PullRequest{}),
+		dal.Where("base_repo_id = ? AND head_ref LIKE ?", repoId, "snyk-%"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.SnykIssue{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(code.PullRequest{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pullRequest := inputRow.(*code.PullRequest)
+			match := fixMetadataPattern.FindStringSubmatch(pullRequest.Description)
+			if match == nil {
+				return nil, nil
+			}
+			metadata := &snykFixMetadata{}
+			if json.Unmarshal([]byte(match[1]), metadata) != nil || metadata.ProjectPublicId != data.Options.ProjectId {
+				return nil, nil
+			}
+			// the vulnerabilities fixed by a merged pull request are no longer reported, they are linked all the same
+			results := make([]interface{}, 0, len(metadata.Vulns))
+			for _, vuln := range metadata.Vulns {
+				results = append(results, &security.SecurityVulnerabilityPullRequest{
+					VulnerabilityId: issueIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId, vuln),
+					PullRequestId:   pullRequest.Id,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
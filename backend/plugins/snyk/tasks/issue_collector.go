@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_ISSUE_TABLE = "snyk_api_issues"
+
+var CollectApiIssuesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiIssues",
+	EntryPoint:       CollectApiIssues,
+	EnabledByDefault: true,
+	Description:      "Collect the aggregated issues of the project from Snyk api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+}
+
+func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUE_TABLE)
+	// the api returns all the issues of the latest snapshot of the project at once, the ones fixed since are gone
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Method:             http.MethodPost,
+		UrlTemplate:        fmt.Sprintf("org/%s/project/{{ .Params.ProjectId }}/aggregated-issues", data.Options.OrgId),
+		RequestBody: func(reqData *api.RequestData) map[string]interface{} {
+			return map[string]interface{}{
+				"includeDescription":       true,
+				"includeIntroducedThrough": false,
+			}
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			body := &struct {
+				Issues []json.RawMessage `json:"issues"`
+			}{}
+			err := api.UnmarshalResponse(res, body)
+			return body.Issues, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
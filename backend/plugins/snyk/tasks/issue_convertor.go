@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/snyk/models"
+)
+
+var ConvertIssuesMeta = plugin.SubTaskMeta{
+	Name:             "convertIssues",
+	EntryPoint:       ConvertIssues,
+	EnabledByDefault: true,
+	Description:      "Convert snyk issues of type vuln into domain layer table security_vulnerabilities",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+}
+
+var issueSeverities = map[string]string{
+	"critical": security.SEVERITY_CRITICAL,
+	"high":     security.SEVERITY_HIGH,
+	"medium":   security.SEVERITY_MEDIUM,
+	"low":      security.SEVERITY_LOW,
+}
+
+// the types of the projects scanning the packages of an image, the other ones scan the manifests of a repository
+var containerProjectTypes = map[string]bool{
+	"apk":        true,
+	"deb":        true,
+	"rpm":        true,
+	"linux":      true,
+	"dockerfile": true,
+}
+
+func ConvertIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUE_TABLE)
+	db := taskCtx.GetDal()
+	project, err := loadProject(db, data)
+	if err != nil {
+		return err
+	}
+	repoId, err := findRepoId(db, project)
+	if err != nil {
+		return err
+	}
+	scanType := security.SCAN_TYPE_DEPENDENCY
+	if containerProjectTypes[project.Type] {
+		scanType = security.SCAN_TYPE_CONTAINER
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.SnykIssue{}),
+		dal.Where("connection_id = ? AND project_id = ? AND issue_type = ?", data.Options.ConnectionId, data.Options.ProjectId, models.ISSUE_TYPE_VULN),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.SnykIssue{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.SnykProject{})
+	scopeId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.SnykIssue{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			issue := inputRow.(*models.SnykIssue)
+			vulnerability := &security.SecurityVulnerability{
+				DomainEntity:     domainlayer.DomainEntity{Id: issueIdGen.Generate(issue.ConnectionId, issue.ProjectId, issue.IssueId)},
+				SecurityScopeId:  scopeId,
+				Tool:             "snyk",
+				ScanType:         scanType,
+				Title:            issue.Title,
+				Description:      issue.Description,
+				Url:              issue.Url,
+				Severity:         issueSeverities[issue.Severity],
+				Cwe:              issue.Cwe,
+				Cve:              issue.Cve,
+				RepoId:           repoId,
+				Component:        issue.PkgName,
+				ComponentVersion: issue.PkgVersions,
+				FixedVersion:     issue.FixedIn,
+				Status:           security.STATUS_OPEN,
+				OriginalStatus:   "open",
+				CreatedDate:      issue.IntroducedDate,
+				UpdatedDate:      project.LastTestedDate,
+			}
+			// a patched issue is fixed without upgrading the package, the fixed issues are no longer returned
+			if issue.IsPatched {
+				vulnerability.Status = security.STATUS_FIXED
+				vulnerability.OriginalStatus = "patched"
+			} else if issue.IsIgnored {
+				vulnerability.Status = security.STATUS_DISMISSED
+				vulnerability.OriginalStatus = "ignored"
+			}
+			return []interface{}{vulnerability}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
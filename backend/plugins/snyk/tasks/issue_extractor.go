@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/snyk/models"
+)
+
+var ExtractApiIssuesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiIssues",
+	EntryPoint:       ExtractApiIssues,
+	EnabledByDefault: true,
+	Description:      "Extract raw issues data into tool layer table _tool_snyk_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+}
+
+type SnykApiIssue struct {
+	Id          string   `json:"id"`
+	IssueType   string   `json:"issueType"`
+	PkgName     string   `json:"pkgName"`
+	PkgVersions []string `json:"pkgVersions"`
+	IssueData   struct {
+		Title       string `json:"title"`
+		Severity    string `json:"severity"`
+		Url         string `json:"url"`
+		Description string `json:"description"`
+		Identifiers struct {
+			CVE []string `json:"CVE"`
+			CWE []string `json:"CWE"`
+		} `json:"identifiers"`
+		ExploitMaturity string     `json:"exploitMaturity"`
+		CvssScore       float64    `json:"cvssScore"`
+		PublicationTime *time.Time `json:"publicationTime"`
+	} `json:"issueData"`
+	IntroducedDate *time.Time `json:"introducedDate"`
+	IsPatched      bool       `json:"isPatched"`
+	IsIgnored      bool       `json:"isIgnored"`
+	FixInfo        struct {
+		IsUpgradable          bool     `json:"isUpgradable"`
+		IsPinnable            bool     `json:"isPinnable"`
+		IsPatchable           bool     `json:"isPatchable"`
+		NearestFixedInVersion string   `json:"nearestFixedInVersion"`
+		FixedIn               []string `json:"fixedIn"`
+	} `json:"fixInfo"`
+	Priority struct {
+		Score int `json:"score"`
+	} `json:"priority"`
+}
+
+func ExtractApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUE_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiIssue := &SnykApiIssue{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiIssue))
+			if err != nil {
+				return nil, err
+			}
+			issue := &models.SnykIssue{
+				ConnectionId:    data.Options.ConnectionId,
+				ProjectId:       data.Options.ProjectId,
+				IssueId:         apiIssue.Id,
+				IssueType:       apiIssue.IssueType,
+				Title:           apiIssue.IssueData.Title,
+				Description:     apiIssue.IssueData.Description,
+				Url:             apiIssue.IssueData.Url,
+				Severity:        apiIssue.IssueData.Severity,
+				Cve:             strings.Join(apiIssue.IssueData.Identifiers.CVE, ","),
+				Cwe:             strings.Join(apiIssue.IssueData.Identifiers.CWE, ","),
+				CvssScore:       apiIssue.IssueData.CvssScore,
+				ExploitMaturity: apiIssue.IssueData.ExploitMaturity,
+				PriorityScore:   apiIssue.Priority.Score,
+				PkgName:         apiIssue.PkgName,
+				PkgVersions:     strings.Join(apiIssue.PkgVersions, ","),
+				FixedIn:         apiIssue.FixInfo.NearestFixedInVersion,
+				IsUpgradable:    apiIssue.FixInfo.IsUpgradable,
+				IsPatchable:     apiIssue.FixInfo.IsPatchable,
+				IsPinnable:      apiIssue.FixInfo.IsPinnable,
+				IsIgnored:       apiIssue.IsIgnored,
+				IsPatched:       apiIssue.IsPatched,
+				IntroducedDate:  apiIssue.IntroducedDate,
+				PublicationTime: apiIssue.IssueData.PublicationTime,
+			}
+			// the nearest fixed version is not always given, all the versions fixing the issue are kept then
+			if issue.FixedIn == "" {
+				issue.FixedIn = strings.Join(apiIssue.FixInfo.FixedIn, ",")
+			}
+			return []interface{}{issue}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
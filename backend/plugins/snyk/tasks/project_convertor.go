@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/snyk/models"
+)
+
+var ConvertProjectMeta = plugin.SubTaskMeta{
+	Name:             "convertProject",
+	EntryPoint:       ConvertProject,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table snyk_projects into domain layer table security_scopes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+}
+
+// the hosts of the scm integrations, the projects imported by them are named after the repository
+var originHosts = map[string]string{
+	"github":          "https://github.com/",
+	"gitlab":          "https://gitlab.com/",
+	"bitbucket-cloud": "https://bitbucket.org/",
+}
+
+// GetApiProject fetches a project of the org by its id
+func GetApiProject(op *SnykOptions, apiClient aha.ApiClientAbstract) (*models.SnykApiProject, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("org/%s/project/%s", op.OrgId, op.ProjectId), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting project detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	body := &models.SnykApiProject{}
+	err = api.UnmarshalResponse(res, body)
+	if err != nil {
+		return nil, err
+	}
+	body.OrgId = op.OrgId
+	return body, nil
+}
+
+// projectRepoUrl returns the url of the repository the project was imported from, the remote url is given by the
+// integrations and the cli run in a git checkout, otherwise it is derived from the name of the project,
+// i.e. example/checkout:package.json imported by the github integration
+func projectRepoUrl(project *models.SnykProject) string {
+	repoUrl := project.RemoteRepoUrl
+	if repoUrl == "" {
+		if originHosts[project.Origin] == "" {
+			return ""
+		}
+		repoUrl = strings.SplitN(project.Name, ":", 2)[0]
+	}
+	if strings.HasPrefix(repoUrl, "git@") {
+		repoUrl = "https://" + strings.Replace(strings.TrimPrefix(repoUrl, "git@"), ":", "/", 1)
+	} else if !strings.Contains(repoUrl, "://") {
+		if originHosts[project.Origin] == "" {
+			return ""
+		}
+		repoUrl = originHosts[project.Origin] + repoUrl
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(repoUrl, "/"), ".git")
+}
+
+// findRepoId looks up the repo the project was imported from among the ones collected by the scm plugins
+func findRepoId(db dal.Dal, project *models.SnykProject) (string, errors.Error) {
+	repoUrl := projectRepoUrl(project)
+	if repoUrl == "" {
+		return "", nil
+	}
+	repo := &code.Repo{}
+	err := db.First(repo, dal.Where("url = ?", repoUrl))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return repo.Id, nil
+}
+
+func loadProject(db dal.Dal, data *SnykTaskData) (*models.SnykProject, errors.Error) {
+	project := &models.SnykProject{}
+	err := db.First(project, dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func ConvertProject(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUE_TABLE)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.SnykProject{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&models.SnykProject{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.SnykProject{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			project := inputRow.(*models.SnykProject)
+			repoId, err := findRepoId(db, project)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&security.SecurityScope{
+					DomainEntity: domainlayer.DomainEntity{Id: projectIdGen.Generate(project.ConnectionId, project.ProjectId)},
+					Name:         project.Name,
+					Tool:         "snyk",
+					Url:          project.BrowseUrl,
+					RepoId:       repoId,
+					CreatedDate:  project.Created,
+					UpdatedDate:  project.LastTestedDate,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
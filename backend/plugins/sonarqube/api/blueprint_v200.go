@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
@@ -101,6 +102,16 @@ func makeScopesV200(bpScopes []*plugin.BlueprintScopeV200, connectionId uint64)
 			}
 			scopes = append(scopes, stProject)
 		}
+		if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_SECURITY) {
+			securityScope := &security.SecurityScope{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: didgen.NewDomainIdGenerator(&models.SonarqubeProject{}).Generate(sonarqubeProject.ConnectionId, sonarqubeProject.ProjectKey),
+				},
+				Name: sonarqubeProject.Name,
+				Tool: "sonarqube",
+			}
+			scopes = append(scopes, securityScope)
+		}
 	}
 	return scopes, nil
 }
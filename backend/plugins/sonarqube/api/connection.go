@@ -137,7 +137,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all sonarqube connections
 // @Description Get all sonarqube connections
 // @Tags plugins/sonarqube
-// @Success 200  {object} []models.SonarqubeConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/sonarqube/connections [GET]
@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/impl"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/tasks"
+)
+
+func TestSonarqubeAnalysisDataFlow(t *testing.T) {
+
+	var sonarqube impl.Sonarqube
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "sonarqube", sonarqube)
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_branches.csv",
+		"_raw_sonarqube_api_branches")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_pull_requests.csv",
+		"_raw_sonarqube_api_pull_requests")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_analysis_measures.csv",
+		"_raw_sonarqube_api_analysis_measures")
+
+	// Standard data
+	taskData := &tasks.SonarqubeTaskData{
+		Options: &tasks.SonarqubeOptions{
+			ConnectionId: 1,
+			ProjectKey:   "f5a50c63-2e8f-4107-9014-853f6f467757",
+		},
+	}
+	// Interfered data
+	taskData2 := &tasks.SonarqubeTaskData{
+		Options: &tasks.SonarqubeOptions{
+			ConnectionId: 2,
+			ProjectKey:   "testWarrenEtcd",
+		},
+	}
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SonarqubeBranch{})
+	dataflowTester.FlushTabler(&models.SonarqubePullRequest{})
+	dataflowTester.FlushTabler(&models.SonarqubeAnalysisMeasure{})
+	for _, data := range []*tasks.SonarqubeTaskData{taskData, taskData2} {
+		dataflowTester.Subtask(tasks.ExtractBranchesMeta, data)
+		dataflowTester.Subtask(tasks.ExtractPullRequestsMeta, data)
+		dataflowTester.Subtask(tasks.ExtractAnalysisMeasuresMeta, data)
+	}
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubeBranch{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_branches.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubePullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubeAnalysisMeasure{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_analysis_measures.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify convertor
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.FlushTabler(&codequality.CqAnalysis{})
+	dataflowTester.Subtask(tasks.ConvertBranchesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&codequality.CqAnalysis{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cq_analyses_branches.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&codequality.CqAnalysis{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&codequality.CqAnalysis{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cq_analyses_pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
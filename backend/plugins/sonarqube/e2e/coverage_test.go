@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/impl"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/tasks"
+)
+
+func TestSonarqubeCoverageDataFlow(t *testing.T) {
+
+	var sonarqube impl.Sonarqube
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "sonarqube", sonarqube)
+
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_sonarqube_coverage_projects.csv",
+		&models.SonarqubeProject{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_sonarqube_coverage_filemetrics.csv",
+		&models.SonarqubeWholeFileMetrics{})
+
+	taskData := &tasks.SonarqubeTaskData{
+		Options: &tasks.SonarqubeOptions{
+			ConnectionId: 2,
+			ProjectKey:   "testDevLake",
+		},
+	}
+
+	dataflowTester.FlushTabler(&qa.QaCoverage{})
+	dataflowTester.Subtask(tasks.ConvertCoverageMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&qa.QaCoverage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/qa_coverages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/sonarqube/impl"
 	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
@@ -45,9 +46,14 @@ func TestSonarqubeProjectDataFlow(t *testing.T) {
 	}
 
 	dataflowTester.FlushTabler(&codequality.CqProject{})
+	dataflowTester.FlushTabler(&security.SecurityScope{})
 	dataflowTester.Subtask(tasks.ConvertProjectsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&codequality.CqProject{}, e2ehelper.TableOptions{
 		CSVRelPath:  "./snapshot_tables/projects.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+	dataflowTester.VerifyTableWithOptions(&security.SecurityScope{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/security_scopes.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
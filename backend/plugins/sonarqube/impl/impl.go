@@ -76,6 +76,9 @@ func (p Sonarqube) GetTablesInfo() []dal.Tabler {
 		&models.SonarqubeHotspot{},
 		&models.SonarqubeFileMetrics{},
 		&models.SonarqubeAccount{},
+		&models.SonarqubeBranch{},
+		&models.SonarqubePullRequest{},
+		&models.SonarqubeAnalysisMeasure{},
 	}
 }
 
@@ -91,12 +94,22 @@ func (p Sonarqube) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractFilemetricsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
+		tasks.CollectBranchesMeta,
+		tasks.ExtractBranchesMeta,
+		tasks.CollectPullRequestsMeta,
+		tasks.ExtractPullRequestsMeta,
+		tasks.CollectAnalysisMeasuresMeta,
+		tasks.ExtractAnalysisMeasuresMeta,
 		tasks.ConvertProjectsMeta,
 		tasks.ConvertIssuesMeta,
 		tasks.ConvertIssueCodeBlocksMeta,
 		tasks.ConvertHotspotsMeta,
+		tasks.ConvertVulnerabilitiesMeta,
 		tasks.ConvertFileMetricsMeta,
+		tasks.ConvertCoverageMeta,
 		tasks.ConvertAccountsMeta,
+		tasks.ConvertBranchesMeta,
+		tasks.ConvertPullRequestsMeta,
 	}
 }
 
@@ -25,5 +25,6 @@ func All() []plugin.MigrationScript {
 		new(addInitTables),
 		new(modifyCharacterSet),
 		new(expandProjectKey20230206),
+		new(addBranchesAndPullRequests),
 	}
 }
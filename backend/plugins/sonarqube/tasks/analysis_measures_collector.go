@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+const RAW_ANALYSIS_MEASURES_TABLE = "sonarqube_api_analysis_measures"
+
+// NEW_CODE_METRIC_KEYS are the metrics measured on the code added or changed by a branch or a pull request
+const NEW_CODE_METRIC_KEYS = "new_lines,new_bugs,new_vulnerabilities,new_code_smells,new_security_hotspots,new_coverage,new_duplicated_lines_density"
+
+var _ plugin.SubTaskEntryPoint = CollectAnalysisMeasures
+
+// SonarqubeAnalysisInput is either a branch or a pull request, the other one is left empty
+type SonarqubeAnalysisInput struct {
+	Branch         string
+	PullRequestKey string
+}
+
+func CollectAnalysisMeasures(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	logger.Info("collect analysis measures")
+
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ANALYSIS_MEASURES_TABLE)
+	db := taskCtx.GetDal()
+
+	iterator := helper.NewQueueIterator()
+	var branches []models.SonarqubeBranch
+	err := db.All(&branches, dal.Where("connection_id = ? AND project_key = ? AND analysis_date IS NOT NULL", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	for _, branch := range branches {
+		iterator.Push(&SonarqubeAnalysisInput{Branch: branch.Name})
+	}
+	var pullRequests []models.SonarqubePullRequest
+	err = db.All(&pullRequests, dal.Where("connection_id = ? AND project_key = ? AND analysis_date IS NOT NULL", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	for _, pullRequest := range pullRequests {
+		iterator.Push(&SonarqubeAnalysisInput{PullRequestKey: pullRequest.PullRequestKey})
+	}
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "measures/component",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			input, ok := reqData.Input.(*SonarqubeAnalysisInput)
+			if !ok {
+				return nil, errors.Default.New(fmt.Sprintf("Input to SonarqubeAnalysisInput failed:%+v", reqData.Input))
+			}
+			query := url.Values{}
+			query.Set("component", data.Options.ProjectKey)
+			query.Set("metricKeys", NEW_CODE_METRIC_KEYS)
+			if input.PullRequestKey != "" {
+				query.Set("pullRequest", input.PullRequestKey)
+			} else {
+				query.Set("branch", input.Branch)
+			}
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var resData struct {
+				Data json.RawMessage `json:"component"`
+			}
+			err := helper.UnmarshalResponse(res, &resData)
+			return []json.RawMessage{resData.Data}, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectAnalysisMeasuresMeta = plugin.SubTaskMeta{
+	Name:             "CollectAnalysisMeasures",
+	EntryPoint:       CollectAnalysisMeasures,
+	EnabledByDefault: true,
+	Description:      "Collect the new code measures of the branches and pull requests from Sonarqube api, must run after they are extracted",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
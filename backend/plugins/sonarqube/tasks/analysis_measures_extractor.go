@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractAnalysisMeasures
+
+// newCodeMeasure is a measure of a new code metric, older servers report its value as the one of the leak period
+type newCodeMeasure struct {
+	Metric string `json:"metric"`
+	Value  string `json:"value"`
+	Period struct {
+		Value string `json:"value"`
+	} `json:"period"`
+}
+
+func ExtractAnalysisMeasures(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ANALYSIS_MEASURES_TABLE)
+
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(resData *helper.RawData) ([]interface{}, errors.Error) {
+			input := &SonarqubeAnalysisInput{}
+			err := errors.Convert(json.Unmarshal(resData.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			var res struct {
+				Measures []newCodeMeasure `json:"measures"`
+			}
+			err = errors.Convert(json.Unmarshal(resData.Data, &res))
+			if err != nil {
+				return nil, err
+			}
+			body := &models.SonarqubeAnalysisMeasure{
+				ConnectionId:   data.Options.ConnectionId,
+				ProjectKey:     data.Options.ProjectKey,
+				Branch:         input.Branch,
+				PullRequestKey: input.PullRequestKey,
+			}
+			err = setNewCodeMetrics(body, res.Measures)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{body}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+func setNewCodeMetrics(measure *models.SonarqubeAnalysisMeasure, measures []newCodeMeasure) errors.Error {
+	var err errors.Error
+	for _, v := range measures {
+		value := v.Value
+		if value == "" {
+			value = v.Period.Value
+		}
+		if value == "" {
+			continue
+		}
+		switch v.Metric {
+		case "new_lines":
+			measure.NewLines, err = errors.Convert01(strconv.Atoi(value))
+		case "new_bugs":
+			measure.NewBugs, err = errors.Convert01(strconv.Atoi(value))
+		case "new_vulnerabilities":
+			measure.NewVulnerabilities, err = errors.Convert01(strconv.Atoi(value))
+		case "new_code_smells":
+			measure.NewCodeSmells, err = errors.Convert01(strconv.Atoi(value))
+		case "new_security_hotspots":
+			measure.NewSecurityHotspots, err = errors.Convert01(strconv.Atoi(value))
+		case "new_coverage":
+			measure.NewCoverage, err = errors.Convert01(strconv.ParseFloat(value, 64))
+		case "new_duplicated_lines_density":
+			measure.NewDuplicatedLinesDensity, err = errors.Convert01(strconv.ParseFloat(value, 64))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var ExtractAnalysisMeasuresMeta = plugin.SubTaskMeta{
+	Name:             "ExtractAnalysisMeasures",
+	EntryPoint:       ExtractAnalysisMeasures,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table sonarqube_analysis_measures",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
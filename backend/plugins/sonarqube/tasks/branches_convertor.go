@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	sonarqubeModels "github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var ConvertBranchesMeta = plugin.SubTaskMeta{
+	Name:             "convertBranches",
+	EntryPoint:       ConvertBranches,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table sonarqube_branches and their measures into domain layer table cq_analyses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+type sonarqubeBranchWithMeasures struct {
+	sonarqubeModels.SonarqubeBranch
+	NewLines                  int
+	NewBugs                   int
+	NewVulnerabilities        int
+	NewCodeSmells             int
+	NewSecurityHotspots       int
+	NewCoverage               float64
+	NewDuplicatedLinesDensity float64
+}
+
+func ConvertBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCHES_TABLE)
+	cursor, err := db.Cursor(
+		dal.Select("b.*, m.new_lines, m.new_bugs, m.new_vulnerabilities, m.new_code_smells, m.new_security_hotspots, m.new_coverage, m.new_duplicated_lines_density"),
+		dal.From("_tool_sonarqube_branches b"),
+		dal.Join(`LEFT JOIN _tool_sonarqube_analysis_measures m ON (m.connection_id = b.connection_id AND m.project_key = b.project_key
+			AND m.branch = b.name AND m.pull_request_key = '')`),
+		dal.Where("b.connection_id = ? AND b.project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeProject{})
+	branchIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeBranch{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(sonarqubeBranchWithMeasures{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			branch := inputRow.(*sonarqubeBranchWithMeasures)
+			domainAnalysis := &codequality.CqAnalysis{
+				DomainEntity:              domainlayer.DomainEntity{Id: branchIdGen.Generate(data.Options.ConnectionId, branch.ProjectKey, branch.Name)},
+				CqProjectId:               projectIdGen.Generate(data.Options.ConnectionId, branch.ProjectKey),
+				Type:                      codequality.ANALYSIS_BRANCH,
+				Name:                      branch.Name,
+				Branch:                    branch.Name,
+				IsMain:                    branch.IsMain,
+				QualityGateStatus:         branch.QualityGateStatus,
+				CommitSha:                 branch.CommitSha,
+				AnalysisDate:              branch.AnalysisDate.ToNullableTime(),
+				NewLines:                  branch.NewLines,
+				NewBugs:                   branch.NewBugs,
+				NewVulnerabilities:        branch.NewVulnerabilities,
+				NewCodeSmells:             branch.NewCodeSmells,
+				NewSecurityHotspots:       branch.NewSecurityHotspots,
+				NewCoverage:               branch.NewCoverage,
+				NewDuplicatedLinesDensity: branch.NewDuplicatedLinesDensity,
+			}
+			return []interface{}{
+				domainAnalysis,
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	sonarqubeModels "github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var ConvertCoverageMeta = plugin.SubTaskMeta{
+	Name:             "convertCoverage",
+	EntryPoint:       ConvertCoverage,
+	EnabledByDefault: true,
+	Description:      "Sum up the line coverage of sonarqube file metrics into domain layer table qa_coverages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_QA},
+}
+
+type projectCoverage struct {
+	LinesToCover   int
+	UncoveredLines int
+}
+
+func ConvertCoverage(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROJECTS_TABLE)
+	cursor, err := db.Cursor(dal.From(sonarqubeModels.SonarqubeProject{}),
+		dal.Where("connection_id = ? and project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeProject{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(sonarqubeModels.SonarqubeProject{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			sonarqubeProject := inputRow.(*sonarqubeModels.SonarqubeProject)
+			sums := &projectCoverage{}
+			err := db.First(sums,
+				dal.Select("COALESCE(SUM(lines_to_cover), 0) AS lines_to_cover, COALESCE(SUM(uncovered_lines), 0) AS uncovered_lines"),
+				dal.From(sonarqubeModels.SonarqubeWholeFileMetrics{}),
+				dal.Where("connection_id = ? and project_key = ?", sonarqubeProject.ConnectionId, sonarqubeProject.ProjectKey),
+			)
+			if err != nil {
+				return nil, err
+			}
+			coverage := &qa.QaCoverage{
+				DomainEntity: domainlayer.DomainEntity{Id: projectIdGen.Generate(sonarqubeProject.ConnectionId, sonarqubeProject.ProjectKey)},
+				Tool:         "sonarqube",
+				CommitSha:    sonarqubeProject.Revision,
+				LinesTotal:   sums.LinesToCover,
+				LinesCovered: sums.LinesToCover - sums.UncoveredLines,
+				CreatedDate:  sonarqubeProject.LastAnalysisDate.ToNullableTime(),
+			}
+			if coverage.LinesTotal > 0 {
+				coverage.LineCoverage = float64(coverage.LinesCovered) * 100 / float64(coverage.LinesTotal)
+			}
+			return []interface{}{
+				coverage,
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	sonarqubeModels "github.com/apache/incubator-devlake/plugins/sonarqube/models"
@@ -37,7 +38,7 @@ var ConvertProjectsMeta = plugin.SubTaskMeta{
 	EntryPoint:       ConvertProjects,
 	EnabledByDefault: true,
 	Description:      "Convert tool layer table sonarqube_projects into  domain layer table projects",
-	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY, plugin.DOMAIN_TYPE_SECURITY},
 }
 
 func ConvertProjects(taskCtx plugin.SubTaskContext) errors.Error {
@@ -65,8 +66,16 @@ func ConvertProjects(taskCtx plugin.SubTaskContext) errors.Error {
 				LastAnalysisDate: sonarqubeProject.LastAnalysisDate,
 				CommitSha:        sonarqubeProject.Revision,
 			}
+			// the project is also the scope of the vulnerabilities found by the analysis
+			securityScope := &security.SecurityScope{
+				DomainEntity: domainlayer.DomainEntity{Id: domainProject.Id},
+				Name:         sonarqubeProject.Name,
+				Tool:         "sonarqube",
+				UpdatedDate:  sonarqubeProject.LastAnalysisDate.ToNullableTime(),
+			}
 			return []interface{}{
 				domainProject,
+				securityScope,
 			}, nil
 		},
 	})
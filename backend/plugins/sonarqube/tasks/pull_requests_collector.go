@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PULL_REQUESTS_TABLE = "sonarqube_api_pull_requests"
+
+var _ plugin.SubTaskEntryPoint = CollectPullRequests
+
+func CollectPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	logger.Info("collect pull requests")
+
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUESTS_TABLE)
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		UrlTemplate:        "project_pull_requests/list",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			// the pull requests are not paginated
+			query.Set("project", data.Options.ProjectKey)
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var resData struct {
+				Data []json.RawMessage `json:"pullRequests"`
+			}
+			err := helper.UnmarshalResponse(res, &resData)
+			return resData.Data, err
+		},
+		AfterResponse: func(res *http.Response) errors.Error {
+			// the community edition analyzes no pull request and rejects the request
+			if res.StatusCode == http.StatusBadRequest || res.StatusCode == http.StatusNotFound {
+				return helper.ErrIgnoreAndContinue
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "CollectPullRequests",
+	EntryPoint:       CollectPullRequests,
+	EnabledByDefault: true,
+	Description:      "Collect PullRequests data from Sonarqube api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	sonarqubeModels "github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var ConvertPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "convertPullRequests",
+	EntryPoint:       ConvertPullRequests,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table sonarqube_pull_requests and their measures into domain layer table cq_analyses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+type sonarqubePullRequestWithMeasures struct {
+	sonarqubeModels.SonarqubePullRequest
+	NewLines                  int
+	NewBugs                   int
+	NewVulnerabilities        int
+	NewCodeSmells             int
+	NewSecurityHotspots       int
+	NewCoverage               float64
+	NewDuplicatedLinesDensity float64
+	DomainPullRequestId       string
+}
+
+func ConvertPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUESTS_TABLE)
+	// the analyses are linked by their urls to the pull requests collected from the code hosting platform,
+	// the ones collected later on get linked by the next run
+	cursor, err := db.Cursor(
+		dal.Select("p.*, m.new_lines, m.new_bugs, m.new_vulnerabilities, m.new_code_smells, m.new_security_hotspots, m.new_coverage, m.new_duplicated_lines_density, pr.id AS domain_pull_request_id"),
+		dal.From("_tool_sonarqube_pull_requests p"),
+		dal.Join(`LEFT JOIN _tool_sonarqube_analysis_measures m ON (m.connection_id = p.connection_id AND m.project_key = p.project_key
+			AND m.branch = '' AND m.pull_request_key = p.pull_request_key)`),
+		dal.Join("LEFT JOIN pull_requests pr ON (p.url != '' AND pr.url = p.url)"),
+		dal.Where("p.connection_id = ? AND p.project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeProject{})
+	pullRequestIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubePullRequest{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(sonarqubePullRequestWithMeasures{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pullRequest := inputRow.(*sonarqubePullRequestWithMeasures)
+			domainAnalysis := &codequality.CqAnalysis{
+				DomainEntity:              domainlayer.DomainEntity{Id: pullRequestIdGen.Generate(data.Options.ConnectionId, pullRequest.ProjectKey, pullRequest.PullRequestKey)},
+				CqProjectId:               projectIdGen.Generate(data.Options.ConnectionId, pullRequest.ProjectKey),
+				Type:                      codequality.ANALYSIS_PULL_REQUEST,
+				Name:                      pullRequest.PullRequestKey,
+				Title:                     pullRequest.Title,
+				Branch:                    pullRequest.Branch,
+				BaseBranch:                pullRequest.Base,
+				PullRequestId:             pullRequest.DomainPullRequestId,
+				Url:                       pullRequest.Url,
+				QualityGateStatus:         pullRequest.QualityGateStatus,
+				CommitSha:                 pullRequest.CommitSha,
+				AnalysisDate:              pullRequest.AnalysisDate.ToNullableTime(),
+				NewLines:                  pullRequest.NewLines,
+				NewBugs:                   pullRequest.NewBugs,
+				NewVulnerabilities:        pullRequest.NewVulnerabilities,
+				NewCodeSmells:             pullRequest.NewCodeSmells,
+				NewSecurityHotspots:       pullRequest.NewSecurityHotspots,
+				NewCoverage:               pullRequest.NewCoverage,
+				NewDuplicatedLinesDensity: pullRequest.NewDuplicatedLinesDensity,
+			}
+			return []interface{}{
+				domainAnalysis,
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	sonarqubeModels "github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var ConvertVulnerabilitiesMeta = plugin.SubTaskMeta{
+	Name:             "convertVulnerabilities",
+	EntryPoint:       ConvertVulnerabilities,
+	EnabledByDefault: true,
+	Description:      "Convert sonarqube issues of type VULNERABILITY into domain layer table security_vulnerabilities",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+}
+
+// maps the sonarqube severities onto the standard ones, sonarqube's BLOCKER is the most severe level
+var vulnerabilitySeverities = map[string]string{
+	"BLOCKER":  security.SEVERITY_CRITICAL,
+	"CRITICAL": security.SEVERITY_HIGH,
+	"MAJOR":    security.SEVERITY_MEDIUM,
+	"MINOR":    security.SEVERITY_LOW,
+	"INFO":     security.SEVERITY_INFO,
+}
+
+func ConvertVulnerabilities(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUES_TABLE)
+	cursor, err := db.Cursor(dal.From(sonarqubeModels.SonarqubeIssue{}),
+		dal.Where("connection_id = ? and project_key = ? and type = ?", data.Options.ConnectionId, data.Options.ProjectKey, "VULNERABILITY"))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeIssue{})
+	projectIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeProject{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(sonarqubeModels.SonarqubeIssue{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			sonarqubeIssue := inputRow.(*sonarqubeModels.SonarqubeIssue)
+			vulnerability := &security.SecurityVulnerability{
+				DomainEntity:    domainlayer.DomainEntity{Id: issueIdGen.Generate(data.Options.ConnectionId, sonarqubeIssue.IssueKey)},
+				SecurityScopeId: projectIdGen.Generate(data.Options.ConnectionId, sonarqubeIssue.ProjectKey),
+				Tool:            "sonarqube",
+				ScanType:        security.SCAN_TYPE_SAST,
+				Title:           sonarqubeIssue.Rule,
+				Description:     sonarqubeIssue.Message,
+				Severity:        vulnerabilitySeverities[sonarqubeIssue.Severity],
+				Component:       sonarqubeIssue.Component,
+				Line:            sonarqubeIssue.Line,
+				Status:          getVulnerabilityStatus(sonarqubeIssue.Status),
+				OriginalStatus:  sonarqubeIssue.Status,
+				CreatedDate:     sonarqubeIssue.CreationDate.ToNullableTime(),
+				UpdatedDate:     sonarqubeIssue.UpdateDate.ToNullableTime(),
+			}
+			if vulnerability.Status == security.STATUS_FIXED {
+				vulnerability.ResolvedDate = vulnerability.UpdatedDate
+			}
+			return []interface{}{
+				vulnerability,
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getVulnerabilityStatus maps the sonarqube issue statuses, resolved and closed issues no longer show up in the analysis
+func getVulnerabilityStatus(status string) string {
+	switch status {
+	case "RESOLVED", "CLOSED":
+		return security.STATUS_FIXED
+	default:
+		return security.STATUS_OPEN
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"strings"
+
+	context2 "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/spinnaker/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users, the applications are not grouped
+// @Tags plugins/spinnaker
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/spinnaker/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.GetScopesFromRemote(input,
+		nil,
+		func(basicRes context2.BasicRes, gid string, queryData *api.RemoteQueryData, connection models.SpinnakerConnection) ([]models.SpinnakerApiApplication, errors.Error) {
+			if gid != "" {
+				return nil, nil
+			}
+			return listApplications(basicRes, &connection, queryData, "")
+		},
+	)
+}
+
+// SearchRemoteScopes filters the applications by name
+// @Summary filters the applications by name
+// @Description filters the applications by name
+// @Tags plugins/spinnaker
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/spinnaker/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return remoteHelper.SearchRemoteScopes(input,
+		func(basicRes context2.BasicRes, queryData *api.RemoteQueryData, connection models.SpinnakerConnection) ([]models.SpinnakerApiApplication, errors.Error) {
+			return listApplications(basicRes, &connection, queryData, queryData.Search[0])
+		},
+	)
+}
+
+// listApplications returns all the applications on the first page since the api does not page them, they are
+// filtered by name when a search is given
+func listApplications(basicRes context2.BasicRes, connection *models.SpinnakerConnection, queryData *api.RemoteQueryData, search string) ([]models.SpinnakerApiApplication, errors.Error) {
+	if queryData.Page > 1 {
+		return nil, nil
+	}
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
+	}
+	res, err := apiClient.Get("applications", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody []models.SpinnakerApiApplication
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+	if search == "" {
+		return resBody, nil
+	}
+	applications := make([]models.SpinnakerApiApplication, 0)
+	for _, application := range resBody {
+		if strings.Contains(application.Name, search) {
+			applications = append(applications, application)
+		}
+	}
+	return applications, nil
+}
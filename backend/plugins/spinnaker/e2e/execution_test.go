@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/spinnaker/impl"
+	"github.com/apache/incubator-devlake/plugins/spinnaker/models"
+	"github.com/apache/incubator-devlake/plugins/spinnaker/tasks"
+)
+
+func TestSpinnakerExecutionDataFlow(t *testing.T) {
+
+	var spinnaker impl.Spinnaker
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "spinnaker", spinnaker)
+
+	regexEnricher := helper.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.DEPLOYMENT, "(?i)deploy|createServerGroup")
+	_ = regexEnricher.TryAdd(devops.PRODUCTION, "prod")
+	taskData := &tasks.SpinnakerTaskData{
+		Options: &tasks.SpinnakerOptions{
+			ConnectionId:    1,
+			ApplicationName: "checkout",
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_spinnaker_applications.csv", &models.SpinnakerApplication{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_spinnaker_api_executions.csv", "_raw_spinnaker_api_executions")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SpinnakerExecution{})
+	dataflowTester.FlushTabler(&models.SpinnakerStage{})
+	dataflowTester.Subtask(tasks.ExtractApiExecutionsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.SpinnakerExecution{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_spinnaker_executions.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.SpinnakerStage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_spinnaker_stages.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdScope{})
+	dataflowTester.Subtask(tasks.ConvertApplicationMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CicdScope{},
+		"./snapshot_tables/cicd_scopes.csv",
+		[]string{
+			"id",
+			"name",
+			"description",
+			"url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertExecutionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines.csv",
+		[]string{
+			"id",
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits.csv",
+		[]string{
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertStagesMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks.csv",
+		[]string{
+			"id",
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		},
+	)
+}
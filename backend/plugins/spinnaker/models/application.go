@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*SpinnakerApplication)(nil)
+var _ plugin.ApiGroup = (*GroupResponse)(nil)
+var _ plugin.ApiScope = (*SpinnakerApiApplication)(nil)
+
+// SpinnakerApplication is an application of Spinnaker, the executions of its pipelines are collected
+type SpinnakerApplication struct {
+	ConnectionId         uint64 `json:"connectionId" gorm:"primaryKey" validate:"required" mapstructure:"connectionId,omitempty"`
+	Name                 string `json:"name" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"name"`
+	Email                string `json:"email" gorm:"type:varchar(255)" mapstructure:"email,omitempty"`
+	Description          string `json:"description" mapstructure:"description,omitempty"`
+	CloudProviders       string `json:"cloudProviders" gorm:"type:varchar(255)" mapstructure:"cloudProviders,omitempty"`
+	TransformationRuleId uint64 `json:"transformationRuleId,omitempty" mapstructure:"transformationRuleId,omitempty"`
+	common.NoPKModel     `json:"-" mapstructure:"-"`
+}
+
+func (SpinnakerApplication) TableName() string {
+	return "_tool_spinnaker_applications"
+}
+
+func (a SpinnakerApplication) ScopeId() string {
+	return a.Name
+}
+
+func (a SpinnakerApplication) ScopeName() string {
+	return a.Name
+}
+
+// SpinnakerApiApplication is an application returned by the api, the detail of an application nests these fields
+// in its attributes
+type SpinnakerApiApplication struct {
+	Name           string `json:"name"`
+	Email          string `json:"email"`
+	Description    string `json:"description"`
+	CloudProviders string `json:"cloudProviders"`
+}
+
+func (a SpinnakerApiApplication) ConvertApiScope() plugin.ToolLayerScope {
+	return &SpinnakerApplication{
+		Name:           a.Name,
+		Email:          a.Email,
+		Description:    a.Description,
+		CloudProviders: a.CloudProviders,
+	}
+}
+
+// GroupResponse is required by the remote api helper, the applications are not grouped
+type GroupResponse struct {
+	Id   string
+	Name string
+}
+
+func (p GroupResponse) GroupId() string {
+	return p.Id
+}
+
+func (p GroupResponse) GroupName() string {
+	return p.Name
+}
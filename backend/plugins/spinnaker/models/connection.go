@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.ApiConnection = (*SpinnakerConnection)(nil)
+
+// SpinnakerConn holds the essential information to connect to the Gate api of Spinnaker,
+// the endpoint is the url of Gate, i.e. https://gate.spinnaker.example.com/
+type SpinnakerConn struct {
+	api.RestConnection `mapstructure:",squash"`
+	api.BasicAuth      `mapstructure:",squash"`
+}
+
+// SpinnakerConnection holds SpinnakerConn plus ID/Name for database storage
+type SpinnakerConnection struct {
+	api.BaseConnection `mapstructure:",squash"`
+	SpinnakerConn      `mapstructure:",squash"`
+}
+
+func (SpinnakerConnection) TableName() string {
+	return "_tool_spinnaker_connections"
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type SpinnakerExecution struct {
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	ExecutionId      string `gorm:"primaryKey;type:varchar(100)"`
+	ApplicationName  string `gorm:"index;type:varchar(255)"`
+	PipelineConfigId string `gorm:"type:varchar(100)"`
+	Name             string `gorm:"type:varchar(255)"`
+	Status           string `gorm:"type:varchar(20)"`
+	TriggerType      string `gorm:"type:varchar(50)"`
+	TriggeredBy      string `gorm:"type:varchar(255)"`
+	CommitSha        string `gorm:"type:varchar(40)"`
+	Branch           string `gorm:"type:varchar(255)"`
+	RepoUrl          string `gorm:"type:varchar(255)"`
+	Type             string `gorm:"type:varchar(100)"`
+	Environment      string `gorm:"type:varchar(255)"`
+	CreatedDate      *time.Time
+	StartedDate      *time.Time
+	FinishedDate     *time.Time
+	archived.NoPKModel
+}
+
+func (SpinnakerExecution) TableName() string {
+	return "_tool_spinnaker_executions"
+}
+
+type SpinnakerStage struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	StageId         string `gorm:"primaryKey;type:varchar(100)"`
+	ExecutionId     string `gorm:"index;type:varchar(100)"`
+	ApplicationName string `gorm:"index;type:varchar(255)"`
+	Name            string `gorm:"type:varchar(255)"`
+	StageType       string `gorm:"type:varchar(100)"`
+	Status          string `gorm:"type:varchar(20)"`
+	Account         string `gorm:"type:varchar(255)"`
+	Type            string `gorm:"type:varchar(100)"`
+	Environment     string `gorm:"type:varchar(255)"`
+	StartedDate     *time.Time
+	FinishedDate    *time.Time
+	archived.NoPKModel
+}
+
+func (SpinnakerStage) TableName() string {
+	return "_tool_spinnaker_stages"
+}
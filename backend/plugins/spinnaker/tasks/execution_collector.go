@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_EXECUTION_TABLE = "spinnaker_api_executions"
+
+var CollectApiExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiExecutions",
+	EntryPoint:       CollectApiExecutions,
+	EnabledByDefault: true,
+	Description:      "Collect the pipeline executions of the application from Spinnaker api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EXECUTION_TABLE)
+	collectorWithState, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	// the api neither pages the executions nor filters them by time, it only returns the last ones of each pipeline,
+	// the executions collected before are kept when collecting incrementally so that they are not lost
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: "applications/{{ .Params.ApplicationName }}/pipelines",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("limit", "100")
+			// the context of the stages is only returned when expanded
+			query.Set("expand", "true")
+			return query, nil
+		},
+		ResponseParser: api.GetRawMessageArrayFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/spinnaker/models"
+)
+
+var ConvertExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "convertExecutions",
+	EntryPoint:       ConvertExecutions,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table spinnaker_executions into domain layer table cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+var executionResultRule = &devops.ResultRule{
+	Success: []string{models.STATUS_SUCCEEDED},
+	Failed:  []string{models.STATUS_TERMINAL, models.STATUS_FAILED_CONTINUE},
+	Abort:   []string{models.STATUS_CANCELED, models.STATUS_STOPPED},
+	Default: "",
+}
+
+var executionStatusRule = &devops.StatusRule{
+	InProgress: []string{
+		models.STATUS_NOT_STARTED, models.STATUS_RUNNING, models.STATUS_PAUSED, models.STATUS_SUSPENDED, models.STATUS_BUFFERED,
+	},
+	Default: devops.DONE,
+}
+
+func ConvertExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EXECUTION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.SpinnakerExecution{}),
+		dal.Where("connection_id = ? AND application_name = ?", data.Options.ConnectionId, data.Options.ApplicationName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	executionIdGen := didgen.NewDomainIdGenerator(&models.SpinnakerExecution{})
+	applicationIdGen := didgen.NewDomainIdGenerator(&models.SpinnakerApplication{})
+	scopeId := applicationIdGen.Generate(data.Options.ConnectionId, data.Options.ApplicationName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.SpinnakerExecution{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			execution := inputRow.(*models.SpinnakerExecution)
+			createdDate := execution.CreatedDate
+			if createdDate == nil {
+				createdDate = execution.StartedDate
+			}
+			if createdDate == nil {
+				return nil, nil
+			}
+			id := executionIdGen.Generate(execution.ConnectionId, execution.ExecutionId)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         execution.Name,
+				Result:       devops.GetResult(executionResultRule, execution.Status),
+				Status:       devops.GetStatus(executionStatusRule, execution.Status),
+				Type:         execution.Type,
+				Environment:  execution.Environment,
+				CreatedDate:  *createdDate,
+				CicdScopeId:  scopeId,
+			}
+			if domainPipeline.Status == devops.DONE && execution.FinishedDate != nil {
+				domainPipeline.FinishedDate = execution.FinishedDate
+				domainPipeline.DurationSec = uint64(execution.FinishedDate.Sub(*createdDate).Seconds())
+			}
+			results := []interface{}{domainPipeline}
+			if execution.CommitSha != "" {
+				results = append(results, &devops.CiCDPipelineCommit{
+					PipelineId: id,
+					CommitSha:  execution.CommitSha,
+					Branch:     execution.Branch,
+					RepoUrl:    execution.RepoUrl,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,159 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/spinnaker/models"
+)
+
+var ExtractApiExecutionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiExecutions",
+	EntryPoint:       ExtractApiExecutions,
+	EnabledByDefault: true,
+	Description:      "Extract raw executions data into tool layer table spinnaker_executions and spinnaker_stages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// gitHosts are the hosts of the sources of the git triggers, the repositories of the other sources are unknown
+var gitHosts = map[string]string{
+	"github":    "https://github.com",
+	"gitlab":    "https://gitlab.com",
+	"bitbucket": "https://bitbucket.org",
+}
+
+type SpinnakerApiStage struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	StartTime *int64 `json:"startTime"`
+	EndTime   *int64 `json:"endTime"`
+	Context   struct {
+		Account     string `json:"account"`
+		Credentials string `json:"credentials"`
+	} `json:"context"`
+}
+
+type SpinnakerApiExecution struct {
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	PipelineConfigId string `json:"pipelineConfigId"`
+	Status           string `json:"status"`
+	BuildTime        *int64 `json:"buildTime"`
+	StartTime        *int64 `json:"startTime"`
+	EndTime          *int64 `json:"endTime"`
+	Trigger          struct {
+		Type    string `json:"type"`
+		User    string `json:"user"`
+		Source  string `json:"source"`
+		Project string `json:"project"`
+		Slug    string `json:"slug"`
+		Branch  string `json:"branch"`
+		Hash    string `json:"hash"`
+	} `json:"trigger"`
+	Stages []SpinnakerApiStage `json:"stages"`
+}
+
+func ExtractApiExecutions(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EXECUTION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiExecution := &SpinnakerApiExecution{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiExecution))
+			if err != nil {
+				return nil, err
+			}
+			execution := &models.SpinnakerExecution{
+				ConnectionId:     data.Options.ConnectionId,
+				ExecutionId:      apiExecution.Id,
+				ApplicationName:  data.Options.ApplicationName,
+				PipelineConfigId: apiExecution.PipelineConfigId,
+				Name:             apiExecution.Name,
+				Status:           apiExecution.Status,
+				TriggerType:      apiExecution.Trigger.Type,
+				TriggeredBy:      apiExecution.Trigger.User,
+				CreatedDate:      unixMilli(apiExecution.BuildTime),
+				StartedDate:      unixMilli(apiExecution.StartTime),
+				FinishedDate:     unixMilli(apiExecution.EndTime),
+			}
+			// the executions started before timeAfter are skipped
+			if data.TimeAfter != nil && execution.CreatedDate != nil && execution.CreatedDate.Before(*data.TimeAfter) {
+				return nil, nil
+			}
+			if apiExecution.Trigger.Type == "git" {
+				execution.CommitSha = apiExecution.Trigger.Hash
+				execution.Branch = apiExecution.Trigger.Branch
+				if host, ok := gitHosts[apiExecution.Trigger.Source]; ok {
+					execution.RepoUrl = fmt.Sprintf("%s/%s/%s", host, apiExecution.Trigger.Project, apiExecution.Trigger.Slug)
+				}
+			}
+			results := make([]interface{}, 0, len(apiExecution.Stages)+1)
+			for _, apiStage := range apiExecution.Stages {
+				stage := &models.SpinnakerStage{
+					ConnectionId:    data.Options.ConnectionId,
+					StageId:         apiStage.Id,
+					ExecutionId:     apiExecution.Id,
+					ApplicationName: data.Options.ApplicationName,
+					Name:            apiStage.Name,
+					StageType:       apiStage.Type,
+					Status:          apiStage.Status,
+					Account:         apiStage.Context.Account,
+					Type:            data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, apiStage.Type),
+					StartedDate:     unixMilli(apiStage.StartTime),
+					FinishedDate:    unixMilli(apiStage.EndTime),
+				}
+				// the server group stages name the account their credentials
+				if stage.Account == "" {
+					stage.Account = apiStage.Context.Credentials
+				}
+				// the execution deploys to production as long as one of its deploy stages does
+				if stage.Type == devops.DEPLOYMENT {
+					stage.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, stage.Account)
+					execution.Type = devops.DEPLOYMENT
+					if stage.Environment == devops.PRODUCTION {
+						execution.Environment = devops.PRODUCTION
+					}
+				}
+				results = append(results, stage)
+			}
+			return append(results, execution), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// unixMilli converts the milliseconds since the epoch returned by the api, they are omitted until known
+func unixMilli(ms *int64) *time.Time {
+	if ms == nil || *ms == 0 {
+		return nil
+	}
+	t := time.UnixMilli(*ms).UTC()
+	return &t
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/spinnaker/models"
+)
+
+var ConvertStagesMeta = plugin.SubTaskMeta{
+	Name:             "convertStages",
+	EntryPoint:       ConvertStages,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table spinnaker_stages into domain layer table cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertStages(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EXECUTION_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.SpinnakerStage{}),
+		dal.Where("connection_id = ? AND application_name = ?", data.Options.ConnectionId, data.Options.ApplicationName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	stageIdGen := didgen.NewDomainIdGenerator(&models.SpinnakerStage{})
+	executionIdGen := didgen.NewDomainIdGenerator(&models.SpinnakerExecution{})
+	applicationIdGen := didgen.NewDomainIdGenerator(&models.SpinnakerApplication{})
+	scopeId := applicationIdGen.Generate(data.Options.ConnectionId, data.Options.ApplicationName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.SpinnakerStage{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			stage := inputRow.(*models.SpinnakerStage)
+			// the stages skipped or not reached yet never started
+			if stage.StartedDate == nil {
+				return nil, nil
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: stageIdGen.Generate(stage.ConnectionId, stage.StageId)},
+				Name:         stage.Name,
+				PipelineId:   executionIdGen.Generate(stage.ConnectionId, stage.ExecutionId),
+				Result:       devops.GetResult(executionResultRule, stage.Status),
+				Status:       devops.GetStatus(executionStatusRule, stage.Status),
+				Type:         stage.Type,
+				Environment:  stage.Environment,
+				StartedDate:  *stage.StartedDate,
+				CicdScopeId:  scopeId,
+			}
+			if domainTask.Status == devops.DONE && stage.FinishedDate != nil {
+				domainTask.FinishedDate = stage.FinishedDate
+				domainTask.DurationSec = uint64(stage.FinishedDate.Sub(*stage.StartedDate).Seconds())
+			}
+			return []interface{}{domainTask}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -33,6 +33,16 @@ const (
 	TimeOut = 10 * time.Second
 )
 
+// Proxy forwards a GET request to the Tapd api with the credentials of the connection
+// @Summary proxy a GET request to the Tapd api
+// @Description Forward a GET request to the Tapd api with the credentials of the connection, the response is passed through as is
+// @Tags plugins/tapd
+// @Param connectionId path int true "connection ID"
+// @Param path path string true "the path of the Tapd api, relative to the endpoint of the connection"
+// @Success 200  {object} map[string]interface{} "the response of the Tapd api"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/tapd/connections/{connectionId}/proxy/rest/{path} [GET]
 func Proxy(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	connection := &models.TapdConnection{}
 	err := connectionHelper.First(connection, input.Params)
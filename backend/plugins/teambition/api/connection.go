@@ -143,7 +143,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // ListConnections @Summary get all teambition connections
 // @Description Get all teambition connections
 // @Tags plugins/teambition
-// @Success 200  {object} []models.TeambitionConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/teambition/connections [GET]
@@ -129,7 +129,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all trello connections
 // @Description Get all trello connections
 // @Tags plugins/trello
-// @Success 200  {object} []models.TrelloConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/trello/connections [GET]
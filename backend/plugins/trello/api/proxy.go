@@ -27,6 +27,16 @@ import (
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
+// Proxy forwards a GET request to the Trello api with the credentials of the connection
+// @Summary proxy a GET request to the Trello api
+// @Description Forward a GET request to the Trello api with the credentials of the connection, the response is passed through as is
+// @Tags plugins/trello
+// @Param connectionId path int true "connection ID"
+// @Param path path string true "the path of the Trello api, relative to the endpoint of the connection"
+// @Success 200  {object} map[string]interface{} "the response of the Trello api"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/trello/connections/{connectionId}/proxy/rest/{path} [GET]
 func Proxy(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	connection := &models.TrelloConnection{}
 	err := connectionHelper.First(connection, input.Params)
@@ -49,3 +59,14 @@ func Proxy(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Er
 	}
 	return &plugin.ApiResourceOutput{Status: resp.StatusCode, ContentType: resp.Header.Get("Content-Type"), Body: body}, nil
 }
+
+// Echo returns the body of the request
+// @Summary echo the body of the request
+// @Description Echo the body of the request, to check the Trello plugin is up
+// @Tags plugins/trello
+// @Param body body map[string]interface{} true "json body"
+// @Success 200  {object} map[string]interface{} "the body of the request"
+// @Router /plugins/trello/echo [POST]
+func Echo(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return &plugin.ApiResourceOutput{Body: input.Body}, nil
+}
@@ -124,7 +124,8 @@ func UpdateScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, err
 // @Param connectionId path int false "connection ID"
 // @Param pageSize query int false "page size, default 50"
 // @Param page query int false "page size, default 1"
-// @Success 200  {object} []apiBoard
+// @Param sort query string false "comma separated fields to sort by, prefixed by - for the descending order"
+// @Success 200  {object} api.ListResponse "the page of the boards under scopes, along with count, page and pageSize"
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
 // @Router /plugins/trello/connections/{connectionId}/scopes/ [GET]
@@ -134,8 +135,19 @@ func GetScopeList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 	if connectionId == 0 {
 		return nil, errors.BadInput.New("invalid path params")
 	}
-	limit, offset := api.GetLimitOffset(input.Query, "pageSize", "page")
-	err := basicRes.GetDal().All(&boards, dal.Where("connection_id = ?", connectionId), dal.Limit(limit), dal.Offset(offset))
+	listQuery, err := api.ParseListQuery(input.Query, api.ListFields(&models.TrelloBoard{}))
+	if err != nil {
+		return nil, err
+	}
+	clauses := append([]dal.Clause{dal.From(&models.TrelloBoard{}), dal.Where("connection_id = ?", connectionId)}, listQuery.Clauses()...)
+	count, err := basicRes.GetDal().Count(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	if orderby := listQuery.Orderby(""); orderby != "" {
+		clauses = append(clauses, dal.Orderby(orderby))
+	}
+	err = basicRes.GetDal().All(&boards, append(clauses, dal.Limit(listQuery.PageSize), dal.Offset(listQuery.GetSkip()))...)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +172,7 @@ func GetScopeList(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 	for _, board := range boards {
 		apiBoards = append(apiBoards, apiBoard{board, names[board.TransformationRuleId]})
 	}
-	return &plugin.ApiResourceOutput{Body: apiBoards, Status: http.StatusOK}, nil
+	return &plugin.ApiResourceOutput{Body: api.NewListResponse("scopes", apiBoards, count, listQuery.Page, listQuery.PageSize), Status: http.StatusOK}, nil
 }
 
 // GetScope get one Trello board
@@ -142,9 +142,7 @@ func (p Trello) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"POST": api.TestConnection,
 		},
 		"echo": {
-			"POST": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-				return &plugin.ApiResourceOutput{Body: input.Body}, nil
-			},
+			"POST": api.Echo,
 		},
 		"connections": {
 			"POST": api.PostConnections,
@@ -0,0 +1,199 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	COVERAGE_FORMAT_LCOV      = "lcov"
+	COVERAGE_FORMAT_COBERTURA = "cobertura"
+)
+
+type WebhookCoverageRequest struct {
+	PipelineId string `mapstructure:"pipeline_id"`
+	// RepoUrl should be unique string, fill url or other unique data
+	RepoId    string `mapstructure:"repo_id"`
+	RepoUrl   string `mapstructure:"repo_url" validate:"required"`
+	CommitSha string `mapstructure:"commit_sha" validate:"required"`
+	Branch    string `mapstructure:"branch"`
+	// Format is the one of the report, the report is the content of the lcov.info or the cobertura xml file
+	Format      string     `mapstructure:"format" validate:"required,oneof=lcov cobertura"`
+	Report      string     `mapstructure:"report" validate:"required"`
+	CreatedDate *time.Time `mapstructure:"create_time"`
+}
+
+// coverageTotals are the counts summed up over all the files of a report
+type coverageTotals struct {
+	LinesTotal      int
+	LinesCovered    int
+	BranchesTotal   int
+	BranchesCovered int
+}
+
+// PostCoverage
+// @Summary create coverage by webhook
+// @Description Create the coverage of a commit by webhook from an uploaded lcov or cobertura report.<br/>
+// @Description example1: {"repo_url":"devlake","commit_sha":"015e3d3b480e417aede5a1293bd61de9b0fd051d","branch":"main","format":"lcov","report":"SF:main.go\nLF:10\nLH:8\nend_of_record\n"}<br/>
+// @Description Uploading a report again for the same commit replaces the previous one.
+// @Description Once the connection has a secret, the request should be signed by the HMAC-SHA256 hex digest of its body in the X-Hub-Signature-256 header or the configured one.
+// @Tags plugins/webhook
+// @Param body body WebhookCoverageRequest true "json body"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Invalid Signature"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/:connectionId/coverages [POST]
+func PostCoverage(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyRequest(input, connection)
+	if err != nil {
+		return nil, err
+	}
+	// get request
+	request := &WebhookCoverageRequest{}
+	err = api.DecodeMapStruct(input.Body, request, true)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+	// validate
+	vld = validator.New()
+	err = errors.Convert(vld.Struct(request))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(vld.Struct(request), `input json error`)
+	}
+	var totals *coverageTotals
+	if request.Format == COVERAGE_FORMAT_COBERTURA {
+		totals, err = parseCobertura(request.Report)
+	} else {
+		totals, err = parseLcov(request.Report)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if request.CreatedDate == nil {
+		now := time.Now()
+		request.CreatedDate = &now
+	}
+	urlHash16 := fmt.Sprintf("%x", md5.Sum([]byte(request.RepoUrl)))[:16]
+	coverage := &qa.QaCoverage{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fmt.Sprintf("%s:%d:%s:%s", "webhook", connection.ID, urlHash16, request.CommitSha),
+		},
+		Tool:            "webhook",
+		RepoId:          request.RepoId,
+		CommitSha:       request.CommitSha,
+		Branch:          request.Branch,
+		PipelineId:      request.PipelineId,
+		LinesTotal:      totals.LinesTotal,
+		LinesCovered:    totals.LinesCovered,
+		BranchesTotal:   totals.BranchesTotal,
+		BranchesCovered: totals.BranchesCovered,
+		CreatedDate:     request.CreatedDate,
+	}
+	if coverage.LinesTotal > 0 {
+		coverage.LineCoverage = float64(coverage.LinesCovered) * 100 / float64(coverage.LinesTotal)
+	}
+	if coverage.BranchesTotal > 0 {
+		coverage.BranchCoverage = float64(coverage.BranchesCovered) * 100 / float64(coverage.BranchesTotal)
+	}
+	err = basicRes.GetDal().CreateOrUpdate(coverage)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// parseLcov sums up the found and hit counts of the lines and branches of every file of the lcov report
+func parseLcov(report string) (*coverageTotals, errors.Error) {
+	totals := &coverageTotals{}
+	counters := map[string]*int{
+		"LF":  &totals.LinesTotal,
+		"LH":  &totals.LinesCovered,
+		"BRF": &totals.BranchesTotal,
+		"BRH": &totals.BranchesCovered,
+	}
+	records := 0
+	scanner := bufio.NewScanner(strings.NewReader(report))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "end_of_record" {
+			records++
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found || counters[key] == nil {
+			continue
+		}
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid lcov line %s", line))
+		}
+		*counters[key] += count
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to read the lcov report")
+	}
+	if records == 0 {
+		return nil, errors.BadInput.New("no record found in the lcov report")
+	}
+	return totals, nil
+}
+
+// parseCobertura reads the totals from the attributes of the root element of the cobertura report
+func parseCobertura(report string) (*coverageTotals, errors.Error) {
+	root := &struct {
+		XMLName         xml.Name `xml:"coverage"`
+		LinesValid      int      `xml:"lines-valid,attr"`
+		LinesCovered    int      `xml:"lines-covered,attr"`
+		BranchesValid   int      `xml:"branches-valid,attr"`
+		BranchesCovered int      `xml:"branches-covered,attr"`
+	}{}
+	err := xml.Unmarshal([]byte(report), root)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse the cobertura report")
+	}
+	return &coverageTotals{
+		LinesTotal:      root.LinesValid,
+		LinesCovered:    root.LinesCovered,
+		BranchesTotal:   root.BranchesValid,
+		BranchesCovered: root.BranchesCovered,
+	}, nil
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLcov(t *testing.T) {
+	report := `TN:
+SF:/src/cart.go
+FN:3,NewCart
+FNF:1
+FNH:1
+DA:3,1
+DA:4,0
+LF:20
+LH:15
+BRF:6
+BRH:4
+end_of_record
+SF:/src/checkout.go
+LF:10
+LH:9
+end_of_record
+`
+	totals, err := parseLcov(report)
+	assert.Nil(t, err)
+	assert.Equal(t, &coverageTotals{LinesTotal: 30, LinesCovered: 24, BranchesTotal: 6, BranchesCovered: 4}, totals)
+
+	_, err = parseLcov("not a report")
+	assert.NotNil(t, err)
+}
+
+func TestParseCobertura(t *testing.T) {
+	report := `<?xml version="1.0" ?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage lines-valid="120" lines-covered="96" line-rate="0.8" branches-valid="40" branches-covered="30" branch-rate="0.75" timestamp="1687946400" version="7.2.7">
+	<packages/>
+</coverage>`
+	totals, err := parseCobertura(report)
+	assert.Nil(t, err)
+	assert.Equal(t, &coverageTotals{LinesTotal: 120, LinesCovered: 96, BranchesTotal: 40, BranchesCovered: 30}, totals)
+
+	_, err = parseCobertura("<report/>")
+	assert.NotNil(t, err)
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/plugin"
-	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/webhook/models"
 
 	"github.com/go-playground/validator/v10"
@@ -55,11 +54,15 @@ type WebhookDeployTaskRequest struct {
 // @Description Create deployment pipeline by webhook.<br/>
 // @Description example1: {"repo_url":"devlake","commit_sha":"015e3d3b480e417aede5a1293bd61de9b0fd051d","start_time":"2020-01-01T12:00:00+00:00","end_time":"2020-01-01T12:59:59+00:00","environment":"PRODUCTION"}<br/>
 // @Description So we suggest request before task after deployment pipeline finish.
+// @Description A list of deployments is accepted as a batch, which is saved only if all of them are valid.
+// @Description Once the connection has a secret, the request should be signed by the HMAC-SHA256 hex digest of its body in the X-Hub-Signature-256 header or the configured one.
+// @Description The payloads are validated by the deployment schema, and rendered into the request by the deployment mapping templates if the connection defines them.
 // @Description Both cicd_pipeline and cicd_task will be created
 // @Tags plugins/webhook
 // @Param body body WebhookDeployTaskRequest true "json body"
 // @Success 200
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Invalid Signature"
 // @Failure 403  {string} errcode.Error "Forbidden"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/deployments [POST]
@@ -69,18 +72,35 @@ func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResource
 	if err != nil {
 		return nil, err
 	}
-	// get request
-	request := &WebhookDeployTaskRequest{}
-	err = api.DecodeMapStruct(input.Body, request, true)
+	payloads, err := readPayloads(input, connection, connection.DeploymentSchema, connection.DeploymentMapping)
 	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+		return nil, err
 	}
-	// validate
+	// decode and validate the whole batch before saving any of it
+	requests := make([]*WebhookDeployTaskRequest, len(payloads))
 	vld = validator.New()
-	err = errors.Convert(vld.Struct(request))
-	if err != nil {
-		return nil, errors.BadInput.Wrap(vld.Struct(request), `input json error`)
+	for i, payload := range payloads {
+		request := &WebhookDeployTaskRequest{}
+		err = decodePayload(payload, request, len(connection.DeploymentMapping) > 0)
+		if err != nil {
+			return &plugin.ApiResourceOutput{Body: payloadError(i, len(payloads), err).Error(), Status: http.StatusBadRequest}, nil
+		}
+		err = errors.Convert(vld.Struct(request))
+		if err != nil {
+			return nil, payloadError(i, len(payloads), errors.BadInput.Wrap(err, `input json error`))
+		}
+		requests[i] = request
 	}
+	for _, request := range requests {
+		err = saveDeployment(connection, request)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+func saveDeployment(connection *models.WebhookConnection, request *WebhookDeployTaskRequest) errors.Error {
 	db := basicRes.GetDal()
 	urlHash16 := fmt.Sprintf("%x", md5.Sum([]byte(request.RepoUrl)))[:16]
 	scopeId := fmt.Sprintf("%s:%d", "webhook", connection.ID)
@@ -124,12 +144,12 @@ func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResource
 		RepoId:           request.RepoId,
 		RepoUrl:          request.RepoUrl,
 	}
-	err = db.CreateOrUpdate(deploymentCommit)
+	err := db.CreateOrUpdate(deploymentCommit)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// TODO: create a deployment record when the table is ready
 
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return nil
 }
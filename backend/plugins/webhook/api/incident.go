@@ -27,7 +27,6 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
-	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/webhook/models"
 
 	"github.com/go-playground/validator/v10"
@@ -65,10 +64,14 @@ type WebhookIssueRequest struct {
 // PostIssue
 // @Summary receive a record as defined and save it
 // @Description receive a record as follow and save it, example: {"url":"","issue_key":"DLK-1234","title":"a feature from DLK","description":"","epic_key":"","type":"BUG","status":"TODO","original_status":"created","story_point":0,"resolution_date":null,"created_date":"2020-01-01T12:00:00+00:00","updated_date":null,"lead_time_minutes":0,"parent_issue_key":"DLK-1200","priority":"","original_estimate_minutes":0,"time_spent_minutes":0,"time_remaining_minutes":0,"creator_id":"user1131","creator_name":"Nick name 1","assignee_id":"user1132","assignee_name":"Nick name 2","severity":"","component":""}
+// @Description A list of issues is accepted as a batch, which is saved only if all of them are valid.
+// @Description Once the connection has a secret, the request should be signed by the HMAC-SHA256 hex digest of its body in the X-Hub-Signature-256 header or the configured one.
+// @Description The payloads are validated by the issue schema, and rendered into the request by the issue mapping templates if the connection defines them.
 // @Tags plugins/webhook
 // @Param body body WebhookIssueRequest true "json body"
 // @Success 200  {string} noResponse ""
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Invalid Signature"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/issues [POST]
 func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
@@ -77,19 +80,34 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 	if err != nil {
 		return nil, err
 	}
-	// get request
-	request := &WebhookIssueRequest{}
-	err = helper.DecodeMapStruct(input.Body, request, true)
+	payloads, err := readPayloads(input, connection, connection.IssueSchema, connection.IssueMapping)
 	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+		return nil, err
 	}
-	// validate
+	// decode and validate the whole batch before saving any of it
+	requests := make([]*WebhookIssueRequest, len(payloads))
 	vld = validator.New()
-	err = errors.Convert(vld.Struct(request))
-	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	for i, payload := range payloads {
+		request := &WebhookIssueRequest{}
+		err = decodePayload(payload, request, len(connection.IssueMapping) > 0)
+		if err == nil {
+			err = errors.Convert(vld.Struct(request))
+		}
+		if err != nil {
+			return &plugin.ApiResourceOutput{Body: payloadError(i, len(payloads), err).Error(), Status: http.StatusBadRequest}, nil
+		}
+		requests[i] = request
 	}
+	for _, request := range requests {
+		err = saveIssue(connection, request)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
 
+func saveIssue(connection *models.WebhookConnection, request *WebhookIssueRequest) errors.Error {
 	db := basicRes.GetDal()
 	domainIssue := &ticket.Issue{
 		DomainEntity: domainlayer.DomainEntity{
@@ -137,7 +155,7 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 	// check if board exists
 	count, err := db.Count(dal.From(&ticket.Board{}), dal.Where("id = ?", domainBoardId))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// only create board with domainBoard non-existent
@@ -149,22 +167,17 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 		}
 		err = db.Create(domainBoard)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// save
 	err = db.CreateOrUpdate(domainIssue)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	err = db.CreateOrUpdate(boardIssue)
-	if err != nil {
-		return nil, err
-	}
-
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return db.CreateOrUpdate(boardIssue)
 }
 
 // CloseIssue
@@ -173,6 +186,7 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 // @Tags plugins/webhook
 // @Success 200  {string} noResponse ""
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Invalid Signature"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/:connectionId/issue/:issueKey/close [POST]
 func CloseIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
@@ -181,6 +195,10 @@ func CloseIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, erro
 	if err != nil {
 		return nil, err
 	}
+	err = verifyRequest(input, connection)
+	if err != nil {
+		return nil, err
+	}
 
 	db := basicRes.GetDal()
 	domainIssue := &ticket.Issue{}
@@ -0,0 +1,209 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/mitchellh/mapstructure"
+)
+
+// MAX_BATCH_SIZE limits the payloads pushed in one request
+const MAX_BATCH_SIZE = 1000
+
+var mappingFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// readBody returns the raw body the signature is computed over
+func readBody(input *plugin.ApiResourceInput) ([]byte, errors.Error) {
+	if input.Request != nil && input.Request.Body != nil {
+		body, err := io.ReadAll(input.Request.Body)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to read the body")
+		}
+		input.Request.Body = io.NopCloser(bytes.NewReader(body))
+		return body, nil
+	}
+	if input.Body == nil {
+		return nil, nil
+	}
+	body, err := json.Marshal(input.Body)
+	return body, errors.Convert(err)
+}
+
+// verifySignature checks the HMAC-SHA256 digest of the body against the signature header once the connection has a secret,
+// the digest is hex encoded and may be prefixed with `sha256=`
+func verifySignature(input *plugin.ApiResourceInput, connection *models.WebhookConnection, body []byte) errors.Error {
+	if connection.Secret == "" {
+		return nil
+	}
+	header := connection.SignatureHeader
+	if header == "" {
+		header = models.DEFAULT_SIGNATURE_HEADER
+	}
+	signature := ""
+	if input.Request != nil {
+		signature = input.Request.Header.Get(header)
+	}
+	if signature == "" {
+		return errors.Unauthorized.New(fmt.Sprintf("the signature header %s is missing", header))
+	}
+	digest, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return errors.Unauthorized.New("the signature is not a hex encoded digest")
+	}
+	mac := hmac.New(sha256.New, []byte(connection.Secret))
+	mac.Write(body)
+	if !hmac.Equal(digest, mac.Sum(nil)) {
+		return errors.Unauthorized.New("the signature does not match the body")
+	}
+	return nil
+}
+
+// verifyRequest verifies the signature of the requests that are not mapped from payloads
+func verifyRequest(input *plugin.ApiResourceInput, connection *models.WebhookConnection) errors.Error {
+	body, err := readBody(input)
+	if err != nil {
+		return err
+	}
+	return verifySignature(input, connection, body)
+}
+
+// readPayloads verifies the request and returns its payloads validated by the schema and transformed by the mapping,
+// the body is either one payload or a list of them
+func readPayloads(
+	input *plugin.ApiResourceInput,
+	connection *models.WebhookConnection,
+	schema map[string]interface{},
+	mapping map[string]interface{},
+) ([]map[string]interface{}, errors.Error) {
+	body, err := readBody(input)
+	if err != nil {
+		return nil, err
+	}
+	err = verifySignature(input, connection, body)
+	if err != nil {
+		return nil, err
+	}
+	var payloads []map[string]interface{}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		err = errors.Convert(json.Unmarshal(body, &payloads))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "the batch should be a list of objects")
+		}
+		if len(payloads) > MAX_BATCH_SIZE {
+			return nil, errors.BadInput.New(fmt.Sprintf("a batch holds at most %d payloads", MAX_BATCH_SIZE))
+		}
+	} else {
+		payloads = []map[string]interface{}{input.Body}
+	}
+
+	templates, err := parseMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+	for i, payload := range payloads {
+		if len(schema) > 0 {
+			problems := validateSchema(schema, payload, "$")
+			if len(problems) > 0 {
+				return nil, payloadError(i, len(payloads), errors.BadInput.New(fmt.Sprintf("the payload does not match the schema: %s", strings.Join(problems, "; "))))
+			}
+		}
+		if len(templates) > 0 {
+			payloads[i], err = mapPayload(payload, templates)
+			if err != nil {
+				return nil, payloadError(i, len(payloads), err)
+			}
+		}
+	}
+	return payloads, nil
+}
+
+// payloadError tells which payload of a batch is rejected
+func payloadError(i int, count int, err errors.Error) errors.Error {
+	if count == 1 {
+		return err
+	}
+	return errors.BadInput.Wrap(err, fmt.Sprintf("the payload #%d of the batch is rejected", i))
+}
+
+func parseMapping(mapping map[string]interface{}) (map[string]*template.Template, errors.Error) {
+	templates := make(map[string]*template.Template, len(mapping))
+	for field, text := range mapping {
+		s, ok := text.(string)
+		if !ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("the mapping of %s should be a template", field))
+		}
+		tpl, err := template.New(field).Funcs(mappingFuncs).Parse(s)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid mapping of %s", field))
+		}
+		templates[field] = tpl
+	}
+	return templates, nil
+}
+
+// mapPayload renders the fields of a request out of a payload, the fields rendered empty are left out
+func mapPayload(payload map[string]interface{}, templates map[string]*template.Template) (map[string]interface{}, errors.Error) {
+	request := make(map[string]interface{}, len(templates))
+	for field, tpl := range templates {
+		var buf bytes.Buffer
+		err := tpl.Execute(&buf, payload)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("failed to map %s", field))
+		}
+		// the keys missing from the payload are rendered as `<no value>`
+		value := strings.TrimSpace(strings.ReplaceAll(buf.String(), "<no value>", ""))
+		if value != "" {
+			request[field] = value
+		}
+	}
+	return request, nil
+}
+
+// decodePayload decodes a payload into a request, the mapped payloads only hold strings so they are decoded weakly
+func decodePayload(payload map[string]interface{}, request interface{}, mapped bool) errors.Error {
+	if !mapped {
+		return api.DecodeMapStruct(payload, request, true)
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ZeroFields:       true,
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.ComposeDecodeHookFunc(api.DecodeHook),
+		Result:           request,
+	})
+	if err != nil {
+		return errors.Convert(err)
+	}
+	return errors.Convert(decoder.Decode(payload))
+}
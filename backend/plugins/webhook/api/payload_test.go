@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPayloadInput(t *testing.T, body string, header string, signature string) *plugin.ApiResourceInput {
+	req, err := http.NewRequest(http.MethodPost, "/plugins/webhook/1/deployments", bytes.NewBufferString(body))
+	assert.Nil(t, err)
+	if signature != "" {
+		req.Header.Set(header, signature)
+	}
+	input := &plugin.ApiResourceInput{Request: req}
+	if body[0] == '{' {
+		assert.Nil(t, json.Unmarshal([]byte(body), &input.Body))
+	}
+	return input
+}
+
+func sign(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := `{"repo_url":"devlake","commit_sha":"015e3d3b"}`
+	connection := &models.WebhookConnection{Secret: "s3cret"}
+
+	_, err := readPayloads(newPayloadInput(t, body, models.DEFAULT_SIGNATURE_HEADER, sign("s3cret", body)), connection, nil, nil)
+	assert.Nil(t, err)
+	_, err = readPayloads(newPayloadInput(t, body, models.DEFAULT_SIGNATURE_HEADER, sign("other", body)), connection, nil, nil)
+	assert.NotNil(t, err)
+	_, err = readPayloads(newPayloadInput(t, body, models.DEFAULT_SIGNATURE_HEADER, ""), connection, nil, nil)
+	assert.NotNil(t, err)
+
+	connection.SignatureHeader = "X-Signature"
+	_, err = readPayloads(newPayloadInput(t, body, "X-Signature", sign("s3cret", body)[len("sha256="):]), connection, nil, nil)
+	assert.Nil(t, err)
+}
+
+func TestReadBatchPayloads(t *testing.T) {
+	body := `[{"repo_url":"devlake","commit_sha":"a"},{"repo_url":"devlake","commit_sha":"b"}]`
+	payloads, err := readPayloads(newPayloadInput(t, body, "", ""), &models.WebhookConnection{}, nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, payloads, 2)
+	assert.Equal(t, "b", payloads[1]["commit_sha"])
+
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"repo_url", "commit_sha"},
+	}
+	body = `[{"repo_url":"devlake","commit_sha":"a"},{"repo_url":"devlake"}]`
+	_, err = readPayloads(newPayloadInput(t, body, "", ""), &models.WebhookConnection{}, schema, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "#1")
+}
+
+func TestMapPayload(t *testing.T) {
+	body := `{"deployment":{"sha":"015e3d3b","env":"prod","started_at":"2023-07-07T12:00:00Z"},"repository":{"url":"https://github.com/apache/incubator-devlake"}}`
+	mapping := map[string]interface{}{
+		"repo_url":    "{{ .repository.url }}",
+		"commit_sha":  "{{ .deployment.sha }}",
+		"start_time":  "{{ .deployment.started_at }}",
+		"end_time":    "{{ .deployment.finished_at }}",
+		"Environment": `{{ if eq .deployment.env "prod" }}PRODUCTION{{ else }}{{ upper .deployment.env }}{{ end }}`,
+	}
+	payloads, err := readPayloads(newPayloadInput(t, body, "", ""), &models.WebhookConnection{}, nil, mapping)
+	assert.Nil(t, err)
+	assert.NotContains(t, payloads[0], "end_time")
+
+	request := &WebhookDeployTaskRequest{}
+	err = decodePayload(payloads[0], request, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://github.com/apache/incubator-devlake", request.RepoUrl)
+	assert.Equal(t, "015e3d3b", request.CommitSha)
+	assert.Equal(t, "PRODUCTION", request.Environment)
+	assert.Equal(t, time.Date(2023, 7, 7, 12, 0, 0, 0, time.UTC), request.StartedDate.UTC())
+	assert.Nil(t, request.FinishedDate)
+}
+
+func TestValidateSchema(t *testing.T) {
+	var schema map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"required": ["issue_key", "status"],
+		"additionalProperties": false,
+		"properties": {
+			"issue_key": {"type": "string", "pattern": "^INC-[0-9]+$"},
+			"status": {"enum": ["TODO", "IN_PROGRESS", "DONE"]},
+			"story_point": {"type": "number", "minimum": 0},
+			"created_date": {"type": "string", "format": "date-time"},
+			"labels": {"type": "array", "maxItems": 2, "items": {"type": "string", "minLength": 1}}
+		}
+	}`), &schema))
+	var valid map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"issue_key":"INC-1","status":"DONE","story_point":3,"created_date":"2023-07-07T12:00:00+08:00","labels":["p1"]}`), &valid))
+	assert.Empty(t, validateSchema(schema, valid, "$"))
+
+	var invalid map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"issue_key":"DLK-1","story_point":-1,"created_date":"yesterday","labels":["", "a", "b"],"extra":true}`), &invalid))
+	problems := validateSchema(schema, invalid, "$")
+	assert.ElementsMatch(t, []string{
+		"$.status is required",
+		"$.issue_key should match ^INC-[0-9]+$",
+		"$.story_point should be at least 0",
+		"$.created_date should be a RFC3339 date-time",
+		"$.labels should hold at most 2 items",
+		"$.labels[0] should be at least 1 characters long",
+		"$.extra is not allowed",
+	}, problems)
+}
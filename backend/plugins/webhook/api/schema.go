@@ -0,0 +1,183 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// validateSchema checks a decoded json value against a json schema and returns the problems found,
+// the keywords supported are type, enum, const, required, properties, additionalProperties, items,
+// minLength, maxLength, pattern, format(date-time), minimum, maximum, minItems and maxItems
+func validateSchema(schema map[string]interface{}, value interface{}, path string) []string {
+	var problems []string
+	if t, ok := schema["type"]; ok && !matchType(t, value) {
+		return []string{fmt.Sprintf("%s should be of type %v", path, t)}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if equalJson(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s should be one of %v", path, enum))
+		}
+	}
+	if c, ok := schema["const"]; ok && !equalJson(c, value) {
+		problems = append(problems, fmt.Sprintf("%s should be %v", path, c))
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		problems = append(problems, validateObject(schema, v, path)...)
+	case []interface{}:
+		if min, ok := toNumber(schema["minItems"]); ok && float64(len(v)) < min {
+			problems = append(problems, fmt.Sprintf("%s should hold at least %v items", path, min))
+		}
+		if max, ok := toNumber(schema["maxItems"]); ok && float64(len(v)) > max {
+			problems = append(problems, fmt.Sprintf("%s should hold at most %v items", path, max))
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				problems = append(problems, validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case string:
+		length := float64(len([]rune(v)))
+		if min, ok := toNumber(schema["minLength"]); ok && length < min {
+			problems = append(problems, fmt.Sprintf("%s should be at least %v characters long", path, min))
+		}
+		if max, ok := toNumber(schema["maxLength"]); ok && length > max {
+			problems = append(problems, fmt.Sprintf("%s should be at most %v characters long", path, max))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("the pattern of %s is invalid: %s", path, err.Error()))
+			} else if !re.MatchString(v) {
+				problems = append(problems, fmt.Sprintf("%s should match %s", path, pattern))
+			}
+		}
+		if format, ok := schema["format"].(string); ok && format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				problems = append(problems, fmt.Sprintf("%s should be a RFC3339 date-time", path))
+			}
+		}
+	default:
+		if n, ok := toNumber(value); ok {
+			if min, ok := toNumber(schema["minimum"]); ok && n < min {
+				problems = append(problems, fmt.Sprintf("%s should be at least %v", path, min))
+			}
+			if max, ok := toNumber(schema["maximum"]); ok && n > max {
+				problems = append(problems, fmt.Sprintf("%s should be at most %v", path, max))
+			}
+		}
+	}
+	return problems
+}
+
+func validateObject(schema map[string]interface{}, object map[string]interface{}, path string) []string {
+	var problems []string
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			key, _ := r.(string)
+			if _, ok := object[key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s is required", path, key))
+			}
+		}
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	for key, v := range object {
+		if property, ok := properties[key].(map[string]interface{}); ok {
+			problems = append(problems, validateSchema(property, v, path+"."+key)...)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				problems = append(problems, fmt.Sprintf("%s.%s is not allowed", path, key))
+			}
+		case map[string]interface{}:
+			problems = append(problems, validateSchema(additional, v, path+"."+key)...)
+		}
+	}
+	return problems
+}
+
+// matchType accepts either a type name or a list of them
+func matchType(t interface{}, value interface{}) bool {
+	if types, ok := t.([]interface{}); ok {
+		for _, t := range types {
+			if matchType(t, value) {
+				return true
+			}
+		}
+		return false
+	}
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := toNumber(value)
+		return ok
+	case "integer":
+		n, ok := toNumber(value)
+		return ok && n == math.Trunc(n)
+	}
+	return true
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func equalJson(a interface{}, b interface{}) bool {
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -75,6 +75,9 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 		":connectionId/deployments": {
 			"POST": api.PostDeploymentCicdTask,
 		},
+		":connectionId/coverages": {
+			"POST": api.PostCoverage,
+		},
 		":connectionId/issues": {
 			"POST": api.PostIssue,
 		},
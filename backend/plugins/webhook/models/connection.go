@@ -19,10 +19,24 @@ package models
 
 import (
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"gorm.io/datatypes"
 )
 
+// the header carrying the signature when the connection does not name one, the value is `sha256=<hex digest>`
+const DEFAULT_SIGNATURE_HEADER = "X-Hub-Signature-256"
+
 type WebhookConnection struct {
 	helper.BaseConnection `mapstructure:",squash"`
+	// Secret signs the bodies with HMAC-SHA256, the unsigned requests are rejected once it is set
+	Secret          string `mapstructure:"secret" json:"secret" gorm:"serializer:encdec"`
+	SignatureHeader string `mapstructure:"signatureHeader" json:"signatureHeader" gorm:"type:varchar(100)"`
+	// the schemas validate the payloads as they are pushed, they are JSON schemas
+	DeploymentSchema datatypes.JSONMap `mapstructure:"deploymentSchema" json:"deploymentSchema" swaggertype:"object" format:"json"`
+	IssueSchema      datatypes.JSONMap `mapstructure:"issueSchema" json:"issueSchema" swaggertype:"object" format:"json"`
+	// the mappings turn the payloads of the CD systems into the requests of the webhook, they map the fields of
+	// the requests to go templates over the payloads, i.e. {"commit_sha": "{{ .deployment.sha }}"}
+	DeploymentMapping datatypes.JSONMap `mapstructure:"deploymentMapping" json:"deploymentMapping" swaggertype:"object" format:"json"`
+	IssueMapping      datatypes.JSONMap `mapstructure:"issueMapping" json:"issueMapping" swaggertype:"object" format:"json"`
 }
 
 func (WebhookConnection) TableName() string {
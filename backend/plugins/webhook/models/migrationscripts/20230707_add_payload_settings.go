@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"gorm.io/datatypes"
+)
+
+type webhookConnection20230707 struct {
+	Secret            string
+	SignatureHeader   string `gorm:"type:varchar(100)"`
+	DeploymentSchema  datatypes.JSONMap
+	IssueSchema       datatypes.JSONMap
+	DeploymentMapping datatypes.JSONMap
+	IssueMapping      datatypes.JSONMap
+}
+
+func (webhookConnection20230707) TableName() string {
+	return "_tool_webhook_connections"
+}
+
+type addPayloadSettings struct{}
+
+func (*addPayloadSettings) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&webhookConnection20230707{})
+}
+
+func (*addPayloadSettings) Version() uint64 {
+	return 20230707100000
+}
+
+func (*addPayloadSettings) Name() string {
+	return "add secret, schemas and mappings of the payloads to _tool_webhook_connections"
+}
@@ -117,7 +117,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Summary get all zentao connections
 // @Description Get all zentao connections
 // @Tags plugins/zentao
-// @Success 200  {object} []models.ZentaoConnection
+// @Success 200  {object} api.ListResponse "the page of the connections under connections, along with count, page and pageSize"
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/zentao/connections [GET]
@@ -18,6 +18,8 @@
 
 if [ -z "$1" ]; then
   cd "$(dirname "$0")"
+  # nothing to build when there is no remote plugin
+  [ -d plugins ] || exit 0
   cd plugins
 else
   cd "$1"
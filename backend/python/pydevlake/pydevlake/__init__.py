@@ -21,13 +21,16 @@ pytest.register_assert_rewrite('pydevlake.testing')
 from sqlmodel import Field as _Field
 
 
-def Field(*args, schema_extra: Optional[dict[str, Any]]=None, source: Optional[str]=None, **kwargs):
+def Field(*args, schema_extra: Optional[dict[str, Any]]=None, source: Optional[str]=None, large_text: bool=False, **kwargs):
     """
     A wrapper around sqlmodel.Field that adds a source parameter.
+    Set large_text for string fields whose values may not fit in a varchar column.
     """
     schema_extra = schema_extra or {}
     if source:
         schema_extra['source'] = source
+    if large_text:
+        schema_extra['large_text'] = True
     return _Field(*args, **kwargs, schema_extra=schema_extra)
 
 
@@ -15,6 +15,7 @@
 
 
 from datetime import datetime
+from enum import Enum
 from typing import Optional
 
 from sqlmodel import Field
@@ -45,6 +46,7 @@ class PullRequest(DomainModel, table=True):
     base_ref: str
     base_commit_sha: str
     head_commit_sha: str
+    deleted_at: Optional[datetime]
 
 
 class PullRequestLabels(NoPKModel, table=True):
@@ -63,7 +65,7 @@ class PullRequestCommit(NoPKModel, table=True):
 
 
 class PullRequestComment(DomainModel, table=True):
-    __tablename__ = 'cicd_scopes'
+    __tablename__ = 'pull_request_comments'
     pull_request_id: str
     body: str
     account_id: str
@@ -73,6 +75,42 @@ class PullRequestComment(DomainModel, table=True):
     type: str
     review_id: str
     status: str
+    thread_id: Optional[str]
+    reply_to_id: Optional[str]
+
+
+class PullRequestReviewThread(DomainModel, table=True):
+    __tablename__ = 'pull_request_review_threads'
+    pull_request_id: str
+    path: Optional[str]
+    line: Optional[int]
+    is_resolved: bool = False
+    is_outdated: bool = False
+    author_id: Optional[str]
+    resolver_id: Optional[str]
+    comment_count: int = 0
+    created_date: datetime
+    resolved_date: Optional[datetime]
+
+
+class PullRequestReviewEventType(Enum):
+    REVIEW_REQUESTED = "REVIEW_REQUESTED"
+    REVIEW_REQUEST_REMOVED = "REVIEW_REQUEST_REMOVED"
+    APPROVED = "APPROVED"
+    CHANGES_REQUESTED = "CHANGES_REQUESTED"
+    COMMENTED = "COMMENTED"
+    DISMISSED = "DISMISSED"
+
+
+class PullRequestReviewEvent(DomainModel, table=True):
+    __tablename__ = 'pull_request_review_events'
+    pull_request_id: str
+    type: PullRequestReviewEventType
+    original_type: Optional[str]
+    reviewer_id: Optional[str]
+    actor_id: Optional[str]
+    review_id: Optional[str]
+    created_date: datetime
 
 
 class Commit(NoPKModel, table=True):
@@ -150,7 +188,7 @@ class Repo(DomainScope, table=True):
     forked_from: Optional[str]
     created_date: Optional[datetime]
     updated_date: Optional[datetime]
-    deleted: bool
+    deleted_at: Optional[datetime]
 
 
 class RepoLanguage(NoPKModel, table=True):
@@ -91,3 +91,80 @@ class CICDTask(DomainModel, table=True):
     started_date: Optional[datetime]
     finished_date: Optional[datetime]
     cicd_scope_id: str
+
+
+class ArtifactType(Enum):
+    CONTAINER_IMAGE = "CONTAINER_IMAGE"
+    PACKAGE = "PACKAGE"
+    BINARY = "BINARY"
+    ARCHIVE = "ARCHIVE"
+    OTHER = "OTHER"
+
+
+class CicdArtifact(DomainModel, table=True):
+    __tablename__ = 'cicd_artifacts'
+    name: str
+    type: Optional[ArtifactType]
+    version: Optional[str]
+    digest: Optional[str]
+    registry: Optional[str]
+    url: Optional[str]
+    size_bytes: Optional[int]
+    cicd_scope_id: Optional[str]
+    pipeline_id: Optional[str]
+    repo_id: Optional[str]
+    commit_sha: Optional[str]
+    created_date: Optional[datetime]
+
+
+class CicdRelease(DomainModel, table=True):
+    __tablename__ = 'cicd_releases'
+    name: str
+    version: Optional[str]
+    tag_name: Optional[str]
+    description: Optional[str]
+    url: Optional[str]
+    is_prerelease: bool = False
+    cicd_scope_id: Optional[str]
+    pipeline_id: Optional[str]
+    repo_id: Optional[str]
+    commit_sha: Optional[str]
+    prev_release_id: Optional[str]
+    author_id: Optional[str]
+    created_date: Optional[datetime]
+    published_date: Optional[datetime]
+
+
+class CicdReleaseArtifact(NoPKModel, table=True):
+    __tablename__ = 'cicd_release_artifacts'
+    release_id: str = Field(primary_key=True)
+    artifact_id: str = Field(primary_key=True)
+
+
+class CicdEnvironmentModel(DomainModel, table=True):
+    __tablename__ = 'cicd_environments'
+    cicd_scope_id: Optional[str]
+    name: str
+    type: Optional[CICDEnvironment]
+    url: Optional[str]
+    created_date: Optional[datetime]
+    updated_date: Optional[datetime]
+
+
+class ApprovalState(Enum):
+    PENDING = "PENDING"
+    APPROVED = "APPROVED"
+    REJECTED = "REJECTED"
+
+
+class CicdDeploymentApproval(DomainModel, table=True):
+    __tablename__ = 'cicd_deployment_approvals'
+    cicd_deployment_id: str
+    environment_id: Optional[str]
+    state: ApprovalState
+    approver_id: Optional[str]
+    approver_name: Optional[str]
+    comment: Optional[str]
+    requested_date: Optional[datetime]
+    approved_date: Optional[datetime]
+    waiting_sec: Optional[int]
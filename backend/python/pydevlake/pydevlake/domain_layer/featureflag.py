@@ -0,0 +1,82 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+from typing import Optional
+from datetime import datetime
+from enum import Enum
+
+from pydevlake.model import DomainModel, DomainScope
+
+
+class FeatureFlagKind(Enum):
+    BOOLEAN = "BOOLEAN"
+    MULTIVARIATE = "MULTIVARIATE"
+
+
+class FeatureFlagStatus(Enum):
+    ACTIVE = "ACTIVE"
+    ARCHIVED = "ARCHIVED"
+
+
+class FeatureFlagEventType(Enum):
+    CREATED = "CREATED"
+    TOGGLED_ON = "TOGGLED_ON"
+    TOGGLED_OFF = "TOGGLED_OFF"
+    ROLLOUT_CHANGED = "ROLLOUT_CHANGED"
+    ENVIRONMENT_CHANGED = "ENVIRONMENT_CHANGED"
+    ARCHIVED = "ARCHIVED"
+
+
+class FeatureFlag(DomainModel, table=True):
+    __tablename__ = 'feature_flags'
+    feature_flag_scope_id: Optional[str]
+    tool: Optional[str]
+    key: str
+    name: Optional[str]
+    description: Optional[str]
+    url: Optional[str]
+    kind: Optional[FeatureFlagKind]
+    status: Optional[FeatureFlagStatus]
+    original_status: Optional[str]
+    creator_id: Optional[str]
+    creator_name: Optional[str]
+    created_date: Optional[datetime]
+    updated_date: Optional[datetime]
+    archived_date: Optional[datetime]
+
+
+class FeatureFlagEvent(DomainModel, table=True):
+    __tablename__ = 'feature_flag_events'
+    feature_flag_id: str
+    event_type: FeatureFlagEventType
+    original_event_type: Optional[str]
+    environment: Optional[str]
+    original_environment: Optional[str]
+    enabled: bool = False
+    rollout_percentage: Optional[float]
+    author_id: Optional[str]
+    author_name: Optional[str]
+    comment: Optional[str]
+    created_date: datetime
+
+
+class FeatureFlagScope(DomainScope):
+    __tablename__ = 'feature_flag_scopes'
+    name: str
+    tool: Optional[str]
+    url: Optional[str]
+    created_date: Optional[datetime]
+    updated_date: Optional[datetime]
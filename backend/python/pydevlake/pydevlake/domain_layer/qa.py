@@ -0,0 +1,78 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+from typing import Optional
+from datetime import datetime
+from enum import Enum
+
+from pydevlake.model import DomainModel
+
+
+class TestStatus(Enum):
+    PASSED = "PASSED"
+    FAILED = "FAILED"
+    SKIPPED = "SKIPPED"
+    FLAKY = "FLAKY"
+
+
+class QaTestRun(DomainModel, table=True):
+    __tablename__ = 'qa_test_runs'
+    name: str
+    tool: Optional[str]
+    cicd_scope_id: Optional[str]
+    pipeline_id: Optional[str]
+    repo_id: Optional[str]
+    commit_sha: Optional[str]
+    status: Optional[TestStatus]
+    total_count: int = 0
+    passed_count: int = 0
+    failed_count: int = 0
+    skipped_count: int = 0
+    flaky_count: int = 0
+    duration_sec: Optional[float]
+    started_date: Optional[datetime]
+    finished_date: Optional[datetime]
+
+
+class QaTestCase(DomainModel, table=True):
+    __tablename__ = 'qa_test_cases'
+    test_run_id: str
+    name: str
+    class_name: Optional[str]
+    file_path: Optional[str]
+    status: Optional[TestStatus]
+    retries: int = 0
+    message: Optional[str]
+    duration_sec: Optional[float]
+    started_date: Optional[datetime]
+    finished_date: Optional[datetime]
+
+
+class QaCoverage(DomainModel, table=True):
+    __tablename__ = 'qa_coverages'
+    tool: Optional[str]
+    repo_id: Optional[str]
+    commit_sha: str
+    branch: Optional[str]
+    pipeline_id: Optional[str]
+    test_run_id: Optional[str]
+    lines_total: int = 0
+    lines_covered: int = 0
+    branches_total: int = 0
+    branches_covered: int = 0
+    line_coverage: Optional[float]
+    branch_coverage: Optional[float]
+    created_date: Optional[datetime]
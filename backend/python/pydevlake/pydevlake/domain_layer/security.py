@@ -0,0 +1,76 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+from typing import Optional
+from datetime import datetime
+from enum import Enum
+
+from pydevlake.model import DomainModel, DomainScope
+
+
+class ScanType(Enum):
+    SAST = "SAST"
+    DAST = "DAST"
+    DEPENDENCY = "DEPENDENCY"
+    CONTAINER = "CONTAINER"
+    SECRET = "SECRET"
+
+
+class Severity(Enum):
+    CRITICAL = "CRITICAL"
+    HIGH = "HIGH"
+    MEDIUM = "MEDIUM"
+    LOW = "LOW"
+    INFO = "INFO"
+
+
+class VulnerabilityStatus(Enum):
+    OPEN = "OPEN"
+    FIXED = "FIXED"
+    DISMISSED = "DISMISSED"
+
+
+class SecurityVulnerability(DomainModel, table=True):
+    __tablename__ = 'security_vulnerabilities'
+    security_scope_id: Optional[str]
+    tool: str
+    scan_type: Optional[ScanType]
+    title: str
+    description: Optional[str]
+    url: Optional[str]
+    severity: Optional[Severity]
+    cwe: Optional[str]
+    cve: Optional[str]
+    repo_id: Optional[str]
+    component: Optional[str]
+    component_version: Optional[str]
+    fixed_version: Optional[str]
+    line: Optional[int]
+    status: Optional[VulnerabilityStatus]
+    original_status: Optional[str]
+    created_date: Optional[datetime]
+    updated_date: Optional[datetime]
+    resolved_date: Optional[datetime]
+
+
+class SecurityScope(DomainScope):
+    __tablename__ = 'security_scopes'
+    name: str
+    tool: Optional[str]
+    url: Optional[str]
+    repo_id: Optional[str]
+    created_date: Optional[datetime]
+    updated_date: Optional[datetime]
@@ -14,6 +14,7 @@
 # limitations under the License.
 
 
+from decimal import Decimal
 from typing import Optional
 
 from pydantic import BaseModel, Field
@@ -35,6 +36,7 @@ class SubtaskMeta(BaseModel):
     description: str
     domain_types: list[str]
     arguments: list[str] = None
+    group: str = None
 
 
 class DynamicModelInfo(Message):
@@ -48,6 +50,12 @@ class DynamicModelInfo(Message):
             # Replace $ref with actual schema
             schema = jsonref.replace_refs(schema, proxies=False)
             del schema['definitions']
+        # JSON schema has no decimal type, flag decimals so that the go side doesn't create float columns
+        for field in model_class.__fields__.values():
+            if isinstance(field.type_, type) and issubclass(field.type_, Decimal):
+                prop = schema.get('properties', {}).get(field.alias)
+                if prop is not None:
+                    prop['format'] = 'decimal'
         return DynamicModelInfo(
             json_schema=schema,
             table_name=model_class.__tablename__
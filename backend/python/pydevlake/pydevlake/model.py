@@ -15,22 +15,81 @@
 
 
 import os
+from copy import copy
 from typing import Iterable, Optional
 from inspect import getmodule
 from datetime import datetime
+from decimal import Decimal
 
 import inflect
 from pydantic import AnyUrl, validator
-from sqlalchemy import Column, DateTime
+from pydantic.fields import ModelField, SHAPE_SINGLETON, Undefined
+from sqlalchemy import Column, DateTime, JSON, Numeric, Text
 from sqlalchemy.orm import declared_attr, Session
 from sqlalchemy.inspection import inspect
 from sqlmodel import SQLModel, Field
+from sqlmodel.main import SQLModelMetaclass
 
 
 inflect_engine = inflect.engine()
 
+# Column types shared with the go side, see server/services/remote/models/conversion.go
+DECIMAL_PRECISION = 65
+DECIMAL_SCALE = 10
+MAX_VARCHAR_LENGTH = 255
 
-class Model(SQLModel):
+
+def get_column_type(field: ModelField):
+    """
+    Returns the column type of the fields sqlmodel can't map on its own, or maps to a varchar, None otherwise:
+    - dicts and lists are stored as JSON
+    - decimals without explicit precision get enough digits to keep their fractional part
+    - strings marked with large_text or longer than a varchar are stored as text
+    """
+    if field.shape != SHAPE_SINGLETON or (isinstance(field.type_, type) and issubclass(field.type_, (dict, list))):
+        return JSON
+    if isinstance(field.type_, type) and issubclass(field.type_, Decimal):
+        return Numeric(
+            precision=getattr(field.type_, 'max_digits', None) or DECIMAL_PRECISION,
+            scale=getattr(field.type_, 'decimal_places', None) or DECIMAL_SCALE
+        )
+    if isinstance(field.type_, type) and issubclass(field.type_, str):
+        max_length = field.field_info.max_length
+        if field.field_info.extra.get('large_text') or (max_length and max_length > MAX_VARCHAR_LENGTH):
+            return Text
+    return None
+
+
+class ColumnTypeMetaclass(SQLModelMetaclass):
+    """
+    Gives an explicit column to the fields of table models that need a column type sqlmodel doesn't provide,
+    columns set through sa_column are left untouched.
+    """
+    def __init__(cls, classname, bases, dict_, **kwargs):
+        if getattr(cls.__config__, 'table', False):
+            for name, field in cls.__fields__.items():
+                if getattr(field.field_info, 'sa_column', Undefined) is not Undefined:
+                    continue
+                column_type = get_column_type(field)
+                if column_type is None:
+                    continue
+                primary_key = getattr(field.field_info, 'primary_key', False)
+                column_kwargs = getattr(field.field_info, 'sa_column_kwargs', Undefined)
+                # fields may be shared with the parent model, each table needs its own column
+                field = copy(field)
+                field.field_info = copy(field.field_info)
+                field.field_info.sa_column = Column(
+                    column_type,
+                    primary_key=primary_key,
+                    nullable=not primary_key and (field.allow_none or not field.required),
+                    index=getattr(field.field_info, 'index', Undefined) is True,
+                    **(column_kwargs if column_kwargs is not Undefined else {})
+                )
+                cls.__fields__[name] = field
+        super().__init__(classname, bases, dict_, **kwargs)
+
+
+class Model(SQLModel, metaclass=ColumnTypeMetaclass):
     id: Optional[int] = Field(primary_key=True)
     created_at: Optional[datetime] = Field(
         sa_column=Column(DateTime(), default=datetime.utcnow)
@@ -39,7 +98,7 @@ class Model(SQLModel):
         sa_column=Column(DateTime(), default=datetime.utcnow, onupdate=datetime.utcnow)
     )
 
-class ToolTable(SQLModel):
+class ToolTable(SQLModel, metaclass=ColumnTypeMetaclass):
     @declared_attr
     def __tablename__(cls) -> str:
         plugin_name = _get_plugin_name(cls)
@@ -88,7 +147,7 @@ class RawModel(SQLModel):
     created_at: datetime = Field(default_factory=datetime.now)
 
 
-class RawDataOrigin(SQLModel):
+class RawDataOrigin(SQLModel, metaclass=ColumnTypeMetaclass):
     # SQLModel doesn't like attributes starting with _
     # so we change the names of the columns.
     raw_data_params: Optional[str] = Field(sa_column_kwargs={'name':'_raw_data_params'})
@@ -182,3 +241,20 @@ class SubtaskRun(SQLModel, table=True):
     started: datetime
     completed: Optional[datetime]
     state: str # JSON encoded dict of atomic values
+
+
+class CollectorState(SQLModel, table=True):
+    """
+    Incremental collection state of a stream (the raw table) of a scope (the raw params),
+    shared with the go side, see core/models/collector_state.go.
+    """
+    __tablename__ = '_devlake_collector_latest_state'
+
+    raw_data_params: str = Field(primary_key=True)
+    raw_data_table: str = Field(primary_key=True)
+    plugin: Optional[str]
+    created_at: Optional[datetime] = Field(default_factory=datetime.now)
+    updated_at: Optional[datetime] = Field(default_factory=datetime.now)
+    time_after: Optional[datetime]
+    latest_success_start: Optional[datetime]
+    cursor: Optional[str] = Field(sa_column=Column(Text)) # JSON encoded dict of atomic values
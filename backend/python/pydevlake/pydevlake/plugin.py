@@ -226,6 +226,7 @@ class Plugin(ABC):
                 name=subtask.name,
                 entry_point_name=subtask.verb,
                 arguments=[subtask.stream.name],
+                group=subtask.stream.group,
                 required=True,
                 enabled_by_default=True,
                 description=subtask.description,
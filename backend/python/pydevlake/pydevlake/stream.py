@@ -31,6 +31,9 @@ class DomainType(Enum):
     CROSS = "CROSS"
     CICD = "CICD"
     CODE_QUALITY = "CODEQUALITY"
+    SECURITY = "SECURITY"
+    QA = "QA"
+    FEATURE_FLAG = "FEATUREFLAG"
 
 
 class Stream:
@@ -53,6 +56,14 @@ class Stream:
     def qualified_name(self):
         return f'{self.plugin_name}_{self.name}'
 
+    @property
+    def group(self):
+        """
+        Name of the root stream this stream depends on, the subtasks of a group run sequentially
+        while distinct groups may run concurrently.
+        """
+        return self.name
+
     @property
     def tool_model(self) -> Type[ToolModel]:
         pass
@@ -111,5 +122,10 @@ class Substream(Stream):
     def parent_stream(self):
         pass
 
+    @property
+    def group(self):
+        # substreams read the tool table of their parent stream
+        return self.parent_stream(self.plugin_name).group
+
     def collect(self, state, context, parent) -> Iterable[tuple[object, dict]]:
         pass
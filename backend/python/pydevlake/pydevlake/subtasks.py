@@ -23,7 +23,7 @@ from typing import Tuple, Dict, Iterable, Generator
 import sqlalchemy.sql as sql
 from sqlmodel import Session, select
 
-from pydevlake.model import RawModel, ToolModel, DomainModel, SubtaskRun
+from pydevlake.model import RawModel, ToolModel, DomainModel, SubtaskRun, CollectorState
 from pydevlake.context import Context
 from pydevlake.message import RemoteProgress
 from pydevlake import logger
@@ -49,7 +49,7 @@ class Subtask:
         with Session(ctx.engine) as session:
             subtask_run = self._start_subtask(session, ctx.connection.id)
             if ctx.incremental:
-                state = self._get_last_state(session, ctx.connection.id)
+                state = self._get_last_state(session, ctx)
             else:
                 self.delete(session, ctx)
                 state = dict()
@@ -84,6 +84,7 @@ class Subtask:
             subtask_run.state = json.dumps(state)
             subtask_run.completed = datetime.now()
             session.merge(subtask_run)
+            self._save_last_state(session, ctx, subtask_run, state)
             session.commit()
 
     def _start_subtask(self, session, connection_id):
@@ -113,11 +114,11 @@ class Subtask:
         """
         pass
 
-    def _get_last_state(self, session, connection_id):
+    def _get_last_state(self, session, ctx: Context):
         stmt = (
             select(SubtaskRun)
             .where(SubtaskRun.subtask_name == self.name)
-            .where(SubtaskRun.connection_id == connection_id)
+            .where(SubtaskRun.connection_id == ctx.connection.id)
             .where(SubtaskRun.completed != None)
             .order_by(sql.desc(SubtaskRun.started))
         )
@@ -126,6 +127,12 @@ class Subtask:
             return json.loads(subtask_run.state)
         return {}
 
+    def _save_last_state(self, session, ctx: Context, subtask_run: SubtaskRun, state: Dict):
+        """
+        Called once the subtask completed, the state is already saved in the subtask run.
+        """
+        pass
+
     def _params(self, ctx: Context) -> str:
         return json.dumps({
             "connection_id": ctx.connection.id,
@@ -141,6 +148,35 @@ class Collector(Subtask):
     def fetch(self, state: Dict, _, ctx: Context) -> Iterable[Tuple[object, Dict]]:
         return self.stream.collect(state, ctx)
 
+    def _get_last_state(self, session, ctx: Context):
+        # The state of collectors is kept per scope and stream in the collector state table
+        # shared with the go plugins, so that it can be inspected and reset from the framework.
+        collector_state = self._collector_state(session, ctx)
+        if collector_state is not None and collector_state.cursor:
+            return json.loads(collector_state.cursor)
+        return {}
+
+    def _save_last_state(self, session, ctx: Context, subtask_run: SubtaskRun, state: Dict):
+        collector_state = self._collector_state(session, ctx)
+        if collector_state is None:
+            collector_state = CollectorState(
+                raw_data_params=self._params(ctx),
+                raw_data_table=self.stream.raw_model_table
+            )
+        collector_state.plugin = self.stream.plugin_name
+        collector_state.latest_success_start = subtask_run.started
+        collector_state.updated_at = datetime.now()
+        collector_state.cursor = json.dumps(state)
+        session.merge(collector_state)
+
+    def _collector_state(self, session, ctx: Context):
+        stmt = (
+            select(CollectorState)
+            .where(CollectorState.raw_data_params == self._params(ctx))
+            .where(CollectorState.raw_data_table == self.stream.raw_model_table)
+        )
+        return session.exec(stmt).first()
+
     def process(self, data: object, session: Session, ctx: Context):
         raw_model_class = self.stream.raw_model(session)
         raw_model = raw_model_class(
@@ -0,0 +1,70 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from decimal import Decimal
+from typing import Optional
+
+from sqlalchemy import JSON, Numeric, Text, String
+
+from pydevlake import ToolModel, Field
+from pydevlake.message import DynamicModelInfo
+
+
+class ColumnTypesModel(ToolModel, table=True):
+    id: str = Field(primary_key=True)
+    labels: list[str]
+    properties: Optional[dict]
+    amount: Decimal
+    description: Optional[str] = Field(large_text=True)
+    long_name: str = Field(max_length=1024)
+    name: str
+
+
+def column_type(name: str):
+    return ColumnTypesModel.__table__.columns[name].type
+
+
+def test_json_columns():
+    assert isinstance(column_type('labels'), JSON)
+    assert isinstance(column_type('properties'), JSON)
+
+
+def test_decimal_column():
+    amount_type = column_type('amount')
+    assert isinstance(amount_type, Numeric)
+    assert amount_type.scale > 0
+
+
+def test_large_text_columns():
+    assert isinstance(column_type('description'), Text)
+    assert isinstance(column_type('long_name'), Text)
+    assert not isinstance(column_type('name'), Text)
+    assert isinstance(column_type('name'), String)
+
+
+def test_decimal_format_in_json_schema():
+    schema = DynamicModelInfo.from_model(ColumnTypesModel).json_schema
+    assert schema['properties']['amount']['format'] == 'decimal'
+    assert schema['properties']['description']['large_text']
+
+
+def test_sqlmodel_type_mapping_is_not_patched():
+    import sqlmodel.main
+    assert sqlmodel.main.get_sqlachemy_type.__module__ == 'sqlmodel.main'
+
+
+def test_column_nullability():
+    assert not ColumnTypesModel.__table__.columns['amount'].nullable
+    assert ColumnTypesModel.__table__.columns['properties'].nullable
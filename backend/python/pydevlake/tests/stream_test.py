@@ -17,10 +17,10 @@
 import json
 
 import pytest
-from sqlmodel import SQLModel, Session, Field, create_engine
+from sqlmodel import SQLModel, Session, Field, create_engine, select
 
-from pydevlake import Stream, Connection, Context, DomainType
-from pydevlake.model import ToolModel, DomainModel, ToolScope
+from pydevlake import Stream, Substream, Connection, Context, DomainType
+from pydevlake.model import ToolModel, DomainModel, ToolScope, CollectorState
 
 
 class DummyToolModel(ToolModel, table=True):
@@ -107,6 +107,23 @@ def test_collect_data(stream, raw_data, ctx):
         assert all_raw == raw_data
 
 
+def test_collect_incremental(stream, ctx):
+    list(stream.collector.run(ctx))
+
+    with Session(ctx.engine) as session:
+        collector_state = session.exec(select(CollectorState)).one()
+        assert collector_state.raw_data_table == stream.raw_model_table
+        assert collector_state.plugin == "test"
+        assert json.loads(collector_state.cursor) == {"count": 1}
+
+    ctx.options['incremental'] = True
+    list(stream.collector.run(ctx))
+
+    with Session(ctx.engine) as session:
+        collector_state = session.exec(select(CollectorState)).one()
+        assert json.loads(collector_state.cursor) == {"count": 2}
+
+
 def test_extract_data(stream, raw_data, ctx):
     with Session(ctx.engine) as session:
         for each in raw_data:
@@ -153,3 +170,21 @@ def test_convert_data(stream, raw_data, ctx):
 
     assert bob.Name == 'bob'
     assert bob.id == 'tests:DummyToolModel:11:2'
+
+
+class DummySubstream(Substream):
+    tool_model = DummyToolModel
+    domain_types = [DomainType.CROSS]
+    parent_stream = DummyStream
+
+
+class DummySubSubstream(Substream):
+    tool_model = DummyToolModel
+    domain_types = [DomainType.CROSS]
+    parent_stream = DummySubstream
+
+
+def test_substream_group():
+    assert DummyStream("test").group == "dummystream"
+    assert DummySubstream("test").group == "dummystream"
+    assert DummySubSubstream("test").group == "dummystream"
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activity
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/api/workspace"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// how long the feed may stay silent before a keep-alive message is sent
+const keepAlive = 30 * time.Second
+
+// @Summary follow the live activity
+// @Description Upgrade to a WebSocket streaming the activity as json messages `{"type", "createdAt", "projectName", "data"}`:
+// @Description the `pipeline` messages on the creation and the status transitions of the pipelines, the `task` messages
+// @Description on the progress of their tasks and the `notification` messages on the events published to the webhooks.
+// @Description A `keep-alive` message is sent after 30s of silence. Only the activity of the projects visible to the
+// @Description caller is streamed, and the messages a client lags too far behind are dropped.
+// @Tags framework/activity
+// @Success 101  "Switching Protocols"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /activity [get]
+func Get(c *gin.Context) {
+	projectNames, err := services.VisibleProjectNames(rbac.CurrentUser(c))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	projectNames, err = services.WorkspaceProjectNames(workspace.Current(c), projectNames)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	server := websocket.Server{
+		// the origins are left to the authentication of the api, like the other routes
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			serve(ws, projectNames)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func serve(ws *websocket.Conn, projectNames []string) {
+	defer ws.Close()
+	events, unsubscribe := services.SubscribeActivity(projectNames)
+	defer unsubscribe()
+	gone := make(chan struct{})
+	go func() {
+		// the messages of the client are discarded, reading only tells when it is gone
+		var message string
+		for websocket.Message.Receive(ws, &message) == nil {
+		}
+		close(gone)
+	}()
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		var err error
+		select {
+		case <-gone:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			err = websocket.JSON.Send(ws, event)
+		case <-ticker.C:
+			err = websocket.JSON.Send(ws, &services.ActivityEvent{Type: services.ACTIVITY_KEEP_ALIVE, CreatedAt: time.Now()})
+		}
+		if err != nil {
+			logruslog.Global.Debug("the activity feed of %s is closed: %v", ws.Request().RemoteAddr, err)
+			return
+		}
+	}
+}
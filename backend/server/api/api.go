@@ -34,11 +34,24 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/apikey"
+	"github.com/apache/incubator-devlake/server/api/apiversion"
+	"github.com/apache/incubator-devlake/server/api/auditlog"
+	"github.com/apache/incubator-devlake/server/api/configbundle"
 	_ "github.com/apache/incubator-devlake/server/api/docs"
+	"github.com/apache/incubator-devlake/server/api/fieldmask"
+	"github.com/apache/incubator-devlake/server/api/health"
+	"github.com/apache/incubator-devlake/server/api/idempotency"
 	"github.com/apache/incubator-devlake/server/api/login"
+	"github.com/apache/incubator-devlake/server/api/metrics"
 	"github.com/apache/incubator-devlake/server/api/ping"
+	"github.com/apache/incubator-devlake/server/api/ratelimit"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/settings"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/version"
+	"github.com/apache/incubator-devlake/server/api/workspace"
+	"github.com/apache/incubator-devlake/server/grpcapi"
 	"github.com/apache/incubator-devlake/server/services"
 	"github.com/apache/incubator-devlake/server/services/auth"
 )
@@ -65,9 +78,12 @@ func CreateApiService() {
 	gin.SetMode(v.GetString("MODE"))
 	// Create a gin router
 	router := gin.Default()
+	// Send the security headers of the settings, the rejected requests included
+	router.Use(settings.SecurityHeaders)
 
 	// For both protected and unprotected routes
 	router.GET("/ping", ping.Get)
+	router.GET("/health/ready", health.GetReady)
 	router.GET("/version", version.Get)
 
 	if auth.Enabled() {
@@ -75,9 +91,21 @@ func CreateApiService() {
 		router.POST("/login", login.Login)
 		router.POST("/login/newpassword", login.NewPassword)
 		router.POST("/login/refreshtoken", login.RefreshToken)
-		// Use AuthenticationMiddleware for protected routes
-		router.Use(auth.Middleware)
+		router.GET("/login/options", login.GetOptions)
 	}
+	if auth.RedirectEnabled() {
+		router.GET("/login/sso", login.Sso)
+		router.GET("/login/sso/callback", login.SsoCallback)
+	}
+	// Record the mutating calls to the protected routes, the rejected ones included
+	router.Use(auditlog.Middleware(router))
+	// Throttle the clients hammering the api, ahead of the authentication so that the rejected requests count as well
+	router.Use(ratelimit.Middleware())
+	// Authenticate the protected routes by api key or by the auth provider
+	router.Use(apikey.Middleware)
+
+	// metrics may reveal plugin names and workload, so they are only exposed to authenticated users
+	router.GET("/metrics", metrics.Get)
 
 	// Endpoint to proceed database migration
 	router.GET("/proceed-db-migration", func(ctx *gin.Context) {
@@ -111,6 +139,12 @@ func CreateApiService() {
 		ctx.Abort()
 	})
 
+	// Enforce the project roles of the authenticated users
+	router.Use(rbac.Middleware)
+
+	// Confine the requests of a workspace to its resources
+	router.Use(workspace.Middleware)
+
 	// Add swagger handlers
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	registerExtraOpenApiSpecs(router)
@@ -122,20 +156,23 @@ func CreateApiService() {
 
 	// Enable CORS
 	router.Use(cors.New(cors.Config{
-		// Allow all origins
-		AllowOrigins: []string{"*"},
+		// Allow the origins of the security settings, which may change at runtime
+		AllowOriginFunc: settings.AllowOrigin,
 		// Allow common methods
 		AllowMethods: []string{"PUT", "PATCH", "POST", "GET", "OPTIONS"},
 		// Allow common headers
-		AllowHeaders: []string{"Origin", "Content-Type"},
+		AllowHeaders: []string{"Origin", "Content-Type", "X-Api-Key", workspace.WORKSPACE_HEADER, configbundle.PassphraseHeader, idempotency.IDEMPOTENCY_KEY_HEADER, apiversion.API_VERSION_HEADER},
 		// Expose these headers
-		ExposeHeaders: []string{"Content-Length"},
+		ExposeHeaders: []string{"Content-Length", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After", idempotency.REPLAYED_HEADER, apiversion.API_VERSION_HEADER, "Deprecation", "Sunset", "Link"},
 		// Allow credentials
 		AllowCredentials: true,
 		// Cache for 2 hours
 		MaxAge: 120 * time.Hour,
 	}))
 
+	// Trim the responses down to the fields asked for
+	router.Use(fieldmask.Middleware)
+
 	// Register API endpoints
 	RegisterRouter(router)
 	// Get port from config
@@ -149,8 +186,17 @@ func CreateApiService() {
 		panic(fmt.Errorf("PORT [%s] must be int: %s", port, err.Error()))
 	}
 
-	// Start the server
-	err = router.Run(fmt.Sprintf("0.0.0.0:%d", portNum))
+	// Serve the grpc api alongside when its port is set
+	if grpcPort := v.GetString("GRPC_PORT"); grpcPort != "" {
+		go func() {
+			if err := grpcapi.Serve(grpcPort); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
+	// Start the server, serving the v2 api on top of the v1 one
+	err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", portNum), apiversion.Negotiate(router))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedApiKeys struct {
+	ApiKeys []*models.ApiKey `json:"apiKeys"`
+	Count   int64            `json:"count"`
+}
+
+// @Summary Get the api keys
+// @Description Get the api keys, the revoked ones included, the keys themselves are never returned
+// @Tags framework/api-keys
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedApiKeys
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /api-keys [get]
+func Index(c *gin.Context) {
+	var query services.ApiKeyQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	apiKeys, count, err := services.GetApiKeys(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedApiKeys{ApiKeys: apiKeys, Count: count}, http.StatusOK)
+}
+
+/*
+Create an api key
+POST /api-keys
+{
+	"name": "jenkins",
+	"scopes": ["pipelines:trigger", "webhook:push"],
+	"expiredAt": "2024-01-01T00:00:00Z"
+}
+*/
+// @Summary Create an api key
+// @Description Create an api key granted the scopes: admin, write, read, readonly, metrics:read, pipelines:trigger or
+// @Description webhook:push. The readonly scope, meant for the dashboards and the reporting jobs, is granted alone.
+// @Description The key is returned only once, it should be passed in the X-Api-Key header of the requests.
+// @Tags framework/api-keys
+// @Accept application/json
+// @Param apiKey body models.ApiInputApiKey true "json"
+// @Success 200  {object} models.ApiOutputApiKey
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /api-keys [post]
+func Post(c *gin.Context) {
+	input := &models.ApiInputApiKey{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	apiKey, err := services.CreateApiKey(input)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, apiKey, http.StatusOK)
+}
+
+// @Summary Revoke an api key
+// @Description Revoke an api key, the requests bearing it are rejected from now on
+// @Tags framework/api-keys
+// @Param apiKeyId path int true "api key id"
+// @Success 200  {object} models.ApiKey
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /api-keys/{apiKeyId} [delete]
+func Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("apiKeyId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiKeyId format supplied"))
+		return
+	}
+	apiKey, err := services.RevokeApiKey(id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, apiKey, http.StatusOK)
+}
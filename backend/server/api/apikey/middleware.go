@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/apache/incubator-devlake/server/services/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+const API_KEY_HEADER = "X-Api-Key"
+
+// Middleware authenticates the requests by their api key, the requests without a key are left to the auth provider.
+// They are let through when there is no auth provider, unless API_KEY_REQUIRED is set.
+func Middleware(c *gin.Context) {
+	key := c.GetHeader(API_KEY_HEADER)
+	if key == "" {
+		if auth.Enabled() {
+			auth.Middleware(c)
+			return
+		}
+		if !services.ApiKeyRequired() {
+			return
+		}
+		// the first key has to be created without any
+		if c.Request.Method == http.MethodPost && c.FullPath() == "/api-keys" {
+			allowed, err := services.ApiKeyBootstrapAllowed()
+			if err != nil {
+				shared.ApiOutputAbort(c, err)
+				return
+			}
+			if allowed {
+				return
+			}
+		}
+		shared.ApiOutputAbort(c, errors.Unauthorized.New(API_KEY_HEADER+" header is missing"))
+		return
+	}
+	apiKey, err := services.AuthenticateApiKey(key, c.Request.Method, c.FullPath())
+	if err != nil {
+		shared.ApiOutputAbort(c, err)
+		return
+	}
+	c.Set("apiKey", apiKey)
+}
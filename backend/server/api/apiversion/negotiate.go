@@ -0,0 +1,227 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+)
+
+const (
+	// V2_PREFIX namespaces the v2 routes
+	V2_PREFIX = "/api/v2"
+	// API_VERSION_HEADER asks for a version on the unversioned paths, and tells the version of the response
+	API_VERSION_HEADER = "Api-Version"
+)
+
+// Negotiate serves the v2 api on top of the v1 one: the v2 requests, namespaced by /api/v2 or asking for version 2 by
+// the Api-Version header, are rewritten to the v1 routes serving them and their responses are reshaped to the v2
+// conventions. The v1 requests to the routes with a v2 successor are flagged as deprecated, along with the sunset
+// date set by API_V1_SUNSET, if any.
+func Negotiate(next http.Handler) http.Handler {
+	sunset := config.GetConfig().GetString("API_V1_SUNSET")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := "1"
+		path := r.URL.Path
+		if path == V2_PREFIX || strings.HasPrefix(path, V2_PREFIX+"/") {
+			version = "2"
+			path = strings.TrimPrefix(path, V2_PREFIX)
+		} else if requested := r.Header.Get(API_VERSION_HEADER); requested != "" {
+			version = requested
+		}
+		w.Header().Set(API_VERSION_HEADER, version)
+		switch version {
+		case "1":
+			if successor, ok := findV1(r.Method, path); ok {
+				w.Header().Set("Deprecation", "true")
+				if sunset != "" {
+					w.Header().Set("Sunset", sunset)
+				}
+				w.Header().Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, V2_PREFIX, successor))
+			}
+			next.ServeHTTP(w, r)
+		case "2":
+			method := r.Method
+			// the preflight requests are routed after the method they ask for
+			if method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				method = r.Header.Get("Access-Control-Request-Method")
+			}
+			v1Path, ok := findV2(method, path)
+			if !ok {
+				writeV2Error(w, http.StatusNotFound, fmt.Sprintf("%s %s is not a v2 route", r.Method, r.URL.Path), nil)
+				return
+			}
+			v1Request := r.Clone(r.Context())
+			v1Request.URL.Path = v1Path
+			v1Request.URL.RawPath = ""
+			v1Request.RequestURI = v1Request.URL.RequestURI()
+			recorder := newRecorder()
+			next.ServeHTTP(recorder, v1Request)
+			reshape(w, recorder, r)
+		default:
+			writeV2Error(w, http.StatusBadRequest, fmt.Sprintf("unsupported %s %s, the supported ones are 1 and 2", API_VERSION_HEADER, version), nil)
+		}
+	})
+}
+
+// recorder buffers the response of the v1 route to reshape it
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
+
+// reshape writes the v1 response the v2 way: the lists are wrapped in `{"items", "count", "page", "pageSize"}`,
+// the errors in `{"error": {"status", "message", "causes"}}` and the bare acknowledgements are dropped for a 204
+func reshape(w http.ResponseWriter, v1 *recorder, r *http.Request) {
+	for name, values := range v1.header {
+		if name != "Content-Length" {
+			w.Header()[name] = values
+		}
+	}
+	if v1.status >= http.StatusBadRequest {
+		body := struct {
+			Message string   `json:"message"`
+			Causes  []string `json:"causes"`
+		}{}
+		_ = json.Unmarshal(v1.body.Bytes(), &body)
+		if body.Message == "" {
+			body.Message = http.StatusText(v1.status)
+		}
+		writeV2Error(w, v1.status, body.Message, body.Causes)
+		return
+	}
+	if !strings.HasPrefix(v1.header.Get("Content-Type"), "application/json") {
+		w.WriteHeader(v1.status)
+		_, _ = w.Write(v1.body.Bytes())
+		return
+	}
+	var object map[string]json.RawMessage
+	if json.Unmarshal(v1.body.Bytes(), &object) != nil {
+		w.WriteHeader(v1.status)
+		_, _ = w.Write(v1.body.Bytes())
+		return
+	}
+	if isAcknowledgement(object) {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if list, ok := toList(object, r); ok {
+		writeJson(w, v1.status, list)
+		return
+	}
+	w.WriteHeader(v1.status)
+	_, _ = w.Write(v1.body.Bytes())
+}
+
+// isAcknowledgement tells the `{"success": true, "message": "success"}` bodies apart
+func isAcknowledgement(object map[string]json.RawMessage) bool {
+	if len(object) > 3 || string(object["success"]) != "true" {
+		return false
+	}
+	_, hasMessage := object["message"]
+	return hasMessage
+}
+
+// v2List is the shape of the v2 lists
+type v2List struct {
+	Items    json.RawMessage `json:"items"`
+	Count    json.RawMessage `json:"count"`
+	Page     int             `json:"page,omitempty"`
+	PageSize int             `json:"pageSize,omitempty"`
+}
+
+// toList converts the v1 lists, i.e. `{"pipelines": [...], "count": 1}`, holding the count and a single array
+func toList(object map[string]json.RawMessage, r *http.Request) (*v2List, bool) {
+	count, ok := object["count"]
+	if !ok {
+		return nil, false
+	}
+	list := &v2List{Count: count}
+	for key, value := range object {
+		switch key {
+		case "count":
+		case "page":
+			_ = json.Unmarshal(value, &list.Page)
+		case "pageSize":
+			_ = json.Unmarshal(value, &list.PageSize)
+		default:
+			trimmed := bytes.TrimSpace(value)
+			if list.Items != nil || (len(trimmed) > 0 && trimmed[0] != '[' && string(trimmed) != "null") {
+				return nil, false
+			}
+			list.Items = value
+		}
+	}
+	if list.Items == nil || string(bytes.TrimSpace(list.Items)) == "null" {
+		list.Items = json.RawMessage("[]")
+	}
+	query := r.URL.Query()
+	if list.Page == 0 {
+		list.Page, _ = strconv.Atoi(query.Get("page"))
+	}
+	if list.PageSize == 0 {
+		list.PageSize, _ = strconv.Atoi(query.Get("pageSize"))
+	}
+	return list, true
+}
+
+func writeV2Error(w http.ResponseWriter, status int, message string, causes []string) {
+	body := map[string]interface{}{
+		"error": map[string]interface{}{
+			"status":  status,
+			"message": message,
+			"causes":  causes,
+		},
+	}
+	writeJson(w, status, body)
+}
+
+func writeJson(w http.ResponseWriter, status int, body interface{}) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		status = http.StatusInternalServerError
+		content = []byte(`{"error":{"status":500,"message":"error serializing the response"}}`)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(content)
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiversion
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	params, ok := match("/blueprints/:blueprintId/trigger", "/blueprints/12/trigger")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{":blueprintId": "12"}, params)
+	_, ok = match("/blueprints/:blueprintId/trigger", "/blueprints/12")
+	assert.False(t, ok)
+	_, ok = match("/blueprints/:blueprintId", "/blueprints/12/trigger")
+	assert.False(t, ok)
+	params, ok = match("/projects/*projectName", "/projects/team/a")
+	assert.True(t, ok)
+	assert.Equal(t, "/team/a", params["*projectName"])
+	_, ok = match("/projects/*projectName", "/projects")
+	assert.False(t, ok)
+
+	v1, ok := findV2(http.MethodPost, "/blueprints/12/pipelines")
+	assert.True(t, ok)
+	assert.Equal(t, "/blueprints/12/trigger", v1)
+	v2, ok := findV1(http.MethodPost, "/blueprints/12/trigger")
+	assert.True(t, ok)
+	assert.Equal(t, "/blueprints/12/pipelines", v2)
+	_, ok = findV1(http.MethodGet, "/domainlayer/repos")
+	assert.False(t, ok)
+}
+
+func newTestRouter() http.Handler {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/pipelines", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"pipelines": []gin.H{{"id": 1}}, "count": 1, "page": 1, "pageSize": 50})
+	})
+	r.GET("/blueprints", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"blueprints": nil, "count": 0})
+	})
+	r.POST("/blueprints/:blueprintId/trigger", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": 7, "blueprintId": c.Param("blueprintId")})
+	})
+	r.DELETE("/pipelines/:pipelineId", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true, "message": "success"})
+	})
+	r.GET("/pipelines/:pipelineId", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "pipeline not found", "causes": []string{}})
+	})
+	r.GET("/domainlayer/repos", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"repos": []string{}, "count": 0})
+	})
+	return Negotiate(r)
+}
+
+func serve(h http.Handler, method string, path string, version string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	if version != "" {
+		req.Header.Set(API_VERSION_HEADER, version)
+	}
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestNegotiateV2(t *testing.T) {
+	h := newTestRouter()
+
+	w := serve(h, http.MethodGet, "/api/v2/pipelines?page=1&pageSize=50", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get(API_VERSION_HEADER))
+	assert.JSONEq(t, `{"items": [{"id": 1}], "count": 1, "page": 1, "pageSize": 50}`, w.Body.String())
+
+	w = serve(h, http.MethodGet, "/api/v2/blueprints?pageSize=20", "")
+	assert.JSONEq(t, `{"items": [], "count": 0, "pageSize": 20}`, w.Body.String())
+
+	w = serve(h, http.MethodPost, "/api/v2/blueprints/3/pipelines", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": 7, "blueprintId": "3"}`, w.Body.String())
+	assert.Empty(t, w.Header().Get("Deprecation"))
+
+	w = serve(h, http.MethodDelete, "/api/v2/pipelines/3", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = serve(h, http.MethodGet, "/api/v2/pipelines/3", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error": {"status": 404, "message": "pipeline not found", "causes": []}}`, w.Body.String())
+
+	// the v1 only routes are out of v2
+	w = serve(h, http.MethodGet, "/api/v2/domainlayer/repos", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// the version may be negotiated on the unversioned paths as well
+	w = serve(h, http.MethodGet, "/pipelines", "2")
+	assert.JSONEq(t, `{"items": [{"id": 1}], "count": 1, "page": 1, "pageSize": 50}`, w.Body.String())
+
+	w = serve(h, http.MethodGet, "/pipelines", "3")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNegotiateV1(t *testing.T) {
+	h := newTestRouter()
+
+	w := serve(h, http.MethodPost, "/blueprints/3/trigger", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get(API_VERSION_HEADER))
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2/blueprints/3/pipelines>; rel="successor-version"`, w.Header().Get("Link"))
+	assert.JSONEq(t, `{"id": 7, "blueprintId": "3"}`, w.Body.String())
+
+	w = serve(h, http.MethodGet, "/pipelines", "1")
+	assert.JSONEq(t, `{"pipelines": [{"id": 1}], "count": 1, "page": 1, "pageSize": 50}`, w.Body.String())
+
+	// the routes without any successor are not deprecated
+	w = serve(h, http.MethodGet, "/domainlayer/repos", "")
+	assert.Empty(t, w.Header().Get("Deprecation"))
+}
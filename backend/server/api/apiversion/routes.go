@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiversion
+
+import (
+	"net/http"
+	"strings"
+)
+
+// route maps a v2 route onto the v1 one serving it, the params are named alike
+type route struct {
+	method string
+	v2     string
+	v1     string
+}
+
+// the v2 routes name the resources by plural nouns and the actions on them by the resources they create, i.e. a
+// pipeline of a blueprint instead of a trigger
+var routes = []route{
+	{http.MethodGet, "/projects", "/projects"},
+	{http.MethodPost, "/projects", "/projects"},
+	{http.MethodGet, "/projects/*projectName", "/projects/*projectName"},
+	{http.MethodPatch, "/projects/*projectName", "/projects/*projectName"},
+	{http.MethodGet, "/blueprints", "/blueprints"},
+	{http.MethodPost, "/blueprints", "/blueprints"},
+	{http.MethodGet, "/blueprints/:blueprintId", "/blueprints/:blueprintId"},
+	{http.MethodPatch, "/blueprints/:blueprintId", "/blueprints/:blueprintId"},
+	{http.MethodGet, "/blueprints/:blueprintId/pipelines", "/blueprints/:blueprintId/pipelines"},
+	{http.MethodPost, "/blueprints/:blueprintId/pipelines", "/blueprints/:blueprintId/trigger"},
+	{http.MethodGet, "/pipelines", "/pipelines"},
+	{http.MethodPost, "/pipelines", "/pipelines"},
+	{http.MethodGet, "/pipelines/:pipelineId", "/pipelines/:pipelineId"},
+	{http.MethodDelete, "/pipelines/:pipelineId", "/pipelines/:pipelineId"},
+	{http.MethodGet, "/pipelines/:pipelineId/tasks", "/pipelines/:pipelineId/tasks"},
+	{http.MethodPost, "/pipelines/:pipelineId/reruns", "/pipelines/:pipelineId/rerun"},
+	{http.MethodGet, "/pipelines/:pipelineId/logs", "/pipelines/:pipelineId/logging.tar.gz"},
+	{http.MethodPost, "/tasks/:taskId/reruns", "/tasks/:taskId/rerun"},
+	{http.MethodGet, "/plugins", "/plugins"},
+	{http.MethodGet, "/api-keys", "/api-keys"},
+	{http.MethodPost, "/api-keys", "/api-keys"},
+	{http.MethodDelete, "/api-keys/:apiKeyId", "/api-keys/:apiKeyId"},
+	{http.MethodGet, "/workspaces", "/workspaces"},
+	{http.MethodPost, "/workspaces", "/workspaces"},
+	{http.MethodGet, "/workspaces/:workspace", "/workspaces/:workspace"},
+	{http.MethodDelete, "/workspaces/:workspace", "/workspaces/:workspace"},
+}
+
+// match extracts the params of the path when it matches the pattern, a `*param` takes the rest of the path along
+// with its leading slash like gin does
+func match(pattern string, path string) (map[string]string, bool) {
+	params := make(map[string]string)
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "*") {
+			if i >= len(pathSegments) || pathSegments[i] == "" {
+				return nil, false
+			}
+			params[segment] = "/" + strings.Join(pathSegments[i:], "/")
+			return params, true
+		}
+		if i >= len(pathSegments) {
+			return nil, false
+		}
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			if pathSegments[i] == "" {
+				return nil, false
+			}
+			params[segment] = pathSegments[i]
+		case segment != pathSegments[i]:
+			return nil, false
+		}
+	}
+	return params, len(patternSegments) == len(pathSegments)
+}
+
+// expand fills the params into the pattern
+func expand(pattern string, params map[string]string) string {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	for i, segment := range segments {
+		if value, ok := params[segment]; ok {
+			segments[i] = strings.TrimPrefix(value, "/")
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// findV2 returns the v1 path serving the v2 one
+func findV2(method string, path string) (string, bool) {
+	for _, r := range routes {
+		if r.method != method {
+			continue
+		}
+		if params, ok := match(r.v2, path); ok {
+			return expand(r.v1, params), true
+		}
+	}
+	return "", false
+}
+
+// findV1 returns the v2 successor of the v1 path, the v1 routes without any are not deprecated
+func findV1(method string, path string) (string, bool) {
+	for _, r := range routes {
+		if r.method != method {
+			continue
+		}
+		if params, ok := match(r.v1, path); ok {
+			return expand(r.v2, params), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedArchives struct {
+	Archives []*models.Archive `json:"archives"`
+	Count    int64             `json:"count"`
+}
+
+// @Summary Get the archives
+// @Description Get the exports of the aged rows of the tables to the object store, the latest first
+// @Tags framework/archives
+// @Param table query string false "the archived table"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedArchives
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /archives [get]
+func Index(c *gin.Context) {
+	var query services.ArchiveQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	archives, count, err := services.GetArchives(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedArchives{Archives: archives, Count: count}, http.StatusOK)
+}
+
+// @Summary Start an archival
+// @Description Start archiving the rows older than ARCHIVE_AFTER_DAYS now rather than on schedule, an archive is returned for every table having such rows, poll them for the progress
+// @Tags framework/archives
+// @Success 201  {object} []models.Archive
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /archives [post]
+func Post(c *gin.Context) {
+	archives, err := services.StartArchive(models.ARCHIVE_TRIGGER_MANUAL)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, archives, http.StatusCreated)
+}
+
+// @Summary Get an archive
+// @Description Get an archive along with its progress
+// @Tags framework/archives
+// @Param archiveId path int true "archive id"
+// @Success 200  {object} models.Archive
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /archives/{archiveId} [get]
+func Get(c *gin.Context) {
+	archiveId, err := strconv.ParseUint(c.Param("archiveId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad archiveId format supplied"))
+		return
+	}
+	archive, err := services.GetArchive(archiveId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, archive, http.StatusOK)
+}
+
+// @Summary Restore an archive
+// @Description Start inserting the archived rows back into their table, the rows still in the table are kept, poll the archive for the progress
+// @Tags framework/archives
+// @Param archiveId path int true "archive id"
+// @Success 200  {object} models.Archive
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /archives/{archiveId}/restore [post]
+func PostRestore(c *gin.Context) {
+	archiveId, err := strconv.ParseUint(c.Param("archiveId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad archiveId format supplied"))
+		return
+	}
+	archive, err := services.RestoreArchive(archiveId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, archive, http.StatusOK)
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedAuditLogs struct {
+	AuditLogs []*models.AuditLog `json:"auditLogs"`
+	Count     int64              `json:"count"`
+}
+
+// @Summary Get the audit logs
+// @Description Get the mutating calls to the api, the latest first, along with the state of the resources before and after them
+// @Tags framework/audit-logs
+// @Param actor query string false "user name, or api-key:<name> for the api keys without any user"
+// @Param method query string false "http method"
+// @Param resourceType query string false "i.e. blueprints or plugins/github/connections"
+// @Param resourceId query string false "resource id"
+// @Param since query string false "RFC3339 time"
+// @Param until query string false "RFC3339 time"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedAuditLogs
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /audit-logs [get]
+func Index(c *gin.Context) {
+	var query services.AuditLogQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	auditLogs, count, err := services.GetAuditLogs(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedAuditLogs{AuditLogs: auditLogs, Count: count}, http.StatusOK)
+}
+
+// @Summary Export the audit logs
+// @Description Download all the audit logs matching the filters, oldest first, as csv or as json lines
+// @Tags framework/audit-logs
+// @Param format query string false "csv (default) or json"
+// @Param actor query string false "user name, or api-key:<name> for the api keys without any user"
+// @Param method query string false "http method"
+// @Param resourceType query string false "i.e. blueprints or plugins/github/connections"
+// @Param resourceId query string false "resource id"
+// @Param since query string false "RFC3339 time"
+// @Param until query string false "RFC3339 time"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /audit-logs/export [get]
+func Export(c *gin.Context) {
+	var query services.AuditLogQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	format := c.DefaultQuery("format", "csv")
+	contentType := "text/csv"
+	if format == "json" {
+		contentType = "application/x-ndjson"
+	} else if format != "csv" {
+		shared.ApiOutputError(c, errors.BadInput.New("the export format must be csv or json"))
+		return
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=audit-logs-%s.%s", time.Now().Format("20060102150405"), format,
+	))
+	c.Status(http.StatusOK)
+	err = services.ExportAuditLogs(&query, format, c.Writer)
+	if err != nil {
+		// the headers are sent already, the export is cut short
+		logruslog.Global.Error(err, "failed to export the audit logs")
+	}
+}
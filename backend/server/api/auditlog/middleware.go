@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// the states of the resources are truncated beyond, and dropped as they are no longer valid json
+const maxStateSize = 1 << 20
+
+// bodyRecorder keeps a copy of the response body
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	if w.body.Len() < maxStateSize {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	if w.body.Len() < maxStateSize {
+		w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Middleware records the mutating calls. The state of the resource before the call is read from the GET route of
+// the same path, if any, and the state after the call is the response body.
+// It runs ahead of the authentication so the rejected calls are recorded as well.
+func Middleware(router *gin.Engine) gin.HandlerFunc {
+	var readableRoutes map[string]bool
+	var once sync.Once
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		route := c.FullPath()
+		if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions || route == "" {
+			return
+		}
+		once.Do(func() {
+			readableRoutes = make(map[string]bool)
+			for _, r := range router.Routes() {
+				if r.Method == http.MethodGet {
+					readableRoutes[r.Path] = true
+				}
+			}
+		})
+		auditLog := &models.AuditLog{
+			ClientIp:     c.ClientIP(),
+			Method:       method,
+			Path:         c.Request.URL.Path,
+			ResourceType: resourceType(route),
+		}
+		if len(c.Params) > 0 {
+			auditLog.ResourceId = strings.TrimPrefix(c.Params[len(c.Params)-1].Value, "/")
+			if readableRoutes[route] {
+				auditLog.Before = readState(router, c)
+			}
+		}
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		auditLog.Status = c.Writer.Status()
+		if auditLog.Status < http.StatusBadRequest && method != http.MethodDelete {
+			auditLog.After = recorder.body.Bytes()
+		}
+		auditLog.Actor = "anonymous"
+		if user := rbac.CurrentUser(c); user != nil {
+			auditLog.Actor = user.Name
+			auditLog.UserId = &user.ID
+		}
+		if v, ok := c.Get("apiKey"); ok {
+			apiKey := v.(*models.ApiKey)
+			auditLog.ApiKeyId = &apiKey.ID
+			if auditLog.UserId == nil {
+				auditLog.Actor = "api-key:" + apiKey.Name
+			}
+		}
+		err := services.SaveAuditLog(auditLog)
+		if err != nil {
+			logruslog.Global.Error(err, "failed to record the audit log of %s %s", method, auditLog.Path)
+		}
+	}
+}
+
+// readState reads the resource through its GET route with the credentials of the call
+func readState(router *gin.Engine, c *gin.Context) []byte {
+	req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, c.Request.URL.Path, nil)
+	if err != nil {
+		return nil
+	}
+	for _, header := range []string{"Authorization", "X-Api-Key"} {
+		if value := c.GetHeader(header); value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	if res.Code != http.StatusOK || res.Body.Len() > maxStateSize {
+		return nil
+	}
+	return res.Body.Bytes()
+}
+
+// resourceType names the resource after the static segments of the route,
+// i.e. `plugins/github/connections` for `/plugins/github/connections/:connectionId`
+func resourceType(route string) string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(route, "/") {
+		if segment != "" && !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
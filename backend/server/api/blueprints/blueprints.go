@@ -23,7 +23,10 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/server/api/rbac"
 	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/api/workspace"
 	"github.com/apache/incubator-devlake/server/services"
 
 	"github.com/gin-gonic/gin"
@@ -32,6 +35,8 @@ import (
 type PaginatedBlueprint struct {
 	Blueprints []*models.Blueprint `json:"blueprints"`
 	Count      int64               `json:"count"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"pageSize"`
 }
 
 // @Summary post blueprints
@@ -52,6 +57,16 @@ func Post(c *gin.Context) {
 		return
 	}
 
+	err = services.CheckBlueprintRoles(rbac.CurrentUser(c), blueprint)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	err = services.CheckWorkspaceProject(workspace.Current(c), blueprint.ProjectName)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	err = services.CreateBlueprint(blueprint)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating blueprint"))
@@ -69,6 +84,7 @@ func Post(c *gin.Context) {
 // @Param page query int false "page"
 // @Param pageSize query int false "pageSize"
 // @Param label query string false "label"
+// @Param sort query string false "comma separated fields to sort by, prefixed by - for the descending order, i.e. -createdAt,name"
 // @Success 200  {object} PaginatedBlueprint
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -80,12 +96,32 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.List, err = helper.ParseListQuery(c.Request.URL.Query(), helper.ListFields(&models.Blueprint{}))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	query.ProjectNames, err = services.VisibleProjectNames(rbac.CurrentUser(c))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	query.ProjectNames, err = services.WorkspaceProjectNames(workspace.Current(c), query.ProjectNames)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	blueprints, count, err := services.GetBlueprints(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting blueprints"))
 		return
 	}
-	shared.ApiOutputSuccess(c, PaginatedBlueprint{Blueprints: blueprints, Count: count}, http.StatusOK)
+	shared.ApiOutputSuccess(c, PaginatedBlueprint{
+		Blueprints: blueprints,
+		Count:      count,
+		Page:       query.GetPage(),
+		PageSize:   query.GetPageSize(),
+	}, http.StatusOK)
 }
 
 // @Summary get blueprints
@@ -155,6 +191,18 @@ func Patch(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	err = services.CheckBlueprintPatch(rbac.CurrentUser(c), id, body)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	if projectName, ok := body["projectName"].(string); ok {
+		err = services.CheckWorkspaceProject(workspace.Current(c), projectName)
+		if err != nil {
+			shared.ApiOutputError(c, err)
+			return
+		}
+	}
 	blueprint, err := services.PatchBlueprint(id, body)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching the blueprint"))
@@ -164,12 +212,16 @@ func Patch(c *gin.Context) {
 }
 
 // @Summary trigger blueprint
-// @Description trigger a blueprint immediately
+// @Description trigger a blueprint immediately, the retries bearing the Idempotency-Key of the original request
+// @Description get its response back instead of triggering the blueprint again
 // @Tags framework/blueprints
 // @Accept application/json
 // @Param blueprintId path string true "blueprintId"
+// @Param Idempotency-Key header string false "a unique key of the request, kept for IDEMPOTENCY_KEY_TTL"
 // @Success 200  {object} models.Pipeline
 // @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 409  {object} shared.ApiBody "The original request is still in progress"
+// @Failure 422  {object} shared.ApiBody "The Idempotency-Key was used for another request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
 // @Router /blueprints/{blueprintId}/trigger [Post]
 func Trigger(c *gin.Context) {
@@ -187,11 +239,46 @@ func Trigger(c *gin.Context) {
 	shared.ApiOutputSuccess(c, pipeline, http.StatusOK)
 }
 
+// @Summary backfill blueprint
+// @Description run a blueprint on a historical window only, optionally for some of its scopes and entities, the collected data is merged into the existing one and the incremental collection states are left untouched
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param blueprintId path string true "blueprintId"
+// @Param backfill body services.BlueprintBackfill true "the window, scopes and entities to backfill"
+// @Success 200  {object} models.Pipeline
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId}/backfill [Post]
+func Backfill(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	var backfill services.BlueprintBackfill
+	err = c.ShouldBindJSON(&backfill)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	pipeline, err := services.BackfillBlueprint(id, &backfill)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error backfilling blueprint"))
+		return
+	}
+	shared.ApiOutputSuccess(c, pipeline, http.StatusOK)
+}
+
 // @Summary get pipelines by blueprint id
 // @Description get pipelines by blueprint id
 // @Tags framework/blueprints
 // @Accept application/json
 // @Param blueprintId path int true "blueprint id"
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Param sort query string false "comma separated fields to sort by, prefixed by - for the descending order, i.e. -beginAt"
+// @Param fields query string false "comma separated fields to return, the nested ones dotted, i.e. id,status,plan"
 // @Success 200  {object} shared.ResponsePipelines
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -208,11 +295,21 @@ func GetBlueprintPipelines(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad request URI format"))
 		return
 	}
+	query.List, err = helper.ParseListQuery(c.Request.URL.Query(), helper.ListFields(&models.Pipeline{}))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 
 	pipelines, count, err := services.GetPipelines(&query)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipelines"))
 		return
 	}
-	shared.ApiOutputSuccess(c, shared.ResponsePipelines{Pipelines: pipelines, Count: count}, http.StatusOK)
+	shared.ApiOutputSuccess(c, shared.ResponsePipelines{
+		Pipelines: pipelines,
+		Count:     count,
+		Page:      query.GetPage(),
+		PageSize:  query.GetPageSize(),
+	}, http.StatusOK)
 }
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectorstate
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedCollectorStates struct {
+	CollectorStates []*models.CollectorLatestState `json:"collectorStates"`
+	Count           int64                          `json:"count"`
+}
+
+// @Summary Get the collector states
+// @Description Get the incremental collection states stored per scope (the raw data params) and stream (the raw table), the latest updated first
+// @Tags framework/collector-states
+// @Param plugin query string false "the plugin name"
+// @Param rawDataTable query string false "the raw table of the stream"
+// @Param rawDataParams query string false "the raw data params of the scope"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedCollectorStates
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /collector-states [get]
+func Index(c *gin.Context) {
+	var query services.CollectorStateQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	states, count, err := services.GetCollectorStates(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedCollectorStates{CollectorStates: states, Count: count}, http.StatusOK)
+}
+
+// @Summary Reset the collector states of a scope
+// @Description Delete the incremental collection states of a scope so that its next collection is a full one, all the streams are reset unless rawDataTable is given
+// @Tags framework/collector-states
+// @Param plugin query string false "the plugin name"
+// @Param rawDataParams query string true "the raw data params of the scope"
+// @Param rawDataTable query string false "the raw table of the stream"
+// @Success 200  {object} []models.CollectorLatestState
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /collector-states [delete]
+func Delete(c *gin.Context) {
+	states, err := services.ResetCollectorStates(c.Query("plugin"), c.Query("rawDataParams"), c.Query("rawDataTable"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, states, http.StatusOK)
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configbundle
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PassphraseHeader carries the passphrase encrypting the credentials of the connections of a bundle
+const PassphraseHeader = "X-Bundle-Passphrase"
+
+// @Summary Export the configuration bundle
+// @Description Export the connections, scope configs, scopes, projects and blueprints as a single versioned bundle,
+// @Description for disaster recovery or promoting the configuration to another lake. The credentials of the
+// @Description connections are encrypted by the passphrase of the X-Bundle-Passphrase header, or left out without it.
+// @Tags framework/config-bundle
+// @Param X-Bundle-Passphrase header string false "the passphrase encrypting the credentials"
+// @Success 200  {object} services.ConfigBundle
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /config-bundle [get]
+func Get(c *gin.Context) {
+	bundle, err := services.ExportConfigBundle(c.GetHeader(PassphraseHeader))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="devlake-config-bundle.json"`)
+	shared.ApiOutputSuccess(c, bundle, http.StatusOK)
+}
+
+// @Summary Import a configuration bundle
+// @Description Create or update the resources of a bundle, the resources missing from the bundle are left as they
+// @Description are. With dryRun, only the changes to be made are reported.
+// @Tags framework/config-bundle
+// @Accept application/json
+// @Param X-Bundle-Passphrase header string false "the passphrase of the encrypted credentials"
+// @Param dryRun query bool false "report the changes without making them"
+// @Param bundle body services.ConfigBundle true "json"
+// @Success 200  {object} services.ConfigBundleImport
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /config-bundle [post]
+func Post(c *gin.Context) {
+	var query struct {
+		DryRun bool `form:"dryRun"`
+	}
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	bundle := &services.ConfigBundle{}
+	err = c.ShouldBindJSON(bundle)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	result, err := services.ImportConfigBundle(bundle, c.GetHeader(PassphraseHeader), query.DryRun)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dataexport
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// the query params of the table exports which are not columns
+var exportParams = map[string]bool{"format": true, "project": true, "timeColumn": true, "since": true, "until": true}
+
+type DataExports struct {
+	Tables  []*services.DataExportInfo `json:"tables"`
+	Queries []*services.DataExportInfo `json:"queries"`
+}
+
+// @Summary Get the data exports
+// @Description Get the domain tables and the canned queries which may be exported
+// @Tags framework/data-exports
+// @Success 200  {object} DataExports
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /data-exports [get]
+func Index(c *gin.Context) {
+	tables, queries := services.GetDataExports()
+	shared.ApiOutputSuccess(c, DataExports{Tables: tables, Queries: queries}, http.StatusOK)
+}
+
+// @Summary Export a domain table
+// @Description Download the rows of a domain table as csv or parquet, the other query params are columns the rows must match, i.e. ?environment=PRODUCTION&result=SUCCESS
+// @Tags framework/data-exports
+// @Param table path string true "domain table, i.e. cicd_deployment_commits"
+// @Param format query string false "csv (default) or parquet"
+// @Param timeColumn query string false "the column since and until apply to, i.e. finished_date"
+// @Param since query string false "RFC3339 time"
+// @Param until query string false "RFC3339 time"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /data-exports/tables/{table} [get]
+func ExportTable(c *gin.Context) {
+	var query services.DataExportQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	columnValues := make(map[string][]string)
+	for param, values := range c.Request.URL.Query() {
+		if !exportParams[param] {
+			columnValues[param] = values
+		}
+	}
+	export, err := services.OpenDomainTableExport(c.Param("table"), &query, columnValues)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	writeExport(c, export, query.GetFormat())
+}
+
+// @Summary Export a canned query
+// @Description Download the rows of a canned query for a project as csv or parquet, i.e. all the deployments of a project in a date range
+// @Tags framework/data-exports
+// @Param query path string true "canned query, i.e. deployments"
+// @Param project query string true "project name"
+// @Param format query string false "csv (default) or parquet"
+// @Param since query string false "RFC3339 time"
+// @Param until query string false "RFC3339 time"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /data-exports/queries/{query} [get]
+func ExportQuery(c *gin.Context) {
+	var query services.DataExportQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.CheckProjectRole(rbac.CurrentUser(c), query.Project, models.PROJECT_ROLE_VIEWER)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	export, err := services.OpenCannedExport(c.Param("query"), &query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	writeExport(c, export, query.GetFormat())
+}
+
+func writeExport(c *gin.Context, export *services.DataExport, format string) {
+	c.Header("Content-Type", exporthelper.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", export.Name, format))
+	c.Status(http.StatusOK)
+	err := export.Write(format, c.Writer)
+	if err != nil {
+		// the headers are sent already, the export is cut short
+		logruslog.Global.Error(err, "failed to export %s", export.Name)
+	}
+}
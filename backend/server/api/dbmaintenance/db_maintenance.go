@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbmaintenance
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedDbMaintenances struct {
+	Maintenances []*models.DbMaintenance `json:"maintenances"`
+	Count        int64                   `json:"count"`
+}
+
+type DbMaintenanceDetail struct {
+	*models.DbMaintenance
+	Tables []*models.DbMaintenanceTable `json:"tables"`
+}
+
+type TableBloats struct {
+	Tables []*services.TableBloat `json:"tables"`
+}
+
+// @Summary Get the database maintenances
+// @Description Get the runs of the job analyzing and reclaiming the free space of the hot raw and domain tables, the latest first
+// @Tags framework/dbmaintenance
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedDbMaintenances
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /db-maintenances [get]
+func Index(c *gin.Context) {
+	var query services.DbMaintenanceQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	maintenances, count, err := services.GetDbMaintenances(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedDbMaintenances{Maintenances: maintenances, Count: count}, http.StatusOK)
+}
+
+// @Summary Start a database maintenance
+// @Description Start analyzing and reclaiming the free space of the hot raw and domain tables now rather than on schedule, poll the returned maintenance for the progress
+// @Tags framework/dbmaintenance
+// @Success 201  {object} models.DbMaintenance
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /db-maintenances [post]
+func Post(c *gin.Context) {
+	maintenance, err := services.StartDbMaintenance(models.DB_MAINTENANCE_TRIGGER_MANUAL)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, maintenance, http.StatusCreated)
+}
+
+// @Summary Get a database maintenance
+// @Description Get a database maintenance along with the tables maintained so far, their bloat statistics before the maintenance and the statements run on them
+// @Tags framework/dbmaintenance
+// @Param maintenanceId path int true "maintenance id"
+// @Success 200  {object} DbMaintenanceDetail
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /db-maintenances/{maintenanceId} [get]
+func Get(c *gin.Context) {
+	maintenanceId, err := strconv.ParseUint(c.Param("maintenanceId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad maintenanceId format supplied"))
+		return
+	}
+	maintenance, tables, err := services.GetDbMaintenance(maintenanceId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, DbMaintenanceDetail{DbMaintenance: maintenance, Tables: tables}, http.StatusOK)
+}
+
+// @Summary Get the bloat statistics of the tables
+// @Description Get the estimated rows, size and reclaimable space (the free bytes of MySQL, the dead rows of PostgreSQL) of the tables, the most bloated first
+// @Tags framework/dbmaintenance
+// @Success 200  {object} TableBloats
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /db-bloat-stats [get]
+func BloatStatsIndex(c *gin.Context) {
+	tables, err := services.GetTableBloatStats()
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, TableBloats{Tables: tables}, http.StatusOK)
+}
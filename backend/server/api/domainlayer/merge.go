@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainlayer
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EntityRedirects struct {
+	Redirects []*crossdomain.EntityRedirect `json:"redirects"`
+	Count     int                           `json:"count"`
+}
+
+/*
+Merge a duplicated repo or board into the entity kept
+POST /domainlayer/merges
+{
+	"entityType": "repos",
+	"fromId": "gitlab:GitlabProject:2:123",
+	"toId": "github:GithubRepo:1:456"
+}
+*/
+// @Summary Merge a duplicated entity
+// @Description Merge a repo or board collected through two connections into the entity kept, the references to the duplicate are redirected every time it is collected again
+// @Tags framework/domainlayer
+// @Accept application/json
+// @Param redirect body crossdomain.EntityRedirect true "json"
+// @Success 201  {object} crossdomain.EntityRedirect
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/merges [post]
+func PostMerge(c *gin.Context) {
+	redirect := &crossdomain.EntityRedirect{}
+	err := c.ShouldBindJSON(redirect)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	redirect, err = services.MergeEntities(redirect)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, redirect, http.StatusCreated)
+}
+
+// @Summary Get the merged entities
+// @Description Get the redirects of the duplicated entities merged into the entities kept
+// @Tags framework/domainlayer
+// @Success 200  {object} EntityRedirects
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/merges [get]
+func MergesIndex(c *gin.Context) {
+	redirects, err := services.GetEntityRedirects()
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, EntityRedirects{Redirects: redirects, Count: len(redirects)}, http.StatusOK)
+}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainlayer
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DomainRowRawData struct {
+	RawData []*services.DomainRowRawData `json:"rawData"`
+	Count   int                          `json:"count"`
+}
+
+/*
+Get the raw api payloads behind the rows of a domain layer table
+GET /domainlayer/raw/:table?id=github:GithubIssue:1:1000
+{
+	"rawData": [
+		{"rawDataTable": "_raw_github_api_issues", "rawDataId": 56, "data": {...}, "retained": true, ...}
+	],
+	"count": 1
+}
+*/
+// @Summary Get the raw data of the rows of a domain layer table
+// @Description Get the raw api payloads the rows matching the column values of the query were extracted from, retained is false if a payload was dropped once extracted
+// @Tags framework/domainlayer
+// @Accept application/json
+// @Param table path string true "domain layer table"
+// @Success 200  {object} DomainRowRawData
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/raw/{table} [get]
+func RawDataIndex(c *gin.Context) {
+	columnValues := make(map[string]string)
+	for column, values := range c.Request.URL.Query() {
+		if len(values) > 0 {
+			columnValues[column] = values[0]
+		}
+	}
+	rawData, err := services.GetDomainRowRawData(c.Param("table"), columnValues)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, DomainRowRawData{RawData: rawData, Count: len(rawData)}, http.StatusOK)
+}
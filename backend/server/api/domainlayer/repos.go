@@ -19,6 +19,7 @@ package domainlayer
 
 import (
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
 	"net/http"
@@ -26,6 +27,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type Repos struct {
+	Repos []*code.Repo `json:"repos"`
+	Count int64        `json:"count"`
+}
+
 /*
 Get all repos from database
 GET /repos
@@ -40,7 +46,7 @@ GET /repos
 // @Description Get all repos from database
 // @Tags framework/domainlayer
 // @Accept application/json
-// @Success 200  {object} gin.H "{"repos": repos, "count": count}"
+// @Success 200  {object} Repos
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /domainlayer/repos [get]
@@ -50,5 +56,5 @@ func ReposIndex(c *gin.Context) {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting repositories"))
 		return
 	}
-	shared.ApiOutputSuccess(c, gin.H{"repos": repos, "count": count}, http.StatusOK)
+	shared.ApiOutputSuccess(c, Repos{Repos: repos, Count: count}, http.StatusOK)
 }
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedKeyRotations struct {
+	Rotations []*models.EncryptionKeyRotation `json:"rotations"`
+	Count     int64                           `json:"count"`
+}
+
+// @Summary Get the encryption key rotations
+// @Description Get the runs of the job re-encrypting the encrypted columns by a new data key, the latest first
+// @Tags framework/encryption
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedKeyRotations
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /encryption/key-rotations [get]
+func KeyRotationsIndex(c *gin.Context) {
+	var query services.EncryptionKeyRotationQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	rotations, count, err := services.GetEncryptionKeyRotations(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedKeyRotations{Rotations: rotations, Count: count}, http.StatusOK)
+}
+
+// @Summary Rotate the encryption key
+// @Description Activate a new data key, wrapped by ENCODE_KEY, and re-encrypt the values of the encrypted columns (the connection credentials, the plans of the blueprints and so on) by it in the background while serving. Poll the returned rotation for the progress, start another one to resume a failed rotation
+// @Tags framework/encryption
+// @Success 201  {object} models.EncryptionKeyRotation
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /encryption/key-rotations [post]
+func PostKeyRotation(c *gin.Context) {
+	rotation, err := services.StartEncryptionKeyRotation()
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, rotation, http.StatusCreated)
+}
+
+// @Summary Get an encryption key rotation
+// @Description Get an encryption key rotation along with its progress
+// @Tags framework/encryption
+// @Param rotationId path int true "rotation id"
+// @Success 200  {object} models.EncryptionKeyRotation
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /encryption/key-rotations/{rotationId} [get]
+func GetKeyRotation(c *gin.Context) {
+	rotationId, err := strconv.ParseUint(c.Param("rotationId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad rotationId format supplied"))
+		return
+	}
+	rotation, err := services.GetEncryptionKeyRotation(rotationId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, rotation, http.StatusOK)
+}
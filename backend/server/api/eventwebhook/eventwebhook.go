@@ -0,0 +1,232 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventwebhook
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedEventDeliveries struct {
+	Deliveries []*models.EventDelivery `json:"deliveries"`
+	Count      int64                   `json:"count"`
+}
+
+// @Summary Get the event webhooks
+// @Description Get the webhooks posted the server events, their secrets are never returned
+// @Tags framework/event-webhooks
+// @Success 200  {object} []models.EventWebhook
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /event-webhooks [get]
+func Index(c *gin.Context) {
+	webhooks, err := services.GetEventWebhooks()
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, webhooks, http.StatusOK)
+}
+
+// @Summary Get an event webhook
+// @Description Get an event webhook
+// @Tags framework/event-webhooks
+// @Param webhookId path int true "webhook id"
+// @Success 200  {object} models.EventWebhook
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /event-webhooks/{webhookId} [get]
+func Get(c *gin.Context) {
+	id, err := webhookId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	webhook, err := services.GetEventWebhook(id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, webhook, http.StatusOK)
+}
+
+/*
+Create an event webhook
+POST /event-webhooks
+{
+	"name": "chatops",
+	"url": "https://chatops.example.com/lake",
+	"secret": "s3cr3t",
+	"events": ["pipeline.failed", "connection.unhealthy"],
+	"maxRetries": 5
+}
+*/
+// @Summary Create an event webhook
+// @Description Create a webhook posted the events it subscribes to, all of them when no event is given:
+// @Description pipeline.completed, pipeline.failed, blueprint.disabled, scope.deleted and connection.unhealthy.
+// @Description The bodies are signed by HMAC-SHA256 with the secret in the X-Lake-Signature-256 header as sha256=<hex digest>.
+// @Tags framework/event-webhooks
+// @Accept application/json
+// @Param webhook body models.ApiInputEventWebhook true "json"
+// @Success 200  {object} models.EventWebhook
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /event-webhooks [post]
+func Post(c *gin.Context) {
+	input := &models.ApiInputEventWebhook{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	webhook, err := services.CreateEventWebhook(input)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, webhook, http.StatusOK)
+}
+
+// @Summary Patch an event webhook
+// @Description Patch an event webhook, the fields left out are kept as they are
+// @Tags framework/event-webhooks
+// @Accept application/json
+// @Param webhookId path int true "webhook id"
+// @Param webhook body models.ApiInputEventWebhook true "json"
+// @Success 200  {object} models.EventWebhook
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /event-webhooks/{webhookId} [patch]
+func Patch(c *gin.Context) {
+	id, err := webhookId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	input := &models.ApiInputEventWebhook{}
+	e := c.ShouldBindJSON(input)
+	if e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+		return
+	}
+	webhook, err := services.PatchEventWebhook(id, input)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, webhook, http.StatusOK)
+}
+
+// @Summary Delete an event webhook
+// @Description Delete an event webhook along with its delivery history
+// @Tags framework/event-webhooks
+// @Param webhookId path int true "webhook id"
+// @Success 200  {object} models.EventWebhook
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /event-webhooks/{webhookId} [delete]
+func Delete(c *gin.Context) {
+	id, err := webhookId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	webhook, err := services.DeleteEventWebhook(id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, webhook, http.StatusOK)
+}
+
+// @Summary Get the deliveries of an event webhook
+// @Description Get the delivery history of an event webhook, the latest first
+// @Tags framework/event-webhooks
+// @Param webhookId path int true "webhook id"
+// @Param status query string false "PENDING, SUCCEEDED or FAILED"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedEventDeliveries
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /event-webhooks/{webhookId}/deliveries [get]
+func GetDeliveries(c *gin.Context) {
+	id, err := webhookId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	var query services.EventDeliveryQuery
+	e := c.ShouldBindQuery(&query)
+	if e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+		return
+	}
+	deliveries, count, err := services.GetEventDeliveries(id, &query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedEventDeliveries{Deliveries: deliveries, Count: count}, http.StatusOK)
+}
+
+// @Summary Redeliver an event
+// @Description Queue a delivery of an event webhook again, with a fresh count of attempts
+// @Tags framework/event-webhooks
+// @Param webhookId path int true "webhook id"
+// @Param deliveryId path int true "delivery id"
+// @Success 200  {object} models.EventDelivery
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /event-webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func PostRedeliver(c *gin.Context) {
+	id, err := webhookId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	deliveryId, e := strconv.ParseUint(c.Param("deliveryId"), 10, 64)
+	if e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, "bad deliveryId format supplied"))
+		return
+	}
+	delivery, err := services.RedeliverEvent(id, deliveryId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, delivery, http.StatusOK)
+}
+
+func webhookId(c *gin.Context) (uint64, errors.Error) {
+	id, err := strconv.ParseUint(c.Param("webhookId"), 10, 64)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "bad webhookId format supplied")
+	}
+	return id, nil
+}
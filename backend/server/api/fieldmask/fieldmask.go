@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmask
+
+import (
+	"strings"
+)
+
+// Mask is a tree of the fields to keep, a nil subtree keeps the whole field
+type Mask map[string]Mask
+
+// Parse reads the comma-separated fields, the nested ones are dotted, i.e. `id,status,tasks.id,tasks.status`
+func Parse(fields string) Mask {
+	mask := Mask{}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		node := mask
+		path := strings.Split(field, ".")
+		for i, name := range path {
+			child, seen := node[name]
+			if seen && child == nil {
+				// the whole field is kept already
+				break
+			}
+			if i == len(path)-1 {
+				node[name] = nil
+				break
+			}
+			if child == nil {
+				child = Mask{}
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	return mask
+}
+
+// Apply keeps the fields of the mask out of the decoded json, the arrays are masked item by item and the values
+// which are not objects are kept as they are
+func (m Mask) Apply(v interface{}) interface{} {
+	if m == nil {
+		return v
+	}
+	switch value := v.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(m))
+		for name, child := range m {
+			if field, ok := value[name]; ok {
+				masked[name] = child.Apply(field)
+			}
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(value))
+		for i, item := range value {
+			masked[i] = m.Apply(item)
+		}
+		return masked
+	}
+	return v
+}
+
+// the fields of the list envelopes, i.e. `{"pipelines": [...], "count": 10, "page": 1, "pageSize": 50}`
+var envelopeFields = map[string]bool{"count": true, "page": true, "pageSize": true}
+
+// ApplyToResponse masks the items of a list envelope and the response itself otherwise
+func (m Mask) ApplyToResponse(v interface{}) interface{} {
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return m.Apply(v)
+	}
+	if _, isList := object["count"]; !isList {
+		return m.Apply(v)
+	}
+	itemsField := ""
+	for name, field := range object {
+		if envelopeFields[name] {
+			continue
+		}
+		if _, isArray := field.([]interface{}); !isArray || itemsField != "" {
+			return m.Apply(v)
+		}
+		itemsField = name
+	}
+	if itemsField == "" {
+		return v
+	}
+	masked := make(map[string]interface{}, len(object))
+	for name, field := range object {
+		masked[name] = field
+	}
+	masked[itemsField] = m.Apply(object[itemsField])
+	return masked
+}
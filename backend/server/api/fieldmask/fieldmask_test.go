@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmask
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	assert.Equal(t, Mask{"id": nil, "tasks": Mask{"id": nil, "progressDetail": Mask{"subTaskName": nil}}},
+		Parse(" id, tasks.id,,tasks.progressDetail.subTaskName"))
+	// a whole field outweighs its nested ones
+	assert.Equal(t, Mask{"tasks": nil}, Parse("tasks.id,tasks"))
+	assert.Equal(t, Mask{"tasks": nil}, Parse("tasks,tasks.id"))
+}
+
+func TestApplyToResponse(t *testing.T) {
+	mask := Parse("id,blueprints.name")
+	list := map[string]interface{}{
+		"scopes": []interface{}{
+			map[string]interface{}{"id": 1, "name": "a", "blueprints": []interface{}{map[string]interface{}{"id": 2, "name": "b"}}},
+			map[string]interface{}{"id": 3, "name": "c", "blueprints": nil},
+		},
+		"count":    2,
+		"page":     1,
+		"pageSize": 10,
+	}
+	assert.Equal(t, map[string]interface{}{
+		"scopes": []interface{}{
+			map[string]interface{}{"id": 1, "blueprints": []interface{}{map[string]interface{}{"name": "b"}}},
+			map[string]interface{}{"id": 3, "blueprints": nil},
+		},
+		"count":    2,
+		"page":     1,
+		"pageSize": 10,
+	}, mask.ApplyToResponse(list))
+
+	// not a list
+	assert.Equal(t, map[string]interface{}{"id": 1}, mask.ApplyToResponse(map[string]interface{}{"id": 1, "name": "a", "count": 3, "other": "x"}))
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": 1}}, mask.ApplyToResponse([]interface{}{map[string]interface{}{"id": 1, "name": "a"}}))
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware)
+	r.GET("/pipelines", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"pipelines": []gin.H{{"id": 9007199254740993, "status": "TASK_COMPLETED", "plan": "huge"}}, "count": 1})
+	})
+	r.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "not found"})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pipelines?fields=id,status", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"pipelines": [{"id": 9007199254740993, "status": "TASK_COMPLETED"}], "count": 1}`, w.Body.String())
+	assert.Contains(t, w.Body.String(), "9007199254740993")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pipelines", nil))
+	assert.JSONEq(t, `{"pipelines": [{"id": 9007199254740993, "status": "TASK_COMPLETED", "plan": "huge"}], "count": 1}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing?fields=id", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"success": false, "message": "not found"}`, w.Body.String())
+}
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmask
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FIELDS_QUERY lists the fields of the partial responses
+const FIELDS_QUERY = "fields"
+
+// bodyRecorder holds the response back so it can be masked
+type bodyRecorder struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bodyRecorder) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bodyRecorder) WriteHeaderNow() {}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bodyRecorder) Status() int {
+	return w.status
+}
+
+func (w *bodyRecorder) Size() int {
+	return w.body.Len()
+}
+
+func (w *bodyRecorder) Written() bool {
+	return w.body.Len() > 0
+}
+
+// Middleware trims the json responses of the GET requests down to the fields given by the `fields` query, i.e.
+// `GET /pipelines?fields=id,status` or `GET /plugins/github/connections/1/scopes?blueprints=true&fields=name,blueprints.name`.
+// The items of the lists are masked, their count and pagination are kept. The failures are left as they are.
+func Middleware(c *gin.Context) {
+	fields := c.Query(FIELDS_QUERY)
+	if fields == "" || c.Request.Method != http.MethodGet {
+		return
+	}
+	writer := c.Writer
+	recorder := &bodyRecorder{ResponseWriter: writer, status: http.StatusOK}
+	c.Writer = recorder
+	c.Next()
+	c.Writer = writer
+
+	body := recorder.body.Bytes()
+	if recorder.status < http.StatusBadRequest && strings.HasPrefix(writer.Header().Get("Content-Type"), "application/json") {
+		var v interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		// keep the big ids intact
+		decoder.UseNumber()
+		if decoder.Decode(&v) == nil {
+			if masked, err := json.Marshal(Parse(fields).ApplyToResponse(v)); err == nil {
+				body = masked
+				writer.Header().Del("Content-Length")
+			}
+		}
+	}
+	writer.WriteHeader(recorder.status)
+	_, _ = writer.Write(body)
+}
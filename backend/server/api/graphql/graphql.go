@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/apache/incubator-devlake/server/services/graphql"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+Query the projects, blueprints, pipelines and the domain aggregates of the projects in one round trip
+POST /graphql
+{
+	"query": "query ($name: String) { project(name: $name) { name blueprint { name latestPipeline { status finishedAt } } summary(since: \"2023-01-01T00:00:00Z\") { deployments incidents } } }",
+	"variables": {"name": "devlake"}
+}
+*/
+// @Summary Run a graphql query
+// @Description Run a graphql query over the projects, blueprints, pipelines, tasks and the domain aggregates of the projects.
+// @Description The root fields are projects, project(name), blueprints, blueprint(id), pipelines and pipeline(id), only the queries are supported.
+// @Description The schema can be introspected, the queries nest at most 15 levels of fields.
+// @Tags framework/graphql
+// @Accept application/json
+// @Param request body graphql.Request true "json"
+// @Success 200  {object} graphql.Response
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /graphql [post]
+func Post(c *gin.Context) {
+	request := &graphql.Request{}
+	err := c.ShouldBindJSON(request)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	execute(c, request)
+}
+
+// @Summary Run a graphql query
+// @Description Run a graphql query passed by the query string, the variables are json encoded
+// @Tags framework/graphql
+// @Param query query string true "the graphql query"
+// @Param operationName query string false "the operation to run"
+// @Param variables query string false "the json encoded variables"
+// @Success 200  {object} graphql.Response
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /graphql [get]
+func Get(c *gin.Context) {
+	request := &graphql.Request{}
+	err := c.ShouldBindQuery(request)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	if variables := c.Query("variables"); variables != "" {
+		err = json.Unmarshal([]byte(variables), &request.Variables)
+		if err != nil {
+			shared.ApiOutputError(c, errors.BadInput.Wrap(err, "the variables should be a json object"))
+			return
+		}
+	}
+	execute(c, request)
+}
+
+func execute(c *gin.Context, request *graphql.Request) {
+	response, err := services.ExecuteGraphql(request)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	// the requests failing validation are not executed at all
+	status := http.StatusOK
+	if response.Data == nil {
+		status = http.StatusBadRequest
+	}
+	shared.ApiOutputSuccess(c, response, status)
+}
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the readiness of the server
+// @Description check the database, the encryption secret, the remote plugins and the pipeline worker, it answers
+// @Description 503 when any of them failed so the probes and the load balancers take the server out of rotation
+// @Tags framework/health
+// @Success 200  {object} services.Health
+// @Failure 503  {object} services.Health "Service Unavailable"
+// @Router /health/ready [get]
+func GetReady(c *gin.Context) {
+	health := services.CheckHealth()
+	status := http.StatusOK
+	if health.Status == services.HEALTH_FAILED {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, health)
+}
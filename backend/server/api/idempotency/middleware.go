@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idempotency
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+	// tells the response is the one recorded for the original request
+	REPLAYED_HEADER = "Idempotent-Replayed"
+)
+
+// responseRecorder keeps a copy of the response body
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Middleware makes the route idempotent for the requests bearing an Idempotency-Key header: the retries of a request
+// get its original response back, flagged by the Idempotent-Replayed header, instead of doing it again. The keys are
+// kept for IDEMPOTENCY_KEY_TTL, 24h by default, and a key is released when its request failed on the server side.
+func Middleware(c *gin.Context) {
+	key := c.GetHeader(IDEMPOTENCY_KEY_HEADER)
+	if key == "" {
+		return
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	requestHash := services.HashIdempotentRequest(c.Request.URL.Path, body)
+	record, replay, e := services.BeginIdempotentRequest(client(c), c.Request.Method+" "+c.FullPath(), key, requestHash)
+	if e != nil {
+		shared.ApiOutputAbort(c, e)
+		return
+	}
+	if replay {
+		c.Header(REPLAYED_HEADER, "true")
+		c.Data(record.Status, "application/json; charset=utf-8", record.Response)
+		c.Abort()
+		return
+	}
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	status := http.StatusInternalServerError
+	// the key is released should the handler panic
+	defer func() {
+		e := services.CompleteIdempotentRequest(record, status, recorder.body.Bytes())
+		if e != nil {
+			logruslog.Global.Error(e, "failed to record the response of the Idempotency-Key %s", key)
+		}
+	}()
+	c.Next()
+	status = c.Writer.Status()
+}
+
+// client tells the clients apart by their api key, their user or else their IP
+func client(c *gin.Context) string {
+	if v, ok := c.Get("apiKey"); ok {
+		return fmt.Sprintf("api-key:%d", v.(*models.ApiKey).ID)
+	}
+	if user := rbac.CurrentUser(c); user != nil {
+		return "user:" + user.Name
+	}
+	return "ip:" + c.ClientIP()
+}
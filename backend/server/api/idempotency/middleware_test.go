@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareWithoutKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	calls := 0
+	r.POST("/pipelines", Middleware, func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"id": calls})
+	})
+	for i := 1; i <= 2; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pipelines", strings.NewReader("{}")))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get(REPLAYED_HEADER))
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/pipelines", nil)
+	c.Request.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "ip:10.0.0.1", client(c))
+	c.Set("apiKey", &models.ApiKey{Model: common.Model{ID: 3}})
+	assert.Equal(t, "api-key:3", client(c))
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
 	"github.com/apache/incubator-devlake/server/services/auth"
 
 	"github.com/gin-gonic/gin"
@@ -49,15 +50,30 @@ func Login(ctx *gin.Context) {
 		return
 	}
 	if res.AuthenticationResult != nil && res.AuthenticationResult.AccessToken != nil {
-		token, err := auth.Provider.CheckAuth(*res.AuthenticationResult.AccessToken)
+		err = syncUser(*res.AuthenticationResult.AccessToken)
 		if err != nil {
 			shared.ApiOutputAbort(ctx, err)
+			return
 		}
-		ctx.Set("token", token)
 	}
 	shared.ApiOutputSuccess(ctx, res, http.StatusOK)
 }
 
+// syncUser registers the user the access token was issued to along with the roles of its groups, the requests
+// carrying the token then only read the user
+func syncUser(accessToken string) errors.Error {
+	token, err := auth.Provider.CheckAuth(accessToken)
+	if err != nil {
+		return err
+	}
+	identity, err := auth.Provider.GetIdentity(token)
+	if err != nil {
+		return err
+	}
+	_, err = services.SyncUser(identity.Name, identity.Email, identity.Groups, identity.IssuedAt)
+	return err
+}
+
 // @Summary post NewPassword
 // @Description post NewPassword
 // @Tags framework/NewPassword
@@ -66,7 +82,7 @@ func Login(ctx *gin.Context) {
 // @Success 200  {object} auth.LoginResponse
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
-// @Router /password [post]
+// @Router /login/newpassword [post]
 func NewPassword(ctx *gin.Context) {
 	newPasswordReq := &auth.NewPasswordRequest{}
 	err := ctx.ShouldBind(newPasswordReq)
@@ -90,7 +106,7 @@ func NewPassword(ctx *gin.Context) {
 // @Success 200  {object} auth.LoginResponse
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
-// @Router /password [post]
+// @Router /login/refreshtoken [post]
 func RefreshToken(ctx *gin.Context) {
 	req := &auth.RefreshTokenRequest{}
 	err := ctx.ShouldBind(req)
@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package login
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ssoCookie         = "devlake_sso"
+	ssoCookieMaxAge   = 10 * time.Minute
+	ssoCallbackPath   = "/login/sso/callback"
+	defaultSsoLanding = "/login"
+)
+
+type LoginOptions struct {
+	// Sso tells whether the users may sign in on the page of the auth provider through /login/sso
+	Sso bool `json:"sso"`
+}
+
+// @Summary get the login options
+// @Description get the ways the users may sign in
+// @Tags framework/login
+// @Success 200  {object} LoginOptions
+// @Router /login/options [get]
+func GetOptions(ctx *gin.Context) {
+	shared.ApiOutputSuccess(ctx, LoginOptions{Sso: auth.RedirectEnabled()}, http.StatusOK)
+}
+
+// @Summary single sign-on
+// @Description redirect to the sign-in page of the auth provider, which redirects back to /login/sso/callback
+// @Tags framework/login
+// @Success 302
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /login/sso [get]
+func Sso(ctx *gin.Context) {
+	provider, ok := auth.Provider.(auth.RedirectProvider)
+	if !ok {
+		shared.ApiOutputError(ctx, errors.BadInput.New("the auth provider does not support the single sign-on"))
+		return
+	}
+	state, err := auth.NewCodeVerifier()
+	if err != nil {
+		shared.ApiOutputError(ctx, err)
+		return
+	}
+	verifier, err := auth.NewCodeVerifier()
+	if err != nil {
+		shared.ApiOutputError(ctx, err)
+		return
+	}
+	// the state and the verifier are kept by the browser until it comes back from the provider
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(ssoCookie, state+"."+verifier, int(ssoCookieMaxAge.Seconds()), "", "", ctx.Request.TLS != nil, true)
+	ctx.Redirect(http.StatusFound, provider.AuthCodeURL(state, verifier, ssoRedirectUrl(ctx)))
+}
+
+// @Summary single sign-on callback
+// @Description trade the code of the auth provider for the tokens, and hand them over to the landing page of
+// @Description OIDC_LANDING_URL in the fragment, i.e. `/login#accessToken=...&refreshToken=...`
+// @Tags framework/login
+// @Param code query string true "the code issued by the provider"
+// @Param state query string true "the state sent to the provider"
+// @Success 302
+// @Router /login/sso/callback [get]
+func SsoCallback(ctx *gin.Context) {
+	fragment := url.Values{}
+	res, err := ssoExchange(ctx)
+	if err == nil {
+		err = syncUser(*res.AuthenticationResult.AccessToken)
+	}
+	if err != nil {
+		logruslog.Global.Error(err, "single sign-on failed")
+		fragment.Set("error", err.Messages().Format())
+	} else {
+		fragment.Set("accessToken", *res.AuthenticationResult.AccessToken)
+		fragment.Set("refreshToken", *res.AuthenticationResult.RefreshToken)
+	}
+	ctx.SetCookie(ssoCookie, "", -1, "", "", ctx.Request.TLS != nil, true)
+	landing := config.GetConfig().GetString("OIDC_LANDING_URL")
+	if landing == "" {
+		landing = defaultSsoLanding
+	}
+	ctx.Redirect(http.StatusFound, landing+"#"+fragment.Encode())
+}
+
+func ssoExchange(ctx *gin.Context) (*auth.LoginResponse, errors.Error) {
+	provider, ok := auth.Provider.(auth.RedirectProvider)
+	if !ok {
+		return nil, errors.BadInput.New("the auth provider does not support the single sign-on")
+	}
+	if providerError := ctx.Query("error"); providerError != "" {
+		return nil, errors.Unauthorized.New(providerError + ": " + ctx.Query("error_description"))
+	}
+	cookie, e := ctx.Cookie(ssoCookie)
+	if e != nil {
+		return nil, errors.Unauthorized.New("the single sign-on expired, please try again")
+	}
+	state, verifier, _ := strings.Cut(cookie, ".")
+	if state == "" || state != ctx.Query("state") {
+		return nil, errors.Unauthorized.New("the state of the single sign-on does not match")
+	}
+	return provider.Exchange(ctx.Query("code"), verifier, ssoRedirectUrl(ctx))
+}
+
+// ssoRedirectUrl returns OIDC_REDIRECT_URL, which has to be set when lake is reached through a proxy rewriting the
+// paths, i.e. `https://lake.example.com/api/login/sso/callback` behind config-ui, or else the callback on this host
+func ssoRedirectUrl(ctx *gin.Context) string {
+	if redirectUrl := config.GetConfig().GetString("OIDC_REDIRECT_URL"); redirectUrl != "" {
+		return redirectUrl
+	}
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := ctx.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + ctx.Request.Host + ssoCallbackPath
+}
@@ -0,0 +1,312 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package management
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// the management api is versioned by its path, the breaking changes go to a new version
+const Prefix = "/management/v1"
+
+func outputPut(c *gin.Context, resource *services.ManagedResource, created bool, err errors.Error) {
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	shared.ApiOutputSuccess(c, resource, status)
+}
+
+func outputGet(c *gin.Context, v interface{}, err errors.Error) {
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, v, http.StatusOK)
+}
+
+func outputDelete(c *gin.Context, err errors.Error) {
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+func bindAttributes(c *gin.Context) (map[string]interface{}, bool) {
+	attributes := make(map[string]interface{})
+	err := c.ShouldBindJSON(&attributes)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return nil, false
+	}
+	return attributes, true
+}
+
+// @Summary List the managed connections of a plugin
+// @Description List the connections of a plugin, identified by `{plugin}/{name}`
+// @Tags framework/management
+// @Param plugin path string true "plugin name"
+// @Success 200  {object} []services.ManagedResource
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/connections/{plugin} [get]
+func ListConnections(c *gin.Context) {
+	connections, err := services.ListManagedConnections(c.Param("plugin"))
+	outputGet(c, connections, err)
+}
+
+// @Summary Get a managed connection
+// @Description Get a connection by its plugin and its name, which is how it is imported
+// @Tags framework/management
+// @Param plugin path string true "plugin name"
+// @Param name path string true "connection name"
+// @Success 200  {object} services.ManagedResource
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/connections/{plugin}/{name} [get]
+func GetConnection(c *gin.Context) {
+	connection, err := services.GetManagedConnection(c.Param("plugin"), c.Param("name"))
+	outputGet(c, connection, err)
+}
+
+// @Summary Put a managed connection
+// @Description Create the connection, or update it with the given attributes when it exists. Putting the same
+// @Description attributes again leaves the connection as it is.
+// @Tags framework/management
+// @Accept application/json
+// @Param plugin path string true "plugin name"
+// @Param name path string true "connection name"
+// @Param attributes body object true "the attributes of the connection of the plugin"
+// @Success 200  {object} services.ManagedResource "updated"
+// @Success 201  {object} services.ManagedResource "created"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/connections/{plugin}/{name} [put]
+func PutConnection(c *gin.Context) {
+	attributes, ok := bindAttributes(c)
+	if !ok {
+		return
+	}
+	connection, created, err := services.PutManagedConnection(c.Param("plugin"), c.Param("name"), attributes)
+	outputPut(c, connection, created, err)
+}
+
+// @Summary Delete a managed connection
+// @Description Delete the connection, it succeeds when the connection does not exist
+// @Tags framework/management
+// @Param plugin path string true "plugin name"
+// @Param name path string true "connection name"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/connections/{plugin}/{name} [delete]
+func DeleteConnection(c *gin.Context) {
+	outputDelete(c, services.DeleteManagedConnection(c.Param("plugin"), c.Param("name")))
+}
+
+// @Summary List the managed scope configs of a connection
+// @Description List the scope configs, i.e. the transformation rules, of a connection, identified by
+// @Description `{plugin}/{connection}/{name}`
+// @Tags framework/management
+// @Param plugin path string true "plugin name"
+// @Param connection path string true "connection name"
+// @Success 200  {object} []services.ManagedResource
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/scope-configs/{plugin}/{connection} [get]
+func ListScopeConfigs(c *gin.Context) {
+	scopeConfigs, err := services.ListManagedScopeConfigs(c.Param("plugin"), c.Param("connection"))
+	outputGet(c, scopeConfigs, err)
+}
+
+// @Summary Get a managed scope config
+// @Description Get a scope config by its plugin, its connection and its name, which is how it is imported
+// @Tags framework/management
+// @Param plugin path string true "plugin name"
+// @Param connection path string true "connection name"
+// @Param name path string true "scope config name"
+// @Success 200  {object} services.ManagedResource
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/scope-configs/{plugin}/{connection}/{name} [get]
+func GetScopeConfig(c *gin.Context) {
+	scopeConfig, err := services.GetManagedScopeConfig(c.Param("plugin"), c.Param("connection"), c.Param("name"))
+	outputGet(c, scopeConfig, err)
+}
+
+// @Summary Put a managed scope config
+// @Description Create the scope config, or update it with the given attributes when it exists
+// @Tags framework/management
+// @Accept application/json
+// @Param plugin path string true "plugin name"
+// @Param connection path string true "connection name"
+// @Param name path string true "scope config name"
+// @Param attributes body object true "the attributes of the transformation rule of the plugin"
+// @Success 200  {object} services.ManagedResource "updated"
+// @Success 201  {object} services.ManagedResource "created"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/scope-configs/{plugin}/{connection}/{name} [put]
+func PutScopeConfig(c *gin.Context) {
+	attributes, ok := bindAttributes(c)
+	if !ok {
+		return
+	}
+	scopeConfig, created, err := services.PutManagedScopeConfig(c.Param("plugin"), c.Param("connection"), c.Param("name"), attributes)
+	outputPut(c, scopeConfig, created, err)
+}
+
+// @Summary Delete a managed scope config
+// @Description Delete the scope config, it succeeds when the scope config does not exist. It fails with 405 when
+// @Description the plugin does not support deleting its scope configs.
+// @Tags framework/management
+// @Param plugin path string true "plugin name"
+// @Param connection path string true "connection name"
+// @Param name path string true "scope config name"
+// @Success 200
+// @Failure 405  {string} errcode.Error "Method Not Allowed"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/scope-configs/{plugin}/{connection}/{name} [delete]
+func DeleteScopeConfig(c *gin.Context) {
+	outputDelete(c, services.DeleteManagedScopeConfig(c.Param("plugin"), c.Param("connection"), c.Param("name")))
+}
+
+// @Summary Put and delete managed scope configs in a batch
+// @Description Put the scope configs under put and delete the ones named under delete in one transaction, nothing
+// @Description is saved when any of them fails and the results tell which one did. It fails with 405 when the plugin
+// @Description does not support batches of scope configs.
+// @Tags framework/management
+// @Accept application/json
+// @Param plugin path string true "plugin name"
+// @Param connection path string true "connection name"
+// @Param batch body services.ManagedScopeConfigBatch true "json"
+// @Success 200  {object} services.ManagedBatchOutput
+// @Failure 400  {object} services.ManagedBatchOutput "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 405  {string} errcode.Error "Method Not Allowed"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/scope-configs/{plugin}/{connection}/batch [post]
+func BatchScopeConfigs(c *gin.Context) {
+	batch := &services.ManagedScopeConfigBatch{}
+	err := c.ShouldBindJSON(batch)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	output, err := services.BatchManagedScopeConfigs(c.Param("plugin"), c.Param("connection"), batch)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	status := http.StatusOK
+	if !output.Applied {
+		status = http.StatusBadRequest
+	}
+	shared.ApiOutputSuccess(c, output, status)
+}
+
+// @Summary Get a managed project
+// @Description Get a project by its name, which is how it is imported
+// @Tags framework/management
+// @Param name path string true "project name"
+// @Success 200  {object} services.ManagedResource
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/projects/{name} [get]
+func GetProject(c *gin.Context) {
+	project, err := services.GetManagedProject(c.Param("name"))
+	outputGet(c, project, err)
+}
+
+// @Summary Put a managed project
+// @Description Create the project, or update it with the given attributes when it exists
+// @Tags framework/management
+// @Accept application/json
+// @Param name path string true "project name"
+// @Param attributes body models.ApiInputProject true "json"
+// @Success 200  {object} services.ManagedResource "updated"
+// @Success 201  {object} services.ManagedResource "created"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/projects/{name} [put]
+func PutProject(c *gin.Context) {
+	attributes, ok := bindAttributes(c)
+	if !ok {
+		return
+	}
+	project, created, err := services.PutManagedProject(c.Param("name"), attributes)
+	outputPut(c, project, created, err)
+}
+
+// @Summary Delete a managed project
+// @Description Delete the project along with its blueprint, it succeeds when the project does not exist
+// @Tags framework/management
+// @Param name path string true "project name"
+// @Success 200
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/projects/{name} [delete]
+func DeleteProject(c *gin.Context) {
+	outputDelete(c, services.DeleteManagedProject(c.Param("name")))
+}
+
+// @Summary Get the managed blueprint of a project
+// @Description Get the blueprint of a project, identified by the name of the project
+// @Tags framework/management
+// @Param name path string true "project name"
+// @Success 200  {object} services.ManagedResource
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/projects/{name}/blueprint [get]
+func GetBlueprint(c *gin.Context) {
+	blueprint, err := services.GetManagedBlueprint(c.Param("name"))
+	outputGet(c, blueprint, err)
+}
+
+// @Summary Put the managed blueprint of a project
+// @Description Create the blueprint of the project, or update it with the given attributes when it exists.
+// @Description A new blueprint is named after the project, in the NORMAL mode and manual unless told otherwise.
+// @Tags framework/management
+// @Accept application/json
+// @Param name path string true "project name"
+// @Param attributes body models.Blueprint true "json"
+// @Success 200  {object} services.ManagedResource "updated"
+// @Success 201  {object} services.ManagedResource "created"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /management/v1/projects/{name}/blueprint [put]
+func PutBlueprint(c *gin.Context) {
+	attributes, ok := bindAttributes(c)
+	if !ok {
+		return
+	}
+	blueprint, created, err := services.PutManagedBlueprint(c.Param("name"), attributes)
+	outputPut(c, blueprint, created, err)
+}
@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var handler = promhttp.Handler()
+
+// @Summary Get server metrics
+// @Description return all metrics registered to the default prometheus registry in the text exposition format,
+// @Description the endpoint is protected by the same authentication as the rest of the api
+// @Tags framework/metrics
+// @Produce plain
+// @Success 200  {string} string ""
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Router /metrics [get]
+func Get(c *gin.Context) {
+	handler.ServeHTTP(c.Writer, c.Request)
+}
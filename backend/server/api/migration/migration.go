@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the migration states
+// @Description Get the progress and checkpoint of the resumable migration scripts, the running ones first
+// @Tags framework/migrations
+// @Success 200  {object} []migration.MigrationState
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /migrations/states [get]
+func StatesIndex(c *gin.Context) {
+	states, err := services.GetMigrationStates()
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, states, http.StatusOK)
+}
+
+// @Summary Roll back a migration script
+// @Description Revert a migration script by the rollback recorded along with its history, only the latest script of a plugin (or of the framework) can be rolled back, and it gets applied again on the next startup
+// @Tags framework/migrations
+// @Accept application/json
+// @Param rollback body services.MigrationRollback true "the migration script"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /migrations/rollback [post]
+func PostRollback(c *gin.Context) {
+	rollback := &services.MigrationRollback{}
+	err := c.ShouldBindJSON(rollback)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.RollbackMigration(rollback)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
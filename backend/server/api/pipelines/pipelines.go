@@ -18,26 +18,37 @@ limitations under the License.
 package pipelines
 
 import (
+	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/rbac"
 	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/api/workspace"
 	"github.com/apache/incubator-devlake/server/services"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 )
 
 // @Summary Create and run a new pipeline
-// @Description Create and run a new pipeline
+// @Description Create and run a new pipeline, the retries bearing the Idempotency-Key of the original request get its
+// @Description response back instead of creating another pipeline
 // @Tags framework/pipelines
 // @Accept application/json
 // @Param pipeline body models.NewPipeline true "json"
+// @Param Idempotency-Key header string false "a unique key of the request, kept for IDEMPOTENCY_KEY_TTL"
 // @Success 200  {object} models.Pipeline
 // @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 409  {string} errcode.Error "The original request is still in progress"
+// @Failure 422  {string} errcode.Error "The Idempotency-Key was used for another request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /pipelines [post]
 func Post(c *gin.Context) {
@@ -67,6 +78,8 @@ func Post(c *gin.Context) {
 // @Param pagesize query int false "pagesize"
 // @Param blueprint_id query int false "blueprint_id"
 // @Param label query string false "label"
+// @Param sort query string false "comma separated fields to sort by, prefixed by - for the descending order, i.e. -beginAt"
+// @Param fields query string false "comma separated fields to return, the nested ones dotted, i.e. id,status,plan"
 // @Success 200  {object} shared.ResponsePipelines
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
@@ -78,12 +91,32 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.List, err = helper.ParseListQuery(c.Request.URL.Query(), helper.ListFields(&models.Pipeline{}))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	query.ProjectNames, err = services.VisibleProjectNames(rbac.CurrentUser(c))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	query.ProjectNames, err = services.WorkspaceProjectNames(workspace.Current(c), query.ProjectNames)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	pipelines, count, err := services.GetPipelines(&query)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipelines"))
 		return
 	}
-	shared.ApiOutputSuccess(c, shared.ResponsePipelines{Pipelines: pipelines, Count: count}, http.StatusOK)
+	shared.ApiOutputSuccess(c, shared.ResponsePipelines{
+		Pipelines: pipelines,
+		Count:     count,
+		Page:      query.GetPage(),
+		PageSize:  query.GetPageSize(),
+	}, http.StatusOK)
 }
 
 // @Summary Get detail of a pipeline
@@ -168,6 +201,89 @@ func DownloadLogs(c *gin.Context) {
 	c.FileAttachment(archive, filepath.Base(archive))
 }
 
+// how long a log stream may stay silent before a keep-alive comment is sent
+const logStreamKeepAlive = 15 * time.Second
+
+// @Summary stream the log of a task
+// @Description Tail the log of a task over server-sent events, every line is a `log` event whose id is the cursor
+// @Description to resume from, sent back in the Last-Event-ID header or the cursor query on reconnection.
+// @Description The stream ends with an `end` event once the task is done.
+// @Tags framework/pipelines
+// @Produce text/event-stream
+// @Param pipelineId path int true "pipeline id"
+// @Param taskId path int true "task id"
+// @Param cursor query int false "the offset to resume from, the start of the log by default"
+// @Success 200  "The event stream"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Pipeline or Task not found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/tasks/{taskId}/logs/stream [get]
+func StreamTaskLog(c *gin.Context) {
+	pipelineId, err := strconv.ParseUint(c.Param("pipelineId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipeline ID format supplied"))
+		return
+	}
+	taskId, err := strconv.ParseUint(c.Param("taskId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad task ID format supplied"))
+		return
+	}
+	cursor := int64(0)
+	if lastEventId := c.GetHeader("Last-Event-ID"); lastEventId != "" {
+		cursor, err = strconv.ParseInt(lastEventId, 10, 64)
+	} else if c.Query("cursor") != "" {
+		cursor, err = strconv.ParseInt(c.Query("cursor"), 10, 64)
+	}
+	if err != nil || cursor < 0 {
+		shared.ApiOutputError(c, errors.BadInput.New("bad cursor supplied"))
+		return
+	}
+	task, e := services.GetPipelineTask(pipelineId, taskId)
+	if e != nil {
+		shared.ApiOutputError(c, e)
+		return
+	}
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// keep the proxies from buffering the events
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	write := func(format string, args ...interface{}) errors.Error {
+		_, err := fmt.Fprintf(c.Writer, format, args...)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		c.Writer.Flush()
+		return nil
+	}
+	lastWrite := time.Now()
+	e = write("retry: 3000\n\n")
+	if e == nil {
+		e = services.TailTaskLog(c.Request.Context(), task, cursor,
+			func(line services.TaskLogLine) errors.Error {
+				lastWrite = time.Now()
+				// a line of the log never holds a line break, but stay on the safe side
+				return write("id: %d\nevent: log\ndata: %s\n\n", line.Cursor, strings.ReplaceAll(line.Text, "\n", "\ndata: "))
+			},
+			func() errors.Error {
+				if time.Since(lastWrite) < logStreamKeepAlive {
+					return nil
+				}
+				lastWrite = time.Now()
+				return write(": keep-alive\n\n")
+			},
+		)
+	}
+	if e == nil && c.Request.Context().Err() == nil {
+		e = write("event: end\ndata: %s\n\n", task.Status)
+	}
+	if e != nil {
+		logruslog.Global.Error(e, "error streaming the log of task #%d", taskId)
+	}
+}
+
 // RerunPipeline rerun all failed tasks of the specified pipeline
 // @Summary rerun tasks
 // @Tags framework/pipelines
@@ -20,7 +20,10 @@ package project
 import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/server/api/rbac"
 	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/api/workspace"
 	"github.com/apache/incubator-devlake/server/services"
 	"net/http"
 
@@ -30,6 +33,8 @@ import (
 type PaginatedProjects struct {
 	Projects []*models.Project `json:"projects"`
 	Count    int64             `json:"count"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"pageSize"`
 }
 
 // @Summary Create and run a new project
@@ -57,6 +62,7 @@ func GetProject(c *gin.Context) {
 // @Tags framework/projects
 // @Param page query int true "query"
 // @Param pageSize query int true "query"
+// @Param sort query string false "comma separated fields to sort by, prefixed by - for the descending order, i.e. name"
 // @Success 200  {object} PaginatedProjects
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
@@ -68,6 +74,21 @@ func GetProjects(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.List, err = helper.ParseListQuery(c.Request.URL.Query(), helper.ListFields(&models.Project{}))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	query.Names, err = services.VisibleProjectNames(rbac.CurrentUser(c))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	query.Names, err = services.WorkspaceProjectNames(workspace.Current(c), query.Names)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	projects, count, err := services.GetProjects(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting projects"))
@@ -77,6 +98,8 @@ func GetProjects(c *gin.Context) {
 	shared.ApiOutputSuccess(c, PaginatedProjects{
 		Projects: projects,
 		Count:    count,
+		Page:     query.GetPage(),
+		PageSize: query.GetPageSize(),
 	}, http.StatusOK)
 }
 
@@ -96,12 +119,22 @@ func PostProject(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	// the projects created by the requests of a workspace belong to it
+	if ws := workspace.Current(c); ws != "" {
+		projectInput.Workspace = ws
+	}
 
 	projectOutput, err := services.CreateProject(projectInput)
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "error creating project"))
 		return
 	}
+	// the creator manages the project from now on
+	err = services.BindProjectCreator(rbac.CurrentUser(c), projectOutput.Name)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 
 	shared.ApiOutputSuccess(c, projectOutput, http.StatusCreated)
 }
@@ -27,6 +27,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type PushResponse struct {
+	RowsAffected int64 `json:"rowsAffected"`
+}
+
 /*
 	POST /push/:tableName
 	[
@@ -42,7 +46,7 @@ import (
 // @Accept application/json
 // @Param tableName path string true "table name"
 // @Param data body string true "data"
-// @Success 200  {object} gin.H "{"rowsAffected": rowsAffected}"
+// @Success 200  {object} PushResponse
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /push/{tableName} [post]
@@ -60,5 +64,5 @@ func Post(c *gin.Context) {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error inserting request body into table %s", tableName)))
 		return
 	}
-	shared.ApiOutputSuccess(c, gin.H{"rowsAffected": rowsAffected}, http.StatusOK)
+	shared.ApiOutputSuccess(c, PushResponse{RowsAffected: rowsAffected}, http.StatusOK)
 }
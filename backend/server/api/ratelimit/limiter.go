@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// the buckets left untouched for this long are full again, so they are dropped to bound the memory
+const idleBucketTtl = 10 * time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Limiter is a token bucket per client, refilled at perMinute tokens a minute up to burst tokens
+type Limiter struct {
+	perMinute float64
+	burst     float64
+	now       func() time.Time
+
+	lock    sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// Decision is the outcome of a request against the limiter
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, zero when it is
+	RetryAfter time.Duration
+}
+
+// NewLimiter returns a limiter allowing perMinute requests a minute to each client, and burst of them at once. The
+// burst defaults to perMinute.
+func NewLimiter(perMinute int, burst int) *Limiter {
+	if burst <= 0 {
+		burst = perMinute
+	}
+	return &Limiter{
+		perMinute: float64(perMinute),
+		burst:     float64(burst),
+		now:       time.Now,
+		buckets:   make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of the client if there is one left
+func (l *Limiter) Allow(client string) Decision {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.updated).Minutes()*l.perMinute)
+	b.updated = now
+	decision := Decision{Limit: int(l.burst)}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = l.durationOf(1 - b.tokens)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.Reset = l.durationOf(l.burst - b.tokens)
+	return decision
+}
+
+// durationOf returns how long the bucket takes to refill the given tokens
+func (l *Limiter) durationOf(tokens float64) time.Duration {
+	return time.Duration(tokens / l.perMinute * float64(time.Minute))
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < idleBucketTtl {
+		return
+	}
+	for client, b := range l.buckets {
+		if now.Sub(b.updated) > idleBucketTtl {
+			delete(l.buckets, client)
+		}
+	}
+	l.swept = now
+}
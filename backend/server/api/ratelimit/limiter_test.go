@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2023, 7, 12, 0, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(60, 2)
+	limiter.now = func() time.Time { return now }
+
+	decision := limiter.Allow("a")
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Limit)
+	assert.Equal(t, 1, decision.Remaining)
+	assert.Equal(t, time.Second, decision.Reset)
+	assert.True(t, limiter.Allow("a").Allowed)
+	decision = limiter.Allow("a")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.Equal(t, time.Second, decision.RetryAfter)
+	// the other clients have their own bucket
+	assert.True(t, limiter.Allow("b").Allowed)
+
+	// a token a second is refilled, up to the burst
+	now = now.Add(time.Second)
+	assert.True(t, limiter.Allow("a").Allowed)
+	assert.False(t, limiter.Allow("a").Allowed)
+	now = now.Add(time.Hour)
+	assert.True(t, limiter.Allow("a").Allowed)
+	assert.True(t, limiter.Allow("a").Allowed)
+	assert.False(t, limiter.Allow("a").Allowed)
+	// the idle buckets were dropped
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestParseRouteLimits(t *testing.T) {
+	limiters, err := parseRouteLimits(`{"POST /pipelines": 10}`)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(10), limiters["POST /pipelines"].perMinute)
+	_, err = parseRouteLimits(`{"POST /pipelines": 0}`)
+	assert.NotNil(t, err)
+	_, err = parseRouteLimits(`[10]`)
+	assert.NotNil(t, err)
+}
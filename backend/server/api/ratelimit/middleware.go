@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware limits the requests of each client, i.e. of each api key or else of each IP, to API_RATE_LIMIT a
+// minute with bursts of API_RATE_LIMIT_BURST. The routes of API_RATE_LIMIT_ROUTES, i.e. `{"POST /pipelines": 10}`,
+// are limited on their own as well. The requests over the limit are rejected with 429.
+// It runs ahead of the api key authentication so that the requests with a missing or a wrong key are limited too,
+// the clients are told apart by their IP as long as no key was resolved.
+func Middleware() gin.HandlerFunc {
+	v := config.GetConfig()
+	var limiter *Limiter
+	if perMinute := v.GetInt("API_RATE_LIMIT"); perMinute > 0 {
+		limiter = NewLimiter(perMinute, v.GetInt("API_RATE_LIMIT_BURST"))
+	}
+	routeLimiters, err := parseRouteLimits(v.GetString("API_RATE_LIMIT_ROUTES"))
+	if err != nil {
+		panic(err)
+	}
+	return func(c *gin.Context) {
+		client := clientOf(c)
+		if limiter != nil && !allow(c, limiter, client) {
+			return
+		}
+		if routeLimiter, ok := routeLimiters[c.Request.Method+" "+c.FullPath()]; ok {
+			allow(c, routeLimiter, client)
+		}
+	}
+}
+
+func parseRouteLimits(value string) (map[string]*Limiter, errors.Error) {
+	limiters := make(map[string]*Limiter)
+	if strings.TrimSpace(value) == "" {
+		return limiters, nil
+	}
+	routeLimits := make(map[string]int)
+	err := json.Unmarshal([]byte(value), &routeLimits)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "API_RATE_LIMIT_ROUTES is not a map of the routes to their limits a minute")
+	}
+	for route, perMinute := range routeLimits {
+		if perMinute <= 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("the limit of %s has to be positive", route))
+		}
+		limiters[route] = NewLimiter(perMinute, 0)
+	}
+	return limiters, nil
+}
+
+// clientOf tells the clients apart by their api key once resolved, or else by their IP
+func clientOf(c *gin.Context) string {
+	if v, ok := c.Get("apiKey"); ok {
+		return fmt.Sprintf("key:%d", v.(*models.ApiKey).ID)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func allow(c *gin.Context, limiter *Limiter, client string) bool {
+	decision := limiter.Allow(client)
+	c.Header("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(decision.Reset.Seconds()))))
+	if decision.Allowed {
+		return true
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+	shared.ApiOutputAbort(c, errors.HttpStatus(http.StatusTooManyRequests).New("too many requests, please retry later"))
+	return false
+}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawdata
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedRawDataCompactions struct {
+	Compactions []*models.RawDataCompaction `json:"compactions"`
+	Count       int64                       `json:"count"`
+}
+
+// @Summary Get the raw data compactions
+// @Description Get the runs of the job deleting the raw data superseded by a later collection of the same url, the latest first
+// @Tags framework/rawdata
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedRawDataCompactions
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/compactions [get]
+func CompactionsIndex(c *gin.Context) {
+	var query services.RawDataCompactionQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	compactions, count, err := services.GetRawDataCompactions(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedRawDataCompactions{Compactions: compactions, Count: count}, http.StatusOK)
+}
+
+// @Summary Start a raw data compaction
+// @Description Start deleting the raw data superseded by a later collection of the same url and input for the same scope, keeping the latest version of each record, and reclaiming the space of the compacted raw tables. Poll the returned compaction for the progress
+// @Tags framework/rawdata
+// @Success 201  {object} models.RawDataCompaction
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/compactions [post]
+func PostCompaction(c *gin.Context) {
+	compaction, err := services.StartRawDataCompaction(models.RAW_DATA_COMPACTION_TRIGGER_MANUAL)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, compaction, http.StatusCreated)
+}
+
+// @Summary Get a raw data compaction
+// @Description Get a raw data compaction along with its progress
+// @Tags framework/rawdata
+// @Param compactionId path int true "compaction id"
+// @Success 200  {object} models.RawDataCompaction
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/compactions/{compactionId} [get]
+func GetCompaction(c *gin.Context) {
+	compactionId, err := strconv.ParseUint(c.Param("compactionId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad compactionId format supplied"))
+		return
+	}
+	compaction, err := services.GetRawDataCompaction(compactionId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, compaction, http.StatusOK)
+}
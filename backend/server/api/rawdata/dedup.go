@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawdata
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the raw data dedup stats
+// @Description Get how many of the api payloads collected for the streams (the raw tables) of the scopes were skipped because an identical payload was stored already, along with the totals and the dedup ratios
+// @Tags framework/rawdata
+// @Param plugin query string false "the plugin name"
+// @Param rawDataTable query string false "the raw table of the stream"
+// @Param rawDataParams query string false "the raw data params of the scope"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} services.RawDataDedupStats
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/dedup-stats [get]
+func DedupStatsIndex(c *gin.Context) {
+	var query services.RawDataDedupStatQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	stats, err := services.GetRawDataDedupStats(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, stats, http.StatusOK)
+}
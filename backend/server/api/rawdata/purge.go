@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawdata
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedRawDataPurges struct {
+	Purges []*models.RawDataPurge `json:"purges"`
+	Count  int64                  `json:"count"`
+}
+
+// @Summary Get the raw data purges
+// @Description Get the runs of the job deleting the raw data past the retentionDays of their retention, the latest first
+// @Tags framework/rawdata
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedRawDataPurges
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/purges [get]
+func PurgesIndex(c *gin.Context) {
+	var query services.RawDataPurgeQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	purges, count, err := services.GetRawDataPurges(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedRawDataPurges{Purges: purges, Count: count}, http.StatusOK)
+}
+
+// @Summary Start a raw data purge
+// @Description Start purging the raw data past the retentionDays of their retention now rather than on schedule, poll the returned purge for the progress
+// @Tags framework/rawdata
+// @Success 201  {object} models.RawDataPurge
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/purges [post]
+func PostPurge(c *gin.Context) {
+	purge, err := services.StartRawDataPurge(models.RAW_DATA_PURGE_TRIGGER_MANUAL)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, purge, http.StatusCreated)
+}
+
+// @Summary Get a raw data purge
+// @Description Get a raw data purge along with its progress
+// @Tags framework/rawdata
+// @Param purgeId path int true "purge id"
+// @Success 200  {object} models.RawDataPurge
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/purges/{purgeId} [get]
+func GetPurge(c *gin.Context) {
+	purgeId, err := strconv.ParseUint(c.Param("purgeId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad purgeId format supplied"))
+		return
+	}
+	purge, err := services.GetRawDataPurge(purgeId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, purge, http.StatusOK)
+}
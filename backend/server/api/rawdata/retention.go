@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawdata
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RawDataRetentions struct {
+	Retentions []*models.RawDataRetention `json:"retentions"`
+	Count      int                        `json:"count"`
+}
+
+// @Summary Get the raw data retentions
+// @Description Get the scopes whose raw data retention was toggled, the raw data of the other scopes is kept
+// @Tags framework/rawdata
+// @Success 200  {object} RawDataRetentions
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/retentions [get]
+func RetentionsIndex(c *gin.Context) {
+	retentions, err := services.GetRawDataRetentions()
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, RawDataRetentions{Retentions: retentions, Count: len(retentions)}, http.StatusOK)
+}
+
+/*
+Keep or drop the raw api payloads of a scope once they are extracted, and purge the kept ones after retentionDays
+PUT /raw-data/retentions
+{
+	"plugin": "github",
+	"rawDataParams": "{\"ConnectionId\":1,\"Name\":\"apache/incubator-devlake\"}",
+	"keepRawData": true,
+	"retentionDays": 90
+}
+*/
+// @Summary Set the raw data retention of a scope or plugin
+// @Description Keep or drop the raw api payloads of a scope, identified by the plugin and the _raw_data_params of its rows, once they are extracted, and how many days the kept ones last. Empty rawDataParams set the retention of the scopes of the plugin without one
+// @Tags framework/rawdata
+// @Accept application/json
+// @Param retention body models.RawDataRetention true "json"
+// @Success 200  {object} models.RawDataRetention
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/retentions [put]
+func PutRetention(c *gin.Context) {
+	retention := &models.RawDataRetention{}
+	err := c.ShouldBindJSON(retention)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	retention, err = services.SaveRawDataRetention(retention)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, retention, http.StatusOK)
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawdata
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the payload of a raw row
+// @Description Get the api payload of a row of a raw table as it was collected, the oversized payloads kept in the blob store (see BLOB_STORE_URL) are read from there
+// @Tags framework/rawdata
+// @Produce json
+// @Param table path string true "the raw table, e.g. _raw_github_api_issues"
+// @Param rowId path int true "the id of the row"
+// @Success 200  {object} object "the payload"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /raw-data/tables/{table}/rows/{rowId}/data [get]
+func GetRowData(c *gin.Context) {
+	rowId, err := strconv.ParseUint(c.Param("rowId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad rowId format supplied"))
+		return
+	}
+	row, err := services.GetRawDataRow(c.Param("table"), rowId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", row.Data)
+}
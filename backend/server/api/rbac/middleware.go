@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/apache/incubator-devlake/server/services/auth"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware resolves the user the request acts on behalf of and checks its roles grant the route. The requests
+// authenticated by an api key without any user, or not authenticated at all, are left to the api key scopes.
+func Middleware(c *gin.Context) {
+	user, err := resolveUser(c)
+	if err != nil {
+		shared.ApiOutputAbort(c, err)
+		return
+	}
+	if user == nil {
+		return
+	}
+	c.Set("user", user)
+	params := make(map[string]string, len(c.Params))
+	for _, param := range c.Params {
+		params[param.Key] = param.Value
+	}
+	err = services.AuthorizeRequest(user, c.Request.Method, c.FullPath(), params)
+	if err != nil {
+		shared.ApiOutputAbort(c, err)
+	}
+}
+
+// CurrentUser returns the user the request acts on behalf of, nil when the roles do not apply to it
+func CurrentUser(c *gin.Context) *models.User {
+	if user, ok := c.Get("user"); ok {
+		return user.(*models.User)
+	}
+	return nil
+}
+
+func resolveUser(c *gin.Context) (*models.User, errors.Error) {
+	if v, ok := c.Get("apiKey"); ok {
+		apiKey := v.(*models.ApiKey)
+		if apiKey.UserId == nil {
+			return nil, nil
+		}
+		user, err := services.GetUser(*apiKey.UserId)
+		if err != nil {
+			return nil, errors.Unauthorized.Wrap(err, "the user of the api key is gone")
+		}
+		return user, nil
+	}
+	if v, ok := c.Get("token"); ok {
+		identity, err := auth.Provider.GetIdentity(v.(*jwt.Token))
+		if err != nil {
+			return nil, err
+		}
+		// the user is synced at the sign-in, the requests only sync it again for the tokens issued since then
+		return services.GetTokenUser(identity.Name, identity.Email, identity.Groups, identity.IssuedAt)
+	}
+	return nil, nil
+}
@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedRoleBindings struct {
+	RoleBindings []*models.ProjectRoleBinding `json:"roleBindings"`
+	Count        int64                        `json:"count"`
+}
+
+// @Summary Get the role bindings
+// @Description Get the roles granted on the projects, the members of a project may list its bindings, only the admins may list them all
+// @Tags framework/role-bindings
+// @Param projectName query string false "project name"
+// @Param userId query int false "user id"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedRoleBindings
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /role-bindings [get]
+func GetRoleBindings(c *gin.Context) {
+	var query services.ProjectRoleBindingQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	user := CurrentUser(c)
+	if user != nil && !services.IsAdminUser(user) {
+		if query.ProjectName == "" {
+			shared.ApiOutputError(c, errors.Forbidden.New("only the admins are granted the bindings of all the projects"))
+			return
+		}
+		err = services.CheckProjectRole(user, query.ProjectName, models.PROJECT_ROLE_VIEWER)
+		if err != nil {
+			shared.ApiOutputError(c, err)
+			return
+		}
+	}
+	bindings, count, err := services.GetProjectRoleBindings(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedRoleBindings{RoleBindings: bindings, Count: count}, http.StatusOK)
+}
+
+/*
+Grant a role on a project to a user
+PUT /role-bindings
+{
+	"projectName": "team-a",
+	"userId": 2,
+	"role": "maintainer"
+}
+*/
+// @Summary Grant a role on a project
+// @Description Grant the role admin, maintainer or viewer on a project to a user, replacing the role it had.
+// @Description Only the admins of the project may manage its bindings.
+// @Tags framework/role-bindings
+// @Accept application/json
+// @Param binding body models.ProjectRoleBinding true "json"
+// @Success 200  {object} models.ProjectRoleBinding
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /role-bindings [put]
+func PutRoleBinding(c *gin.Context) {
+	binding := &models.ProjectRoleBinding{}
+	err := c.ShouldBindJSON(binding)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.CheckProjectRole(CurrentUser(c), binding.ProjectName, models.PROJECT_ROLE_ADMIN)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	err = services.PutProjectRoleBinding(binding)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, binding, http.StatusOK)
+}
+
+// @Summary Revoke a role on a project
+// @Description Revoke the role of a user on a project, only the admins of the project may manage its bindings
+// @Tags framework/role-bindings
+// @Param projectName query string true "project name"
+// @Param userId query int true "user id"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /role-bindings [delete]
+func DeleteRoleBinding(c *gin.Context) {
+	var query services.ProjectRoleBindingQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil || query.ProjectName == "" || query.UserId == 0 {
+		shared.ApiOutputError(c, errors.BadInput.New("projectName and userId are required"))
+		return
+	}
+	err = services.CheckProjectRole(CurrentUser(c), query.ProjectName, models.PROJECT_ROLE_ADMIN)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	err = services.DeleteProjectRoleBinding(query.ProjectName, query.UserId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
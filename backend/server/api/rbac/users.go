@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedUsers struct {
+	Users []*models.User `json:"users"`
+	Count int64          `json:"count"`
+}
+
+// @Summary Get the users
+// @Description Get the users the project roles may be granted to
+// @Tags framework/users
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedUsers
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /users [get]
+func GetUsers(c *gin.Context) {
+	var query services.UserQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	users, count, err := services.GetUsers(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedUsers{Users: users, Count: count}, http.StatusOK)
+}
+
+/*
+Create a user
+POST /users
+{
+	"name": "jane",
+	"email": "jane@example.com",
+	"isAdmin": false
+}
+*/
+// @Summary Create a user
+// @Description Create a user, typically to own api keys. The users of the auth provider are created on their first request.
+// @Tags framework/users
+// @Accept application/json
+// @Param user body models.ApiInputUser true "json"
+// @Success 200  {object} models.User
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /users [post]
+func PostUser(c *gin.Context) {
+	input := &models.ApiInputUser{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	user, err := services.CreateUser(input)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, user, http.StatusOK)
+}
+
+// @Summary Delete a user
+// @Description Delete a user along with its role bindings, the api keys of the user are revoked
+// @Tags framework/users
+// @Param userId path int true "user id"
+// @Success 200  {object} models.User
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /users/{userId} [delete]
+func DeleteUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad userId format supplied"))
+		return
+	}
+	user, err := services.DeleteUser(id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, user, http.StatusOK)
+}
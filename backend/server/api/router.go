@@ -18,19 +18,46 @@ limitations under the License.
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"reflect"
 	"strings"
 
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/server/api/activity"
+	"github.com/apache/incubator-devlake/server/api/apikey"
+	"github.com/apache/incubator-devlake/server/api/archive"
+	"github.com/apache/incubator-devlake/server/api/auditlog"
 	"github.com/apache/incubator-devlake/server/api/blueprints"
+	"github.com/apache/incubator-devlake/server/api/collectorstate"
+	"github.com/apache/incubator-devlake/server/api/configbundle"
+	"github.com/apache/incubator-devlake/server/api/dataexport"
+	"github.com/apache/incubator-devlake/server/api/dbmaintenance"
 	"github.com/apache/incubator-devlake/server/api/domainlayer"
+	"github.com/apache/incubator-devlake/server/api/encryption"
+	"github.com/apache/incubator-devlake/server/api/eventwebhook"
+	"github.com/apache/incubator-devlake/server/api/graphql"
+	"github.com/apache/incubator-devlake/server/api/idempotency"
+	"github.com/apache/incubator-devlake/server/api/management"
+	"github.com/apache/incubator-devlake/server/api/migration"
 	"github.com/apache/incubator-devlake/server/api/pipelines"
 	"github.com/apache/incubator-devlake/server/api/plugininfo"
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
+	"github.com/apache/incubator-devlake/server/api/rawdata"
+	"github.com/apache/incubator-devlake/server/api/rbac"
+	"github.com/apache/incubator-devlake/server/api/safequery"
+	"github.com/apache/incubator-devlake/server/api/settings"
 	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/api/slowquery"
 	"github.com/apache/incubator-devlake/server/api/task"
+	"github.com/apache/incubator-devlake/server/api/workspace"
 	"github.com/apache/incubator-devlake/server/services"
 
 	"github.com/gin-gonic/gin"
@@ -38,10 +65,11 @@ import (
 
 func RegisterRouter(r *gin.Engine) {
 	r.GET("/pipelines", pipelines.Index)
-	r.POST("/pipelines", pipelines.Post)
+	r.POST("/pipelines", idempotency.Middleware, pipelines.Post)
 	r.GET("/pipelines/:pipelineId", pipelines.Get)
 	r.PATCH("/blueprints/:blueprintId", blueprints.Patch)
-	r.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
+	r.POST("/blueprints/:blueprintId/trigger", idempotency.Middleware, blueprints.Trigger)
+	r.POST("/blueprints/:blueprintId/backfill", idempotency.Middleware, blueprints.Backfill)
 	// r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
 
 	r.GET("/blueprints", blueprints.Index)
@@ -54,11 +82,54 @@ func RegisterRouter(r *gin.Engine) {
 	r.POST("/tasks/:taskId/rerun", task.PostRerun)
 
 	r.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
+	r.GET("/pipelines/:pipelineId/tasks/:taskId/logs/stream", pipelines.StreamTaskLog)
+	r.GET("/activity", activity.Get)
 
 	//r.GET("/ping", ping.Get)
 	//r.GET("/version", version.Get)
 	r.POST("/push/:tableName", push.Post)
 	r.GET("/domainlayer/repos", domainlayer.ReposIndex)
+	r.GET("/domainlayer/lineage/:table", domainlayer.LineageIndex)
+	r.GET("/domainlayer/merges", domainlayer.MergesIndex)
+	r.POST("/domainlayer/merges", domainlayer.PostMerge)
+	r.GET("/domainlayer/raw/:table", domainlayer.RawDataIndex)
+	r.GET("/data-exports", dataexport.Index)
+	r.GET("/data-exports/tables/:table", dataexport.ExportTable)
+	r.GET("/data-exports/queries/:query", dataexport.ExportQuery)
+	r.GET("/queries/tables", safequery.GetTables)
+	r.POST("/queries", safequery.Post)
+	r.GET("/saved-queries", safequery.GetSavedQueries)
+	r.GET("/saved-queries/:name", safequery.GetSavedQuery)
+	r.PUT("/saved-queries/:name", safequery.PutSavedQuery)
+	r.DELETE("/saved-queries/:name", safequery.DeleteSavedQuery)
+	r.GET("/saved-queries/:name/results", safequery.GetSavedQueryResults)
+	r.GET("/raw-data/retentions", rawdata.RetentionsIndex)
+	r.PUT("/raw-data/retentions", rawdata.PutRetention)
+	r.GET("/raw-data/purges", rawdata.PurgesIndex)
+	r.POST("/raw-data/purges", rawdata.PostPurge)
+	r.GET("/raw-data/purges/:purgeId", rawdata.GetPurge)
+	r.GET("/raw-data/compactions", rawdata.CompactionsIndex)
+	r.POST("/raw-data/compactions", rawdata.PostCompaction)
+	r.GET("/raw-data/compactions/:compactionId", rawdata.GetCompaction)
+	r.GET("/raw-data/dedup-stats", rawdata.DedupStatsIndex)
+	r.GET("/raw-data/tables/:table/rows/:rowId/data", rawdata.GetRowData)
+	r.GET("/archives", archive.Index)
+	r.POST("/archives", archive.Post)
+	r.GET("/archives/:archiveId", archive.Get)
+	r.POST("/archives/:archiveId/restore", archive.PostRestore)
+	r.GET("/db-maintenances", dbmaintenance.Index)
+	r.POST("/db-maintenances", dbmaintenance.Post)
+	r.GET("/db-maintenances/:maintenanceId", dbmaintenance.Get)
+	r.GET("/db-bloat-stats", dbmaintenance.BloatStatsIndex)
+	r.GET("/slow-queries", slowquery.Index)
+	r.DELETE("/slow-queries", slowquery.Delete)
+	r.GET("/encryption/key-rotations", encryption.KeyRotationsIndex)
+	r.POST("/encryption/key-rotations", encryption.PostKeyRotation)
+	r.GET("/encryption/key-rotations/:rotationId", encryption.GetKeyRotation)
+	r.GET("/collector-states", collectorstate.Index)
+	r.DELETE("/collector-states", collectorstate.Delete)
+	r.GET("/migrations/states", migration.StatesIndex)
+	r.POST("/migrations/rollback", migration.PostRollback)
 
 	// plugin api
 	r.GET("/plugininfo", plugininfo.Get)
@@ -71,6 +142,71 @@ func RegisterRouter(r *gin.Engine) {
 	r.POST("/projects", project.PostProject)
 	r.GET("/projects", project.GetProjects)
 
+	// api key api
+	r.GET("/api-keys", apikey.Index)
+	r.POST("/api-keys", apikey.Post)
+	r.DELETE("/api-keys/:apiKeyId", apikey.Delete)
+
+	// workspace api
+	r.GET("/workspaces", workspace.Index)
+	r.POST("/workspaces", workspace.Post)
+	r.GET("/workspaces/:workspace", workspace.Get)
+	r.DELETE("/workspaces/:workspace", workspace.Delete)
+	r.PUT("/workspaces/:workspace/projects/:projectName", workspace.PutProject)
+	r.DELETE("/workspaces/:workspace/projects/:projectName", workspace.DeleteProject)
+	r.PUT("/workspaces/:workspace/connections/:plugin/:connectionId", workspace.PutConnection)
+	r.DELETE("/workspaces/:workspace/connections/:plugin/:connectionId", workspace.DeleteConnection)
+
+	// audit log api
+	r.GET("/audit-logs", auditlog.Index)
+	r.GET("/audit-logs/export", auditlog.Export)
+
+	// event webhook api
+	r.GET("/event-webhooks", eventwebhook.Index)
+	r.POST("/event-webhooks", eventwebhook.Post)
+	r.GET("/event-webhooks/:webhookId", eventwebhook.Get)
+	r.PATCH("/event-webhooks/:webhookId", eventwebhook.Patch)
+	r.DELETE("/event-webhooks/:webhookId", eventwebhook.Delete)
+	r.GET("/event-webhooks/:webhookId/deliveries", eventwebhook.GetDeliveries)
+	r.POST("/event-webhooks/:webhookId/deliveries/:deliveryId/redeliver", eventwebhook.PostRedeliver)
+
+	// rbac api
+	r.GET("/users", rbac.GetUsers)
+	r.POST("/users", rbac.PostUser)
+	r.DELETE("/users/:userId", rbac.DeleteUser)
+	r.GET("/role-bindings", rbac.GetRoleBindings)
+	r.PUT("/role-bindings", rbac.PutRoleBinding)
+	r.DELETE("/role-bindings", rbac.DeleteRoleBinding)
+
+	// settings api
+	r.GET("/settings/security", settings.GetSecurity)
+	r.PUT("/settings/security", settings.PutSecurity)
+
+	// management api, stable for the infrastructure as code tools
+	m := r.Group(management.Prefix)
+	m.GET("/connections/:plugin", management.ListConnections)
+	m.GET("/connections/:plugin/:name", management.GetConnection)
+	m.PUT("/connections/:plugin/:name", management.PutConnection)
+	m.DELETE("/connections/:plugin/:name", management.DeleteConnection)
+	m.GET("/scope-configs/:plugin/:connection", management.ListScopeConfigs)
+	m.GET("/scope-configs/:plugin/:connection/:name", management.GetScopeConfig)
+	m.PUT("/scope-configs/:plugin/:connection/:name", management.PutScopeConfig)
+	m.DELETE("/scope-configs/:plugin/:connection/:name", management.DeleteScopeConfig)
+	m.POST("/scope-configs/:plugin/:connection/batch", management.BatchScopeConfigs)
+	m.GET("/projects/:name", management.GetProject)
+	m.PUT("/projects/:name", management.PutProject)
+	m.DELETE("/projects/:name", management.DeleteProject)
+	m.GET("/projects/:name/blueprint", management.GetBlueprint)
+	m.PUT("/projects/:name/blueprint", management.PutBlueprint)
+
+	// configuration bundle api
+	r.GET("/config-bundle", configbundle.Get)
+	r.POST("/config-bundle", configbundle.Post)
+
+	// graphql api
+	r.GET("/graphql", graphql.Get)
+	r.POST("/graphql", graphql.Post)
+
 	// mount all api resources for all plugins
 	resources, err := services.GetPluginsApiResources()
 	if err != nil {
@@ -110,14 +246,42 @@ func handlePluginCall(pluginName string, handler plugin.ApiResourceHandler) func
 			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
 				input.Request = c.Request
 			} else {
-				err = c.ShouldBindJSON(&input.Body)
-				if err != nil && err.Error() != "EOF" {
+				// the raw body is kept readable for the handlers verifying its signature or accepting a list
+				var body []byte
+				body, err = io.ReadAll(c.Request.Body)
+				if err != nil {
 					shared.ApiOutputError(c, err)
 					return
 				}
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+				input.Request = c.Request
+				body = bytes.TrimSpace(body)
+				if len(body) > 0 && body[0] != '[' {
+					err = json.Unmarshal(body, &input.Body)
+					if err != nil {
+						shared.ApiOutputError(c, err)
+						return
+					}
+				}
 			}
 		}
 		output, err := handler(input)
+		if err == nil && output != nil {
+			output.Body, err = authorizeConnections(c, pluginName, output.Body)
+		}
+		if err == nil && output != nil {
+			output.Body, err = confineConnections(c, pluginName, output.Body)
+		}
+		if err == nil && output != nil {
+			output.Body, err = paginateConnections(c, output.Body)
+		}
+		if err == nil && c.Request.Method == http.MethodDelete && strings.HasSuffix(c.FullPath(), "/scopes/:scopeId") {
+			services.PublishEvent(models.EVENT_SCOPE_DELETED, map[string]interface{}{
+				"plugin":       pluginName,
+				"connectionId": input.Params["connectionId"],
+				"scopeId":      input.Params["scopeId"],
+			})
+		}
 		if err != nil {
 			shared.ApiOutputError(c, err)
 		} else if output != nil {
@@ -139,3 +303,56 @@ func handlePluginCall(pluginName string, handler plugin.ApiResourceHandler) func
 		}
 	}
 }
+
+// authorizeConnections hides the connections the user is not granted any role on, and records the user as the owner
+// of the connections it creates
+func authorizeConnections(c *gin.Context, pluginName string, body interface{}) (interface{}, errors.Error) {
+	user := rbac.CurrentUser(c)
+	if user == nil || !strings.HasSuffix(c.FullPath(), "/connections") {
+		return body, nil
+	}
+	switch c.Request.Method {
+	case http.MethodGet:
+		return services.FilterConnections(user, pluginName, body)
+	case http.MethodPost:
+		return body, services.RecordConnectionOwner(user, pluginName, body)
+	}
+	return body, nil
+}
+
+// confineConnections hides the connections out of the workspace of the request, and puts the connections it creates
+// into the workspace
+func confineConnections(c *gin.Context, pluginName string, body interface{}) (interface{}, errors.Error) {
+	ws := workspace.Current(c)
+	if ws == "" || !strings.HasSuffix(c.FullPath(), "/connections") {
+		return body, nil
+	}
+	switch c.Request.Method {
+	case http.MethodGet:
+		return services.FilterWorkspaceConnections(ws, pluginName, body)
+	case http.MethodPost:
+		return body, services.RecordConnectionWorkspace(ws, pluginName, body)
+	}
+	return body, nil
+}
+
+// paginateConnections wraps the connections listed by the plugins into the envelope shared by the list endpoints,
+// sorted, filtered and paginated by the query params
+func paginateConnections(c *gin.Context, body interface{}) (interface{}, errors.Error) {
+	if c.Request.Method != http.MethodGet || !strings.HasSuffix(c.FullPath(), "/connections") {
+		return body, nil
+	}
+	listType := reflect.TypeOf(body)
+	if listType == nil || listType.Kind() != reflect.Slice {
+		return body, nil
+	}
+	listQuery, err := helper.ParseListQuery(c.Request.URL.Query(), helper.ListFields(reflect.New(listType.Elem()).Interface()))
+	if err != nil {
+		return nil, err
+	}
+	connections, count, err := listQuery.Apply(body)
+	if err != nil {
+		return nil, err
+	}
+	return helper.NewListResponse("connections", connections, count, listQuery.Page, listQuery.PageSize), nil
+}
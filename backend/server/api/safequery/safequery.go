@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package safequery
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedSavedQueries struct {
+	SavedQueries []*models.SavedQuery `json:"savedQueries"`
+	Count        int64                `json:"count"`
+}
+
+// @Summary Get the tables of the safe queries
+// @Description Get the domain layer tables the safe queries may read, along with their columns
+// @Tags framework/queries
+// @Success 200  {object} []services.SafeQueryTable
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /queries/tables [get]
+func GetTables(c *gin.Context) {
+	tables, err := services.GetSafeQueryTables()
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, tables, http.StatusOK)
+}
+
+/*
+Run a safe query
+POST /queries
+{
+	"table": "pull_requests",
+	"columns": ["status"],
+	"aggregates": [{"func": "count", "as": "prs"}],
+	"groupBy": ["status"],
+	"filters": [{"column": "created_date", "op": "gte", "value": "2023-01-01"}],
+	"orderBy": ["-prs"],
+	"limit": 10
+}
+*/
+// @Summary Run a safe query
+// @Description Read the rows of a domain layer table without resorting to sql. The rows are capped by
+// @Description SAFE_QUERY_MAX_ROWS and the query is cancelled after SAFE_QUERY_TIMEOUT.
+// @Tags framework/queries
+// @Accept application/json
+// @Param query body models.SafeQuery true "json"
+// @Success 200  {object} services.SafeQueryResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /queries [post]
+func Post(c *gin.Context) {
+	query := &models.SafeQuery{}
+	err := c.ShouldBindJSON(query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	result, err := services.RunSafeQuery(query, nil)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
+
+// @Summary Get the saved queries
+// @Description Get the saved queries
+// @Tags framework/queries
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedSavedQueries
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /saved-queries [get]
+func GetSavedQueries(c *gin.Context) {
+	var query services.SavedQueryQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	savedQueries, count, err := services.GetSavedQueries(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedSavedQueries{SavedQueries: savedQueries, Count: count}, http.StatusOK)
+}
+
+// @Summary Get a saved query
+// @Description Get a saved query
+// @Tags framework/queries
+// @Param name path string true "saved query name"
+// @Success 200  {object} models.SavedQuery
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /saved-queries/{name} [get]
+func GetSavedQuery(c *gin.Context) {
+	savedQuery, err := services.GetSavedQuery(c.Param("name"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, savedQuery, http.StatusOK)
+}
+
+/*
+Save a query
+PUT /saved-queries/merged-prs
+{
+	"description": "the pull requests of a repo merged since a date",
+	"query": {
+		"table": "pull_requests",
+		"columns": ["id", "title", "merged_date"],
+		"filters": [
+			{"column": "base_repo_id", "op": "eq", "param": "repo"},
+			{"column": "merged_date", "op": "gte", "param": "since", "value": "2023-01-01"}
+		]
+	}
+}
+*/
+// @Summary Save a query
+// @Description Save a safe query under the name, replacing the one saved before. The filters naming a param
+// @Description take their value from the query string of the runs, the value of the filter being the default.
+// @Tags framework/queries
+// @Accept application/json
+// @Param name path string true "saved query name"
+// @Param savedQuery body models.SavedQuery true "json"
+// @Success 200  {object} models.SavedQuery
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /saved-queries/{name} [put]
+func PutSavedQuery(c *gin.Context) {
+	savedQuery := &models.SavedQuery{}
+	err := c.ShouldBindJSON(savedQuery)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	savedQuery.Name = c.Param("name")
+	err = services.PutSavedQuery(savedQuery)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, savedQuery, http.StatusOK)
+}
+
+// @Summary Delete a saved query
+// @Description Delete a saved query
+// @Tags framework/queries
+// @Param name path string true "saved query name"
+// @Success 200  {object} models.SavedQuery
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /saved-queries/{name} [delete]
+func DeleteSavedQuery(c *gin.Context) {
+	savedQuery, err := services.DeleteSavedQuery(c.Param("name"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, savedQuery, http.StatusOK)
+}
+
+// @Summary Run a saved query
+// @Description Run a saved query, its parameters are given in the query string, i.e. `?repo=github:GithubRepo:1:1&since=2023-06-01`,
+// @Description the in filters taking comma-separated values
+// @Tags framework/queries
+// @Param name path string true "saved query name"
+// @Param limit query int false "the limit of the rows, capped by SAFE_QUERY_MAX_ROWS"
+// @Success 200  {object} services.SafeQueryResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /saved-queries/{name}/results [get]
+func GetSavedQueryResults(c *gin.Context) {
+	params := make(map[string]string)
+	for key, values := range c.Request.URL.Query() {
+		params[key] = values[len(values)-1]
+	}
+	limit := 0
+	if value, ok := params["limit"]; ok {
+		var e error
+		limit, e = strconv.Atoi(value)
+		if e != nil || limit < 0 {
+			shared.ApiOutputError(c, errors.BadInput.New("limit must be a positive integer"))
+			return
+		}
+		delete(params, "limit")
+	}
+	result, err := services.RunSavedQuery(c.Param("name"), params, limit)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package settings
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the security settings
+// @Description Get the origins allowed by CORS and the security headers sent along with the responses
+// @Tags framework/settings
+// @Success 200  {object} services.SecuritySettings
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /settings/security [get]
+func GetSecurity(c *gin.Context) {
+	shared.ApiOutputSuccess(c, services.GetSecuritySettings(), http.StatusOK)
+}
+
+// @Summary Put the security settings
+// @Description Replace the origins allowed by CORS and the security headers, they are saved in the database and in
+// @Description effect right away without restarting the server
+// @Tags framework/settings
+// @Accept application/json
+// @Param settings body services.SecuritySettings true "json"
+// @Success 200  {object} services.SecuritySettings
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /settings/security [put]
+func PutSecurity(c *gin.Context) {
+	settings := &services.SecuritySettings{}
+	err := c.ShouldBindJSON(settings)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	settings, err = services.PutSecuritySettings(settings)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, settings, http.StatusOK)
+}
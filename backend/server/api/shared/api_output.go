@@ -37,6 +37,8 @@ type ApiBody struct {
 
 type ResponsePipelines struct {
 	Count     int64              `json:"count"`
+	Page      int                `json:"page"`
+	PageSize  int                `json:"pageSize"`
 	Pipelines []*models.Pipeline `json:"pipelines"`
 }
 
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowquery
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedSlowQueries struct {
+	Queries []*dalgorm.QueryStat `json:"queries"`
+	Count   int64                `json:"count"`
+}
+
+// @Summary Get the slow queries
+// @Description Get the queries issued on the domain and tool tables since the server started, or since the durations were reset, the slowest first. The queries are told apart by their sql with the parameters left out and by the code issuing them, to find the indexes missing for the workload
+// @Tags framework/slowqueries
+// @Param table query string false "the table queried"
+// @Param sortBy query string false "max (the default), total, avg or count"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedSlowQueries
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /slow-queries [get]
+func Index(c *gin.Context) {
+	var query services.SlowQueryQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	queries, count, err := services.GetSlowQueries(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedSlowQueries{Queries: queries, Count: count}, http.StatusOK)
+}
+
+// @Summary Reset the slow queries
+// @Description Forget the durations of the queries tracked so far, e.g. to measure the effect of a new index
+// @Tags framework/slowqueries
+// @Success 200
+// @Router /slow-queries [delete]
+func Delete(c *gin.Context) {
+	services.ResetSlowQueries()
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
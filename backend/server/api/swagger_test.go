@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	swaggerRouter   = regexp.MustCompile(`^//\s*@Router\s+(\S+)\s+\[(\w+)\]`)
+	swaggerResponse = regexp.MustCompile(`^//\s*@(Success|Failure)\s+(.*)$`)
+	responseParam   = regexp.MustCompile(`^\d{3}(\s+\{(object|array|string)\}\s+\S+.*|\s+".*"|)$`)
+	routeParam      = regexp.MustCompile(`\{[^}]+\}|:[^/]+|\*[^/]+`)
+	resourcePath    = regexp.MustCompile(`^\s*"([^"]*)":\s*\{\s*$`)
+	resourceMethod  = regexp.MustCompile(`^\s*"(GET|POST|PUT|PATCH|DELETE)":`)
+	pluginResources = regexp.MustCompile(`\) ApiResources\(\) map`)
+)
+
+// normalizeRoute makes the gin routes and the swagger ones comparable, the names of the params aside
+func normalizeRoute(method string, path string) string {
+	path = strings.TrimSuffix(routeParam.ReplaceAllString(path, "{}"), "/")
+	return strings.ToUpper(method) + " " + path
+}
+
+// documentedRoutes collects the routes of the swagger annotations of the go files under the dirs, the responses are
+// checked along the way since swag fails the whole generation on a malformed one
+func documentedRoutes(t *testing.T, dirs ...string) map[string]bool {
+	routes := make(map[string]bool)
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for i, line := range strings.Split(string(content), "\n") {
+				line = strings.TrimSpace(line)
+				if m := swaggerRouter.FindStringSubmatch(line); m != nil {
+					routes[normalizeRoute(m[2], m[1])] = true
+				} else if m := swaggerResponse.FindStringSubmatch(line); m != nil {
+					assert.Regexp(t, responseParam, strings.TrimSpace(m[2]), "%s:%d has a malformed @%s", path, i+1, m[1])
+				}
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+	}
+	return routes
+}
+
+func TestFrameworkRoutesDocumented(t *testing.T) {
+	r := gin.New()
+	RegisterRouter(r)
+	documented := documentedRoutes(t, ".")
+	for _, route := range r.Routes() {
+		assert.True(t, documented[normalizeRoute(route.Method, route.Path)], "%s %s has no swagger annotation", route.Method, route.Path)
+	}
+}
+
+// TestPluginRoutesDocumented reads the api resources of the plugins out of their sources, so the plugins aren't loaded
+func TestPluginRoutesDocumented(t *testing.T) {
+	impls, err := filepath.Glob("../../plugins/*/impl/impl.go")
+	assert.Nil(t, err)
+	for _, impl := range impls {
+		pluginDir := filepath.Dir(filepath.Dir(impl))
+		pluginName := filepath.Base(pluginDir)
+		content, err := os.ReadFile(impl)
+		assert.Nil(t, err)
+		lines := strings.Split(string(content), "\n")
+		start := -1
+		for i, line := range lines {
+			if pluginResources.MatchString(line) {
+				start = i
+			}
+		}
+		if start < 0 {
+			continue
+		}
+		documented := documentedRoutes(t, filepath.Join(pluginDir, "api"))
+		path := ""
+		for _, line := range lines[start+1:] {
+			if line == "}" {
+				break
+			}
+			if m := resourcePath.FindStringSubmatch(line); m != nil {
+				path = m[1]
+			} else if m := resourceMethod.FindStringSubmatch(line); m != nil {
+				route := normalizeRoute(m[1], "/plugins/"+pluginName+"/"+path)
+				assert.True(t, documented[route], "%s has no swagger annotation", route)
+			}
+		}
+	}
+}
@@ -53,6 +53,7 @@ type getTaskResponse struct {
 // @Tags framework/tasks
 // @Accept application/json
 // @Param pipelineId path int true "pipelineId"
+// @Param fields query string false "comma separated fields to return, the nested ones dotted, i.e. id,status,progressDetail.subTaskName"
 // @Success 200  {object} getTaskResponse
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WORKSPACE_HEADER selects the workspace of the requests whose api key does not confine them to one
+const WORKSPACE_HEADER = "X-Workspace"
+
+// Middleware resolves the workspace of the request, the one of its api key or else the one selected by the
+// X-Workspace header, and checks the route reaches the resources of the workspace only
+func Middleware(c *gin.Context) {
+	workspace := c.GetHeader(WORKSPACE_HEADER)
+	if v, ok := c.Get("apiKey"); ok {
+		if scoped := v.(*models.ApiKey).Workspace; scoped != "" {
+			if workspace != "" && workspace != scoped {
+				shared.ApiOutputAbort(c, errors.Forbidden.New("the api key is confined to the workspace "+scoped))
+				return
+			}
+			workspace = scoped
+		}
+	}
+	if workspace == "" {
+		return
+	}
+	if _, err := services.GetWorkspace(workspace); err != nil {
+		shared.ApiOutputAbort(c, err)
+		return
+	}
+	c.Set("workspace", workspace)
+	params := make(map[string]string, len(c.Params))
+	for _, param := range c.Params {
+		params[param.Key] = param.Value
+	}
+	err := services.AuthorizeWorkspaceRequest(workspace, c.Request.Method, c.FullPath(), params)
+	if err != nil {
+		shared.ApiOutputAbort(c, err)
+	}
+}
+
+// Current returns the workspace of the request, empty when it is out of any
+func Current(c *gin.Context) string {
+	return c.GetString("workspace")
+}
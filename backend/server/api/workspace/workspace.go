@@ -0,0 +1,215 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedWorkspaces struct {
+	Workspaces []*models.Workspace `json:"workspaces"`
+	Count      int64               `json:"count"`
+}
+
+// @Summary Get the workspaces
+// @Description Get the workspaces
+// @Tags framework/workspaces
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedWorkspaces
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces [get]
+func Index(c *gin.Context) {
+	var query services.WorkspaceQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	workspaces, count, err := services.GetWorkspaces(&query)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedWorkspaces{Workspaces: workspaces, Count: count}, http.StatusOK)
+}
+
+// @Summary Create a workspace
+// @Description Create a workspace, the api keys of the workspace confine their requests to its connections and
+// @Description projects, which they create in the workspace
+// @Tags framework/workspaces
+// @Accept application/json
+// @Param workspace body models.Workspace true "json"
+// @Success 200  {object} models.Workspace
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces [post]
+func Post(c *gin.Context) {
+	workspace := &models.Workspace{}
+	err := c.ShouldBindJSON(workspace)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.CreateWorkspace(workspace)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, workspace, http.StatusOK)
+}
+
+// @Summary Get a workspace
+// @Description Get a workspace
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Success 200  {object} models.Workspace
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace} [get]
+func Get(c *gin.Context) {
+	workspace, err := services.GetWorkspace(c.Param("workspace"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, workspace, http.StatusOK)
+}
+
+// @Summary Delete a workspace
+// @Description Delete a workspace without any project, connection or api key left
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Success 200  {object} models.Workspace
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace} [delete]
+func Delete(c *gin.Context) {
+	workspace, err := services.DeleteWorkspace(c.Param("workspace"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, workspace, http.StatusOK)
+}
+
+// @Summary Move a project to a workspace
+// @Description Move a project, along with its blueprint and domain data, to the workspace
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Param projectName path string true "project name"
+// @Success 200
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace}/projects/{projectName} [put]
+func PutProject(c *gin.Context) {
+	err := services.MoveProjectToWorkspace(c.Param("projectName"), c.Param("workspace"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Move a project out of its workspace
+// @Description Move a project out of its workspace, it is visible to the requests out of any workspace only
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Param projectName path string true "project name"
+// @Success 200
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace}/projects/{projectName} [delete]
+func DeleteProject(c *gin.Context) {
+	err := services.CheckWorkspaceProject(c.Param("workspace"), c.Param("projectName"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.NotFound.Wrap(err, "the project is not in the workspace"))
+		return
+	}
+	err = services.MoveProjectToWorkspace(c.Param("projectName"), "")
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Move a connection to a workspace
+// @Description Move a plugin connection to the workspace
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Param plugin path string true "plugin name"
+// @Param connectionId path int true "connection id"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace}/connections/{plugin}/{connectionId} [put]
+func PutConnection(c *gin.Context) {
+	connectionId, err := strconv.ParseUint(c.Param("connectionId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad connectionId format supplied"))
+		return
+	}
+	err = services.MoveConnectionToWorkspace(c.Param("plugin"), connectionId, c.Param("workspace"))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Move a connection out of its workspace
+// @Description Move a plugin connection out of its workspace, it is visible to the requests out of any workspace only
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Param plugin path string true "plugin name"
+// @Param connectionId path int true "connection id"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace}/connections/{plugin}/{connectionId} [delete]
+func DeleteConnection(c *gin.Context) {
+	connectionId, err := strconv.ParseUint(c.Param("connectionId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad connectionId format supplied"))
+		return
+	}
+	err = services.CheckWorkspaceConnection(c.Param("workspace"), c.Param("plugin"), connectionId)
+	if err != nil {
+		shared.ApiOutputError(c, errors.NotFound.Wrap(err, "the connection is not in the workspace"))
+		return
+	}
+	err = services.MoveConnectionToWorkspace(c.Param("plugin"), connectionId, "")
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/services"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// API_KEY_METADATA carries the api key of the calls, like the X-Api-Key header of the REST api
+const API_KEY_METADATA = "x-api-key"
+
+// authorize authenticates the call by its api key and checks the key is granted the equivalent REST route, i.e.
+// `/pipelines/:pipelineId`, along with its path params. Unlike the REST api, a key is always required.
+func authorize(ctx context.Context, method string, route string, params map[string]string) (*models.ApiKey, errors.Error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(API_KEY_METADATA)
+	if len(keys) == 0 || keys[0] == "" {
+		return nil, errors.Unauthorized.New(API_KEY_METADATA + " metadata is missing")
+	}
+	apiKey, err := services.AuthenticateApiKey(keys[0], method, route)
+	if err != nil {
+		return nil, err
+	}
+	err = services.AuthorizeWorkspaceRequest(apiKey.Workspace, method, route, params)
+	if err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+// audit records the mutating call in the audit logs as if it was made to the REST path
+func audit(ctx context.Context, apiKey *models.ApiKey, resourceType string, path string, resourceId string, result interface{}, err errors.Error) {
+	auditLog := &models.AuditLog{
+		Actor:        "api-key:" + apiKey.Name,
+		ApiKeyId:     &apiKey.ID,
+		Method:       http.MethodPost,
+		Path:         path,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		Status:       http.StatusOK,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, e := net.SplitHostPort(p.Addr.String()); e == nil {
+			auditLog.ClientIp = host
+		}
+	}
+	if err != nil {
+		auditLog.Status = err.GetType().GetHttpCode()
+	} else if after, e := json.Marshal(result); e == nil {
+		auditLog.After = after
+	}
+	if e := services.SaveAuditLog(auditLog); e != nil {
+		logruslog.Global.Error(e, "failed to record the audit log of the grpc call to %s", path)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: pipeline.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PipelineServiceClient is the client API for PipelineService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PipelineServiceClient interface {
+	// TriggerBlueprint creates a pipeline of the blueprint right away, like POST /blueprints/:blueprintId/trigger
+	TriggerBlueprint(ctx context.Context, in *TriggerBlueprintRequest, opts ...grpc.CallOption) (*Pipeline, error)
+	// GetPipeline returns the pipeline as it is now, like GET /pipelines/:pipelineId
+	GetPipeline(ctx context.Context, in *GetPipelineRequest, opts ...grpc.CallOption) (*Pipeline, error)
+	// WatchPipeline sends the pipeline along with its tasks whenever their progress changes, and ends once the
+	// pipeline is done
+	WatchPipeline(ctx context.Context, in *WatchPipelineRequest, opts ...grpc.CallOption) (PipelineService_WatchPipelineClient, error)
+}
+
+type pipelineServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPipelineServiceClient(cc grpc.ClientConnInterface) PipelineServiceClient {
+	return &pipelineServiceClient{cc}
+}
+
+func (c *pipelineServiceClient) TriggerBlueprint(ctx context.Context, in *TriggerBlueprintRequest, opts ...grpc.CallOption) (*Pipeline, error) {
+	out := new(Pipeline)
+	err := c.cc.Invoke(ctx, "/devlake.v1.PipelineService/TriggerBlueprint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetPipeline(ctx context.Context, in *GetPipelineRequest, opts ...grpc.CallOption) (*Pipeline, error) {
+	out := new(Pipeline)
+	err := c.cc.Invoke(ctx, "/devlake.v1.PipelineService/GetPipeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) WatchPipeline(ctx context.Context, in *WatchPipelineRequest, opts ...grpc.CallOption) (PipelineService_WatchPipelineClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PipelineService_ServiceDesc.Streams[0], "/devlake.v1.PipelineService/WatchPipeline", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pipelineServiceWatchPipelineClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PipelineService_WatchPipelineClient interface {
+	Recv() (*PipelineProgress, error)
+	grpc.ClientStream
+}
+
+type pipelineServiceWatchPipelineClient struct {
+	grpc.ClientStream
+}
+
+func (x *pipelineServiceWatchPipelineClient) Recv() (*PipelineProgress, error) {
+	m := new(PipelineProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PipelineServiceServer is the server API for PipelineService service.
+// All implementations must embed UnimplementedPipelineServiceServer
+// for forward compatibility
+type PipelineServiceServer interface {
+	// TriggerBlueprint creates a pipeline of the blueprint right away, like POST /blueprints/:blueprintId/trigger
+	TriggerBlueprint(context.Context, *TriggerBlueprintRequest) (*Pipeline, error)
+	// GetPipeline returns the pipeline as it is now, like GET /pipelines/:pipelineId
+	GetPipeline(context.Context, *GetPipelineRequest) (*Pipeline, error)
+	// WatchPipeline sends the pipeline along with its tasks whenever their progress changes, and ends once the
+	// pipeline is done
+	WatchPipeline(*WatchPipelineRequest, PipelineService_WatchPipelineServer) error
+	mustEmbedUnimplementedPipelineServiceServer()
+}
+
+// UnimplementedPipelineServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPipelineServiceServer struct {
+}
+
+func (UnimplementedPipelineServiceServer) TriggerBlueprint(context.Context, *TriggerBlueprintRequest) (*Pipeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerBlueprint not implemented")
+}
+func (UnimplementedPipelineServiceServer) GetPipeline(context.Context, *GetPipelineRequest) (*Pipeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipeline not implemented")
+}
+func (UnimplementedPipelineServiceServer) WatchPipeline(*WatchPipelineRequest, PipelineService_WatchPipelineServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPipeline not implemented")
+}
+func (UnimplementedPipelineServiceServer) mustEmbedUnimplementedPipelineServiceServer() {}
+
+// UnsafePipelineServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PipelineServiceServer will
+// result in compilation errors.
+type UnsafePipelineServiceServer interface {
+	mustEmbedUnimplementedPipelineServiceServer()
+}
+
+func RegisterPipelineServiceServer(s grpc.ServiceRegistrar, srv PipelineServiceServer) {
+	s.RegisterService(&PipelineService_ServiceDesc, srv)
+}
+
+func _PipelineService_TriggerBlueprint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerBlueprintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).TriggerBlueprint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/devlake.v1.PipelineService/TriggerBlueprint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).TriggerBlueprint(ctx, req.(*TriggerBlueprintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/devlake.v1.PipelineService/GetPipeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetPipeline(ctx, req.(*GetPipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_WatchPipeline_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPipelineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PipelineServiceServer).WatchPipeline(m, &pipelineServiceWatchPipelineServer{stream})
+}
+
+type PipelineService_WatchPipelineServer interface {
+	Send(*PipelineProgress) error
+	grpc.ServerStream
+}
+
+type pipelineServiceWatchPipelineServer struct {
+	grpc.ServerStream
+}
+
+func (x *pipelineServiceWatchPipelineServer) Send(m *PipelineProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PipelineService_ServiceDesc is the grpc.ServiceDesc for PipelineService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PipelineService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "devlake.v1.PipelineService",
+	HandlerType: (*PipelineServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TriggerBlueprint",
+			Handler:    _PipelineService_TriggerBlueprint_Handler,
+		},
+		{
+			MethodName: "GetPipeline",
+			Handler:    _PipelineService_GetPipeline_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPipeline",
+			Handler:       _PipelineService_WatchPipeline_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pipeline.proto",
+}
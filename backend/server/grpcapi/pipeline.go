@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/grpcapi/pb"
+	"github.com/apache/incubator-devlake/server/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type pipelineServer struct {
+	pb.UnimplementedPipelineServiceServer
+}
+
+func (s *pipelineServer) TriggerBlueprint(ctx context.Context, req *pb.TriggerBlueprintRequest) (*pb.Pipeline, error) {
+	blueprintId := req.GetBlueprintId()
+	if projectName := req.GetProjectName(); projectName != "" {
+		blueprint, err := services.GetBlueprintByProjectName(projectName)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		if blueprint == nil {
+			return nil, toStatus(errors.NotFound.New(fmt.Sprintf("the project [%s] has no blueprint", projectName)))
+		}
+		blueprintId = blueprint.ID
+	}
+	if blueprintId == 0 {
+		return nil, toStatus(errors.BadInput.New("either blueprint_id or project_name is required"))
+	}
+	id := fmt.Sprintf("%d", blueprintId)
+	apiKey, err := authorize(ctx, http.MethodPost, "/blueprints/:blueprintId/trigger", map[string]string{"blueprintId": id})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	pipeline, err := services.TriggerBlueprint(blueprintId)
+	audit(ctx, apiKey, "blueprints/trigger", fmt.Sprintf("/blueprints/%s/trigger", id), id, pipeline, err)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPipeline(pipeline), nil
+}
+
+func (s *pipelineServer) GetPipeline(ctx context.Context, req *pb.GetPipelineRequest) (*pb.Pipeline, error) {
+	_, err := authorizePipeline(ctx, req.GetPipelineId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	pipeline, err := services.GetPipeline(req.GetPipelineId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPipeline(pipeline), nil
+}
+
+func (s *pipelineServer) WatchPipeline(req *pb.WatchPipelineRequest, stream pb.PipelineService_WatchPipelineServer) error {
+	ctx := stream.Context()
+	_, err := authorizePipeline(ctx, req.GetPipelineId())
+	if err != nil {
+		return toStatus(err)
+	}
+	err = services.WatchPipeline(ctx, req.GetPipelineId(), func(progress *services.PipelineProgress) errors.Error {
+		if e := stream.Send(toPipelineProgress(progress)); e != nil {
+			return errors.Default.Wrap(e, "error sending the progress of the pipeline")
+		}
+		return nil
+	})
+	return toStatus(err)
+}
+
+func authorizePipeline(ctx context.Context, pipelineId uint64) (*models.ApiKey, errors.Error) {
+	if pipelineId == 0 {
+		return nil, errors.BadInput.New("pipeline_id is required")
+	}
+	return authorize(ctx, http.MethodGet, "/pipelines/:pipelineId", map[string]string{"pipelineId": fmt.Sprintf("%d", pipelineId)})
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toPipeline(pipeline *models.Pipeline) *pb.Pipeline {
+	return &pb.Pipeline{
+		Id:            pipeline.ID,
+		Name:          pipeline.Name,
+		BlueprintId:   pipeline.BlueprintId,
+		Status:        pipeline.Status,
+		Message:       pipeline.Message,
+		ErrorName:     pipeline.ErrorName,
+		TotalTasks:    int32(pipeline.TotalTasks),
+		FinishedTasks: int32(pipeline.FinishedTasks),
+		Stage:         int32(pipeline.Stage),
+		CreatedAt:     toTimestamp(&pipeline.CreatedAt),
+		BeganAt:       toTimestamp(pipeline.BeganAt),
+		FinishedAt:    toTimestamp(pipeline.FinishedAt),
+		SpentSeconds:  int32(pipeline.SpentSeconds),
+		Labels:        pipeline.Labels,
+	}
+}
+
+func toTask(task *models.Task) *pb.Task {
+	t := &pb.Task{
+		Id:            task.ID,
+		Plugin:        task.Plugin,
+		Status:        task.Status,
+		Message:       task.Message,
+		ErrorName:     task.ErrorName,
+		FailedSubTask: task.FailedSubTask,
+		Progress:      task.Progress,
+		PipelineRow:   int32(task.PipelineRow),
+		PipelineCol:   int32(task.PipelineCol),
+		BeganAt:       toTimestamp(task.BeganAt),
+		FinishedAt:    toTimestamp(task.FinishedAt),
+		SpentSeconds:  int32(task.SpentSeconds),
+	}
+	if detail := task.ProgressDetail; detail != nil {
+		t.TotalSubTasks = int32(detail.TotalSubTasks)
+		t.FinishedSubTasks = int32(detail.FinishedSubTasks)
+		t.SubTaskName = detail.SubTaskName
+		t.TotalRecords = int32(detail.TotalRecords)
+		t.FinishedRecords = int32(detail.FinishedRecords)
+	}
+	return t
+}
+
+func toPipelineProgress(progress *services.PipelineProgress) *pb.PipelineProgress {
+	tasks := make([]*pb.Task, len(progress.Tasks))
+	for i, task := range progress.Tasks {
+		tasks[i] = toTask(task)
+	}
+	return &pb.PipelineProgress{Pipeline: toPipeline(progress.Pipeline), Tasks: tasks, Done: progress.Done}
+}
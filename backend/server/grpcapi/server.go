@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/grpcapi/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Serve serves the grpc api on the port until the listener fails
+func Serve(port string) errors.Error {
+	port = strings.TrimLeft(port, ":")
+	listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%s", port))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error listening on the grpc port %s", port))
+	}
+	err = NewServer().Serve(listener)
+	if err != nil {
+		return errors.Default.Wrap(err, "error serving the grpc api")
+	}
+	return nil
+}
+
+// NewServer creates the grpc server with the services of the framework registered
+func NewServer() *grpc.Server {
+	server := grpc.NewServer()
+	pb.RegisterPipelineServiceServer(server, &pipelineServer{})
+	return server
+}
+
+// toStatus converts the error to the grpc status closest to its http status
+func toStatus(err errors.Error) error {
+	if err == nil {
+		return nil
+	}
+	code := codes.Internal
+	switch err.GetType().GetHttpCode() {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusGatewayTimeout:
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Messages().Format())
+}
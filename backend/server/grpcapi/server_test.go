@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	assert.Nil(t, toStatus(nil))
+	for err, code := range map[errors.Error]codes.Code{
+		errors.BadInput.New("bad"):                          codes.InvalidArgument,
+		errors.Unauthorized.New("who"):                      codes.Unauthenticated,
+		errors.Forbidden.New("no"):                          codes.PermissionDenied,
+		errors.NotFound.New("gone"):                         codes.NotFound,
+		errors.HttpStatus(http.StatusConflict).New("again"): codes.FailedPrecondition,
+		errors.Default.New("oops"):                          codes.Internal,
+	} {
+		s, ok := status.FromError(toStatus(err))
+		assert.True(t, ok)
+		assert.Equal(t, code, s.Code())
+		assert.Equal(t, err.Messages().Format(), s.Message())
+	}
+}
+
+func TestAuthorizeRequiresApiKey(t *testing.T) {
+	_, err := authorize(context.Background(), http.MethodGet, "/pipelines/:pipelineId", nil)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(API_KEY_METADATA, ""))
+	_, err = authorize(ctx, http.MethodGet, "/pipelines/:pipelineId", nil)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+
+	_, err = authorizePipeline(ctx, 0)
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
@@ -0,0 +1,247 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+const (
+	ACTIVITY_PIPELINE     = "pipeline"     // a pipeline was created or changed its status
+	ACTIVITY_TASK         = "task"         // a task changed its status or made progress
+	ACTIVITY_NOTIFICATION = "notification" // an event was published to the webhooks
+	ACTIVITY_KEEP_ALIVE   = "keep-alive"   // nothing happened for a while
+)
+
+// how often the pipelines are checked for changes while anyone follows the activity
+var activityPollInterval = time.Second
+
+// the events a subscriber may lag behind before the following ones are dropped for it
+const activitySubscriberBuffer = 64
+
+// ActivityEvent is an event of the live activity feed, ProjectName is empty for the events out of any project
+type ActivityEvent struct {
+	Type        string      `json:"type"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	ProjectName string      `json:"projectName,omitempty"`
+	Data        interface{} `json:"data"`
+}
+
+// ActivityNotification is the data of the notification events
+type ActivityNotification struct {
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+type activitySubscriber struct {
+	events chan *ActivityEvent
+	// the projects whose events are delivered, all of them when nil
+	projects map[string]bool
+}
+
+type activityHub struct {
+	mu          sync.Mutex
+	subscribers map[*activitySubscriber]bool
+	stopWatch   context.CancelFunc
+}
+
+var activity = &activityHub{subscribers: make(map[*activitySubscriber]bool)}
+
+// SubscribeActivity follows the live activity of the given projects, or of everything when projectNames is nil.
+// The events are dropped rather than queued for the subscribers lagging behind, and the returned function ends the
+// subscription.
+func SubscribeActivity(projectNames []string) (<-chan *ActivityEvent, func()) {
+	subscriber := &activitySubscriber{events: make(chan *ActivityEvent, activitySubscriberBuffer)}
+	if projectNames != nil {
+		subscriber.projects = make(map[string]bool, len(projectNames))
+		for _, name := range projectNames {
+			subscriber.projects[name] = true
+		}
+	}
+	activity.mu.Lock()
+	activity.subscribers[subscriber] = true
+	// the pipelines are only watched while anyone follows them
+	if activity.stopWatch == nil {
+		var ctx context.Context
+		ctx, activity.stopWatch = context.WithCancel(context.Background())
+		go watchActivity(ctx)
+	}
+	activity.mu.Unlock()
+	var once sync.Once
+	return subscriber.events, func() {
+		once.Do(func() {
+			activity.mu.Lock()
+			defer activity.mu.Unlock()
+			delete(activity.subscribers, subscriber)
+			close(subscriber.events)
+			if len(activity.subscribers) == 0 && activity.stopWatch != nil {
+				activity.stopWatch()
+				activity.stopWatch = nil
+			}
+		})
+	}
+}
+
+func publishActivity(event *ActivityEvent) {
+	activity.mu.Lock()
+	defer activity.mu.Unlock()
+	for subscriber := range activity.subscribers {
+		if subscriber.projects != nil && !subscriber.projects[event.ProjectName] {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+		}
+	}
+}
+
+// publishNotificationActivity relays the event published to the webhooks to the activity feed
+func publishNotificationActivity(event string, data interface{}) {
+	activity.mu.Lock()
+	followed := len(activity.subscribers) > 0
+	activity.mu.Unlock()
+	if !followed {
+		return
+	}
+	projectName := ""
+	switch d := data.(type) {
+	case *models.Pipeline:
+		projectName = activityProjectName(d.BlueprintId, nil)
+	case *models.Blueprint:
+		projectName = d.ProjectName
+	}
+	publishActivity(&ActivityEvent{
+		Type:        ACTIVITY_NOTIFICATION,
+		CreatedAt:   time.Now(),
+		ProjectName: projectName,
+		Data:        &ActivityNotification{Event: event, Data: data},
+	})
+}
+
+// activityProjectName returns the project of the blueprint, the names looked up already are cached
+func activityProjectName(blueprintId uint64, cache map[uint64]string) string {
+	if blueprintId == 0 {
+		return ""
+	}
+	if name, ok := cache[blueprintId]; ok {
+		return name
+	}
+	blueprint := &models.Blueprint{}
+	err := db.First(blueprint, dal.Where("id = ?", blueprintId))
+	if err != nil {
+		if !db.IsErrorNotFound(err) {
+			logger.Warn(err, "failed to find the project of the blueprint #%d", blueprintId)
+		}
+		return ""
+	}
+	if cache != nil {
+		cache[blueprintId] = blueprint.ProjectName
+	}
+	return blueprint.ProjectName
+}
+
+// activityWatcher tells the changes of the pipelines and their tasks apart by their last seen states
+type activityWatcher struct {
+	pipelines map[uint64]string
+	tasks     map[uint64]string
+	projects  map[uint64]string
+	since     time.Time
+}
+
+func newActivityWatcher() *activityWatcher {
+	return &activityWatcher{
+		pipelines: make(map[uint64]string),
+		tasks:     make(map[uint64]string),
+		projects:  make(map[uint64]string),
+		since:     time.Now(),
+	}
+}
+
+func watchActivity(ctx context.Context) {
+	watcher := newActivityWatcher()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(activityPollInterval):
+		}
+		watcher.check()
+	}
+}
+
+// check publishes the changes of the pending pipelines and of the ones finished since the last check
+func (w *activityWatcher) check() {
+	now := time.Now()
+	pipelines := make([]*models.Pipeline, 0)
+	err := db.All(&pipelines, dal.Where("status IN ? OR finished_at >= ?", models.PendingTaskStatus, w.since))
+	if err != nil {
+		logger.Error(err, "failed to find the pipelines for the activity feed")
+		return
+	}
+	w.since = now
+	seenPipelines := make(map[uint64]bool, len(pipelines))
+	seenTasks := make(map[uint64]bool)
+	for _, pipeline := range pipelines {
+		seenPipelines[pipeline.ID] = true
+		projectName := activityProjectName(pipeline.BlueprintId, w.projects)
+		pipeline.Plan = nil
+		if w.changed(w.pipelines, pipeline.ID, pipeline.Status, pipeline.FinishedTasks, pipeline.Stage) {
+			publishActivity(&ActivityEvent{Type: ACTIVITY_PIPELINE, CreatedAt: now, ProjectName: projectName, Data: pipeline})
+		}
+		tasks, err := GetTasksWithLastStatus(pipeline.ID)
+		if err != nil {
+			logger.Error(err, "failed to find the tasks of the pipeline #%d for the activity feed", pipeline.ID)
+			continue
+		}
+		for _, task := range tasks {
+			seenTasks[task.ID] = true
+			task.Options = ""
+			if w.changed(w.tasks, task.ID, task.Status, task.Progress, task.ProgressDetail) {
+				publishActivity(&ActivityEvent{Type: ACTIVITY_TASK, CreatedAt: now, ProjectName: projectName, Data: task})
+			}
+		}
+	}
+	// the ones out of sight are not coming back
+	for id := range w.pipelines {
+		if !seenPipelines[id] {
+			delete(w.pipelines, id)
+		}
+	}
+	for id := range w.tasks {
+		if !seenTasks[id] {
+			delete(w.tasks, id)
+		}
+	}
+}
+
+// changed records the state of the resource and tells whether it differs from the last one recorded
+func (w *activityWatcher) changed(states map[uint64]string, id uint64, state ...interface{}) bool {
+	current, _ := json.Marshal(state)
+	if states[id] == string(current) {
+		return false
+	}
+	states[id] = string(current)
+	return true
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeActivity(t *testing.T) {
+	// keep the watcher from reaching the DB
+	activityPollInterval = time.Hour
+	all, unsubscribeAll := SubscribeActivity(nil)
+	confined, unsubscribeConfined := SubscribeActivity([]string{"project-a"})
+	assert.NotNil(t, activity.stopWatch)
+
+	ofA := &ActivityEvent{Type: ACTIVITY_PIPELINE, ProjectName: "project-a"}
+	ofB := &ActivityEvent{Type: ACTIVITY_TASK, ProjectName: "project-b"}
+	outOfProjects := &ActivityEvent{Type: ACTIVITY_NOTIFICATION}
+	publishActivity(ofA)
+	publishActivity(ofB)
+	publishActivity(outOfProjects)
+	assert.Equal(t, ofA, <-all)
+	assert.Equal(t, ofB, <-all)
+	assert.Equal(t, outOfProjects, <-all)
+	assert.Equal(t, ofA, <-confined)
+	assert.Len(t, confined, 0)
+
+	// the events are dropped for the subscribers lagging behind
+	for i := 0; i < activitySubscriberBuffer+10; i++ {
+		publishActivity(ofA)
+	}
+	assert.Len(t, all, activitySubscriberBuffer)
+
+	unsubscribeConfined()
+	unsubscribeConfined()
+	// the channel is closed once drained
+	drained := 0
+	for range confined {
+		drained++
+	}
+	assert.Equal(t, activitySubscriberBuffer, drained)
+	assert.NotNil(t, activity.stopWatch)
+	unsubscribeAll()
+	assert.Nil(t, activity.stopWatch)
+	assert.Empty(t, activity.subscribers)
+}
+
+func TestActivityWatcherChanged(t *testing.T) {
+	w := newActivityWatcher()
+	assert.True(t, w.changed(w.tasks, 1, models.TASK_RUNNING, 0.5))
+	assert.False(t, w.changed(w.tasks, 1, models.TASK_RUNNING, 0.5))
+	assert.True(t, w.changed(w.tasks, 1, models.TASK_RUNNING, 0.75))
+	assert.True(t, w.changed(w.tasks, 2, models.TASK_RUNNING, 0.75))
+}
@@ -0,0 +1,248 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+const apiKeyPrefix = "dlk_"
+
+// the routes the scopes grant besides the ones of admin, write and read
+var apiKeyScopeRoutes = map[string][]string{
+	// unlike read, it grants neither the connections, which hold the credentials and proxy the data sources, nor the
+	// logs of the pipelines
+	models.API_KEY_SCOPE_READONLY: {
+		"GET /projects",
+		"GET /projects/*projectName",
+		"GET /blueprints",
+		"GET /blueprints/:blueprintId",
+		"GET /blueprints/:blueprintId/pipelines",
+		"GET /pipelines",
+		"GET /pipelines/:pipelineId",
+		"GET /pipelines/:pipelineId/tasks",
+		"GET /activity",
+		"GET /domainlayer/repos",
+		"GET /domainlayer/lineage/:table",
+		"GET /domainlayer/merges",
+		"GET /data-exports",
+		"GET /data-exports/tables/:table",
+		"GET /data-exports/queries/:query",
+		"GET /queries/tables",
+		"POST /queries",
+		"GET /saved-queries",
+		"GET /saved-queries/:name",
+		"GET /saved-queries/:name/results",
+		"GET /graphql",
+		"POST /graphql",
+		"GET /metrics",
+		"GET /plugininfo",
+		"GET /plugins",
+	},
+	models.API_KEY_SCOPE_METRICS_READ: {
+		"GET /metrics",
+	},
+	models.API_KEY_SCOPE_PIPELINES_TRIGGER: {
+		"GET /pipelines",
+		"POST /pipelines",
+		"GET /pipelines/:pipelineId",
+		"GET /pipelines/:pipelineId/tasks",
+		"GET /activity",
+		"POST /pipelines/:pipelineId/rerun",
+		"POST /tasks/:taskId/rerun",
+		"POST /blueprints/:blueprintId/trigger",
+	},
+	models.API_KEY_SCOPE_WEBHOOK_PUSH: {
+		"POST /plugins/webhook/:connectionId/deployments",
+		"POST /plugins/webhook/:connectionId/issues",
+		"POST /plugins/webhook/:connectionId/issue/:issueKey/close",
+		"POST /plugins/webhook/:connectionId/coverages",
+	},
+}
+
+// ApiKeyQuery used to query api keys as the api input
+type ApiKeyQuery struct {
+	Pagination
+}
+
+// ApiKeyRequired tells whether the requests without any credential are rejected
+func ApiKeyRequired() bool {
+	return cfg.GetBool("API_KEY_REQUIRED")
+}
+
+// GetApiKeys returns a paginated list of the api keys, the revoked ones included
+func GetApiKeys(query *ApiKeyQuery) ([]*models.ApiKey, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.ApiKey{}),
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of api keys")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	apiKeys := make([]*models.ApiKey, 0)
+	err = db.All(&apiKeys, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB api keys")
+	}
+	return apiKeys, count, nil
+}
+
+// CreateApiKey generates a key with the given scopes, the key is not stored and cannot be read afterwards
+func CreateApiKey(input *models.ApiInputApiKey) (*models.ApiOutputApiKey, errors.Error) {
+	// the readonly keys must not be granted anything else by mistake
+	for _, scope := range input.Scopes {
+		if scope == models.API_KEY_SCOPE_READONLY && len(input.Scopes) > 1 {
+			return nil, errors.BadInput.New("the readonly scope can not be combined with other scopes")
+		}
+	}
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	if input.ExpiredAt != nil && input.ExpiredAt.Before(time.Now()) {
+		return nil, errors.BadInput.New("the api key would be expired already")
+	}
+	if input.UserId != nil {
+		if _, err := GetUser(*input.UserId); err != nil {
+			return nil, err
+		}
+	}
+	if input.Workspace != "" {
+		if _, err := GetWorkspace(input.Workspace); err != nil {
+			return nil, err
+		}
+	}
+	secret := make([]byte, 20)
+	_, e := rand.Read(secret)
+	if e != nil {
+		return nil, errors.Default.Wrap(e, "error generating the api key")
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+	apiKey := &models.ApiKey{
+		Name:      input.Name,
+		Prefix:    key[:len(apiKeyPrefix)+8],
+		KeyHash:   hashApiKey(key),
+		Scopes:    input.Scopes,
+		ExpiredAt: input.ExpiredAt,
+		UserId:    input.UserId,
+		Workspace: input.Workspace,
+	}
+	err := db.Create(apiKey)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error creating DB api key")
+	}
+	return &models.ApiOutputApiKey{ApiKey: *apiKey, Key: key}, nil
+}
+
+// RevokeApiKey disables an api key for good, the key is kept to tell who used to access the api
+func RevokeApiKey(id uint64) (*models.ApiKey, errors.Error) {
+	apiKey := &models.ApiKey{}
+	err := db.First(apiKey, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("api key not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the api key from DB")
+	}
+	if apiKey.RevokedAt == nil {
+		now := time.Now()
+		apiKey.RevokedAt = &now
+		err = db.UpdateColumn(&models.ApiKey{}, "revoked_at", now, dal.Where("id = ?", id))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error revoking the api key")
+		}
+	}
+	return apiKey, nil
+}
+
+// ApiKeyBootstrapAllowed tells whether the first api key may be created without any credential
+func ApiKeyBootstrapAllowed() (bool, errors.Error) {
+	count, err := db.Count(dal.From(&models.ApiKey{}))
+	return count == 0, err
+}
+
+// AuthenticateApiKey checks the key is valid and one of its scopes grants the route, i.e. `/pipelines/:pipelineId`
+func AuthenticateApiKey(key string, method string, route string) (*models.ApiKey, errors.Error) {
+	apiKey := &models.ApiKey{}
+	err := db.First(apiKey, dal.Where("key_hash = ?", hashApiKey(key)))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.Unauthorized.New("invalid api key")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the api key from DB")
+	}
+	now := time.Now()
+	if apiKey.RevokedAt != nil {
+		return nil, errors.Unauthorized.New("the api key is revoked")
+	}
+	if apiKey.ExpiredAt != nil && apiKey.ExpiredAt.Before(now) {
+		return nil, errors.Unauthorized.New("the api key is expired")
+	}
+	if !ApiKeyScopesAllow(apiKey.Scopes, method, route) {
+		return nil, errors.Forbidden.New("the scopes of the api key do not grant " + method + " " + route)
+	}
+	err = db.UpdateColumn(&models.ApiKey{}, "last_used_at", now, dal.Where("id = ?", apiKey.ID))
+	if err != nil {
+		logger.Warn(err, "failed to record the usage of api key #%d", apiKey.ID)
+	}
+	return apiKey, nil
+}
+
+// ApiKeyScopesAllow tells whether any of the scopes grants the route
+func ApiKeyScopesAllow(scopes []string, method string, route string) bool {
+	management := route == "/api-keys" || strings.HasPrefix(route, "/api-keys/") || strings.HasPrefix(route, "/audit-logs")
+	for _, scope := range scopes {
+		switch scope {
+		case models.API_KEY_SCOPE_ADMIN:
+			return true
+		case models.API_KEY_SCOPE_WRITE:
+			if !management {
+				return true
+			}
+		case models.API_KEY_SCOPE_READ:
+			if !management && (method == http.MethodGet || method == http.MethodHead || (method == http.MethodPost && route == "/graphql")) {
+				return true
+			}
+		default:
+			for _, r := range apiKeyScopeRoutes[scope] {
+				if r == method+" "+route {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyScopesAllow(t *testing.T) {
+	admin := []string{models.API_KEY_SCOPE_ADMIN}
+	write := []string{models.API_KEY_SCOPE_WRITE}
+	read := []string{models.API_KEY_SCOPE_READ}
+	ci := []string{models.API_KEY_SCOPE_PIPELINES_TRIGGER, models.API_KEY_SCOPE_WEBHOOK_PUSH}
+	metrics := []string{models.API_KEY_SCOPE_METRICS_READ}
+	readonly := []string{models.API_KEY_SCOPE_READONLY}
+
+	assert.True(t, ApiKeyScopesAllow(admin, "POST", "/api-keys"))
+	assert.False(t, ApiKeyScopesAllow(write, "POST", "/api-keys"))
+	assert.False(t, ApiKeyScopesAllow(write, "DELETE", "/api-keys/:apiKeyId"))
+	assert.True(t, ApiKeyScopesAllow(write, "PATCH", "/blueprints/:blueprintId"))
+
+	assert.True(t, ApiKeyScopesAllow(read, "GET", "/projects"))
+	assert.True(t, ApiKeyScopesAllow(read, "POST", "/graphql"))
+	assert.False(t, ApiKeyScopesAllow(read, "POST", "/pipelines"))
+	assert.False(t, ApiKeyScopesAllow(read, "GET", "/api-keys"))
+
+	assert.True(t, ApiKeyScopesAllow(ci, "POST", "/blueprints/:blueprintId/trigger"))
+	assert.True(t, ApiKeyScopesAllow(ci, "GET", "/pipelines/:pipelineId"))
+	assert.True(t, ApiKeyScopesAllow(ci, "POST", "/plugins/webhook/:connectionId/deployments"))
+	assert.False(t, ApiKeyScopesAllow(ci, "POST", "/plugins/webhook/connections"))
+	assert.False(t, ApiKeyScopesAllow(ci, "GET", "/metrics"))
+
+	assert.True(t, ApiKeyScopesAllow(metrics, "GET", "/metrics"))
+	assert.False(t, ApiKeyScopesAllow(metrics, "GET", "/projects"))
+	assert.False(t, ApiKeyScopesAllow(nil, "GET", "/metrics"))
+
+	assert.True(t, ApiKeyScopesAllow(readonly, "GET", "/pipelines/:pipelineId"))
+	assert.True(t, ApiKeyScopesAllow(readonly, "POST", "/queries"))
+	assert.True(t, ApiKeyScopesAllow(readonly, "POST", "/graphql"))
+	assert.False(t, ApiKeyScopesAllow(readonly, "GET", "/plugins/github/connections"))
+	assert.False(t, ApiKeyScopesAllow(readonly, "GET", "/plugins/github/connections/:connectionId/proxy/rest/*path"))
+	assert.False(t, ApiKeyScopesAllow(readonly, "GET", "/pipelines/:pipelineId/logging.tar.gz"))
+	assert.False(t, ApiKeyScopesAllow(readonly, "GET", "/config-bundle"))
+	assert.False(t, ApiKeyScopesAllow(readonly, "POST", "/blueprints/:blueprintId/trigger"))
+	assert.False(t, ApiKeyScopesAllow(readonly, "GET", "/proceed-db-migration"))
+}
+
+func TestCreateApiKeyReadonlyAlone(t *testing.T) {
+	_, err := CreateApiKey(&models.ApiInputApiKey{
+		Name:   "grafana",
+		Scopes: []string{models.API_KEY_SCOPE_READONLY, models.API_KEY_SCOPE_WRITE},
+	})
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
@@ -0,0 +1,540 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+	"github.com/apache/incubator-devlake/helpers/objectstore"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/robfig/cron/v3"
+)
+
+const defaultArchiveCron = "0 4 * * *"
+const defaultArchiveAfterDays = 365
+const defaultArchiveTables = "_raw_*"
+const defaultArchiveRowsPerFile = 20000
+
+// the rows of a restore are inserted by statements of this many values at most
+const archiveRestoreValues = 10000
+
+const archiveManifestVersion = 1
+
+// only one archive or restore runs at a time, they would compete for the same tables otherwise
+var archiveRunning atomic.Bool
+
+// ArchiveQuery is the query of the archives
+type ArchiveQuery struct {
+	Pagination
+	Table string `form:"table"`
+}
+
+// archiveManifest describes the parquet files of an archive, it is stored next to them as manifest.json so the
+// archive may be read without DevLake
+type archiveManifest struct {
+	Version      int                   `json:"version"`
+	Table        string                `json:"table"`
+	CutoffColumn string                `json:"cutoffColumn"`
+	Cutoff       time.Time             `json:"cutoff"`
+	Columns      []string              `json:"columns"`
+	Files        []archiveManifestFile `json:"files"`
+	Rows         int64                 `json:"rows"`
+	ArchivedAt   time.Time             `json:"archivedAt"`
+}
+
+type archiveManifestFile struct {
+	Key  string `json:"key"`
+	Rows int64  `json:"rows"`
+}
+
+// archiveServiceInit schedules the archival when ARCHIVE_URL is set, ARCHIVE_CRON is a standard cron expression in UTC
+func archiveServiceInit() {
+	if cfg.GetString("ARCHIVE_URL") == "" {
+		return
+	}
+	spec := cfg.GetString("ARCHIVE_CRON")
+	if spec == "" {
+		spec = defaultArchiveCron
+	}
+	c := cron.New(cron.WithLocation(time.UTC))
+	_, err := c.AddFunc(spec, func() {
+		if _, err := StartArchive(models.ARCHIVE_TRIGGER_CRON); err != nil {
+			logger.Error(err, "failed to start the archival")
+		}
+	})
+	if err != nil {
+		logger.Error(err, "invalid ARCHIVE_CRON %s, the archival is not scheduled", spec)
+		return
+	}
+	c.Start()
+}
+
+func openArchiveStore() (objectstore.Store, errors.Error) {
+	url := cfg.GetString("ARCHIVE_URL")
+	if url == "" {
+		return nil, errors.BadInput.New("the archival is disabled, ARCHIVE_URL is not set")
+	}
+	return objectstore.Open(url, cfg.GetString("ARCHIVE_ACCESS_KEY_ID"), cfg.GetString("ARCHIVE_SECRET_ACCESS_KEY"))
+}
+
+// GetArchives returns the archives, latest first
+func GetArchives(query *ArchiveQuery) ([]*models.Archive, int64, errors.Error) {
+	clauses := []dal.Clause{dal.From(&models.Archive{})}
+	if query.Table != "" {
+		clauses = append(clauses, dal.Where("archived_table = ?", query.Table))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of archives")
+	}
+	archives := make([]*models.Archive, 0)
+	err = db.All(
+		&archives,
+		append(clauses,
+			dal.Orderby("id DESC"),
+			dal.Offset(query.GetSkip()),
+			dal.Limit(query.GetPageSize()),
+		)...,
+	)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB archives")
+	}
+	return archives, count, nil
+}
+
+// GetArchive returns the archive along with its progress
+func GetArchive(id uint64) (*models.Archive, errors.Error) {
+	archive := &models.Archive{}
+	err := db.First(archive, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("archive %d not found", id))
+		}
+		return nil, errors.Default.Wrap(err, "error getting the archive from DB")
+	}
+	return archive, nil
+}
+
+// StartArchive starts exporting the rows older than ARCHIVE_AFTER_DAYS of the tables matching ARCHIVE_TABLES to the
+// object store in the background, the rows are deleted once exported. An archive is returned for every table having
+// such rows, their progress is reported as the tables get archived one after another
+func StartArchive(trigger string) ([]*models.Archive, errors.Error) {
+	store, err := openArchiveStore()
+	if err != nil {
+		return nil, err
+	}
+	if !archiveRunning.CompareAndSwap(false, true) {
+		return nil, errors.BadInput.New("an archival or a restore is already running")
+	}
+	archives, err := planArchives(trigger)
+	if err != nil || len(archives) == 0 {
+		archiveRunning.Store(false)
+		return archives, err
+	}
+	progress := make([]models.Archive, len(archives))
+	for i, archive := range archives {
+		progress[i] = *archive
+	}
+	go func() {
+		defer archiveRunning.Store(false)
+		for i := range progress {
+			archive := &progress[i]
+			now := time.Now()
+			archive.BeganAt = &now
+			archive.Status = models.ARCHIVE_RUNNING
+			err := archiveTable(store, archive)
+			finishedAt := time.Now()
+			archive.FinishedAt = &finishedAt
+			archive.Status = models.ARCHIVE_DONE
+			if err != nil {
+				logger.Error(err, "archive #%d of %s failed", archive.ID, archive.ArchivedTable)
+				archive.Status = models.ARCHIVE_FAILED
+				archive.Message = err.Error()
+			}
+			if err = db.Update(archive); err != nil {
+				logger.Error(err, "failed to save the archive #%d", archive.ID)
+			}
+		}
+	}()
+	return archives, nil
+}
+
+// planArchives creates an archive for every table matching ARCHIVE_TABLES having rows older than ARCHIVE_AFTER_DAYS
+func planArchives(trigger string) ([]*models.Archive, errors.Error) {
+	afterDays := cfg.GetInt("ARCHIVE_AFTER_DAYS")
+	if afterDays <= 0 {
+		afterDays = defaultArchiveAfterDays
+	}
+	patterns := cfg.GetString("ARCHIVE_TABLES")
+	if patterns == "" {
+		patterns = defaultArchiveTables
+	}
+	tables, err := db.AllTables()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().AddDate(0, 0, -afterDays)
+	archives := make([]*models.Archive, 0)
+	for _, table := range matchArchiveTables(tables, strings.Split(patterns, ",")) {
+		columns, err := dal.GetColumnNames(db, &dal.DefaultTabler{Name: table}, nil)
+		if err != nil {
+			return nil, err
+		}
+		cutoffColumn := archiveCutoffColumn(table, columns)
+		if cutoffColumn == "" {
+			logger.Warn(nil, "%s is not archived, it has no id and %s columns", table, archiveCutoffColumn(table, nil))
+			continue
+		}
+		count, err := db.Count(dal.From(table), dal.Where(cutoffColumn+" < ?", cutoff))
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			continue
+		}
+		archive := &models.Archive{
+			ArchivedTable: table,
+			Status:        models.ARCHIVE_RUNNING,
+			Trigger:       trigger,
+			CutoffColumn:  cutoffColumn,
+			Cutoff:        cutoff,
+		}
+		if err = db.Create(archive); err != nil {
+			return nil, errors.Default.Wrap(err, "error creating the archive")
+		}
+		archives = append(archives, archive)
+	}
+	return archives, nil
+}
+
+// matchArchiveTables returns the tables matching any of the path.Match patterns, the tables of the framework and the
+// partitions of the raw tables are never archived
+func matchArchiveTables(tables []string, patterns []string) []string {
+	matched := make([]string, 0)
+	for _, table := range tables {
+		if strings.HasPrefix(table, "_devlake_") || helper.IsRawTablePartitionName(table) {
+			continue
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.TrimSpace(pattern), table); ok {
+				matched = append(matched, table)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// archiveCutoffColumn returns the column telling the age of the rows, the collection time of the raw rows and the
+// last update of the others, or nothing when the table lacks it or the id the rows are deleted by
+func archiveCutoffColumn(table string, columns []string) string {
+	cutoffColumn := "updated_at"
+	if strings.HasPrefix(table, "_raw_") {
+		cutoffColumn = "created_at"
+	}
+	if columns == nil {
+		return cutoffColumn
+	}
+	var hasId, hasCutoff bool
+	for _, column := range columns {
+		hasId = hasId || column == "id"
+		hasCutoff = hasCutoff || column == cutoffColumn
+	}
+	if !hasId || !hasCutoff {
+		return ""
+	}
+	return cutoffColumn
+}
+
+func archiveTable(store objectstore.Store, archive *models.Archive) errors.Error {
+	rowsPerFile := cfg.GetInt("ARCHIVE_ROWS_PER_FILE")
+	if rowsPerFile <= 0 {
+		rowsPerFile = defaultArchiveRowsPerFile
+	}
+	prefix := fmt.Sprintf("%s/%s-%d", archive.ArchivedTable, archive.Cutoff.UTC().Format("20060102"), archive.ID)
+	manifest := &archiveManifest{
+		Version:      archiveManifestVersion,
+		Table:        archive.ArchivedTable,
+		CutoffColumn: archive.CutoffColumn,
+		Cutoff:       archive.Cutoff,
+	}
+	cursor, err := db.Cursor(dal.From(archive.ArchivedTable), dal.Where(archive.CutoffColumn+" < ?", archive.Cutoff))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	columnTypes, e := cursor.ColumnTypes()
+	if e != nil {
+		return errors.Convert(e)
+	}
+	columns := exporthelper.ColumnsOf(columnTypes)
+	for _, column := range columns {
+		manifest.Columns = append(manifest.Columns, column.Name)
+	}
+	row := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range row {
+		pointers[i] = &row[i]
+	}
+	var buf bytes.Buffer
+	var writer exporthelper.RowWriter
+	var fileRows int64
+	putFile := func() errors.Error {
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		key := fmt.Sprintf("%s/part-%05d.parquet", prefix, len(manifest.Files))
+		if err := store.Put(context.Background(), key, buf.Bytes()); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, archiveManifestFile{Key: key, Rows: fileRows})
+		archive.Files++
+		archive.Rows += fileRows
+		writer = nil
+		return db.Update(archive)
+	}
+	for cursor.Next() {
+		if e = cursor.Scan(pointers...); e != nil {
+			return errors.Default.Wrap(errors.Convert(e), "error scanning the rows of "+archive.ArchivedTable)
+		}
+		if writer == nil {
+			buf.Reset()
+			fileRows = 0
+			if writer, err = exporthelper.NewParquetWriter(&buf, columns); err != nil {
+				return err
+			}
+		}
+		if err = writer.Write(row); err != nil {
+			return err
+		}
+		if fileRows++; fileRows >= int64(rowsPerFile) {
+			if err = putFile(); err != nil {
+				return err
+			}
+		}
+	}
+	// the rows may have been cut short by an error of the connection, nothing must be deleted then
+	if rows, ok := cursor.(*sql.Rows); ok && rows.Err() != nil {
+		return errors.Convert(rows.Err())
+	}
+	if writer != nil {
+		if err = putFile(); err != nil {
+			return err
+		}
+	}
+	manifest.Rows = archive.Rows
+	manifest.ArchivedAt = time.Now()
+	body, e := json.MarshalIndent(manifest, "", "  ")
+	if e != nil {
+		return errors.Convert(e)
+	}
+	archive.ManifestKey = prefix + "/manifest.json"
+	if err = store.Put(context.Background(), archive.ManifestKey, body); err != nil {
+		return err
+	}
+	if err = db.Update(archive); err != nil {
+		return err
+	}
+	return deleteArchivedRows(archive)
+}
+
+// deleteArchivedRows deletes the archived rows by batches to keep the transactions small, the whole months of a raw
+// table go away with their partitions first
+func deleteArchivedRows(archive *models.Archive) errors.Error {
+	if strings.HasPrefix(archive.ArchivedTable, "_raw_") {
+		if _, err := helper.DropRawTablePartitionsBefore(db, archive.ArchivedTable, archive.Cutoff); err != nil {
+			return err
+		}
+	}
+	batchSize := cfg.GetInt("RAW_DATA_PURGE_BATCH_SIZE")
+	if batchSize <= 0 {
+		batchSize = defaultRawDataPurgeBatchSize
+	}
+	for {
+		var ids []string
+		err := db.Pluck("id", &ids, dal.From(archive.ArchivedTable), dal.Where(archive.CutoffColumn+" < ?", archive.Cutoff), dal.Limit(batchSize))
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		err = db.Exec("DELETE FROM ? WHERE id IN ?", dal.ClauseTable{Name: archive.ArchivedTable}, ids)
+		if err != nil {
+			return err
+		}
+		archive.DeletedRows += int64(len(ids))
+		if err = db.Update(archive); err != nil {
+			return err
+		}
+		if len(ids) < batchSize {
+			return nil
+		}
+	}
+}
+
+// RestoreArchive starts inserting the rows of the archive back into its table in the background, the rows still in
+// the table are left as they are and the columns the table no longer has are skipped
+func RestoreArchive(id uint64) (*models.Archive, errors.Error) {
+	archive, err := GetArchive(id)
+	if err != nil {
+		return nil, err
+	}
+	if archive.ManifestKey == "" || archive.Status == models.ARCHIVE_RUNNING || archive.Status == models.ARCHIVE_RESTORING {
+		return nil, errors.BadInput.New(fmt.Sprintf("archive %d is %s, it can't be restored", id, archive.Status))
+	}
+	store, err := openArchiveStore()
+	if err != nil {
+		return nil, err
+	}
+	if !archiveRunning.CompareAndSwap(false, true) {
+		return nil, errors.BadInput.New("an archival or a restore is already running")
+	}
+	archive.Status = models.ARCHIVE_RESTORING
+	archive.RestoredRows = 0
+	archive.Message = ""
+	if err = db.Update(archive); err != nil {
+		archiveRunning.Store(false)
+		return nil, errors.Default.Wrap(err, "error updating the archive")
+	}
+	progress := *archive
+	go func() {
+		defer archiveRunning.Store(false)
+		err := restoreArchive(store, &progress)
+		now := time.Now()
+		progress.RestoredAt = &now
+		progress.Status = models.ARCHIVE_RESTORED
+		if err != nil {
+			logger.Error(err, "restore of the archive #%d failed", progress.ID)
+			progress.Status = models.ARCHIVE_RESTORE_FAILED
+			progress.Message = err.Error()
+		}
+		if err = db.Update(&progress); err != nil {
+			logger.Error(err, "failed to save the archive #%d", progress.ID)
+		}
+	}()
+	return archive, nil
+}
+
+func restoreArchive(store objectstore.Store, archive *models.Archive) errors.Error {
+	body, err := store.Get(context.Background(), archive.ManifestKey)
+	if err != nil {
+		return err
+	}
+	manifest := &archiveManifest{}
+	if e := json.Unmarshal(body, manifest); e != nil {
+		return errors.Default.Wrap(e, "invalid manifest "+archive.ManifestKey)
+	}
+	if manifest.Version > archiveManifestVersion {
+		return errors.Default.New(fmt.Sprintf("unsupported manifest version %d", manifest.Version))
+	}
+	tableColumns, err := db.GetColumns(&dal.DefaultTabler{Name: archive.ArchivedTable}, nil)
+	if err != nil {
+		return err
+	}
+	// the binary columns were exported as strings
+	binaryColumns := make(map[string]bool)
+	for _, column := range tableColumns {
+		databaseType := strings.ToUpper(column.DatabaseTypeName())
+		binaryColumns[column.Name()] = strings.Contains(databaseType, "BLOB") ||
+			strings.Contains(databaseType, "BINARY") || databaseType == "BYTEA"
+	}
+	for _, file := range manifest.Files {
+		body, err = store.Get(context.Background(), file.Key)
+		if err != nil {
+			return err
+		}
+		columns, rows, err := exporthelper.ReadParquet(body)
+		if err != nil {
+			return errors.Default.Wrap(err, "invalid archive file "+file.Key)
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(columns))
+		indexes := make([]int, 0, len(columns))
+		for i, column := range columns {
+			if isBinary, ok := binaryColumns[column.Name]; ok {
+				names = append(names, column.Name)
+				indexes = append(indexes, i)
+				for _, row := range rows {
+					if s, ok := row[i].(string); ok && isBinary {
+						row[i] = []byte(s)
+					}
+				}
+			}
+		}
+		if len(names) == 0 {
+			return errors.Default.New(fmt.Sprintf("%s has none of the archived columns", archive.ArchivedTable))
+		}
+		batchSize := archiveRestoreValues / len(names)
+		if batchSize == 0 {
+			batchSize = 1
+		}
+		for start := 0; start < len(rows); start += batchSize {
+			end := start + batchSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+			if err = insertArchivedRows(archive.ArchivedTable, names, indexes, rows[start:end]); err != nil {
+				return err
+			}
+			archive.RestoredRows += int64(end - start)
+			if err = db.Update(archive); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// insertArchivedRows inserts the values of the columns at the indexes of the rows, the rows conflicting with the
+// ones in the table are ignored
+func insertArchivedRows(table string, names []string, indexes []int, rows [][]interface{}) errors.Error {
+	params := []interface{}{dal.ClauseTable{Name: table}}
+	for _, name := range names {
+		params = append(params, dal.ClauseColumn{Name: name})
+	}
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?,", len(names)), ",") + ")"
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = placeholders
+		for _, index := range indexes {
+			params = append(params, row[index])
+		}
+	}
+	statement := fmt.Sprintf("INTO ? %s VALUES %s", placeholders, strings.Join(values, ","))
+	if db.Dialect() == "mysql" {
+		statement = "INSERT IGNORE " + statement
+	} else {
+		statement = "INSERT " + statement + " ON CONFLICT DO NOTHING"
+	}
+	return db.Exec(statement, params...)
+}
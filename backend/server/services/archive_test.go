@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchArchiveTables(t *testing.T) {
+	tables := []string{
+		"_devlake_archives",
+		"_raw_github_api_issues",
+		"_raw_github_api_issues_p202307",
+		"_raw_jira_api_issues",
+		"_tool_github_issues",
+		"issues",
+		"pull_requests",
+	}
+	assert.Equal(t, []string{"_raw_github_api_issues", "_raw_jira_api_issues"}, matchArchiveTables(tables, []string{"_raw_*"}))
+	assert.Equal(t,
+		[]string{"_raw_github_api_issues", "issues"},
+		matchArchiveTables(tables, []string{"_raw_github_*", " issues"}),
+	)
+	assert.Empty(t, matchArchiveTables(tables, []string{"_devlake_*"}))
+}
+
+func TestArchiveCutoffColumn(t *testing.T) {
+	assert.Equal(t, "created_at", archiveCutoffColumn("_raw_jira_api_issues", []string{"id", "params", "data", "created_at"}))
+	assert.Equal(t, "updated_at", archiveCutoffColumn("issues", []string{"id", "created_at", "updated_at"}))
+	assert.Equal(t, "", archiveCutoffColumn("project_mapping", []string{"project_name", "table", "row_id", "updated_at"}))
+	assert.Equal(t, "", archiveCutoffColumn("_raw_jira_api_issues", []string{"id", "params"}))
+}
@@ -0,0 +1,269 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"gorm.io/datatypes"
+)
+
+// the fields holding credentials, their values are masked out of the audit logs
+var auditSensitiveField = regexp.MustCompile(`(?i)(token|password|secret|credential|private_?key|api_?key|app_?key)`)
+
+const auditMask = "******"
+
+// AuditLogQuery used to query and export the audit logs as the api input
+type AuditLogQuery struct {
+	Pagination
+	Actor        string     `form:"actor"`
+	Method       string     `form:"method"`
+	ResourceType string     `form:"resourceType"`
+	ResourceId   string     `form:"resourceId"`
+	Since        *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until        *time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (query *AuditLogQuery) clauses() []dal.Clause {
+	clauses := []dal.Clause{
+		dal.From(&models.AuditLog{}),
+	}
+	if query.Actor != "" {
+		clauses = append(clauses, dal.Where("actor = ?", query.Actor))
+	}
+	if query.Method != "" {
+		clauses = append(clauses, dal.Where("method = ?", query.Method))
+	}
+	if query.ResourceType != "" {
+		clauses = append(clauses, dal.Where("resource_type = ?", query.ResourceType))
+	}
+	if query.ResourceId != "" {
+		clauses = append(clauses, dal.Where("resource_id = ?", query.ResourceId))
+	}
+	if query.Since != nil {
+		clauses = append(clauses, dal.Where("created_at >= ?", *query.Since))
+	}
+	if query.Until != nil {
+		clauses = append(clauses, dal.Where("created_at < ?", *query.Until))
+	}
+	return clauses
+}
+
+// GetAuditLogs returns a paginated list of the audit logs, the latest first
+func GetAuditLogs(query *AuditLogQuery) ([]*models.AuditLog, int64, errors.Error) {
+	clauses := query.clauses()
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of audit logs")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	auditLogs := make([]*models.AuditLog, 0)
+	err = db.All(&auditLogs, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB audit logs")
+	}
+	return auditLogs, count, nil
+}
+
+// ExportAuditLogs writes all the audit logs matching the query, oldest first, as csv or as json lines
+func ExportAuditLogs(query *AuditLogQuery, format string, w io.Writer) errors.Error {
+	if format != "csv" && format != "json" {
+		return errors.BadInput.New("the export format must be csv or json")
+	}
+	cursor, err := db.Cursor(append(query.clauses(), dal.Orderby("id"))...)
+	if err != nil {
+		return errors.Default.Wrap(err, "error querying the audit logs")
+	}
+	defer cursor.Close()
+	var writer *csv.Writer
+	encoder := json.NewEncoder(w)
+	if format == "csv" {
+		writer = csv.NewWriter(w)
+		defer writer.Flush()
+		e := writer.Write([]string{
+			"id", "created_at", "actor", "user_id", "api_key_id", "client_ip", "method", "path",
+			"resource_type", "resource_id", "status", "before", "after", "diff",
+		})
+		if e != nil {
+			return errors.Convert(e)
+		}
+	}
+	for cursor.Next() {
+		auditLog := &models.AuditLog{}
+		err = db.Fetch(cursor, auditLog)
+		if err != nil {
+			return errors.Default.Wrap(err, "error fetching the audit log")
+		}
+		var e error
+		if writer == nil {
+			e = encoder.Encode(auditLog)
+		} else {
+			e = writer.Write([]string{
+				strconv.FormatUint(auditLog.ID, 10),
+				auditLog.CreatedAt.Format(time.RFC3339),
+				auditLog.Actor,
+				formatOptionalId(auditLog.UserId),
+				formatOptionalId(auditLog.ApiKeyId),
+				auditLog.ClientIp,
+				auditLog.Method,
+				auditLog.Path,
+				auditLog.ResourceType,
+				auditLog.ResourceId,
+				strconv.Itoa(auditLog.Status),
+				string(auditLog.Before),
+				string(auditLog.After),
+				string(auditLog.Diff),
+			})
+		}
+		if e != nil {
+			return errors.Convert(e)
+		}
+	}
+	return nil
+}
+
+// SaveAuditLog masks the credentials out of the states of the resource, computes their diff, and stores the log
+func SaveAuditLog(auditLog *models.AuditLog) errors.Error {
+	auditLog.Before = maskAuditState(auditLog.Before)
+	auditLog.After = maskAuditState(auditLog.After)
+	auditLog.Diff = diffAuditStates(auditLog.Before, auditLog.After)
+	err := db.Create(auditLog)
+	if err != nil {
+		return errors.Default.Wrap(err, "error creating DB audit log")
+	}
+	return nil
+}
+
+func formatOptionalId(id *uint64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(*id, 10)
+}
+
+// maskAuditState replaces the values of the sensitive fields, the states which are not json are dropped
+func maskAuditState(state datatypes.JSON) datatypes.JSON {
+	if len(state) == 0 {
+		return nil
+	}
+	var v interface{}
+	if json.Unmarshal(state, &v) != nil {
+		return nil
+	}
+	masked, err := json.Marshal(maskAuditValue(v))
+	if err != nil {
+		return nil
+	}
+	return masked
+}
+
+func maskAuditValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if auditSensitiveField.MatchString(key) {
+				if field != nil && field != "" {
+					value[key] = auditMask
+				}
+			} else {
+				value[key] = maskAuditValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = maskAuditValue(item)
+		}
+	}
+	return v
+}
+
+// diffAuditStates maps the paths of the fields which differ to their values before and after, the arrays are
+// compared as a whole
+func diffAuditStates(before, after datatypes.JSON) datatypes.JSON {
+	if len(before) == 0 && len(after) == 0 {
+		return nil
+	}
+	flatBefore := map[string]interface{}{}
+	flatAfter := map[string]interface{}{}
+	flattenAuditState(before, flatBefore)
+	flattenAuditState(after, flatAfter)
+	type change struct {
+		Before interface{} `json:"before"`
+		After  interface{} `json:"after"`
+	}
+	diff := map[string]change{}
+	for path, value := range flatBefore {
+		if !reflect.DeepEqual(value, flatAfter[path]) {
+			diff[path] = change{Before: value, After: flatAfter[path]}
+		}
+	}
+	for path, value := range flatAfter {
+		if _, ok := flatBefore[path]; !ok {
+			diff[path] = change{After: value}
+		}
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+	// the keys of the maps are sorted by encoding/json
+	result, err := json.Marshal(diff)
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+func flattenAuditState(state datatypes.JSON, flat map[string]interface{}) {
+	if len(state) == 0 {
+		return
+	}
+	var v interface{}
+	if json.Unmarshal(state, &v) != nil {
+		return
+	}
+	flattenAuditValue("", v, flat)
+}
+
+func flattenAuditValue(prefix string, v interface{}, flat map[string]interface{}) {
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		flat[prefix] = v
+		return
+	}
+	for key, field := range fields {
+		path := key
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, key)
+		}
+		flattenAuditValue(path, field, flat)
+	}
+}
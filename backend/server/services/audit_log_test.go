@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestMaskAuditState(t *testing.T) {
+	masked := maskAuditState(datatypes.JSON(`{
+		"name": "github",
+		"token": "ghp_secret",
+		"appKey": "",
+		"settings": {"connections": [{"password": "pwd", "plugin": "jira"}]}
+	}`))
+	assert.JSONEq(t, `{
+		"name": "github",
+		"token": "******",
+		"appKey": "",
+		"settings": {"connections": [{"password": "******", "plugin": "jira"}]}
+	}`, string(masked))
+	assert.Nil(t, maskAuditState(datatypes.JSON(`not json`)))
+	assert.Nil(t, maskAuditState(nil))
+}
+
+func TestDiffAuditStates(t *testing.T) {
+	diff := diffAuditStates(
+		datatypes.JSON(`{"id": 1, "name": "a", "enable": true, "settings": {"cron": "0 0 * * *", "labels": ["x"]}}`),
+		datatypes.JSON(`{"id": 1, "name": "b", "enable": true, "settings": {"cron": "0 0 * * *", "labels": ["x", "y"]}, "projectName": "p"}`),
+	)
+	assert.JSONEq(t, `{
+		"name": {"before": "a", "after": "b"},
+		"settings.labels": {"before": ["x"], "after": ["x", "y"]},
+		"projectName": {"before": null, "after": "p"}
+	}`, string(diff))
+
+	diff = diffAuditStates(datatypes.JSON(`{"id": 1, "name": "a"}`), nil)
+	assert.JSONEq(t, `{"id": {"before": 1, "after": null}, "name": {"before": "a", "after": null}}`, string(diff))
+
+	assert.Nil(t, diffAuditStates(datatypes.JSON(`{"id": 1}`), datatypes.JSON(`{"id": 1}`)))
+}
@@ -59,6 +59,15 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// Identity is who a token was issued to
+type Identity struct {
+	Name   string
+	Email  string
+	Groups []string
+	// IssuedAt is the iat claim of the token, in seconds since the epoch
+	IssuedAt int64
+}
+
 // auth provider interface
 type AuthProvider interface {
 	SignIn(*LoginRequest) (*LoginResponse, errors.Error)
@@ -66,6 +75,16 @@ type AuthProvider interface {
 	RefreshToken(*RefreshTokenRequest) (*LoginResponse, errors.Error)
 	// ChangePassword(ctx *gin.Context, oldPassword, newPassword string) errors.Error
 	CheckAuth(token string) (*jwt.Token, errors.Error)
+	// GetIdentity reads the user out of a token checked by CheckAuth
+	GetIdentity(token *jwt.Token) (*Identity, errors.Error)
+}
+
+// RedirectProvider is implemented by the providers signing the users in on their own page, i.e. the single sign-on
+type RedirectProvider interface {
+	// AuthCodeURL returns the page of the provider, which redirects back to redirectUrl along with a code
+	AuthCodeURL(state, codeVerifier, redirectUrl string) string
+	// Exchange trades the code for the tokens
+	Exchange(code, codeVerifier, redirectUrl string) (*LoginResponse, errors.Error)
 }
 
 var Provider AuthProvider
@@ -77,6 +96,9 @@ func InitProvider(basicRes context.BasicRes) {
 	if awsCognitoEnabled {
 		Provider = NewCognitoProvider(basicRes)
 	}
+	if v.GetBool("OIDC_ENABLED") {
+		Provider = NewOidcProvider(basicRes)
+	}
 }
 
 func Middleware(ctx *gin.Context) {
@@ -108,3 +130,44 @@ func Middleware(ctx *gin.Context) {
 func Enabled() bool {
 	return Provider != nil
 }
+
+// RedirectEnabled tells whether the users may sign in on the page of the provider
+func RedirectEnabled() bool {
+	_, ok := Provider.(RedirectProvider)
+	return ok
+}
+
+// stringClaim returns the first of the claims present in the token
+func stringClaim(claims jwt.MapClaims, names ...string) string {
+	for _, name := range names {
+		if value, ok := claims[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// int64Claim reads a numeric claim, 0 when missing
+func int64Claim(claims jwt.MapClaims, name string) int64 {
+	if value, ok := claims[name].(float64); ok {
+		return int64(value)
+	}
+	return 0
+}
+
+// stringsClaim reads a claim holding either a list of strings or a single one
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
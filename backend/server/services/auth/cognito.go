@@ -196,6 +196,24 @@ func (cgt *AwsCognitoProvider) CheckAuth(tokenString string) (*jwt.Token, errors
 	return token, nil
 }
 
+// GetIdentity reads the username out of the access tokens, or of the id tokens where it is prefixed by the provider
+func (cgt *AwsCognitoProvider) GetIdentity(token *jwt.Token) (*Identity, errors.Error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.Unauthorized.New("the token does not carry any claims")
+	}
+	identity := &Identity{
+		Name:     stringClaim(claims, "username", "cognito:username"),
+		Email:    stringClaim(claims, "email"),
+		Groups:   stringsClaim(claims, "cognito:groups"),
+		IssuedAt: int64Claim(claims, "iat"),
+	}
+	if identity.Name == "" {
+		return nil, errors.Unauthorized.New("the token does not carry any username")
+	}
+	return identity, nil
+}
+
 func pemHeader(encodedKey string) []byte {
 	// Decode the base64 encoded key
 	key, err := base64.RawURLEncoding.DecodeString(encodedKey)
@@ -209,8 +227,12 @@ func pemHeader(encodedKey string) []byte {
 type Jwks struct {
 	Keys []struct {
 		Kid string `json:"kid"`
+		Kty string `json:"kty"`
 		N   string `json:"n"`
 		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
 	} `json:"keys"`
 }
 
@@ -0,0 +1,369 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/dgrijalva/jwt-go"
+)
+
+// the keys of the issuer are fetched again for an unknown key id, but not more often than this
+const oidcJwksMinRefreshInterval = time.Minute
+
+// OidcProvider authenticates the users against an OpenID Connect issuer, i.e. Okta, Azure AD or Keycloak.
+// The id token is used as the bearer token of the api since its audience is lake, which is not always true of the
+// access token.
+type OidcProvider struct {
+	issuer         string
+	clientId       string
+	clientSecret   string
+	audiences      []string
+	scopes         string
+	usernameClaims []string
+	groupsClaim    string
+	discovery      oidcDiscovery
+	client         *http.Client
+	logger         log.Logger
+
+	jwksLock      sync.RWMutex
+	jwks          Jwks
+	jwksFetchedAt time.Time
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IdToken          string `json:"id_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	TokenType        string `json:"token_type"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func NewOidcProvider(basicRes context.BasicRes) *OidcProvider {
+	v := basicRes.GetConfigReader()
+	provider := &OidcProvider{
+		issuer:       strings.TrimSuffix(v.GetString("OIDC_ISSUER"), "/"),
+		clientId:     v.GetString("OIDC_CLIENT_ID"),
+		clientSecret: v.GetString("OIDC_CLIENT_SECRET"),
+		scopes:       v.GetString("OIDC_SCOPES"),
+		groupsClaim:  v.GetString("OIDC_GROUPS_CLAIM"),
+		client:       &http.Client{Timeout: 30 * time.Second},
+		logger:       basicRes.GetLogger().Nested("oidc"),
+	}
+	if provider.issuer == "" || provider.clientId == "" {
+		panic(fmt.Errorf("OIDC_ISSUER and OIDC_CLIENT_ID are required by OIDC_ENABLED"))
+	}
+	if provider.scopes == "" {
+		provider.scopes = "openid profile email"
+	}
+	if provider.groupsClaim == "" {
+		provider.groupsClaim = "groups"
+	}
+	provider.audiences = []string{provider.clientId}
+	for _, audience := range strings.Split(v.GetString("OIDC_AUDIENCES"), ",") {
+		if audience = strings.TrimSpace(audience); audience != "" {
+			provider.audiences = append(provider.audiences, audience)
+		}
+	}
+	if claim := v.GetString("OIDC_USERNAME_CLAIM"); claim != "" {
+		provider.usernameClaims = []string{claim}
+	}
+	provider.usernameClaims = append(provider.usernameClaims, "preferred_username", "email")
+	err := provider.discover()
+	if err != nil {
+		panic(err)
+	}
+	err = provider.fetchJWKS()
+	if err != nil {
+		panic(err)
+	}
+	return provider
+}
+
+func (p *OidcProvider) discover() errors.Error {
+	err := p.getJson(p.issuer+"/.well-known/openid-configuration", &p.discovery)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to discover the OIDC issuer "+p.issuer)
+	}
+	if strings.TrimSuffix(p.discovery.Issuer, "/") != p.issuer {
+		return errors.Default.New(fmt.Sprintf("the OIDC issuer %s calls itself %s", p.issuer, p.discovery.Issuer))
+	}
+	return nil
+}
+
+func (p *OidcProvider) fetchJWKS() errors.Error {
+	jwks := Jwks{}
+	err := p.getJson(p.discovery.JwksUri, &jwks)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to fetch JWKS")
+	}
+	p.jwksLock.Lock()
+	defer p.jwksLock.Unlock()
+	p.jwks = jwks
+	p.jwksFetchedAt = time.Now()
+	return nil
+}
+
+func (p *OidcProvider) getJson(uri string, v interface{}) errors.Error {
+	res, err := p.client.Get(uri)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to get "+uri)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return errors.Default.New(fmt.Sprintf("unexpected status code %d getting %s", res.StatusCode, uri))
+	}
+	err = json.NewDecoder(res.Body).Decode(v)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to decode "+uri)
+	}
+	return nil
+}
+
+// SignIn trades the password of the user for the tokens, the issuer has to allow the password grant to the client
+func (p *OidcProvider) SignIn(loginReq *LoginRequest) (*LoginResponse, errors.Error) {
+	return p.requestToken(url.Values{
+		"grant_type": {"password"},
+		"username":   {loginReq.Username},
+		"password":   {loginReq.Password},
+		"scope":      {p.scopes},
+	})
+}
+
+func (p *OidcProvider) NewPassword(*NewPasswordRequest) (*LoginResponse, errors.Error) {
+	return nil, errors.BadInput.New("the passwords are managed by the OIDC issuer")
+}
+
+func (p *OidcProvider) RefreshToken(req *RefreshTokenRequest) (*LoginResponse, errors.Error) {
+	return p.requestToken(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {req.RefreshToken},
+		"scope":         {p.scopes},
+	})
+}
+
+// AuthCodeURL returns the authorization page of the issuer, the code is bound to the verifier by PKCE
+func (p *OidcProvider) AuthCodeURL(state, codeVerifier, redirectUrl string) string {
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.clientId},
+		"redirect_uri":          {redirectUrl},
+		"scope":                 {p.scopes},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.discovery.AuthorizationEndpoint + separator + query.Encode()
+}
+
+func (p *OidcProvider) Exchange(code, codeVerifier, redirectUrl string) (*LoginResponse, errors.Error) {
+	return p.requestToken(url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {codeVerifier},
+		"redirect_uri":  {redirectUrl},
+	})
+}
+
+func (p *OidcProvider) requestToken(form url.Values) (*LoginResponse, errors.Error) {
+	form.Set("client_id", p.clientId)
+	if p.clientSecret != "" {
+		form.Set("client_secret", p.clientSecret)
+	}
+	res, err := p.client.PostForm(p.discovery.TokenEndpoint, form)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to request the token")
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to read the token")
+	}
+	token := &oidcTokenResponse{}
+	err = json.Unmarshal(body, token)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to decode the token, status code %d", res.StatusCode))
+	}
+	if token.Error != "" {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s: %s", token.Error, token.ErrorDescription))
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Default.New(fmt.Sprintf("unexpected status code %d requesting the token", res.StatusCode))
+	}
+	if token.IdToken == "" {
+		return nil, errors.Default.New("the OIDC issuer did not return any id token, is the openid scope granted?")
+	}
+	return &LoginResponse{
+		AuthenticationResult: &AuthenticationResult{
+			AccessToken:  &token.IdToken,
+			ExpiresIn:    &token.ExpiresIn,
+			IdToken:      &token.IdToken,
+			RefreshToken: &token.RefreshToken,
+			TokenType:    &token.TokenType,
+		},
+	}, nil
+}
+
+func (p *OidcProvider) CheckAuth(tokenString string) (*jwt.Token, errors.Error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, errors.Unauthorized.New(fmt.Sprintf("Unexpected signing method: %v", token.Header["alg"]))
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(kid)
+	})
+	if err != nil {
+		if ve, ok := err.(*jwt.ValidationError); ok && ve.Errors == jwt.ValidationErrorExpired {
+			return nil, errors.Forbidden.New("Token expired")
+		}
+	}
+	if err != nil || !token.Valid {
+		p.logger.Error(err, "Invalid token")
+		return nil, errors.Unauthorized.New("Invalid token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || strings.TrimSuffix(stringClaim(claims, "iss"), "/") != p.issuer {
+		return nil, errors.Unauthorized.New("Invalid token: unexpected issuer")
+	}
+	if !p.acceptsAudience(stringsClaim(claims, "aud")) {
+		return nil, errors.Unauthorized.New("Invalid token: unexpected audience")
+	}
+	return token, nil
+}
+
+func (p *OidcProvider) acceptsAudience(audiences []string) bool {
+	for _, audience := range audiences {
+		for _, accepted := range p.audiences {
+			if audience == accepted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// publicKey looks the key up in the JWKS of the issuer, fetching it again in case the keys were rotated
+func (p *OidcProvider) publicKey(kid string) (interface{}, error) {
+	p.jwksLock.RLock()
+	key, err := p.jwks.publicKey(kid)
+	stale := time.Since(p.jwksFetchedAt) > oidcJwksMinRefreshInterval
+	p.jwksLock.RUnlock()
+	if key != nil || !stale {
+		return key, err
+	}
+	if err := p.fetchJWKS(); err != nil {
+		return nil, err
+	}
+	p.jwksLock.RLock()
+	defer p.jwksLock.RUnlock()
+	return p.jwks.publicKey(kid)
+}
+
+func (p *OidcProvider) GetIdentity(token *jwt.Token) (*Identity, errors.Error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.Unauthorized.New("the token does not carry any claims")
+	}
+	identity := &Identity{
+		Name:     stringClaim(claims, p.usernameClaims...),
+		Email:    stringClaim(claims, "email"),
+		Groups:   stringsClaim(claims, p.groupsClaim),
+		IssuedAt: int64Claim(claims, "iat"),
+	}
+	if identity.Name == "" {
+		return nil, errors.Unauthorized.New("the token does not carry any username")
+	}
+	return identity, nil
+}
+
+// publicKey builds the RSA or EC key of the given id
+func (jwks *Jwks) publicKey(kid string) (interface{}, error) {
+	for _, key := range jwks.Keys {
+		if key.Kid != kid {
+			continue
+		}
+		switch key.Kty {
+		case "EC":
+			var curve elliptic.Curve
+			switch key.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			case "P-521":
+				curve = elliptic.P521()
+			default:
+				return nil, fmt.Errorf("unsupported curve %s", key.Crv)
+			}
+			return &ecdsa.PublicKey{
+				Curve: curve,
+				X:     new(big.Int).SetBytes(pemHeader(key.X)),
+				Y:     new(big.Int).SetBytes(pemHeader(key.Y)),
+			}, nil
+		default:
+			return &rsa.PublicKey{
+				N: new(big.Int).SetBytes(pemHeader(key.N)),
+				E: int(new(big.Int).SetBytes(pemHeader(key.E)).Int64()),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("Public key not found")
+}
+
+// NewCodeVerifier returns a random string fit for the state and the PKCE code verifier
+func NewCodeVerifier() (string, errors.Error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "failed to generate a random string")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
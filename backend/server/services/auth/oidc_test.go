@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIssuer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                server.URL,
+			AuthorizationEndpoint: server.URL + "/authorize",
+			TokenEndpoint:         server.URL + "/token",
+			JwksUri:               server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "k1",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		if r.PostForm.Get("code") != "good" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"bad code"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id_token": "id", "refresh_token": "refresh", "expires_in": 300})
+	})
+	return server
+}
+
+func newTestOidcProvider(t *testing.T, issuer string) *OidcProvider {
+	provider := &OidcProvider{
+		issuer:         issuer,
+		clientId:       "lake",
+		audiences:      []string{"lake"},
+		scopes:         "openid",
+		usernameClaims: []string{"preferred_username", "email"},
+		groupsClaim:    "groups",
+		client:         http.DefaultClient,
+		logger:         logruslog.Global,
+	}
+	assert.Nil(t, provider.discover())
+	assert.Nil(t, provider.fetchJWKS())
+	return provider
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString(key)
+	assert.Nil(t, err)
+	return signed
+}
+
+func TestOidcCheckAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	server := newTestIssuer(t, key)
+	defer server.Close()
+	provider := newTestOidcProvider(t, server.URL)
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	token, e := provider.CheckAuth(signTestToken(t, key, jwt.MapClaims{
+		"iss":                server.URL,
+		"aud":                []string{"other", "lake"},
+		"exp":                expiresAt,
+		"preferred_username": "jane",
+		"email":              "jane@example.com",
+		"groups":             []string{"team-a", "team-b"},
+	}))
+	assert.Nil(t, e)
+	identity, e := provider.GetIdentity(token)
+	assert.Nil(t, e)
+	assert.Equal(t, &Identity{Name: "jane", Email: "jane@example.com", Groups: []string{"team-a", "team-b"}}, identity)
+
+	_, e = provider.CheckAuth(signTestToken(t, key, jwt.MapClaims{"iss": server.URL, "aud": "other", "exp": expiresAt}))
+	assert.NotNil(t, e)
+	_, e = provider.CheckAuth(signTestToken(t, key, jwt.MapClaims{"iss": "https://evil", "aud": "lake", "exp": expiresAt}))
+	assert.NotNil(t, e)
+	_, e = provider.CheckAuth(signTestToken(t, key, jwt.MapClaims{"iss": server.URL, "aud": "lake", "exp": time.Now().Add(-time.Hour).Unix()}))
+	assert.NotNil(t, e)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	_, e = provider.CheckAuth(signTestToken(t, otherKey, jwt.MapClaims{"iss": server.URL, "aud": "lake", "exp": expiresAt}))
+	assert.NotNil(t, e)
+}
+
+func TestOidcExchange(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	server := newTestIssuer(t, key)
+	defer server.Close()
+	provider := newTestOidcProvider(t, server.URL)
+
+	assert.Contains(t, provider.AuthCodeURL("s", "v", "http://lake/login/sso/callback"), server.URL+"/authorize?")
+	res, e := provider.Exchange("good", "v", "http://lake/login/sso/callback")
+	assert.Nil(t, e)
+	assert.Equal(t, "id", *res.AuthenticationResult.AccessToken)
+	assert.Equal(t, "refresh", *res.AuthenticationResult.RefreshToken)
+	_, e = provider.Exchange("bad", "v", "http://lake/login/sso/callback")
+	assert.NotNil(t, e)
+}
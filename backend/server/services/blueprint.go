@@ -42,6 +42,10 @@ type BlueprintQuery struct {
 	Enable   *bool  `form:"enable,omitempty"`
 	IsManual *bool  `form:"isManual"`
 	Label    string `form:"label"`
+	// ProjectNames restricts the blueprints to the projects visible to the user when it is not nil
+	ProjectNames []string `form:"-"`
+	// List holds the sorting and the filters by the fields of the blueprints
+	List *helper.ListQuery `form:"-"`
 }
 
 type BlueprintJob struct {
@@ -78,11 +82,14 @@ func CreateBlueprint(blueprint *models.Blueprint) errors.Error {
 // GetBlueprints returns a paginated list of Blueprints based on `query`
 func GetBlueprints(query *BlueprintQuery) ([]*models.Blueprint, int64, errors.Error) {
 	blueprints, count, err := bpManager.GetDbBlueprints(&services.GetBlueprintQuery{
-		Enable:      query.Enable,
-		IsManual:    query.IsManual,
-		Label:       query.Label,
-		SkipRecords: query.GetSkip(),
-		PageSize:    query.GetPageSize(),
+		Enable:       query.Enable,
+		IsManual:     query.IsManual,
+		Label:        query.Label,
+		ProjectNames: query.ProjectNames,
+		Clauses:      query.List.Clauses(),
+		Orderby:      query.List.Orderby("id DESC"),
+		SkipRecords:  query.GetSkip(),
+		PageSize:     query.GetPageSize(),
 	})
 	if err != nil {
 		return nil, 0, errors.Convert(err)
@@ -207,6 +214,7 @@ func PatchBlueprint(id uint64, body map[string]interface{}) (*models.Blueprint,
 	}
 
 	originMode := blueprint.Mode
+	originEnable := blueprint.Enable
 	err = helper.DecodeMapStruct(body, blueprint, true)
 	if err != nil {
 		return nil, err
@@ -220,6 +228,9 @@ func PatchBlueprint(id uint64, body map[string]interface{}) (*models.Blueprint,
 	if err != nil {
 		return nil, err
 	}
+	if originEnable && !blueprint.Enable {
+		PublishEvent(models.EVENT_BLUEPRINT_DISABLED, blueprint)
+	}
 
 	return blueprint, nil
 }
@@ -299,7 +310,16 @@ func MakePlanForBlueprint(blueprint *models.Blueprint) (plugin.PipelinePlan, err
 
 	bpSyncPolicy := plugin.BlueprintSyncPolicy{}
 	bpSyncPolicy.TimeAfter = bpSettings.TimeAfter
+	return makePlanForSettings(blueprint, bpSettings, bpSyncPolicy)
+}
 
+// makePlanForSettings generates the pipeline plan of the blueprint with the given settings and sync policy
+func makePlanForSettings(
+	blueprint *models.Blueprint,
+	bpSettings *models.BlueprintSettings,
+	bpSyncPolicy plugin.BlueprintSyncPolicy,
+) (plugin.PipelinePlan, errors.Error) {
+	var err errors.Error
 	var plan plugin.PipelinePlan
 	switch bpSettings.Version {
 	case "1.0.0":
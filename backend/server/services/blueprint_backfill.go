@@ -0,0 +1,158 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+// BlueprintBackfill restricts a run of a blueprint to a historical window, and optionally to some of its scopes and
+// entities, so that a gap in the data is filled without a full refresh
+type BlueprintBackfill struct {
+	plugin.BackfillWindow
+	// Scopes the backfill is restricted to, all the scopes of the blueprint if empty
+	Scopes []*BackfillScope `json:"scopes" validate:"dive"`
+	// Entities the backfill is restricted to, i.e. CODE or TICKET, all the entities of the scopes if empty
+	Entities []string `json:"entities"`
+}
+
+// BackfillScope identifies a scope of the blueprint
+type BackfillScope struct {
+	Plugin       string `json:"plugin" validate:"required"`
+	ConnectionId uint64 `json:"connectionId" validate:"required"`
+	ScopeId      string `json:"scopeId" validate:"required"`
+}
+
+// BackfillBlueprint creates a pipeline collecting the window of the backfill for the selected scopes and entities of
+// the blueprint, the collected data is merged into the existing one
+func BackfillBlueprint(id uint64, backfill *BlueprintBackfill) (*models.Pipeline, errors.Error) {
+	err := VerifyStruct(backfill)
+	if err != nil {
+		return nil, err
+	}
+	err = backfill.Validate()
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range backfill.Entities {
+		if !utils.StringsContains(plugin.DOMAIN_TYPES, entity) {
+			return nil, errors.BadInput.New(fmt.Sprintf("invalid entity(domain type): %s", entity))
+		}
+	}
+	blueprint, err := GetBlueprint(id)
+	if err != nil {
+		return nil, err
+	}
+	if blueprint.Mode != models.BLUEPRINT_MODE_NORMAL {
+		return nil, errors.BadInput.New("only the blueprints in NORMAL mode can be backfilled")
+	}
+	bpSettings, err := blueprint.UnmarshalSettings()
+	if err != nil {
+		return nil, err
+	}
+	if bpSettings.Version != "2.0.0" {
+		return nil, errors.BadInput.New(fmt.Sprintf("blueprint settings of version %s can't be backfilled", bpSettings.Version))
+	}
+	connections, err := bpSettings.UnmarshalConnections()
+	if err != nil {
+		return nil, err
+	}
+	connections = backfill.selectScopes(connections)
+	if len(connections) == 0 {
+		return nil, errors.BadInput.New("none of the scopes of the blueprint is backfilled")
+	}
+	bpSettings.Connections, err = errors.Convert01(json.Marshal(connections))
+	if err != nil {
+		return nil, err
+	}
+
+	// the plugins collect from the start of the window
+	bpSyncPolicy := plugin.BlueprintSyncPolicy{}
+	bpSyncPolicy.TimeAfter = backfill.From
+	plan, err := makePlanForSettings(blueprint, &bpSettings, bpSyncPolicy)
+	if err != nil {
+		return nil, err
+	}
+	newPipeline := models.NewPipeline{}
+	newPipeline.Plan = plan
+	newPipeline.Name = fmt.Sprintf("%s (backfill %s)", blueprint.Name, &backfill.BackfillWindow)
+	newPipeline.BlueprintId = blueprint.ID
+	newPipeline.Labels = blueprint.Labels
+	newPipeline.SkipOnFail = blueprint.SkipOnFail
+	newPipeline.Backfill = &backfill.BackfillWindow
+	return CreatePipeline(&newPipeline)
+}
+
+// selectScopes keeps the connections and scopes which are backfilled, the entities of the scopes are narrowed down
+// to the backfilled ones
+func (backfill *BlueprintBackfill) selectScopes(connections []*plugin.BlueprintConnectionV200) []*plugin.BlueprintConnectionV200 {
+	selected := make([]*plugin.BlueprintConnectionV200, 0, len(connections))
+	for _, connection := range connections {
+		scopes := make([]*plugin.BlueprintScopeV200, 0, len(connection.Scopes))
+		for _, scope := range connection.Scopes {
+			if !backfill.coversScope(connection, scope) {
+				continue
+			}
+			if len(backfill.Entities) > 0 {
+				// a scope without entities collects all of them
+				if len(scope.Entities) == 0 {
+					scope.Entities = backfill.Entities
+				} else {
+					scope.Entities = intersectEntities(scope.Entities, backfill.Entities)
+				}
+				if len(scope.Entities) == 0 {
+					continue
+				}
+			}
+			scopes = append(scopes, scope)
+		}
+		if len(scopes) > 0 {
+			connection.Scopes = scopes
+			selected = append(selected, connection)
+		}
+	}
+	return selected
+}
+
+func (backfill *BlueprintBackfill) coversScope(connection *plugin.BlueprintConnectionV200, scope *plugin.BlueprintScopeV200) bool {
+	if len(backfill.Scopes) == 0 {
+		return true
+	}
+	for _, s := range backfill.Scopes {
+		if s.Plugin == connection.Plugin && s.ConnectionId == connection.ConnectionId && s.ScopeId == scope.Id {
+			return true
+		}
+	}
+	return false
+}
+
+func intersectEntities(entities []string, wanted []string) []string {
+	intersection := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		if utils.StringsContains(wanted, entity) {
+			intersection = append(intersection, entity)
+		}
+	}
+	return intersection
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func backfillConnections() []*plugin.BlueprintConnectionV200 {
+	return []*plugin.BlueprintConnectionV200{
+		{
+			Plugin:       "github",
+			ConnectionId: 1,
+			Scopes: []*plugin.BlueprintScopeV200{
+				{Id: "1", Entities: []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET}},
+				{Id: "2"},
+			},
+		},
+		{
+			Plugin:       "jira",
+			ConnectionId: 1,
+			Scopes: []*plugin.BlueprintScopeV200{
+				{Id: "1", Entities: []string{plugin.DOMAIN_TYPE_TICKET}},
+			},
+		},
+	}
+}
+
+func TestBackfillSelectScopes(t *testing.T) {
+	backfill := &BlueprintBackfill{}
+	assert.Equal(t, backfillConnections(), backfill.selectScopes(backfillConnections()))
+
+	backfill.Scopes = []*BackfillScope{{Plugin: "github", ConnectionId: 1, ScopeId: "1"}}
+	selected := backfill.selectScopes(backfillConnections())
+	assert.Len(t, selected, 1)
+	assert.Equal(t, "github", selected[0].Plugin)
+	assert.Len(t, selected[0].Scopes, 1)
+	assert.Equal(t, "1", selected[0].Scopes[0].Id)
+}
+
+func TestBackfillSelectEntities(t *testing.T) {
+	backfill := &BlueprintBackfill{Entities: []string{plugin.DOMAIN_TYPE_CODE}}
+	selected := backfill.selectScopes(backfillConnections())
+	// the jira scope has no code
+	assert.Len(t, selected, 1)
+	assert.Len(t, selected[0].Scopes, 2)
+	assert.Equal(t, []string{plugin.DOMAIN_TYPE_CODE}, selected[0].Scopes[0].Entities)
+	// a scope without entities collects all of them
+	assert.Equal(t, []string{plugin.DOMAIN_TYPE_CODE}, selected[0].Scopes[1].Entities)
+
+	backfill.Scopes = []*BackfillScope{{Plugin: "jira", ConnectionId: 1, ScopeId: "1"}}
+	assert.Empty(t, backfill.selectScopes(backfillConnections()))
+}
+
+func TestBackfillWindowValidate(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	assert.NotNil(t, (&plugin.BackfillWindow{}).Validate())
+	assert.NotNil(t, (&plugin.BackfillWindow{From: &to, To: &from}).Validate())
+	assert.Nil(t, (&plugin.BackfillWindow{From: &from}).Validate())
+	assert.Nil(t, (&plugin.BackfillWindow{From: &from, To: &to}).Validate())
+	assert.Equal(t, "[2023-01-01T00:00:00Z, 2023-02-01T00:00:00Z)", (&plugin.BackfillWindow{From: &from, To: &to}).String())
+}
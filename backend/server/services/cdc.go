@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/helpers/eventstream"
+)
+
+const defaultCdcTopicPrefix = "devlake."
+const cdcRelayBatchSize = 500
+
+var cdcRelayInterval = time.Second
+var cdcRetryInterval = 30 * time.Second
+
+// cdcMessage is the value of the message of a change, Row is null for a deletion
+type cdcMessage struct {
+	Table     string          `json:"table"`
+	Operation string          `json:"op"`
+	Key       json.RawMessage `json:"key"`
+	Row       json.RawMessage `json:"row"`
+	ChangedAt time.Time       `json:"changedAt"`
+}
+
+// cdcServiceInit relays the changes of the tables selected by CDC_TABLES to CDC_BROKER, a topic per table
+func cdcServiceInit() {
+	if len(runner.GetCdcTables(cfg)) == 0 {
+		return
+	}
+	broker := cfg.GetString("CDC_BROKER")
+	if broker == "" {
+		logger.Warn(nil, "CDC_TABLES is set without CDC_BROKER, the captured changes are kept until it is set")
+		return
+	}
+	publisher, err := eventstream.Open(broker)
+	if err != nil {
+		logger.Error(err, "invalid CDC_BROKER, the captured changes are not published")
+		return
+	}
+	topicPrefix := cfg.GetString("CDC_TOPIC_PREFIX")
+	if topicPrefix == "" {
+		topicPrefix = defaultCdcTopicPrefix
+	}
+	go func() {
+		for {
+			relayed, err := relayCdcEvents(publisher, topicPrefix)
+			if err != nil {
+				logger.Error(err, "failed to publish the captured changes, retrying in %s", cdcRetryInterval)
+				time.Sleep(cdcRetryInterval)
+				continue
+			}
+			if relayed < cdcRelayBatchSize {
+				time.Sleep(cdcRelayInterval)
+			}
+		}
+	}()
+}
+
+// relayCdcEvents publishes the oldest captured changes and deletes them once acknowledged, they are published again
+// if the server stops in between
+func relayCdcEvents(publisher eventstream.Publisher, topicPrefix string) (int, errors.Error) {
+	var events []*models.CdcEvent
+	err := db.All(&events, dal.Orderby("id"), dal.Limit(cdcRelayBatchSize))
+	if err != nil {
+		return 0, errors.Default.Wrap(err, "error reading the captured changes")
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+	topics, messages, err := makeCdcMessages(events, topicPrefix)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	for _, topic := range topics {
+		err = publisher.Publish(ctx, topic, messages[topic])
+		if err != nil {
+			return 0, err
+		}
+	}
+	// a change committed late may have a lower id than the ones published, it is left for the next batch
+	ids := make([]uint64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	err = db.Delete(&models.CdcEvent{}, dal.Where("id IN ?", ids))
+	if err != nil {
+		return 0, errors.Default.Wrap(err, "error deleting the published changes")
+	}
+	return len(events), nil
+}
+
+// makeCdcMessages returns the messages of the events by topic, in order. Only the last change of a row is kept, i.e.
+// the rows deleted and saved again by a converter are published once
+func makeCdcMessages(events []*models.CdcEvent, topicPrefix string) ([]string, map[string][]eventstream.Message, errors.Error) {
+	latest := make(map[string]bool)
+	kept := make([]*models.CdcEvent, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		rowKey := events[i].SourceTable + "\x00" + events[i].Key
+		if latest[rowKey] {
+			continue
+		}
+		latest[rowKey] = true
+		kept = append(kept, events[i])
+	}
+	var topics []string
+	messages := make(map[string][]eventstream.Message)
+	for i := len(kept) - 1; i >= 0; i-- {
+		event := kept[i]
+		message := &cdcMessage{
+			Table:     event.SourceTable,
+			Operation: event.Operation,
+			Key:       json.RawMessage(event.Key),
+			ChangedAt: event.CreatedAt,
+		}
+		if event.Row != "" {
+			message.Row = json.RawMessage(event.Row)
+		}
+		value, err := json.Marshal(message)
+		if err != nil {
+			return nil, nil, errors.Default.Wrap(err, "error encoding the change of "+event.SourceTable)
+		}
+		topic := topicPrefix + event.SourceTable
+		if _, ok := messages[topic]; !ok {
+			topics = append(topics, topic)
+		}
+		messages[topic] = append(messages[topic], eventstream.Message{Key: []byte(event.Key), Value: value})
+	}
+	return topics, messages, nil
+}
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/eventstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeCdcMessages(t *testing.T) {
+	changedAt := time.Date(2023, 7, 29, 0, 0, 0, 0, time.UTC)
+	events := []*models.CdcEvent{
+		{ID: 1, SourceTable: "issues", Operation: models.CDC_DELETE, Key: `{"id":"1"}`, CreatedAt: changedAt},
+		{ID: 2, SourceTable: "issues", Operation: models.CDC_DELETE, Key: `{"id":"2"}`, CreatedAt: changedAt},
+		{ID: 3, SourceTable: "boards", Operation: models.CDC_UPSERT, Key: `{"id":"1"}`, Row: `{"id":"1","name":"b"}`, CreatedAt: changedAt},
+		{ID: 4, SourceTable: "issues", Operation: models.CDC_UPSERT, Key: `{"id":"1"}`, Row: `{"id":"1","title":"a"}`, CreatedAt: changedAt},
+	}
+	topics, messages, err := makeCdcMessages(events, "lake.")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"lake.issues", "lake.boards"}, topics)
+	assert.Equal(t, []eventstream.Message{
+		{
+			Key:   []byte(`{"id":"2"}`),
+			Value: []byte(`{"table":"issues","op":"delete","key":{"id":"2"},"row":null,"changedAt":"2023-07-29T00:00:00Z"}`),
+		},
+		{
+			Key:   []byte(`{"id":"1"}`),
+			Value: []byte(`{"table":"issues","op":"upsert","key":{"id":"1"},"row":{"id":"1","title":"a"},"changedAt":"2023-07-29T00:00:00Z"}`),
+		},
+	}, messages["lake.issues"])
+	assert.Equal(t, 1, len(messages["lake.boards"]))
+}
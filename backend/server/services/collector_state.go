@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// CollectorStateQuery is the query of the incremental collection states
+type CollectorStateQuery struct {
+	Pagination
+	Plugin        string `form:"plugin"`
+	RawDataTable  string `form:"rawDataTable"`
+	RawDataParams string `form:"rawDataParams"`
+}
+
+func collectorStateClauses(plugin, table, params string) []dal.Clause {
+	clauses := []dal.Clause{dal.From(&models.CollectorLatestState{})}
+	if plugin != "" {
+		clauses = append(clauses, dal.Where("plugin = ?", plugin))
+	}
+	if table != "" {
+		clauses = append(clauses, dal.Where("raw_data_table = ?", table))
+	}
+	if params != "" {
+		clauses = append(clauses, dal.Where("raw_data_params = ?", params))
+	}
+	return clauses
+}
+
+// GetCollectorStates returns the stored incremental collection states, the latest updated first
+func GetCollectorStates(query *CollectorStateQuery) ([]*models.CollectorLatestState, int64, errors.Error) {
+	clauses := collectorStateClauses(query.Plugin, query.RawDataTable, query.RawDataParams)
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of collector states")
+	}
+	states := make([]*models.CollectorLatestState, 0)
+	err = db.All(
+		&states,
+		append(clauses,
+			dal.Orderby("updated_at DESC"),
+			dal.Offset(query.GetSkip()),
+			dal.Limit(query.GetPageSize()),
+		)...,
+	)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB collector states")
+	}
+	return states, count, nil
+}
+
+// ResetCollectorStates deletes the incremental collection states of the scope so that its next collection starts over,
+// the states of all the streams of the scope are deleted unless the table is given, the deleted states are returned
+func ResetCollectorStates(plugin, params, table string) ([]*models.CollectorLatestState, errors.Error) {
+	if params == "" {
+		return nil, errors.BadInput.New("rawDataParams is required")
+	}
+	clauses := collectorStateClauses(plugin, table, params)
+	states := make([]*models.CollectorLatestState, 0)
+	err := db.All(&states, clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB collector states")
+	}
+	if len(states) == 0 {
+		return states, nil
+	}
+	err = db.Delete(&models.CollectorLatestState{}, clauses[1:]...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting DB collector states")
+	}
+	logger.Info("reset %d collector states of %s", len(states), params)
+	return states, nil
+}
@@ -0,0 +1,512 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// CONFIG_BUNDLE_VERSION is the version of the bundles exported by this lake, the bundles of other versions are
+// rejected on import
+const CONFIG_BUNDLE_VERSION = 1
+
+// the actions importing the resources of a bundle
+const (
+	CONFIG_BUNDLE_CREATE    = "create"
+	CONFIG_BUNDLE_UPDATE    = "update"
+	CONFIG_BUNDLE_UNCHANGED = "unchanged"
+)
+
+// the attributes of the scopes linking them to their connection and scope config, or computed by lake. Their id is
+// kept as it is the id of the data source for some plugins.
+var configBundleScopeComputedAttributes = []string{
+	"createdAt", "updatedAt", "connectionId", "transformationRuleId", "transformationRuleName", "blueprints",
+}
+
+// the page size listing the scopes of a connection
+const configBundleScopePageSize = 100
+
+// ConfigBundle holds the whole configuration of a lake, the connections are addressed by their names rather than
+// their ids so the bundle may be imported into another lake
+type ConfigBundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// Encrypted tells the credentials of the connections are encrypted by a passphrase, they are left out otherwise
+	Encrypted   bool                      `json:"encrypted"`
+	Connections []*ConfigBundleConnection `json:"connections"`
+	Projects    []*ConfigBundleProject    `json:"projects"`
+}
+
+// ConfigBundleConnection is a connection of a plugin along with its scope configs and scopes
+type ConfigBundleConnection struct {
+	Plugin       string                   `json:"plugin"`
+	Name         string                   `json:"name"`
+	Attributes   map[string]interface{}   `json:"attributes"`
+	ScopeConfigs []map[string]interface{} `json:"scopeConfigs"`
+	// Scopes refer to their scope config by its name, under transformationRuleName
+	Scopes []map[string]interface{} `json:"scopes"`
+}
+
+// ConfigBundleProject is a project along with its blueprint, the connections of the blueprint settings refer to
+// their connection by its name, under connectionName
+type ConfigBundleProject struct {
+	Name       string                 `json:"name"`
+	Attributes map[string]interface{} `json:"attributes"`
+	Blueprint  map[string]interface{} `json:"blueprint"`
+}
+
+// ConfigBundleChange is the change importing a bundle makes to a resource
+type ConfigBundleChange struct {
+	Id     string   `json:"id"`
+	Kind   string   `json:"kind"`
+	Action string   `json:"action"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// ConfigBundleImport reports the changes made, or to be made on a dry run, by importing a bundle
+type ConfigBundleImport struct {
+	DryRun  bool                  `json:"dryRun"`
+	Changes []*ConfigBundleChange `json:"changes"`
+}
+
+// ExportConfigBundle exports the connections, scope configs, scopes, projects and blueprints. The credentials of the
+// connections are encrypted by the passphrase, or left out without any.
+func ExportConfigBundle(passphrase string) (*ConfigBundle, errors.Error) {
+	bundle := &ConfigBundle{
+		Version:     CONFIG_BUNDLE_VERSION,
+		ExportedAt:  time.Now(),
+		Encrypted:   passphrase != "",
+		Connections: make([]*ConfigBundleConnection, 0),
+		Projects:    make([]*ConfigBundleProject, 0),
+	}
+	// the names of the connections by `{plugin}/{id}`, for the blueprint settings
+	connectionNames := make(map[string]string)
+	for _, pluginName := range configBundlePlugins() {
+		connections, err := listManagedConnections(pluginName)
+		if err != nil {
+			return nil, err
+		}
+		secrets := connectionSecretFields(pluginName)
+		for _, connection := range connections {
+			connectionId := managedConnectionId(connection)
+			name := fmt.Sprintf("%v", connection["name"])
+			connectionNames[pluginName+"/"+connectionId] = name
+			for _, field := range secrets {
+				value, ok := connection[field].(string)
+				if !ok {
+					continue
+				}
+				if passphrase == "" || value == "" {
+					delete(connection, field)
+					continue
+				}
+				connection[field], err = plugin.Encrypt(passphrase, value)
+				if err != nil {
+					return nil, errors.Default.Wrap(err, "error encrypting the credentials of the connection")
+				}
+			}
+			exported := &ConfigBundleConnection{Plugin: pluginName, Name: name, Attributes: connection}
+			if hasPluginApi(pluginName, "connections/:connectionId/transformation_rules", http.MethodGet) {
+				exported.ScopeConfigs, err = listManagedScopeConfigs(pluginName, connectionId)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if hasPluginApi(pluginName, "connections/:connectionId/scopes", http.MethodGet) {
+				exported.Scopes, err = listConfigBundleScopes(pluginName, connectionId)
+				if err != nil {
+					return nil, err
+				}
+			}
+			bundle.Connections = append(bundle.Connections, exported)
+		}
+	}
+
+	projects := make([]*models.Project, 0)
+	err := db.All(&projects, dal.Orderby("name"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error listing the projects")
+	}
+	for _, p := range projects {
+		project, err := GetManagedProject(p.Name)
+		if err != nil {
+			return nil, err
+		}
+		exported := &ConfigBundleProject{Name: p.Name, Attributes: project.Attributes}
+		blueprint, err := GetManagedBlueprint(p.Name)
+		if err != nil && err.GetType() != errors.NotFound {
+			return nil, err
+		}
+		if blueprint != nil {
+			exported.Blueprint = blueprint.Attributes
+			mapBlueprintConnections(exported.Blueprint, func(connection map[string]interface{}) {
+				key := fmt.Sprintf("%v/%v", connection["plugin"], connection["connectionId"])
+				if name, ok := connectionNames[key]; ok {
+					connection["connectionName"] = name
+				}
+			})
+		}
+		bundle.Projects = append(bundle.Projects, exported)
+	}
+	return bundle, nil
+}
+
+// ImportConfigBundle creates or updates the resources of the bundle, the resources missing from the bundle are left
+// as they are. A dry run only reports the changes it would make.
+func ImportConfigBundle(bundle *ConfigBundle, passphrase string, dryRun bool) (*ConfigBundleImport, errors.Error) {
+	if bundle.Version != CONFIG_BUNDLE_VERSION {
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported version %d of the bundle, expected %d", bundle.Version, CONFIG_BUNDLE_VERSION))
+	}
+	if bundle.Encrypted && passphrase == "" {
+		return nil, errors.BadInput.New("the credentials of the bundle are encrypted, the passphrase is required")
+	}
+	importer := &configBundleImporter{
+		dryRun:        dryRun,
+		passphrase:    passphrase,
+		encrypted:     bundle.Encrypted,
+		result:        &ConfigBundleImport{DryRun: dryRun, Changes: make([]*ConfigBundleChange, 0)},
+		connectionIds: make(map[string]string),
+	}
+	for _, connection := range bundle.Connections {
+		if err := importer.importConnection(connection); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("error importing the %s connection [%s]", connection.Plugin, connection.Name))
+		}
+	}
+	for _, project := range bundle.Projects {
+		if err := importer.importProject(project); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("error importing the project [%s]", project.Name))
+		}
+	}
+	return importer.result, nil
+}
+
+type configBundleImporter struct {
+	dryRun     bool
+	passphrase string
+	encrypted  bool
+	result     *ConfigBundleImport
+	// the ids of the connections by `{plugin}/{name}`, for the blueprint settings
+	connectionIds map[string]string
+}
+
+// change records the change of a resource and tells whether it has to be put
+func (im *configBundleImporter) change(kind string, id string, current map[string]interface{}, desired map[string]interface{}, ignored ...string) bool {
+	change := &ConfigBundleChange{Id: id, Kind: kind, Action: CONFIG_BUNDLE_CREATE}
+	if current != nil {
+		change.Fields = diffManagedAttributes(current, desired, ignored...)
+		change.Action = CONFIG_BUNDLE_UPDATE
+		if len(change.Fields) == 0 {
+			change.Action = CONFIG_BUNDLE_UNCHANGED
+		}
+	}
+	im.result.Changes = append(im.result.Changes, change)
+	return !im.dryRun && change.Action != CONFIG_BUNDLE_UNCHANGED
+}
+
+func (im *configBundleImporter) importConnection(bundled *ConfigBundleConnection) errors.Error {
+	attributes := putManagedAttributes(bundled.Attributes, bundled.Name)
+	if im.encrypted {
+		for _, field := range connectionSecretFields(bundled.Plugin) {
+			value, ok := attributes[field].(string)
+			if !ok || value == "" {
+				continue
+			}
+			decrypted, err := plugin.Decrypt(im.passphrase, value)
+			if err != nil {
+				return errors.BadInput.Wrap(err, "error decrypting the credentials, the passphrase may be wrong")
+			}
+			attributes[field] = decrypted
+		}
+	}
+	current, err := findManagedConnection(bundled.Plugin, bundled.Name)
+	if err != nil {
+		return err
+	}
+	id := bundled.Plugin + "/" + bundled.Name
+	connectionId := ""
+	if current != nil {
+		connectionId = managedConnectionId(current)
+	}
+	if im.change(MANAGED_KIND_CONNECTION, id, current, attributes) {
+		connection, _, err := PutManagedConnection(bundled.Plugin, bundled.Name, attributes)
+		if err != nil {
+			return err
+		}
+		connectionId = managedConnectionId(connection.Attributes)
+	}
+	if connectionId != "" {
+		im.connectionIds[id] = connectionId
+	}
+
+	// the scope configs
+	currentScopeConfigs := make([]map[string]interface{}, 0)
+	if connectionId != "" && len(bundled.ScopeConfigs) > 0 {
+		currentScopeConfigs, err = listManagedScopeConfigs(bundled.Plugin, connectionId)
+		if err != nil {
+			return err
+		}
+	}
+	for _, scopeConfig := range bundled.ScopeConfigs {
+		name := fmt.Sprintf("%v", scopeConfig["name"])
+		if im.change(MANAGED_KIND_SCOPE_CONFIG, managedScopeConfigId(bundled.Plugin, bundled.Name, name),
+			findManagedByName(currentScopeConfigs, name), putManagedAttributes(scopeConfig, name)) {
+			_, _, err = PutManagedScopeConfig(bundled.Plugin, bundled.Name, name, scopeConfig)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(bundled.Scopes) == 0 {
+		return nil
+	}
+
+	// the scopes, put all at once
+	currentScopes := make([]map[string]interface{}, 0)
+	scopeConfigIds := make(map[string]interface{})
+	if connectionId != "" {
+		currentScopes, err = listConfigBundleScopes(bundled.Plugin, connectionId)
+		if err != nil {
+			return err
+		}
+		if len(bundled.ScopeConfigs) > 0 && !im.dryRun {
+			currentScopeConfigs, err = listManagedScopeConfigs(bundled.Plugin, connectionId)
+			if err != nil {
+				return err
+			}
+		}
+		for _, scopeConfig := range currentScopeConfigs {
+			scopeConfigIds[fmt.Sprintf("%v", scopeConfig["name"])] = scopeConfig["id"]
+		}
+	}
+	changed := make([]interface{}, 0)
+	for _, scope := range bundled.Scopes {
+		name := fmt.Sprintf("%v", scope["name"])
+		desired := make(map[string]interface{}, len(scope))
+		for k, v := range scope {
+			desired[k] = v
+		}
+		for _, k := range configBundleScopeComputedAttributes {
+			delete(desired, k)
+		}
+		current := findManagedByName(currentScopes, name)
+		if im.change(MANAGED_KIND_SCOPE, managedScopeConfigId(bundled.Plugin, bundled.Name, name), current, desired) {
+			desired["connectionId"], _ = strconv.ParseUint(connectionId, 10, 64)
+			if ruleName, ok := scope["transformationRuleName"].(string); ok && ruleName != "" {
+				desired["transformationRuleId"] = scopeConfigIds[ruleName]
+			}
+			changed = append(changed, desired)
+		}
+	}
+	if len(changed) > 0 {
+		_, err = callPluginApi(bundled.Plugin, "connections/:connectionId/scopes", http.MethodPut,
+			map[string]string{"connectionId": connectionId}, nil, map[string]interface{}{"data": changed})
+		return err
+	}
+	return nil
+}
+
+func (im *configBundleImporter) importProject(bundled *ConfigBundleProject) errors.Error {
+	current, err := GetManagedProject(bundled.Name)
+	if err != nil && err.GetType() != errors.NotFound {
+		return err
+	}
+	var currentAttributes map[string]interface{}
+	if current != nil {
+		currentAttributes = current.Attributes
+	}
+	if im.change(MANAGED_KIND_PROJECT, bundled.Name, currentAttributes, putManagedAttributes(bundled.Attributes, bundled.Name)) {
+		_, _, err = PutManagedProject(bundled.Name, bundled.Attributes)
+		if err != nil {
+			return err
+		}
+	}
+	if bundled.Blueprint == nil {
+		return nil
+	}
+
+	// the blueprint, with the ids of the connections of this lake
+	mapBlueprintConnections(bundled.Blueprint, func(connection map[string]interface{}) {
+		key := fmt.Sprintf("%v/%v", connection["plugin"], connection["connectionName"])
+		if id, ok := im.connectionIds[key]; ok {
+			connection["connectionId"], _ = strconv.ParseUint(id, 10, 64)
+			delete(connection, "connectionName")
+		}
+	})
+	currentAttributes = nil
+	if current != nil {
+		blueprint, err := GetManagedBlueprint(bundled.Name)
+		if err != nil && err.GetType() != errors.NotFound {
+			return err
+		}
+		if blueprint != nil {
+			currentAttributes = blueprint.Attributes
+		}
+	}
+	name, _ := bundled.Blueprint["name"].(string)
+	ignored := []string{"projectName"}
+	if bundled.Blueprint["mode"] == models.BLUEPRINT_MODE_NORMAL {
+		// the plan is made of the settings, along with the ids of this lake
+		ignored = append(ignored, "plan")
+	}
+	if im.change(MANAGED_KIND_BLUEPRINT, bundled.Name, currentAttributes, putManagedAttributes(bundled.Blueprint, name), ignored...) {
+		_, _, err = PutManagedBlueprint(bundled.Name, bundled.Blueprint)
+		return err
+	}
+	return nil
+}
+
+// diffManagedAttributes returns the attributes desired with a value other than the current one, sorted
+func diffManagedAttributes(current map[string]interface{}, desired map[string]interface{}, ignored ...string) []string {
+	skipped := make(map[string]bool)
+	for _, k := range append(managedComputedAttributes, ignored...) {
+		skipped[k] = true
+	}
+	// the values are compared by their json representation, i.e. the numbers are float64 on both sides
+	normalized := make(map[string]interface{})
+	_ = convertManaged(desired, &normalized)
+	fields := make([]string, 0)
+	for k, v := range normalized {
+		if !skipped[k] && !reflect.DeepEqual(current[k], v) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func findManagedByName(resources []map[string]interface{}, name string) map[string]interface{} {
+	for _, resource := range resources {
+		if fmt.Sprintf("%v", resource["name"]) == name {
+			return resource
+		}
+	}
+	return nil
+}
+
+// mapBlueprintConnections calls fn on every connection of the settings of a blueprint
+func mapBlueprintConnections(blueprint map[string]interface{}, fn func(connection map[string]interface{})) {
+	settings, ok := blueprint["settings"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	connections, ok := settings["connections"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, c := range connections {
+		if connection, ok := c.(map[string]interface{}); ok {
+			fn(connection)
+		}
+	}
+}
+
+func listConfigBundleScopes(pluginName string, connectionId string) ([]map[string]interface{}, errors.Error) {
+	scopes := make([]map[string]interface{}, 0)
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("pageSize", strconv.Itoa(configBundleScopePageSize))
+		body, err := callPluginApi(pluginName, "connections/:connectionId/scopes", http.MethodGet,
+			map[string]string{"connectionId": connectionId}, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var output struct {
+			Scopes []map[string]interface{} `json:"scopes"`
+		}
+		err = convertManaged(body, &output)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, output.Scopes...)
+		if len(output.Scopes) < configBundleScopePageSize {
+			return scopes, nil
+		}
+	}
+}
+
+// configBundlePlugins returns the plugins with connections, sorted by name
+func configBundlePlugins() []string {
+	names := make([]string, 0)
+	for name := range plugin.AllPlugins() {
+		if hasPluginApi(name, "connections", http.MethodGet) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func hasPluginApi(pluginName string, resource string, method string) bool {
+	pluginApi, ok := plugin.AllPlugins()[pluginName].(plugin.PluginApi)
+	if !ok {
+		return false
+	}
+	_, ok = pluginApi.ApiResources()[resource][method]
+	return ok
+}
+
+// connectionSecretFields returns the json names of the credentials of the connections of a plugin, i.e. the fields
+// encrypted in the database
+func connectionSecretFields(pluginName string) []string {
+	source, ok := plugin.AllPlugins()[pluginName].(plugin.PluginSource)
+	if !ok || source.Connection() == nil {
+		return nil
+	}
+	fields := make([]string, 0)
+	collectSecretFields(reflect.TypeOf(source.Connection()), &fields)
+	return fields
+}
+
+func collectSecretFields(t reflect.Type, fields *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			collectSecretFields(field.Type, fields)
+			continue
+		}
+		if !strings.Contains(field.Tag.Get("gorm"), "serializer:encdec") && field.Tag.Get("encrypt") != "yes" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		if name != "-" {
+			*fields = append(*fields, name)
+		}
+	}
+}
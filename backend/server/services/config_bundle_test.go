@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportConfigBundle(t *testing.T) {
+	p := &testManagedPlugin{}
+	assert.Nil(t, plugin.RegisterPlugin("bundletest", p))
+	assert.Equal(t, []string{"token"}, connectionSecretFields("bundletest"))
+
+	encrypted, err := plugin.Encrypt("secret", "t0ken")
+	assert.Nil(t, err)
+	bundle := &ConfigBundle{
+		Version:   CONFIG_BUNDLE_VERSION,
+		Encrypted: true,
+		Connections: []*ConfigBundleConnection{{
+			Plugin:     "bundletest",
+			Name:       "main",
+			Attributes: map[string]interface{}{"id": 7, "name": "main", "endpoint": "https://a.example.com", "token": encrypted},
+		}},
+	}
+
+	_, err = ImportConfigBundle(bundle, "", false)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	_, err = ImportConfigBundle(bundle, "wrong", false)
+	assert.NotNil(t, err)
+
+	// the dry run only reports the changes
+	result, err := ImportConfigBundle(bundle, "secret", true)
+	assert.Nil(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []*ConfigBundleChange{{Id: "bundletest/main", Kind: MANAGED_KIND_CONNECTION, Action: CONFIG_BUNDLE_CREATE}}, result.Changes)
+	assert.Empty(t, p.connections)
+
+	result, err = ImportConfigBundle(bundle, "secret", false)
+	assert.Nil(t, err)
+	assert.Equal(t, CONFIG_BUNDLE_CREATE, result.Changes[0].Action)
+	assert.Len(t, p.connections, 1)
+	assert.Equal(t, "t0ken", p.connections[0].Token)
+
+	// importing the same bundle again changes nothing
+	result, err = ImportConfigBundle(bundle, "secret", true)
+	assert.Nil(t, err)
+	assert.Equal(t, CONFIG_BUNDLE_UNCHANGED, result.Changes[0].Action)
+
+	bundle.Connections[0].Attributes["endpoint"] = "https://b.example.com"
+	result, err = ImportConfigBundle(bundle, "secret", true)
+	assert.Nil(t, err)
+	assert.Equal(t, CONFIG_BUNDLE_UPDATE, result.Changes[0].Action)
+	assert.Equal(t, []string{"endpoint"}, result.Changes[0].Fields)
+
+	bundle.Version = CONFIG_BUNDLE_VERSION + 1
+	_, err = ImportConfigBundle(bundle, "secret", true)
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
+
+func TestMapBlueprintConnections(t *testing.T) {
+	blueprint := map[string]interface{}{
+		"settings": map[string]interface{}{
+			"connections": []interface{}{
+				map[string]interface{}{"plugin": "github", "connectionId": float64(1)},
+			},
+		},
+	}
+	mapBlueprintConnections(blueprint, func(connection map[string]interface{}) {
+		connection["connectionName"] = "main"
+	})
+	connection := blueprint["settings"].(map[string]interface{})["connections"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "main", connection["connectionName"])
+	// the blueprints without settings are left as they are
+	mapBlueprintConnections(map[string]interface{}{}, func(connection map[string]interface{}) {
+		t.Fail()
+	})
+}
@@ -0,0 +1,276 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+)
+
+// DataExportQuery filters the rows of an export as the api input
+type DataExportQuery struct {
+	Format string `form:"format" validate:"omitempty,oneof=csv parquet"`
+	// Project is required by the canned queries, the domain tables are read from its data schema if it has one
+	Project string `form:"project"`
+	// TimeColumn is the column of a domain table Since and Until apply to, the canned queries have their own
+	TimeColumn string     `form:"timeColumn"`
+	Since      *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until      *time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// GetFormat returns the export format, csv by default
+func (query *DataExportQuery) GetFormat() string {
+	if query.Format == "" {
+		return "csv"
+	}
+	return query.Format
+}
+
+// DataExportInfo describes an export available to the api
+type DataExportInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// cannedExport is a query over the domain tables for the rows of a project
+type cannedExport struct {
+	description string
+	// timeColumn is the column Since and Until apply to
+	timeColumn string
+	clauses    func(project string) []dal.Clause
+}
+
+var cannedExports = map[string]cannedExport{
+	"deployments": {
+		description: "the deployment commits of the project, by their finished date",
+		timeColumn:  "cdc.finished_date",
+		clauses: func(project string) []dal.Clause {
+			return []dal.Clause{
+				dal.Select("cdc.*"),
+				dal.From("cicd_deployment_commits cdc"),
+				dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = cdc.cicd_scope_id)"),
+				dal.Where("pm.project_name = ?", project),
+				dal.Orderby("cdc.finished_date, cdc.id"),
+			}
+		},
+	},
+	"pipelines": {
+		description: "the ci/cd pipelines of the project, by their finished date",
+		timeColumn:  "p.finished_date",
+		clauses: func(project string) []dal.Clause {
+			return []dal.Clause{
+				dal.Select("p.*"),
+				dal.From("cicd_pipelines p"),
+				dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = p.cicd_scope_id)"),
+				dal.Where("pm.project_name = ?", project),
+				dal.Orderby("p.finished_date, p.id"),
+			}
+		},
+	},
+	"pull_requests": {
+		description: "the pull requests of the repos of the project, by their created date",
+		timeColumn:  "pr.created_date",
+		clauses: func(project string) []dal.Clause {
+			return []dal.Clause{
+				dal.Select("pr.*"),
+				dal.From("pull_requests pr"),
+				dal.Join("JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = pr.base_repo_id)"),
+				dal.Where("pm.project_name = ?", project),
+				dal.Orderby("pr.created_date, pr.id"),
+			}
+		},
+	},
+	"issues": {
+		description: "the issues of the boards of the project, by their created date",
+		timeColumn:  "i.created_date",
+		clauses: func(project string) []dal.Clause {
+			return projectIssueClauses(project)
+		},
+	},
+	"incidents": {
+		description: "the incidents of the boards of the project, by their created date",
+		timeColumn:  "i.created_date",
+		clauses: func(project string) []dal.Clause {
+			return append(projectIssueClauses(project), dal.Where("i.type = ?", "INCIDENT"))
+		},
+	},
+}
+
+func projectIssueClauses(project string) []dal.Clause {
+	return []dal.Clause{
+		// an issue may be on several boards of the project
+		dal.Select("DISTINCT i.*"),
+		dal.From("issues i"),
+		dal.Join("JOIN board_issues bi ON bi.issue_id = i.id"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = bi.board_id)"),
+		dal.Where("pm.project_name = ?", project),
+		dal.Orderby("i.created_date, i.id"),
+	}
+}
+
+// DataExport is an export whose rows are ready to be streamed
+type DataExport struct {
+	Name   string
+	cursor dal.Rows
+}
+
+// GetDataExports returns the domain tables and the canned queries which may be exported
+func GetDataExports() (tables []*DataExportInfo, queries []*DataExportInfo) {
+	for _, table := range domaininfo.GetDomainTablesInfo() {
+		tables = append(tables, &DataExportInfo{Name: table.TableName()})
+	}
+	for name, export := range cannedExports {
+		queries = append(queries, &DataExportInfo{Name: name, Description: export.description})
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+	return tables, queries
+}
+
+// OpenDomainTableExport queries the rows of a domain table matching the values of its columns and the time range
+func OpenDomainTableExport(table string, query *DataExportQuery, columnValues map[string][]string) (*DataExport, errors.Error) {
+	if err := VerifyStruct(query); err != nil {
+		return nil, err
+	}
+	var tabler dal.Tabler
+	for _, domainTable := range domaininfo.GetDomainTablesInfo() {
+		if domainTable.TableName() == table {
+			tabler = domainTable
+		}
+	}
+	if tabler == nil {
+		return nil, errors.NotFound.New(fmt.Sprintf("%s is not a domain layer table", table))
+	}
+	columnNames, err := dal.GetColumnNames(db, tabler, nil)
+	if err != nil {
+		return nil, err
+	}
+	knownColumns := make(map[string]bool)
+	for _, columnName := range columnNames {
+		knownColumns[columnName] = true
+	}
+	// sort the columns so the query is stable
+	var columns []string
+	for column := range columnValues {
+		if !knownColumns[column] {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s has no column %s", table, column))
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	clauses := []dal.Clause{dal.From(tabler)}
+	for _, column := range columns {
+		clauses = append(clauses, dal.Where(fmt.Sprintf("%s IN ?", column), columnValues[column]))
+	}
+	if query.Since != nil || query.Until != nil {
+		if !knownColumns[query.TimeColumn] {
+			return nil, errors.BadInput.New("timeColumn must be a column of " + table + " to export a time range")
+		}
+		clauses = append(clauses, timeRangeClauses(query.TimeColumn, query)...)
+		clauses = append(clauses, dal.Orderby(query.TimeColumn))
+	}
+	// the rows of an isolated project are read from its data schema
+	exportDb := replicaDb
+	if query.Project != "" {
+		exportDb, err = projectDb(query.Project)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return openDataExport(exportDb, table, clauses)
+}
+
+// OpenCannedExport queries the rows of a canned query for a project within the time range
+func OpenCannedExport(name string, query *DataExportQuery) (*DataExport, errors.Error) {
+	if err := VerifyStruct(query); err != nil {
+		return nil, err
+	}
+	export, ok := cannedExports[name]
+	if !ok {
+		return nil, errors.NotFound.New(fmt.Sprintf("no canned export %s", name))
+	}
+	if query.Project == "" {
+		return nil, errors.BadInput.New("project is required")
+	}
+	dataDb, err := projectDb(query.Project)
+	if err != nil {
+		return nil, err
+	}
+	clauses := append(export.clauses(query.Project), timeRangeClauses(export.timeColumn, query)...)
+	return openDataExport(dataDb, name, clauses)
+}
+
+func timeRangeClauses(column string, query *DataExportQuery) []dal.Clause {
+	var clauses []dal.Clause
+	if query.Since != nil {
+		clauses = append(clauses, dal.Where(column+" >= ?", *query.Since))
+	}
+	if query.Until != nil {
+		clauses = append(clauses, dal.Where(column+" < ?", *query.Until))
+	}
+	return clauses
+}
+
+func openDataExport(d dal.Dal, name string, clauses []dal.Clause) (*DataExport, errors.Error) {
+	cursor, err := d.Cursor(clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error querying the rows of "+name)
+	}
+	return &DataExport{Name: name, cursor: cursor}, nil
+}
+
+// Write streams the rows in the format, csv or parquet, and closes the cursor
+func (e *DataExport) Write(format string, w io.Writer) errors.Error {
+	defer e.cursor.Close()
+	columnTypes, err := e.cursor.ColumnTypes()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	writer, e1 := exporthelper.NewRowWriter(format, w, exporthelper.ColumnsOf(columnTypes))
+	if e1 != nil {
+		return e1
+	}
+	row := make([]interface{}, len(columnTypes))
+	pointers := make([]interface{}, len(columnTypes))
+	for i := range row {
+		pointers[i] = &row[i]
+	}
+	for e.cursor.Next() {
+		err = e.cursor.Scan(pointers...)
+		if err != nil {
+			return errors.Default.Wrap(errors.Convert(err), "error scanning the rows of "+e.Name)
+		}
+		e1 = writer.Write(row)
+		if e1 != nil {
+			return e1
+		}
+	}
+	// the rows may have been cut short by an error of the connection
+	if rows, ok := e.cursor.(*sql.Rows); ok && rows.Err() != nil {
+		return errors.Convert(rows.Err())
+	}
+	return writer.Close()
+}
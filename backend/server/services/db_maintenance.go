@@ -0,0 +1,294 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	"github.com/robfig/cron/v3"
+)
+
+const defaultDbMaintenanceCron = "0 2 * * 0"
+const defaultDbMaintenanceMinRows = 10000
+const defaultDbMaintenanceOptimizeRatio = 0.2
+
+var dbMaintenanceRunning atomic.Bool
+
+// DbMaintenanceQuery is the query of the database maintenances
+type DbMaintenanceQuery struct {
+	Pagination
+}
+
+// TableBloat is the bloat statistics of a table
+type TableBloat struct {
+	Table string `json:"table"`
+	models.TableBloatStat
+}
+
+// dbMaintenanceServiceInit schedules the database maintenance unless DB_MAINTENANCE_ENABLED is false,
+// DB_MAINTENANCE_CRON is a standard cron expression in UTC
+func dbMaintenanceServiceInit() {
+	if cfg.IsSet("DB_MAINTENANCE_ENABLED") && !cfg.GetBool("DB_MAINTENANCE_ENABLED") {
+		return
+	}
+	spec := cfg.GetString("DB_MAINTENANCE_CRON")
+	if spec == "" {
+		spec = defaultDbMaintenanceCron
+	}
+	c := cron.New(cron.WithLocation(time.UTC))
+	_, err := c.AddFunc(spec, func() {
+		if _, err := StartDbMaintenance(models.DB_MAINTENANCE_TRIGGER_CRON); err != nil {
+			logger.Error(err, "failed to start the database maintenance")
+		}
+	})
+	if err != nil {
+		logger.Error(err, "invalid DB_MAINTENANCE_CRON %s, the database maintenance is not scheduled", spec)
+		return
+	}
+	c.Start()
+}
+
+// GetDbMaintenances returns the database maintenances, latest first
+func GetDbMaintenances(query *DbMaintenanceQuery) ([]*models.DbMaintenance, int64, errors.Error) {
+	count, err := db.Count(dal.From(&models.DbMaintenance{}))
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of database maintenances")
+	}
+	maintenances := make([]*models.DbMaintenance, 0)
+	err = db.All(
+		&maintenances,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB database maintenances")
+	}
+	return maintenances, count, nil
+}
+
+// GetDbMaintenance returns the database maintenance along with the tables maintained so far
+func GetDbMaintenance(id uint64) (*models.DbMaintenance, []*models.DbMaintenanceTable, errors.Error) {
+	maintenance := &models.DbMaintenance{}
+	err := db.First(maintenance, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, nil, errors.NotFound.New(fmt.Sprintf("database maintenance %d not found", id))
+		}
+		return nil, nil, errors.Default.Wrap(err, "error getting the database maintenance from DB")
+	}
+	tables := make([]*models.DbMaintenanceTable, 0)
+	err = db.All(&tables, dal.Where("maintenance_id = ?", id), dal.Orderby("table_name"))
+	if err != nil {
+		return nil, nil, errors.Default.Wrap(err, "error getting the tables of the database maintenance from DB")
+	}
+	return maintenance, tables, nil
+}
+
+// StartDbMaintenance starts refreshing the statistics of the hot tables in the background, i.e. the raw and domain
+// tables having DB_MAINTENANCE_MIN_ROWS rows at least, and reclaiming their free space. Only one maintenance runs at
+// a time, the progress is reported by the returned models.DbMaintenance
+func StartDbMaintenance(trigger string) (*models.DbMaintenance, errors.Error) {
+	if !dbMaintenanceRunning.CompareAndSwap(false, true) {
+		return nil, errors.BadInput.New("a database maintenance is already running")
+	}
+	now := time.Now()
+	maintenance := &models.DbMaintenance{
+		Status:  models.DB_MAINTENANCE_RUNNING,
+		Trigger: trigger,
+		BeganAt: &now,
+	}
+	err := db.Create(maintenance)
+	if err != nil {
+		dbMaintenanceRunning.Store(false)
+		return nil, errors.Default.Wrap(err, "error creating the database maintenance")
+	}
+	progress := *maintenance
+	go func() {
+		defer dbMaintenanceRunning.Store(false)
+		err := runDbMaintenance(&progress)
+		finishedAt := time.Now()
+		progress.FinishedAt = &finishedAt
+		progress.CurrentTable = ""
+		progress.Status = models.DB_MAINTENANCE_DONE
+		if err != nil {
+			logger.Error(err, "database maintenance #%d failed", progress.ID)
+			progress.Status = models.DB_MAINTENANCE_FAILED
+			progress.Message = err.Error()
+		}
+		if err = db.Update(&progress); err != nil {
+			logger.Error(err, "failed to save the database maintenance #%d", progress.ID)
+		}
+	}()
+	return maintenance, nil
+}
+
+func runDbMaintenance(maintenance *models.DbMaintenance) errors.Error {
+	stats, err := GetTableBloatStats()
+	if err != nil {
+		return err
+	}
+	minRows := int64(defaultDbMaintenanceMinRows)
+	if cfg.IsSet("DB_MAINTENANCE_MIN_ROWS") {
+		minRows = cfg.GetInt64("DB_MAINTENANCE_MIN_ROWS")
+	}
+	optimizeRatio := cfg.GetFloat64("DB_MAINTENANCE_OPTIMIZE_RATIO")
+	if optimizeRatio <= 0 {
+		optimizeRatio = defaultDbMaintenanceOptimizeRatio
+	}
+	hotTables := selectHotTables(stats, domaininfo.GetDomainTableNames(), minRows)
+	maintenance.TotalTables = len(hotTables)
+	if err = db.Update(maintenance); err != nil {
+		return err
+	}
+	var failures []string
+	for _, stat := range hotTables {
+		maintenance.CurrentTable = stat.Table
+		if err = db.Update(maintenance); err != nil {
+			return err
+		}
+		table := &models.DbMaintenanceTable{
+			MaintenanceId:  maintenance.ID,
+			Table:          stat.Table,
+			TableBloatStat: stat.TableBloatStat,
+		}
+		began := time.Now()
+		operations, maintainErr := maintainTable(stat, optimizeRatio)
+		table.Operations = strings.Join(operations, ",")
+		table.DurationMs = time.Since(began).Milliseconds()
+		if maintainErr != nil {
+			logger.Error(maintainErr, "failed to maintain %s", stat.Table)
+			table.Message = maintainErr.Error()
+			failures = append(failures, stat.Table)
+		}
+		if err = db.Create(table); err != nil {
+			return err
+		}
+		maintenance.MaintainedTables++
+	}
+	if len(failures) > 0 {
+		return errors.Default.New(fmt.Sprintf("failed to maintain %s", strings.Join(failures, ", ")))
+	}
+	return nil
+}
+
+// selectHotTables returns the raw and domain tables having minRows rows at least, the most bloated first
+func selectHotTables(stats []*TableBloat, domainTables []string, minRows int64) []*TableBloat {
+	isDomainTable := make(map[string]bool, len(domainTables))
+	for _, table := range domainTables {
+		isDomainTable[table] = true
+	}
+	hotTables := make([]*TableBloat, 0)
+	for _, stat := range stats {
+		if !strings.HasPrefix(stat.Table, "_raw_") && !isDomainTable[stat.Table] {
+			continue
+		}
+		if stat.TableRows < minRows {
+			continue
+		}
+		hotTables = append(hotTables, stat)
+	}
+	sort.SliceStable(hotTables, func(i, j int) bool {
+		return hotTables[i].BloatRatio > hotTables[j].BloatRatio
+	})
+	return hotTables
+}
+
+// maintainTable refreshes the statistics of the table for the query planner and reclaims its free space: MySQL
+// rebuilds the table by OPTIMIZE once its free space reaches DB_MAINTENANCE_OPTIMIZE_RATIO (0.2 by default) of it,
+// PostgreSQL vacuums the dead rows for reuse without locking the table as VACUUM FULL would
+func maintainTable(stat *TableBloat, optimizeRatio float64) ([]string, errors.Error) {
+	table := dal.ClauseTable{Name: stat.Table}
+	var operations []string
+	switch db.Dialect() {
+	case "mysql":
+		operations = append(operations, "ANALYZE")
+		if err := db.Exec("ANALYZE TABLE ?", table); err != nil {
+			return operations, err
+		}
+		if stat.FreeBytes > 0 && stat.BloatRatio >= optimizeRatio {
+			operations = append(operations, "OPTIMIZE")
+			if err := db.Exec("OPTIMIZE TABLE ?", table); err != nil {
+				return operations, err
+			}
+		}
+	case "postgres":
+		operations = append(operations, "VACUUM ANALYZE")
+		if err := db.Exec("VACUUM (ANALYZE) ?", table); err != nil {
+			return operations, err
+		}
+	default:
+		return nil, errors.Default.New(fmt.Sprintf("the database maintenance is not supported by %s", db.Dialect()))
+	}
+	return operations, nil
+}
+
+// GetTableBloatStats returns the bloat statistics of the tables of the database, the most bloated first. The
+// statistics are estimated by the database, they are as accurate as its last ANALYZE
+func GetTableBloatStats() ([]*TableBloat, errors.Error) {
+	var clauses []dal.Clause
+	switch db.Dialect() {
+	case "mysql":
+		clauses = []dal.Clause{
+			dal.Select("table_name AS `table`, COALESCE(table_rows, 0) AS table_rows, " +
+				"COALESCE(data_length + index_length, 0) AS total_bytes, COALESCE(data_free, 0) AS free_bytes"),
+			dal.From("information_schema.tables"),
+			dal.Where("table_schema = DATABASE() AND table_type = 'BASE TABLE'"),
+		}
+	case "postgres":
+		clauses = []dal.Clause{
+			dal.Select(`relname AS "table", n_live_tup AS table_rows, ` +
+				"pg_total_relation_size(relid) AS total_bytes, n_dead_tup AS dead_rows"),
+			dal.From("pg_stat_user_tables"),
+			dal.Where("schemaname = current_schema()"),
+		}
+	default:
+		return nil, errors.Default.New(fmt.Sprintf("the bloat statistics are not supported by %s", db.Dialect()))
+	}
+	stats := make([]*TableBloat, 0)
+	err := db.All(&stats, clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error reading the bloat statistics of the tables")
+	}
+	for _, stat := range stats {
+		stat.BloatRatio = bloatRatio(&stat.TableBloatStat)
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].BloatRatio > stats[j].BloatRatio
+	})
+	return stats, nil
+}
+
+// bloatRatio is the share of the free space of a MySQL table, or of the dead rows of a PostgreSQL table
+func bloatRatio(stat *models.TableBloatStat) float64 {
+	if stat.FreeBytes > 0 && stat.TotalBytes+stat.FreeBytes > 0 {
+		return float64(stat.FreeBytes) / float64(stat.TotalBytes+stat.FreeBytes)
+	}
+	if stat.DeadRows > 0 && stat.TableRows+stat.DeadRows > 0 {
+		return float64(stat.DeadRows) / float64(stat.TableRows+stat.DeadRows)
+	}
+	return 0
+}
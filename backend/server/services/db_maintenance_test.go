@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectHotTables(t *testing.T) {
+	stat := func(table string, rows int64, ratio float64) *TableBloat {
+		return &TableBloat{Table: table, TableBloatStat: models.TableBloatStat{TableRows: rows, BloatRatio: ratio}}
+	}
+	hotTables := selectHotTables(
+		[]*TableBloat{
+			stat("_raw_github_api_issues", 50000, 0.1),
+			stat("_raw_github_api_comments", 100, 0.9),
+			stat("issues", 20000, 0.3),
+			stat("_tool_github_issues", 90000, 0.5),
+			stat("_devlake_pipelines", 90000, 0.5),
+			stat("commits", 10000, 0),
+		},
+		[]string{"issues", "commits"},
+		10000,
+	)
+	tables := make([]string, len(hotTables))
+	for i, hotTable := range hotTables {
+		tables[i] = hotTable.Table
+	}
+	assert.Equal(t, []string{"issues", "_raw_github_api_issues", "commits"}, tables)
+}
+
+func TestBloatRatio(t *testing.T) {
+	// the free bytes of mysql
+	assert.Equal(t, 0.25, bloatRatio(&models.TableBloatStat{TotalBytes: 300, FreeBytes: 100}))
+	// the dead rows of postgres
+	assert.Equal(t, 0.2, bloatRatio(&models.TableBloatStat{TableRows: 80, DeadRows: 20}))
+	assert.Equal(t, 0.0, bloatRatio(&models.TableBloatStat{}))
+}
@@ -26,6 +26,6 @@ import (
 // GetRepos FIXME ...
 func GetRepos() ([]*code.Repo, int64, errors.Error) {
 	repos := make([]*code.Repo, 0)
-	err := db.All(&repos, dal.Orderby("id DESC"))
+	err := replicaDb.All(&repos, dal.Orderby("id DESC"))
 	return repos, int64(len(repos)), err
 }
@@ -0,0 +1,319 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+)
+
+const defaultEncryptionKeyRotationBatchSize = 500
+
+var encryptionKeyRotationRunning atomic.Bool
+
+// EncryptionKeyRotationQuery is the query of the encryption key rotations
+type EncryptionKeyRotationQuery struct {
+	Pagination
+}
+
+// encryptedColumns are the encrypted columns of a table, along with the primary key to update the rows by
+type encryptedColumns struct {
+	table       string
+	columns     []string
+	primaryKeys []string
+}
+
+// GetEncryptionKeyRotations returns the encryption key rotations, latest first
+func GetEncryptionKeyRotations(query *EncryptionKeyRotationQuery) ([]*models.EncryptionKeyRotation, int64, errors.Error) {
+	count, err := db.Count(dal.From(&models.EncryptionKeyRotation{}))
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of encryption key rotations")
+	}
+	rotations := make([]*models.EncryptionKeyRotation, 0)
+	err = db.All(
+		&rotations,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB encryption key rotations")
+	}
+	return rotations, count, nil
+}
+
+// GetEncryptionKeyRotation returns the encryption key rotation along with its progress
+func GetEncryptionKeyRotation(id uint64) (*models.EncryptionKeyRotation, errors.Error) {
+	rotation := &models.EncryptionKeyRotation{}
+	err := db.First(rotation, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("encryption key rotation %d not found", id))
+		}
+		return nil, errors.Default.Wrap(err, "error getting the encryption key rotation from DB")
+	}
+	return rotation, nil
+}
+
+// StartEncryptionKeyRotation activates a new data key, wrapped by ENCODE_KEY, and re-encrypts the values of the
+// encrypted columns by it in the background, only one rotation runs at a time. The values are written by the new key
+// as soon as it is activated, those encrypted by the retired keys or by ENCODE_KEY itself are re-encrypted by batches
+// of ENCRYPTION_KEY_ROTATION_BATCH_SIZE rows, a failed rotation is resumed by starting another one
+func StartEncryptionKeyRotation() (*models.EncryptionKeyRotation, errors.Error) {
+	if !encryptionKeyRotationRunning.CompareAndSwap(false, true) {
+		return nil, errors.BadInput.New("an encryption key rotation is already running")
+	}
+	key, err := activateEncryptionKey()
+	if err != nil {
+		encryptionKeyRotationRunning.Store(false)
+		return nil, err
+	}
+	now := time.Now()
+	rotation := &models.EncryptionKeyRotation{
+		Status:  models.ENCRYPTION_KEY_ROTATION_RUNNING,
+		KeyId:   key.ID,
+		BeganAt: &now,
+	}
+	err = db.Create(rotation)
+	if err != nil {
+		encryptionKeyRotationRunning.Store(false)
+		return nil, errors.Default.Wrap(err, "error creating the encryption key rotation")
+	}
+	progress := *rotation
+	go func() {
+		defer encryptionKeyRotationRunning.Store(false)
+		err := runEncryptionKeyRotation(&progress)
+		finishedAt := time.Now()
+		progress.FinishedAt = &finishedAt
+		progress.CurrentTable = ""
+		progress.Status = models.ENCRYPTION_KEY_ROTATION_DONE
+		if err != nil {
+			logger.Error(err, "encryption key rotation #%d failed", progress.ID)
+			progress.Status = models.ENCRYPTION_KEY_ROTATION_FAILED
+			progress.Message = err.Error()
+		}
+		if err = db.Update(&progress); err != nil {
+			logger.Error(err, "failed to save the encryption key rotation #%d", progress.ID)
+		}
+	}()
+	return rotation, nil
+}
+
+// activateEncryptionKey creates a random data key as the active one and retires the previous ones, the retired keys
+// are kept to decrypt the values not re-encrypted yet
+func activateEncryptionKey() (*models.EncryptionKey, errors.Error) {
+	encKey := cfg.GetString(plugin.EncodeKeyEnvStr)
+	if encKey == "" {
+		return nil, errors.Default.New("ENCODE_KEY is not set")
+	}
+	dataKey, err := plugin.RandomEncKey()
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := plugin.Encrypt(encKey, dataKey)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to wrap the data key")
+	}
+	key := &models.EncryptionKey{
+		WrappedKey: wrappedKey,
+		Status:     models.ENCRYPTION_KEY_ACTIVE,
+	}
+	tx := db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	now := time.Now()
+	err = tx.UpdateColumns(
+		&models.EncryptionKey{},
+		[]dal.DalSet{
+			{ColumnName: "status", Value: models.ENCRYPTION_KEY_RETIRED},
+			{ColumnName: "retired_at", Value: now},
+		},
+		dal.Where("status = ?", models.ENCRYPTION_KEY_ACTIVE),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to retire the data keys")
+	}
+	err = tx.Create(key)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to save the data key")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	// encrypt by the new key from now on
+	err = dalgorm.LoadEncryptionKeys(db)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func runEncryptionKeyRotation(rotation *models.EncryptionKeyRotation) errors.Error {
+	tables := getEncryptedColumns()
+	rotation.TotalTables = len(tables)
+	if err := db.Update(rotation); err != nil {
+		return err
+	}
+	batchSize := cfg.GetInt("ENCRYPTION_KEY_ROTATION_BATCH_SIZE")
+	if batchSize <= 0 {
+		batchSize = defaultEncryptionKeyRotationBatchSize
+	}
+	for _, t := range tables {
+		rotation.CurrentTable = t.table
+		if err := db.Update(rotation); err != nil {
+			return err
+		}
+		for _, column := range t.columns {
+			err := reEncryptColumn(rotation, t, column, batchSize)
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to re-encrypt %s.%s", t.table, column))
+			}
+		}
+		rotation.RotatedTables++
+	}
+	return nil
+}
+
+// reEncryptColumn re-encrypts the values of the column not encrypted by the key of the rotation. A value is only
+// replaced if it wasn't changed since it was read, the values saved meanwhile are encrypted by the new key already
+func reEncryptColumn(rotation *models.EncryptionKeyRotation, t *encryptedColumns, column string, batchSize int) errors.Error {
+	prefix := dalgorm.EncryptionKeyPrefix(rotation.KeyId)
+	selected := append(append([]string{}, t.primaryKeys...), column)
+	for {
+		rows, err := db.Cursor(
+			dal.Select(strings.Join(selected, ", ")),
+			dal.From(t.table),
+			dal.Where(fmt.Sprintf("%s IS NOT NULL AND %s <> '' AND %s NOT LIKE ?", column, column, column), prefix+"%"),
+			dal.Limit(batchSize),
+		)
+		if err != nil {
+			return err
+		}
+		var batch [][]interface{}
+		for rows.Next() {
+			values := make([]interface{}, len(selected))
+			pointers := make([]interface{}, len(selected))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err = errors.Convert(rows.Scan(pointers...)); err != nil {
+				rows.Close()
+				return err
+			}
+			batch = append(batch, values)
+		}
+		rows.Close()
+		for _, values := range batch {
+			encrypted := values[len(values)-1]
+			var decrypted, reEncrypted string
+			decrypted, err = dalgorm.DecryptValue(string(toBytes(encrypted)))
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to decrypt the row %v", values[:len(values)-1]))
+			}
+			reEncrypted, err = dalgorm.EncryptValue(decrypted)
+			if err != nil {
+				return err
+			}
+			conditions := make([]string, 0, len(selected))
+			for _, name := range selected {
+				conditions = append(conditions, fmt.Sprintf("%s = ?", name))
+			}
+			err = db.UpdateColumn(t.table, column, reEncrypted, dal.Where(strings.Join(conditions, " AND "), values...))
+			if err != nil {
+				return err
+			}
+		}
+		rotation.ReEncryptedRows += int64(len(batch))
+		if err = db.Update(rotation); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+	}
+}
+
+func toBytes(value interface{}) []byte {
+	switch v := value.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return []byte(fmt.Sprint(value))
+}
+
+// getEncryptedColumns returns the tables of the framework and the plugins having encrypted columns, by name
+func getEncryptedColumns() []*encryptedColumns {
+	tables := []dal.Tabler{
+		&models.Blueprint{},
+		&models.Pipeline{},
+		&models.Task{},
+		&models.EventWebhook{},
+	}
+	for _, meta := range plugin.AllPlugins() {
+		if pluginModel, ok := meta.(plugin.PluginModel); ok {
+			tables = append(tables, pluginModel.GetTablesInfo()...)
+		}
+	}
+	byTable := make(map[string]*encryptedColumns)
+	for _, table := range tables {
+		if _, ok := byTable[table.TableName()]; ok {
+			continue
+		}
+		model := models.UnwrapObject(table)
+		// the remote plugins only report the names of their tables
+		if model == nil {
+			continue
+		}
+		columns, primaryKeys, err := dalgorm.GetEncryptedColumns(model)
+		if err != nil {
+			logger.Warn(err, "failed to find the encrypted columns of %s", table.TableName())
+			continue
+		}
+		if len(columns) == 0 || len(primaryKeys) == 0 {
+			continue
+		}
+		byTable[table.TableName()] = &encryptedColumns{
+			table:       table.TableName(),
+			columns:     columns,
+			primaryKeys: primaryKeys,
+		}
+	}
+	result := make([]*encryptedColumns, 0, len(byTable))
+	for _, t := range byTable {
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].table < result[j].table
+	})
+	return result
+}
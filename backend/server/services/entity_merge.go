@@ -0,0 +1,255 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/qa"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// entityReference is a column of a domain layer table referring to the id of an entity
+type entityReference struct {
+	table  dal.Tabler
+	column string
+	// field is the name of the struct field of the column when it is part of the primary key, such rows are moved
+	// to the kept entity by upserting them, so the rows which exist for both entities are not duplicated
+	field string
+	// tableColumn is the column telling the table of the referred entity, for the tables referring to the entities
+	// of several tables whose ids may collide, i.e. project_mapping
+	tableColumn string
+}
+
+// where matches the rows of the reference referring to the entity id of the entity table
+func (reference entityReference) where(entityTable string, id string) dal.Clause {
+	if reference.tableColumn == "" {
+		return dal.Where(fmt.Sprintf("%s = ?", reference.column), id)
+	}
+	// the column is qualified by the table since `table` is a reserved word
+	return dal.Where(
+		fmt.Sprintf("%s = ? AND %s.%s = ?", reference.column, reference.table.TableName(), reference.tableColumn),
+		id, entityTable,
+	)
+}
+
+type mergeableEntity struct {
+	table      dal.Tabler
+	references []entityReference
+}
+
+var mergeableEntities = map[string]mergeableEntity{
+	"repos": {
+		table: &code.Repo{},
+		references: []entityReference{
+			{table: &code.RepoCommit{}, column: "repo_id", field: "RepoId"},
+			{table: &code.RepoSnapshot{}, column: "repo_id", field: "RepoId"},
+			{table: &code.CodeOwner{}, column: "repo_id", field: "RepoId"},
+			{table: &code.RepoDependency{}, column: "repo_id", field: "RepoId"},
+			{table: &code.Component{}, column: "repo_id", field: "RepoId"},
+			{table: &code.CommitComponent{}, column: "repo_id", field: "RepoId"},
+			{table: &crossdomain.BoardRepo{}, column: "repo_id", field: "RepoId"},
+			{table: &crossdomain.ProjectMapping{}, column: "row_id", field: "RowId", tableColumn: "table"},
+			{table: &crossdomain.TeamScope{}, column: "row_id", field: "RowId", tableColumn: "table"},
+			{table: &code.PullRequest{}, column: "base_repo_id"},
+			{table: &code.PullRequest{}, column: "head_repo_id"},
+			{table: &code.Ref{}, column: "repo_id"},
+			{table: &code.RepoBranchProtection{}, column: "repo_id"},
+			{table: &devops.CiCDPipelineCommit{}, column: "repo_id"},
+			{table: &devops.CicdDeploymentCommit{}, column: "repo_id"},
+			{table: &devops.CicdCommitDeployment{}, column: "repo_id"},
+			{table: &devops.CicdRelease{}, column: "repo_id"},
+			{table: &devops.CicdArtifact{}, column: "repo_id"},
+			{table: &qa.QaCoverage{}, column: "repo_id"},
+			{table: &qa.QaTestRun{}, column: "repo_id"},
+			{table: &security.SecurityScope{}, column: "repo_id"},
+			{table: &security.SecurityVulnerability{}, column: "repo_id"},
+		},
+	},
+	"boards": {
+		table: &ticket.Board{},
+		references: []entityReference{
+			{table: &ticket.BoardIssue{}, column: "board_id", field: "BoardId"},
+			{table: &ticket.BoardSprint{}, column: "board_id", field: "BoardId"},
+			{table: &crossdomain.BoardRepo{}, column: "board_id", field: "BoardId"},
+			{table: &crossdomain.ProjectMapping{}, column: "row_id", field: "RowId", tableColumn: "table"},
+			{table: &crossdomain.TeamScope{}, column: "row_id", field: "RowId", tableColumn: "table"},
+			{table: &ticket.Sprint{}, column: "original_board_id"},
+		},
+	},
+}
+
+// MergeEntities merges the duplicated entity FromId into the entity ToId and keeps the redirect, so the duplicate
+// is merged again every time it is collected. The merge is done in one transaction, the references are never left
+// half moved.
+func MergeEntities(redirect *crossdomain.EntityRedirect) (*crossdomain.EntityRedirect, errors.Error) {
+	entity, ok := mergeableEntities[redirect.EntityType]
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("entities of %s can not be merged", redirect.EntityType))
+	}
+	if redirect.FromId == "" || redirect.ToId == "" {
+		return nil, errors.BadInput.New("fromId and toId are required")
+	}
+	if redirect.FromId == redirect.ToId {
+		return nil, errors.BadInput.New("an entity can not be merged into itself")
+	}
+	count, err := db.Count(dal.From(entity.table), dal.Where("id = ?", redirect.ToId))
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("%s %s not found", redirect.EntityType, redirect.ToId))
+	}
+	// merging into a duplicate would leave a chain of redirects, the entity kept by the duplicate is used instead
+	count, err = db.Count(
+		dal.From(&crossdomain.EntityRedirect{}),
+		dal.Where("entity_type = ? AND from_id = ?", redirect.EntityType, redirect.ToId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s %s is merged into another entity", redirect.EntityType, redirect.ToId))
+	}
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			if e := tx.Rollback(); e != nil {
+				logger.Error(e, "MergeEntities: failed to rollback")
+			}
+		}
+	}()
+	// the duplicates previously merged into FromId follow it
+	err = tx.UpdateColumn(
+		&crossdomain.EntityRedirect{}, "to_id", redirect.ToId,
+		dal.Where("entity_type = ? AND to_id = ?", redirect.EntityType, redirect.FromId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.CreateOrUpdate(redirect)
+	if err != nil {
+		return nil, err
+	}
+	err = applyEntityRedirect(tx, entity, redirect)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return redirect, nil
+}
+
+// GetEntityRedirects returns the redirects of the merged entities
+func GetEntityRedirects() ([]*crossdomain.EntityRedirect, errors.Error) {
+	redirects := make([]*crossdomain.EntityRedirect, 0)
+	err := db.All(&redirects, dal.Orderby("entity_type, from_id"))
+	if err != nil {
+		return nil, err
+	}
+	return redirects, nil
+}
+
+// ApplyEntityRedirects merges the duplicated entities collected again since they were merged. The merge deletes the
+// duplicate, so only the redirects whose duplicate exists again, i.e. was just collected by a pipeline, are applied.
+func ApplyEntityRedirects() errors.Error {
+	for entityType, entity := range mergeableEntities {
+		redirects := make([]*crossdomain.EntityRedirect, 0)
+		err := db.All(
+			&redirects,
+			dal.Select("r.*"),
+			dal.From(fmt.Sprintf("%s r", crossdomain.EntityRedirect{}.TableName())),
+			dal.Join(fmt.Sprintf("JOIN %s e ON e.id = r.from_id", entity.table.TableName())),
+			dal.Where("r.entity_type = ?", entityType),
+		)
+		if err != nil {
+			return err
+		}
+		for _, redirect := range redirects {
+			err = applyEntityRedirectInTx(entity, redirect)
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to merge %s %s", redirect.EntityType, redirect.FromId))
+			}
+		}
+	}
+	return nil
+}
+
+func applyEntityRedirectInTx(entity mergeableEntity, redirect *crossdomain.EntityRedirect) (err errors.Error) {
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			if e := tx.Rollback(); e != nil {
+				logger.Error(e, "ApplyEntityRedirects: failed to rollback")
+			}
+		}
+	}()
+	err = applyEntityRedirect(tx, entity, redirect)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func applyEntityRedirect(tx dal.Dal, entity mergeableEntity, redirect *crossdomain.EntityRedirect) errors.Error {
+	entityTable := entity.table.TableName()
+	for _, reference := range entity.references {
+		var err errors.Error
+		if reference.field == "" {
+			err = tx.UpdateColumn(
+				reference.table, reference.column, redirect.ToId,
+				reference.where(entityTable, redirect.FromId),
+			)
+		} else {
+			err = moveEntityReferences(tx, entityTable, reference, redirect)
+		}
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to redirect %s.%s", reference.table.TableName(), reference.column))
+		}
+	}
+	return tx.Delete(entity.table, dal.Where("id = ?", redirect.FromId))
+}
+
+func moveEntityReferences(tx dal.Dal, entityTable string, reference entityReference, redirect *crossdomain.EntityRedirect) errors.Error {
+	where := reference.where(entityTable, redirect.FromId)
+	// the rows are loaded at once rather than through a cursor, the connection of the transaction can't run the
+	// upserts while a cursor is open on it
+	rows := reflect.New(reflect.SliceOf(reflect.TypeOf(reference.table)))
+	err := tx.All(rows.Interface(), dal.From(reference.table), where)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < rows.Elem().Len(); i++ {
+		row := rows.Elem().Index(i)
+		row.Elem().FieldByName(reference.field).SetString(redirect.ToId)
+		err = tx.CreateOrUpdate(row.Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Delete(reference.table, where)
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEntityReferenceWhere(t *testing.T) {
+	repoCommits := entityReference{table: &code.RepoCommit{}, column: "repo_id", field: "RepoId"}
+	assert.Equal(t, dal.Where("repo_id = ?", "github:GithubRepo:1:1"), repoCommits.where("repos", "github:GithubRepo:1:1"))
+	// the boards and the cicd scopes sharing the id of the repo are left alone
+	projectMappings := entityReference{table: &crossdomain.ProjectMapping{}, column: "row_id", field: "RowId", tableColumn: "table"}
+	assert.Equal(
+		t,
+		dal.Where("row_id = ? AND project_mapping.table = ?", "github:GithubRepo:1:1", "repos"),
+		projectMappings.where("repos", "github:GithubRepo:1:1"),
+	)
+}
+
+func TestMergeableEntitiesFilterSharedTables(t *testing.T) {
+	for entityType, entity := range mergeableEntities {
+		for _, reference := range entity.references {
+			switch reference.table.(type) {
+			case *crossdomain.ProjectMapping, *crossdomain.TeamScope:
+				assert.Equal(t, "table", reference.tableColumn, "%s of %s", reference.table.TableName(), entityType)
+			}
+		}
+	}
+}
+
+func TestMergeEntitiesRollback(t *testing.T) {
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("UpdateColumn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
+	// the first references moved fail
+	mockTx.On("All", mock.Anything, mock.Anything).Return(errors.Default.New("lost connection"))
+	mockTx.On("Rollback").Return(nil).Once()
+
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Count", mock.Anything).Return(int64(1), nil).Once()
+	mockDal.On("Count", mock.Anything).Return(int64(0), nil).Once()
+	mockDal.On("Begin").Return(mockTx)
+	formerDb := db
+	db = mockDal
+	defer func() { db = formerDb }()
+
+	_, err := MergeEntities(&crossdomain.EntityRedirect{
+		EntityType: "repos",
+		FromId:     "gitlab:GitlabProject:1:2",
+		ToId:       "github:GithubRepo:1:1",
+	})
+	assert.NotNil(t, err)
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit")
+	mockTx.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
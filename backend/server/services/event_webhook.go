@@ -0,0 +1,366 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+const (
+	eventWebhookDefaultMaxRetries = 3
+	eventDeliveryTimeout          = 10 * time.Second
+	eventDeliveryPollInterval     = 10 * time.Second
+	eventDeliveryFirstBackoff     = 30 * time.Second
+	eventDeliveryMaxResponse      = 4096
+)
+
+// eventDeliveryWakeup triggers the dispatching of the deliveries without waiting for the next poll
+var eventDeliveryWakeup = make(chan struct{}, 1)
+
+var eventDeliveryClient = &http.Client{Timeout: eventDeliveryTimeout}
+
+// EventPayload is the body posted to the webhooks
+type EventPayload struct {
+	Id        uint64      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+// EventDeliveryQuery used to query the deliveries of a webhook as the api input
+type EventDeliveryQuery struct {
+	Pagination
+	Status string `form:"status"`
+}
+
+func eventWebhookServiceInit() {
+	go func() {
+		ticker := time.NewTicker(eventDeliveryPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-eventDeliveryWakeup:
+			}
+			dispatchEventDeliveries()
+		}
+	}()
+}
+
+// GetEventWebhooks returns all the event webhooks
+func GetEventWebhooks() ([]*models.EventWebhook, errors.Error) {
+	webhooks := make([]*models.EventWebhook, 0)
+	err := db.All(&webhooks, dal.Orderby("id"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB event webhooks")
+	}
+	return webhooks, nil
+}
+
+// GetEventWebhook returns the event webhook of the given id
+func GetEventWebhook(id uint64) (*models.EventWebhook, errors.Error) {
+	webhook := &models.EventWebhook{}
+	err := db.First(webhook, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("event webhook not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the event webhook from DB")
+	}
+	return webhook, nil
+}
+
+// CreateEventWebhook registers a webhook, enabled and retrying the failed deliveries 3 times unless told otherwise
+func CreateEventWebhook(input *models.ApiInputEventWebhook) (*models.EventWebhook, errors.Error) {
+	if input.Name == nil || input.Url == nil {
+		return nil, errors.BadInput.New("the name and the url of the webhook are required")
+	}
+	webhook := &models.EventWebhook{
+		Enable:     true,
+		MaxRetries: eventWebhookDefaultMaxRetries,
+	}
+	err := applyEventWebhookInput(webhook, input)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Create(webhook)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error creating DB event webhook")
+	}
+	return webhook, nil
+}
+
+// PatchEventWebhook updates the fields of the webhook given by the input
+func PatchEventWebhook(id uint64, input *models.ApiInputEventWebhook) (*models.EventWebhook, errors.Error) {
+	webhook, err := GetEventWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	err = applyEventWebhookInput(webhook, input)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(webhook)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error updating DB event webhook")
+	}
+	return webhook, nil
+}
+
+// DeleteEventWebhook removes the webhook along with its delivery history
+func DeleteEventWebhook(id uint64) (*models.EventWebhook, errors.Error) {
+	webhook, err := GetEventWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Delete(&models.EventDelivery{}, dal.Where("webhook_id = ?", id))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting the deliveries of the event webhook")
+	}
+	err = db.Delete(webhook)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting DB event webhook")
+	}
+	return webhook, nil
+}
+
+// GetEventDeliveries returns a paginated list of the deliveries of the webhook, the latest first
+func GetEventDeliveries(webhookId uint64, query *EventDeliveryQuery) ([]*models.EventDelivery, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.EventDelivery{}),
+		dal.Where("webhook_id = ?", webhookId),
+	}
+	if query.Status != "" {
+		clauses = append(clauses, dal.Where("status = ?", query.Status))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of event deliveries")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	deliveries := make([]*models.EventDelivery, 0)
+	err = db.All(&deliveries, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB event deliveries")
+	}
+	return deliveries, count, nil
+}
+
+// RedeliverEvent queues the delivery again, whatever its status, with a fresh count of attempts
+func RedeliverEvent(webhookId uint64, deliveryId uint64) (*models.EventDelivery, errors.Error) {
+	delivery := &models.EventDelivery{}
+	err := db.First(delivery, dal.Where("id = ? AND webhook_id = ?", deliveryId, webhookId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("event delivery not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the event delivery from DB")
+	}
+	now := time.Now()
+	delivery.Status = models.EVENT_DELIVERY_PENDING
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	err = db.Update(delivery)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error queuing the event delivery")
+	}
+	wakeupEventDeliveries()
+	return delivery, nil
+}
+
+// PublishEvent queues the event for the enabled webhooks subscribing to it and relays it to the activity feed, the
+// failures are logged only so the events never disturb the operations firing them
+func PublishEvent(event string, data interface{}) {
+	publishNotificationActivity(event, data)
+	webhooks := make([]*models.EventWebhook, 0)
+	err := db.All(&webhooks, dal.Where("enable = ?", true))
+	if err != nil {
+		logger.Error(err, "failed to find the webhooks of the event %s", event)
+		return
+	}
+	queued := false
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event) {
+			continue
+		}
+		now := time.Now()
+		delivery := &models.EventDelivery{
+			WebhookId:     webhook.ID,
+			Event:         event,
+			Status:        models.EVENT_DELIVERY_PENDING,
+			NextAttemptAt: &now,
+		}
+		err = db.Create(delivery)
+		if err == nil {
+			// the payload carries the id of the delivery so the receivers can tell the retries apart
+			var payload []byte
+			payload, err = errors.Convert01(json.Marshal(&EventPayload{Id: delivery.ID, Event: event, CreatedAt: now, Data: data}))
+			if err == nil {
+				delivery.Payload = string(payload)
+				err = db.Update(delivery)
+			}
+		}
+		if err != nil {
+			logger.Error(err, "failed to queue the event %s for the webhook #%d", event, webhook.ID)
+			continue
+		}
+		queued = true
+	}
+	if queued {
+		wakeupEventDeliveries()
+	}
+}
+
+func wakeupEventDeliveries() {
+	select {
+	case eventDeliveryWakeup <- struct{}{}:
+	default:
+	}
+}
+
+func applyEventWebhookInput(webhook *models.EventWebhook, input *models.ApiInputEventWebhook) errors.Error {
+	if err := VerifyStruct(input); err != nil {
+		return err
+	}
+	if input.Name != nil {
+		webhook.Name = *input.Name
+	}
+	if input.Url != nil {
+		webhook.Url = *input.Url
+	}
+	if input.Secret != nil {
+		webhook.Secret = *input.Secret
+	}
+	if input.Events != nil {
+		webhook.Events = input.Events
+	}
+	if input.Enable != nil {
+		webhook.Enable = *input.Enable
+	}
+	if input.MaxRetries != nil {
+		webhook.MaxRetries = *input.MaxRetries
+	}
+	if webhook.Name == "" || webhook.Url == "" {
+		return errors.BadInput.New("the name and the url of the webhook are required")
+	}
+	return nil
+}
+
+// dispatchEventDeliveries attempts the pending deliveries which are due
+func dispatchEventDeliveries() {
+	deliveries := make([]*models.EventDelivery, 0)
+	err := db.All(
+		&deliveries,
+		dal.Where("status = ? AND next_attempt_at <= ?", models.EVENT_DELIVERY_PENDING, time.Now()),
+		dal.Orderby("id"),
+		dal.Limit(100),
+	)
+	if err != nil {
+		logger.Error(err, "failed to find the pending event deliveries")
+		return
+	}
+	webhooks := make(map[uint64]*models.EventWebhook)
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookId]
+		if !ok {
+			webhook, err = GetEventWebhook(delivery.WebhookId)
+			if err != nil {
+				logger.Error(err, "failed to get the webhook of the event delivery #%d", delivery.ID)
+				continue
+			}
+			webhooks[delivery.WebhookId] = webhook
+		}
+		attemptEventDelivery(webhook, delivery)
+		err = db.Update(delivery)
+		if err != nil {
+			logger.Error(err, "failed to record the attempt of the event delivery #%d", delivery.ID)
+		}
+	}
+}
+
+// attemptEventDelivery posts the payload and updates the delivery, which is retried with an exponential backoff
+// until the webhook runs out of retries
+func attemptEventDelivery(webhook *models.EventWebhook, delivery *models.EventDelivery) {
+	delivery.Attempts++
+	delivery.ResponseCode = 0
+	delivery.Response = ""
+	delivery.Message = ""
+	err := postEventPayload(webhook, delivery)
+	now := time.Now()
+	if err == nil {
+		delivery.Status = models.EVENT_DELIVERY_SUCCEEDED
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		return
+	}
+	delivery.Message = err.Error()
+	if delivery.Attempts > webhook.MaxRetries {
+		delivery.Status = models.EVENT_DELIVERY_FAILED
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(eventDeliveryFirstBackoff << (delivery.Attempts - 1))
+	delivery.NextAttemptAt = &next
+}
+
+func postEventPayload(webhook *models.EventWebhook, delivery *models.EventDelivery) errors.Error {
+	req, e := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewBufferString(delivery.Payload))
+	if e != nil {
+		return errors.BadInput.Wrap(e, "invalid url of the webhook")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Lake-Event", delivery.Event)
+	req.Header.Set("X-Lake-Delivery", fmt.Sprintf("%d", delivery.ID))
+	if webhook.Secret != "" {
+		req.Header.Set("X-Lake-Signature-256", "sha256="+signEventPayload(webhook.Secret, delivery.Payload))
+	}
+	res, e := eventDeliveryClient.Do(req)
+	if e != nil {
+		return errors.Default.Wrap(e, "error posting the event")
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(res.Body, eventDeliveryMaxResponse))
+	delivery.ResponseCode = res.StatusCode
+	delivery.Response = string(body)
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("the webhook responded %d", res.StatusCode))
+	}
+	return nil
+}
+
+func signEventPayload(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
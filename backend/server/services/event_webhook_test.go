@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptEventDelivery(t *testing.T) {
+	status := http.StatusInternalServerError
+	var signature, event string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"id":7}`, string(body))
+		signature = r.Header.Get("X-Lake-Signature-256")
+		event = r.Header.Get("X-Lake-Event")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	webhook := &models.EventWebhook{Url: server.URL, Secret: "secret", MaxRetries: 1}
+	delivery := &models.EventDelivery{Event: models.EVENT_PIPELINE_FAILED, Payload: `{"id":7}`}
+
+	// failed, retried later
+	attemptEventDelivery(webhook, delivery)
+	assert.Equal(t, "sha256="+signEventPayload("secret", `{"id":7}`), signature)
+	assert.Equal(t, models.EVENT_PIPELINE_FAILED, event)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseCode)
+	assert.NotEqual(t, models.EVENT_DELIVERY_FAILED, delivery.Status)
+	assert.NotNil(t, delivery.NextAttemptAt)
+
+	// out of retries
+	attemptEventDelivery(webhook, delivery)
+	assert.Equal(t, models.EVENT_DELIVERY_FAILED, delivery.Status)
+	assert.Nil(t, delivery.NextAttemptAt)
+
+	status = http.StatusNoContent
+	attemptEventDelivery(webhook, delivery)
+	assert.Equal(t, models.EVENT_DELIVERY_SUCCEEDED, delivery.Status)
+	assert.NotNil(t, delivery.DeliveredAt)
+	assert.Empty(t, delivery.Message)
+}
+
+func TestEventWebhookSubscribes(t *testing.T) {
+	all := &models.EventWebhook{}
+	assert.True(t, all.Subscribes(models.EVENT_SCOPE_DELETED))
+	some := &models.EventWebhook{Events: []string{models.EVENT_PIPELINE_FAILED}}
+	assert.True(t, some.Subscribes(models.EVENT_PIPELINE_FAILED))
+	assert.False(t, some.Subscribes(models.EVENT_PIPELINE_COMPLETED))
+}
@@ -0,0 +1,302 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/services/graphql"
+)
+
+var graphqlSchema *graphql.Schema
+var graphqlSchemaErr errors.Error
+var graphqlSchemaOnce sync.Once
+
+var paginationArgs = []graphql.Arg{{Name: "page", Type: graphql.Int}, {Name: "pageSize", Type: graphql.Int}}
+
+// ExecuteGraphql runs a graphql query over the projects, blueprints, pipelines and the domain aggregates of the projects
+func ExecuteGraphql(request *graphql.Request) (*graphql.Response, errors.Error) {
+	graphqlSchemaOnce.Do(func() {
+		graphqlSchema, graphqlSchemaErr = newGraphqlSchema()
+	})
+	if graphqlSchemaErr != nil {
+		return nil, graphqlSchemaErr
+	}
+	return graphqlSchema.Execute(request), nil
+}
+
+func getPagination(args map[string]interface{}) (Pagination, errors.Error) {
+	page, err := graphql.IntArg(args, "page", 1)
+	if err != nil {
+		return Pagination{}, err
+	}
+	pageSize, err := graphql.IntArg(args, "pageSize", 50)
+	return Pagination{Page: page, PageSize: pageSize}, err
+}
+
+func getIdArg(args map[string]interface{}) (uint64, errors.Error) {
+	id, err := graphql.IntArg(args, "id", 0)
+	if err != nil {
+		return 0, err
+	}
+	if id <= 0 {
+		return 0, errors.BadInput.New("the argument id is required")
+	}
+	return uint64(id), nil
+}
+
+func newGraphqlSchema() (*graphql.Schema, errors.Error) {
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: map[string]*graphql.Field{
+			"projects": {Type: "Project", List: true, Args: paginationArgs, Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				pagination, err := getPagination(args)
+				if err != nil {
+					return nil, err
+				}
+				projects, _, err := GetProjects(&ProjectQuery{Pagination: pagination})
+				return projects, err
+			}},
+			"project": {Type: "Project", Args: []graphql.Arg{{Name: "name", Type: graphql.String}}, Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				name, err := graphql.StringArg(args, "name", "")
+				if err != nil {
+					return nil, err
+				}
+				project := &models.Project{}
+				err = db.First(project, dal.Where("name = ?", name))
+				if db.IsErrorNotFound(err) {
+					return nil, nil
+				}
+				return project, err
+			}},
+			"blueprints": {Type: "Blueprint", List: true, Args: append([]graphql.Arg{{Name: "enable", Type: graphql.Boolean}, {Name: "label", Type: graphql.String}}, paginationArgs...), Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				query := &BlueprintQuery{}
+				var err errors.Error
+				if query.Pagination, err = getPagination(args); err != nil {
+					return nil, err
+				}
+				if query.Enable, err = graphql.BooleanArg(args, "enable"); err != nil {
+					return nil, err
+				}
+				if query.Label, err = graphql.StringArg(args, "label", ""); err != nil {
+					return nil, err
+				}
+				blueprints, _, err := GetBlueprints(query)
+				return blueprints, err
+			}},
+			"blueprint": {Type: "Blueprint", Args: []graphql.Arg{{Name: "id", Type: graphql.Int}}, Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				id, err := getIdArg(args)
+				if err != nil {
+					return nil, err
+				}
+				return GetBlueprint(id)
+			}},
+			"pipelines": {Type: "Pipeline", List: true, Args: append([]graphql.Arg{{Name: "status", Type: graphql.String}, {Name: "blueprintId", Type: graphql.Int}, {Name: "label", Type: graphql.String}}, paginationArgs...), Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				query := &PipelineQuery{}
+				var err errors.Error
+				if query.Pagination, err = getPagination(args); err != nil {
+					return nil, err
+				}
+				if query.Status, err = graphql.StringArg(args, "status", ""); err != nil {
+					return nil, err
+				}
+				if query.Label, err = graphql.StringArg(args, "label", ""); err != nil {
+					return nil, err
+				}
+				blueprintId, err := graphql.IntArg(args, "blueprintId", 0)
+				if err != nil {
+					return nil, err
+				}
+				query.BlueprintId = uint64(blueprintId)
+				pipelines, _, err := GetPipelines(query)
+				return pipelines, err
+			}},
+			"pipeline": {Type: "Pipeline", Args: []graphql.Arg{{Name: "id", Type: graphql.Int}}, Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				id, err := getIdArg(args)
+				if err != nil {
+					return nil, err
+				}
+				return GetPipeline(id)
+			}},
+		},
+	}
+	project := &graphql.Object{
+		Name: "Project",
+		Fields: map[string]*graphql.Field{
+			"name":        {Type: graphql.String},
+			"description": {Type: graphql.String},
+			"teamId":      {Type: graphql.String},
+			"createdAt":   {Type: graphql.Time},
+			"updatedAt":   {Type: graphql.Time},
+			"metrics": {Type: "ProjectMetric", List: true, Resolve: func(source interface{}, _ map[string]interface{}) (interface{}, errors.Error) {
+				metrics := make([]models.ProjectMetricSetting, 0)
+				err := db.All(&metrics, dal.Where("project_name = ?", source.(*models.Project).Name))
+				return metrics, err
+			}},
+			"blueprint": {Type: "Blueprint", Resolve: func(source interface{}, _ map[string]interface{}) (interface{}, errors.Error) {
+				return GetBlueprintByProjectName(source.(*models.Project).Name)
+			}},
+			"summary": {Type: "ProjectSummary", Args: []graphql.Arg{{Name: "since", Type: graphql.Time}}, Description: "the domain aggregates of the scopes of the project", Resolve: func(source interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				since, err := graphql.TimeArg(args, "since")
+				if err != nil {
+					return nil, err
+				}
+				return GetProjectSummary(source.(*models.Project).Name, since)
+			}},
+		},
+	}
+	projectMetric := &graphql.Object{
+		Name: "ProjectMetric",
+		Fields: map[string]*graphql.Field{
+			"pluginName":   {Type: graphql.String},
+			"pluginOption": {Type: graphql.String},
+			"enable":       {Type: graphql.Boolean},
+		},
+	}
+	projectSummary := &graphql.Object{
+		Name: "ProjectSummary",
+		Fields: map[string]*graphql.Field{
+			"since":              {Type: graphql.Time},
+			"issues":             {Type: graphql.Int},
+			"openIssues":         {Type: graphql.Int},
+			"incidents":          {Type: graphql.Int},
+			"pullRequests":       {Type: graphql.Int},
+			"mergedPullRequests": {Type: graphql.Int},
+			"deployments":        {Type: graphql.Int, Description: "the deployments to the production environment"},
+			"failedDeployments":  {Type: graphql.Int},
+		},
+	}
+	blueprint := &graphql.Object{
+		Name: "Blueprint",
+		Fields: map[string]*graphql.Field{
+			"id":          {Type: graphql.Int},
+			"name":        {Type: graphql.String},
+			"projectName": {Type: graphql.String},
+			"mode":        {Type: graphql.String},
+			"enable":      {Type: graphql.Boolean},
+			"cronConfig":  {Type: graphql.String},
+			"isManual":    {Type: graphql.Boolean},
+			"skipOnFail":  {Type: graphql.Boolean},
+			"labels":      {Type: graphql.String, List: true},
+			"plan":        {Type: graphql.JSON},
+			"settings":    {Type: graphql.JSON},
+			"createdAt":   {Type: graphql.Time},
+			"updatedAt":   {Type: graphql.Time},
+			"project": {Type: "Project", Resolve: func(source interface{}, _ map[string]interface{}) (interface{}, errors.Error) {
+				name := source.(*models.Blueprint).ProjectName
+				if name == "" {
+					return nil, nil
+				}
+				project := &models.Project{}
+				err := db.First(project, dal.Where("name = ?", name))
+				if db.IsErrorNotFound(err) {
+					return nil, nil
+				}
+				return project, err
+			}},
+			"pipelines": {Type: "Pipeline", List: true, Args: append([]graphql.Arg{{Name: "status", Type: graphql.String}}, paginationArgs...), Resolve: func(source interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				query := &PipelineQuery{BlueprintId: source.(*models.Blueprint).ID}
+				var err errors.Error
+				if query.Pagination, err = getPagination(args); err != nil {
+					return nil, err
+				}
+				if query.Status, err = graphql.StringArg(args, "status", ""); err != nil {
+					return nil, err
+				}
+				pipelines, _, err := GetPipelines(query)
+				return pipelines, err
+			}},
+			"latestPipeline": {Type: "Pipeline", Resolve: func(source interface{}, _ map[string]interface{}) (interface{}, errors.Error) {
+				pipelines, _, err := GetPipelines(&PipelineQuery{
+					BlueprintId: source.(*models.Blueprint).ID,
+					Pagination:  Pagination{PageSize: 1},
+				})
+				if err != nil || len(pipelines) == 0 {
+					return nil, err
+				}
+				return pipelines[0], nil
+			}},
+		},
+	}
+	pipeline := &graphql.Object{
+		Name: "Pipeline",
+		Fields: map[string]*graphql.Field{
+			"id":            {Type: graphql.Int},
+			"name":          {Type: graphql.String},
+			"blueprintId":   {Type: graphql.Int},
+			"status":        {Type: graphql.String},
+			"message":       {Type: graphql.String},
+			"errorName":     {Type: graphql.String},
+			"totalTasks":    {Type: graphql.Int},
+			"finishedTasks": {Type: graphql.Int},
+			"beganAt":       {Type: graphql.Time},
+			"finishedAt":    {Type: graphql.Time},
+			"spentSeconds":  {Type: graphql.Int},
+			"stage":         {Type: graphql.Int},
+			"labels":        {Type: graphql.String, List: true},
+			"skipOnFail":    {Type: graphql.Boolean},
+			"createdAt":     {Type: graphql.Time},
+			"updatedAt":     {Type: graphql.Time},
+			"blueprint": {Type: "Blueprint", Resolve: func(source interface{}, _ map[string]interface{}) (interface{}, errors.Error) {
+				id := source.(*models.Pipeline).BlueprintId
+				if id == 0 {
+					return nil, nil
+				}
+				return GetBlueprint(id)
+			}},
+			"tasks": {Type: "Task", List: true, Args: []graphql.Arg{{Name: "status", Type: graphql.String}, {Name: "plugin", Type: graphql.String}}, Resolve: func(source interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				query := &TaskQuery{PipelineId: source.(*models.Pipeline).ID}
+				var err errors.Error
+				if query.Status, err = graphql.StringArg(args, "status", ""); err != nil {
+					return nil, err
+				}
+				if query.Plugin, err = graphql.StringArg(args, "plugin", ""); err != nil {
+					return nil, err
+				}
+				tasks, _, err := GetTasks(query)
+				return tasks, err
+			}},
+		},
+	}
+	// the options of the tasks are left out as they may hold credentials
+	task := &graphql.Object{
+		Name: "Task",
+		Fields: map[string]*graphql.Field{
+			"id":            {Type: graphql.Int},
+			"plugin":        {Type: graphql.String},
+			"subtasks":      {Type: graphql.JSON},
+			"status":        {Type: graphql.String},
+			"message":       {Type: graphql.String},
+			"errorName":     {Type: graphql.String},
+			"progress":      {Type: graphql.Float},
+			"failedSubTask": {Type: graphql.String},
+			"pipelineId":    {Type: graphql.Int},
+			"pipelineRow":   {Type: graphql.Int},
+			"pipelineCol":   {Type: graphql.Int},
+			"beganAt":       {Type: graphql.Time},
+			"finishedAt":    {Type: graphql.Time},
+			"spentSeconds":  {Type: graphql.Int},
+			"createdAt":     {Type: graphql.Time},
+			"updatedAt":     {Type: graphql.Time},
+		},
+	}
+	return graphql.NewSchema(query, project, projectMetric, projectSummary, blueprint, pipeline, task)
+}
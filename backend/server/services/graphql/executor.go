@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Request is a graphql request as posted by the clients
+type Request struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response holds the data of the request, Data is nil when the request is not executed
+type Response struct {
+	Data   interface{}                `json:"data"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// Execute validates and runs a query, the errors of the fields are reported along with the data of the others
+func (s *Schema) Execute(request *Request) *Response {
+	doc, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return &Response{Errors: gqlerrors.FormatErrors(err)}
+	}
+	// the fragment cycles are rejected first, the rule merging the overlapping fields doesn't stop on them
+	validation := graphql.ValidateDocument(&s.schema, doc, []graphql.ValidationRuleFn{graphql.NoFragmentCyclesRule})
+	if validation.IsValid {
+		validation = graphql.ValidateDocument(&s.schema, doc, nil)
+	}
+	if !validation.IsValid {
+		return &Response{Errors: validation.Errors}
+	}
+	if depth := documentDepth(doc); depth > s.MaxDepth {
+		return &Response{Errors: gqlerrors.FormatErrors(fmt.Errorf(
+			"the query nests %d levels of fields, more than the max depth %d", depth, s.MaxDepth,
+		))}
+	}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+	})
+	return &Response{Data: result.Data, Errors: result.Errors}
+}
+
+// documentDepth returns the deepest nesting of the fields selected by the operations of the document
+func documentDepth(doc *ast.Document) int {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range doc.Definitions {
+		if f, ok := definition.(*ast.FragmentDefinition); ok {
+			fragments[f.Name.Value] = f
+		}
+	}
+	depths := make(map[string]int)
+	depth := 0
+	for _, definition := range doc.Definitions {
+		if op, ok := definition.(*ast.OperationDefinition); ok {
+			if d := selectionDepth(op.SelectionSet, fragments, depths); d > depth {
+				depth = d
+			}
+		}
+	}
+	return depth
+}
+
+// selectionDepth memoizes the depths of the fragments, a fragment may be spread many times
+func selectionDepth(set *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, depths map[string]int) int {
+	if set == nil {
+		return 0
+	}
+	depth := 0
+	for _, selection := range set.Selections {
+		d := 0
+		switch s := selection.(type) {
+		case *ast.Field:
+			d = 1 + selectionDepth(s.SelectionSet, fragments, depths)
+		case *ast.InlineFragment:
+			d = selectionDepth(s.SelectionSet, fragments, depths)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragmentDepth, ok := depths[name]
+			if !ok {
+				if f := fragments[name]; f != nil {
+					fragmentDepth = selectionDepth(f.SelectionSet, fragments, depths)
+				}
+				depths[name] = fragmentDepth
+			}
+			d = fragmentDepth
+		}
+		if d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
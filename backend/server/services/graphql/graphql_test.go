@@ -0,0 +1,185 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAuthor struct {
+	Name string `json:"name"`
+}
+
+type testBase struct {
+	Id uint64 `json:"id"`
+}
+
+type testBook struct {
+	testBase
+	Title   string      `json:"title"`
+	Tags    []string    `json:"tags"`
+	Author  *testAuthor `json:"author"`
+	private string
+}
+
+func newTestSchema(t *testing.T) *Schema {
+	books := []*testBook{
+		{testBase: testBase{Id: 1}, Title: "Go", Tags: []string{"lang"}, Author: &testAuthor{Name: "Rob"}},
+		{testBase: testBase{Id: 2}, Title: "Lake"},
+	}
+	query := &Object{
+		Name: "Query",
+		Fields: map[string]*Field{
+			"books": {Type: "Book", List: true, Args: []Arg{{Name: "first", Type: Int}}, Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				first, err := IntArg(args, "first", len(books))
+				if err != nil {
+					return nil, err
+				}
+				return books[:first], nil
+			}},
+			"book": {Type: "Book", Args: []Arg{{Name: "id", Type: Int}}, Resolve: func(_ interface{}, args map[string]interface{}) (interface{}, errors.Error) {
+				id, err := IntArg(args, "id", 0)
+				if err != nil {
+					return nil, err
+				}
+				for _, b := range books {
+					if b.Id == uint64(id) {
+						return b, nil
+					}
+				}
+				return nil, errors.NotFound.New("book not found")
+			}},
+		},
+	}
+	book := &Object{
+		Name: "Book",
+		Fields: map[string]*Field{
+			"id":     {Type: Int},
+			"title":  {Type: String},
+			"tags":   {Type: String, List: true},
+			"author": {Type: "Author"},
+		},
+	}
+	author := &Object{
+		Name: "Author",
+		Fields: map[string]*Field{
+			"name": {Type: String},
+			"books": {Type: "Book", List: true, Resolve: func(source interface{}, _ map[string]interface{}) (interface{}, errors.Error) {
+				written := make([]*testBook, 0)
+				for _, b := range books {
+					if b.Author == source {
+						written = append(written, b)
+					}
+				}
+				return written, nil
+			}},
+		},
+	}
+	schema, err := NewSchema(query, book, author)
+	assert.Nil(t, err)
+	return schema
+}
+
+func marshal(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	assert.Nil(t, err)
+	return string(b)
+}
+
+func TestExecute(t *testing.T) {
+	schema := newTestSchema(t)
+	res := schema.Execute(&Request{Query: `
+		# the books and their authors
+		query Books($first: Int = 1) {
+			books(first: $first) { ...bookFields author { name } }
+			second: book(id: 2) { __typename title author { name } }
+		}
+		fragment bookFields on Book { id title tags }
+	`})
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t,
+		`{"books":[{"id":1,"title":"Go","tags":["lang"],"author":{"name":"Rob"}}],"second":{"__typename":"Book","title":"Lake","author":null}}`,
+		marshal(t, res.Data),
+	)
+
+	res = schema.Execute(&Request{
+		Query:     `query ($first: Int, $tags: Boolean!) { books(first: $first) { title tags @include(if: $tags) ... on Book { id } } }`,
+		Variables: map[string]interface{}{"first": float64(2), "tags": false},
+	})
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"books":[{"title":"Go","id":1},{"title":"Lake","id":2}]}`, marshal(t, res.Data))
+}
+
+func TestExecuteFieldError(t *testing.T) {
+	res := newTestSchema(t).Execute(&Request{Query: `{ book(id: 3) { title } books { title } }`})
+	assert.JSONEq(t, `{"book":null,"books":[{"title":"Go"},{"title":"Lake"}]}`, marshal(t, res.Data))
+	assert.Len(t, res.Errors, 1)
+	assert.Equal(t, []interface{}{"book"}, res.Errors[0].Path)
+}
+
+func TestValidate(t *testing.T) {
+	schema := newTestSchema(t)
+	for query, message := range map[string]string{
+		`{ books { isbn } }`:                             `Cannot query field "isbn" on type "Book"`,
+		`{ books(last: 1) { title } }`:                   `Unknown argument "last" on field "books"`,
+		`{ books }`:                                      `Field "books" of type "[Book]" must have a sub selection`,
+		`{ books { title { name } } }`:                   `Field "title" of type "String" must not have a sub selection`,
+		`{ books { ...missing } }`:                       `Unknown fragment "missing"`,
+		`{ book(id: $id) { title } }`:                    `Variable "$id" is not defined`,
+		`mutation { books { title } }`:                   "Schema is not configured for mutations",
+		`{ books { title }`:                              "Syntax Error",
+		`{ books { ... on Author { name } } }`:           `can never be of type "Author"`,
+		`fragment a on Book { ...a } { books { ...a } }`: `Cannot spread fragment "a" within itself`,
+	} {
+		res := schema.Execute(&Request{Query: query})
+		assert.Nil(t, res.Data, query)
+		if assert.NotEmpty(t, res.Errors, query) {
+			assert.Contains(t, res.Errors[0].Message, message, query)
+		}
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	res := newTestSchema(t).Execute(&Request{Query: `{ __type(name: "Book") { name fields { name type { kind ofType { name } } } } }`})
+	assert.Empty(t, res.Errors)
+	data := marshal(t, res.Data)
+	assert.Contains(t, data, `"name":"Book"`)
+	assert.Contains(t, data, `{"name":"tags","type":{"kind":"LIST","ofType":{"name":"String"}}}`)
+}
+
+func TestMaxDepth(t *testing.T) {
+	schema := newTestSchema(t)
+	schema.MaxDepth = 4
+	res := schema.Execute(&Request{Query: `{ books { author { books { title } } } }`})
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"books":[{"author":{"books":[{"title":"Go"}]}},{"author":null}]}`, marshal(t, res.Data))
+
+	// the fields of the fragments count as well
+	res = schema.Execute(&Request{Query: `
+		{ books { ...authorBooks } }
+		fragment authorBooks on Book { author { books { author { name } } } }
+	`})
+	assert.Nil(t, res.Data)
+	if assert.Len(t, res.Errors, 1) {
+		assert.Equal(t, "the query nests 5 levels of fields, more than the max depth 4", res.Errors[0].Message)
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// the scalar types, the values of JSON are marshaled to json as they are
+const (
+	String  = "String"
+	Int     = "Int"
+	Float   = "Float"
+	Boolean = "Boolean"
+	Time    = "Time"
+	JSON    = "JSON"
+)
+
+// DefaultMaxDepth bounds the nesting of the selections, the introspection query of the graphql clients nests 12 levels
+const DefaultMaxDepth = 15
+
+var timeScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        Time,
+	Description: "a RFC3339 time",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(time.RFC3339Nano)
+		case *time.Time:
+			if v != nil {
+				return v.Format(time.RFC3339Nano)
+			}
+		}
+		return nil
+	},
+	ParseValue: parseTime,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if v, ok := valueAST.(*ast.StringValue); ok {
+			return parseTime(v.Value)
+		}
+		return nil
+	},
+})
+
+func parseTime(value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t
+		}
+	}
+	return nil
+}
+
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        JSON,
+	Description: "a json value, it is returned as it is",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return nil
+	},
+})
+
+var scalars = map[string]*graphql.Scalar{
+	String:  graphql.String,
+	Int:     graphql.Int,
+	Float:   graphql.Float,
+	Boolean: graphql.Boolean,
+	Time:    timeScalar,
+	JSON:    jsonScalar,
+}
+
+// Resolver returns the value of a field out of the value of its parent object
+type Resolver func(source interface{}, args map[string]interface{}) (interface{}, errors.Error)
+
+// Arg defines an argument of a field, its type is one of the scalar types
+type Arg struct {
+	Name string
+	Type string
+}
+
+// Field defines a field of an object, the value is read from the json field of the source with the same name when Resolve is nil
+type Field struct {
+	Type        string
+	List        bool
+	Args        []Arg
+	Description string
+	Resolve     Resolver
+}
+
+// Object defines an object type
+type Object struct {
+	Name        string
+	Description string
+	Fields      map[string]*Field
+}
+
+// Schema is the graphql schema of the query root and the object types reachable from it
+type Schema struct {
+	schema graphql.Schema
+	// MaxDepth bounds the nesting of the selections of a query
+	MaxDepth int
+}
+
+// NewSchema creates a schema out of the query root and the other object types, the introspection comes with it
+func NewSchema(query *Object, types ...*Object) (*Schema, errors.Error) {
+	definitions := map[string]*Object{query.Name: query}
+	for _, t := range types {
+		if _, ok := definitions[t.Name]; ok {
+			return nil, errors.Default.New(fmt.Sprintf("the type %s is defined twice", t.Name))
+		}
+		definitions[t.Name] = t
+	}
+	for _, t := range definitions {
+		for name, f := range t.Fields {
+			if scalars[f.Type] == nil && definitions[f.Type] == nil {
+				return nil, errors.Default.New(fmt.Sprintf("the type %s of %s.%s is not defined", f.Type, t.Name, name))
+			}
+			for _, arg := range f.Args {
+				if scalars[arg.Type] == nil {
+					return nil, errors.Default.New(fmt.Sprintf("the argument %s of %s.%s should be a scalar", arg.Name, t.Name, name))
+				}
+			}
+		}
+	}
+	// the fields are thunks as the object types refer to each other
+	objects := make(map[string]*graphql.Object, len(definitions))
+	for name, t := range definitions {
+		objects[name] = newObject(t, objects)
+	}
+	config := graphql.SchemaConfig{Query: objects[query.Name]}
+	for _, t := range types {
+		config.Types = append(config.Types, objects[t.Name])
+	}
+	schema, err := graphql.NewSchema(config)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to create the graphql schema")
+	}
+	return &Schema{schema: schema, MaxDepth: DefaultMaxDepth}, nil
+}
+
+func newObject(t *Object, objects map[string]*graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        t.Name,
+		Description: t.Description,
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := make(graphql.Fields, len(t.Fields))
+			for name, f := range t.Fields {
+				var fieldType graphql.Output = objects[f.Type]
+				if scalar := scalars[f.Type]; scalar != nil {
+					fieldType = scalar
+				}
+				if f.List {
+					fieldType = graphql.NewList(fieldType)
+				}
+				args := make(graphql.FieldConfigArgument, len(f.Args))
+				for _, arg := range f.Args {
+					args[arg.Name] = &graphql.ArgumentConfig{Type: scalars[arg.Type]}
+				}
+				fields[name] = &graphql.Field{
+					Type:        fieldType,
+					Args:        args,
+					Description: f.Description,
+					Resolve:     resolveFn(f.Resolve),
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+func resolveFn(resolve Resolver) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if resolve == nil {
+			return readField(p.Source, p.Info.FieldName), nil
+		}
+		value, err := resolve(p.Source, p.Args)
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// readField reads the value of a field from a map or from the struct field with the json name, including the embedded ones
+func readField(source interface{}, name string) interface{} {
+	if m, ok := source.(map[string]interface{}); ok {
+		return m[name]
+	}
+	v := reflect.ValueOf(source)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	if f, ok := findField(v, name); ok {
+		return f.Interface()
+	}
+	return nil
+}
+
+func findField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if tag == name || (tag == "" && !sf.Anonymous && strings.EqualFold(sf.Name, name)) {
+			return v.Field(i), true
+		}
+	}
+	// the fields of the embedded structs are looked up after the ones of the struct itself
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.Anonymous || sf.Tag.Get("json") != "" {
+			continue
+		}
+		f := v.Field(i)
+		if f.Kind() == reflect.Ptr {
+			if f.IsNil() {
+				continue
+			}
+			f = f.Elem()
+		}
+		if f.Kind() == reflect.Struct {
+			if found, ok := findField(f, name); ok {
+				return found, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// IntArg returns an integer argument, the library coerces the literals and the variables to int
+func IntArg(args map[string]interface{}, name string, defaultValue int) (int, errors.Error) {
+	switch v := args[name].(type) {
+	case nil:
+		return defaultValue, nil
+	case int:
+		return v, nil
+	}
+	return 0, errors.BadInput.New(fmt.Sprintf("the argument %s should be an integer", name))
+}
+
+// StringArg returns a string argument
+func StringArg(args map[string]interface{}, name string, defaultValue string) (string, errors.Error) {
+	switch v := args[name].(type) {
+	case nil:
+		return defaultValue, nil
+	case string:
+		return v, nil
+	}
+	return "", errors.BadInput.New(fmt.Sprintf("the argument %s should be a string", name))
+}
+
+// BooleanArg returns a boolean argument or nil when it is missing
+func BooleanArg(args map[string]interface{}, name string) (*bool, errors.Error) {
+	switch v := args[name].(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return &v, nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("the argument %s should be a boolean", name))
+}
+
+// TimeArg returns a Time argument or nil when it is missing
+func TimeArg(args map[string]interface{}, name string) (*time.Time, errors.Error) {
+	switch v := args[name].(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return &v, nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("the argument %s should be a RFC3339 time", name))
+}
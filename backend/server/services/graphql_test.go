@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/server/services/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphqlSchema(t *testing.T) {
+	schema, err := newGraphqlSchema()
+	assert.Nil(t, err)
+
+	res := schema.Execute(&graphql.Request{Query: `{ __type(name: "Project") { fields { name args { name type { name } } } } }`})
+	assert.Empty(t, res.Errors)
+	data, e := json.Marshal(res.Data)
+	assert.Nil(t, e)
+	assert.Contains(t, string(data), `{"args":[{"name":"since","type":{"name":"Time"}}],"name":"summary"}`)
+
+	// the argument types are checked before any resolver runs
+	res = schema.Execute(&graphql.Request{Query: `{ pipeline(id: "1") { id } }`})
+	assert.Nil(t, res.Data)
+	assert.NotEmpty(t, res.Errors)
+}
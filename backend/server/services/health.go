@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/services/remote"
+)
+
+// the statuses of the readiness checks
+const (
+	HEALTH_OK      = "ok"
+	HEALTH_FAILED  = "failed"
+	HEALTH_SKIPPED = "skipped"
+)
+
+// the names of the readiness checks
+const (
+	HEALTH_CHECK_DATABASE        = "database"
+	HEALTH_CHECK_ENCRYPTION      = "encryption"
+	HEALTH_CHECK_REMOTE_PLUGINS  = "remotePlugins"
+	HEALTH_CHECK_PIPELINE_WORKER = "pipelineWorker"
+)
+
+const defaultHealthCheckTimeout = 5 * time.Second
+const defaultPipelineWorkerStaleAfter = 30 * time.Second
+
+// HealthCheck is the outcome of one readiness check. The messages stay vague on purpose, the endpoint is open to the
+// probes, the details are logged instead.
+type HealthCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Health tells whether the server is ready to serve, which it is when none of its checks failed
+type Health struct {
+	Status string         `json:"status"`
+	Checks []*HealthCheck `json:"checks"`
+}
+
+type healthChecker func() (string, string)
+
+var healthCheckers = map[string]healthChecker{
+	HEALTH_CHECK_DATABASE:        checkDatabaseHealth,
+	HEALTH_CHECK_ENCRYPTION:      checkEncryptionHealth,
+	HEALTH_CHECK_REMOTE_PLUGINS:  checkRemotePluginsHealth,
+	HEALTH_CHECK_PIPELINE_WORKER: checkPipelineWorkerHealth,
+}
+
+// CheckHealth runs the readiness checks side by side, a check taking longer than HEALTH_CHECK_TIMEOUT fails
+func CheckHealth() *Health {
+	timeout := cfg.GetDuration("HEALTH_CHECK_TIMEOUT")
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	return runHealthChecks(healthCheckers, timeout)
+}
+
+func runHealthChecks(checkers map[string]healthChecker, timeout time.Duration) *Health {
+	health := &Health{Status: HEALTH_OK, Checks: make([]*HealthCheck, 0, len(checkers))}
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker healthChecker) {
+			defer wg.Done()
+			check := runHealthCheck(name, checker, timeout)
+			lock.Lock()
+			defer lock.Unlock()
+			health.Checks = append(health.Checks, check)
+			if check.Status == HEALTH_FAILED {
+				health.Status = HEALTH_FAILED
+			}
+		}(name, checker)
+	}
+	wg.Wait()
+	sort.Slice(health.Checks, func(i, j int) bool {
+		return health.Checks[i].Name < health.Checks[j].Name
+	})
+	return health
+}
+
+func runHealthCheck(name string, checker healthChecker, timeout time.Duration) *HealthCheck {
+	check := &HealthCheck{Name: name}
+	done := make(chan struct{})
+	begin := time.Now()
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error(nil, "the %s health check panicked: %v", name, r)
+				check.Status, check.Message = HEALTH_FAILED, "the check panicked"
+			}
+		}()
+		check.Status, check.Message = checker()
+	}()
+	select {
+	case <-done:
+		check.DurationMs = time.Since(begin).Milliseconds()
+		return check
+	case <-time.After(timeout):
+		// the check is left running, its outcome is dropped
+		return &HealthCheck{
+			Name:       name,
+			Status:     HEALTH_FAILED,
+			Message:    fmt.Sprintf("the check did not finish within %s", timeout),
+			DurationMs: timeout.Milliseconds(),
+		}
+	}
+}
+
+func checkDatabaseHealth() (string, string) {
+	if db == nil {
+		return HEALTH_FAILED, "the database is not initialized"
+	}
+	if err := db.Exec("SELECT 1"); err != nil {
+		logger.Error(err, "the database health check failed")
+		return HEALTH_FAILED, "the database is unreachable"
+	}
+	if MigrationRequireConfirmation() {
+		return HEALTH_FAILED, "the database migration is waiting for confirmation"
+	}
+	return HEALTH_OK, ""
+}
+
+// checkEncryptionHealth makes sure the secret the connections are encrypted with is set and usable
+func checkEncryptionHealth() (string, string) {
+	encKey := cfg.GetString(plugin.EncodeKeyEnvStr)
+	if encKey == "" {
+		return HEALTH_FAILED, "the encryption secret is not set"
+	}
+	const probe = "devlake-health"
+	encrypted, err := plugin.Encrypt(encKey, probe)
+	if err == nil {
+		var decrypted string
+		decrypted, err = plugin.Decrypt(encKey, encrypted)
+		if err == nil && decrypted != probe {
+			err = errors.Default.New("the decrypted probe does not match")
+		}
+	}
+	if err != nil {
+		logger.Error(err, "the encryption health check failed")
+		return HEALTH_FAILED, "the encryption secret is unusable"
+	}
+	return HEALTH_OK, ""
+}
+
+func checkRemotePluginsHealth() (string, string) {
+	if cfg.GetString("REMOTE_PLUGIN_DIR") == "" {
+		return HEALTH_SKIPPED, "the remote plugins are disabled"
+	}
+	names, failures := remote.CheckPlugins()
+	if len(names) == 0 {
+		return HEALTH_FAILED, "no remote plugin is registered"
+	}
+	if len(failures) > 0 {
+		failed := make([]string, 0, len(failures))
+		for name, err := range failures {
+			logger.Error(err, "the remote plugin %s can not be invoked", name)
+			failed = append(failed, name)
+		}
+		sort.Strings(failed)
+		return HEALTH_FAILED, fmt.Sprintf("the remote plugins %s can not be invoked", strings.Join(failed, ", "))
+	}
+	return HEALTH_OK, fmt.Sprintf("%d remote plugins registered", len(names))
+}
+
+// checkPipelineWorkerHealth tells whether the pipeline queue keeps looking for pipelines to run, it is healthy while
+// waiting for a running pipeline to finish
+func checkPipelineWorkerHealth() (string, string) {
+	polledAt := pipelineQueuePolledAt.Load()
+	if polledAt == 0 {
+		return HEALTH_FAILED, "the pipeline worker has not started"
+	}
+	if pipelineQueueFull.Load() {
+		return HEALTH_OK, "waiting for a running pipeline to finish"
+	}
+	staleAfter := cfg.GetDuration("HEALTH_PIPELINE_WORKER_STALE_AFTER")
+	if staleAfter <= 0 {
+		staleAfter = defaultPipelineWorkerStaleAfter
+	}
+	if since := time.Since(time.Unix(0, polledAt)); since > staleAfter {
+		return HEALTH_FAILED, fmt.Sprintf("the pipeline worker has been stuck for %s", since.Truncate(time.Second))
+	}
+	return HEALTH_OK, ""
+}
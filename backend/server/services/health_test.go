@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunHealthChecks(t *testing.T) {
+	health := runHealthChecks(map[string]healthChecker{
+		"b": func() (string, string) { return HEALTH_OK, "" },
+		"a": func() (string, string) { return HEALTH_SKIPPED, "disabled" },
+	}, time.Second)
+	assert.Equal(t, HEALTH_OK, health.Status)
+	assert.Len(t, health.Checks, 2)
+	assert.Equal(t, "a", health.Checks[0].Name)
+	assert.Equal(t, "disabled", health.Checks[0].Message)
+
+	// a slow check fails without holding the others
+	release := make(chan struct{})
+	defer close(release)
+	health = runHealthChecks(map[string]healthChecker{
+		"ok": func() (string, string) { return HEALTH_OK, "" },
+		"slow": func() (string, string) {
+			<-release
+			return HEALTH_OK, ""
+		},
+	}, 50*time.Millisecond)
+	assert.Equal(t, HEALTH_FAILED, health.Status)
+	assert.Equal(t, HEALTH_OK, health.Checks[0].Status)
+	assert.Equal(t, HEALTH_FAILED, health.Checks[1].Status)
+}
+
+func TestCheckPipelineWorkerHealth(t *testing.T) {
+	cfg = config.GetConfig()
+	defer pipelineQueuePolledAt.Store(0)
+	defer pipelineQueueFull.Store(false)
+
+	status, _ := checkPipelineWorkerHealth()
+	assert.Equal(t, HEALTH_FAILED, status)
+
+	pipelineQueuePolledAt.Store(time.Now().UnixNano())
+	status, _ = checkPipelineWorkerHealth()
+	assert.Equal(t, HEALTH_OK, status)
+
+	pipelineQueuePolledAt.Store(time.Now().Add(-time.Hour).UnixNano())
+	status, message := checkPipelineWorkerHealth()
+	assert.Equal(t, HEALTH_FAILED, status)
+	assert.Contains(t, message, "stuck")
+
+	// waiting for a free slot is not being stuck
+	pipelineQueueFull.Store(true)
+	status, _ = checkPipelineWorkerHealth()
+	assert.Equal(t, HEALTH_OK, status)
+}
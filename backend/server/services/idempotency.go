@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// how long the responses are kept for the retries unless IDEMPOTENCY_KEY_TTL is set
+const defaultIdempotencyKeyTtl = 24 * time.Hour
+
+// the longest Idempotency-Key accepted
+const maxIdempotencyKeyLength = 255
+
+// HashIdempotentRequest hashes the path and the body of the request, the retries must send the same ones
+func HashIdempotentRequest(path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// BeginIdempotentRequest claims the key for the request of the client on the route. Once the key was claimed, the
+// record of the original request is returned for a retry to get its response back, and replay tells so. A request
+// differing from the original one, or sent while the original one is still in flight, is rejected.
+func BeginIdempotentRequest(client string, route string, key string, requestHash string) (*models.IdempotencyKey, bool, errors.Error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, false, errors.BadInput.New("the Idempotency-Key must have 1 to 255 characters")
+	}
+	ttl := cfg.GetDuration("IDEMPOTENCY_KEY_TTL")
+	if ttl <= 0 {
+		ttl = defaultIdempotencyKeyTtl
+	}
+	now := time.Now()
+	err := db.Delete(&models.IdempotencyKey{}, dal.Where("created_at < ?", now.Add(-ttl)))
+	if err != nil {
+		return nil, false, errors.Default.Wrap(err, "error purging the expired idempotency keys")
+	}
+	record := &models.IdempotencyKey{
+		Client:      client,
+		Route:       route,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+	}
+	err = db.Create(record)
+	if err == nil {
+		return record, false, nil
+	}
+	if !db.IsDuplicationError(err) {
+		return nil, false, errors.Default.Wrap(err, "error claiming the idempotency key")
+	}
+	original := &models.IdempotencyKey{}
+	err = db.First(original, dal.Where("client = ? AND route = ? AND idempotency_key = ?", client, route, key))
+	if err != nil {
+		return nil, false, errors.Default.Wrap(err, "error getting the idempotency key from DB")
+	}
+	if original.RequestHash != requestHash {
+		return nil, false, errors.HttpStatus(http.StatusUnprocessableEntity).New("the Idempotency-Key was used for another request")
+	}
+	if original.Status == 0 {
+		return nil, false, errors.HttpStatus(http.StatusConflict).New("the request of the Idempotency-Key is still in progress")
+	}
+	return original, true, nil
+}
+
+// CompleteIdempotentRequest records the response of the request for its retries. The key is released instead when
+// the request failed on the server side, so it may be retried for real.
+func CompleteIdempotentRequest(record *models.IdempotencyKey, status int, response []byte) errors.Error {
+	where := dal.Where("client = ? AND route = ? AND idempotency_key = ?", record.Client, record.Route, record.Key)
+	if status >= http.StatusInternalServerError || !json.Valid(response) {
+		return db.Delete(&models.IdempotencyKey{}, where)
+	}
+	record.Status = status
+	record.Response = response
+	return db.UpdateColumns(&models.IdempotencyKey{}, []dal.DalSet{
+		{ColumnName: "status", Value: status},
+		{ColumnName: "response", Value: record.Response},
+	}, where)
+}
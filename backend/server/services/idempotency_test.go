@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashIdempotentRequest(t *testing.T) {
+	hash := HashIdempotentRequest("/blueprints/1/trigger", nil)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashIdempotentRequest("/blueprints/1/trigger", []byte{}))
+	assert.NotEqual(t, hash, HashIdempotentRequest("/blueprints/2/trigger", nil))
+	assert.NotEqual(t, HashIdempotentRequest("/pipelines", []byte(`{"name":"a"}`)), HashIdempotentRequest("/pipelines", []byte(`{"name":"b"}`)))
+	// the path and the body don't run into each other
+	assert.NotEqual(t, HashIdempotentRequest("/pipelines", []byte("x")), HashIdempotentRequest("/pipelinesx", nil))
+}
+
+func TestBeginIdempotentRequestRejectsBadKeys(t *testing.T) {
+	for _, key := range []string{"", strings.Repeat("k", maxIdempotencyKeyLength+1)} {
+		_, _, err := BeginIdempotentRequest("ip:127.0.0.1", "POST /pipelines", key, "")
+		assert.Equal(t, errors.BadInput, err.GetType())
+	}
+}
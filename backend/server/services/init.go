@@ -30,6 +30,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/runner"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/services"
+	contextimpl "github.com/apache/incubator-devlake/impls/context"
 	"github.com/apache/incubator-devlake/impls/dalgorm"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/apache/incubator-devlake/server/services/auth"
@@ -41,6 +42,9 @@ var cfg config.ConfigReader
 var logger log.Logger
 var db dal.Dal
 
+// replicaDb serves the analytical reads of the query endpoints, it is db unless DB_REPLICA_URL is set
+var replicaDb dal.Dal
+
 var bpManager *services.BlueprintManager
 var basicRes context.BasicRes
 var migrator plugin.Migrator
@@ -48,6 +52,9 @@ var cronManager *cron.Cron
 var cronLocker sync.Mutex
 var vld *validator.Validate
 
+// newReplicaGormDb opens the replica database, replaced by the tests
+var newReplicaGormDb = runner.NewReplicaGormDb
+
 const failToCreateCronJob = "created cron job failed"
 
 // InitResources creates resources needed by services module
@@ -60,6 +67,13 @@ func InitResources() {
 	cfg = basicRes.GetConfigReader()
 	logger = basicRes.GetLogger()
 	db = basicRes.GetDal()
+	replicaDb, err = openReplicaDb(db)
+	if err != nil {
+		panic(err)
+	}
+	if res, ok := basicRes.(*contextimpl.DefaultBasicRes); ok {
+		res.SetReplicaDal(replicaDb)
+	}
 	bpManager = services.NewBlueprintManager(db)
 	// initialize db migrator
 	migrator, err = runner.InitMigrator(basicRes)
@@ -70,6 +84,22 @@ func InitResources() {
 	migrator.Register(migrationscripts.All(), "Framework")
 }
 
+// openReplicaDb returns the Dal of the replica given by DB_REPLICA_URL, or db when it isn't set
+func openReplicaDb(db dal.Dal) (dal.Dal, errors.Error) {
+	if cfg.GetString("DB_REPLICA_URL") == "" {
+		return db, nil
+	}
+	replicaGormDb, err := newReplicaGormDb(cfg, logger.Nested("replica db"))
+	if err != nil {
+		return nil, err
+	}
+	if err = runner.RegisterDbStatsMetrics(replicaGormDb, "devlake_replica"); err != nil {
+		logger.Error(err, "failed to register the metrics of the replica database")
+	}
+	logger.Info("the query endpoints read from the replica database")
+	return dalgorm.NewDalgorm(replicaGormDb), nil
+}
+
 // GetBasicRes returns the context.BasicRes instance used by services module
 func GetBasicRes() context.BasicRes {
 	return basicRes
@@ -123,6 +153,18 @@ func ExecuteMigration() errors.Error {
 		return err
 	}
 
+	// the data keys of the encrypted columns are loaded once their table is migrated
+	err = dalgorm.LoadEncryptionKeys(db)
+	if err != nil {
+		return err
+	}
+
+	// bring the data schemas of the isolated projects up to date with the migrated domain tables
+	err = provisionDataSchemas()
+	if err != nil {
+		logger.Error(err, "failed to provision the data schemas of the projects")
+	}
+
 	// cronjob for blueprint triggering
 	location := cron.WithLocation(time.UTC)
 	cronManager = cron.New(location)
@@ -132,6 +174,27 @@ func ExecuteMigration() errors.Error {
 
 	// initialize pipeline server, mainly to start the pipeline consuming process
 	pipelineServiceInit()
+
+	// start posting the server events to the webhooks
+	eventWebhookServiceInit()
+
+	// purge the raw data past its retention on schedule
+	rawDataPurgeServiceInit()
+
+	// delete the superseded raw data on schedule
+	rawDataCompactionServiceInit()
+
+	// analyze and reclaim the free space of the hot tables on schedule
+	dbMaintenanceServiceInit()
+
+	// archive the aged rows to the object store on schedule
+	archiveServiceInit()
+
+	// publish the captured changes of the domain tables
+	cdcServiceInit()
+
+	// apply the long-running data migrations while serving
+	executeOnlineMigration()
 	return nil
 }
 
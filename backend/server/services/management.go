@@ -0,0 +1,634 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// the kinds of the resources managed as code
+const (
+	MANAGED_KIND_CONNECTION   = "connection"
+	MANAGED_KIND_SCOPE_CONFIG = "scope_config"
+	MANAGED_KIND_SCOPE        = "scope"
+	MANAGED_KIND_PROJECT      = "project"
+	MANAGED_KIND_BLUEPRINT    = "blueprint"
+)
+
+// the attributes computed by lake, they are ignored when putting a managed resource
+var managedComputedAttributes = []string{"id", "createdAt", "updatedAt", "connectionId", "blueprint"}
+
+// the page size listing the transformation rules of a connection
+const managedScopeConfigPageSize = 100
+
+// ManagedResource is the envelope of the resources of the management api. Its id is made of the names of the
+// resource and its parents, i.e. `github/my-connection`, so it is known before the resource is created and stays
+// the same across the lake instances, which is what the infrastructure as code tools import the resources by.
+type ManagedResource struct {
+	Id         string                 `json:"id"`
+	Kind       string                 `json:"kind"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+func newManagedResource(kind string, id string, v interface{}) (*ManagedResource, errors.Error) {
+	attributes, err := toManagedAttributes(v)
+	if err != nil {
+		return nil, err
+	}
+	return &ManagedResource{Id: id, Kind: kind, Attributes: attributes}, nil
+}
+
+// toManagedAttributes converts any json serializable value, i.e. the connection of a plugin, to the attributes of
+// a managed resource
+func toManagedAttributes(v interface{}) (map[string]interface{}, errors.Error) {
+	attributes := make(map[string]interface{})
+	err := convertManaged(v, &attributes)
+	if err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// convertManaged converts the output of the plugin api to its json representation, i.e. a list of maps
+func convertManaged(v interface{}, dst interface{}) errors.Error {
+	blob, err := json.Marshal(v)
+	if err != nil {
+		return errors.Default.Wrap(err, "error serializing the managed resource")
+	}
+	err = json.Unmarshal(blob, dst)
+	if err != nil {
+		return errors.Default.Wrap(err, "error deserializing the managed resource")
+	}
+	return nil
+}
+
+// putManagedAttributes returns the attributes to put, without the computed ones and named after the resource
+func putManagedAttributes(attributes map[string]interface{}, name string) map[string]interface{} {
+	body := make(map[string]interface{}, len(attributes)+1)
+	for k, v := range attributes {
+		body[k] = v
+	}
+	for _, k := range managedComputedAttributes {
+		delete(body, k)
+	}
+	body["name"] = name
+	return body
+}
+
+// callPluginApi calls an api resource of a plugin, i.e. `connections/:connectionId`, and returns the body of its
+// output
+func callPluginApi(pluginName string, resource string, method string, params map[string]string, query url.Values, body map[string]interface{}) (interface{}, errors.Error) {
+	output, err := callPluginApiOutput(pluginName, resource, method, params, query, body)
+	if err != nil || output == nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+// callPluginApiOutput calls an api resource of a plugin and returns its whole output, for the status it tells
+func callPluginApiOutput(pluginName string, resource string, method string, params map[string]string, query url.Values, body map[string]interface{}) (*plugin.ApiResourceOutput, errors.Error) {
+	pluginMeta, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, errors.NotFound.Wrap(err, fmt.Sprintf("plugin %s not found", pluginName))
+	}
+	pluginApi, ok := pluginMeta.(plugin.PluginApi)
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s does not have any api", pluginName))
+	}
+	handler, ok := pluginApi.ApiResources()[resource][method]
+	if !ok {
+		return nil, errors.HttpStatus(http.StatusMethodNotAllowed).New(fmt.Sprintf("plugin %s does not support %s %s", pluginName, method, resource))
+	}
+	input := &plugin.ApiResourceInput{
+		Params: map[string]string{"plugin": pluginName},
+		Query:  query,
+		Body:   body,
+	}
+	for k, v := range params {
+		input.Params[k] = v
+	}
+	if input.Query == nil {
+		input.Query = url.Values{}
+	}
+	return handler(input)
+}
+
+// findManagedConnection returns the connection of the plugin with the given name, nil if there is none
+func findManagedConnection(pluginName string, name string) (map[string]interface{}, errors.Error) {
+	connections, err := listManagedConnections(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	for _, connection := range connections {
+		if connection["name"] == name {
+			return connection, nil
+		}
+	}
+	return nil, nil
+}
+
+func listManagedConnections(pluginName string) ([]map[string]interface{}, errors.Error) {
+	body, err := callPluginApi(pluginName, "connections", http.MethodGet, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	connections := make([]map[string]interface{}, 0)
+	err = convertManaged(body, &connections)
+	if err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// managedConnectionId returns the numeric id of a connection, as the plugin api takes it
+func managedConnectionId(connection map[string]interface{}) string {
+	if id, ok := connection["id"].(float64); ok {
+		return strconv.FormatUint(uint64(id), 10)
+	}
+	return fmt.Sprintf("%v", connection["id"])
+}
+
+func getManagedConnection(pluginName string, name string) (map[string]interface{}, errors.Error) {
+	connection, err := findManagedConnection(pluginName, name)
+	if err != nil {
+		return nil, err
+	}
+	if connection == nil {
+		return nil, errors.NotFound.New(fmt.Sprintf("could not find the %s connection [%s]", pluginName, name))
+	}
+	return connection, nil
+}
+
+// ListManagedConnections returns the connections of a plugin
+func ListManagedConnections(pluginName string) ([]*ManagedResource, errors.Error) {
+	connections, err := listManagedConnections(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*ManagedResource, 0, len(connections))
+	for _, connection := range connections {
+		resources = append(resources, &ManagedResource{
+			Id:         fmt.Sprintf("%s/%v", pluginName, connection["name"]),
+			Kind:       MANAGED_KIND_CONNECTION,
+			Attributes: connection,
+		})
+	}
+	return resources, nil
+}
+
+// GetManagedConnection returns the connection of a plugin by its name
+func GetManagedConnection(pluginName string, name string) (*ManagedResource, errors.Error) {
+	connection, err := getManagedConnection(pluginName, name)
+	if err != nil {
+		return nil, err
+	}
+	return &ManagedResource{Id: pluginName + "/" + name, Kind: MANAGED_KIND_CONNECTION, Attributes: connection}, nil
+}
+
+// PutManagedConnection creates the connection of a plugin, or updates it with the given attributes when it exists,
+// and tells whether it was created
+func PutManagedConnection(pluginName string, name string, attributes map[string]interface{}) (*ManagedResource, bool, errors.Error) {
+	connection, err := findManagedConnection(pluginName, name)
+	if err != nil {
+		return nil, false, err
+	}
+	body := putManagedAttributes(attributes, name)
+	var output interface{}
+	if connection == nil {
+		output, err = callPluginApi(pluginName, "connections", http.MethodPost, nil, nil, body)
+	} else {
+		output, err = callPluginApi(pluginName, "connections/:connectionId", http.MethodPatch,
+			map[string]string{"connectionId": managedConnectionId(connection)}, nil, body)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	resource, err := newManagedResource(MANAGED_KIND_CONNECTION, pluginName+"/"+name, output)
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, connection == nil, nil
+}
+
+// DeleteManagedConnection deletes the connection of a plugin by its name, it succeeds when there is none
+func DeleteManagedConnection(pluginName string, name string) errors.Error {
+	connection, err := findManagedConnection(pluginName, name)
+	if err != nil || connection == nil {
+		return err
+	}
+	_, err = callPluginApi(pluginName, "connections/:connectionId", http.MethodDelete,
+		map[string]string{"connectionId": managedConnectionId(connection)}, nil, nil)
+	return err
+}
+
+func listManagedScopeConfigs(pluginName string, connectionId string) ([]map[string]interface{}, errors.Error) {
+	scopeConfigs := make([]map[string]interface{}, 0)
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("pageSize", strconv.Itoa(managedScopeConfigPageSize))
+		body, err := callPluginApi(pluginName, "connections/:connectionId/transformation_rules", http.MethodGet,
+			map[string]string{"connectionId": connectionId}, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		rules := make([]map[string]interface{}, 0)
+		err = convertManaged(body, &rules)
+		if err != nil {
+			return nil, err
+		}
+		scopeConfigs = append(scopeConfigs, rules...)
+		if len(rules) < managedScopeConfigPageSize {
+			return scopeConfigs, nil
+		}
+	}
+}
+
+func findManagedScopeConfig(pluginName string, connectionName string, name string) (string, map[string]interface{}, errors.Error) {
+	connection, err := getManagedConnection(pluginName, connectionName)
+	if err != nil {
+		return "", nil, err
+	}
+	connectionId := managedConnectionId(connection)
+	scopeConfigs, err := listManagedScopeConfigs(pluginName, connectionId)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, scopeConfig := range scopeConfigs {
+		if scopeConfig["name"] == name {
+			return connectionId, scopeConfig, nil
+		}
+	}
+	return connectionId, nil, nil
+}
+
+func managedScopeConfigId(pluginName string, connectionName string, name string) string {
+	return pluginName + "/" + connectionName + "/" + name
+}
+
+// ListManagedScopeConfigs returns the scope configs, i.e. the transformation rules, of a connection
+func ListManagedScopeConfigs(pluginName string, connectionName string) ([]*ManagedResource, errors.Error) {
+	connection, err := getManagedConnection(pluginName, connectionName)
+	if err != nil {
+		return nil, err
+	}
+	scopeConfigs, err := listManagedScopeConfigs(pluginName, managedConnectionId(connection))
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*ManagedResource, 0, len(scopeConfigs))
+	for _, scopeConfig := range scopeConfigs {
+		resources = append(resources, &ManagedResource{
+			Id:         managedScopeConfigId(pluginName, connectionName, fmt.Sprintf("%v", scopeConfig["name"])),
+			Kind:       MANAGED_KIND_SCOPE_CONFIG,
+			Attributes: scopeConfig,
+		})
+	}
+	return resources, nil
+}
+
+// GetManagedScopeConfig returns the scope config of a connection by its name
+func GetManagedScopeConfig(pluginName string, connectionName string, name string) (*ManagedResource, errors.Error) {
+	_, scopeConfig, err := findManagedScopeConfig(pluginName, connectionName, name)
+	if err != nil {
+		return nil, err
+	}
+	if scopeConfig == nil {
+		return nil, errors.NotFound.New(fmt.Sprintf("could not find the scope config [%s] of the %s connection [%s]", name, pluginName, connectionName))
+	}
+	return &ManagedResource{
+		Id:         managedScopeConfigId(pluginName, connectionName, name),
+		Kind:       MANAGED_KIND_SCOPE_CONFIG,
+		Attributes: scopeConfig,
+	}, nil
+}
+
+// PutManagedScopeConfig creates the scope config of a connection, or updates it with the given attributes when it
+// exists, and tells whether it was created
+func PutManagedScopeConfig(pluginName string, connectionName string, name string, attributes map[string]interface{}) (*ManagedResource, bool, errors.Error) {
+	connectionId, scopeConfig, err := findManagedScopeConfig(pluginName, connectionName, name)
+	if err != nil {
+		return nil, false, err
+	}
+	body := putManagedAttributes(attributes, name)
+	var output interface{}
+	if scopeConfig == nil {
+		output, err = callPluginApi(pluginName, "connections/:connectionId/transformation_rules", http.MethodPost,
+			map[string]string{"connectionId": connectionId}, nil, body)
+	} else {
+		output, err = callPluginApi(pluginName, "connections/:connectionId/transformation_rules/:id", http.MethodPatch,
+			map[string]string{"connectionId": connectionId, "id": managedConnectionId(scopeConfig)}, nil, body)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	resource, err := newManagedResource(MANAGED_KIND_SCOPE_CONFIG, managedScopeConfigId(pluginName, connectionName, name), output)
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, scopeConfig == nil, nil
+}
+
+// DeleteManagedScopeConfig deletes the scope config of a connection by its name, it succeeds when there is none.
+// The plugins not supporting the deletion of their scope configs fail with 405.
+func DeleteManagedScopeConfig(pluginName string, connectionName string, name string) errors.Error {
+	connectionId, scopeConfig, err := findManagedScopeConfig(pluginName, connectionName, name)
+	if err != nil {
+		if err.GetType() == errors.NotFound {
+			return nil
+		}
+		return err
+	}
+	if scopeConfig == nil {
+		return nil
+	}
+	_, err = callPluginApi(pluginName, "connections/:connectionId/transformation_rules/:id", http.MethodDelete,
+		map[string]string{"connectionId": connectionId, "id": managedConnectionId(scopeConfig)}, nil, nil)
+	return err
+}
+
+// ManagedScopeConfigPut is a scope config to put in a batch, by its name
+type ManagedScopeConfigPut struct {
+	Name       string                 `json:"name"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// ManagedScopeConfigBatch is a batch of scope configs of a connection, the ones to put and the names of the ones to
+// delete
+type ManagedScopeConfigBatch struct {
+	Put    []*ManagedScopeConfigPut `json:"put"`
+	Delete []string                 `json:"delete"`
+}
+
+// ManagedBatchResult is the outcome of one resource of a batch
+type ManagedBatchResult struct {
+	Id       string           `json:"id"`
+	Op       string           `json:"op"`
+	Created  bool             `json:"created,omitempty"`
+	Resource *ManagedResource `json:"resource,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// ManagedBatchOutput tells whether the batch was applied, which is all or nothing, along with the outcome of every
+// resource
+type ManagedBatchOutput struct {
+	Applied bool                  `json:"applied"`
+	Results []*ManagedBatchResult `json:"results"`
+}
+
+// the operations of a batch of managed resources
+const (
+	MANAGED_BATCH_PUT    = "put"
+	MANAGED_BATCH_DELETE = "delete"
+)
+
+// BatchManagedScopeConfigs puts and deletes the scope configs of a connection in one transaction of the plugin.
+// Deleting a scope config which does not exist succeeds as it does one at a time, and the plugins without batches
+// of scope configs fail with 405.
+func BatchManagedScopeConfigs(pluginName string, connectionName string, batch *ManagedScopeConfigBatch) (*ManagedBatchOutput, errors.Error) {
+	connection, err := getManagedConnection(pluginName, connectionName)
+	if err != nil {
+		return nil, err
+	}
+	connectionId := managedConnectionId(connection)
+	scopeConfigs, err := listManagedScopeConfigs(pluginName, connectionId)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]map[string]interface{}, len(scopeConfigs))
+	for _, scopeConfig := range scopeConfigs {
+		existing[fmt.Sprintf("%v", scopeConfig["name"])] = scopeConfig
+	}
+
+	seen := make(map[string]bool)
+	checkName := func(name string) errors.Error {
+		if name == "" {
+			return errors.BadInput.New("the scope configs of the batch should be given by their names")
+		}
+		if seen[name] {
+			return errors.BadInput.New(fmt.Sprintf("the scope config [%s] is given more than once in the batch", name))
+		}
+		seen[name] = true
+		return nil
+	}
+	var creates, updates, deletes []interface{}
+	var createNames, updateNames, deleteNames []string
+	output := &ManagedBatchOutput{Results: make([]*ManagedBatchResult, 0, len(batch.Put)+len(batch.Delete))}
+	for _, put := range batch.Put {
+		if put == nil {
+			return nil, errors.BadInput.New("the scope configs to put should be objects")
+		}
+		if err = checkName(put.Name); err != nil {
+			return nil, err
+		}
+		body := putManagedAttributes(put.Attributes, put.Name)
+		if scopeConfig, ok := existing[put.Name]; ok {
+			body["id"] = scopeConfig["id"]
+			updates = append(updates, body)
+			updateNames = append(updateNames, put.Name)
+		} else {
+			creates = append(creates, body)
+			createNames = append(createNames, put.Name)
+		}
+	}
+	var missing []*ManagedBatchResult
+	for _, name := range batch.Delete {
+		if err = checkName(name); err != nil {
+			return nil, err
+		}
+		if scopeConfig, ok := existing[name]; ok {
+			deletes = append(deletes, scopeConfig["id"])
+			deleteNames = append(deleteNames, name)
+		} else {
+			missing = append(missing, &ManagedBatchResult{
+				Id: managedScopeConfigId(pluginName, connectionName, name),
+				Op: MANAGED_BATCH_DELETE,
+			})
+		}
+	}
+
+	pluginOutput, err := callPluginApiOutput(pluginName, "connections/:connectionId/transformation_rules/batch", http.MethodPost,
+		map[string]string{"connectionId": connectionId}, nil, map[string]interface{}{
+			helper.BATCH_OP_CREATE: creates,
+			helper.BATCH_OP_UPDATE: updates,
+			helper.BATCH_OP_DELETE: deletes,
+		})
+	if err != nil {
+		return nil, err
+	}
+	pluginBatch := &helper.TransformationRuleBatchOutput{}
+	if pluginOutput != nil {
+		err = convertManaged(pluginOutput.Body, pluginBatch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	names := map[string][]string{
+		helper.BATCH_OP_CREATE: createNames,
+		helper.BATCH_OP_UPDATE: updateNames,
+		helper.BATCH_OP_DELETE: deleteNames,
+	}
+	for _, r := range pluginBatch.Results {
+		if r.Index < 0 || r.Index >= len(names[r.Op]) {
+			return nil, errors.Default.New(fmt.Sprintf("the %s plugin answered an unknown item of the batch", pluginName))
+		}
+		id := managedScopeConfigId(pluginName, connectionName, names[r.Op][r.Index])
+		result := &ManagedBatchResult{Id: id, Op: MANAGED_BATCH_PUT, Created: r.Op == helper.BATCH_OP_CREATE, Error: r.Error}
+		if r.Op == helper.BATCH_OP_DELETE {
+			result.Op = MANAGED_BATCH_DELETE
+		}
+		if r.Rule != nil {
+			result.Resource, err = newManagedResource(MANAGED_KIND_SCOPE_CONFIG, id, r.Rule)
+			if err != nil {
+				return nil, err
+			}
+		}
+		output.Results = append(output.Results, result)
+	}
+	output.Results = append(output.Results, missing...)
+	output.Applied = pluginBatch.Applied || len(pluginBatch.Results) == 0
+	return output, nil
+}
+
+func newManagedProject(project *models.ApiOutputProject) (*ManagedResource, errors.Error) {
+	resource, err := newManagedResource(MANAGED_KIND_PROJECT, project.Name, project)
+	if err != nil {
+		return nil, err
+	}
+	// the blueprint of the project is a resource of its own
+	delete(resource.Attributes, "blueprint")
+	resource.Attributes["enable"] = project.Blueprint == nil || project.Blueprint.Enable
+	return resource, nil
+}
+
+// GetManagedProject returns a project
+func GetManagedProject(name string) (*ManagedResource, errors.Error) {
+	project, err := GetProject(name)
+	if err != nil {
+		return nil, err
+	}
+	return newManagedProject(project)
+}
+
+// PutManagedProject creates the project, or updates it with the given attributes when it exists, and tells whether
+// it was created
+func PutManagedProject(name string, attributes map[string]interface{}) (*ManagedResource, bool, errors.Error) {
+	current, err := GetManagedProject(name)
+	if err != nil && err.GetType() != errors.NotFound {
+		return nil, false, err
+	}
+	body := putManagedAttributes(attributes, name)
+	var project *models.ApiOutputProject
+	if current == nil {
+		projectInput := &models.ApiInputProject{}
+		err = helper.DecodeMapStruct(body, projectInput, true)
+		if err != nil {
+			return nil, false, errors.BadInput.Wrap(err, "invalid project")
+		}
+		project, err = CreateProject(projectInput)
+	} else {
+		// the attributes left out are kept as they are
+		for k, v := range current.Attributes {
+			if _, ok := body[k]; !ok {
+				body[k] = v
+			}
+		}
+		project, err = PatchProject(name, body)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	resource, err := newManagedProject(project)
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, current == nil, nil
+}
+
+// DeleteManagedProject deletes a project, it succeeds when there is none
+func DeleteManagedProject(name string) errors.Error {
+	err := DeleteProject(name)
+	if err != nil && err.GetType() == errors.NotFound {
+		return nil
+	}
+	return err
+}
+
+// GetManagedBlueprint returns the blueprint of a project
+func GetManagedBlueprint(projectName string) (*ManagedResource, errors.Error) {
+	if _, err := GetProject(projectName); err != nil {
+		return nil, err
+	}
+	blueprint, err := GetBlueprintByProjectName(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if blueprint == nil {
+		return nil, errors.NotFound.New(fmt.Sprintf("the project [%s] does not have any blueprint", projectName))
+	}
+	return newManagedResource(MANAGED_KIND_BLUEPRINT, projectName, blueprint)
+}
+
+// PutManagedBlueprint creates the blueprint of a project, or updates it with the given attributes when it exists,
+// and tells whether it was created
+func PutManagedBlueprint(projectName string, attributes map[string]interface{}) (*ManagedResource, bool, errors.Error) {
+	if _, err := GetProject(projectName); err != nil {
+		return nil, false, err
+	}
+	current, err := GetBlueprintByProjectName(projectName)
+	if err != nil {
+		return nil, false, err
+	}
+	name := projectName
+	if current != nil {
+		name = current.Name
+	}
+	if bpName, ok := attributes["name"].(string); ok && bpName != "" {
+		name = bpName
+	}
+	body := putManagedAttributes(attributes, name)
+	body["projectName"] = projectName
+	var blueprint *models.Blueprint
+	if current == nil {
+		blueprint = &models.Blueprint{Mode: models.BLUEPRINT_MODE_NORMAL, Enable: true, CronConfig: "manual"}
+		err = helper.DecodeMapStruct(body, blueprint, true)
+		if err != nil {
+			return nil, false, errors.BadInput.Wrap(err, "invalid blueprint")
+		}
+		err = CreateBlueprint(blueprint)
+	} else {
+		blueprint, err = PatchBlueprint(current.ID, body)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	resource, err := newManagedResource(MANAGED_KIND_BLUEPRINT, projectName, blueprint)
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, current == nil, nil
+}
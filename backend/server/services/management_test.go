@@ -0,0 +1,236 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type testManagedConnection struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`
+	Token    string `json:"token" gorm:"serializer:encdec"`
+}
+
+// testManagedPlugin keeps its connections in memory, the way the plugins expose them over their api
+type testManagedPlugin struct {
+	connections []*testManagedConnection
+	nextId      uint64
+}
+
+func (p *testManagedPlugin) Description() string { return "test" }
+
+func (p *testManagedPlugin) RootPkgPath() string { return "test" }
+
+func (p *testManagedPlugin) Connection() interface{} { return &testManagedConnection{} }
+
+func (p *testManagedPlugin) Scope() interface{} { return nil }
+
+func (p *testManagedPlugin) TransformationRule() interface{} { return nil }
+
+func (p *testManagedPlugin) find(input *plugin.ApiResourceInput) (int, errors.Error) {
+	id, _ := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	for i, connection := range p.connections {
+		if connection.ID == id {
+			return i, nil
+		}
+	}
+	return 0, errors.NotFound.New("connection not found")
+}
+
+func (p *testManagedPlugin) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"connections": {
+			"GET": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+				return &plugin.ApiResourceOutput{Body: p.connections}, nil
+			},
+			"POST": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+				p.nextId++
+				connection := &testManagedConnection{ID: p.nextId, Name: input.Body["name"].(string)}
+				connection.Endpoint, _ = input.Body["endpoint"].(string)
+				connection.Token, _ = input.Body["token"].(string)
+				p.connections = append(p.connections, connection)
+				return &plugin.ApiResourceOutput{Body: connection}, nil
+			},
+		},
+		"connections/:connectionId": {
+			"PATCH": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+				i, err := p.find(input)
+				if err != nil {
+					return nil, err
+				}
+				if endpoint, ok := input.Body["endpoint"].(string); ok {
+					p.connections[i].Endpoint = endpoint
+				}
+				if token, ok := input.Body["token"].(string); ok {
+					p.connections[i].Token = token
+				}
+				return &plugin.ApiResourceOutput{Body: p.connections[i]}, nil
+			},
+			"DELETE": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+				i, err := p.find(input)
+				if err != nil {
+					return nil, err
+				}
+				connection := p.connections[i]
+				p.connections = append(p.connections[:i], p.connections[i+1:]...)
+				return &plugin.ApiResourceOutput{Body: connection}, nil
+			},
+		},
+	}
+}
+
+func TestManagedConnection(t *testing.T) {
+	p := &testManagedPlugin{}
+	assert.Nil(t, plugin.RegisterPlugin("managedtest", p))
+
+	connection, created, err := PutManagedConnection("managedtest", "main", map[string]interface{}{
+		"id":       42,
+		"endpoint": "https://a.example.com",
+	})
+	assert.Nil(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "managedtest/main", connection.Id)
+	assert.Equal(t, MANAGED_KIND_CONNECTION, connection.Kind)
+	// the computed id is left to the plugin
+	assert.Equal(t, float64(1), connection.Attributes["id"])
+
+	// putting the connection again updates it in place
+	connection, created, err = PutManagedConnection("managedtest", "main", map[string]interface{}{
+		"endpoint": "https://b.example.com",
+	})
+	assert.Nil(t, err)
+	assert.False(t, created)
+	assert.Len(t, p.connections, 1)
+	assert.Equal(t, "https://b.example.com", connection.Attributes["endpoint"])
+
+	connection, err = GetManagedConnection("managedtest", "main")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://b.example.com", connection.Attributes["endpoint"])
+	_, err = GetManagedConnection("managedtest", "missing")
+	assert.Equal(t, errors.NotFound, err.GetType())
+
+	// deleting is idempotent
+	assert.Nil(t, DeleteManagedConnection("managedtest", "main"))
+	assert.Nil(t, DeleteManagedConnection("managedtest", "main"))
+	assert.Empty(t, p.connections)
+
+	// the plugins without scope configs tell so
+	_, _, err = PutManagedScopeConfig("managedtest", "missing", "rule", nil)
+	assert.Equal(t, errors.NotFound, err.GetType())
+	_, err = callPluginApi("managedtest", "connections/:connectionId/transformation_rules", http.MethodGet, nil, nil, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, err.GetType().GetHttpCode())
+}
+
+// testBatchPlugin keeps the scope configs of its connection in memory and answers batches of them the way the
+// transformation rule helper does
+type testBatchPlugin struct {
+	testManagedPlugin
+	rules   map[uint64]string
+	batches []map[string]interface{}
+}
+
+func (p *testBatchPlugin) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	resources := p.testManagedPlugin.ApiResources()
+	resources["connections/:connectionId/transformation_rules"] = map[string]plugin.ApiResourceHandler{
+		"GET": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+			rules := make([]map[string]interface{}, 0)
+			for id, name := range p.rules {
+				rules = append(rules, map[string]interface{}{"id": id, "name": name})
+			}
+			return &plugin.ApiResourceOutput{Body: rules}, nil
+		},
+	}
+	resources["connections/:connectionId/transformation_rules/batch"] = map[string]plugin.ApiResourceHandler{
+		"POST": func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+			p.batches = append(p.batches, input.Body)
+			output := &helper.TransformationRuleBatchOutput{Applied: true}
+			for i, v := range input.Body[helper.BATCH_OP_CREATE].([]interface{}) {
+				body := v.(map[string]interface{})
+				output.Results = append(output.Results, &helper.TransformationRuleBatchResult{
+					Op: helper.BATCH_OP_CREATE, Index: i, Id: 7, Rule: body,
+				})
+			}
+			for i, v := range input.Body[helper.BATCH_OP_UPDATE].([]interface{}) {
+				body := v.(map[string]interface{})
+				output.Results = append(output.Results, &helper.TransformationRuleBatchResult{
+					Op: helper.BATCH_OP_UPDATE, Index: i, Rule: body, Error: "invalid rule",
+				})
+				output.Applied = false
+			}
+			for i := range input.Body[helper.BATCH_OP_DELETE].([]interface{}) {
+				output.Results = append(output.Results, &helper.TransformationRuleBatchResult{
+					Op: helper.BATCH_OP_DELETE, Index: i,
+				})
+			}
+			return &plugin.ApiResourceOutput{Body: output}, nil
+		},
+	}
+	return resources
+}
+
+func TestBatchManagedScopeConfigs(t *testing.T) {
+	p := &testBatchPlugin{rules: map[uint64]string{1: "existing", 2: "old"}}
+	p.connections = []*testManagedConnection{{ID: 3, Name: "main"}}
+	p.nextId = 3
+	assert.Nil(t, plugin.RegisterPlugin("managedbatchtest", p))
+
+	output, err := BatchManagedScopeConfigs("managedbatchtest", "main", &ManagedScopeConfigBatch{
+		Put: []*ManagedScopeConfigPut{
+			{Name: "new", Attributes: map[string]interface{}{"id": 42, "prType": "feat"}},
+			{Name: "existing", Attributes: map[string]interface{}{"prType": "fix"}},
+		},
+		Delete: []string{"old", "missing"},
+	})
+	assert.Nil(t, err)
+	// the names are resolved to the ids of the plugin, leaving out the computed attributes
+	assert.Len(t, p.batches, 1)
+	creates := p.batches[0][helper.BATCH_OP_CREATE].([]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "new", "prType": "feat"}, creates[0])
+	updates := p.batches[0][helper.BATCH_OP_UPDATE].([]interface{})
+	assert.Equal(t, float64(1), updates[0].(map[string]interface{})["id"])
+	assert.Equal(t, []interface{}{float64(2)}, p.batches[0][helper.BATCH_OP_DELETE])
+
+	// the results are told by the managed ids, deleting a missing scope config succeeds
+	assert.False(t, output.Applied)
+	assert.Len(t, output.Results, 4)
+	assert.Equal(t, "managedbatchtest/main/new", output.Results[0].Id)
+	assert.True(t, output.Results[0].Created)
+	assert.Equal(t, "feat", output.Results[0].Resource.Attributes["prType"])
+	assert.Equal(t, "managedbatchtest/main/existing", output.Results[1].Id)
+	assert.Equal(t, "invalid rule", output.Results[1].Error)
+	assert.Equal(t, MANAGED_BATCH_DELETE, output.Results[2].Op)
+	assert.Equal(t, "managedbatchtest/main/old", output.Results[2].Id)
+	assert.Equal(t, "managedbatchtest/main/missing", output.Results[3].Id)
+	assert.Empty(t, output.Results[3].Error)
+
+	// the names are given once
+	_, err = BatchManagedScopeConfigs("managedbatchtest", "main", &ManagedScopeConfigBatch{
+		Put:    []*ManagedScopeConfigPut{{Name: "new"}},
+		Delete: []string{"new"},
+	})
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
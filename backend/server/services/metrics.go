@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var pipelineStatuses = []string{
+	models.TASK_CREATED,
+	models.TASK_RERUN,
+	models.TASK_RUNNING,
+	models.TASK_COMPLETED,
+	models.TASK_PARTIAL,
+	models.TASK_FAILED,
+	models.TASK_CANCELLED,
+}
+
+// pipelineStatusCollector counts the pipelines by their status when the metrics are scraped, the database being the
+// only place knowing them across restarts
+type pipelineStatusCollector struct {
+	desc *prometheus.Desc
+}
+
+func newPipelineStatusCollector() *pipelineStatusCollector {
+	return &pipelineStatusCollector{
+		desc: prometheus.NewDesc("devlake_pipelines", "Number of the pipelines by their status", []string{"status"}, nil),
+	}
+}
+
+func (c *pipelineStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *pipelineStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := countPipelinesByStatus()
+	if err != nil {
+		logger.Error(err, "failed to count the pipelines for the metrics")
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	for _, status := range pipelineStatuses {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(counts[status]), status)
+	}
+}
+
+func countPipelinesByStatus() (map[string]int64, errors.Error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := db.All(&rows,
+		dal.Select("status, COUNT(*) AS count"),
+		dal.From(&models.Pipeline{}),
+		dal.Groupby("status"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// registerMetrics registers the metrics of the services to the default registry served on /metrics
+func registerMetrics() {
+	err := prometheus.Register(newPipelineStatusCollector())
+	if _, ok := err.(prometheus.AlreadyRegisteredError); !ok && err != nil {
+		logger.Error(err, "failed to register the metrics of the pipelines")
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/migration"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// MigrationRollback identifies the migration script to roll back
+type MigrationRollback struct {
+	ScriptName    string `json:"scriptName" validate:"required"`
+	ScriptVersion uint64 `json:"scriptVersion" validate:"required"`
+}
+
+// executeOnlineMigration applies the online migration scripts in the background, so the long-running data migrations
+// don't hold the server startup, their progress is exposed by GetMigrationStates
+func executeOnlineMigration() {
+	onlineMigrator, ok := migrator.(plugin.OnlineMigrator)
+	if !ok {
+		return
+	}
+	go func() {
+		err := onlineMigrator.ExecuteOnline()
+		if err != nil {
+			logger.Error(err, "failed to apply the online migration scripts, they resume on the next startup")
+			return
+		}
+		logger.Info("online migration scripts applied")
+	}()
+}
+
+// GetMigrationStates returns the progress of the resumable migration scripts
+func GetMigrationStates() ([]migration.MigrationState, errors.Error) {
+	return migration.GetMigrationStates(basicRes)
+}
+
+// RollbackMigration reverts a migration script by the rollback recorded in its history
+func RollbackMigration(rollback *MigrationRollback) errors.Error {
+	err := VerifyStruct(rollback)
+	if err != nil {
+		return err
+	}
+	return migration.Rollback(basicRes, rollback.ScriptName, rollback.ScriptVersion)
+}
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
@@ -31,6 +32,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/google/uuid"
 	v11 "go.temporal.io/api/enums/v1"
@@ -43,6 +45,11 @@ var notificationService *NotificationService
 var temporalClient client.Client
 var globalPipelineLog = logruslog.Global.Nested("pipeline service")
 
+// the pipeline queue tells when it last looked for a pipeline to run, and whether it is waiting for a running one to
+// finish instead, which is how the readiness check tells a busy queue from a stuck one
+var pipelineQueuePolledAt atomic.Int64
+var pipelineQueueFull atomic.Bool
+
 // PipelineQuery is a query for GetPipelines
 type PipelineQuery struct {
 	Pagination
@@ -50,6 +57,10 @@ type PipelineQuery struct {
 	Pending     int    `form:"pending"`
 	BlueprintId uint64 `uri:"blueprintId" form:"blueprint_id"`
 	Label       string `form:"label"`
+	// ProjectNames restricts the pipelines to the ones of the blueprints of the given projects when it is not nil
+	ProjectNames []string `form:"-"`
+	// List holds the sorting and the filters by the fields of the pipelines
+	List *helper.ListQuery `form:"-"`
 }
 
 func pipelineServiceInit() {
@@ -114,6 +125,7 @@ func pipelineServiceInit() {
 	}
 	// run pipeline with independent goroutine
 	go RunPipelineInQueue(pipelineMaxParallel)
+	registerMetrics()
 }
 
 // CreatePipeline and return the model
@@ -173,15 +185,19 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 	var runningParallelLabelLock sync.Mutex
 	for {
 		globalPipelineLog.Info("acquire lock")
+		pipelineQueuePolledAt.Store(time.Now().UnixNano())
 		// start goroutine when sema lock ready and pipeline exist.
 		// to avoid read old pipeline, acquire lock before read exist pipeline
+		pipelineQueueFull.Store(true)
 		err := sema.Acquire(context.TODO(), 1)
+		pipelineQueueFull.Store(false)
 		if err != nil {
 			panic(err)
 		}
 		globalPipelineLog.Info("get lock and wait next pipeline")
 		dbPipeline := &models.Pipeline{}
 		for {
+			pipelineQueuePolledAt.Store(time.Now().UnixNano())
 			cronLocker.Lock()
 			// prepare query to find an appropriate pipeline to execute
 			err := db.First(dbPipeline,
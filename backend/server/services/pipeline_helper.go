@@ -61,6 +61,11 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (*models.Pipeline, errors
 			return nil, errors.Default.New(fmt.Sprintf("the blueprint is running fetched:[%d],count:[%d]:\r\n%s", fetched, count, errstr))
 		}
 	}
+	if newPipeline.Backfill != nil {
+		if err := newPipeline.Backfill.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	planByte, err := errors.Convert01(json.Marshal(newPipeline.Plan))
 	if err != nil {
 		return nil, err
@@ -74,6 +79,7 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (*models.Pipeline, errors
 		SpentSeconds:  0,
 		Plan:          planByte,
 		SkipOnFail:    newPipeline.SkipOnFail,
+		Backfill:      newPipeline.Backfill,
 	}
 	if newPipeline.BlueprintId != 0 {
 		dbPipeline.BlueprintId = newPipeline.BlueprintId
@@ -155,6 +161,13 @@ func GetDbPipelines(query *PipelineQuery) ([]*models.Pipeline, int64, errors.Err
 			dal.Where("pl.name = ?", query.Label),
 		)
 	}
+	if query.ProjectNames != nil {
+		clauses = append(clauses, dal.Where(
+			"blueprint_id IN (SELECT id FROM _devlake_blueprints WHERE project_name IN ?)",
+			query.ProjectNames,
+		))
+	}
+	clauses = append(clauses, query.List.Clauses()...)
 
 	// count total records
 	count, err := db.Count(clauses...)
@@ -164,7 +177,7 @@ func GetDbPipelines(query *PipelineQuery) ([]*models.Pipeline, int64, errors.Err
 
 	// load paginated blueprints from database
 	clauses = append(clauses,
-		dal.Orderby("id DESC"),
+		dal.Orderby(query.List.Orderby("id DESC")),
 		dal.Offset(query.GetSkip()),
 		dal.Limit(query.GetPageSize()),
 	)
@@ -116,6 +116,10 @@ func runPipeline(pipelineId uint64) errors.Error {
 	if err != nil {
 		err = errors.Default.Wrap(err, fmt.Sprintf("Error running pipeline %d.", pipelineId))
 	}
+	// the duplicated entities collected again by the pipeline are merged into the ones kept
+	if e := ApplyEntityRedirects(); e != nil {
+		pipelineRun.logger.Error(e, "failed to apply the entity redirects")
+	}
 	dbPipeline, e := GetDbPipeline(pipelineId)
 	if e != nil {
 		return errors.Default.Wrap(err, fmt.Sprintf("Unable to get pipeline %d.", pipelineId))
@@ -140,6 +144,12 @@ func runPipeline(pipelineId uint64) errors.Error {
 		globalPipelineLog.Error(err, "update pipeline state failed")
 		return err
 	}
+	switch dbPipeline.Status {
+	case models.TASK_COMPLETED, models.TASK_PARTIAL:
+		PublishEvent(models.EVENT_PIPELINE_COMPLETED, dbPipeline)
+	case models.TASK_FAILED:
+		PublishEvent(models.EVENT_PIPELINE_FAILED, dbPipeline)
+	}
 	// notify external webhook
 	return NotifyExternal(pipelineId)
 }
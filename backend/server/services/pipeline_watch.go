@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// how often the progress of a watched pipeline is checked
+var pipelineWatchInterval = time.Second
+
+// PipelineProgress is the pipeline along with its latest tasks, Done tells no more progress follows
+type PipelineProgress struct {
+	Pipeline *models.Pipeline
+	Tasks    []*models.Task
+	Done     bool
+}
+
+// GetPipelineProgress returns the pipeline along with its latest tasks and their running progress
+func GetPipelineProgress(pipelineId uint64) (*PipelineProgress, errors.Error) {
+	pipeline, err := GetPipeline(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := GetTasksWithLastStatus(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	done := true
+	for _, status := range models.PendingTaskStatus {
+		if pipeline.Status == status {
+			done = false
+		}
+	}
+	return &PipelineProgress{Pipeline: pipeline, Tasks: tasks, Done: done}, nil
+}
+
+// WatchPipeline calls emit with the progress of the pipeline right away and then whenever it changes, until the
+// pipeline is done or the context is cancelled
+func WatchPipeline(ctx context.Context, pipelineId uint64, emit func(*PipelineProgress) errors.Error) errors.Error {
+	return watchPipeline(ctx, func() (*PipelineProgress, errors.Error) { return GetPipelineProgress(pipelineId) }, emit)
+}
+
+func watchPipeline(
+	ctx context.Context,
+	load func() (*PipelineProgress, errors.Error),
+	emit func(*PipelineProgress) errors.Error,
+) errors.Error {
+	var last []byte
+	for {
+		progress, err := load()
+		if err != nil {
+			return err
+		}
+		current, e := json.Marshal(progress)
+		if e != nil {
+			return errors.Default.Wrap(e, "error serializing the progress of the pipeline")
+		}
+		if !bytes.Equal(current, last) {
+			err = emit(progress)
+			if err != nil {
+				return err
+			}
+			last = current
+		}
+		if progress.Done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pipelineWatchInterval):
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchPipeline(t *testing.T) {
+	pipelineWatchInterval = time.Millisecond
+	snapshots := []*PipelineProgress{
+		{Pipeline: &models.Pipeline{Status: models.TASK_CREATED}},
+		{Pipeline: &models.Pipeline{Status: models.TASK_RUNNING, TotalTasks: 2}, Tasks: []*models.Task{{Progress: 0.5}}},
+		// nothing changed since the last check
+		{Pipeline: &models.Pipeline{Status: models.TASK_RUNNING, TotalTasks: 2}, Tasks: []*models.Task{{Progress: 0.5}}},
+		{Pipeline: &models.Pipeline{Status: models.TASK_RUNNING, TotalTasks: 2}, Tasks: []*models.Task{{Progress: 1}}},
+		{Pipeline: &models.Pipeline{Status: models.TASK_COMPLETED, TotalTasks: 2, FinishedTasks: 2}, Done: true},
+	}
+	loads := 0
+	load := func() (*PipelineProgress, errors.Error) {
+		loads++
+		return snapshots[loads-1], nil
+	}
+	var emitted []*PipelineProgress
+	emit := func(progress *PipelineProgress) errors.Error {
+		emitted = append(emitted, progress)
+		return nil
+	}
+	assert.Nil(t, watchPipeline(context.Background(), load, emit))
+	assert.Equal(t, 5, loads)
+	assert.Equal(t, []*PipelineProgress{snapshots[0], snapshots[1], snapshots[3], snapshots[4]}, emitted)
+
+	// stop watching once cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	loads, emitted = 0, nil
+	assert.Nil(t, watchPipeline(ctx, load, emit))
+	assert.Equal(t, 1, loads)
+	assert.Len(t, emitted, 1)
+
+	// the failures of the client end the watch
+	loads = 0
+	failure := errors.Default.New("the stream is closed")
+	assert.Equal(t, failure, watchPipeline(context.Background(), load, func(*PipelineProgress) errors.Error { return failure }))
+	assert.Equal(t, 1, loads)
+}
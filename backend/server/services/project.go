@@ -19,16 +19,24 @@ package services
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
 // ProjectQuery used to query projects as the api project input
 type ProjectQuery struct {
 	Pagination
+	// Names restricts the projects to the ones visible to the user when it is not nil
+	Names []string `form:"-"`
+	// List holds the sorting and the filters by the fields of the projects
+	List *helper.ListQuery `form:"-"`
 }
 
 // GetProjects returns a paginated list of Projects based on `query`
@@ -40,6 +48,10 @@ func GetProjects(query *ProjectQuery) ([]*models.Project, int64, errors.Error) {
 	clauses := []dal.Clause{
 		dal.From(&models.Project{}),
 	}
+	if query.Names != nil {
+		clauses = append(clauses, dal.Where("name IN ?", query.Names))
+	}
+	clauses = append(clauses, query.List.Clauses()...)
 
 	count, err := db.Count(clauses...)
 	if err != nil {
@@ -47,7 +59,7 @@ func GetProjects(query *ProjectQuery) ([]*models.Project, int64, errors.Error) {
 	}
 
 	clauses = append(clauses,
-		dal.Orderby("created_at DESC"),
+		dal.Orderby(query.List.Orderby("created_at DESC")),
 		dal.Offset(query.GetSkip()),
 		dal.Limit(query.GetPageSize()),
 	)
@@ -80,8 +92,23 @@ func CreateProject(projectInput *models.ApiInputProject) (*models.ApiOutputProje
 	}()
 
 	// create project first
+	if projectInput.Workspace != "" {
+		_, err = GetWorkspace(projectInput.Workspace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// the domain data of the project is written into its own schema, which must exist before any pipeline runs
+	if projectInput.DataSchema != "" {
+		err = provisionDataSchema(projectInput.DataSchema)
+		if err != nil {
+			return nil, err
+		}
+	}
 	project := &models.Project{}
 	project.BaseProject = projectInput.BaseProject
+	project.Workspace = projectInput.Workspace
+	project.DataSchema = projectInput.DataSchema
 	err = db.Create(project)
 	if err != nil {
 		if db.IsDuplicationError(err) {
@@ -104,7 +131,7 @@ func CreateProject(projectInput *models.ApiInputProject) (*models.ApiOutputProje
 		return nil, err
 	}
 
-	return makeProjectOutput(&projectInput.BaseProject)
+	return makeProjectOutput(project)
 }
 
 // GetProject returns a Project
@@ -125,7 +152,7 @@ func GetProject(name string) (*models.ApiOutputProject, errors.Error) {
 	}
 
 	// convert to api output
-	return makeProjectOutput(&project.BaseProject)
+	return makeProjectOutput(project)
 }
 
 // PatchProject FIXME ...
@@ -193,8 +220,13 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 			return nil, err
 		}
 
-		// ProjectMapping
-		err = tx.UpdateColumn(
+		// ProjectMapping, kept along with the domain data of the project
+		var dataTx dal.Dal
+		dataTx, err = withDataSchema(tx, project.DataSchema)
+		if err != nil {
+			return nil, err
+		}
+		err = dataTx.UpdateColumn(
 			&crossdomain.ProjectMapping{},
 			"project_name", project.Name,
 			dal.Where("project_name = ?", name),
@@ -212,6 +244,16 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 		if err != nil {
 			return nil, err
 		}
+
+		// ProjectRoleBinding
+		err = tx.UpdateColumn(
+			&models.ProjectRoleBinding{},
+			"project_name", project.Name,
+			dal.Where("project_name = ?", name),
+		)
+		if err != nil {
+			return nil, err
+		}
 		// rename project
 		err = tx.UpdateColumn(
 			&models.Project{},
@@ -220,6 +262,15 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 		)
 	}
 
+	// the blueprints disabled along with the project
+	disabledBlueprints := make([]*models.Blueprint, 0)
+	if projectInput.Enable != nil && !*projectInput.Enable {
+		err = tx.All(&disabledBlueprints, dal.Where("project_name = ? AND enable = ?", project.Name, true))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Blueprint
 	err = tx.UpdateColumn(
 		&models.Blueprint{},
@@ -249,9 +300,85 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 	if err != nil {
 		return nil, err
 	}
+	for _, blueprint := range disabledBlueprints {
+		blueprint.Enable = false
+		PublishEvent(models.EVENT_BLUEPRINT_DISABLED, blueprint)
+	}
 
 	// all good, render output
-	return makeProjectOutput(&projectInput.BaseProject)
+	return makeProjectOutput(project)
+}
+
+// DeleteProject deletes a project along with its blueprint, metrics, mappings and role bindings
+func DeleteProject(name string) errors.Error {
+	project, err := GetProject(name)
+	if err != nil {
+		return err
+	}
+	blueprint, err := GetBlueprintByProjectName(name)
+	if err != nil {
+		return err
+	}
+
+	// wrap all operation inside a transaction
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			err = tx.Rollback()
+			if err != nil {
+				logger.Error(err, "DeleteProject: failed to rollback")
+			}
+		}
+	}()
+
+	if blueprint != nil {
+		err = tx.Delete(&models.DbBlueprintLabel{}, dal.Where("blueprint_id = ?", blueprint.ID))
+		if err != nil {
+			return err
+		}
+		err = tx.Delete(&models.Blueprint{}, dal.Where("id = ?", blueprint.ID))
+		if err != nil {
+			return err
+		}
+	}
+	for _, table := range []interface{}{
+		&models.ProjectMetricSetting{},
+		&crossdomain.ProjectPrMetric{},
+		&crossdomain.ProjectIssueMetric{},
+		&crossdomain.ProjectMapping{},
+		&models.ProjectRoleBinding{},
+	} {
+		err = tx.Delete(table, dal.Where("project_name = ?", name))
+		if err != nil {
+			return err
+		}
+	}
+	// the data schema itself is left in place so the domain data may still be looked into
+	if project.DataSchema != "" {
+		var dataTx dal.Dal
+		dataTx, err = withDataSchema(tx, project.DataSchema)
+		if err != nil {
+			return err
+		}
+		err = dataTx.Delete(&crossdomain.ProjectMapping{}, dal.Where("project_name = ?", name))
+		if err != nil {
+			return err
+		}
+	}
+	err = tx.Delete(&models.Project{}, dal.Where("name = ?", name))
+	if err != nil {
+		return err
+	}
+
+	// commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	if blueprint != nil {
+		return ReloadBlueprints(cronManager)
+	}
+	return nil
 }
 
 func refreshProjectMetrics(tx dal.Transaction, projectInput *models.ApiInputProject) errors.Error {
@@ -274,9 +401,11 @@ func refreshProjectMetrics(tx dal.Transaction, projectInput *models.ApiInputProj
 	return nil
 }
 
-func makeProjectOutput(baseProject *models.BaseProject) (*models.ApiOutputProject, errors.Error) {
+func makeProjectOutput(project *models.Project) (*models.ApiOutputProject, errors.Error) {
 	projectOutput := &models.ApiOutputProject{}
-	projectOutput.BaseProject = *baseProject
+	projectOutput.BaseProject = project.BaseProject
+	projectOutput.Workspace = project.Workspace
+	projectOutput.DataSchema = project.DataSchema
 	// load project metrics
 	projectMetrics := make([]models.ProjectMetricSetting, 0)
 	err := db.All(&projectMetrics, dal.Where("project_name = ?", projectOutput.Name))
@@ -299,3 +428,71 @@ func makeProjectOutput(baseProject *models.BaseProject) (*models.ApiOutputProjec
 	}
 	return projectOutput, err
 }
+
+// ProjectSummary holds the key domain aggregates of a project
+type ProjectSummary struct {
+	Since              *time.Time `json:"since"`
+	Issues             int64      `json:"issues"`
+	OpenIssues         int64      `json:"openIssues"`
+	Incidents          int64      `json:"incidents"`
+	PullRequests       int64      `json:"pullRequests"`
+	MergedPullRequests int64      `json:"mergedPullRequests"`
+	Deployments        int64      `json:"deployments"`
+	FailedDeployments  int64      `json:"failedDeployments"`
+}
+
+// GetProjectSummary aggregates the domain data of the scopes mapped to a project, since a given time if any
+func GetProjectSummary(name string, since *time.Time) (*ProjectSummary, errors.Error) {
+	dataDb, err := projectDb(name)
+	if err != nil {
+		return nil, err
+	}
+	summary := &ProjectSummary{Since: since}
+	sinceClause := func(column string) dal.Clause {
+		if since == nil {
+			return dal.Where("1 = 1")
+		}
+		return dal.Where(column+" >= ?", since)
+	}
+	err = dataDb.All(
+		summary,
+		dal.Select(
+			"count(distinct i.id) as issues, count(distinct case when i.status != ? then i.id end) as open_issues, count(distinct case when i.type = ? then i.id end) as incidents",
+			ticket.DONE, ticket.INCIDENT,
+		),
+		dal.From("issues i"),
+		dal.Join("left join board_issues bi on bi.issue_id = i.id"),
+		dal.Join("left join project_mapping pm on pm.row_id = bi.board_id and pm.table = 'boards'"),
+		dal.Where("pm.project_name = ?", name),
+		sinceClause("i.created_date"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error summarizing the issues of the project")
+	}
+	err = dataDb.All(
+		summary,
+		dal.Select("count(distinct pr.id) as pull_requests, count(distinct case when pr.merged_date is not null then pr.id end) as merged_pull_requests"),
+		dal.From("pull_requests pr"),
+		dal.Join("left join project_mapping pm on pm.row_id = pr.base_repo_id and pm.table = 'repos'"),
+		dal.Where("pm.project_name = ?", name),
+		sinceClause("pr.created_date"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error summarizing the pull requests of the project")
+	}
+	err = dataDb.All(
+		summary,
+		dal.Select(
+			"count(distinct dc.cicd_deployment_id) as deployments, count(distinct case when dc.result = ? then dc.cicd_deployment_id end) as failed_deployments",
+			devops.FAILURE,
+		),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("left join project_mapping pm on pm.row_id = dc.cicd_scope_id and pm.table = 'cicd_scopes'"),
+		dal.Where("pm.project_name = ? and dc.environment = ?", name, devops.PRODUCTION),
+		sinceClause("dc.finished_date"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error summarizing the deployments of the project")
+	}
+	return summary, nil
+}
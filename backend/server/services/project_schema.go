@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+)
+
+var dataSchemaPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// provisionDataSchema creates the data schema of a project along with its domain tables, the columns added to the
+// shared tables by the migrations since it was created are added to the isolated ones
+func provisionDataSchema(schema string) errors.Error {
+	if !dataSchemaPattern.MatchString(schema) {
+		return errors.BadInput.New(fmt.Sprintf("invalid data schema %s, only lowercase letters, digits and _ are allowed", schema))
+	}
+	var createSchema, createTable string
+	switch db.Dialect() {
+	case "mysql":
+		createSchema = "CREATE DATABASE IF NOT EXISTS ?"
+		createTable = "CREATE TABLE IF NOT EXISTS ? LIKE ?"
+	case "postgres":
+		createSchema = "CREATE SCHEMA IF NOT EXISTS ?"
+		createTable = "CREATE TABLE IF NOT EXISTS ? (LIKE ? INCLUDING ALL)"
+	default:
+		return errors.Default.New(fmt.Sprintf("data schemas are not supported by %s", db.Dialect()))
+	}
+	err := db.Exec(createSchema, dal.ClauseTable{Name: schema})
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error creating data schema %s", schema))
+	}
+	for _, tabler := range domaininfo.GetDomainTablesInfo() {
+		table := tabler.TableName()
+		qualified := schema + "." + table
+		err = db.Exec(createTable, dal.ClauseTable{Name: qualified}, dal.ClauseTable{Name: table})
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error creating %s", qualified))
+		}
+		var isolatedColumns []string
+		err = db.Pluck(
+			"column_name",
+			&isolatedColumns,
+			dal.From("information_schema.columns"),
+			dal.Where("table_schema = ? AND table_name = ?", schema, table),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error reading the columns of %s", qualified))
+		}
+		isolated := make(map[string]bool, len(isolatedColumns))
+		for _, column := range isolatedColumns {
+			isolated[column] = true
+		}
+		sharedColumns, err := db.GetColumns(tabler, func(columnMeta dal.ColumnMeta) bool {
+			return !isolated[columnMeta.Name()]
+		})
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error reading the columns of %s", table))
+		}
+		for _, column := range sharedColumns {
+			columnType, ok := column.ColumnType()
+			if !ok {
+				columnType = column.DatabaseTypeName()
+			}
+			err = db.AddColumn(qualified, column.Name(), dal.ColumnType(columnType))
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("error adding column %s to %s", column.Name(), qualified))
+			}
+		}
+	}
+	return nil
+}
+
+// provisionDataSchemas brings the data schemas of the projects up to date with the migrated domain tables
+func provisionDataSchemas() errors.Error {
+	var schemas []string
+	err := db.Pluck("data_schema", &schemas, dal.From(&models.Project{}), dal.Where("data_schema != ''"), dal.Groupby("data_schema"))
+	if err != nil {
+		return errors.Default.Wrap(err, "error reading the data schemas of the projects")
+	}
+	for _, schema := range schemas {
+		err = provisionDataSchema(schema)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectDb returns the dal reading the domain data of the project, from its data schema if it has one
+func projectDb(name string) (dal.Dal, errors.Error) {
+	project := &models.Project{}
+	err := db.First(project, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("could not find project [%s] in DB", name))
+		}
+		return nil, errors.Default.Wrap(err, "error getting project from DB")
+	}
+	return withDataSchema(replicaDb, project.DataSchema)
+}
+
+// withDataSchema redirects the domain tables of the dal to the data schema, the dal is returned as is without one
+func withDataSchema(d dal.Dal, schema string) (dal.Dal, errors.Error) {
+	if schema == "" {
+		return d, nil
+	}
+	redirector, ok := d.(dal.TableSchemaRedirector)
+	if !ok {
+		return nil, errors.Default.New(fmt.Sprintf("the dal can't redirect the domain tables to the data schema %s", schema))
+	}
+	return redirector.WithTableSchema(schema, domaininfo.GetDomainTableNames()), nil
+}
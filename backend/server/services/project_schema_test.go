@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSchemaPattern(t *testing.T) {
+	for _, schema := range []string{"lake_team_a", "p1", "a"} {
+		assert.True(t, dataSchemaPattern.MatchString(schema), schema)
+	}
+	for _, schema := range []string{"", "1team", "Team", "team-a", "team;drop", "lake.issues"} {
+		assert.False(t, dataSchemaPattern.MatchString(schema), schema)
+		assert.NotNil(t, provisionDataSchema(schema), schema)
+	}
+}
+
+func TestWithDataSchemaWithoutSchema(t *testing.T) {
+	d, err := withDataSchema(nil, "")
+	assert.Nil(t, err)
+	assert.Nil(t, d)
+}
@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// DomainRowRawData is the raw api payload a row of a domain layer table was extracted from
+type DomainRowRawData struct {
+	RawDataTable  string          `json:"rawDataTable"`
+	RawDataId     uint64          `json:"rawDataId"`
+	RawDataParams string          `json:"rawDataParams"`
+	Url           string          `json:"url"`
+	Data          json.RawMessage `json:"data"`
+	CreatedAt     *time.Time      `json:"createdAt"`
+	// Retained is false when the payload was dropped once extracted, see models.RawDataRetention
+	Retained bool `json:"retained"`
+}
+
+// GetDomainRowRawData returns the raw api payloads behind the rows of a domain layer table, the rows are located by
+// the values of their columns, e.g. {"id": "github:GithubIssue:1:1000"}
+func GetDomainRowRawData(table string, columnValues map[string]string) ([]*DomainRowRawData, errors.Error) {
+	clauses, err := domainRowClauses(table, columnValues)
+	if err != nil {
+		return nil, err
+	}
+	clauses = append(clauses, dal.Select("DISTINCT _raw_data_table, _raw_data_id, _raw_data_params"))
+	var origins []common.RawDataOrigin
+	err = db.All(&origins, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	rawData := make([]*DomainRowRawData, 0)
+	for _, origin := range origins {
+		// the rows produced without an api payload, e.g. by an enricher, have no raw table to look into
+		if !strings.HasPrefix(origin.RawDataTable, "_raw_") || !db.HasTable(origin.RawDataTable) {
+			continue
+		}
+		rowRawData := &DomainRowRawData{
+			RawDataTable:  origin.RawDataTable,
+			RawDataId:     origin.RawDataId,
+			RawDataParams: origin.RawDataParams,
+		}
+		row := &helper.RawData{}
+		err = db.First(row, dal.From(origin.RawDataTable), dal.Where("id = ?", origin.RawDataId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			err = helper.LoadRawData(context.Background(), row)
+			if err != nil {
+				return nil, err
+			}
+			rowRawData.Url = row.Url
+			rowRawData.Data = row.Data
+			rowRawData.CreatedAt = &row.CreatedAt
+			rowRawData.Retained = true
+		}
+		rawData = append(rawData, rowRawData)
+	}
+	return rawData, nil
+}
+
+// GetRawDataRow returns a row of a raw table along with its payload, wherever the payload is stored
+func GetRawDataRow(table string, id uint64) (*helper.RawData, errors.Error) {
+	if !strings.HasPrefix(table, "_raw_") || !db.HasTable(table) {
+		return nil, errors.NotFound.New(fmt.Sprintf("raw table %s not found", table))
+	}
+	row := &helper.RawData{}
+	err := db.First(row, dal.From(table), dal.Where("id = ?", id))
+	if db.IsErrorNotFound(err) {
+		return nil, errors.NotFound.New(fmt.Sprintf("row %d not found in %s", id, table))
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = helper.LoadRawData(context.Background(), row)
+	if err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// GetRawDataRetentions returns the scopes whose raw data retention was toggled
+func GetRawDataRetentions() ([]*models.RawDataRetention, errors.Error) {
+	retentions := make([]*models.RawDataRetention, 0)
+	err := db.All(&retentions, dal.Orderby("plugin, raw_data_params"))
+	if err != nil {
+		return nil, err
+	}
+	return retentions, nil
+}
+
+// SaveRawDataRetention sets the raw data retention of a scope or plugin, the keepRawData toggle takes effect from the
+// next extraction and the retentionDays from the next raw data purge
+func SaveRawDataRetention(retention *models.RawDataRetention) (*models.RawDataRetention, errors.Error) {
+	if _, err := plugin.GetPlugin(retention.Plugin); err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid plugin %s", retention.Plugin))
+	}
+	// empty params stand for all the scopes of the plugin
+	if retention.RawDataParams != "" && !json.Valid([]byte(retention.RawDataParams)) {
+		return nil, errors.BadInput.New("rawDataParams must be the _raw_data_params of the scope, or empty for the plugin")
+	}
+	if retention.RetentionDays < 0 {
+		return nil, errors.BadInput.New("retentionDays must not be negative")
+	}
+	err := db.CreateOrUpdate(retention)
+	if err != nil {
+		return nil, err
+	}
+	return retention, nil
+}
+
+// RawDataDedupStatQuery is the query of the raw data dedup stats
+type RawDataDedupStatQuery struct {
+	Pagination
+	Plugin        string `form:"plugin"`
+	RawDataTable  string `form:"rawDataTable"`
+	RawDataParams string `form:"rawDataParams"`
+}
+
+// RawDataDedupStats is a page of the dedup stats of the streams along with the totals of all those matching the query
+type RawDataDedupStats struct {
+	Stats      []*models.RawDataDedupStat `json:"stats"`
+	Count      int64                      `json:"count"`
+	Collected  int64                      `json:"collected"`
+	Duplicated int64                      `json:"duplicated"`
+	DedupRatio float64                    `json:"dedupRatio"`
+}
+
+// GetRawDataDedupStats returns how many of the collected payloads were skipped as duplicated, the most duplicated first
+func GetRawDataDedupStats(query *RawDataDedupStatQuery) (*RawDataDedupStats, errors.Error) {
+	clauses := []dal.Clause{dal.From(&models.RawDataDedupStat{})}
+	if query.Plugin != "" {
+		clauses = append(clauses, dal.Where("plugin = ?", query.Plugin))
+	}
+	if query.RawDataTable != "" {
+		clauses = append(clauses, dal.Where("raw_data_table = ?", query.RawDataTable))
+	}
+	if query.RawDataParams != "" {
+		clauses = append(clauses, dal.Where("raw_data_params = ?", query.RawDataParams))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting DB count of raw data dedup stats")
+	}
+	result := &RawDataDedupStats{Stats: make([]*models.RawDataDedupStat, 0), Count: count}
+	err = db.All(
+		&result.Stats,
+		append(clauses,
+			dal.Orderby("duplicated DESC, raw_data_table, raw_data_params"),
+			dal.Offset(query.GetSkip()),
+			dal.Limit(query.GetPageSize()),
+		)...,
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB raw data dedup stats")
+	}
+	for _, stat := range result.Stats {
+		stat.DedupRatio = dedupRatio(stat.Collected, stat.Duplicated)
+	}
+	var totals []struct {
+		Collected  int64
+		Duplicated int64
+	}
+	err = db.All(&totals, append(clauses, dal.Select("COALESCE(SUM(collected), 0) AS collected, COALESCE(SUM(duplicated), 0) AS duplicated"))...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error summing DB raw data dedup stats")
+	}
+	if len(totals) > 0 {
+		result.Collected = totals[0].Collected
+		result.Duplicated = totals[0].Duplicated
+		result.DedupRatio = dedupRatio(result.Collected, result.Duplicated)
+	}
+	return result, nil
+}
+
+func dedupRatio(collected, duplicated int64) float64 {
+	if collected == 0 {
+		return 0
+	}
+	return float64(duplicated) / float64(collected)
+}
@@ -0,0 +1,256 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/robfig/cron/v3"
+)
+
+const defaultRawDataCompactionBatchSize = 10000
+
+var rawDataCompactionRunning atomic.Bool
+
+// RawDataCompactionQuery is the query of the raw data compactions
+type RawDataCompactionQuery struct {
+	Pagination
+}
+
+// rawDataCompactionServiceInit schedules the raw data compaction when RAW_DATA_COMPACTION_CRON is set, a standard
+// cron expression in UTC
+func rawDataCompactionServiceInit() {
+	spec := cfg.GetString("RAW_DATA_COMPACTION_CRON")
+	if spec == "" {
+		return
+	}
+	c := cron.New(cron.WithLocation(time.UTC))
+	_, err := c.AddFunc(spec, func() {
+		if _, err := StartRawDataCompaction(models.RAW_DATA_COMPACTION_TRIGGER_CRON); err != nil {
+			logger.Error(err, "failed to start the raw data compaction")
+		}
+	})
+	if err != nil {
+		logger.Error(err, "invalid RAW_DATA_COMPACTION_CRON %s, the raw data compaction is not scheduled", spec)
+		return
+	}
+	c.Start()
+}
+
+// GetRawDataCompactions returns the raw data compactions, latest first
+func GetRawDataCompactions(query *RawDataCompactionQuery) ([]*models.RawDataCompaction, int64, errors.Error) {
+	count, err := db.Count(dal.From(&models.RawDataCompaction{}))
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of raw data compactions")
+	}
+	compactions := make([]*models.RawDataCompaction, 0)
+	err = db.All(
+		&compactions,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB raw data compactions")
+	}
+	return compactions, count, nil
+}
+
+// GetRawDataCompaction returns the raw data compaction along with its progress
+func GetRawDataCompaction(id uint64) (*models.RawDataCompaction, errors.Error) {
+	compaction := &models.RawDataCompaction{}
+	err := db.First(compaction, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("raw data compaction %d not found", id))
+		}
+		return nil, errors.Default.Wrap(err, "error getting the raw data compaction from DB")
+	}
+	return compaction, nil
+}
+
+// StartRawDataCompaction starts compacting the raw tables in the background, only one compaction runs at a time.
+// A record of a raw table is the payloads collected from a url with an input for a scope (the params), only its
+// latest collection is kept, the payloads of the former ones are deleted and the space of the table reclaimed.
+// The progress is reported by the returned models.RawDataCompaction
+func StartRawDataCompaction(trigger string) (*models.RawDataCompaction, errors.Error) {
+	if !rawDataCompactionRunning.CompareAndSwap(false, true) {
+		return nil, errors.BadInput.New("a raw data compaction is already running")
+	}
+	now := time.Now()
+	compaction := &models.RawDataCompaction{
+		Status:  models.RAW_DATA_COMPACTION_RUNNING,
+		Trigger: trigger,
+		BeganAt: &now,
+	}
+	err := db.Create(compaction)
+	if err != nil {
+		rawDataCompactionRunning.Store(false)
+		return nil, errors.Default.Wrap(err, "error creating the raw data compaction")
+	}
+	progress := *compaction
+	go func() {
+		defer rawDataCompactionRunning.Store(false)
+		err := runRawDataCompaction(&progress)
+		finishedAt := time.Now()
+		progress.FinishedAt = &finishedAt
+		progress.CurrentTable = ""
+		progress.Status = models.RAW_DATA_COMPACTION_DONE
+		if err != nil {
+			logger.Error(err, "raw data compaction #%d failed", progress.ID)
+			progress.Status = models.RAW_DATA_COMPACTION_FAILED
+			progress.Message = err.Error()
+		}
+		if err = db.Update(&progress); err != nil {
+			logger.Error(err, "failed to save the raw data compaction #%d", progress.ID)
+		}
+	}()
+	return compaction, nil
+}
+
+func runRawDataCompaction(compaction *models.RawDataCompaction) errors.Error {
+	allTables, err := db.AllTables()
+	if err != nil {
+		return err
+	}
+	tables := make([]string, 0)
+	for _, table := range allTables {
+		if strings.HasPrefix(table, "_raw_") && !helper.IsRawTablePartitionName(table) {
+			tables = append(tables, table)
+		}
+	}
+	sort.Strings(tables)
+	compaction.TotalTables = len(tables)
+	if err = db.Update(compaction); err != nil {
+		return err
+	}
+	batchSize := cfg.GetInt("RAW_DATA_COMPACTION_BATCH_SIZE")
+	if batchSize <= 0 {
+		batchSize = defaultRawDataCompactionBatchSize
+	}
+	for _, table := range tables {
+		compaction.CurrentTable = table
+		if err = db.Update(compaction); err != nil {
+			return err
+		}
+		if err = compactRawTable(compaction, table, batchSize); err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to compact %s", table))
+		}
+		compaction.CompactedTables++
+	}
+	return nil
+}
+
+// compactRawTable deletes the superseded payloads of the raw table scope by scope, then reclaims the space of the
+// table once any was deleted
+func compactRawTable(compaction *models.RawDataCompaction, table string, batchSize int) errors.Error {
+	withBlobs, err := hasBlobKeyColumn(table)
+	if err != nil {
+		return err
+	}
+	var paramsList []string
+	err = db.Pluck("DISTINCT params", &paramsList, dal.From(table))
+	if err != nil {
+		return err
+	}
+	deletedRows := compaction.DeletedRows
+	for _, params := range paramsList {
+		ids, err := findSupersededRawRows(table, params)
+		if err != nil {
+			return err
+		}
+		for len(ids) > 0 {
+			batch := ids
+			if len(batch) > batchSize {
+				batch = ids[:batchSize]
+			}
+			ids = ids[len(batch):]
+			if err = deleteRawRowsByIds(table, batch, withBlobs); err != nil {
+				return err
+			}
+			compaction.DeletedRows += int64(len(batch))
+			if err = db.Update(compaction); err != nil {
+				return err
+			}
+		}
+	}
+	if compaction.DeletedRows == deletedRows {
+		return nil
+	}
+	return reclaimTableSpace(table)
+}
+
+// findSupersededRawRows returns the rows of the scope collected before the latest collection of their url and input,
+// the payloads of a response are saved at once and share the created_at
+func findSupersededRawRows(table, params string) ([]uint64, errors.Error) {
+	cursor, err := db.Cursor(
+		dal.Select("id, url, input, created_at"),
+		dal.From(table),
+		dal.Where("params = ?", params),
+		dal.Orderby("id DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	latest := make(map[[sha256.Size]byte]time.Time)
+	ids := make([]uint64, 0)
+	for cursor.Next() {
+		row := &helper.RawData{}
+		if err = db.Fetch(cursor, row); err != nil {
+			return nil, err
+		}
+		if isSupersededRawRow(latest, row) {
+			ids = append(ids, row.ID)
+		}
+	}
+	return ids, nil
+}
+
+// isSupersededRawRow tells if a later collection of the url and input of the row was seen already, the rows are
+// expected from the latest to the earliest
+func isSupersededRawRow(latest map[[sha256.Size]byte]time.Time, row *helper.RawData) bool {
+	key := sha256.Sum256([]byte(row.Url + "\x00" + string(row.Input)))
+	latestAt, ok := latest[key]
+	if !ok {
+		latest[key] = row.CreatedAt
+		return false
+	}
+	return row.CreatedAt.Before(latestAt)
+}
+
+// reclaimTableSpace rebuilds the table on MySQL to release the space of the deleted rows, PostgreSQL vacuums them to
+// reuse the space without locking the table as VACUUM FULL would
+func reclaimTableSpace(table string) errors.Error {
+	switch db.Dialect() {
+	case "mysql":
+		return db.Exec("OPTIMIZE TABLE ?", dal.ClauseTable{Name: table})
+	case "postgres":
+		return db.Exec("VACUUM (ANALYZE) ?", dal.ClauseTable{Name: table})
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSupersededRawRow(t *testing.T) {
+	first := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	rows := []*helper.RawData{
+		// the latest collection, two payloads of the same response
+		{ID: 6, Url: "https://api/issues?page=1", Input: []byte("null"), CreatedAt: second},
+		{ID: 5, Url: "https://api/issues?page=1", Input: []byte("null"), CreatedAt: second},
+		{ID: 4, Url: "https://api/comments", Input: []byte(`{"issue":2}`), CreatedAt: second},
+		// the former collection
+		{ID: 3, Url: "https://api/issues?page=1", Input: []byte("null"), CreatedAt: first},
+		{ID: 2, Url: "https://api/comments", Input: []byte(`{"issue":1}`), CreatedAt: first},
+		{ID: 1, Url: "https://api/comments", Input: []byte(`{"issue":2}`), CreatedAt: first},
+	}
+	latest := make(map[[sha256.Size]byte]time.Time)
+	var superseded []uint64
+	for _, row := range rows {
+		if isSupersededRawRow(latest, row) {
+			superseded = append(superseded, row.ID)
+		}
+	}
+	assert.Equal(t, []uint64{3, 1}, superseded)
+}
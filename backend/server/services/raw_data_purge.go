@@ -0,0 +1,329 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/robfig/cron/v3"
+)
+
+const defaultRawDataPurgeCron = "0 3 * * *"
+const defaultRawDataPurgeBatchSize = 10000
+
+var rawDataPurgeRunning atomic.Bool
+
+// RawDataPurgeQuery is the query of the raw data purges
+type RawDataPurgeQuery struct {
+	Pagination
+}
+
+// rawDataPurgeServiceInit schedules the raw data purge, RAW_DATA_PURGE_CRON is a standard cron expression in UTC
+func rawDataPurgeServiceInit() {
+	spec := cfg.GetString("RAW_DATA_PURGE_CRON")
+	if spec == "" {
+		spec = defaultRawDataPurgeCron
+	}
+	c := cron.New(cron.WithLocation(time.UTC))
+	_, err := c.AddFunc(spec, func() {
+		if _, err := StartRawDataPurge(models.RAW_DATA_PURGE_TRIGGER_CRON); err != nil {
+			logger.Error(err, "failed to start the raw data purge")
+		}
+	})
+	if err != nil {
+		logger.Error(err, "invalid RAW_DATA_PURGE_CRON %s, the raw data purge is not scheduled", spec)
+		return
+	}
+	c.Start()
+}
+
+// GetRawDataPurges returns the raw data purges, latest first
+func GetRawDataPurges(query *RawDataPurgeQuery) ([]*models.RawDataPurge, int64, errors.Error) {
+	count, err := db.Count(dal.From(&models.RawDataPurge{}))
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of raw data purges")
+	}
+	purges := make([]*models.RawDataPurge, 0)
+	err = db.All(
+		&purges,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB raw data purges")
+	}
+	return purges, count, nil
+}
+
+// GetRawDataPurge returns the raw data purge along with its progress
+func GetRawDataPurge(id uint64) (*models.RawDataPurge, errors.Error) {
+	purge := &models.RawDataPurge{}
+	err := db.First(purge, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("raw data purge %d not found", id))
+		}
+		return nil, errors.Default.Wrap(err, "error getting the raw data purge from DB")
+	}
+	return purge, nil
+}
+
+// StartRawDataPurge starts deleting the raw data older than the retentionDays of their models.RawDataRetention in
+// the background, only one purge runs at a time. The progress is reported by the returned models.RawDataPurge
+func StartRawDataPurge(trigger string) (*models.RawDataPurge, errors.Error) {
+	if !rawDataPurgeRunning.CompareAndSwap(false, true) {
+		return nil, errors.BadInput.New("a raw data purge is already running")
+	}
+	now := time.Now()
+	purge := &models.RawDataPurge{
+		Status:  models.RAW_DATA_PURGE_RUNNING,
+		Trigger: trigger,
+		BeganAt: &now,
+	}
+	err := db.Create(purge)
+	if err != nil {
+		rawDataPurgeRunning.Store(false)
+		return nil, errors.Default.Wrap(err, "error creating the raw data purge")
+	}
+	progress := *purge
+	go func() {
+		defer rawDataPurgeRunning.Store(false)
+		err := runRawDataPurge(&progress)
+		finishedAt := time.Now()
+		progress.FinishedAt = &finishedAt
+		progress.CurrentTable = ""
+		progress.Status = models.RAW_DATA_PURGE_DONE
+		if err != nil {
+			logger.Error(err, "raw data purge #%d failed", progress.ID)
+			progress.Status = models.RAW_DATA_PURGE_FAILED
+			progress.Message = err.Error()
+		}
+		if err = db.Update(&progress); err != nil {
+			logger.Error(err, "failed to save the raw data purge #%d", progress.ID)
+		}
+	}()
+	return purge, nil
+}
+
+func runRawDataPurge(purge *models.RawDataPurge) errors.Error {
+	retentions := make([]*models.RawDataRetention, 0)
+	err := db.All(&retentions)
+	if err != nil {
+		return err
+	}
+	tables, err := db.AllTables()
+	if err != nil {
+		return err
+	}
+	plans := planRawDataPurge(tables, retentions, pluginNames())
+	purge.TotalTables = len(plans)
+	if err = db.Update(purge); err != nil {
+		return err
+	}
+	batchSize := cfg.GetInt("RAW_DATA_PURGE_BATCH_SIZE")
+	if batchSize <= 0 {
+		batchSize = defaultRawDataPurgeBatchSize
+	}
+	now := time.Now()
+	for _, p := range plans {
+		purge.CurrentTable = p.table
+		if err = db.Update(purge); err != nil {
+			return err
+		}
+		if err = purgeRawTable(purge, p, now, batchSize); err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to purge %s", p.table))
+		}
+		purge.PurgedTables++
+	}
+	return nil
+}
+
+// rawDataPurgePlan tells what to purge from a raw table: the scope retentions apply to their own rows,
+// the plugin retention to the rows of the other scopes
+type rawDataPurgePlan struct {
+	table            string
+	scopeRetentions  []*models.RawDataRetention
+	pluginRetention  *models.RawDataRetention
+	overriddenScopes []string
+}
+
+func planRawDataPurge(tables []string, retentions []*models.RawDataRetention, plugins []string) []*rawDataPurgePlan {
+	retentionsByPlugin := make(map[string][]*models.RawDataRetention)
+	for _, retention := range retentions {
+		retentionsByPlugin[retention.Plugin] = append(retentionsByPlugin[retention.Plugin], retention)
+	}
+	// the longest names first, the raw tables of github_graphql start with the prefix of github
+	sort.Slice(plugins, func(i, j int) bool {
+		return len(plugins[i]) > len(plugins[j])
+	})
+	plans := make([]*rawDataPurgePlan, 0)
+	for _, table := range tables {
+		if !strings.HasPrefix(table, "_raw_") || helper.IsRawTablePartitionName(table) {
+			continue
+		}
+		var pluginName string
+		for _, name := range plugins {
+			if strings.HasPrefix(table, fmt.Sprintf("_raw_%s_", name)) {
+				pluginName = name
+				break
+			}
+		}
+		plan := &rawDataPurgePlan{table: table}
+		for _, retention := range retentionsByPlugin[pluginName] {
+			if retention.RawDataParams == "" {
+				if retention.RetentionDays > 0 {
+					plan.pluginRetention = retention
+				}
+				continue
+			}
+			// a scope retention overrides the plugin one even when it keeps the raw data forever
+			plan.overriddenScopes = append(plan.overriddenScopes, retention.RawDataParams)
+			if retention.RetentionDays > 0 {
+				plan.scopeRetentions = append(plan.scopeRetentions, retention)
+			}
+		}
+		if plan.pluginRetention != nil || len(plan.scopeRetentions) > 0 {
+			plans = append(plans, plan)
+		}
+	}
+	return plans
+}
+
+func purgeRawTable(purge *models.RawDataPurge, p *rawDataPurgePlan, now time.Time, batchSize int) errors.Error {
+	for _, retention := range p.scopeRetentions {
+		cutoff := now.AddDate(0, 0, -retention.RetentionDays)
+		err := deleteRawRows(purge, p.table, batchSize, dal.Where("params = ? AND created_at < ?", retention.RawDataParams, cutoff))
+		if err != nil {
+			return err
+		}
+	}
+	if p.pluginRetention == nil {
+		return nil
+	}
+	cutoff := now.AddDate(0, 0, -p.pluginRetention.RetentionDays)
+	if len(p.overriddenScopes) == 0 {
+		// the whole months past the retention go away with their partitions, without scanning them
+		dropped, err := helper.DropRawTablePartitionsBefore(db, p.table, cutoff)
+		if err != nil {
+			return err
+		}
+		purge.DroppedPartitions += dropped
+		return deleteRawRows(purge, p.table, batchSize, dal.Where("created_at < ?", cutoff))
+	}
+	return deleteRawRows(purge, p.table, batchSize, dal.Where("created_at < ? AND params NOT IN ?", cutoff, p.overriddenScopes))
+}
+
+// deleteRawRows deletes the matched rows by batches to keep the transactions small
+func deleteRawRows(purge *models.RawDataPurge, table string, batchSize int, where dal.Clause) errors.Error {
+	withBlobs, err := hasBlobKeyColumn(table)
+	if err != nil {
+		return err
+	}
+	for {
+		var ids []uint64
+		err = db.Pluck("id", &ids, dal.From(table), where, dal.Limit(batchSize))
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err = deleteRawRowsByIds(table, ids, withBlobs); err != nil {
+			return err
+		}
+		purge.DeletedRows += int64(len(ids))
+		if err = db.Update(purge); err != nil {
+			return err
+		}
+		if len(ids) < batchSize {
+			return nil
+		}
+	}
+}
+
+// hasBlobKeyColumn tells if the raw table may refer to payloads in the blob store, the tables not collected into
+// since the blob store was introduced have no blob_key column
+func hasBlobKeyColumn(table string) (bool, errors.Error) {
+	columns, err := dal.GetColumnNames(db, &dal.DefaultTabler{Name: table}, func(cm dal.ColumnMeta) bool {
+		return cm.Name() == "blob_key"
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(columns) > 0, nil
+}
+
+// deleteRawRowsByIds deletes the rows of the raw table along with their payloads in the blob store, unless other rows
+// refer to them
+func deleteRawRowsByIds(table string, ids []uint64, withBlobs bool) errors.Error {
+	var blobKeys []string
+	if withBlobs {
+		err := db.Pluck("DISTINCT blob_key", &blobKeys, dal.From(table), dal.Where("id IN ? AND blob_key <> ''", ids))
+		if err != nil {
+			return err
+		}
+	}
+	err := db.Delete(&helper.RawData{}, dal.From(table), dal.Where("id IN ?", ids))
+	if err != nil {
+		return err
+	}
+	return deleteUnreferencedBlobs(table, blobKeys)
+}
+
+// deleteUnreferencedBlobs deletes the payloads of the blob store no longer referred to by the raw table, the payloads
+// are shared by the rows of the same content
+func deleteUnreferencedBlobs(table string, blobKeys []string) errors.Error {
+	if len(blobKeys) == 0 {
+		return nil
+	}
+	var referenced []string
+	err := db.Pluck("DISTINCT blob_key", &referenced, dal.From(table), dal.Where("blob_key IN ?", blobKeys))
+	if err != nil {
+		return err
+	}
+	isReferenced := make(map[string]bool, len(referenced))
+	for _, key := range referenced {
+		isReferenced[key] = true
+	}
+	unreferenced := make([]string, 0, len(blobKeys))
+	for _, key := range blobKeys {
+		if !isReferenced[key] {
+			unreferenced = append(unreferenced, key)
+		}
+	}
+	return helper.DeleteRawDataBlobs(context.Background(), unreferenced)
+}
+
+func pluginNames() []string {
+	names := make([]string, 0)
+	for name := range plugin.AllPlugins() {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanRawDataPurge(t *testing.T) {
+	githubPlugin := &models.RawDataRetention{Plugin: "github", RetentionDays: 30}
+	githubScope := &models.RawDataRetention{Plugin: "github", RawDataParams: `{"ConnectionId":1,"Name":"a/b"}`, RetentionDays: 7}
+	githubKept := &models.RawDataRetention{Plugin: "github", RawDataParams: `{"ConnectionId":1,"Name":"a/c"}`, KeepRawData: true}
+	jiraKept := &models.RawDataRetention{Plugin: "jira", RawDataParams: `{"ConnectionId":1,"BoardId":1}`, KeepRawData: true}
+	plans := planRawDataPurge(
+		[]string{
+			"_raw_github_api_issues",
+			"_raw_github_api_issues_p202307",
+			"_raw_github_graphql_issues",
+			"_raw_jira_api_issues",
+			"_tool_github_issues",
+		},
+		[]*models.RawDataRetention{githubPlugin, githubScope, githubKept, jiraKept},
+		[]string{"github", "github_graphql", "jira"},
+	)
+	assert.Len(t, plans, 1)
+	assert.Equal(t, "_raw_github_api_issues", plans[0].table)
+	assert.Equal(t, githubPlugin, plans[0].pluginRetention)
+	assert.Equal(t, []*models.RawDataRetention{githubScope}, plans[0].scopeRetentions)
+	assert.Equal(t, []string{githubScope.RawDataParams, githubKept.RawDataParams}, plans[0].overriddenScopes)
+}
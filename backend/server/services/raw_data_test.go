@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockColumns(names ...string) []dal.ColumnMeta {
+	columns := make([]dal.ColumnMeta, 0, len(names))
+	for _, name := range names {
+		column := new(mockdal.ColumnMeta)
+		column.On("Name").Return(name)
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func TestGetDomainRowRawData(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	formerDb := db
+	db = mockDal
+	defer func() { db = formerDb }()
+
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return(mockColumns("id", "url", "_raw_data_table"), nil)
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]common.RawDataOrigin) = []common.RawDataOrigin{
+			{RawDataTable: "_raw_github_api_issues", RawDataId: 1, RawDataParams: `{"ConnectionId":1}`},
+			{RawDataTable: "_raw_github_api_issues", RawDataId: 2, RawDataParams: `{"ConnectionId":1}`},
+			// saved by an enricher
+			{RawDataTable: "", RawDataParams: `{"ConnectionId":1}`},
+		}
+	}).Return(nil).Once()
+	mockDal.On("HasTable", "_raw_github_api_issues").Return(true)
+	mockDal.On("First", mock.Anything, []dal.Clause{dal.From("_raw_github_api_issues"), dal.Where("id = ?", uint64(1))}).Run(func(args mock.Arguments) {
+		row := args.Get(0).(*helper.RawData)
+		row.ID = 1
+		row.Url = "https://api.github.com/repos/apache/incubator-devlake/issues/1"
+		row.Data = []byte(`{"number":1}`)
+	}).Return(nil).Once()
+	// the payload was dropped once extracted
+	notFound := errors.NotFound.New("record not found")
+	mockDal.On("First", mock.Anything, []dal.Clause{dal.From("_raw_github_api_issues"), dal.Where("id = ?", uint64(2))}).Return(notFound).Once()
+	mockDal.On("IsErrorNotFound", notFound).Return(true)
+
+	rawData, err := GetDomainRowRawData("issues", map[string]string{"url": "https://github.com/apache/incubator-devlake/issues/1"})
+	assert.Nil(t, err)
+	assert.Len(t, rawData, 2)
+	assert.True(t, rawData[0].Retained)
+	assert.Equal(t, "https://api.github.com/repos/apache/incubator-devlake/issues/1", rawData[0].Url)
+	assert.JSONEq(t, `{"number":1}`, string(rawData[0].Data))
+	assert.False(t, rawData[1].Retained)
+	assert.Equal(t, uint64(2), rawData[1].RawDataId)
+	assert.Nil(t, rawData[1].Data)
+	mockDal.AssertExpectations(t)
+}
+
+func TestGetDomainRowRawDataInvalidRows(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	formerDb := db
+	db = mockDal
+	defer func() { db = formerDb }()
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return(mockColumns("id", "url"), nil)
+
+	_, err := GetDomainRowRawData("_raw_github_api_issues", map[string]string{"id": "1"})
+	assert.Equal(t, errors.BadInput, err.GetType())
+	_, err = GetDomainRowRawData("issues", map[string]string{})
+	assert.Equal(t, errors.BadInput, err.GetType())
+	_, err = GetDomainRowRawData("issues", map[string]string{"title": "x"})
+	assert.Equal(t, errors.BadInput, err.GetType())
+	mockDal.AssertNotCalled(t, "All", mock.Anything, mock.Anything)
+}
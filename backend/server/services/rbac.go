@@ -0,0 +1,717 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// the ranks of the project roles, a role grants everything the lower ones do
+var projectRoleRanks = map[string]int{
+	models.PROJECT_ROLE_VIEWER:     1,
+	models.PROJECT_ROLE_MAINTAINER: 2,
+	models.PROJECT_ROLE_ADMIN:      3,
+}
+
+// the routes granted to every user, the handlers restrict them to the projects of the user
+var rbacUserRoutes = map[string]bool{
+	"GET /projects":         true,
+	"POST /projects":        true,
+	"GET /blueprints":       true,
+	"POST /blueprints":      true,
+	"GET /pipelines":        true,
+	"GET /activity":         true,
+	"GET /users":            true,
+	"GET /role-bindings":    true,
+	"PUT /role-bindings":    true,
+	"DELETE /role-bindings": true,
+	"GET /plugininfo":       true,
+	"GET /plugins":          true,
+	"GET /swagger/*any":     true,
+}
+
+// the project of AUTH_GROUP_ROLES standing for all of them, only the admin role may be granted on it
+const rbacAllProjects = "*"
+
+// the groups each user was last synced with, so the bindings are only rewritten when they change
+var groupRoleSyncs sync.Map
+
+// the tokens each user was last synced from by name, the requests carrying them only read the user
+var userSyncs sync.Map
+
+type userSync struct {
+	issuedAt int64
+	// groupAdmin tells the groups of the token grant everything, which isn't saved along with the user
+	groupAdmin bool
+}
+
+// UserQuery used to query users as the api input
+type UserQuery struct {
+	Pagination
+}
+
+// ProjectRoleBindingQuery used to query role bindings as the api input
+type ProjectRoleBindingQuery struct {
+	Pagination
+	ProjectName string `form:"projectName"`
+	UserId      uint64 `form:"userId"`
+}
+
+// GetUsers returns a paginated list of the users
+func GetUsers(query *UserQuery) ([]*models.User, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.User{}),
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of users")
+	}
+	clauses = append(clauses,
+		dal.Orderby("name"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	users := make([]*models.User, 0)
+	err = db.All(&users, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB users")
+	}
+	return users, count, nil
+}
+
+// GetUser returns the user of the given id
+func GetUser(id uint64) (*models.User, errors.Error) {
+	user := &models.User{}
+	err := db.First(user, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("user not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the user from DB")
+	}
+	return user, nil
+}
+
+// CreateUser registers a user, the users authenticated by the auth provider are registered at their sign-in
+func CreateUser(input *models.ApiInputUser) (*models.User, errors.Error) {
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	count, err := db.Count(dal.From(&models.User{}), dal.Where("name = ?", input.Name))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error checking the name of the user")
+	}
+	if count > 0 {
+		return nil, errors.BadInput.New("the user " + input.Name + " exists already")
+	}
+	user := &models.User{
+		Name:    input.Name,
+		Email:   input.Email,
+		IsAdmin: input.IsAdmin,
+	}
+	err = db.Create(user)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error creating DB user")
+	}
+	return user, nil
+}
+
+// DeleteUser removes a user along with its role bindings, and revokes the api keys acting on its behalf
+func DeleteUser(id uint64) (*models.User, errors.Error) {
+	user, err := GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Delete(&models.ProjectRoleBinding{}, dal.Where("user_id = ?", id))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting the role bindings of the user")
+	}
+	err = db.UpdateColumn(&models.ApiKey{}, "revoked_at", time.Now(), dal.Where("user_id = ? AND revoked_at IS NULL", id))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error revoking the api keys of the user")
+	}
+	err = db.Delete(user)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting DB user")
+	}
+	groupRoleSyncs.Delete(id)
+	userSyncs.Delete(user.Name)
+	return user, nil
+}
+
+// EnsureUser returns the user of the given name, registering it if needed, the email is only recorded when missing
+func EnsureUser(name string, email string) (*models.User, errors.Error) {
+	user := &models.User{}
+	err := db.First(user, dal.Where("name = ?", name))
+	if err == nil {
+		if user.Email == "" && email != "" {
+			user.Email = email
+			err = db.Update(user)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, "error recording the email of the user")
+			}
+		}
+		return user, nil
+	}
+	if !db.IsErrorNotFound(err) {
+		return nil, errors.Default.Wrap(err, "error getting the user from DB")
+	}
+	user.Name = name
+	user.Email = email
+	err = db.Create(user)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error creating DB user")
+	}
+	return user, nil
+}
+
+// SyncUser registers the user a token was issued to and grants it the roles of its groups, it is called at the
+// sign-in and whenever a request carries a token issued after the one the user was last synced from
+func SyncUser(name string, email string, groups []string, issuedAt int64) (*models.User, errors.Error) {
+	user, err := EnsureUser(name, email)
+	if err != nil {
+		return nil, err
+	}
+	savedAdmin := user.IsAdmin
+	err = ApplyGroupRoles(user, groups)
+	if err != nil {
+		return nil, err
+	}
+	userSyncs.Store(name, &userSync{issuedAt: issuedAt, groupAdmin: user.IsAdmin && !savedAdmin})
+	return user, nil
+}
+
+// GetTokenUser returns the user a token was issued to, the user is only synced again by SyncUser when the token
+// was issued after the one it was last synced from
+func GetTokenUser(name string, email string, groups []string, issuedAt int64) (*models.User, errors.Error) {
+	v, ok := userSyncs.Load(name)
+	if !ok || issuedAt > v.(*userSync).issuedAt {
+		return SyncUser(name, email, groups, issuedAt)
+	}
+	user := &models.User{}
+	err := db.First(user, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return SyncUser(name, email, groups, issuedAt)
+		}
+		return nil, errors.Default.Wrap(err, "error getting the user from DB")
+	}
+	if v.(*userSync).groupAdmin {
+		user.IsAdmin = true
+	}
+	return user, nil
+}
+
+// IsAdminUser tells whether the user is granted everything, either by its flag or by RBAC_ADMINS
+func IsAdminUser(user *models.User) bool {
+	if user.IsAdmin {
+		return true
+	}
+	for _, name := range strings.Split(cfg.GetString("RBAC_ADMINS"), ",") {
+		if strings.TrimSpace(name) == user.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseGroupRoles reads AUTH_GROUP_ROLES, i.e. `{"lake-admins": {"*": "admin"}, "team-a": {"project-a": "maintainer"}}`,
+// which maps the groups of the auth provider to the roles they grant on the projects
+func ParseGroupRoles(value string) (map[string]map[string]string, errors.Error) {
+	groupRoles := make(map[string]map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return groupRoles, nil
+	}
+	err := json.Unmarshal([]byte(value), &groupRoles)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "AUTH_GROUP_ROLES is not a map of the groups to the roles on the projects")
+	}
+	for group, projectRoles := range groupRoles {
+		for projectName, role := range projectRoles {
+			if projectRoleRanks[role] == 0 {
+				return nil, errors.BadInput.New("unknown role " + role + " granted to the group " + group)
+			}
+			if projectName == rbacAllProjects && role != models.PROJECT_ROLE_ADMIN {
+				return nil, errors.BadInput.New("only the admin role may be granted on all the projects, to the group " + group)
+			}
+		}
+	}
+	return groupRoles, nil
+}
+
+// GroupProjectRoles returns the highest role the groups grant on each project, and whether they grant everything
+func GroupProjectRoles(groupRoles map[string]map[string]string, groups []string) (map[string]string, bool) {
+	roles := make(map[string]string)
+	for _, group := range groups {
+		for projectName, role := range groupRoles[group] {
+			if projectRoleRanks[role] > projectRoleRanks[roles[projectName]] {
+				roles[projectName] = role
+			}
+		}
+	}
+	isAdmin := roles[rbacAllProjects] == models.PROJECT_ROLE_ADMIN
+	delete(roles, rbacAllProjects)
+	return roles, isAdmin
+}
+
+// ApplyGroupRoles grants the user the roles its groups are mapped to by AUTH_GROUP_ROLES. The admins by group are
+// only flagged on the given user, and the group bindings replace the ones of the previous groups, the bindings
+// granted through the api taking precedence over them.
+func ApplyGroupRoles(user *models.User, groups []string) errors.Error {
+	groupRoles, err := ParseGroupRoles(cfg.GetString("AUTH_GROUP_ROLES"))
+	if err != nil {
+		return err
+	}
+	roles, isAdmin := GroupProjectRoles(groupRoles, groups)
+	if isAdmin {
+		user.IsAdmin = true
+	}
+	sorted := append([]string(nil), groups...)
+	sort.Strings(sorted)
+	signature := strings.Join(sorted, "\n")
+	if synced, ok := groupRoleSyncs.Load(user.ID); ok && synced.(string) == signature {
+		return nil
+	}
+	err = syncGroupRoleBindings(user, roles)
+	if err != nil {
+		return err
+	}
+	groupRoleSyncs.Store(user.ID, signature)
+	return nil
+}
+
+func syncGroupRoleBindings(user *models.User, roles map[string]string) errors.Error {
+	manual := make([]*models.ProjectRoleBinding, 0)
+	err := db.All(&manual, dal.Where("user_id = ? AND source = ?", user.ID, models.ROLE_BINDING_SOURCE_MANUAL))
+	if err != nil {
+		return errors.Default.Wrap(err, "error finding the role bindings of the user")
+	}
+	for _, binding := range manual {
+		delete(roles, binding.ProjectName)
+	}
+	err = db.Delete(&models.ProjectRoleBinding{}, dal.Where("user_id = ? AND source = ?", user.ID, models.ROLE_BINDING_SOURCE_GROUP))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting the group role bindings of the user")
+	}
+	for projectName, role := range roles {
+		err = db.Create(&models.ProjectRoleBinding{
+			ProjectName: projectName,
+			UserId:      user.ID,
+			Role:        role,
+			Source:      models.ROLE_BINDING_SOURCE_GROUP,
+		})
+		if err != nil {
+			return errors.Default.Wrap(err, "error saving the group role binding")
+		}
+	}
+	return nil
+}
+
+// GetProjectRoleBindings returns a paginated list of the role bindings
+func GetProjectRoleBindings(query *ProjectRoleBindingQuery) ([]*models.ProjectRoleBinding, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.ProjectRoleBinding{}),
+	}
+	if query.ProjectName != "" {
+		clauses = append(clauses, dal.Where("project_name = ?", query.ProjectName))
+	}
+	if query.UserId != 0 {
+		clauses = append(clauses, dal.Where("user_id = ?", query.UserId))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of role bindings")
+	}
+	clauses = append(clauses,
+		dal.Orderby("project_name, user_id"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	bindings := make([]*models.ProjectRoleBinding, 0)
+	err = db.All(&bindings, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB role bindings")
+	}
+	return bindings, count, nil
+}
+
+// PutProjectRoleBinding grants the role to the user on the project, replacing the role it had
+func PutProjectRoleBinding(binding *models.ProjectRoleBinding) errors.Error {
+	if err := VerifyStruct(binding); err != nil {
+		return err
+	}
+	if _, err := GetProject(binding.ProjectName); err != nil {
+		return err
+	}
+	if _, err := GetUser(binding.UserId); err != nil {
+		return err
+	}
+	binding.Source = models.ROLE_BINDING_SOURCE_MANUAL
+	err := db.CreateOrUpdate(binding)
+	if err != nil {
+		return errors.Default.Wrap(err, "error saving the role binding")
+	}
+	return nil
+}
+
+// DeleteProjectRoleBinding revokes the role of the user on the project
+func DeleteProjectRoleBinding(projectName string, userId uint64) errors.Error {
+	err := db.Delete(&models.ProjectRoleBinding{}, dal.Where("project_name = ? AND user_id = ?", projectName, userId))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting the role binding")
+	}
+	return nil
+}
+
+// BindProjectCreator makes the user who created the project its admin
+func BindProjectCreator(user *models.User, projectName string) errors.Error {
+	if user == nil {
+		return nil
+	}
+	err := db.CreateOrUpdate(&models.ProjectRoleBinding{
+		ProjectName: projectName,
+		UserId:      user.ID,
+		Role:        models.PROJECT_ROLE_ADMIN,
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error binding the creator of the project")
+	}
+	return nil
+}
+
+// RecordConnectionOwner records the user as the owner of the connection, so it keeps seeing the connection before
+// any blueprint uses it
+func RecordConnectionOwner(user *models.User, pluginName string, connection interface{}) errors.Error {
+	if user == nil {
+		return nil
+	}
+	id, ok := connectionIdOf(connection)
+	if !ok {
+		return nil
+	}
+	err := db.CreateOrUpdate(&models.ConnectionOwner{
+		Plugin:       pluginName,
+		ConnectionId: id,
+		UserId:       user.ID,
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error recording the owner of the connection")
+	}
+	return nil
+}
+
+// GetProjectRole returns the role of the user on the project, an empty string if it has none
+func GetProjectRole(user *models.User, projectName string) (string, errors.Error) {
+	if IsAdminUser(user) {
+		return models.PROJECT_ROLE_ADMIN, nil
+	}
+	if projectName == "" {
+		return "", nil
+	}
+	binding := &models.ProjectRoleBinding{}
+	err := db.First(binding, dal.Where("project_name = ? AND user_id = ?", projectName, user.ID))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Default.Wrap(err, "error getting the role binding from DB")
+	}
+	return binding.Role, nil
+}
+
+// CheckProjectRole fails unless the user is granted the role on the project, it always succeeds without any user
+func CheckProjectRole(user *models.User, projectName string, role string) errors.Error {
+	if user == nil {
+		return nil
+	}
+	granted, err := GetProjectRole(user, projectName)
+	if err != nil {
+		return err
+	}
+	if !ProjectRoleGrants(granted, role) {
+		return errors.Forbidden.New("the user " + user.Name + " is not " + role + " of the project " + projectName)
+	}
+	return nil
+}
+
+// ProjectRoleGrants tells whether the granted role includes the required one
+func ProjectRoleGrants(granted string, required string) bool {
+	return projectRoleRanks[granted] > 0 && projectRoleRanks[granted] >= projectRoleRanks[required]
+}
+
+// CheckBlueprintRoles fails unless the user is maintainer of the project of the blueprint and of the connections
+// it uses, so a blueprint cannot be used to reach the data of another team
+func CheckBlueprintRoles(user *models.User, blueprint *models.Blueprint) errors.Error {
+	if user == nil || IsAdminUser(user) {
+		return nil
+	}
+	err := CheckProjectRole(user, blueprint.ProjectName, models.PROJECT_ROLE_MAINTAINER)
+	if err != nil {
+		return err
+	}
+	if blueprint.Settings == nil {
+		return nil
+	}
+	connections, err := blueprint.GetConnections()
+	if err != nil {
+		return err
+	}
+	for _, connection := range connections {
+		err = checkConnectionRole(user, connection.Plugin, connection.ConnectionId, models.PROJECT_ROLE_MAINTAINER)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckBlueprintPatch checks the roles of the user on the blueprint as it would be once patched
+func CheckBlueprintPatch(user *models.User, id uint64, body map[string]interface{}) errors.Error {
+	if user == nil || IsAdminUser(user) {
+		return nil
+	}
+	blueprint, err := GetBlueprint(id)
+	if err != nil {
+		return err
+	}
+	err = helper.DecodeMapStruct(body, blueprint, true)
+	if err != nil {
+		return err
+	}
+	return CheckBlueprintRoles(user, blueprint)
+}
+
+// VisibleProjectNames returns the projects the user is granted any role on, nil when it sees all of them
+func VisibleProjectNames(user *models.User) ([]string, errors.Error) {
+	if user == nil || IsAdminUser(user) {
+		return nil, nil
+	}
+	bindings := make([]*models.ProjectRoleBinding, 0)
+	err := db.All(&bindings, dal.Where("user_id = ?", user.ID))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding the role bindings of the user")
+	}
+	names := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		names = append(names, binding.ProjectName)
+	}
+	return names, nil
+}
+
+// FilterConnections keeps the connections of the list the user is granted any role on
+func FilterConnections(user *models.User, pluginName string, connections interface{}) (interface{}, errors.Error) {
+	if user == nil || IsAdminUser(user) {
+		return connections, nil
+	}
+	list := reflect.ValueOf(connections)
+	if list.Kind() != reflect.Slice {
+		return connections, nil
+	}
+	visible := reflect.MakeSlice(list.Type(), 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		id, ok := connectionIdOf(list.Index(i).Interface())
+		if !ok {
+			continue
+		}
+		role, err := getConnectionRole(user, pluginName, id)
+		if err != nil {
+			return nil, err
+		}
+		if role != "" {
+			visible = reflect.Append(visible, list.Index(i))
+		}
+	}
+	return visible.Interface(), nil
+}
+
+// AuthorizeRequest checks the user is granted the route, i.e. `/blueprints/:blueprintId`, along with its path params.
+// The routes out of any project are reserved to the admins, it always succeeds without any user.
+func AuthorizeRequest(user *models.User, method string, route string, params map[string]string) errors.Error {
+	if user == nil || rbacUserRoutes[method+" "+route] || IsAdminUser(user) {
+		return nil
+	}
+	role := requiredProjectRole(method)
+	switch {
+	case strings.HasPrefix(route, "/projects/"):
+		return CheckProjectRole(user, strings.TrimPrefix(params["projectName"], "/"), role)
+	case strings.HasPrefix(route, "/blueprints/:blueprintId"):
+		id, err := parseRbacId(params["blueprintId"])
+		if err != nil {
+			return err
+		}
+		return checkBlueprintRole(user, id, role)
+	case strings.HasPrefix(route, "/pipelines/:pipelineId"):
+		id, err := parseRbacId(params["pipelineId"])
+		if err != nil {
+			return err
+		}
+		return checkPipelineRole(user, id, role)
+	case strings.HasPrefix(route, "/tasks/:taskId"):
+		id, err := parseRbacId(params["taskId"])
+		if err != nil {
+			return err
+		}
+		task, err := GetTask(id)
+		if err != nil {
+			return err
+		}
+		return checkPipelineRole(user, task.PipelineId, role)
+	case route == "/data-exports/queries/:query":
+		// the project of the query is checked by the handler
+		return nil
+	case strings.HasPrefix(route, "/plugins/"):
+		pluginName := strings.SplitN(strings.TrimPrefix(route, "/plugins/"), "/", 2)[0]
+		if connectionId, ok := params["connectionId"]; ok {
+			id, err := parseRbacId(connectionId)
+			if err != nil {
+				return err
+			}
+			return checkConnectionRole(user, pluginName, id, role)
+		}
+		// creating and testing connections are open to every user, listing them is filtered by the router
+		if method == http.MethodGet || strings.HasSuffix(route, "/connections") || strings.HasSuffix(route, "/test") {
+			return nil
+		}
+	}
+	return errors.Forbidden.New("only the admins are granted " + method + " " + route)
+}
+
+func requiredProjectRole(method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return models.PROJECT_ROLE_VIEWER
+	}
+	return models.PROJECT_ROLE_MAINTAINER
+}
+
+func parseRbacId(param string) (uint64, errors.Error) {
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "invalid id "+param)
+	}
+	return id, nil
+}
+
+func checkBlueprintRole(user *models.User, blueprintId uint64, role string) errors.Error {
+	blueprint, err := GetBlueprint(blueprintId)
+	if err != nil {
+		return err
+	}
+	if blueprint.ProjectName == "" {
+		return errors.Forbidden.New("only the admins are granted the blueprints out of any project")
+	}
+	return CheckProjectRole(user, blueprint.ProjectName, role)
+}
+
+func checkPipelineRole(user *models.User, pipelineId uint64, role string) errors.Error {
+	pipeline, err := GetPipeline(pipelineId)
+	if err != nil {
+		return err
+	}
+	if pipeline.BlueprintId == 0 {
+		return errors.Forbidden.New("only the admins are granted the pipelines out of any blueprint")
+	}
+	return checkBlueprintRole(user, pipeline.BlueprintId, role)
+}
+
+func checkConnectionRole(user *models.User, pluginName string, connectionId uint64, role string) errors.Error {
+	granted, err := getConnectionRole(user, pluginName, connectionId)
+	if err != nil {
+		return err
+	}
+	if !ProjectRoleGrants(granted, role) {
+		return errors.Forbidden.New("the user " + user.Name + " is not " + role + " of any project using the connection")
+	}
+	return nil
+}
+
+// getConnectionRole returns admin for the owner of the connection, or else the highest role of the user on the
+// projects whose blueprints use the connection
+func getConnectionRole(user *models.User, pluginName string, connectionId uint64) (string, errors.Error) {
+	owner := &models.ConnectionOwner{}
+	err := db.First(owner, dal.Where("plugin = ? AND connection_id = ?", pluginName, connectionId))
+	if err == nil && owner.UserId == user.ID {
+		return models.PROJECT_ROLE_ADMIN, nil
+	}
+	if err != nil && !db.IsErrorNotFound(err) {
+		return "", errors.Default.Wrap(err, "error getting the owner of the connection from DB")
+	}
+	names, err := VisibleProjectNames(user)
+	if err != nil || len(names) == 0 {
+		return "", err
+	}
+	blueprints := make([]*models.Blueprint, 0)
+	err = db.All(&blueprints, dal.Where("project_name IN ?", names))
+	if err != nil {
+		return "", errors.Default.Wrap(err, "error finding the blueprints of the user")
+	}
+	granted := ""
+	for _, blueprint := range blueprints {
+		if blueprint.Settings == nil {
+			continue
+		}
+		connections, err := blueprint.GetConnections()
+		if err != nil {
+			logger.Warn(err, "failed to read the connections of blueprint #%d", blueprint.ID)
+			continue
+		}
+		for _, connection := range connections {
+			if connection.Plugin != pluginName || connection.ConnectionId != connectionId {
+				continue
+			}
+			role, err := GetProjectRole(user, blueprint.ProjectName)
+			if err != nil {
+				return "", err
+			}
+			if projectRoleRanks[role] > projectRoleRanks[granted] {
+				granted = role
+			}
+		}
+	}
+	return granted, nil
+}
+
+// connectionIdOf reads the ID field of a connection
+func connectionIdOf(connection interface{}) (uint64, bool) {
+	v := reflect.ValueOf(connection)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+	id := v.FieldByName("ID")
+	if !id.IsValid() || id.Kind() != reflect.Uint64 {
+		return 0, false
+	}
+	return id.Uint(), true
+}
@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProjectRoleGrants(t *testing.T) {
+	assert.True(t, ProjectRoleGrants(models.PROJECT_ROLE_ADMIN, models.PROJECT_ROLE_MAINTAINER))
+	assert.True(t, ProjectRoleGrants(models.PROJECT_ROLE_MAINTAINER, models.PROJECT_ROLE_MAINTAINER))
+	assert.True(t, ProjectRoleGrants(models.PROJECT_ROLE_MAINTAINER, models.PROJECT_ROLE_VIEWER))
+	assert.False(t, ProjectRoleGrants(models.PROJECT_ROLE_VIEWER, models.PROJECT_ROLE_MAINTAINER))
+	assert.False(t, ProjectRoleGrants(models.PROJECT_ROLE_MAINTAINER, models.PROJECT_ROLE_ADMIN))
+	assert.False(t, ProjectRoleGrants("", models.PROJECT_ROLE_VIEWER))
+}
+
+func TestAuthorizeRequestWithoutProject(t *testing.T) {
+	v := config.GetConfig()
+	v.Set("RBAC_ADMINS", "root, ops")
+	defer v.Set("RBAC_ADMINS", "")
+	cfg = v
+	user := &models.User{Name: "jane"}
+
+	// the roles do not apply without any user, nor to the admins
+	assert.Nil(t, AuthorizeRequest(nil, "POST", "/push/:tableName", nil))
+	assert.Nil(t, AuthorizeRequest(&models.User{Name: "ops"}, "POST", "/push/:tableName", nil))
+	assert.Nil(t, AuthorizeRequest(&models.User{IsAdmin: true}, "DELETE", "/api-keys/:apiKeyId", nil))
+
+	assert.Nil(t, AuthorizeRequest(user, "GET", "/projects", nil))
+	assert.Nil(t, AuthorizeRequest(user, "POST", "/blueprints", nil))
+	assert.Nil(t, AuthorizeRequest(user, "PUT", "/role-bindings", nil))
+	assert.Nil(t, AuthorizeRequest(user, "POST", "/plugins/github/connections", nil))
+	assert.Nil(t, AuthorizeRequest(user, "POST", "/plugins/github/test", nil))
+	assert.NotNil(t, AuthorizeRequest(user, "POST", "/push/:tableName", nil))
+	assert.NotNil(t, AuthorizeRequest(user, "POST", "/pipelines", nil))
+	assert.NotNil(t, AuthorizeRequest(user, "GET", "/api-keys", nil))
+	assert.NotNil(t, AuthorizeRequest(user, "POST", "/users", nil))
+	assert.NotNil(t, AuthorizeRequest(user, "POST", "/graphql", nil))
+	assert.NotNil(t, AuthorizeRequest(user, "GET", "/blueprints/:blueprintId", map[string]string{"blueprintId": "x"}))
+}
+
+func TestConnectionIdOf(t *testing.T) {
+	type connection struct {
+		common.Model
+		Name string
+	}
+	id, ok := connectionIdOf(&connection{Model: common.Model{ID: 3}})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), id)
+	id, ok = connectionIdOf(connection{Model: common.Model{ID: 4}})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), id)
+	_, ok = connectionIdOf(map[string]interface{}{"id": 5})
+	assert.False(t, ok)
+	_, ok = connectionIdOf((*connection)(nil))
+	assert.False(t, ok)
+}
+
+func TestGroupProjectRoles(t *testing.T) {
+	groupRoles, err := ParseGroupRoles(`{
+		"lake-admins": {"*": "admin"},
+		"team-a": {"project-a": "maintainer", "project-b": "viewer"},
+		"team-b": {"project-b": "maintainer"}
+	}`)
+	assert.Nil(t, err)
+
+	roles, isAdmin := GroupProjectRoles(groupRoles, []string{"team-a", "team-b", "unknown"})
+	assert.False(t, isAdmin)
+	assert.Equal(t, map[string]string{
+		"project-a": models.PROJECT_ROLE_MAINTAINER,
+		"project-b": models.PROJECT_ROLE_MAINTAINER,
+	}, roles)
+
+	roles, isAdmin = GroupProjectRoles(groupRoles, []string{"lake-admins"})
+	assert.True(t, isAdmin)
+	assert.Empty(t, roles)
+
+	roles, isAdmin = GroupProjectRoles(groupRoles, nil)
+	assert.False(t, isAdmin)
+	assert.Empty(t, roles)
+}
+
+func TestParseGroupRoles(t *testing.T) {
+	groupRoles, err := ParseGroupRoles(" ")
+	assert.Nil(t, err)
+	assert.Empty(t, groupRoles)
+	_, err = ParseGroupRoles(`{"team-a": "admin"}`)
+	assert.NotNil(t, err)
+	_, err = ParseGroupRoles(`{"team-a": {"project-a": "owner"}}`)
+	assert.NotNil(t, err)
+	_, err = ParseGroupRoles(`{"team-a": {"*": "viewer"}}`)
+	assert.NotNil(t, err)
+}
+
+func TestGetTokenUserSyncsOncePerToken(t *testing.T) {
+	v := config.GetConfig()
+	v.Set("AUTH_GROUP_ROLES", `{"lake-admins": {"*": "admin"}}`)
+	defer v.Set("AUTH_GROUP_ROLES", "")
+	cfg = v
+	mockDal := new(mockdal.Dal)
+	formerDb := db
+	db = mockDal
+	defer func() { db = formerDb }()
+	defer userSyncs.Delete("jane")
+
+	mockDal.On("First", mock.AnythingOfType("*models.User"), []dal.Clause{dal.Where("name = ?", "jane")}).Run(func(args mock.Arguments) {
+		*args.Get(0).(*models.User) = models.User{Model: common.Model{ID: 7}, Name: "jane", Email: "jane@example.com"}
+	}).Return(nil)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(nil)
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	defer groupRoleSyncs.Delete(uint64(7))
+
+	// the first request syncs the user, the groups of the token grant everything
+	user, err := GetTokenUser("jane", "jane@example.com", []string{"lake-admins"}, 1000)
+	assert.Nil(t, err)
+	assert.True(t, user.IsAdmin)
+	mockDal.AssertNumberOfCalls(t, "Delete", 1)
+
+	// the following requests with the same token only read the user
+	user, err = GetTokenUser("jane", "jane@example.com", []string{"lake-admins"}, 1000)
+	assert.Nil(t, err)
+	assert.True(t, user.IsAdmin)
+	user, err = GetTokenUser("jane", "jane@example.com", []string{"lake-admins"}, 900)
+	assert.Nil(t, err)
+	assert.True(t, user.IsAdmin)
+	mockDal.AssertNumberOfCalls(t, "Delete", 1)
+
+	// a newer token syncs the user again, its groups no longer grant everything
+	user, err = GetTokenUser("jane", "jane@example.com", []string{"team-a"}, 2000)
+	assert.Nil(t, err)
+	assert.False(t, user.IsAdmin)
+	mockDal.AssertNumberOfCalls(t, "Delete", 2)
+	user, err = GetTokenUser("jane", "jane@example.com", []string{"team-a"}, 2000)
+	assert.Nil(t, err)
+	assert.False(t, user.IsAdmin)
+	mockDal.AssertNumberOfCalls(t, "Delete", 2)
+	mockDal.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
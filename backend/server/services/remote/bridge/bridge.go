@@ -44,6 +44,7 @@ func (b *Bridge) RemoteSubtaskEntrypointHandler(subtaskMeta models.SubtaskMeta)
 			args = append(args, arg)
 		}
 		stream := b.invoker.Stream(subtaskMeta.EntryPointName, NewChildRemoteContext(ctx), args...)
+		lastCurrent := 0
 		for recv := range stream.Receive() {
 			if recv.Err != nil {
 				return recv.Err
@@ -55,8 +56,11 @@ func (b *Bridge) RemoteSubtaskEntrypointHandler(subtaskMeta models.SubtaskMeta)
 			}
 			if progress.Total != 0 {
 				ctx.SetProgress(progress.Current, progress.Total)
+				stream.addRecords(progress.Current - lastCurrent)
+				lastCurrent = progress.Current
 			} else if progress.Increment != 0 {
 				ctx.IncProgress(progress.Increment)
+				stream.addRecords(progress.Increment)
 			}
 		}
 		return nil
@@ -31,11 +31,17 @@ import (
 
 type CmdInvoker struct {
 	resolveCmd  func(methodName string, args ...string) (string, []string)
-	cancelled   bool
 	workingPath string
+	pluginName  string
 }
 
+// NewCmdInvoker creates an invoker for the executable, metrics are reported under the name of its directory
 func NewCmdInvoker(execPath string) *CmdInvoker {
+	return NewPluginCmdInvoker(path.Base(path.Dir(execPath)), execPath)
+}
+
+// NewPluginCmdInvoker creates an invoker for the executable of the given plugin
+func NewPluginCmdInvoker(pluginName string, execPath string) *CmdInvoker {
 	// Split the path into dir and file
 	dir, file := path.Split(execPath)
 	resolveCmd := func(methodName string, args ...string) (string, []string) {
@@ -47,6 +53,7 @@ func NewCmdInvoker(execPath string) *CmdInvoker {
 	return &CmdInvoker{
 		resolveCmd:  resolveCmd,
 		workingPath: dir,
+		pluginName:  pluginName,
 	}
 }
 
@@ -63,6 +70,7 @@ func (c *CmdInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any
 	if c.workingPath != "" {
 		cmd.Dir = c.workingPath
 	}
+	recorder := startInvocation(c.pluginName, methodName)
 	response, err := utils.RunProcess(cmd, &utils.RunProcessOptions{
 		OnStdout: func(b []byte) {
 			msg := string(b)
@@ -75,9 +83,11 @@ func (c *CmdInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any
 		UseFdOut: true,
 	})
 	if err != nil {
+		recorder.finish(cmd.ProcessState, err, false)
 		return NewCallResult(nil, err)
 	}
 	err = response.GetError()
+	recorder.finish(cmd.ProcessState, err, false)
 	if err != nil {
 		return &CallResult{
 			Err: errors.Default.Wrap(err, fmt.Sprintf("get error when invoking remote function %s", methodName)),
@@ -103,6 +113,8 @@ func (c *CmdInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...a
 	if c.workingPath != "" {
 		cmd.Dir = c.workingPath
 	}
+	recorder := startInvocation(c.pluginName, methodName)
+	stream.recorder = recorder
 	processHandle, err := utils.StreamProcess(cmd, &utils.StreamProcessOptions{
 		OnStdout: func(b []byte) {
 			msg := string(b)
@@ -115,24 +127,34 @@ func (c *CmdInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...a
 		UseFdOut: true,
 	})
 	if err != nil {
+		recorder.finish(nil, err, false)
 		recvChannel <- NewStreamResult(nil, err)
 		return stream
 	}
 	go func() {
 		defer close(recvChannel)
+		// streams of the same plugin may run concurrently, so cancellation is tracked per process
+		cancelled := false
+		var streamErr errors.Error
+		// the receive channel is closed once the process exited
+		defer func() {
+			recorder.finish(cmd.ProcessState, streamErr, cancelled)
+		}()
 		for msg := range processHandle.Receive() {
-			if err = msg.GetError(); err != nil {
+			if err := msg.GetError(); err != nil {
+				streamErr = err
 				recvChannel <- NewStreamResult(nil, err)
 			}
-			if !c.cancelled {
+			if !cancelled {
 				select {
 				case <-ctx.GetContext().Done():
-					err = processHandle.Cancel()
+					err := processHandle.Cancel()
 					if err != nil {
+						streamErr = err
 						recvChannel <- NewStreamResult(nil, errors.Default.Wrap(err, "error cancelling python target"))
 						return
 					}
-					c.cancelled = true
+					cancelled = true
 					// continue until the stream gets closed by the child
 				default:
 				}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"os"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	statusSuccess   = "success"
+	statusFailure   = "failure"
+	statusCancelled = "cancelled"
+)
+
+var (
+	invocationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "devlake_remote_plugin_invocations_total",
+		Help: "Number of remote plugin method invocations",
+	}, []string{"plugin", "method", "status"})
+	invocationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "devlake_remote_plugin_invocation_duration_seconds",
+		Help:    "Duration of remote plugin method invocations",
+		Buckets: prometheus.ExponentialBuckets(0.1, 4, 10),
+	}, []string{"plugin", "method"})
+	recordsEmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "devlake_remote_plugin_records_total",
+		Help: "Number of records reported as processed by remote plugin subtasks",
+	}, []string{"plugin", "method"})
+	processMaxRss = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "devlake_remote_plugin_process_max_rss_bytes",
+		Help:    "Peak resident set size of remote plugin processes",
+		Buckets: prometheus.ExponentialBuckets(32<<20, 2, 8),
+	}, []string{"plugin", "method"})
+	runningProcesses = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "devlake_remote_plugin_running_processes",
+		Help: "Number of remote plugin processes currently running",
+	}, []string{"plugin"})
+)
+
+// invocationRecorder records the metrics of a single remote method invocation
+type invocationRecorder struct {
+	plugin  string
+	method  string
+	beganAt time.Time
+}
+
+func startInvocation(plugin string, method string) *invocationRecorder {
+	runningProcesses.WithLabelValues(plugin).Inc()
+	return &invocationRecorder{
+		plugin:  plugin,
+		method:  method,
+		beganAt: time.Now(),
+	}
+}
+
+// finish must be called once the process exited, state may be nil if it never started
+func (r *invocationRecorder) finish(state *os.ProcessState, err errors.Error, cancelled bool) {
+	runningProcesses.WithLabelValues(r.plugin).Dec()
+	status := statusSuccess
+	if cancelled {
+		status = statusCancelled
+	} else if err != nil {
+		status = statusFailure
+	}
+	invocationsTotal.WithLabelValues(r.plugin, r.method, status).Inc()
+	invocationDuration.WithLabelValues(r.plugin, r.method).Observe(time.Since(r.beganAt).Seconds())
+	if rss := maxRssBytes(state); rss > 0 {
+		processMaxRss.WithLabelValues(r.plugin, r.method).Observe(float64(rss))
+	}
+}
+
+func (r *invocationRecorder) addRecords(count int) {
+	if count > 0 {
+		recordsEmitted.WithLabelValues(r.plugin, r.method).Add(float64(count))
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvocationRecorder(t *testing.T) {
+	recorder := startInvocation("metrics-test", "collect")
+	assert.Equal(t, float64(1), testutil.ToFloat64(runningProcesses.WithLabelValues("metrics-test")))
+	recorder.addRecords(3)
+	recorder.addRecords(0)
+	recorder.finish(nil, errors.Default.New("failed"), false)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(runningProcesses.WithLabelValues("metrics-test")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(recordsEmitted.WithLabelValues("metrics-test", "collect")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(invocationsTotal.WithLabelValues("metrics-test", "collect", statusFailure)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(invocationsTotal.WithLabelValues("metrics-test", "collect", statusSuccess)))
+}
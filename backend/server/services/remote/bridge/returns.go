@@ -34,6 +34,8 @@ type (
 		outbound chan<- any
 		// receive
 		inbound <-chan *StreamResult
+		// metrics of the underlying invocation, may be nil
+		recorder *invocationRecorder
 	}
 )
 
@@ -65,3 +67,10 @@ func NewStreamResult(results []byte, err errors.Error) *StreamResult {
 func (m *MethodStream) Receive() <-chan *StreamResult {
 	return m.inbound
 }
+
+// addRecords reports records processed by the remote method
+func (m *MethodStream) addRecords(count int) {
+	if m.recorder != nil {
+		m.recorder.addRecords(count)
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"os"
+	"syscall"
+)
+
+// maxRssBytes returns the peak memory usage of the exited process, ru_maxrss is expressed in kilobytes on linux
+func maxRssBytes(state *os.ProcessState) int64 {
+	if state == nil {
+		return 0
+	}
+	if usage, ok := state.SysUsage().(*syscall.Rusage); ok && usage != nil {
+		return usage.Maxrss * 1024
+	}
+	return 0
+}
@@ -19,6 +19,9 @@ package remote
 
 import (
 	"fmt"
+	"os"
+	"sort"
+
 	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -55,3 +58,21 @@ func NewRemotePlugin(info *models.PluginInfo) (models.RemotePlugin, errors.Error
 	remotePlugins[info.Name] = plugin
 	return plugin, nil
 }
+
+// CheckPlugins tells, by the sorted names of the registered remote plugins, whether their executables can still be
+// invoked, nil when they can
+func CheckPlugins() ([]string, map[string]errors.Error) {
+	names := make([]string, 0, len(remotePlugins))
+	failures := make(map[string]errors.Error)
+	for name, plugin := range remotePlugins {
+		names = append(names, name)
+		info, err := os.Stat(plugin.PluginPath())
+		if err != nil {
+			failures[name] = errors.Convert(err)
+		} else if info.IsDir() || info.Mode()&0111 == 0 {
+			failures[name] = errors.Default.New(fmt.Sprintf("%s is not executable", plugin.PluginPath()))
+		}
+	}
+	sort.Strings(names)
+	return names, failures
+}
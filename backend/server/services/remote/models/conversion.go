@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"gorm.io/datatypes"
+	"gorm.io/gorm/schema"
 )
 
 func LoadTableModel(tableName string, schema map[string]any, encrypt bool, parentModel any) (*models.DynamicTabler, errors.Error) {
@@ -95,9 +96,38 @@ func ToDatabaseMap(tableName string, ifc any, createdAt *time.Time, updatedAt *t
 		m["updatedAt"] = updatedAt
 	}
 	m = dalgorm.ToDatabaseMap(tableName, m)
+	// decoding into a map turns every number into a float64, put the decimals back as they were
+	strategy := schema.NamingStrategy{}
+	for name, value := range decimalValues(ifc) {
+		m[strategy.ColumnName(tableName, name)] = value
+	}
 	return m, nil
 }
 
+// decimalValues returns the decimal fields of the struct by their json names
+func decimalValues(ifc any) map[string]string {
+	values := map[string]string{}
+	v := reflect.Indirect(reflect.ValueOf(ifc))
+	if v.Kind() != reflect.Struct {
+		return values
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Type != decimalType {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		// empty numbers are encoded as 0 by MapTo already
+		if value := v.Field(i).String(); value != "" {
+			values[name] = value
+		}
+	}
+	return values
+}
+
 func isBaseTypeField(fieldName string, baseType reflect.Type) bool {
 	fieldName = canonicalFieldName(fieldName)
 	for i := 0; i < baseType.NumField(); i++ {
@@ -119,41 +149,83 @@ func canonicalFieldName(fieldName string) string {
 }
 
 func generateStructField(name string, encrypt bool, schema map[string]any) (*reflect.StructField, errors.Error) {
-	goType, err := getGoType(schema)
+	goType, columnType, err := getGoType(schema)
 	if err != nil {
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("couldn't resolve type for field: \"%s\"", name))
 	}
+	var gormTags []string
+	if encrypt {
+		gormTags = append(gormTags, "serializer:encdec") //just encrypt everything for GORM operations - makes things easy
+	}
+	if columnType != "" {
+		gormTags = append(gormTags, "type:"+columnType)
+	}
+	tag := fmt.Sprintf("json:\"%s\"", name)
+	if len(gormTags) > 0 {
+		tag += fmt.Sprintf(" gorm:\"%s\"", strings.Join(gormTags, ";"))
+	}
 	sf := &reflect.StructField{
 		Name: strings.Title(name), //nolint:staticcheck
 		Type: goType,
-		Tag:  reflect.StructTag(fmt.Sprintf("json:\"%s\"", name)),
-	}
-	if encrypt {
-		sf.Tag = reflect.StructTag(fmt.Sprintf("json:\"%s\" "+
-			"gorm:\"serializer:encdec\"", //just encrypt everything for GORM operations - makes things easy
-			name))
+		Tag:  reflect.StructTag(tag),
 	}
 	return sf, nil
 }
 
-func getGoType(schema map[string]any) (reflect.Type, errors.Error) {
+const (
+	// maxVarcharLength is the length of the varchar columns created by gorm for strings, longer strings are stored as text
+	maxVarcharLength = 255
+	// decimalColumnType is valid for both MySQL and PostgreSQL
+	decimalColumnType = "decimal(65,10)"
+)
+
+var decimalType = reflect.TypeOf(json.Number(""))
+
+// getGoType returns the go type of the field described by the JSON schema, along with the column type to be used
+// when gorm's default isn't suitable. JSON types (object and array) are left to gorm datatypes which resolve to
+// json on MySQL and jsonb on PostgreSQL.
+func getGoType(schema map[string]any) (reflect.Type, string, errors.Error) {
 	var goType reflect.Type
+	columnType := ""
 	jsonType, ok := schema["type"].(string)
 	if !ok {
-		return nil, errors.BadInput.New("\"type\" property must be a string")
+		return nil, "", errors.BadInput.New("\"type\" property must be a string")
 	}
+	format, _ := schema["format"].(string)
 	switch jsonType {
-	//TODO: support more types
 	case "integer":
 		goType = reflect.TypeOf(uint64(0))
+	case "number":
+		goType = reflect.TypeOf(float64(0))
+		if format == "decimal" {
+			// json.Number keeps the digits as they were sent instead of rounding them to a float
+			goType = decimalType
+			columnType = decimalColumnType
+		}
 	case "boolean":
 		goType = reflect.TypeOf(false)
 	case "string":
 		goType = reflect.TypeOf("")
+		if isLargeText(schema) {
+			columnType = "text"
+		}
 	case "object":
 		goType = reflect.TypeOf(datatypes.JSONMap{})
+	case "array":
+		goType = reflect.TypeOf(datatypes.JSON{})
 	default:
-		return nil, errors.BadInput.New(fmt.Sprintf("Unsupported type %s", jsonType))
+		return nil, "", errors.BadInput.New(fmt.Sprintf("Unsupported type %s", jsonType))
+	}
+	return goType, columnType, nil
+}
+
+func isLargeText(schema map[string]any) bool {
+	if largeText, ok := schema["large_text"].(bool); ok && largeText {
+		return true
+	}
+	// numbers are decoded as float64 from JSON
+	if maxLength, ok := schema["maxLength"].(float64); ok && maxLength > maxVarcharLength {
+		return true
 	}
-	return goType, nil
+	return false
 }
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestGenerateStructTypeColumnTypes(t *testing.T) {
+	schema := map[string]any{
+		"properties": map[string]any{
+			"title":       map[string]any{"type": "string"},
+			"description": map[string]any{"type": "string", "large_text": true},
+			"summary":     map[string]any{"type": "string", "maxLength": float64(1024)},
+			"amount":      map[string]any{"type": "number", "format": "decimal"},
+			"ratio":       map[string]any{"type": "number"},
+			"labels":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"properties":  map[string]any{"type": "object"},
+		},
+	}
+	structType, err := GenerateStructType(schema, false, reflect.TypeOf(TransformationModel{}))
+	assert.Nil(t, err)
+
+	assertField := func(name string, goType reflect.Type, gormTag string) {
+		field, ok := structType.FieldByName(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, goType, field.Type, name)
+		assert.Equal(t, gormTag, field.Tag.Get("gorm"), name)
+	}
+	assertField("Title", reflect.TypeOf(""), "")
+	assertField("Description", reflect.TypeOf(""), "type:text")
+	assertField("Summary", reflect.TypeOf(""), "type:text")
+	assertField("Amount", reflect.TypeOf(json.Number("")), "type:decimal(65,10)")
+	assertField("Ratio", reflect.TypeOf(float64(0)), "")
+	assertField("Labels", reflect.TypeOf(datatypes.JSON{}), "")
+	assertField("Properties", reflect.TypeOf(datatypes.JSONMap{}), "")
+}
+
+func TestGenerateStructTypeEncryptedLargeText(t *testing.T) {
+	schema := map[string]any{
+		"properties": map[string]any{
+			"token": map[string]any{"type": "string", "large_text": true},
+		},
+	}
+	structType, err := GenerateStructType(schema, true, reflect.TypeOf(TransformationModel{}))
+	assert.Nil(t, err)
+	field, _ := structType.FieldByName("Token")
+	assert.Equal(t, "serializer:encdec;type:text", field.Tag.Get("gorm"))
+}
+
+func TestToDatabaseMapKeepsDecimalDigits(t *testing.T) {
+	schema := map[string]any{
+		"properties": map[string]any{
+			"amount": map[string]any{"type": "number", "format": "decimal"},
+			"ratio":  map[string]any{"type": "number"},
+		},
+	}
+	structType, err := GenerateStructType(schema, false, reflect.TypeOf(TransformationModel{}))
+	assert.Nil(t, err)
+	scope := reflect.New(structType).Interface()
+	assert.Nil(t, MapTo(map[string]any{"amount": json.Number("12345678901234567.0123456789"), "ratio": 0.5}, scope))
+
+	m, err := ToDatabaseMap("_tool_test_scopes", scope, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901234567.0123456789", m["amount"])
+	assert.Equal(t, 0.5, m["ratio"])
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/featureflag"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 )
@@ -39,6 +41,10 @@ func newScopeByTypeName(typeName string) (plugin.Scope, errors.Error) {
 		return &devops.CicdScope{}, nil
 	case "Board":
 		return &ticket.Board{}, nil
+	case "SecurityScope":
+		return &security.SecurityScope{}, nil
+	case "FeatureFlagScope":
+		return &featureflag.FeatureFlagScope{}, nil
 	default:
 		return nil, errors.BadInput.New(fmt.Sprintf("Unknown scope type %s", typeName))
 	}
@@ -84,6 +84,7 @@ type SubtaskMeta struct {
 	Name             string   `json:"name" validate:"required"`
 	EntryPointName   string   `json:"entry_point_name" validate:"required"`
 	Arguments        []string `json:"arguments"`
+	Group            string   `json:"group"` // root stream of the subtask, subtasks of a group run in order
 	Required         bool     `json:"required"`
 	EnabledByDefault bool     `json:"enabled_by_default"`
 	Description      string   `json:"description" validate:"required"`
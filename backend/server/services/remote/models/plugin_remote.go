@@ -30,4 +30,6 @@ type RemotePlugin interface {
 	plugin.PluginOpenApiSpec
 	plugin.PluginModel
 	RunMigrations(forceMigrate bool) errors.Error
+	// PluginPath is the executable the plugin is invoked through
+	PluginPath() string
 }
@@ -43,7 +43,7 @@ func Init(br context.BasicRes) {
 }
 
 func NewRemotePlugin(info *models.PluginInfo) (models.RemotePlugin, errors.Error) {
-	invoker := bridge.NewCmdInvoker(info.PluginPath)
+	invoker := bridge.NewPluginCmdInvoker(info.Name, info.PluginPath)
 	plugin, err := newPlugin(info, invoker)
 
 	if err != nil {
@@ -35,6 +35,7 @@ type (
 	remotePluginImpl struct {
 		name                     string
 		subtaskMetas             []plugin.SubTaskMeta
+		subtaskGroups            map[string]string
 		pluginPath               string
 		description              string
 		invoker                  bridge.Invoker
@@ -85,6 +86,7 @@ func newPlugin(info *models.PluginInfo, invoker bridge.Invoker) (*remotePluginIm
 		transformationRuleTabler: txRuleTabler,
 		resources:                GetDefaultAPI(invoker, connectionTabler, txRuleTabler, scopeTabler, connectionHelper),
 		openApiSpec:              *openApiSpec,
+		subtaskGroups:            make(map[string]string),
 	}
 	remoteBridge := bridge.NewBridge(invoker)
 	for _, subtask := range info.SubtaskMetas {
@@ -96,6 +98,7 @@ func newPlugin(info *models.PluginInfo, invoker bridge.Invoker) (*remotePluginIm
 			Description:      subtask.Description,
 			DomainTypes:      subtask.DomainTypes,
 		})
+		p.subtaskGroups[subtask.Name] = subtaskGroup(&subtask)
 	}
 	for _, tableName := range info.Tables {
 		p.tables = append(p.tables, coreModels.NewDynamicTabler(tableName, nil))
@@ -103,10 +106,39 @@ func newPlugin(info *models.PluginInfo, invoker bridge.Invoker) (*remotePluginIm
 	return &p, nil
 }
 
+// subtaskGroup returns the group reported by the plugin, substreams are reported in the group of their parent stream
+// because they read its tool table. Older plugins don't report groups, the first argument of their subtasks is the
+// name of the stream they belong to.
+func subtaskGroup(subtask *models.SubtaskMeta) string {
+	if subtask.Group != "" {
+		return subtask.Group
+	}
+	if len(subtask.Arguments) > 0 {
+		return subtask.Arguments[0]
+	}
+	return subtask.Name
+}
+
 func (p *remotePluginImpl) SubTaskMetas() []plugin.SubTaskMeta {
 	return p.subtaskMetas
 }
 
+func (p *remotePluginImpl) SubTaskGroup(subtaskMeta *plugin.SubTaskMeta) string {
+	return p.subtaskGroups[subtaskMeta.Name]
+}
+
+// SubTaskParallelism returns the number of streams to run concurrently, each in its own plugin process.
+// The `parallelism` task option takes precedence over the REMOTE_PLUGIN_PARALLELISM setting.
+func (p *remotePluginImpl) SubTaskParallelism(taskCtx plugin.TaskContext, options map[string]interface{}) int {
+	if parallelism, ok := options["parallelism"].(float64); ok && parallelism > 0 {
+		return int(parallelism)
+	}
+	if parallelism := taskCtx.GetConfigReader().GetInt("REMOTE_PLUGIN_PARALLELISM"); parallelism > 0 {
+		return parallelism
+	}
+	return 1
+}
+
 func (p *remotePluginImpl) GetTablesInfo() []dal.Tabler {
 	return p.tables
 }
@@ -178,6 +210,10 @@ func (p *remotePluginImpl) Description() string {
 	return p.description
 }
 
+func (p *remotePluginImpl) PluginPath() string {
+	return p.pluginPath
+}
+
 func (p *remotePluginImpl) RootPkgPath() string {
 	// RootPkgPath is used by DomainIdGenerator to find the name of the plugin that defines a given type.
 	// While remote plugins do not use the DomainIdGenerator, we still need to implement this function.
@@ -216,3 +252,4 @@ func (p *remotePluginImpl) OpenApiSpec() string {
 }
 
 var _ models.RemotePlugin = (*remotePluginImpl)(nil)
+var _ plugin.ConcurrentPluginTask = (*remotePluginImpl)(nil)
@@ -70,7 +70,7 @@ func (pa *pluginAPI) UpdateScope(input *plugin.ApiResourceInput) (*plugin.ApiRes
 }
 
 func (pa *pluginAPI) ListScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	scopes, err := scopeHelper.GetScopes(input)
+	scopes, count, err := scopeHelper.GetScopes(input)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +78,7 @@ func (pa *pluginAPI) ListScopes(input *plugin.ApiResourceInput) (*plugin.ApiReso
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: response, Status: http.StatusOK}, nil
+	return &plugin.ApiResourceOutput{Body: api.NewPageResponse("scopes", response, count, input.Query), Status: http.StatusOK}, nil
 }
 
 func (pa *pluginAPI) GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
@@ -84,19 +84,30 @@ func (s *ScopeDatabaseHelperImpl) GetScope(connectionId uint64, scopeId string)
 	return scope.Unwrap(), nil
 }
 
-func (s *ScopeDatabaseHelperImpl) ListScopes(input *plugin.ApiResourceInput, connectionId uint64) ([]*models.RemoteScope, errors.Error) {
-	limit, offset := api.GetLimitOffset(input.Query, "pageSize", "page")
+func (s *ScopeDatabaseHelperImpl) ListScopes(input *plugin.ApiResourceInput, connectionId uint64) ([]*models.RemoteScope, int64, errors.Error) {
+	listQuery, err := api.ParseListQuery(input.Query, api.ListFields(s.pa.scopeType.NewValue()))
+	if err != nil {
+		return nil, 0, err
+	}
+	clauses := append([]dal.Clause{dal.Where("connection_id = ?", connectionId)}, listQuery.Clauses()...)
+	count, err := s.db.Count(append(clauses, dal.From(s.pa.scopeType.TableName()))...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if orderby := listQuery.Orderby(""); orderby != "" {
+		clauses = append(clauses, dal.Orderby(orderby))
+	}
 	scopes := s.pa.scopeType.NewSlice()
-	err := api.CallDB(s.db.All, scopes, dal.Where("connection_id = ?", connectionId), dal.Limit(limit), dal.Offset(offset))
+	err = api.CallDB(s.db.All, scopes, append(clauses, dal.Limit(listQuery.PageSize), dal.Offset(listQuery.GetSkip()))...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	var result []*models.RemoteScope
 	for _, scope := range scopes.UnwrapSlice() {
 		scope := scope.(models.RemoteScope)
 		result = append(result, &scope)
 	}
-	return result, nil
+	return result, count, nil
 }
 
 func (s *ScopeDatabaseHelperImpl) DeleteScope(connectionId uint64, scopeId string) errors.Error {
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+)
+
+// GetRowLineages returns the runs which produced or last updated the rows of a domain layer table, the rows are
+// located by the values of their columns, e.g. {"id": "github:GithubIssue:1:1000"}
+func GetRowLineages(table string, columnValues map[string]string) ([]*models.RowLineage, errors.Error) {
+	clauses, err := domainRowClauses(table, columnValues)
+	if err != nil {
+		return nil, err
+	}
+	clauses = append(clauses, dal.Select("DISTINCT _raw_data_table, _raw_data_params"))
+	var origins []common.RawDataOrigin
+	err = db.All(&origins, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	lineages := make([]*models.RowLineage, 0)
+	for _, origin := range origins {
+		lineage := &models.RowLineage{}
+		err = db.First(lineage, dal.Where(
+			"target_table = ? AND raw_data_table = ? AND raw_data_params = ?",
+			table, origin.RawDataTable, origin.RawDataParams,
+		))
+		// the rows saved before the lineage was recorded, or outside of a pipeline, can't be traced
+		if db.IsErrorNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		lineages = append(lineages, lineage)
+	}
+	return lineages, nil
+}
+
+// domainRowClauses locates the rows of a domain layer table by the values of their columns
+func domainRowClauses(table string, columnValues map[string]string) ([]dal.Clause, errors.Error) {
+	var tabler dal.Tabler
+	for _, domainTable := range domaininfo.GetDomainTablesInfo() {
+		if domainTable.TableName() == table {
+			tabler = domainTable
+		}
+	}
+	if tabler == nil {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s is not a domain layer table", table))
+	}
+	if len(columnValues) == 0 {
+		return nil, errors.BadInput.New("at least one column is required to locate the rows")
+	}
+	columnNames, err := dal.GetColumnNames(db, tabler, nil)
+	if err != nil {
+		return nil, err
+	}
+	knownColumns := make(map[string]bool)
+	for _, columnName := range columnNames {
+		knownColumns[columnName] = true
+	}
+	// sort the columns so the query is stable
+	var columns []string
+	for column := range columnValues {
+		if !knownColumns[column] {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s has no column %s", table, column))
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	clauses := []dal.Clause{
+		dal.From(tabler),
+	}
+	for _, column := range columns {
+		clauses = append(clauses, dal.Where(fmt.Sprintf("%s = ?", column), columnValues[column]))
+	}
+	return clauses, nil
+}
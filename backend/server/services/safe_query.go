@@ -0,0 +1,436 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	"github.com/apache/incubator-devlake/helpers/exporthelper"
+)
+
+const (
+	defaultSafeQueryMaxRows = 1000
+	defaultSafeQueryTimeout = 30 * time.Second
+)
+
+var safeQueryAliasPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// SafeQueryTable describes a domain table the safe queries may read
+type SafeQueryTable struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+}
+
+// SafeQueryResult is the rows read by a safe query, Truncated tells whether there were more than the limit
+type SafeQueryResult struct {
+	Columns   []string                 `json:"columns"`
+	Rows      []map[string]interface{} `json:"rows"`
+	Truncated bool                     `json:"truncated"`
+}
+
+// SavedQueryQuery used to query saved queries as the api input
+type SavedQueryQuery struct {
+	Pagination
+}
+
+// GetSafeQueryTables returns the domain tables along with their columns
+func GetSafeQueryTables() ([]*SafeQueryTable, errors.Error) {
+	tables := make([]*SafeQueryTable, 0)
+	for _, tabler := range domaininfo.GetDomainTablesInfo() {
+		columns, err := dal.GetColumnNames(db, tabler, nil)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, &SafeQueryTable{Name: tabler.TableName(), Columns: columns})
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	return tables, nil
+}
+
+// RunSafeQuery reads the rows of the query within SAFE_QUERY_TIMEOUT, the values of the parameters override the
+// ones of the filters
+func RunSafeQuery(query *models.SafeQuery, params map[string]string) (*SafeQueryResult, errors.Error) {
+	clauses, limit, err := buildSafeQuery(query, params, true)
+	if err != nil {
+		return nil, err
+	}
+	// one more row tells whether the result was truncated
+	clauses = append(clauses, dal.Limit(limit+1))
+	tx := replicaDb.Begin()
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	timeout := cfg.GetDuration("SAFE_QUERY_TIMEOUT")
+	if timeout <= 0 {
+		timeout = defaultSafeQueryTimeout
+	}
+	switch replicaDb.Dialect() {
+	case "mysql":
+		// the session outlives the transaction, so the timeout is reset once done
+		err = tx.Exec(fmt.Sprintf("SET SESSION max_execution_time = %d", timeout.Milliseconds()))
+		defer func() {
+			_ = tx.Exec("SET SESSION max_execution_time = 0")
+		}()
+	case "postgres":
+		err = tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds()))
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error setting the timeout of the query")
+	}
+	cursor, err := tx.Cursor(clauses...)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "error running the query")
+	}
+	defer cursor.Close()
+	return readSafeQueryResult(cursor, limit)
+}
+
+func readSafeQueryResult(cursor dal.Rows, limit int) (*SafeQueryResult, errors.Error) {
+	columnTypes, e := cursor.ColumnTypes()
+	if e != nil {
+		return nil, errors.Convert(e)
+	}
+	columns := exporthelper.ColumnsOf(columnTypes)
+	result := &SafeQueryResult{Rows: make([]map[string]interface{}, 0)}
+	for _, column := range columns {
+		result.Columns = append(result.Columns, column.Name)
+	}
+	row := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range row {
+		pointers[i] = &row[i]
+	}
+	for cursor.Next() {
+		if len(result.Rows) == limit {
+			result.Truncated = true
+			break
+		}
+		e = cursor.Scan(pointers...)
+		if e != nil {
+			return nil, errors.Default.Wrap(errors.Convert(e), "error scanning the rows of the query")
+		}
+		values := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			value, err := column.Normalize(row[i])
+			if err != nil {
+				return nil, err
+			}
+			values[column.Name] = value
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	// the query may have been interrupted by the timeout
+	if rows, ok := cursor.(*sql.Rows); ok && rows.Err() != nil {
+		return nil, errors.Default.Wrap(errors.Convert(rows.Err()), "error reading the rows of the query")
+	}
+	return result, nil
+}
+
+// buildSafeQuery checks the query against the columns of its table and returns its clauses, along with its limit.
+// The parameters without any value are only rejected when checkParams is set, so the saved queries may be checked
+// ahead of their runs.
+func buildSafeQuery(query *models.SafeQuery, params map[string]string, checkParams bool) ([]dal.Clause, int, errors.Error) {
+	if err := VerifyStruct(query); err != nil {
+		return nil, 0, err
+	}
+	var tabler dal.Tabler
+	for _, domainTable := range domaininfo.GetDomainTablesInfo() {
+		if domainTable.TableName() == query.Table {
+			tabler = domainTable
+		}
+	}
+	if tabler == nil {
+		return nil, 0, errors.NotFound.New(fmt.Sprintf("%s is not a domain layer table", query.Table))
+	}
+	columnNames, err := dal.GetColumnNames(db, tabler, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	b := &safeQueryBuilder{
+		table:    query.Table,
+		columns:  make(map[string]bool),
+		selected: make(map[string]bool),
+		quote:    quoteIdentifier(db.Dialect()),
+	}
+	for _, columnName := range columnNames {
+		b.columns[columnName] = true
+	}
+	clauses := []dal.Clause{dal.From(tabler)}
+	selects, err := b.selects(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	clauses = append(clauses, dal.Select(strings.Join(selects, ", ")))
+	for _, filter := range query.Filters {
+		filterClauses, err := b.filter(filter, params, checkParams)
+		if err != nil {
+			return nil, 0, err
+		}
+		clauses = append(clauses, filterClauses...)
+	}
+	if len(query.GroupBy) > 0 {
+		clauses = append(clauses, dal.Groupby(b.quoteAll(query.GroupBy)))
+	}
+	if len(query.OrderBy) > 0 {
+		orders := make([]string, len(query.OrderBy))
+		for i, order := range query.OrderBy {
+			name := strings.TrimPrefix(order, "-")
+			if !b.selected[name] {
+				return nil, 0, errors.BadInput.New(fmt.Sprintf("cannot order by %s which is not selected", name))
+			}
+			orders[i] = b.quote(name)
+			if strings.HasPrefix(order, "-") {
+				orders[i] += " DESC"
+			}
+		}
+		clauses = append(clauses, dal.Orderby(strings.Join(orders, ", ")))
+	}
+	maxRows := cfg.GetInt("SAFE_QUERY_MAX_ROWS")
+	if maxRows <= 0 {
+		maxRows = defaultSafeQueryMaxRows
+	}
+	limit := query.Limit
+	if limit == 0 || limit > maxRows {
+		limit = maxRows
+	}
+	return clauses, limit, nil
+}
+
+type safeQueryBuilder struct {
+	table   string
+	columns map[string]bool
+	// selected are the columns and the aggregates the query may be ordered by
+	selected map[string]bool
+	quote    func(string) string
+}
+
+func (b *safeQueryBuilder) checkColumn(column string) errors.Error {
+	if !b.columns[column] {
+		return errors.BadInput.New(fmt.Sprintf("%s has no column %s", b.table, column))
+	}
+	return nil
+}
+
+func (b *safeQueryBuilder) quoteAll(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = b.quote(column)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (b *safeQueryBuilder) selects(query *models.SafeQuery) ([]string, errors.Error) {
+	grouped := len(query.GroupBy) > 0 || len(query.Aggregates) > 0
+	groupBy := make(map[string]bool)
+	for _, column := range query.GroupBy {
+		if err := b.checkColumn(column); err != nil {
+			return nil, err
+		}
+		groupBy[column] = true
+	}
+	columns := query.Columns
+	if len(columns) == 0 {
+		columns = query.GroupBy
+		if !grouped {
+			columns = make([]string, 0, len(b.columns))
+			for column := range b.columns {
+				columns = append(columns, column)
+			}
+			sort.Strings(columns)
+		}
+	}
+	selects := make([]string, 0, len(columns)+len(query.Aggregates))
+	for _, column := range columns {
+		if err := b.checkColumn(column); err != nil {
+			return nil, err
+		}
+		if grouped && !groupBy[column] {
+			return nil, errors.BadInput.New(fmt.Sprintf("the column %s has to be grouped by along with the aggregates", column))
+		}
+		b.selected[column] = true
+		selects = append(selects, b.quote(column))
+	}
+	for _, aggregate := range query.Aggregates {
+		if !safeQueryAliasPattern.MatchString(aggregate.As) || b.selected[aggregate.As] {
+			return nil, errors.BadInput.New(fmt.Sprintf("the aggregate name %s is invalid or taken", aggregate.As))
+		}
+		expr := "*"
+		if aggregate.Column != "" {
+			if err := b.checkColumn(aggregate.Column); err != nil {
+				return nil, err
+			}
+			expr = b.quote(aggregate.Column)
+		} else if aggregate.Func != "count" {
+			return nil, errors.BadInput.New(fmt.Sprintf("the aggregate %s requires a column", aggregate.As))
+		}
+		switch aggregate.Func {
+		case "countDistinct":
+			expr = "COUNT(DISTINCT " + expr + ")"
+		default:
+			expr = strings.ToUpper(aggregate.Func) + "(" + expr + ")"
+		}
+		b.selected[aggregate.As] = true
+		selects = append(selects, expr+" AS "+b.quote(aggregate.As))
+	}
+	return selects, nil
+}
+
+func (b *safeQueryBuilder) filter(filter models.QueryFilter, params map[string]string, checkParams bool) ([]dal.Clause, errors.Error) {
+	if err := b.checkColumn(filter.Column); err != nil {
+		return nil, err
+	}
+	column := b.quote(filter.Column)
+	switch filter.Op {
+	case models.QUERY_OP_NULL:
+		return []dal.Clause{dal.Where(column + " IS NULL")}, nil
+	case models.QUERY_OP_NOT_NULL:
+		return []dal.Clause{dal.Where(column + " IS NOT NULL")}, nil
+	}
+	value := filter.Value
+	if param, ok := params[filter.Param]; ok && filter.Param != "" {
+		value = param
+		if filter.Op == models.QUERY_OP_IN {
+			value = strings.Split(param, ",")
+		}
+	}
+	if value == nil {
+		if !checkParams && filter.Param != "" {
+			return nil, nil
+		}
+		if filter.Param != "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("the parameter %s is required", filter.Param))
+		}
+		return nil, errors.BadInput.New(fmt.Sprintf("the filter on %s requires a value", filter.Column))
+	}
+	switch v := value.(type) {
+	case []interface{}, []string:
+		if filter.Op != models.QUERY_OP_IN {
+			return nil, errors.BadInput.New(fmt.Sprintf("only the in filter on %s takes a list", filter.Column))
+		}
+	case map[string]interface{}:
+		return nil, errors.BadInput.New(fmt.Sprintf("the filter on %s takes a value, not %v", filter.Column, v))
+	default:
+		if filter.Op == models.QUERY_OP_IN {
+			return nil, errors.BadInput.New(fmt.Sprintf("the in filter on %s takes a list", filter.Column))
+		}
+	}
+	operators := map[string]string{
+		models.QUERY_OP_EQ:   "=",
+		models.QUERY_OP_NE:   "<>",
+		models.QUERY_OP_GT:   ">",
+		models.QUERY_OP_GTE:  ">=",
+		models.QUERY_OP_LT:   "<",
+		models.QUERY_OP_LTE:  "<=",
+		models.QUERY_OP_IN:   "IN",
+		models.QUERY_OP_LIKE: "LIKE",
+	}
+	return []dal.Clause{dal.Where(fmt.Sprintf("%s %s ?", column, operators[filter.Op]), value)}, nil
+}
+
+// quoteIdentifier returns the quoting of the dialect, the identifiers being checked beforehand
+func quoteIdentifier(dialect string) func(string) string {
+	if dialect == "postgres" {
+		return func(name string) string { return `"` + name + `"` }
+	}
+	return func(name string) string { return "`" + name + "`" }
+}
+
+// GetSavedQueries returns a paginated list of the saved queries
+func GetSavedQueries(query *SavedQueryQuery) ([]*models.SavedQuery, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.SavedQuery{}),
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of saved queries")
+	}
+	clauses = append(clauses,
+		dal.Orderby("name"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	savedQueries := make([]*models.SavedQuery, 0)
+	err = db.All(&savedQueries, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB saved queries")
+	}
+	return savedQueries, count, nil
+}
+
+// GetSavedQuery returns the saved query of the given name
+func GetSavedQuery(name string) (*models.SavedQuery, errors.Error) {
+	savedQuery := &models.SavedQuery{}
+	err := db.First(savedQuery, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("saved query not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the saved query from DB")
+	}
+	return savedQuery, nil
+}
+
+// PutSavedQuery saves the query under its name, replacing the one saved before, the query is checked first
+func PutSavedQuery(savedQuery *models.SavedQuery) errors.Error {
+	if err := VerifyStruct(savedQuery); err != nil {
+		return err
+	}
+	if _, _, err := buildSafeQuery(&savedQuery.Query, nil, false); err != nil {
+		return err
+	}
+	err := db.CreateOrUpdate(savedQuery)
+	if err != nil {
+		return errors.Default.Wrap(err, "error saving the query")
+	}
+	return nil
+}
+
+// DeleteSavedQuery removes the saved query of the given name
+func DeleteSavedQuery(name string) (*models.SavedQuery, errors.Error) {
+	savedQuery, err := GetSavedQuery(name)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Delete(savedQuery)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting DB saved query")
+	}
+	return savedQuery, nil
+}
+
+// RunSavedQuery runs the saved query with the values of its parameters, the limit overrides the one saved if set
+func RunSavedQuery(name string, params map[string]string, limit int) (*SafeQueryResult, errors.Error) {
+	savedQuery, err := GetSavedQuery(name)
+	if err != nil {
+		return nil, err
+	}
+	query := savedQuery.Query
+	if limit > 0 {
+		query.Limit = limit
+	}
+	return RunSafeQuery(&query, params)
+}
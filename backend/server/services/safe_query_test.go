@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSafeQueryBuilder() *safeQueryBuilder {
+	return &safeQueryBuilder{
+		table:    "pull_requests",
+		columns:  map[string]bool{"id": true, "status": true, "created_date": true, "base_repo_id": true},
+		selected: make(map[string]bool),
+		quote:    quoteIdentifier("mysql"),
+	}
+}
+
+func TestSafeQuerySelects(t *testing.T) {
+	selects, err := newTestSafeQueryBuilder().selects(&models.SafeQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"`base_repo_id`", "`created_date`", "`id`", "`status`"}, selects)
+
+	b := newTestSafeQueryBuilder()
+	selects, err = b.selects(&models.SafeQuery{
+		GroupBy: []string{"status"},
+		Aggregates: []models.QueryAggregate{
+			{Func: "count", As: "prs"},
+			{Func: "countDistinct", Column: "base_repo_id", As: "repos"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"`status`", "COUNT(*) AS `prs`", "COUNT(DISTINCT `base_repo_id`) AS `repos`"}, selects)
+	assert.True(t, b.selected["prs"])
+
+	_, err = newTestSafeQueryBuilder().selects(&models.SafeQuery{Columns: []string{"password"}})
+	assert.NotNil(t, err)
+	_, err = newTestSafeQueryBuilder().selects(&models.SafeQuery{
+		Columns:    []string{"id"},
+		Aggregates: []models.QueryAggregate{{Func: "count", As: "prs"}},
+	})
+	assert.NotNil(t, err)
+	_, err = newTestSafeQueryBuilder().selects(&models.SafeQuery{
+		Aggregates: []models.QueryAggregate{{Func: "count", As: "prs) FROM users --"}},
+	})
+	assert.NotNil(t, err)
+	_, err = newTestSafeQueryBuilder().selects(&models.SafeQuery{
+		Aggregates: []models.QueryAggregate{{Func: "sum", As: "total"}},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestSafeQueryFilter(t *testing.T) {
+	b := newTestSafeQueryBuilder()
+	clauses, err := b.filter(models.QueryFilter{Column: "status", Op: "eq", Value: "MERGED"}, nil, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []dal.Clause{dal.Where("`status` = ?", "MERGED")}, clauses)
+
+	clauses, err = b.filter(models.QueryFilter{Column: "status", Op: "in", Param: "statuses"}, map[string]string{"statuses": "OPEN,MERGED"}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []dal.Clause{dal.Where("`status` IN ?", []string{"OPEN", "MERGED"})}, clauses)
+
+	clauses, err = b.filter(models.QueryFilter{Column: "created_date", Op: "gte", Param: "since", Value: "2023-01-01"}, nil, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []dal.Clause{dal.Where("`created_date` >= ?", "2023-01-01")}, clauses)
+
+	clauses, err = b.filter(models.QueryFilter{Column: "base_repo_id", Op: "null"}, nil, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []dal.Clause{dal.Where("`base_repo_id` IS NULL")}, clauses)
+
+	// the parameters are only required to run the query
+	_, err = b.filter(models.QueryFilter{Column: "base_repo_id", Op: "eq", Param: "repo"}, nil, true)
+	assert.NotNil(t, err)
+	clauses, err = b.filter(models.QueryFilter{Column: "base_repo_id", Op: "eq", Param: "repo"}, nil, false)
+	assert.Nil(t, err)
+	assert.Empty(t, clauses)
+
+	_, err = b.filter(models.QueryFilter{Column: "status", Op: "in", Value: "MERGED"}, nil, true)
+	assert.NotNil(t, err)
+	_, err = b.filter(models.QueryFilter{Column: "status", Op: "eq", Value: []interface{}{"MERGED"}}, nil, true)
+	assert.NotNil(t, err)
+	_, err = b.filter(models.QueryFilter{Column: "1=1 OR status", Op: "eq", Value: "MERGED"}, nil, true)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,185 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// the names of the settings
+const (
+	SETTING_SECURITY = "security"
+)
+
+// SecuritySettings holds the CORS policy and the security headers of the api, the headers left empty are not sent
+type SecuritySettings struct {
+	// the origins allowed to call the api from a browser, `*` allows all of them
+	AllowOrigins            []string `json:"allowOrigins"`
+	ContentSecurityPolicy   string   `json:"contentSecurityPolicy"`
+	FrameOptions            string   `json:"frameOptions" validate:"omitempty,oneof=DENY SAMEORIGIN"`
+	ReferrerPolicy          string   `json:"referrerPolicy"`
+	StrictTransportSecurity string   `json:"strictTransportSecurity"`
+	ContentTypeOptions      string   `json:"contentTypeOptions" validate:"omitempty,oneof=nosniff"`
+}
+
+// the security settings in effect, loaded once and replaced when they are put
+var securitySettings atomic.Pointer[SecuritySettings]
+
+// getSetting reads a setting into v, and tells whether it was ever put
+func getSetting(name string, v interface{}) (bool, errors.Error) {
+	setting := &models.Setting{}
+	err := db.First(setting, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Default.Wrap(err, fmt.Sprintf("error reading the setting %s", name))
+	}
+	if e := json.Unmarshal(setting.Value, v); e != nil {
+		return false, errors.Default.Wrap(e, fmt.Sprintf("error decoding the setting %s", name))
+	}
+	return true, nil
+}
+
+func putSetting(name string, v interface{}) errors.Error {
+	value, e := json.Marshal(v)
+	if e != nil {
+		return errors.Default.Wrap(e, fmt.Sprintf("error encoding the setting %s", name))
+	}
+	err := db.CreateOrUpdate(&models.Setting{Name: name, Value: value})
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error saving the setting %s", name))
+	}
+	return nil
+}
+
+// defaultSecuritySettings allows the origins of CORS_ALLOW_ORIGINS, all of them when it is not set
+func defaultSecuritySettings() *SecuritySettings {
+	settings := &SecuritySettings{AllowOrigins: []string{"*"}}
+	if cfg == nil {
+		return settings
+	}
+	if origins := splitSettingList(cfg.GetString("CORS_ALLOW_ORIGINS")); len(origins) > 0 {
+		settings.AllowOrigins = origins
+	}
+	return settings
+}
+
+func splitSettingList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetSecuritySettings returns the security settings in effect, the defaults until they are put
+func GetSecuritySettings() *SecuritySettings {
+	if settings := securitySettings.Load(); settings != nil {
+		return settings
+	}
+	settings := defaultSecuritySettings()
+	if db == nil {
+		return settings
+	}
+	_, err := getSetting(SETTING_SECURITY, settings)
+	if err != nil {
+		// the defaults are used until the settings can be read
+		logger.Error(err, "failed to load the security settings")
+		return defaultSecuritySettings()
+	}
+	securitySettings.Store(settings)
+	return settings
+}
+
+// PutSecuritySettings replaces the security settings, they are in effect right away
+func PutSecuritySettings(settings *SecuritySettings) (*SecuritySettings, errors.Error) {
+	if err := validateSecuritySettings(settings); err != nil {
+		return nil, err
+	}
+	if err := putSetting(SETTING_SECURITY, settings); err != nil {
+		return nil, err
+	}
+	securitySettings.Store(settings)
+	return settings, nil
+}
+
+func validateSecuritySettings(settings *SecuritySettings) errors.Error {
+	if vld != nil {
+		if err := vld.Struct(settings); err != nil {
+			return errors.BadInput.Wrap(err, "invalid security settings")
+		}
+	}
+	if len(settings.AllowOrigins) == 0 {
+		return errors.BadInput.New("allowOrigins should not be empty, use * to allow all the origins")
+	}
+	for _, origin := range settings.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") ||
+			u.RawQuery != "" || u.User != nil {
+			return errors.BadInput.New(fmt.Sprintf("the origin %s should be like https://devlake.example.com", origin))
+		}
+	}
+	for _, header := range []string{settings.ContentSecurityPolicy, settings.ReferrerPolicy, settings.StrictTransportSecurity} {
+		if strings.ContainsAny(header, "\r\n") {
+			return errors.BadInput.New("the security headers should be on a single line")
+		}
+	}
+	return nil
+}
+
+// AllowOrigin tells whether the security settings allow the browsers to call the api from the origin
+func (settings *SecuritySettings) AllowOrigin(origin string) bool {
+	origin = strings.TrimSuffix(origin, "/")
+	for _, allowed := range settings.AllowOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Headers returns the security headers to send along with every response
+func (settings *SecuritySettings) Headers() map[string]string {
+	headers := make(map[string]string)
+	for name, value := range map[string]string{
+		"Content-Security-Policy":   settings.ContentSecurityPolicy,
+		"X-Frame-Options":           settings.FrameOptions,
+		"Referrer-Policy":           settings.ReferrerPolicy,
+		"Strict-Transport-Security": settings.StrictTransportSecurity,
+		"X-Content-Type-Options":    settings.ContentTypeOptions,
+	} {
+		if value != "" {
+			headers[name] = value
+		}
+	}
+	return headers
+}
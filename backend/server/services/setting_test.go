@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSecuritySettings(t *testing.T) {
+	assert.Nil(t, validateSecuritySettings(&SecuritySettings{AllowOrigins: []string{"*"}}))
+	assert.Nil(t, validateSecuritySettings(&SecuritySettings{
+		AllowOrigins:          []string{"https://devlake.example.com", "http://localhost:4000/"},
+		ContentSecurityPolicy: "default-src 'self'",
+	}))
+
+	for _, settings := range []*SecuritySettings{
+		{},
+		{AllowOrigins: []string{"devlake.example.com"}},
+		{AllowOrigins: []string{"https://devlake.example.com/ui"}},
+		{AllowOrigins: []string{"ftp://devlake.example.com"}},
+		{AllowOrigins: []string{"*"}, ContentSecurityPolicy: "default-src 'self'\r\nSet-Cookie: a=b"},
+	} {
+		err := validateSecuritySettings(settings)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, errors.BadInput, err.GetType())
+		}
+	}
+}
+
+func TestSecuritySettingsAllowOrigin(t *testing.T) {
+	settings := &SecuritySettings{AllowOrigins: []string{"https://devlake.example.com/"}}
+	assert.True(t, settings.AllowOrigin("https://devlake.example.com"))
+	assert.True(t, settings.AllowOrigin("https://DevLake.example.com"))
+	assert.False(t, settings.AllowOrigin("https://evil.example.com"))
+	assert.True(t, (&SecuritySettings{AllowOrigins: []string{"*"}}).AllowOrigin("https://evil.example.com"))
+
+	settings.FrameOptions = "DENY"
+	assert.Equal(t, map[string]string{"X-Frame-Options": "DENY"}, settings.Headers())
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+)
+
+// SlowQueryQuery is the query of the slow queries, sortBy is max (the default), total, avg or count
+type SlowQueryQuery struct {
+	Pagination
+	Table  string `form:"table"`
+	SortBy string `form:"sortBy"`
+}
+
+var slowQuerySorts = map[string]func(stat *dalgorm.QueryStat) float64{
+	"max":   func(stat *dalgorm.QueryStat) float64 { return stat.MaxMs },
+	"total": func(stat *dalgorm.QueryStat) float64 { return stat.TotalMs },
+	"avg":   func(stat *dalgorm.QueryStat) float64 { return stat.AvgMs },
+	"count": func(stat *dalgorm.QueryStat) float64 { return float64(stat.Count) },
+}
+
+// GetSlowQueries returns the queries issued on the domain and tool tables by this process, the slowest first, along
+// with the tables they were issued on and the code issuing them
+func GetSlowQueries(query *SlowQueryQuery) ([]*dalgorm.QueryStat, int64, errors.Error) {
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = "max"
+	}
+	valueOf, ok := slowQuerySorts[sortBy]
+	if !ok {
+		return nil, 0, errors.BadInput.New(fmt.Sprintf("invalid sortBy %s, max, total, avg or count expected", query.SortBy))
+	}
+	stats := make([]*dalgorm.QueryStat, 0)
+	for _, stat := range dalgorm.GetQueryStats() {
+		if query.Table == "" || stat.Table == query.Table {
+			stats = append(stats, stat)
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return valueOf(stats[i]) > valueOf(stats[j])
+	})
+	count := int64(len(stats))
+	skip := query.GetSkip()
+	if skip > len(stats) {
+		skip = len(stats)
+	}
+	stats = stats[skip:]
+	if len(stats) > query.GetPageSize() {
+		stats = stats[:query.GetPageSize()]
+	}
+	return stats, count, nil
+}
+
+// ResetSlowQueries forgets the durations tracked so far, e.g. once the indexes are created
+func ResetSlowQueries() {
+	dalgorm.ResetQueryStats()
+}
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+)
+
+// how often the log of a running task is checked for new lines
+var taskLogPollInterval = 500 * time.Millisecond
+
+// TaskLogLine is a line of the log of a task, Cursor is the offset right after it to resume from
+type TaskLogLine struct {
+	Text   string
+	Cursor int64
+}
+
+// GetPipelineTask returns the task of the pipeline, failing when the task belongs to another pipeline
+func GetPipelineTask(pipelineId uint64, taskId uint64) (*models.Task, errors.Error) {
+	task, err := GetTask(taskId)
+	if err != nil {
+		return nil, err
+	}
+	if task.PipelineId != pipelineId {
+		return nil, errors.NotFound.New(fmt.Sprintf("task #%d not found in pipeline #%d", taskId, pipelineId))
+	}
+	return task, nil
+}
+
+// TailTaskLog calls emit with the lines of the log of the task from the cursor on, and follows the log until the
+// task is done or the context is cancelled. idle is called whenever there were no new lines, so the caller may
+// keep its connection alive.
+func TailTaskLog(ctx context.Context, task *models.Task, cursor int64, emit func(TaskLogLine) errors.Error, idle func() errors.Error) errors.Error {
+	pipeline, err := GetPipeline(task.PipelineId)
+	if err != nil {
+		return err
+	}
+	pipelineLogsPath, err := getPipelineLogsPath(pipeline)
+	if err != nil {
+		return err
+	}
+	path := logruslog.GetTaskLoggerPath(&log.LoggerConfig{Path: pipelineLogsPath}, task)
+	done := func() (bool, errors.Error) {
+		t, err := GetTask(task.ID)
+		if err != nil {
+			return false, err
+		}
+		for _, status := range models.PendingTaskStatus {
+			if t.Status == status {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return tailLog(ctx, path, cursor, done, emit, idle)
+}
+
+func tailLog(
+	ctx context.Context,
+	path string,
+	cursor int64,
+	done func() (bool, errors.Error),
+	emit func(TaskLogLine) errors.Error,
+	idle func() errors.Error,
+) errors.Error {
+	var file *os.File
+	var reader *bufio.Reader
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+	var pending []byte
+	for {
+		// the status is read ahead of the log so the last lines are not missed
+		finished, err := done()
+		if err != nil {
+			return err
+		}
+		if file == nil {
+			f, e := os.Open(path)
+			if e != nil && !os.IsNotExist(e) {
+				return errors.Default.Wrap(e, "error opening the log "+path)
+			}
+			if e == nil {
+				file = f
+				if _, e = file.Seek(cursor, io.SeekStart); e != nil {
+					return errors.Default.Wrap(e, "error seeking the log "+path)
+				}
+				reader = bufio.NewReader(file)
+			}
+		}
+		emitted := false
+		for reader != nil {
+			chunk, e := reader.ReadBytes('\n')
+			pending = append(pending, chunk...)
+			if e == io.EOF {
+				break
+			}
+			if e != nil {
+				return errors.Default.Wrap(e, "error reading the log "+path)
+			}
+			cursor += int64(len(pending))
+			err = emit(TaskLogLine{Text: string(bytes.TrimRight(pending, "\r\n")), Cursor: cursor})
+			if err != nil {
+				return err
+			}
+			pending = pending[:0]
+			emitted = true
+		}
+		if finished {
+			// the last line may lack its line break
+			if len(pending) > 0 {
+				cursor += int64(len(pending))
+				return emit(TaskLogLine{Text: string(bytes.TrimRight(pending, "\r\n")), Cursor: cursor})
+			}
+			return nil
+		}
+		if !emitted {
+			err = idle()
+			if err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(taskLogPollInterval):
+		}
+	}
+}
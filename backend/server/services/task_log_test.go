@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTailLog(t *testing.T) {
+	taskLogPollInterval = time.Millisecond
+	path := filepath.Join(t.TempDir(), "task.log")
+	polls := 0
+	done := func() (bool, errors.Error) {
+		polls++
+		switch polls {
+		case 2:
+			// the log shows up once the task is running
+			assert.Nil(t, os.WriteFile(path, []byte("first\nsecond\nthi"), 0600))
+		case 3:
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+			assert.Nil(t, err)
+			_, err = f.WriteString("rd\nlast")
+			assert.Nil(t, err)
+			assert.Nil(t, f.Close())
+			return true, nil
+		}
+		return false, nil
+	}
+	var lines []TaskLogLine
+	emit := func(line TaskLogLine) errors.Error {
+		lines = append(lines, line)
+		return nil
+	}
+	idles := 0
+	idle := func() errors.Error {
+		idles++
+		return nil
+	}
+	assert.Nil(t, tailLog(context.Background(), path, 0, done, emit, idle))
+	assert.Equal(t, []TaskLogLine{
+		{Text: "first", Cursor: 6},
+		{Text: "second", Cursor: 13},
+		{Text: "third", Cursor: 19},
+		{Text: "last", Cursor: 23},
+	}, lines)
+	assert.Equal(t, 1, idles)
+
+	// resume from a cursor
+	lines = nil
+	assert.Nil(t, tailLog(context.Background(), path, 13, func() (bool, errors.Error) { return true, nil }, emit, idle))
+	assert.Equal(t, []TaskLogLine{{Text: "third", Cursor: 19}, {Text: "last", Cursor: 23}}, lines)
+
+	// stop following once cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	lines = nil
+	assert.Nil(t, tailLog(ctx, path, 19, func() (bool, errors.Error) { return false, nil }, emit, idle))
+	assert.Empty(t, lines)
+}
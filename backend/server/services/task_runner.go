@@ -135,9 +135,33 @@ func runTaskStandalone(parentLog log.Logger, taskId uint64) errors.Error {
 		taskId,
 	)
 	close(progress)
+	if err != nil && (err.As(errors.Unauthorized) != nil || err.As(errors.Forbidden) != nil) {
+		publishConnectionUnhealthy(taskId, err)
+	}
 	return err
 }
 
+// publishConnectionUnhealthy tells the webhooks the data source rejected the credentials of the connection of the task
+func publishConnectionUnhealthy(taskId uint64, err errors.Error) {
+	task, e := GetTask(taskId)
+	if e != nil {
+		logger.Error(e, "failed to get the task #%d rejected by its data source", taskId)
+		return
+	}
+	options, e := task.GetOptions()
+	if e != nil {
+		logger.Error(e, "failed to read the options of the task #%d", taskId)
+		return
+	}
+	PublishEvent(models.EVENT_CONNECTION_UNHEALTHY, map[string]interface{}{
+		"plugin":       task.Plugin,
+		"connectionId": options["connectionId"],
+		"taskId":       task.ID,
+		"pipelineId":   task.PipelineId,
+		"message":      err.Error(),
+	})
+}
+
 func getRunningTaskById(taskId uint64) *RunningTaskData {
 	runningTasks.mu.Lock()
 	defer runningTasks.mu.Unlock()
@@ -0,0 +1,323 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// the routes open to the requests of a workspace besides the ones of its projects and connections, the lists are
+// narrowed down to the workspace by their handlers
+var workspaceRoutes = map[string]bool{
+	"GET /projects":     true,
+	"POST /projects":    true,
+	"GET /blueprints":   true,
+	"POST /blueprints":  true,
+	"GET /pipelines":    true,
+	"GET /activity":     true,
+	"GET /plugininfo":   true,
+	"GET /plugins":      true,
+	"GET /swagger/*any": true,
+}
+
+// WorkspaceQuery used to query workspaces as the api input
+type WorkspaceQuery struct {
+	Pagination
+}
+
+// GetWorkspaces returns a paginated list of workspaces
+func GetWorkspaces(query *WorkspaceQuery) ([]*models.Workspace, int64, errors.Error) {
+	clauses := []dal.Clause{dal.From(&models.Workspace{})}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of workspaces")
+	}
+	clauses = append(clauses,
+		dal.Orderby("name"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	workspaces := make([]*models.Workspace, 0)
+	err = db.All(&workspaces, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB workspaces")
+	}
+	return workspaces, count, nil
+}
+
+// GetWorkspace returns a workspace by its name
+func GetWorkspace(name string) (*models.Workspace, errors.Error) {
+	workspace := &models.Workspace{}
+	err := db.First(workspace, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("could not find workspace [%s] in DB", name))
+		}
+		return nil, errors.Default.Wrap(err, "error getting workspace from DB")
+	}
+	return workspace, nil
+}
+
+// CreateWorkspace accepts a workspace instance and insert it to database
+func CreateWorkspace(workspace *models.Workspace) errors.Error {
+	if err := VerifyStruct(workspace); err != nil {
+		return err
+	}
+	err := db.Create(workspace)
+	if err != nil {
+		if db.IsDuplicationError(err) {
+			return errors.BadInput.New(fmt.Sprintf("A workspace with name [%s] already exists", workspace.Name))
+		}
+		return errors.Default.Wrap(err, "error creating DB workspace")
+	}
+	return nil
+}
+
+// DeleteWorkspace deletes a workspace, the projects, connections and api keys of the workspace have to be moved out
+// or deleted beforehand
+func DeleteWorkspace(name string) (*models.Workspace, errors.Error) {
+	workspace, err := GetWorkspace(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range []interface{}{&models.Project{}, &models.WorkspaceConnection{}} {
+		count, err := db.Count(dal.From(table), dal.Where("workspace = ?", name))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error counting the resources of the workspace")
+		}
+		if count > 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("the workspace [%s] still has projects or connections", name))
+		}
+	}
+	count, err := db.Count(dal.From(&models.ApiKey{}), dal.Where("workspace = ? AND revoked_at IS NULL", name))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error counting the api keys of the workspace")
+	}
+	if count > 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("the workspace [%s] still has api keys", name))
+	}
+	err = db.Delete(workspace)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error deleting DB workspace")
+	}
+	return workspace, nil
+}
+
+// MoveProjectToWorkspace moves a project to a workspace, or out of any when the workspace is empty
+func MoveProjectToWorkspace(projectName string, workspace string) errors.Error {
+	if _, err := GetProject(projectName); err != nil {
+		return err
+	}
+	if workspace != "" {
+		if _, err := GetWorkspace(workspace); err != nil {
+			return err
+		}
+	}
+	err := db.UpdateColumn(&models.Project{}, "workspace", workspace, dal.Where("name = ?", projectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error moving the project to the workspace")
+	}
+	return nil
+}
+
+// MoveConnectionToWorkspace moves a plugin connection to a workspace, or out of any when the workspace is empty
+func MoveConnectionToWorkspace(pluginName string, connectionId uint64, workspace string) errors.Error {
+	if workspace == "" {
+		err := db.Delete(&models.WorkspaceConnection{}, dal.Where("plugin = ? AND connection_id = ?", pluginName, connectionId))
+		if err != nil {
+			return errors.Default.Wrap(err, "error moving the connection out of the workspace")
+		}
+		return nil
+	}
+	if _, err := GetWorkspace(workspace); err != nil {
+		return err
+	}
+	err := db.CreateOrUpdate(&models.WorkspaceConnection{
+		Plugin:       pluginName,
+		ConnectionId: connectionId,
+		Workspace:    workspace,
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error moving the connection to the workspace")
+	}
+	return nil
+}
+
+// RecordConnectionWorkspace puts the connection created by a request of a workspace into the workspace
+func RecordConnectionWorkspace(workspace string, pluginName string, connection interface{}) errors.Error {
+	if workspace == "" {
+		return nil
+	}
+	id, ok := connectionIdOf(connection)
+	if !ok {
+		return nil
+	}
+	return MoveConnectionToWorkspace(pluginName, id, workspace)
+}
+
+// FilterWorkspaceConnections keeps the connections of the list belonging to the workspace
+func FilterWorkspaceConnections(workspace string, pluginName string, connections interface{}) (interface{}, errors.Error) {
+	if workspace == "" {
+		return connections, nil
+	}
+	list := reflect.ValueOf(connections)
+	if list.Kind() != reflect.Slice {
+		return connections, nil
+	}
+	assigned := make([]*models.WorkspaceConnection, 0)
+	err := db.All(&assigned, dal.Where("plugin = ? AND workspace = ?", pluginName, workspace))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding the connections of the workspace")
+	}
+	ids := make(map[uint64]bool, len(assigned))
+	for _, a := range assigned {
+		ids[a.ConnectionId] = true
+	}
+	visible := reflect.MakeSlice(list.Type(), 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		if id, ok := connectionIdOf(list.Index(i).Interface()); ok && ids[id] {
+			visible = reflect.Append(visible, list.Index(i))
+		}
+	}
+	return visible.Interface(), nil
+}
+
+// WorkspaceProjectNames narrows the names of the projects visible to a request down to the ones of its workspace.
+// The names are nil when every project is visible.
+func WorkspaceProjectNames(workspace string, visible []string) ([]string, errors.Error) {
+	if workspace == "" {
+		return visible, nil
+	}
+	projects := make([]*models.Project, 0)
+	clauses := []dal.Clause{dal.Where("workspace = ?", workspace)}
+	if visible != nil {
+		clauses = append(clauses, dal.Where("name IN ?", visible))
+	}
+	err := db.All(&projects, clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding the projects of the workspace")
+	}
+	names := make([]string, 0, len(projects))
+	for _, project := range projects {
+		names = append(names, project.Name)
+	}
+	return names, nil
+}
+
+// CheckWorkspaceProject checks the project belongs to the workspace of the request, if any
+func CheckWorkspaceProject(workspace string, projectName string) errors.Error {
+	if workspace == "" {
+		return nil
+	}
+	project := &models.Project{}
+	err := db.First(project, dal.Where("name = ?", projectName))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return errors.Default.Wrap(err, "error getting project from DB")
+	}
+	if err != nil || project.Workspace != workspace {
+		return errors.Forbidden.New(fmt.Sprintf("the project [%s] is out of the workspace [%s]", projectName, workspace))
+	}
+	return nil
+}
+
+// AuthorizeWorkspaceRequest checks the route, i.e. `/blueprints/:blueprintId`, along with its path params, reaches
+// the resources of the workspace of the request only. It always succeeds out of any workspace.
+func AuthorizeWorkspaceRequest(workspace string, method string, route string, params map[string]string) errors.Error {
+	if workspace == "" || workspaceRoutes[method+" "+route] {
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(route, "/projects/"):
+		return CheckWorkspaceProject(workspace, strings.TrimPrefix(params["projectName"], "/"))
+	case strings.HasPrefix(route, "/blueprints/:blueprintId"):
+		id, err := parseRbacId(params["blueprintId"])
+		if err != nil {
+			return err
+		}
+		return checkWorkspaceBlueprint(workspace, id)
+	case strings.HasPrefix(route, "/pipelines/:pipelineId"):
+		id, err := parseRbacId(params["pipelineId"])
+		if err != nil {
+			return err
+		}
+		return checkWorkspacePipeline(workspace, id)
+	case strings.HasPrefix(route, "/tasks/:taskId"):
+		id, err := parseRbacId(params["taskId"])
+		if err != nil {
+			return err
+		}
+		task, err := GetTask(id)
+		if err != nil {
+			return err
+		}
+		return checkWorkspacePipeline(workspace, task.PipelineId)
+	case strings.HasPrefix(route, "/plugins/"):
+		pluginName := strings.SplitN(strings.TrimPrefix(route, "/plugins/"), "/", 2)[0]
+		if connectionId, ok := params["connectionId"]; ok {
+			id, err := parseRbacId(connectionId)
+			if err != nil {
+				return err
+			}
+			return CheckWorkspaceConnection(workspace, pluginName, id)
+		}
+		// the connections are listed and created by the router on behalf of the workspace
+		if method == http.MethodGet || strings.HasSuffix(route, "/connections") || strings.HasSuffix(route, "/test") {
+			return nil
+		}
+	}
+	return errors.Forbidden.New(fmt.Sprintf("the requests of the workspace [%s] are not granted %s %s", workspace, method, route))
+}
+
+func checkWorkspaceBlueprint(workspace string, blueprintId uint64) errors.Error {
+	blueprint, err := GetBlueprint(blueprintId)
+	if err != nil {
+		return err
+	}
+	return CheckWorkspaceProject(workspace, blueprint.ProjectName)
+}
+
+func checkWorkspacePipeline(workspace string, pipelineId uint64) errors.Error {
+	pipeline, err := GetPipeline(pipelineId)
+	if err != nil {
+		return err
+	}
+	if pipeline.BlueprintId == 0 {
+		return errors.Forbidden.New(fmt.Sprintf("the pipelines out of any blueprint are out of the workspace [%s]", workspace))
+	}
+	return checkWorkspaceBlueprint(workspace, pipeline.BlueprintId)
+}
+
+// CheckWorkspaceConnection checks the plugin connection belongs to the workspace
+func CheckWorkspaceConnection(workspace string, pluginName string, connectionId uint64) errors.Error {
+	assigned := &models.WorkspaceConnection{}
+	err := db.First(assigned, dal.Where("plugin = ? AND connection_id = ?", pluginName, connectionId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return errors.Default.Wrap(err, "error getting the workspace of the connection from DB")
+	}
+	if err != nil || assigned.Workspace != workspace {
+		return errors.Forbidden.New(fmt.Sprintf("the %s connection %d is out of the workspace [%s]", pluginName, connectionId, workspace))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeWorkspaceRequestWithoutResource(t *testing.T) {
+	// the requests out of any workspace are left as they are
+	assert.Nil(t, AuthorizeWorkspaceRequest("", "GET", "/workspaces", nil))
+	assert.Nil(t, AuthorizeWorkspaceRequest("", "POST", "/graphql", nil))
+
+	assert.Nil(t, AuthorizeWorkspaceRequest("sales", "GET", "/projects", nil))
+	assert.Nil(t, AuthorizeWorkspaceRequest("sales", "POST", "/blueprints", nil))
+	assert.Nil(t, AuthorizeWorkspaceRequest("sales", "POST", "/plugins/github/connections", nil))
+	assert.Nil(t, AuthorizeWorkspaceRequest("sales", "POST", "/plugins/github/test", nil))
+
+	// the domain data is reached through the projects of the workspace only
+	for _, route := range []string{"/domainlayer/repos", "/graphql", "/data-exports", "/queries/tables", "/workspaces", "/api-keys"} {
+		err := AuthorizeWorkspaceRequest("sales", "GET", route, nil)
+		assert.Equal(t, errors.Forbidden, err.GetType(), route)
+	}
+	assert.NotNil(t, AuthorizeWorkspaceRequest("sales", "POST", "/pipelines", nil))
+	assert.NotNil(t, AuthorizeWorkspaceRequest("sales", "GET", "/blueprints/:blueprintId", map[string]string{"blueprintId": "x"}))
+}
+
+func TestWorkspaceProjectNamesWithoutWorkspace(t *testing.T) {
+	names, err := WorkspaceProjectNames("", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, names)
+	names, err = WorkspaceProjectNames("", []string{"a"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a"}, names)
+}
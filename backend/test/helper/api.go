@@ -54,11 +54,13 @@ func (d *DevlakeClient) CreateConnection(pluginName string, connection any) *Con
 // ListConnections FIXME
 func (d *DevlakeClient) ListConnections(pluginName string) []*Connection {
 	d.testCtx.Helper()
-	all := sendHttpRequest[[]*Connection](d.testCtx, d.timeout, debugInfo{
+	page := sendHttpRequest[struct {
+		Connections []*Connection `json:"connections"`
+	}](d.testCtx, d.timeout, debugInfo{
 		print:      true,
 		inlineJson: false,
 	}, http.MethodGet, fmt.Sprintf("%s/plugins/%s/connections", d.Endpoint, pluginName), nil, nil)
-	return all
+	return page.Connections
 }
 
 // CreateBasicBlueprintV2 FIXME
@@ -169,12 +171,14 @@ func (d *DevlakeClient) UpdateScope(pluginName string, connectionId uint64, scop
 }
 
 func (d *DevlakeClient) ListScopes(pluginName string, connectionId uint64, listBlueprints bool) []ScopeResponse {
-	scopesRaw := sendHttpRequest[[]map[string]any](d.testCtx, d.timeout, debugInfo{
+	page := sendHttpRequest[struct {
+		Scopes []map[string]any `json:"scopes"`
+	}](d.testCtx, d.timeout, debugInfo{
 		print:      true,
 		inlineJson: false,
 	}, http.MethodGet, fmt.Sprintf("%s/plugins/%s/connections/%d/scopes?blueprints=%v", d.Endpoint, pluginName, connectionId, listBlueprints), nil, nil)
 	var responses []ScopeResponse
-	for _, scopeRaw := range scopesRaw {
+	for _, scopeRaw := range page.Scopes {
 		responses = append(responses, getScopeResponse(scopeRaw))
 	}
 	return responses
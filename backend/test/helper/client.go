@@ -58,6 +58,7 @@ var (
 	initService            = new(sync.Once)
 	dbTruncationExclusions = []string{
 		migration.MigrationHistory{}.TableName(),
+		migration.MigrationState{}.TableName(),
 		models.LockingHistory{}.TableName(),
 		models.LockingStub{}.TableName(),
 	}
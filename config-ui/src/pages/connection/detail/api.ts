@@ -21,4 +21,5 @@ import { request } from '@/utils';
 export const deleteConnection = (plugin: string, id: ID) =>
   request(`/plugins/${plugin}/connections/${id}`, { method: 'delete' });
 
-export const getDataScope = (plugin: string, id: ID) => request(`/plugins/${plugin}/connections/${id}/scopes`);
+export const getDataScope = (plugin: string, id: ID) =>
+  request(`/plugins/${plugin}/connections/${id}/scopes`).then((res) => res.scopes);
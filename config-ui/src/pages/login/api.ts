@@ -16,6 +16,7 @@
  *
  */
 
+import { DEVLAKE_ENDPOINT } from '@/config';
 import { request } from '@/utils';
 
 type LoginPayload = {
@@ -32,3 +33,7 @@ type NewPasswordPayload = {
 export const login = (payload: LoginPayload) => request(`/login`, { method: 'post', data: payload });
 export const newPassword = (payload: NewPasswordPayload) =>
   request(`/login/newpassword`, { method: 'post', data: payload });
+
+export const getOptions = (): Promise<{ sso: boolean }> => request('/login/options');
+
+export const SSO_URL = `${DEVLAKE_ENDPOINT}/login/sso`;
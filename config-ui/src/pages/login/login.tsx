@@ -16,10 +16,11 @@
  *
  */
 
-import { useState } from 'react';
+import { useState, useEffect } from 'react';
 import { useHistory } from 'react-router-dom';
 import { FormGroup, InputGroup, Button, Intent } from '@blueprintjs/core';
 
+import { toast } from '@/components';
 import { operator } from '@/utils';
 
 import * as API from './api';
@@ -40,8 +41,33 @@ export const LoginPage = () => {
     (challenge === 'NEW_PASSWORD_REQUIRED' &&
       (!newPassword || !confirmNewPassword || newPassword !== confirmNewPassword));
 
+  const [sso, setSso] = useState(false);
+
   const history = useHistory();
 
+  const signedIn = (accessToken: string, refreshToken: string) => {
+    localStorage.setItem('accessToken', accessToken);
+    localStorage.setItem('refreshToken', refreshToken);
+    document.cookie = 'access_token=' + accessToken + '; path=/';
+    history.push('/');
+  };
+
+  useEffect(() => {
+    // the single sign-on hands the tokens over in the fragment
+    const fragment = new URLSearchParams(window.location.hash.slice(1));
+    window.history.replaceState(null, '', window.location.pathname);
+    if (fragment.get('error')) {
+      toast.error(fragment.get('error') as string);
+    } else if (fragment.get('accessToken')) {
+      signedIn(fragment.get('accessToken') as string, fragment.get('refreshToken') || '');
+      return;
+    }
+    API.getOptions().then(
+      (options) => setSso(options.sso),
+      () => setSso(false),
+    );
+  }, []);
+
   // () =>
   const handleSubmit = async () => {
     var request: () => Promise<any>;
@@ -67,14 +93,11 @@ export const LoginPage = () => {
         setChallenge(res.challengeName);
         setSession(res.session);
       } else {
-        localStorage.setItem('accessToken', res.authenticationResult.accessToken);
-        localStorage.setItem('refreshToken', res.authenticationResult.refreshToken);
-        document.cookie = 'access_token=' + res.authenticationResult.accessToken + '; path=/';
         setUsername('');
         setPassword('');
         setChallenge('');
         setSession('');
-        history.push('/');
+        signedIn(res.authenticationResult.accessToken, res.authenticationResult.refreshToken);
       }
     }
   };
@@ -123,6 +146,11 @@ export const LoginPage = () => {
         <Button intent={Intent.PRIMARY} onClick={handleSubmit} disabled={loginDisabled}>
           Login
         </Button>
+        {sso && (
+          <Button style={{ marginTop: 8 }} onClick={() => window.location.assign(API.SSO_URL)}>
+            Sign in with SSO
+          </Button>
+        )}
       </S.Inner>
     </S.Wrapper>
   );
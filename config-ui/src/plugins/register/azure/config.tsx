@@ -58,7 +58,7 @@ export const AzureConfig: PluginConfigType = {
       },
     ],
   },
-  entities: ['CODE', 'CODEREVIEW', 'CROSS', 'CICD'],
+  entities: ['CODE', 'TICKET', 'CODEREVIEW', 'CROSS', 'CICD'],
   transformation: {
     deploymentPattern: '(deploy|push-image)',
     productionPattern: 'prod(.*)',
@@ -31,12 +31,12 @@ interface Props {
 
 export const AzureDataScope = ({ connectionId, onChangeItems, ...props }: Props) => {
   const selectedItems = useMemo(
-    () => props.selectedItems.map((it) => ({ id: `${it.id}`, name: it.name, data: it })),
+    () => props.selectedItems.map((it) => ({ id: `${it.azuredevopsId}`, name: it.name, data: it })),
     [props.selectedItems],
   );
 
   const disabledItems = useMemo(
-    () => (props.disabledItems ?? []).map((it) => ({ id: `${it.id}`, name: it.name, data: it })),
+    () => (props.disabledItems ?? []).map((it) => ({ id: `${it.azuredevopsId}`, name: it.name, data: it })),
     [props.disabledItems],
   );
 
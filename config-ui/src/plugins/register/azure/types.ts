@@ -18,6 +18,6 @@
 
 export type AzureScopeType = {
   connectionId: ID;
-  id: ID;
+  azuredevopsId: string;
   name: string;
 };
@@ -18,7 +18,8 @@
 
 import { request } from '@/utils';
 
-export const getConnections = () => request('/plugins/webhook/connections');
+export const getConnections = () =>
+  request('/plugins/webhook/connections', { data: { pageSize: 1000 } }).then((res) => res.connections);
 
 export const getConnection = (id: ID) => request(`/plugins/webhook/connections/${id}`);
 
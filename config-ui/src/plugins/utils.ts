@@ -28,6 +28,8 @@ export const getPluginId = (plugin: string) => {
       return 'jobFullName';
     case 'bitbucket':
       return 'bitbucketId';
+    case 'azuredevops':
+      return 'azuredevopsId';
     case 'sonarqube':
       return 'projectKey';
     default:
@@ -29,7 +29,17 @@ type GetConnectionRes = {
   authMethod?: string;
 };
 
-export const getConnection = (plugin: string): Promise<GetConnectionRes[]> => request(`/plugins/${plugin}/connections`);
+type GetConnectionsRes = {
+  connections: GetConnectionRes[];
+  count: number;
+  page: number;
+  pageSize: number;
+};
+
+export const getConnection = (plugin: string): Promise<GetConnectionRes[]> =>
+  request(`/plugins/${plugin}/connections`, { data: { pageSize: 1000 } }).then(
+    (res: GetConnectionsRes) => res.connections,
+  );
 
 type TestConnectionPayload = {
   endpoint: string;